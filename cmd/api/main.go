@@ -7,12 +7,17 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/attaboy/platform/internal/app"
 	"github.com/attaboy/platform/internal/auth"
+	"github.com/attaboy/platform/internal/handler"
 	"github.com/attaboy/platform/internal/infra"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/projection"
+	"github.com/attaboy/platform/internal/provider"
 )
 
 func main() {
@@ -51,6 +56,13 @@ func run(logger *slog.Logger) error {
 		return fmt.Errorf("run migrations: %w", err)
 	}
 
+	// Redis cache, connected on first use
+	cache, err := projection.NewRedisStore(cfg.RedisURL)
+	if err != nil {
+		return fmt.Errorf("redis cache: %w", err)
+	}
+	defer cache.Close()
+
 	// Parse JWT expiry durations
 	playerExpiry, err := time.ParseDuration(cfg.JWTPlayerExpiry)
 	if err != nil {
@@ -68,6 +80,77 @@ func run(logger *slog.Logger) error {
 	// Initialize JWT manager
 	jwtMgr := auth.NewJWTManager(cfg.JWTSecret, playerExpiry, adminExpiry, affiliateExpiry)
 
+	p2pRules := policy.DefaultTransferRules()
+	p2pRules.Enabled = cfg.P2PTransfersEnabled
+	p2pRules.MaxTransfer = cfg.P2PMaxTransfer
+	p2pRules.DailyLimit = cfg.P2PDailyLimit
+	p2pRules.FeeBps = cfg.P2PFeeBps
+	p2pRules.FeeMin = cfg.P2PFeeMin
+
+	sweepsRules := policy.DefaultSweepsRules()
+	sweepsRules.Enabled = cfg.SweepstakesMode
+	sweepsRules.RedeemMin = cfg.SweepsRedeemMin
+	sweepsRules.Playthrough = cfg.SweepsPlaythrough
+	sweepsRules.CashCurrency = strings.ToUpper(cfg.SweepsCashCurrency)
+	sweepsRules.ExcludedCountries = splitList(cfg.SweepsExcludedCountries)
+	sweepsRules.DisabledVerticals = splitList(cfg.SweepsDisabledVerticals)
+
+	kycStorage := provider.S3Config{
+		Endpoint:  cfg.KYCStorageEndpoint,
+		Region:    cfg.KYCStorageRegion,
+		Bucket:    cfg.KYCStorageBucket,
+		AccessKey: cfg.KYCStorageAccessKey,
+		SecretKey: cfg.KYCStorageSecretKey,
+	}
+
+	exportStorage := provider.S3Config{
+		Endpoint:  cfg.ExportStorageEndpoint,
+		Region:    cfg.ExportStorageRegion,
+		Bucket:    cfg.ExportStorageBucket,
+		AccessKey: cfg.ExportStorageAccessKey,
+		SecretKey: cfg.ExportStorageSecretKey,
+	}
+
+	retention := policy.RetentionPeriods{
+		ProviderCallbackDays: cfg.RetentionProviderCallbackDays,
+		AnalyticsEventDays:   cfg.RetentionAnalyticsEventDays,
+		AIConversationDays:   cfg.RetentionAIConversationDays,
+	}
+
+	exposure := policy.ExposureLimits{
+		Selection:   cfg.SportsbookMaxSelectionLiability,
+		Event:       cfg.SportsbookMaxEventLiability,
+		PlayerEvent: cfg.SportsbookMaxPlayerEventLiability,
+	}
+
+	inPlay := policy.InPlaySuspension{
+		Freeze:   time.Duration(cfg.SportsbookInPlayFreezeSeconds) * time.Second,
+		SwingBps: cfg.SportsbookOddsSwingBps,
+	}
+
+	rtpBands := policy.RTPBandRules{
+		Z:          cfg.RTPBandZ,
+		Volatility: cfg.RTPBandVolatility,
+		MinRounds:  cfg.RTPBandMinRounds,
+	}
+
+	ipRisk := policy.DefaultIPRiskRules()
+	ipRisk.ProxyVerdicts, err = policy.ParseIPProxyVerdicts(cfg.IPRiskProxyVerdicts)
+	if err != nil {
+		return fmt.Errorf("parse IP risk verdicts: %w", err)
+	}
+	ipRisk.ReviewRisk = cfg.IPRiskReviewScore
+	ipRisk.BlockRisk = cfg.IPRiskBlockScore
+	ipIntel := provider.ProxyCheckConfig{BaseURL: cfg.IPIntelBaseURL, APIKey: cfg.IPIntelAPIKey}
+
+	// Request time budgets: reports scan far more rows than anything else
+	budgets := handler.DeadlineBudgets{
+		Default: time.Duration(cfg.RequestBudgetMS) * time.Millisecond,
+		Routes: map[string]time.Duration{
+			"/admin/reports": time.Duration(cfg.ReportRequestBudgetMS) * time.Millisecond,
+		},
+	}
+
 	// Build router via wire
 	r := app.NewRouter(app.RouterDeps{
 		Pool:                pool,
@@ -75,12 +158,44 @@ func run(logger *slog.Logger) error {
 		Logger:              logger,
 		StripeSecretKey:     cfg.StripeSecretKey,
 		StripeWebhookSecret: cfg.StripeWebhookSecret,
+		AdyenAPIKey:         cfg.AdyenAPIKey,
+		AdyenMerchant:       cfg.AdyenMerchantAccount,
+		AdyenHMACKey:        cfg.AdyenHMACKey,
+		AdyenCheckoutURL:    cfg.AdyenCheckoutURL,
+		AdyenPayoutURL:      cfg.AdyenPayoutURL,
 		RandomOrgAPIKey:     cfg.RandomOrgAPIKey,
 		SlotopolBaseURL:     "http://localhost:4002",
 		CORSAllowedOrigins:  cfg.CORSAllowedOrigins,
+		CORSBrandOrigins:    cfg.CORSBrandOrigins,
+		CORSAdminOrigins:    cfg.CORSAdminOrigins,
+		CORSMaxAge:          cfg.CORSMaxAge,
 		DomeBaseURL:         cfg.DomeBaseURL,
 		DomeAPIKey:          cfg.DomeAPIKey,
 		OddsAPIKey:          cfg.OddsAPIKey,
+		CaptchaProvider:     cfg.CaptchaProvider,
+		CaptchaSecretKey:    cfg.CaptchaSecretKey,
+		CaptchaBrands:       cfg.CaptchaBrands,
+		GraphQLEnabled:      cfg.GraphQLEnabled,
+		WalletCurrencies:    cfg.WalletCurrencies,
+		SOFThresholds:       cfg.SOFDepositThresholds,
+		NetLossRules:        cfg.RGNetLossRules,
+		ReceiptSigningKey:   cfg.ReceiptSigningKey,
+		P2PTransfers:        p2pRules,
+		Sweepstakes:         sweepsRules,
+		ClosedLoopPayouts:   cfg.PaymentsClosedLoop,
+		KYCStorage:          kycStorage,
+		KYCThreshold:        cfg.KYCWithdrawalThreshold,
+		ExportStorage:       exportStorage,
+		MaxExportsPerAdmin:  cfg.ExportMaxConcurrentPerAdmin,
+		Retention:           retention,
+		SportsbookExposure:  exposure,
+		InPlaySuspension:    inPlay,
+		RTPBands:            rtpBands,
+		IPIntel:             ipIntel,
+		IPRisk:              ipRisk,
+		Cache:               cache,
+		GameSessionTTL:      time.Duration(cfg.GameSessionTTLMinutes) * time.Minute,
+		RequestBudgets:      budgets,
 	})
 
 	// Start server
@@ -89,7 +204,7 @@ func run(logger *slog.Logger) error {
 		Addr:         addr,
 		Handler:      r,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		WriteTimeout: budgets.Longest() + 5*time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
@@ -120,3 +235,14 @@ func run(logger *slog.Logger) error {
 	logger.Info("server stopped gracefully")
 	return nil
 }
+
+// splitList splits a comma-separated config value, dropping blanks.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
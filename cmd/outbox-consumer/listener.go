@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/infra"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// outboxChannel is the NOTIFY channel raised by the event_outbox insert trigger.
+const outboxChannel = "event_outbox"
+
+// listenerBackoff is how long to wait before re-establishing a dropped
+// LISTEN connection; it grows while the database stays unreachable, as it
+// does through a failover. The fallback ticker keeps events flowing meanwhile.
+var listenerBackoff = infra.Backoff{Min: time.Second, Max: 30 * time.Second}
+
+// listenForOutbox holds a dedicated connection on LISTEN event_outbox and
+// signals wake for every notification. Signals coalesce: wake has capacity one
+// and a pending signal already triggers a full drain.
+func listenForOutbox(ctx context.Context, pool *pgxpool.Pool, wake chan<- struct{}, logger *slog.Logger) {
+	failures := 0
+	for {
+		subscribed, err := listenOnce(ctx, pool, wake, logger)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			failures = 0
+		}
+		failures++
+		delay := listenerBackoff.Delay(failures)
+		logger.Warn("outbox listener disconnected, falling back to polling", "error", err,
+			"failures", failures, "retry_in", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// listenOnce listens until the connection drops and reports whether it got
+// as far as subscribing.
+func listenOnce(ctx context.Context, pool *pgxpool.Pool, wake chan<- struct{}, logger *slog.Logger) (bool, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	// A connection left in LISTEN state must not return to the pool.
+	listenConn := conn.Hijack()
+	defer listenConn.Close(context.Background())
+
+	if _, err := listenConn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+		return false, err
+	}
+	logger.Info("outbox listener subscribed", "channel", outboxChannel)
+
+	// Catch up on anything inserted before LISTEN took effect.
+	wakeConsumer(wake)
+
+	for {
+		if _, err := listenConn.WaitForNotification(ctx); err != nil {
+			return true, err
+		}
+		wakeConsumer(wake)
+	}
+}
+
+func wakeConsumer(wake chan<- struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
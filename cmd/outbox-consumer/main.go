@@ -2,16 +2,26 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
 	"github.com/attaboy/platform/internal/infra"
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/projection"
 	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -41,7 +51,9 @@ func run(logger *slog.Logger) error {
 	defer pool.Close()
 	logger.Info("outbox-consumer connected to postgres")
 
-	pollInterval := 2 * time.Second
+	// With LISTEN/NOTIFY the ticker is only a fallback (dropped listener,
+	// retries coming due), so it can run much less often than before.
+	pollInterval := 10 * time.Second
 	if s := os.Getenv("OUTBOX_POLL_INTERVAL"); s != "" {
 		if d, err := time.ParseDuration(s); err == nil {
 			pollInterval = d
@@ -55,50 +67,289 @@ func run(logger *slog.Logger) error {
 		}
 	}
 
-	repo := repository.NewOutboxRepository()
-	logger.Info("outbox-consumer starting", "poll_interval", pollInterval, "batch_size", batchSize)
+	// Delivery lag alarm threshold and backlog sampling interval
+	lagAlert := 5 * time.Minute
+	if s := os.Getenv("OUTBOX_LAG_ALERT"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+			lagAlert = d
+		}
+	}
+	metricsInterval := 15 * time.Second
+	if s := os.Getenv("OUTBOX_METRICS_INTERVAL"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			metricsInterval = d
+		}
+	}
+
+	// Liveness fails once the delivery loop goes this long without
+	// finishing a poll. Failing polls back off rather than count as stuck.
+	stuckAfter := 5 * time.Minute
+	if s := os.Getenv("OUTBOX_STUCK_AFTER"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			stuckAfter = d
+		}
+	}
+	health := infra.NewWorkerHealth()
+	health.Register(outboxWorker, max(stuckAfter, 3*pollInterval))
+
+	producer := infra.NewKafkaProducer(cfg.KafkaBrokers, cfg.KafkaEnabled, logger)
+	defer producer.Close()
+
+	c := &consumer{
+		pool:      pool,
+		repo:      repository.NewOutboxRepository(),
+		consents:  repository.NewConsentRepository(),
+		publisher: producer,
+		retry:     policy.DefaultOutboxRetryPolicy(),
+		metrics:   newOutboxMetrics(lagAlert, logger),
+		health:    health,
+		backoff:   infra.DefaultBackoff(),
+		logger:    logger,
+	}
+	// Read-model projections run here, ahead of publishing, because
+	// published events are deleted from the outbox.
+	projections := os.Getenv("OUTBOX_PROJECTIONS") != "false"
+	if projections {
+		c.projector = projection.NewProjector(pool)
+	}
+	questProgress := os.Getenv("OUTBOX_QUEST_PROGRESS") != "false"
+	if questProgress {
+		c.quests = service.NewQuestProgressEngine(pool, c.repo, logger)
+	}
+	listen := os.Getenv("OUTBOX_LISTEN") != "false"
+	logger.Info("outbox-consumer starting", "poll_interval", pollInterval, "batch_size", batchSize,
+		"max_attempts", c.retry.MaxAttempts, "listen", listen, "lag_alert", lagAlert, "projections", projections,
+		"quest_progress", questProgress)
+
+	go c.metrics.sampleEvery(ctx, pool, c.repo, metricsInterval)
+
+	metricsAddr := ":9103"
+	if s, ok := os.LookupEnv("OUTBOX_METRICS_ADDR"); ok {
+		metricsAddr = s
+	}
+	if metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler(c.metrics.instruments()...))
+		mux.Handle("/healthz", handler.WorkerHealthHandler(health))
+		srv := &http.Server{Addr: metricsAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			logger.Info("outbox-consumer metrics listening", "addr", metricsAddr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("outbox-consumer metrics server", "error", err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	wake := make(chan struct{}, 1)
+	if listen {
+		go listenForOutbox(ctx, pool, wake, logger)
+	}
 
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	for {
+		tick, notify := ticker.C, (<-chan struct{})(wake)
+		var retry <-chan time.Time
+		if delay := c.drain(ctx, batchSize); delay > 0 {
+			// Backing off: ticks and notifications wait for the retry.
+			tick, notify, retry = nil, nil, time.After(delay)
+		}
 		select {
 		case <-ctx.Done():
 			logger.Info("outbox-consumer shutting down")
 			return nil
-		case <-ticker.C:
-			if err := poll(ctx, pool, repo, logger, batchSize); err != nil {
-				logger.Error("poll error", "error", err)
+		case <-retry:
+		case <-tick:
+		case <-notify:
+		}
+	}
+}
+
+// outboxWorker names the delivery loop in the liveness report.
+const outboxWorker = "outbox.delivery"
+
+// publisher sends one outbox event downstream.
+type publisher interface {
+	Publish(ctx context.Context, topic string, key, value []byte) error
+}
+
+type consumer struct {
+	pool      *pgxpool.Pool
+	repo      repository.OutboxRepository
+	consents  repository.ConsentRepository
+	publisher publisher
+	// projector updates the read models before an event is published; nil
+	// disables projections.
+	projector *projection.Projector
+	// quests advances automated quests before an event is published; nil
+	// disables quest progress.
+	quests  *service.QuestProgressEngine
+	retry   policy.OutboxRetryPolicy
+	metrics *outboxMetrics
+	// health records each poll for the liveness probe; backoff spaces out
+	// polls while the database is failing, and failures counts them.
+	health   *infra.WorkerHealth
+	backoff  infra.Backoff
+	failures int
+	logger   *slog.Logger
+}
+
+// drain polls until a batch comes back short, so a burst of inserts is
+// cleared without waiting for further notifications or ticks. When a poll
+// fails, the pool is re-validated in case the database failed over and
+// drain returns how long to back off before the next poll; otherwise it
+// returns zero and the caller waits for the next notification or tick.
+func (c *consumer) drain(ctx context.Context, batchSize int) time.Duration {
+	for ctx.Err() == nil {
+		n, err := c.poll(ctx, batchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			c.failures++
+			c.health.Failure(outboxWorker, err)
+			delay := c.backoff.Delay(c.failures)
+			c.logger.Error("poll error", "error", err, "failures", c.failures, "retry_in", delay)
+			if infra.RevalidatePool(ctx, c.pool, err) {
+				c.logger.Warn("database connections reset after poll error")
 			}
+			return delay
+		}
+		if c.failures > 0 {
+			c.logger.Info("outbox polling recovered", "failures", c.failures)
+			c.failures = 0
+		}
+		c.health.Success(outboxWorker)
+		if n < batchSize {
+			return 0
 		}
 	}
+	return 0
 }
 
-func poll(ctx context.Context, pool *pgxpool.Pool, repo repository.OutboxRepository, logger *slog.Logger, limit int) error {
-	rows, err := repo.FetchUnpublishedRows(ctx, pool, limit)
+// poll publishes one batch and returns how many rows it fetched. A row that fails is retried with exponential
+// backoff and dead-lettered once its attempts are exhausted; it never holds
+// up the rows behind it.
+func (c *consumer) poll(ctx context.Context, limit int) (int, error) {
+	rows, err := c.repo.FetchUnpublishedRows(ctx, c.pool, limit)
 	if err != nil {
-		return fmt.Errorf("fetch: %w", err)
+		return 0, fmt.Errorf("fetch: %w", err)
 	}
 	if len(rows) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	ids := make([]int64, 0, len(rows))
+	suppressed := 0
 	for _, row := range rows {
-		logger.Info("outbox event",
-			"seq_id", row.SeqID,
-			"event_id", row.EventID,
-			"aggregate_type", row.AggregateType,
-			"event_type", row.EventType,
-			"aggregate_id", row.AggregateID,
-		)
+		if c.projector != nil {
+			if err := c.projector.Project(ctx, row.OutboxDraft); err != nil {
+				c.handleFailure(ctx, row, err)
+				continue
+			}
+		}
+		if c.quests != nil {
+			if err := c.quests.Apply(ctx, row.OutboxDraft); err != nil {
+				c.handleFailure(ctx, row, err)
+				continue
+			}
+		}
+		var extra map[string]interface{}
+		if policy.IsCRMEvent(row.EventType) && row.AggregateType == domain.AggregatePlayer {
+			channels, err := c.marketingChannels(ctx, row.AggregateID)
+			if err != nil {
+				c.handleFailure(ctx, row, err)
+				continue
+			}
+			if len(channels) == 0 {
+				// No marketing consent: the event is consumed but never
+				// reaches CRM.
+				suppressed++
+				c.metrics.suppressed.Add(1)
+				ids = append(ids, row.SeqID)
+				continue
+			}
+			extra = map[string]interface{}{"consent_channels": channels}
+		}
+		if err := c.publish(ctx, row, extra); err != nil {
+			c.handleFailure(ctx, row, err)
+			continue
+		}
+		c.metrics.published.Add(1, string(row.AggregateType))
 		ids = append(ids, row.SeqID)
 	}
 
-	if err := repo.MarkPublished(ctx, pool, ids); err != nil {
-		return fmt.Errorf("mark published: %w", err)
+	if err := c.repo.MarkPublished(ctx, c.pool, ids); err != nil {
+		return 0, fmt.Errorf("mark published: %w", err)
+	}
+
+	c.logger.Info("processed outbox batch", "published", len(ids)-suppressed, "suppressed", suppressed,
+		"failed", len(rows)-len(ids))
+	return len(rows), nil
+}
+
+// marketingChannels returns the channels the player has consented to
+// marketing on.
+func (c *consumer) marketingChannels(ctx context.Context, aggregateID string) ([]string, error) {
+	playerID, err := uuid.Parse(aggregateID)
+	if err != nil {
+		return nil, fmt.Errorf("parse player id: %w", err)
+	}
+	channels, err := c.consents.GrantedChannels(ctx, c.pool, playerID, domain.ConsentMarketing)
+	if err != nil {
+		return nil, fmt.Errorf("check marketing consent: %w", err)
+	}
+	return channels, nil
+}
+
+// publish sends row downstream, with any extra fields added to the message.
+func (c *consumer) publish(ctx context.Context, row repository.OutboxRow, extra map[string]interface{}) error {
+	topic := "attaboy." + string(row.AggregateType) + "." + string(row.EventType)
+	key := row.PartitionKey
+	if key == "" {
+		key = row.AggregateID
+	}
+	fields := map[string]interface{}{
+		"event_id":       row.EventID,
+		"aggregate_type": row.AggregateType,
+		"aggregate_id":   row.AggregateID,
+		"event_type":     row.EventType,
+		"headers":        row.Headers,
+		"payload":        row.Payload,
+		"occurred_at":    row.OccurredAt,
 	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	msg, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	return c.publisher.Publish(ctx, topic, []byte(key), msg)
+}
 
-	logger.Info("processed outbox batch", "count", len(ids))
-	return nil
+func (c *consumer) handleFailure(ctx context.Context, row repository.OutboxRow, publishErr error) {
+	attempts := row.Attempts + 1
+	decision := policy.EvaluateOutboxRetry(c.retry, attempts)
+	c.metrics.failed.Add(1)
+
+	if decision.DeadLetter {
+		c.metrics.deadLetterNew.Add(1)
+		c.logger.Error("outbox event dead-lettered",
+			"seq_id", row.SeqID, "event_id", row.EventID, "event_type", row.EventType,
+			"attempts", attempts, "error", publishErr)
+		if err := c.repo.MoveToDLQ(ctx, c.pool, row.SeqID, publishErr.Error()); err != nil {
+			c.logger.Error("move to dlq failed", "seq_id", row.SeqID, "error", err)
+		}
+		return
+	}
+
+	c.logger.Warn("outbox publish failed, will retry",
+		"seq_id", row.SeqID, "event_id", row.EventID, "attempts", attempts,
+		"retry_in", decision.Delay, "error", publishErr)
+	if err := c.repo.RecordFailure(ctx, c.pool, row.SeqID, publishErr.Error(), time.Now().Add(decision.Delay)); err != nil {
+		c.logger.Error("record outbox failure failed", "seq_id", row.SeqID, "error", err)
+	}
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// outboxMetrics exports event delivery for scraping: backlog gauges sampled
+// from the database, throughput counters kept by the consumer, and a lag
+// alarm raised when the oldest unpublished event is older than lagThreshold.
+type outboxMetrics struct {
+	unpublished  *metrics.GaugeVec
+	oldestAge    *metrics.GaugeVec
+	deadLettered *metrics.GaugeVec
+	lagAlert     *metrics.GaugeVec
+
+	published     *metrics.CounterVec
+	suppressed    *metrics.CounterVec
+	failed        *metrics.CounterVec
+	deadLetterNew *metrics.CounterVec
+
+	lagThreshold time.Duration
+	alerting     bool
+	logger       *slog.Logger
+}
+
+func newOutboxMetrics(lagThreshold time.Duration, logger *slog.Logger) *outboxMetrics {
+	return &outboxMetrics{
+		unpublished: metrics.NewGaugeVec("outbox_unpublished_events",
+			"Events waiting in the outbox, including those backing off after a failure.", nil),
+		oldestAge: metrics.NewGaugeVec("outbox_oldest_unpublished_age_seconds",
+			"Age of the oldest unpublished outbox event; 0 when the outbox is empty.", nil),
+		deadLettered: metrics.NewGaugeVec("outbox_dead_letter_events",
+			"Events in the outbox dead-letter queue.", nil),
+		lagAlert: metrics.NewGaugeVec("outbox_lag_alert",
+			"Whether the oldest unpublished event is older than the lag threshold.", nil),
+		published: metrics.NewCounterVec("outbox_events_published_total",
+			"Outbox events published downstream.", []string{"aggregate_type"}),
+		suppressed: metrics.NewCounterVec("outbox_events_suppressed_total",
+			"CRM events consumed without publishing for lack of marketing consent.", nil),
+		failed: metrics.NewCounterVec("outbox_publish_failures_total",
+			"Outbox publish attempts that failed.", nil),
+		deadLetterNew: metrics.NewCounterVec("outbox_events_dead_lettered_total",
+			"Outbox events moved to the dead-letter queue.", nil),
+		lagThreshold: lagThreshold,
+		logger:       logger,
+	}
+}
+
+// instruments returns everything exported on /metrics.
+func (m *outboxMetrics) instruments() []io.WriterTo {
+	return []io.WriterTo{
+		m.unpublished, m.oldestAge, m.deadLettered, m.lagAlert,
+		m.published, m.suppressed, m.failed, m.deadLetterNew,
+	}
+}
+
+// sampleEvery refreshes the backlog gauges every interval until ctx is done.
+func (m *outboxMetrics) sampleEvery(ctx context.Context, pool *pgxpool.Pool, repo repository.OutboxRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		stats, err := repo.Stats(ctx, pool)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			m.logger.Error("sample outbox stats", "error", err)
+		} else {
+			m.observe(stats, time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// observe records a backlog sample and fires or resolves the lag alarm on
+// state change.
+func (m *outboxMetrics) observe(stats repository.OutboxStats, now time.Time) {
+	var lag time.Duration
+	if stats.OldestCreatedAt != nil {
+		lag = max(now.Sub(*stats.OldestCreatedAt), 0)
+	}
+	m.unpublished.Set(float64(stats.Unpublished))
+	m.oldestAge.Set(lag.Seconds())
+	m.deadLettered.Set(float64(stats.DeadLettered))
+
+	firing := m.lagThreshold > 0 && lag >= m.lagThreshold
+	was := m.alerting
+	m.alerting = firing
+	if firing {
+		m.lagAlert.Set(1)
+	} else {
+		m.lagAlert.Set(0)
+	}
+
+	switch {
+	case firing && !was:
+		m.logger.Error("outbox lag alert",
+			"oldest_age_seconds", lag.Seconds(), "threshold_seconds", m.lagThreshold.Seconds(),
+			"unpublished", stats.Unpublished)
+	case !firing && was:
+		m.logger.Info("outbox lag alert resolved",
+			"oldest_age_seconds", lag.Seconds(), "unpublished", stats.Unpublished)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/attaboy/platform/internal/infra"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	slog.SetDefault(logger)
+
+	if err := run(logger); err != nil {
+		logger.Error("reconciler failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(logger *slog.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := infra.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	pool, err := infra.NewPostgresPool(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect postgres: %w", err)
+	}
+	defer pool.Close()
+	logger.Info("reconciler connected to postgres")
+
+	interval := time.Hour
+	if s := os.Getenv("LEDGER_RECONCILE_INTERVAL"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			interval = d
+		}
+	}
+
+	svc := service.NewLedgerReconciliationService(pool, repository.NewOutboxRepository(), logger)
+	logger.Info("reconciler starting", "interval", interval)
+	svc.StartScheduler(ctx, interval)
+
+	retentionInterval := 24 * time.Hour
+	if s := os.Getenv("RETENTION_INTERVAL"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			retentionInterval = d
+		}
+	}
+
+	retention := service.NewRetentionService(pool, retentionPeriods(cfg), logger)
+	logger.Info("retention worker starting", "interval", retentionInterval, "dry_run", cfg.RetentionDryRun)
+	retention.StartScheduler(ctx, retentionInterval, cfg.RetentionDryRun)
+
+	bonusExpiryInterval := 15 * time.Minute
+	if s := os.Getenv("BONUS_EXPIRY_INTERVAL"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			bonusExpiryInterval = d
+		}
+	}
+
+	// Players are reminded this long before a bonus expires; 0 disables it.
+	bonusReminder := 72 * time.Hour
+	if s := os.Getenv("BONUS_EXPIRY_REMINDER"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+			bonusReminder = d
+		}
+	}
+
+	bonusRepo := repository.NewBonusRepository()
+	outboxRepo := repository.NewOutboxRepository()
+	engine := ledger.NewEngine(repository.NewPlayerRepository(), repository.NewWalletRepository(),
+		repository.NewTransactionRepository(), repository.NewLedgerEntryRepository(),
+		outboxRepo, repository.NewGameRoundRepository(), bonusRepo)
+	bonusExpiry := service.NewBonusExpiryWorker(pool, engine, bonusRepo, outboxRepo, bonusReminder, logger)
+	logger.Info("bonus expiry worker starting", "interval", bonusExpiryInterval, "remind_before", bonusReminder)
+	bonusExpiry.StartScheduler(ctx, bonusExpiryInterval)
+
+	metricsAddr := ":9102"
+	if s, ok := os.LookupEnv("RECONCILER_METRICS_ADDR"); ok {
+		metricsAddr = s
+	}
+	if metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler(bonusExpiry.Metrics()...))
+		srv := &http.Server{Addr: metricsAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			logger.Info("reconciler metrics listening", "addr", metricsAddr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("reconciler metrics server", "error", err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	<-ctx.Done()
+	logger.Info("reconciler shutting down")
+	return nil
+}
+
+// retentionPeriods reads the retention policy periods from config.
+func retentionPeriods(cfg *infra.Config) policy.RetentionPeriods {
+	return policy.RetentionPeriods{
+		ProviderCallbackDays: cfg.RetentionProviderCallbackDays,
+		AnalyticsEventDays:   cfg.RetentionAnalyticsEventDays,
+		AIConversationDays:   cfg.RetentionAIConversationDays,
+	}
+}
@@ -10,10 +10,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/handler"
 	"github.com/attaboy/platform/internal/infra"
 	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/metrics"
 	"github.com/attaboy/platform/internal/provider"
 	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/attaboy/platform/internal/walletserver"
 )
 
@@ -49,24 +53,70 @@ func run(logger *slog.Logger) error {
 	// Repositories & ledger
 	playerRepo := repository.NewPlayerRepository()
 	txRepo := repository.NewTransactionRepository()
+	ledgerEntryRepo := repository.NewLedgerEntryRepository()
 	outboxRepo := repository.NewOutboxRepository()
-	ledgerEngine := ledger.NewEngine(playerRepo, txRepo, outboxRepo)
+	walletRepo := repository.NewWalletRepository()
+	gameRoundRepo := repository.NewGameRoundRepository()
+	bonusRepo := repository.NewBonusRepository()
+	ledgerEngine := ledger.NewEngine(playerRepo, walletRepo, txRepo, ledgerEntryRepo, outboxRepo, gameRoundRepo, bonusRepo)
+
+	// Void provider rounds abandoned past the TTL, refunding their stakes
+	roundSweeper := service.NewRoundSweeper(pool, ledgerEngine, gameRoundRepo,
+		time.Duration(cfg.GameRoundTTLMinutes)*time.Minute, cfg.GameRoundSweepExempt, logger)
+	roundSweeper.StartScheduler(ctx, time.Duration(cfg.GameRoundSweepMinutes)*time.Minute)
+
+	// Provider adapters: the manufacturers registry when populated, else the
+	// WALLET_PROVIDERS list
+	manufacturerSvc := service.NewManufacturerService(pool, logger)
+	manufacturers, err := manufacturerSvc.List(ctx)
+	if err != nil {
+		return fmt.Errorf("wallet providers: %w", err)
+	}
+	var adapterConfigs []provider.AdapterConfig
+	if len(manufacturers) > 0 {
+		adapterConfigs, err = provider.ManufacturerAdapterConfigs(manufacturers, os.Getenv)
+		logger.Info("wallet providers loaded from registry", "count", len(manufacturers))
+	} else {
+		adapterConfigs, err = provider.ParseAdapterConfigs(cfg.WalletProviders, os.Getenv)
+	}
+	if err != nil {
+		return fmt.Errorf("wallet providers: %w", err)
+	}
+	adapters, err := provider.DefaultAdapterRegistry().Build(adapterConfigs, logger)
+	if err != nil {
+		return fmt.Errorf("wallet providers: %w", err)
+	}
+
+	// Callback latency SLO
+	sloCfg := metrics.DefaultSLOConfig()
+	sloCfg.Threshold = time.Duration(cfg.WalletSLOThresholdMS) * time.Millisecond
+	sloCfg.Target = cfg.WalletSLOTarget
+	latency := metrics.NewCallbackLatency(sloCfg, logger)
+
+	// Per-provider circuit breaker around ledger dispatch
+	breaker := guard.NewCircuitBreaker(cfg.WalletBreakerFailures, time.Duration(cfg.WalletBreakerResetSeconds)*time.Second)
 
-	// Provider adapters
-	bsAdapter := provider.NewBetSolutionsAdapter(
-		os.Getenv("BETSOLUTIONS_HMAC_SECRET"), logger)
-	ppAdapter := provider.NewPragmaticAdapter(
-		os.Getenv("PRAGMATIC_SECRET_KEY"), logger)
+	// Game session tokens issued at launch by the API
+	gameSessions := service.NewGameSessionService(pool, time.Duration(cfg.GameSessionTTLMinutes)*time.Minute)
+
+	// Provider enablement and allowed actions, reloaded as admins change them
+	providerRegistry := walletserver.NewProviderRegistry(manufacturerSvc, logger)
+	providerRegistry.Set(manufacturers)
+	go providerRegistry.Watch(ctx, pool, time.Duration(cfg.WalletProviderReloadSeconds)*time.Second)
 
 	// Router
-	r := walletserver.NewRouter(pool, ledgerEngine, txRepo, bsAdapter, ppAdapter, logger)
+	r := walletserver.NewRouter(pool, ledgerEngine, txRepo, gameSessions, providerRegistry, adapters, latency, breaker, logger)
+
+	// Callbacks get a short budget: providers retry or roll back a callback
+	// they do not hear back from within a few seconds.
+	budgets := handler.DeadlineBudgets{Default: time.Duration(cfg.WalletCallbackBudgetMS) * time.Millisecond}
 
 	addr := fmt.Sprintf(":%d", cfg.WalletServerPort)
 	srv := &http.Server{
 		Addr:         addr,
-		Handler:      r,
+		Handler:      handler.Deadline(budgets)(r),
 		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		WriteTimeout: budgets.Longest() + 5*time.Second,
 		IdleTimeout:  30 * time.Second,
 	}
 
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS account_recovery_audit;
+DROP TABLE IF EXISTS account_recovery_requests;
+ALTER TABLE auth_users DROP COLUMN IF EXISTS password_reset_required;
@@ -0,0 +1,35 @@
+-- 000012_account_recovery.up.sql
+-- Support-assisted account recovery with identity challenge and audit trail
+
+ALTER TABLE auth_users ADD COLUMN password_reset_required boolean NOT NULL DEFAULT false;
+
+CREATE TABLE account_recovery_requests (
+    id               uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id        uuid        NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+    email            citext      NOT NULL,
+    backup_email     citext      NOT NULL,
+    status           varchar(20) NOT NULL DEFAULT 'pending',
+    challenge_score  integer     NOT NULL DEFAULT 0,
+    challenge_result jsonb       NOT NULL DEFAULT '{}',
+    ip_address       text        NOT NULL DEFAULT '',
+    reviewed_by      uuid        REFERENCES admin_users(id) ON DELETE SET NULL,
+    reviewed_at      timestamptz,
+    decision_note    text,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    updated_at       timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_account_recovery_status ON account_recovery_requests (status, created_at);
+CREATE INDEX idx_account_recovery_player ON account_recovery_requests (player_id);
+
+CREATE TABLE account_recovery_audit (
+    id          bigserial   PRIMARY KEY,
+    request_id  uuid        NOT NULL REFERENCES account_recovery_requests(id) ON DELETE CASCADE,
+    actor_type  varchar(20) NOT NULL,
+    actor_id    uuid,
+    action      varchar(50) NOT NULL,
+    detail      jsonb       NOT NULL DEFAULT '{}',
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_account_recovery_audit_request ON account_recovery_audit (request_id, created_at);
@@ -0,0 +1,8 @@
+ALTER TABLE auth_users DROP COLUMN IF EXISTS tokens_revoked_at;
+DROP INDEX IF EXISTS idx_login_attempts_player;
+ALTER TABLE login_attempts
+    DROP COLUMN IF EXISTS reported_at,
+    DROP COLUMN IF EXISTS device,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS player_id;
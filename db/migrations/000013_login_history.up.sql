@@ -0,0 +1,13 @@
+-- 000013_login_history.up.sql
+-- Login history (country/device) and "not me" reporting with token revocation
+
+ALTER TABLE login_attempts
+    ADD COLUMN player_id   uuid REFERENCES v2_players(id) ON DELETE CASCADE,
+    ADD COLUMN country     varchar(2) NOT NULL DEFAULT '',
+    ADD COLUMN user_agent  text NOT NULL DEFAULT '',
+    ADD COLUMN device      varchar(20) NOT NULL DEFAULT 'unknown',
+    ADD COLUMN reported_at timestamptz;
+
+CREATE INDEX idx_login_attempts_player ON login_attempts (player_id, created_at DESC) WHERE player_id IS NOT NULL;
+
+ALTER TABLE auth_users ADD COLUMN tokens_revoked_at timestamptz;
@@ -0,0 +1,2 @@
+ALTER TABLE player_profiles DROP CONSTRAINT IF EXISTS player_profiles_account_status_check;
+DROP TABLE IF EXISTS player_status_history;
@@ -0,0 +1,25 @@
+-- 000014_player_status_history.up.sql
+-- Account status state machine: append-only transition log projected into player_profiles.account_status
+
+CREATE TABLE IF NOT EXISTS player_status_history (
+  id           bigserial    PRIMARY KEY,
+  player_id    uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  from_status  varchar(20)  NOT NULL,
+  to_status    varchar(20)  NOT NULL,
+  reason       text         NOT NULL DEFAULT '',
+  actor_type   varchar(20)  NOT NULL DEFAULT 'system',
+  actor_id     uuid,
+  state        varchar(20)  NOT NULL DEFAULT 'applied',
+  effective_at timestamptz  NOT NULL DEFAULT now(),
+  applied_at   timestamptz,
+  created_at   timestamptz  NOT NULL DEFAULT now(),
+  CONSTRAINT player_status_history_state_check CHECK (state IN ('applied', 'scheduled', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_status_history_player ON player_status_history (player_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_player_status_history_due ON player_status_history (effective_at) WHERE state = 'scheduled';
+
+-- Legacy rows may hold ad-hoc values; enforce the state machine for new writes only.
+ALTER TABLE player_profiles
+  ADD CONSTRAINT player_profiles_account_status_check
+  CHECK (account_status IN ('active', 'suspended', 'self_excluded', 'closed')) NOT VALID;
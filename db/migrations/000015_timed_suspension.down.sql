@@ -0,0 +1 @@
+ALTER TABLE player_profiles DROP COLUMN IF EXISTS suspended_until;
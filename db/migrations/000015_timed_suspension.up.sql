@@ -0,0 +1,4 @@
+-- 000015_timed_suspension.up.sql
+-- Windowed suspensions: reinstatement time shown to the player and honoured by wallet/bet paths
+
+ALTER TABLE player_profiles ADD COLUMN suspended_until timestamptz;
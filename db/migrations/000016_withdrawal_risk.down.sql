@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS withdrawal_risk_assessments;
+DROP TABLE IF EXISTS aml_alerts;
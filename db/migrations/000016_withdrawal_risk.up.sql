@@ -0,0 +1,26 @@
+-- 000016_withdrawal_risk.up.sql
+-- Automated withdrawal risk checks and the AML alerts they read
+
+CREATE TABLE IF NOT EXISTS aml_alerts (
+  id          uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id   uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  alert_type  varchar(50)  NOT NULL,
+  severity    varchar(10)  NOT NULL DEFAULT 'medium',
+  status      varchar(20)  NOT NULL DEFAULT 'open',
+  details     jsonb        NOT NULL DEFAULT '{}',
+  created_at  timestamptz  NOT NULL DEFAULT now(),
+  resolved_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_aml_alerts_player_open ON aml_alerts (player_id) WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS withdrawal_risk_assessments (
+  payment_id     uuid         PRIMARY KEY REFERENCES payments(id) ON DELETE CASCADE,
+  player_id      uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  score          integer      NOT NULL,
+  recommendation varchar(10)  NOT NULL,
+  factors        jsonb        NOT NULL DEFAULT '[]',
+  inputs         jsonb        NOT NULL DEFAULT '{}',
+  created_at     timestamptz  NOT NULL DEFAULT now(),
+  CONSTRAINT withdrawal_risk_recommendation_check CHECK (recommendation IN ('approve', 'review', 'deny'))
+);
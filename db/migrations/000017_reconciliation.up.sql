@@ -0,0 +1,33 @@
+-- 000017_reconciliation.up.sql
+-- Payment provider reconciliation runs and their findings
+
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+  id                uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  provider          varchar(30)  NOT NULL,
+  period_start      timestamptz  NOT NULL,
+  period_end        timestamptz  NOT NULL,
+  status            varchar(20)  NOT NULL DEFAULT 'running',
+  provider_records  integer      NOT NULL DEFAULT 0,
+  matched_count     integer      NOT NULL DEFAULT 0,
+  discrepancy_count integer      NOT NULL DEFAULT 0,
+  error             text,
+  started_at        timestamptz  NOT NULL DEFAULT now(),
+  finished_at       timestamptz,
+  CONSTRAINT reconciliation_runs_status_check CHECK (status IN ('running', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_provider ON reconciliation_runs (provider, started_at DESC);
+
+CREATE TABLE IF NOT EXISTS reconciliation_discrepancies (
+  id              bigserial    PRIMARY KEY,
+  run_id          uuid         NOT NULL REFERENCES reconciliation_runs(id) ON DELETE CASCADE,
+  kind            varchar(30)  NOT NULL,
+  provider_ref    varchar(255),
+  payment_id      uuid         REFERENCES payments(id) ON DELETE SET NULL,
+  expected_amount bigint,
+  actual_amount   bigint,
+  detail          text         NOT NULL DEFAULT '',
+  created_at      timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_run ON reconciliation_discrepancies (run_id);
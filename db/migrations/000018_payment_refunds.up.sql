@@ -0,0 +1,25 @@
+-- 000018_payment_refunds.up.sql
+-- Admin-initiated deposit refunds tracked through PSP webhooks
+
+CREATE TABLE IF NOT EXISTS payment_refunds (
+  id                      uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  payment_id              uuid          NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+  player_id               uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  amount                  numeric(15,0) NOT NULL,
+  currency                varchar(3)    NOT NULL,
+  status                  varchar(20)   NOT NULL DEFAULT 'pending',
+  reason                  text          NOT NULL DEFAULT '',
+  provider                varchar(30)   NOT NULL,
+  provider_refund_id      varchar(255),
+  failure_reason          text,
+  requested_by            uuid,
+  transaction_id          uuid          REFERENCES v2_transactions(id),
+  reversal_transaction_id uuid          REFERENCES v2_transactions(id),
+  created_at              timestamptz   NOT NULL DEFAULT now(),
+  updated_at              timestamptz   NOT NULL DEFAULT now(),
+  CONSTRAINT payment_refunds_status_check CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_payment ON payment_refunds (payment_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_refunds_provider_ref ON payment_refunds (provider_refund_id)
+  WHERE provider_refund_id IS NOT NULL;
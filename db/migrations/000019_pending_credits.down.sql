@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pending_credits;
+ALTER TABLE player_profiles DROP COLUMN IF EXISTS deposit_review_required;
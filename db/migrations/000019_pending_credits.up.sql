@@ -0,0 +1,25 @@
+-- 000019_pending_credits.up.sql
+-- Manual review of PSP deposits before the ledger credit (fraud-flagged players)
+
+ALTER TABLE player_profiles ADD COLUMN IF NOT EXISTS deposit_review_required boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS pending_credits (
+  id                    uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  payment_id            uuid          NOT NULL UNIQUE REFERENCES payments(id) ON DELETE CASCADE,
+  player_id             uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  amount                numeric(15,0) NOT NULL,
+  currency              varchar(3)    NOT NULL,
+  provider              varchar(30)   NOT NULL,
+  provider_payment_id   varchar(255),
+  provider_event_id     varchar(255)  NOT NULL,
+  status                varchar(20)   NOT NULL DEFAULT 'pending',
+  note                  text,
+  reviewed_by           uuid,
+  reviewed_at           timestamptz,
+  transaction_id        uuid          REFERENCES v2_transactions(id),
+  provider_refund_id    varchar(255),
+  created_at            timestamptz   NOT NULL DEFAULT now(),
+  CONSTRAINT pending_credits_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_credits_status ON pending_credits (status, created_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS event_outbox_dlq;
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS "lastError";
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS "nextAttemptAt";
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS "attempts";
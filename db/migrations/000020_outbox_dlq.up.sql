@@ -0,0 +1,24 @@
+-- 000020_outbox_dlq.up.sql
+-- Per-row retry state on the outbox and a dead-letter table for events that
+-- keep failing to publish.
+
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS "attempts" integer NOT NULL DEFAULT 0;
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS "nextAttemptAt" timestamptz;
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS "lastError" text;
+
+CREATE TABLE IF NOT EXISTS event_outbox_dlq (
+  "id" bigserial PRIMARY KEY,
+  "eventId" uuid NOT NULL UNIQUE,
+  "aggregateType" varchar(64) NOT NULL,
+  "aggregateId" varchar(128) NOT NULL,
+  "eventType" varchar(128) NOT NULL,
+  "partitionKey" varchar(128),
+  "headers" jsonb NOT NULL DEFAULT '{}'::jsonb,
+  "payload" jsonb NOT NULL,
+  "occurredAt" timestamptz NOT NULL,
+  "attempts" integer NOT NULL,
+  "lastError" text NOT NULL,
+  "failedAt" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_dlq_failed ON event_outbox_dlq ("failedAt");
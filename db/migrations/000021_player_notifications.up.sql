@@ -0,0 +1,14 @@
+-- 000021_player_notifications.up.sql
+-- In-app notifications for players (unread count surfaces on GET /home)
+
+CREATE TABLE IF NOT EXISTS player_notifications (
+  id          uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id   uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  type        varchar(50)   NOT NULL,
+  title       varchar(300)  NOT NULL,
+  message     text,
+  read        boolean       NOT NULL DEFAULT false,
+  created_at  timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_notifications_unread ON player_notifications (player_id) WHERE NOT read;
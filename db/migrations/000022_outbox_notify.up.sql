@@ -0,0 +1,15 @@
+-- 000022_outbox_notify.up.sql
+-- Wake the outbox consumer on insert instead of waiting for its next poll.
+-- Statement-level so a multi-row insert sends a single notification.
+
+CREATE OR REPLACE FUNCTION notify_event_outbox() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('event_outbox', '');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS event_outbox_notify ON event_outbox;
+CREATE TRIGGER event_outbox_notify
+  AFTER INSERT ON event_outbox
+  FOR EACH STATEMENT EXECUTE FUNCTION notify_event_outbox();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fx_rates;
+ALTER TABLE v2_transactions DROP COLUMN IF EXISTS currency;
+DROP TABLE IF EXISTS player_wallets;
@@ -0,0 +1,32 @@
+-- 000023_player_wallets.up.sql
+-- Multi-currency wallets. v2_players keeps the base-currency balances; each
+-- additional currency a player holds gets a player_wallets row. Ledger entries
+-- record the currency of the wallet they moved.
+
+CREATE TABLE IF NOT EXISTS player_wallets (
+  player_id         uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  currency          varchar(3)    NOT NULL,
+  balance           numeric(15,0) NOT NULL DEFAULT 0,
+  bonus_balance     numeric(15,0) NOT NULL DEFAULT 0,
+  reserved_balance  numeric(15,0) NOT NULL DEFAULT 0,
+  created_at        timestamptz   NOT NULL DEFAULT now(),
+  updated_at        timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (player_id, currency),
+  CONSTRAINT player_wallets_non_negative CHECK (balance >= 0 AND bonus_balance >= 0 AND reserved_balance >= 0)
+);
+
+ALTER TABLE v2_transactions ADD COLUMN IF NOT EXISTS currency varchar(3);
+
+UPDATE v2_transactions t SET currency = p.currency
+FROM v2_players p
+WHERE p.id = t.player_id AND t.currency IS NULL;
+
+-- Explicit conversion rates between wallet currencies (1 base = rate quote).
+CREATE TABLE IF NOT EXISTS fx_rates (
+  base        varchar(3)    NOT NULL,
+  quote       varchar(3)    NOT NULL,
+  rate        numeric(18,8) NOT NULL CHECK (rate > 0),
+  updated_by  uuid,
+  updated_at  timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (base, quote)
+);
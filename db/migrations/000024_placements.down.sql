@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS placement_events;
+DROP TABLE IF EXISTS placements;
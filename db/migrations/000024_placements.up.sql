@@ -0,0 +1,34 @@
+-- 000024_placements.up.sql
+-- Server-driven promotional placements (banners/cards) and their engagement log
+
+CREATE TABLE IF NOT EXISTS placements (
+  id          uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  name        varchar(200)  NOT NULL,
+  kind        varchar(20)   NOT NULL CHECK (kind IN ('banner', 'card')),
+  title       varchar(300)  NOT NULL,
+  body        text,
+  image_url   text,
+  deep_link   text          NOT NULL,
+  vertical    varchar(20)   NOT NULL DEFAULT 'all',
+  segment     varchar(30)   NOT NULL DEFAULT 'all',
+  priority    integer       NOT NULL DEFAULT 0,
+  starts_at   timestamptz   NOT NULL DEFAULT now(),
+  ends_at     timestamptz,
+  active      boolean       NOT NULL DEFAULT true,
+  created_by  uuid,
+  created_at  timestamptz   NOT NULL DEFAULT now(),
+  updated_at  timestamptz   NOT NULL DEFAULT now(),
+  CONSTRAINT placements_window CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_placements_live ON placements (priority DESC, starts_at) WHERE active;
+
+CREATE TABLE IF NOT EXISTS placement_events (
+  id            bigserial     PRIMARY KEY,
+  placement_id  uuid          NOT NULL REFERENCES placements(id) ON DELETE CASCADE,
+  player_id     uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  type          varchar(20)   NOT NULL CHECK (type IN ('impression', 'click')),
+  created_at    timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_placement_events_placement ON placement_events (placement_id, type);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS experiment_exposures;
+DROP TABLE IF EXISTS experiments;
+DROP TABLE IF EXISTS feature_flags;
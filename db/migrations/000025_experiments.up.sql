@@ -0,0 +1,39 @@
+-- 000025_experiments.up.sql
+-- Feature flags and A/B experiments. A running experiment overrides its flag's
+-- value with the variant a player hashes into; first exposures are logged.
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+  key          varchar(100)  PRIMARY KEY,
+  description  text,
+  enabled      boolean       NOT NULL DEFAULT false,
+  value        jsonb         NOT NULL DEFAULT 'true',
+  updated_by   uuid,
+  updated_at   timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS experiments (
+  id           uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  key          varchar(100)  NOT NULL UNIQUE,
+  flag_key     varchar(100)  REFERENCES feature_flags(key) ON DELETE SET NULL,
+  description  text,
+  status       varchar(20)   NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'running', 'stopped')),
+  traffic_pct  integer       NOT NULL DEFAULT 100 CHECK (traffic_pct BETWEEN 0 AND 100),
+  variants     jsonb         NOT NULL,
+  started_at   timestamptz,
+  stopped_at   timestamptz,
+  created_by   uuid,
+  created_at   timestamptz   NOT NULL DEFAULT now(),
+  updated_at   timestamptz   NOT NULL DEFAULT now()
+);
+
+-- At most one running experiment drives a given flag.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_experiments_running_flag
+  ON experiments (flag_key) WHERE status = 'running';
+
+CREATE TABLE IF NOT EXISTS experiment_exposures (
+  experiment_id  uuid          NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+  player_id      uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  variant        varchar(50)   NOT NULL,
+  exposed_at     timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (experiment_id, player_id)
+);
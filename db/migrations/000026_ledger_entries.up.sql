@@ -0,0 +1,18 @@
+-- 000026_ledger_entries.up.sql
+-- Double-entry postings behind every v2_transactions row. Player accounts are
+-- operator liabilities (credit = player balance up); house, bonus pool and
+-- provider/payment clearing accounts take the other side. Each transaction's
+-- debits equal its credits.
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+  id              bigserial     PRIMARY KEY,
+  transaction_id  uuid          NOT NULL REFERENCES v2_transactions(id),
+  account         varchar(200)  NOT NULL,
+  direction       varchar(6)    NOT NULL CHECK (direction IN ('debit', 'credit')),
+  amount          numeric(15,0) NOT NULL CHECK (amount > 0),
+  currency        varchar(3)    NOT NULL,
+  created_at      timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries (account, currency);
@@ -0,0 +1,2 @@
+-- 000027_ledger_discrepancies.down.sql
+DROP TABLE IF EXISTS ledger_discrepancies;
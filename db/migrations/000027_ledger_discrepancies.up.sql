@@ -0,0 +1,24 @@
+-- 000027_ledger_discrepancies.up.sql
+-- Balance drift found by the ledger reconciler: a wallet whose stored balances
+-- no longer match the snapshot on its latest v2_transactions row. At most one
+-- open discrepancy per wallet; it is resolved once the balances line up again.
+
+CREATE TABLE IF NOT EXISTS ledger_discrepancies (
+  id                        bigserial     PRIMARY KEY,
+  player_id                 uuid          NOT NULL REFERENCES v2_players(id),
+  currency                  varchar(3)    NOT NULL,
+  kind                      varchar(30)   NOT NULL CHECK (kind IN ('snapshot_mismatch', 'missing_history')),
+  stored_balance            numeric(15,0) NOT NULL,
+  stored_bonus_balance      numeric(15,0) NOT NULL,
+  stored_reserved_balance   numeric(15,0) NOT NULL,
+  expected_balance          numeric(15,0) NOT NULL,
+  expected_bonus_balance    numeric(15,0) NOT NULL,
+  expected_reserved_balance numeric(15,0) NOT NULL,
+  last_transaction_id       uuid          REFERENCES v2_transactions(id),
+  detected_at               timestamptz   NOT NULL DEFAULT now(),
+  resolved_at               timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_discrepancies_open
+  ON ledger_discrepancies (player_id, currency) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_ledger_discrepancies_detected ON ledger_discrepancies (detected_at DESC);
@@ -0,0 +1,3 @@
+-- 000028_terms.down.sql
+DROP TABLE IF EXISTS terms_acceptances;
+DROP TABLE IF EXISTS terms_documents;
@@ -0,0 +1,27 @@
+-- 000028_terms.up.sql
+-- Versioned T&C and bonus-terms documents. A document is a draft until
+-- published; the highest published version of each kind is current and every
+-- player must have accepted it. Acceptances keep the content hash the player
+-- saw, as regulatory evidence.
+
+CREATE TABLE IF NOT EXISTS terms_documents (
+  id            uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  kind          varchar(20)   NOT NULL CHECK (kind IN ('terms', 'bonus_terms')),
+  version       integer       NOT NULL CHECK (version > 0),
+  title         varchar(200)  NOT NULL,
+  body          text          NOT NULL,
+  content_hash  varchar(64)   NOT NULL,
+  created_by    uuid,
+  created_at    timestamptz   NOT NULL DEFAULT now(),
+  published_at  timestamptz,
+  UNIQUE (kind, version)
+);
+
+CREATE TABLE IF NOT EXISTS terms_acceptances (
+  player_id     uuid          NOT NULL REFERENCES v2_players(id),
+  document_id   uuid          NOT NULL REFERENCES terms_documents(id),
+  content_hash  varchar(64)   NOT NULL,
+  ip            varchar(64),
+  accepted_at   timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (player_id, document_id)
+);
@@ -0,0 +1,4 @@
+-- 000029_dob_verification.down.sql
+ALTER TABLE player_profiles
+  DROP COLUMN IF EXISTS dob_verified_by,
+  DROP COLUMN IF EXISTS dob_verified_at;
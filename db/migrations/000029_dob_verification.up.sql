@@ -0,0 +1,8 @@
+-- 000029_dob_verification.up.sql
+-- Date of birth is captured at registration (and checked against the
+-- jurisdiction's minimum age) but only counts as verified once an admin has
+-- checked it against an identity document.
+
+ALTER TABLE player_profiles
+  ADD COLUMN IF NOT EXISTS dob_verified_at timestamptz,
+  ADD COLUMN IF NOT EXISTS dob_verified_by uuid;
@@ -0,0 +1,3 @@
+-- 000030_source_of_funds.down.sql
+DROP TABLE IF EXISTS sof_documents;
+DROP TABLE IF EXISTS sof_questionnaires;
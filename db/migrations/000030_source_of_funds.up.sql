@@ -0,0 +1,38 @@
+-- 000030_source_of_funds.up.sql
+-- Source-of-funds questionnaires. One is opened per player for each
+-- cumulative-deposit threshold crossed; deposits stay blocked until the
+-- player submits it. Supporting documents are stored inline.
+
+CREATE TABLE IF NOT EXISTS sof_questionnaires (
+  id                  uuid           PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id           uuid           NOT NULL REFERENCES v2_players(id),
+  threshold           numeric(15,0)  NOT NULL,
+  status              varchar(20)    NOT NULL DEFAULT 'pending'
+                      CHECK (status IN ('pending', 'submitted', 'approved', 'rejected')),
+  occupation          varchar(200),
+  employer            varchar(200),
+  annual_income_band  varchar(50),
+  source_of_funds     varchar(50),
+  details             text,
+  review_note         text,
+  reviewed_by         uuid,
+  reviewed_at         timestamptz,
+  submitted_at        timestamptz,
+  created_at          timestamptz    NOT NULL DEFAULT now(),
+  updated_at          timestamptz    NOT NULL DEFAULT now(),
+  UNIQUE (player_id, threshold)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sof_questionnaires_status ON sof_questionnaires (status, submitted_at);
+
+CREATE TABLE IF NOT EXISTS sof_documents (
+  id                uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  questionnaire_id  uuid          NOT NULL REFERENCES sof_questionnaires(id) ON DELETE CASCADE,
+  file_name         varchar(255)  NOT NULL,
+  content_type      varchar(100)  NOT NULL,
+  size_bytes        integer       NOT NULL,
+  content           bytea         NOT NULL,
+  uploaded_at       timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sof_documents_questionnaire ON sof_documents (questionnaire_id);
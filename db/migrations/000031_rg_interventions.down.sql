@@ -0,0 +1,2 @@
+-- 000031_rg_interventions.down.sql
+DROP TABLE IF EXISTS rg_interventions;
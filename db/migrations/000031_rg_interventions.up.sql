@@ -0,0 +1,21 @@
+-- 000031_rg_interventions.up.sql
+-- Automated responsible-gambling interventions triggered when a player's
+-- rolling net losses cross a jurisdiction's thresholds. Every row is kept as
+-- the compliance audit trail, including how the player responded.
+
+CREATE TABLE IF NOT EXISTS rg_interventions (
+  id               uuid           PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id        uuid           NOT NULL REFERENCES v2_players(id),
+  kind             varchar(30)    NOT NULL
+                   CHECK (kind IN ('email_nudge', 'break_suggestion', 'deposit_limit_prompt')),
+  jurisdiction     varchar(10)    NOT NULL,
+  net_loss         numeric(15,0)  NOT NULL,
+  threshold        numeric(15,0)  NOT NULL,
+  window_days      integer        NOT NULL,
+  triggered_at     timestamptz    NOT NULL DEFAULT now(),
+  acknowledged_at  timestamptz,
+  response         varchar(20)    CHECK (response IN ('accepted', 'dismissed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_rg_interventions_player ON rg_interventions (player_id, kind, triggered_at DESC);
+CREATE INDEX IF NOT EXISTS idx_rg_interventions_triggered ON rg_interventions (triggered_at DESC);
@@ -0,0 +1,2 @@
+-- 000032_rg_risk_scores.down.sql
+DROP TABLE IF EXISTS rg_risk_scores;
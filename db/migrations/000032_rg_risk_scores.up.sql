@@ -0,0 +1,24 @@
+-- 000032_rg_risk_scores.up.sql
+-- Rule-based responsible-gambling scores for casino play sessions. A session
+-- is a run of stakes and payouts with no gap over 30 minutes; re-scoring an
+-- ongoing session updates its row.
+
+CREATE TABLE IF NOT EXISTS rg_risk_scores (
+  id             uuid           PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id      uuid           NOT NULL REFERENCES v2_players(id),
+  session_start  timestamptz    NOT NULL,
+  session_end    timestamptz    NOT NULL,
+  score          integer        NOT NULL CHECK (score BETWEEN 0 AND 100),
+  band           varchar(10)    NOT NULL CHECK (band IN ('low', 'medium', 'high')),
+  factors        jsonb          NOT NULL DEFAULT '[]',
+  escalations    integer        NOT NULL DEFAULT 0,
+  bets           integer        NOT NULL DEFAULT 0,
+  staked         numeric(15,0)  NOT NULL DEFAULT 0,
+  net_loss       numeric(15,0)  NOT NULL DEFAULT 0,
+  minutes        integer        NOT NULL DEFAULT 0,
+  scored_at      timestamptz    NOT NULL DEFAULT now(),
+  UNIQUE (player_id, session_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rg_risk_scores_session ON rg_risk_scores (session_start DESC);
+CREATE INDEX IF NOT EXISTS idx_rg_risk_scores_band ON rg_risk_scores (band, score DESC);
@@ -0,0 +1,3 @@
+-- 000033_rg_cases.down.sql
+DROP TABLE IF EXISTS rg_case_interactions;
+DROP TABLE IF EXISTS rg_cases;
@@ -0,0 +1,40 @@
+-- 000033_rg_cases.up.sql
+-- Responsible-gambling case management. A case follows one player through
+-- review: who owns it, every contact and note along the way, and the outcome
+-- it closed with. A player has at most one case open at a time.
+
+CREATE TABLE IF NOT EXISTS rg_cases (
+  id            uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id     uuid          NOT NULL REFERENCES v2_players(id),
+  status        varchar(20)   NOT NULL DEFAULT 'open'
+                CHECK (status IN ('open', 'in_progress', 'closed')),
+  priority      varchar(10)   NOT NULL DEFAULT 'medium'
+                CHECK (priority IN ('low', 'medium', 'high')),
+  source        varchar(20)   NOT NULL DEFAULT 'manual'
+                CHECK (source IN ('risk_score', 'intervention', 'manual')),
+  reason        text          NOT NULL,
+  assigned_to   uuid,
+  opened_by     uuid,
+  outcome       varchar(20)
+                CHECK (outcome IN ('no_action', 'monitoring', 'limits_set', 'self_excluded', 'account_closed')),
+  outcome_note  text,
+  opened_at     timestamptz   NOT NULL DEFAULT now(),
+  updated_at    timestamptz   NOT NULL DEFAULT now(),
+  closed_at     timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rg_cases_open_player ON rg_cases (player_id) WHERE status <> 'closed';
+CREATE INDEX IF NOT EXISTS idx_rg_cases_status ON rg_cases (status, opened_at DESC);
+CREATE INDEX IF NOT EXISTS idx_rg_cases_assigned ON rg_cases (assigned_to) WHERE status <> 'closed';
+
+CREATE TABLE IF NOT EXISTS rg_case_interactions (
+  id          uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  case_id     uuid          NOT NULL REFERENCES rg_cases(id) ON DELETE CASCADE,
+  admin_id    uuid,
+  kind        varchar(20)   NOT NULL
+              CHECK (kind IN ('note', 'call', 'email', 'chat', 'assignment', 'status_change')),
+  summary     text          NOT NULL,
+  created_at  timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rg_case_interactions_case ON rg_case_interactions (case_id, created_at);
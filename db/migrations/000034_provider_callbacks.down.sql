@@ -0,0 +1,2 @@
+-- 000034_provider_callbacks.down.sql
+DROP TABLE IF EXISTS provider_callbacks;
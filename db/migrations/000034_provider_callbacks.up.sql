@@ -0,0 +1,30 @@
+-- 000034_provider_callbacks.up.sql
+-- Journal of every casino provider wallet callback as received: the raw body,
+-- whether its signature checked out, the action it resolved to and what the
+-- ledger answered. Used to settle disputes with game vendors and to find
+-- callbacks and ledger transactions that do not match up.
+
+CREATE TABLE IF NOT EXISTS provider_callbacks (
+  id               uuid           PRIMARY KEY DEFAULT gen_random_uuid(),
+  provider         varchar(50)    NOT NULL,
+  path             text           NOT NULL,
+  action           varchar(20),
+  player_id        uuid,
+  transaction_id   text,
+  reference_id     text,
+  round_id         text,
+  amount           numeric(15,0),
+  currency         varchar(3),
+  body             text           NOT NULL DEFAULT '',
+  signature_valid  boolean,
+  status           varchar(20)    NOT NULL,
+  message          text,
+  balance          numeric(15,0),
+  bonus_balance    numeric(15,0),
+  duration_ms      integer        NOT NULL DEFAULT 0,
+  received_at      timestamptz    NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_provider_callbacks_received ON provider_callbacks (received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_provider_callbacks_tx ON provider_callbacks (provider, transaction_id);
+CREATE INDEX IF NOT EXISTS idx_provider_callbacks_player ON provider_callbacks (player_id, received_at DESC);
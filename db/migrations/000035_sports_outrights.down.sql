@@ -0,0 +1,25 @@
+-- 000035_sports_outrights.down.sql
+DELETE FROM sports_bets WHERE event_id IS NULL;
+DELETE FROM sports_markets WHERE event_id IS NULL;
+
+DROP INDEX IF EXISTS sports_bets_market_id_status_idx;
+ALTER TABLE sports_bets
+  DROP COLUMN IF EXISTS each_way_fraction,
+  DROP COLUMN IF EXISTS each_way_places,
+  DROP COLUMN IF EXISTS each_way;
+ALTER TABLE sports_bets ALTER COLUMN event_id SET NOT NULL;
+
+ALTER TABLE sports_selections
+  DROP COLUMN IF EXISTS dead_heat_count,
+  DROP COLUMN IF EXISTS finish_position;
+
+DROP INDEX IF EXISTS sports_markets_outright_idx;
+ALTER TABLE sports_markets
+  DROP CONSTRAINT IF EXISTS sports_markets_each_way_chk,
+  DROP CONSTRAINT IF EXISTS sports_markets_event_or_sport_chk,
+  DROP COLUMN IF EXISTS each_way_fraction,
+  DROP COLUMN IF EXISTS each_way_places,
+  DROP COLUMN IF EXISTS settles_at,
+  DROP COLUMN IF EXISTS league,
+  DROP COLUMN IF EXISTS sport_id;
+ALTER TABLE sports_markets ALTER COLUMN event_id SET NOT NULL;
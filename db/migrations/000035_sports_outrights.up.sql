@@ -0,0 +1,32 @@
+-- 000035_sports_outrights.up.sql
+-- Outright (futures) markets: season-long markets such as league winner that
+-- hang off a sport and league instead of a single fixture and settle weeks or
+-- months after they open. Markets may offer each-way terms (paying places and
+-- the fraction of the win odds paid for a place); selections record their
+-- finishing position and how many runners dead-heated for it.
+
+ALTER TABLE sports_markets ALTER COLUMN event_id DROP NOT NULL;
+ALTER TABLE sports_markets
+  ADD COLUMN IF NOT EXISTS sport_id          uuid REFERENCES sports(id) ON DELETE CASCADE,
+  ADD COLUMN IF NOT EXISTS league            varchar(100),
+  ADD COLUMN IF NOT EXISTS settles_at        timestamptz,
+  ADD COLUMN IF NOT EXISTS each_way_places   integer CHECK (each_way_places > 0),
+  ADD COLUMN IF NOT EXISTS each_way_fraction integer CHECK (each_way_fraction > 0),
+  ADD CONSTRAINT sports_markets_event_or_sport_chk CHECK (event_id IS NOT NULL OR sport_id IS NOT NULL),
+  ADD CONSTRAINT sports_markets_each_way_chk CHECK ((each_way_places IS NULL) = (each_way_fraction IS NULL));
+
+CREATE INDEX IF NOT EXISTS sports_markets_outright_idx ON sports_markets (sport_id, status) WHERE event_id IS NULL;
+
+ALTER TABLE sports_selections
+  ADD COLUMN IF NOT EXISTS finish_position integer CHECK (finish_position > 0),
+  ADD COLUMN IF NOT EXISTS dead_heat_count integer NOT NULL DEFAULT 1 CHECK (dead_heat_count > 0);
+
+-- Each-way bets lock the market's terms at placement; stake_amount_minor is
+-- the total debited across both the win and place lines.
+ALTER TABLE sports_bets ALTER COLUMN event_id DROP NOT NULL;
+ALTER TABLE sports_bets
+  ADD COLUMN IF NOT EXISTS each_way          boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS each_way_places   integer,
+  ADD COLUMN IF NOT EXISTS each_way_fraction integer;
+
+CREATE INDEX IF NOT EXISTS sports_bets_market_id_status_idx ON sports_bets (market_id, status);
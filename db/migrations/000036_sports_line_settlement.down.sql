@@ -0,0 +1,6 @@
+-- 000036_sports_line_settlement.down.sql
+ALTER TABLE sports_selections
+  DROP CONSTRAINT IF EXISTS sports_selections_line_quarter_chk,
+  DROP CONSTRAINT IF EXISTS sports_selections_line_side_chk,
+  DROP COLUMN IF EXISTS side,
+  DROP COLUMN IF EXISTS line;
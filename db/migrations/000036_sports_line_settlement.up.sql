@@ -0,0 +1,11 @@
+-- 000036_sports_line_settlement.up.sql
+-- Handicap and totals selections carry their line (in hundredths, so -0.25
+-- is -25 and 2.5 is 250) and side, letting event settlement derive results
+-- from the final score: Asian quarter lines half-win or half-lose, whole
+-- lines push when they land exactly.
+
+ALTER TABLE sports_selections
+  ADD COLUMN IF NOT EXISTS line integer,
+  ADD COLUMN IF NOT EXISTS side varchar(10) CHECK (side IN ('home', 'away', 'over', 'under')),
+  ADD CONSTRAINT sports_selections_line_side_chk CHECK ((line IS NULL) = (side IS NULL)),
+  ADD CONSTRAINT sports_selections_line_quarter_chk CHECK (line % 25 = 0);
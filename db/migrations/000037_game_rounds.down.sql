@@ -0,0 +1,2 @@
+-- 000037_game_rounds.down.sql
+DROP TABLE IF EXISTS game_rounds;
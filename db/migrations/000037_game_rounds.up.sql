@@ -0,0 +1,26 @@
+-- 000037_game_rounds.up.sql
+-- Lifecycle of each provider game round, kept by the ledger: a round opens on
+-- its first stake and closes on the provider's win or loss. Rounds left open
+-- past the configured TTL are voided by the stuck-round sweeper, which refunds
+-- the unsettled stakes.
+
+CREATE TABLE IF NOT EXISTS game_rounds (
+  id                uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id         uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  manufacturer_id   varchar(64)   NOT NULL,
+  round_id          varchar(128)  NOT NULL,
+  currency          varchar(3),
+  status            varchar(20)   NOT NULL DEFAULT 'open'
+                    CHECK (status IN ('open', 'closed', 'voided')),
+  stake_total       bigint        NOT NULL DEFAULT 0,
+  win_total         bigint        NOT NULL DEFAULT 0,
+  refund_total      bigint        NOT NULL DEFAULT 0,
+  opened_at         timestamptz   NOT NULL DEFAULT now(),
+  last_activity_at  timestamptz   NOT NULL DEFAULT now(),
+  closed_at         timestamptz,
+  UNIQUE (player_id, manufacturer_id, round_id)
+);
+
+CREATE INDEX IF NOT EXISTS game_rounds_open_idx
+  ON game_rounds (last_activity_at)
+  WHERE status = 'open';
@@ -0,0 +1,2 @@
+-- 000038_idempotency_keys.down.sql
+DROP TABLE IF EXISTS idempotency_keys;
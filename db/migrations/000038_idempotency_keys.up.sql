@@ -0,0 +1,18 @@
+-- 000038_idempotency_keys.up.sql
+-- Responses to player requests sent with an Idempotency-Key header, kept for
+-- 24 hours so a retried deposit or withdrawal returns the original response
+-- instead of creating a second Stripe session or withdrawal. A row with no
+-- status_code is a request still in flight.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  player_id      uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  key            varchar(255)  NOT NULL,
+  scope          varchar(200)  NOT NULL,
+  request_hash   varchar(64)   NOT NULL,
+  status_code    integer,
+  content_type   varchar(100),
+  response_body  bytea,
+  created_at     timestamptz   NOT NULL DEFAULT now(),
+  completed_at   timestamptz,
+  PRIMARY KEY (player_id, key)
+);
@@ -0,0 +1,6 @@
+-- 000039_bet_pools.down.sql
+DROP TABLE IF EXISTS bet_pool_messages;
+DROP TABLE IF EXISTS bet_pool_payouts;
+DROP TABLE IF EXISTS bet_pool_contributions;
+DROP TABLE IF EXISTS bet_pool_members;
+DROP TABLE IF EXISTS bet_pools;
@@ -0,0 +1,74 @@
+-- 000039_bet_pools.up.sql
+-- Social bet pools: a group of invited players contributes stakes towards one
+-- sportsbook bet. Each contribution is debited through the ledger when made;
+-- once the contribution window closes the pool is placed at the selection's
+-- odds, and on settlement the return is split pro-rata between contributors
+-- with a ledger entry per participant.
+
+CREATE TABLE IF NOT EXISTS bet_pools (
+  id                      uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  owner_id                uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  name                    varchar(100)  NOT NULL,
+  event_id                uuid          REFERENCES sports_events(id) ON DELETE CASCADE,
+  market_id               uuid          NOT NULL REFERENCES sports_markets(id) ON DELETE CASCADE,
+  selection_id            uuid          NOT NULL REFERENCES sports_selections(id) ON DELETE CASCADE,
+  min_contribution        bigint        NOT NULL DEFAULT 100 CHECK (min_contribution > 0),
+  contributions_close_at  timestamptz   NOT NULL,
+  status                  varchar(20)   NOT NULL DEFAULT 'open'
+                          CHECK (status IN ('open', 'placed', 'settled', 'cancelled')),
+  total_stake             bigint        NOT NULL DEFAULT 0,
+  odds_at_placement       integer,
+  result                  varchar(20),
+  payout                  bigint        NOT NULL DEFAULT 0,
+  game_round_id           varchar(128)  NOT NULL,
+  created_at              timestamptz   NOT NULL DEFAULT now(),
+  placed_at               timestamptz,
+  settled_at              timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS bet_pools_status_close_idx ON bet_pools (status, contributions_close_at);
+CREATE INDEX IF NOT EXISTS bet_pools_market_idx ON bet_pools (market_id) WHERE status = 'placed';
+
+CREATE TABLE IF NOT EXISTS bet_pool_members (
+  pool_id     uuid          NOT NULL REFERENCES bet_pools(id) ON DELETE CASCADE,
+  player_id   uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  status      varchar(20)   NOT NULL DEFAULT 'invited'
+              CHECK (status IN ('invited', 'joined', 'declined')),
+  invited_by  uuid          REFERENCES v2_players(id) ON DELETE SET NULL,
+  invited_at  timestamptz   NOT NULL DEFAULT now(),
+  joined_at   timestamptz,
+  PRIMARY KEY (pool_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS bet_pool_members_player_idx ON bet_pool_members (player_id);
+
+CREATE TABLE IF NOT EXISTS bet_pool_contributions (
+  id              uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  pool_id         uuid          NOT NULL REFERENCES bet_pools(id) ON DELETE CASCADE,
+  player_id       uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  amount          bigint        NOT NULL CHECK (amount > 0),
+  transaction_id  uuid          NOT NULL,
+  created_at      timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS bet_pool_contributions_pool_idx ON bet_pool_contributions (pool_id, player_id);
+
+CREATE TABLE IF NOT EXISTS bet_pool_payouts (
+  pool_id         uuid          NOT NULL REFERENCES bet_pools(id) ON DELETE CASCADE,
+  player_id       uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  stake           bigint        NOT NULL,
+  payout          bigint        NOT NULL,
+  transaction_id  uuid,
+  created_at      timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (pool_id, player_id)
+);
+
+CREATE TABLE IF NOT EXISTS bet_pool_messages (
+  id          uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  pool_id     uuid          NOT NULL REFERENCES bet_pools(id) ON DELETE CASCADE,
+  player_id   uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  body        text          NOT NULL CHECK (length(body) BETWEEN 1 AND 1000),
+  created_at  timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS bet_pool_messages_pool_idx ON bet_pool_messages (pool_id, created_at);
@@ -0,0 +1,2 @@
+-- 000040_p2p_transfers.down.sql
+DROP TABLE IF EXISTS p2p_transfers;
@@ -0,0 +1,27 @@
+-- 000040_p2p_transfers.up.sql
+-- Player-to-player transfers (gifting) for social casino deployments. Each
+-- completed transfer posts a debit on the sender and a credit on the
+-- recipient, plus a fee leg when a fee applies. Rejected attempts are kept
+-- with their reason for AML review.
+
+CREATE TABLE IF NOT EXISTS p2p_transfers (
+  id                     uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  sender_id              uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  recipient_id           uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  amount                 bigint        NOT NULL CHECK (amount > 0),
+  fee                    bigint        NOT NULL DEFAULT 0 CHECK (fee >= 0),
+  currency               varchar(3)    NOT NULL,
+  message                varchar(200),
+  status                 varchar(20)   NOT NULL CHECK (status IN ('completed', 'rejected')),
+  reject_reason          varchar(50),
+  aml_flags              jsonb         NOT NULL DEFAULT '[]',
+  debit_transaction_id   uuid          REFERENCES v2_transactions(id),
+  credit_transaction_id  uuid          REFERENCES v2_transactions(id),
+  fee_transaction_id     uuid          REFERENCES v2_transactions(id),
+  created_at             timestamptz   NOT NULL DEFAULT now(),
+  CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS p2p_transfers_sender_idx ON p2p_transfers (sender_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS p2p_transfers_recipient_idx ON p2p_transfers (recipient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS p2p_transfers_status_idx ON p2p_transfers (status, created_at DESC);
@@ -0,0 +1,4 @@
+-- 000041_sweepstakes.down.sql
+DROP TABLE IF EXISTS sweeps_redemptions;
+DROP TABLE IF EXISTS coin_purchases;
+DROP TABLE IF EXISTS coin_packages;
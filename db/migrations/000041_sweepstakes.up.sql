@@ -0,0 +1,53 @@
+-- 000041_sweepstakes.up.sql
+-- Sweepstakes mode: players buy coin packages instead of depositing. A package
+-- credits Gold Coins (GC, play-only) and Sweeps Coins (SC, redeemable) into
+-- player_wallets rows; eligible SC is redeemed for cash through an admin
+-- reviewed redemption that reserves the coins until it is paid or rejected.
+
+CREATE TABLE IF NOT EXISTS coin_packages (
+  id              uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  name            varchar(100)  NOT NULL,
+  price           bigint        NOT NULL CHECK (price > 0),
+  price_currency  varchar(3)    NOT NULL,
+  gold_coins      bigint        NOT NULL CHECK (gold_coins >= 0),
+  sweeps_coins    bigint        NOT NULL CHECK (sweeps_coins >= 0),
+  active          boolean       NOT NULL DEFAULT true,
+  sort_order      integer       NOT NULL DEFAULT 0,
+  created_at      timestamptz   NOT NULL DEFAULT now(),
+  updated_at      timestamptz   NOT NULL DEFAULT now(),
+  CHECK (gold_coins > 0 OR sweeps_coins > 0)
+);
+
+CREATE TABLE IF NOT EXISTS coin_purchases (
+  id                uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id         uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  package_id        uuid          NOT NULL REFERENCES coin_packages(id),
+  payment_id        uuid          NOT NULL UNIQUE REFERENCES payments(id) ON DELETE CASCADE,
+  price             bigint        NOT NULL,
+  price_currency    varchar(3)    NOT NULL,
+  gold_coins        bigint        NOT NULL,
+  sweeps_coins      bigint        NOT NULL,
+  status            varchar(20)   NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed')),
+  created_at        timestamptz   NOT NULL DEFAULT now(),
+  completed_at      timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS coin_purchases_player_idx ON coin_purchases (player_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS sweeps_redemptions (
+  id                     uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id              uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  amount                 bigint        NOT NULL CHECK (amount > 0),
+  cash_amount            bigint        NOT NULL CHECK (cash_amount > 0),
+  cash_currency          varchar(3)    NOT NULL,
+  status                 varchar(20)   NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'rejected')),
+  reserve_transaction_id uuid          NOT NULL REFERENCES v2_transactions(id),
+  settle_transaction_id  uuid          REFERENCES v2_transactions(id),
+  reviewed_by            uuid,
+  review_note            text,
+  created_at             timestamptz   NOT NULL DEFAULT now(),
+  reviewed_at            timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS sweeps_redemptions_player_idx ON sweeps_redemptions (player_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS sweeps_redemptions_status_idx ON sweeps_redemptions (status, created_at);
@@ -0,0 +1,4 @@
+-- 000042_store.down.sql
+DROP TABLE IF EXISTS player_entitlements;
+DROP TABLE IF EXISTS store_orders;
+DROP TABLE IF EXISTS store_items;
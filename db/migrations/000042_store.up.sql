@@ -0,0 +1,64 @@
+-- 000042_store.up.sql
+-- Store for virtual goods bought through PSP checkout. An item is a coin
+-- bundle (credited to a GC or SC wallet through the ledger), an avatar item
+-- or a timed quest boost. Every completed order grants a player_entitlements
+-- row; coin bundles also reference the ledger transaction that credited them.
+
+CREATE TABLE IF NOT EXISTS store_items (
+  id                    uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  sku                   varchar(64)   NOT NULL UNIQUE,
+  name                  varchar(100)  NOT NULL,
+  description           text          NOT NULL DEFAULT '',
+  kind                  varchar(20)   NOT NULL CHECK (kind IN ('coin_bundle', 'avatar_item', 'quest_boost')),
+  price                 bigint        NOT NULL CHECK (price > 0),
+  price_currency        varchar(3)    NOT NULL,
+  coin_currency         varchar(3),
+  coin_amount           bigint        NOT NULL DEFAULT 0,
+  item_code             varchar(100),
+  boost_multiplier_bps  integer       NOT NULL DEFAULT 0,
+  boost_minutes         integer       NOT NULL DEFAULT 0,
+  active                boolean       NOT NULL DEFAULT true,
+  sort_order            integer       NOT NULL DEFAULT 0,
+  created_at            timestamptz   NOT NULL DEFAULT now(),
+  updated_at            timestamptz   NOT NULL DEFAULT now(),
+  CHECK (kind <> 'coin_bundle' OR (coin_currency IS NOT NULL AND coin_amount > 0)),
+  CHECK (kind <> 'avatar_item' OR item_code IS NOT NULL),
+  CHECK (kind <> 'quest_boost' OR (boost_multiplier_bps > 10000 AND boost_minutes > 0))
+);
+
+CREATE TABLE IF NOT EXISTS store_orders (
+  id              uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id       uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  item_id         uuid          NOT NULL REFERENCES store_items(id),
+  payment_id      uuid          NOT NULL UNIQUE REFERENCES payments(id) ON DELETE CASCADE,
+  sku             varchar(64)   NOT NULL,
+  item_name       varchar(100)  NOT NULL,
+  kind            varchar(20)   NOT NULL,
+  price           bigint        NOT NULL,
+  price_currency  varchar(3)    NOT NULL,
+  status          varchar(20)   NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed')),
+  created_at      timestamptz   NOT NULL DEFAULT now(),
+  completed_at    timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS store_orders_player_idx ON store_orders (player_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS player_entitlements (
+  id                    uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id             uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  item_id               uuid          NOT NULL REFERENCES store_items(id),
+  order_id              uuid          NOT NULL UNIQUE REFERENCES store_orders(id) ON DELETE CASCADE,
+  kind                  varchar(20)   NOT NULL,
+  item_code             varchar(100),
+  coin_currency         varchar(3),
+  coin_amount           bigint        NOT NULL DEFAULT 0,
+  transaction_id        uuid          REFERENCES v2_transactions(id),
+  boost_multiplier_bps  integer       NOT NULL DEFAULT 0,
+  expires_at            timestamptz,
+  created_at            timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS player_entitlements_player_idx ON player_entitlements (player_id, kind, expires_at);
+-- A player owns an avatar item at most once.
+CREATE UNIQUE INDEX IF NOT EXISTS player_entitlements_item_code_uniq
+  ON player_entitlements (player_id, item_code) WHERE kind = 'avatar_item';
@@ -0,0 +1,3 @@
+-- 000043_cosmetics.down.sql
+DROP TABLE IF EXISTS player_cosmetics;
+DROP TABLE IF EXISTS cosmetic_items;
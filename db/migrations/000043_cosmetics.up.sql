@@ -0,0 +1,31 @@
+-- 000043_cosmetics.up.sql
+-- Cosmetic catalog and player inventory. Items are earned (achievements,
+-- tournaments, admin grants) or bought as store avatar items; a player owns an
+-- item once and equips at most one item per slot. Equipped items are shown on
+-- the public profile and next to the player's social posts.
+
+CREATE TABLE IF NOT EXISTS cosmetic_items (
+  code        varchar(100)  PRIMARY KEY,
+  name        varchar(100)  NOT NULL,
+  slot        varchar(30)   NOT NULL CHECK (slot IN ('avatar', 'frame', 'hat', 'background', 'badge')),
+  rarity      varchar(20)   NOT NULL DEFAULT 'common' CHECK (rarity IN ('common', 'rare', 'epic', 'legendary')),
+  image_url   text          NOT NULL DEFAULT '',
+  active      boolean       NOT NULL DEFAULT true,
+  created_at  timestamptz   NOT NULL DEFAULT now(),
+  updated_at  timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS player_cosmetics (
+  player_id    uuid          NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  item_code    varchar(100)  NOT NULL REFERENCES cosmetic_items(code),
+  slot         varchar(30)   NOT NULL,
+  source       varchar(20)   NOT NULL CHECK (source IN ('store', 'achievement', 'tournament', 'admin')),
+  source_ref   varchar(200),
+  equipped     boolean       NOT NULL DEFAULT false,
+  acquired_at  timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (player_id, item_code)
+);
+
+-- slot is copied from the catalog so this index can hold one equipped item per slot.
+CREATE UNIQUE INDEX IF NOT EXISTS player_cosmetics_equipped_slot_uniq
+  ON player_cosmetics (player_id, slot) WHERE equipped;
@@ -0,0 +1,15 @@
+-- 000044_player_payment_methods.down.sql
+DROP INDEX IF EXISTS payment_methods_provider_ref_uniq;
+DROP INDEX IF EXISTS payment_methods_player_idx;
+ALTER TABLE payment_methods
+  DROP COLUMN IF EXISTS updated_at,
+  DROP COLUMN IF EXISTS created_at,
+  DROP COLUMN IF EXISTS verified_at,
+  DROP COLUMN IF EXISTS verified_by,
+  DROP COLUMN IF EXISTS verified,
+  DROP COLUMN IF EXISTS country,
+  DROP COLUMN IF EXISTS last4,
+  DROP COLUMN IF EXISTS brand,
+  DROP COLUMN IF EXISTS provider_ref,
+  DROP COLUMN IF EXISTS provider,
+  DROP COLUMN IF EXISTS player_id;
@@ -0,0 +1,24 @@
+-- 000044_player_payment_methods.up.sql
+-- Saved payment methods. The phase 1 payment_methods table becomes per-player:
+-- each row is a card or bank account tokenized by Stripe (provider_ref is the
+-- pm_ id) with only display details stored here. Withdrawals target a saved
+-- method; under the closed-loop policy it must be verified and already used
+-- for a completed deposit. Removed methods are deactivated, not deleted,
+-- because payments reference them.
+
+ALTER TABLE payment_methods
+  ADD COLUMN IF NOT EXISTS player_id     uuid REFERENCES v2_players(id) ON DELETE CASCADE,
+  ADD COLUMN IF NOT EXISTS provider      varchar(30),
+  ADD COLUMN IF NOT EXISTS provider_ref  varchar(100),
+  ADD COLUMN IF NOT EXISTS brand         varchar(30),
+  ADD COLUMN IF NOT EXISTS last4         varchar(4),
+  ADD COLUMN IF NOT EXISTS country       varchar(2),
+  ADD COLUMN IF NOT EXISTS verified      boolean NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS verified_by   uuid,
+  ADD COLUMN IF NOT EXISTS verified_at   timestamptz,
+  ADD COLUMN IF NOT EXISTS created_at    timestamptz NOT NULL DEFAULT now(),
+  ADD COLUMN IF NOT EXISTS updated_at    timestamptz NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS payment_methods_player_idx ON payment_methods (player_id) WHERE player_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS payment_methods_provider_ref_uniq
+  ON payment_methods (player_id, provider, provider_ref) WHERE player_id IS NOT NULL;
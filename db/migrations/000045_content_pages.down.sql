@@ -0,0 +1,3 @@
+-- 000045_content_pages.down.sql
+DROP TABLE IF EXISTS content_page_versions;
+DROP TABLE IF EXISTS content_pages;
@@ -0,0 +1,26 @@
+-- 000045_content_pages.up.sql
+-- Static content pages (T&Cs, promo rules, help articles) managed from the
+-- admin CMS. Every save appends a version holding the markdown title and body
+-- per locale; publishing points the page at one version, which GET
+-- /content/{slug} serves. Older versions stay for history and rollback.
+
+CREATE TABLE IF NOT EXISTS content_pages (
+  id                 uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  slug               varchar(100)  NOT NULL UNIQUE,
+  default_locale     varchar(10)   NOT NULL DEFAULT 'en',
+  latest_version     int           NOT NULL DEFAULT 1,
+  published_version  int,
+  published_at       timestamptz,
+  created_by         uuid,
+  created_at         timestamptz   NOT NULL DEFAULT now(),
+  updated_at         timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS content_page_versions (
+  page_id       uuid         NOT NULL REFERENCES content_pages(id) ON DELETE CASCADE,
+  version       int          NOT NULL,
+  translations  jsonb        NOT NULL, -- {"en": {"title": ..., "body": ...}, ...}
+  created_by    uuid,
+  created_at    timestamptz  NOT NULL DEFAULT now(),
+  PRIMARY KEY (page_id, version)
+);
@@ -0,0 +1,3 @@
+-- 000046_placement_schedules.down.sql
+DROP TABLE IF EXISTS maintenance_windows;
+ALTER TABLE placements DROP COLUMN IF EXISTS schedule;
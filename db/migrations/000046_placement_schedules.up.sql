@@ -0,0 +1,19 @@
+-- 000046_placement_schedules.up.sql
+-- Serve-time scheduling rules for placements (weekdays, local hours, sports
+-- game days, hiding during maintenance) and the maintenance windows they
+-- react to. A window with vertical 'all' covers every product area.
+
+ALTER TABLE placements ADD COLUMN IF NOT EXISTS schedule jsonb NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+  id          uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  vertical    varchar(20)   NOT NULL DEFAULT 'all',
+  reason      varchar(300)  NOT NULL,
+  starts_at   timestamptz   NOT NULL,
+  ends_at     timestamptz   NOT NULL,
+  created_by  uuid,
+  created_at  timestamptz   NOT NULL DEFAULT now(),
+  CONSTRAINT maintenance_windows_window CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_windows_ends ON maintenance_windows (ends_at);
@@ -0,0 +1,2 @@
+-- 000047_kyc_documents.down.sql
+DROP TABLE IF EXISTS kyc_documents;
@@ -0,0 +1,25 @@
+-- 000047_kyc_documents.up.sql
+-- KYC identity documents. Files live in S3-compatible object storage under
+-- storage_key; only metadata and the review outcome are kept here. A player
+-- counts as verified (player_profiles.verified) once an identity document
+-- and a proof of address have both been approved.
+
+CREATE TABLE IF NOT EXISTS kyc_documents (
+  id             uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id      uuid          NOT NULL REFERENCES v2_players(id),
+  document_type  varchar(30)   NOT NULL
+                 CHECK (document_type IN ('passport', 'national_id', 'driving_licence', 'proof_of_address', 'selfie')),
+  file_name      varchar(255)  NOT NULL,
+  content_type   varchar(100)  NOT NULL,
+  size_bytes     integer       NOT NULL,
+  storage_key    varchar(300)  NOT NULL UNIQUE,
+  status         varchar(20)   NOT NULL DEFAULT 'pending'
+                 CHECK (status IN ('pending', 'approved', 'rejected')),
+  review_note    text,
+  reviewed_by    uuid,
+  reviewed_at    timestamptz,
+  uploaded_at    timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_documents_player ON kyc_documents (player_id, uploaded_at DESC);
+CREATE INDEX IF NOT EXISTS idx_kyc_documents_pending ON kyc_documents (uploaded_at) WHERE status = 'pending';
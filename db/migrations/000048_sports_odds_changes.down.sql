@@ -0,0 +1,2 @@
+-- 000048_sports_odds_changes.down.sql
+DROP TABLE IF EXISTS sports_odds_changes;
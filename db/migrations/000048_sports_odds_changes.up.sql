@@ -0,0 +1,19 @@
+-- 000048_sports_odds_changes.up.sql
+-- Audit trail of trader odds overrides. Every selection repriced by a bulk
+-- edit gets one row; rows of the same edit share edit_id.
+
+CREATE TABLE IF NOT EXISTS sports_odds_changes (
+  id                bigserial    PRIMARY KEY,
+  edit_id           uuid         NOT NULL,
+  selection_id      uuid         NOT NULL REFERENCES sports_selections(id) ON DELETE CASCADE,
+  market_id         uuid         NOT NULL REFERENCES sports_markets(id) ON DELETE CASCADE,
+  old_odds          integer      NOT NULL,
+  new_odds          integer      NOT NULL,
+  margin_delta_bps  integer,
+  reason            text         NOT NULL DEFAULT '',
+  admin_id          uuid         NOT NULL,
+  created_at        timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sports_odds_changes_market ON sports_odds_changes (market_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_sports_odds_changes_edit ON sports_odds_changes (edit_id);
@@ -0,0 +1,6 @@
+-- 000049_player_limit_changes.down.sql
+DROP INDEX IF EXISTS idx_player_limits_active;
+ALTER TABLE player_limits
+  DROP COLUMN IF EXISTS updated_at,
+  DROP COLUMN IF EXISTS pending_effective_at,
+  DROP COLUMN IF EXISTS pending_value;
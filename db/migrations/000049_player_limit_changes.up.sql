@@ -0,0 +1,12 @@
+-- 000049_player_limit_changes.up.sql
+-- Self-service deposit and loss limits on the existing player_limits table.
+-- Decreases overwrite limit_value at once; increases and removals wait in
+-- pending_value (0 = remove) until pending_effective_at.
+
+ALTER TABLE player_limits
+  ADD COLUMN IF NOT EXISTS pending_value        decimal(15,0),
+  ADD COLUMN IF NOT EXISTS pending_effective_at timestamptz,
+  ADD COLUMN IF NOT EXISTS updated_at           timestamptz NOT NULL DEFAULT now();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_limits_active
+  ON player_limits (player_id, type, period) WHERE active;
@@ -0,0 +1,3 @@
+-- Player self-exclusion: end of a time-limited exclusion, NULL while permanent
+
+ALTER TABLE player_profiles ADD COLUMN self_excluded_until timestamptz;
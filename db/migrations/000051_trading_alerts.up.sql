@@ -0,0 +1,22 @@
+-- 000051_trading_alerts.up.sql
+-- Alerts raised for unusual stake patterns, reviewed by traders on the
+-- liabilities page. dedupe_key keeps one open alert per selection spike or
+-- per player and market.
+
+CREATE TABLE IF NOT EXISTS trading_alerts (
+  id               uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  alert_type       varchar(50)  NOT NULL,
+  dedupe_key       text         NOT NULL,
+  market_id        uuid         NOT NULL REFERENCES sports_markets(id) ON DELETE CASCADE,
+  selection_id     uuid         REFERENCES sports_selections(id) ON DELETE CASCADE,
+  player_id        uuid         REFERENCES v2_players(id) ON DELETE CASCADE,
+  details          jsonb        NOT NULL DEFAULT '{}',
+  status           varchar(20)  NOT NULL DEFAULT 'open'
+                   CHECK (status IN ('open', 'acknowledged')),
+  acknowledged_by  uuid,
+  acknowledged_at  timestamptz,
+  created_at       timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trading_alerts_open_key ON trading_alerts (dedupe_key) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_trading_alerts_market ON trading_alerts (market_id, created_at DESC);
@@ -0,0 +1,26 @@
+-- 000052_retention_runs.up.sql
+-- Audit trail of the data retention worker. Each run records, per policy,
+-- the cutoff it applied and how many rows it deleted or redacted (or would
+-- have, for a dry run).
+
+CREATE TABLE IF NOT EXISTS retention_runs (
+  id           uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  dry_run      boolean      NOT NULL,
+  status       varchar(20)  NOT NULL DEFAULT 'running'
+               CHECK (status IN ('running', 'completed', 'failed')),
+  error        text,
+  started_at   timestamptz  NOT NULL DEFAULT now(),
+  finished_at  timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started ON retention_runs (started_at DESC);
+
+CREATE TABLE IF NOT EXISTS retention_run_items (
+  run_id      uuid         NOT NULL REFERENCES retention_runs(id) ON DELETE CASCADE,
+  policy      varchar(50)  NOT NULL,
+  table_name  varchar(100) NOT NULL,
+  action      varchar(20)  NOT NULL,
+  cutoff      timestamptz  NOT NULL,
+  affected    bigint       NOT NULL DEFAULT 0,
+  PRIMARY KEY (run_id, policy)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS player_bonuses_expiry_idx;
+DROP INDEX IF EXISTS player_bonuses_active_idx;
+DROP INDEX IF EXISTS games_external_game_id_idx;
+ALTER TABLE games DROP COLUMN IF EXISTS wagering_contribution_bps;
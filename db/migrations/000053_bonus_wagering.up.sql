@@ -0,0 +1,13 @@
+-- 000053_bonus_wagering.up.sql
+-- Per-game override of the share of a stake that counts toward bonus
+-- wagering, in basis points. NULL falls back to the game category's weight.
+ALTER TABLE games ADD COLUMN IF NOT EXISTS wagering_contribution_bps integer
+  CHECK (wagering_contribution_bps BETWEEN 0 AND 10000);
+
+CREATE INDEX IF NOT EXISTS games_external_game_id_idx ON games (external_game_id);
+
+-- Active bonuses are looked up per player on every bet and swept on expiry.
+CREATE INDEX IF NOT EXISTS player_bonuses_active_idx ON player_bonuses (player_id, created_at)
+  WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS player_bonuses_expiry_idx ON player_bonuses (expires_at)
+  WHERE status = 'active';
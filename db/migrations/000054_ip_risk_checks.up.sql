@@ -0,0 +1,25 @@
+-- 000054_ip_risk_checks.up.sql
+-- IP screening detections at registration, deposit and bet time that were
+-- flagged for review or blocked. Compliance marks flagged rows reviewed.
+CREATE TABLE IF NOT EXISTS ip_risk_checks (
+  id           uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id    uuid         REFERENCES v2_players(id) ON DELETE SET NULL,
+  reference    varchar(255),
+  checkpoint   varchar(20)  NOT NULL CHECK (checkpoint IN ('registration', 'deposit', 'bet')),
+  ip           varchar(45)  NOT NULL,
+  provider     varchar(30)  NOT NULL,
+  proxy        boolean      NOT NULL,
+  proxy_type   varchar(30),
+  risk         integer      NOT NULL DEFAULT 0,
+  country      varchar(2),
+  verdict      varchar(10)  NOT NULL CHECK (verdict IN ('review', 'block')),
+  reason       varchar(100) NOT NULL,
+  reviewed_by  uuid,
+  reviewed_at  timestamptz,
+  review_note  text,
+  created_at   timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ip_risk_checks_player_idx ON ip_risk_checks (player_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS ip_risk_checks_unreviewed_idx ON ip_risk_checks (created_at DESC)
+  WHERE reviewed_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS free_spin_grants;
+ALTER TABLE bonuses DROP COLUMN IF EXISTS free_spin_games;
+ALTER TABLE bonuses DROP COLUMN IF EXISTS free_spin_value;
+ALTER TABLE bonuses DROP COLUMN IF EXISTS free_spins;
+ALTER TABLE bonuses DROP COLUMN IF EXISTS type;
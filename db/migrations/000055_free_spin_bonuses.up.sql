@@ -0,0 +1,36 @@
+-- 000055_free_spin_bonuses.up.sql
+-- Bonus types. A cash bonus credits max_bonus to the bonus balance on grant;
+-- a free_spins bonus awards free_spins rounds of free_spin_value on the
+-- listed games at the game provider instead.
+ALTER TABLE bonuses ADD COLUMN IF NOT EXISTS type varchar(20) NOT NULL DEFAULT 'cash'
+  CHECK (type IN ('cash', 'free_spins'));
+ALTER TABLE bonuses ADD COLUMN IF NOT EXISTS free_spins integer CHECK (free_spins > 0);
+ALTER TABLE bonuses ADD COLUMN IF NOT EXISTS free_spin_value bigint CHECK (free_spin_value > 0);
+ALTER TABLE bonuses ADD COLUMN IF NOT EXISTS free_spin_games text[];
+
+-- Free rounds awarded to a player. Wins are credited to the bonus balance as
+-- each round is played; once every round is settled the total win becomes a
+-- player bonus with the bonus's wagering requirement. rounds_used counts
+-- rounds started, rounds_settled those whose win has been credited.
+CREATE TABLE IF NOT EXISTS free_spin_grants (
+  id                uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id         uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  bonus_id          uuid         NOT NULL REFERENCES bonuses(id),
+  provider          varchar(30)  NOT NULL,
+  provider_grant_id varchar(100) NOT NULL,
+  game_ids          text[]       NOT NULL,
+  rounds            integer      NOT NULL CHECK (rounds > 0),
+  rounds_used       integer      NOT NULL DEFAULT 0 CHECK (rounds_used BETWEEN 0 AND rounds),
+  rounds_settled    integer      NOT NULL DEFAULT 0 CHECK (rounds_settled BETWEEN 0 AND rounds_used),
+  spin_value        bigint       NOT NULL CHECK (spin_value > 0),
+  total_win         bigint       NOT NULL DEFAULT 0,
+  status            varchar(20)  NOT NULL DEFAULT 'active'
+                    CHECK (status IN ('active', 'completed', 'expired')),
+  player_bonus_id   uuid         REFERENCES player_bonuses(id),
+  granted_by        uuid,
+  expires_at        timestamptz  NOT NULL,
+  created_at        timestamptz  NOT NULL DEFAULT now(),
+  updated_at        timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS free_spin_grants_player_idx ON free_spin_grants (player_id, created_at DESC);
@@ -0,0 +1,3 @@
+DELETE FROM placement_events WHERE player_id IS NULL;
+ALTER TABLE placement_events ALTER COLUMN player_id SET NOT NULL;
+DROP TABLE IF EXISTS consents;
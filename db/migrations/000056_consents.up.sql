@@ -0,0 +1,20 @@
+-- 000056_consents.up.sql
+-- Consent records, one row per change, per purpose and channel. A player's
+-- current consent is their latest row; without one, consent is not given.
+-- Analytics and profiling are not tied to a channel and use 'all'.
+CREATE TABLE IF NOT EXISTS consents (
+  id          bigserial    PRIMARY KEY,
+  player_id   uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  purpose     varchar(20)  NOT NULL CHECK (purpose IN ('marketing', 'analytics', 'profiling')),
+  channel     varchar(20)  NOT NULL CHECK (channel IN ('email', 'sms', 'push', 'phone', 'all')),
+  granted     boolean      NOT NULL,
+  source      varchar(20)  NOT NULL,
+  ip          varchar(45),
+  created_at  timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS consents_current_idx ON consents (player_id, purpose, channel, id DESC);
+
+-- Placement events of players without analytics consent are kept
+-- anonymously so impression and click totals stay whole.
+ALTER TABLE placement_events ALTER COLUMN player_id DROP NOT NULL;
@@ -0,0 +1,7 @@
+UPDATE free_spin_grants SET status = 'expired' WHERE status = 'revoked';
+ALTER TABLE free_spin_grants DROP CONSTRAINT IF EXISTS free_spin_grants_status_check;
+ALTER TABLE free_spin_grants ADD CONSTRAINT free_spin_grants_status_check
+  CHECK (status IN ('active', 'completed', 'expired'));
+
+DROP TABLE IF EXISTS bonus_grant_job_items;
+DROP TABLE IF EXISTS bonus_grant_jobs;
@@ -0,0 +1,42 @@
+-- 000057_bonus_grant_jobs.up.sql
+-- Bulk bonus grants. A job grants one bonus to a segment or a list of players
+-- in the background, one item per player; progress is counted from the items.
+-- Rolling a job back revokes every bonus it granted.
+CREATE TABLE IF NOT EXISTS bonus_grant_jobs (
+  id                uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  bonus_id          uuid         NOT NULL REFERENCES bonuses(id),
+  segment           varchar(30),
+  status            varchar(20)  NOT NULL DEFAULT 'running'
+                    CHECK (status IN ('running', 'completed', 'rolling_back', 'rolled_back')),
+  created_by        uuid,
+  rolled_back_by    uuid,
+  created_at        timestamptz  NOT NULL DEFAULT now(),
+  finished_at       timestamptz,
+  rollback_at       timestamptz,
+  rolled_back_at    timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS bonus_grant_jobs_created_idx ON bonus_grant_jobs (created_at DESC);
+
+-- pending -> granting -> granted | failed, or skipped when the player cannot
+-- be granted at all; granted -> revoking -> revoked | revoke_failed. An item
+-- left granting or revoking was interrupted mid-way.
+CREATE TABLE IF NOT EXISTS bonus_grant_job_items (
+  job_id             uuid         NOT NULL REFERENCES bonus_grant_jobs(id) ON DELETE CASCADE,
+  player_id          uuid         NOT NULL,
+  status             varchar(20)  NOT NULL DEFAULT 'pending'
+                     CHECK (status IN ('pending', 'granting', 'granted', 'skipped', 'failed',
+                                       'revoking', 'revoked', 'revoke_failed')),
+  player_bonus_id    uuid,
+  free_spin_grant_id uuid,
+  error              text,
+  updated_at         timestamptz  NOT NULL DEFAULT now(),
+  PRIMARY KEY (job_id, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS bonus_grant_job_items_status_idx ON bonus_grant_job_items (job_id, status);
+
+-- Revoked free spins can no longer be played.
+ALTER TABLE free_spin_grants DROP CONSTRAINT IF EXISTS free_spin_grants_status_check;
+ALTER TABLE free_spin_grants ADD CONSTRAINT free_spin_grants_status_check
+  CHECK (status IN ('active', 'completed', 'expired', 'revoked'));
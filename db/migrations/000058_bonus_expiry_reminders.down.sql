@@ -0,0 +1 @@
+ALTER TABLE player_bonuses DROP COLUMN IF EXISTS expiry_reminded_at;
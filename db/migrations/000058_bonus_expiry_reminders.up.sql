@@ -0,0 +1,4 @@
+-- 000058_bonus_expiry_reminders.up.sql
+-- When the player was reminded that an active bonus is about to expire, so
+-- each bonus is reminded about once.
+ALTER TABLE player_bonuses ADD COLUMN IF NOT EXISTS expiry_reminded_at timestamptz;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS bonus_opt_ins;
+DROP INDEX IF EXISTS player_bonuses_payment_idx;
+ALTER TABLE player_bonuses DROP COLUMN IF EXISTS payment_id;
+ALTER TABLE bonuses DROP COLUMN IF EXISTS match_percent;
+ALTER TABLE bonuses DROP CONSTRAINT IF EXISTS bonuses_type_check;
+ALTER TABLE bonuses ADD CONSTRAINT bonuses_type_check CHECK (type IN ('cash', 'free_spins'));
@@ -0,0 +1,27 @@
+-- 000059_deposit_match_bonuses.up.sql
+-- Deposit-match bonuses. A player opts in to one, and their next deposit of
+-- at least min_deposit is matched at match_percent, capped at max_bonus, in
+-- the same transaction that credits the deposit.
+ALTER TABLE bonuses DROP CONSTRAINT IF EXISTS bonuses_type_check;
+ALTER TABLE bonuses ADD CONSTRAINT bonuses_type_check
+  CHECK (type IN ('cash', 'free_spins', 'deposit_match'));
+ALTER TABLE bonuses ADD COLUMN IF NOT EXISTS match_percent integer CHECK (match_percent > 0);
+
+-- The deposit a matched player bonus was awarded for.
+ALTER TABLE player_bonuses ADD COLUMN IF NOT EXISTS payment_id uuid REFERENCES payments(id);
+CREATE UNIQUE INDEX IF NOT EXISTS player_bonuses_payment_idx ON player_bonuses (payment_id)
+  WHERE payment_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS bonus_opt_ins (
+  id              uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id       uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  bonus_id        uuid         NOT NULL REFERENCES bonuses(id),
+  status          varchar(20)  NOT NULL DEFAULT 'pending'
+                  CHECK (status IN ('pending', 'claimed', 'cancelled')),
+  player_bonus_id uuid         REFERENCES player_bonuses(id),
+  created_at      timestamptz  NOT NULL DEFAULT now(),
+  updated_at      timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS bonus_opt_ins_pending_idx ON bonus_opt_ins (player_id, bonus_id)
+  WHERE status = 'pending';
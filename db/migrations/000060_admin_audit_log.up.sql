@@ -0,0 +1,22 @@
+-- 000060_admin_audit_log.up.sql
+-- Every authenticated admin API request: who made it, the route it hit, the
+-- player it concerned (for /admin/players/{id} routes) and how it ended.
+-- Aggregated into the admin activity report to spot internal misuse.
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+  id          bigserial    PRIMARY KEY,
+  admin_id    uuid         NOT NULL,
+  admin_email varchar(255) NOT NULL DEFAULT '',
+  role        varchar(20)  NOT NULL DEFAULT '',
+  method      varchar(10)  NOT NULL,
+  route       varchar(200) NOT NULL,
+  path        text         NOT NULL,
+  player_id   uuid,
+  status_code integer      NOT NULL,
+  ip          varchar(64),
+  created_at  timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_log_created_idx ON admin_audit_log (created_at);
+CREATE INDEX IF NOT EXISTS admin_audit_log_admin_idx ON admin_audit_log (admin_id, created_at);
+CREATE INDEX IF NOT EXISTS admin_audit_log_player_idx ON admin_audit_log (player_id, created_at)
+  WHERE player_id IS NOT NULL;
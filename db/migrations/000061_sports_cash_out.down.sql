@@ -0,0 +1 @@
+ALTER TABLE sports_events DROP COLUMN IF EXISTS cash_out_enabled;
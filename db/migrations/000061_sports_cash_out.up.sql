@@ -0,0 +1,6 @@
+-- 000061_sports_cash_out.up.sql
+-- Cash-out: players may settle an open single early at a value priced from
+-- the selection's current odds. The bet moves to status 'cashed_out' with
+-- the value in payout_amount_minor. Traders can switch cash-out off per event.
+ALTER TABLE sports_events
+  ADD COLUMN IF NOT EXISTS cash_out_enabled boolean NOT NULL DEFAULT true;
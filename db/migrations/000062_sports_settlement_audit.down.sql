@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sports_settlement_audit;
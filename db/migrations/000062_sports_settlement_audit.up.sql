@@ -0,0 +1,18 @@
+-- 000062_sports_settlement_audit.up.sql
+-- One row per automatic settlement attempt: the feed and event it came from,
+-- the final score and the raw feed entry it was read from, and either the
+-- settlement summary or the error that stopped it.
+CREATE TABLE IF NOT EXISTS sports_settlement_audit (
+  id                bigserial    PRIMARY KEY,
+  event_id          uuid         NOT NULL REFERENCES sports_events(id) ON DELETE CASCADE,
+  source            varchar(50)  NOT NULL,
+  external_event_id varchar(100) NOT NULL,
+  score_home        integer      NOT NULL,
+  score_away        integer      NOT NULL,
+  source_data       jsonb        NOT NULL,
+  result            jsonb,
+  error             text,
+  created_at        timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS sports_settlement_audit_event_idx ON sports_settlement_audit (event_id, created_at DESC);
@@ -0,0 +1,26 @@
+-- 000063_admin_export_jobs.up.sql
+-- Background exports too large to stream over one HTTP request. A job is
+-- written to object storage in the background; rows_written against
+-- total_rows is its progress, and a completed job's object_key is handed out
+-- as a presigned download link.
+CREATE TABLE IF NOT EXISTS admin_export_jobs (
+  id           uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  admin_id     uuid         NOT NULL,
+  kind         varchar(30)  NOT NULL CHECK (kind IN ('transactions', 'players')),
+  format       varchar(10)  NOT NULL CHECK (format IN ('csv', 'json')),
+  from_at      timestamptz  NOT NULL,
+  to_at        timestamptz  NOT NULL,
+  status       varchar(20)  NOT NULL DEFAULT 'queued'
+                            CHECK (status IN ('queued', 'running', 'completed', 'failed')),
+  total_rows   integer      NOT NULL DEFAULT 0,
+  rows_written integer      NOT NULL DEFAULT 0,
+  object_key   text,
+  error        text,
+  created_at   timestamptz  NOT NULL DEFAULT now(),
+  started_at   timestamptz,
+  finished_at  timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS admin_export_jobs_admin_idx ON admin_export_jobs (admin_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS admin_export_jobs_active_idx ON admin_export_jobs (admin_id)
+  WHERE status IN ('queued', 'running');
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS game_rtp_alerts;
+DROP TABLE IF EXISTS game_rtp_daily;
+ALTER TABLE game_rounds DROP COLUMN IF EXISTS game_id;
@@ -0,0 +1,44 @@
+-- 000064_game_rtp_stats.up.sql
+-- Per-game return-to-player figures aggregated daily from the ledger. Rounds
+-- now record the provider's game id from their first stake so wins can be
+-- attributed to the game. A day whose actual RTP falls outside the
+-- confidence band around the game's theoretical RTP raises an alert.
+
+ALTER TABLE game_rounds ADD COLUMN IF NOT EXISTS game_id varchar(200);
+
+CREATE TABLE IF NOT EXISTS game_rtp_daily (
+  manufacturer_id  varchar(64)   NOT NULL,
+  game_id          varchar(200)  NOT NULL,
+  day              date          NOT NULL,
+  game_name        varchar(200),
+  theoretical_rtp  decimal(5,2),
+  stake_total      bigint        NOT NULL,
+  win_total        bigint        NOT NULL,
+  rounds           integer       NOT NULL,
+  players          integer       NOT NULL,
+  computed_at      timestamptz   NOT NULL DEFAULT now(),
+  PRIMARY KEY (manufacturer_id, game_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS game_rtp_daily_day_idx ON game_rtp_daily (day);
+
+CREATE TABLE IF NOT EXISTS game_rtp_alerts (
+  id               uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+  manufacturer_id  varchar(64)   NOT NULL,
+  game_id          varchar(200)  NOT NULL,
+  day              date          NOT NULL,
+  theoretical_rtp  decimal(5,2)  NOT NULL,
+  actual_rtp       decimal(9,2)  NOT NULL,
+  lower_bound      decimal(9,2)  NOT NULL,
+  upper_bound      decimal(9,2)  NOT NULL,
+  stake_total      bigint        NOT NULL,
+  rounds           integer       NOT NULL,
+  status           varchar(20)   NOT NULL DEFAULT 'open'
+                   CHECK (status IN ('open', 'acknowledged')),
+  acknowledged_by  uuid,
+  acknowledged_at  timestamptz,
+  created_at       timestamptz   NOT NULL DEFAULT now(),
+  UNIQUE (manufacturer_id, game_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS game_rtp_alerts_status_idx ON game_rtp_alerts (status, created_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS sports_bets_open_event_idx;
+ALTER TABLE sports_events DROP COLUMN IF EXISTS max_liability;
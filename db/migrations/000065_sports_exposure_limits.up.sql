@@ -0,0 +1,9 @@
+-- 000065_sports_exposure_limits.up.sql
+-- Per-event override of the worst-case liability limit, in cents. NULL uses
+-- the configured default.
+ALTER TABLE sports_events
+  ADD COLUMN IF NOT EXISTS max_liability bigint CHECK (max_liability >= 0);
+
+-- Exposure is summed over an event's open bets on every placement.
+CREATE INDEX IF NOT EXISTS sports_bets_open_event_idx ON sports_bets (event_id, market_id, selection_id)
+  WHERE status = 'open';
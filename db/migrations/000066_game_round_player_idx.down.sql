@@ -0,0 +1 @@
+DROP INDEX IF EXISTS game_rounds_player_opened_idx;
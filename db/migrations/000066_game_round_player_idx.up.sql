@@ -0,0 +1,6 @@
+-- 000066_game_round_player_idx.up.sql
+-- A player's game rounds by start time, read when assembling their game
+-- session history.
+
+CREATE INDEX IF NOT EXISTS game_rounds_player_opened_idx
+  ON game_rounds (player_id, opened_at DESC);
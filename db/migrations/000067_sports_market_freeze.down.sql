@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS sports_markets_suspended_until_idx;
+ALTER TABLE sports_markets
+  DROP COLUMN IF EXISTS suspension_reason,
+  DROP COLUMN IF EXISTS suspended_until;
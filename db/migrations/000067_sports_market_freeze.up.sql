@@ -0,0 +1,12 @@
+-- 000067_sports_market_freeze.up.sql
+-- Markets the odds feed suspends when their event goes live or a price
+-- swings sharply carry the end of the freeze; they reopen once it passes.
+-- Markets suspended by hand have no end and stay suspended.
+
+ALTER TABLE sports_markets
+  ADD COLUMN IF NOT EXISTS suspended_until   timestamptz,
+  ADD COLUMN IF NOT EXISTS suspension_reason varchar(30);
+
+CREATE INDEX IF NOT EXISTS sports_markets_suspended_until_idx
+  ON sports_markets (suspended_until)
+  WHERE suspended_until IS NOT NULL;
@@ -0,0 +1,13 @@
+-- 000068_game_launches.up.sql
+-- Each time a player opens a game from the lobby, for their recently played
+-- list and game recommendations.
+
+CREATE TABLE IF NOT EXISTS game_launches (
+  id           bigserial    PRIMARY KEY,
+  player_id    uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  game_id      uuid         NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+  launched_at  timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS game_launches_player_idx ON game_launches (player_id, launched_at DESC);
+CREATE INDEX IF NOT EXISTS game_launches_game_idx ON game_launches (game_id, launched_at DESC);
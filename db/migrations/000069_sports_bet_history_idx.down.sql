@@ -0,0 +1 @@
+DROP INDEX IF EXISTS sports_bets_player_placed_idx;
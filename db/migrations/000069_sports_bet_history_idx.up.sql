@@ -0,0 +1,5 @@
+-- 000069_sports_bet_history_idx.up.sql
+-- Keyset order for paging a player's bet history, newest first.
+
+CREATE INDEX IF NOT EXISTS sports_bets_player_placed_idx
+  ON sports_bets (player_id, placed_at DESC, id DESC);
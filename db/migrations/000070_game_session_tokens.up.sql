@@ -0,0 +1,16 @@
+-- 000070_game_session_tokens.up.sql
+-- Tokens issued to the game client at launch. Providers present them on
+-- wallet callbacks, which resolve the player and currency from the session.
+-- Only the SHA-256 hash of a token is stored.
+
+CREATE TABLE IF NOT EXISTS game_session_tokens (
+  token_hash       varchar(64)  PRIMARY KEY,
+  player_id        uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  manufacturer_id  varchar(4)   NOT NULL REFERENCES game_manufacturers(id),
+  game_id          uuid         REFERENCES games(id) ON DELETE SET NULL,
+  currency         varchar(3)   NOT NULL,
+  expires_at       timestamptz  NOT NULL,
+  created_at       timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS game_session_tokens_player_idx ON game_session_tokens (player_id, created_at DESC);
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS sports_bet_legs;
+
+DELETE FROM sports_bets WHERE bet_class = 'system';
+
+ALTER TABLE sports_bets
+  DROP CONSTRAINT IF EXISTS sports_bets_system_chk,
+  DROP CONSTRAINT IF EXISTS sports_bets_bet_class_chk,
+  ALTER COLUMN market_id SET NOT NULL,
+  ALTER COLUMN selection_id SET NOT NULL,
+  DROP COLUMN IF EXISTS num_lines,
+  DROP COLUMN IF EXISTS stake_per_line_minor,
+  DROP COLUMN IF EXISTS system_type,
+  DROP COLUMN IF EXISTS bet_class;
@@ -0,0 +1,35 @@
+-- 000071_sports_bet_classes.up.sql
+-- Bet classes: a single, an each-way single or a system bet (trixie, yankee
+-- and the like) over several selections. Every bet records its stake per
+-- line and number of lines; a system bet's selections are its legs, and the
+-- bet itself has no market or selection.
+
+ALTER TABLE sports_bets
+  ADD COLUMN IF NOT EXISTS bet_class            varchar(20) NOT NULL DEFAULT 'single',
+  ADD COLUMN IF NOT EXISTS system_type          varchar(20),
+  ADD COLUMN IF NOT EXISTS stake_per_line_minor bigint,
+  ADD COLUMN IF NOT EXISTS num_lines            integer     NOT NULL DEFAULT 1;
+
+UPDATE sports_bets SET bet_class = 'each_way', num_lines = 2 WHERE each_way;
+UPDATE sports_bets SET stake_per_line_minor = stake_amount_minor / num_lines WHERE stake_per_line_minor IS NULL;
+
+ALTER TABLE sports_bets
+  ALTER COLUMN stake_per_line_minor SET NOT NULL,
+  ALTER COLUMN market_id DROP NOT NULL,
+  ALTER COLUMN selection_id DROP NOT NULL,
+  ADD CONSTRAINT sports_bets_bet_class_chk CHECK (bet_class IN ('single', 'each_way', 'system')),
+  ADD CONSTRAINT sports_bets_system_chk CHECK (
+    (bet_class = 'system') = (system_type IS NOT NULL)
+    AND (bet_class = 'system' OR (market_id IS NOT NULL AND selection_id IS NOT NULL)));
+
+CREATE TABLE IF NOT EXISTS sports_bet_legs (
+  bet_id             uuid     NOT NULL REFERENCES sports_bets(id) ON DELETE CASCADE,
+  leg_no             integer  NOT NULL,
+  event_id           uuid     NOT NULL REFERENCES sports_events(id) ON DELETE CASCADE,
+  market_id          uuid     NOT NULL REFERENCES sports_markets(id) ON DELETE CASCADE,
+  selection_id       uuid     NOT NULL REFERENCES sports_selections(id) ON DELETE CASCADE,
+  odds_at_placement  integer  NOT NULL,
+  PRIMARY KEY (bet_id, leg_no)
+);
+
+CREATE INDEX IF NOT EXISTS sports_bet_legs_event_idx ON sports_bet_legs (event_id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS manufacturers_notify ON manufacturers;
+DROP FUNCTION IF EXISTS notify_manufacturers_changed();
+DROP TABLE IF EXISTS manufacturers;
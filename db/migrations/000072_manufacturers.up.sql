@@ -0,0 +1,33 @@
+-- 000072_manufacturers.up.sql
+-- Wallet provider registry. When it has rows, the wallet server mounts these
+-- providers instead of the WALLET_PROVIDERS list. Secrets stay in the
+-- environment: secret_ref names the variable holding one. Changes notify the
+-- wallet server, which reloads enablement and allowed actions without a restart.
+
+CREATE TABLE IF NOT EXISTS manufacturers (
+  id               varchar(50)  PRIMARY KEY,
+  kind             varchar(50)  NOT NULL,
+  display_name     varchar(100) NOT NULL,
+  prefix           varchar(100) NOT NULL UNIQUE,
+  secret_ref       varchar(100),
+  enabled          boolean      NOT NULL DEFAULT true,
+  money_format     varchar(10)  NOT NULL DEFAULT 'decimal'
+                   CHECK (money_format IN ('cents', 'decimal')),
+  allowed_actions  text[]       NOT NULL DEFAULT '{}', -- empty allows every action
+  disabled_reason  text,
+  updated_by       uuid,
+  created_at       timestamptz  NOT NULL DEFAULT now(),
+  updated_at       timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE OR REPLACE FUNCTION notify_manufacturers_changed() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('manufacturers_changed', '');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS manufacturers_notify ON manufacturers;
+CREATE TRIGGER manufacturers_notify
+  AFTER INSERT OR UPDATE OR DELETE ON manufacturers
+  FOR EACH STATEMENT EXECUTE FUNCTION notify_manufacturers_changed();
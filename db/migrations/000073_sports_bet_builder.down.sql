@@ -0,0 +1,11 @@
+DELETE FROM sports_bets WHERE bet_class = 'bet_builder';
+
+ALTER TABLE sports_bets
+  DROP CONSTRAINT IF EXISTS sports_bets_bet_class_chk,
+  DROP CONSTRAINT IF EXISTS sports_bets_system_chk;
+
+ALTER TABLE sports_bets
+  ADD CONSTRAINT sports_bets_bet_class_chk CHECK (bet_class IN ('single', 'each_way', 'system')),
+  ADD CONSTRAINT sports_bets_system_chk CHECK (
+    (bet_class = 'system') = (system_type IS NOT NULL)
+    AND (bet_class = 'system' OR (market_id IS NOT NULL AND selection_id IS NOT NULL)));
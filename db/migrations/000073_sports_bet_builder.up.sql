@@ -0,0 +1,15 @@
+-- 000073_sports_bet_builder.up.sql
+-- Bet builders: several markets of one event combined into a single bet at a
+-- correlated price. Like a system bet its selections are its legs; unlike one
+-- it belongs to the event it is built on.
+
+ALTER TABLE sports_bets
+  DROP CONSTRAINT IF EXISTS sports_bets_bet_class_chk,
+  DROP CONSTRAINT IF EXISTS sports_bets_system_chk;
+
+ALTER TABLE sports_bets
+  ADD CONSTRAINT sports_bets_bet_class_chk CHECK (bet_class IN ('single', 'each_way', 'system', 'bet_builder')),
+  ADD CONSTRAINT sports_bets_system_chk CHECK (
+    (bet_class = 'system') = (system_type IS NOT NULL)
+    AND (bet_class IN ('system', 'bet_builder') OR (market_id IS NOT NULL AND selection_id IS NOT NULL))
+    AND (bet_class <> 'bet_builder' OR event_id IS NOT NULL));
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_prediction_stakes_active_market;
+
+ALTER TABLE prediction_stakes
+  DROP COLUMN IF EXISTS transaction_id,
+  DROP COLUMN IF EXISTS odds_at_placement;
+
+ALTER TABLE prediction_markets
+  ALTER COLUMN winning_outcome_id TYPE uuid USING winning_outcome_id::uuid;
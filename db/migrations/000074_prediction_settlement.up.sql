@@ -0,0 +1,15 @@
+-- 000074_prediction_settlement.up.sql
+-- Prediction stake payouts: stakes lock their outcome's odds when placed and
+-- are paid, lost or refunded once their market is settled or voided. Outcome
+-- IDs are free-form (house markets use "yes"/"no"), so the winning outcome is
+-- stored as text like the stakes' own outcome_id.
+
+ALTER TABLE prediction_markets
+  ALTER COLUMN winning_outcome_id TYPE varchar(100) USING winning_outcome_id::text;
+
+ALTER TABLE prediction_stakes
+  ADD COLUMN odds_at_placement numeric(10,3),
+  ADD COLUMN transaction_id    uuid;
+
+CREATE INDEX idx_prediction_stakes_active_market
+  ON prediction_stakes (market_id) WHERE status = 'active';
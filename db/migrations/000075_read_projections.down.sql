@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS projected_events;
+DROP TABLE IF EXISTS bet_feed;
+DROP TABLE IF EXISTS daily_kpis;
+DROP TABLE IF EXISTS player_summary;
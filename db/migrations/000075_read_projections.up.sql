@@ -0,0 +1,113 @@
+-- 000075_read_projections.up.sql
+-- Denormalized read models kept up to date from outbox events by the outbox
+-- consumer, so the admin dashboard and home screen read a row instead of
+-- aggregating the ledger. Totals are gross: cancellations are not netted
+-- off. projected_events records the events already applied, so redelivered
+-- events are not counted twice.
+
+CREATE TABLE IF NOT EXISTS player_summary (
+  player_id         uuid         PRIMARY KEY,
+  email             varchar(255),
+  currency          varchar(3),
+  registered_at     timestamptz,
+  deposit_count     integer      NOT NULL DEFAULT 0,
+  deposit_total     bigint       NOT NULL DEFAULT 0,
+  withdrawal_total  bigint       NOT NULL DEFAULT 0,
+  bet_count         integer      NOT NULL DEFAULT 0,
+  stake_total       bigint       NOT NULL DEFAULT 0,
+  win_total         bigint       NOT NULL DEFAULT 0,
+  bonus_total       bigint       NOT NULL DEFAULT 0,
+  last_activity_at  timestamptz,
+  updated_at        timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_summary_last_activity
+  ON player_summary (last_activity_at);
+
+CREATE TABLE IF NOT EXISTS daily_kpis (
+  day               date         NOT NULL,
+  currency          varchar(3)   NOT NULL,
+  registrations     integer      NOT NULL DEFAULT 0,
+  deposit_count     integer      NOT NULL DEFAULT 0,
+  deposit_total     bigint       NOT NULL DEFAULT 0,
+  withdrawal_total  bigint       NOT NULL DEFAULT 0,
+  bet_count         integer      NOT NULL DEFAULT 0,
+  stake_total       bigint       NOT NULL DEFAULT 0,
+  win_total         bigint       NOT NULL DEFAULT 0,
+  bonus_total       bigint       NOT NULL DEFAULT 0,
+  updated_at        timestamptz  NOT NULL DEFAULT now(),
+  PRIMARY KEY (day, currency)
+);
+
+CREATE TABLE IF NOT EXISTS bet_feed (
+  id            bigserial    PRIMARY KEY,
+  kind          varchar(20)  NOT NULL CHECK (kind IN ('sportsbook', 'prediction')),
+  reference_id  uuid         NOT NULL, -- sports_bets.id or prediction_stakes.id
+  player_id     uuid         NOT NULL,
+  stake         bigint       NOT NULL DEFAULT 0,
+  status        varchar(20)  NOT NULL,
+  returned      bigint       NOT NULL DEFAULT 0,
+  settled_at    timestamptz  NOT NULL,
+  UNIQUE (kind, reference_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bet_feed_settled ON bet_feed (settled_at DESC);
+
+CREATE TABLE IF NOT EXISTS projected_events (
+  event_id      uuid         PRIMARY KEY,
+  projected_at  timestamptz  NOT NULL DEFAULT now()
+);
+
+-- Backfill from the ledger. Events still waiting in the outbox are already
+-- counted here, so they are marked projected.
+INSERT INTO player_summary (player_id, email, currency, registered_at, deposit_count, deposit_total,
+                            withdrawal_total, bet_count, stake_total, win_total, bonus_total, last_activity_at)
+SELECT p.id, pp.email, p.currency, p.created_at,
+       COALESCE(t.deposit_count, 0), COALESCE(t.deposit_total, 0), COALESCE(t.withdrawal_total, 0),
+       COALESCE(t.bet_count, 0), COALESCE(t.stake_total, 0), COALESCE(t.win_total, 0),
+       COALESCE(t.bonus_total, 0), t.last_activity_at
+FROM v2_players p
+LEFT JOIN player_profiles pp ON pp.player_id = p.id
+LEFT JOIN (
+  SELECT player_id,
+         COUNT(*) FILTER (WHERE type = 'wallet_deposit') AS deposit_count,
+         SUM(amount) FILTER (WHERE type = 'wallet_deposit') AS deposit_total,
+         SUM(amount) FILTER (WHERE type = 'wallet_withdrawal_processed') AS withdrawal_total,
+         COUNT(*) FILTER (WHERE type = 'bet') AS bet_count,
+         SUM(amount) FILTER (WHERE type = 'bet') AS stake_total,
+         SUM(amount) FILTER (WHERE type = 'win') AS win_total,
+         SUM(amount) FILTER (WHERE type = 'bonus_credit') AS bonus_total,
+         MAX(created_at) AS last_activity_at
+  FROM v2_transactions
+  GROUP BY player_id
+) t ON t.player_id = p.id
+ON CONFLICT (player_id) DO NOTHING;
+
+INSERT INTO daily_kpis (day, currency, registrations)
+SELECT (created_at AT TIME ZONE 'UTC')::date, currency, COUNT(*)
+FROM v2_players
+GROUP BY 1, 2
+ON CONFLICT (day, currency) DO NOTHING;
+
+INSERT INTO daily_kpis (day, currency, deposit_count, deposit_total, withdrawal_total,
+                        bet_count, stake_total, win_total, bonus_total)
+SELECT (t.created_at AT TIME ZONE 'UTC')::date, COALESCE(t.currency, p.currency),
+       COUNT(*) FILTER (WHERE t.type = 'wallet_deposit'),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'wallet_deposit'), 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'wallet_withdrawal_processed'), 0),
+       COUNT(*) FILTER (WHERE t.type = 'bet'),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'bet'), 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'win'), 0),
+       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'bonus_credit'), 0)
+FROM v2_transactions t
+JOIN v2_players p ON p.id = t.player_id
+GROUP BY 1, 2
+ON CONFLICT (day, currency) DO UPDATE
+SET deposit_count = EXCLUDED.deposit_count, deposit_total = EXCLUDED.deposit_total,
+    withdrawal_total = EXCLUDED.withdrawal_total, bet_count = EXCLUDED.bet_count,
+    stake_total = EXCLUDED.stake_total, win_total = EXCLUDED.win_total,
+    bonus_total = EXCLUDED.bonus_total;
+
+INSERT INTO projected_events (event_id)
+SELECT "eventId" FROM event_outbox
+ON CONFLICT DO NOTHING;
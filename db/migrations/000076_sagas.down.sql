@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS saga_step_log;
+DROP TABLE IF EXISTS sagas;
@@ -0,0 +1,41 @@
+-- 000076_sagas.up.sql
+-- Sagas: multi-step money flows (withdrawal payouts first) whose steps span
+-- the ledger and external systems. A saga's progress is stored after every
+-- step so a crashed or failed run resumes, or compensates its completed
+-- steps, from where it stopped. locked_until is the lease of the runner
+-- currently advancing it.
+
+CREATE TABLE sagas (
+  id              uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  kind            varchar(50)  NOT NULL,
+  reference       varchar(200) NOT NULL,
+  status          varchar(20)  NOT NULL DEFAULT 'running',
+  step            int          NOT NULL DEFAULT 0,
+  attempts        int          NOT NULL DEFAULT 0,
+  data            jsonb        NOT NULL DEFAULT '{}',
+  last_error      text,
+  next_attempt_at timestamptz  NOT NULL DEFAULT now(),
+  locked_until    timestamptz,
+  created_at      timestamptz  NOT NULL DEFAULT now(),
+  updated_at      timestamptz  NOT NULL DEFAULT now(),
+  CONSTRAINT sagas_kind_reference_key UNIQUE (kind, reference),
+  CONSTRAINT sagas_status_check
+    CHECK (status IN ('running', 'completed', 'compensating', 'compensated', 'failed'))
+);
+
+CREATE INDEX idx_sagas_due ON sagas (next_attempt_at)
+  WHERE status IN ('running', 'compensating');
+CREATE INDEX idx_sagas_status ON sagas (status, updated_at DESC);
+
+CREATE TABLE saga_step_log (
+  id         bigserial    PRIMARY KEY,
+  saga_id    uuid         NOT NULL REFERENCES sagas(id) ON DELETE CASCADE,
+  step       int          NOT NULL,
+  name       varchar(100) NOT NULL,
+  phase      varchar(20)  NOT NULL CHECK (phase IN ('action', 'compensation')),
+  succeeded  boolean      NOT NULL,
+  error      text,
+  created_at timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_saga_step_log_saga ON saga_step_log (saga_id, id);
@@ -0,0 +1,8 @@
+ALTER TABLE prediction_markets
+  DROP CONSTRAINT IF EXISTS prediction_markets_pricing_check,
+  DROP COLUMN IF EXISTS amm_collected,
+  DROP COLUMN IF EXISTS amm_shares,
+  DROP COLUMN IF EXISTS amm_seed,
+  DROP COLUMN IF EXISTS amm_exposure_cap,
+  DROP COLUMN IF EXISTS amm_liquidity,
+  DROP COLUMN IF EXISTS pricing;
@@ -0,0 +1,18 @@
+-- 000077_prediction_amm.up.sql
+-- Market-maker pricing for house prediction markets. An 'lmsr' market's odds
+-- move with the stakes placed on it; 'fixed' markets keep the odds in their
+-- outcomes, and Dome markets always do. Shares are the potential payout
+-- issued per outcome (outcome ID → cents); the seed holds the virtual shares
+-- that open the market at its starting odds and is filled from the outcomes
+-- on the first stake.
+
+ALTER TABLE prediction_markets
+  ADD COLUMN pricing          varchar(10)   NOT NULL DEFAULT 'fixed',
+  ADD COLUMN amm_liquidity    numeric(15,0),
+  ADD COLUMN amm_exposure_cap numeric(15,0),
+  ADD COLUMN amm_seed         jsonb         NOT NULL DEFAULT '{}',
+  ADD COLUMN amm_shares       jsonb         NOT NULL DEFAULT '{}',
+  ADD COLUMN amm_collected    numeric(15,0) NOT NULL DEFAULT 0,
+  ADD CONSTRAINT prediction_markets_pricing_check CHECK (
+    pricing = 'fixed'
+    OR (pricing = 'lmsr' AND dome_platform IS NULL AND amm_liquidity > 0));
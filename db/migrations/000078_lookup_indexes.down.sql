@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_provider_callbacks_round_id;
+DROP INDEX IF EXISTS idx_provider_callbacks_reference_id;
+DROP INDEX IF EXISTS idx_provider_callbacks_transaction_id;
+DROP INDEX IF EXISTS idx_sports_bets_transaction;
+DROP INDEX IF EXISTS idx_payments_transaction;
+DROP INDEX IF EXISTS idx_payments_provider_session_id;
+DROP INDEX IF EXISTS idx_payments_provider_payment_id;
+DROP INDEX IF EXISTS idx_payments_external_id;
+DROP INDEX IF EXISTS idx_v2_transactions_target;
+DROP INDEX IF EXISTS idx_v2_transactions_external_id;
@@ -0,0 +1,24 @@
+-- 000078_lookup_indexes.up.sql
+-- Support lookups search by external and provider IDs without knowing the
+-- player, so those columns need indexes of their own.
+
+CREATE INDEX IF NOT EXISTS idx_v2_transactions_external_id
+  ON v2_transactions (external_transaction_id) WHERE external_transaction_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_v2_transactions_target
+  ON v2_transactions (target_transaction_id) WHERE target_transaction_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_external_id
+  ON payments (external_transaction_id) WHERE external_transaction_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_provider_payment_id
+  ON payments (provider_payment_id) WHERE provider_payment_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_provider_session_id
+  ON payments (provider_session_id) WHERE provider_session_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_transaction
+  ON payments (transaction_id) WHERE transaction_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sports_bets_transaction
+  ON sports_bets (transaction_id) WHERE transaction_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_provider_callbacks_transaction_id ON provider_callbacks (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_provider_callbacks_reference_id ON provider_callbacks (reference_id);
+CREATE INDEX IF NOT EXISTS idx_provider_callbacks_round_id ON provider_callbacks (round_id);
@@ -0,0 +1 @@
+ALTER TABLE prediction_stakes DROP COLUMN IF EXISTS realized_pnl_minor;
@@ -0,0 +1,14 @@
+-- 000079_prediction_position_sales.up.sql
+-- Players can sell an active prediction stake back before its market settles.
+-- A sold stake keeps its sale proceeds in payout_amount_minor; every closed
+-- stake records its realized profit or loss against the amount staked.
+
+ALTER TABLE prediction_stakes
+  ADD COLUMN realized_pnl_minor bigint;
+
+UPDATE prediction_stakes
+SET realized_pnl_minor = CASE status
+  WHEN 'void' THEN 0
+  ELSE COALESCE(payout_amount_minor, 0) - stake_amount_minor
+END
+WHERE status IN ('won', 'lost', 'void');
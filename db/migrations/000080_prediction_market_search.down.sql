@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_prediction_markets_search;
+DROP INDEX IF EXISTS idx_prediction_markets_tags;
+DROP INDEX IF EXISTS idx_prediction_markets_category;
+DROP INDEX IF EXISTS idx_prediction_markets_status_volume;
+DROP INDEX IF EXISTS idx_prediction_markets_status_close;
+DROP INDEX IF EXISTS idx_prediction_markets_status_created;
+
+ALTER TABLE prediction_markets
+  DROP COLUMN IF EXISTS search,
+  DROP COLUMN IF EXISTS volume_minor;
@@ -0,0 +1,24 @@
+-- 000080_prediction_market_search.up.sql
+-- Filtering, search and sorting for the player market list, which Dome sync
+-- can fill with thousands of markets. volume_minor is the amount staked on a
+-- house market; a Dome market carries its source platform's traded volume,
+-- refreshed on every sync. search is the title and description for text
+-- search.
+
+ALTER TABLE prediction_markets
+  ADD COLUMN volume_minor bigint NOT NULL DEFAULT 0,
+  ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple', title || ' ' || COALESCE(description, ''))) STORED;
+
+UPDATE prediction_markets pm
+SET volume_minor = COALESCE(
+  round((pm.dome_metadata->>'volume_total')::numeric * 100),
+  (SELECT SUM(ps.stake_amount_minor) FROM prediction_stakes ps WHERE ps.market_id = pm.id),
+  0);
+
+CREATE INDEX idx_prediction_markets_status_created ON prediction_markets (status, created_at DESC);
+CREATE INDEX idx_prediction_markets_status_close ON prediction_markets (status, close_at);
+CREATE INDEX idx_prediction_markets_status_volume ON prediction_markets (status, volume_minor DESC);
+CREATE INDEX idx_prediction_markets_category ON prediction_markets (category, status);
+CREATE INDEX idx_prediction_markets_tags ON prediction_markets USING gin (tags jsonb_path_ops);
+CREATE INDEX idx_prediction_markets_search ON prediction_markets USING gin (search);
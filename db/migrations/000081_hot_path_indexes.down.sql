@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_idempotency_keys_player_created;
+DROP INDEX IF EXISTS idx_v2_transactions_player_type_created;
+CREATE INDEX IF NOT EXISTS v2_transactions_player_created_idx
+  ON v2_transactions (player_id, created_at);
+DROP INDEX IF EXISTS idx_v2_transactions_player_history;
@@ -0,0 +1,16 @@
+-- 000081_hot_path_indexes.up.sql
+-- Indexes shaped to the hot ledger queries, so their prepared plans read
+-- rows in index order instead of sorting or filtering heap rows:
+--   * transaction history pages by (created_at, id) descending per player;
+--   * daily limits sum one transaction type per player since midnight;
+--   * every idempotency key claim first purges the player's expired keys.
+
+CREATE INDEX IF NOT EXISTS idx_v2_transactions_player_history
+  ON v2_transactions (player_id, created_at DESC, id DESC);
+DROP INDEX IF EXISTS v2_transactions_player_created_idx;
+
+CREATE INDEX IF NOT EXISTS idx_v2_transactions_player_type_created
+  ON v2_transactions (player_id, type, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_player_created
+  ON idempotency_keys (player_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS quest_progress_events;
+DROP INDEX IF EXISTS idx_quests_active_type;
+ALTER TABLE quests DROP COLUMN IF EXISTS criteria;
@@ -0,0 +1,15 @@
+-- 000082_quest_progress_automation.up.sql
+-- Quests of the automated types (bet_count, deposit_volume, engagement, ...)
+-- advance from outbox events. criteria narrows which events count: a
+-- minimum amount, the manufacturers a bet or win must come from, or the
+-- engagement signal. quest_progress_events records the events already
+-- applied, so a redelivered event does not advance a quest twice.
+
+ALTER TABLE quests ADD COLUMN IF NOT EXISTS criteria jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_quests_active_type ON quests (type) WHERE active;
+
+CREATE TABLE IF NOT EXISTS quest_progress_events (
+  event_id      uuid         PRIMARY KEY,
+  applied_at    timestamptz  NOT NULL DEFAULT now()
+);
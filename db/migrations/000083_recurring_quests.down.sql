@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS player_quest_history;
+DROP INDEX IF EXISTS idx_quests_next_reset;
+ALTER TABLE quests
+  DROP COLUMN IF EXISTS next_reset_at,
+  DROP COLUMN IF EXISTS recurrence_cron,
+  DROP COLUMN IF EXISTS recurrence;
@@ -0,0 +1,27 @@
+-- 000083_recurring_quests.up.sql
+-- Recurring quests reset on schedule: daily, weekly or on a cron schedule,
+-- in UTC. next_reset_at is when the quest's current period ends; the reset
+-- scheduler archives every player's progress for the period into
+-- player_quest_history, claimed or expired, and clears it so the quest
+-- starts over.
+
+ALTER TABLE quests
+  ADD COLUMN IF NOT EXISTS recurrence      varchar(10)  NOT NULL DEFAULT 'none',
+  ADD COLUMN IF NOT EXISTS recurrence_cron varchar(100),
+  ADD COLUMN IF NOT EXISTS next_reset_at   timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_quests_next_reset ON quests (next_reset_at) WHERE next_reset_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS player_quest_history (
+  id            uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_id     uuid         NOT NULL REFERENCES v2_players(id) ON DELETE CASCADE,
+  quest_id      uuid         NOT NULL REFERENCES quests(id) ON DELETE CASCADE,
+  period_end    timestamptz  NOT NULL,
+  progress      integer      NOT NULL,
+  status        varchar(30)  NOT NULL CHECK (status IN ('claimed', 'expired')),
+  completed_at  timestamp,
+  claimed_at    timestamp,
+  archived_at   timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_quest_history_player ON player_quest_history (player_id, period_end DESC);
@@ -0,0 +1,15 @@
+-- 000084_quest_prerequisites.up.sql
+-- Quest chains: a quest with prerequisites stays locked for a player until
+-- they have claimed every prerequisite, in its current period or, for a
+-- recurring quest, an earlier one. Edges point from a quest to the quests
+-- it requires; the admin API keeps the graph acyclic.
+
+CREATE TABLE IF NOT EXISTS quest_prerequisites (
+  quest_id         uuid         NOT NULL REFERENCES quests(id) ON DELETE CASCADE,
+  prerequisite_id  uuid         NOT NULL REFERENCES quests(id) ON DELETE CASCADE,
+  created_at       timestamptz  NOT NULL DEFAULT now(),
+  PRIMARY KEY (quest_id, prerequisite_id),
+  CHECK (quest_id <> prerequisite_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quest_prerequisites_prerequisite ON quest_prerequisites (prerequisite_id);
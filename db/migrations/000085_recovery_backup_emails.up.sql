@@ -0,0 +1,15 @@
+-- 000085_recovery_backup_emails.up.sql
+-- Account recovery delivers its reset token to a backup email the player
+-- registered and verified while signed in, never to an address typed into
+-- the recovery form. Only the hash of the pending verification token is
+-- kept; changing the address clears the verification.
+
+CREATE TABLE IF NOT EXISTS player_backup_emails (
+  player_id                uuid         PRIMARY KEY REFERENCES v2_players(id) ON DELETE CASCADE,
+  email                    citext       NOT NULL,
+  verified_at              timestamptz,
+  verification_token_hash  text,
+  verification_expires_at  timestamptz,
+  created_at               timestamptz  NOT NULL DEFAULT now(),
+  updated_at               timestamptz  NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+ALTER TABLE admin_export_jobs DROP COLUMN IF EXISTS locked_until;
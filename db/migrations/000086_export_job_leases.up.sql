@@ -0,0 +1,10 @@
+-- 000086_export_job_leases.up.sql
+-- The replica running an export job holds a lease on it, renewed while the
+-- job runs. Only a job whose lease has lapsed, because its replica stopped,
+-- is failed as interrupted; jobs other replicas are running are left alone.
+-- Jobs already in flight get one lease's grace.
+
+ALTER TABLE admin_export_jobs ADD COLUMN IF NOT EXISTS locked_until timestamptz;
+
+UPDATE admin_export_jobs SET locked_until = now() + interval '2 minutes'
+WHERE status IN ('queued', 'running');
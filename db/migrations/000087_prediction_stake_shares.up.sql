@@ -0,0 +1,13 @@
+-- 000087_prediction_stake_shares.up.sql
+-- A prediction stake records the shares it was issued, which is what it pays
+-- if its outcome wins and what a sale sells back to a market maker's book.
+-- Stakes placed before this recorded only their odds, so their shares are
+-- the payout those odds gave.
+
+ALTER TABLE prediction_stakes ADD COLUMN IF NOT EXISTS shares bigint;
+
+UPDATE prediction_stakes
+SET shares = floor(stake_amount_minor * COALESCE(odds_at_placement, 0))::bigint
+WHERE shares IS NULL;
+
+ALTER TABLE prediction_stakes ALTER COLUMN shares SET NOT NULL;
@@ -2,16 +2,22 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/attaboy/platform/internal/auth"
 	"github.com/attaboy/platform/internal/guard"
 	"github.com/attaboy/platform/internal/handler"
 	adminhandler "github.com/attaboy/platform/internal/handler/admin"
+	"github.com/attaboy/platform/internal/infra"
 	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/projection"
 	"github.com/attaboy/platform/internal/provider"
 	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/saga"
 	"github.com/attaboy/platform/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -25,12 +31,114 @@ type RouterDeps struct {
 	// External provider config
 	StripeSecretKey     string
 	StripeWebhookSecret string
+	AdyenAPIKey         string
+	AdyenMerchant       string
+	AdyenHMACKey        string
+	AdyenCheckoutURL    string
+	AdyenPayoutURL      string
 	RandomOrgAPIKey     string
 	SlotopolBaseURL     string
 	CORSAllowedOrigins  string
+	CORSBrandOrigins    string
+	CORSAdminOrigins    string
+	CORSMaxAge          int
 	DomeBaseURL         string
 	DomeAPIKey          string
 	OddsAPIKey          string
+	CaptchaProvider     string
+	CaptchaSecretKey    string
+	CaptchaBrands       string
+	GraphQLEnabled      bool
+	WalletCurrencies    string
+	SOFThresholds       string
+	NetLossRules        string
+	ReceiptSigningKey   string
+	// P2PTransfers configures player-to-player transfers; the zero value
+	// leaves them disabled.
+	P2PTransfers policy.TransferRules
+	// Sweepstakes switches the deployment to GC/SC play; the zero value is
+	// a regular real-money deployment.
+	Sweepstakes policy.SweepsRules
+	// ClosedLoopPayouts requires withdrawals to target a verified saved
+	// payment method already used for a deposit.
+	ClosedLoopPayouts bool
+	// KYCStorage is the S3-compatible bucket for KYC documents; uploads
+	// fail until it is configured.
+	KYCStorage provider.S3Config
+	// KYCThreshold is the withdrawal amount in cents above which players
+	// must be KYC verified; 0 disables the check.
+	KYCThreshold int64
+	// ExportStorage is the S3-compatible bucket admin export jobs write to;
+	// jobs are refused until it is configured.
+	ExportStorage provider.S3Config
+	// MaxExportsPerAdmin caps each admin's queued and running export jobs.
+	MaxExportsPerAdmin int
+	// Retention sets the data retention periods; zero fields use the
+	// defaults.
+	Retention policy.RetentionPeriods
+	// SportsbookExposure caps open sportsbook liability; zero limits are not
+	// enforced.
+	SportsbookExposure policy.ExposureLimits
+	// InPlaySuspension freezes feed-priced markets when their event goes
+	// live or a price swings; the zero value never freezes.
+	InPlaySuspension policy.InPlaySuspension
+	// RTPBands sets when a game's daily RTP is alerted on; the zero value
+	// uses the defaults.
+	RTPBands policy.RTPBandRules
+	// IPIntel configures the VPN/proxy detection provider; screening is off
+	// until an API key is set.
+	IPIntel provider.ProxyCheckConfig
+	// IPRisk sets the screening verdicts at registration, deposit and bet.
+	IPRisk policy.IPRiskRules
+	// Cache holds short-lived per-player data such as the lobby's game
+	// lists; nil uses an in-process store.
+	Cache projection.Store
+	// RequestBudgets bound each request's context; zero leaves requests
+	// unbounded.
+	RequestBudgets handler.DeadlineBudgets
+	// GameSessionTTL is how long a game launch's session token accepts new
+	// stakes; zero uses the service default.
+	GameSessionTTL time.Duration
+}
+
+// corsConfig builds the CORS policy. Invalid brand overrides are logged and
+// ignored, leaving the default origins in force.
+func corsConfig(deps RouterDeps) handler.CORSConfig {
+	cfg := handler.CORSConfig{
+		Origins:      handler.ParseOriginList(deps.CORSAllowedOrigins),
+		AdminOrigins: handler.ParseOriginList(deps.CORSAdminOrigins),
+		MaxAge:       deps.CORSMaxAge,
+	}
+	if deps.CORSBrandOrigins != "" {
+		brands, err := handler.ParseBrandOrigins(deps.CORSBrandOrigins)
+		if err != nil {
+			deps.Logger.Warn("cors brand origins ignored", "error", err)
+		} else {
+			cfg.BrandOrigins = brands
+		}
+	}
+	return cfg
+}
+
+// receiptSigningKey returns the bet receipt HMAC key. Without a configured key
+// a random one is used, so receipts only verify until the process restarts.
+func receiptSigningKey(deps RouterDeps) []byte {
+	if deps.ReceiptSigningKey != "" {
+		return []byte(deps.ReceiptSigningKey)
+	}
+	deps.Logger.Warn("RECEIPT_SIGNING_KEY not set; bet receipts are signed with an ephemeral key")
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+// cacheStore returns the configured cache, or an in-process store when none
+// is set.
+func cacheStore(deps RouterDeps) projection.Store {
+	if deps.Cache != nil {
+		return deps.Cache
+	}
+	return projection.NewInMemoryStore()
 }
 
 // NewRouter assembles the chi.Router with all routes and middleware.
@@ -42,63 +150,192 @@ func NewRouter(deps RouterDeps) chi.Router {
 	// Repositories
 	playerRepo := repository.NewPlayerRepository()
 	txRepo := repository.NewTransactionRepository()
+	ledgerEntryRepo := repository.NewLedgerEntryRepository()
 	outboxRepo := repository.NewOutboxRepository()
+	walletRepo := repository.NewWalletRepository()
+	gameRoundRepo := repository.NewGameRoundRepository()
+	bonusRepo := repository.NewBonusRepository()
 	authUserRepo := repository.NewPgAuthUserRepository()
 	profileRepo := repository.NewPgProfileRepository()
 	paymentRepo := repository.NewPaymentRepository()
+	consentRepo := repository.NewConsentRepository()
 
 	// Ledger engine
-	ledgerEngine := ledger.NewEngine(playerRepo, txRepo, outboxRepo)
+	ledgerEngine := ledger.NewEngine(playerRepo, walletRepo, txRepo, ledgerEntryRepo, outboxRepo, gameRoundRepo, bonusRepo)
 
 	// External providers
 	stripeProvider := provider.NewStripeProvider(deps.StripeSecretKey, deps.StripeWebhookSecret)
+	adyenProvider := provider.NewAdyenProvider(deps.AdyenAPIKey, deps.AdyenMerchant, deps.AdyenHMACKey, deps.AdyenCheckoutURL)
+	var adyenPayouts *provider.AdyenPayoutProvider
+	if deps.AdyenPayoutURL != "" {
+		adyenPayouts = provider.NewAdyenPayoutProvider(deps.AdyenAPIKey, deps.AdyenMerchant, deps.AdyenPayoutURL)
+	}
 	rngClient := provider.NewRandomOrgClient(deps.RandomOrgAPIKey, logger)
 	slotopolClient := provider.NewSlotopolClient(deps.SlotopolBaseURL, logger)
 
+	// Background connector loops report here for the /health/workers probe
+	workerHealth := infra.NewWorkerHealth()
+
 	// Dome prediction feed — start sync if configured
+	var feedResyncer service.FeedResyncer
 	if deps.DomeBaseURL != "" && deps.DomeAPIKey != "" {
 		domeConnector := provider.NewDomeConnector(pool, deps.DomeBaseURL, deps.DomeAPIKey, logger)
-		domeConnector.StartMarketSync(context.Background())
+		domeConnector.StartMarketSync(context.Background(), workerHealth)
+		feedResyncer = domeConnector
 	}
 
-	// The Odds API — live sportsbook odds sync
+	// Services
+	captchaGate := service.NewCaptchaGate(pool, deps.CaptchaProvider, deps.CaptchaSecretKey, deps.CaptchaBrands, logger)
+	var ipIntel provider.IPIntelligence
+	if proxyCheck := provider.NewProxyCheckClient(deps.IPIntel); proxyCheck.Configured() {
+		ipIntel = proxyCheck
+	}
+	ipRiskSvc := service.NewIPRiskService(pool, ipIntel, deps.IPRisk, logger)
+	authSvc := service.NewAuthService(pool, authUserRepo, playerRepo, profileRepo, outboxRepo, jwtMgr, captchaGate, ipRiskSvc)
+	bonusSvc := service.NewBonusService(pool, ledgerEngine, bonusRepo, outboxRepo, slotopolClient, logger)
+	bonusSvc.ResumeBulkGrants(context.Background())
+	sagaOrchestrator := saga.NewOrchestrator(pool, logger)
+	paymentSvc := service.NewPaymentService(pool, stripeProvider, adyenProvider, adyenPayouts, paymentRepo, playerRepo, txRepo, outboxRepo, ledgerEngine, sagaOrchestrator, bonusSvc, deps.ClosedLoopPayouts, deps.KYCThreshold, logger)
+	sagaOrchestrator.StartProcessor(context.Background(), time.Minute)
+	sportsbookSvc := service.NewSportsbookService(pool, txRepo, ledgerEngine, outboxRepo, deps.SportsbookExposure, logger)
+	sportsbookSvc.StartPoolScheduler(context.Background(), time.Minute)
+	predictionSvc := service.NewPredictionService(pool, txRepo, ledgerEngine, outboxRepo, logger)
+	predictionSettlementSvc := service.NewPredictionSettlementService(pool, ledgerEngine, outboxRepo, logger)
+	predictionSettlementSvc.StartSettlementProcessor(context.Background(), time.Minute)
+	predictionAdminSvc := service.NewPredictionAdminService(pool, predictionSettlementSvc, logger)
+	feedSvc := service.NewFeedService(pool, feedResyncer, logger)
+	questResetSvc := service.NewQuestResetService(pool, logger)
+	questResetSvc.StartResetScheduler(context.Background(), time.Minute)
+
+	// The Odds API — live sportsbook odds sync, and settlement from its final scores
 	if deps.OddsAPIKey != "" {
-		oddsConnector := provider.NewOddsAPIConnector(pool, deps.OddsAPIKey, logger)
-		oddsConnector.StartSync(context.Background())
+		oddsConnector := provider.NewOddsAPIConnector(pool, deps.OddsAPIKey, deps.InPlaySuspension, logger)
+		oddsConnector.StartSync(context.Background(), workerHealth)
+		sportsbookSvc.StartAutoSettlement(context.Background(), oddsConnector, service.OddsAPISettlementSource, 15*time.Minute)
 	}
 
-	// Services
-	authSvc := service.NewAuthService(pool, authUserRepo, playerRepo, profileRepo, jwtMgr)
-	paymentSvc := service.NewPaymentService(pool, stripeProvider, paymentRepo, playerRepo, txRepo, ledgerEngine, logger)
-	sportsbookSvc := service.NewSportsbookService(pool, txRepo, ledgerEngine, logger)
+	betReceiptSvc := service.NewBetReceiptService(pool, outboxRepo, receiptSigningKey(deps), logger)
+	p2pTransferSvc := service.NewP2PTransferService(pool, ledgerEngine, outboxRepo, deps.P2PTransfers, logger)
+	sweepsSvc := service.NewSweepstakesService(pool, paymentSvc, walletRepo, ledgerEngine, deps.Sweepstakes, logger)
+	storeSvc := service.NewStoreService(pool, paymentSvc, walletRepo, logger)
+	cosmeticSvc := service.NewCosmeticService(pool, logger)
+	contentSvc := service.NewContentService(pool, logger)
 	affiliateSvc := service.NewAffiliateService(pool, jwtMgr, logger)
 	pluginSvc := service.NewPluginService(pool, logger)
+	recoverySvc := service.NewRecoveryService(pool, authUserRepo, profileRepo, outboxRepo, logger)
+	loginHistorySvc := service.NewLoginHistoryService(pool, authUserRepo, outboxRepo, logger)
+	playerStatusSvc := service.NewPlayerStatusService(pool, outboxRepo, logger)
+	playerStatusSvc.StartScheduler(context.Background(), time.Minute)
+	walletSvc := service.NewWalletService(pool, playerRepo, walletRepo, ledgerEngine, deps.WalletCurrencies, logger)
+	placementSvc := service.NewPlacementService(pool, consentRepo, logger)
+	experimentSvc := service.NewExperimentService(pool, outboxRepo, consentRepo, logger)
+	consentSvc := service.NewConsentService(pool, consentRepo, outboxRepo, logger)
+	adminAuditSvc := service.NewAdminAuditService(pool, logger)
+	ledgerReconSvc := service.NewLedgerReconciliationService(pool, outboxRepo, logger)
+	retentionSvc := service.NewRetentionService(pool, deps.Retention, logger)
+	termsSvc := service.NewTermsService(pool, logger)
+	sofSvc := service.NewSourceOfFundsService(pool, deps.SOFThresholds, logger)
+	playerLimitSvc := service.NewPlayerLimitService(pool, logger)
+	kycSvc := service.NewKYCService(pool, provider.NewS3Storage(deps.KYCStorage), deps.KYCThreshold, logger)
+	exportJobSvc := service.NewExportJobService(pool, provider.NewS3Storage(deps.ExportStorage), deps.MaxExportsPerAdmin, logger)
+	exportJobSvc.StartInterruptedSweep(context.Background(), time.Minute)
+	interventionSvc := service.NewNetLossInterventionService(pool, outboxRepo, deps.NetLossRules, logger)
+	interventionSvc.StartScheduler(context.Background(), 15*time.Minute)
+	rgRiskSvc := service.NewRGRiskService(pool, logger)
+	rgRiskSvc.StartScheduler(context.Background(), 15*time.Minute)
+	casinoReportSvc := service.NewCasinoReportService(pool, outboxRepo, deps.RTPBands, logger)
+	casinoReportSvc.StartScheduler(context.Background(), time.Hour)
+	gameSessionSvc := service.NewGameSessionService(pool, deps.GameSessionTTL)
+	casinoSvc := service.NewCasinoService(pool, cacheStore(deps), gameSessionSvc, logger)
+	rgCaseSvc := service.NewRGCaseService(pool, txRepo, playerStatusSvc, interventionSvc, rgRiskSvc, logger)
+	providerCallbackSvc := service.NewProviderCallbackService(pool, logger)
+	manufacturerSvc := service.NewManufacturerService(pool, logger)
+	reconSvc := service.NewReconciliationService(pool, stripeProvider, logger)
+	if deps.StripeSecretKey != "" {
+		reconSvc.StartDailyStripe(context.Background())
+	}
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authSvc)
 	playerHandler := handler.NewPlayerHandler(playerRepo, profileRepo, pool)
 	walletHandler := handler.NewWalletHandler(playerRepo, txRepo, pool)
+	currencyHandler := handler.NewCurrencyHandler(walletSvc)
 	paymentHandler := handler.NewPaymentHandler(paymentSvc)
 	webhookHandler := handler.NewWebhookHandler(paymentSvc, logger)
 	sportsbookHandler := handler.NewSportsbookHandler(sportsbookSvc, pool)
+	betReceiptHandler := handler.NewBetReceiptHandler(betReceiptSvc)
+	betPoolHandler := handler.NewBetPoolHandler(sportsbookSvc)
+	p2pTransferHandler := handler.NewP2PTransferHandler(p2pTransferSvc)
+	sweepsHandler := handler.NewSweepstakesHandler(sweepsSvc)
+	storeHandler := handler.NewStoreHandler(storeSvc)
+	bonusHandler := handler.NewBonusHandler(bonusSvc)
+	cosmeticHandler := handler.NewCosmeticHandler(cosmeticSvc)
+	contentHandler := handler.NewContentHandler(contentSvc)
 	affiliateHandler := handler.NewAffiliateHandler(affiliateSvc)
-	questHandler := handler.NewQuestHandler(pool)
-	engagementHandler := handler.NewEngagementHandler(pool)
+	questHandler := handler.NewQuestHandler(pool, outboxRepo)
+	engagementHandler := handler.NewEngagementHandler(pool, outboxRepo)
 	pluginHandler := handler.NewPluginHandler(pluginSvc)
-	predictionHandler := handler.NewPredictionHandler(pool)
+	predictionHandler := handler.NewPredictionHandler(pool, predictionSvc)
 	aiHandler := handler.NewAIHandler(pool)
 	videoHandler := handler.NewVideoHandler(pool)
-	socialHandler := handler.NewSocialHandler(pool)
+	socialHandler := handler.NewSocialHandler(pool, cosmeticSvc)
 	rngHandler := handler.NewRNGHandler(rngClient, slotopolClient)
+	recoveryHandler := handler.NewRecoveryHandler(recoverySvc)
+	loginHistoryHandler := handler.NewLoginHistoryHandler(loginHistorySvc)
+	casinoHandler := handler.NewCasinoHandler(casinoSvc)
+	homeHandler := handler.NewHomeHandler(pool, playerRepo, logger)
+	placementHandler := handler.NewPlacementHandler(placementSvc)
+	featureHandler := handler.NewFeatureHandler(experimentSvc)
+	termsHandler := handler.NewTermsHandler(termsSvc)
+	sofHandler := handler.NewSourceOfFundsHandler(sofSvc)
+	kycHandler := handler.NewKYCHandler(kycSvc)
+	playerLimitHandler := handler.NewPlayerLimitHandler(playerLimitSvc)
+	consentHandler := handler.NewConsentHandler(consentSvc)
+	selfExclusionHandler := handler.NewSelfExclusionHandler(playerStatusSvc)
+	interventionHandler := handler.NewInterventionHandler(interventionSvc)
+	graphqlHandler := handler.NewGraphQLHandler(pool, playerRepo, profileRepo, txRepo, sportsbookSvc, logger)
 
 	// Admin handlers
-	playerAdmin := adminhandler.NewPlayerAdminHandler(pool, playerRepo, profileRepo)
-	bonusAdmin := adminhandler.NewBonusAdminHandler(pool)
+	playerAdmin := adminhandler.NewPlayerAdminHandler(pool, playerRepo, profileRepo, playerStatusSvc)
+	bonusAdmin := adminhandler.NewBonusAdminHandler(pool, bonusSvc)
 	sbAdmin := adminhandler.NewSportsbookAdminHandler(pool, sportsbookSvc)
+	betReceiptAdmin := adminhandler.NewBetReceiptAdminHandler(betReceiptSvc)
+	predictionAdmin := adminhandler.NewPredictionAdminHandler(predictionAdminSvc)
+	feedAdmin := adminhandler.NewFeedAdminHandler(feedSvc)
 	reportsAdmin := adminhandler.NewReportsHandler(pool)
+	exportJobAdmin := adminhandler.NewExportJobHandler(exportJobSvc)
+	casinoAdmin := adminhandler.NewCasinoAdminHandler(casinoReportSvc)
 	affiliateAdmin := adminhandler.NewAffiliateAdminHandler(pool)
 	questAdmin := adminhandler.NewQuestAdminHandler(pool)
 	moderationAdmin := adminhandler.NewModerationHandler(pool)
+	recoveryAdmin := adminhandler.NewRecoveryAdminHandler(recoverySvc)
+	withdrawalAdmin := adminhandler.NewWithdrawalAdminHandler(paymentSvc)
+	sagaAdmin := adminhandler.NewSagaAdminHandler(sagaOrchestrator)
+	paymentAdmin := adminhandler.NewPaymentAdminHandler(paymentSvc)
+	outboxAdmin := adminhandler.NewOutboxAdminHandler(pool, outboxRepo)
+	reconAdmin := adminhandler.NewReconciliationAdminHandler(reconSvc)
+	fxAdmin := adminhandler.NewFxRateAdminHandler(walletSvc)
+	placementAdmin := adminhandler.NewPlacementAdminHandler(placementSvc)
+	experimentAdmin := adminhandler.NewExperimentAdminHandler(experimentSvc)
+	termsAdmin := adminhandler.NewTermsAdminHandler(termsSvc)
+	consentAdmin := adminhandler.NewConsentAdminHandler(consentSvc)
+	adminAuditAdmin := adminhandler.NewAdminAuditHandler(adminAuditSvc)
+	sofAdmin := adminhandler.NewSourceOfFundsAdminHandler(sofSvc)
+	kycAdmin := adminhandler.NewKYCAdminHandler(kycSvc)
+	interventionAdmin := adminhandler.NewInterventionAdminHandler(interventionSvc)
+	p2pTransferAdmin := adminhandler.NewP2PTransferAdminHandler(p2pTransferSvc)
+	sweepsAdmin := adminhandler.NewSweepstakesAdminHandler(sweepsSvc)
+	storeAdmin := adminhandler.NewStoreAdminHandler(storeSvc)
+	cosmeticAdmin := adminhandler.NewCosmeticAdminHandler(cosmeticSvc)
+	contentAdmin := adminhandler.NewContentAdminHandler(contentSvc)
+	rgRiskAdmin := adminhandler.NewRGRiskAdminHandler(rgRiskSvc)
+	rgCaseAdmin := adminhandler.NewRGCaseAdminHandler(rgCaseSvc)
+	providerCallbackAdmin := adminhandler.NewProviderCallbackAdminHandler(providerCallbackSvc)
+	manufacturerAdmin := adminhandler.NewManufacturerAdminHandler(manufacturerSvc)
+	ledgerAdmin := adminhandler.NewLedgerAdminHandler(pool, ledgerEngine, ledgerEntryRepo, ledgerReconSvc)
+	lookupAdmin := adminhandler.NewLookupAdminHandler(service.NewSupportLookupService(pool, txRepo, paymentRepo))
+	retentionAdmin := adminhandler.NewRetentionAdminHandler(retentionSvc)
+	ipRiskAdmin := adminhandler.NewIPRiskAdminHandler(ipRiskSvc)
 
 	// Router
 	r := chi.NewRouter()
@@ -107,7 +344,9 @@ func NewRouter(deps RouterDeps) chi.Router {
 	r.Use(handler.Recovery(logger))
 	r.Use(handler.RequestID)
 	r.Use(handler.RequestLogger(logger))
-	r.Use(handler.CORSWithOrigins(deps.CORSAllowedOrigins))
+	r.Use(handler.Deadline(deps.RequestBudgets))
+	r.Use(handler.CORS(corsConfig(deps)))
+	r.Use(handler.Compress())
 	r.Use(handler.JSONContentType)
 
 	// Auth rate limiter: 10 attempts per 15 minutes per IP
@@ -115,9 +354,11 @@ func NewRouter(deps RouterDeps) chi.Router {
 
 	// Health (no auth)
 	r.Get("/health", handler.HealthHandler(pool))
+	r.Get("/health/workers", handler.WorkerHealthHandler(workerHealth))
 
 	// Webhooks (no auth, no JSON content-type — raw body required for signature verification)
 	r.Post("/webhooks/stripe", webhookHandler.HandleStripeWebhook)
+	r.Post("/webhooks/adyen", webhookHandler.HandleAdyenWebhook)
 
 	// Auth routes (no auth, rate-limited by IP)
 	r.Route("/auth", func(r chi.Router) {
@@ -126,6 +367,7 @@ func NewRouter(deps RouterDeps) chi.Router {
 		r.Post("/login", authHandler.Login)
 		r.Post("/password-reset/request", authHandler.RequestPasswordReset)
 		r.Post("/password-reset/confirm", authHandler.ConfirmPasswordReset)
+		r.Post("/recovery", recoveryHandler.SubmitRecovery)
 	})
 
 	// Affiliate auth routes (no player auth)
@@ -137,35 +379,143 @@ func NewRouter(deps RouterDeps) chi.Router {
 	// Public click tracking (no auth)
 	r.Get("/track/{btag}", affiliateHandler.TrackClick)
 
+	// Current T&C documents (no auth)
+	r.Get("/terms", termsHandler.ListCurrent)
+
+	// Published CMS pages (no auth)
+	r.Get("/content/{slug}", contentHandler.GetPage)
+
 	// Player-authenticated routes
 	r.Group(func(r chi.Router) {
 		r.Use(auth.AuthenticatePlayer(jwtMgr))
-
+		r.Use(auth.RejectRevokedTokens(loginHistorySvc))
+		requireActive := handler.RequireActiveAccount(pool)
+		// Money-moving routes also stay closed until the current terms are accepted.
+		requireTerms := handler.RequireAcceptedTerms(pool)
+		// Deposits stop at each cumulative threshold until source of funds is declared.
+		requireSOF := handler.RequireSourceOfFunds(pool, sofSvc.Thresholds())
+		// Payment submissions replay the first response for a repeated Idempotency-Key.
+		idempotent := handler.Idempotent(pool)
+		// vertical closes a route when sweepstakes mode rules it out
+		vertical := func(v string) func(http.Handler) http.Handler {
+			return handler.RequireVertical(deps.Sweepstakes, v)
+		}
+		// Deposits and bets from VPNs and risky networks are flagged or blocked.
+		screenDeposit := handler.ScreenIP(ipRiskSvc, policy.IPCheckpointDeposit)
+		screenBet := handler.ScreenIP(ipRiskSvc, policy.IPCheckpointBet)
+
+		r.Get("/home", homeHandler.GetHome)
+		r.Get("/placements", placementHandler.ListPlacements)
+		r.Post("/placements/{id}/impression", placementHandler.TrackImpression)
+		r.Post("/placements/{id}/click", placementHandler.TrackClick)
+		r.Get("/features", featureHandler.ListFeatures)
+		r.Post("/features/{key}/exposure", featureHandler.RecordExposure)
 		r.Get("/players/me", playerHandler.GetMe)
+		r.Get("/players/me/logins", loginHistoryHandler.ListLogins)
+		r.Post("/players/me/logins/{id}/report", loginHistoryHandler.ReportLogin)
+		r.Get("/players/me/backup-email", recoveryHandler.GetBackupEmail)
+		r.Put("/players/me/backup-email", recoveryHandler.SetBackupEmail)
+		r.Post("/players/me/backup-email/verify", recoveryHandler.VerifyBackupEmail)
+		r.Get("/players/me/terms", termsHandler.ListAcceptances)
+		r.Get("/terms/pending", termsHandler.ListPending)
+		r.Post("/terms/{id}/accept", termsHandler.Accept)
+		r.Get("/players/me/source-of-funds", sofHandler.List)
+		r.Put("/players/me/source-of-funds/{id}", sofHandler.Submit)
+		r.Post("/players/me/source-of-funds/{id}/documents", sofHandler.UploadDocument)
+		r.Get("/players/me/limits", playerLimitHandler.List)
+		r.Put("/players/me/limits", playerLimitHandler.Set)
+		r.Delete("/players/me/limits/{type}/{period}", playerLimitHandler.Remove)
+		r.Get("/players/me/consents", consentHandler.List)
+		r.Put("/players/me/consents", consentHandler.Update)
+		r.Get("/players/me/consents/history", consentHandler.History)
+		r.Get("/players/me/self-exclusion", selfExclusionHandler.Get)
+		r.Post("/players/me/self-exclusion", selfExclusionHandler.Enable)
+		r.Get("/players/me/kyc", kycHandler.Status)
+		r.Get("/players/me/kyc/documents", kycHandler.ListDocuments)
+		r.Post("/players/me/kyc/documents", kycHandler.UploadDocument)
+		r.Get("/players/me/interventions", interventionHandler.List)
+		r.Post("/players/me/interventions/{id}/acknowledge", interventionHandler.Acknowledge)
+		r.Get("/players/me/cosmetics", cosmeticHandler.Inventory)
+		r.Post("/players/me/cosmetics/{code}/equip", cosmeticHandler.Equip)
+		r.Post("/players/me/cosmetics/{code}/unequip", cosmeticHandler.Unequip)
 
 		r.Route("/wallet", func(r chi.Router) {
 			r.Get("/balance", walletHandler.GetBalance)
 			r.Get("/transactions", walletHandler.GetTransactions)
+			r.Get("/wallets", currencyHandler.ListWallets)
+			r.Post("/wallets", currencyHandler.OpenWallet)
+			r.With(requireActive, requireTerms).Post("/convert", currencyHandler.Convert)
+			r.With(vertical(policy.VerticalTransfers), requireActive, requireTerms, idempotent).Post("/transfers", p2pTransferHandler.Send)
+			r.Get("/transfers", p2pTransferHandler.List)
 		})
 
 		r.Route("/payments", func(r chi.Router) {
-			r.Post("/deposit", paymentHandler.InitiateDeposit)
-			r.Post("/withdraw", paymentHandler.RequestWithdrawal)
+			r.With(vertical(policy.VerticalDeposits), requireActive, requireTerms, requireSOF, screenDeposit, idempotent).Post("/deposit", paymentHandler.InitiateDeposit)
+			r.With(vertical(policy.VerticalWithdrawals), requireActive, requireTerms, idempotent).Post("/withdraw", paymentHandler.RequestWithdrawal)
 			r.Get("/history", paymentHandler.GetPaymentHistory)
+			r.Get("/methods", paymentHandler.ListPaymentMethods)
+			r.With(requireActive).Post("/methods", paymentHandler.AddPaymentMethod)
+			r.Patch("/methods/{id}", paymentHandler.UpdatePaymentMethod)
+			r.Delete("/methods/{id}", paymentHandler.RemovePaymentMethod)
+		})
+
+		r.Route("/sweeps", func(r chi.Router) {
+			r.Use(vertical(policy.VerticalSweeps))
+			r.Get("/packages", sweepsHandler.ListPackages)
+			r.With(requireActive, requireTerms, screenDeposit, idempotent).Post("/purchases", sweepsHandler.Purchase)
+			r.Get("/purchases", sweepsHandler.ListPurchases)
+			r.Get("/redemptions/eligibility", sweepsHandler.Eligibility)
+			r.With(requireActive, requireTerms, idempotent).Post("/redemptions", sweepsHandler.Redeem)
+			r.Get("/redemptions", sweepsHandler.ListRedemptions)
+		})
+
+		r.Route("/store", func(r chi.Router) {
+			r.With(handler.ETag).Get("/items", storeHandler.ListItems)
+			r.With(requireActive, requireTerms, idempotent).Post("/orders", storeHandler.Checkout)
+			r.Get("/orders", storeHandler.ListOrders)
+			r.Get("/entitlements", storeHandler.ListEntitlements)
+		})
+
+		r.Route("/bonuses", func(r chi.Router) {
+			r.Get("/free-spins", bonusHandler.ListFreeSpins)
+			r.With(requireActive, requireTerms, screenBet).Post("/free-spins/{id}/spin", bonusHandler.PlayFreeSpin)
+			r.Get("/deposit-match", bonusHandler.ListDepositMatchOffers)
+			r.Get("/opt-ins", bonusHandler.ListOptIns)
+			r.With(requireActive, requireTerms).Post("/{id}/opt-in", bonusHandler.OptIn)
+			r.Delete("/{id}/opt-in", bonusHandler.CancelOptIn)
 		})
 
 		r.Route("/sportsbook", func(r chi.Router) {
-			r.Get("/sports", sportsbookHandler.ListSports)
-			r.Get("/sports/{sportID}/events", sportsbookHandler.ListEvents)
-			r.Get("/events/{eventID}/markets", sportsbookHandler.ListMarkets)
-			r.Get("/markets/{marketID}/selections", sportsbookHandler.ListSelections)
-			r.Post("/bets", sportsbookHandler.PlaceBet)
+			r.With(handler.ETag).Get("/sports", sportsbookHandler.ListSports)
+			r.With(handler.ETag).Get("/sports/{sportID}/events", sportsbookHandler.ListEvents)
+			r.With(handler.ETag).Get("/sports/{sportID}/outrights", sportsbookHandler.ListOutrights)
+			r.With(handler.ETag).Get("/events/{eventID}/markets", sportsbookHandler.ListMarkets)
+			r.With(handler.ETag).Get("/markets/{marketID}/selections", sportsbookHandler.ListSelections)
+			r.With(vertical(policy.VerticalSportsbook), requireActive, requireTerms, screenBet).Post("/bets", sportsbookHandler.PlaceBet)
 			r.Get("/bets/me", sportsbookHandler.MyBets)
+			r.Get("/bets/{id}/cashout", sportsbookHandler.CashOutQuote)
+			r.With(vertical(policy.VerticalSportsbook), requireActive, requireTerms).Post("/bets/{id}/cashout", sportsbookHandler.CashOut)
+			r.Get("/bets/{id}/receipt", betReceiptHandler.Receipt)
+			r.Post("/bets/{id}/receipt/email", betReceiptHandler.EmailReceipt)
+			r.Route("/pools", func(r chi.Router) {
+				r.With(vertical(policy.VerticalSportsbook), requireActive, requireTerms, screenBet).Post("/", betPoolHandler.Create)
+				r.Get("/me", betPoolHandler.MyPools)
+				r.Get("/{id}", betPoolHandler.Get)
+				r.Post("/{id}/invitations", betPoolHandler.Invite)
+				r.Post("/{id}/join", betPoolHandler.Join)
+				r.Post("/{id}/decline", betPoolHandler.Decline)
+				r.With(vertical(policy.VerticalSportsbook), requireActive, requireTerms, screenBet).Post("/{id}/contributions", betPoolHandler.Contribute)
+				r.Post("/{id}/place", betPoolHandler.Place)
+				r.Post("/{id}/cancel", betPoolHandler.Cancel)
+				r.Get("/{id}/messages", betPoolHandler.Messages)
+				r.Post("/{id}/messages", betPoolHandler.PostMessage)
+			})
 		})
 
 		r.Route("/quests", func(r chi.Router) {
 			r.Get("/", questHandler.ListActive)
-			r.Post("/{id}/claim", questHandler.ClaimReward)
+			r.Get("/history", questHandler.ListHistory)
+			r.With(requireActive, requireTerms).Post("/{id}/claim", questHandler.ClaimReward)
 		})
 
 		r.Route("/engagement", func(r chi.Router) {
@@ -174,10 +524,12 @@ func NewRouter(deps RouterDeps) chi.Router {
 		})
 
 		r.Route("/predictions", func(r chi.Router) {
-			r.Get("/markets", predictionHandler.ListMarkets)
-			r.Get("/markets/{id}", predictionHandler.GetMarket)
-			r.Post("/markets/{id}/stake", predictionHandler.PlaceStake)
+			r.With(handler.ETag).Get("/markets", predictionHandler.ListMarkets)
+			r.With(handler.ETag).Get("/markets/{id}", predictionHandler.GetMarket)
+			r.With(handler.ETag).Get("/categories", predictionHandler.ListCategories)
+			r.With(vertical(policy.VerticalPredictions), requireActive, requireTerms, screenBet).Post("/markets/{id}/stake", predictionHandler.PlaceStake)
 			r.Get("/positions", predictionHandler.MyPositions)
+			r.With(vertical(policy.VerticalPredictions), requireActive).Post("/positions/{id}/sell", predictionHandler.SellPosition)
 		})
 
 		r.Route("/ai", func(r chi.Router) {
@@ -197,6 +549,7 @@ func NewRouter(deps RouterDeps) chi.Router {
 			r.Post("/posts", socialHandler.CreatePost)
 			r.Get("/posts", socialHandler.ListPosts)
 			r.Delete("/posts/{id}", socialHandler.DeletePost)
+			r.Get("/players/{id}", socialHandler.PlayerProfile)
 		})
 
 		r.Route("/plugins", func(r chi.Router) {
@@ -205,51 +558,223 @@ func NewRouter(deps RouterDeps) chi.Router {
 			r.Get("/{pluginID}/dispatches", pluginHandler.ListDispatches)
 		})
 
+		r.Route("/casino", func(r chi.Router) {
+			r.Get("/sessions", casinoHandler.ListSessions)
+			r.Get("/games/recent", casinoHandler.RecentGames)
+			r.Get("/games/recommended", casinoHandler.RecommendedGames)
+			r.With(requireActive, requireTerms).Post("/games/{id}/launch", casinoHandler.RecordLaunch)
+		})
+
 		r.Post("/rng/random", rngHandler.GetRandom)
 
 		r.Route("/slots", func(r chi.Router) {
-			r.Get("/games", rngHandler.ListSlotGames)
-			r.Post("/spin", rngHandler.Spin)
+			r.With(handler.ETag).Get("/games", rngHandler.ListSlotGames)
+			r.With(requireActive, requireTerms, screenBet).Post("/spin", rngHandler.Spin)
 		})
+
+		if deps.GraphQLEnabled {
+			r.Get("/graphql", graphqlHandler.Serve)
+			r.Post("/graphql", graphqlHandler.Serve)
+		}
 	})
 
 	// Admin-authenticated routes — 3 permission tiers via RequireRole
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(auth.AuthenticateAdmin(jwtMgr))
+		r.Use(handler.AuditAdminRequests(adminAuditSvc))
 
 		// Read tier — all admin roles (viewer, admin, superadmin)
 		r.Group(func(r chi.Router) {
 			r.Use(auth.RequireRole(auth.AllAdminRoles()...))
+			r.Get("/lookup", lookupAdmin.Lookup)
 			r.Get("/players", playerAdmin.SearchPlayers)
 			r.Get("/players/{id}", playerAdmin.GetPlayerDetail)
+			r.Get("/players/{id}/summary", reportsAdmin.GetPlayerSummary)
+			r.Get("/players/{id}/status-history", playerAdmin.GetStatusHistory)
+			r.Get("/players/{id}/balance-at", ledgerAdmin.BalanceAt)
+			r.Get("/players/{id}/terms-acceptances", termsAdmin.ListPlayerAcceptances)
+			r.Get("/players/{id}/consents", consentAdmin.PlayerConsents)
+			r.Get("/players/{id}/store-orders", storeAdmin.PlayerOrders)
+			r.Get("/players/{id}/entitlements", storeAdmin.PlayerEntitlements)
+			r.Get("/players/{id}/cosmetics", cosmeticAdmin.PlayerInventory)
+			r.Get("/cosmetics", cosmeticAdmin.ListItems)
+			r.Get("/content/pages", contentAdmin.ListPages)
+			r.Get("/content/pages/{id}", contentAdmin.GetPage)
+			r.Get("/content/pages/{id}/versions", contentAdmin.ListVersions)
 			r.Get("/reports/dashboard", reportsAdmin.GetDashboardStats)
+			r.Get("/reports/kpis", reportsAdmin.GetDailyKPIs)
+			r.Get("/reports/bet-feed", reportsAdmin.GetBetFeed)
 			r.Get("/reports/transactions", reportsAdmin.GetTransactionReport)
+			r.Get("/reports/transactions/export", reportsAdmin.ExportTransactions)
+			r.Get("/exports", exportJobAdmin.List)
+			r.Get("/exports/{id}", exportJobAdmin.Get)
+			r.Get("/reports/unverified-dob", reportsAdmin.GetUnverifiedDOBReport)
+			r.Get("/reports/casino", casinoAdmin.Performance)
+			r.Get("/reports/liabilities", ledgerAdmin.LiabilityReport)
+			r.Get("/casino/rtp-alerts", casinoAdmin.ListAlerts)
 			r.Get("/affiliates", affiliateAdmin.ListAffiliates)
 			r.Get("/quests", questAdmin.ListQuests)
 			r.Get("/bonuses", bonusAdmin.ListBonuses)
+			r.Get("/bonus-grants", bonusAdmin.ListBulkGrants)
+			r.Get("/bonus-grants/{id}", bonusAdmin.GetBulkGrant)
+			r.Get("/bonus-grants/{id}/items", bonusAdmin.ListBulkGrantItems)
 			r.Get("/sportsbook/events", sbAdmin.ListEvents)
+			r.Get("/sportsbook/events/{id}/settlement-audit", sbAdmin.ListSettlementAudit)
+			r.Get("/sportsbook/markets/{id}/odds-changes", sbAdmin.ListOddsChanges)
+			r.Get("/sportsbook/liabilities", sbAdmin.Liabilities)
+			r.Get("/sportsbook/exposure", sbAdmin.ListExposure)
+			r.Get("/sportsbook/events/{id}/exposure", sbAdmin.EventExposure)
+			r.Get("/sportsbook/trading-alerts", sbAdmin.ListTradingAlerts)
+			r.Post("/sportsbook/receipts/verify", betReceiptAdmin.Verify)
+			r.Get("/predictions", predictionAdmin.List)
+			r.Get("/predictions/{id}", predictionAdmin.Get)
+			r.Get("/feeds", feedAdmin.List)
 			r.Get("/moderation/posts", moderationAdmin.ListFlaggedPosts)
 			r.Get("/moderation/dispatches", moderationAdmin.ListPluginDispatches)
+			r.Get("/recovery-requests", recoveryAdmin.ListRequests)
+			r.Get("/recovery-requests/{id}", recoveryAdmin.GetRequest)
+			r.Get("/withdrawals", withdrawalAdmin.ListQueue)
+			r.Get("/sagas", sagaAdmin.List)
+			r.Get("/sagas/{id}", sagaAdmin.Get)
+			r.Get("/payments/{id}/refunds", paymentAdmin.ListRefunds)
+			r.Get("/pending-credits", paymentAdmin.ListPendingCredits)
+			r.Get("/players/{id}/payment-methods", paymentAdmin.ListPlayerPaymentMethods)
+			r.Get("/outbox/dlq", outboxAdmin.ListDLQ)
+			r.Get("/reconciliation/runs", reconAdmin.ListRuns)
+			r.Get("/reconciliation/runs/{id}", reconAdmin.GetRun)
+			r.Get("/fx-rates", fxAdmin.ListRates)
+			r.Get("/ledger/verify", ledgerAdmin.Verify)
+			r.Get("/ledger/accounts", ledgerAdmin.ListAccounts)
+			r.Get("/ledger/discrepancies", ledgerAdmin.ListDiscrepancies)
+			r.Get("/retention/policies", retentionAdmin.Policies)
+			r.Get("/retention/runs", retentionAdmin.ListRuns)
+			r.Get("/terms", termsAdmin.ListDocuments)
+			r.Get("/source-of-funds", sofAdmin.List)
+			r.Get("/source-of-funds/{id}", sofAdmin.Get)
+			r.Get("/kyc", kycAdmin.Queue)
+			r.Get("/kyc/players/{id}", kycAdmin.PlayerStatus)
+			r.Get("/rg/interventions", interventionAdmin.List)
+			r.Get("/p2p-transfers", p2pTransferAdmin.List)
+			r.Get("/p2p-transfers/rules", p2pTransferAdmin.Rules)
+			r.Get("/sweeps/rules", sweepsAdmin.Rules)
+			r.Get("/sweeps/packages", sweepsAdmin.ListPackages)
+			r.Get("/sweeps/redemptions", sweepsAdmin.ListRedemptions)
+			r.Get("/store/items", storeAdmin.ListItems)
+			r.Get("/rg/dashboard", rgRiskAdmin.Dashboard)
+			r.Get("/rg/risk-scores", rgRiskAdmin.List)
+			r.Get("/ip-checks", ipRiskAdmin.List)
+			r.Get("/rg/players", rgCaseAdmin.Players)
+			r.Get("/rg/cases", rgCaseAdmin.List)
+			r.Get("/rg/cases/{id}", rgCaseAdmin.Get)
+			r.Get("/rg/cases/{id}/export", rgCaseAdmin.Export)
+			r.Get("/providers/callbacks", providerCallbackAdmin.List)
+			r.Get("/providers/callbacks/mismatches", providerCallbackAdmin.Mismatches)
+			r.Get("/manufacturers", manufacturerAdmin.List)
+			r.Get("/manufacturers/{id}", manufacturerAdmin.Get)
+			r.Get("/placements", placementAdmin.ListPlacements)
+			r.Get("/maintenance-windows", placementAdmin.ListMaintenanceWindows)
+			r.Get("/flags", experimentAdmin.ListFlags)
+			r.Get("/experiments", experimentAdmin.ListExperiments)
+			r.Get("/experiments/{id}/results", experimentAdmin.GetResults)
 		})
 
 		// Write tier — admin + superadmin
 		r.Group(func(r chi.Router) {
 			r.Use(auth.RequireRole(auth.WriteRoles()...))
 			r.Patch("/players/{id}/status", playerAdmin.UpdatePlayerStatus)
+			r.Post("/players/{id}/suspend", playerAdmin.SuspendPlayer)
+			r.Put("/players/{id}/dob-verification", playerAdmin.VerifyDateOfBirth)
 			r.Post("/bonuses", bonusAdmin.CreateBonus)
+			r.Post("/bonuses/simulate", bonusAdmin.SimulateBonus)
 			r.Patch("/bonuses/{id}/status", bonusAdmin.UpdateBonusStatus)
+			r.Post("/players/{id}/bonuses", bonusAdmin.GrantBonus)
+			r.Post("/bonuses/{id}/grant", bonusAdmin.BulkGrant)
+			r.Post("/bonus-grants/{id}/rollback", bonusAdmin.RollbackBulkGrant)
 			r.Post("/sportsbook/events", sbAdmin.CreateEvent)
 			r.Patch("/sportsbook/events/{id}/status", sbAdmin.UpdateEventStatus)
+			r.Patch("/sportsbook/events/{id}/cash-out", sbAdmin.SetEventCashOut)
+			r.Patch("/sportsbook/events/{id}/exposure-limit", sbAdmin.SetEventLiabilityLimit)
+			r.Post("/sportsbook/outrights", sbAdmin.CreateOutright)
+			r.Post("/sportsbook/selections/bulk-odds", sbAdmin.BulkUpdateOdds)
+			r.Post("/sportsbook/trading-alerts/{id}/acknowledge", sbAdmin.AcknowledgeTradingAlert)
+			r.Post("/predictions", predictionAdmin.Create)
+			r.Put("/predictions/{id}/outcomes", predictionAdmin.UpdateOutcomes)
+			r.Post("/predictions/{id}/close", predictionAdmin.Close)
+			r.Post("/feeds/{name}/resync", feedAdmin.Resync)
+			r.Post("/reports/casino/aggregate", casinoAdmin.Aggregate)
+			r.Post("/casino/rtp-alerts/{id}/acknowledge", casinoAdmin.AcknowledgeAlert)
 			r.Patch("/affiliates/{id}/status", affiliateAdmin.UpdateAffiliateStatus)
 			r.Post("/quests", questAdmin.CreateQuest)
+			r.Post("/quests/simulate", questAdmin.SimulateQuest)
 			r.Patch("/quests/{id}/toggle", questAdmin.ToggleQuest)
+			r.Put("/quests/{id}/prerequisites", questAdmin.SetPrerequisites)
 			r.Delete("/moderation/posts/{id}", moderationAdmin.DeletePost)
+			r.Post("/recovery-requests/{id}/approve", recoveryAdmin.ApproveRequest)
+			r.Post("/recovery-requests/{id}/reject", recoveryAdmin.RejectRequest)
+			r.Post("/exports", exportJobAdmin.Create)
+			r.Post("/reconciliation/stripe", reconAdmin.RunStripe)
+			r.Post("/payments/{id}/refund", paymentAdmin.RefundPayment)
+			r.Post("/withdrawals/{id}/approve", withdrawalAdmin.Approve)
+			r.Post("/sagas/{id}/retry", sagaAdmin.Retry)
+			r.Post("/pending-credits/{id}/approve", paymentAdmin.ApprovePendingCredit)
+			r.Post("/pending-credits/{id}/reject", paymentAdmin.RejectPendingCredit)
+			r.Put("/players/{id}/deposit-review", paymentAdmin.SetDepositReview)
+			r.Post("/payment-methods/{id}/verify", paymentAdmin.VerifyPaymentMethod)
+			r.Post("/sweeps/packages", sweepsAdmin.CreatePackage)
+			r.Put("/sweeps/packages/{id}", sweepsAdmin.UpdatePackage)
+			r.Post("/store/items", storeAdmin.CreateItem)
+			r.Put("/store/items/{id}", storeAdmin.UpdateItem)
+			r.Put("/cosmetics/{code}", cosmeticAdmin.PutItem)
+			r.Post("/players/{id}/cosmetics", cosmeticAdmin.Grant)
+			r.Post("/content/pages", contentAdmin.CreatePage)
+			r.Put("/content/pages/{id}", contentAdmin.UpdatePage)
+			r.Post("/content/pages/{id}/publish", contentAdmin.PublishPage)
+			r.Post("/content/pages/{id}/unpublish", contentAdmin.UnpublishPage)
+			r.Delete("/content/pages/{id}", contentAdmin.DeletePage)
+			r.Post("/sweeps/redemptions/{id}/approve", sweepsAdmin.ApproveRedemption)
+			r.Post("/sweeps/redemptions/{id}/reject", sweepsAdmin.RejectRedemption)
+			r.Post("/outbox/dlq/{id}/requeue", outboxAdmin.RequeueDLQ)
+			r.Put("/fx-rates/{base}/{quote}", fxAdmin.SetRate)
+			r.Post("/ledger/reconcile", ledgerAdmin.Reconcile)
+			r.Post("/terms", termsAdmin.CreateDocument)
+			r.Post("/terms/{id}/publish", termsAdmin.PublishDocument)
+			r.Get("/source-of-funds/documents/{id}", sofAdmin.DownloadDocument)
+			r.Post("/source-of-funds/{id}/approve", sofAdmin.Approve)
+			r.Post("/source-of-funds/{id}/reject", sofAdmin.Reject)
+			r.Get("/kyc/documents/{id}", kycAdmin.DownloadDocument)
+			r.Post("/kyc/documents/{id}/approve", kycAdmin.Approve)
+			r.Post("/kyc/documents/{id}/reject", kycAdmin.Reject)
+			r.Post("/rg/interventions/run", interventionAdmin.Run)
+			r.Post("/rg/risk-scores/run", rgRiskAdmin.Run)
+			r.Post("/ip-checks/{id}/review", ipRiskAdmin.Review)
+			r.Post("/rg/cases", rgCaseAdmin.Open)
+			r.Post("/rg/cases/{id}/assign", rgCaseAdmin.Assign)
+			r.Post("/rg/cases/{id}/interactions", rgCaseAdmin.AddInteraction)
+			r.Post("/rg/cases/{id}/close", rgCaseAdmin.Close)
+			r.Post("/placements", placementAdmin.CreatePlacement)
+			r.Put("/placements/{id}", placementAdmin.UpdatePlacement)
+			r.Patch("/placements/{id}/status", placementAdmin.UpdatePlacementStatus)
+			r.Post("/maintenance-windows", placementAdmin.CreateMaintenanceWindow)
+			r.Delete("/maintenance-windows/{id}", placementAdmin.DeleteMaintenanceWindow)
+			r.Put("/flags/{key}", experimentAdmin.SetFlag)
+			r.Post("/experiments", experimentAdmin.CreateExperiment)
+			r.Patch("/experiments/{id}/status", experimentAdmin.UpdateExperimentStatus)
+			r.Put("/manufacturers/{id}", manufacturerAdmin.Put)
+			r.Post("/manufacturers/{id}/disable", manufacturerAdmin.Disable)
+			r.Post("/manufacturers/{id}/enable", manufacturerAdmin.Enable)
 		})
 
-		// Settlement tier — superadmin only
+		// Settlement and oversight tier — superadmin only
 		r.Group(func(r chi.Router) {
 			r.Use(auth.RequireRole(auth.RoleSuperAdmin))
 			r.Post("/sportsbook/events/{id}/settle", sbAdmin.SettleEvent)
+			r.Post("/sportsbook/outrights/{id}/settle", sbAdmin.SettleOutright)
+			r.Post("/predictions/{id}/void", predictionAdmin.Void)
+			r.Post("/predictions/{id}/settle", predictionAdmin.Settle)
+			r.Post("/retention/runs", retentionAdmin.Run)
+			r.Get("/audit-log", adminAuditAdmin.ListEntries)
+			r.Get("/reports/admin-activity", adminAuditAdmin.ActivityReport)
+			r.Get("/reports/admin-activity/export", adminAuditAdmin.ExportActivity)
 		})
 	})
 
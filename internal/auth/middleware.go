@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type contextKey string
@@ -60,6 +61,38 @@ func AuthenticateAffiliate(jwtMgr *JWTManager) func(http.Handler) http.Handler {
 	}
 }
 
+// RevocationChecker reports the cutoff before which a subject's tokens are no
+// longer accepted. A zero time means nothing has been revoked.
+type RevocationChecker interface {
+	RevokedSince(ctx context.Context, subject string) (time.Time, error)
+}
+
+// RejectRevokedTokens returns middleware that refuses tokens issued before the
+// subject's revocation cutoff. Must run after an Authenticate* middleware.
+func RejectRevokedTokens(checker RevocationChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := ClaimsFromContext(r.Context())
+			if claims == nil {
+				http.Error(w, `{"code":"UNAUTHORIZED","message":"no auth context"}`, http.StatusUnauthorized)
+				return
+			}
+
+			revokedAt, err := checker.RevokedSince(r.Context(), claims.Subject)
+			if err != nil {
+				http.Error(w, `{"code":"INTERNAL_ERROR","message":"revocation check failed"}`, http.StatusInternalServerError)
+				return
+			}
+			// JWT iat has second precision, so compare at that granularity.
+			if !revokedAt.IsZero() && (claims.IssuedAt == nil || !claims.IssuedAt.After(revokedAt.Truncate(time.Second))) {
+				http.Error(w, `{"code":"UNAUTHORIZED","message":"token revoked"}`, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireRole returns middleware that checks the admin role.
 func RequireRole(roles ...string) func(http.Handler) http.Handler {
 	roleSet := make(map[string]bool, len(roles))
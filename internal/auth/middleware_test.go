@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRevocations time.Time
+
+func (s stubRevocations) RevokedSince(context.Context, string) (time.Time, error) {
+	return time.Time(s), nil
+}
+
+func serveWithRevocation(t *testing.T, mgr *JWTManager, token string, revokedAt time.Time) int {
+	t.Helper()
+	h := AuthenticatePlayer(mgr)(RejectRevokedTokens(stubRevocations(revokedAt))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })))
+
+	req := httptest.NewRequest(http.MethodGet, "/players/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestRejectRevokedTokens(t *testing.T) {
+	mgr := newTestJWTManager()
+	token, err := mgr.GenerateToken(RealmPlayer, uuid.New(), "p@test.com", "", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, serveWithRevocation(t, mgr, token, time.Time{}))
+	assert.Equal(t, http.StatusOK, serveWithRevocation(t, mgr, token, time.Now().Add(-time.Hour)))
+	assert.Equal(t, http.StatusUnauthorized, serveWithRevocation(t, mgr, token, time.Now()))
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminAuditEntry represents an admin_audit_log row: one admin API request.
+// Route is the matched route pattern; PlayerID is set for routes under
+// /admin/players/{id}.
+type AdminAuditEntry struct {
+	ID         int64      `json:"id"`
+	AdminID    uuid.UUID  `json:"admin_id"`
+	AdminEmail string     `json:"admin_email"`
+	Role       string     `json:"role"`
+	Method     string     `json:"method"`
+	Route      string     `json:"route"`
+	Path       string     `json:"path"`
+	PlayerID   *uuid.UUID `json:"player_id,omitempty"`
+	StatusCode int        `json:"status_code"`
+	IP         string     `json:"ip,omitempty"`
+	OutOfHours bool       `json:"out_of_hours"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// AdminDailyActivity is one cell of the admin activity heatmap: what one
+// admin did on one day. Writes are non-GET requests; Denied are requests
+// refused with 401 or 403.
+type AdminDailyActivity struct {
+	AdminID    uuid.UUID `json:"admin_id"`
+	AdminEmail string    `json:"admin_email"`
+	Day        string    `json:"day"` // YYYY-MM-DD in the report's time zone
+	Actions    int       `json:"actions"`
+	Writes     int       `json:"writes"`
+	Denied     int       `json:"denied"`
+}
+
+// PlayerTouches counts the admin requests about one player.
+type PlayerTouches struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Actions  int       `json:"actions"`
+	Writes   int       `json:"writes"`
+	Admins   int       `json:"admins"` // distinct admins
+	LastAt   time.Time `json:"last_at"`
+}
+
+// AdminOutOfHours sums one admin's requests outside business hours.
+type AdminOutOfHours struct {
+	AdminID    uuid.UUID `json:"admin_id"`
+	AdminEmail string    `json:"admin_email"`
+	Actions    int       `json:"actions"`
+	Writes     int       `json:"writes"`
+	FirstAt    time.Time `json:"first_at"`
+	LastAt     time.Time `json:"last_at"`
+}
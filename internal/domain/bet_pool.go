@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BetPoolStatus is the lifecycle state of a bet pool.
+type BetPoolStatus string
+
+const (
+	BetPoolOpen      BetPoolStatus = "open"      // taking contributions until contributions_close_at
+	BetPoolPlaced    BetPoolStatus = "placed"    // odds locked, awaiting the result
+	BetPoolSettled   BetPoolStatus = "settled"   // result paid out to contributors
+	BetPoolCancelled BetPoolStatus = "cancelled" // contributions refunded
+)
+
+// BetPoolMemberStatus is a player's standing in a bet pool.
+type BetPoolMemberStatus string
+
+const (
+	BetPoolMemberInvited  BetPoolMemberStatus = "invited"
+	BetPoolMemberJoined   BetPoolMemberStatus = "joined"
+	BetPoolMemberDeclined BetPoolMemberStatus = "declined"
+)
+
+// BetPool is a single sportsbook bet staked jointly by a group of players.
+type BetPool struct {
+	ID                   uuid.UUID     `json:"id"`
+	OwnerID              uuid.UUID     `json:"owner_id"`
+	Name                 string        `json:"name"`
+	EventID              *uuid.UUID    `json:"event_id,omitempty"`
+	MarketID             uuid.UUID     `json:"market_id"`
+	SelectionID          uuid.UUID     `json:"selection_id"`
+	MinContribution      int64         `json:"min_contribution"`
+	ContributionsCloseAt time.Time     `json:"contributions_close_at"`
+	Status               BetPoolStatus `json:"status"`
+	TotalStake           int64         `json:"total_stake"`
+	OddsAtPlacement      *int          `json:"odds_at_placement,omitempty"`
+	Result               *string       `json:"result,omitempty"`
+	Payout               int64         `json:"payout"`
+	GameRoundID          string        `json:"game_round_id"`
+	CreatedAt            time.Time     `json:"created_at"`
+	PlacedAt             *time.Time    `json:"placed_at,omitempty"`
+	SettledAt            *time.Time    `json:"settled_at,omitempty"`
+}
+
+// BetPoolMember is a player invited to a bet pool, with their contribution so
+// far and, once settled, their share of the return.
+type BetPoolMember struct {
+	PlayerID    uuid.UUID           `json:"player_id"`
+	Status      BetPoolMemberStatus `json:"status"`
+	InvitedBy   *uuid.UUID          `json:"invited_by,omitempty"`
+	InvitedAt   time.Time           `json:"invited_at"`
+	JoinedAt    *time.Time          `json:"joined_at,omitempty"`
+	Contributed int64               `json:"contributed"`
+	Payout      *int64              `json:"payout,omitempty"`
+}
+
+// BetPoolContribution is one stake paid into a bet pool.
+type BetPoolContribution struct {
+	ID            uuid.UUID `json:"id"`
+	PoolID        uuid.UUID `json:"pool_id"`
+	PlayerID      uuid.UUID `json:"player_id"`
+	Amount        int64     `json:"amount"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BetPoolMessage is a chat message posted to a bet pool by a member.
+type BetPoolMessage struct {
+	ID        uuid.UUID `json:"id"`
+	PoolID    uuid.UUID `json:"pool_id"`
+	PlayerID  uuid.UUID `json:"player_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BetPoolDetail is a bet pool with its members, as seen by a member.
+type BetPoolDetail struct {
+	BetPool
+	Members []BetPoolMember `json:"members"`
+}
@@ -16,15 +16,32 @@ const (
 	BonusStatusForfeited BonusStatus = "forfeited"
 )
 
-// Bonus represents a bonus definition.
+// BonusType identifies what granting a bonus awards.
+type BonusType string
+
+const (
+	BonusTypeCash      BonusType = "cash"       // credits MaxBonus to the bonus balance
+	BonusTypeFreeSpins BonusType = "free_spins" // awards free rounds at the game provider
+	// BonusTypeDepositMatch matches an opted-in player's next deposit at
+	// MatchPercent, capped at MaxBonus.
+	BonusTypeDepositMatch BonusType = "deposit_match"
+)
+
+// Bonus represents a bonus definition. The free spin fields are only set for
+// free_spins bonuses, and MatchPercent for deposit_match ones.
 type Bonus struct {
 	ID                  uuid.UUID `json:"id"`
 	Name                string    `json:"name"`
 	Code                string    `json:"code"`
+	Type                BonusType `json:"type"`
 	WageringMultiplier  float64   `json:"wagering_multiplier"`
 	MinDeposit          int64     `json:"min_deposit"`
 	MaxBonus            int64     `json:"max_bonus"`
 	DaysUntilExpiry     int       `json:"days_until_expiry"`
+	FreeSpins           int       `json:"free_spins,omitempty"`
+	FreeSpinValue       int64     `json:"free_spin_value,omitempty"` // stake per round, minor units
+	FreeSpinGames       []string  `json:"free_spin_games,omitempty"`
+	MatchPercent        int       `json:"match_percent,omitempty"`
 	Active              bool      `json:"active"`
 }
 
@@ -38,10 +55,73 @@ type PlayerBonus struct {
 	WageringRequirement int64       `json:"wagering_requirement"`
 	Wagered             int64       `json:"wagered"`
 	ExpiresAt           *time.Time  `json:"expires_at,omitempty"`
-	CreatedAt           time.Time   `json:"created_at"`
+	// PaymentID is the deposit a deposit_match bonus was awarded for.
+	PaymentID *uuid.UUID `json:"payment_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// BonusOptInStatus tracks a player's opt-in to a deposit_match bonus.
+type BonusOptInStatus string
+
+const (
+	BonusOptInPending   BonusOptInStatus = "pending"   // waiting for a qualifying deposit
+	BonusOptInClaimed   BonusOptInStatus = "claimed"   // matched; PlayerBonusID is set
+	BonusOptInCancelled BonusOptInStatus = "cancelled" // withdrawn by the player
+)
+
+// BonusOptIn represents a bonus_opt_ins row.
+type BonusOptIn struct {
+	ID            uuid.UUID        `json:"id"`
+	PlayerID      uuid.UUID        `json:"player_id"`
+	BonusID       uuid.UUID        `json:"bonus_id"`
+	Status        BonusOptInStatus `json:"status"`
+	PlayerBonusID *uuid.UUID       `json:"player_bonus_id,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// FreeSpinStatus tracks the lifecycle of a free spin grant.
+type FreeSpinStatus string
+
+const (
+	FreeSpinStatusActive    FreeSpinStatus = "active"
+	FreeSpinStatusCompleted FreeSpinStatus = "completed"
+	FreeSpinStatusExpired   FreeSpinStatus = "expired"
+	FreeSpinStatusRevoked   FreeSpinStatus = "revoked" // taken back by an admin
+)
+
+// FreeSpinGrant represents a free_spin_grants row: free rounds awarded to a
+// player at a game provider by a free_spins bonus.
+type FreeSpinGrant struct {
+	ID              uuid.UUID      `json:"id"`
+	PlayerID        uuid.UUID      `json:"player_id"`
+	BonusID         uuid.UUID      `json:"bonus_id"`
+	Provider        string         `json:"provider"`
+	ProviderGrantID string         `json:"provider_grant_id"`
+	GameIDs         []string       `json:"game_ids"`
+	Rounds          int            `json:"rounds"`
+	RoundsUsed      int            `json:"rounds_used"`
+	SpinValue       int64          `json:"spin_value"`
+	TotalWin        int64          `json:"total_win"`
+	Status          FreeSpinStatus `json:"status"`
+	PlayerBonusID   *uuid.UUID     `json:"player_bonus_id,omitempty"`
+	GrantedBy       *uuid.UUID     `json:"granted_by,omitempty"`
+	ExpiresAt       time.Time      `json:"expires_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// RoundsLeft returns how many free rounds remain.
+func (g *FreeSpinGrant) RoundsLeft() int {
+	return g.Rounds - g.RoundsUsed
 }
 
 // IsWageringComplete checks if the wagering requirement has been met.
 func (pb *PlayerBonus) IsWageringComplete() bool {
 	return pb.Wagered >= pb.WageringRequirement
 }
+
+// IsExpired reports whether the bonus's expiry has passed at now.
+func (pb *PlayerBonus) IsExpired(now time.Time) bool {
+	return pb.ExpiresAt != nil && !pb.ExpiresAt.After(now)
+}
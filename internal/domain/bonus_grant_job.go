@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BonusGrantJobStatus tracks the lifecycle of a bulk bonus grant.
+type BonusGrantJobStatus string
+
+const (
+	BonusGrantJobRunning     BonusGrantJobStatus = "running"
+	BonusGrantJobCompleted   BonusGrantJobStatus = "completed"
+	BonusGrantJobRollingBack BonusGrantJobStatus = "rolling_back"
+	BonusGrantJobRolledBack  BonusGrantJobStatus = "rolled_back"
+)
+
+// BonusGrantItemStatus tracks one player of a bulk bonus grant.
+type BonusGrantItemStatus string
+
+const (
+	BonusGrantItemPending      BonusGrantItemStatus = "pending"
+	BonusGrantItemGranting     BonusGrantItemStatus = "granting"
+	BonusGrantItemGranted      BonusGrantItemStatus = "granted"
+	BonusGrantItemSkipped      BonusGrantItemStatus = "skipped" // unknown or inactive player
+	BonusGrantItemFailed       BonusGrantItemStatus = "failed"
+	BonusGrantItemRevoking     BonusGrantItemStatus = "revoking"
+	BonusGrantItemRevoked      BonusGrantItemStatus = "revoked"
+	BonusGrantItemRevokeFailed BonusGrantItemStatus = "revoke_failed"
+)
+
+// BonusGrantProgress counts a bulk grant's players by outcome.
+type BonusGrantProgress struct {
+	Total        int `json:"total"`
+	Pending      int `json:"pending"`
+	Granted      int `json:"granted"`
+	Skipped      int `json:"skipped"`
+	Failed       int `json:"failed"`
+	Revoked      int `json:"revoked"`
+	RevokeFailed int `json:"revoke_failed"`
+}
+
+// BonusGrantJob represents a bonus_grant_jobs row with its progress.
+type BonusGrantJob struct {
+	ID           uuid.UUID           `json:"id"`
+	BonusID      uuid.UUID           `json:"bonus_id"`
+	Segment      *string             `json:"segment,omitempty"`
+	Status       BonusGrantJobStatus `json:"status"`
+	Progress     BonusGrantProgress  `json:"progress"`
+	CreatedBy    *uuid.UUID          `json:"created_by,omitempty"`
+	RolledBackBy *uuid.UUID          `json:"rolled_back_by,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	FinishedAt   *time.Time          `json:"finished_at,omitempty"`
+	RollbackAt   *time.Time          `json:"rollback_at,omitempty"`
+	RolledBackAt *time.Time          `json:"rolled_back_at,omitempty"`
+}
+
+// BonusGrantItem represents a bonus_grant_job_items row: what a bulk grant
+// did for one player.
+type BonusGrantItem struct {
+	PlayerID        uuid.UUID            `json:"player_id"`
+	Status          BonusGrantItemStatus `json:"status"`
+	PlayerBonusID   *uuid.UUID           `json:"player_bonus_id,omitempty"`
+	FreeSpinGrantID *uuid.UUID           `json:"free_spin_grant_id,omitempty"`
+	Error           *string              `json:"error,omitempty"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LobbyGame is a catalogue game as shown in the casino lobby.
+type LobbyGame struct {
+	ID             uuid.UUID `json:"id"`
+	ManufacturerID *string   `json:"manufacturer_id,omitempty"`
+	Provider       *string   `json:"provider,omitempty"`
+	ExternalGameID *string   `json:"external_game_id,omitempty"`
+	Name           string    `json:"name"`
+	Category       *string   `json:"category,omitempty"`
+	RTP            *float64  `json:"rtp,omitempty"`
+}
+
+// GameLaunch records a player opening a game. The session token is handed to
+// the game client, which passes it to the provider for wallet callbacks; games
+// without a manufacturer get none.
+type GameLaunch struct {
+	GameID           uuid.UUID  `json:"game_id"`
+	LaunchedAt       time.Time  `json:"launched_at"`
+	SessionToken     string     `json:"session_token,omitempty"`
+	SessionExpiresAt *time.Time `json:"session_expires_at,omitempty"`
+}
+
+// GameSessionToken is the game session a launch token stands for: the player,
+// the provider the game belongs to and the wallet currency played in.
+type GameSessionToken struct {
+	PlayerID       uuid.UUID
+	ManufacturerID string
+	Provider       *string // manufacturer name, when registered
+	GameID         *uuid.UUID
+	Currency       string
+	ExpiresAt      time.Time
+}
+
+// IssuedTo reports whether the session belongs to a wallet provider, named
+// either by manufacturer id or by manufacturer name.
+func (s *GameSessionToken) IssuedTo(provider string) bool {
+	return strings.EqualFold(s.ManufacturerID, provider) ||
+		(s.Provider != nil && strings.EqualFold(*s.Provider, provider))
+}
+
+// RecentGame is a game the player launched recently.
+type RecentGame struct {
+	LobbyGame
+	LastLaunchedAt time.Time `json:"last_launched_at"`
+	Launches       int       `json:"launches"`
+}
+
+// Game recommendation reasons, strongest first.
+const (
+	RecommendSimilarPlayers = "similar_players" // launched by players who play the same games
+	RecommendSameCategory   = "same_category"   // popular in a category the player plays
+	RecommendPopular        = "popular"         // popular across the casino
+)
+
+// RecommendedGame is a game suggested to a player, with why and how many
+// players launched it in the recommendation window.
+type RecommendedGame struct {
+	LobbyGame
+	Reason  string `json:"reason"`
+	Players int    `json:"players"`
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Consent purposes.
+const (
+	ConsentMarketing = "marketing" // promotional messages, per channel
+	ConsentAnalytics = "analytics" // behavioural tracking tied to the player
+	ConsentProfiling = "profiling" // segment targeting from the player's activity
+)
+
+// Consent channels. Analytics and profiling are not tied to a channel and
+// use ConsentChannelAll.
+const (
+	ConsentChannelEmail = "email"
+	ConsentChannelSMS   = "sms"
+	ConsentChannelPush  = "push"
+	ConsentChannelPhone = "phone"
+	ConsentChannelAll   = "all"
+)
+
+// ConsentSourcePlayer marks a change the player made themselves.
+const ConsentSourcePlayer = "player"
+
+// Consent is a player's current consent for one purpose and channel.
+// UpdatedAt is nil when nothing was ever recorded, so consent is not given.
+type Consent struct {
+	Purpose   string     `json:"purpose"`
+	Channel   string     `json:"channel"`
+	Granted   bool       `json:"granted"`
+	Source    string     `json:"source,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ConsentRecord represents a consents row: one grant or withdrawal.
+type ConsentRecord struct {
+	ID        int64     `json:"id"`
+	PlayerID  uuid.UUID `json:"player_id"`
+	Purpose   string    `json:"purpose"`
+	Channel   string    `json:"channel"`
+	Granted   bool      `json:"granted"`
+	Source    string    `json:"source"`
+	IP        *string   `json:"ip,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
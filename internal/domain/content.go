@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContentTranslation is the markdown title and body of a page in one locale.
+type ContentTranslation struct {
+	Title string `json:"title"`
+	Body  string `json:"body"` // markdown
+}
+
+// ContentPage represents a content_pages row with its latest version's
+// translations. A page is live once PublishedVersion is set.
+type ContentPage struct {
+	ID               uuid.UUID                     `json:"id"`
+	Slug             string                        `json:"slug"`
+	DefaultLocale    string                        `json:"default_locale"`
+	LatestVersion    int                           `json:"latest_version"`
+	PublishedVersion *int                          `json:"published_version,omitempty"`
+	PublishedAt      *time.Time                    `json:"published_at,omitempty"`
+	Translations     map[string]ContentTranslation `json:"translations"`
+	CreatedBy        *uuid.UUID                    `json:"created_by,omitempty"`
+	CreatedAt        time.Time                     `json:"created_at"`
+	UpdatedAt        time.Time                     `json:"updated_at"`
+}
+
+// ContentPageVersion is one saved revision of a page.
+type ContentPageVersion struct {
+	PageID       uuid.UUID                     `json:"page_id"`
+	Version      int                           `json:"version"`
+	Translations map[string]ContentTranslation `json:"translations"`
+	CreatedBy    *uuid.UUID                    `json:"created_by,omitempty"`
+	CreatedAt    time.Time                     `json:"created_at"`
+}
+
+// PublishedContent is the public view of a page in a single locale.
+type PublishedContent struct {
+	Slug        string    `json:"slug"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Version     int       `json:"version"`
+	PublishedAt time.Time `json:"published_at"`
+}
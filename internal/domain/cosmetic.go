@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CosmeticSource records how a player came to own a cosmetic item.
+type CosmeticSource string
+
+const (
+	CosmeticSourceStore       CosmeticSource = "store"
+	CosmeticSourceAchievement CosmeticSource = "achievement"
+	CosmeticSourceTournament  CosmeticSource = "tournament"
+	CosmeticSourceAdmin       CosmeticSource = "admin"
+)
+
+// CosmeticItem represents a cosmetic_items row. An item's slot is fixed once
+// created.
+type CosmeticItem struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Slot      string    `json:"slot"` // avatar, frame, hat, background, badge
+	Rarity    string    `json:"rarity"`
+	ImageURL  string    `json:"image_url"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PlayerCosmetic is an owned item in a player's inventory.
+type PlayerCosmetic struct {
+	ItemCode   string         `json:"item_code"`
+	Name       string         `json:"name"`
+	Slot       string         `json:"slot"`
+	Rarity     string         `json:"rarity"`
+	ImageURL   string         `json:"image_url"`
+	Source     CosmeticSource `json:"source"`
+	SourceRef  *string        `json:"source_ref,omitempty"`
+	Equipped   bool           `json:"equipped"`
+	AcquiredAt time.Time      `json:"acquired_at"`
+}
+
+// EquippedCosmetic is the public view of an equipped item.
+type EquippedCosmetic struct {
+	Slot     string `json:"slot"`
+	ItemCode string `json:"item_code"`
+	Name     string `json:"name"`
+	Rarity   string `json:"rarity"`
+	ImageURL string `json:"image_url"`
+}
+
+// PublicProfile is what other players see of a player.
+type PublicProfile struct {
+	PlayerID    uuid.UUID          `json:"player_id"`
+	DisplayName string             `json:"display_name"`
+	MemberSince time.Time          `json:"member_since"`
+	Equipped    []EquippedCosmetic `json:"equipped"`
+}
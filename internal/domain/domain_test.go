@@ -433,3 +433,155 @@ func TestNewLimitBreachedEvent(t *testing.T) {
 	assert.Equal(t, float64(100000), payload["limit_value"])
 	assert.Equal(t, float64(150000), payload["requested_amount"])
 }
+
+func TestNewExperimentExposedEvent(t *testing.T) {
+	playerID := uuid.New()
+	event := NewExperimentExposedEvent(playerID, "welcome_bonus", "treatment")
+
+	assert.Equal(t, EventExperimentExposed, event.EventType)
+	assert.Equal(t, playerID.String(), event.PartitionKey)
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, "welcome_bonus", payload["experiment"])
+	assert.Equal(t, "treatment", payload["variant"])
+}
+
+func TestNewLedgerDriftDetectedEvent(t *testing.T) {
+	playerID := uuid.New()
+	event := NewLedgerDriftDetectedEvent(LedgerDiscrepancy{
+		ID: 7, PlayerID: playerID, Currency: "EUR", Kind: "snapshot_mismatch",
+		Stored: Balances{Balance: 9000}, Expected: Balances{Balance: 5000},
+	})
+
+	assert.Equal(t, EventLedgerDriftDetected, event.EventType)
+	assert.Equal(t, AggregateWallet, event.AggregateType)
+	assert.Equal(t, playerID.String(), event.AggregateID)
+
+	var payload LedgerDiscrepancy
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, int64(9000), payload.Stored.Balance)
+	assert.Equal(t, int64(5000), payload.Expected.Balance)
+}
+
+func TestNewRGInterventionTriggeredEvent(t *testing.T) {
+	playerID := uuid.New()
+	event := NewRGInterventionTriggeredEvent(RGIntervention{
+		ID: uuid.New(), PlayerID: playerID, Kind: "email_nudge", Jurisdiction: "GB",
+		NetLoss: 30000, Threshold: 25000, WindowDays: 30,
+	})
+
+	assert.Equal(t, EventRGInterventionTriggered, event.EventType)
+	assert.Equal(t, AggregatePlayer, event.AggregateType)
+	assert.Equal(t, playerID.String(), event.PartitionKey)
+
+	var payload RGIntervention
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, "email_nudge", payload.Kind)
+	assert.Equal(t, int64(30000), payload.NetLoss)
+}
+
+func TestNewPaymentStatusChangedEvent(t *testing.T) {
+	playerID := uuid.New()
+	payment := Payment{ID: uuid.New(), PlayerID: playerID, Type: PaymentTypeDeposit, Amount: 5000, Currency: "EUR"}
+	event := NewPaymentStatusChangedEvent(payment, PaymentStatusCompleted)
+
+	assert.Equal(t, EventPaymentStatusChanged, event.EventType)
+	assert.Equal(t, AggregatePayment, event.AggregateType)
+	assert.Equal(t, payment.ID.String(), event.AggregateID)
+	assert.Equal(t, playerID.String(), event.PartitionKey)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, "completed", payload["status"])
+	assert.Equal(t, float64(5000), payload["amount"])
+}
+
+func TestNewBetSettledEvent(t *testing.T) {
+	playerID, betID := uuid.New(), uuid.New()
+	event := NewBetSettledEvent(playerID, betID, 1000, "won", 2500)
+
+	assert.Equal(t, EventBetSettled, event.EventType)
+	assert.Equal(t, playerID.String(), event.PartitionKey)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	assert.Equal(t, betID.String(), payload["bet_id"])
+	assert.Equal(t, float64(2500), payload["return"])
+}
+
+func TestTermsContentHash(t *testing.T) {
+	h := TermsContentHash("Terms", "body")
+	assert.Len(t, h, 64)
+	assert.Equal(t, h, TermsContentHash("Terms", "body"))
+	assert.NotEqual(t, h, TermsContentHash("Terms", "body."))
+	assert.True(t, TermsGeneral.Valid())
+	assert.False(t, TermsKind("privacy").Valid())
+}
+
+func TestAccountStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to AccountStatus
+		want     bool
+	}{
+		{AccountStatusActive, AccountStatusSuspended, true},
+		{AccountStatusActive, AccountStatusSelfExcluded, true},
+		{AccountStatusSuspended, AccountStatusActive, true},
+		{AccountStatusSelfExcluded, AccountStatusSuspended, false},
+		{AccountStatusClosed, AccountStatusActive, false},
+		{AccountStatusActive, AccountStatusActive, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestParseAccountStatus(t *testing.T) {
+	status, err := ParseAccountStatus("self_excluded")
+	require.NoError(t, err)
+	assert.Equal(t, AccountStatusSelfExcluded, status)
+
+	_, err = ParseAccountStatus("banned")
+	assert.Error(t, err)
+}
+
+// --- BetReceipt Tests ---
+
+func TestBetReceipt_SignVerify(t *testing.T) {
+	key := []byte("receipt-signing-key")
+	eventID := uuid.New()
+	starts := time.Date(2026, 5, 2, 15, 0, 0, 0, time.FixedZone("BST", 3600))
+	r := BetReceipt{
+		BetID:    uuid.New(),
+		PlayerID: uuid.New(),
+		PlacedAt: time.Date(2026, 5, 1, 9, 30, 0, 123456000, time.Local),
+		Selections: []BetReceiptSelection{{
+			EventID: &eventID, Event: "Team A v Team B", StartsAt: &starts,
+			MarketID: uuid.New(), Market: "Match Winner", SelectionID: uuid.New(), Selection: "Team A", Odds: 250,
+		}},
+		Stake:           1000,
+		Currency:        "EUR",
+		PotentialPayout: 2500,
+		TermsHash:       TermsContentHash("Terms", "body"),
+		IssuedAt:        time.Now(),
+	}
+	r.Signature = r.Sign(key)
+	assert.True(t, r.Verify(key))
+
+	// Survives a JSON round trip.
+	raw, err := json.Marshal(r)
+	require.NoError(t, err)
+	var decoded BetReceipt
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.True(t, decoded.Verify(key))
+
+	assert.False(t, r.Verify([]byte("other-key")))
+
+	tampered := decoded
+	tampered.PotentialPayout = 25000
+	assert.False(t, tampered.Verify(key))
+
+	tampered = decoded
+	tampered.Signature = "not-hex"
+	assert.False(t, tampered.Verify(key))
+}
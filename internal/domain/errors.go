@@ -1,13 +1,17 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // AppError is the base domain error type.
 type AppError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Status  int    `json:"-"`
-	Cause   error  `json:"-"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Status  int                    `json:"-"`
+	Cause   error                  `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -53,6 +57,199 @@ func ErrAccountLocked(msg string) *AppError {
 	return &AppError{Code: "ACCOUNT_LOCKED", Message: msg, Status: 429}
 }
 
+// ErrAccountSuspended is returned by wallet and betting paths while a
+// suspension is in force. reinstateAt is nil for open-ended suspensions.
+func ErrAccountSuspended(reinstateAt *time.Time) *AppError {
+	err := &AppError{Code: "ACCOUNT_SUSPENDED", Message: "account is suspended", Status: 403}
+	if reinstateAt != nil {
+		err.Message = fmt.Sprintf("account is suspended until %s", reinstateAt.UTC().Format(time.RFC3339))
+		err.Details = map[string]interface{}{"reinstate_at": reinstateAt.UTC()}
+	}
+	return err
+}
+
+// ErrAccountInactive is returned when a self-excluded or closed account
+// attempts a wallet or betting operation.
+func ErrAccountInactive(status AccountStatus) *AppError {
+	return &AppError{
+		Code:    "ACCOUNT_INACTIVE",
+		Message: fmt.Sprintf("account is %s", status),
+		Details: map[string]interface{}{"account_status": status},
+		Status:  403,
+	}
+}
+
+// ErrUnderage is returned when a player is below the minimum gambling age
+// for their jurisdiction.
+func ErrUnderage(minimumAge int) *AppError {
+	return &AppError{
+		Code:    "UNDERAGE",
+		Message: fmt.Sprintf("players must be at least %d years old", minimumAge),
+		Details: map[string]interface{}{"minimum_age": minimumAge},
+		Status:  403,
+	}
+}
+
+// ErrSourceOfFundsRequired is returned while a player who crossed a
+// cumulative-deposit threshold has not submitted the questionnaire for it.
+func ErrSourceOfFundsRequired(questionnaireID string, threshold int64) *AppError {
+	return &AppError{
+		Code:    "SOURCE_OF_FUNDS_REQUIRED",
+		Message: "a source-of-funds questionnaire must be submitted before depositing",
+		Details: map[string]interface{}{"questionnaire_id": questionnaireID, "threshold": threshold},
+		Status:  403,
+	}
+}
+
+// ErrKYCRequired is returned for a withdrawal above the KYC threshold while
+// the player's identity documents have not been approved.
+func ErrKYCRequired(threshold int64) *AppError {
+	return &AppError{
+		Code:    "KYC_REQUIRED",
+		Message: "identity verification is required for withdrawals above the threshold",
+		Details: map[string]interface{}{"threshold": threshold},
+		Status:  403,
+	}
+}
+
+// ErrTermsAcceptanceRequired is returned while a player has not accepted the
+// current version of every published terms document.
+func ErrTermsAcceptanceRequired(pending []TermsDocument) *AppError {
+	return &AppError{
+		Code:    "TERMS_ACCEPTANCE_REQUIRED",
+		Message: "the latest terms must be accepted before continuing",
+		Details: map[string]interface{}{"pending_terms": pending},
+		Status:  403,
+	}
+}
+
+// ErrIdempotencyKeyInProgress is returned when a request repeats an
+// Idempotency-Key whose first request has not finished.
+func ErrIdempotencyKeyInProgress() *AppError {
+	return &AppError{
+		Code:    "IDEMPOTENCY_KEY_IN_PROGRESS",
+		Message: "a request with this idempotency key is still in progress",
+		Status:  409,
+	}
+}
+
+// ErrIdempotencyKeyReused is returned when an Idempotency-Key is sent again
+// with a different request.
+func ErrIdempotencyKeyReused() *AppError {
+	return &AppError{
+		Code:    "IDEMPOTENCY_KEY_REUSED",
+		Message: "idempotency key was already used for a different request",
+		Status:  422,
+	}
+}
+
+// ErrTransferRejected is returned when limits or AML checks refuse a
+// player-to-player transfer.
+func ErrTransferRejected(reason string) *AppError {
+	return &AppError{
+		Code:    "TRANSFER_REJECTED",
+		Message: "transfer was rejected",
+		Details: map[string]interface{}{"reason": reason},
+		Status:  422,
+	}
+}
+
+// ErrRedemptionIneligible is returned when a sweepstakes redemption fails
+// its eligibility checks.
+func ErrRedemptionIneligible(reason string) *AppError {
+	return &AppError{
+		Code:    "REDEMPTION_INELIGIBLE",
+		Message: "redemption is not allowed",
+		Details: map[string]interface{}{"reason": reason},
+		Status:  422,
+	}
+}
+
+// ErrWithdrawalDestinationRejected is returned when a withdrawal targets a
+// payment method the closed-loop policy does not allow.
+func ErrWithdrawalDestinationRejected(reason string) *AppError {
+	return &AppError{
+		Code:    "WITHDRAWAL_DESTINATION_REJECTED",
+		Message: "withdrawal destination is not allowed",
+		Details: map[string]interface{}{"reason": reason},
+		Status:  422,
+	}
+}
+
+// ErrRGLimitBreached is returned when a deposit or stake would exceed a
+// responsible-gaming limit such as "daily_deposit" or "weekly_loss".
+func ErrRGLimitBreached(action, limit string, value int64) *AppError {
+	return &AppError{
+		Code:    "RG_LIMIT_BREACHED",
+		Message: fmt.Sprintf("%s exceeds %s limit", action, limit),
+		Details: map[string]interface{}{"limit": limit, "value": value},
+		Status:  422,
+	}
+}
+
+// ErrOddsChanged is returned when a selection's odds moved beyond the
+// tolerance since the bettor saw them; the bet can be placed again at the
+// current odds.
+func ErrOddsChanged(expected, current int) *AppError {
+	return &AppError{
+		Code:    "ODDS_CHANGED",
+		Message: fmt.Sprintf("odds changed from %d to %d", expected, current),
+		Details: map[string]interface{}{"expected_odds": expected, "current_odds": current},
+		Status:  409,
+	}
+}
+
+// ErrMarketSuspended is returned when a bet is placed on a suspended market.
+// until is when an automatic freeze ends; nil means the market was suspended
+// until further notice.
+func ErrMarketSuspended(until *time.Time) *AppError {
+	details := map[string]interface{}{}
+	if until != nil {
+		details["suspended_until"] = until.UTC().Format(time.RFC3339)
+	}
+	return &AppError{
+		Code:    "MARKET_SUSPENDED",
+		Message: "market is suspended",
+		Details: details,
+		Status:  409,
+	}
+}
+
+// ErrExposureLimitExceeded is returned when a stake's potential payout would
+// take the book past a liability limit. maxStake is the largest stake that
+// fits, possibly zero.
+func ErrExposureLimitExceeded(maxStake int64) *AppError {
+	return &AppError{
+		Code:    "EXPOSURE_LIMIT_EXCEEDED",
+		Message: "stake exceeds the available liability",
+		Details: map[string]interface{}{"max_stake": maxStake},
+		Status:  422,
+	}
+}
+
+// ErrIPBlocked is returned when IP screening blocks a registration,
+// deposit or bet, for example from a VPN or proxy.
+func ErrIPBlocked(checkpoint string) *AppError {
+	return &AppError{
+		Code:    "IP_BLOCKED",
+		Message: fmt.Sprintf("%s is not allowed from this network", checkpoint),
+		Details: map[string]interface{}{"checkpoint": checkpoint},
+		Status:  403,
+	}
+}
+
+// ErrQuestLocked is returned when a player claims a quest before claiming
+// its prerequisites; lockedBy lists the prerequisite quest IDs still
+// unclaimed.
+func ErrQuestLocked(lockedBy []string) *AppError {
+	return &AppError{
+		Code:    "QUEST_LOCKED",
+		Message: "quest is locked until its prerequisites are claimed",
+		Details: map[string]interface{}{"locked_by": lockedBy},
+		Status:  409,
+	}
+}
+
 func ErrInternal(msg string, cause error) *AppError {
 	return &AppError{Code: "INTERNAL_ERROR", Message: msg, Status: 500, Cause: cause}
 }
@@ -11,22 +11,42 @@ import (
 type EventType string
 
 const (
-	EventPlayerCreated          EventType = "pam.player.created"
-	EventSessionCreated         EventType = "pam.session.created"
-	EventSessionRevoked         EventType = "pam.session.revoked"
-	EventTransactionPosted      EventType = "pam.wallet.transaction.posted"
-	EventWalletRouteAccepted    EventType = "pam.wallet.route.accepted"
-	EventWalletRouteRejected    EventType = "pam.wallet.route.rejected"
-	EventLimitBreached          EventType = "pam.limit.breached"
-	EventSelfExclusionEnabled   EventType = "pam.selfexclusion.enabled"
-	EventSelfExclusionDisabled  EventType = "pam.selfexclusion.disabled"
-	EventPluginDispatchReq      EventType = "pam.plugin.dispatch.requested"
-	EventPluginDispatchDone     EventType = "pam.plugin.dispatch.completed"
-	EventPluginDispatchFailed   EventType = "pam.plugin.dispatch.failed"
-	EventPluginDispatchFallback EventType = "pam.plugin.dispatch.fallback"
-	EventPluginOutputBlocked    EventType = "pam.plugin.output.blocked"
-	EventPluginOutputFlagged    EventType = "pam.plugin.output.flagged"
-	EventPluginOutputPassed     EventType = "pam.plugin.output.passed"
+	EventPlayerCreated           EventType = "pam.player.created"
+	EventSessionCreated          EventType = "pam.session.created"
+	EventSessionRevoked          EventType = "pam.session.revoked"
+	EventTransactionPosted       EventType = "pam.wallet.transaction.posted"
+	EventWalletRouteAccepted     EventType = "pam.wallet.route.accepted"
+	EventWalletRouteRejected     EventType = "pam.wallet.route.rejected"
+	EventPlayerStatusChanged     EventType = "pam.player.status.changed"
+	EventLimitBreached           EventType = "pam.limit.breached"
+	EventSelfExclusionEnabled    EventType = "pam.selfexclusion.enabled"
+	EventSelfExclusionDisabled   EventType = "pam.selfexclusion.disabled"
+	EventPluginDispatchReq       EventType = "pam.plugin.dispatch.requested"
+	EventPluginDispatchDone      EventType = "pam.plugin.dispatch.completed"
+	EventPluginDispatchFailed    EventType = "pam.plugin.dispatch.failed"
+	EventPluginDispatchFallback  EventType = "pam.plugin.dispatch.fallback"
+	EventPluginOutputBlocked     EventType = "pam.plugin.output.blocked"
+	EventPluginOutputFlagged     EventType = "pam.plugin.output.flagged"
+	EventPluginOutputPassed      EventType = "pam.plugin.output.passed"
+	EventExperimentExposed       EventType = "pam.experiment.exposed"
+	EventLedgerDriftDetected     EventType = "pam.wallet.ledger.drift_detected"
+	EventRGInterventionTriggered EventType = "pam.rg.intervention.triggered"
+	EventBetReceiptRequested     EventType = "pam.sportsbook.bet_receipt.requested"
+	EventP2PTransferReceived     EventType = "pam.wallet.p2p_transfer.received"
+	EventTradingAlertRaised      EventType = "pam.sportsbook.trading_alert.raised"
+	EventBonusExpired            EventType = "pam.bonus.expired"
+	EventBonusExpiring           EventType = "pam.bonus.expiring"
+	EventConsentChanged          EventType = "pam.player.consent.changed"
+	EventGameRTPAlertRaised      EventType = "pam.casino.rtp_alert.raised"
+	EventPaymentStatusChanged    EventType = "pam.payment.status.changed"
+	EventBonusAwarded            EventType = "pam.bonus.awarded"
+	EventQuestRewardClaimed      EventType = "pam.quest.reward.claimed"
+	EventQuestCompleted          EventType = "pam.quest.completed"
+	EventEngagementSignal        EventType = "pam.engagement.signal.recorded"
+	EventBetSettled              EventType = "pam.sportsbook.bet.settled"
+	EventPredictionStakeSettled  EventType = "pam.prediction.stake.settled"
+	EventBackupEmailVerify       EventType = "pam.player.backup_email.verification_requested"
+	EventRecoveryResetIssued     EventType = "pam.player.recovery.reset_issued"
 )
 
 // AggregateType enumerates the aggregate root types for outbox events.
@@ -37,6 +57,9 @@ const (
 	AggregateSession AggregateType = "session"
 	AggregateWallet  AggregateType = "wallet"
 	AggregatePlugin  AggregateType = "plugin"
+	AggregateMarket  AggregateType = "market"
+	AggregateGame    AggregateType = "game"
+	AggregatePayment AggregateType = "payment"
 )
 
 // OutboxDraft is the payload written to the event_outbox table.
@@ -51,3 +74,13 @@ type OutboxDraft struct {
 	Payload       json.RawMessage `json:"payload"`
 	OccurredAt    time.Time       `json:"occurredAt"`
 }
+
+// OutboxDeadLetter is an outbox event moved to event_outbox_dlq after
+// exhausting its publish retries.
+type OutboxDeadLetter struct {
+	ID int64 `json:"id"`
+	OutboxDraft
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError"`
+	FailedAt  time.Time `json:"failedAt"`
+}
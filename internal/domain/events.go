@@ -106,3 +106,422 @@ func NewLimitBreachedEvent(playerID uuid.UUID, limitType string, limitValue, req
 		OccurredAt:    time.Now(),
 	}
 }
+
+// NewSessionRevokedEvent creates a session revocation event.
+func NewSessionRevokedEvent(playerID uuid.UUID, reason string, sessionsRevoked int64) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id":        playerID.String(),
+		"reason":           reason,
+		"sessions_revoked": sessionsRevoked,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregateSession,
+		AggregateID:   playerID.String(),
+		EventType:     EventSessionRevoked,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewPlayerStatusChangedEvent creates an account status transition event.
+func NewPlayerStatusChangedEvent(change *PlayerStatusChange) OutboxDraft {
+	payload, _ := json.Marshal(change)
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   change.PlayerID.String(),
+		EventType:     EventPlayerStatusChanged,
+		PartitionKey:  change.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewExperimentExposedEvent records a player's first exposure to an experiment variant.
+func NewExperimentExposedEvent(playerID uuid.UUID, experimentKey, variant string) OutboxDraft {
+	payload, _ := json.Marshal(map[string]string{
+		"player_id":  playerID.String(),
+		"experiment": experimentKey,
+		"variant":    variant,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventExperimentExposed,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewLedgerDriftDetectedEvent alerts that a wallet's stored balances no longer
+// match its transaction history.
+func NewLedgerDriftDetectedEvent(d LedgerDiscrepancy) OutboxDraft {
+	payload, _ := json.Marshal(d)
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregateWallet,
+		AggregateID:   d.PlayerID.String(),
+		EventType:     EventLedgerDriftDetected,
+		PartitionKey:  d.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewRGInterventionTriggeredEvent announces an automated responsible-gambling
+// intervention; the notification consumer delivers email nudges from it.
+func NewRGInterventionTriggeredEvent(i RGIntervention) OutboxDraft {
+	payload, _ := json.Marshal(i)
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   i.PlayerID.String(),
+		EventType:     EventRGInterventionTriggered,
+		PartitionKey:  i.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewBetReceiptRequestedEvent asks for a signed bet receipt to be emailed to
+// the player; the notification consumer renders and sends it.
+func NewBetReceiptRequestedEvent(r BetReceipt, email string) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"email":   email,
+		"receipt": r,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   r.PlayerID.String(),
+		EventType:     EventBetReceiptRequested,
+		PartitionKey:  r.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewP2PTransferReceivedEvent tells a recipient a player sent them funds; it
+// is partitioned by the recipient so their notifications stay ordered.
+func NewP2PTransferReceivedEvent(t P2PTransfer) OutboxDraft {
+	payload, _ := json.Marshal(t)
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   t.RecipientID.String(),
+		EventType:     EventP2PTransferReceived,
+		PartitionKey:  t.RecipientID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewTradingAlertRaisedEvent publishes an unusual stake pattern to the
+// alerting consumers; it is partitioned by market.
+func NewTradingAlertRaisedEvent(a TradingAlert) OutboxDraft {
+	payload, _ := json.Marshal(a)
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregateMarket,
+		AggregateID:   a.MarketID.String(),
+		EventType:     EventTradingAlertRaised,
+		PartitionKey:  a.MarketID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewGameRTPAlertRaisedEvent publishes a game whose actual RTP left its
+// confidence band; it is partitioned by game.
+func NewGameRTPAlertRaisedEvent(a GameRTPAlert) OutboxDraft {
+	payload, _ := json.Marshal(a)
+	key := a.ManufacturerID + ":" + a.GameID
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregateGame,
+		AggregateID:   key,
+		EventType:     EventGameRTPAlertRaised,
+		PartitionKey:  key,
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewBonusExpiredEvent notifies CRM that a player's bonus expired with its
+// wagering unfinished, and how much bonus balance was forfeited with it.
+func NewBonusExpiredEvent(b PlayerBonus, forfeited int64) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_bonus_id":      b.ID.String(),
+		"player_id":            b.PlayerID.String(),
+		"bonus_id":             b.BonusID.String(),
+		"initial_amount":       b.InitialAmount,
+		"wagering_requirement": b.WageringRequirement,
+		"wagered":              b.Wagered,
+		"expires_at":           b.ExpiresAt,
+		"forfeited":            forfeited,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   b.PlayerID.String(),
+		EventType:     EventBonusExpired,
+		PartitionKey:  b.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewBonusExpiringEvent asks CRM to remind a player that a bonus with
+// unfinished wagering is about to expire.
+func NewBonusExpiringEvent(b PlayerBonus) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_bonus_id":      b.ID.String(),
+		"player_id":            b.PlayerID.String(),
+		"bonus_id":             b.BonusID.String(),
+		"initial_amount":       b.InitialAmount,
+		"wagering_requirement": b.WageringRequirement,
+		"wagered":              b.Wagered,
+		"expires_at":           b.ExpiresAt,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   b.PlayerID.String(),
+		EventType:     EventBonusExpiring,
+		PartitionKey:  b.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewConsentChangedEvent tells CRM and analytics consumers which of a
+// player's consents changed, so withdrawals are honoured downstream too.
+func NewConsentChangedEvent(playerID uuid.UUID, changed []ConsentRecord) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID.String(),
+		"changes":   changed,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventConsentChanged,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewPaymentStatusChangedEvent publishes a payment reaching status; it is
+// partitioned by player so a player's payment events stay ordered.
+func NewPaymentStatusChangedEvent(p Payment, status PaymentStatus) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"payment_id": p.ID.String(),
+		"player_id":  p.PlayerID.String(),
+		"type":       p.Type,
+		"amount":     p.Amount,
+		"currency":   p.Currency,
+		"provider":   p.Provider,
+		"status":     status,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePayment,
+		AggregateID:   p.ID.String(),
+		EventType:     EventPaymentStatusChanged,
+		PartitionKey:  p.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewBonusAwardedEvent announces a bonus granted to a player.
+func NewBonusAwardedEvent(b PlayerBonus) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_bonus_id":      b.ID.String(),
+		"player_id":            b.PlayerID.String(),
+		"bonus_id":             b.BonusID.String(),
+		"initial_amount":       b.InitialAmount,
+		"wagering_requirement": b.WageringRequirement,
+		"expires_at":           b.ExpiresAt,
+		"payment_id":           b.PaymentID,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   b.PlayerID.String(),
+		EventType:     EventBonusAwarded,
+		PartitionKey:  b.PlayerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewQuestRewardClaimedEvent records a player claiming a completed quest's
+// reward.
+func NewQuestRewardClaimedEvent(playerID, questID uuid.UUID, amount int64, currency string) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID.String(),
+		"quest_id":  questID.String(),
+		"amount":    amount,
+		"currency":  currency,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventQuestRewardClaimed,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewBetSettledEvent publishes a sportsbook bet's settlement: its stake, its
+// status and what it returned, the stake itself when refunded.
+func NewBetSettledEvent(playerID, betID uuid.UUID, stake int64, status string, returned int64) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID.String(),
+		"bet_id":    betID.String(),
+		"stake":     stake,
+		"status":    status,
+		"return":    returned,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventBetSettled,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewPredictionStakeSettledEvent publishes a prediction stake closed with its
+// market or sold back before it: its amount, won, lost, void or sold, and
+// the payout a win or sale earned.
+func NewPredictionStakeSettledEvent(playerID, stakeID, marketID uuid.UUID, stake int64, status string, payout int64) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID.String(),
+		"stake_id":  stakeID.String(),
+		"market_id": marketID.String(),
+		"stake":     stake,
+		"status":    status,
+		"payout":    payout,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventPredictionStakeSettled,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewQuestCompletedEvent records a player reaching a quest's target, which
+// makes its reward claimable.
+func NewQuestCompletedEvent(playerID, questID uuid.UUID) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID.String(),
+		"quest_id":  questID.String(),
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventQuestCompleted,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewEngagementSignalEvent records an engagement signal a player's client
+// reported: its type (video, social, ...) and value.
+func NewEngagementSignalEvent(playerID uuid.UUID, signal string, value int) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID.String(),
+		"signal":    signal,
+		"value":     value,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventEngagementSignal,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewBackupEmailVerifyEvent asks for a verification token to be emailed to a
+// backup email the player registered; the notification consumer sends it.
+// The payload carries the raw token, so other consumers must not log it.
+func NewBackupEmailVerifyEvent(playerID uuid.UUID, email, token string, expiresAt time.Time) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id":  playerID.String(),
+		"email":      email,
+		"token":      token,
+		"expires_at": expiresAt,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventBackupEmailVerify,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewRecoveryResetIssuedEvent asks for the password reset token of an
+// approved account recovery to be emailed to the player's verified backup
+// email. Like NewBackupEmailVerifyEvent, the payload carries the raw token.
+func NewRecoveryResetIssuedEvent(playerID, requestID uuid.UUID, email, token string, expiresAt time.Time) OutboxDraft {
+	payload, _ := json.Marshal(map[string]interface{}{
+		"player_id":  playerID.String(),
+		"request_id": requestID.String(),
+		"email":      email,
+		"token":      token,
+		"expires_at": expiresAt,
+	})
+	return OutboxDraft{
+		EventID:       uuid.New(),
+		AggregateType: AggregatePlayer,
+		AggregateID:   playerID.String(),
+		EventType:     EventRecoveryResetIssued,
+		PartitionKey:  playerID.String(),
+		Headers:       json.RawMessage(`{}`),
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExperimentStatus tracks the lifecycle of an experiment.
+type ExperimentStatus string
+
+const (
+	ExperimentDraft   ExperimentStatus = "draft"
+	ExperimentRunning ExperimentStatus = "running"
+	ExperimentStopped ExperimentStatus = "stopped"
+)
+
+// FeatureFlag is a named switch with an optional JSON value.
+type FeatureFlag struct {
+	Key         string          `json:"key"`
+	Description *string         `json:"description,omitempty"`
+	Enabled     bool            `json:"enabled"`
+	Value       json.RawMessage `json:"value"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// ExperimentVariant is one arm of an experiment. Weight is relative to the
+// other variants; Value replaces the flag value for players in this arm.
+type ExperimentVariant struct {
+	Name   string          `json:"name"`
+	Weight int             `json:"weight"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// Experiment splits players across variants by a stable hash of their ID.
+type Experiment struct {
+	ID          uuid.UUID           `json:"id"`
+	Key         string              `json:"key"`
+	FlagKey     *string             `json:"flag_key,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Status      ExperimentStatus    `json:"status"`
+	TrafficPct  int                 `json:"traffic_pct"`
+	Variants    []ExperimentVariant `json:"variants"`
+	StartedAt   *time.Time          `json:"started_at,omitempty"`
+	StoppedAt   *time.Time          `json:"stopped_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// FlagEvaluation is a flag's value for one player. Experiment and Variant are
+// set when a running experiment decided the value.
+type FlagEvaluation struct {
+	Enabled    bool            `json:"enabled"`
+	Value      json.RawMessage `json:"value"`
+	Experiment string          `json:"experiment,omitempty"`
+	Variant    string          `json:"variant,omitempty"`
+}
+
+// VariantResult aggregates outcomes for players exposed to one variant.
+// Deposits and bets count only activity after the player's first exposure.
+type VariantResult struct {
+	Variant           string `json:"variant"`
+	Exposed           int64  `json:"exposed"`
+	DepositingPlayers int64  `json:"depositing_players"`
+	DepositTotal      int64  `json:"deposit_total"`
+	BetTotal          int64  `json:"bet_total"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportJobStatus tracks the lifecycle of an admin export job.
+type ExportJobStatus string
+
+const (
+	ExportJobQueued    ExportJobStatus = "queued"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// Export kinds an export job can produce.
+const (
+	ExportKindTransactions = "transactions"
+	ExportKindPlayers      = "players"
+)
+
+// ExportJob represents an admin_export_jobs row. DownloadURL is a presigned
+// link to the file, set only on completed jobs when they are read.
+type ExportJob struct {
+	ID          uuid.UUID       `json:"id"`
+	AdminID     uuid.UUID       `json:"admin_id"`
+	Kind        string          `json:"kind"`
+	Format      string          `json:"format"`
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	Status      ExportJobStatus `json:"status"`
+	TotalRows   int             `json:"total_rows"`
+	RowsWritten int             `json:"rows_written"`
+	Error       *string         `json:"error,omitempty"`
+	DownloadURL string          `json:"download_url,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+	ObjectKey   *string         `json:"-"`
+}
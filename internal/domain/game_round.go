@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameRoundStatus is the lifecycle state of a provider game round.
+type GameRoundStatus string
+
+const (
+	GameRoundOpen   GameRoundStatus = "open"   // stakes placed, awaiting the provider's result
+	GameRoundClosed GameRoundStatus = "closed" // settled by a win or a loss
+	GameRoundVoided GameRoundStatus = "voided" // abandoned; unsettled stakes refunded
+)
+
+// GameRound represents a game_rounds row: one provider round for a player,
+// opened by the ledger on its first stake and closed on the result.
+type GameRound struct {
+	ID             uuid.UUID       `json:"id"`
+	PlayerID       uuid.UUID       `json:"player_id"`
+	ManufacturerID string          `json:"manufacturer_id"`
+	RoundID        string          `json:"round_id"`
+	GameID         *string         `json:"game_id,omitempty"`
+	Currency       *string         `json:"currency,omitempty"`
+	Status         GameRoundStatus `json:"status"`
+	StakeTotal     int64           `json:"stake_total"`
+	WinTotal       int64           `json:"win_total"`
+	RefundTotal    int64           `json:"refund_total"`
+	OpenedAt       time.Time       `json:"opened_at"`
+	LastActivityAt time.Time       `json:"last_activity_at"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+}
+
+// CloseRoundParams holds the input for ExecuteCloseRound: a provider's
+// losing result, which settles the round without moving money.
+type CloseRoundParams struct {
+	PlayerID       uuid.UUID
+	ManufacturerID string
+	GameRoundID    string
+	Currency       string
+}
+
+// VoidRoundParams holds the input for ExecuteVoidRound. The round is only
+// voided if it is still open and has been idle since IdleSince.
+type VoidRoundParams struct {
+	RoundID   uuid.UUID // game_rounds.id
+	PlayerID  uuid.UUID
+	IdleSince time.Time
+}
+
+// GameSession is a run of a player's rounds on one game, assembled from
+// game_rounds: a gap between rounds longer than the session gap starts a new
+// session. Net is what the player won back less what they staked, so a
+// losing session is negative.
+type GameSession struct {
+	ManufacturerID string     `json:"manufacturer_id"`
+	Provider       *string    `json:"provider,omitempty"`
+	GameID         *string    `json:"game_id,omitempty"`
+	GameName       *string    `json:"game_name,omitempty"`
+	Currency       *string    `json:"currency,omitempty"`
+	StartedAt      time.Time  `json:"started_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	Rounds         int        `json:"rounds"`
+	TotalBet       int64      `json:"total_bet"`
+	TotalWin       int64      `json:"total_win"`
+	TotalRefund    int64      `json:"total_refund"`
+	Net            int64      `json:"net"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameRTPDay is a game_rtp_daily row: one game's casino stakes and wins on
+// one day, with its actual RTP checked against the theoretical RTP band.
+// RTP figures are percentages.
+type GameRTPDay struct {
+	ManufacturerID string    `json:"manufacturer_id"`
+	GameID         string    `json:"game_id"`
+	Day            time.Time `json:"day"`
+	GameName       *string   `json:"game_name,omitempty"`
+	TheoreticalRTP *float64  `json:"theoretical_rtp,omitempty"`
+	ActualRTP      float64   `json:"actual_rtp"`
+	LowerBound     *float64  `json:"lower_bound,omitempty"`
+	UpperBound     *float64  `json:"upper_bound,omitempty"`
+	Deviates       bool      `json:"deviates"`
+	StakeTotal     int64     `json:"stake_total"`
+	WinTotal       int64     `json:"win_total"`
+	GGR            int64     `json:"ggr"`
+	Rounds         int       `json:"rounds"`
+	Players        int       `json:"players"`
+	AvgStake       int64     `json:"avg_stake"`
+	ComputedAt     time.Time `json:"computed_at"`
+}
+
+// GamePerformance sums a game's days over a report period.
+type GamePerformance struct {
+	ManufacturerID string       `json:"manufacturer_id"`
+	GameID         string       `json:"game_id"`
+	GameName       *string      `json:"game_name,omitempty"`
+	TheoreticalRTP *float64     `json:"theoretical_rtp,omitempty"`
+	ActualRTP      float64      `json:"actual_rtp"`
+	StakeTotal     int64        `json:"stake_total"`
+	WinTotal       int64        `json:"win_total"`
+	GGR            int64        `json:"ggr"`
+	Rounds         int          `json:"rounds"`
+	DeviatingDays  int          `json:"deviating_days"`
+	Days           []GameRTPDay `json:"days"`
+}
+
+// CasinoPerformanceReport is the admin casino performance report.
+type CasinoPerformanceReport struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	StakeTotal int64             `json:"stake_total"`
+	WinTotal   int64             `json:"win_total"`
+	GGR        int64             `json:"ggr"`
+	ActualRTP  float64           `json:"actual_rtp"`
+	OpenAlerts int               `json:"open_alerts"`
+	Games      []GamePerformance `json:"games"`
+}
+
+// GameRTPAlert is a game_rtp_alerts row: a day on which a game's actual RTP
+// fell outside the confidence band around its theoretical RTP.
+type GameRTPAlert struct {
+	ID             uuid.UUID  `json:"id"`
+	ManufacturerID string     `json:"manufacturer_id"`
+	GameID         string     `json:"game_id"`
+	Day            time.Time  `json:"day"`
+	TheoreticalRTP float64    `json:"theoretical_rtp"`
+	ActualRTP      float64    `json:"actual_rtp"`
+	LowerBound     float64    `json:"lower_bound"`
+	UpperBound     float64    `json:"upper_bound"`
+	StakeTotal     int64      `json:"stake_total"`
+	Rounds         int        `json:"rounds"`
+	Status         string     `json:"status"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IPReputation is what an IP intelligence provider knows about an address.
+type IPReputation struct {
+	Proxy     bool   `json:"proxy"`
+	ProxyType string `json:"proxy_type,omitempty"` // VPN, TOR, SOCKS5, ...
+	Risk      int    `json:"risk"`                 // 0-100
+	Country   string `json:"country,omitempty"`
+}
+
+// IPRiskCheck is an ip_risk_checks row: a detection at registration,
+// deposit or bet time that was flagged or blocked, kept for compliance and
+// fraud review.
+type IPRiskCheck struct {
+	ID         uuid.UUID  `json:"id"`
+	PlayerID   *uuid.UUID `json:"player_id,omitempty"`
+	Reference  *string    `json:"reference,omitempty"` // e.g. the email registering
+	Checkpoint string     `json:"checkpoint"`
+	IP         string     `json:"ip"`
+	Provider   string     `json:"provider"`
+	IPReputation
+	Verdict    string     `json:"verdict"`
+	Reason     string     `json:"reason"`
+	ReviewedBy *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote *string    `json:"review_note,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
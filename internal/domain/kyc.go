@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KYCDocumentType is the kind of identity document a player uploads.
+type KYCDocumentType string
+
+const (
+	KYCPassport       KYCDocumentType = "passport"
+	KYCNationalID     KYCDocumentType = "national_id"
+	KYCDrivingLicence KYCDocumentType = "driving_licence"
+	KYCProofOfAddress KYCDocumentType = "proof_of_address"
+	KYCSelfie         KYCDocumentType = "selfie"
+)
+
+// Valid reports whether t is a known document type.
+func (t KYCDocumentType) Valid() bool {
+	switch t {
+	case KYCPassport, KYCNationalID, KYCDrivingLicence, KYCProofOfAddress, KYCSelfie:
+		return true
+	}
+	return false
+}
+
+// KYCDocumentStatus is the review state of a KYC document.
+type KYCDocumentStatus string
+
+const (
+	KYCDocumentPending  KYCDocumentStatus = "pending"
+	KYCDocumentApproved KYCDocumentStatus = "approved"
+	KYCDocumentRejected KYCDocumentStatus = "rejected" // player must upload a new one
+)
+
+// KYCDocument is the metadata of a kyc_documents row; the file itself is in
+// object storage.
+type KYCDocument struct {
+	ID           uuid.UUID         `json:"id"`
+	PlayerID     uuid.UUID         `json:"player_id"`
+	DocumentType KYCDocumentType   `json:"document_type"`
+	FileName     string            `json:"file_name"`
+	ContentType  string            `json:"content_type"`
+	SizeBytes    int               `json:"size_bytes"`
+	Status       KYCDocumentStatus `json:"status"`
+	ReviewNote   *string           `json:"review_note,omitempty"`
+	ReviewedBy   *uuid.UUID        `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time        `json:"reviewed_at,omitempty"`
+	UploadedAt   time.Time         `json:"uploaded_at"`
+}
+
+// KYCStatus summarizes a player's verification for the player API.
+type KYCStatus struct {
+	Verified bool `json:"verified"`
+	// Missing lists what still needs an approved document: "identity"
+	// (passport, national ID or driving licence) and/or "proof_of_address".
+	Missing []string `json:"missing"`
+	// WithdrawalThreshold is the largest withdrawal, in cents, allowed
+	// before verification; 0 means verification is not required.
+	WithdrawalThreshold int64         `json:"withdrawal_threshold"`
+	Documents           []KYCDocument `json:"documents"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "github.com/google/uuid"
+
+// PostingDirection is the side of a double-entry posting.
+type PostingDirection string
+
+const (
+	Debit  PostingDirection = "debit"
+	Credit PostingDirection = "credit"
+)
+
+// Operator-side ledger accounts. Player and provider accounts are built with
+// PlayerAccount and ProviderAccount.
+const (
+	AccountHouseGaming     = "house:gaming"
+	AccountHouseBonusPool  = "house:bonus_pool"
+	AccountHouseFX         = "house:fx"
+	AccountHouseFees       = "house:fees"
+	AccountP2PClearing     = "p2p:clearing"
+	AccountPaymentClearing = "payments:clearing"
+	AccountSuspense        = "house:suspense"
+)
+
+// Player balance tiers, used as the last segment of a player account.
+const (
+	TierCash     = "cash"
+	TierBonus    = "bonus"
+	TierReserved = "reserved"
+)
+
+// PlayerAccount names one balance tier of a player's wallet.
+func PlayerAccount(playerID uuid.UUID, tier string) string {
+	return "player:" + playerID.String() + ":" + tier
+}
+
+// ProviderAccount names the clearing account for a game or payment provider.
+func ProviderAccount(manufacturerID string) string {
+	return "provider:" + manufacturerID
+}
+
+// LedgerPosting is one leg of a balanced ledger entry (ledger_entries row).
+type LedgerPosting struct {
+	Account   string           `json:"account"`
+	Direction PostingDirection `json:"direction"`
+	Amount    int64            `json:"amount"`
+	Currency  string           `json:"currency"`
+}
+
+// AccountBalance is the net position of one ledger account: credits - debits.
+type AccountBalance struct {
+	Account  string `json:"account"`
+	Currency string `json:"currency"`
+	Debits   int64  `json:"debits"`
+	Credits  int64  `json:"credits"`
+	Net      int64  `json:"net"`
+}
+
+// LedgerBalanceReport is the result of Engine.VerifyBalanced.
+type LedgerBalanceReport struct {
+	Balanced     bool        `json:"balanced"`
+	TotalDebits  int64       `json:"total_debits"`
+	TotalCredits int64       `json:"total_credits"`
+	Unbalanced   []uuid.UUID `json:"unbalanced_transactions"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginRecord is a player-facing view of a login_attempts row.
+type LoginRecord struct {
+	ID         int64      `json:"id"`
+	Success    bool       `json:"success"`
+	IPAddress  string     `json:"ip_address"`
+	Country    string     `json:"country,omitempty"`
+	Device     string     `json:"device"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	ReportedAt *time.Time `json:"reported_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// LoginReport is the outcome of a player flagging a login as "not me".
+type LoginReport struct {
+	LoginID         int64     `json:"login_id"`
+	PlayerID        uuid.UUID `json:"player_id"`
+	SessionsRevoked int64     `json:"sessions_revoked"`
+	PasswordReset   bool      `json:"password_reset_required"`
+	TokensRevokedAt time.Time `json:"tokens_revoked_at"`
+}
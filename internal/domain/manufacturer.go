@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Wallet provider money formats: how amounts travel on the wire.
+const (
+	MoneyFormatCents   = "cents"   // integer minor units
+	MoneyFormatDecimal = "decimal" // decimal major units, "10.50"
+)
+
+// Manufacturer represents a manufacturers row: a wallet provider the wallet
+// server mounts, with its enablement and the callback actions it may send.
+type Manufacturer struct {
+	ID             string     `json:"id"` // adapter name, recorded on ledger transactions
+	Kind           string     `json:"kind"`
+	DisplayName    string     `json:"display_name"`
+	Prefix         string     `json:"prefix"`
+	SecretRef      *string    `json:"secret_ref,omitempty"` // environment variable holding the secret
+	Enabled        bool       `json:"enabled"`
+	MoneyFormat    string     `json:"money_format"`
+	AllowedActions []string   `json:"allowed_actions"` // empty allows every action
+	DisabledReason *string    `json:"disabled_reason,omitempty"`
+	UpdatedBy      *uuid.UUID `json:"updated_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Allows reports whether the manufacturer may send a callback action.
+func (m *Manufacturer) Allows(action string) bool {
+	return len(m.AllowedActions) == 0 || slices.Contains(m.AllowedActions, action)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// P2PTransferStatus is the outcome of a player-to-player transfer request.
+type P2PTransferStatus string
+
+const (
+	P2PTransferCompleted P2PTransferStatus = "completed"
+	P2PTransferRejected  P2PTransferStatus = "rejected" // refused by limits or AML checks
+)
+
+// P2PTransfer represents a p2p_transfers row.
+type P2PTransfer struct {
+	ID                  uuid.UUID         `json:"id"`
+	SenderID            uuid.UUID         `json:"sender_id"`
+	RecipientID         uuid.UUID         `json:"recipient_id"`
+	Amount              int64             `json:"amount"`
+	Fee                 int64             `json:"fee"`
+	Currency            string            `json:"currency"`
+	Message             *string           `json:"message,omitempty"`
+	Status              P2PTransferStatus `json:"status"`
+	RejectReason        *string           `json:"reject_reason,omitempty"`
+	AMLFlags            json.RawMessage   `json:"aml_flags"`
+	DebitTransactionID  *uuid.UUID        `json:"debit_transaction_id,omitempty"`
+	CreditTransactionID *uuid.UUID        `json:"credit_transaction_id,omitempty"`
+	FeeTransactionID    *uuid.UUID        `json:"fee_transaction_id,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+}
+
+// TransferParams holds the input for ExecuteTransfer. Fee is debited from
+// the sender on top of Amount.
+type TransferParams struct {
+	SenderID    uuid.UUID
+	RecipientID uuid.UUID
+	Amount      int64
+	Fee         int64
+	Currency    string
+	// TransferID ties the legs together and keys their idempotency.
+	TransferID uuid.UUID
+}
+
+// TransferResult holds the legs of a player-to-player transfer. Fee is nil
+// when no fee was charged.
+type TransferResult struct {
+	Debit      *Transaction
+	Credit     *Transaction
+	Fee        *Transaction
+	Idempotent bool
+}
@@ -13,6 +13,12 @@ type PaymentType string
 const (
 	PaymentTypeDeposit    PaymentType = "deposit"
 	PaymentTypeWithdrawal PaymentType = "withdrawal"
+	// PaymentTypePurchase buys a sweepstakes coin package; it credits the
+	// GC and SC wallets rather than the cash balance.
+	PaymentTypePurchase PaymentType = "purchase"
+	// PaymentTypeStore buys a store item; it grants an entitlement, and a
+	// ledger credit for coin bundles.
+	PaymentTypeStore PaymentType = "store"
 )
 
 // PaymentStatus tracks the payment lifecycle.
@@ -25,6 +31,8 @@ const (
 	PaymentStatusCancelled PaymentStatus = "cancelled"
 	PaymentStatusApproved  PaymentStatus = "approved"
 	PaymentStatusRejected  PaymentStatus = "rejected"
+	// PaymentStatusOnHold: the PSP captured the funds but the credit awaits admin review.
+	PaymentStatusOnHold PaymentStatus = "on_hold"
 )
 
 // Payment represents a payments table row.
@@ -48,6 +56,27 @@ type Payment struct {
 	UpdatedAt             time.Time       `json:"updated_at"`
 }
 
+// PaymentMethod represents a player's saved payment_methods row: a card or
+// bank account tokenized by the PSP, with display details only. Removed
+// methods are deactivated because payments keep referencing them.
+type PaymentMethod struct {
+	ID          uuid.UUID  `json:"id"`
+	PlayerID    uuid.UUID  `json:"player_id"`
+	Label       string     `json:"label"`
+	Type        string     `json:"type"` // card, sepa_debit, us_bank_account
+	Provider    string     `json:"provider"`
+	ProviderRef string     `json:"provider_ref"`
+	Brand       *string    `json:"brand,omitempty"`
+	Last4       *string    `json:"last4,omitempty"`
+	Country     *string    `json:"country,omitempty"`
+	Active      bool       `json:"active"`
+	Verified    bool       `json:"verified"`
+	VerifiedBy  *uuid.UUID `json:"verified_by,omitempty"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
 // PaymentEvent tracks status changes for audit trail.
 type PaymentEvent struct {
 	ID          uuid.UUID       `json:"id"`
@@ -58,3 +87,75 @@ type PaymentEvent struct {
 	RawData     json.RawMessage `json:"raw_data,omitempty"`
 	CreatedAt   time.Time       `json:"created_at"`
 }
+
+// WithdrawalQueueItem is a pending withdrawal with its automated risk check,
+// as shown in the admin withdrawal queue.
+type WithdrawalQueueItem struct {
+	PaymentID      uuid.UUID       `json:"payment_id"`
+	PlayerID       uuid.UUID       `json:"player_id"`
+	Email          string          `json:"email"`
+	Amount         int64           `json:"amount"`
+	Currency       string          `json:"currency"`
+	Status         PaymentStatus   `json:"status"`
+	RiskScore      *int            `json:"risk_score,omitempty"`
+	Recommendation *string         `json:"recommendation,omitempty"`
+	RiskFactors    json.RawMessage `json:"risk_factors,omitempty"`
+	RequestedAt    time.Time       `json:"requested_at"`
+}
+
+// RefundStatus tracks a deposit refund through the PSP.
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+)
+
+// PaymentRefund represents a payment_refunds row.
+type PaymentRefund struct {
+	ID                    uuid.UUID    `json:"id"`
+	PaymentID             uuid.UUID    `json:"payment_id"`
+	PlayerID              uuid.UUID    `json:"player_id"`
+	Amount                int64        `json:"amount"`
+	Currency              string       `json:"currency"`
+	Status                RefundStatus `json:"status"`
+	Reason                string       `json:"reason"`
+	Provider              string       `json:"provider"`
+	ProviderRefundID      *string      `json:"provider_refund_id,omitempty"`
+	FailureReason         *string      `json:"failure_reason,omitempty"`
+	RequestedBy           *uuid.UUID   `json:"requested_by,omitempty"`
+	TransactionID         *uuid.UUID   `json:"transaction_id,omitempty"`
+	ReversalTransactionID *uuid.UUID   `json:"reversal_transaction_id,omitempty"`
+	CreatedAt             time.Time    `json:"created_at"`
+	UpdatedAt             time.Time    `json:"updated_at"`
+}
+
+// PendingCreditStatus tracks a held deposit through admin review.
+type PendingCreditStatus string
+
+const (
+	PendingCreditPending  PendingCreditStatus = "pending"
+	PendingCreditApproved PendingCreditStatus = "approved"
+	PendingCreditRejected PendingCreditStatus = "rejected"
+)
+
+// PendingCredit is a captured PSP deposit held for manual review before the
+// ledger credit.
+type PendingCredit struct {
+	ID                uuid.UUID           `json:"id"`
+	PaymentID         uuid.UUID           `json:"payment_id"`
+	PlayerID          uuid.UUID           `json:"player_id"`
+	Amount            int64               `json:"amount"`
+	Currency          string              `json:"currency"`
+	Provider          string              `json:"provider"`
+	ProviderPaymentID *string             `json:"provider_payment_id,omitempty"`
+	ProviderEventID   string              `json:"provider_event_id"`
+	Status            PendingCreditStatus `json:"status"`
+	Note              *string             `json:"note,omitempty"`
+	ReviewedBy        *uuid.UUID          `json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time          `json:"reviewed_at,omitempty"`
+	TransactionID     *uuid.UUID          `json:"transaction_id,omitempty"`
+	ProviderRefundID  *string             `json:"provider_refund_id,omitempty"`
+	CreatedAt         time.Time           `json:"created_at"`
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlacementKind is how a placement renders in the client.
+type PlacementKind string
+
+const (
+	PlacementBanner PlacementKind = "banner"
+	PlacementCard   PlacementKind = "card"
+)
+
+// Placement engagement event types.
+const (
+	PlacementImpression = "impression"
+	PlacementClick      = "click"
+)
+
+// Placement is an admin-scheduled promotional banner or card. It is served to
+// players in Segment while the validity window is open; Vertical "all" shows
+// in every product area.
+type Placement struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name"`
+	Kind      PlacementKind     `json:"kind"`
+	Title     string            `json:"title"`
+	Body      *string           `json:"body,omitempty"`
+	ImageURL  *string           `json:"image_url,omitempty"`
+	DeepLink  string            `json:"deep_link"`
+	Vertical  string            `json:"vertical"`
+	Segment   string            `json:"segment"`
+	Priority  int               `json:"priority"`
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    *time.Time        `json:"ends_at,omitempty"`
+	Schedule  PlacementSchedule `json:"schedule"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// PlacementSchedule narrows when a live placement is served, on top of its
+// validity window. Every set rule must pass. Weekdays, hours and game days
+// are judged in Timezone (UTC when empty).
+type PlacementSchedule struct {
+	Timezone string         `json:"timezone,omitempty"` // IANA name, e.g. America/New_York
+	Weekdays []time.Weekday `json:"weekdays,omitempty"` // 0 = Sunday
+	Hours    *HourWindow    `json:"hours,omitempty"`
+	// GameDay shows the placement only on days with a sportsbook event for
+	// the sport (and league, when set) starting that local day.
+	GameDay *GameDayRule `json:"game_day,omitempty"`
+	// HideDuringMaintenance hides the placement while a maintenance window
+	// covers its vertical.
+	HideDuringMaintenance bool `json:"hide_during_maintenance,omitempty"`
+}
+
+// HourWindow is a daily window of whole local hours, From inclusive and To
+// exclusive. A window with From > To runs overnight.
+type HourWindow struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// GameDayRule ties a placement to a sports calendar.
+type GameDayRule struct {
+	Sport  string `json:"sport"` // sports.key, e.g. americanfootball
+	League string `json:"league,omitempty"`
+}
+
+// MaintenanceWindow is a planned outage of a vertical ("all" for the whole
+// platform). Placements can hide themselves while one is open.
+type MaintenanceWindow struct {
+	ID        uuid.UUID  `json:"id"`
+	Vertical  string     `json:"vertical"`
+	Reason    string     `json:"reason"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PlacementStats is a placement with its engagement totals, for the admin list.
+type PlacementStats struct {
+	Placement
+	Impressions   int64 `json:"impressions"`
+	Clicks        int64 `json:"clicks"`
+	UniqueViewers int64 `json:"unique_viewers"`
+}
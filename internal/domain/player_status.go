@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountStatus is the lifecycle state stored in player_profiles.account_status.
+type AccountStatus string
+
+const (
+	AccountStatusActive       AccountStatus = "active"
+	AccountStatusSuspended    AccountStatus = "suspended"
+	AccountStatusSelfExcluded AccountStatus = "self_excluded"
+	AccountStatusClosed       AccountStatus = "closed"
+)
+
+// accountStatusTransitions lists the allowed next states for each status.
+// Closed is terminal.
+var accountStatusTransitions = map[AccountStatus][]AccountStatus{
+	AccountStatusActive:       {AccountStatusSuspended, AccountStatusSelfExcluded, AccountStatusClosed},
+	AccountStatusSuspended:    {AccountStatusActive, AccountStatusSelfExcluded, AccountStatusClosed},
+	AccountStatusSelfExcluded: {AccountStatusActive, AccountStatusClosed},
+	AccountStatusClosed:       {},
+}
+
+// ParseAccountStatus validates a status string.
+func ParseAccountStatus(s string) (AccountStatus, error) {
+	status := AccountStatus(s)
+	if _, ok := accountStatusTransitions[status]; !ok {
+		return "", fmt.Errorf("unknown account status: %q", s)
+	}
+	return status, nil
+}
+
+// CanTransitionTo reports whether the state machine allows moving to next.
+func (s AccountStatus) CanTransitionTo(next AccountStatus) bool {
+	for _, allowed := range accountStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrInvalidStatusTransition is returned when a transition is not allowed.
+func ErrInvalidStatusTransition(from, to AccountStatus) *AppError {
+	return &AppError{
+		Code:    "INVALID_STATUS_TRANSITION",
+		Message: fmt.Sprintf("cannot change account status from %s to %s", from, to),
+		Status:  409,
+	}
+}
+
+// StatusChangeState tracks whether a player_status_history row has taken effect.
+type StatusChangeState string
+
+const (
+	StatusChangeApplied   StatusChangeState = "applied"
+	StatusChangeScheduled StatusChangeState = "scheduled"
+	StatusChangeCancelled StatusChangeState = "cancelled"
+)
+
+// PlayerStatusChange represents a player_status_history row — the append-only
+// event log that account_status is projected from.
+type PlayerStatusChange struct {
+	ID          int64             `json:"id"`
+	PlayerID    uuid.UUID         `json:"player_id"`
+	FromStatus  AccountStatus     `json:"from_status"`
+	ToStatus    AccountStatus     `json:"to_status"`
+	Reason      string            `json:"reason"`
+	ActorType   string            `json:"actor_type"` // admin, player, system
+	ActorID     *uuid.UUID        `json:"actor_id,omitempty"`
+	State       StatusChangeState `json:"state"`
+	EffectiveAt time.Time         `json:"effective_at"`
+	AppliedAt   *time.Time        `json:"applied_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// SelfExclusion is a player's view of their own self-exclusion. Until is nil
+// while excluded means the exclusion is permanent.
+type SelfExclusion struct {
+	Excluded      bool          `json:"excluded"`
+	Permanent     bool          `json:"permanent"`
+	Until         *time.Time    `json:"until,omitempty"`
+	AccountStatus AccountStatus `json:"account_status"`
+}
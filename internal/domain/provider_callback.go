@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProviderCallback represents a provider_callbacks row: one casino wallet
+// callback as received and the answer the wallet server gave.
+type ProviderCallback struct {
+	ID             uuid.UUID  `json:"id"`
+	Provider       string     `json:"provider"`
+	Path           string     `json:"path"`
+	Action         *string    `json:"action,omitempty"` // nil when the request never resolved
+	PlayerID       *uuid.UUID `json:"player_id,omitempty"`
+	TransactionID  *string    `json:"transaction_id,omitempty"`
+	ReferenceID    *string    `json:"reference_id,omitempty"`
+	RoundID        *string    `json:"round_id,omitempty"`
+	Amount         *int64     `json:"amount,omitempty"` // cents
+	Currency       *string    `json:"currency,omitempty"`
+	Body           string     `json:"body"`
+	SignatureValid *bool      `json:"signature_valid,omitempty"`
+	Status         string     `json:"status"` // ok, bad_request, unauthorized, forbidden, insufficient_funds, error
+	Message        *string    `json:"message,omitempty"`
+	Balance        *int64     `json:"balance,omitempty"`
+	BonusBalance   *int64     `json:"bonus_balance,omitempty"`
+	DurationMs     int        `json:"duration_ms"`
+	ReceivedAt     time.Time  `json:"received_at"`
+}
+
+// Provider callback mismatch kinds.
+const (
+	// An accepted money callback with no ledger transaction behind it.
+	MismatchMissingTransaction = "missing_transaction"
+	// A provider ledger transaction no accepted callback accounts for.
+	MismatchUnjournaledTransaction = "unjournaled_transaction"
+)
+
+// ProviderCallbackMismatch is one discrepancy between the callback journal
+// and the ledger.
+type ProviderCallbackMismatch struct {
+	Kind          string     `json:"kind"`
+	Provider      string     `json:"provider"`
+	PlayerID      *uuid.UUID `json:"player_id,omitempty"`
+	TransactionID string     `json:"transaction_id"` // provider-side id
+	Action        string     `json:"action"`         // callback action or ledger transaction type
+	Amount        int64      `json:"amount"`
+	CallbackID    *uuid.UUID `json:"callback_id,omitempty"`
+	LedgerID      *uuid.UUID `json:"ledger_transaction_id,omitempty"`
+	At            time.Time  `json:"at"`
+}
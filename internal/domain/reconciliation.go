@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconciliationRun represents a reconciliation_runs row.
+type ReconciliationRun struct {
+	ID               uuid.UUID  `json:"id"`
+	Provider         string     `json:"provider"`
+	PeriodStart      time.Time  `json:"period_start"`
+	PeriodEnd        time.Time  `json:"period_end"`
+	Status           string     `json:"status"` // running, completed, failed
+	ProviderRecords  int        `json:"provider_records"`
+	MatchedCount     int        `json:"matched_count"`
+	DiscrepancyCount int        `json:"discrepancy_count"`
+	Error            *string    `json:"error,omitempty"`
+	StartedAt        time.Time  `json:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+// ReconciliationDiscrepancy represents a reconciliation_discrepancies row.
+type ReconciliationDiscrepancy struct {
+	ID             int64      `json:"id"`
+	RunID          uuid.UUID  `json:"run_id"`
+	Kind           string     `json:"kind"`
+	ProviderRef    *string    `json:"provider_ref,omitempty"`
+	PaymentID      *uuid.UUID `json:"payment_id,omitempty"`
+	ExpectedAmount *int64     `json:"expected_amount,omitempty"`
+	ActualAmount   *int64     `json:"actual_amount,omitempty"`
+	Detail         string     `json:"detail"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// LedgerDiscrepancy represents a ledger_discrepancies row: a wallet whose
+// stored balances drifted from its transaction history.
+type LedgerDiscrepancy struct {
+	ID                int64      `json:"id"`
+	PlayerID          uuid.UUID  `json:"player_id"`
+	Currency          string     `json:"currency"`
+	Kind              string     `json:"kind"`
+	Stored            Balances   `json:"stored"`
+	Expected          Balances   `json:"expected"`
+	LastTransactionID *uuid.UUID `json:"last_transaction_id,omitempty"`
+	DetectedAt        time.Time  `json:"detected_at"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+}
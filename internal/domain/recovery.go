@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryStatus tracks a support-assisted account recovery request.
+type RecoveryStatus string
+
+const (
+	RecoveryStatusPending  RecoveryStatus = "pending"
+	RecoveryStatusApproved RecoveryStatus = "approved"
+	RecoveryStatusRejected RecoveryStatus = "rejected"
+)
+
+// RecoveryRequest represents an account_recovery_requests row.
+type RecoveryRequest struct {
+	ID              uuid.UUID       `json:"id"`
+	PlayerID        uuid.UUID       `json:"player_id"`
+	Email           string          `json:"email"`
+	BackupEmail     string          `json:"backup_email"`
+	Status          RecoveryStatus  `json:"status"`
+	ChallengeScore  int             `json:"challenge_score"`
+	ChallengeResult json.RawMessage `json:"challenge_result"`
+	IPAddress       string          `json:"ip_address"`
+	ReviewedBy      *uuid.UUID      `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	DecisionNote    *string         `json:"decision_note,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// RecoveryAuditEntry is one step in a recovery request's audit trail.
+type RecoveryAuditEntry struct {
+	ID        int64           `json:"id"`
+	ActorType string          `json:"actor_type"` // player, admin, system
+	ActorID   *uuid.UUID      `json:"actor_id,omitempty"`
+	Action    string          `json:"action"`
+	Detail    json.RawMessage `json:"detail"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// BackupEmail is the address a player registered to receive account recovery
+// tokens. Recovery only uses it once it is verified.
+type BackupEmail struct {
+	Email      string     `json:"email"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionRun is a retention_runs row with the outcome of each policy.
+type RetentionRun struct {
+	ID         uuid.UUID          `json:"id"`
+	DryRun     bool               `json:"dry_run"`
+	Status     string             `json:"status"` // running, completed, failed
+	Error      *string            `json:"error,omitempty"`
+	Items      []RetentionRunItem `json:"items"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+}
+
+// RetentionRunItem records what one policy deleted or redacted in a run;
+// for a dry run, Affected is what it would have touched.
+type RetentionRunItem struct {
+	Policy   string    `json:"policy"`
+	Table    string    `json:"table"`
+	Action   string    `json:"action"`
+	Cutoff   time.Time `json:"cutoff"`
+	Affected int64     `json:"affected"`
+}
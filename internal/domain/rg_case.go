@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RGCaseStatus is the workflow state of a responsible-gambling case.
+type RGCaseStatus string
+
+const (
+	RGCaseOpen       RGCaseStatus = "open"        // waiting for an owner
+	RGCaseInProgress RGCaseStatus = "in_progress" // assigned and being worked
+	RGCaseClosed     RGCaseStatus = "closed"
+)
+
+// RGCaseOutcome records how a case was resolved.
+type RGCaseOutcome string
+
+const (
+	RGOutcomeNoAction      RGCaseOutcome = "no_action"
+	RGOutcomeMonitoring    RGCaseOutcome = "monitoring"
+	RGOutcomeLimitsSet     RGCaseOutcome = "limits_set"
+	RGOutcomeSelfExcluded  RGCaseOutcome = "self_excluded"  // player is self-excluded on close
+	RGOutcomeAccountClosed RGCaseOutcome = "account_closed" // player account is closed on close
+)
+
+// Valid reports whether o is a known outcome.
+func (o RGCaseOutcome) Valid() bool {
+	switch o {
+	case RGOutcomeNoAction, RGOutcomeMonitoring, RGOutcomeLimitsSet, RGOutcomeSelfExcluded, RGOutcomeAccountClosed:
+		return true
+	}
+	return false
+}
+
+// Interaction kinds. Agents record note, call, email and chat; assignment and
+// status_change are written by the workflow itself.
+const (
+	RGInteractionNote         = "note"
+	RGInteractionCall         = "call"
+	RGInteractionEmail        = "email"
+	RGInteractionChat         = "chat"
+	RGInteractionAssignment   = "assignment"
+	RGInteractionStatusChange = "status_change"
+)
+
+// Case sources.
+const (
+	RGCaseSourceRiskScore    = "risk_score"
+	RGCaseSourceIntervention = "intervention"
+	RGCaseSourceManual       = "manual"
+)
+
+// RGCase represents an rg_cases row.
+type RGCase struct {
+	ID          uuid.UUID      `json:"id"`
+	PlayerID    uuid.UUID      `json:"player_id"`
+	Status      RGCaseStatus   `json:"status"`
+	Priority    string         `json:"priority"` // low, medium, high
+	Source      string         `json:"source"`
+	Reason      string         `json:"reason"`
+	AssignedTo  *uuid.UUID     `json:"assigned_to,omitempty"`
+	OpenedBy    *uuid.UUID     `json:"opened_by,omitempty"`
+	Outcome     *RGCaseOutcome `json:"outcome,omitempty"`
+	OutcomeNote *string        `json:"outcome_note,omitempty"`
+	OpenedAt    time.Time      `json:"opened_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	ClosedAt    *time.Time     `json:"closed_at,omitempty"`
+}
+
+// RGCaseInteraction represents an rg_case_interactions row.
+type RGCaseInteraction struct {
+	ID        uuid.UUID  `json:"id"`
+	CaseID    uuid.UUID  `json:"case_id"`
+	AdminID   *uuid.UUID `json:"admin_id,omitempty"`
+	Kind      string     `json:"kind"`
+	Summary   string     `json:"summary"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RGCaseDetail is a case with everything an agent needs to work it: the
+// interaction log, the player's account status and self-exclusion history,
+// the limits in force, interventions and recent risk scores.
+type RGCaseDetail struct {
+	RGCase
+	Interactions  []RGCaseInteraction  `json:"interactions"`
+	AccountStatus AccountStatus        `json:"account_status"`
+	StatusHistory []PlayerStatusChange `json:"status_history"`
+	Limits        RGLimitUsage         `json:"limits"`
+	Interventions []RGIntervention     `json:"interventions"`
+	RiskScores    []RGRiskScore        `json:"risk_scores"`
+}
+
+// RGLimitUsage is the RG limits in force for a player with today's usage.
+type RGLimitUsage struct {
+	SingleTransactionMax int64 `json:"single_transaction_max"` // cents
+	DailyDepositMax      int64 `json:"daily_deposit_max"`      // cents
+	DailyLossMax         int64 `json:"daily_loss_max"`         // cents
+	DepositsToday        int64 `json:"deposits_today"`         // cents
+}
+
+// RGCasePlayer is one row of the RG worklist: a player ranked by recent risk
+// with their open case, if any.
+type RGCasePlayer struct {
+	PlayerID             uuid.UUID     `json:"player_id"`
+	Email                string        `json:"email"`
+	AccountStatus        AccountStatus `json:"account_status"`
+	MaxScore             int           `json:"max_score"`
+	Band                 string        `json:"band"`
+	Sessions             int           `json:"sessions"`
+	LastSessionAt        time.Time     `json:"last_session_at"`
+	PendingInterventions int           `json:"pending_interventions"`
+	OpenCaseID           *uuid.UUID    `json:"open_case_id,omitempty"`
+	OpenCaseStatus       *RGCaseStatus `json:"open_case_status,omitempty"`
+}
+
+// RGCaseHistoryEntry is one event in an exported case history.
+type RGCaseHistoryEntry struct {
+	At      time.Time  `json:"at"`
+	Source  string     `json:"source"` // case, interaction, intervention, status, risk_score
+	Kind    string     `json:"kind"`
+	ActorID *uuid.UUID `json:"actor_id,omitempty"`
+	Summary string     `json:"summary"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RGIntervention represents an rg_interventions row: one automated
+// responsible-gambling intervention and the player's response to it.
+type RGIntervention struct {
+	ID             uuid.UUID  `json:"id"`
+	PlayerID       uuid.UUID  `json:"player_id"`
+	Kind           string     `json:"kind"`
+	Jurisdiction   string     `json:"jurisdiction"`
+	NetLoss        int64      `json:"net_loss"`  // cents over the window
+	Threshold      int64      `json:"threshold"` // cents
+	WindowDays     int        `json:"window_days"`
+	TriggeredAt    time.Time  `json:"triggered_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	Response       *string    `json:"response,omitempty"`
+}
+
+// Player responses to an intervention.
+const (
+	InterventionAccepted  = "accepted"
+	InterventionDismissed = "dismissed"
+)
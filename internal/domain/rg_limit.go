@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlayerLimitType is what a self-set responsible-gaming limit caps.
+type PlayerLimitType string
+
+const (
+	LimitDeposit PlayerLimitType = "deposit" // deposits over the period
+	LimitLoss    PlayerLimitType = "loss"    // stakes less winnings over the period
+)
+
+// Valid reports whether t is a known limit type.
+func (t PlayerLimitType) Valid() bool {
+	return t == LimitDeposit || t == LimitLoss
+}
+
+// LimitPeriod is the calendar window (UTC) a player limit applies to.
+type LimitPeriod string
+
+const (
+	LimitDaily   LimitPeriod = "daily"
+	LimitWeekly  LimitPeriod = "weekly" // Monday to Sunday
+	LimitMonthly LimitPeriod = "monthly"
+)
+
+// Valid reports whether p is a known limit period.
+func (p LimitPeriod) Valid() bool {
+	return p == LimitDaily || p == LimitWeekly || p == LimitMonthly
+}
+
+// PlayerLimit represents a self-set player_limits row. Decreases apply at
+// once; an increase or removal waits in PendingValue until
+// PendingEffectiveAt, where a pending value of 0 means the limit is removed.
+type PlayerLimit struct {
+	ID                 uuid.UUID       `json:"id"`
+	PlayerID           uuid.UUID       `json:"player_id"`
+	Type               PlayerLimitType `json:"type"`
+	Period             LimitPeriod     `json:"period"`
+	Value              int64           `json:"value"` // cents
+	PendingValue       *int64          `json:"pending_value,omitempty"`
+	PendingEffectiveAt *time.Time      `json:"pending_effective_at,omitempty"`
+	Used               int64           `json:"used"` // cents in the current period
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
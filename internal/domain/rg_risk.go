@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RGRiskScore represents an rg_risk_scores row: the chasing-behaviour score
+// of one casino play session.
+type RGRiskScore struct {
+	ID           uuid.UUID       `json:"id"`
+	PlayerID     uuid.UUID       `json:"player_id"`
+	SessionStart time.Time       `json:"session_start"`
+	SessionEnd   time.Time       `json:"session_end"`
+	Score        int             `json:"score"`
+	Band         string          `json:"band"`
+	Factors      json.RawMessage `json:"factors"`
+	Escalations  int             `json:"escalations"`
+	Bets         int             `json:"bets"`
+	Staked       int64           `json:"staked"`   // cents
+	NetLoss      int64           `json:"net_loss"` // cents
+	Minutes      int             `json:"minutes"`
+	ScoredAt     time.Time       `json:"scored_at"`
+}
+
+// RGRiskPlayer summarises a player's riskiest recent session for the RG
+// dashboard.
+type RGRiskPlayer struct {
+	PlayerID      uuid.UUID       `json:"player_id"`
+	Email         string          `json:"email"`
+	MaxScore      int             `json:"max_score"`
+	HighSessions  int             `json:"high_sessions"`
+	LastSessionAt time.Time       `json:"last_session_at"`
+	Factors       json.RawMessage `json:"factors"` // of the riskiest session
+}
+
+// RGDashboard is the responsible-gambling overview for compliance.
+type RGDashboard struct {
+	Since                time.Time      `json:"since"`
+	SessionsByBand       map[string]int `json:"sessions_by_band"`
+	HighRiskPlayers      []RGRiskPlayer `json:"high_risk_players"`
+	InterventionsByKind  map[string]int `json:"interventions_by_kind"`
+	PendingInterventions int            `json:"pending_interventions"`
+}
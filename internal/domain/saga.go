@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SagaStatus tracks the lifecycle of a saga.
+type SagaStatus string
+
+const (
+	SagaRunning      SagaStatus = "running"
+	SagaCompleted    SagaStatus = "completed"
+	SagaCompensating SagaStatus = "compensating"
+	SagaCompensated  SagaStatus = "compensated"
+	// SagaFailed: a compensation kept failing, or a step at or after the
+	// pivot gave up, and the saga needs an operator.
+	SagaFailed SagaStatus = "failed"
+)
+
+// Saga represents a sagas row: a multi-step flow across the ledger and
+// external systems, run step by step and undone by compensation when a step
+// cannot complete. Step counts the steps completed; while compensating it
+// counts those still to undo.
+type Saga struct {
+	ID            uuid.UUID       `json:"id"`
+	Kind          string          `json:"kind"`
+	Reference     string          `json:"reference"`
+	Status        SagaStatus      `json:"status"`
+	Step          int             `json:"step"`
+	Attempts      int             `json:"attempts"`
+	Data          json.RawMessage `json:"data"`
+	LastError     *string         `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// SagaStepLog represents a saga_step_log row: one attempt at a step or its
+// compensation.
+type SagaStepLog struct {
+	Step      int       `json:"step"`
+	Name      string    `json:"name"`
+	Phase     string    `json:"phase"` // action or compensation
+	Succeeded bool      `json:"succeeded"`
+	Error     *string   `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -30,6 +30,9 @@ const (
 	BetStatusLost     BetStatus = "lost"
 	BetStatusVoid     BetStatus = "void"
 	BetStatusCashout  BetStatus = "cashout"
+	BetStatusPush     BetStatus = "push"
+	BetStatusHalfWon  BetStatus = "half_won"
+	BetStatusHalfLost BetStatus = "half_lost"
 )
 
 // SportsbookBet represents a placed bet.
@@ -89,3 +92,11 @@ type PredictionStake struct {
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// PredictionOutcome is one entry of a market's outcomes: stakes name it by
+// ID and lock its decimal odds.
+type PredictionOutcome struct {
+	ID    string  `json:"id"`
+	Label string  `json:"label"`
+	Odds  float64 `json:"odds"`
+}
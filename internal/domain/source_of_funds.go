@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SOFStatus is the review state of a source-of-funds questionnaire.
+type SOFStatus string
+
+const (
+	SOFPending   SOFStatus = "pending"   // opened, waiting for the player
+	SOFSubmitted SOFStatus = "submitted" // answered, waiting for review
+	SOFApproved  SOFStatus = "approved"
+	SOFRejected  SOFStatus = "rejected" // player must resubmit
+)
+
+// SourceOfFunds is the declared origin of the money a player deposits.
+type SourceOfFunds string
+
+const (
+	SOFSalary      SourceOfFunds = "salary"
+	SOFBusiness    SourceOfFunds = "business"
+	SOFSavings     SourceOfFunds = "savings"
+	SOFInvestments SourceOfFunds = "investments"
+	SOFInheritance SourceOfFunds = "inheritance"
+	SOFPension     SourceOfFunds = "pension"
+	SOFOtherSource SourceOfFunds = "other"
+)
+
+// Valid reports whether s is a known source of funds.
+func (s SourceOfFunds) Valid() bool {
+	switch s {
+	case SOFSalary, SOFBusiness, SOFSavings, SOFInvestments, SOFInheritance, SOFPension, SOFOtherSource:
+		return true
+	}
+	return false
+}
+
+// SOFQuestionnaire represents a sof_questionnaires row.
+type SOFQuestionnaire struct {
+	ID               uuid.UUID      `json:"id"`
+	PlayerID         uuid.UUID      `json:"player_id"`
+	Threshold        int64          `json:"threshold"` // cumulative deposits, cents
+	Status           SOFStatus      `json:"status"`
+	Occupation       *string        `json:"occupation,omitempty"`
+	Employer         *string        `json:"employer,omitempty"`
+	AnnualIncomeBand *string        `json:"annual_income_band,omitempty"`
+	SourceOfFunds    *SourceOfFunds `json:"source_of_funds,omitempty"`
+	Details          *string        `json:"details,omitempty"`
+	ReviewNote       *string        `json:"review_note,omitempty"`
+	ReviewedBy       *uuid.UUID     `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time     `json:"reviewed_at,omitempty"`
+	SubmittedAt      *time.Time     `json:"submitted_at,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	Documents        []SOFDocument  `json:"documents"`
+}
+
+// SOFDocument is the metadata of a sof_documents row; the content is only
+// read when the document is downloaded.
+type SOFDocument struct {
+	ID              uuid.UUID `json:"id"`
+	QuestionnaireID uuid.UUID `json:"questionnaire_id"`
+	FileName        string    `json:"file_name"`
+	ContentType     string    `json:"content_type"`
+	SizeBytes       int       `json:"size_bytes"`
+	UploadedAt      time.Time `json:"uploaded_at"`
+}
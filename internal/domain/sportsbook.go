@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,16 +32,32 @@ type SportsEvent struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
-// SportsMarket represents a betting market within an event.
+// MarketTypeOutright is the market type of season-long outright (futures)
+// markets, which belong to a sport and league rather than a single event.
+const MarketTypeOutright = "outright"
+
+// SportsMarket represents a betting market within an event, or an outright
+// market when EventID is nil.
 type SportsMarket struct {
-	ID         uuid.UUID `json:"id"`
-	EventID    uuid.UUID `json:"event_id"`
-	Name       string    `json:"name"`
-	Type       string    `json:"type"`
-	Status     string    `json:"status"`
-	Specifiers *string   `json:"specifiers,omitempty"`
-	SortOrder  int       `json:"sort_order"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID              uuid.UUID  `json:"id"`
+	EventID         *uuid.UUID `json:"event_id"`
+	SportID         *uuid.UUID `json:"sport_id,omitempty"`
+	League          *string    `json:"league,omitempty"`
+	Name            string     `json:"name"`
+	Type            string     `json:"type"`
+	Status          string     `json:"status"`
+	Specifiers      *string    `json:"specifiers,omitempty"`
+	SortOrder       int        `json:"sort_order"`
+	SettlesAt       *time.Time `json:"settles_at,omitempty"`
+	EachWayPlaces   *int       `json:"each_way_places,omitempty"`
+	EachWayFraction *int       `json:"each_way_fraction,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// OutrightMarket is an outright market with its selections.
+type OutrightMarket struct {
+	SportsMarket
+	Selections []SportsSelection `json:"selections"`
 }
 
 // SportsSelection represents a selection within a market.
@@ -53,6 +70,10 @@ type SportsSelection struct {
 	OddsAmerican    *string   `json:"odds_american,omitempty"`
 	Status          string    `json:"status"`
 	Result          *string   `json:"result,omitempty"`
+	FinishPosition  *int      `json:"finish_position,omitempty"`
+	DeadHeatCount   int       `json:"dead_heat_count"`
+	Line            *int      `json:"line,omitempty"` // handicap or total x100
+	Side            *string   `json:"side,omitempty"` // home, away, over, under
 	SortOrder       int       `json:"sort_order"`
 	CreatedAt       time.Time `json:"created_at"`
 }
@@ -60,14 +81,34 @@ type SportsSelection struct {
 // BetStatusOpen is the initial state for placed sportsbook bets.
 const BetStatusOpen BetStatus = "open"
 
-// SportsBetRecord represents a sports_bets row.
+// BetStatusCashedOut marks a bet the player settled early at its cash-out
+// value.
+const BetStatusCashedOut BetStatus = "cashed_out"
+
+// Bet classes: a single on one selection, an each-way single (a win and a
+// place line), a system bet covering combinations of several selections or a
+// bet builder combining markets of one event at a correlated price.
+const (
+	BetClassSingle     = "single"
+	BetClassEachWay    = "each_way"
+	BetClassSystem     = "system"
+	BetClassBetBuilder = "bet_builder"
+)
+
+// SportsBetRecord represents a sports_bets row. System bets and bet builders
+// have no market or selection of their own; their selections are in Legs.
 type SportsBetRecord struct {
 	ID                 uuid.UUID       `json:"id"`
 	PlayerID           uuid.UUID       `json:"player_id"`
-	EventID            uuid.UUID       `json:"event_id"`
-	MarketID           uuid.UUID       `json:"market_id"`
-	SelectionID        uuid.UUID       `json:"selection_id"`
+	EventID            *uuid.UUID      `json:"event_id"`
+	MarketID           *uuid.UUID      `json:"market_id"`
+	SelectionID        *uuid.UUID      `json:"selection_id"`
+	BetClass           string          `json:"bet_class"`
+	SystemType         *string         `json:"system_type,omitempty"`
+	EachWay            bool            `json:"each_way"`
 	StakeAmountMinor   int             `json:"stake_amount_minor"`
+	StakePerLineMinor  int64           `json:"stake_per_line_minor"`
+	NumLines           int             `json:"num_lines"`
 	Currency           string          `json:"currency"`
 	OddsAtPlacement    int             `json:"odds_at_placement"`
 	PotentialPayoutMinor int           `json:"potential_payout_minor"`
@@ -77,4 +118,111 @@ type SportsBetRecord struct {
 	TransactionID      *uuid.UUID      `json:"transaction_id,omitempty"`
 	PlacedAt           time.Time       `json:"placed_at"`
 	SettledAt          *time.Time      `json:"settled_at,omitempty"`
+	Legs               []SportsBetLeg  `json:"legs,omitempty"`
+}
+
+// SportsBetLeg is one selection of a system bet or bet builder.
+type SportsBetLeg struct {
+	LegNo           int       `json:"leg_no"`
+	EventID         uuid.UUID `json:"event_id"`
+	MarketID        uuid.UUID `json:"market_id"`
+	SelectionID     uuid.UUID `json:"selection_id"`
+	OddsAtPlacement int       `json:"odds_at_placement"`
+}
+
+// OddsChange is a sports_odds_changes row: one selection repriced by a
+// trader's bulk odds edit.
+type OddsChange struct {
+	ID             int64     `json:"id"`
+	EditID         uuid.UUID `json:"edit_id"`
+	SelectionID    uuid.UUID `json:"selection_id"`
+	MarketID       uuid.UUID `json:"market_id"`
+	OldOdds        int       `json:"old_odds"`
+	NewOdds        int       `json:"new_odds"`
+	MarginDeltaBps *int      `json:"margin_delta_bps,omitempty"`
+	Reason         string    `json:"reason"`
+	AdminID        uuid.UUID `json:"admin_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TradingAlert is a trading_alerts row: an unusual stake pattern flagged for
+// trader review. SelectionID is set for selection spikes, PlayerID for
+// players holding a large share of a market's handle.
+type TradingAlert struct {
+	ID             uuid.UUID       `json:"id"`
+	AlertType      string          `json:"alert_type"`
+	MarketID       uuid.UUID       `json:"market_id"`
+	SelectionID    *uuid.UUID      `json:"selection_id,omitempty"`
+	PlayerID       *uuid.UUID      `json:"player_id,omitempty"`
+	Details        json.RawMessage `json:"details"`
+	Status         string          `json:"status"`
+	AcknowledgedBy *uuid.UUID      `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// SelectionLiability summarises open bets on a selection of an unsettled
+// market: the stake taken and what the book pays if it wins.
+type SelectionLiability struct {
+	SelectionID   uuid.UUID `json:"selection_id"`
+	SelectionName string    `json:"selection_name"`
+	MarketID      uuid.UUID `json:"market_id"`
+	MarketName    string    `json:"market_name"`
+	OddsDecimal   int       `json:"odds_decimal"`
+	OpenBets      int       `json:"open_bets"`
+	Stake         int64     `json:"stake"`     // cents
+	Liability     int64     `json:"liability"` // potential payout, cents
+	OpenAlerts    int       `json:"open_alerts"`
+}
+
+// EventExposure is the open-bet liability on an event against its limit.
+// WorstCase sums the largest selection liability of each market; a zero
+// LiabilityLimit is not enforced. Markets and Players are only filled for a
+// single event.
+type EventExposure struct {
+	EventID        uuid.UUID        `json:"event_id"`
+	HomeTeam       string           `json:"home_team"`
+	AwayTeam       string           `json:"away_team"`
+	Status         string           `json:"status"`
+	StartTime      time.Time        `json:"start_time"`
+	OpenBets       int              `json:"open_bets"`
+	Stake          int64            `json:"stake"`      // cents
+	WorstCase      int64            `json:"worst_case"` // cents
+	LiabilityLimit int64            `json:"liability_limit"`
+	LimitOverride  bool             `json:"limit_override"`
+	Markets        []MarketExposure `json:"markets,omitempty"`
+	Players        []PlayerExposure `json:"players,omitempty"`
+}
+
+// MarketExposure is the open-bet liability on one market of an event.
+type MarketExposure struct {
+	MarketID   uuid.UUID            `json:"market_id"`
+	MarketName string               `json:"market_name"`
+	OpenBets   int                  `json:"open_bets"`
+	Stake      int64                `json:"stake"`
+	WorstCase  int64                `json:"worst_case"`
+	Selections []SelectionLiability `json:"selections"`
+}
+
+// PlayerExposure is one player's open-bet liability on an event.
+type PlayerExposure struct {
+	PlayerID  uuid.UUID `json:"player_id"`
+	OpenBets  int       `json:"open_bets"`
+	Stake     int64     `json:"stake"`
+	Liability int64     `json:"liability"`
+}
+
+// SettlementAudit is a sports_settlement_audit row: one automatic settlement
+// attempt and the feed data it was based on.
+type SettlementAudit struct {
+	ID              int64           `json:"id"`
+	EventID         uuid.UUID       `json:"event_id"`
+	Source          string          `json:"source"`
+	ExternalEventID string          `json:"external_event_id"`
+	ScoreHome       int             `json:"score_home"`
+	ScoreAway       int             `json:"score_away"`
+	SourceData      json.RawMessage `json:"source_data"`
+	Result          json.RawMessage `json:"result,omitempty"`
+	Error           *string         `json:"error,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
 }
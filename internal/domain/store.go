@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoreItemKind identifies what a store item grants.
+type StoreItemKind string
+
+const (
+	StoreItemCoinBundle StoreItemKind = "coin_bundle" // credits a coin wallet through the ledger
+	StoreItemAvatar     StoreItemKind = "avatar_item" // permanent cosmetic, owned once
+	StoreItemQuestBoost StoreItemKind = "quest_boost" // multiplies quest rewards for a while
+)
+
+// StoreItem represents a store_items row. Only the grant fields of its kind
+// are set.
+type StoreItem struct {
+	ID                 uuid.UUID     `json:"id"`
+	SKU                string        `json:"sku"`
+	Name               string        `json:"name"`
+	Description        string        `json:"description"`
+	Kind               StoreItemKind `json:"kind"`
+	Price              int64         `json:"price"` // minor units of PriceCurrency
+	PriceCurrency      string        `json:"price_currency"`
+	CoinCurrency       *string       `json:"coin_currency,omitempty"`
+	CoinAmount         int64         `json:"coin_amount,omitempty"`
+	ItemCode           *string       `json:"item_code,omitempty"`
+	BoostMultiplierBps int           `json:"boost_multiplier_bps,omitempty"` // 15000 = 1.5x
+	BoostMinutes       int           `json:"boost_minutes,omitempty"`
+	Active             bool          `json:"active"`
+	SortOrder          int           `json:"sort_order"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+}
+
+// StoreOrder represents a store_orders row. The item's SKU, name and kind are
+// copied so the history survives catalog edits.
+type StoreOrder struct {
+	ID            uuid.UUID     `json:"id"`
+	PlayerID      uuid.UUID     `json:"player_id"`
+	ItemID        uuid.UUID     `json:"item_id"`
+	PaymentID     uuid.UUID     `json:"payment_id"`
+	SKU           string        `json:"sku"`
+	ItemName      string        `json:"item_name"`
+	Kind          StoreItemKind `json:"kind"`
+	Price         int64         `json:"price"`
+	PriceCurrency string        `json:"price_currency"`
+	Status        string        `json:"status"` // pending, completed
+	CreatedAt     time.Time     `json:"created_at"`
+	CompletedAt   *time.Time    `json:"completed_at,omitempty"`
+}
+
+// Entitlement represents a player_entitlements row: what a completed store
+// order granted.
+type Entitlement struct {
+	ID                 uuid.UUID     `json:"id"`
+	PlayerID           uuid.UUID     `json:"player_id"`
+	ItemID             uuid.UUID     `json:"item_id"`
+	OrderID            uuid.UUID     `json:"order_id"`
+	Kind               StoreItemKind `json:"kind"`
+	ItemCode           *string       `json:"item_code,omitempty"`
+	CoinCurrency       *string       `json:"coin_currency,omitempty"`
+	CoinAmount         int64         `json:"coin_amount,omitempty"`
+	TransactionID      *uuid.UUID    `json:"transaction_id,omitempty"`
+	BoostMultiplierBps int           `json:"boost_multiplier_bps,omitempty"`
+	ExpiresAt          *time.Time    `json:"expires_at,omitempty"`
+	CreatedAt          time.Time     `json:"created_at"`
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sweepstakes wallet currencies. Gold Coins are play-only; Sweeps Coins can
+// be redeemed for cash once played through.
+const (
+	CurrencyGoldCoins   = "GC"
+	CurrencySweepsCoins = "SC"
+)
+
+// CoinPackage represents a coin_packages row: a purchasable bundle of GC and
+// bonus SC.
+type CoinPackage struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	Price         int64     `json:"price"` // minor units of PriceCurrency
+	PriceCurrency string    `json:"price_currency"`
+	GoldCoins     int64     `json:"gold_coins"`
+	SweepsCoins   int64     `json:"sweeps_coins"`
+	Active        bool      `json:"active"`
+	SortOrder     int       `json:"sort_order"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CoinPurchase represents a coin_purchases row. The coins are copied from the
+// package at checkout so later package edits do not change what was bought.
+type CoinPurchase struct {
+	ID            uuid.UUID  `json:"id"`
+	PlayerID      uuid.UUID  `json:"player_id"`
+	PackageID     uuid.UUID  `json:"package_id"`
+	PaymentID     uuid.UUID  `json:"payment_id"`
+	Price         int64      `json:"price"`
+	PriceCurrency string     `json:"price_currency"`
+	GoldCoins     int64      `json:"gold_coins"`
+	SweepsCoins   int64      `json:"sweeps_coins"`
+	Status        string     `json:"status"` // pending, completed
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// RedemptionStatus tracks an SC redemption through review.
+type RedemptionStatus string
+
+const (
+	RedemptionPending  RedemptionStatus = "pending"
+	RedemptionPaid     RedemptionStatus = "paid"
+	RedemptionRejected RedemptionStatus = "rejected" // reserved SC returned to the player
+)
+
+// SweepsRedemption represents a sweeps_redemptions row.
+type SweepsRedemption struct {
+	ID                   uuid.UUID        `json:"id"`
+	PlayerID             uuid.UUID        `json:"player_id"`
+	Amount               int64            `json:"amount"` // SC minor units
+	CashAmount           int64            `json:"cash_amount"`
+	CashCurrency         string           `json:"cash_currency"`
+	Status               RedemptionStatus `json:"status"`
+	ReserveTransactionID uuid.UUID        `json:"reserve_transaction_id"`
+	SettleTransactionID  *uuid.UUID       `json:"settle_transaction_id,omitempty"`
+	ReviewedBy           *uuid.UUID       `json:"reviewed_by,omitempty"`
+	ReviewNote           *string          `json:"review_note,omitempty"`
+	CreatedAt            time.Time        `json:"created_at"`
+	ReviewedAt           *time.Time       `json:"reviewed_at,omitempty"`
+}
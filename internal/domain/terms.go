@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TermsKind is the kind of legal document a player must accept.
+type TermsKind string
+
+const (
+	TermsGeneral TermsKind = "terms"
+	TermsBonus   TermsKind = "bonus_terms"
+)
+
+// Valid reports whether k is a known document kind.
+func (k TermsKind) Valid() bool {
+	return k == TermsGeneral || k == TermsBonus
+}
+
+// TermsDocument represents a terms_documents row. Body is omitted from
+// listings that only need to name the document.
+type TermsDocument struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        TermsKind  `json:"kind"`
+	Version     int        `json:"version"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body,omitempty"`
+	ContentHash string     `json:"content_hash"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// TermsAcceptance represents a terms_acceptances row.
+type TermsAcceptance struct {
+	PlayerID    uuid.UUID `json:"player_id"`
+	DocumentID  uuid.UUID `json:"document_id"`
+	Kind        TermsKind `json:"kind"`
+	Version     int       `json:"version"`
+	ContentHash string    `json:"content_hash"`
+	IP          *string   `json:"ip,omitempty"`
+	AcceptedAt  time.Time `json:"accepted_at"`
+}
+
+// TermsContentHash is the hex SHA-256 of a document's title and body.
+func TermsContentHash(title, body string) string {
+	sum := sha256.Sum256([]byte(title + "\n\n" + body))
+	return hex.EncodeToString(sum[:])
+}
@@ -19,17 +19,36 @@ const (
 	TxWin                 TransactionType = "win"
 	TxSettlementLoss      TransactionType = "settlement_loss"
 
+	// TxCashOut settles an open sportsbook bet early at its cash-out value,
+	// closing the bet's round like a win.
+	TxCashOut TransactionType = "cash_out"
+
+	// Reservation: a stake held in reserved_balance until the provider
+	// releases it into a bet (see ExecuteRelease) or cancels it.
+	TxReserve TransactionType = "bet_reserve"
+
 	// Cancellation
 	TxCancelDeposit     TransactionType = "cancel_deposit"
 	TxCancelBet         TransactionType = "cancel_bet"
 	TxCancelWin         TransactionType = "cancel_win"
 	TxCancelWithdrawal  TransactionType = "wallet_cancel_withdrawal"
+	TxCancelReserve     TransactionType = "cancel_bet_reserve"
 
 	// Bonus
 	TxBonusCredit      TransactionType = "bonus_credit"
 	TxBonusForfeit     TransactionType = "bonus_forfeit"
 	TxBonusLost        TransactionType = "bonus_lost"
 	TxTurnBonusToReal  TransactionType = "turn_bonus_to_real"
+
+	// Currency conversion between a player's wallets
+	TxConversionOut TransactionType = "fx_conversion_out"
+	TxConversionIn  TransactionType = "fx_conversion_in"
+
+	// Player-to-player transfer: debit on the sender, credit on the
+	// recipient, and the sender's fee when one applies
+	TxTransferOut TransactionType = "p2p_transfer_out"
+	TxTransferIn  TransactionType = "p2p_transfer_in"
+	TxTransferFee TransactionType = "p2p_transfer_fee"
 )
 
 // CancellationTypeMap maps original transaction types to their cancel type.
@@ -38,6 +57,7 @@ var CancellationTypeMap = map[TransactionType]TransactionType{
 	TxBet:        TxCancelBet,
 	TxWin:        TxCancelWin,
 	TxWithdrawal: TxCancelWithdrawal,
+	TxReserve:    TxCancelReserve,
 }
 
 // CasinoWinType specifies sub-types of casino wins stored in metadata.
@@ -48,6 +68,10 @@ const (
 	CasinoWinJackpot      CasinoWinType = "win_jackpot"
 	CasinoWinLocalJackpot CasinoWinType = "win_local_jackpot"
 	CasinoWinFreespins    CasinoWinType = "win_freespins"
+	// CasinoWinPromoFreeRound is a payout from operator-granted promotional
+	// free rounds. There is no real-money stake behind it, so the whole
+	// amount is credited to bonus balance and wagered like any other bonus.
+	CasinoWinPromoFreeRound CasinoWinType = "win_promo_freeround"
 )
 
 // Transaction represents a v2_transactions row (append-only ledger entry).
@@ -66,6 +90,7 @@ type Transaction struct {
 	GameRoundID           *string         `json:"game_round_id,omitempty"`
 	Metadata              json.RawMessage `json:"metadata"`
 	CreatedAt             time.Time       `json:"created_at"`
+	Currency              string          `json:"currency,omitempty"`
 }
 
 // IdempotencyKey is the composite key used for deduplication.
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -34,7 +35,11 @@ type PostLedgerEntryParams struct {
 	SubTransactionID      *string
 	TargetTransactionID   *uuid.UUID
 	GameRoundID           *string
+	GameID                *string // provider's game id, recorded on the round it opens
 	Metadata              json.RawMessage
+	// Currency selects a secondary wallet (player_wallets). Empty posts to
+	// the base balances on v2_players.
+	Currency string
 }
 
 // CommandResult is the return value from all 9 wallet commands.
@@ -53,6 +58,7 @@ type DepositParams struct {
 	ManufacturerID        string
 	SubTransactionID      string
 	Metadata              json.RawMessage
+	Currency              string // wallet currency; empty means the player's base currency
 }
 
 // PlaceBetParams holds the input for ExecutePlaceBet.
@@ -63,7 +69,9 @@ type PlaceBetParams struct {
 	ManufacturerID        string
 	SubTransactionID      string
 	GameRoundID           string
+	GameID                string // provider's game id, for bonus wagering weights
 	Metadata              json.RawMessage
+	Currency              string
 }
 
 // CreditWinParams holds the input for ExecuteCreditWin.
@@ -76,6 +84,42 @@ type CreditWinParams struct {
 	GameRoundID           string
 	WinType               CasinoWinType
 	Metadata              json.RawMessage
+	Currency              string
+}
+
+// CashOutParams holds the input for ExecuteCashOut.
+type CashOutParams struct {
+	PlayerID              uuid.UUID
+	Amount                int64
+	ExternalTransactionID string
+	ManufacturerID        string
+	GameRoundID           string
+	Metadata              json.RawMessage
+	Currency              string
+}
+
+// ReserveParams holds the input for ExecuteReserve.
+type ReserveParams struct {
+	PlayerID              uuid.UUID
+	Amount                int64
+	ExternalTransactionID string
+	ManufacturerID        string
+	GameRoundID           string
+	Metadata              json.RawMessage
+	Currency              string
+}
+
+// ReleaseParams holds the input for ExecuteRelease. The reservation is found
+// by the provider's reference for it; WinAmount may be zero for a losing round.
+type ReleaseParams struct {
+	PlayerID              uuid.UUID
+	ReservationID         string // provider transaction ID of the reservation
+	WinAmount             int64
+	ExternalTransactionID string // provider transaction ID of the release
+	ManufacturerID        string
+	GameRoundID           string
+	Metadata              json.RawMessage
+	Currency              string
 }
 
 // CancelTransactionParams holds the input for ExecuteCancelTransaction.
@@ -95,6 +139,7 @@ type WithdrawParams struct {
 	Amount                int64
 	ExternalTransactionID string
 	Metadata              json.RawMessage
+	Currency              string
 }
 
 // CompleteWithdrawalParams holds the input for ExecuteCompleteWithdrawal.
@@ -103,6 +148,7 @@ type CompleteWithdrawalParams struct {
 	Amount                int64
 	ExternalTransactionID string
 	Metadata              json.RawMessage
+	Currency              string
 }
 
 // BonusCreditParams holds the input for ExecuteBonusCredit.
@@ -111,6 +157,7 @@ type BonusCreditParams struct {
 	Amount                int64
 	ExternalTransactionID string
 	Metadata              json.RawMessage
+	Currency              string
 }
 
 // TurnBonusToRealParams holds the input for ExecuteTurnBonusToReal.
@@ -119,6 +166,7 @@ type TurnBonusToRealParams struct {
 	Amount                int64
 	ExternalTransactionID string
 	Metadata              json.RawMessage
+	Currency              string
 }
 
 // ForfeitBonusParams holds the input for ExecuteForfeitBonus.
@@ -128,4 +176,50 @@ type ForfeitBonusParams struct {
 	ExternalTransactionID string
 	IsBonusLost           bool // true → bonus_lost, false → bonus_forfeit
 	Metadata              json.RawMessage
+	Currency              string
+}
+
+// ExpireBonusParams holds the input for ExecuteExpireBonus.
+type ExpireBonusParams struct {
+	PlayerID      uuid.UUID
+	PlayerBonusID uuid.UUID
+	Now           time.Time // expiry is checked against this instant
+}
+
+// Wallet is one currency balance held by a player. The base wallet lives on
+// v2_players; additional currencies live in player_wallets.
+type Wallet struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Currency string    `json:"currency"`
+	Balances
+	Base      bool      `json:"base"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ConversionParams holds the input for ExecuteCurrencyConversion.
+type ConversionParams struct {
+	PlayerID              uuid.UUID
+	FromCurrency          string
+	ToCurrency            string
+	Amount                int64 // debited from FromCurrency, minor units
+	ConvertedAmount       int64 // credited to ToCurrency, minor units
+	Rate                  string
+	ExternalTransactionID string
+}
+
+// ConversionResult holds both legs of a currency conversion.
+type ConversionResult struct {
+	Debit      *Transaction `json:"debit"`
+	Credit     *Transaction `json:"credit"`
+	Rate       string       `json:"rate"`
+	Idempotent bool         `json:"idempotent"`
+}
+
+// FxRate is an admin-maintained conversion rate: 1 Base = Rate Quote.
+type FxRate struct {
+	Base      string     `json:"base"`
+	Quote     string     `json:"quote"`
+	Rate      string     `json:"rate"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,46 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CheckAccountActive returns an error if the player may not move money or
+// place bets: ACCOUNT_SUSPENDED (with the reinstate time) during a suspension
+// window, ACCOUNT_INACTIVE when self-excluded or closed. A suspension whose
+// window has passed is honoured as lifted even if the reinstatement job has
+// not run yet.
+func CheckAccountActive(ctx context.Context, db repository.DBTX, playerID uuid.UUID) error {
+	var status string
+	var suspendedUntil *time.Time
+	err := db.QueryRow(ctx, `
+		SELECT account_status, suspended_until FROM player_profiles WHERE player_id = $1`,
+		playerID).Scan(&status, &suspendedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return domain.ErrInternal("check account status", err)
+	}
+	return EvaluateAccountStatus(domain.AccountStatus(status), suspendedUntil, time.Now())
+}
+
+// EvaluateAccountStatus applies the account-status rules at the given time.
+func EvaluateAccountStatus(status domain.AccountStatus, suspendedUntil *time.Time, now time.Time) error {
+	switch status {
+	case domain.AccountStatusSuspended:
+		if suspendedUntil != nil && !now.Before(*suspendedUntil) {
+			return nil
+		}
+		return domain.ErrAccountSuspended(suspendedUntil)
+	case domain.AccountStatusSelfExcluded, domain.AccountStatusClosed:
+		return domain.ErrAccountInactive(status)
+	}
+	return nil
+}
@@ -18,6 +18,25 @@ const (
 	CircuitHalfOpen
 )
 
+// String returns the state name reported by health endpoints.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitStatus is a point-in-time view of one circuit.
+type CircuitStatus struct {
+	State       CircuitState
+	Failures    int
+	LastFailure time.Time // zero if the circuit never failed
+}
+
 // CircuitBreaker implements a per-plugin circuit breaker.
 type CircuitBreaker struct {
 	mu             sync.RWMutex
@@ -121,3 +140,20 @@ func (cb *CircuitBreaker) RecordFailure(key string) {
 		c.state = CircuitOpen
 	}
 }
+
+// Status reports the circuit for key. An open circuit whose reset timeout has
+// passed is reported half-open, since the next request will probe it.
+func (cb *CircuitBreaker) Status(key string) CircuitStatus {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	c, ok := cb.circuits[key]
+	if !ok {
+		return CircuitStatus{State: CircuitClosed}
+	}
+	state := c.state
+	if state == CircuitOpen && time.Since(c.lastFailure) > cb.resetTimeout {
+		state = CircuitHalfOpen
+	}
+	return CircuitStatus{State: state, Failures: c.failures, LastFailure: c.lastFailure}
+}
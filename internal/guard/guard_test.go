@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/attaboy/platform/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,6 +76,25 @@ func TestCircuitBreaker_SuccessResets(t *testing.T) {
 	assert.True(t, result.Allowed)
 }
 
+func TestCircuitBreaker_Status(t *testing.T) {
+	cb := NewCircuitBreaker(2, 20*time.Millisecond)
+	ctx := context.Background()
+
+	assert.Equal(t, CircuitClosed, cb.Status("provider-a").State)
+
+	cb.Check(ctx, "provider-a")
+	cb.RecordFailure("provider-a")
+	cb.RecordFailure("provider-a")
+	status := cb.Status("provider-a")
+	assert.Equal(t, CircuitOpen, status.State)
+	assert.Equal(t, "open", status.State.String())
+	assert.Equal(t, 2, status.Failures)
+	assert.False(t, status.LastFailure.IsZero())
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, cb.Status("provider-a").State)
+}
+
 func TestIdempotencyGuard_AllowsFirst(t *testing.T) {
 	ig := NewIdempotencyGuard()
 	ctx := context.Background()
@@ -115,3 +135,45 @@ func TestIdempotencyGuard_RemoveAllowsRetry(t *testing.T) {
 	result := ig.Check(ctx, "req-456")
 	require.True(t, result.Allowed)
 }
+
+func TestClassifyDevice(t *testing.T) {
+	tests := []struct {
+		ua   string
+		want string
+	}{
+		{"", "unknown"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile/15E148", "mobile"},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) Mobile Safari/537.36", "mobile"},
+		{"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X)", "tablet"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0", "desktop"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) Safari/605.1.15", "desktop"},
+		{"Googlebot/2.1 (+http://www.google.com/bot.html)", "bot"},
+		{"curl/8.4.0", "bot"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ClassifyDevice(tt.ua), tt.ua)
+	}
+}
+
+func TestEvaluateAccountStatus(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Minute)
+
+	assert.NoError(t, EvaluateAccountStatus(domain.AccountStatusActive, nil, now))
+	assert.NoError(t, EvaluateAccountStatus(domain.AccountStatusSuspended, &past, now))
+
+	err := EvaluateAccountStatus(domain.AccountStatusSuspended, &future, now)
+	var appErr *domain.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "ACCOUNT_SUSPENDED", appErr.Code)
+	assert.Equal(t, future.UTC(), appErr.Details["reinstate_at"])
+
+	err = EvaluateAccountStatus(domain.AccountStatusSuspended, nil, now)
+	require.ErrorAs(t, err, &appErr)
+	assert.Nil(t, appErr.Details)
+
+	err = EvaluateAccountStatus(domain.AccountStatusClosed, nil, now)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "ACCOUNT_INACTIVE", appErr.Code)
+}
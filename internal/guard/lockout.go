@@ -2,9 +2,11 @@ package guard
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -15,10 +17,47 @@ const (
 
 // RecordAttempt inserts a login attempt row.
 func RecordAttempt(ctx context.Context, pool *pgxpool.Pool, email, realm, ip string, success bool) {
+	RecordAttemptDetail(ctx, pool, LoginAttempt{Email: email, Realm: realm, IP: ip, Success: success})
+}
+
+// LoginAttempt carries the full context of a login for the player login history.
+type LoginAttempt struct {
+	Email     string
+	Realm     string
+	PlayerID  *uuid.UUID // nil when the email did not match an account
+	IP        string
+	Country   string
+	UserAgent string
+	Success   bool
+}
+
+// RecordAttemptDetail inserts a login attempt row with device and location context.
+func RecordAttemptDetail(ctx context.Context, pool *pgxpool.Pool, a LoginAttempt) {
 	_, _ = pool.Exec(ctx, `
-		INSERT INTO login_attempts (email, realm, ip_address, success)
-		VALUES ($1, $2, $3, $4)`,
-		email, realm, ip, success)
+		INSERT INTO login_attempts (email, realm, ip_address, success, player_id, country, user_agent, device)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		a.Email, a.Realm, a.IP, a.Success, a.PlayerID, a.Country, a.UserAgent, ClassifyDevice(a.UserAgent))
+}
+
+// ClassifyDevice buckets a User-Agent into mobile, tablet, desktop, bot or unknown.
+func ClassifyDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return "unknown"
+	case strings.Contains(ua, "bot") || strings.Contains(ua, "crawler") || strings.Contains(ua, "spider") ||
+		strings.HasPrefix(ua, "curl/") || strings.HasPrefix(ua, "python-requests"):
+		return "bot"
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet"):
+		return "tablet"
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone") || strings.Contains(ua, "android"):
+		return "mobile"
+	case strings.Contains(ua, "windows") || strings.Contains(ua, "macintosh") || strings.Contains(ua, "linux") ||
+		strings.Contains(ua, "x11") || strings.Contains(ua, "cros"):
+		return "desktop"
+	default:
+		return "unknown"
+	}
 }
 
 // CheckLocked returns ErrAccountLocked if the account has >= MaxAttempts failed
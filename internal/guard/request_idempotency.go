@@ -0,0 +1,110 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// IdempotencyKeyTTL is how long a stored response is replayed.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyClaimTTL is how long an unfinished request holds its key
+	// before a retry may take it over; it outlasts the API write timeout.
+	idempotencyClaimTTL = time.Minute
+)
+
+// StoredResponse is a response recorded under an Idempotency-Key.
+type StoredResponse struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// ClaimIdempotencyKey reserves a player's Idempotency-Key for a request.
+// It returns nil when the caller should process the request, or the stored
+// response when the same request already completed. A key held by an
+// unfinished request returns ErrIdempotencyKeyInProgress; a key first used
+// for a different request returns ErrIdempotencyKeyReused. Expired keys, and
+// claims abandoned by a request that never finished, are taken over.
+func ClaimIdempotencyKey(ctx context.Context, db repository.DBTX, playerID uuid.UUID, key, scope, requestHash string) (*StoredResponse, error) {
+	now := time.Now()
+	if _, err := db.Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE player_id = $1 AND created_at < $2`,
+		playerID, now.Add(-IdempotencyKeyTTL)); err != nil {
+		return nil, domain.ErrInternal("purge idempotency keys", err)
+	}
+
+	var claimed bool
+	err := db.QueryRow(ctx, `
+		INSERT INTO idempotency_keys (player_id, key, scope, request_hash)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (player_id, key) DO UPDATE
+		SET scope = EXCLUDED.scope, request_hash = EXCLUDED.request_hash, status_code = NULL,
+		    content_type = NULL, response_body = NULL, created_at = now(), completed_at = NULL
+		WHERE idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < $5
+		RETURNING true`,
+		playerID, key, scope, requestHash, now.Add(-idempotencyClaimTTL)).Scan(&claimed)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInternal("claim idempotency key", err)
+	}
+
+	var hash string
+	var status *int
+	var contentType *string
+	var body []byte
+	err = db.QueryRow(ctx, `
+		SELECT request_hash, status_code, content_type, response_body
+		FROM idempotency_keys WHERE player_id = $1 AND key = $2`,
+		playerID, key).Scan(&hash, &status, &contentType, &body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrIdempotencyKeyInProgress()
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("read idempotency key", err)
+	}
+	if hash != requestHash {
+		return nil, domain.ErrIdempotencyKeyReused()
+	}
+	if status == nil {
+		return nil, domain.ErrIdempotencyKeyInProgress()
+	}
+	stored := &StoredResponse{Status: *status, Body: body}
+	if contentType != nil {
+		stored.ContentType = *contentType
+	}
+	return stored, nil
+}
+
+// CompleteIdempotencyKey records the response to a claimed key.
+func CompleteIdempotencyKey(ctx context.Context, db repository.DBTX, playerID uuid.UUID, key string, resp StoredResponse) error {
+	_, err := db.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = NULLIF($4, ''), response_body = $5, completed_at = now()
+		WHERE player_id = $1 AND key = $2`,
+		playerID, key, resp.Status, resp.ContentType, resp.Body)
+	if err != nil {
+		return domain.ErrInternal("complete idempotency key", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops a claimed key so the request can be retried,
+// used when it failed without a result worth replaying.
+func ReleaseIdempotencyKey(ctx context.Context, db repository.DBTX, playerID uuid.UUID, key string) error {
+	_, err := db.Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE player_id = $1 AND key = $2 AND status_code IS NULL`,
+		playerID, key)
+	if err != nil {
+		return domain.ErrInternal("release idempotency key", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package guard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CheckPlayerLimits returns RG_LIMIT_BREACHED when amount would take the
+// player past one of their own limits of the given type in its current
+// period. Deposits count completed deposits; losses count stakes less
+// winnings and cancelled stakes, so amount is treated as a potential loss.
+// Pending increases are honoured once their cooldown has passed.
+func CheckPlayerLimits(ctx context.Context, db repository.DBTX, playerID uuid.UUID, limitType domain.PlayerLimitType, amount int64) error {
+	rows, err := db.Query(ctx, `
+		SELECT period,
+		       (CASE WHEN pending_effective_at <= now() THEN pending_value ELSE limit_value END)::bigint
+		FROM player_limits
+		WHERE player_id = $1 AND type = $2 AND active`, playerID, string(limitType))
+	if err != nil {
+		return domain.ErrInternal("query player limits", err)
+	}
+	type limit struct {
+		period domain.LimitPeriod
+		value  int64
+	}
+	var limits []limit
+	for rows.Next() {
+		var l limit
+		if err := rows.Scan(&l.period, &l.value); err != nil {
+			rows.Close()
+			return domain.ErrInternal("scan player limit", err)
+		}
+		limits = append(limits, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ErrInternal("read player limits", err)
+	}
+
+	now := time.Now()
+	for _, l := range limits {
+		if l.value <= 0 {
+			continue
+		}
+		used, err := PlayerLimitUsage(ctx, db, playerID, limitType, policy.LimitPeriodStart(l.period, now))
+		if err != nil {
+			return err
+		}
+		if !policy.PlayerLimitAllows(l.value, used, amount) {
+			action := "deposit"
+			if limitType == domain.LimitLoss {
+				action = "bet"
+			}
+			return domain.ErrRGLimitBreached(action, string(l.period)+"_"+string(limitType), l.value)
+		}
+	}
+	return nil
+}
+
+// PlayerLimitUsage returns how much of a limit type the player has used
+// since from, in cents. Net losses below zero count as nothing used.
+func PlayerLimitUsage(ctx context.Context, db repository.DBTX, playerID uuid.UUID, limitType domain.PlayerLimitType, from time.Time) (int64, error) {
+	var used int64
+	var err error
+	if limitType == domain.LimitDeposit {
+		err = db.QueryRow(ctx, `
+			SELECT COALESCE(SUM(amount), 0)::bigint FROM v2_transactions
+			WHERE player_id = $1 AND type = $2 AND created_at >= $3`,
+			playerID, string(domain.TxDeposit), from).Scan(&used)
+	} else {
+		err = db.QueryRow(ctx, `
+			SELECT GREATEST(COALESCE(SUM(CASE WHEN type = $2 THEN amount ELSE -amount END), 0), 0)::bigint
+			FROM v2_transactions
+			WHERE player_id = $1 AND type IN ($2, $3, $4, $5) AND created_at >= $6`,
+			playerID, string(domain.TxBet), string(domain.TxWin), string(domain.TxCancelBet), string(domain.TxCashOut), from).Scan(&used)
+	}
+	if err != nil {
+		return 0, domain.ErrInternal("sum player limit usage", err)
+	}
+	return used, nil
+}
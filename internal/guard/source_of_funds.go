@@ -0,0 +1,51 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CheckSourceOfFunds returns SOURCE_OF_FUNDS_REQUIRED once the player's
+// lifetime deposits reach a threshold whose questionnaire has not been
+// submitted. The questionnaire is opened on first check so the player finds
+// it waiting. A rejected questionnaire blocks again until it is resubmitted.
+func CheckSourceOfFunds(ctx context.Context, db repository.DBTX, playerID uuid.UUID, thresholds []int64) error {
+	if len(thresholds) == 0 {
+		return nil
+	}
+
+	var deposited int64
+	if err := db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount), 0)::bigint FROM v2_transactions
+		WHERE player_id = $1 AND type = $2`,
+		playerID, string(domain.TxDeposit)).Scan(&deposited); err != nil {
+		return domain.ErrInternal("sum deposits", err)
+	}
+	threshold := policy.SOFThresholdReached(thresholds, deposited)
+	if threshold == 0 {
+		return nil
+	}
+
+	if _, err := db.Exec(ctx, `
+		INSERT INTO sof_questionnaires (player_id, threshold) VALUES ($1, $2)
+		ON CONFLICT (player_id, threshold) DO NOTHING`,
+		playerID, threshold); err != nil {
+		return domain.ErrInternal("open source-of-funds questionnaire", err)
+	}
+
+	var id uuid.UUID
+	var status domain.SOFStatus
+	if err := db.QueryRow(ctx, `
+		SELECT id, status FROM sof_questionnaires WHERE player_id = $1 AND threshold = $2`,
+		playerID, threshold).Scan(&id, &status); err != nil {
+		return domain.ErrInternal("read source-of-funds questionnaire", err)
+	}
+	if status == domain.SOFSubmitted || status == domain.SOFApproved {
+		return nil
+	}
+	return domain.ErrSourceOfFundsRequired(id.String(), threshold)
+}
@@ -0,0 +1,55 @@
+package guard
+
+import (
+	"context"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PendingTerms returns the current published documents (latest version per
+// kind) the player has not accepted yet, without their bodies.
+func PendingTerms(ctx context.Context, db repository.DBTX, playerID uuid.UUID) ([]domain.TermsDocument, error) {
+	rows, err := db.Query(ctx, `
+		SELECT d.id, d.kind, d.version, d.title, d.content_hash, d.created_at, d.published_at
+		FROM (
+			SELECT DISTINCT ON (kind) *
+			FROM terms_documents
+			WHERE published_at IS NOT NULL
+			ORDER BY kind, version DESC
+		) d
+		WHERE NOT EXISTS (
+			SELECT 1 FROM terms_acceptances a WHERE a.player_id = $1 AND a.document_id = d.id)
+		ORDER BY d.kind`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("query pending terms", err)
+	}
+	defer rows.Close()
+
+	pending := []domain.TermsDocument{}
+	for rows.Next() {
+		var d domain.TermsDocument
+		if err := rows.Scan(&d.ID, &d.Kind, &d.Version, &d.Title, &d.ContentHash, &d.CreatedAt, &d.PublishedAt); err != nil {
+			return nil, domain.ErrInternal("scan pending terms", err)
+		}
+		pending = append(pending, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read pending terms", err)
+	}
+	return pending, nil
+}
+
+// CheckTermsAccepted returns TERMS_ACCEPTANCE_REQUIRED while the player has
+// pending documents.
+func CheckTermsAccepted(ctx context.Context, db repository.DBTX, playerID uuid.UUID) error {
+	pending, err := PendingTerms(ctx, db, playerID)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return domain.ErrTermsAcceptanceRequired(pending)
+	}
+	return nil
+}
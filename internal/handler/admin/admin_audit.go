@@ -0,0 +1,198 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
+)
+
+// AdminAuditHandler serves the admin audit log and the admin activity
+// report built from it.
+type AdminAuditHandler struct {
+	svc *service.AdminAuditService
+}
+
+// NewAdminAuditHandler creates a new AdminAuditHandler.
+func NewAdminAuditHandler(svc *service.AdminAuditService) *AdminAuditHandler {
+	return &AdminAuditHandler{svc: svc}
+}
+
+// parseActivityWindow reads from/to (RFC 3339, default the last 7 days) and
+// tz (an IANA zone for business hours and days, default UTC).
+func parseActivityWindow(r *http.Request) (time.Time, time.Time, policy.BusinessHours, error) {
+	hours := policy.DefaultBusinessHours()
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return from, to, hours, domain.ErrValidation("from must be RFC 3339")
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return from, to, hours, domain.ErrValidation("to must be RFC 3339")
+		}
+		to = t
+	}
+	if !from.Before(to) {
+		return from, to, hours, domain.ErrValidation("from must be before to")
+	}
+	if v := q.Get("tz"); v != "" {
+		loc, err := time.LoadLocation(v)
+		if err != nil {
+			return from, to, hours, domain.ErrValidation("unknown time zone: " + v)
+		}
+		hours.Location = loc
+	}
+	return from, to, hours, nil
+}
+
+// ListEntries handles GET /admin/audit-log?admin_id=&player_id=&from=&to=&tz=&limit=.
+func (h *AdminAuditHandler) ListEntries(w http.ResponseWriter, r *http.Request) {
+	from, to, hours, err := parseActivityWindow(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	f := service.AdminAuditFilter{From: from, To: to}
+	q := r.URL.Query()
+	if v := q.Get("admin_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid admin_id"))
+			return
+		}
+		f.AdminID = &id
+	}
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		f.PlayerID = &id
+	}
+	if v := q.Get("limit"); v != "" {
+		f.Limit, _ = strconv.Atoi(v)
+	}
+
+	entries, err := h.svc.ListEntries(r.Context(), f, hours)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, entries)
+}
+
+// ActivityReport handles GET /admin/reports/admin-activity?from=&to=&tz=.
+func (h *AdminAuditHandler) ActivityReport(w http.ResponseWriter, r *http.Request) {
+	from, to, hours, err := parseActivityWindow(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	report, err := h.svc.ActivityReport(r.Context(), from, to, hours)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, report)
+}
+
+// ExportActivity handles GET /admin/reports/admin-activity/export?view=heatmap|players|out_of_hours&format=csv|json,
+// with the same window as ActivityReport.
+func (h *AdminAuditHandler) ExportActivity(w http.ResponseWriter, r *http.Request) {
+	from, to, hours, err := parseActivityWindow(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		handler.RespondError(w, domain.ErrValidation("format must be json or csv"))
+		return
+	}
+
+	var header []string
+	var records [][]string
+	var rows []interface{}
+	ts := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+	itoa := strconv.Itoa
+	switch view := q.Get("view"); view {
+	case "", "heatmap":
+		cells, err := h.svc.Heatmap(r.Context(), from, to, hours)
+		if err != nil {
+			handler.RespondError(w, err)
+			return
+		}
+		header = []string{"admin_id", "admin_email", "day", "actions", "writes", "denied"}
+		for _, c := range cells {
+			rows = append(rows, c)
+			records = append(records, []string{c.AdminID.String(), c.AdminEmail, c.Day,
+				itoa(c.Actions), itoa(c.Writes), itoa(c.Denied)})
+		}
+	case "players":
+		players, err := h.svc.TopPlayers(r.Context(), from, to, service.MaxAdminActivityRows)
+		if err != nil {
+			handler.RespondError(w, err)
+			return
+		}
+		header = []string{"player_id", "actions", "writes", "admins", "last_at"}
+		for _, p := range players {
+			rows = append(rows, p)
+			records = append(records, []string{p.PlayerID.String(), itoa(p.Actions), itoa(p.Writes),
+				itoa(p.Admins), ts(p.LastAt)})
+		}
+	case "out_of_hours":
+		admins, err := h.svc.OutOfHours(r.Context(), from, to, hours)
+		if err != nil {
+			handler.RespondError(w, err)
+			return
+		}
+		header = []string{"admin_id", "admin_email", "actions", "writes", "first_at", "last_at"}
+		for _, a := range admins {
+			rows = append(rows, a)
+			records = append(records, []string{a.AdminID.String(), a.AdminEmail, itoa(a.Actions),
+				itoa(a.Writes), ts(a.FirstAt), ts(a.LastAt)})
+		}
+	default:
+		handler.RespondError(w, domain.ErrValidation("view must be heatmap, players or out_of_hours"))
+		return
+	}
+
+	if format == "csv" {
+		stream, err := handler.NewCSVStream(w, "admin-activity.csv", header)
+		if err != nil {
+			return
+		}
+		for _, rec := range records {
+			if err := stream.Write(rec); err != nil {
+				return // client went away
+			}
+		}
+		stream.Close()
+		return
+	}
+	stream := handler.NewJSONArrayStream(w)
+	for _, row := range rows {
+		if err := stream.Write(row); err != nil {
+			return
+		}
+	}
+	stream.Close()
+}
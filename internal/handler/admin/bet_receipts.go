@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// BetReceiptAdminHandler checks bet receipts presented in disputes.
+type BetReceiptAdminHandler struct {
+	receiptSvc *service.BetReceiptService
+}
+
+// NewBetReceiptAdminHandler creates a new BetReceiptAdminHandler.
+func NewBetReceiptAdminHandler(receiptSvc *service.BetReceiptService) *BetReceiptAdminHandler {
+	return &BetReceiptAdminHandler{receiptSvc: receiptSvc}
+}
+
+// Verify handles POST /admin/sportsbook/receipts/verify — the body is the
+// receipt's JSON form as downloaded by the player.
+func (h *BetReceiptAdminHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var receipt domain.BetReceipt
+	if err := handler.DecodeJSON(r, &receipt); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"bet_id": receipt.BetID,
+		"valid":  h.receiptSvc.Verify(receipt),
+	})
+}
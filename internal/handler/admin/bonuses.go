@@ -1,10 +1,16 @@
 package admin
 
 import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -13,35 +19,21 @@ import (
 // BonusAdminHandler handles admin bonus management.
 type BonusAdminHandler struct {
 	pool *pgxpool.Pool
+	svc  *service.BonusService
 }
 
 // NewBonusAdminHandler creates a new BonusAdminHandler.
-func NewBonusAdminHandler(pool *pgxpool.Pool) *BonusAdminHandler {
-	return &BonusAdminHandler{pool: pool}
+func NewBonusAdminHandler(pool *pgxpool.Pool, svc *service.BonusService) *BonusAdminHandler {
+	return &BonusAdminHandler{pool: pool, svc: svc}
 }
 
 // ListBonuses handles GET /admin/bonuses.
 func (h *BonusAdminHandler) ListBonuses(w http.ResponseWriter, r *http.Request) {
-	rows, err := h.pool.Query(r.Context(), `
-		SELECT id, name, code, wagering_multiplier, min_deposit, max_bonus,
-		       days_until_expiry, active
-		FROM bonuses ORDER BY active DESC, name ASC LIMIT 50`)
+	bonuses, err := h.svc.ListBonuses(r.Context())
 	if err != nil {
-		handler.RespondError(w, domain.ErrInternal("list bonuses", err))
+		handler.RespondError(w, err)
 		return
 	}
-	defer rows.Close()
-
-	var bonuses []domain.Bonus
-	for rows.Next() {
-		var b domain.Bonus
-		if err := rows.Scan(&b.ID, &b.Name, &b.Code, &b.WageringMultiplier, &b.MinDeposit, &b.MaxBonus, &b.DaysUntilExpiry, &b.Active); err != nil {
-			handler.RespondError(w, domain.ErrInternal("scan bonus", err))
-			return
-		}
-		bonuses = append(bonuses, b)
-	}
-
 	handler.RespondJSON(w, http.StatusOK, bonuses)
 }
 
@@ -55,19 +47,201 @@ func (h *BonusAdminHandler) CreateBonus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var bonusID uuid.UUID
-	err := h.pool.QueryRow(r.Context(), `
-		INSERT INTO bonuses (name, code, wagering_multiplier, min_deposit, max_bonus, days_until_expiry, active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
-		input.Name, input.Code, input.WageringMultiplier, input.MinDeposit,
-		input.MaxBonus, input.DaysUntilExpiry, true,
-	).Scan(&bonusID)
+	bonus, err := h.svc.CreateBonus(r.Context(), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusCreated, bonus)
+}
+
+// SimulateBonus handles POST /admin/bonuses/simulate: it estimates the
+// reach and cost of a proposed bonus over recent history without saving it.
+func (h *BonusAdminHandler) SimulateBonus(w http.ResponseWriter, r *http.Request) {
+	var input service.BonusSimulationInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	sim, err := h.svc.SimulateBonus(r.Context(), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, sim)
+}
+
+// GrantBonus handles POST /admin/players/{id}/bonuses: it grants a bonus to
+// the player, crediting a cash bonus or awarding free spins.
+func (h *BonusAdminHandler) GrantBonus(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		BonusID uuid.UUID `json:"bonus_id"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+	if input.BonusID == uuid.Nil {
+		handler.RespondError(w, domain.ErrValidation("bonus_id is required"))
+		return
+	}
+
+	grant, err := h.svc.Grant(r.Context(), input.BonusID, playerID, &adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, grant)
+}
+
+// BulkGrant handles POST /admin/bonuses/{id}/grant. The players are either
+// a JSON body of {"segment"} or {"player_ids"}, or an uploaded text/csv list
+// with a player ID in the first column. The grant runs in the background;
+// the response is the job to poll for progress.
+func (h *BonusAdminHandler) BulkGrant(w http.ResponseWriter, r *http.Request) {
+	bonusID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid bonus id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.BulkGrantInput
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "text/csv" {
+		input.PlayerIDs, err = readPlayerIDs(http.MaxBytesReader(w, r.Body, 1<<20))
+		if err != nil {
+			handler.RespondError(w, err)
+			return
+		}
+	} else if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	job, err := h.svc.StartBulkGrant(r.Context(), bonusID, input, &adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusAccepted, job)
+}
+
+// readPlayerIDs reads player IDs from the first column of a CSV upload. A
+// first row that is not an ID is taken as a header.
+func readPlayerIDs(body io.Reader) ([]uuid.UUID, error) {
+	cr := csv.NewReader(body)
+	cr.FieldsPerRecord = -1
+	ids := []uuid.UUID{}
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, domain.ErrValidation("invalid csv upload")
+		}
+		field := strings.TrimSpace(record[0])
+		if field == "" {
+			continue
+		}
+		id, err := uuid.Parse(field)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, domain.ErrValidation(fmt.Sprintf("invalid player id on line %d", line))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ListBulkGrants handles GET /admin/bonus-grants.
+func (h *BonusAdminHandler) ListBulkGrants(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.svc.ListBulkGrants(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, jobs)
+}
+
+// GetBulkGrant handles GET /admin/bonus-grants/{id}: the job and its
+// progress.
+func (h *BonusAdminHandler) GetBulkGrant(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		handler.RespondError(w, domain.ErrInternal("create bonus", err))
+		handler.RespondError(w, domain.ErrValidation("invalid bonus grant id"))
 		return
 	}
 
-	handler.RespondJSON(w, http.StatusCreated, map[string]string{"id": bonusID.String()})
+	job, err := h.svc.GetBulkGrant(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, job)
+}
+
+// ListBulkGrantItems handles GET /admin/bonus-grants/{id}/items?status=.
+func (h *BonusAdminHandler) ListBulkGrantItems(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid bonus grant id"))
+		return
+	}
+
+	items, err := h.svc.ListBulkGrantItems(r.Context(), id, r.URL.Query().Get("status"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, items)
+}
+
+// RollbackBulkGrant handles POST /admin/bonus-grants/{id}/rollback: it
+// revokes every bonus a completed grant gave out, in the background.
+func (h *BonusAdminHandler) RollbackBulkGrant(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid bonus grant id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	job, err := h.svc.RollbackBulkGrant(r.Context(), id, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusAccepted, job)
 }
 
 // UpdateBonusStatus handles PATCH /admin/bonuses/{id}/status.
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CasinoAdminHandler serves the casino performance report and RTP alerts.
+type CasinoAdminHandler struct {
+	svc *service.CasinoReportService
+}
+
+// NewCasinoAdminHandler creates a new CasinoAdminHandler.
+func NewCasinoAdminHandler(svc *service.CasinoReportService) *CasinoAdminHandler {
+	return &CasinoAdminHandler{svc: svc}
+}
+
+// parseDay reads a YYYY-MM-DD query parameter, returning def when absent.
+func parseDay(r *http.Request, name string, def time.Time) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return def, domain.ErrValidation(name + " must be a date (YYYY-MM-DD)")
+	}
+	return t, nil
+}
+
+// Performance handles GET /admin/reports/casino?from=&to=&game_id=. The
+// period defaults to the last 30 days including today.
+func (h *CasinoAdminHandler) Performance(w http.ResponseWriter, r *http.Request) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from, err := parseDay(r, "from", today.AddDate(0, 0, -29))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	to, err := parseDay(r, "to", today)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	report, err := h.svc.Report(r.Context(), from, to, r.URL.Query().Get("game_id"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, report)
+}
+
+// Aggregate handles POST /admin/reports/casino/aggregate?day=, recomputing
+// one day (default today) ahead of the scheduler.
+func (h *CasinoAdminHandler) Aggregate(w http.ResponseWriter, r *http.Request) {
+	day, err := parseDay(r, "day", time.Now().UTC())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	summary, err := h.svc.Aggregate(r.Context(), day)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, summary)
+}
+
+// ListAlerts handles GET /admin/casino/rtp-alerts?status=&limit=.
+func (h *CasinoAdminHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	alerts, err := h.svc.ListAlerts(r.Context(), r.URL.Query().Get("status"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, alerts)
+}
+
+// AcknowledgeAlert handles POST /admin/casino/rtp-alerts/{id}/acknowledge.
+func (h *CasinoAdminHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid alert id"))
+		return
+	}
+
+	alert, err := h.svc.AcknowledgeAlert(r.Context(), id, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, alert)
+}
@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ConsentAdminHandler shows a player's consent and its audit trail to
+// support and compliance staff. Only players can change their consent.
+type ConsentAdminHandler struct {
+	svc *service.ConsentService
+}
+
+// NewConsentAdminHandler creates a new ConsentAdminHandler.
+func NewConsentAdminHandler(svc *service.ConsentService) *ConsentAdminHandler {
+	return &ConsentAdminHandler{svc: svc}
+}
+
+// PlayerConsents handles GET /admin/players/{id}/consents.
+func (h *ConsentAdminHandler) PlayerConsents(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	consents, err := h.svc.ListConsents(r.Context(), playerID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	history, err := h.svc.ConsentHistory(r.Context(), playerID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"consents": consents,
+		"history":  history,
+	})
+}
@@ -0,0 +1,180 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ContentAdminHandler manages CMS pages and their versions.
+type ContentAdminHandler struct {
+	contentSvc *service.ContentService
+}
+
+// NewContentAdminHandler creates a new ContentAdminHandler.
+func NewContentAdminHandler(contentSvc *service.ContentService) *ContentAdminHandler {
+	return &ContentAdminHandler{contentSvc: contentSvc}
+}
+
+// ListPages handles GET /admin/content/pages.
+func (h *ContentAdminHandler) ListPages(w http.ResponseWriter, r *http.Request) {
+	pages, err := h.contentSvc.ListPages(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, pages)
+}
+
+// GetPage handles GET /admin/content/pages/{id}.
+func (h *ContentAdminHandler) GetPage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid content page id"))
+		return
+	}
+
+	page, err := h.contentSvc.GetPage(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, page)
+}
+
+// ListVersions handles GET /admin/content/pages/{id}/versions.
+func (h *ContentAdminHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid content page id"))
+		return
+	}
+
+	versions, err := h.contentSvc.ListVersions(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, versions)
+}
+
+// CreatePage handles POST /admin/content/pages.
+func (h *ContentAdminHandler) CreatePage(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.ContentPageInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	page, err := h.contentSvc.CreatePage(r.Context(), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, page)
+}
+
+// UpdatePage handles PUT /admin/content/pages/{id} — saves a new version.
+func (h *ContentAdminHandler) UpdatePage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid content page id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.ContentPageInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	page, err := h.contentSvc.UpdatePage(r.Context(), id, input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, page)
+}
+
+// PublishPage handles POST /admin/content/pages/{id}/publish. An optional
+// {"version": n} body publishes an earlier version; the latest otherwise.
+func (h *ContentAdminHandler) PublishPage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid content page id"))
+		return
+	}
+
+	var body struct {
+		Version int `json:"version"`
+	}
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &body); err != nil {
+			handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+	if body.Version < 0 {
+		handler.RespondError(w, domain.ErrValidation("version must be positive"))
+		return
+	}
+
+	page, err := h.contentSvc.Publish(r.Context(), id, body.Version)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, page)
+}
+
+// UnpublishPage handles POST /admin/content/pages/{id}/unpublish.
+func (h *ContentAdminHandler) UnpublishPage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid content page id"))
+		return
+	}
+
+	page, err := h.contentSvc.Unpublish(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, page)
+}
+
+// DeletePage handles DELETE /admin/content/pages/{id}.
+func (h *ContentAdminHandler) DeletePage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid content page id"))
+		return
+	}
+
+	if err := h.contentSvc.DeletePage(r.Context(), id); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
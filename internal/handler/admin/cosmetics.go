@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CosmeticAdminHandler manages the cosmetic catalog and grants items to
+// players.
+type CosmeticAdminHandler struct {
+	svc *service.CosmeticService
+}
+
+// NewCosmeticAdminHandler creates a new CosmeticAdminHandler.
+func NewCosmeticAdminHandler(svc *service.CosmeticService) *CosmeticAdminHandler {
+	return &CosmeticAdminHandler{svc: svc}
+}
+
+// ListItems handles GET /admin/cosmetics.
+func (h *CosmeticAdminHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListItems(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, items)
+}
+
+// PutItem handles PUT /admin/cosmetics/{code}.
+func (h *CosmeticAdminHandler) PutItem(w http.ResponseWriter, r *http.Request) {
+	var input service.CosmeticItemInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	item, err := h.svc.PutItem(r.Context(), chi.URLParam(r, "code"), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, item)
+}
+
+// PlayerInventory handles GET /admin/players/{id}/cosmetics.
+func (h *CosmeticAdminHandler) PlayerInventory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	items, err := h.svc.Inventory(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, items)
+}
+
+// Grant handles POST /admin/players/{id}/cosmetics, awarding an item for an
+// achievement, a tournament placing or by hand.
+func (h *CosmeticAdminHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	var input struct {
+		ItemCode  string                `json:"item_code"`
+		Source    domain.CosmeticSource `json:"source"`
+		SourceRef string                `json:"source_ref"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+	if input.Source == "" {
+		input.Source = domain.CosmeticSourceAdmin
+	}
+
+	granted, err := h.svc.Grant(r.Context(), id, input.ItemCode, input.Source, input.SourceRef)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	status := http.StatusCreated
+	if !granted {
+		status = http.StatusOK
+	}
+	handler.RespondJSON(w, status, map[string]interface{}{"item_code": input.ItemCode, "granted": granted})
+}
@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ExperimentAdminHandler manages feature flags and A/B experiments.
+type ExperimentAdminHandler struct {
+	experimentSvc *service.ExperimentService
+}
+
+// NewExperimentAdminHandler creates a new ExperimentAdminHandler.
+func NewExperimentAdminHandler(experimentSvc *service.ExperimentService) *ExperimentAdminHandler {
+	return &ExperimentAdminHandler{experimentSvc: experimentSvc}
+}
+
+// ListFlags handles GET /admin/flags.
+func (h *ExperimentAdminHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.experimentSvc.ListFlags(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, flags)
+}
+
+// SetFlag handles PUT /admin/flags/{key}.
+func (h *ExperimentAdminHandler) SetFlag(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.FlagInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	flag, err := h.experimentSvc.SetFlag(r.Context(), chi.URLParam(r, "key"), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, flag)
+}
+
+// ListExperiments handles GET /admin/experiments.
+func (h *ExperimentAdminHandler) ListExperiments(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.experimentSvc.ListExperiments(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, experiments)
+}
+
+// CreateExperiment handles POST /admin/experiments.
+func (h *ExperimentAdminHandler) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.ExperimentInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	exp, err := h.experimentSvc.CreateExperiment(r.Context(), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, exp)
+}
+
+// UpdateExperimentStatus handles PATCH /admin/experiments/{id}/status.
+func (h *ExperimentAdminHandler) UpdateExperimentStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid experiment id"))
+		return
+	}
+
+	var input struct {
+		Status domain.ExperimentStatus `json:"status"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	exp, err := h.experimentSvc.SetExperimentStatus(r.Context(), id, input.Status)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, exp)
+}
+
+// GetResults handles GET /admin/experiments/{id}/results.
+func (h *ExperimentAdminHandler) GetResults(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid experiment id"))
+		return
+	}
+
+	results, err := h.experimentSvc.ExperimentResults(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, results)
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ExportJobHandler serves background export jobs. Admins only see their own
+// jobs.
+type ExportJobHandler struct {
+	svc *service.ExportJobService
+}
+
+// NewExportJobHandler creates a new ExportJobHandler.
+func NewExportJobHandler(svc *service.ExportJobService) *ExportJobHandler {
+	return &ExportJobHandler{svc: svc}
+}
+
+// Create handles POST /admin/exports.
+func (h *ExportJobHandler) Create(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	var input service.ExportJobInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	job, err := h.svc.Create(r.Context(), adminID, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusAccepted, job)
+}
+
+// List handles GET /admin/exports.
+func (h *ExportJobHandler) List(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	jobs, err := h.svc.List(r.Context(), adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, jobs)
+}
+
+// Get handles GET /admin/exports/{id}: the job's progress, and a presigned
+// download_url once it has completed.
+func (h *ExportJobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid export job id"))
+		return
+	}
+
+	job, err := h.svc.Get(r.Context(), adminID, id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, job)
+}
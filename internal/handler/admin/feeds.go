@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// FeedAdminHandler shows prediction feed health and triggers resyncs.
+type FeedAdminHandler struct {
+	svc *service.FeedService
+}
+
+// NewFeedAdminHandler creates a new FeedAdminHandler.
+func NewFeedAdminHandler(svc *service.FeedService) *FeedAdminHandler {
+	return &FeedAdminHandler{svc: svc}
+}
+
+// List handles GET /admin/feeds.
+func (h *FeedAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	feeds, err := h.svc.List(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, feeds)
+}
+
+// Resync handles POST /admin/feeds/{name}/resync. The job runs in the
+// background, so this answers 202 with the feed's state before the run.
+func (h *FeedAdminHandler) Resync(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.svc.Resync(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusAccepted, feed)
+}
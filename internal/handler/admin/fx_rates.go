@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// FxRateAdminHandler manages the conversion rates used between player wallets.
+type FxRateAdminHandler struct {
+	walletSvc *service.WalletService
+}
+
+// NewFxRateAdminHandler creates a new FxRateAdminHandler.
+func NewFxRateAdminHandler(walletSvc *service.WalletService) *FxRateAdminHandler {
+	return &FxRateAdminHandler{walletSvc: walletSvc}
+}
+
+// ListRates handles GET /admin/fx-rates.
+func (h *FxRateAdminHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.walletSvc.ListRates(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, rates)
+}
+
+// SetRate handles PUT /admin/fx-rates/{base}/{quote}.
+func (h *FxRateAdminHandler) SetRate(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		Rate string `json:"rate"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	rate, err := h.walletSvc.SetRate(r.Context(), chi.URLParam(r, "base"), chi.URLParam(r, "quote"), input.Rate, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, rate)
+}
@@ -0,0 +1,22 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/auth"
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+)
+
+// adminIDFromContext extracts the acting admin's UUID from the auth context.
+func adminIDFromContext(r *http.Request) (uuid.UUID, error) {
+	sub := auth.SubjectFromContext(r.Context())
+	if sub == "" {
+		return uuid.Nil, domain.ErrUnauthorized("no subject in context")
+	}
+	id, err := uuid.Parse(sub)
+	if err != nil {
+		return uuid.Nil, domain.ErrUnauthorized("invalid subject")
+	}
+	return id, nil
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// IPRiskAdminHandler exposes flagged and blocked IP screening results for
+// compliance and fraud review.
+type IPRiskAdminHandler struct {
+	svc *service.IPRiskService
+}
+
+// NewIPRiskAdminHandler creates a new IPRiskAdminHandler.
+func NewIPRiskAdminHandler(svc *service.IPRiskService) *IPRiskAdminHandler {
+	return &IPRiskAdminHandler{svc: svc}
+}
+
+// List handles GET /admin/ip-checks?player_id=&verdict=&unreviewed=true.
+func (h *IPRiskAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := service.IPRiskCheckFilter{Verdict: q.Get("verdict"), Unreviewed: q.Get("unreviewed") == "true"}
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		filter.PlayerID = &id
+	}
+	filter.Limit, _ = strconv.Atoi(q.Get("limit"))
+
+	checks, err := h.svc.ListChecks(r.Context(), filter)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, checks)
+}
+
+// Review handles POST /admin/ip-checks/{id}/review with an optional note.
+func (h *IPRiskAdminHandler) Review(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid check id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		Note string `json:"note"`
+	}
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &input); err != nil {
+			handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	check, err := h.svc.ReviewCheck(r.Context(), id, adminID, input.Note)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, check)
+}
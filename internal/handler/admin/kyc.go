@@ -0,0 +1,119 @@
+package admin
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// KYCAdminHandler serves the compliance review queue for KYC documents.
+type KYCAdminHandler struct {
+	kycSvc *service.KYCService
+}
+
+// NewKYCAdminHandler creates a new KYCAdminHandler.
+func NewKYCAdminHandler(kycSvc *service.KYCService) *KYCAdminHandler {
+	return &KYCAdminHandler{kycSvc: kycSvc}
+}
+
+type kycDecisionRequest struct {
+	Note string `json:"note"`
+}
+
+// Queue handles GET /admin/kyc?status=pending&limit=50.
+func (h *KYCAdminHandler) Queue(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	docs, err := h.kycSvc.ReviewQueue(r.Context(), r.URL.Query().Get("status"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, docs)
+}
+
+// PlayerStatus handles GET /admin/kyc/players/{id}.
+func (h *KYCAdminHandler) PlayerStatus(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	status, err := h.kycSvc.Status(r.Context(), playerID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, status)
+}
+
+// DownloadDocument handles GET /admin/kyc/documents/{id}.
+func (h *KYCAdminHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid document id"))
+		return
+	}
+
+	doc, content, err := h.kycSvc.Document(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// Approve handles POST /admin/kyc/documents/{id}/approve.
+func (h *KYCAdminHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.kycSvc.Approve)
+}
+
+// Reject handles POST /admin/kyc/documents/{id}/reject. A note is required.
+func (h *KYCAdminHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.kycSvc.Reject)
+}
+
+func (h *KYCAdminHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	review func(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.KYCDocument, error),
+) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid document id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input kycDecisionRequest
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &input); err != nil {
+			handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	doc, err := review(r.Context(), id, adminID, input.Note)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, doc)
+}
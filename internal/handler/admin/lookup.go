@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// LookupAdminHandler traces external IDs for support.
+type LookupAdminHandler struct {
+	lookup *service.SupportLookupService
+}
+
+// NewLookupAdminHandler creates a new LookupAdminHandler.
+func NewLookupAdminHandler(lookup *service.SupportLookupService) *LookupAdminHandler {
+	return &LookupAdminHandler{lookup: lookup}
+}
+
+// Lookup handles GET /admin/lookup?external_id= — the transactions,
+// payments, sports bets and provider callbacks behind one provider ticket.
+func (h *LookupAdminHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	result, err := h.lookup.Lookup(r.Context(), strings.TrimSpace(r.URL.Query().Get("external_id")))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, result)
+}
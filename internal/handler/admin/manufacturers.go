@@ -0,0 +1,106 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// ManufacturerAdminHandler manages the wallet provider registry.
+type ManufacturerAdminHandler struct {
+	manufacturerSvc *service.ManufacturerService
+}
+
+// NewManufacturerAdminHandler creates a new ManufacturerAdminHandler.
+func NewManufacturerAdminHandler(manufacturerSvc *service.ManufacturerService) *ManufacturerAdminHandler {
+	return &ManufacturerAdminHandler{manufacturerSvc: manufacturerSvc}
+}
+
+// List handles GET /admin/manufacturers.
+func (h *ManufacturerAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	manufacturers, err := h.manufacturerSvc.List(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, manufacturers)
+}
+
+// Get handles GET /admin/manufacturers/{id}.
+func (h *ManufacturerAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	m, err := h.manufacturerSvc.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, m)
+}
+
+// Put handles PUT /admin/manufacturers/{id}.
+func (h *ManufacturerAdminHandler) Put(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.ManufacturerInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	m, err := h.manufacturerSvc.Put(r.Context(), chi.URLParam(r, "id"), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, m)
+}
+
+// Disable handles POST /admin/manufacturers/{id}/disable. The wallet server
+// refuses the provider's callbacks as soon as it sees the change.
+func (h *ManufacturerAdminHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := handler.DecodeJSON(r, &body); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	m, err := h.manufacturerSvc.SetEnabled(r.Context(), chi.URLParam(r, "id"), false, body.Reason, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, m)
+}
+
+// Enable handles POST /admin/manufacturers/{id}/enable.
+func (h *ManufacturerAdminHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	m, err := h.manufacturerSvc.SetEnabled(r.Context(), chi.URLParam(r, "id"), true, "", adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, m)
+}
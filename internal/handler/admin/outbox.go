@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxAdminHandler exposes the outbox dead-letter queue.
+type OutboxAdminHandler struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+}
+
+// NewOutboxAdminHandler creates a new OutboxAdminHandler.
+func NewOutboxAdminHandler(pool *pgxpool.Pool, outbox repository.OutboxRepository) *OutboxAdminHandler {
+	return &OutboxAdminHandler{pool: pool, outbox: outbox}
+}
+
+// ListDLQ handles GET /admin/outbox/dlq?limit=100.
+func (h *OutboxAdminHandler) ListDLQ(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	entries, err := h.outbox.ListDLQ(r.Context(), h.pool, limit)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("list dlq", err))
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, entries)
+}
+
+// RequeueDLQ handles POST /admin/outbox/dlq/{id}/requeue — puts the event
+// back on the outbox with a fresh retry budget.
+func (h *OutboxAdminHandler) RequeueDLQ(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid dlq id"))
+		return
+	}
+
+	ok, err := h.outbox.Requeue(r.Context(), h.pool, id)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("requeue dlq", err))
+		return
+	}
+	if !ok {
+		handler.RespondError(w, domain.ErrNotFound("dlq entry", chi.URLParam(r, "id")))
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{"id": id, "requeued": true})
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
+)
+
+// P2PTransferAdminHandler gives compliance visibility of player-to-player
+// transfers, including attempts the limits or AML checks rejected.
+type P2PTransferAdminHandler struct {
+	transferSvc *service.P2PTransferService
+}
+
+// NewP2PTransferAdminHandler creates a new P2PTransferAdminHandler.
+func NewP2PTransferAdminHandler(transferSvc *service.P2PTransferService) *P2PTransferAdminHandler {
+	return &P2PTransferAdminHandler{transferSvc: transferSvc}
+}
+
+// List handles GET /admin/p2p-transfers?player_id=&status=&limit=.
+func (h *P2PTransferAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var playerID *uuid.UUID
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		playerID = &id
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	transfers, err := h.transferSvc.List(r.Context(), playerID, q.Get("status"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, transfers)
+}
+
+// Rules handles GET /admin/p2p-transfers/rules — the caps, fee and AML
+// thresholds in force.
+func (h *P2PTransferAdminHandler) Rules(w http.ResponseWriter, r *http.Request) {
+	handler.RespondJSON(w, http.StatusOK, h.transferSvc.Rules())
+}
@@ -0,0 +1,194 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// PaymentAdminHandler serves admin payment operations.
+type PaymentAdminHandler struct {
+	paymentSvc *service.PaymentService
+}
+
+// NewPaymentAdminHandler creates a new PaymentAdminHandler.
+func NewPaymentAdminHandler(paymentSvc *service.PaymentService) *PaymentAdminHandler {
+	return &PaymentAdminHandler{paymentSvc: paymentSvc}
+}
+
+// RefundPayment handles POST /admin/payments/{id}/refund.
+func (h *PaymentAdminHandler) RefundPayment(w http.ResponseWriter, r *http.Request) {
+	paymentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid payment id"))
+		return
+	}
+
+	var input service.RefundInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+	if input.Reason == "" {
+		handler.RespondError(w, domain.ErrValidation("reason is required"))
+		return
+	}
+
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	refund, err := h.paymentSvc.RefundDeposit(r.Context(), paymentID, input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, refund)
+}
+
+// ListRefunds handles GET /admin/payments/{id}/refunds.
+func (h *PaymentAdminHandler) ListRefunds(w http.ResponseWriter, r *http.Request) {
+	paymentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid payment id"))
+		return
+	}
+
+	refunds, err := h.paymentSvc.ListRefunds(r.Context(), paymentID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, refunds)
+}
+
+// ListPendingCredits handles GET /admin/pending-credits?status=pending —
+// deposits held for review before the ledger credit.
+func (h *PaymentAdminHandler) ListPendingCredits(w http.ResponseWriter, r *http.Request) {
+	credits, err := h.paymentSvc.ListPendingCredits(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, credits)
+}
+
+// ApprovePendingCredit handles POST /admin/pending-credits/{id}/approve.
+func (h *PaymentAdminHandler) ApprovePendingCredit(w http.ResponseWriter, r *http.Request) {
+	h.reviewPendingCredit(w, r, h.paymentSvc.ApprovePendingCredit)
+}
+
+// RejectPendingCredit handles POST /admin/pending-credits/{id}/reject.
+// The captured funds are refunded through the PSP.
+func (h *PaymentAdminHandler) RejectPendingCredit(w http.ResponseWriter, r *http.Request) {
+	h.reviewPendingCredit(w, r, h.paymentSvc.RejectPendingCredit)
+}
+
+func (h *PaymentAdminHandler) reviewPendingCredit(w http.ResponseWriter, r *http.Request,
+	review func(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.PendingCredit, error)) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid pending credit id"))
+		return
+	}
+
+	var input struct {
+		Note string `json:"note"`
+	}
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &input); err != nil {
+			handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	credit, err := review(r.Context(), id, adminID, input.Note)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, credit)
+}
+
+// SetDepositReview handles PUT /admin/players/{id}/deposit-review — when
+// required, the player's PSP deposits are held for approval before credit.
+func (h *PaymentAdminHandler) SetDepositReview(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	var input struct {
+		Required bool `json:"required"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.paymentSvc.SetDepositReview(r.Context(), id, input.Required); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"player_id": id, "deposit_review_required": input.Required,
+	})
+}
+
+// ListPlayerPaymentMethods handles GET /admin/players/{id}/payment-methods,
+// including removed methods.
+func (h *PaymentAdminHandler) ListPlayerPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	methods, err := h.paymentSvc.ListPlayerPaymentMethods(r.Context(), playerID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, methods)
+}
+
+// VerifyPaymentMethod handles POST /admin/payment-methods/{id}/verify.
+func (h *PaymentAdminHandler) VerifyPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	methodID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid payment method id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	method, err := h.paymentSvc.VerifyPaymentMethod(r.Context(), methodID, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, method)
+}
@@ -0,0 +1,153 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// PlacementAdminHandler manages scheduled banners and cards.
+type PlacementAdminHandler struct {
+	placementSvc *service.PlacementService
+}
+
+// NewPlacementAdminHandler creates a new PlacementAdminHandler.
+func NewPlacementAdminHandler(placementSvc *service.PlacementService) *PlacementAdminHandler {
+	return &PlacementAdminHandler{placementSvc: placementSvc}
+}
+
+// ListPlacements handles GET /admin/placements — includes impression/click totals.
+func (h *PlacementAdminHandler) ListPlacements(w http.ResponseWriter, r *http.Request) {
+	placements, err := h.placementSvc.List(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, placements)
+}
+
+// CreatePlacement handles POST /admin/placements.
+func (h *PlacementAdminHandler) CreatePlacement(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.PlacementInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	placement, err := h.placementSvc.Create(r.Context(), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, placement)
+}
+
+// UpdatePlacement handles PUT /admin/placements/{id}.
+func (h *PlacementAdminHandler) UpdatePlacement(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid placement id"))
+		return
+	}
+
+	var input service.PlacementInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	placement, err := h.placementSvc.Update(r.Context(), id, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, placement)
+}
+
+// UpdatePlacementStatus handles PATCH /admin/placements/{id}/status.
+func (h *PlacementAdminHandler) UpdatePlacementStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid placement id"))
+		return
+	}
+
+	var input struct {
+		Active bool `json:"active"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.placementSvc.SetActive(r.Context(), id, input.Active); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]string{"status": "updated"})
+}
+
+// ListMaintenanceWindows handles GET /admin/maintenance-windows — current and upcoming.
+func (h *PlacementAdminHandler) ListMaintenanceWindows(w http.ResponseWriter, r *http.Request) {
+	windows, err := h.placementSvc.ListMaintenanceWindows(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, windows)
+}
+
+// CreateMaintenanceWindow handles POST /admin/maintenance-windows.
+func (h *PlacementAdminHandler) CreateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.MaintenanceWindowInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	window, err := h.placementSvc.CreateMaintenanceWindow(r.Context(), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, window)
+}
+
+// DeleteMaintenanceWindow handles DELETE /admin/maintenance-windows/{id}.
+func (h *PlacementAdminHandler) DeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid maintenance window id"))
+		return
+	}
+
+	if err := h.placementSvc.DeleteMaintenanceWindow(r.Context(), id); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
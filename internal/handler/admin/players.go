@@ -1,27 +1,33 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/policy"
 	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // PlayerAdminHandler handles admin player management.
 type PlayerAdminHandler struct {
-	pool     *pgxpool.Pool
-	players  repository.PlayerRepository
-	profiles repository.ProfileRepository
+	pool      *pgxpool.Pool
+	players   repository.PlayerRepository
+	profiles  repository.ProfileRepository
+	statusSvc *service.PlayerStatusService
 }
 
 // NewPlayerAdminHandler creates a new PlayerAdminHandler.
-func NewPlayerAdminHandler(pool *pgxpool.Pool, players repository.PlayerRepository, profiles repository.ProfileRepository) *PlayerAdminHandler {
-	return &PlayerAdminHandler{pool: pool, players: players, profiles: profiles}
+func NewPlayerAdminHandler(pool *pgxpool.Pool, players repository.PlayerRepository, profiles repository.ProfileRepository, statusSvc *service.PlayerStatusService) *PlayerAdminHandler {
+	return &PlayerAdminHandler{pool: pool, players: players, profiles: profiles, statusSvc: statusSvc}
 }
 
 // SearchPlayers handles GET /admin/players?q=email.
@@ -97,6 +103,8 @@ func (h *PlayerAdminHandler) GetPlayerDetail(w http.ResponseWriter, r *http.Requ
 }
 
 // UpdatePlayerStatus handles PATCH /admin/players/{id}/status.
+// The change goes through the account status state machine; invalid
+// transitions are rejected with 409 INVALID_STATUS_TRANSITION.
 func (h *PlayerAdminHandler) UpdatePlayerStatus(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
@@ -104,8 +112,88 @@ func (h *PlayerAdminHandler) UpdatePlayerStatus(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.StatusTransitionInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+	input.PlayerID = id
+	input.ActorType = "admin"
+	input.ActorID = &adminID
+
+	change, err := h.statusSvc.Transition(r.Context(), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, change)
+}
+
+// SuspendPlayer handles POST /admin/players/{id}/suspend — suspends the
+// player until the given time, after which they are reinstated automatically.
+func (h *PlayerAdminHandler) SuspendPlayer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		Until  time.Time `json:"until"`
+		Reason string    `json:"reason"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	change, err := h.statusSvc.SuspendUntil(r.Context(), id, input.Until, input.Reason, "admin", &adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"change":       change,
+		"reinstate_at": input.Until.UTC(),
+	})
+}
+
+// VerifyDateOfBirth handles PUT /admin/players/{id}/dob-verification — records
+// the date of birth as checked against an identity document. The date must
+// meet the minimum age for the player's country.
+func (h *PlayerAdminHandler) VerifyDateOfBirth(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
 	var input struct {
-		AccountStatus string `json:"account_status"`
+		DateOfBirth string `json:"date_of_birth"`
 	}
 	if err := handler.DecodeJSON(r, &input); err != nil {
 		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
@@ -114,13 +202,53 @@ func (h *PlayerAdminHandler) UpdatePlayerStatus(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	_, err = h.pool.Exec(r.Context(),
-		`UPDATE player_profiles SET account_status = $2 WHERE player_id = $1`,
-		id, input.AccountStatus)
+	var country string
+	err = h.pool.QueryRow(r.Context(),
+		`SELECT COALESCE(country, '') FROM player_profiles WHERE player_id = $1`, id).Scan(&country)
+	if errors.Is(err, pgx.ErrNoRows) {
+		handler.RespondError(w, domain.ErrNotFound("player", id.String()))
+		return
+	}
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("find player profile", err))
+		return
+	}
+	if err := policy.CheckMinimumAge(input.DateOfBirth, country, time.Now()); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var verifiedAt time.Time
+	if err := h.pool.QueryRow(r.Context(), `
+		UPDATE player_profiles
+		SET date_of_birth = $2::text::date, dob_verified_at = now(), dob_verified_by = $3
+		WHERE player_id = $1
+		RETURNING dob_verified_at`,
+		id, strings.TrimSpace(input.DateOfBirth), adminID).Scan(&verifiedAt); err != nil {
+		handler.RespondError(w, domain.ErrInternal("verify date of birth", err))
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"player_id":       id,
+		"date_of_birth":   strings.TrimSpace(input.DateOfBirth),
+		"dob_verified_at": verifiedAt,
+	})
+}
+
+// GetStatusHistory handles GET /admin/players/{id}/status-history.
+func (h *PlayerAdminHandler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	history, err := h.statusSvc.History(r.Context(), id)
 	if err != nil {
-		handler.RespondError(w, domain.ErrInternal("update status", err))
+		handler.RespondError(w, err)
 		return
 	}
 
-	handler.RespondJSON(w, http.StatusOK, map[string]string{"status": "updated"})
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{"history": history})
 }
@@ -0,0 +1,161 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// PredictionAdminHandler manages house prediction markets.
+type PredictionAdminHandler struct {
+	svc *service.PredictionAdminService
+}
+
+// NewPredictionAdminHandler creates a new PredictionAdminHandler.
+func NewPredictionAdminHandler(svc *service.PredictionAdminService) *PredictionAdminHandler {
+	return &PredictionAdminHandler{svc: svc}
+}
+
+// List handles GET /admin/predictions?status=open&house=true&limit=50.
+func (h *PredictionAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	house, _ := strconv.ParseBool(q.Get("house"))
+	markets, err := h.svc.List(r.Context(), q.Get("status"), house, limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, markets)
+}
+
+// Get handles GET /admin/predictions/{id}.
+func (h *PredictionAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+
+	market, err := h.svc.Get(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, market)
+}
+
+// Create handles POST /admin/predictions.
+func (h *PredictionAdminHandler) Create(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.PredictionMarketInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	market, err := h.svc.Create(r.Context(), adminID, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, market)
+}
+
+// UpdateOutcomes handles PUT /admin/predictions/{id}/outcomes.
+func (h *PredictionAdminHandler) UpdateOutcomes(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+
+	var input struct {
+		Outcomes []domain.PredictionOutcome `json:"outcomes"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	market, err := h.svc.UpdateOutcomes(r.Context(), id, input.Outcomes)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, market)
+}
+
+// Close handles POST /admin/predictions/{id}/close.
+func (h *PredictionAdminHandler) Close(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+
+	market, err := h.svc.Close(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, market)
+}
+
+// Void handles POST /admin/predictions/{id}/void.
+func (h *PredictionAdminHandler) Void(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+
+	result, err := h.svc.Void(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, result)
+}
+
+// Settle handles POST /admin/predictions/{id}/settle with the winning
+// outcome and the oracle attestation it was resolved on.
+func (h *PredictionAdminHandler) Settle(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+
+	var input struct {
+		WinningOutcomeID string             `json:"winning_outcome_id"`
+		Attestation      domain.Attestation `json:"attestation"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	result, err := h.svc.Settle(r.Context(), id, input.WinningOutcomeID, input.Attestation)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, result)
+}
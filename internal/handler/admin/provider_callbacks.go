@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
+)
+
+// ProviderCallbackAdminHandler exposes the provider callback journal for
+// vendor disputes.
+type ProviderCallbackAdminHandler struct {
+	callbackSvc *service.ProviderCallbackService
+}
+
+// NewProviderCallbackAdminHandler creates a new ProviderCallbackAdminHandler.
+func NewProviderCallbackAdminHandler(callbackSvc *service.ProviderCallbackService) *ProviderCallbackAdminHandler {
+	return &ProviderCallbackAdminHandler{callbackSvc: callbackSvc}
+}
+
+// List handles GET /admin/providers/callbacks?provider=&action=&status=
+// &player_id=&transaction_id=&signature_valid=&from=&to=&limit=.
+func (h *ProviderCallbackAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f := service.ProviderCallbackFilter{
+		Provider:      q.Get("provider"),
+		Action:        q.Get("action"),
+		Status:        q.Get("status"),
+		TransactionID: q.Get("transaction_id"),
+	}
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		f.PlayerID = &id
+	}
+	if v := q.Get("signature_valid"); v != "" {
+		valid, err := strconv.ParseBool(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("signature_valid must be true or false"))
+			return
+		}
+		f.SignatureValid = &valid
+	}
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("from must be RFC 3339"))
+			return
+		}
+		f.From = &t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("to must be RFC 3339"))
+			return
+		}
+		f.To = &t
+	}
+	f.Limit, _ = strconv.Atoi(q.Get("limit"))
+
+	callbacks, err := h.callbackSvc.List(r.Context(), f)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, callbacks)
+}
+
+// Mismatches handles GET /admin/providers/callbacks/mismatches?provider=&from=&to=
+// — callbacks and ledger transactions that do not account for each other.
+// The window defaults to the last 24 hours.
+func (h *ProviderCallbackAdminHandler) Mismatches(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("from must be RFC 3339"))
+			return
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("to must be RFC 3339"))
+			return
+		}
+		to = t
+	}
+
+	mismatches, err := h.callbackSvc.Mismatches(r.Context(), q.Get("provider"), from, to)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, mismatches)
+}
@@ -1,14 +1,17 @@
 package admin
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/policy"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -26,7 +29,9 @@ func NewQuestAdminHandler(pool *pgxpool.Pool) *QuestAdminHandler {
 func (h *QuestAdminHandler) ListQuests(w http.ResponseWriter, r *http.Request) {
 	rows, err := h.pool.Query(r.Context(), `
 		SELECT id, name, description, type, target_progress, reward_amount, reward_currency,
-		       min_score, cooldown_minutes, daily_budget_minor, active, sort_order, created_at
+		       min_score, cooldown_minutes, daily_budget_minor, active, sort_order, created_at, criteria,
+		       recurrence, COALESCE(recurrence_cron, ''), next_reset_at,
+		       ARRAY(SELECT prerequisite_id FROM quest_prerequisites WHERE quest_id = quests.id ORDER BY prerequisite_id)
 		FROM quests ORDER BY sort_order ASC`)
 	if err != nil {
 		handler.RespondError(w, domain.ErrInternal("list quests", err))
@@ -48,6 +53,11 @@ func (h *QuestAdminHandler) ListQuests(w http.ResponseWriter, r *http.Request) {
 		Active          bool      `json:"active"`
 		SortOrder       int       `json:"sort_order"`
 		CreatedAt       time.Time `json:"created_at"`
+		Criteria        policy.QuestCriteria `json:"criteria"`
+		Recurrence      string     `json:"recurrence"`
+		RecurrenceCron  string     `json:"recurrence_cron,omitempty"`
+		NextResetAt     *time.Time `json:"next_reset_at,omitempty"`
+		PrerequisiteIDs []uuid.UUID `json:"prerequisite_ids"`
 	}
 
 	var quests []questRow
@@ -55,7 +65,8 @@ func (h *QuestAdminHandler) ListQuests(w http.ResponseWriter, r *http.Request) {
 		var q questRow
 		if err := rows.Scan(&q.ID, &q.Name, &q.Description, &q.Type, &q.TargetProgress,
 			&q.RewardAmount, &q.RewardCurrency, &q.MinScore, &q.CooldownMinutes,
-			&q.DailyBudgetMinor, &q.Active, &q.SortOrder, &q.CreatedAt); err != nil {
+			&q.DailyBudgetMinor, &q.Active, &q.SortOrder, &q.CreatedAt, &q.Criteria,
+			&q.Recurrence, &q.RecurrenceCron, &q.NextResetAt, &q.PrerequisiteIDs); err != nil {
 			handler.RespondError(w, domain.ErrInternal("scan quest", err))
 			return
 		}
@@ -65,7 +76,12 @@ func (h *QuestAdminHandler) ListQuests(w http.ResponseWriter, r *http.Request) {
 	handler.RespondJSON(w, http.StatusOK, quests)
 }
 
-// CreateQuest handles POST /admin/quests.
+// CreateQuest handles POST /admin/quests. Quests of an automated type
+// (bet_count, deposit_volume, engagement, ...) advance from player activity
+// matching their criteria; other types only move when written directly. A
+// recurring quest (daily, weekly or cron) resets every player's progress at
+// the end of each period. A quest with prerequisite_ids stays locked for a
+// player until they have claimed each of those quests.
 func (h *QuestAdminHandler) CreateQuest(w http.ResponseWriter, r *http.Request) {
 	var input struct {
 		Name            string `json:"name"`
@@ -77,6 +93,10 @@ func (h *QuestAdminHandler) CreateQuest(w http.ResponseWriter, r *http.Request)
 		MinScore        int    `json:"min_score"`
 		CooldownMinutes int    `json:"cooldown_minutes"`
 		DailyBudgetMinor int   `json:"daily_budget_minor"`
+		Criteria        policy.QuestCriteria `json:"criteria"`
+		Recurrence      string `json:"recurrence"`
+		RecurrenceCron  string `json:"recurrence_cron"`
+		PrerequisiteIDs []uuid.UUID `json:"prerequisite_ids"`
 	}
 	if err := handler.DecodeJSON(r, &input); err != nil {
 		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
@@ -92,23 +112,169 @@ func (h *QuestAdminHandler) CreateQuest(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := policy.ValidateQuestCriteria(input.Type, input.Criteria); err != nil {
+		handler.RespondError(w, domain.ErrValidation(err.Error()))
+		return
+	}
+	if policy.IsAutomatedQuestType(input.Type) && input.TargetProgress < 1 {
+		handler.RespondError(w, domain.ErrValidation("target_progress must be positive"))
+		return
+	}
+	if err := policy.ValidateQuestRecurrence(input.Recurrence, input.RecurrenceCron); err != nil {
+		handler.RespondError(w, domain.ErrValidation(err.Error()))
+		return
+	}
+	if input.Recurrence == "" {
+		input.Recurrence = policy.QuestRecurrenceNone
+	}
+	at, recurs, err := policy.NextQuestReset(input.Recurrence, input.RecurrenceCron, time.Now())
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation(err.Error()))
+		return
+	}
+	var nextReset *time.Time
+	if recurs {
+		nextReset = &at
+	}
+	var recurrenceCron *string
+	if input.RecurrenceCron != "" {
+		recurrenceCron = &input.RecurrenceCron
+	}
+
+	tx, err := h.pool.Begin(r.Context())
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("begin tx", err))
+		return
+	}
+	defer tx.Rollback(r.Context())
+
 	var questID uuid.UUID
-	err := h.pool.QueryRow(r.Context(), `
+	err = tx.QueryRow(r.Context(), `
 		INSERT INTO quests (name, description, type, target_progress, reward_amount, reward_currency,
-			min_score, cooldown_minutes, daily_budget_minor)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+			min_score, cooldown_minutes, daily_budget_minor, criteria, recurrence, recurrence_cron, next_reset_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`,
 		input.Name, input.Description, input.Type, input.TargetProgress,
 		input.RewardAmount, input.RewardCurrency, input.MinScore,
-		input.CooldownMinutes, input.DailyBudgetMinor,
+		input.CooldownMinutes, input.DailyBudgetMinor, input.Criteria,
+		input.Recurrence, recurrenceCron, nextReset,
 	).Scan(&questID)
 	if err != nil {
 		handler.RespondError(w, domain.ErrInternal("create quest", err))
 		return
 	}
+	if len(input.PrerequisiteIDs) > 0 {
+		if err := setQuestPrerequisites(r.Context(), tx, questID, input.PrerequisiteIDs); err != nil {
+			handler.RespondError(w, err)
+			return
+		}
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		handler.RespondError(w, domain.ErrInternal("commit tx", err))
+		return
+	}
 
 	handler.RespondJSON(w, http.StatusCreated, map[string]string{"id": questID.String()})
 }
 
+// SetPrerequisites handles PUT /admin/quests/{id}/prerequisites, replacing
+// the quests a quest requires. An empty list unlocks it for everyone.
+func (h *QuestAdminHandler) SetPrerequisites(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid quest id"))
+		return
+	}
+	var input struct {
+		PrerequisiteIDs []uuid.UUID `json:"prerequisite_ids"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	tx, err := h.pool.Begin(r.Context())
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("begin tx", err))
+		return
+	}
+	defer tx.Rollback(r.Context())
+
+	var exists bool
+	if err := tx.QueryRow(r.Context(), `SELECT EXISTS (SELECT 1 FROM quests WHERE id = $1)`, id).Scan(&exists); err != nil {
+		handler.RespondError(w, domain.ErrInternal("get quest", err))
+		return
+	}
+	if !exists {
+		handler.RespondError(w, domain.ErrNotFound("quest", id.String()))
+		return
+	}
+	if err := setQuestPrerequisites(r.Context(), tx, id, input.PrerequisiteIDs); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		handler.RespondError(w, domain.ErrInternal("commit tx", err))
+		return
+	}
+
+	if input.PrerequisiteIDs == nil {
+		input.PrerequisiteIDs = []uuid.UUID{}
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"id": id, "prerequisite_ids": input.PrerequisiteIDs,
+	})
+}
+
+// setQuestPrerequisites replaces questID's prerequisites in tx. The table
+// is locked against concurrent edits first, so two changes that are each
+// acyclic cannot together close a cycle.
+func setQuestPrerequisites(ctx context.Context, tx pgx.Tx, questID uuid.UUID, prerequisites []uuid.UUID) error {
+	if _, err := tx.Exec(ctx, `LOCK TABLE quest_prerequisites IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return domain.ErrInternal("lock quest prerequisites", err)
+	}
+
+	var known int
+	if err := tx.QueryRow(ctx, `SELECT count(*) FROM quests WHERE id = ANY($1)`, prerequisites).Scan(&known); err != nil {
+		return domain.ErrInternal("check prerequisites", err)
+	}
+	rows, err := tx.Query(ctx, `SELECT quest_id, prerequisite_id FROM quest_prerequisites WHERE quest_id <> $1`, questID)
+	if err != nil {
+		return domain.ErrInternal("load quest graph", err)
+	}
+	graph := map[uuid.UUID][]uuid.UUID{}
+	for rows.Next() {
+		var quest, prerequisite uuid.UUID
+		if err := rows.Scan(&quest, &prerequisite); err != nil {
+			rows.Close()
+			return domain.ErrInternal("scan quest graph", err)
+		}
+		graph[quest] = append(graph[quest], prerequisite)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ErrInternal("load quest graph", err)
+	}
+
+	if err := policy.ValidateQuestPrerequisites(questID, prerequisites, graph); err != nil {
+		return domain.ErrValidation(err.Error())
+	}
+	if known != len(prerequisites) {
+		return domain.ErrValidation("prerequisite_ids must all be existing quests")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM quest_prerequisites WHERE quest_id = $1`, questID); err != nil {
+		return domain.ErrInternal("clear prerequisites", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO quest_prerequisites (quest_id, prerequisite_id)
+		SELECT $1, unnest($2::uuid[])`, questID, prerequisites); err != nil {
+		return domain.ErrInternal("set prerequisites", err)
+	}
+	return nil
+}
+
 // ToggleQuest handles PATCH /admin/quests/{id}/toggle.
 func (h *QuestAdminHandler) ToggleQuest(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
@@ -126,3 +292,114 @@ func (h *QuestAdminHandler) ToggleQuest(w http.ResponseWriter, r *http.Request)
 
 	handler.RespondJSON(w, http.StatusOK, map[string]string{"status": "toggled"})
 }
+
+// questSimulationMetrics maps the progress metrics a quest simulation can
+// replay to their player_engagement expression.
+var questSimulationMetrics = map[string]string{
+	"score":               "score",
+	"active_days":         "1",
+	"video_minutes":       "video_minutes",
+	"social_interactions": "social_interactions",
+	"prediction_actions":  "prediction_actions",
+	"wager_count":         "wager_count",
+	"deposit_count":       "deposit_count",
+}
+
+// SimulateQuest handles POST /admin/quests/simulate. It replays a proposed
+// quest against the last days of player_engagement without saving it: a
+// player completes the quest on the first day their running total of metric
+// reaches target_progress with that day's score at least min_score, and
+// each day's rewards are capped by daily_budget_minor.
+func (h *QuestAdminHandler) SimulateQuest(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TargetProgress   int    `json:"target_progress"`
+		RewardAmount     int64  `json:"reward_amount"`
+		RewardCurrency   string `json:"reward_currency"`
+		MinScore         int    `json:"min_score"`
+		DailyBudgetMinor int64  `json:"daily_budget_minor"`
+		Metric           string `json:"metric"`
+		Days             int    `json:"days"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	days, err := policy.SimulationDays(input.Days)
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation(err.Error()))
+		return
+	}
+	if input.Metric == "" {
+		input.Metric = "score"
+	}
+	metric, ok := questSimulationMetrics[input.Metric]
+	switch {
+	case !ok:
+		handler.RespondError(w, domain.ErrValidation("unknown metric: "+input.Metric))
+		return
+	case input.TargetProgress < 1:
+		handler.RespondError(w, domain.ErrValidation("target_progress must be positive"))
+		return
+	case input.RewardAmount < 0 || input.DailyBudgetMinor < 0:
+		handler.RespondError(w, domain.ErrValidation("reward_amount and daily_budget_minor must not be negative"))
+		return
+	}
+	if input.RewardCurrency == "" {
+		input.RewardCurrency = "EUR"
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	rows, err := h.pool.Query(r.Context(), `
+		WITH running AS (
+			SELECT player_id, date, score,
+			       SUM(`+metric+`) OVER (PARTITION BY player_id ORDER BY date) AS progress
+			FROM player_engagement
+			WHERE date >= $1::date
+		), completions AS (
+			SELECT DISTINCT ON (player_id) player_id, date
+			FROM running
+			WHERE progress >= $2 AND score >= $3
+			ORDER BY player_id, date
+		)
+		SELECT count(*) FROM completions GROUP BY date ORDER BY date`,
+		since, input.TargetProgress, input.MinScore)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("simulate quest", err))
+		return
+	}
+	defer rows.Close()
+
+	var perDay []int
+	qualified, budgetLimitedDays := 0, 0
+	for rows.Next() {
+		var n int
+		if err := rows.Scan(&n); err != nil {
+			handler.RespondError(w, domain.ErrInternal("scan quest simulation", err))
+			return
+		}
+		perDay = append(perDay, n)
+		qualified += n
+		if input.DailyBudgetMinor > 0 && int64(n)*input.RewardAmount > input.DailyBudgetMinor {
+			budgetLimitedDays++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		handler.RespondError(w, domain.ErrInternal("simulate quest", err))
+		return
+	}
+	rewarded, cost := policy.BudgetedRewards(perDay, input.RewardAmount, input.DailyBudgetMinor)
+
+	handler.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"days":                days,
+		"since":               since,
+		"metric":              input.Metric,
+		"qualified_players":   qualified,
+		"rewarded_players":    rewarded,
+		"budget_limited_days": budgetLimitedDays,
+		"projected_cost":      cost,
+		"reward_currency":     input.RewardCurrency,
+	})
+}
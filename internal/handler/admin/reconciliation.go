@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ReconciliationAdminHandler exposes payment provider reconciliation reports.
+type ReconciliationAdminHandler struct {
+	reconSvc *service.ReconciliationService
+}
+
+// NewReconciliationAdminHandler creates a new ReconciliationAdminHandler.
+func NewReconciliationAdminHandler(reconSvc *service.ReconciliationService) *ReconciliationAdminHandler {
+	return &ReconciliationAdminHandler{reconSvc: reconSvc}
+}
+
+// ListRuns handles GET /admin/reconciliation/runs.
+func (h *ReconciliationAdminHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.reconSvc.ListRuns(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, runs)
+}
+
+// GetRun handles GET /admin/reconciliation/runs/{id}.
+func (h *ReconciliationAdminHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid run id"))
+		return
+	}
+
+	report, err := h.reconSvc.GetReport(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, report)
+}
+
+// RunStripe handles POST /admin/reconciliation/stripe — reconciles the given
+// period (defaults to the previous UTC day).
+func (h *ReconciliationAdminHandler) RunStripe(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		From *time.Time `json:"from"`
+		To   *time.Time `json:"to"`
+	}
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &input); err != nil {
+			handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if input.To != nil {
+		to = *input.To
+	}
+	from := to.Add(-24 * time.Hour)
+	if input.From != nil {
+		from = *input.From
+	}
+
+	run, err := h.reconSvc.RunStripe(r.Context(), from, to)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, run)
+}
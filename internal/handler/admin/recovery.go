@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// RecoveryAdminHandler handles support review of account recovery requests.
+type RecoveryAdminHandler struct {
+	recoverySvc *service.RecoveryService
+}
+
+// NewRecoveryAdminHandler creates a new RecoveryAdminHandler.
+func NewRecoveryAdminHandler(recoverySvc *service.RecoveryService) *RecoveryAdminHandler {
+	return &RecoveryAdminHandler{recoverySvc: recoverySvc}
+}
+
+// ListRequests handles GET /admin/recovery-requests?status=pending.
+func (h *RecoveryAdminHandler) ListRequests(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.recoverySvc.List(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, requests)
+}
+
+// GetRequest handles GET /admin/recovery-requests/{id}.
+func (h *RecoveryAdminHandler) GetRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid recovery request id"))
+		return
+	}
+
+	detail, err := h.recoverySvc.Get(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, detail)
+}
+
+type recoveryDecisionRequest struct {
+	Note     string `json:"note"`
+	Override bool   `json:"override"`
+}
+
+// ApproveRequest handles POST /admin/recovery-requests/{id}/approve.
+func (h *RecoveryAdminHandler) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid recovery request id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input recoveryDecisionRequest
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	approval, err := h.recoverySvc.Approve(r.Context(), id, adminID, input.Note, input.Override)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, approval)
+}
+
+// RejectRequest handles POST /admin/recovery-requests/{id}/reject.
+func (h *RecoveryAdminHandler) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid recovery request id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input recoveryDecisionRequest
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	if err := h.recoverySvc.Reject(r.Context(), id, adminID, input.Note); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]string{"status": "rejected"})
+}
@@ -1,10 +1,16 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,26 +24,30 @@ func NewReportsHandler(pool *pgxpool.Pool) *ReportsHandler {
 	return &ReportsHandler{pool: pool}
 }
 
-// GetDashboardStats handles GET /admin/reports/dashboard.
+// GetDashboardStats handles GET /admin/reports/dashboard. Player and money
+// totals come from the player_summary and daily_kpis projections, so they
+// trail the ledger by the outbox consumer's lag.
 func (h *ReportsHandler) GetDashboardStats(w http.ResponseWriter, r *http.Request) {
 	type stats struct {
-		TotalPlayers      int   `json:"total_players"`
-		ActivePlayers     int   `json:"active_players"`
-		TotalDeposits     int64 `json:"total_deposits"`
-		TotalWithdrawals  int64 `json:"total_withdrawals"`
-		PendingWithdrawals int  `json:"pending_withdrawals"`
-		OpenBets          int   `json:"open_bets"`
+		TotalPlayers       int   `json:"total_players"`
+		ActivePlayers      int   `json:"active_players"`
+		TotalDeposits      int64 `json:"total_deposits"`
+		TotalWithdrawals   int64 `json:"total_withdrawals"`
+		PendingWithdrawals int   `json:"pending_withdrawals"`
+		OpenBets           int   `json:"open_bets"`
 	}
 
 	var s stats
 
-	// Total players
-	h.pool.QueryRow(r.Context(), `SELECT COUNT(*) FROM v2_players`).Scan(&s.TotalPlayers)
+	// Total players, and active players (have transactions in last 30 days)
+	h.pool.QueryRow(r.Context(), `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE last_activity_at > now() - interval '30 days')
+		FROM player_summary`).Scan(&s.TotalPlayers, &s.ActivePlayers)
 
-	// Active players (have transactions in last 30 days)
+	// Lifetime deposits and paid-out withdrawals
 	h.pool.QueryRow(r.Context(), `
-		SELECT COUNT(DISTINCT player_id) FROM v2_transactions
-		WHERE created_at > now() - interval '30 days'`).Scan(&s.ActivePlayers)
+		SELECT COALESCE(SUM(deposit_total), 0), COALESCE(SUM(withdrawal_total), 0)
+		FROM daily_kpis`).Scan(&s.TotalDeposits, &s.TotalWithdrawals)
 
 	// Pending withdrawals
 	h.pool.QueryRow(r.Context(), `
@@ -50,6 +60,142 @@ func (h *ReportsHandler) GetDashboardStats(w http.ResponseWriter, r *http.Reques
 	handler.RespondJSON(w, http.StatusOK, s)
 }
 
+// GetDailyKPIs handles GET /admin/reports/kpis?days= — the daily_kpis
+// projection for the last days (default 30, at most 366), newest first.
+func (h *ReportsHandler) GetDailyKPIs(w http.ResponseWriter, r *http.Request) {
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	if days <= 0 || days > 366 {
+		days = 30
+	}
+
+	rows, err := h.pool.Query(r.Context(), `
+		SELECT day::text, currency, registrations, deposit_count, deposit_total, withdrawal_total,
+		       bet_count, stake_total, win_total, bonus_total
+		FROM daily_kpis
+		WHERE day > (now() AT TIME ZONE 'UTC')::date - $1::int
+		ORDER BY day DESC, currency`, days)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("query daily kpis", err))
+		return
+	}
+	defer rows.Close()
+
+	type dailyKPI struct {
+		Day             string `json:"day"`
+		Currency        string `json:"currency"`
+		Registrations   int    `json:"registrations"`
+		DepositCount    int    `json:"deposit_count"`
+		DepositTotal    int64  `json:"deposit_total"`
+		WithdrawalTotal int64  `json:"withdrawal_total"`
+		BetCount        int    `json:"bet_count"`
+		StakeTotal      int64  `json:"stake_total"`
+		WinTotal        int64  `json:"win_total"`
+		BonusTotal      int64  `json:"bonus_total"`
+		GGR             int64  `json:"ggr"`
+	}
+
+	kpis := []dailyKPI{}
+	for rows.Next() {
+		var k dailyKPI
+		if err := rows.Scan(&k.Day, &k.Currency, &k.Registrations, &k.DepositCount, &k.DepositTotal,
+			&k.WithdrawalTotal, &k.BetCount, &k.StakeTotal, &k.WinTotal, &k.BonusTotal); err != nil {
+			handler.RespondError(w, domain.ErrInternal("scan daily kpis", err))
+			return
+		}
+		k.GGR = k.StakeTotal - k.WinTotal
+		kpis = append(kpis, k)
+	}
+
+	handler.RespondJSON(w, http.StatusOK, kpis)
+}
+
+// GetBetFeed handles GET /admin/reports/bet-feed?limit= — the latest
+// settled sportsbook bets and prediction stakes from the bet_feed projection.
+func (h *ReportsHandler) GetBetFeed(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	rows, err := h.pool.Query(r.Context(), `
+		SELECT kind, reference_id, player_id, stake, status, returned, settled_at
+		FROM bet_feed
+		ORDER BY settled_at DESC, id DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("query bet feed", err))
+		return
+	}
+	defer rows.Close()
+
+	type feedEntry struct {
+		Kind        string    `json:"kind"`
+		ReferenceID uuid.UUID `json:"reference_id"`
+		PlayerID    uuid.UUID `json:"player_id"`
+		Stake       int64     `json:"stake"`
+		Status      string    `json:"status"`
+		Returned    int64     `json:"returned"`
+		SettledAt   time.Time `json:"settled_at"`
+	}
+
+	entries := []feedEntry{}
+	for rows.Next() {
+		var e feedEntry
+		if err := rows.Scan(&e.Kind, &e.ReferenceID, &e.PlayerID, &e.Stake, &e.Status, &e.Returned, &e.SettledAt); err != nil {
+			handler.RespondError(w, domain.ErrInternal("scan bet feed", err))
+			return
+		}
+		entries = append(entries, e)
+	}
+
+	handler.RespondJSON(w, http.StatusOK, entries)
+}
+
+// GetPlayerSummary handles GET /admin/players/{id}/summary — the player's
+// lifetime totals from the player_summary projection.
+func (h *ReportsHandler) GetPlayerSummary(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	type playerSummary struct {
+		PlayerID        uuid.UUID  `json:"player_id"`
+		Email           *string    `json:"email"`
+		Currency        *string    `json:"currency"`
+		RegisteredAt    *time.Time `json:"registered_at"`
+		DepositCount    int        `json:"deposit_count"`
+		DepositTotal    int64      `json:"deposit_total"`
+		WithdrawalTotal int64      `json:"withdrawal_total"`
+		BetCount        int        `json:"bet_count"`
+		StakeTotal      int64      `json:"stake_total"`
+		WinTotal        int64      `json:"win_total"`
+		BonusTotal      int64      `json:"bonus_total"`
+		LastActivityAt  *time.Time `json:"last_activity_at"`
+		UpdatedAt       time.Time  `json:"updated_at"`
+	}
+
+	var ps playerSummary
+	err = h.pool.QueryRow(r.Context(), `
+		SELECT player_id, email, currency, registered_at, deposit_count, deposit_total, withdrawal_total,
+		       bet_count, stake_total, win_total, bonus_total, last_activity_at, updated_at
+		FROM player_summary WHERE player_id = $1`, id).Scan(
+		&ps.PlayerID, &ps.Email, &ps.Currency, &ps.RegisteredAt, &ps.DepositCount, &ps.DepositTotal,
+		&ps.WithdrawalTotal, &ps.BetCount, &ps.StakeTotal, &ps.WinTotal, &ps.BonusTotal,
+		&ps.LastActivityAt, &ps.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		handler.RespondError(w, domain.ErrNotFound("player summary", id.String()))
+		return
+	}
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("query player summary", err))
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, ps)
+}
+
 // GetTransactionReport handles GET /admin/reports/transactions.
 func (h *ReportsHandler) GetTransactionReport(w http.ResponseWriter, r *http.Request) {
 	period := r.URL.Query().Get("period")
@@ -86,3 +232,161 @@ func (h *ReportsHandler) GetTransactionReport(w http.ResponseWriter, r *http.Req
 
 	handler.RespondJSON(w, http.StatusOK, summaries)
 }
+
+// GetUnverifiedDOBReport handles GET /admin/reports/unverified-dob — players
+// who have never completed a withdrawal and have no verified date of birth,
+// so the check can happen before their first payout. Players with a pending
+// withdrawal come first, then by balance.
+func (h *ReportsHandler) GetUnverifiedDOBReport(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.pool.Query(r.Context(), `
+		SELECT pp.player_id, pp.email, COALESCE(pp.country, ''), pp.date_of_birth::text,
+		       p.balance::bigint, p.currency, pp.created_at,
+		       EXISTS (SELECT 1 FROM payments w
+		               WHERE w.player_id = pp.player_id AND w.type = 'withdrawal'
+		                 AND w.status IN ('pending', 'approved', 'on_hold')) AS pending_withdrawal
+		FROM player_profiles pp
+		JOIN v2_players p ON p.id = pp.player_id
+		WHERE pp.dob_verified_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM payments w
+		                  WHERE w.player_id = pp.player_id AND w.type = 'withdrawal' AND w.status = 'completed')
+		ORDER BY pending_withdrawal DESC, p.balance DESC, pp.created_at
+		LIMIT 500`)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("query unverified dob report", err))
+		return
+	}
+	defer rows.Close()
+
+	type unverifiedPlayer struct {
+		PlayerID          uuid.UUID `json:"player_id"`
+		Email             string    `json:"email"`
+		Country           string    `json:"country,omitempty"`
+		DateOfBirth       *string   `json:"date_of_birth"`
+		Balance           int64     `json:"balance"`
+		Currency          string    `json:"currency"`
+		RegisteredAt      time.Time `json:"registered_at"`
+		PendingWithdrawal bool      `json:"pending_withdrawal"`
+	}
+
+	players := []unverifiedPlayer{}
+	for rows.Next() {
+		var p unverifiedPlayer
+		if err := rows.Scan(&p.PlayerID, &p.Email, &p.Country, &p.DateOfBirth,
+			&p.Balance, &p.Currency, &p.RegisteredAt, &p.PendingWithdrawal); err != nil {
+			handler.RespondError(w, domain.ErrInternal("scan unverified dob report", err))
+			return
+		}
+		players = append(players, p)
+	}
+
+	handler.RespondJSON(w, http.StatusOK, players)
+}
+
+// exportedTransaction is one row of the transaction export.
+type exportedTransaction struct {
+	ID                    uuid.UUID `json:"id"`
+	PlayerID              uuid.UUID `json:"player_id"`
+	Type                  string    `json:"type"`
+	Amount                int64     `json:"amount"`
+	BalanceAfter          int64     `json:"balance_after"`
+	BonusBalanceAfter     int64     `json:"bonus_balance_after"`
+	ExternalTransactionID *string   `json:"external_transaction_id,omitempty"`
+	ManufacturerID        *string   `json:"manufacturer_id,omitempty"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+func (t exportedTransaction) csvRecord() []string {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return []string{
+		t.ID.String(), t.PlayerID.String(), t.Type,
+		strconv.FormatInt(t.Amount, 10), strconv.FormatInt(t.BalanceAfter, 10),
+		strconv.FormatInt(t.BonusBalanceAfter, 10),
+		deref(t.ExternalTransactionID), deref(t.ManufacturerID),
+		t.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ExportTransactions handles GET /admin/reports/transactions/export?from=&to=&format=csv|json.
+// Rows are streamed from the cursor straight to the client; from/to are
+// RFC 3339 and default to the last 24 hours.
+func (h *ReportsHandler) ExportTransactions(w http.ResponseWriter, r *http.Request) {
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+	q := r.URL.Query()
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("from must be RFC 3339"))
+			return
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("to must be RFC 3339"))
+			return
+		}
+		to = t
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		handler.RespondError(w, domain.ErrValidation("format must be json or csv"))
+		return
+	}
+
+	rows, err := h.pool.Query(r.Context(), `
+		SELECT id, player_id, type, amount::bigint, balance_after::bigint, bonus_balance_after::bigint,
+		       external_transaction_id, manufacturer_id, created_at
+		FROM v2_transactions
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at, id`, from, to)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("query export", err))
+		return
+	}
+	defer rows.Close()
+
+	// Headers are sent with the first byte, so a failure past this point can
+	// only truncate the stream.
+	var write func(exportedTransaction) error
+	var finish func() error
+	if format == "csv" {
+		stream, err := handler.NewCSVStream(w, "transactions.csv", []string{
+			"id", "player_id", "type", "amount", "balance_after", "bonus_balance_after",
+			"external_transaction_id", "manufacturer_id", "created_at",
+		})
+		if err != nil {
+			return
+		}
+		write = func(t exportedTransaction) error { return stream.Write(t.csvRecord()) }
+		finish = stream.Close
+	} else {
+		stream := handler.NewJSONArrayStream(w)
+		write = func(t exportedTransaction) error { return stream.Write(t) }
+		finish = stream.Close
+	}
+
+	for rows.Next() {
+		var t exportedTransaction
+		if err := rows.Scan(&t.ID, &t.PlayerID, &t.Type, &t.Amount, &t.BalanceAfter, &t.BonusBalanceAfter,
+			&t.ExternalTransactionID, &t.ManufacturerID, &t.CreatedAt); err != nil {
+			return
+		}
+		if err := write(t); err != nil {
+			return // client went away
+		}
+	}
+	if rows.Err() != nil {
+		return
+	}
+	finish()
+}
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// RetentionAdminHandler exposes the data retention policies and their runs.
+type RetentionAdminHandler struct {
+	svc *service.RetentionService
+}
+
+// NewRetentionAdminHandler creates a new RetentionAdminHandler.
+func NewRetentionAdminHandler(svc *service.RetentionService) *RetentionAdminHandler {
+	return &RetentionAdminHandler{svc: svc}
+}
+
+// Policies handles GET /admin/retention/policies.
+func (h *RetentionAdminHandler) Policies(w http.ResponseWriter, r *http.Request) {
+	handler.RespondJSON(w, http.StatusOK, h.svc.Policies())
+}
+
+// ListRuns handles GET /admin/retention/runs — the deletion audit trail.
+func (h *RetentionAdminHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	runs, err := h.svc.ListRuns(r.Context(), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, runs)
+}
+
+// Run handles POST /admin/retention/runs. Runs are dry unless the body sets
+// "dry_run": false.
+func (h *RetentionAdminHandler) Run(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		DryRun *bool `json:"dry_run"`
+	}
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &body); err != nil {
+			handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+	dryRun := body.DryRun == nil || *body.DryRun
+
+	run, err := h.svc.Run(r.Context(), dryRun)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, run)
+}
@@ -0,0 +1,259 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// RGCaseAdminHandler serves the responsible-gambling case workflow: the
+// risk-ranked player worklist, case handling and case history export.
+type RGCaseAdminHandler struct {
+	caseSvc *service.RGCaseService
+}
+
+// NewRGCaseAdminHandler creates a new RGCaseAdminHandler.
+func NewRGCaseAdminHandler(caseSvc *service.RGCaseService) *RGCaseAdminHandler {
+	return &RGCaseAdminHandler{caseSvc: caseSvc}
+}
+
+type rgAssignRequest struct {
+	AdminID *uuid.UUID `json:"admin_id"` // omitted assigns the caller
+}
+
+type rgInteractionRequest struct {
+	Kind    string `json:"kind"`
+	Summary string `json:"summary"`
+}
+
+type rgCloseRequest struct {
+	Outcome domain.RGCaseOutcome `json:"outcome"`
+	Note    string               `json:"note"`
+}
+
+// Players handles GET /admin/rg/players?band=&limit= — players ranked by
+// their riskiest session in the last 30 days.
+func (h *RGCaseAdminHandler) Players(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	players, err := h.caseSvc.ListPlayers(r.Context(), r.URL.Query().Get("band"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, players)
+}
+
+// List handles GET /admin/rg/cases?player_id=&status=&assigned_to=&limit=.
+func (h *RGCaseAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var playerID, assignedTo *uuid.UUID
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		playerID = &id
+	}
+	if v := q.Get("assigned_to"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid assigned_to"))
+			return
+		}
+		assignedTo = &id
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	cases, err := h.caseSvc.List(r.Context(), playerID, q.Get("status"), assignedTo, limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, cases)
+}
+
+// Get handles GET /admin/rg/cases/{id}.
+func (h *RGCaseAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid case id"))
+		return
+	}
+
+	c, err := h.caseSvc.Get(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, c)
+}
+
+// Open handles POST /admin/rg/cases.
+func (h *RGCaseAdminHandler) Open(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.OpenRGCaseInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	c, err := h.caseSvc.Open(r.Context(), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, c)
+}
+
+// Assign handles POST /admin/rg/cases/{id}/assign.
+func (h *RGCaseAdminHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	id, adminID, ok := h.caseAndAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var input rgAssignRequest
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+	assignee := adminID
+	if input.AdminID != nil {
+		assignee = *input.AdminID
+	}
+
+	c, err := h.caseSvc.Assign(r.Context(), id, assignee, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, c)
+}
+
+// AddInteraction handles POST /admin/rg/cases/{id}/interactions.
+func (h *RGCaseAdminHandler) AddInteraction(w http.ResponseWriter, r *http.Request) {
+	id, adminID, ok := h.caseAndAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var input rgInteractionRequest
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	interaction, err := h.caseSvc.AddInteraction(r.Context(), id, adminID, input.Kind, input.Summary)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, interaction)
+}
+
+// Close handles POST /admin/rg/cases/{id}/close.
+func (h *RGCaseAdminHandler) Close(w http.ResponseWriter, r *http.Request) {
+	id, adminID, ok := h.caseAndAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var input rgCloseRequest
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	c, err := h.caseSvc.Close(r.Context(), id, adminID, input.Outcome, input.Note)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, c)
+}
+
+// Export handles GET /admin/rg/cases/{id}/export?format=json|csv — the case
+// history as one timeline for regulators and audits.
+func (h *RGCaseAdminHandler) Export(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid case id"))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		handler.RespondError(w, domain.ErrValidation("format must be json or csv"))
+		return
+	}
+
+	entries, err := h.caseSvc.History(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	if format == "json" {
+		stream := handler.NewJSONArrayStream(w)
+		for _, e := range entries {
+			if err := stream.Write(e); err != nil {
+				return
+			}
+		}
+		stream.Close()
+		return
+	}
+
+	stream, err := handler.NewCSVStream(w, "rg_case_"+id.String()+".csv", []string{
+		"at", "source", "kind", "actor_id", "summary",
+	})
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		actor := ""
+		if e.ActorID != nil {
+			actor = e.ActorID.String()
+		}
+		if err := stream.Write([]string{e.At.UTC().Format(time.RFC3339), e.Source, e.Kind, actor, e.Summary}); err != nil {
+			return
+		}
+	}
+	stream.Close()
+}
+
+func (h *RGCaseAdminHandler) caseAndAdmin(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid case id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, adminID, true
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
+)
+
+// InterventionAdminHandler exposes the responsible-gambling intervention
+// audit log to compliance.
+type InterventionAdminHandler struct {
+	interventionSvc *service.NetLossInterventionService
+}
+
+// NewInterventionAdminHandler creates a new InterventionAdminHandler.
+func NewInterventionAdminHandler(interventionSvc *service.NetLossInterventionService) *InterventionAdminHandler {
+	return &InterventionAdminHandler{interventionSvc: interventionSvc}
+}
+
+// List handles GET /admin/rg/interventions?player_id=&kind=&limit=.
+func (h *InterventionAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var playerID *uuid.UUID
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		playerID = &id
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	interventions, err := h.interventionSvc.List(r.Context(), playerID, q.Get("kind"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, interventions)
+}
+
+// Run handles POST /admin/rg/interventions/run — evaluates net losses now
+// instead of waiting for the scheduler.
+func (h *InterventionAdminHandler) Run(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.interventionSvc.Run(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, summary)
+}
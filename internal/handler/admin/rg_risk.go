@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
+)
+
+// RGRiskAdminHandler exposes session risk scores and the responsible-gambling
+// dashboard to compliance.
+type RGRiskAdminHandler struct {
+	riskSvc *service.RGRiskService
+}
+
+// NewRGRiskAdminHandler creates a new RGRiskAdminHandler.
+func NewRGRiskAdminHandler(riskSvc *service.RGRiskService) *RGRiskAdminHandler {
+	return &RGRiskAdminHandler{riskSvc: riskSvc}
+}
+
+// Dashboard handles GET /admin/rg/dashboard.
+func (h *RGRiskAdminHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	d, err := h.riskSvc.Dashboard(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, d)
+}
+
+// List handles GET /admin/rg/risk-scores?player_id=&band=&limit=.
+func (h *RGRiskAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var playerID *uuid.UUID
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		playerID = &id
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	scores, err := h.riskSvc.List(r.Context(), playerID, q.Get("band"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, scores)
+}
+
+// Run handles POST /admin/rg/risk-scores/run — scores recent sessions now
+// instead of waiting for the scheduler.
+func (h *RGRiskAdminHandler) Run(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.riskSvc.Run(r.Context())
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, summary)
+}
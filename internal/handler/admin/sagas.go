@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/saga"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SagaAdminHandler lets operators follow multi-step money flows, such as
+// withdrawal payouts, and find those that failed.
+type SagaAdminHandler struct {
+	sagas *saga.Orchestrator
+}
+
+// NewSagaAdminHandler creates a new SagaAdminHandler.
+func NewSagaAdminHandler(sagas *saga.Orchestrator) *SagaAdminHandler {
+	return &SagaAdminHandler{sagas: sagas}
+}
+
+// List handles GET /admin/sagas?status=failed.
+func (h *SagaAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	sagas, err := h.sagas.List(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("list sagas", err))
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, sagas)
+}
+
+// Retry handles POST /admin/sagas/{id}/retry: a saga waiting to retry a
+// failed step runs it now. Completed, compensated and failed sagas conflict.
+func (h *SagaAdminHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid saga id"))
+		return
+	}
+
+	sg, ok, err := h.sagas.Retry(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("retry saga", err))
+		return
+	}
+	if sg == nil {
+		handler.RespondError(w, domain.ErrNotFound("saga", id.String()))
+		return
+	}
+	if !ok {
+		handler.RespondError(w, domain.ErrConflict(fmt.Sprintf("saga is %s", sg.Status)))
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, sg)
+}
+
+// Get handles GET /admin/sagas/{id}: the saga with its step log.
+func (h *SagaAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid saga id"))
+		return
+	}
+
+	sg, err := h.sagas.Get(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("get saga", err))
+		return
+	}
+	if sg == nil {
+		handler.RespondError(w, domain.ErrNotFound("saga", id.String()))
+		return
+	}
+	steps, err := h.sagas.Steps(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, domain.ErrInternal("list saga steps", err))
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, struct {
+		domain.Saga
+		Steps []domain.SagaStepLog `json:"steps"`
+	}{*sg, steps})
+}
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SourceOfFundsAdminHandler serves the compliance review queue for
+// source-of-funds questionnaires.
+type SourceOfFundsAdminHandler struct {
+	sofSvc *service.SourceOfFundsService
+}
+
+// NewSourceOfFundsAdminHandler creates a new SourceOfFundsAdminHandler.
+func NewSourceOfFundsAdminHandler(sofSvc *service.SourceOfFundsService) *SourceOfFundsAdminHandler {
+	return &SourceOfFundsAdminHandler{sofSvc: sofSvc}
+}
+
+type sofDecisionRequest struct {
+	Note string `json:"note"`
+}
+
+// List handles GET /admin/source-of-funds?status=submitted&limit=50.
+func (h *SourceOfFundsAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	questionnaires, err := h.sofSvc.List(r.Context(), r.URL.Query().Get("status"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, questionnaires)
+}
+
+// Get handles GET /admin/source-of-funds/{id}.
+func (h *SourceOfFundsAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid questionnaire id"))
+		return
+	}
+
+	q, err := h.sofSvc.Get(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, q)
+}
+
+// DownloadDocument handles GET /admin/source-of-funds/documents/{id}.
+func (h *SourceOfFundsAdminHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid document id"))
+		return
+	}
+
+	doc, content, err := h.sofSvc.Document(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// Approve handles POST /admin/source-of-funds/{id}/approve.
+func (h *SourceOfFundsAdminHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.sofSvc.Approve)
+}
+
+// Reject handles POST /admin/source-of-funds/{id}/reject. A note is required.
+func (h *SourceOfFundsAdminHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.sofSvc.Reject)
+}
+
+func (h *SourceOfFundsAdminHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	review func(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.SOFQuestionnaire, error),
+) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid questionnaire id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input sofDecisionRequest
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	q, err := review(r.Context(), id, adminID, input.Note)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, q)
+}
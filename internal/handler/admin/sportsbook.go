@@ -2,6 +2,7 @@ package admin
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
@@ -88,11 +89,104 @@ func (h *SportsbookAdminHandler) UpdateEventStatus(w http.ResponseWriter, r *htt
 	handler.RespondJSON(w, http.StatusOK, map[string]string{"status": "updated"})
 }
 
+// SetEventCashOut handles PATCH /admin/sportsbook/events/{id}/cash-out,
+// the per-event cash-out kill switch.
+func (h *SportsbookAdminHandler) SetEventCashOut(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid event id"))
+		return
+	}
+
+	var input struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil || input.Enabled == nil {
+		handler.RespondError(w, domain.ErrValidation("enabled is required"))
+		return
+	}
+
+	if err := h.svc.SetEventCashOut(r.Context(), id, *input.Enabled); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]bool{"cash_out_enabled": *input.Enabled})
+}
+
+// SetEventLiabilityLimit handles PATCH /admin/sportsbook/events/{id}/exposure-limit.
+// A null max_liability restores the default limit; zero lifts it.
+func (h *SportsbookAdminHandler) SetEventLiabilityLimit(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid event id"))
+		return
+	}
+
+	var input struct {
+		MaxLiability *int64 `json:"max_liability"`
+	}
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	if err := h.svc.SetEventLiabilityLimit(r.Context(), id, input.MaxLiability); err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, map[string]*int64{"max_liability": input.MaxLiability})
+}
+
+// EventExposure handles GET /admin/sportsbook/events/{id}/exposure.
+func (h *SportsbookAdminHandler) EventExposure(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid event id"))
+		return
+	}
+
+	exposure, err := h.svc.EventExposure(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, exposure)
+}
+
+// ListExposure handles GET /admin/sportsbook/exposure?limit=, the events
+// with open bets by worst-case liability.
+func (h *SportsbookAdminHandler) ListExposure(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	exposures, err := h.svc.ListExposure(r.Context(), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, exposures)
+}
+
+// ListSettlementAudit handles GET /admin/sportsbook/events/{id}/settlement-audit.
+func (h *SportsbookAdminHandler) ListSettlementAudit(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid event id"))
+		return
+	}
+
+	entries, err := h.svc.ListSettlementAudit(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, entries)
+}
+
 // ListEvents handles GET /admin/sportsbook/events.
 func (h *SportsbookAdminHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 	rows, err := h.pool.Query(r.Context(), `
 		SELECT e.id, e.sport_id, s.name as sport_name, e.league, e.home_team, e.away_team,
-		       e.start_time, e.status, e.score_home, e.score_away
+		       e.start_time, e.status, e.score_home, e.score_away, e.cash_out_enabled
 		FROM sports_events e JOIN sports s ON s.id = e.sport_id
 		ORDER BY e.start_time DESC LIMIT 100`)
 	if err != nil {
@@ -112,12 +206,13 @@ func (h *SportsbookAdminHandler) ListEvents(w http.ResponseWriter, r *http.Reque
 		Status    string    `json:"status"`
 		ScoreHome int       `json:"score_home"`
 		ScoreAway int       `json:"score_away"`
+		CashOut   bool      `json:"cash_out_enabled"`
 	}
 
 	var events []eventSummary
 	for rows.Next() {
 		var e eventSummary
-		if err := rows.Scan(&e.ID, &e.SportID, &e.SportName, &e.League, &e.HomeTeam, &e.AwayTeam, &e.StartTime, &e.Status, &e.ScoreHome, &e.ScoreAway); err != nil {
+		if err := rows.Scan(&e.ID, &e.SportID, &e.SportName, &e.League, &e.HomeTeam, &e.AwayTeam, &e.StartTime, &e.Status, &e.ScoreHome, &e.ScoreAway, &e.CashOut); err != nil {
 			handler.RespondError(w, domain.ErrInternal("scan event", err))
 			return
 		}
@@ -143,3 +238,148 @@ func (h *SportsbookAdminHandler) SettleEvent(w http.ResponseWriter, r *http.Requ
 
 	handler.RespondJSON(w, http.StatusOK, result)
 }
+
+// CreateOutright handles POST /admin/sportsbook/outrights.
+func (h *SportsbookAdminHandler) CreateOutright(w http.ResponseWriter, r *http.Request) {
+	var input service.CreateOutrightInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	market, err := h.svc.CreateOutright(r.Context(), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusCreated, market)
+}
+
+// SettleOutright handles POST /admin/sportsbook/outrights/{id}/settle.
+func (h *SportsbookAdminHandler) SettleOutright(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+
+	var input service.SettleOutrightInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	result, err := h.svc.SettleOutright(r.Context(), id, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, result)
+}
+
+// BulkUpdateOdds handles POST /admin/sportsbook/selections/bulk-odds.
+func (h *SportsbookAdminHandler) BulkUpdateOdds(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.BulkOddsInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	result, err := h.svc.BulkUpdateOdds(r.Context(), adminID, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, result)
+}
+
+// ListOddsChanges handles GET /admin/sportsbook/markets/{id}/odds-changes?limit=100.
+func (h *SportsbookAdminHandler) ListOddsChanges(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid market id"))
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	changes, err := h.svc.ListOddsChanges(r.Context(), id, limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, changes)
+}
+
+// Liabilities handles GET /admin/sportsbook/liabilities — open-bet exposure
+// per selection, optionally for one market (?market_id=).
+func (h *SportsbookAdminHandler) Liabilities(w http.ResponseWriter, r *http.Request) {
+	var marketID *uuid.UUID
+	if v := r.URL.Query().Get("market_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid market_id"))
+			return
+		}
+		marketID = &id
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	liabilities, err := h.svc.Liabilities(r.Context(), marketID, limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, liabilities)
+}
+
+// ListTradingAlerts handles GET /admin/sportsbook/trading-alerts?status=.
+func (h *SportsbookAdminHandler) ListTradingAlerts(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	alerts, err := h.svc.ListTradingAlerts(r.Context(), r.URL.Query().Get("status"), limit)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, alerts)
+}
+
+// AcknowledgeTradingAlert handles POST /admin/sportsbook/trading-alerts/{id}/acknowledge.
+func (h *SportsbookAdminHandler) AcknowledgeTradingAlert(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid alert id"))
+		return
+	}
+
+	alert, err := h.svc.AcknowledgeTradingAlert(r.Context(), id, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	handler.RespondJSON(w, http.StatusOK, alert)
+}
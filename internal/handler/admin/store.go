@@ -0,0 +1,101 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// StoreAdminHandler manages the store catalog and looks up player orders.
+type StoreAdminHandler struct {
+	svc *service.StoreService
+}
+
+// NewStoreAdminHandler creates a new StoreAdminHandler.
+func NewStoreAdminHandler(svc *service.StoreService) *StoreAdminHandler {
+	return &StoreAdminHandler{svc: svc}
+}
+
+// ListItems handles GET /admin/store/items, inactive items included.
+func (h *StoreAdminHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListItems(r.Context(), false)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, items)
+}
+
+// CreateItem handles POST /admin/store/items.
+func (h *StoreAdminHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
+	var input service.StoreItemInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	item, err := h.svc.CreateItem(r.Context(), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, item)
+}
+
+// UpdateItem handles PUT /admin/store/items/{id}.
+func (h *StoreAdminHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid item id"))
+		return
+	}
+
+	var input service.StoreItemInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	item, err := h.svc.UpdateItem(r.Context(), id, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, item)
+}
+
+// PlayerOrders handles GET /admin/players/{id}/store-orders.
+func (h *StoreAdminHandler) PlayerOrders(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	orders, err := h.svc.ListOrders(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, orders)
+}
+
+// PlayerEntitlements handles GET /admin/players/{id}/entitlements.
+func (h *StoreAdminHandler) PlayerEntitlements(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	entitlements, err := h.svc.ListEntitlements(r.Context(), id, false)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, entitlements)
+}
@@ -0,0 +1,140 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SweepstakesAdminHandler manages coin packages and the SC redemption queue.
+type SweepstakesAdminHandler struct {
+	svc *service.SweepstakesService
+}
+
+// NewSweepstakesAdminHandler creates a new SweepstakesAdminHandler.
+func NewSweepstakesAdminHandler(svc *service.SweepstakesService) *SweepstakesAdminHandler {
+	return &SweepstakesAdminHandler{svc: svc}
+}
+
+// Rules handles GET /admin/sweeps/rules.
+func (h *SweepstakesAdminHandler) Rules(w http.ResponseWriter, r *http.Request) {
+	handler.RespondJSON(w, http.StatusOK, h.svc.Rules())
+}
+
+// ListPackages handles GET /admin/sweeps/packages, inactive packages included.
+func (h *SweepstakesAdminHandler) ListPackages(w http.ResponseWriter, r *http.Request) {
+	packages, err := h.svc.ListPackages(r.Context(), false)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, packages)
+}
+
+// CreatePackage handles POST /admin/sweeps/packages.
+func (h *SweepstakesAdminHandler) CreatePackage(w http.ResponseWriter, r *http.Request) {
+	var input service.CoinPackageInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	pkg, err := h.svc.CreatePackage(r.Context(), input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, pkg)
+}
+
+// UpdatePackage handles PUT /admin/sweeps/packages/{id}.
+func (h *SweepstakesAdminHandler) UpdatePackage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid package id"))
+		return
+	}
+
+	var input service.CoinPackageInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	pkg, err := h.svc.UpdatePackage(r.Context(), id, input)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, pkg)
+}
+
+// ListRedemptions handles GET /admin/sweeps/redemptions?status=&player_id=.
+func (h *SweepstakesAdminHandler) ListRedemptions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var playerID *uuid.UUID
+	if v := q.Get("player_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid player_id"))
+			return
+		}
+		playerID = &id
+	}
+
+	redemptions, err := h.svc.ListRedemptions(r.Context(), playerID, q.Get("status"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, redemptions)
+}
+
+// ApproveRedemption handles POST /admin/sweeps/redemptions/{id}/approve,
+// recording that the cash prize was paid.
+func (h *SweepstakesAdminHandler) ApproveRedemption(w http.ResponseWriter, r *http.Request) {
+	h.reviewRedemption(w, r, h.svc.ApproveRedemption)
+}
+
+// RejectRedemption handles POST /admin/sweeps/redemptions/{id}/reject.
+// The reserved SC returns to the player.
+func (h *SweepstakesAdminHandler) RejectRedemption(w http.ResponseWriter, r *http.Request) {
+	h.reviewRedemption(w, r, h.svc.RejectRedemption)
+}
+
+func (h *SweepstakesAdminHandler) reviewRedemption(w http.ResponseWriter, r *http.Request,
+	review func(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.SweepsRedemption, error)) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid redemption id"))
+		return
+	}
+
+	var input struct {
+		Note string `json:"note"`
+	}
+	if r.ContentLength > 0 {
+		if err := handler.DecodeJSON(r, &input); err != nil {
+			handler.RespondError(w, domain.ErrValidation("invalid request body"))
+			return
+		}
+	}
+
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	redemption, err := review(r.Context(), id, adminID, input.Note)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, redemption)
+}
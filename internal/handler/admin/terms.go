@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// TermsAdminHandler manages T&C document versions and acceptance evidence.
+type TermsAdminHandler struct {
+	termsSvc *service.TermsService
+}
+
+// NewTermsAdminHandler creates a new TermsAdminHandler.
+func NewTermsAdminHandler(termsSvc *service.TermsService) *TermsAdminHandler {
+	return &TermsAdminHandler{termsSvc: termsSvc}
+}
+
+// ListDocuments handles GET /admin/terms?kind=bonus_terms.
+func (h *TermsAdminHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	docs, err := h.termsSvc.ListDocuments(r.Context(), r.URL.Query().Get("kind"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, docs)
+}
+
+// CreateDocument handles POST /admin/terms — drafts the next version.
+func (h *TermsAdminHandler) CreateDocument(w http.ResponseWriter, r *http.Request) {
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	var input service.TermsInput
+	if err := handler.DecodeJSON(r, &input); err != nil {
+		handler.RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	doc, err := h.termsSvc.CreateDraft(r.Context(), input, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusCreated, doc)
+}
+
+// PublishDocument handles POST /admin/terms/{id}/publish.
+func (h *TermsAdminHandler) PublishDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid terms document id"))
+		return
+	}
+
+	doc, err := h.termsSvc.Publish(r.Context(), id)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, doc)
+}
+
+// ListPlayerAcceptances handles GET /admin/players/{id}/terms-acceptances.
+func (h *TermsAdminHandler) ListPlayerAcceptances(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	acceptances, err := h.termsSvc.ListAcceptances(r.Context(), playerID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, acceptances)
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/handler"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// WithdrawalAdminHandler serves the admin withdrawal queue.
+type WithdrawalAdminHandler struct {
+	paymentSvc *service.PaymentService
+}
+
+// NewWithdrawalAdminHandler creates a new WithdrawalAdminHandler.
+func NewWithdrawalAdminHandler(paymentSvc *service.PaymentService) *WithdrawalAdminHandler {
+	return &WithdrawalAdminHandler{paymentSvc: paymentSvc}
+}
+
+// ListQueue handles GET /admin/withdrawals?status=pending.
+// Each item carries the automated approve/review/deny recommendation.
+func (h *WithdrawalAdminHandler) ListQueue(w http.ResponseWriter, r *http.Request) {
+	items, err := h.paymentSvc.ListWithdrawalQueue(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, items)
+}
+
+// Approve handles POST /admin/withdrawals/{id}/approve. The withdrawal is
+// paid out by a saga; the response is the saga as it stands, completed
+// unless the PSP refused or is being retried.
+func (h *WithdrawalAdminHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		handler.RespondError(w, domain.ErrValidation("invalid withdrawal id"))
+		return
+	}
+	adminID, err := adminIDFromContext(r)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+
+	sg, err := h.paymentSvc.ApproveWithdrawal(r.Context(), id, adminID)
+	if err != nil {
+		handler.RespondError(w, err)
+		return
+	}
+	handler.RespondJSON(w, http.StatusOK, sg)
+}
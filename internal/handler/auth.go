@@ -27,6 +27,9 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	input.IP = ClientIP(r)
+	input.Brand = BrandFromRequest(r)
+
 	result, err := h.authSvc.Register(r.Context(), input)
 	if err != nil {
 		RespondError(w, err)
@@ -48,6 +51,9 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input.IP = ClientIP(r)
+	input.Brand = BrandFromRequest(r)
+	input.Country = ClientCountry(r)
+	input.UserAgent = r.UserAgent()
 
 	result, err := h.authSvc.Login(r.Context(), input)
 	if err != nil {
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// BetPoolHandler handles social bet pool endpoints.
+type BetPoolHandler struct {
+	svc *service.SportsbookService
+}
+
+// NewBetPoolHandler creates a new BetPoolHandler.
+func NewBetPoolHandler(svc *service.SportsbookService) *BetPoolHandler {
+	return &BetPoolHandler{svc: svc}
+}
+
+// poolRequest resolves the calling player and the {id} pool path parameter.
+func poolRequest(w http.ResponseWriter, r *http.Request) (playerID, poolID uuid.UUID, ok bool) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	poolID, err = uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid pool id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return playerID, poolID, true
+}
+
+// Create handles POST /sportsbook/pools.
+func (h *BetPoolHandler) Create(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.CreateBetPoolInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	pool, err := h.svc.CreatePool(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, pool)
+}
+
+// MyPools handles GET /sportsbook/pools/me.
+func (h *BetPoolHandler) MyPools(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	pools, err := h.svc.ListPlayerPools(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pools)
+}
+
+// Get handles GET /sportsbook/pools/{id}.
+func (h *BetPoolHandler) Get(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	pool, err := h.svc.GetPool(r.Context(), poolID, playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pool)
+}
+
+// Invite handles POST /sportsbook/pools/{id}/invitations.
+func (h *BetPoolHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		PlayerIDs []uuid.UUID `json:"player_ids"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	pool, err := h.svc.InvitePool(r.Context(), poolID, playerID, req.PlayerIDs)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pool)
+}
+
+// Join handles POST /sportsbook/pools/{id}/join.
+func (h *BetPoolHandler) Join(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	pool, err := h.svc.JoinPool(r.Context(), poolID, playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pool)
+}
+
+// Decline handles POST /sportsbook/pools/{id}/decline.
+func (h *BetPoolHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeclinePool(r.Context(), poolID, playerID); err != nil {
+		RespondError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Contribute handles POST /sportsbook/pools/{id}/contributions.
+func (h *BetPoolHandler) Contribute(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Amount int64 `json:"amount"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	contribution, err := h.svc.ContributePool(r.Context(), poolID, playerID, req.Amount)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, contribution)
+}
+
+// Place handles POST /sportsbook/pools/{id}/place.
+func (h *BetPoolHandler) Place(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	pool, err := h.svc.PlacePool(r.Context(), poolID, playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pool)
+}
+
+// Cancel handles POST /sportsbook/pools/{id}/cancel.
+func (h *BetPoolHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	pool, err := h.svc.CancelPool(r.Context(), poolID, playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pool)
+}
+
+// Messages handles GET /sportsbook/pools/{id}/messages.
+func (h *BetPoolHandler) Messages(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	messages, err := h.svc.ListPoolMessages(r.Context(), poolID, playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, messages)
+}
+
+// PostMessage handles POST /sportsbook/pools/{id}/messages.
+func (h *BetPoolHandler) PostMessage(w http.ResponseWriter, r *http.Request) {
+	playerID, poolID, ok := poolRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	message, err := h.svc.PostPoolMessage(r.Context(), poolID, playerID, req.Body)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, message)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// BetReceiptHandler serves signed sportsbook bet receipts.
+type BetReceiptHandler struct {
+	receiptSvc *service.BetReceiptService
+}
+
+// NewBetReceiptHandler creates a new BetReceiptHandler.
+func NewBetReceiptHandler(receiptSvc *service.BetReceiptService) *BetReceiptHandler {
+	return &BetReceiptHandler{receiptSvc: receiptSvc}
+}
+
+// Receipt handles GET /sportsbook/bets/{id}/receipt?format=pdf|json. The PDF
+// is the default; the JSON form is the signed payload support verifies.
+func (h *BetReceiptHandler) Receipt(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	betID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid bet id"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "pdf" && format != "json" {
+		RespondError(w, domain.ErrValidation("format must be pdf or json"))
+		return
+	}
+
+	receipt, err := h.receiptSvc.Receipt(r.Context(), playerID, betID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	if format == "json" {
+		RespondJSON(w, http.StatusOK, receipt)
+		return
+	}
+	RespondPDF(w, "bet_receipt_"+betID.String()+".pdf", "Bet Receipt", receiptLines(receipt))
+}
+
+// EmailReceipt handles POST /sportsbook/bets/{id}/receipt/email.
+func (h *BetReceiptHandler) EmailReceipt(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	betID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid bet id"))
+		return
+	}
+
+	receipt, err := h.receiptSvc.Email(r.Context(), playerID, betID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"bet_id": receipt.BetID, "signature": receipt.Signature,
+	})
+}
+
+// receiptLines lays out a receipt for the PDF.
+func receiptLines(rc *domain.BetReceipt) []string {
+	lines := []string{
+		"Bet ID: " + rc.BetID.String(),
+		"Placed: " + rc.PlacedAt.UTC().Format(time.RFC3339),
+		"",
+	}
+	for _, sel := range rc.Selections {
+		lines = append(lines,
+			"Event: "+sel.Event,
+			"Market: "+sel.Market,
+			"Selection: "+sel.Selection,
+			"Odds: "+formatMinor(int64(sel.Odds)),
+			"",
+		)
+	}
+	stake := formatMinor(rc.Stake) + " " + rc.Currency
+	if rc.EachWay && rc.EachWayPlaces != nil && rc.EachWayFraction != nil {
+		stake += fmt.Sprintf(" (each-way, 1/%d odds %d places)", *rc.EachWayFraction, *rc.EachWayPlaces)
+	}
+	lines = append(lines,
+		"Stake: "+stake,
+		"Potential payout: "+formatMinor(rc.PotentialPayout)+" "+rc.Currency,
+	)
+	if rc.TransactionID != nil {
+		lines = append(lines, "Transaction: "+rc.TransactionID.String())
+	}
+	terms := rc.TermsHash
+	if terms == "" {
+		terms = "none accepted"
+	}
+	lines = append(lines,
+		"Terms hash: "+terms,
+		"",
+		"Issued: "+rc.IssuedAt.UTC().Format(time.RFC3339),
+		"Signature: "+rc.Signature,
+	)
+	return lines
+}
+
+// formatMinor renders a minor-unit amount (or x100 odds) with two decimals.
+func formatMinor(v int64) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// BonusHandler handles the player side of bonuses.
+type BonusHandler struct {
+	svc *service.BonusService
+}
+
+// NewBonusHandler creates a new BonusHandler.
+func NewBonusHandler(svc *service.BonusService) *BonusHandler {
+	return &BonusHandler{svc: svc}
+}
+
+// ListFreeSpins handles GET /bonuses/free-spins.
+func (h *BonusHandler) ListFreeSpins(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	grants, err := h.svc.ListFreeSpins(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, grants)
+}
+
+// PlayFreeSpin handles POST /bonuses/free-spins/{id}/spin with an optional
+// game_id; without one the grant's first game is played.
+func (h *BonusHandler) PlayFreeSpin(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	grantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid free spins id"))
+		return
+	}
+
+	var input struct {
+		GameID string `json:"game_id"`
+	}
+	if r.ContentLength > 0 {
+		if err := DecodeJSON(r, &input); err != nil {
+			RespondError(w, domain.ErrValidation("invalid request body"))
+			return
+		}
+	}
+
+	result, err := h.svc.PlayFreeSpin(r.Context(), playerID, grantID, input.GameID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, result)
+}
+
+// ListDepositMatchOffers handles GET /bonuses/deposit-match.
+func (h *BonusHandler) ListDepositMatchOffers(w http.ResponseWriter, r *http.Request) {
+	offers, err := h.svc.ListDepositMatchOffers(r.Context())
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, offers)
+}
+
+// ListOptIns handles GET /bonuses/opt-ins.
+func (h *BonusHandler) ListOptIns(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	optIns, err := h.svc.ListOptIns(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, optIns)
+}
+
+// OptIn handles POST /bonuses/{id}/opt-in: the player's next qualifying
+// deposit is matched by the bonus.
+func (h *BonusHandler) OptIn(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	bonusID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid bonus id"))
+		return
+	}
+
+	optIn, err := h.svc.OptIn(r.Context(), playerID, bonusID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, optIn)
+}
+
+// CancelOptIn handles DELETE /bonuses/{id}/opt-in.
+func (h *BonusHandler) CancelOptIn(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	bonusID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid bonus id"))
+		return
+	}
+
+	if err := h.svc.CancelOptIn(r.Context(), playerID, bonusID); err != nil {
+		RespondError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
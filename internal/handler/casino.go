@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CasinoHandler handles the player's casino activity and lobby endpoints.
+type CasinoHandler struct {
+	casinoSvc *service.CasinoService
+}
+
+// NewCasinoHandler creates a new CasinoHandler.
+func NewCasinoHandler(casinoSvc *service.CasinoService) *CasinoHandler {
+	return &CasinoHandler{casinoSvc: casinoSvc}
+}
+
+// ListSessions handles GET /casino/sessions?limit=.
+func (h *CasinoHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	sessions, err := h.casinoSvc.ListSessions(r.Context(), playerID, limit)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{"sessions": sessions})
+}
+
+// RecordLaunch handles POST /casino/games/{id}/launch, called as the lobby
+// opens a game.
+func (h *CasinoHandler) RecordLaunch(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	gameID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid game id"))
+		return
+	}
+
+	launch, err := h.casinoSvc.RecordLaunch(r.Context(), playerID, gameID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusCreated, launch)
+}
+
+// RecentGames handles GET /casino/games/recent?limit=.
+func (h *CasinoHandler) RecentGames(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	games, err := h.casinoSvc.RecentGames(r.Context(), playerID, limit)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{"games": games})
+}
+
+// RecommendedGames handles GET /casino/games/recommended?limit=.
+func (h *CasinoHandler) RecommendedGames(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	games, err := h.casinoSvc.RecommendedGames(r.Context(), playerID, limit)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{"games": games})
+}
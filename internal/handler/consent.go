@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// ConsentHandler lets players manage their marketing, analytics and
+// profiling consent.
+type ConsentHandler struct {
+	svc *service.ConsentService
+}
+
+// NewConsentHandler creates a new ConsentHandler.
+func NewConsentHandler(svc *service.ConsentService) *ConsentHandler {
+	return &ConsentHandler{svc: svc}
+}
+
+// List handles GET /players/me/consents.
+func (h *ConsentHandler) List(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	consents, err := h.svc.ListConsents(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, consents)
+}
+
+type updateConsentsRequest struct {
+	Consents []service.ConsentChange `json:"consents"`
+}
+
+// Update handles PUT /players/me/consents. Only the purposes and channels
+// in the body change; the rest are left as they are.
+func (h *ConsentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var req updateConsentsRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	consents, err := h.svc.UpdateConsents(r.Context(), playerID, req.Consents, domain.ConsentSourcePlayer, ClientIP(r))
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, consents)
+}
+
+// History handles GET /players/me/consents/history.
+func (h *ConsentHandler) History(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	records, err := h.svc.ConsentHistory(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, records)
+}
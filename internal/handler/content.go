@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// ContentHandler serves published CMS pages.
+type ContentHandler struct {
+	contentSvc *service.ContentService
+}
+
+// NewContentHandler creates a new ContentHandler.
+func NewContentHandler(contentSvc *service.ContentService) *ContentHandler {
+	return &ContentHandler{contentSvc: contentSvc}
+}
+
+// GetPage handles GET /content/{slug}?locale=de. Without a locale parameter
+// the Accept-Language header picks the translation.
+func (h *ContentHandler) GetPage(w http.ResponseWriter, r *http.Request) {
+	page, err := h.contentSvc.Published(r.Context(), chi.URLParam(r, "slug"), requestLocales(r))
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, page)
+}
+
+// requestLocales lists the locales a request asks for, most preferred first:
+// the locale query parameter, then the Accept-Language tags in header order.
+func requestLocales(r *http.Request) []string {
+	var locales []string
+	if l := r.URL.Query().Get("locale"); l != "" {
+		locales = append(locales, l)
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		if tag = strings.TrimSpace(tag); tag != "" && tag != "*" {
+			locales = append(locales, tag)
+		}
+	}
+	return locales
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// CORSConfig controls which browser origins may call the API.
+//
+// Origins may be exact ("https://app.attaboy.io"), a subdomain wildcard
+// ("https://*.attaboy.io") or "*". Admin routes never honour "*": they are
+// closed to cross-origin callers unless AdminOrigins lists the origin.
+type CORSConfig struct {
+	Origins      []string
+	BrandOrigins map[string][]string // X-Brand key → origins, replacing Origins for that brand
+	AdminOrigins []string
+	AdminPrefix  string // defaults to /admin
+	MaxAge       int    // preflight cache, seconds
+}
+
+// ParseOriginList splits a comma-separated origin list.
+func ParseOriginList(spec string) []string {
+	var origins []string
+	for _, o := range strings.Split(spec, ",") {
+		o = strings.TrimRight(strings.TrimSpace(o), "/")
+		if o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+// ParseBrandOrigins parses "brand=origin1,origin2;brand2=origin3".
+func ParseBrandOrigins(spec string) (map[string][]string, error) {
+	brands := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		brand, list, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(brand) == "" {
+			return nil, fmt.Errorf("invalid cors brand entry %q", entry)
+		}
+		brands[strings.ToLower(strings.TrimSpace(brand))] = ParseOriginList(list)
+	}
+	return brands, nil
+}
+
+// CORS returns CORS middleware for the given config.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	if cfg.AdminPrefix == "" {
+		cfg.AdminPrefix = "/admin"
+	}
+	// Preflights carry no X-Brand header, so any brand's origin may preflight;
+	// the brand is enforced on the actual request.
+	var anyBrand []string
+	for _, origins := range cfg.BrandOrigins {
+		anyBrand = append(anyBrand, origins...)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			admin := strings.HasPrefix(r.URL.Path, cfg.AdminPrefix)
+			preflight := r.Method == http.MethodOptions
+
+			var allowed []string
+			switch {
+			case admin:
+				allowed = cfg.AdminOrigins
+			case preflight:
+				allowed = append(append([]string{}, cfg.Origins...), anyBrand...)
+			default:
+				allowed = cfg.Origins
+				if brandOrigins, ok := cfg.BrandOrigins[BrandFromRequest(r)]; ok {
+					allowed = brandOrigins
+				}
+			}
+
+			w.Header().Add("Vary", "Origin")
+			allowOrigin := ""
+			switch {
+			case origin == "":
+				// Not a browser cross-origin call; advertise a fixed policy
+				// only when it is unambiguous.
+				if !admin && len(allowed) == 1 && !strings.Contains(allowed[0], "*.") {
+					allowOrigin = allowed[0]
+				}
+			case sameOrigin(r, origin):
+				allowOrigin = origin
+			case originAllowed(origin, allowed, !admin):
+				allowOrigin = origin
+				if !admin && len(allowed) == 1 && allowed[0] == "*" {
+					allowOrigin = "*"
+				}
+			case admin:
+				// Refuse rather than rely on the browser discarding the response.
+				RespondError(w, domain.ErrForbidden("cross-origin request not allowed"))
+				return
+			}
+
+			if allowOrigin != "" {
+				w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, X-Brand, Idempotency-Key")
+				w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Idempotent-Replayed")
+				if preflight && cfg.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+				}
+			}
+
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// originAllowed matches origin against exact and "*." wildcard entries.
+// A bare "*" only counts when allowAny is set.
+func originAllowed(origin string, allowed []string, allowAny bool) bool {
+	origin = strings.ToLower(origin)
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		switch {
+		case a == "*":
+			if allowAny {
+				return true
+			}
+		case strings.Contains(a, "://*."):
+			scheme, suffix, _ := strings.Cut(a, "://*.")
+			rest, ok := strings.CutPrefix(origin, scheme+"://")
+			if ok && strings.HasSuffix(rest, "."+suffix) {
+				return true
+			}
+		case a == origin:
+			return true
+		}
+	}
+	return false
+}
+
+// sameOrigin reports whether origin names the host the request was sent to.
+func sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
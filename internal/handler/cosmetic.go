@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// CosmeticHandler handles a player's cosmetic inventory.
+type CosmeticHandler struct {
+	svc *service.CosmeticService
+}
+
+// NewCosmeticHandler creates a new CosmeticHandler.
+func NewCosmeticHandler(svc *service.CosmeticService) *CosmeticHandler {
+	return &CosmeticHandler{svc: svc}
+}
+
+// Inventory handles GET /players/me/cosmetics.
+func (h *CosmeticHandler) Inventory(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	items, err := h.svc.Inventory(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, items)
+}
+
+// Equip handles POST /players/me/cosmetics/{code}/equip.
+func (h *CosmeticHandler) Equip(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	code := chi.URLParam(r, "code")
+	if err := h.svc.Equip(r.Context(), playerID, code); err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, map[string]string{"item_code": code, "status": "equipped"})
+}
+
+// Unequip handles POST /players/me/cosmetics/{code}/unequip.
+func (h *CosmeticHandler) Unequip(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	code := chi.URLParam(r, "code")
+	if err := h.svc.Unequip(r.Context(), playerID, code); err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, map[string]string{"item_code": code, "status": "unequipped"})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/service"
+)
+
+// CurrencyHandler serves a player's per-currency wallets and conversions.
+type CurrencyHandler struct {
+	walletSvc *service.WalletService
+}
+
+// NewCurrencyHandler creates a new CurrencyHandler.
+func NewCurrencyHandler(walletSvc *service.WalletService) *CurrencyHandler {
+	return &CurrencyHandler{walletSvc: walletSvc}
+}
+
+// ListWallets handles GET /wallet/wallets — base wallet first.
+func (h *CurrencyHandler) ListWallets(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	wallets, err := h.walletSvc.ListWallets(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, wallets)
+}
+
+// OpenWallet handles POST /wallet/wallets.
+func (h *CurrencyHandler) OpenWallet(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		Currency string `json:"currency"`
+	}
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	wallet, err := h.walletSvc.OpenWallet(r.Context(), playerID, input.Currency)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, wallet)
+}
+
+// Convert handles POST /wallet/convert.
+func (h *CurrencyHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.ConvertInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	result, err := h.walletSvc.Convert(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, result)
+}
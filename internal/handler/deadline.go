@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DeadlineBudgets are how long a request may run before its context is
+// cancelled. Routes maps a path prefix to its own budget; the longest
+// matching prefix wins and other paths get Default. Budgets must stay below
+// the server's write timeout, so a slow query or provider call ends in a
+// DEADLINE_EXCEEDED response rather than a connection cut without one.
+type DeadlineBudgets struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// For returns the budget of a request to path.
+func (b DeadlineBudgets) For(path string) time.Duration {
+	budget, matched := b.Default, ""
+	for prefix, d := range b.Routes {
+		if (path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/")) && len(prefix) > len(matched) {
+			budget, matched = d, prefix
+		}
+	}
+	return budget
+}
+
+// Longest returns the largest budget, the floor for the write timeout.
+func (b DeadlineBudgets) Longest() time.Duration {
+	longest := b.Default
+	for _, d := range b.Routes {
+		longest = max(longest, d)
+	}
+	return longest
+}
+
+// Deadline returns middleware that bounds each request's context by its
+// budget. Repositories and providers take the request context, so their
+// calls are cancelled once the budget is spent. A zero budget leaves the
+// request unbounded.
+func Deadline(budgets DeadlineBudgets) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			budget := budgets.For(r.URL.Path)
+			if budget <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), budget)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
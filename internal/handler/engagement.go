@@ -5,17 +5,19 @@ import (
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // EngagementHandler handles engagement tracking endpoints.
 type EngagementHandler struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
 }
 
 // NewEngagementHandler creates a new EngagementHandler.
-func NewEngagementHandler(pool *pgxpool.Pool) *EngagementHandler {
-	return &EngagementHandler{pool: pool}
+func NewEngagementHandler(pool *pgxpool.Pool, outbox repository.OutboxRepository) *EngagementHandler {
+	return &EngagementHandler{pool: pool, outbox: outbox}
 }
 
 type engagementResponse struct {
@@ -57,6 +59,7 @@ func (h *EngagementHandler) GetMyEngagement(w http.ResponseWriter, r *http.Reque
 }
 
 // RecordSignal handles POST /engagement/signal — records an engagement event.
+// The signal is also published, so engagement quests advance from it.
 func (h *EngagementHandler) RecordSignal(w http.ResponseWriter, r *http.Request) {
 	playerID, err := playerIDFromContext(r)
 	if err != nil {
@@ -110,7 +113,14 @@ func (h *EngagementHandler) RecordSignal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	_, err = h.pool.Exec(r.Context(), query, playerID, today, input.Value)
+	tx, err := h.pool.Begin(r.Context())
+	if err != nil {
+		RespondError(w, domain.ErrInternal("begin tx", err))
+		return
+	}
+	defer tx.Rollback(r.Context())
+
+	_, err = tx.Exec(r.Context(), query, playerID, today, input.Value)
 	if err != nil {
 		// Fallback: just update if the column naming doesn't match exactly
 		RespondError(w, domain.ErrInternal("record signal", err))
@@ -118,13 +128,21 @@ func (h *EngagementHandler) RecordSignal(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Recompute score: video*2 + social*3 + prediction*5
-	_, err = h.pool.Exec(r.Context(), `
+	_, err = tx.Exec(r.Context(), `
 		UPDATE player_engagement SET score = (video_minutes * 2 + social_interactions * 3 + prediction_actions * 5)
 		WHERE player_id = $1 AND date = $2`, playerID, today)
 	if err != nil {
 		RespondError(w, domain.ErrInternal("update score", err))
 		return
 	}
+	if err := h.outbox.Insert(r.Context(), tx, domain.NewEngagementSignalEvent(playerID, input.Type, input.Value)); err != nil {
+		RespondError(w, domain.ErrInternal("insert outbox event", err))
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		RespondError(w, domain.ErrInternal("commit tx", err))
+		return
+	}
 
 	RespondJSON(w, http.StatusOK, map[string]string{"status": "recorded"})
 }
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// FeatureHandler serves feature flag values, including experiment variants.
+type FeatureHandler struct {
+	experimentSvc *service.ExperimentService
+}
+
+// NewFeatureHandler creates a new FeatureHandler.
+func NewFeatureHandler(experimentSvc *service.ExperimentService) *FeatureHandler {
+	return &FeatureHandler{experimentSvc: experimentSvc}
+}
+
+// ListFeatures handles GET /features — every flag evaluated for the player.
+func (h *FeatureHandler) ListFeatures(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	flags, err := h.experimentSvc.EvaluateFlags(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, flags)
+}
+
+// RecordExposure handles POST /features/{key}/exposure — the client calls it
+// when the flag's experiment variant is actually shown.
+func (h *FeatureHandler) RecordExposure(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	eval, err := h.experimentSvc.ExposeFlag(r.Context(), playerID, chi.URLParam(r, "key"))
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, eval)
+}
@@ -0,0 +1,807 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/attaboy/platform/internal/auth"
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	graphqlMaxDepth    = 6
+	graphqlMaxCost     = 500
+	graphqlMaxQuery    = 8 << 10 // 8 KiB
+	graphqlLoaderWait  = 2 * time.Millisecond
+	graphqlLoaderBatch = 100
+)
+
+// graphqlSchema is the player graph. Money amounts are minor units and can
+// exceed 32 bits, so they use Long rather than Int.
+const graphqlSchema = `
+schema {
+	query: Query
+}
+
+scalar Long
+
+type Query {
+	me: Player
+	feed(limit: Int): [Post!]
+}
+
+type Player {
+	id: ID!
+	email: String
+	firstName: String
+	accountStatus: String
+	verified: Boolean
+	wallet: Wallet
+	transactions(limit: Int): [Transaction!]
+	bets(limit: Int): [Bet!]
+	quests: [Quest!]
+	predictionPositions(limit: Int): [PredictionPosition!]
+	posts(limit: Int): [Post!]
+}
+
+type Wallet {
+	balance: Long!
+	bonusBalance: Long!
+	reservedBalance: Long!
+	currency: String!
+}
+
+type Transaction {
+	id: ID!
+	type: String!
+	amount: Long!
+	balanceAfter: Long!
+	createdAt: String!
+}
+
+type Bet {
+	id: ID!
+	status: String!
+	stake: Long!
+	currency: String!
+	odds: Int!
+	potentialPayout: Long!
+	payout: Long!
+	selectionId: ID
+	placedAt: String!
+	settledAt: String
+	event: SportEvent
+}
+
+type SportEvent {
+	id: ID!
+	league: String!
+	homeTeam: String!
+	awayTeam: String!
+	startTime: String!
+	status: String!
+}
+
+type Quest {
+	id: ID!
+	name: String!
+	description: String!
+	type: String!
+	targetProgress: Int!
+	progress: Int!
+	status: String!
+	rewardAmount: Long!
+	rewardCurrency: String!
+}
+
+type PredictionPosition {
+	id: ID!
+	outcomeId: String!
+	stakeAmount: Long!
+	status: String!
+	placedAt: String!
+	market: PredictionMarket
+}
+
+type PredictionMarket {
+	id: ID!
+	title: String!
+	category: String!
+	status: String!
+	closeAt: String
+}
+
+type Post {
+	id: ID!
+	content: String!
+	type: String!
+	targetType: String
+	targetId: ID
+	createdAt: String!
+	author: Author
+}
+
+type Author {
+	id: ID!
+	displayName: String!
+}
+`
+
+// GraphQLHandler serves the player graph (wallet, bets, quests, predictions,
+// social) at /graphql so a screen can fetch everything in one round trip.
+type GraphQLHandler struct {
+	pool         *pgxpool.Pool
+	players      repository.PlayerRepository
+	profiles     repository.ProfileRepository
+	transactions repository.TransactionRepository
+	sportsbook   *service.SportsbookService
+	schema       *graphql.Schema
+	logger       *slog.Logger
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler and parses its schema.
+func NewGraphQLHandler(pool *pgxpool.Pool, players repository.PlayerRepository, profiles repository.ProfileRepository,
+	transactions repository.TransactionRepository, sportsbook *service.SportsbookService, logger *slog.Logger) *GraphQLHandler {
+	h := &GraphQLHandler{
+		pool:         pool,
+		players:      players,
+		profiles:     profiles,
+		transactions: transactions,
+		sportsbook:   sportsbook,
+		logger:       logger,
+	}
+	// MustParseSchema only fails on programmer error: the schema is static.
+	h.schema = graphql.MustParseSchema(graphqlSchema, &graphqlQuery{h: h},
+		graphql.UseFieldResolvers(),
+		graphql.MaxDepth(graphqlMaxDepth),
+		graphql.DisableIntrospection(),
+	)
+	return h
+}
+
+// graphqlRequest is a GraphQL-over-HTTP request body.
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Serve handles GET and POST /graphql. GET takes query, operationName and
+// variables (JSON) as URL parameters.
+func (h *GraphQLHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				RespondError(w, domain.ErrValidation("variables must be a JSON object"))
+				return
+			}
+		}
+	} else if err := DecodeJSON(r, &req); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	if req.Query == "" {
+		RespondError(w, domain.ErrValidation("query is required"))
+		return
+	}
+	if len(req.Query) > graphqlMaxQuery {
+		RespondError(w, domain.ErrValidation("query is too large"))
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphqlScopeKey{}, h.newScope())
+	RespondJSON(w, http.StatusOK, h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// graphqlScope is the per-request state shared by resolvers: loaders that
+// batch the lookups which would otherwise run once per list item, and the
+// remaining cost budget.
+type graphqlScope struct {
+	events  *dataloader.Loader[uuid.UUID, *graphqlEvent]
+	markets *dataloader.Loader[uuid.UUID, *graphqlMarket]
+	authors *dataloader.Loader[uuid.UUID, *graphqlAuthor]
+	cost    atomic.Int64
+}
+
+type graphqlScopeKey struct{}
+
+func scopeFromContext(ctx context.Context) *graphqlScope {
+	return ctx.Value(graphqlScopeKey{}).(*graphqlScope)
+}
+
+func (h *GraphQLHandler) newScope() *graphqlScope {
+	s := &graphqlScope{
+		events:  newGraphQLLoader(h.batchEvents),
+		markets: newGraphQLLoader(h.batchMarkets),
+		authors: newGraphQLLoader(h.batchAuthors),
+	}
+	s.cost.Store(graphqlMaxCost)
+	return s
+}
+
+// newGraphQLLoader adapts a map-returning batch lookup to a dataloader.
+// Keys missing from the map load as nil.
+func newGraphQLLoader[V any](fetch func(context.Context, []uuid.UUID) (map[uuid.UUID]V, error)) *dataloader.Loader[uuid.UUID, V] {
+	return dataloader.NewBatchedLoader(func(ctx context.Context, ids []uuid.UUID) []*dataloader.Result[V] {
+		found, err := fetch(ctx, ids)
+		out := make([]*dataloader.Result[V], len(ids))
+		for i, id := range ids {
+			out[i] = &dataloader.Result[V]{Data: found[id], Error: err}
+		}
+		return out
+	}, dataloader.WithWait[uuid.UUID, V](graphqlLoaderWait), dataloader.WithBatchCapacity[uuid.UUID, V](graphqlLoaderBatch))
+}
+
+// charge spends n from the request's cost budget. Root fields and loaded
+// objects cost 1 and lists cost their page size, charged before the fetch,
+// so aliasing a field many times cannot fan out into unbounded queries.
+func (s *graphqlScope) charge(n int) error {
+	if s.cost.Add(-int64(n)) < 0 {
+		return domain.ErrValidation(fmt.Sprintf("query exceeds the maximum cost of %d", graphqlMaxCost))
+	}
+	return nil
+}
+
+// resolverError surfaces client-facing errors and logs and masks internal
+// failures.
+func (h *GraphQLHandler) resolverError(err error) error {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) && appErr.Status < http.StatusInternalServerError {
+		return errors.New(appErr.Message)
+	}
+	h.logger.Error("graphql resolver failed", "error", err)
+	return errors.New("internal error")
+}
+
+func graphqlPlayerID(ctx context.Context) (uuid.UUID, error) {
+	id, err := uuid.Parse(auth.SubjectFromContext(ctx))
+	if err != nil {
+		return uuid.Nil, domain.ErrUnauthorized("invalid subject")
+	}
+	return id, nil
+}
+
+// graphqlLimitArgs is the argument set of paged list fields.
+type graphqlLimitArgs struct {
+	Limit *int32
+}
+
+// limit returns the requested page size clamped to 1..max.
+func (a graphqlLimitArgs) limit(def, max int) int {
+	if a.Limit == nil {
+		return def
+	}
+	n := int(*a.Limit)
+	if n < 1 {
+		return 1
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// graphqlLong is the Long scalar: a 64-bit integer serialized as a JSON number.
+type graphqlLong int64
+
+func (graphqlLong) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+func (l *graphqlLong) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*l = graphqlLong(v)
+	case int64:
+		*l = graphqlLong(v)
+	case float64:
+		*l = graphqlLong(v)
+	default:
+		return fmt.Errorf("wrong type for Long: %T", input)
+	}
+	return nil
+}
+
+// graphqlTime formats a timestamp the way encoding/json does.
+func graphqlTime(t time.Time) string { return t.Format(time.RFC3339Nano) }
+
+func graphqlTimePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := graphqlTime(*t)
+	return &s
+}
+
+func graphqlIDPtr(id *uuid.UUID) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	s := graphql.ID(id.String())
+	return &s
+}
+
+// graphqlLoad loads the object id references through l; a nil id loads nil.
+func graphqlLoad[V any](ctx context.Context, h *GraphQLHandler, l *dataloader.Loader[uuid.UUID, *V], id *uuid.UUID) (*V, error) {
+	if id == nil {
+		return nil, nil
+	}
+	if err := scopeFromContext(ctx).charge(1); err != nil {
+		return nil, h.resolverError(err)
+	}
+	v, err := l.Load(ctx, *id)()
+	if err != nil {
+		return nil, h.resolverError(err)
+	}
+	return v, nil
+}
+
+type graphqlQuery struct {
+	h *GraphQLHandler
+}
+
+func (q *graphqlQuery) Me(ctx context.Context) (*graphqlPlayer, error) {
+	me, err := q.h.resolveMe(ctx)
+	if err != nil {
+		return nil, q.h.resolverError(err)
+	}
+	return me, nil
+}
+
+func (q *graphqlQuery) Feed(ctx context.Context, args graphqlLimitArgs) (*[]*graphqlPost, error) {
+	posts, err := q.h.queryPosts(ctx, nil, args.limit(20, 50))
+	if err != nil {
+		return nil, q.h.resolverError(err)
+	}
+	return &posts, nil
+}
+
+type graphqlPlayer struct {
+	ID            graphql.ID
+	Email         *string
+	FirstName     *string
+	AccountStatus *string
+	Verified      *bool
+	Wallet        *graphqlWallet
+
+	h        *GraphQLHandler
+	playerID uuid.UUID
+}
+
+type graphqlWallet struct {
+	Balance         graphqlLong
+	BonusBalance    graphqlLong
+	ReservedBalance graphqlLong
+	Currency        string
+}
+
+func (p *graphqlPlayer) Transactions(ctx context.Context, args graphqlLimitArgs) (*[]*graphqlTransaction, error) {
+	txs, err := p.h.resolveTransactions(ctx, p.playerID, args.limit(20, 100))
+	if err != nil {
+		return nil, p.h.resolverError(err)
+	}
+	return &txs, nil
+}
+
+func (p *graphqlPlayer) Bets(ctx context.Context, args graphqlLimitArgs) (*[]*graphqlBet, error) {
+	bets, err := p.h.resolveBets(ctx, p.playerID, args.limit(20, 50))
+	if err != nil {
+		return nil, p.h.resolverError(err)
+	}
+	return &bets, nil
+}
+
+func (p *graphqlPlayer) Quests(ctx context.Context) (*[]*graphqlQuest, error) {
+	quests, err := p.h.resolveQuests(ctx, p.playerID)
+	if err != nil {
+		return nil, p.h.resolverError(err)
+	}
+	return &quests, nil
+}
+
+func (p *graphqlPlayer) PredictionPositions(ctx context.Context, args graphqlLimitArgs) (*[]*graphqlPosition, error) {
+	positions, err := p.h.resolvePositions(ctx, p.playerID, args.limit(20, 50))
+	if err != nil {
+		return nil, p.h.resolverError(err)
+	}
+	return &positions, nil
+}
+
+func (p *graphqlPlayer) Posts(ctx context.Context, args graphqlLimitArgs) (*[]*graphqlPost, error) {
+	posts, err := p.h.queryPosts(ctx, &p.playerID, args.limit(20, 50))
+	if err != nil {
+		return nil, p.h.resolverError(err)
+	}
+	return &posts, nil
+}
+
+type graphqlTransaction struct {
+	ID           graphql.ID
+	Type         string
+	Amount       graphqlLong
+	BalanceAfter graphqlLong
+	CreatedAt    string
+}
+
+type graphqlBet struct {
+	ID              graphql.ID
+	Status          string
+	Stake           graphqlLong
+	Currency        string
+	Odds            int32
+	PotentialPayout graphqlLong
+	Payout          graphqlLong
+	SelectionID     *graphql.ID
+	PlacedAt        string
+	SettledAt       *string
+
+	h       *GraphQLHandler
+	eventID *uuid.UUID
+}
+
+func (b *graphqlBet) Event(ctx context.Context) (*graphqlEvent, error) {
+	return graphqlLoad(ctx, b.h, scopeFromContext(ctx).events, b.eventID)
+}
+
+type graphqlEvent struct {
+	ID        graphql.ID
+	League    string
+	HomeTeam  string
+	AwayTeam  string
+	StartTime string
+	Status    string
+}
+
+type graphqlQuest struct {
+	ID             graphql.ID
+	Name           string
+	Description    string
+	Type           string
+	TargetProgress int32
+	Progress       int32
+	Status         string
+	RewardAmount   graphqlLong
+	RewardCurrency string
+}
+
+type graphqlPosition struct {
+	ID          graphql.ID
+	OutcomeID   string
+	StakeAmount graphqlLong
+	Status      string
+	PlacedAt    string
+
+	h        *GraphQLHandler
+	marketID uuid.UUID
+}
+
+func (p *graphqlPosition) Market(ctx context.Context) (*graphqlMarket, error) {
+	return graphqlLoad(ctx, p.h, scopeFromContext(ctx).markets, &p.marketID)
+}
+
+type graphqlMarket struct {
+	ID       graphql.ID
+	Title    string
+	Category string
+	Status   string
+	CloseAt  *string
+}
+
+type graphqlPost struct {
+	ID         graphql.ID
+	Content    string
+	Type       string
+	TargetType *string
+	TargetID   *graphql.ID
+	CreatedAt  string
+
+	h        *GraphQLHandler
+	authorID uuid.UUID
+}
+
+func (p *graphqlPost) Author(ctx context.Context) (*graphqlAuthor, error) {
+	return graphqlLoad(ctx, p.h, scopeFromContext(ctx).authors, &p.authorID)
+}
+
+type graphqlAuthor struct {
+	ID          graphql.ID
+	DisplayName string
+}
+
+func (h *GraphQLHandler) resolveMe(ctx context.Context) (*graphqlPlayer, error) {
+	if err := scopeFromContext(ctx).charge(1); err != nil {
+		return nil, err
+	}
+	playerID, err := graphqlPlayerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	player, err := h.players.FindByID(ctx, h.pool, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("find player", err)
+	}
+	if player == nil {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	me := &graphqlPlayer{
+		ID: graphql.ID(player.ID.String()),
+		Wallet: &graphqlWallet{
+			Balance:         graphqlLong(player.Balance),
+			BonusBalance:    graphqlLong(player.BonusBalance),
+			ReservedBalance: graphqlLong(player.ReservedBalance),
+			Currency:        player.Currency,
+		},
+		h:        h,
+		playerID: playerID,
+	}
+	profile, err := h.profiles.FindByPlayerID(ctx, h.pool, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("find profile", err)
+	}
+	if profile != nil {
+		me.Email = &profile.Email
+		me.FirstName = &profile.FirstName
+		me.AccountStatus = &profile.AccountStatus
+		me.Verified = &profile.Verified
+	}
+	return me, nil
+}
+
+func (h *GraphQLHandler) resolveTransactions(ctx context.Context, playerID uuid.UUID, limit int) ([]*graphqlTransaction, error) {
+	if err := scopeFromContext(ctx).charge(limit); err != nil {
+		return nil, err
+	}
+	txs, err := h.transactions.ListByPlayer(ctx, h.pool, playerID, nil, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list transactions", err)
+	}
+	out := make([]*graphqlTransaction, 0, len(txs))
+	for _, tx := range txs {
+		out = append(out, &graphqlTransaction{
+			ID:           graphql.ID(tx.ID.String()),
+			Type:         string(tx.Type),
+			Amount:       graphqlLong(tx.Amount),
+			BalanceAfter: graphqlLong(tx.BalanceAfter),
+			CreatedAt:    graphqlTime(tx.CreatedAt),
+		})
+	}
+	return out, nil
+}
+
+func (h *GraphQLHandler) resolveBets(ctx context.Context, playerID uuid.UUID, limit int) ([]*graphqlBet, error) {
+	if err := scopeFromContext(ctx).charge(limit); err != nil {
+		return nil, err
+	}
+	page, err := h.sportsbook.ListPlayerBets(ctx, playerID, service.BetHistoryFilter{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*graphqlBet, 0, len(page.Bets))
+	for _, b := range page.Bets {
+		out = append(out, &graphqlBet{
+			ID:              graphql.ID(b.ID.String()),
+			Status:          string(b.Status),
+			Stake:           graphqlLong(b.StakeAmountMinor),
+			Currency:        b.Currency,
+			Odds:            int32(b.OddsAtPlacement),
+			PotentialPayout: graphqlLong(b.PotentialPayoutMinor),
+			Payout:          graphqlLong(b.PayoutAmountMinor),
+			SelectionID:     graphqlIDPtr(b.SelectionID),
+			PlacedAt:        graphqlTime(b.PlacedAt),
+			SettledAt:       graphqlTimePtr(b.SettledAt),
+			h:               h,
+			eventID:         b.EventID,
+		})
+	}
+	return out, nil
+}
+
+// resolveQuests lists every active quest. The list is not paged, so it is
+// charged by its length once fetched.
+func (h *GraphQLHandler) resolveQuests(ctx context.Context, playerID uuid.UUID) ([]*graphqlQuest, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT q.id, q.name, COALESCE(q.description, ''), q.type, q.target_progress,
+		       q.reward_amount, q.reward_currency,
+		       COALESCE(pqp.progress, 0), COALESCE(pqp.status, 'not_started')
+		FROM quests q
+		LEFT JOIN player_quest_progress pqp ON pqp.quest_id = q.id AND pqp.player_id = $1
+		WHERE q.active = true
+		ORDER BY q.sort_order ASC`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("query quests", err)
+	}
+	defer rows.Close()
+
+	out := []*graphqlQuest{}
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			q        graphqlQuest
+			reward   int64
+			target   int32
+			progress int32
+		)
+		if err := rows.Scan(&id, &q.Name, &q.Description, &q.Type, &target, &reward, &q.RewardCurrency, &progress, &q.Status); err != nil {
+			return nil, domain.ErrInternal("scan quest", err)
+		}
+		q.ID = graphql.ID(id.String())
+		q.TargetProgress = target
+		q.Progress = progress
+		q.RewardAmount = graphqlLong(reward)
+		out = append(out, &q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("list quests", err)
+	}
+	if err := scopeFromContext(ctx).charge(len(out)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (h *GraphQLHandler) resolvePositions(ctx context.Context, playerID uuid.UUID, limit int) ([]*graphqlPosition, error) {
+	if err := scopeFromContext(ctx).charge(limit); err != nil {
+		return nil, err
+	}
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, market_id, outcome_id, stake_amount_minor, status, placed_at
+		FROM prediction_stakes
+		WHERE player_id = $1
+		ORDER BY placed_at DESC LIMIT $2`, playerID, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list positions", err)
+	}
+	defer rows.Close()
+
+	out := []*graphqlPosition{}
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			amount   int64
+			placedAt time.Time
+		)
+		p := &graphqlPosition{h: h}
+		if err := rows.Scan(&id, &p.marketID, &p.OutcomeID, &amount, &p.Status, &placedAt); err != nil {
+			return nil, domain.ErrInternal("scan position", err)
+		}
+		p.ID = graphql.ID(id.String())
+		p.StakeAmount = graphqlLong(amount)
+		p.PlacedAt = graphqlTime(placedAt)
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// queryPosts lists social posts newest first, optionally for one player.
+func (h *GraphQLHandler) queryPosts(ctx context.Context, playerID *uuid.UUID, limit int) ([]*graphqlPost, error) {
+	if err := scopeFromContext(ctx).charge(limit); err != nil {
+		return nil, err
+	}
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, player_id, content, type, target_type, target_id, created_at
+		FROM social_posts
+		WHERE $1::uuid IS NULL OR player_id = $1
+		ORDER BY created_at DESC LIMIT $2`, playerID, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list social posts", err)
+	}
+	defer rows.Close()
+
+	out := []*graphqlPost{}
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			targetID  *uuid.UUID
+			createdAt time.Time
+		)
+		p := &graphqlPost{h: h}
+		if err := rows.Scan(&id, &p.authorID, &p.Content, &p.Type, &p.TargetType, &targetID, &createdAt); err != nil {
+			return nil, domain.ErrInternal("scan social post", err)
+		}
+		p.ID = graphql.ID(id.String())
+		p.TargetID = graphqlIDPtr(targetID)
+		p.CreatedAt = graphqlTime(createdAt)
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (h *GraphQLHandler) batchEvents(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*graphqlEvent, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, COALESCE(league, ''), home_team, away_team, start_time, status
+		FROM sports_events WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("load events", err)
+	}
+	defer rows.Close()
+
+	out := make(map[uuid.UUID]*graphqlEvent, len(ids))
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			e         graphqlEvent
+			startTime time.Time
+		)
+		if err := rows.Scan(&id, &e.League, &e.HomeTeam, &e.AwayTeam, &startTime, &e.Status); err != nil {
+			return nil, domain.ErrInternal("scan event", err)
+		}
+		e.ID = graphql.ID(id.String())
+		e.StartTime = graphqlTime(startTime)
+		out[id] = &e
+	}
+	return out, rows.Err()
+}
+
+func (h *GraphQLHandler) batchMarkets(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*graphqlMarket, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, title, category, status, close_at
+		FROM prediction_markets WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("load prediction markets", err)
+	}
+	defer rows.Close()
+
+	out := make(map[uuid.UUID]*graphqlMarket, len(ids))
+	for rows.Next() {
+		var (
+			id      uuid.UUID
+			m       graphqlMarket
+			closeAt *time.Time
+		)
+		if err := rows.Scan(&id, &m.Title, &m.Category, &m.Status, &closeAt); err != nil {
+			return nil, domain.ErrInternal("scan prediction market", err)
+		}
+		m.ID = graphql.ID(id.String())
+		m.CloseAt = graphqlTimePtr(closeAt)
+		out[id] = &m
+	}
+	return out, rows.Err()
+}
+
+// batchAuthors resolves post authors to a public display name (first name
+// only; players without one show as "Player").
+func (h *GraphQLHandler) batchAuthors(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*graphqlAuthor, error) {
+	out := make(map[uuid.UUID]*graphqlAuthor, len(ids))
+	for _, id := range ids {
+		out[id] = &graphqlAuthor{ID: graphql.ID(id.String()), DisplayName: "Player"}
+	}
+
+	rows, err := h.pool.Query(ctx, `
+		SELECT player_id, COALESCE(first_name, '')
+		FROM player_profiles WHERE player_id = ANY($1)`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("load authors", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			firstName string
+		)
+		if err := rows.Scan(&id, &firstName); err != nil {
+			return nil, domain.ErrInternal("scan author", err)
+		}
+		if firstName != "" {
+			out[id].DisplayName = firstName
+		}
+	}
+	return out, rows.Err()
+}
@@ -2,16 +2,22 @@ package handler
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/andybalholm/brotli"
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/infra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -84,6 +90,16 @@ func TestRespondError(t *testing.T) {
 		assert.Equal(t, "INTERNAL_ERROR", body["code"])
 		assert.Equal(t, "internal server error", body["message"])
 	})
+
+	t.Run("deadline exceeded returns 504", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		RespondError(w, domain.ErrInternal("get player", fmt.Errorf("query: %w", context.DeadlineExceeded)))
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, "DEADLINE_EXCEEDED", body["code"])
+	})
 }
 
 // --- DecodeJSON Tests ---
@@ -192,6 +208,61 @@ func TestGetRequestID_EmptyContext(t *testing.T) {
 	assert.Empty(t, id)
 }
 
+// --- Deadline Middleware Tests ---
+
+func TestDeadlineBudgets_For(t *testing.T) {
+	budgets := DeadlineBudgets{
+		Default: 10 * time.Second,
+		Routes: map[string]time.Duration{
+			"/admin/reports":             30 * time.Second,
+			"/admin/reports/liabilities": time.Minute,
+			"/wallet":                    5 * time.Second,
+		},
+	}
+
+	assert.Equal(t, 10*time.Second, budgets.For("/sportsbook/events"))
+	assert.Equal(t, 30*time.Second, budgets.For("/admin/reports"))
+	assert.Equal(t, 30*time.Second, budgets.For("/admin/reports/kpis"))
+	assert.Equal(t, time.Minute, budgets.For("/admin/reports/liabilities"))
+	assert.Equal(t, 5*time.Second, budgets.For("/wallet/deposit"))
+	assert.Equal(t, 10*time.Second, budgets.For("/wallets"), "prefix matches whole segments")
+	assert.Equal(t, time.Minute, budgets.Longest())
+}
+
+func TestDeadline(t *testing.T) {
+	budgets := DeadlineBudgets{Default: time.Second, Routes: map[string]time.Duration{"/unbounded": 0}}
+	var deadline time.Time
+	var bounded bool
+	h := Deadline(budgets)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, bounded = r.Context().Deadline()
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wallet/balance", nil))
+	require.True(t, bounded)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unbounded", nil))
+	assert.False(t, bounded)
+}
+
+func TestWorkerHealthHandler(t *testing.T) {
+	health := infra.NewWorkerHealth()
+	health.Register("outbox", time.Hour)
+	health.Failure("outbox", fmt.Errorf("connection refused"))
+
+	rec := httptest.NewRecorder()
+	WorkerHealthHandler(health)(rec, httptest.NewRequest(http.MethodGet, "/health/workers", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, "a failing worker is retrying, not stuck")
+	assert.Contains(t, rec.Body.String(), `"status":"failing"`)
+
+	health.Register("dome", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	rec = httptest.NewRecorder()
+	WorkerHealthHandler(health)(rec, httptest.NewRequest(http.MethodGet, "/health/workers", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"stuck"`)
+}
+
 // --- JSONContentType Middleware Tests ---
 
 func TestJSONContentType(t *testing.T) {
@@ -222,7 +293,9 @@ func TestCORSWithOrigins(t *testing.T) {
 		assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "GET")
 		assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
 		assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key")
 		assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "X-Request-ID")
+		assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "Idempotent-Replayed")
 	})
 
 	t.Run("OPTIONS returns 204", func(t *testing.T) {
@@ -251,6 +324,206 @@ func TestCORSWithOrigins(t *testing.T) {
 	})
 }
 
+func TestCORS_Policy(t *testing.T) {
+	cfg := CORSConfig{
+		Origins:      []string{"*"},
+		BrandOrigins: map[string][]string{"luckyco": {"https://*.luckyco.com"}},
+		AdminOrigins: []string{"https://backoffice.attaboy.io"},
+		MaxAge:       600,
+	}
+	h := CORS(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(method, path, origin, brand string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		if brand != "" {
+			r.Header.Set("X-Brand", brand)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("player routes open", func(t *testing.T) {
+		w := do(http.MethodGet, "/wallet/balance", "https://anything.example", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("brand restricts origins", func(t *testing.T) {
+		w := do(http.MethodGet, "/wallet/balance", "https://www.luckyco.com", "luckyco")
+		assert.Equal(t, "https://www.luckyco.com", w.Header().Get("Access-Control-Allow-Origin"))
+
+		w = do(http.MethodGet, "/wallet/balance", "https://evil.example", "luckyco")
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight caching", func(t *testing.T) {
+		w := do(http.MethodOptions, "/wallet/balance", "https://www.luckyco.com", "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("admin blocks other origins despite wildcard", func(t *testing.T) {
+		w := do(http.MethodOptions, "/admin/players", "https://anything.example", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+		w = do(http.MethodGet, "/admin/players", "https://anything.example", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("admin allows listed and same origin", func(t *testing.T) {
+		w := do(http.MethodGet, "/admin/players", "https://backoffice.attaboy.io", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://backoffice.attaboy.io", w.Header().Get("Access-Control-Allow-Origin"))
+
+		w = do(http.MethodGet, "/admin/players", "http://example.com", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = do(http.MethodGet, "/admin/players", "", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestParseBrandOrigins(t *testing.T) {
+	brands, err := ParseBrandOrigins("Attaboy=https://attaboy.io, https://*.attaboy.io/; luckyco=https://luckyco.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://attaboy.io", "https://*.attaboy.io"}, brands["attaboy"])
+	assert.Equal(t, []string{"https://luckyco.com"}, brands["luckyco"])
+
+	_, err = ParseBrandOrigins("attaboy")
+	assert.Error(t, err)
+}
+
+// --- ETag Middleware Tests ---
+
+func TestETag(t *testing.T) {
+	body := `[{"id":"soccer"}]`
+	h := ETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		RespondJSON(w, http.StatusOK, json.RawMessage(body))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sportsbook/sports", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	tag := w.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+	assert.True(t, strings.HasPrefix(tag, `W/"`), "tag is weak, as Compress may encode the body")
+	assert.JSONEq(t, body, w.Body.String())
+
+	t.Run("matching If-None-Match returns 304", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/sportsbook/sports", nil)
+		r.Header.Set("If-None-Match", `"other", `+tag)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, tag, w.Header().Get("ETag"))
+	})
+
+	t.Run("weak comparison ignores the W/ prefix", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/sportsbook/sports", nil)
+		r.Header.Set("If-None-Match", strings.TrimPrefix(tag, "W/"))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("stale tag returns body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/sportsbook/sports", nil)
+		r.Header.Set("If-None-Match", `"stale"`)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, body, w.Body.String())
+	})
+
+	t.Run("errors are not tagged", func(t *testing.T) {
+		h := ETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			RespondError(w, domain.ErrNotFound("market", "x"))
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/predictions/markets/x", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+}
+
+// --- Compression & Streaming Tests ---
+
+func TestCompress_GzipJSON(t *testing.T) {
+	payload := strings.Repeat(`{"type":"bet","amount":100},`, 200)
+	h := Compress()(JSONContentType(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/admin/reports/transactions/export", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	out, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(out))
+}
+
+func TestCompress_BrotliPreferred(t *testing.T) {
+	payload := strings.Repeat(`{"type":"bet","amount":100},`, 200)
+	h := Compress()(JSONContentType(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/admin/reports/transactions/export", nil)
+	r.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	out, err := io.ReadAll(brotli.NewReader(w.Body))
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(out))
+}
+
+func TestJSONArrayStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	stream := NewJSONArrayStream(w)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, stream.Write(map[string]int{"n": i}))
+	}
+	require.NoError(t, stream.Close())
+
+	var out []map[string]int
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &out))
+	assert.Len(t, out, 3)
+	assert.Equal(t, 2, out[2]["n"])
+}
+
+func TestJSONArrayStream_Empty(t *testing.T) {
+	w := httptest.NewRecorder()
+	require.NoError(t, NewJSONArrayStream(w).Close())
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestCSVStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	stream, err := NewCSVStream(w, "tx.csv", []string{"id", "amount"})
+	require.NoError(t, err)
+	require.NoError(t, stream.Write([]string{"a", "100"}))
+	require.NoError(t, stream.Close())
+
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "tx.csv")
+	assert.Equal(t, "id,amount\na,100\n", w.Body.String())
+}
+
 // --- Recovery Middleware Tests ---
 
 func TestRecovery(t *testing.T) {
@@ -303,3 +576,162 @@ func TestResponseWriter_CapturesStatus(t *testing.T) {
 func noopLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
 }
+
+func TestRespondPDF(t *testing.T) {
+	w := httptest.NewRecorder()
+	RespondPDF(w, "receipt.pdf", "Bet Receipt", []string{"Stake: 10.00 EUR", "Selection: Team (A) \\ B", "Café"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="receipt.pdf"`, w.Header().Get("Content-Disposition"))
+
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(body, "%%EOF\n"))
+	assert.Contains(t, body, "(Bet Receipt) Tj")
+	assert.Contains(t, body, `(Selection: Team \(A\) \\ B) Tj`)
+	assert.Contains(t, body, "(Caf?) Tj")
+
+	// The xref table points at each object.
+	var startxref int
+	_, err := fmt.Sscanf(body[strings.LastIndex(body, "startxref\n"):], "startxref\n%d", &startxref)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(body[startxref:], "xref\n0 7\n"))
+	for i := 1; i <= 6; i++ {
+		assert.Contains(t, body, fmt.Sprintf("%d 0 obj", i))
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	t.Run("no key passes through", func(t *testing.T) {
+		called := false
+		h := Idempotent(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/payments/withdraw", strings.NewReader(`{}`)))
+
+		assert.True(t, called)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("overlong key is rejected", func(t *testing.T) {
+		h := Idempotent(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler should not run")
+		}))
+
+		r := httptest.NewRequest(http.MethodPost, "/payments/withdraw", strings.NewReader(`{}`))
+		r.Header.Set(IdempotencyKeyHeader, strings.Repeat("k", 256))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("request hash covers route and body", func(t *testing.T) {
+		base := requestHash("POST /payments/withdraw", []byte(`{"amount":100}`))
+		assert.Len(t, base, 64)
+		assert.Equal(t, base, requestHash("POST /payments/withdraw", []byte(`{"amount":100}`)))
+		assert.NotEqual(t, base, requestHash("POST /payments/deposit", []byte(`{"amount":100}`)))
+		assert.NotEqual(t, base, requestHash("POST /payments/withdraw", []byte(`{"amount":200}`)))
+	})
+}
+
+// --- Prediction Market List Tests ---
+
+func TestMarketListQuery(t *testing.T) {
+	t.Run("defaults to newest listed markets", func(t *testing.T) {
+		query, args, err := marketListQuery(url.Values{})
+		require.NoError(t, err)
+		assert.Contains(t, query, "WHERE status IN ('open', 'closed')\n")
+		assert.Contains(t, query, "ORDER BY created_at DESC, id")
+		assert.Equal(t, []any{50, 0}, args)
+	})
+
+	t.Run("filters become numbered conditions", func(t *testing.T) {
+		query, args, err := marketListQuery(url.Values{
+			"category": {"politics"}, "tag": {"elections"}, "q": {" senate race "},
+			"close_before": {"2026-11-04T00:00:00Z"}, "sort": {"volume"}, "limit": {"20"}, "offset": {"40"},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, query, "category = $1")
+		assert.Contains(t, query, "tags @> jsonb_build_array($2::text)")
+		assert.Contains(t, query, "search @@ websearch_to_tsquery('simple', $3)")
+		assert.Contains(t, query, "close_at < $4")
+		assert.Contains(t, query, "ORDER BY volume_minor DESC, id")
+		assert.Contains(t, query, "LIMIT $5 OFFSET $6")
+		require.Len(t, args, 6)
+		assert.Equal(t, "senate race", args[2])
+		assert.Equal(t, []any{20, 40}, args[4:])
+	})
+
+	t.Run("closing soon leaves out closed markets", func(t *testing.T) {
+		query, _, err := marketListQuery(url.Values{"sort": {"closing"}})
+		require.NoError(t, err)
+		assert.Contains(t, query, "close_at > now()")
+		assert.Contains(t, query, "ORDER BY close_at ASC NULLS LAST, id")
+	})
+
+	t.Run("rejects bad parameters", func(t *testing.T) {
+		for _, params := range []url.Values{
+			{"sort": {"popular"}},
+			{"limit": {"0"}},
+			{"limit": {"1000"}},
+			{"offset": {"-1"}},
+			{"close_after": {"tomorrow"}},
+		} {
+			_, _, err := marketListQuery(params)
+			assert.Error(t, err, params.Encode())
+		}
+	})
+}
+
+// --- GraphQL Tests ---
+
+func TestGraphQL_RejectsInvalidQueries(t *testing.T) {
+	h := NewGraphQLHandler(nil, nil, nil, nil, nil, slog.Default())
+
+	serve := func(query string) map[string]interface{} {
+		body, _ := json.Marshal(map[string]string{"query": query})
+		w := httptest.NewRecorder()
+		h.Serve(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	for query, want := range map[string]string{
+		`{ me { password } }`:                   `"password"`,
+		`{ me { wallet } }`:                     `must have a selection`,
+		`{ feed(limit: "ten") { id } }`:         `cannot represent`,
+		`mutation { me { id } }`:                `mutation`,
+		`{ ...F } fragment F on Query { ...F }`: `within itself`,
+	} {
+		resp := serve(query)
+		assert.NotContains(t, resp, "data", query)
+		require.NotEmpty(t, resp["errors"], query)
+		msg := resp["errors"].([]interface{})[0].(map[string]interface{})["message"]
+		assert.Contains(t, msg, want, query)
+	}
+}
+
+func TestGraphQL_QueryTooLarge(t *testing.T) {
+	h := NewGraphQLHandler(nil, nil, nil, nil, nil, slog.Default())
+	body, _ := json.Marshal(map[string]string{"query": "{ me { id " + strings.Repeat(" ", graphqlMaxQuery) + "} }"})
+	w := httptest.NewRecorder()
+	h.Serve(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGraphQL_CostBudget(t *testing.T) {
+	s := NewGraphQLHandler(nil, nil, nil, nil, nil, slog.Default()).newScope()
+	require.NoError(t, s.charge(graphqlMaxCost-1))
+	require.NoError(t, s.charge(1))
+
+	err := s.charge(1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum cost")
+}
@@ -25,3 +25,22 @@ func HealthHandler(pool *pgxpool.Pool) http.HandlerFunc {
 		})
 	}
 }
+
+// WorkerHealthHandler returns a liveness endpoint for the background
+// workers in health. It fails only while a worker is stuck; workers that are
+// failing and backing off are reported but keep the process live, since a
+// restart would not bring the database or a provider back any sooner.
+func WorkerHealthHandler(health *infra.WorkerHealth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		workers, live := health.Status()
+		status := "healthy"
+		if !live {
+			status = "stuck"
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":  status,
+			"workers": workers,
+		})
+	}
+}
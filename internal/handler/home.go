@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// homeSectionTimeout bounds each section so one slow query cannot hold up the screen.
+const homeSectionTimeout = 2 * time.Second
+
+// HomeHandler serves the aggregated mobile home screen.
+type HomeHandler struct {
+	pool    *pgxpool.Pool
+	players repository.PlayerRepository
+	logger  *slog.Logger
+}
+
+// NewHomeHandler creates a new HomeHandler.
+func NewHomeHandler(pool *pgxpool.Pool, players repository.PlayerRepository, logger *slog.Logger) *HomeHandler {
+	return &HomeHandler{pool: pool, players: players, logger: logger}
+}
+
+type homeBonus struct {
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	InitialAmount       int64      `json:"initial_amount"`
+	WageringRequirement int64      `json:"wagering_requirement"`
+	Wagered             int64      `json:"wagered"`
+	ExpiresAt           *time.Time `json:"expires_at,omitempty"`
+}
+
+type homeQuest struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	TargetProgress int       `json:"target_progress"`
+	Progress       int       `json:"progress"`
+	RewardAmount   int       `json:"reward_amount"`
+	RewardCurrency string    `json:"reward_currency"`
+}
+
+type homeEvent struct {
+	ID        uuid.UUID `json:"id"`
+	League    string    `json:"league,omitempty"`
+	HomeTeam  string    `json:"home_team"`
+	AwayTeam  string    `json:"away_team"`
+	StartTime time.Time `json:"start_time"`
+	Status    string    `json:"status"`
+}
+
+type homeMarket struct {
+	ID           uuid.UUID  `json:"id"`
+	Title        string     `json:"title"`
+	Category     string     `json:"category"`
+	CloseAt      *time.Time `json:"close_at,omitempty"`
+	RecentStakes int        `json:"recent_stakes"`
+}
+
+type homeWin struct {
+	Kind      string    `json:"kind"`
+	Stake     int64     `json:"stake"`
+	Returned  int64     `json:"returned"`
+	SettledAt time.Time `json:"settled_at"`
+}
+
+// homeResponse is the shape of GET /home. A section that failed to load is
+// null and named in Unavailable; the rest of the payload is still returned.
+type homeResponse struct {
+	Balance             *balanceResponse `json:"balance"`
+	ActiveBonuses       []homeBonus      `json:"active_bonuses"`
+	TopQuests           []homeQuest      `json:"top_quests"`
+	FeaturedEvents      []homeEvent      `json:"featured_events"`
+	TrendingMarkets     []homeMarket     `json:"trending_markets"`
+	RecentWins          []homeWin        `json:"recent_wins"`
+	UnreadNotifications *int             `json:"unread_notifications"`
+	Unavailable         []string         `json:"unavailable,omitempty"`
+}
+
+// GetHome handles GET /home — loads every section concurrently.
+func (h *HomeHandler) GetHome(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var resp homeResponse
+	sections := []struct {
+		name string
+		load func(ctx context.Context) error
+	}{
+		{"balance", func(ctx context.Context) (err error) {
+			resp.Balance, err = h.loadBalance(ctx, playerID)
+			return err
+		}},
+		{"active_bonuses", func(ctx context.Context) (err error) {
+			resp.ActiveBonuses, err = h.loadBonuses(ctx, playerID)
+			return err
+		}},
+		{"top_quests", func(ctx context.Context) (err error) {
+			resp.TopQuests, err = h.loadQuests(ctx, playerID)
+			return err
+		}},
+		{"featured_events", func(ctx context.Context) (err error) {
+			resp.FeaturedEvents, err = h.loadEvents(ctx)
+			return err
+		}},
+		{"trending_markets", func(ctx context.Context) (err error) {
+			resp.TrendingMarkets, err = h.loadMarkets(ctx)
+			return err
+		}},
+		{"recent_wins", func(ctx context.Context) (err error) {
+			resp.RecentWins, err = h.loadRecentWins(ctx)
+			return err
+		}},
+		{"unread_notifications", func(ctx context.Context) error {
+			var n int
+			if err := h.pool.QueryRow(ctx, `
+				SELECT COUNT(*) FROM player_notifications WHERE player_id = $1 AND NOT read`,
+				playerID).Scan(&n); err != nil {
+				return err
+			}
+			resp.UnreadNotifications = &n
+			return nil
+		}},
+	}
+
+	failed := make([]bool, len(sections))
+	var wg sync.WaitGroup
+	for i, s := range sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if rec := recover(); rec != nil {
+					h.logger.Error("home section panicked", "section", s.name, "panic", rec)
+					failed[i] = true
+				}
+			}()
+			ctx, cancel := context.WithTimeout(r.Context(), homeSectionTimeout)
+			defer cancel()
+			if err := s.load(ctx); err != nil {
+				h.logger.Warn("home section failed", "section", s.name, "player_id", playerID, "error", err)
+				failed[i] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, s := range sections {
+		if failed[i] {
+			resp.Unavailable = append(resp.Unavailable, s.name)
+		}
+	}
+	RespondJSON(w, http.StatusOK, resp)
+}
+
+func (h *HomeHandler) loadBalance(ctx context.Context, playerID uuid.UUID) (*balanceResponse, error) {
+	player, err := h.players.FindByID(ctx, h.pool, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	return &balanceResponse{
+		Balance:         player.Balance,
+		BonusBalance:    player.BonusBalance,
+		ReservedBalance: player.ReservedBalance,
+		Currency:        player.Currency,
+	}, nil
+}
+
+func (h *HomeHandler) loadBonuses(ctx context.Context, playerID uuid.UUID) ([]homeBonus, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT pb.id, COALESCE(b.name, ''), COALESCE(pb.initial_amount, 0)::bigint,
+		       COALESCE(pb.wagering_requirement, 0)::bigint, COALESCE(pb.wagered, 0)::bigint, pb.expires_at
+		FROM player_bonuses pb
+		LEFT JOIN bonuses b ON b.id = pb.bonus_id
+		WHERE pb.player_id = $1 AND pb.status = 'active'
+		ORDER BY pb.expires_at ASC NULLS LAST`, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bonuses := []homeBonus{}
+	for rows.Next() {
+		var b homeBonus
+		if err := rows.Scan(&b.ID, &b.Name, &b.InitialAmount, &b.WageringRequirement, &b.Wagered, &b.ExpiresAt); err != nil {
+			return nil, err
+		}
+		bonuses = append(bonuses, b)
+	}
+	return bonuses, rows.Err()
+}
+
+// loadQuests returns the first unfinished quests, furthest progress first.
+func (h *HomeHandler) loadQuests(ctx context.Context, playerID uuid.UUID) ([]homeQuest, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT q.id, q.name, q.target_progress, COALESCE(pqp.progress, 0), q.reward_amount, q.reward_currency
+		FROM quests q
+		LEFT JOIN player_quest_progress pqp ON pqp.quest_id = q.id AND pqp.player_id = $1
+		WHERE q.active = true AND COALESCE(pqp.status, 'not_started') NOT IN ('completed', 'claimed')
+		ORDER BY COALESCE(pqp.progress, 0)::float / GREATEST(q.target_progress, 1) DESC, q.sort_order ASC
+		LIMIT 3`, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quests := []homeQuest{}
+	for rows.Next() {
+		var q homeQuest
+		if err := rows.Scan(&q.ID, &q.Name, &q.TargetProgress, &q.Progress, &q.RewardAmount, &q.RewardCurrency); err != nil {
+			return nil, err
+		}
+		quests = append(quests, q)
+	}
+	return quests, rows.Err()
+}
+
+// loadEvents returns live events, then the next upcoming ones.
+func (h *HomeHandler) loadEvents(ctx context.Context) ([]homeEvent, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, COALESCE(league, ''), home_team, away_team, start_time, status
+		FROM sports_events
+		WHERE status IN ('live', 'upcoming') AND start_time > now() - interval '3 hours'
+		ORDER BY status = 'live' DESC, start_time ASC
+		LIMIT 5`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []homeEvent{}
+	for rows.Next() {
+		var e homeEvent
+		if err := rows.Scan(&e.ID, &e.League, &e.HomeTeam, &e.AwayTeam, &e.StartTime, &e.Status); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// loadMarkets ranks open prediction markets by stakes placed in the last 24h.
+func (h *HomeHandler) loadMarkets(ctx context.Context) ([]homeMarket, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT pm.id, pm.title, pm.category, pm.close_at, COUNT(ps.id)
+		FROM prediction_markets pm
+		LEFT JOIN prediction_stakes ps ON ps.market_id = pm.id AND ps.placed_at > now() - interval '24 hours'
+		WHERE pm.status = 'open'
+		GROUP BY pm.id
+		ORDER BY COUNT(ps.id) DESC, pm.created_at DESC
+		LIMIT 5`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	markets := []homeMarket{}
+	for rows.Next() {
+		var m homeMarket
+		if err := rows.Scan(&m.ID, &m.Title, &m.Category, &m.CloseAt, &m.RecentStakes); err != nil {
+			return nil, err
+		}
+		markets = append(markets, m)
+	}
+	return markets, rows.Err()
+}
+
+// loadRecentWins returns the latest settled bets and stakes that paid more
+// than their stake, from the bet_feed projection. Players are not named.
+func (h *HomeHandler) loadRecentWins(ctx context.Context) ([]homeWin, error) {
+	rows, err := h.pool.Query(ctx, `
+		SELECT kind, stake, returned, settled_at
+		FROM bet_feed
+		WHERE returned > stake
+		ORDER BY settled_at DESC
+		LIMIT 5`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	wins := []homeWin{}
+	for rows.Next() {
+		var w homeWin
+		if err := rows.Scan(&w.Kind, &w.Stake, &w.Returned, &w.SettledAt); err != nil {
+			return nil, err
+		}
+		wins = append(wins, w)
+	}
+	return wins, rows.Err()
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/repository"
+)
+
+const (
+	// IdempotencyKeyHeader names the client-chosen key for a retried request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed from the key store.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen  = 255
+	maxIdempotentBodySize = 1 << 20
+)
+
+// Idempotent honours an Idempotency-Key header on a player route. The first
+// request with a key runs and its response is stored; a retry with the same
+// key and body gets that response back with Idempotent-Replayed: true instead
+// of running again. Server errors are not stored, so the client may retry
+// them. Requests without the header pass straight through.
+func Idempotent(db repository.DBTX) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLen {
+				RespondError(w, domain.ErrValidation("Idempotency-Key must be at most 255 characters"))
+				return
+			}
+
+			playerID, err := playerIDFromContext(r)
+			if err != nil {
+				RespondError(w, err)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize))
+			if err != nil {
+				RespondError(w, domain.ErrValidation("could not read request body"))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := r.Method + " " + r.URL.Path
+			stored, err := guard.ClaimIdempotencyKey(r.Context(), db, playerID, key, scope, requestHash(scope, body))
+			if err != nil {
+				RespondError(w, err)
+				return
+			}
+			if stored != nil {
+				if stored.ContentType != "" {
+					w.Header().Set("Content-Type", stored.ContentType)
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(stored.Status)
+				w.Write(stored.Body)
+				return
+			}
+
+			rec := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// Record the outcome even if the client has gone away. A failure
+			// here leaves the claim to expire, after which a retry runs again.
+			ctx := context.WithoutCancel(r.Context())
+			if rec.status >= http.StatusInternalServerError {
+				guard.ReleaseIdempotencyKey(ctx, db, playerID, key)
+			} else {
+				guard.CompleteIdempotencyKey(ctx, db, playerID, key, guard.StoredResponse{
+					Status:      rec.status,
+					ContentType: w.Header().Get("Content-Type"),
+					Body:        rec.body.Bytes(),
+				})
+			}
+
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// requestHash fingerprints a request so a key reused for a different one is
+// caught.
+func requestHash(scope string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(scope))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// KYCHandler lets players upload identity documents and see their
+// verification status.
+type KYCHandler struct {
+	kycSvc *service.KYCService
+}
+
+// NewKYCHandler creates a new KYCHandler.
+func NewKYCHandler(kycSvc *service.KYCService) *KYCHandler {
+	return &KYCHandler{kycSvc: kycSvc}
+}
+
+// Status handles GET /players/me/kyc.
+func (h *KYCHandler) Status(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	status, err := h.kycSvc.Status(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, status)
+}
+
+// ListDocuments handles GET /players/me/kyc/documents.
+func (h *KYCHandler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	docs, err := h.kycSvc.ListForPlayer(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, docs)
+}
+
+// UploadDocument handles POST /players/me/kyc/documents as
+// multipart/form-data with a "document_type" field and a "file" part. The
+// content type is sniffed from the file itself rather than trusted from the
+// client.
+func (h *KYCHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxKYCDocumentBytes+1<<20)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		RespondError(w, domain.ErrValidation("invalid multipart body or file exceeds 10MB"))
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		RespondError(w, domain.ErrValidation("file is required"))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, service.MaxKYCDocumentBytes+1))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("could not read file"))
+		return
+	}
+
+	doc, err := h.kycSvc.Upload(r.Context(), playerID, service.KYCUpload{
+		DocumentType: domain.KYCDocumentType(r.FormValue("document_type")),
+		FileName:     header.Filename,
+		ContentType:  http.DetectContentType(content),
+		Content:      content,
+	})
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, doc)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// LoginHistoryHandler handles the player's login history endpoints.
+type LoginHistoryHandler struct {
+	loginSvc *service.LoginHistoryService
+}
+
+// NewLoginHistoryHandler creates a new LoginHistoryHandler.
+func NewLoginHistoryHandler(loginSvc *service.LoginHistoryService) *LoginHistoryHandler {
+	return &LoginHistoryHandler{loginSvc: loginSvc}
+}
+
+// ListLogins handles GET /players/me/logins.
+func (h *LoginHistoryHandler) ListLogins(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	logins, err := h.loginSvc.List(r.Context(), playerID, limit)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{"logins": logins})
+}
+
+// ReportLogin handles POST /players/me/logins/{id}/report — the "not me" action.
+func (h *LoginHistoryHandler) ReportLogin(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	loginID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid login id"))
+		return
+	}
+
+	report, err := h.loginSvc.ReportNotMe(r.Context(), playerID, loginID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, report)
+}
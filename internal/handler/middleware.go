@@ -8,7 +8,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/attaboy/platform/internal/auth"
+	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
@@ -72,23 +78,10 @@ func Recovery(logger *slog.Logger) func(http.Handler) http.Handler {
 	}
 }
 
-// CORSWithOrigins returns CORS middleware using the given allowed origins value.
-// Use "*" for development; set to specific origins in production.
+// CORSWithOrigins returns CORS middleware for a comma-separated origin list
+// with default admin and preflight settings. See CORS for the full policy.
 func CORSWithOrigins(allowedOrigins string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", allowedOrigins)
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
-			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
-
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusNoContent)
-				return
-			}
-			next.ServeHTTP(w, r)
-		})
-	}
+	return CORS(CORSConfig{Origins: ParseOriginList(allowedOrigins)})
 }
 
 // JSONContentType sets Content-Type to application/json for all responses.
@@ -110,6 +103,13 @@ func (w *responseWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Flush lets streamed responses pass through the request logger.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // RateLimitMiddleware returns HTTP middleware that enforces a per-key rate limit.
 // keyFn extracts the rate-limit key from the request (typically client IP).
 func RateLimitMiddleware(rl *guard.RateLimiter, keyFn func(*http.Request) string) func(http.Handler) http.Handler {
@@ -139,3 +139,159 @@ func ClientIP(r *http.Request) string {
 	}
 	return r.RemoteAddr
 }
+
+// BrandFromRequest returns the brand key sent by the client in X-Brand.
+// An empty string means the default brand.
+func BrandFromRequest(r *http.Request) string {
+	return strings.ToLower(strings.TrimSpace(r.Header.Get("X-Brand")))
+}
+
+// ClientCountry returns the ISO country code set by the edge proxy
+// (CF-IPCountry or X-Country-Code), or an empty string if unknown.
+func ClientCountry(r *http.Request) string {
+	country := r.Header.Get("CF-IPCountry")
+	if country == "" {
+		country = r.Header.Get("X-Country-Code")
+	}
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 || country == "XX" {
+		return ""
+	}
+	return country
+}
+
+// RequireActiveAccount returns middleware that blocks wallet and betting
+// requests from suspended, self-excluded or closed players. Must run after
+// auth.AuthenticatePlayer.
+func RequireActiveAccount(db repository.DBTX) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			playerID, err := playerIDFromContext(r)
+			if err != nil {
+				RespondError(w, err)
+				return
+			}
+			if err := guard.CheckAccountActive(r.Context(), db, playerID); err != nil {
+				RespondError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ScreenIP screens the client IP at checkpoint (deposit or bet) and stops
+// the route with IP_BLOCKED when policy blocks the address.
+func ScreenIP(svc *service.IPRiskService, checkpoint string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			playerID, err := playerIDFromContext(r)
+			if err != nil {
+				RespondError(w, err)
+				return
+			}
+			if err := svc.Screen(r.Context(), service.IPScreen{
+				Checkpoint: checkpoint, IP: ClientIP(r), PlayerID: &playerID,
+			}); err != nil {
+				RespondError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAcceptedTerms blocks the route with TERMS_ACCEPTANCE_REQUIRED until
+// the player has accepted the current version of every published document.
+func RequireAcceptedTerms(db repository.DBTX) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			playerID, err := playerIDFromContext(r)
+			if err != nil {
+				RespondError(w, err)
+				return
+			}
+			if err := guard.CheckTermsAccepted(r.Context(), db, playerID); err != nil {
+				RespondError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSourceOfFunds blocks deposits with SOURCE_OF_FUNDS_REQUIRED once the
+// player has crossed a cumulative-deposit threshold without submitting the
+// questionnaire for it.
+func RequireSourceOfFunds(db repository.DBTX, thresholds []int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			playerID, err := playerIDFromContext(r)
+			if err != nil {
+				RespondError(w, err)
+				return
+			}
+			if err := guard.CheckSourceOfFunds(r.Context(), db, playerID, thresholds); err != nil {
+				RespondError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireVertical closes a route with VERTICAL_UNAVAILABLE when the
+// deployment's sweepstakes rules do not allow the vertical.
+func RequireVertical(rules policy.SweepsRules, vertical string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !rules.VerticalAllowed(vertical) {
+				RespondError(w, &domain.AppError{
+					Code:    "VERTICAL_UNAVAILABLE",
+					Message: vertical + " is not available on this deployment",
+					Status:  http.StatusForbidden,
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuditAdminRequests records every admin request in the admin audit log once
+// it has been served. Must run after auth.AuthenticateAdmin.
+func AuditAdminRequests(svc *service.AdminAuditService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := &responseWriter{ResponseWriter: w, status: 200}
+			next.ServeHTTP(ww, r)
+
+			claims := auth.ClaimsFromContext(r.Context())
+			adminID, err := uuid.Parse(auth.SubjectFromContext(r.Context()))
+			if claims == nil || err != nil {
+				return
+			}
+			entry := domain.AdminAuditEntry{
+				AdminID:    adminID,
+				AdminEmail: claims.Email,
+				Role:       claims.Role,
+				Method:     r.Method,
+				Route:      r.URL.Path,
+				Path:       r.URL.Path,
+				StatusCode: ww.status,
+				IP:         ClientIP(r),
+			}
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					entry.Route = pattern
+				}
+				if strings.HasPrefix(entry.Route, "/admin/players/{id}") {
+					if id, err := uuid.Parse(rctx.URLParam("id")); err == nil {
+						entry.PlayerID = &id
+					}
+				}
+			}
+			svc.Record(context.WithoutCancel(r.Context()), entry)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/service"
+)
+
+// P2PTransferHandler serves player-to-player transfers.
+type P2PTransferHandler struct {
+	transferSvc *service.P2PTransferService
+}
+
+// NewP2PTransferHandler creates a new P2PTransferHandler.
+func NewP2PTransferHandler(transferSvc *service.P2PTransferService) *P2PTransferHandler {
+	return &P2PTransferHandler{transferSvc: transferSvc}
+}
+
+// Send handles POST /wallet/transfers.
+func (h *P2PTransferHandler) Send(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.SendTransferInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	transfer, err := h.transferSvc.Send(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, transfer)
+}
+
+// List handles GET /wallet/transfers.
+func (h *P2PTransferHandler) List(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	transfers, err := h.transferSvc.ListForPlayer(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, transfers)
+}
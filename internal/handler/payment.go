@@ -5,6 +5,8 @@ import (
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 // PaymentHandler handles deposit and withdrawal endpoints.
@@ -20,8 +22,11 @@ func NewPaymentHandler(paymentSvc *service.PaymentService) *PaymentHandler {
 type initiateDepositRequest struct {
 	Amount     int64  `json:"amount"`
 	Currency   string `json:"currency"`
+	Method     string `json:"method"` // stripe (default) or adyen
 	SuccessURL string `json:"success_url"`
 	CancelURL  string `json:"cancel_url"`
+	// PaymentMethodID names the saved method paid with, if any.
+	PaymentMethodID *uuid.UUID `json:"payment_method_id,omitempty"`
 }
 
 // InitiateDeposit handles POST /payments/deposit.
@@ -45,7 +50,7 @@ func (h *PaymentHandler) InitiateDeposit(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	session, err := h.paymentSvc.InitiateDeposit(r.Context(), playerID, req.Amount, req.Currency, req.SuccessURL, req.CancelURL)
+	session, err := h.paymentSvc.InitiateDeposit(r.Context(), playerID, req.Amount, req.Currency, req.Method, req.SuccessURL, req.CancelURL, req.PaymentMethodID)
 	if err != nil {
 		RespondError(w, err)
 		return
@@ -56,6 +61,8 @@ func (h *PaymentHandler) InitiateDeposit(w http.ResponseWriter, r *http.Request)
 
 type requestWithdrawalRequest struct {
 	Amount int64 `json:"amount"`
+	// PaymentMethodID is the saved method to pay out to.
+	PaymentMethodID *uuid.UUID `json:"payment_method_id,omitempty"`
 }
 
 // RequestWithdrawal handles POST /payments/withdraw.
@@ -79,7 +86,7 @@ func (h *PaymentHandler) RequestWithdrawal(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.paymentSvc.RequestWithdrawal(r.Context(), playerID, req.Amount); err != nil {
+	if err := h.paymentSvc.RequestWithdrawal(r.Context(), playerID, req.Amount, req.PaymentMethodID); err != nil {
 		RespondError(w, err)
 		return
 	}
@@ -103,3 +110,99 @@ func (h *PaymentHandler) GetPaymentHistory(w http.ResponseWriter, r *http.Reques
 
 	RespondJSON(w, http.StatusOK, payments)
 }
+
+type addPaymentMethodRequest struct {
+	ProviderRef string `json:"provider_ref"` // Stripe pm_ id from client-side tokenization
+	Label       string `json:"label"`
+}
+
+// ListPaymentMethods handles GET /payments/methods.
+func (h *PaymentHandler) ListPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	methods, err := h.paymentSvc.ListPaymentMethods(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, methods)
+}
+
+// AddPaymentMethod handles POST /payments/methods.
+func (h *PaymentHandler) AddPaymentMethod(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var req addPaymentMethodRequest
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	method, err := h.paymentSvc.AddPaymentMethod(r.Context(), playerID, req.ProviderRef, req.Label)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, method)
+}
+
+// UpdatePaymentMethod handles PATCH /payments/methods/{id}.
+func (h *PaymentHandler) UpdatePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	methodID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid payment method id"))
+		return
+	}
+
+	var req struct {
+		Label string `json:"label"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	method, err := h.paymentSvc.RenamePaymentMethod(r.Context(), playerID, methodID, req.Label)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, method)
+}
+
+// RemovePaymentMethod handles DELETE /payments/methods/{id}.
+func (h *PaymentHandler) RemovePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	methodID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid payment method id"))
+		return
+	}
+
+	if err := h.paymentSvc.RemovePaymentMethod(r.Context(), playerID, methodID); err != nil {
+		RespondError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
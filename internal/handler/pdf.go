@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	pdfFontSize   = 10
+	pdfLeading    = 14
+	pdfPageHeight = 842 // A4 in points
+	pdfMargin     = 56
+	pdfMaxLines   = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// RespondPDF writes a single-page A4 PDF attachment with a bold title and
+// the given lines of text in Helvetica. It covers plain documents such as
+// receipts without pulling in a PDF library; lines past one page are dropped
+// and characters outside printable ASCII are replaced with '?'.
+func RespondPDF(w http.ResponseWriter, filename, title string, lines []string) {
+	body := RenderPDF(title, lines)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// RenderPDF renders the document RespondPDF serves.
+func RenderPDF(title string, lines []string) []byte {
+	var content strings.Builder
+	fmt.Fprintf(&content, "BT\n/F2 14 Tf\n%d %d Td\n(%s) Tj\n", pdfMargin, pdfPageHeight-pdfMargin, pdfEscape(title))
+	fmt.Fprintf(&content, "/F1 %d Tf\n%d TL\nT*\n", pdfFontSize, pdfLeading)
+	for i, line := range lines {
+		if i >= pdfMaxLines-2 {
+			break
+		}
+		fmt.Fprintf(&content, "T* (%s) Tj\n", pdfEscape(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 %d] "+
+			"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pdfPageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfEscape makes s safe inside a PDF literal string.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
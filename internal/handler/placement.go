@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// PlacementHandler serves the player's promotional placement feed.
+type PlacementHandler struct {
+	placementSvc *service.PlacementService
+}
+
+// NewPlacementHandler creates a new PlacementHandler.
+func NewPlacementHandler(placementSvc *service.PlacementService) *PlacementHandler {
+	return &PlacementHandler{placementSvc: placementSvc}
+}
+
+// ListPlacements handles GET /placements?vertical=casino.
+func (h *PlacementHandler) ListPlacements(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	placements, err := h.placementSvc.ListForPlayer(r.Context(), playerID, r.URL.Query().Get("vertical"))
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, placements)
+}
+
+// TrackImpression handles POST /placements/{id}/impression.
+func (h *PlacementHandler) TrackImpression(w http.ResponseWriter, r *http.Request) {
+	h.track(w, r, domain.PlacementImpression)
+}
+
+// TrackClick handles POST /placements/{id}/click.
+func (h *PlacementHandler) TrackClick(w http.ResponseWriter, r *http.Request) {
+	h.track(w, r, domain.PlacementClick)
+}
+
+func (h *PlacementHandler) track(w http.ResponseWriter, r *http.Request, eventType string) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	placementID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid placement id"))
+		return
+	}
+
+	if err := h.placementSvc.Track(r.Context(), playerID, placementID, eventType); err != nil {
+		RespondError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
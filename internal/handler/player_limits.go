@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// PlayerLimitHandler lets players manage their own deposit and loss limits.
+type PlayerLimitHandler struct {
+	limitSvc *service.PlayerLimitService
+}
+
+// NewPlayerLimitHandler creates a new PlayerLimitHandler.
+func NewPlayerLimitHandler(limitSvc *service.PlayerLimitService) *PlayerLimitHandler {
+	return &PlayerLimitHandler{limitSvc: limitSvc}
+}
+
+// List handles GET /players/me/limits.
+func (h *PlayerLimitHandler) List(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	limits, err := h.limitSvc.List(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, limits)
+}
+
+// Set handles PUT /players/me/limits. Decreases apply at once; increases
+// come back with a pending value and the time it takes effect.
+func (h *PlayerLimitHandler) Set(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.PlayerLimitInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	limit, err := h.limitSvc.Set(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, limit)
+}
+
+// Remove handles DELETE /players/me/limits/{type}/{period}. The limit stays
+// in force until the cooldown passes.
+func (h *PlayerLimitHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	limit, err := h.limitSvc.Remove(r.Context(), playerID,
+		domain.PlayerLimitType(chi.URLParam(r, "type")), domain.LimitPeriod(chi.URLParam(r, "period")))
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, limit)
+}
@@ -2,23 +2,30 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // PredictionHandler handles prediction market endpoints.
 type PredictionHandler struct {
 	pool *pgxpool.Pool
+	svc  *service.PredictionService
 }
 
 // NewPredictionHandler creates a new PredictionHandler.
-func NewPredictionHandler(pool *pgxpool.Pool) *PredictionHandler {
-	return &PredictionHandler{pool: pool}
+func NewPredictionHandler(pool *pgxpool.Pool, svc *service.PredictionService) *PredictionHandler {
+	return &PredictionHandler{pool: pool, svc: svc}
 }
 
 type predictionMarketResponse struct {
@@ -27,37 +34,127 @@ type predictionMarketResponse struct {
 	Description  *string          `json:"description,omitempty"`
 	Category     string           `json:"category"`
 	Status       string           `json:"status"`
+	Pricing      string           `json:"pricing"`
 	CloseAt      *time.Time       `json:"close_at,omitempty"`
 	Outcomes     json.RawMessage  `json:"outcomes"`
 	DomePlatform *string          `json:"source,omitempty"`
 	DomeMeta     json.RawMessage  `json:"metadata,omitempty"`
 	Tags         json.RawMessage  `json:"tags,omitempty"`
+	Volume       int64            `json:"volume"`
 	CreatedAt    time.Time        `json:"created_at"`
 }
 
-// ListMarkets handles GET /predictions/markets.
-func (h *PredictionHandler) ListMarkets(w http.ResponseWriter, r *http.Request) {
-	rows, err := h.pool.Query(r.Context(), `
-		SELECT id, title, description, category, status, close_at,
-		       COALESCE(outcomes, '[]'::jsonb),
-		       dome_platform,
-		       COALESCE(dome_metadata, '{}'::jsonb),
-		       COALESCE(tags, '[]'::jsonb),
-		       created_at
+const predictionMarketColumns = `id, title, description, category, status, pricing, close_at,
+	COALESCE(outcomes, '[]'::jsonb), dome_platform, COALESCE(dome_metadata, '{}'::jsonb),
+	COALESCE(tags, '[]'::jsonb), volume_minor, created_at`
+
+func scanPredictionMarket(row pgx.Row, m *predictionMarketResponse) error {
+	return row.Scan(&m.ID, &m.Title, &m.Description, &m.Category, &m.Status, &m.Pricing, &m.CloseAt,
+		&m.Outcomes, &m.DomePlatform, &m.DomeMeta, &m.Tags, &m.Volume, &m.CreatedAt)
+}
+
+// predictionMarketSorts maps the sort parameter of ListMarkets to its
+// ORDER BY; each leads with an indexed column.
+var predictionMarketSorts = map[string]string{
+	"newest":  "created_at DESC, id",
+	"closing": "close_at ASC NULLS LAST, id",
+	"volume":  "volume_minor DESC, id",
+}
+
+// maxPredictionMarketPage caps the limit parameter of ListMarkets.
+const maxPredictionMarketPage = 200
+
+// marketListQuery builds the ListMarkets query from its parameters:
+// category, tag, q (text search over title and description),
+// close_after/close_before (RFC3339), sort (newest, closing or volume),
+// limit and offset. Only the filters given become conditions, so each
+// query can use its index.
+func marketListQuery(params url.Values) (string, []any, error) {
+	conds := []string{"status IN ('open', 'closed')"}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if v := params.Get("category"); v != "" {
+		conds = append(conds, "category = "+arg(v))
+	}
+	if v := params.Get("tag"); v != "" {
+		conds = append(conds, "tags @> jsonb_build_array("+arg(v)+"::text)")
+	}
+	if v := strings.TrimSpace(params.Get("q")); v != "" {
+		conds = append(conds, "search @@ websearch_to_tsquery('simple', "+arg(v)+")")
+	}
+	for _, bound := range []struct{ param, op string }{{"close_after", ">="}, {"close_before", "<"}} {
+		v := params.Get(bound.param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s must be an RFC3339 timestamp", bound.param)
+		}
+		conds = append(conds, "close_at "+bound.op+" "+arg(t))
+	}
+
+	sort := params.Get("sort")
+	if sort == "" {
+		sort = "newest"
+	}
+	orderBy, ok := predictionMarketSorts[sort]
+	if !ok {
+		return "", nil, fmt.Errorf("sort must be newest, closing or volume")
+	}
+	if sort == "closing" {
+		// Closing soon: markets that have not closed yet.
+		conds = append(conds, "close_at > now()")
+	}
+
+	limit, offset := 50, 0
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxPredictionMarketPage {
+			return "", nil, fmt.Errorf("limit must be between 1 and %d", maxPredictionMarketPage)
+		}
+		limit = n
+	}
+	if v := params.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return "", nil, fmt.Errorf("offset must not be negative")
+		}
+		offset = n
+	}
+
+	query := `
+		SELECT ` + predictionMarketColumns + `
 		FROM prediction_markets
-		WHERE status IN ('open', 'closed')
-		ORDER BY created_at DESC LIMIT 50`)
+		WHERE ` + strings.Join(conds, " AND ") + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + arg(limit) + ` OFFSET ` + arg(offset)
+	return query, args, nil
+}
+
+// ListMarkets handles GET /predictions/markets, filtered and sorted by the
+// parameters marketListQuery takes.
+func (h *PredictionHandler) ListMarkets(w http.ResponseWriter, r *http.Request) {
+	query, args, err := marketListQuery(r.URL.Query())
+	if err != nil {
+		RespondError(w, domain.ErrValidation(err.Error()))
+		return
+	}
+	rows, err := h.pool.Query(r.Context(), query, args...)
 	if err != nil {
 		RespondError(w, domain.ErrInternal("list prediction markets", err))
 		return
 	}
 	defer rows.Close()
 
-	var markets []predictionMarketResponse
+	markets := []predictionMarketResponse{}
 	for rows.Next() {
 		var m predictionMarketResponse
-		if err := rows.Scan(&m.ID, &m.Title, &m.Description, &m.Category, &m.Status, &m.CloseAt,
-			&m.Outcomes, &m.DomePlatform, &m.DomeMeta, &m.Tags, &m.CreatedAt); err != nil {
+		if err := scanPredictionMarket(rows, &m); err != nil {
 			RespondError(w, domain.ErrInternal("scan prediction market", err))
 			return
 		}
@@ -67,6 +164,38 @@ func (h *PredictionHandler) ListMarkets(w http.ResponseWriter, r *http.Request)
 	RespondJSON(w, http.StatusOK, markets)
 }
 
+// ListCategories handles GET /predictions/categories — each category with
+// listed markets and how many it has.
+func (h *PredictionHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.pool.Query(r.Context(), `
+		SELECT category, count(*)
+		FROM prediction_markets
+		WHERE status IN ('open', 'closed')
+		GROUP BY category
+		ORDER BY count(*) DESC, category`)
+	if err != nil {
+		RespondError(w, domain.ErrInternal("list prediction categories", err))
+		return
+	}
+	defer rows.Close()
+
+	type category struct {
+		Category string `json:"category"`
+		Markets  int    `json:"markets"`
+	}
+	categories := []category{}
+	for rows.Next() {
+		var c category
+		if err := rows.Scan(&c.Category, &c.Markets); err != nil {
+			RespondError(w, domain.ErrInternal("scan prediction category", err))
+			return
+		}
+		categories = append(categories, c)
+	}
+
+	RespondJSON(w, http.StatusOK, categories)
+}
+
 // GetMarket handles GET /predictions/markets/{id}.
 func (h *PredictionHandler) GetMarket(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
@@ -76,16 +205,8 @@ func (h *PredictionHandler) GetMarket(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var m predictionMarketResponse
-	err = h.pool.QueryRow(r.Context(), `
-		SELECT id, title, description, category, status, close_at,
-		       COALESCE(outcomes, '[]'::jsonb),
-		       dome_platform,
-		       COALESCE(dome_metadata, '{}'::jsonb),
-		       COALESCE(tags, '[]'::jsonb),
-		       created_at
-		FROM prediction_markets WHERE id = $1`, id).
-		Scan(&m.ID, &m.Title, &m.Description, &m.Category, &m.Status, &m.CloseAt,
-			&m.Outcomes, &m.DomePlatform, &m.DomeMeta, &m.Tags, &m.CreatedAt)
+	err = scanPredictionMarket(h.pool.QueryRow(r.Context(), `
+		SELECT `+predictionMarketColumns+` FROM prediction_markets WHERE id = $1`, id), &m)
 	if err != nil {
 		RespondError(w, domain.ErrNotFound("prediction market", id.String()))
 		return
@@ -119,25 +240,47 @@ func (h *PredictionHandler) PlaceStake(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify market is open
-	var status string
-	err = h.pool.QueryRow(r.Context(), `SELECT status FROM prediction_markets WHERE id = $1`, marketID).Scan(&status)
-	if err != nil || status != "open" {
-		RespondError(w, domain.ErrValidation("market is not open for stakes"))
+	result, err := h.svc.PlaceStake(r.Context(), playerID, marketID, input.OutcomeID, int64(input.Amount))
+	if err != nil {
+		RespondError(w, err)
 		return
 	}
 
-	var stakeID uuid.UUID
-	err = h.pool.QueryRow(r.Context(), `
-		INSERT INTO prediction_stakes (player_id, market_id, outcome_id, stake_amount_minor)
-		VALUES ($1, $2, $3, $4) RETURNING id`,
-		playerID, marketID, input.OutcomeID, input.Amount).Scan(&stakeID)
+	RespondJSON(w, http.StatusCreated, result)
+}
+
+// SellPosition handles POST /predictions/positions/{id}/sell. An optional
+// min_value refuses the sale if its proceeds have fallen below it.
+func (h *PredictionHandler) SellPosition(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
 	if err != nil {
-		RespondError(w, domain.ErrInternal("place stake", err))
+		RespondError(w, err)
+		return
+	}
+	stakeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid position id"))
 		return
 	}
 
-	RespondJSON(w, http.StatusCreated, map[string]string{"id": stakeID.String()})
+	var input struct {
+		MinValue int64 `json:"min_value,omitempty"`
+	}
+	if r.ContentLength > 0 {
+		if err := DecodeJSON(r, &input); err != nil {
+			RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	sale, err := h.svc.SellPosition(r.Context(), playerID, stakeID, input.MinValue)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, sale)
 }
 
 // MyPositions handles GET /predictions/positions.
@@ -149,7 +292,8 @@ func (h *PredictionHandler) MyPositions(w http.ResponseWriter, r *http.Request)
 	}
 
 	rows, err := h.pool.Query(r.Context(), `
-		SELECT ps.id, ps.market_id, pm.title, ps.outcome_id, ps.stake_amount_minor, ps.status, ps.placed_at
+		SELECT ps.id, ps.market_id, pm.title, ps.outcome_id, ps.stake_amount_minor,
+		       ps.odds_at_placement::float8, ps.status, COALESCE(ps.payout_amount_minor, 0), ps.realized_pnl_minor, ps.placed_at, ps.settled_at
 		FROM prediction_stakes ps
 		JOIN prediction_markets pm ON pm.id = ps.market_id
 		WHERE ps.player_id = $1
@@ -161,19 +305,23 @@ func (h *PredictionHandler) MyPositions(w http.ResponseWriter, r *http.Request)
 	defer rows.Close()
 
 	type position struct {
-		ID       uuid.UUID `json:"id"`
-		MarketID uuid.UUID `json:"market_id"`
-		Title    string    `json:"market_title"`
-		Outcome  string    `json:"outcome_id"`
-		Amount   int       `json:"stake_amount"`
-		Status   string    `json:"status"`
-		PlacedAt time.Time `json:"placed_at"`
+		ID        uuid.UUID  `json:"id"`
+		MarketID  uuid.UUID  `json:"market_id"`
+		Title     string     `json:"market_title"`
+		Outcome   string     `json:"outcome_id"`
+		Amount    int        `json:"stake_amount"`
+		Odds      *float64   `json:"odds,omitempty"`
+		Status    string     `json:"status"`
+		Payout    int        `json:"payout_amount"`
+		PnL       *int64     `json:"realized_pnl,omitempty"`
+		PlacedAt  time.Time  `json:"placed_at"`
+		SettledAt *time.Time `json:"settled_at,omitempty"`
 	}
 
 	var positions []position
 	for rows.Next() {
 		var p position
-		if err := rows.Scan(&p.ID, &p.MarketID, &p.Title, &p.Outcome, &p.Amount, &p.Status, &p.PlacedAt); err != nil {
+		if err := rows.Scan(&p.ID, &p.MarketID, &p.Title, &p.Outcome, &p.Amount, &p.Odds, &p.Status, &p.Payout, &p.PnL, &p.PlacedAt, &p.SettledAt); err != nil {
 			RespondError(w, domain.ErrInternal("scan position", err))
 			return
 		}
@@ -5,18 +5,21 @@ import (
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // QuestHandler handles quest endpoints.
 type QuestHandler struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
 }
 
 // NewQuestHandler creates a new QuestHandler.
-func NewQuestHandler(pool *pgxpool.Pool) *QuestHandler {
-	return &QuestHandler{pool: pool}
+func NewQuestHandler(pool *pgxpool.Pool, outbox repository.OutboxRepository) *QuestHandler {
+	return &QuestHandler{pool: pool, outbox: outbox}
 }
 
 type questWithProgress struct {
@@ -30,9 +33,30 @@ type questWithProgress struct {
 	MinScore        int       `json:"min_score"`
 	Progress        int       `json:"progress"`
 	Status          string    `json:"status"`
+	// Recurring quests reset at ResetsAt, ResetsInSeconds from now.
+	Recurrence      string     `json:"recurrence"`
+	ResetsAt        *time.Time `json:"resets_at,omitempty"`
+	ResetsInSeconds *int64     `json:"resets_in_seconds,omitempty"`
+	// LockedBy lists the prerequisites still to claim while Status is
+	// locked.
+	LockedBy []uuid.UUID `json:"locked_by,omitempty"`
 }
 
-// ListActive handles GET /quests — returns active quests with player progress.
+// questLockedBySQL selects, as an array, the prerequisites of quest q that
+// player $1 has not claimed, in the current period or, for a recurring
+// prerequisite, an earlier one.
+const questLockedBySQL = `ARRAY(
+	SELECT qp.prerequisite_id FROM quest_prerequisites qp
+	WHERE qp.quest_id = q.id
+	  AND NOT EXISTS (SELECT 1 FROM player_quest_progress c
+	                  WHERE c.player_id = $1 AND c.quest_id = qp.prerequisite_id AND c.status = 'claimed')
+	  AND NOT EXISTS (SELECT 1 FROM player_quest_history c
+	                  WHERE c.player_id = $1 AND c.quest_id = qp.prerequisite_id AND c.status = 'claimed')
+	ORDER BY qp.prerequisite_id)`
+
+// ListActive handles GET /quests — returns active quests with player
+// progress and, for recurring quests, the time until progress resets.
+// Quests whose prerequisites the player has not all claimed are locked.
 func (h *QuestHandler) ListActive(w http.ResponseWriter, r *http.Request) {
 	playerID, err := playerIDFromContext(r)
 	if err != nil {
@@ -43,7 +67,8 @@ func (h *QuestHandler) ListActive(w http.ResponseWriter, r *http.Request) {
 	rows, err := h.pool.Query(r.Context(), `
 		SELECT q.id, q.name, q.description, q.type, q.target_progress,
 		       q.reward_amount, q.reward_currency, q.min_score,
-		       COALESCE(pqp.progress, 0), COALESCE(pqp.status, 'not_started')
+		       COALESCE(pqp.progress, 0), COALESCE(pqp.status, 'not_started'),
+		       q.recurrence, q.next_reset_at, `+questLockedBySQL+`
 		FROM quests q
 		LEFT JOIN player_quest_progress pqp ON pqp.quest_id = q.id AND pqp.player_id = $1
 		WHERE q.active = true
@@ -54,43 +79,118 @@ func (h *QuestHandler) ListActive(w http.ResponseWriter, r *http.Request) {
 	}
 	defer rows.Close()
 
+	now := time.Now()
 	var quests []questWithProgress
 	for rows.Next() {
 		var q questWithProgress
 		if err := rows.Scan(&q.ID, &q.Name, &q.Description, &q.Type, &q.TargetProgress,
-			&q.RewardAmount, &q.RewardCurrency, &q.MinScore, &q.Progress, &q.Status); err != nil {
+			&q.RewardAmount, &q.RewardCurrency, &q.MinScore, &q.Progress, &q.Status,
+			&q.Recurrence, &q.ResetsAt, &q.LockedBy); err != nil {
 			RespondError(w, domain.ErrInternal("scan quest", err))
 			return
 		}
+		if len(q.LockedBy) > 0 {
+			q.Status = "locked"
+		}
+		if q.ResetsAt != nil {
+			secs := int64(max(q.ResetsAt.Sub(now), 0) / time.Second)
+			q.ResetsInSeconds = &secs
+		}
 		quests = append(quests, q)
 	}
 
 	RespondJSON(w, http.StatusOK, quests)
 }
 
-// ClaimReward handles POST /quests/{id}/claim.
+// questHistoryEntry is one finished period of a recurring quest.
+type questHistoryEntry struct {
+	QuestID     uuid.UUID  `json:"quest_id"`
+	Name        string     `json:"name"`
+	PeriodEnd   time.Time  `json:"period_end"`
+	Progress    int        `json:"progress"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
+}
+
+// ListHistory handles GET /quests/history — the player's progress on
+// recurring quests in periods that have reset, claimed or expired, newest
+// first.
+func (h *QuestHandler) ListHistory(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	rows, err := h.pool.Query(r.Context(), `
+		SELECT hist.quest_id, q.name, hist.period_end, hist.progress, hist.status, hist.completed_at, hist.claimed_at
+		FROM player_quest_history hist
+		JOIN quests q ON q.id = hist.quest_id
+		WHERE hist.player_id = $1
+		ORDER BY hist.period_end DESC, q.sort_order ASC
+		LIMIT 200`, playerID)
+	if err != nil {
+		RespondError(w, domain.ErrInternal("query quest history", err))
+		return
+	}
+	defer rows.Close()
+
+	history := []questHistoryEntry{}
+	for rows.Next() {
+		var e questHistoryEntry
+		if err := rows.Scan(&e.QuestID, &e.Name, &e.PeriodEnd, &e.Progress, &e.Status, &e.CompletedAt, &e.ClaimedAt); err != nil {
+			RespondError(w, domain.ErrInternal("scan quest history", err))
+			return
+		}
+		history = append(history, e)
+	}
+	if err := rows.Err(); err != nil {
+		RespondError(w, domain.ErrInternal("query quest history", err))
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, history)
+}
+
+// ClaimReward handles POST /quests/{id}/claim. A quest in a chain can be
+// claimed only once its prerequisites have been.
 func (h *QuestHandler) ClaimReward(w http.ResponseWriter, r *http.Request) {
 	playerID, err := playerIDFromContext(r)
 	if err != nil {
 		RespondError(w, err)
 		return
 	}
+	questID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid quest id"))
+		return
+	}
 
 	// Check quest progress is completed and unclaimed
-	var questID uuid.UUID
-	var progressStatus string
 	var rewardAmount int
 	var rewardCurrency string
 	var minScore int
+	var lockedBy []uuid.UUID
 
 	err = h.pool.QueryRow(r.Context(), `
-		SELECT pqp.quest_id, pqp.status, q.reward_amount, q.reward_currency, q.min_score
+		SELECT q.reward_amount, q.reward_currency, q.min_score, `+questLockedBySQL+`
 		FROM player_quest_progress pqp
 		JOIN quests q ON q.id = pqp.quest_id
-		WHERE pqp.player_id = $1 AND pqp.status = 'completed'
-		LIMIT 1`, playerID).Scan(&questID, &progressStatus, &rewardAmount, &rewardCurrency, &minScore)
+		WHERE pqp.player_id = $1 AND pqp.quest_id = $2 AND pqp.status = 'completed'`,
+		playerID, questID).Scan(&rewardAmount, &rewardCurrency, &minScore, &lockedBy)
 	if err != nil {
-		RespondError(w, domain.ErrNotFound("completed quest", playerID.String()))
+		RespondError(w, domain.ErrNotFound("completed quest", questID.String()))
+		return
+	}
+
+	// Enforce the quest chain
+	if len(lockedBy) > 0 {
+		ids := make([]string, len(lockedBy))
+		for i, id := range lockedBy {
+			ids[i] = id.String()
+		}
+		RespondError(w, domain.ErrQuestLocked(ids))
 		return
 	}
 
@@ -107,18 +207,39 @@ func (h *QuestHandler) ClaimReward(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// An active quest boost bought in the store multiplies the reward
+	var boostBps int
+	_ = h.pool.QueryRow(r.Context(), `
+		SELECT COALESCE(MAX(boost_multiplier_bps), 0) FROM player_entitlements
+		WHERE player_id = $1 AND kind = 'quest_boost' AND expires_at > now()`,
+		playerID).Scan(&boostBps)
+	if boostBps > 0 {
+		rewardAmount = rewardAmount * boostBps / 10_000
+	}
+
+	tx, err := h.pool.Begin(r.Context())
+	if err != nil {
+		RespondError(w, domain.ErrInternal("begin tx", err))
+		return
+	}
+	defer tx.Rollback(r.Context())
+
 	// Mark as claimed
-	_, err = h.pool.Exec(r.Context(), `
+	tag, err := tx.Exec(r.Context(), `
 		UPDATE player_quest_progress SET status = 'claimed', claimed_at = $2
-		WHERE player_id = $1 AND quest_id = $3`,
+		WHERE player_id = $1 AND quest_id = $3 AND status = 'completed'`,
 		playerID, time.Now(), questID)
 	if err != nil {
 		RespondError(w, domain.ErrInternal("claim quest", err))
 		return
 	}
+	if tag.RowsAffected() == 0 {
+		RespondError(w, domain.ErrNotFound("completed quest", questID.String()))
+		return
+	}
 
 	// Record reward grant
-	_, err = h.pool.Exec(r.Context(), `
+	_, err = tx.Exec(r.Context(), `
 		INSERT INTO reward_grants (player_id, quest_id, amount, currency)
 		VALUES ($1, $2, $3, $4)`,
 		playerID, questID, rewardAmount, rewardCurrency)
@@ -126,6 +247,14 @@ func (h *QuestHandler) ClaimReward(w http.ResponseWriter, r *http.Request) {
 		RespondError(w, domain.ErrInternal("record reward", err))
 		return
 	}
+	if err := h.outbox.Insert(r.Context(), tx, domain.NewQuestRewardClaimedEvent(playerID, questID, int64(rewardAmount), rewardCurrency)); err != nil {
+		RespondError(w, domain.ErrInternal("insert outbox event", err))
+		return
+	}
+	if err := tx.Commit(r.Context()); err != nil {
+		RespondError(w, domain.ErrInternal("commit tx", err))
+		return
+	}
 
 	RespondJSON(w, http.StatusOK, map[string]interface{}{
 		"quest_id":        questID,
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// RecoveryHandler handles the player side of support-assisted account recovery.
+type RecoveryHandler struct {
+	recoverySvc *service.RecoveryService
+}
+
+// NewRecoveryHandler creates a new RecoveryHandler.
+func NewRecoveryHandler(recoverySvc *service.RecoveryService) *RecoveryHandler {
+	return &RecoveryHandler{recoverySvc: recoverySvc}
+}
+
+// SubmitRecovery handles POST /auth/recovery.
+// Always responds 202 so the endpoint cannot be used to probe for accounts or
+// for whether they have a verified backup email.
+func (h *RecoveryHandler) SubmitRecovery(w http.ResponseWriter, r *http.Request) {
+	var input service.RecoverySubmitInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	input.IP = ClientIP(r)
+
+	if err := h.recoverySvc.Submit(r.Context(), input); err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusAccepted, map[string]string{"status": "submitted"})
+}
+
+// GetBackupEmail handles GET /players/me/backup-email.
+func (h *RecoveryHandler) GetBackupEmail(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	backup, err := h.recoverySvc.GetBackupEmail(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	if backup == nil {
+		RespondError(w, domain.ErrNotFound("backup email", ""))
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, backup)
+}
+
+// SetBackupEmail handles PUT /players/me/backup-email. The address is used
+// for account recovery once verified with the token emailed to it.
+func (h *RecoveryHandler) SetBackupEmail(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		Email string `json:"email"`
+	}
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	backup, err := h.recoverySvc.SetBackupEmail(r.Context(), playerID, input.Email)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, backup)
+}
+
+// VerifyBackupEmail handles POST /players/me/backup-email/verify.
+func (h *RecoveryHandler) VerifyBackupEmail(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input struct {
+		Token string `json:"token"`
+	}
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	backup, err := h.recoverySvc.VerifyBackupEmail(r.Context(), playerID, input.Token)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, backup)
+}
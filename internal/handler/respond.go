@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/attaboy/platform/internal/domain"
@@ -16,8 +18,24 @@ func RespondJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 // RespondError writes a JSON error response, detecting domain.AppError for status codes.
+// An error caused by the request's deadline answers 504 DEADLINE_EXCEEDED.
 func RespondError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		RespondJSON(w, http.StatusGatewayTimeout, map[string]string{
+			"code":    "DEADLINE_EXCEEDED",
+			"message": "request did not finish within its time budget",
+		})
+		return
+	}
 	if appErr, ok := err.(*domain.AppError); ok {
+		if len(appErr.Details) > 0 {
+			RespondJSON(w, appErr.Status, map[string]interface{}{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"details": appErr.Details,
+			})
+			return
+		}
 		RespondJSON(w, appErr.Status, map[string]string{
 			"code":    appErr.Code,
 			"message": appErr.Message,
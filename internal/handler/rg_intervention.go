@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// InterventionHandler shows players their responsible-gambling interventions
+// and records how they respond.
+type InterventionHandler struct {
+	interventionSvc *service.NetLossInterventionService
+}
+
+// NewInterventionHandler creates a new InterventionHandler.
+func NewInterventionHandler(interventionSvc *service.NetLossInterventionService) *InterventionHandler {
+	return &InterventionHandler{interventionSvc: interventionSvc}
+}
+
+// List handles GET /players/me/interventions?pending=true.
+func (h *InterventionHandler) List(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	interventions, err := h.interventionSvc.ListForPlayer(r.Context(), playerID, r.URL.Query().Get("pending") == "true")
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, interventions)
+}
+
+// Acknowledge handles POST /players/me/interventions/{id}/acknowledge.
+func (h *InterventionHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid intervention id"))
+		return
+	}
+
+	var req struct {
+		Response string `json:"response"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	intervention, err := h.interventionSvc.Acknowledge(r.Context(), playerID, id, req.Response)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, intervention)
+}
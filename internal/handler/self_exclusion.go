@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/service"
+)
+
+// SelfExclusionHandler lets players exclude themselves from play.
+type SelfExclusionHandler struct {
+	statusSvc *service.PlayerStatusService
+}
+
+// NewSelfExclusionHandler creates a new SelfExclusionHandler.
+func NewSelfExclusionHandler(statusSvc *service.PlayerStatusService) *SelfExclusionHandler {
+	return &SelfExclusionHandler{statusSvc: statusSvc}
+}
+
+// Get handles GET /players/me/self-exclusion.
+func (h *SelfExclusionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	exclusion, err := h.statusSvc.SelfExclusion(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, exclusion)
+}
+
+// Enable handles POST /players/me/self-exclusion. The body gives either
+// duration_days or permanent: true.
+func (h *SelfExclusionHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.SelfExclusionInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	exclusion, err := h.statusSvc.SelfExclude(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, exclusion)
+}
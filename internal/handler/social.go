@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,12 +13,13 @@ import (
 
 // SocialHandler handles social interaction endpoints.
 type SocialHandler struct {
-	pool *pgxpool.Pool
+	pool      *pgxpool.Pool
+	cosmetics *service.CosmeticService
 }
 
 // NewSocialHandler creates a new SocialHandler.
-func NewSocialHandler(pool *pgxpool.Pool) *SocialHandler {
-	return &SocialHandler{pool: pool}
+func NewSocialHandler(pool *pgxpool.Pool, cosmetics *service.CosmeticService) *SocialHandler {
+	return &SocialHandler{pool: pool, cosmetics: cosmetics}
 }
 
 // CreatePost handles POST /social/posts.
@@ -88,9 +90,12 @@ func (h *SocialHandler) ListPosts(w http.ResponseWriter, r *http.Request) {
 		TargetType *string    `json:"target_type,omitempty"`
 		TargetID   *uuid.UUID `json:"target_id,omitempty"`
 		CreatedAt  time.Time  `json:"created_at"`
+		// Equipped lists the author's equipped cosmetics
+		Equipped []domain.EquippedCosmetic `json:"equipped,omitempty"`
 	}
 
 	var posts []post
+	var authors []uuid.UUID
 	for rows.Next() {
 		var p post
 		if err := rows.Scan(&p.ID, &p.PlayerID, &p.Content, &p.Type, &p.TargetType, &p.TargetID, &p.CreatedAt); err != nil {
@@ -98,6 +103,17 @@ func (h *SocialHandler) ListPosts(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		posts = append(posts, p)
+		authors = append(authors, p.PlayerID)
+	}
+	rows.Close()
+
+	equipped, err := h.cosmetics.Equipped(r.Context(), authors)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	for i := range posts {
+		posts[i].Equipped = equipped[posts[i].PlayerID]
 	}
 
 	RespondJSON(w, http.StatusOK, posts)
@@ -132,3 +148,20 @@ func (h *SocialHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
 
 	RespondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
 }
+
+// PlayerProfile handles GET /social/players/{id} — a player's public profile
+// with their equipped cosmetics.
+func (h *SocialHandler) PlayerProfile(w http.ResponseWriter, r *http.Request) {
+	playerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid player id"))
+		return
+	}
+
+	profile, err := h.cosmetics.PublicProfile(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, profile)
+}
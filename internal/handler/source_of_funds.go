@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SourceOfFundsHandler lets players answer source-of-funds questionnaires.
+type SourceOfFundsHandler struct {
+	sofSvc *service.SourceOfFundsService
+}
+
+// NewSourceOfFundsHandler creates a new SourceOfFundsHandler.
+func NewSourceOfFundsHandler(sofSvc *service.SourceOfFundsService) *SourceOfFundsHandler {
+	return &SourceOfFundsHandler{sofSvc: sofSvc}
+}
+
+// List handles GET /players/me/source-of-funds.
+func (h *SourceOfFundsHandler) List(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	questionnaires, err := h.sofSvc.ListForPlayer(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, questionnaires)
+}
+
+// Submit handles PUT /players/me/source-of-funds/{id}.
+func (h *SourceOfFundsHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid questionnaire id"))
+		return
+	}
+
+	var input service.SOFSubmission
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	q, err := h.sofSvc.Submit(r.Context(), playerID, id, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, q)
+}
+
+// UploadDocument handles POST /players/me/source-of-funds/{id}/documents as
+// multipart/form-data with a "file" part. The content type is sniffed from
+// the file itself rather than trusted from the client.
+func (h *SourceOfFundsHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid questionnaire id"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxSOFDocumentBytes+1<<20)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		RespondError(w, domain.ErrValidation("invalid multipart body or file exceeds 5MB"))
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		RespondError(w, domain.ErrValidation("file is required"))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, service.MaxSOFDocumentBytes+1))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("could not read file"))
+		return
+	}
+
+	doc, err := h.sofSvc.AttachDocument(r.Context(), playerID, id, service.SOFUpload{
+		FileName:    header.Filename,
+		ContentType: http.DetectContentType(content),
+		Content:     content,
+	})
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, doc)
+}
@@ -2,6 +2,9 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/repository"
@@ -46,6 +49,21 @@ func (h *SportsbookHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 	RespondJSON(w, http.StatusOK, events)
 }
 
+// ListOutrights handles GET /sportsbook/sports/{sportID}/outrights.
+func (h *SportsbookHandler) ListOutrights(w http.ResponseWriter, r *http.Request) {
+	sportID, err := uuid.Parse(chi.URLParam(r, "sportID"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid sport id"))
+		return
+	}
+	markets, err := h.svc.ListOutrights(r.Context(), h.db, sportID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, markets)
+}
+
 // ListMarkets handles GET /sportsbook/events/{eventID}/markets.
 func (h *SportsbookHandler) ListMarkets(w http.ResponseWriter, r *http.Request) {
 	eventID, err := uuid.Parse(chi.URLParam(r, "eventID"))
@@ -101,7 +119,9 @@ func (h *SportsbookHandler) PlaceBet(w http.ResponseWriter, r *http.Request) {
 	RespondJSON(w, http.StatusCreated, result)
 }
 
-// MyBets handles GET /sportsbook/bets/me.
+// MyBets handles GET /sportsbook/bets/me with cursor-based pagination and
+// optional status (comma-separated), from, to and sport_id filters. Dates are
+// YYYY-MM-DD, where to includes the whole day, or RFC 3339 timestamps.
 func (h *SportsbookHandler) MyBets(w http.ResponseWriter, r *http.Request) {
 	playerID, err := playerIDFromContext(r)
 	if err != nil {
@@ -109,11 +129,116 @@ func (h *SportsbookHandler) MyBets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bets, err := h.svc.ListPlayerBets(r.Context(), playerID)
+	q := r.URL.Query()
+	filter := service.BetHistoryFilter{}
+	filter.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if v := q.Get("status"); v != "" {
+		filter.Statuses = strings.Split(v, ",")
+	}
+	if filter.From, err = parseHistoryTime(q.Get("from"), false); err != nil {
+		RespondError(w, domain.ErrValidation("from must be a date (YYYY-MM-DD) or RFC 3339 time"))
+		return
+	}
+	if filter.To, err = parseHistoryTime(q.Get("to"), true); err != nil {
+		RespondError(w, domain.ErrValidation("to must be a date (YYYY-MM-DD) or RFC 3339 time"))
+		return
+	}
+	if v := q.Get("sport_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			RespondError(w, domain.ErrValidation("invalid sport_id"))
+			return
+		}
+		filter.SportID = &id
+	}
+	if v := q.Get("cursor"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			RespondError(w, domain.ErrValidation("invalid cursor"))
+			return
+		}
+		filter.Cursor = &id
+	}
+
+	page, err := h.svc.ListPlayerBets(r.Context(), playerID, filter)
 	if err != nil {
 		RespondError(w, err)
 		return
 	}
 
-	RespondJSON(w, http.StatusOK, bets)
+	RespondJSON(w, http.StatusOK, page)
+}
+
+// parseHistoryTime reads a history bound given as a date or an RFC 3339
+// time. A date used as an upper bound is moved to the end of that day.
+func parseHistoryTime(v string, upper bool) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		if upper {
+			t = t.AddDate(0, 0, 1)
+		}
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// CashOutQuote handles GET /sportsbook/bets/{id}/cashout.
+func (h *SportsbookHandler) CashOutQuote(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	betID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid bet id"))
+		return
+	}
+
+	quote, err := h.svc.CashOutQuote(r.Context(), playerID, betID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, quote)
+}
+
+// CashOut handles POST /sportsbook/bets/{id}/cashout. An optional min_value
+// refuses the cash-out if the value has fallen below it since the quote.
+func (h *SportsbookHandler) CashOut(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	betID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid bet id"))
+		return
+	}
+
+	var input struct {
+		MinValue int64 `json:"min_value,omitempty"`
+	}
+	if r.ContentLength > 0 {
+		if err := DecodeJSON(r, &input); err != nil {
+			RespondJSON(w, http.StatusBadRequest, map[string]string{
+				"code": "VALIDATION_ERROR", "message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	quote, err := h.svc.CashOut(r.Context(), playerID, betID, input.MinValue)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, quote)
 }
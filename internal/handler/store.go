@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// StoreHandler handles the player side of the virtual goods store.
+type StoreHandler struct {
+	svc *service.StoreService
+}
+
+// NewStoreHandler creates a new StoreHandler.
+func NewStoreHandler(svc *service.StoreService) *StoreHandler {
+	return &StoreHandler{svc: svc}
+}
+
+// ListItems handles GET /store/items.
+func (h *StoreHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListItems(r.Context(), true)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, items)
+}
+
+// Checkout handles POST /store/orders.
+func (h *StoreHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.StoreCheckoutInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	session, err := h.svc.Checkout(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, session)
+}
+
+// ListOrders handles GET /store/orders — the player's purchase history.
+func (h *StoreHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	orders, err := h.svc.ListOrders(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, orders)
+}
+
+// ListEntitlements handles GET /store/entitlements. ?all=true includes
+// expired quest boosts.
+func (h *StoreHandler) ListEntitlements(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	entitlements, err := h.svc.ListEntitlements(r.Context(), playerID, r.URL.Query().Get("all") != "true")
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, entitlements)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+)
+
+// SweepstakesHandler handles the player side of sweepstakes mode: coin
+// packages and SC redemptions.
+type SweepstakesHandler struct {
+	svc *service.SweepstakesService
+}
+
+// NewSweepstakesHandler creates a new SweepstakesHandler.
+func NewSweepstakesHandler(svc *service.SweepstakesService) *SweepstakesHandler {
+	return &SweepstakesHandler{svc: svc}
+}
+
+// ListPackages handles GET /sweeps/packages.
+func (h *SweepstakesHandler) ListPackages(w http.ResponseWriter, r *http.Request) {
+	packages, err := h.svc.ListPackages(r.Context(), true)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, packages)
+}
+
+// Purchase handles POST /sweeps/purchases.
+func (h *SweepstakesHandler) Purchase(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var input service.PurchaseInput
+	if err := DecodeJSON(r, &input); err != nil {
+		RespondJSON(w, http.StatusBadRequest, map[string]string{
+			"code": "VALIDATION_ERROR", "message": "invalid request body",
+		})
+		return
+	}
+
+	session, err := h.svc.Purchase(r.Context(), playerID, input)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, session)
+}
+
+// ListPurchases handles GET /sweeps/purchases.
+func (h *SweepstakesHandler) ListPurchases(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	purchases, err := h.svc.ListPurchases(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, purchases)
+}
+
+// Eligibility handles GET /sweeps/redemptions/eligibility.
+func (h *SweepstakesHandler) Eligibility(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	decision, err := h.svc.RedemptionEligibility(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, decision)
+}
+
+// Redeem handles POST /sweeps/redemptions.
+func (h *SweepstakesHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	var req struct {
+		Amount int64 `json:"amount"`
+	}
+	if err := DecodeJSON(r, &req); err != nil {
+		RespondError(w, domain.ErrValidation("invalid request body"))
+		return
+	}
+
+	redemption, err := h.svc.Redeem(r.Context(), playerID, req.Amount)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusCreated, redemption)
+}
+
+// ListRedemptions handles GET /sweeps/redemptions.
+func (h *SweepstakesHandler) ListRedemptions(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	redemptions, err := h.svc.ListRedemptions(r.Context(), &playerID, "")
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, redemptions)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// TermsHandler serves T&C documents and records player acceptance.
+type TermsHandler struct {
+	termsSvc *service.TermsService
+}
+
+// NewTermsHandler creates a new TermsHandler.
+func NewTermsHandler(termsSvc *service.TermsService) *TermsHandler {
+	return &TermsHandler{termsSvc: termsSvc}
+}
+
+// ListCurrent handles GET /terms — the current version of each document.
+func (h *TermsHandler) ListCurrent(w http.ResponseWriter, r *http.Request) {
+	docs, err := h.termsSvc.Current(r.Context())
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, docs)
+}
+
+// ListPending handles GET /terms/pending — documents the player must accept.
+func (h *TermsHandler) ListPending(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	pending, err := h.termsSvc.Pending(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, pending)
+}
+
+// Accept handles POST /terms/{id}/accept.
+func (h *TermsHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondError(w, domain.ErrValidation("invalid terms document id"))
+		return
+	}
+
+	acceptance, err := h.termsSvc.Accept(r.Context(), playerID, id, ClientIP(r))
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, acceptance)
+}
+
+// ListAcceptances handles GET /players/me/terms — the player's acceptance history.
+func (h *TermsHandler) ListAcceptances(w http.ResponseWriter, r *http.Request) {
+	playerID, err := playerIDFromContext(r)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+
+	acceptances, err := h.termsSvc.ListAcceptances(r.Context(), playerID)
+	if err != nil {
+		RespondError(w, err)
+		return
+	}
+	RespondJSON(w, http.StatusOK, acceptances)
+}
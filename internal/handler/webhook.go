@@ -8,7 +8,7 @@ import (
 	"github.com/attaboy/platform/internal/service"
 )
 
-// WebhookHandler handles Stripe webhook callbacks.
+// WebhookHandler handles PSP webhook callbacks.
 type WebhookHandler struct {
 	paymentSvc *service.PaymentService
 	logger     *slog.Logger
@@ -46,3 +46,25 @@ func (h *WebhookHandler) HandleStripeWebhook(w http.ResponseWriter, r *http.Requ
 	// Stripe expects 200 OK
 	w.WriteHeader(http.StatusOK)
 }
+
+// HandleAdyenWebhook handles POST /webhooks/adyen.
+// Adyen signs each notification item in the body, so there is no signature header.
+func (h *WebhookHandler) HandleAdyenWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1MB limit
+	if err != nil {
+		h.logger.Error("read webhook body", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.paymentSvc.HandleAdyenWebhook(r.Context(), body); err != nil {
+		h.logger.Error("process adyen webhook", "error", err)
+		RespondError(w, err)
+		return
+	}
+
+	// Adyen expects the literal "[accepted]" acknowledgement
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("[accepted]"))
+}
@@ -16,6 +16,13 @@ type Config struct {
 	PGPassword  string `env:"PGPASSWORD" envDefault:"attaboy"`
 	PGDatabase  string `env:"PGDATABASE" envDefault:"attaboy"`
 
+	// Prepared statements: pgx prepares each distinct query once per
+	// connection and keeps up to PGStatementCacheSize of them. Behind a
+	// transaction-pooling PgBouncer set PG_QUERY_EXEC_MODE to exec or
+	// simple_protocol, which never leave a statement on the server.
+	PGQueryExecMode      string `env:"PG_QUERY_EXEC_MODE" envDefault:"cache_statement"`
+	PGStatementCacheSize int    `env:"PG_STATEMENT_CACHE_SIZE" envDefault:"512"`
+
 	// Redis
 	RedisURL string `env:"REDIS_URL" envDefault:"redis://localhost:6380"`
 
@@ -29,12 +36,139 @@ type Config struct {
 	APIPort          int `env:"API_PORT" envDefault:"3100"`
 	WalletServerPort int `env:"WALLET_SERVER_PORT" envDefault:"4001"`
 
+	// Request time budgets: a request's context is cancelled once its budget
+	// is spent. Admin reports get the longer report budget; wallet-server
+	// callbacks get the wallet budget. Each server's write timeout is set a
+	// few seconds above its longest budget.
+	RequestBudgetMS        int `env:"REQUEST_BUDGET_MS" envDefault:"10000"`
+	ReportRequestBudgetMS  int `env:"REPORT_REQUEST_BUDGET_MS" envDefault:"30000"`
+	WalletCallbackBudgetMS int `env:"WALLET_CALLBACK_BUDGET_MS" envDefault:"5000"`
+
+	// Wallet callback latency SLO: target share of provider callbacks that
+	// must reach ledger commit within the threshold.
+	WalletSLOThresholdMS int     `env:"WALLET_SLO_THRESHOLD_MS" envDefault:"250"`
+	WalletSLOTarget      float64 `env:"WALLET_SLO_TARGET" envDefault:"0.99"`
+
+	// Per-provider circuit breaker around ledger dispatch: this many
+	// consecutive failures fail callbacks fast until the reset period passes.
+	WalletBreakerFailures     int `env:"WALLET_BREAKER_FAILURES" envDefault:"10"`
+	WalletBreakerResetSeconds int `env:"WALLET_BREAKER_RESET_SECONDS" envDefault:"30"`
+
+	// Casino wallet adapters mounted by the wallet server, comma-separated
+	// "name" or "name=kind" entries. Each reads WALLET_PROVIDER_<NAME>_PREFIX
+	// (default "/name") and WALLET_PROVIDER_<NAME>_SECRET. Ignored once the
+	// manufacturers table has rows, which the wallet server then re-reads on
+	// every change and at least every reload interval.
+	WalletProviders             string `env:"WALLET_PROVIDERS" envDefault:"betsolutions,pragmatic,evolution,relax,netent,redtiger=netent"`
+	WalletProviderReloadSeconds int    `env:"WALLET_PROVIDER_RELOAD_SECONDS" envDefault:"30"`
+
+	// Stuck-round sweeper: provider game rounds with no activity for the TTL
+	// are voided and their stakes refunded; a TTL of 0 disables the sweeper.
+	// Exempt manufacturers (comma-separated) are never swept.
+	GameRoundTTLMinutes   int    `env:"GAME_ROUND_TTL_MINUTES" envDefault:"1440"`
+	GameRoundSweepMinutes int    `env:"GAME_ROUND_SWEEP_MINUTES" envDefault:"5"`
+	GameRoundSweepExempt  string `env:"GAME_ROUND_SWEEP_EXEMPT" envDefault:"sportsbook,predictions"`
+
+	// Game session tokens issued at launch accept new stakes for this long;
+	// wins and rollbacks settle on them after expiry.
+	GameSessionTTLMinutes int `env:"GAME_SESSION_TTL_MINUTES" envDefault:"240"`
+
 	// Kafka
 	KafkaBrokers string `env:"KAFKA_BROKERS" envDefault:"localhost:9092"`
 	KafkaEnabled bool   `env:"KAFKA_ENABLED" envDefault:"false"`
 
-	// CORS
+	// CORS. Origins are comma-separated and may use "https://*.domain" wildcards.
+	// CORS_BRAND_ORIGINS overrides per brand: "brand=origin1,origin2;brand2=origin3".
+	// Admin routes only accept CORS_ADMIN_ORIGINS (closed by default).
 	CORSAllowedOrigins string `env:"CORS_ALLOWED_ORIGINS" envDefault:"*"`
+	CORSBrandOrigins   string `env:"CORS_BRAND_ORIGINS"`
+	CORSAdminOrigins   string `env:"CORS_ADMIN_ORIGINS"`
+	CORSMaxAge         int    `env:"CORS_MAX_AGE" envDefault:"600"`
+
+	// GraphQL gateway for player data (POST/GET /graphql), off by default.
+	GraphQLEnabled bool `env:"GRAPHQL_ENABLED" envDefault:"false"`
+
+	// Currencies players may open wallets in, comma-separated ISO codes.
+	WalletCurrencies string `env:"WALLET_CURRENCIES" envDefault:"EUR,USD,GBP"`
+
+	// Lifetime deposit levels (cents, comma-separated) at which players must
+	// submit a source-of-funds questionnaire before depositing again; "off" disables.
+	SOFDepositThresholds string `env:"SOF_DEPOSIT_THRESHOLDS" envDefault:"500000,2500000"`
+
+	// Per-jurisdiction net-loss intervention overrides, merged over the built-in
+	// ladders: "GB=30:25000,100000,200000;default=30:50000,150000,300000".
+	RGNetLossRules string `env:"RG_NET_LOSS_RULES"`
+
+	// Player-to-player transfers for social casino deployments, off by
+	// default. Caps and the minimum fee are in cents; the fee is charged to
+	// the sender on top of the amount. A cap of 0 means no cap.
+	P2PTransfersEnabled bool  `env:"P2P_TRANSFERS_ENABLED" envDefault:"false"`
+	P2PMaxTransfer      int64 `env:"P2P_MAX_TRANSFER" envDefault:"25000"`
+	P2PDailyLimit       int64 `env:"P2P_DAILY_LIMIT" envDefault:"50000"`
+	P2PFeeBps           int   `env:"P2P_FEE_BPS" envDefault:"0"`
+	P2PFeeMin           int64 `env:"P2P_FEE_MIN" envDefault:"0"`
+
+	// Sweepstakes mode: play in Gold Coins and Sweeps Coins, sell coin
+	// packages instead of taking deposits and redeem SC for cash prizes.
+	// Country and vertical lists are comma-separated.
+	SweepstakesMode         bool   `env:"SWEEPSTAKES_MODE" envDefault:"false"`
+	SweepsRedeemMin         int64  `env:"SWEEPS_REDEEM_MIN" envDefault:"5000"`
+	SweepsPlaythrough       int    `env:"SWEEPS_PLAYTHROUGH" envDefault:"1"`
+	SweepsCashCurrency      string `env:"SWEEPS_CASH_CURRENCY" envDefault:"USD"`
+	SweepsExcludedCountries string `env:"SWEEPS_EXCLUDED_COUNTRIES"`
+	SweepsDisabledVerticals string `env:"SWEEPS_DISABLED_VERTICALS" envDefault:"sportsbook,predictions"`
+
+	// Closed-loop payouts: withdrawals must go to a verified saved payment
+	// method the player has already deposited with.
+	PaymentsClosedLoop bool `env:"PAYMENTS_CLOSED_LOOP" envDefault:"true"`
+
+	// KYC documents go to an S3-compatible bucket (path-style URLs, so MinIO
+	// works). Withdrawals above KYC_WITHDRAWAL_THRESHOLD (cents) need a
+	// verified player; 0 disables the check.
+	KYCStorageEndpoint     string `env:"KYC_STORAGE_ENDPOINT"`
+	KYCStorageRegion       string `env:"KYC_STORAGE_REGION" envDefault:"us-east-1"`
+	KYCStorageBucket       string `env:"KYC_STORAGE_BUCKET"`
+	KYCStorageAccessKey    string `env:"KYC_STORAGE_ACCESS_KEY"`
+	KYCStorageSecretKey    string `env:"KYC_STORAGE_SECRET_KEY"`
+	KYCWithdrawalThreshold int64  `env:"KYC_WITHDRAWAL_THRESHOLD" envDefault:"200000"`
+
+	// Admin export jobs write their files to an S3-compatible bucket and hand
+	// out presigned download links. Each admin may have at most
+	// EXPORT_MAX_CONCURRENT_PER_ADMIN jobs queued or running at once.
+	ExportStorageEndpoint       string `env:"EXPORT_STORAGE_ENDPOINT"`
+	ExportStorageRegion         string `env:"EXPORT_STORAGE_REGION" envDefault:"us-east-1"`
+	ExportStorageBucket         string `env:"EXPORT_STORAGE_BUCKET"`
+	ExportStorageAccessKey      string `env:"EXPORT_STORAGE_ACCESS_KEY"`
+	ExportStorageSecretKey      string `env:"EXPORT_STORAGE_SECRET_KEY"`
+	ExportMaxConcurrentPerAdmin int    `env:"EXPORT_MAX_CONCURRENT_PER_ADMIN" envDefault:"2"`
+
+	// Sportsbook exposure: the most open potential payout allowed on one
+	// selection, as an event's worst case and to one player on an event, in
+	// cents. 0 disables a limit.
+	SportsbookMaxSelectionLiability   int64 `env:"SPORTSBOOK_MAX_SELECTION_LIABILITY" envDefault:"5000000"`
+	SportsbookMaxEventLiability       int64 `env:"SPORTSBOOK_MAX_EVENT_LIABILITY" envDefault:"25000000"`
+	SportsbookMaxPlayerEventLiability int64 `env:"SPORTSBOOK_MAX_PLAYER_EVENT_LIABILITY" envDefault:"1000000"`
+
+	// In-play suspension: markets synced from the odds feed are suspended
+	// for SPORTSBOOK_INPLAY_FREEZE_SECONDS when their event goes live or a
+	// price moves by SPORTSBOOK_ODDS_SWING_BPS or more. 0 seconds disables it.
+	SportsbookInPlayFreezeSeconds int `env:"SPORTSBOOK_INPLAY_FREEZE_SECONDS" envDefault:"60"`
+	SportsbookOddsSwingBps        int `env:"SPORTSBOOK_ODDS_SWING_BPS" envDefault:"1500"`
+
+	// Casino RTP monitoring: a game's daily actual RTP is alerted on when it
+	// falls more than RTP_BAND_Z standard errors from its theoretical RTP,
+	// once the day has RTP_BAND_MIN_ROUNDS rounds.
+	RTPBandZ          float64 `env:"RTP_BAND_Z" envDefault:"3"`
+	RTPBandVolatility float64 `env:"RTP_BAND_VOLATILITY" envDefault:"10"`
+	RTPBandMinRounds  int     `env:"RTP_BAND_MIN_ROUNDS" envDefault:"1000"`
+
+	// Data retention: days before raw provider callback bodies are redacted,
+	// analytics events deleted and quiet AI conversations deleted. With
+	// RETENTION_DRY_RUN the worker only records what it would remove.
+	RetentionProviderCallbackDays int  `env:"RETENTION_PROVIDER_CALLBACK_DAYS" envDefault:"90"`
+	RetentionAnalyticsEventDays   int  `env:"RETENTION_ANALYTICS_EVENT_DAYS" envDefault:"365"`
+	RetentionAIConversationDays   int  `env:"RETENTION_AI_CONVERSATION_DAYS" envDefault:"183"`
+	RetentionDryRun               bool `env:"RETENTION_DRY_RUN" envDefault:"false"`
 
 	// Dev
 	AllowInsecureDefaults bool `env:"ALLOW_INSECURE_DEFAULTS" envDefault:"false"`
@@ -44,12 +178,43 @@ type Config struct {
 	StripeSecretKey     string `env:"STRIPE_SECRET_KEY"`
 	StripeWebhookSecret string `env:"STRIPE_WEBHOOK_SECRET"`
 
+	// Adyen, for markets Stripe does not serve. ADYEN_HMAC_KEY is the hex key
+	// from the webhook settings; ADYEN_CHECKOUT_URL is the live prefix URL and
+	// defaults to the test environment. ADYEN_PAYOUT_URL, the Payout API base
+	// URL, sends approved withdrawals through Adyen; without it they are paid
+	// by hand.
+	AdyenAPIKey          string `env:"ADYEN_API_KEY"`
+	AdyenMerchantAccount string `env:"ADYEN_MERCHANT_ACCOUNT"`
+	AdyenHMACKey         string `env:"ADYEN_HMAC_KEY"`
+	AdyenCheckoutURL     string `env:"ADYEN_CHECKOUT_URL"`
+	AdyenPayoutURL       string `env:"ADYEN_PAYOUT_URL"`
+
 	// Dome prediction feed
 	DomeBaseURL string `env:"DOME_BASE_URL"`
 	DomeAPIKey  string `env:"DOME_API_KEY"`
 
 	// The Odds API (sportsbook live odds)
 	OddsAPIKey string `env:"ODDS_API_KEY"`
+
+	// HMAC key for signed bet receipts. Unset means an ephemeral per-process key.
+	ReceiptSigningKey string `env:"RECEIPT_SIGNING_KEY"`
+
+	// CAPTCHA for risky auth flows (hcaptcha or turnstile).
+	// CAPTCHA_BRANDS overrides per brand: "brand=provider:secret;brand2=provider:secret".
+	CaptchaProvider  string `env:"CAPTCHA_PROVIDER"`
+	CaptchaSecretKey string `env:"CAPTCHA_SECRET_KEY"`
+	CaptchaBrands    string `env:"CAPTCHA_BRANDS"`
+
+	// VPN/proxy screening at registration, deposit and bet time through
+	// proxycheck.io; off without an API key. IP_RISK_PROXY_VERDICTS overrides
+	// the verdict per checkpoint: "registration=review,deposit=block,bet=review".
+	// Risk scores (0-100) at or above the review/block scores are flagged or
+	// blocked anywhere; 0 disables.
+	IPIntelAPIKey       string `env:"IP_INTEL_API_KEY"`
+	IPIntelBaseURL      string `env:"IP_INTEL_BASE_URL"`
+	IPRiskProxyVerdicts string `env:"IP_RISK_PROXY_VERDICTS"`
+	IPRiskReviewScore   int    `env:"IP_RISK_REVIEW_SCORE" envDefault:"66"`
+	IPRiskBlockScore    int    `env:"IP_RISK_BLOCK_SCORE" envDefault:"90"`
 }
 
 // LoadConfig parses environment variables into a Config struct.
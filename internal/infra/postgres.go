@@ -5,9 +5,29 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// queryExecModes maps PG_QUERY_EXEC_MODE values to pgx's modes; the names
+// are the ones pgx accepts as default_query_exec_mode in a DSN.
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// ParseQueryExecMode returns the pgx query execution mode named by mode.
+func ParseQueryExecMode(mode string) (pgx.QueryExecMode, error) {
+	m, ok := queryExecModes[mode]
+	if !ok {
+		return 0, fmt.Errorf("unknown query exec mode %q", mode)
+	}
+	return m, nil
+}
+
 // NewPostgresPool creates a pgx connection pool from the given config.
 func NewPostgresPool(ctx context.Context, cfg *Config) (*pgxpool.Pool, error) {
 	poolCfg, err := pgxpool.ParseConfig(cfg.DSN())
@@ -21,6 +41,18 @@ func NewPostgresPool(ctx context.Context, cfg *Config) (*pgxpool.Pool, error) {
 	poolCfg.MaxConnIdleTime = 5 * time.Minute
 	poolCfg.HealthCheckPeriod = 30 * time.Second
 
+	// Hot ledger and listing queries run on every request, so each
+	// connection prepares them once and reuses the plan.
+	mode, err := ParseQueryExecMode(cfg.PGQueryExecMode)
+	if err != nil {
+		return nil, fmt.Errorf("PG_QUERY_EXEC_MODE: %w", err)
+	}
+	poolCfg.ConnConfig.DefaultQueryExecMode = mode
+	if cfg.PGStatementCacheSize > 0 {
+		poolCfg.ConnConfig.StatementCacheCapacity = cfg.PGStatementCacheSize
+		poolCfg.ConnConfig.DescriptionCacheCapacity = cfg.PGStatementCacheSize
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("create pool: %w", err)
@@ -0,0 +1,22 @@
+package infra
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryExecMode(t *testing.T) {
+	mode, err := ParseQueryExecMode("cache_statement")
+	require.NoError(t, err)
+	assert.Equal(t, pgx.QueryExecModeCacheStatement, mode)
+
+	mode, err = ParseQueryExecMode("simple_protocol")
+	require.NoError(t, err)
+	assert.Equal(t, pgx.QueryExecModeSimpleProtocol, mode)
+
+	_, err = ParseQueryExecMode("prepared")
+	assert.Error(t, err)
+}
@@ -0,0 +1,255 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Backoff is the retry schedule of a failing worker loop: Min doubling up to
+// Max, with up to a quarter taken off as jitter so replicas that failed
+// together do not retry in step.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// DefaultBackoff retries after 1s, doubling to a 1 minute cap.
+func DefaultBackoff() Backoff {
+	return Backoff{Min: time.Second, Max: time.Minute}
+}
+
+// Delay returns the wait after the failures-th consecutive failure.
+func (b Backoff) Delay(failures int) time.Duration {
+	delay := b.Min
+	for i := 1; i < failures && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if delay > b.Max {
+		delay = b.Max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay - time.Duration(rand.Int64N(int64(delay)/4+1))
+}
+
+// failoverCodes are the SQLSTATEs a failover leaves behind: a write on a
+// connection to the old primary, now a standby, and the server shutting
+// down or still starting. Class 08 (connection exceptions) is matched too.
+var failoverCodes = map[string]bool{
+	"25006": true, // read_only_sql_transaction
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// IsFailover reports whether err looks like the database failing over or
+// going away, rather than a problem with the query itself.
+func IsFailover(err error) bool {
+	if err == nil {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return failoverCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08")
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// RevalidatePool checks the pool after a worker failure and resets it when
+// err looks like a failover or the database does not answer a ping. Reset
+// closes every idle connection, so the next acquire dials whichever server
+// is primary now instead of reusing a connection to the old one. It
+// reports whether the pool was reset.
+func RevalidatePool(ctx context.Context, pool *pgxpool.Pool, err error) bool {
+	if !IsFailover(err) && HealthCheck(ctx, pool) == nil {
+		return false
+	}
+	pool.Reset()
+	return true
+}
+
+// WorkerHealth tracks the background loops of a process for its liveness
+// probe. A worker is stuck when it has not finished a run, successful or
+// not, within its stuck threshold: a loop that keeps failing is retrying
+// and a restart would not help it, but one that stopped running needs one.
+// A nil WorkerHealth records nothing.
+type WorkerHealth struct {
+	mu      sync.Mutex
+	workers map[string]*workerState
+	now     func() time.Time
+}
+
+type workerState struct {
+	stuckAfter  time.Duration
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastError   string
+	failures    int
+}
+
+// WorkerStatus is one worker's entry in the liveness report.
+type WorkerStatus struct {
+	Name                string     `json:"name"`
+	Status              string     `json:"status"` // ok, failing or stuck
+	LastRunAt           time.Time  `json:"last_run_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
+
+// NewWorkerHealth creates an empty WorkerHealth.
+func NewWorkerHealth() *WorkerHealth {
+	return &WorkerHealth{workers: map[string]*workerState{}, now: time.Now}
+}
+
+// Register adds a worker that is stuck once stuckAfter passes without a
+// finished run. Registering counts as a run, so a worker is not stuck
+// before it has had the chance to run.
+func (h *WorkerHealth) Register(name string, stuckAfter time.Duration) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.workers[name] = &workerState{stuckAfter: stuckAfter, lastRun: h.now()}
+}
+
+// Success records a run of name that succeeded.
+func (h *WorkerHealth) Success(name string) {
+	h.record(name, nil)
+}
+
+// Failure records a run of name that failed with err.
+func (h *WorkerHealth) Failure(name string, err error) {
+	h.record(name, err)
+}
+
+func (h *WorkerHealth) record(name string, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w, ok := h.workers[name]
+	if !ok {
+		return
+	}
+	w.lastRun = h.now()
+	if err != nil {
+		w.lastError = err.Error()
+		w.failures++
+		return
+	}
+	w.lastSuccess = w.lastRun
+	w.lastError = ""
+	w.failures = 0
+}
+
+// Status reports every worker, by name, and whether none of them is stuck.
+func (h *WorkerHealth) Status() ([]WorkerStatus, bool) {
+	if h == nil {
+		return []WorkerStatus{}, true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	live := true
+	statuses := make([]WorkerStatus, 0, len(h.workers))
+	for name, w := range h.workers {
+		s := WorkerStatus{
+			Name: name, Status: "ok", LastRunAt: w.lastRun,
+			LastError: w.lastError, ConsecutiveFailures: w.failures,
+		}
+		if !w.lastSuccess.IsZero() {
+			at := w.lastSuccess
+			s.LastSuccessAt = &at
+		}
+		switch {
+		case w.stuckAfter > 0 && now.Sub(w.lastRun) > w.stuckAfter:
+			s.Status = "stuck"
+			live = false
+		case w.failures > 0:
+			s.Status = "failing"
+		}
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, live
+}
+
+// Worker is a periodic background loop that survives database failovers.
+type Worker struct {
+	Name     string
+	Interval time.Duration
+	// Timeout bounds each run, so a query stuck on a connection to a server
+	// that went away cannot hang the loop; zero leaves runs unbounded.
+	Timeout time.Duration
+	Backoff Backoff
+	// Pool is revalidated after each failure; nil skips revalidation.
+	Pool   *pgxpool.Pool
+	Health *WorkerHealth
+	Logger *slog.Logger
+}
+
+// Run calls fn now and then every Interval until ctx ends. After a failure
+// the pool is revalidated and the next run comes after the backoff delay
+// instead, growing while the failures continue. The worker is registered
+// with Health as stuck after three intervals, or three maximum backoffs,
+// without a finished run.
+func (w Worker) Run(ctx context.Context, fn func(ctx context.Context) error) {
+	w.Health.Register(w.Name, 3*max(w.Interval, w.Backoff.Max, w.Timeout))
+
+	failures := 0
+	for {
+		err := w.runOnce(ctx, fn)
+		if ctx.Err() != nil {
+			return
+		}
+		wait := w.Interval
+		if err != nil {
+			failures++
+			w.Health.Failure(w.Name, err)
+			wait = w.Backoff.Delay(failures)
+			w.Logger.Error("worker run failed", "worker", w.Name, "failures", failures, "retry_in", wait, "error", err)
+			if w.Pool != nil && RevalidatePool(ctx, w.Pool, err) {
+				w.Logger.Warn("database connections reset after worker failure", "worker", w.Name)
+			}
+		} else {
+			if failures > 0 {
+				w.Logger.Info("worker recovered", "worker", w.Name, "failures", failures)
+			}
+			failures = 0
+			w.Health.Success(w.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (w Worker) runOnce(ctx context.Context, fn func(ctx context.Context) error) error {
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
@@ -0,0 +1,108 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Min: time.Second, Max: 8 * time.Second}
+	for failures, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 10: 8 * time.Second} {
+		d := b.Delay(failures)
+		assert.LessOrEqual(t, d, want, "failures=%d", failures)
+		assert.GreaterOrEqual(t, d, want*3/4, "failures=%d", failures)
+	}
+	assert.Zero(t, Backoff{}.Delay(3))
+}
+
+func TestIsFailover(t *testing.T) {
+	assert.True(t, IsFailover(&pgconn.PgError{Code: "25006"}))
+	assert.True(t, IsFailover(fmt.Errorf("sync: %w", &pgconn.PgError{Code: "57P01"})))
+	assert.True(t, IsFailover(&pgconn.PgError{Code: "08006"}))
+	assert.True(t, IsFailover(fmt.Errorf("query: %w", io.ErrUnexpectedEOF)))
+
+	assert.False(t, IsFailover(nil))
+	assert.False(t, IsFailover(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, IsFailover(errors.New("dome returned 502")))
+}
+
+func TestWorkerHealth_Status(t *testing.T) {
+	now := time.Now()
+	h := NewWorkerHealth()
+	h.now = func() time.Time { return now }
+	h.Register("prices", time.Minute)
+	h.Register("sync", time.Minute)
+
+	workers, live := h.Status()
+	require.Len(t, workers, 2)
+	assert.True(t, live)
+	assert.Equal(t, "ok", workers[0].Status)
+	assert.Nil(t, workers[0].LastSuccessAt)
+
+	now = now.Add(30 * time.Second)
+	h.Failure("prices", errors.New("connection refused"))
+	h.Success("sync")
+	workers, live = h.Status()
+	assert.True(t, live, "a failing worker is still running")
+	assert.Equal(t, "failing", workers[0].Status)
+	assert.Equal(t, 1, workers[0].ConsecutiveFailures)
+	assert.Equal(t, "connection refused", workers[0].LastError)
+	assert.Equal(t, "ok", workers[1].Status)
+	require.NotNil(t, workers[1].LastSuccessAt)
+
+	now = now.Add(75 * time.Second)
+	h.Failure("prices", errors.New("connection refused"))
+	workers, live = h.Status()
+	assert.False(t, live)
+	assert.Equal(t, "failing", workers[0].Status)
+	assert.Equal(t, "stuck", workers[1].Status)
+
+	var nilHealth *WorkerHealth
+	nilHealth.Success("sync")
+	workers, live = nilHealth.Status()
+	assert.Empty(t, workers)
+	assert.True(t, live)
+}
+
+func TestWorker_RunBacksOffAndRecovers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := NewWorkerHealth()
+
+	runs := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Worker{
+			Name: "job", Interval: time.Hour, Backoff: Backoff{Min: time.Millisecond, Max: time.Millisecond},
+			Health: h, Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		}.Run(ctx, func(context.Context) error {
+			runs++
+			if runs < 3 {
+				return errors.New("database unavailable")
+			}
+			cancel()
+			return nil
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not retry")
+	}
+	assert.Equal(t, 3, runs)
+	workers, live := h.Status()
+	require.Len(t, workers, 1)
+	assert.True(t, live)
+	assert.Equal(t, 2, workers[0].ConsecutiveFailures, "the run cancelled with ctx is not recorded")
+}
@@ -15,7 +15,7 @@ func (e *Engine) ExecuteBonusCredit(ctx context.Context, tx pgx.Tx, params domai
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("bonus credit: %w", err)
 	}
@@ -42,6 +42,7 @@ func (e *Engine) ExecuteBonusCredit(ctx context.Context, tx pgx.Tx, params domai
 		BalanceUpdate:         domain.BalanceUpdate{BonusBalance: params.Amount},
 		ExternalTransactionID: strPtr(extID),
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("bonus credit post: %w", err)
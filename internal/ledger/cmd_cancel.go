@@ -70,6 +70,25 @@ func (e *Engine) ExecuteCancelTransaction(ctx context.Context, tx pgx.Tx, params
 	case domain.TxWithdrawal:
 		// Restore from reserved back to balance
 		delta = domain.BalanceUpdate{Balance: params.Amount, ReservedBalance: -params.Amount}
+	case domain.TxReserve:
+		// A released reservation has already become a bet
+		released, err := e.transactions.FindByTarget(ctx, tx, target.ID, domain.TxBet)
+		if err != nil {
+			return nil, fmt.Errorf("cancel find release: %w", err)
+		}
+		if released != nil {
+			return nil, domain.ErrConflict("reservation already released")
+		}
+		realBet, bonusBet := extractBetSplit(target)
+		delta = domain.BalanceUpdate{Balance: realBet, BonusBalance: bonusBet, ReservedBalance: -target.Amount}
+	}
+
+	// Reverse into the wallet the original entry was posted to
+	currency := target.Currency
+	if isBaseCurrency(player, currency) {
+		currency = ""
+	} else if _, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, currency); err != nil {
+		return nil, fmt.Errorf("cancel: %w", err)
 	}
 
 	targetID := params.TargetTransactionID
@@ -83,6 +102,7 @@ func (e *Engine) ExecuteCancelTransaction(ctx context.Context, tx pgx.Tx, params
 		SubTransactionID:      strPtr(subID),
 		TargetTransactionID:   &targetID,
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cancel post: %w", err)
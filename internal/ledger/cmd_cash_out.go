@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExecuteCashOut settles an open bet early, crediting its cash-out value.
+// The credit is split between real and bonus balance like a win, and closes
+// the bet's round the same way.
+func (e *Engine) ExecuteCashOut(ctx context.Context, tx pgx.Tx, params domain.CashOutParams) (*domain.CommandResult, error) {
+	if err := domain.ValidatePositiveAmount(params.Amount); err != nil {
+		return nil, err
+	}
+
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("cash out: %w", err)
+	}
+
+	extID := params.ExternalTransactionID
+	mfgID := params.ManufacturerID
+	if extID != "" {
+		existing, err := e.FindExistingTransaction(ctx, tx, domain.IdempotencyKey{
+			PlayerID:              params.PlayerID,
+			ManufacturerID:        mfgID,
+			ExternalTransactionID: extID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return &domain.CommandResult{Transaction: existing, Player: player, Idempotent: true}, nil
+		}
+	}
+
+	realWin, bonusWin := computeWinSplit(ctx, e.transactions, tx, player, params.Amount, params.GameRoundID)
+	meta := mergeMeta(params.Metadata, map[string]interface{}{
+		"realWin":  realWin,
+		"bonusWin": bonusWin,
+	})
+
+	entry, updatedPlayer, err := e.PostLedgerEntry(ctx, tx, domain.PostLedgerEntryParams{
+		PlayerID:              params.PlayerID,
+		Type:                  domain.TxCashOut,
+		Amount:                params.Amount,
+		BalanceUpdate:         domain.BalanceUpdate{Balance: realWin, BonusBalance: bonusWin},
+		ExternalTransactionID: strPtr(extID),
+		ManufacturerID:        strPtr(mfgID),
+		GameRoundID:           strPtr(params.GameRoundID),
+		Metadata:              meta,
+		Currency:              params.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cash out post: %w", err)
+	}
+
+	return &domain.CommandResult{
+		Transaction: entry,
+		Player:      updatedPlayer,
+		Events:      []domain.OutboxDraft{domain.NewTransactionPostedEvent(entry)},
+	}, nil
+}
@@ -0,0 +1,27 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExecuteCloseRound records a losing result for a game round. No money
+// moves, so the result carries the player's balances but no transaction.
+func (e *Engine) ExecuteCloseRound(ctx context.Context, tx pgx.Tx, params domain.CloseRoundParams) (*domain.CommandResult, error) {
+	if params.GameRoundID == "" {
+		return nil, domain.ErrValidation("round id is required")
+	}
+
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("close round: %w", err)
+	}
+
+	if err := e.rounds.RecordSettlement(ctx, tx, params.PlayerID, params.ManufacturerID, params.GameRoundID, player.Currency, 0); err != nil {
+		return nil, err
+	}
+	return &domain.CommandResult{Player: player}, nil
+}
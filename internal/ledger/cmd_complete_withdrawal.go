@@ -16,7 +16,7 @@ func (e *Engine) ExecuteCompleteWithdrawal(ctx context.Context, tx pgx.Tx, param
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("complete withdrawal: %w", err)
 	}
@@ -48,6 +48,7 @@ func (e *Engine) ExecuteCompleteWithdrawal(ctx context.Context, tx pgx.Tx, param
 		BalanceUpdate:         domain.BalanceUpdate{ReservedBalance: -params.Amount},
 		ExternalTransactionID: strPtr(extID),
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("complete withdrawal post: %w", err)
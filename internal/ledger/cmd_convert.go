@@ -0,0 +1,126 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// conversionManufacturer tags both legs of a conversion in the idempotency key;
+// the legs are told apart by sub_transaction_id.
+const conversionManufacturer = "fx"
+
+// ExecuteCurrencyConversion moves real balance between two of the player's
+// wallets: an fx_conversion_out debit on FromCurrency and an fx_conversion_in
+// credit on ToCurrency, posted in the caller's transaction.
+// Pattern: Lock both wallets → Idempotency → PostLedgerEntry ×2
+func (e *Engine) ExecuteCurrencyConversion(ctx context.Context, tx pgx.Tx, params domain.ConversionParams) (*domain.ConversionResult, error) {
+	if err := domain.ValidatePositiveAmount(params.Amount); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidatePositiveAmount(params.ConvertedAmount); err != nil {
+		return nil, err
+	}
+	from := strings.ToUpper(params.FromCurrency)
+	to := strings.ToUpper(params.ToCurrency)
+	if from == to {
+		return nil, domain.ErrValidation("cannot convert a currency to itself")
+	}
+
+	// Lock
+	source, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, from)
+	if err != nil {
+		return nil, fmt.Errorf("convert: %w", err)
+	}
+	if _, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, to); err != nil {
+		return nil, fmt.Errorf("convert: %w", err)
+	}
+
+	// Idempotency check — the debit leg is written first, so it decides
+	extID := params.ExternalTransactionID
+	if extID != "" {
+		debit, err := e.FindExistingTransaction(ctx, tx, conversionKey(params, "out"))
+		if err != nil {
+			return nil, err
+		}
+		if debit != nil {
+			credit, err := e.FindExistingTransaction(ctx, tx, conversionKey(params, "in"))
+			if err != nil {
+				return nil, err
+			}
+			return &domain.ConversionResult{Debit: debit, Credit: credit, Rate: params.Rate, Idempotent: true}, nil
+		}
+	}
+
+	// Only real balance converts; bonus funds stay in their wallet
+	if source.Balance < params.Amount {
+		return nil, domain.ErrInsufficientBalance()
+	}
+
+	meta := mergeMeta(nil, map[string]interface{}{
+		"fromCurrency":    from,
+		"toCurrency":      to,
+		"rate":            params.Rate,
+		"amount":          params.Amount,
+		"convertedAmount": params.ConvertedAmount,
+	})
+
+	debit, _, err := e.PostLedgerEntry(ctx, tx, domain.PostLedgerEntryParams{
+		PlayerID:              params.PlayerID,
+		Type:                  domain.TxConversionOut,
+		Amount:                params.Amount,
+		BalanceUpdate:         domain.BalanceUpdate{Balance: -params.Amount},
+		ExternalTransactionID: strPtr(extID),
+		ManufacturerID:        conversionManufacturerID(extID),
+		SubTransactionID:      conversionSubID(extID, "out"),
+		Metadata:              meta,
+		Currency:              from,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convert debit post: %w", err)
+	}
+
+	credit, _, err := e.PostLedgerEntry(ctx, tx, domain.PostLedgerEntryParams{
+		PlayerID:              params.PlayerID,
+		Type:                  domain.TxConversionIn,
+		Amount:                params.ConvertedAmount,
+		BalanceUpdate:         domain.BalanceUpdate{Balance: params.ConvertedAmount},
+		ExternalTransactionID: strPtr(extID),
+		ManufacturerID:        conversionManufacturerID(extID),
+		SubTransactionID:      conversionSubID(extID, "in"),
+		TargetTransactionID:   &debit.ID,
+		Metadata:              meta,
+		Currency:              to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convert credit post: %w", err)
+	}
+
+	return &domain.ConversionResult{Debit: debit, Credit: credit, Rate: params.Rate}, nil
+}
+
+func conversionKey(params domain.ConversionParams, leg string) domain.IdempotencyKey {
+	return domain.IdempotencyKey{
+		PlayerID:              params.PlayerID,
+		ManufacturerID:        conversionManufacturer,
+		ExternalTransactionID: params.ExternalTransactionID,
+		SubTransactionID:      leg,
+	}
+}
+
+func conversionManufacturerID(extID string) *string {
+	if extID == "" {
+		return nil
+	}
+	return strPtr(conversionManufacturer)
+}
+
+func conversionSubID(extID, leg string) *string {
+	if extID == "" {
+		return nil
+	}
+	return strPtr(leg)
+}
@@ -16,13 +16,15 @@ import (
 // Win-split algorithm (from Node.js):
 //   - If player has active bonus balance → all win goes to bonus
 //   - Otherwise → proportional split based on original bet real/bonus ratio
+//
+// Promotional free-round wins skip the split and go entirely to bonus.
 func (e *Engine) ExecuteCreditWin(ctx context.Context, tx pgx.Tx, params domain.CreditWinParams) (*domain.CommandResult, error) {
 	if err := domain.ValidatePositiveAmount(params.Amount); err != nil {
 		return nil, err
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("credit win: %w", err)
 	}
@@ -46,14 +48,19 @@ func (e *Engine) ExecuteCreditWin(ctx context.Context, tx pgx.Tx, params domain.
 		}
 	}
 
-	// Compute win split based on bet history in this round
-	realWin, bonusWin := computeWinSplit(ctx, e.transactions, tx, player, params)
-
 	winType := params.WinType
 	if winType == "" {
 		winType = domain.CasinoWinNormal
 	}
 
+	// Compute win split based on bet history in this round
+	var realWin, bonusWin int64
+	if winType == domain.CasinoWinPromoFreeRound {
+		bonusWin = params.Amount
+	} else {
+		realWin, bonusWin = computeWinSplit(ctx, e.transactions, tx, player, params.Amount, params.GameRoundID)
+	}
+
 	meta := mergeMeta(params.Metadata, map[string]interface{}{
 		"realWin":  realWin,
 		"bonusWin": bonusWin,
@@ -71,6 +78,7 @@ func (e *Engine) ExecuteCreditWin(ctx context.Context, tx pgx.Tx, params domain.
 		SubTransactionID:      strPtr(subID),
 		GameRoundID:           strPtr(roundID),
 		Metadata:              meta,
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("credit win post: %w", err)
@@ -85,15 +93,15 @@ func (e *Engine) ExecuteCreditWin(ctx context.Context, tx pgx.Tx, params domain.
 
 // computeWinSplit determines how to split a win between real and bonus balance.
 // V1 invariant: if bonus is active → all to bonus; else proportional.
-func computeWinSplit(ctx context.Context, txRepo repository.TransactionRepository, tx pgx.Tx, player *domain.Player, params domain.CreditWinParams) (realWin, bonusWin int64) {
+func computeWinSplit(ctx context.Context, txRepo repository.TransactionRepository, tx pgx.Tx, player *domain.Player, amount int64, gameRoundID string) (realWin, bonusWin int64) {
 	// If player has active bonus balance, all win goes to bonus
 	if player.BonusBalance > 0 {
-		return 0, params.Amount
+		return 0, amount
 	}
 
 	// Look up bet history in this round to determine proportion
-	if params.GameRoundID != "" {
-		bets, err := txRepo.ListByGameRound(ctx, tx, params.GameRoundID)
+	if gameRoundID != "" {
+		bets, err := txRepo.ListByGameRound(ctx, tx, gameRoundID)
 		if err == nil && len(bets) > 0 {
 			var totalRealBet, totalBonusBet int64
 			for _, bet := range bets {
@@ -113,13 +121,13 @@ func computeWinSplit(ctx context.Context, txRepo repository.TransactionRepositor
 			totalBet := totalRealBet + totalBonusBet
 			if totalBet > 0 && totalBonusBet > 0 {
 				// Proportional split
-				bonusWin = (params.Amount * totalBonusBet) / totalBet
-				realWin = params.Amount - bonusWin
+				bonusWin = (amount * totalBonusBet) / totalBet
+				realWin = amount - bonusWin
 				return realWin, bonusWin
 			}
 		}
 	}
 
 	// Default: all to real balance
-	return params.Amount, 0
+	return amount, 0
 }
@@ -17,7 +17,7 @@ func (e *Engine) ExecuteDeposit(ctx context.Context, tx pgx.Tx, params domain.De
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("deposit: %w", err)
 	}
@@ -51,6 +51,7 @@ func (e *Engine) ExecuteDeposit(ctx context.Context, tx pgx.Tx, params domain.De
 		ManufacturerID:        strPtr(mfgID),
 		SubTransactionID:      strPtr(subID),
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("deposit post: %w", err)
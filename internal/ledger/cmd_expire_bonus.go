@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExecuteExpireBonus expires an active player bonus whose expiry has passed,
+// forfeiting what is left of its bonus balance. Returns nil if the bonus is
+// no longer active or not yet expired, so a sweep racing a bet is harmless.
+func (e *Engine) ExecuteExpireBonus(ctx context.Context, tx pgx.Tx, params domain.ExpireBonusParams) (*domain.CommandResult, error) {
+	// Lock the player before the bonus, in the order bets lock them.
+	if _, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID); err != nil {
+		return nil, fmt.Errorf("expire bonus: %w", err)
+	}
+	b, err := e.bonuses.LockByID(ctx, tx, params.PlayerBonusID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil || b.PlayerID != params.PlayerID || b.Status != domain.BonusStatusActive || !b.IsExpired(params.Now) {
+		return nil, nil
+	}
+	return e.expireBonus(ctx, tx, *b)
+}
+
+// expireBonus marks an active bonus expired and forfeits what is left of its
+// bonus balance, capped at the amount granted, as a bonus_forfeit. CRM is
+// told through a bonus expired event either way.
+func (e *Engine) expireBonus(ctx context.Context, tx pgx.Tx, b domain.PlayerBonus) (*domain.CommandResult, error) {
+	if err := e.bonuses.SetStatus(ctx, tx, b.ID, domain.BonusStatusExpired); err != nil {
+		return nil, err
+	}
+	player, err := e.LockPlayerForUpdate(ctx, tx, b.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.CommandResult{Player: player}
+	if amount := min(player.BonusBalance, b.InitialAmount); amount > 0 {
+		result, err = e.ExecuteForfeitBonus(ctx, tx, domain.ForfeitBonusParams{
+			PlayerID:              b.PlayerID,
+			Amount:                amount,
+			ExternalTransactionID: "bonus-expiry-" + b.ID.String(),
+			Metadata:              mergeMeta(nil, map[string]interface{}{"playerBonusId": b.ID.String(), "reason": "expired"}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("expire bonus %s: %w", b.ID, err)
+		}
+	}
+
+	var forfeited int64
+	if result.Transaction != nil {
+		forfeited = result.Transaction.Amount
+	}
+	event := domain.NewBonusExpiredEvent(b, forfeited)
+	if err := e.outbox.Insert(ctx, tx, event); err != nil {
+		return nil, fmt.Errorf("insert outbox event: %w", err)
+	}
+	result.Events = append(result.Events, event)
+	return result, nil
+}
@@ -18,7 +18,7 @@ func (e *Engine) ExecuteForfeitBonus(ctx context.Context, tx pgx.Tx, params doma
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("forfeit bonus: %w", err)
 	}
@@ -55,6 +55,7 @@ func (e *Engine) ExecuteForfeitBonus(ctx context.Context, tx pgx.Tx, params doma
 		BalanceUpdate:         domain.BalanceUpdate{BonusBalance: -params.Amount},
 		ExternalTransactionID: strPtr(extID),
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("forfeit bonus post: %w", err)
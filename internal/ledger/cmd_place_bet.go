@@ -10,14 +10,15 @@ import (
 )
 
 // ExecutePlaceBet deducts from the player's balance (real-first, then bonus).
-// Tracks the real/bonus split in metadata for matching on win.
+// Tracks the real/bonus split in metadata for matching on win, and counts
+// the stake toward the player's active bonus wagering requirements.
 func (e *Engine) ExecutePlaceBet(ctx context.Context, tx pgx.Tx, params domain.PlaceBetParams) (*domain.CommandResult, error) {
 	if err := domain.ValidatePositiveAmount(params.Amount); err != nil {
 		return nil, err
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("place bet: %w", err)
 	}
@@ -70,17 +71,31 @@ func (e *Engine) ExecutePlaceBet(ctx context.Context, tx pgx.Tx, params domain.P
 		ManufacturerID:        strPtr(mfgID),
 		SubTransactionID:      strPtr(subID),
 		GameRoundID:           strPtr(roundID),
+		GameID:                strPtr(params.GameID),
 		Metadata:              meta,
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("place bet post: %w", err)
 	}
 
-	return &domain.CommandResult{
+	result := &domain.CommandResult{
 		Transaction: entry,
 		Player:      updatedPlayer,
 		Events:      []domain.OutboxDraft{domain.NewTransactionPostedEvent(entry)},
-	}, nil
+	}
+
+	// Bonus wagering: a completed bonus converts to real money, so the
+	// balances returned are the ones after the conversion.
+	released, err := e.trackWagering(ctx, tx, params)
+	if err != nil {
+		return nil, fmt.Errorf("place bet wagering: %w", err)
+	}
+	if released != nil {
+		result.Player = released.Player
+		result.Events = append(result.Events, released.Events...)
+	}
+	return result, nil
 }
 
 func mergeMeta(base json.RawMessage, extra map[string]interface{}) json.RawMessage {
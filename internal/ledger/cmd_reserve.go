@@ -0,0 +1,191 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// releaseSubTransactionID distinguishes the bet posted on release from the
+// reservation it settles, which shares its external transaction ID.
+const releaseSubTransactionID = "release"
+
+// ExecuteReserve holds a stake in reserved_balance for providers that settle
+// rounds in two phases. The stake is taken real-first, then bonus, and the
+// split is kept in metadata so release and cancel can restore it.
+// Phase 1: balance/bonus_balance -= amount, reserved_balance += amount
+func (e *Engine) ExecuteReserve(ctx context.Context, tx pgx.Tx, params domain.ReserveParams) (*domain.CommandResult, error) {
+	if err := domain.ValidatePositiveAmount(params.Amount); err != nil {
+		return nil, err
+	}
+
+	// Lock
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("reserve: %w", err)
+	}
+
+	// Idempotency check
+	extID := params.ExternalTransactionID
+	mfgID := params.ManufacturerID
+	if extID != "" {
+		existing, err := e.FindExistingTransaction(ctx, tx, domain.IdempotencyKey{
+			PlayerID:              params.PlayerID,
+			ManufacturerID:        mfgID,
+			ExternalTransactionID: extID,
+			SubTransactionID:      "1",
+		})
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return &domain.CommandResult{Transaction: existing, Player: player, Idempotent: true}, nil
+		}
+	}
+
+	if player.Balance+player.BonusBalance < params.Amount {
+		return nil, domain.ErrInsufficientBalance()
+	}
+
+	realBet := params.Amount
+	var bonusBet int64
+	if realBet > player.Balance {
+		realBet = player.Balance
+		bonusBet = params.Amount - realBet
+	}
+
+	meta := mergeMeta(params.Metadata, map[string]interface{}{
+		"realBet":  realBet,
+		"bonusBet": bonusBet,
+	})
+
+	roundID := params.GameRoundID
+	entry, updatedPlayer, err := e.PostLedgerEntry(ctx, tx, domain.PostLedgerEntryParams{
+		PlayerID:              params.PlayerID,
+		Type:                  domain.TxReserve,
+		Amount:                params.Amount,
+		BalanceUpdate:         domain.BalanceUpdate{Balance: -realBet, BonusBalance: -bonusBet, ReservedBalance: params.Amount},
+		ExternalTransactionID: strPtr(extID),
+		ManufacturerID:        strPtr(mfgID),
+		SubTransactionID:      strPtr("1"),
+		GameRoundID:           strPtr(roundID),
+		Metadata:              meta,
+		Currency:              params.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reserve post: %w", err)
+	}
+
+	return &domain.CommandResult{
+		Transaction: entry,
+		Player:      updatedPlayer,
+		Events:      []domain.OutboxDraft{domain.NewTransactionPostedEvent(entry)},
+	}, nil
+}
+
+// ExecuteRelease settles a reservation: the reserved stake is posted as a bet
+// against the provider and any win is credited like a normal casino win,
+// split by the reservation's real/bonus ratio.
+// Phase 2: reserved_balance -= stake, then balance/bonus_balance += win
+func (e *Engine) ExecuteRelease(ctx context.Context, tx pgx.Tx, params domain.ReleaseParams) (*domain.CommandResult, error) {
+	if params.WinAmount < 0 {
+		return nil, domain.ErrValidation("win amount must not be negative")
+	}
+	if params.ReservationID == "" {
+		return nil, domain.ErrValidation("reservation id is required")
+	}
+
+	// Lock
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("release: %w", err)
+	}
+
+	reservation, err := e.FindExistingTransaction(ctx, tx, domain.IdempotencyKey{
+		PlayerID:              params.PlayerID,
+		ManufacturerID:        params.ManufacturerID,
+		ExternalTransactionID: params.ReservationID,
+		SubTransactionID:      "1",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil || reservation.Type != domain.TxReserve {
+		return nil, domain.ErrNotFound("reservation", params.ReservationID)
+	}
+
+	cancelled, err := e.transactions.FindByTarget(ctx, tx, reservation.ID, domain.TxCancelReserve)
+	if err != nil {
+		return nil, fmt.Errorf("release find cancel: %w", err)
+	}
+	if cancelled != nil {
+		return nil, domain.ErrConflict("reservation was cancelled")
+	}
+
+	roundID := params.GameRoundID
+	if roundID == "" && reservation.GameRoundID != nil {
+		roundID = *reservation.GameRoundID
+	}
+
+	// The stake leg is keyed by the reservation so a replayed release finds it.
+	var result *domain.CommandResult
+	settled, err := e.transactions.FindByTarget(ctx, tx, reservation.ID, domain.TxBet)
+	if err != nil {
+		return nil, fmt.Errorf("release find bet: %w", err)
+	}
+	if settled != nil {
+		result = &domain.CommandResult{Transaction: settled, Player: player, Idempotent: true}
+	} else {
+		realBet, bonusBet := extractBetSplit(reservation)
+		reservationID := reservation.ID
+		entry, updatedPlayer, err := e.PostLedgerEntry(ctx, tx, domain.PostLedgerEntryParams{
+			PlayerID:              params.PlayerID,
+			Type:                  domain.TxBet,
+			Amount:                reservation.Amount,
+			BalanceUpdate:         domain.BalanceUpdate{ReservedBalance: -reservation.Amount},
+			ExternalTransactionID: strPtr(params.ReservationID),
+			ManufacturerID:        strPtr(params.ManufacturerID),
+			SubTransactionID:      strPtr(releaseSubTransactionID),
+			TargetTransactionID:   &reservationID,
+			GameRoundID:           strPtr(roundID),
+			Metadata: mergeMeta(params.Metadata, map[string]interface{}{
+				"realBet":  realBet,
+				"bonusBet": bonusBet,
+				"released": true,
+			}),
+			Currency: params.Currency,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("release post: %w", err)
+		}
+		result = &domain.CommandResult{
+			Transaction: entry,
+			Player:      updatedPlayer,
+			Events:      []domain.OutboxDraft{domain.NewTransactionPostedEvent(entry)},
+		}
+	}
+
+	if params.WinAmount == 0 {
+		return result, nil
+	}
+
+	win, err := e.ExecuteCreditWin(ctx, tx, domain.CreditWinParams{
+		PlayerID:              params.PlayerID,
+		Amount:                params.WinAmount,
+		ExternalTransactionID: params.ExternalTransactionID,
+		ManufacturerID:        params.ManufacturerID,
+		SubTransactionID:      "1",
+		GameRoundID:           roundID,
+		WinType:               domain.CasinoWinNormal,
+		Metadata:              params.Metadata,
+		Currency:              params.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("release win: %w", err)
+	}
+	win.Events = append(result.Events, win.Events...)
+	win.Idempotent = win.Idempotent && result.Idempotent
+	return win, nil
+}
@@ -0,0 +1,123 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// transferManufacturer tags every leg of a player-to-player transfer in the
+// idempotency key; the legs are told apart by sub_transaction_id.
+const transferManufacturer = "p2p"
+
+// ExecuteTransfer moves real balance from one player to another: a
+// p2p_transfer_out debit on the sender, a p2p_transfer_fee debit when a fee
+// applies, and a p2p_transfer_in credit on the recipient, posted in the
+// caller's transaction. Both wallets are locked in player-id order so
+// opposing transfers cannot deadlock.
+// Pattern: Lock both wallets → Idempotency → PostLedgerEntry ×2-3
+func (e *Engine) ExecuteTransfer(ctx context.Context, tx pgx.Tx, params domain.TransferParams) (*domain.TransferResult, error) {
+	if err := domain.ValidatePositiveAmount(params.Amount); err != nil {
+		return nil, err
+	}
+	if params.Fee < 0 {
+		return nil, domain.ErrValidation("fee must not be negative")
+	}
+	if params.SenderID == params.RecipientID {
+		return nil, domain.ErrValidation("cannot transfer to yourself")
+	}
+
+	// Lock
+	var sender *domain.Player
+	for _, playerID := range lockOrder(params.SenderID, params.RecipientID) {
+		player, err := e.LockWalletForUpdate(ctx, tx, playerID, params.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("transfer: %w", err)
+		}
+		if playerID == params.SenderID {
+			sender = player
+		}
+	}
+
+	// Idempotency check — the debit leg is written first, so it decides
+	debit, err := e.FindExistingTransaction(ctx, tx, transferKey(params, params.SenderID, "out"))
+	if err != nil {
+		return nil, err
+	}
+	if debit != nil {
+		credit, err := e.FindExistingTransaction(ctx, tx, transferKey(params, params.RecipientID, "in"))
+		if err != nil {
+			return nil, err
+		}
+		fee, err := e.FindExistingTransaction(ctx, tx, transferKey(params, params.SenderID, "fee"))
+		if err != nil {
+			return nil, err
+		}
+		return &domain.TransferResult{Debit: debit, Credit: credit, Fee: fee, Idempotent: true}, nil
+	}
+
+	// Only real balance transfers; bonus funds stay with the sender
+	if sender.Balance < params.Amount+params.Fee {
+		return nil, domain.ErrInsufficientBalance()
+	}
+
+	meta := mergeMeta(nil, map[string]interface{}{
+		"transferId":  params.TransferID,
+		"senderId":    params.SenderID,
+		"recipientId": params.RecipientID,
+		"amount":      params.Amount,
+		"fee":         params.Fee,
+	})
+	leg := func(playerID uuid.UUID, txType domain.TransactionType, amount, delta int64, sub string, target *uuid.UUID) (*domain.Transaction, error) {
+		entry, _, err := e.PostLedgerEntry(ctx, tx, domain.PostLedgerEntryParams{
+			PlayerID:              playerID,
+			Type:                  txType,
+			Amount:                amount,
+			BalanceUpdate:         domain.BalanceUpdate{Balance: delta},
+			ExternalTransactionID: strPtr(params.TransferID.String()),
+			ManufacturerID:        strPtr(transferManufacturer),
+			SubTransactionID:      strPtr(sub),
+			TargetTransactionID:   target,
+			Metadata:              meta,
+			Currency:              params.Currency,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("transfer %s post: %w", sub, err)
+		}
+		return entry, nil
+	}
+
+	result := &domain.TransferResult{}
+	if result.Debit, err = leg(params.SenderID, domain.TxTransferOut, params.Amount, -params.Amount, "out", nil); err != nil {
+		return nil, err
+	}
+	if params.Fee > 0 {
+		if result.Fee, err = leg(params.SenderID, domain.TxTransferFee, params.Fee, -params.Fee, "fee", &result.Debit.ID); err != nil {
+			return nil, err
+		}
+	}
+	if result.Credit, err = leg(params.RecipientID, domain.TxTransferIn, params.Amount, params.Amount, "in", &result.Debit.ID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lockOrder returns two player ids in the order their rows must be locked.
+func lockOrder(a, b uuid.UUID) [2]uuid.UUID {
+	if b.String() < a.String() {
+		return [2]uuid.UUID{b, a}
+	}
+	return [2]uuid.UUID{a, b}
+}
+
+func transferKey(params domain.TransferParams, playerID uuid.UUID, leg string) domain.IdempotencyKey {
+	return domain.IdempotencyKey{
+		PlayerID:              playerID,
+		ManufacturerID:        transferManufacturer,
+		ExternalTransactionID: params.TransferID.String(),
+		SubTransactionID:      leg,
+	}
+}
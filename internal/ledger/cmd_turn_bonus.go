@@ -16,7 +16,7 @@ func (e *Engine) ExecuteTurnBonusToReal(ctx context.Context, tx pgx.Tx, params d
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("turn bonus: %w", err)
 	}
@@ -48,6 +48,7 @@ func (e *Engine) ExecuteTurnBonusToReal(ctx context.Context, tx pgx.Tx, params d
 		BalanceUpdate:         domain.BalanceUpdate{Balance: params.Amount, BonusBalance: -params.Amount},
 		ExternalTransactionID: strPtr(extID),
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("turn bonus post: %w", err)
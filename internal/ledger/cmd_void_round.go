@@ -0,0 +1,86 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ExecuteVoidRound voids an abandoned game round and refunds the stakes no
+// result settled, each through a cancellation keyed "void_<external id>" so
+// a retried sweep finds it. The player is locked before the round, the same
+// order a provider callback takes them, so a late result either lands first
+// and the round is no longer open, or waits and lands on the voided round.
+// Returns a nil result when the round is no longer open and idle.
+func (e *Engine) ExecuteVoidRound(ctx context.Context, tx pgx.Tx, params domain.VoidRoundParams) (*domain.CommandResult, error) {
+	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	if err != nil {
+		return nil, fmt.Errorf("void round: %w", err)
+	}
+
+	round, err := e.rounds.LockOpen(ctx, tx, params.RoundID, params.IdleSince)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil || round.PlayerID != params.PlayerID {
+		return nil, nil
+	}
+
+	txs, err := e.transactions.ListByGameRound(ctx, tx, round.RoundID)
+	if err != nil {
+		return nil, fmt.Errorf("void round list: %w", err)
+	}
+	var own []domain.Transaction
+	for _, t := range txs {
+		if t.PlayerID == round.PlayerID && t.ManufacturerID != nil && *t.ManufacturerID == round.ManufacturerID {
+			own = append(own, t)
+		}
+	}
+
+	result := &domain.CommandResult{Player: player}
+	var refunded int64
+	for _, stake := range unsettledStakes(own) {
+		cancelType := domain.CancellationTypeMap[stake.Type]
+		cancelled, err := e.transactions.FindByTarget(ctx, tx, stake.ID, cancelType)
+		if err != nil {
+			return nil, fmt.Errorf("void round find cancel: %w", err)
+		}
+		if cancelled != nil {
+			continue
+		}
+
+		externalID := stake.ID.String()
+		if stake.ExternalTransactionID != nil {
+			externalID = *stake.ExternalTransactionID
+		}
+		subID := "1"
+		if stake.SubTransactionID != nil {
+			subID = *stake.SubTransactionID
+		}
+		cancel, err := e.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+			PlayerID:              round.PlayerID,
+			Amount:                stake.Amount,
+			ExternalTransactionID: "void_" + externalID,
+			ManufacturerID:        round.ManufacturerID,
+			SubTransactionID:      subID,
+			TargetTransactionID:   stake.ID,
+			Metadata:              mergeMeta(nil, map[string]interface{}{"reason": "stuck_round"}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("void round refund: %w", err)
+		}
+		if !cancel.Idempotent {
+			refunded += stake.Amount
+		}
+		result.Transaction = cancel.Transaction
+		result.Player = cancel.Player
+		result.Events = append(result.Events, cancel.Events...)
+	}
+
+	if err := e.rounds.MarkVoided(ctx, tx, round.ID, refunded); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -16,7 +16,7 @@ func (e *Engine) ExecuteWithdraw(ctx context.Context, tx pgx.Tx, params domain.W
 	}
 
 	// Lock
-	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	player, err := e.LockWalletForUpdate(ctx, tx, params.PlayerID, params.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("withdraw: %w", err)
 	}
@@ -48,6 +48,7 @@ func (e *Engine) ExecuteWithdraw(ctx context.Context, tx pgx.Tx, params domain.W
 		BalanceUpdate:         domain.BalanceUpdate{Balance: -params.Amount, ReservedBalance: params.Amount},
 		ExternalTransactionID: strPtr(extID),
 		Metadata:              ensureJSON(params.Metadata),
+		Currency:              params.Currency,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("withdraw post: %w", err)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -188,3 +189,58 @@ func TestJsonUnmarshal(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// --- unsettledStakes Tests ---
+
+func TestUnsettledStakes(t *testing.T) {
+	target := uuid.New()
+	bet := func(amount int64) domain.Transaction {
+		return domain.Transaction{ID: uuid.New(), Type: domain.TxBet, Amount: amount}
+	}
+	reserve := domain.Transaction{ID: uuid.New(), Type: domain.TxReserve, Amount: 300}
+	released := domain.Transaction{ID: uuid.New(), Type: domain.TxBet, Amount: 300, TargetTransactionID: &target}
+	win := domain.Transaction{ID: uuid.New(), Type: domain.TxWin, Amount: 500}
+
+	t.Run("no result leaves every stake", func(t *testing.T) {
+		b1, b2 := bet(100), bet(200)
+		pending := unsettledStakes([]domain.Transaction{b1, b2})
+		require.Len(t, pending, 2)
+		assert.Equal(t, b1.ID, pending[0].ID)
+		assert.Equal(t, b2.ID, pending[1].ID)
+	})
+
+	t.Run("win settles earlier stakes", func(t *testing.T) {
+		b1, b2 := bet(100), bet(200)
+		pending := unsettledStakes([]domain.Transaction{b1, win, b2})
+		require.Len(t, pending, 1)
+		assert.Equal(t, b2.ID, pending[0].ID)
+	})
+
+	t.Run("cash out settles earlier stakes", func(t *testing.T) {
+		cashOut := domain.Transaction{ID: uuid.New(), Type: domain.TxCashOut, Amount: 150}
+		assert.Empty(t, unsettledStakes([]domain.Transaction{bet(100), cashOut}))
+	})
+
+	t.Run("release settles its reservation", func(t *testing.T) {
+		assert.Empty(t, unsettledStakes([]domain.Transaction{reserve, released}))
+	})
+
+	t.Run("open reservation is unsettled", func(t *testing.T) {
+		pending := unsettledStakes([]domain.Transaction{bet(100), win, reserve})
+		require.Len(t, pending, 1)
+		assert.Equal(t, domain.TxReserve, pending[0].Type)
+	})
+
+	t.Run("empty round", func(t *testing.T) {
+		assert.Empty(t, unsettledStakes(nil))
+	})
+}
+
+// --- lockOrder Tests ---
+
+func TestLockOrder(t *testing.T) {
+	a := uuid.MustParse("10000000-0000-0000-0000-000000000000")
+	b := uuid.MustParse("20000000-0000-0000-0000-000000000000")
+	assert.Equal(t, [2]uuid.UUID{a, b}, lockOrder(a, b))
+	assert.Equal(t, [2]uuid.UUID{a, b}, lockOrder(b, a))
+}
@@ -3,6 +3,7 @@ package ledger
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/repository"
@@ -16,20 +17,32 @@ import (
 //   3. PostLedgerEntry — atomic balance update + append-only insert + outbox event
 type Engine struct {
 	players      repository.PlayerRepository
+	wallets      repository.WalletRepository
 	transactions repository.TransactionRepository
+	entries      repository.LedgerEntryRepository
 	outbox       repository.OutboxRepository
+	rounds       repository.GameRoundRepository
+	bonuses      repository.BonusRepository
 }
 
 // NewEngine creates a ledger engine with the given repositories.
 func NewEngine(
 	players repository.PlayerRepository,
+	wallets repository.WalletRepository,
 	transactions repository.TransactionRepository,
+	entries repository.LedgerEntryRepository,
 	outbox repository.OutboxRepository,
+	rounds repository.GameRoundRepository,
+	bonuses repository.BonusRepository,
 ) *Engine {
 	return &Engine{
 		players:      players,
+		wallets:      wallets,
 		transactions: transactions,
+		entries:      entries,
 		outbox:       outbox,
+		rounds:       rounds,
+		bonuses:      bonuses,
 	}
 }
 
@@ -46,6 +59,41 @@ func (e *Engine) LockPlayerForUpdate(ctx context.Context, tx pgx.Tx, playerID uu
 	return player, nil
 }
 
+// LockWalletForUpdate locks the player and, for a non-base currency, the
+// matching player_wallets row. The returned player carries that wallet's
+// balances and currency so commands can check funds without caring which
+// table backs it. An empty currency is the base wallet.
+func (e *Engine) LockWalletForUpdate(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, currency string) (*domain.Player, error) {
+	player, err := e.LockPlayerForUpdate(ctx, tx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if isBaseCurrency(player, currency) {
+		return player, nil
+	}
+
+	wallet, err := e.wallets.LockForUpdate(ctx, tx, playerID, strings.ToUpper(currency))
+	if err != nil {
+		return nil, fmt.Errorf("lock wallet: %w", err)
+	}
+	if wallet == nil {
+		return nil, domain.ErrNotFound("wallet", strings.ToUpper(currency))
+	}
+	return walletView(player, wallet), nil
+}
+
+func isBaseCurrency(player *domain.Player, currency string) bool {
+	return currency == "" || strings.EqualFold(currency, player.Currency)
+}
+
+// walletView returns a copy of player holding the wallet's balances.
+func walletView(player *domain.Player, wallet *domain.Wallet) *domain.Player {
+	view := *player
+	view.Balances = wallet.Balances
+	view.Currency = wallet.Currency
+	return &view
+}
+
 // FindExistingTransaction checks if a transaction with the same idempotency key exists.
 // Returns nil if no duplicate found.
 func (e *Engine) FindExistingTransaction(ctx context.Context, tx pgx.Tx, key domain.IdempotencyKey) (*domain.Transaction, error) {
@@ -60,16 +108,18 @@ func (e *Engine) FindExistingTransaction(ctx context.Context, tx pgx.Tx, key dom
 // This is the core write primitive — all 9 commands delegate to this.
 //
 // Steps:
-//  1. Update player balances using server-side arithmetic (dynamic SET clauses)
-//  2. Insert transaction with the post-update balance snapshot
+//  1. Update player (or currency wallet) balances using server-side arithmetic
+//  2. Insert transaction with the post-update balance snapshot and its
+//     balanced double-entry postings
 //  3. Insert outbox event
+//  4. Track the game round the entry belongs to, if any
 //
-// All 3 steps run within the caller's transaction.
+// All 4 steps run within the caller's transaction.
 func (e *Engine) PostLedgerEntry(ctx context.Context, tx pgx.Tx, params domain.PostLedgerEntryParams) (*domain.Transaction, *domain.Player, error) {
 	// Step 1: Atomic balance update with server-side arithmetic
-	updatedPlayer, err := e.players.UpdateBalances(ctx, tx, params.PlayerID, params.BalanceUpdate)
+	updatedPlayer, err := e.updateBalances(ctx, tx, &params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("update balances: %w", err)
+		return nil, nil, err
 	}
 
 	// Step 2: Insert ledger entry with post-update balance snapshot
@@ -77,6 +127,13 @@ func (e *Engine) PostLedgerEntry(ctx context.Context, tx pgx.Tx, params domain.P
 	if err != nil {
 		return nil, nil, fmt.Errorf("insert transaction: %w", err)
 	}
+	postings := buildPostings(params, entry.Currency)
+	if err := checkBalanced(postings); err != nil {
+		return nil, nil, fmt.Errorf("post ledger entries: %w", err)
+	}
+	if err := e.entries.Insert(ctx, tx, entry.ID, postings); err != nil {
+		return nil, nil, fmt.Errorf("insert ledger entries: %w", err)
+	}
 
 	// Step 3: Insert outbox event (same transaction for atomicity)
 	event := domain.NewTransactionPostedEvent(entry)
@@ -84,5 +141,62 @@ func (e *Engine) PostLedgerEntry(ctx context.Context, tx pgx.Tx, params domain.P
 		return nil, nil, fmt.Errorf("insert outbox event: %w", err)
 	}
 
+	// Step 4: Open or close the game round
+	if err := e.trackRound(ctx, tx, params, entry); err != nil {
+		return nil, nil, err
+	}
+
 	return entry, updatedPlayer, nil
 }
+
+// VerifyBalanced checks the double-entry invariant across the whole ledger:
+// total debits equal total credits and no transaction is individually
+// unbalanced. Up to 100 offending transaction IDs are reported.
+func (e *Engine) VerifyBalanced(ctx context.Context, db repository.DBTX) (*domain.LedgerBalanceReport, error) {
+	debits, credits, err := e.entries.Totals(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	unbalanced, err := e.entries.Unbalanced(ctx, db, 100)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LedgerBalanceReport{
+		Balanced:     debits == credits && len(unbalanced) == 0,
+		TotalDebits:  debits,
+		TotalCredits: credits,
+		Unbalanced:   unbalanced,
+	}, nil
+}
+
+// updateBalances applies the delta to the wallet named by params.Currency.
+// The base currency has no player_wallets row, so a miss falls through to
+// v2_players. params.Currency is normalised to the posted wallet's currency.
+func (e *Engine) updateBalances(ctx context.Context, tx pgx.Tx, params *domain.PostLedgerEntryParams) (*domain.Player, error) {
+	if params.Currency != "" {
+		params.Currency = strings.ToUpper(params.Currency)
+		wallet, err := e.wallets.UpdateBalances(ctx, tx, params.PlayerID, params.Currency, params.BalanceUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("update wallet balances: %w", err)
+		}
+		if wallet != nil {
+			player, err := e.players.FindByID(ctx, tx, params.PlayerID)
+			if err != nil {
+				return nil, fmt.Errorf("find player: %w", err)
+			}
+			if player == nil {
+				return nil, domain.ErrNotFound("player", params.PlayerID.String())
+			}
+			return walletView(player, wallet), nil
+		}
+	}
+
+	player, err := e.players.UpdateBalances(ctx, tx, params.PlayerID, params.BalanceUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("update balances: %w", err)
+	}
+	if !isBaseCurrency(player, params.Currency) {
+		return nil, domain.ErrNotFound("wallet", params.Currency)
+	}
+	return player, nil
+}
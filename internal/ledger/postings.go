@@ -0,0 +1,80 @@
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// buildPostings turns a balance delta into balanced double-entry legs. Each
+// non-zero tier delta moves the player's account (credit = balance up); the
+// net amount is offset against the counterparty for the transaction type.
+// Pure transfers between a player's own tiers net to zero and need no
+// counterparty.
+func buildPostings(params domain.PostLedgerEntryParams, currency string) []domain.LedgerPosting {
+	var postings []domain.LedgerPosting
+	add := func(account string, delta int64) {
+		switch {
+		case delta > 0:
+			postings = append(postings, domain.LedgerPosting{Account: account, Direction: domain.Credit, Amount: delta, Currency: currency})
+		case delta < 0:
+			postings = append(postings, domain.LedgerPosting{Account: account, Direction: domain.Debit, Amount: -delta, Currency: currency})
+		}
+	}
+
+	delta := params.BalanceUpdate
+	add(domain.PlayerAccount(params.PlayerID, domain.TierCash), delta.Balance)
+	add(domain.PlayerAccount(params.PlayerID, domain.TierBonus), delta.BonusBalance)
+	add(domain.PlayerAccount(params.PlayerID, domain.TierReserved), delta.ReservedBalance)
+
+	// The counterparty moves opposite to the player's net change.
+	net := delta.Balance + delta.BonusBalance + delta.ReservedBalance
+	add(counterpartyAccount(params), -net)
+	return postings
+}
+
+// counterpartyAccount picks the operator or provider account on the other
+// side of a player balance change.
+func counterpartyAccount(params domain.PostLedgerEntryParams) string {
+	manufacturer := ""
+	if params.ManufacturerID != nil {
+		manufacturer = *params.ManufacturerID
+	}
+
+	switch params.Type {
+	case domain.TxDeposit, domain.TxCancelDeposit, domain.TxWithdrawalProcessed:
+		return domain.AccountPaymentClearing
+	case domain.TxBet, domain.TxWin, domain.TxCashOut, domain.TxCancelBet, domain.TxCancelWin, domain.TxSettlementLoss:
+		if manufacturer != "" {
+			return domain.ProviderAccount(manufacturer)
+		}
+		return domain.AccountHouseGaming
+	case domain.TxBonusCredit, domain.TxBonusForfeit, domain.TxBonusLost:
+		return domain.AccountHouseBonusPool
+	case domain.TxConversionOut, domain.TxConversionIn:
+		return domain.AccountHouseFX
+	case domain.TxTransferOut, domain.TxTransferIn:
+		return domain.AccountP2PClearing
+	case domain.TxTransferFee:
+		return domain.AccountHouseFees
+	}
+	return domain.AccountSuspense
+}
+
+// checkBalanced verifies debits equal credits per currency.
+func checkBalanced(postings []domain.LedgerPosting) error {
+	sums := make(map[string]int64)
+	for _, p := range postings {
+		if p.Direction == domain.Debit {
+			sums[p.Currency] += p.Amount
+		} else {
+			sums[p.Currency] -= p.Amount
+		}
+	}
+	for currency, diff := range sums {
+		if diff != 0 {
+			return fmt.Errorf("unbalanced postings in %s: debits-credits=%d", currency, diff)
+		}
+	}
+	return nil
+}
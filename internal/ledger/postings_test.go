@@ -0,0 +1,156 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- buildPostings Tests ---
+
+func TestBuildPostings(t *testing.T) {
+	playerID := uuid.New()
+	cash := domain.PlayerAccount(playerID, domain.TierCash)
+	bonus := domain.PlayerAccount(playerID, domain.TierBonus)
+	reserved := domain.PlayerAccount(playerID, domain.TierReserved)
+
+	t.Run("deposit credits player against payment clearing", func(t *testing.T) {
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxDeposit,
+			BalanceUpdate: domain.BalanceUpdate{Balance: 5000},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		assert.Equal(t, []domain.LedgerPosting{
+			{Account: cash, Direction: domain.Credit, Amount: 5000, Currency: "EUR"},
+			{Account: domain.AccountPaymentClearing, Direction: domain.Debit, Amount: 5000, Currency: "EUR"},
+		}, postings)
+	})
+
+	t.Run("split bet debits both tiers against house", func(t *testing.T) {
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxBet,
+			BalanceUpdate: domain.BalanceUpdate{Balance: -300, BonusBalance: -200},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		require.Len(t, postings, 3)
+		assert.Equal(t, domain.LedgerPosting{Account: domain.AccountHouseGaming, Direction: domain.Credit, Amount: 500, Currency: "EUR"}, postings[2])
+	})
+
+	t.Run("manufacturer routes to provider account", func(t *testing.T) {
+		mfg := "evolution"
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:       playerID,
+			Type:           domain.TxWin,
+			ManufacturerID: &mfg,
+			BalanceUpdate:  domain.BalanceUpdate{Balance: 800},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		assert.Equal(t, "provider:evolution", postings[1].Account)
+		assert.Equal(t, domain.Debit, postings[1].Direction)
+	})
+
+	t.Run("cash out credits player against provider", func(t *testing.T) {
+		mfg := "sportsbook"
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:       playerID,
+			Type:           domain.TxCashOut,
+			ManufacturerID: &mfg,
+			BalanceUpdate:  domain.BalanceUpdate{Balance: 650},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		assert.Equal(t, domain.LedgerPosting{Account: "provider:sportsbook", Direction: domain.Debit, Amount: 650, Currency: "EUR"}, postings[1])
+	})
+
+	t.Run("turn bonus to real has no counterparty", func(t *testing.T) {
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxTurnBonusToReal,
+			BalanceUpdate: domain.BalanceUpdate{Balance: 1000, BonusBalance: -1000},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		require.Len(t, postings, 2)
+		assert.Equal(t, bonus, postings[1].Account)
+	})
+
+	t.Run("withdrawal moves cash into reserved", func(t *testing.T) {
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxWithdrawal,
+			BalanceUpdate: domain.BalanceUpdate{Balance: -2000, ReservedBalance: 2000},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		assert.Equal(t, []domain.LedgerPosting{
+			{Account: cash, Direction: domain.Debit, Amount: 2000, Currency: "EUR"},
+			{Account: reserved, Direction: domain.Credit, Amount: 2000, Currency: "EUR"},
+		}, postings)
+	})
+
+	t.Run("released reservation debits reserved against provider", func(t *testing.T) {
+		mfg := "netent"
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:       playerID,
+			Type:           domain.TxBet,
+			ManufacturerID: &mfg,
+			BalanceUpdate:  domain.BalanceUpdate{ReservedBalance: -700},
+		}, "EUR")
+		require.NoError(t, checkBalanced(postings))
+		assert.Equal(t, []domain.LedgerPosting{
+			{Account: reserved, Direction: domain.Debit, Amount: 700, Currency: "EUR"},
+			{Account: "provider:netent", Direction: domain.Credit, Amount: 700, Currency: "EUR"},
+		}, postings)
+	})
+
+	t.Run("conversion offsets against fx account", func(t *testing.T) {
+		postings := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxConversionIn,
+			BalanceUpdate: domain.BalanceUpdate{Balance: 4340},
+		}, "USD")
+		assert.Equal(t, domain.AccountHouseFX, postings[1].Account)
+		assert.Equal(t, "USD", postings[1].Currency)
+	})
+
+	t.Run("p2p transfer legs offset against clearing, fee against house", func(t *testing.T) {
+		out := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxTransferOut,
+			BalanceUpdate: domain.BalanceUpdate{Balance: -1000},
+		}, "EUR")
+		assert.Equal(t, domain.LedgerPosting{Account: domain.AccountP2PClearing, Direction: domain.Credit, Amount: 1000, Currency: "EUR"}, out[1])
+		fee := buildPostings(domain.PostLedgerEntryParams{
+			PlayerID:      playerID,
+			Type:          domain.TxTransferFee,
+			BalanceUpdate: domain.BalanceUpdate{Balance: -20},
+		}, "EUR")
+		assert.Equal(t, domain.AccountHouseFees, fee[1].Account)
+	})
+}
+
+// --- checkBalanced Tests ---
+
+func TestCheckBalanced(t *testing.T) {
+	t.Run("empty is balanced", func(t *testing.T) {
+		assert.NoError(t, checkBalanced(nil))
+	})
+
+	t.Run("mismatch is rejected", func(t *testing.T) {
+		err := checkBalanced([]domain.LedgerPosting{
+			{Account: "a", Direction: domain.Debit, Amount: 100, Currency: "EUR"},
+			{Account: "b", Direction: domain.Credit, Amount: 90, Currency: "EUR"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("currencies balance independently", func(t *testing.T) {
+		err := checkBalanced([]domain.LedgerPosting{
+			{Account: "a", Direction: domain.Debit, Amount: 100, Currency: "EUR"},
+			{Account: "b", Direction: domain.Credit, Amount: 100, Currency: "USD"},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// trackRound keeps game_rounds in step with a posted entry. A stake (bet or
+// reservation) opens the round; a win or cash-out, or the stake leg a release posts,
+// closes it. Cancellations carry no round and leave it as is.
+func (e *Engine) trackRound(ctx context.Context, tx pgx.Tx, params domain.PostLedgerEntryParams, entry *domain.Transaction) error {
+	if params.GameRoundID == nil || params.ManufacturerID == nil {
+		return nil
+	}
+	playerID, mfgID, roundID := params.PlayerID, *params.ManufacturerID, *params.GameRoundID
+	var gameID string
+	if params.GameID != nil {
+		gameID = *params.GameID
+	}
+
+	switch params.Type {
+	case domain.TxReserve:
+		return e.rounds.RecordStake(ctx, tx, playerID, mfgID, roundID, gameID, entry.Currency, params.Amount)
+	case domain.TxBet:
+		if params.TargetTransactionID != nil {
+			// Released reservation: its stake was counted when reserved.
+			return e.rounds.RecordSettlement(ctx, tx, playerID, mfgID, roundID, entry.Currency, 0)
+		}
+		return e.rounds.RecordStake(ctx, tx, playerID, mfgID, roundID, gameID, entry.Currency, params.Amount)
+	case domain.TxWin, domain.TxCashOut:
+		return e.rounds.RecordSettlement(ctx, tx, playerID, mfgID, roundID, entry.Currency, params.Amount)
+	}
+	return nil
+}
+
+// unsettledStakes returns the stakes in a round's transactions (oldest
+// first) that no later result settled: every bet or reservation after the
+// round's last win, cash-out or release.
+func unsettledStakes(txs []domain.Transaction) []domain.Transaction {
+	var pending []domain.Transaction
+	for _, t := range txs {
+		switch {
+		case t.Type == domain.TxWin, t.Type == domain.TxCashOut, t.Type == domain.TxBet && t.TargetTransactionID != nil:
+			pending = nil
+		case t.Type == domain.TxBet, t.Type == domain.TxReserve:
+			pending = append(pending, t)
+		}
+	}
+	return pending
+}
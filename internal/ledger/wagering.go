@@ -0,0 +1,128 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/jackc/pgx/v5"
+)
+
+// sportsbookManufacturerID is the manufacturer the sportsbook posts its
+// bets under. Its stakes are weighted as a category rather than looked up in
+// the game catalog.
+const sportsbookManufacturerID = "sportsbook"
+
+// trackWagering counts a base-currency bet toward the player's active
+// bonuses, weighted by the game it was placed on. A bonus whose requirement
+// is met is completed and what is left of its bonus balance, up to the
+// amount granted, is turned into real money. Active bonuses found past their
+// expiry are forfeited instead of counted. It returns the combined result of
+// those ledger commands, or nil if none was posted.
+func (e *Engine) trackWagering(ctx context.Context, tx pgx.Tx, params domain.PlaceBetParams) (*domain.CommandResult, error) {
+	player, err := e.LockPlayerForUpdate(ctx, tx, params.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if !isBaseCurrency(player, params.Currency) {
+		return nil, nil
+	}
+
+	active, err := e.bonuses.LockActive(ctx, tx, params.PlayerID)
+	if err != nil || len(active) == 0 {
+		return nil, err
+	}
+
+	var posted *domain.CommandResult
+	collect := func(result *domain.CommandResult) {
+		if result == nil {
+			return
+		}
+		if posted != nil {
+			result.Events = append(posted.Events, result.Events...)
+		}
+		posted = result
+	}
+
+	now := time.Now()
+	var bonuses []domain.PlayerBonus
+	for _, b := range active {
+		if !b.IsExpired(now) {
+			bonuses = append(bonuses, b)
+			continue
+		}
+		result, err := e.expireBonus(ctx, tx, b)
+		if err != nil {
+			return nil, err
+		}
+		collect(result)
+	}
+	if len(bonuses) == 0 {
+		return posted, nil
+	}
+
+	game := policy.WageringGame{}
+	switch {
+	case params.ManufacturerID == sportsbookManufacturerID:
+		game.Category = policy.WageringCategorySportsbook
+	case params.ManufacturerID != "" && params.GameID != "":
+		game.Category, game.OverrideBps, err = e.bonuses.FindGameWagering(ctx, tx, params.ManufacturerID, params.GameID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	contribution := policy.WageringContribution(params.Amount, game)
+
+	progress := make([]policy.WageringProgress, len(bonuses))
+	for i, b := range bonuses {
+		progress[i] = policy.WageringProgress{Requirement: b.WageringRequirement, Wagered: b.Wagered}
+	}
+	for i, added := range policy.AllocateWagering(progress, contribution) {
+		if added == 0 {
+			continue
+		}
+		b := bonuses[i]
+		b.Wagered += added
+		status := domain.BonusStatusActive
+		if b.IsWageringComplete() {
+			status = domain.BonusStatusCompleted
+		}
+		if err := e.bonuses.AddWagered(ctx, tx, b.ID, added, status); err != nil {
+			return nil, err
+		}
+		if status != domain.BonusStatusCompleted {
+			continue
+		}
+		result, err := e.releaseBonus(ctx, tx, b)
+		if err != nil {
+			return nil, err
+		}
+		collect(result)
+	}
+	return posted, nil
+}
+
+// releaseBonus turns a completed bonus's remaining bonus balance, capped at
+// the amount granted, into real money.
+func (e *Engine) releaseBonus(ctx context.Context, tx pgx.Tx, b domain.PlayerBonus) (*domain.CommandResult, error) {
+	player, err := e.LockPlayerForUpdate(ctx, tx, b.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	amount := min(player.BonusBalance, b.InitialAmount)
+	if amount <= 0 {
+		return nil, nil
+	}
+	result, err := e.ExecuteTurnBonusToReal(ctx, tx, domain.TurnBonusToRealParams{
+		PlayerID:              b.PlayerID,
+		Amount:                amount,
+		ExternalTransactionID: "bonus-wagering-" + b.ID.String(),
+		Metadata:              mergeMeta(nil, map[string]interface{}{"playerBonusId": b.ID.String(), "reason": "wagering_complete"}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("release bonus %s: %w", b.ID, err)
+	}
+	return result, nil
+}
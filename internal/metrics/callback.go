@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SLOConfig configures the wallet callback latency objective and its
+// multi-window burn-rate alert.
+type SLOConfig struct {
+	Threshold     time.Duration // callbacks slower than this spend budget
+	Target        float64       // share of callbacks that must meet Threshold
+	AlertBurnRate float64       // alert when both windows burn at least this fast
+	ShortWindow   time.Duration
+	LongWindow    time.Duration
+}
+
+// DefaultSLOConfig is 99% of callbacks committed within 250ms, alerting on a
+// 14.4x burn (2% of a 30-day budget in an hour) over 5m and 1h windows.
+func DefaultSLOConfig() SLOConfig {
+	return SLOConfig{
+		Threshold:     250 * time.Millisecond,
+		Target:        0.99,
+		AlertBurnRate: 14.4,
+		ShortWindow:   5 * time.Minute,
+		LongWindow:    time.Hour,
+	}
+}
+
+// CallbackLatency measures the time from provider callback receipt to ledger
+// commit, per provider and action, and tracks the latency SLO per provider.
+type CallbackLatency struct {
+	cfg    SLOConfig
+	hist   *HistogramVec
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	slos     map[string]*BurnRateSLO
+	alerting map[string]bool
+}
+
+// NewCallbackLatency creates a CallbackLatency recorder.
+func NewCallbackLatency(cfg SLOConfig, logger *slog.Logger) *CallbackLatency {
+	return &CallbackLatency{
+		cfg: cfg,
+		hist: NewHistogramVec("wallet_callback_commit_seconds",
+			"Time from provider callback receipt to ledger commit.",
+			[]string{"provider", "action", "outcome"}, DefaultLatencyBuckets),
+		logger:   logger,
+		slos:     make(map[string]*BurnRateSLO),
+		alerting: make(map[string]bool),
+	}
+}
+
+// Observe records one callback. A non-nil err marks the outcome as error.
+func (c *CallbackLatency) Observe(provider, action string, d time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	c.hist.Observe(d, provider, action, outcome)
+
+	slo := c.slo(provider)
+	slo.Record(d, err != nil)
+	c.evaluate(provider, slo)
+}
+
+// Alerting reports whether the provider's burn-rate alert is firing.
+func (c *CallbackLatency) Alerting(provider string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.alerting[provider]
+}
+
+// ErrorRate returns the share of the provider's callbacks that failed over
+// the trailing window.
+func (c *CallbackLatency) ErrorRate(provider string, window time.Duration) float64 {
+	return c.slo(provider).ErrorRate(window)
+}
+
+func (c *CallbackLatency) slo(provider string) *BurnRateSLO {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.slos[provider]
+	if !ok {
+		s = NewBurnRateSLO(c.cfg.Threshold, c.cfg.Target)
+		c.slos[provider] = s
+	}
+	return s
+}
+
+// evaluate fires or resolves the provider's alert on state change.
+func (c *CallbackLatency) evaluate(provider string, slo *BurnRateSLO) {
+	short := slo.BurnRate(c.cfg.ShortWindow)
+	long := slo.BurnRate(c.cfg.LongWindow)
+	firing := short >= c.cfg.AlertBurnRate && long >= c.cfg.AlertBurnRate
+
+	c.mu.Lock()
+	was := c.alerting[provider]
+	c.alerting[provider] = firing
+	c.mu.Unlock()
+
+	switch {
+	case firing && !was:
+		c.logger.Error("wallet callback latency SLO burn rate alert",
+			"provider", provider, "burn_rate_short", short, "burn_rate_long", long,
+			"threshold_ms", c.cfg.Threshold.Milliseconds(), "target", c.cfg.Target)
+	case !firing && was:
+		c.logger.Info("wallet callback latency SLO burn rate resolved",
+			"provider", provider, "burn_rate_short", short, "burn_rate_long", long)
+	}
+}
+
+// WriteTo writes the histogram and SLO gauges in Prometheus text format.
+func (c *CallbackLatency) WriteTo(w io.Writer) (int64, error) {
+	n, err := c.hist.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+
+	c.mu.Lock()
+	providers := make([]string, 0, len(c.slos))
+	for p := range c.slos {
+		providers = append(providers, p)
+	}
+	c.mu.Unlock()
+	sort.Strings(providers)
+
+	var b strings.Builder
+	b.WriteString("# HELP wallet_callback_slo_burn_rate Error-budget burn rate of the callback latency SLO.\n")
+	b.WriteString("# TYPE wallet_callback_slo_burn_rate gauge\n")
+	for _, p := range providers {
+		slo := c.slo(p)
+		for _, window := range []time.Duration{c.cfg.ShortWindow, c.cfg.LongWindow} {
+			fmt.Fprintf(&b, "wallet_callback_slo_burn_rate{provider=%q,window=%q} %g\n",
+				p, shortDuration(window), slo.BurnRate(window))
+		}
+	}
+	b.WriteString("# HELP wallet_callback_slo_alert Whether the callback latency burn-rate alert is firing.\n")
+	b.WriteString("# TYPE wallet_callback_slo_alert gauge\n")
+	for _, p := range providers {
+		v := 0
+		if c.Alerting(p) {
+			v = 1
+		}
+		fmt.Fprintf(&b, "wallet_callback_slo_alert{provider=%q} %d\n", p, v)
+	}
+
+	m, err := io.WriteString(w, b.String())
+	return n + int64(m), err
+}
+
+// Handler serves the metrics for scraping.
+func (c *CallbackLatency) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		c.WriteTo(w)
+	})
+}
+
+// shortDuration renders 5m0s as 5m and 1h0m0s as 1h.
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CounterVec is a set of counters partitioned by label values. A vector
+// without labels holds a single counter.
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*counter
+}
+
+type counter struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounterVec creates a counter vector.
+func NewCounterVec(name, help string, labels []string) *CounterVec {
+	return &CounterVec{name: name, help: help, labels: labels, series: make(map[string]*counter)}
+}
+
+// Add increases the counter for the given label values by v, which must not
+// be negative.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if len(labelValues) != len(c.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d labels, got %d", c.name, len(c.labels), len(labelValues)))
+	}
+	if v < 0 {
+		panic(fmt.Sprintf("metrics: %s cannot decrease", c.name))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &counter{labelValues: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += v
+}
+
+// Value returns the counter for the given label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.series[strings.Join(labelValues, "\xff")]; ok {
+		return s.value
+	}
+	return 0
+}
+
+// WriteTo writes the counters in Prometheus text format.
+func (c *CounterVec) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+
+	keys := make([]string, 0, len(c.series))
+	for k := range c.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := c.series[k]
+		if len(c.labels) == 0 {
+			fmt.Fprintf(&b, "%s %g\n", c.name, s.value)
+			continue
+		}
+		labels := strings.TrimSuffix(formatLabels(c.labels, s.labelValues), ",")
+		fmt.Fprintf(&b, "%s{%s} %g\n", c.name, labels, s.value)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// Handler serves the given instruments for scraping.
+func Handler(instruments ...io.WriterTo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		for _, m := range instruments {
+			m.WriteTo(w)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GaugeVec is a set of gauges partitioned by label values. A vector without
+// labels holds a single gauge.
+type GaugeVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*gauge
+}
+
+type gauge struct {
+	labelValues []string
+	value       float64
+}
+
+// NewGaugeVec creates a gauge vector.
+func NewGaugeVec(name, help string, labels []string) *GaugeVec {
+	return &GaugeVec{name: name, help: help, labels: labels, series: make(map[string]*gauge)}
+}
+
+// Set sets the gauge for the given label values to v.
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	if len(labelValues) != len(g.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d labels, got %d", g.name, len(g.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s, ok := g.series[key]
+	if !ok {
+		s = &gauge{labelValues: append([]string(nil), labelValues...)}
+		g.series[key] = s
+	}
+	s.value = v
+}
+
+// Value returns the gauge for the given label values.
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if s, ok := g.series[strings.Join(labelValues, "\xff")]; ok {
+		return s.value
+	}
+	return 0
+}
+
+// WriteTo writes the gauges in Prometheus text format.
+func (g *GaugeVec) WriteTo(w io.Writer) (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+
+	keys := make([]string, 0, len(g.series))
+	for k := range g.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := g.series[k]
+		if len(g.labels) == 0 {
+			fmt.Fprintf(&b, "%s %g\n", g.name, s.value)
+			continue
+		}
+		labels := strings.TrimSuffix(formatLabels(g.labels, s.labelValues), ",")
+		fmt.Fprintf(&b, "%s{%s} %g\n", g.name, labels, s.value)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramVec_WriteTo(t *testing.T) {
+	h := NewHistogramVec("test_seconds", "Test.", []string{"provider"}, []float64{0.1, 1})
+	h.Observe(50*time.Millisecond, "pragmatic")
+	h.Observe(500*time.Millisecond, "pragmatic")
+	h.Observe(3*time.Second, "pragmatic")
+
+	var buf bytes.Buffer
+	_, err := h.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE test_seconds histogram")
+	assert.Contains(t, out, `test_seconds_bucket{provider="pragmatic",le="0.1"} 1`)
+	assert.Contains(t, out, `test_seconds_bucket{provider="pragmatic",le="1"} 2`)
+	assert.Contains(t, out, `test_seconds_bucket{provider="pragmatic",le="+Inf"} 3`)
+	assert.Contains(t, out, `test_seconds_count{provider="pragmatic"} 3`)
+}
+
+func TestCounterVec_WriteTo(t *testing.T) {
+	c := NewCounterVec("test_total", "Test.", []string{"kind"})
+	c.Add(2, "cash")
+	c.Add(1.5, "cash")
+	c.Add(1, "free_spins")
+
+	var buf bytes.Buffer
+	_, err := c.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE test_total counter")
+	assert.Contains(t, out, `test_total{kind="cash"} 3.5`)
+	assert.Contains(t, out, `test_total{kind="free_spins"} 1`)
+	assert.Equal(t, 3.5, c.Value("cash"))
+	assert.Zero(t, c.Value("other"))
+
+	plain := NewCounterVec("plain_total", "Plain.", nil)
+	plain.Add(4)
+	buf.Reset()
+	_, err = plain.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "plain_total 4\n")
+	assert.Panics(t, func() { plain.Add(-1) })
+}
+
+func TestGaugeVec_WriteTo(t *testing.T) {
+	g := NewGaugeVec("test_depth", "Test.", []string{"queue"})
+	g.Set(7, "outbox")
+	g.Set(3, "outbox")
+	g.Set(0.5, "dlq")
+
+	var buf bytes.Buffer
+	_, err := g.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE test_depth gauge")
+	assert.Contains(t, out, `test_depth{queue="outbox"} 3`)
+	assert.Contains(t, out, `test_depth{queue="dlq"} 0.5`)
+	assert.Equal(t, 3.0, g.Value("outbox"))
+	assert.Panics(t, func() { g.Set(1) })
+}
+
+func TestBurnRateSLO_Windows(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	slo := NewBurnRateSLO(100*time.Millisecond, 0.99)
+	slo.now = func() time.Time { return now }
+
+	// 30 minutes ago: 100 fast callbacks
+	now = now.Add(-30 * time.Minute)
+	for i := 0; i < 100; i++ {
+		slo.Record(10*time.Millisecond, false)
+	}
+	// Now: 10 callbacks, half slow or failed
+	now = now.Add(30 * time.Minute)
+	for i := 0; i < 5; i++ {
+		slo.Record(10*time.Millisecond, false)
+	}
+	slo.Record(time.Second, false)
+	slo.Record(time.Second, false)
+	slo.Record(10*time.Millisecond, true)
+	slo.Record(10*time.Millisecond, true)
+	slo.Record(10*time.Millisecond, true)
+
+	assert.InDelta(t, 50.0, slo.BurnRate(5*time.Minute), 0.001)     // 5/10 bad / 1% budget
+	assert.InDelta(t, 5.0/110/0.01, slo.BurnRate(time.Hour), 0.001) // 5/110 bad
+}
+
+func TestBurnRateSLO_NoEvents(t *testing.T) {
+	slo := NewBurnRateSLO(100*time.Millisecond, 0.99)
+	assert.Zero(t, slo.BurnRate(time.Hour))
+	assert.Zero(t, slo.ErrorRate(time.Hour))
+}
+
+func TestBurnRateSLO_ErrorRateIgnoresLatency(t *testing.T) {
+	slo := NewBurnRateSLO(100*time.Millisecond, 0.99)
+	for i := 0; i < 6; i++ {
+		slo.Record(time.Second, false) // slow but successful
+	}
+	slo.Record(10*time.Millisecond, true)
+	slo.Record(10*time.Millisecond, true)
+	assert.InDelta(t, 0.25, slo.ErrorRate(5*time.Minute), 0.0001)
+}
+
+func TestCallbackLatency_AlertFiresAndResolves(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cfg := DefaultSLOConfig()
+	c := NewCallbackLatency(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	clock := func() time.Time { return now }
+	c.slo("pragmatic").now = clock
+
+	for i := 0; i < 20; i++ {
+		c.Observe("pragmatic", "bet", 20*time.Millisecond, nil)
+	}
+	assert.False(t, c.Alerting("pragmatic"))
+
+	for i := 0; i < 5; i++ {
+		c.Observe("pragmatic", "bet", time.Second, nil)
+	}
+	c.Observe("pragmatic", "win", 20*time.Millisecond, errors.New("commit failed"))
+	assert.True(t, c.Alerting("pragmatic"))
+
+	// An hour later the slow minute has aged out of both windows.
+	now = now.Add(61 * time.Minute)
+	c.Observe("pragmatic", "bet", 20*time.Millisecond, nil)
+	assert.False(t, c.Alerting("pragmatic"))
+
+	var buf bytes.Buffer
+	_, err := c.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `wallet_callback_commit_seconds_count{provider="pragmatic",action="win",outcome="error"} 1`)
+	assert.Contains(t, buf.String(), `wallet_callback_slo_burn_rate{provider="pragmatic",window="5m"} 0`)
+	assert.Contains(t, buf.String(), `wallet_callback_slo_alert{provider="pragmatic"} 0`)
+}
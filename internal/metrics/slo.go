@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// BurnRateSLO tracks a latency objective ("target share of events complete
+// within threshold") over a rolling hour in one-minute slots, and reports how
+// fast the error budget is being spent.
+type BurnRateSLO struct {
+	threshold time.Duration
+	target    float64
+
+	mu    sync.Mutex
+	slots [60]sloSlot
+	now   func() time.Time
+}
+
+type sloSlot struct {
+	minute int64
+	total  uint64
+	bad    uint64
+	failed uint64
+}
+
+// NewBurnRateSLO creates an SLO where target (e.g. 0.99) of events must
+// complete within threshold.
+func NewBurnRateSLO(threshold time.Duration, target float64) *BurnRateSLO {
+	return &BurnRateSLO{threshold: threshold, target: target, now: time.Now}
+}
+
+// Record adds one event. Failed events always spend budget.
+func (s *BurnRateSLO) Record(d time.Duration, failed bool) {
+	minute := s.now().Unix() / 60
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slot := &s.slots[minute%int64(len(s.slots))]
+	if slot.minute != minute {
+		*slot = sloSlot{minute: minute}
+	}
+	slot.total++
+	if failed || d > s.threshold {
+		slot.bad++
+	}
+	if failed {
+		slot.failed++
+	}
+}
+
+// BurnRate returns the error-budget burn rate over the trailing window
+// (capped at one hour): 1 means the budget lasts exactly the SLO period.
+func (s *BurnRateSLO) BurnRate(window time.Duration) float64 {
+	total, bad, _ := s.window(window)
+	if total == 0 || s.target >= 1 {
+		return 0
+	}
+	return (float64(bad) / float64(total)) / (1 - s.target)
+}
+
+// ErrorRate returns the share of events over the trailing window (capped at
+// one hour) that failed, regardless of latency.
+func (s *BurnRateSLO) ErrorRate(window time.Duration) float64 {
+	total, _, failed := s.window(window)
+	if total == 0 {
+		return 0
+	}
+	return float64(failed) / float64(total)
+}
+
+func (s *BurnRateSLO) window(window time.Duration) (total, bad, failed uint64) {
+	minutes := int64(window / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes > int64(len(s.slots)) {
+		minutes = int64(len(s.slots))
+	}
+	current := s.now().Unix() / 60
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, slot := range s.slots {
+		if slot.total > 0 && current-slot.minute < minutes {
+			total += slot.total
+			bad += slot.bad
+			failed += slot.failed
+		}
+	}
+	return total, bad, failed
+}
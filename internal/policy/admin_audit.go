@@ -0,0 +1,30 @@
+package policy
+
+import "time"
+
+// BusinessHours is the working day admin activity is expected in: weekdays
+// from StartHour up to EndHour, local to Location.
+type BusinessHours struct {
+	StartHour int
+	EndHour   int
+	Location  *time.Location
+}
+
+// DefaultBusinessHours is 07:00-20:00 UTC, Monday to Friday.
+func DefaultBusinessHours() BusinessHours {
+	return BusinessHours{StartHour: 7, EndHour: 20, Location: time.UTC}
+}
+
+// IsOutOfHours reports whether t falls outside h: at a weekend, or before
+// StartHour or from EndHour on a weekday.
+func (h BusinessHours) IsOutOfHours(t time.Time) bool {
+	loc := h.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return true
+	}
+	return t.Hour() < h.StartHour || t.Hour() >= h.EndHour
+}
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessHours_IsOutOfHours(t *testing.T) {
+	h := DefaultBusinessHours()
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return tm
+	}
+
+	assert.False(t, h.IsOutOfHours(at("2026-10-14T07:00:00Z")), "Wednesday opening")
+	assert.False(t, h.IsOutOfHours(at("2026-10-14T19:59:59Z")))
+	assert.True(t, h.IsOutOfHours(at("2026-10-14T20:00:00Z")), "Wednesday closing")
+	assert.True(t, h.IsOutOfHours(at("2026-10-14T03:12:00Z")), "night")
+	assert.True(t, h.IsOutOfHours(at("2026-10-17T12:00:00Z")), "Saturday")
+
+	// 06:30 UTC is 08:30 in Berlin
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	h.Location = berlin
+	assert.False(t, h.IsOutOfHours(at("2026-10-14T06:30:00Z")))
+	assert.True(t, h.IsOutOfHours(at("2026-10-14T18:30:00Z")))
+}
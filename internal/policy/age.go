@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// DefaultMinimumAge applies to every jurisdiction not listed in minimumAges.
+const DefaultMinimumAge = 18
+
+// minimumAges holds jurisdictions whose gambling age is above the default,
+// keyed by ISO 3166-1 alpha-2 country code.
+var minimumAges = map[string]int{
+	"BE": 21,
+	"EE": 21,
+	"GR": 21,
+	"PT": 18,
+	"US": 21,
+}
+
+// MinimumAge returns the minimum gambling age for a country code.
+func MinimumAge(country string) int {
+	if age, ok := minimumAges[strings.ToUpper(strings.TrimSpace(country))]; ok {
+		return age
+	}
+	return DefaultMinimumAge
+}
+
+// ParseDateOfBirth parses a YYYY-MM-DD date of birth.
+func ParseDateOfBirth(s string) (time.Time, error) {
+	dob, err := time.Parse(time.DateOnly, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date_of_birth must be YYYY-MM-DD")
+	}
+	return dob, nil
+}
+
+// AgeOn returns the age in whole years on the given day.
+func AgeOn(dob, now time.Time) int {
+	age := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
+		age--
+	}
+	return age
+}
+
+// CheckMinimumAge validates a date of birth against the jurisdiction's
+// minimum age: VALIDATION_ERROR for a malformed or future date, UNDERAGE
+// when the player is too young.
+func CheckMinimumAge(dateOfBirth, country string, now time.Time) error {
+	dob, err := ParseDateOfBirth(dateOfBirth)
+	if err != nil {
+		return domain.ErrValidation(err.Error())
+	}
+	if dob.After(now) || AgeOn(dob, now) > 120 {
+		return domain.ErrValidation("date_of_birth is out of range")
+	}
+	if minAge := MinimumAge(country); AgeOn(dob, now) < minAge {
+		return domain.ErrUnderage(minAge)
+	}
+	return nil
+}
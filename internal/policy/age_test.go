@@ -0,0 +1,55 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimumAge(t *testing.T) {
+	assert.Equal(t, 18, MinimumAge("DE"))
+	assert.Equal(t, 21, MinimumAge("gr"))
+	assert.Equal(t, DefaultMinimumAge, MinimumAge(""))
+}
+
+func TestAgeOn(t *testing.T) {
+	dob := time.Date(2008, 6, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 17, AgeOn(dob, time.Date(2026, 6, 14, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 18, AgeOn(dob, time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 18, AgeOn(dob, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCheckMinimumAge(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dob     string
+		country string
+		code    string
+	}{
+		{"adult", "1990-01-01", "DE", ""},
+		{"18th birthday today", "2008-06-15", "DE", ""},
+		{"day before 18th birthday", "2008-06-16", "DE", "UNDERAGE"},
+		{"18 but jurisdiction requires 21", "2006-01-01", "GR", "UNDERAGE"},
+		{"21 in 21+ jurisdiction", "2005-06-15", "GR", ""},
+		{"malformed", "15/06/1990", "DE", "VALIDATION_ERROR"},
+		{"future", "2030-01-01", "DE", "VALIDATION_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMinimumAge(tt.dob, tt.country, now)
+			if tt.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var appErr *domain.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
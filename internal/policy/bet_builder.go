@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Market types a bet builder can combine.
+const (
+	MarketTypeMatchResult = "1x2"
+	MarketTypeSpread      = "spread"
+	MarketTypeOverUnder   = "over_under"
+)
+
+// BetBuilderRules says which markets of one event may be combined and how
+// their correlation is priced. Each pair of legs' market types must have a
+// factor; the combined price is the product of the legs' odds and of every
+// pair's factor. Two legs of the same market type never combine: their
+// outcomes overlap or contradict each other.
+type BetBuilderRules struct {
+	// PairFactorBps maps a pair of market types, in either order, to the share
+	// of the uncorrelated price the combination pays.
+	PairFactorBps map[[2]string]int
+}
+
+// DefaultBetBuilderRules: a result and a total pay 90% of their product, a
+// handicap and a total 93%, and a result and a handicap, which largely
+// decide each other, 85%.
+func DefaultBetBuilderRules() BetBuilderRules {
+	return BetBuilderRules{PairFactorBps: map[[2]string]int{
+		{MarketTypeMatchResult, MarketTypeOverUnder}: 9_000,
+		{MarketTypeSpread, MarketTypeOverUnder}:      9_300,
+		{MarketTypeMatchResult, MarketTypeSpread}:    8_500,
+	}}
+}
+
+// BuilderLeg is one selection of a bet builder: its market type, its odds
+// (x100) at placement and, once settled, its outcome.
+type BuilderLeg struct {
+	MarketType string
+	Odds       int
+	Outcome    SelectionOutcome
+}
+
+// pairFactor returns the factor for two market types and whether they combine.
+func (r BetBuilderRules) pairFactor(a, b string) (int, bool) {
+	if f, ok := r.PairFactorBps[[2]string{a, b}]; ok {
+		return f, true
+	}
+	f, ok := r.PairFactorBps[[2]string{b, a}]
+	return f, ok
+}
+
+// Combinable checks that a bet builder's market types may be combined: at
+// least two legs, each pair of which has a correlation factor.
+func (r BetBuilderRules) Combinable(marketTypes []string) error {
+	if len(marketTypes) < 2 {
+		return fmt.Errorf("a bet builder takes at least 2 selections")
+	}
+	for i := range marketTypes {
+		for j := i + 1; j < len(marketTypes); j++ {
+			if _, ok := r.pairFactor(marketTypes[i], marketTypes[j]); !ok {
+				return fmt.Errorf("%s and %s markets cannot be combined", marketTypes[i], marketTypes[j])
+			}
+		}
+	}
+	return nil
+}
+
+// Price returns the combined odds (x100) of combinable legs, rounded down.
+func (r BetBuilderRules) Price(legs []BuilderLeg) int {
+	price := big.NewRat(100, 1)
+	for _, leg := range legs {
+		price.Mul(price, big.NewRat(int64(leg.Odds), 100))
+	}
+	price.Mul(price, r.correlation(legs))
+	return int(new(big.Int).Quo(price.Num(), price.Denom()).Int64())
+}
+
+// correlation is the product of the pair factors between legs.
+func (r BetBuilderRules) correlation(legs []BuilderLeg) *big.Rat {
+	f := big.NewRat(1, 1)
+	for i := range legs {
+		for j := i + 1; j < len(legs); j++ {
+			bps, _ := r.pairFactor(legs[i].MarketType, legs[j].MarketType)
+			f.Mul(f, big.NewRat(int64(bps), 10_000))
+		}
+	}
+	return f
+}
+
+// SettleBetBuilder settles a bet builder staked at placedOdds. It loses when
+// any leg loses. When every leg wins outright it pays the placed odds. Void
+// and pushed legs otherwise drop out and the rest are repriced: their returns
+// per unit staked, as in SettleSystemBet, times the correlation between them.
+// When every leg is void or pushes, the stake is refunded.
+func SettleBetBuilder(r BetBuilderRules, stake int64, placedOdds int, legs []BuilderLeg) Settlement {
+	var live []BuilderLeg
+	allWon := true
+	for _, leg := range legs {
+		switch leg.Outcome.Result {
+		case ResultLost:
+			return Settlement{Status: ResultLost}
+		case ResultVoid, ResultPush:
+			allWon = false
+			continue
+		case ResultWon:
+			if leg.Outcome.Placing.Position > 1 || leg.Outcome.Placing.DeadHeat > 1 {
+				allWon = false
+			}
+		default:
+			allWon = false
+		}
+		live = append(live, leg)
+	}
+	if len(live) == 0 {
+		return Settlement{Status: ResultVoid, Return: stake, Refund: true}
+	}
+	if allWon {
+		return Settlement{Status: ResultWon, Return: stake * int64(placedOdds) / 100}
+	}
+
+	ret := big.NewRat(stake, 1)
+	for _, leg := range live {
+		ret.Mul(ret, legFactor(SystemLeg{Odds: leg.Odds, Outcome: leg.Outcome}))
+	}
+	ret.Mul(ret, r.correlation(live))
+	if v := new(big.Int).Quo(ret.Num(), ret.Denom()).Int64(); v > 0 {
+		return Settlement{Status: ResultWon, Return: v}
+	}
+	return Settlement{Status: ResultLost}
+}
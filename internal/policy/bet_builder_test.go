@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBetBuilder_Combinable(t *testing.T) {
+	rules := DefaultBetBuilderRules()
+
+	assert.NoError(t, rules.Combinable([]string{MarketTypeMatchResult, MarketTypeOverUnder}))
+	assert.NoError(t, rules.Combinable([]string{MarketTypeOverUnder, MarketTypeSpread, MarketTypeMatchResult}))
+	assert.ErrorContains(t, rules.Combinable([]string{MarketTypeOverUnder}), "at least 2")
+	assert.ErrorContains(t, rules.Combinable([]string{MarketTypeOverUnder, MarketTypeOverUnder}), "cannot be combined")
+	assert.ErrorContains(t, rules.Combinable([]string{MarketTypeMatchResult, "outright"}), "cannot be combined")
+}
+
+func TestBetBuilder_Price(t *testing.T) {
+	rules := DefaultBetBuilderRules()
+
+	// 2.00 x 1.90 = 3.80, at 90% for a result with a total.
+	assert.Equal(t, 342, rules.Price([]BuilderLeg{
+		{MarketType: MarketTypeMatchResult, Odds: 200}, {MarketType: MarketTypeOverUnder, Odds: 190},
+	}))
+	// 2.00 x 1.90 x 1.80 = 6.84, at 90% x 93% x 85%: 4.8660...
+	assert.Equal(t, 486, rules.Price([]BuilderLeg{
+		{MarketType: MarketTypeMatchResult, Odds: 200}, {MarketType: MarketTypeOverUnder, Odds: 190},
+		{MarketType: MarketTypeSpread, Odds: 180},
+	}))
+}
+
+func TestSettleBetBuilder(t *testing.T) {
+	rules := DefaultBetBuilderRules()
+	leg := func(marketType string, odds int, result string) BuilderLeg {
+		return BuilderLeg{MarketType: marketType, Odds: odds, Outcome: SelectionOutcome{Result: result}}
+	}
+
+	tests := []struct {
+		name string
+		legs []BuilderLeg
+		want Settlement
+	}{
+		{"all win pays the placed odds",
+			[]BuilderLeg{leg(MarketTypeMatchResult, 200, ResultWon), leg(MarketTypeOverUnder, 190, ResultWon)},
+			Settlement{Status: ResultWon, Return: 3_420}},
+		{"any leg lost",
+			[]BuilderLeg{leg(MarketTypeMatchResult, 200, ResultWon), leg(MarketTypeOverUnder, 190, ResultLost)},
+			Settlement{Status: ResultLost}},
+		// The void total drops out: the result pays alone at 2.00.
+		{"void leg reprices the rest",
+			[]BuilderLeg{leg(MarketTypeMatchResult, 200, ResultWon), leg(MarketTypeOverUnder, 190, ResultVoid)},
+			Settlement{Status: ResultWon, Return: 2_000}},
+		// 2.00 x (1.80 + 1) / 2 = 2.80, at 85%.
+		{"half-won handicap",
+			[]BuilderLeg{leg(MarketTypeMatchResult, 200, ResultWon), leg(MarketTypeSpread, 180, ResultHalfWon)},
+			Settlement{Status: ResultWon, Return: 2_380}},
+		{"all void refunds",
+			[]BuilderLeg{leg(MarketTypeMatchResult, 200, ResultVoid), leg(MarketTypeOverUnder, 190, ResultPush)},
+			Settlement{Status: ResultVoid, Return: 1_000, Refund: true}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, SettleBetBuilder(rules, 1_000, 342, tc.legs))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package policy
+
+import "sort"
+
+// SplitProRata divides total between participants in proportion to their
+// weights (pool stakes, in cents). Shares are rounded down and the cents left
+// over go one each to the largest remainders, ties broken by order, so the
+// shares always sum to total. Participants with no weight receive nothing.
+func SplitProRata(total int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	var sum int64
+	for _, w := range weights {
+		if w > 0 {
+			sum += w
+		}
+	}
+	if total <= 0 || sum == 0 {
+		return shares
+	}
+
+	remainders := make([]int64, len(weights))
+	order := make([]int, 0, len(weights))
+	var allocated int64
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		// total*w can overflow for very large pools; split the product.
+		q, r := total/sum, total%sum
+		shares[i] = q*w + (r*w)/sum
+		remainders[i] = (r * w) % sum
+		allocated += shares[i]
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for k := 0; allocated < total; k++ {
+		shares[order[k%len(order)]]++
+		allocated++
+	}
+	return shares
+}
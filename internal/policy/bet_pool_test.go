@@ -0,0 +1,41 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitProRata(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{"even split", 3000, []int64{500, 500, 500}, []int64{1000, 1000, 1000}},
+		{"proportional", 5000, []int64{1000, 3000, 1000}, []int64{1000, 3000, 1000}},
+		{"leftover cent to largest remainder", 1000, []int64{100, 200}, []int64{333, 667}},
+		{"leftover cents tie by order", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"zero weight gets nothing", 900, []int64{300, 0, 600}, []int64{300, 0, 600}},
+		{"no stake", 900, []int64{0, 0}, []int64{0, 0}},
+		{"no return", 0, []int64{100, 200}, []int64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitProRata(tt.total, tt.weights)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitProRata_SumsToTotal(t *testing.T) {
+	weights := []int64{137, 251, 3, 999, 12}
+	for _, total := range []int64{1, 7, 1403, 98765, 1 << 40} {
+		var sum int64
+		for _, s := range SplitProRata(total, weights) {
+			sum += s
+		}
+		assert.Equal(t, total, sum, "total %d", total)
+	}
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// MaxFreeSpins caps the rounds a single free_spins bonus can award.
+const MaxFreeSpins = 500
+
+// MaxMatchPercent caps the match rate of a deposit_match bonus.
+const MaxMatchPercent = 500
+
+// ValidateBonus checks a bonus definition is well-formed for its type. An
+// empty type is a cash bonus.
+func ValidateBonus(b domain.Bonus) error {
+	if strings.TrimSpace(b.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if b.WageringMultiplier < 0 {
+		return fmt.Errorf("wagering_multiplier must not be negative")
+	}
+	if b.DaysUntilExpiry < 0 {
+		return fmt.Errorf("days_until_expiry must not be negative")
+	}
+	switch b.Type {
+	case "", domain.BonusTypeCash:
+		if b.MaxBonus < 0 {
+			return fmt.Errorf("max_bonus must not be negative")
+		}
+	case domain.BonusTypeFreeSpins:
+		if b.FreeSpins < 1 || b.FreeSpins > MaxFreeSpins {
+			return fmt.Errorf("free_spins must be between 1 and %d", MaxFreeSpins)
+		}
+		if b.FreeSpinValue <= 0 {
+			return fmt.Errorf("free_spin_value must be positive")
+		}
+		if len(b.FreeSpinGames) == 0 {
+			return fmt.Errorf("free_spin_games is required")
+		}
+		for _, g := range b.FreeSpinGames {
+			if strings.TrimSpace(g) == "" {
+				return fmt.Errorf("free_spin_games must not contain empty ids")
+			}
+		}
+	case domain.BonusTypeDepositMatch:
+		if b.MatchPercent < 1 || b.MatchPercent > MaxMatchPercent {
+			return fmt.Errorf("match_percent must be between 1 and %d", MaxMatchPercent)
+		}
+		if b.MaxBonus <= 0 {
+			return fmt.Errorf("max_bonus must be positive")
+		}
+		if b.MinDeposit < 0 {
+			return fmt.Errorf("min_deposit must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown bonus type: %s", b.Type)
+	}
+	return nil
+}
+
+// BonusWageringRequirement returns how much must be wagered to release a
+// bonus of amount at multiplier, rounded up.
+func BonusWageringRequirement(amount int64, multiplier float64) int64 {
+	if amount <= 0 || multiplier <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(float64(amount) * multiplier))
+}
+
+// DepositMatchAmount returns the bonus a deposit_match bonus awards for a
+// deposit of amount: matchPercent of it, rounded down and capped at
+// maxBonus. A deposit below minDeposit earns nothing.
+func DepositMatchAmount(amount, minDeposit, maxBonus int64, matchPercent int) int64 {
+	if amount <= 0 || amount < minDeposit || matchPercent <= 0 || maxBonus <= 0 {
+		return 0
+	}
+	return min(amount*int64(matchPercent)/100, maxBonus)
+}
@@ -0,0 +1,68 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBonus(t *testing.T) {
+	freeSpins := func(mod func(*domain.Bonus)) domain.Bonus {
+		b := domain.Bonus{
+			Name: "Welcome Spins", Type: domain.BonusTypeFreeSpins,
+			FreeSpins: 20, FreeSpinValue: 20, FreeSpinGames: []string{"book-of-ra"},
+		}
+		if mod != nil {
+			mod(&b)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name    string
+		bonus   domain.Bonus
+		wantErr string
+	}{
+		{"cash bonus", domain.Bonus{Name: "Reload", MaxBonus: 5_000, WageringMultiplier: 30}, ""},
+		{"free spins bonus", freeSpins(nil), ""},
+		{"name required", domain.Bonus{Name: " "}, "name is required"},
+		{"negative multiplier", domain.Bonus{Name: "x", WageringMultiplier: -1}, "wagering_multiplier must not be negative"},
+		{"negative max bonus", domain.Bonus{Name: "x", MaxBonus: -1}, "max_bonus must not be negative"},
+		{"unknown type", domain.Bonus{Name: "x", Type: "cashback"}, "unknown bonus type: cashback"},
+		{"no spins", freeSpins(func(b *domain.Bonus) { b.FreeSpins = 0 }), "free_spins must be between 1 and 500"},
+		{"too many spins", freeSpins(func(b *domain.Bonus) { b.FreeSpins = 501 }), "free_spins must be between 1 and 500"},
+		{"no spin value", freeSpins(func(b *domain.Bonus) { b.FreeSpinValue = 0 }), "free_spin_value must be positive"},
+		{"no games", freeSpins(func(b *domain.Bonus) { b.FreeSpinGames = nil }), "free_spin_games is required"},
+		{"blank game", freeSpins(func(b *domain.Bonus) { b.FreeSpinGames = []string{""} }), "free_spin_games must not contain empty ids"},
+		{"deposit match bonus", domain.Bonus{Name: "Match", Type: domain.BonusTypeDepositMatch, MatchPercent: 100, MaxBonus: 10_000}, ""},
+		{"no match percent", domain.Bonus{Name: "x", Type: domain.BonusTypeDepositMatch, MaxBonus: 10_000}, "match_percent must be between 1 and 500"},
+		{"match without cap", domain.Bonus{Name: "x", Type: domain.BonusTypeDepositMatch, MatchPercent: 50}, "max_bonus must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBonus(tt.bonus)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDepositMatchAmount(t *testing.T) {
+	assert.Equal(t, int64(5_000), DepositMatchAmount(5_000, 1_000, 10_000, 100))
+	assert.Equal(t, int64(10_000), DepositMatchAmount(50_000, 1_000, 10_000, 100), "capped at max bonus")
+	assert.Equal(t, int64(333), DepositMatchAmount(666, 0, 10_000, 50), "rounded down")
+	assert.Zero(t, DepositMatchAmount(999, 1_000, 10_000, 100), "below min deposit")
+	assert.Zero(t, DepositMatchAmount(5_000, 0, 10_000, 0))
+}
+
+func TestBonusWageringRequirement(t *testing.T) {
+	assert.Equal(t, int64(30_000), BonusWageringRequirement(1_000, 30))
+	assert.Equal(t, int64(1_750), BonusWageringRequirement(500, 3.5))
+	assert.Equal(t, int64(4), BonusWageringRequirement(3, 1.1))
+	assert.Zero(t, BonusWageringRequirement(1_000, 0))
+	assert.Zero(t, BonusWageringRequirement(0, 30))
+}
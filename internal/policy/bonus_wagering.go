@@ -0,0 +1,72 @@
+package policy
+
+import "strings"
+
+// Game categories with a wagering weight of their own. A stake on a game in
+// any other category, or on a game missing from the catalog, counts in full.
+const (
+	WageringCategorySlots      = "slots"
+	WageringCategoryTable      = "table"
+	WageringCategoryLive       = "live"
+	WageringCategorySportsbook = "sportsbook"
+)
+
+// FullWageringContributionBps is a contribution weight of 100%.
+const FullWageringContributionBps = 10_000
+
+// wageringCategoryWeights is the share of a stake, in basis points, that
+// counts toward bonus wagering by game category. Low-edge table and live
+// games count at 10% so bonuses cannot be cleared at near-zero risk.
+var wageringCategoryWeights = map[string]int{
+	WageringCategorySlots:      10_000,
+	WageringCategoryTable:      1_000,
+	WageringCategoryLive:       1_000,
+	WageringCategorySportsbook: 10_000,
+}
+
+// WageringGame is what the catalog knows about the game a stake was placed on.
+type WageringGame struct {
+	Category    string
+	OverrideBps *int // per-game weight; takes precedence over the category's
+}
+
+// WageringContributionBps returns the weight, in basis points, of a stake on
+// game.
+func WageringContributionBps(game WageringGame) int {
+	if bps := game.OverrideBps; bps != nil {
+		return min(max(*bps, 0), FullWageringContributionBps)
+	}
+	if bps, ok := wageringCategoryWeights[strings.ToLower(game.Category)]; ok {
+		return bps
+	}
+	return FullWageringContributionBps
+}
+
+// WageringContribution returns how much of a stake counts toward wagering,
+// rounded down.
+func WageringContribution(stake int64, game WageringGame) int64 {
+	return stake * int64(WageringContributionBps(game)) / FullWageringContributionBps
+}
+
+// WageringProgress is one active bonus's wagering state.
+type WageringProgress struct {
+	Requirement int64
+	Wagered     int64
+}
+
+// AllocateWagering spreads a contribution across active bonuses in order
+// (oldest first): each takes up to what it still needs and the rest carries
+// over to the next. It returns the amount added to each bonus; whatever is
+// left once every bonus is cleared is dropped.
+func AllocateWagering(bonuses []WageringProgress, contribution int64) []int64 {
+	added := make([]int64, len(bonuses))
+	for i, b := range bonuses {
+		if contribution <= 0 {
+			break
+		}
+		take := min(max(b.Requirement-b.Wagered, 0), contribution)
+		added[i] = take
+		contribution -= take
+	}
+	return added
+}
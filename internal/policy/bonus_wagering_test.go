@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWageringContribution(t *testing.T) {
+	bps := func(v int) *int { return &v }
+
+	tests := []struct {
+		name  string
+		stake int64
+		game  WageringGame
+		want  int64
+	}{
+		{"slots count in full", 1_000, WageringGame{Category: "slots"}, 1_000},
+		{"table games count 10%", 1_000, WageringGame{Category: "table"}, 100},
+		{"category is case-insensitive", 1_000, WageringGame{Category: "Live"}, 100},
+		{"unknown category counts in full", 1_000, WageringGame{Category: "crash"}, 1_000},
+		{"uncatalogued game counts in full", 1_000, WageringGame{}, 1_000},
+		{"override beats category", 1_000, WageringGame{Category: "table", OverrideBps: bps(5_000)}, 500},
+		{"zero override excludes game", 1_000, WageringGame{Category: "slots", OverrideBps: bps(0)}, 0},
+		{"override is clamped", 1_000, WageringGame{OverrideBps: bps(20_000)}, 1_000},
+		{"rounds down", 15, WageringGame{Category: "table"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WageringContribution(tt.stake, tt.game))
+		})
+	}
+}
+
+func TestAllocateWagering(t *testing.T) {
+	tests := []struct {
+		name         string
+		bonuses      []WageringProgress
+		contribution int64
+		want         []int64
+	}{
+		{"no bonuses", nil, 500, []int64{}},
+		{"within first bonus", []WageringProgress{{1_000, 200}, {1_000, 0}}, 300, []int64{300, 0}},
+		{"carries over to next", []WageringProgress{{1_000, 800}, {1_000, 0}}, 500, []int64{200, 300}},
+		{"excess is dropped", []WageringProgress{{1_000, 900}}, 500, []int64{100}},
+		{"already complete takes nothing", []WageringProgress{{1_000, 1_000}, {500, 0}}, 200, []int64{0, 200}},
+		{"zero contribution", []WageringProgress{{1_000, 0}}, 0, []int64{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AllocateWagering(tt.bonuses, tt.contribution))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// CashOutMarginBps is the house margin taken off a bet's fair cash-out
+// value, in basis points.
+const CashOutMarginBps = 500
+
+// CashOutState is what decides whether an open bet may be cashed out now.
+type CashOutState struct {
+	BetClass        string
+	EachWay         bool
+	EventEnabled    bool // the event's cash-out kill switch; outrights have no event and pass true
+	MarketStatus    string
+	SelectionStatus string
+	HasResult       bool
+}
+
+// CashOutAvailable reports why a bet cannot be cashed out, or nil if it can.
+// Only win-only singles on an open market with an unresulted selection are
+// offered cash-out.
+func CashOutAvailable(s CashOutState) error {
+	switch {
+	case s.BetClass == domain.BetClassSystem || s.BetClass == domain.BetClassBetBuilder:
+		return fmt.Errorf("system and bet-builder bets cannot be cashed out")
+	case s.EachWay:
+		return fmt.Errorf("each-way bets cannot be cashed out")
+	case !s.EventEnabled:
+		return fmt.Errorf("cash-out is disabled for this event")
+	case s.MarketStatus != "open":
+		return fmt.Errorf("market is not open")
+	case s.SelectionStatus != "active" || s.HasResult:
+		return fmt.Errorf("selection is no longer trading")
+	}
+	return nil
+}
+
+// CashOutValue returns what an open bet is worth at the selection's current
+// odds (x100): the stake scaled by the price movement since placement, less
+// CashOutMarginBps. As current odds are at least MinOdds, the value stays
+// below the bet's potential payout.
+func CashOutValue(stake int64, oddsAtPlacement, currentOdds int) int64 {
+	if stake <= 0 || currentOdds < MinOdds {
+		return 0
+	}
+	fair := stake * int64(oddsAtPlacement) / int64(currentOdds)
+	return fair * (10000 - CashOutMarginBps) / 10000
+}
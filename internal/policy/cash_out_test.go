@@ -0,0 +1,55 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashOutValue(t *testing.T) {
+	tests := []struct {
+		name            string
+		stake           int64
+		placed, current int
+		want            int64
+	}{
+		{"unchanged price returns stake less margin", 1000, 200, 200, 950},
+		{"shortened price is worth more", 1000, 300, 150, 1900},
+		{"drifted price is worth less", 1000, 200, 400, 475},
+		{"near certain winner stays under payout", 1000, 500, 101, 4702},
+		{"no price", 1000, 200, 0, 0},
+		{"no stake", 0, 200, 200, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CashOutValue(tt.stake, tt.placed, tt.current))
+		})
+	}
+}
+
+func TestCashOutAvailable(t *testing.T) {
+	open := CashOutState{EventEnabled: true, MarketStatus: "open", SelectionStatus: "active"}
+	assert.NoError(t, CashOutAvailable(open))
+
+	eachWay := open
+	eachWay.EachWay = true
+	assert.Error(t, CashOutAvailable(eachWay))
+
+	disabled := open
+	disabled.EventEnabled = false
+	assert.EqualError(t, CashOutAvailable(disabled), "cash-out is disabled for this event")
+
+	suspended := open
+	suspended.MarketStatus = "suspended"
+	assert.Error(t, CashOutAvailable(suspended))
+
+	resulted := open
+	resulted.HasResult = true
+	assert.Error(t, CashOutAvailable(resulted))
+
+	for _, class := range []string{domain.BetClassSystem, domain.BetClassBetBuilder} {
+		multi := CashOutState{BetClass: class, EventEnabled: true}
+		assert.EqualError(t, CashOutAvailable(multi), "system and bet-builder bets cannot be cashed out", class)
+	}
+}
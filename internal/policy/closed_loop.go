@@ -0,0 +1,31 @@
+package policy
+
+// WithdrawalDestination holds the facts about the saved payment method a
+// withdrawal targets. A nil destination means none was given.
+type WithdrawalDestination struct {
+	Active            bool `json:"active"`
+	Verified          bool `json:"verified"`
+	CompletedDeposits int  `json:"completed_deposits"` // deposits paid with this method
+}
+
+// CheckWithdrawalDestination returns why a withdrawal destination is refused,
+// or "" when it is acceptable. Under the closed-loop policy funds may only go
+// back to a verified method the player has already deposited with; without
+// it a destination is optional but must still be active.
+func CheckWithdrawalDestination(closedLoop bool, dest *WithdrawalDestination) string {
+	switch {
+	case dest == nil && closedLoop:
+		return "method_required"
+	case dest == nil:
+		return ""
+	case !dest.Active:
+		return "method_inactive"
+	case !closedLoop:
+		return ""
+	case !dest.Verified:
+		return "method_unverified"
+	case dest.CompletedDeposits == 0:
+		return "method_unused"
+	}
+	return ""
+}
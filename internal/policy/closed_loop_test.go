@@ -0,0 +1,32 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWithdrawalDestination(t *testing.T) {
+	used := &WithdrawalDestination{Active: true, Verified: true, CompletedDeposits: 2}
+
+	tests := []struct {
+		name       string
+		closedLoop bool
+		dest       *WithdrawalDestination
+		reason     string
+	}{
+		{"closed loop, used verified method", true, used, ""},
+		{"closed loop, no method", true, nil, "method_required"},
+		{"closed loop, removed method", true, &WithdrawalDestination{Verified: true, CompletedDeposits: 1}, "method_inactive"},
+		{"closed loop, unverified", true, &WithdrawalDestination{Active: true, CompletedDeposits: 1}, "method_unverified"},
+		{"closed loop, never deposited", true, &WithdrawalDestination{Active: true, Verified: true}, "method_unused"},
+		{"open loop, no method", false, nil, ""},
+		{"open loop, unverified unused", false, &WithdrawalDestination{Active: true}, ""},
+		{"open loop, removed method", false, &WithdrawalDestination{}, "method_inactive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reason, CheckWithdrawalDestination(tt.closedLoop, tt.dest))
+		})
+	}
+}
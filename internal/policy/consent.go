@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// consentChannels lists the channels each purpose is recorded for.
+var consentChannels = map[string][]string{
+	domain.ConsentMarketing: {
+		domain.ConsentChannelEmail, domain.ConsentChannelSMS,
+		domain.ConsentChannelPush, domain.ConsentChannelPhone,
+	},
+	domain.ConsentAnalytics: {domain.ConsentChannelAll},
+	domain.ConsentProfiling: {domain.ConsentChannelAll},
+}
+
+// ConsentPurposes lists the purposes in display order.
+var ConsentPurposes = []string{domain.ConsentMarketing, domain.ConsentAnalytics, domain.ConsentProfiling}
+
+// ConsentChannels returns the channels a purpose is recorded for, or nil for
+// an unknown purpose.
+func ConsentChannels(purpose string) []string {
+	return consentChannels[purpose]
+}
+
+// ValidateConsent checks a purpose and channel pair can be recorded. An empty
+// channel is accepted for purposes with a single channel and returned filled
+// in.
+func ValidateConsent(purpose, channel string) (string, error) {
+	channels, ok := consentChannels[purpose]
+	if !ok {
+		return "", fmt.Errorf("unknown consent purpose: %s", purpose)
+	}
+	if channel == "" && len(channels) == 1 {
+		return channels[0], nil
+	}
+	for _, c := range channels {
+		if c == channel {
+			return channel, nil
+		}
+	}
+	return "", fmt.Errorf("%s consent has no %q channel", purpose, channel)
+}
+
+// crmEventTypes are the events CRM turns into promotional messages. They are
+// only delivered for players with marketing consent on at least one channel.
+var crmEventTypes = map[domain.EventType]bool{
+	domain.EventBonusExpired:  true,
+	domain.EventBonusExpiring: true,
+}
+
+// IsCRMEvent reports whether an event drives marketing messages and so needs
+// marketing consent to be delivered.
+func IsCRMEvent(t domain.EventType) bool {
+	return crmEventTypes[t]
+}
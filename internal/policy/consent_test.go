@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConsent(t *testing.T) {
+	tests := []struct {
+		name        string
+		purpose     string
+		channel     string
+		wantChannel string
+		wantErr     string
+	}{
+		{"marketing email", "marketing", "email", "email", ""},
+		{"marketing needs a channel", "marketing", "", "", `marketing consent has no "" channel`},
+		{"marketing has no all channel", "marketing", "all", "", `marketing consent has no "all" channel`},
+		{"analytics defaults to all", "analytics", "", "all", ""},
+		{"profiling all", "profiling", "all", "all", ""},
+		{"analytics is not per channel", "analytics", "sms", "", `analytics consent has no "sms" channel`},
+		{"unknown purpose", "research", "all", "", "unknown consent purpose: research"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, err := ValidateConsent(tt.purpose, tt.channel)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChannel, channel)
+		})
+	}
+}
+
+func TestConsentChannels(t *testing.T) {
+	assert.Equal(t, []string{"email", "sms", "push", "phone"}, ConsentChannels(domain.ConsentMarketing))
+	assert.Equal(t, []string{"all"}, ConsentChannels(domain.ConsentProfiling))
+	assert.Nil(t, ConsentChannels("research"))
+}
+
+func TestIsCRMEvent(t *testing.T) {
+	assert.True(t, IsCRMEvent(domain.EventBonusExpired))
+	assert.False(t, IsCRMEvent(domain.EventTransactionPosted))
+	assert.False(t, IsCRMEvent(domain.EventConsentChanged))
+}
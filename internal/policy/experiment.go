@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/google/uuid"
+)
+
+// experimentBuckets is the resolution of traffic allocation (0.01%).
+const experimentBuckets = 10000
+
+// WeightedVariant is the part of a variant that assignment needs.
+type WeightedVariant struct {
+	Name   string
+	Weight int
+}
+
+// AssignVariant deterministically places a player in an experiment. The
+// traffic check and the variant pick hash independently, so widening
+// trafficPct adds players without moving anyone already assigned. Returns
+// false when the player falls outside the traffic allocation.
+func AssignVariant(experimentKey string, playerID uuid.UUID, trafficPct int, variants []WeightedVariant) (string, bool) {
+	if trafficPct <= 0 || len(variants) == 0 {
+		return "", false
+	}
+	if experimentBucket(experimentKey, "traffic", playerID) >= uint64(trafficPct)*experimentBuckets/100 {
+		return "", false
+	}
+
+	var total uint64
+	for _, v := range variants {
+		if v.Weight > 0 {
+			total += uint64(v.Weight)
+		}
+	}
+	if total == 0 {
+		return "", false
+	}
+
+	point := experimentBucket(experimentKey, "variant", playerID) * total / experimentBuckets
+	for _, v := range variants {
+		if v.Weight <= 0 {
+			continue
+		}
+		if point < uint64(v.Weight) {
+			return v.Name, true
+		}
+		point -= uint64(v.Weight)
+	}
+	return variants[len(variants)-1].Name, true
+}
+
+func experimentBucket(experimentKey, salt string, playerID uuid.UUID) uint64 {
+	h := sha256.New()
+	h.Write([]byte(experimentKey))
+	h.Write([]byte{0})
+	h.Write([]byte(salt))
+	h.Write([]byte{0})
+	h.Write(playerID[:])
+	return binary.BigEndian.Uint64(h.Sum(nil)[:8]) % experimentBuckets
+}
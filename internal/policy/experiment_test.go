@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignVariant_Deterministic(t *testing.T) {
+	variants := []WeightedVariant{{"control", 50}, {"treatment", 50}}
+	player := uuid.New()
+
+	first, ok := AssignVariant("exp", player, 100, variants)
+	assert.True(t, ok)
+	for i := 0; i < 10; i++ {
+		again, _ := AssignVariant("exp", player, 100, variants)
+		assert.Equal(t, first, again)
+	}
+}
+
+func TestAssignVariant_Distribution(t *testing.T) {
+	variants := []WeightedVariant{{"control", 80}, {"treatment", 20}}
+	counts := map[string]int{}
+	const n = 20000
+	for i := 0; i < n; i++ {
+		v, ok := AssignVariant("dist", uuid.New(), 100, variants)
+		assert.True(t, ok)
+		counts[v]++
+	}
+	assert.InDelta(t, 0.8, float64(counts["control"])/n, 0.02)
+	assert.InDelta(t, 0.2, float64(counts["treatment"])/n, 0.02)
+}
+
+func TestAssignVariant_Traffic(t *testing.T) {
+	variants := []WeightedVariant{{"control", 1}, {"treatment", 1}}
+
+	in := 0
+	const n = 20000
+	players := make([]uuid.UUID, n)
+	for i := range players {
+		players[i] = uuid.New()
+		if _, ok := AssignVariant("traffic", players[i], 25, variants); ok {
+			in++
+		}
+	}
+	assert.InDelta(t, 0.25, float64(in)/n, 0.02)
+
+	// Widening traffic keeps existing assignments stable
+	for _, p := range players[:500] {
+		before, okBefore := AssignVariant("traffic", p, 25, variants)
+		after, okAfter := AssignVariant("traffic", p, 60, variants)
+		if okBefore {
+			assert.True(t, okAfter)
+			assert.Equal(t, before, after)
+		}
+	}
+}
+
+func TestAssignVariant_NoAllocation(t *testing.T) {
+	_, ok := AssignVariant("off", uuid.New(), 0, []WeightedVariant{{"a", 1}})
+	assert.False(t, ok)
+	_, ok = AssignVariant("empty", uuid.New(), 100, nil)
+	assert.False(t, ok)
+	_, ok = AssignVariant("zero", uuid.New(), 100, []WeightedVariant{{"a", 0}})
+	assert.False(t, ok)
+}
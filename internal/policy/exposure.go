@@ -0,0 +1,66 @@
+package policy
+
+import "math"
+
+// ExposureLimits caps the sportsbook's open liability, the potential payout
+// of open bets, in cents. A zero limit is not enforced.
+type ExposureLimits struct {
+	// Selection caps the liability on any one selection.
+	Selection int64 `json:"selection"`
+	// Event caps an event's worst case: the sum over its markets of the
+	// largest selection liability.
+	Event int64 `json:"event"`
+	// PlayerEvent caps one player's liability on an event.
+	PlayerEvent int64 `json:"player_event"`
+}
+
+// DefaultExposureLimits: €50,000 on a selection, €250,000 worst case on an
+// event and €10,000 to any one player on an event.
+func DefaultExposureLimits() ExposureLimits {
+	return ExposureLimits{
+		Selection:   5_000_000,
+		Event:       25_000_000,
+		PlayerEvent: 1_000_000,
+	}
+}
+
+// ExposureFacts holds the open liability a new bet adds to.
+type ExposureFacts struct {
+	Selection   int64 `json:"selection"`    // on the bet's selection
+	Market      int64 `json:"market"`       // largest selection liability in the bet's market
+	Event       int64 `json:"event"`        // the event's worst case
+	PlayerEvent int64 `json:"player_event"` // the bettor's liability on the event
+}
+
+// MaxPayout returns the largest potential payout a new bet may add under the
+// limits, never negative. A payout that leaves its market's largest
+// liability unchanged does not raise the event's worst case, so it stays
+// allowed even when the event is already at its limit.
+func (l ExposureLimits) MaxPayout(f ExposureFacts) int64 {
+	allowed := int64(math.MaxInt64)
+	if l.Selection > 0 {
+		allowed = min(allowed, l.Selection-f.Selection)
+	}
+	if l.Event > 0 {
+		allowed = min(allowed, max(l.Event-(f.Event-f.Market)-f.Selection, f.Market-f.Selection))
+	}
+	if l.PlayerEvent > 0 {
+		allowed = min(allowed, l.PlayerEvent-f.PlayerEvent)
+	}
+	return max(allowed, 0)
+}
+
+// CapStake returns the largest stake, at most stake, whose payout stays
+// within maxPayout. payout maps a stake to its potential payout and must
+// grow with it.
+func CapStake(stake, maxPayout int64, payout func(int64) int64) int64 {
+	full := payout(stake)
+	if full <= maxPayout {
+		return stake
+	}
+	capped := stake * maxPayout / full
+	for capped > 0 && payout(capped) > maxPayout {
+		capped--
+	}
+	return capped
+}
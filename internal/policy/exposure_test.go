@@ -0,0 +1,41 @@
+package policy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureLimits_MaxPayout(t *testing.T) {
+	limits := ExposureLimits{Selection: 10_000, Event: 15_000, PlayerEvent: 4_000}
+	tests := []struct {
+		name  string
+		facts ExposureFacts
+		want  int64
+	}{
+		{"empty book is bound by the player limit", ExposureFacts{}, 4_000},
+		{"selection limit", ExposureFacts{Selection: 9_000, Market: 9_000, Event: 9_000}, 1_000},
+		{"event worst case", ExposureFacts{Selection: 1_000, Market: 3_000, Event: 14_000}, 3_000},
+		{"payout under the market's largest liability is free",
+			ExposureFacts{Selection: 1_000, Market: 8_000, Event: 16_000}, 4_000},
+		{"player limit reached", ExposureFacts{PlayerEvent: 4_500}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, limits.MaxPayout(tt.facts))
+		})
+	}
+}
+
+func TestExposureLimits_MaxPayoutUnlimited(t *testing.T) {
+	assert.Equal(t, int64(math.MaxInt64), ExposureLimits{}.MaxPayout(ExposureFacts{Event: 1 << 40}))
+}
+
+func TestCapStake(t *testing.T) {
+	payout := func(stake int64) int64 { return stake * 333 / 100 }
+	assert.Equal(t, int64(1000), CapStake(1000, 10_000, payout))
+	assert.Equal(t, int64(600), CapStake(1000, 2_000, payout))
+	assert.LessOrEqual(t, payout(CapStake(1000, 2_000, payout)), int64(2_000))
+	assert.Zero(t, CapStake(1000, 2, payout))
+}
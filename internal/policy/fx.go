@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ConvertAmount applies an exchange rate (decimal string, quote per base) to a
+// minor-unit amount. The result is floored so a conversion never credits more
+// than the rate allows.
+func ConvertAmount(amount int64, rate string) (int64, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid rate %q", rate)
+	}
+	if amount < 0 {
+		return 0, fmt.Errorf("amount must not be negative")
+	}
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), r)
+	converted := new(big.Int).Quo(product.Num(), product.Denom())
+	if !converted.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows")
+	}
+	return converted.Int64(), nil
+}
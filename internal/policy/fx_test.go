@@ -0,0 +1,38 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rate   string
+		want   int64
+	}{
+		{"identity", 10000, "1", 10000},
+		{"eur to usd", 10000, "1.0850", 10850},
+		{"floors fractional minor units", 999, "0.8571", 856},
+		{"zero amount", 0, "1.2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertAmount(tt.amount, tt.rate)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvertAmount_Invalid(t *testing.T) {
+	for _, rate := range []string{"", "abc", "0", "-1.5"} {
+		_, err := ConvertAmount(100, rate)
+		assert.Error(t, err, rate)
+	}
+	_, err := ConvertAmount(-1, "1")
+	assert.Error(t, err)
+}
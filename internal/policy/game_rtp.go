@@ -0,0 +1,58 @@
+package policy
+
+import "math"
+
+// RTPBandRules configures the confidence band a game's actual RTP is checked
+// against. The band is the theoretical RTP plus or minus Z standard errors,
+// where one round's return per unit staked has standard deviation Volatility.
+type RTPBandRules struct {
+	Z          float64 `json:"z"`
+	Volatility float64 `json:"volatility"`
+	// Days with fewer rounds than MinRounds are too noisy to alert on.
+	MinRounds int `json:"min_rounds"`
+}
+
+// DefaultRTPBandRules: a 3-sigma band for a typical slot's volatility,
+// checked once a day has 1,000 rounds.
+func DefaultRTPBandRules() RTPBandRules {
+	return RTPBandRules{Z: 3, Volatility: 10, MinRounds: 1000}
+}
+
+// RTPCheck is the outcome of comparing actual against theoretical RTP. All
+// figures are percentages. Evaluated is false when the game has no
+// theoretical RTP, no stakes or too few rounds.
+type RTPCheck struct {
+	Actual    float64 `json:"actual_rtp"`
+	Lower     float64 `json:"lower_bound"`
+	Upper     float64 `json:"upper_bound"`
+	Evaluated bool    `json:"evaluated"`
+	Deviates  bool    `json:"deviates"`
+}
+
+// CheckRTP computes the actual RTP of stake and win over rounds and whether
+// it falls outside the band around theoretical (a percentage, e.g. 96.5).
+func CheckRTP(rules RTPBandRules, theoretical float64, stake, win int64, rounds int) RTPCheck {
+	c := RTPCheck{Actual: ActualRTP(stake, win)}
+	if stake <= 0 || theoretical <= 0 || rounds <= 0 || rounds < rules.MinRounds {
+		return c
+	}
+	half := rules.Z * rules.Volatility * 100 / math.Sqrt(float64(rounds))
+	c.Lower = roundPct(math.Max(theoretical-half, 0))
+	c.Upper = roundPct(theoretical + half)
+	c.Evaluated = true
+	c.Deviates = c.Actual < c.Lower || c.Actual > c.Upper
+	return c
+}
+
+// ActualRTP returns win as a percentage of stake, to two decimals; zero when
+// nothing was staked.
+func ActualRTP(stake, win int64) float64 {
+	if stake <= 0 {
+		return 0
+	}
+	return roundPct(float64(win) * 100 / float64(stake))
+}
+
+func roundPct(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,39 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRTP(t *testing.T) {
+	rules := DefaultRTPBandRules()
+	tests := []struct {
+		name        string
+		theoretical float64
+		stake, win  int64
+		rounds      int
+		want        RTPCheck
+	}{
+		{"within band", 96, 1_000_000, 950_000, 10_000,
+			RTPCheck{Actual: 95, Lower: 66, Upper: 126, Evaluated: true}},
+		{"below band", 96, 1_000_000, 600_000, 10_000,
+			RTPCheck{Actual: 60, Lower: 66, Upper: 126, Evaluated: true, Deviates: true}},
+		{"above band", 96, 1_000_000, 1_300_000, 10_000,
+			RTPCheck{Actual: 130, Lower: 66, Upper: 126, Evaluated: true, Deviates: true}},
+		{"band narrows with volume", 96, 10_000_000, 9_000_000, 1_000_000,
+			RTPCheck{Actual: 90, Lower: 93, Upper: 99, Evaluated: true, Deviates: true}},
+		{"small sample widens band", 96, 100_000, 0, 1_000,
+			RTPCheck{Actual: 0, Lower: 1.13, Upper: 190.87, Evaluated: true, Deviates: true}},
+		{"lower bound floors at zero", 50, 100_000, 0, 1_000,
+			RTPCheck{Actual: 0, Lower: 0, Upper: 144.87, Evaluated: true}},
+		{"too few rounds", 96, 100_000, 0, 999, RTPCheck{Actual: 0}},
+		{"no theoretical rtp", 0, 100_000, 50_000, 10_000, RTPCheck{Actual: 50}},
+		{"no stakes", 96, 0, 0, 10_000, RTPCheck{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CheckRTP(rules, tt.theoretical, tt.stake, tt.win, tt.rounds))
+		})
+	}
+}
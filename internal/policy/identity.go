@@ -17,7 +17,7 @@ func EvaluateIdentityPolicy(profile *domain.PlayerProfile) (IdentityStatus, erro
 		AgeVerified:   profile.DateOfBirth != nil && *profile.DateOfBirth != "",
 		KYCApproved:   profile.Verified,
 		OnWatchlist:   false, // Would integrate with external watchlist service
-		AccountActive: profile.AccountStatus == "active",
+		AccountActive: profile.AccountStatus == string(domain.AccountStatusActive),
 	}
 	return status, nil
 }
@@ -0,0 +1,32 @@
+package policy
+
+import "time"
+
+// InPlaySuspension configures the automatic freeze of a feed-priced event's
+// markets when the event goes live or a price swings sharply. A zero Freeze
+// disables it.
+type InPlaySuspension struct {
+	Freeze   time.Duration // how long markets stay suspended
+	SwingBps int           // price move, in basis points, that counts as a swing
+}
+
+// DefaultInPlaySuspension freezes markets for a minute on a move of 15% or
+// more.
+func DefaultInPlaySuspension() InPlaySuspension {
+	return InPlaySuspension{Freeze: time.Minute, SwingBps: 1500}
+}
+
+// Enabled reports whether markets are frozen at all.
+func (r InPlaySuspension) Enabled() bool {
+	return r.Freeze > 0
+}
+
+// IsSwing reports whether a selection repriced from previous to current
+// moved far enough to freeze its market. A selection without a previous
+// price, or rules without a swing threshold, never swing.
+func (r InPlaySuspension) IsSwing(previous, current int) bool {
+	if !r.Enabled() || r.SwingBps <= 0 || previous <= 0 {
+		return false
+	}
+	return !OddsChangeAcceptable(previous, current, r.SwingBps)
+}
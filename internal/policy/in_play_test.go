@@ -0,0 +1,34 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInPlaySuspension_IsSwing(t *testing.T) {
+	rules := DefaultInPlaySuspension()
+	tests := []struct {
+		name              string
+		previous, current int
+		want              bool
+	}{
+		{"unchanged", 200, 200, false},
+		{"small move", 200, 220, false},
+		{"shortened at threshold", 200, 170, false},
+		{"shortened past threshold", 200, 169, true},
+		{"lengthened past threshold", 200, 231, true},
+		{"new selection", 0, 500, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.IsSwing(tt.previous, tt.current))
+		})
+	}
+}
+
+func TestInPlaySuspension_Disabled(t *testing.T) {
+	assert.False(t, InPlaySuspension{SwingBps: 1500}.IsSwing(200, 400))
+	assert.False(t, InPlaySuspension{Freeze: 60}.IsSwing(200, 400))
+	assert.False(t, InPlaySuspension{}.Enabled())
+}
@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// Checkpoints where a player's IP is screened.
+const (
+	IPCheckpointRegistration = "registration"
+	IPCheckpointDeposit      = "deposit"
+	IPCheckpointBet          = "bet"
+)
+
+// IP screening verdicts, least to most severe. A review verdict lets the
+// request through and flags it for compliance.
+const (
+	IPVerdictAllow  = "allow"
+	IPVerdictReview = "review"
+	IPVerdictBlock  = "block"
+)
+
+var ipVerdictSeverity = map[string]int{IPVerdictAllow: 0, IPVerdictReview: 1, IPVerdictBlock: 2}
+
+// IPRiskRules configures IP screening.
+type IPRiskRules struct {
+	// ProxyVerdicts is the verdict per checkpoint for an address detected
+	// as a VPN, proxy or Tor exit. A missing checkpoint allows.
+	ProxyVerdicts map[string]string
+	// ReviewRisk and BlockRisk are provider risk scores (0-100) at or above
+	// which a request is flagged or blocked at any checkpoint; 0 disables.
+	ReviewRisk int
+	BlockRisk  int
+}
+
+// DefaultIPRiskRules flags VPN registrations and bets for review and blocks
+// deposits through them.
+func DefaultIPRiskRules() IPRiskRules {
+	return IPRiskRules{
+		ProxyVerdicts: map[string]string{
+			IPCheckpointRegistration: IPVerdictReview,
+			IPCheckpointDeposit:      IPVerdictBlock,
+			IPCheckpointBet:          IPVerdictReview,
+		},
+		ReviewRisk: 66,
+		BlockRisk:  90,
+	}
+}
+
+// ParseIPProxyVerdicts parses "registration=review,deposit=block,bet=allow"
+// and merges it over the defaults.
+func ParseIPProxyVerdicts(s string) (map[string]string, error) {
+	verdicts := DefaultIPRiskRules().ProxyVerdicts
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		checkpoint, verdict, ok := strings.Cut(entry, "=")
+		checkpoint = strings.ToLower(strings.TrimSpace(checkpoint))
+		verdict = strings.ToLower(strings.TrimSpace(verdict))
+		if !ok {
+			return nil, fmt.Errorf("ip proxy verdict %q: want CHECKPOINT=VERDICT", entry)
+		}
+		if _, known := verdicts[checkpoint]; !known {
+			return nil, fmt.Errorf("ip proxy verdict %q: unknown checkpoint", entry)
+		}
+		if _, known := ipVerdictSeverity[verdict]; !known {
+			return nil, fmt.Errorf("ip proxy verdict %q: verdict must be allow, review or block", entry)
+		}
+		verdicts[checkpoint] = verdict
+	}
+	return verdicts, nil
+}
+
+// EvaluateIPRisk returns the verdict for a request at checkpoint from an
+// address with reputation rep, and the reason for anything but allow. The
+// most severe of the proxy and risk-score verdicts wins.
+func EvaluateIPRisk(rules IPRiskRules, checkpoint string, rep domain.IPReputation) (verdict, reason string) {
+	verdict = IPVerdictAllow
+	raise := func(v, why string) {
+		if ipVerdictSeverity[v] > ipVerdictSeverity[verdict] {
+			verdict, reason = v, why
+		}
+	}
+
+	if rep.Proxy {
+		kind := strings.ToLower(rep.ProxyType)
+		if kind == "" {
+			kind = "proxy"
+		}
+		if v, ok := rules.ProxyVerdicts[checkpoint]; ok {
+			raise(v, "proxy:"+kind)
+		}
+	}
+	if rules.BlockRisk > 0 && rep.Risk >= rules.BlockRisk {
+		raise(IPVerdictBlock, fmt.Sprintf("risk:%d", rep.Risk))
+	}
+	if rules.ReviewRisk > 0 && rep.Risk >= rules.ReviewRisk {
+		raise(IPVerdictReview, fmt.Sprintf("risk:%d", rep.Risk))
+	}
+	return verdict, reason
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateIPRisk(t *testing.T) {
+	rules := DefaultIPRiskRules()
+
+	tests := []struct {
+		name        string
+		checkpoint  string
+		rep         domain.IPReputation
+		wantVerdict string
+		wantReason  string
+	}{
+		{"clean address", IPCheckpointDeposit, domain.IPReputation{Risk: 10}, IPVerdictAllow, ""},
+		{"vpn deposit blocked", IPCheckpointDeposit, domain.IPReputation{Proxy: true, ProxyType: "VPN"}, IPVerdictBlock, "proxy:vpn"},
+		{"vpn bet flagged", IPCheckpointBet, domain.IPReputation{Proxy: true, ProxyType: "VPN"}, IPVerdictReview, "proxy:vpn"},
+		{"untyped proxy", IPCheckpointRegistration, domain.IPReputation{Proxy: true}, IPVerdictReview, "proxy:proxy"},
+		{"risky address flagged", IPCheckpointBet, domain.IPReputation{Risk: 66}, IPVerdictReview, "risk:66"},
+		{"very risky address blocked", IPCheckpointBet, domain.IPReputation{Risk: 95}, IPVerdictBlock, "risk:95"},
+		{"risk outranks proxy verdict", IPCheckpointBet, domain.IPReputation{Proxy: true, ProxyType: "TOR", Risk: 100}, IPVerdictBlock, "risk:100"},
+		{"unknown checkpoint", "withdrawal", domain.IPReputation{Proxy: true}, IPVerdictAllow, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, reason := EvaluateIPRisk(rules, tt.checkpoint, tt.rep)
+			assert.Equal(t, tt.wantVerdict, verdict)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+
+	verdict, _ := EvaluateIPRisk(IPRiskRules{}, IPCheckpointDeposit, domain.IPReputation{Proxy: true, Risk: 100})
+	assert.Equal(t, IPVerdictAllow, verdict)
+}
+
+func TestParseIPProxyVerdicts(t *testing.T) {
+	verdicts, err := ParseIPProxyVerdicts("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultIPRiskRules().ProxyVerdicts, verdicts)
+
+	verdicts, err = ParseIPProxyVerdicts(" bet=Block, registration=allow ")
+	require.NoError(t, err)
+	assert.Equal(t, IPVerdictBlock, verdicts[IPCheckpointBet])
+	assert.Equal(t, IPVerdictAllow, verdicts[IPCheckpointRegistration])
+	assert.Equal(t, IPVerdictBlock, verdicts[IPCheckpointDeposit])
+
+	for _, bad := range []string{"bet", "withdrawal=block", "bet=deny"} {
+		_, err := ParseIPProxyVerdicts(bad)
+		assert.Error(t, err, bad)
+	}
+}
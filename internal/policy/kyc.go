@@ -0,0 +1,34 @@
+package policy
+
+import "github.com/attaboy/platform/internal/domain"
+
+// MissingKYC returns which verification requirements the approved document
+// types do not yet cover: "identity" needs a passport, national ID or
+// driving licence and "proof_of_address" a proof of address. An empty result
+// means the player is verified.
+func MissingKYC(approved []domain.KYCDocumentType) []string {
+	var identity, address bool
+	for _, t := range approved {
+		switch t {
+		case domain.KYCPassport, domain.KYCNationalID, domain.KYCDrivingLicence:
+			identity = true
+		case domain.KYCProofOfAddress:
+			address = true
+		}
+	}
+	missing := []string{}
+	if !identity {
+		missing = append(missing, "identity")
+	}
+	if !address {
+		missing = append(missing, "proof_of_address")
+	}
+	return missing
+}
+
+// WithdrawalNeedsKYC reports whether a withdrawal of amount cents must wait
+// for verification. Withdrawals above threshold require it; a threshold of 0
+// disables the check.
+func WithdrawalNeedsKYC(threshold, amount int64, verified bool) bool {
+	return threshold > 0 && amount > threshold && !verified
+}
@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingKYC(t *testing.T) {
+	tests := []struct {
+		name     string
+		approved []domain.KYCDocumentType
+		missing  []string
+	}{
+		{"nothing approved", nil, []string{"identity", "proof_of_address"}},
+		{"selfie only", []domain.KYCDocumentType{domain.KYCSelfie}, []string{"identity", "proof_of_address"}},
+		{"passport only", []domain.KYCDocumentType{domain.KYCPassport}, []string{"proof_of_address"}},
+		{"address only", []domain.KYCDocumentType{domain.KYCProofOfAddress}, []string{"identity"}},
+		{"licence and address", []domain.KYCDocumentType{domain.KYCDrivingLicence, domain.KYCProofOfAddress}, []string{}},
+		{"national id and address", []domain.KYCDocumentType{domain.KYCProofOfAddress, domain.KYCNationalID}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.missing, MissingKYC(tt.approved))
+		})
+	}
+}
+
+func TestWithdrawalNeedsKYC(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int64
+		amount    int64
+		verified  bool
+		want      bool
+	}{
+		{"disabled", 0, 1_000_000, false, false},
+		{"below threshold", 200_000, 50_000, false, false},
+		{"at threshold", 200_000, 200_000, false, false},
+		{"above threshold, unverified", 200_000, 200_001, false, true},
+		{"above threshold, verified", 200_000, 500_000, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WithdrawalNeedsKYC(tt.threshold, tt.amount, tt.verified))
+		})
+	}
+}
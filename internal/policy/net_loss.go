@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InterventionKind is an automated responsible-gambling intervention.
+type InterventionKind string
+
+const (
+	InterventionEmailNudge         InterventionKind = "email_nudge"
+	InterventionBreakSuggestion    InterventionKind = "break_suggestion"
+	InterventionDepositLimitPrompt InterventionKind = "deposit_limit_prompt"
+)
+
+// interventionLadder is the escalation order thresholds are configured in.
+var interventionLadder = []InterventionKind{
+	InterventionEmailNudge,
+	InterventionBreakSuggestion,
+	InterventionDepositLimitPrompt,
+}
+
+// NetLossThreshold triggers an intervention once rolling net losses reach Amount (cents).
+type NetLossThreshold struct {
+	Kind   InterventionKind `json:"kind"`
+	Amount int64            `json:"amount"`
+}
+
+// NetLossRule is a jurisdiction's rolling window and intervention thresholds.
+type NetLossRule struct {
+	Window     time.Duration      `json:"window"`
+	Thresholds []NetLossThreshold `json:"thresholds"`
+}
+
+// DefaultNetLossJurisdiction keys the rule used for countries without their own.
+const DefaultNetLossJurisdiction = "default"
+
+// DefaultNetLossRules: €500 / €1,500 / €3,000 over 30 days, with tighter
+// ladders where the regulator expects earlier contact.
+var DefaultNetLossRules = map[string]NetLossRule{
+	DefaultNetLossJurisdiction: newNetLossRule(30, 50_000, 150_000, 300_000),
+	"GB":                       newNetLossRule(30, 25_000, 100_000, 200_000),
+	"NL":                       newNetLossRule(7, 15_000, 50_000, 100_000),
+}
+
+func newNetLossRule(windowDays int, amounts ...int64) NetLossRule {
+	rule := NetLossRule{Window: time.Duration(windowDays) * 24 * time.Hour}
+	for i, amount := range amounts {
+		if amount > 0 {
+			rule.Thresholds = append(rule.Thresholds, NetLossThreshold{Kind: interventionLadder[i], Amount: amount})
+		}
+	}
+	return rule
+}
+
+// ParseNetLossRules parses overrides of the form
+// "GB=30:25000,100000,200000;default=30:50000,150000,300000": a country code
+// (or "default"), the window in days, then the nudge, break and deposit-limit
+// thresholds in cents. A 0 threshold disables that step. Overrides are merged
+// over DefaultNetLossRules.
+func ParseNetLossRules(s string) (map[string]NetLossRule, error) {
+	rules := make(map[string]NetLossRule, len(DefaultNetLossRules))
+	for k, v := range DefaultNetLossRules {
+		rules[k] = v
+	}
+
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		jurisdiction, spec, ok := strings.Cut(entry, "=")
+		windowStr, amountsStr, ok2 := strings.Cut(spec, ":")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("net-loss rule %q: want JURISDICTION=DAYS:NUDGE,BREAK,LIMIT", entry)
+		}
+		jurisdiction = strings.TrimSpace(jurisdiction)
+		if !strings.EqualFold(jurisdiction, DefaultNetLossJurisdiction) {
+			jurisdiction = strings.ToUpper(jurisdiction)
+		}
+
+		days, err := strconv.Atoi(strings.TrimSpace(windowStr))
+		if err != nil || days <= 0 {
+			return nil, fmt.Errorf("net-loss rule %q: invalid window", entry)
+		}
+		parts := strings.Split(amountsStr, ",")
+		if len(parts) != len(interventionLadder) {
+			return nil, fmt.Errorf("net-loss rule %q: want %d thresholds", entry, len(interventionLadder))
+		}
+		amounts := make([]int64, len(parts))
+		for i, p := range parts {
+			amounts[i], err = strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+			if err != nil || amounts[i] < 0 {
+				return nil, fmt.Errorf("net-loss rule %q: invalid threshold %q", entry, p)
+			}
+		}
+		rules[jurisdiction] = newNetLossRule(days, amounts...)
+	}
+	return rules, nil
+}
+
+// NetLossRuleFor returns the rule for a country, falling back to the default.
+func NetLossRuleFor(rules map[string]NetLossRule, country string) (string, NetLossRule) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if rule, ok := rules[country]; ok && country != "" {
+		return country, rule
+	}
+	return DefaultNetLossJurisdiction, rules[DefaultNetLossJurisdiction]
+}
+
+// DueInterventions returns the thresholds net losses have reached whose
+// intervention has not already been triggered in the current window.
+func DueInterventions(rule NetLossRule, netLoss int64, triggered map[InterventionKind]bool) []NetLossThreshold {
+	var due []NetLossThreshold
+	for _, t := range rule.Thresholds {
+		if netLoss >= t.Amount && !triggered[t.Kind] {
+			due = append(due, t)
+		}
+	}
+	return due
+}
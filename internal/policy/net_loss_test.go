@@ -0,0 +1,68 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetLossRules(t *testing.T) {
+	t.Run("empty keeps defaults", func(t *testing.T) {
+		rules, err := ParseNetLossRules("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultNetLossRules, rules)
+	})
+
+	t.Run("override merges and zero disables a step", func(t *testing.T) {
+		rules, err := ParseNetLossRules("se=14:10000,0,80000; default=30:60000,160000,320000")
+		require.NoError(t, err)
+
+		se := rules["SE"]
+		assert.Equal(t, 14*24*time.Hour, se.Window)
+		assert.Equal(t, []NetLossThreshold{
+			{Kind: InterventionEmailNudge, Amount: 10000},
+			{Kind: InterventionDepositLimitPrompt, Amount: 80000},
+		}, se.Thresholds)
+		assert.Equal(t, int64(60000), rules[DefaultNetLossJurisdiction].Thresholds[0].Amount)
+		assert.Equal(t, DefaultNetLossRules["GB"], rules["GB"])
+	})
+
+	t.Run("malformed entries are rejected", func(t *testing.T) {
+		for _, s := range []string{"GB=25000,100000,200000", "GB=0:1,2,3", "GB=30:1,2", "GB=30:1,x,3"} {
+			_, err := ParseNetLossRules(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestNetLossRuleFor(t *testing.T) {
+	jurisdiction, rule := NetLossRuleFor(DefaultNetLossRules, "gb")
+	assert.Equal(t, "GB", jurisdiction)
+	assert.Equal(t, int64(25_000), rule.Thresholds[0].Amount)
+
+	jurisdiction, rule = NetLossRuleFor(DefaultNetLossRules, "")
+	assert.Equal(t, DefaultNetLossJurisdiction, jurisdiction)
+	assert.Equal(t, 30*24*time.Hour, rule.Window)
+}
+
+func TestDueInterventions(t *testing.T) {
+	rule := DefaultNetLossRules[DefaultNetLossJurisdiction]
+
+	assert.Empty(t, DueInterventions(rule, 49_999, nil))
+
+	due := DueInterventions(rule, 200_000, nil)
+	require.Len(t, due, 2)
+	assert.Equal(t, InterventionEmailNudge, due[0].Kind)
+	assert.Equal(t, InterventionBreakSuggestion, due[1].Kind)
+
+	due = DueInterventions(rule, 400_000, map[InterventionKind]bool{
+		InterventionEmailNudge:      true,
+		InterventionBreakSuggestion: true,
+	})
+	require.Len(t, due, 1)
+	assert.Equal(t, InterventionDepositLimitPrompt, due[0].Kind)
+
+	assert.Empty(t, DueInterventions(rule, -5_000, nil))
+}
@@ -0,0 +1,17 @@
+package policy
+
+// OddsChangeToleranceBps is how far, in basis points of the expected price,
+// a selection's odds may move between the bet slip and placement before the
+// bettor must confirm the new price.
+const OddsChangeToleranceBps = 200
+
+// OddsChangeAcceptable reports whether current is within toleranceBps of
+// the odds the bettor expected. Moves either way count: a lengthened price
+// is confirmed like a shortened one.
+func OddsChangeAcceptable(expected, current, toleranceBps int) bool {
+	diff := current - expected
+	if diff < 0 {
+		diff = -diff
+	}
+	return int64(diff)*10_000 <= int64(expected)*int64(toleranceBps)
+}
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOddsChangeAcceptable(t *testing.T) {
+	tests := []struct {
+		name              string
+		expected, current int
+		want              bool
+	}{
+		{"unchanged", 250, 250, true},
+		{"shortened within tolerance", 250, 245, true},
+		{"lengthened within tolerance", 250, 255, true},
+		{"shortened beyond tolerance", 250, 244, false},
+		{"lengthened beyond tolerance", 250, 256, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OddsChangeAcceptable(tt.expected, tt.current, OddsChangeToleranceBps))
+		})
+	}
+}
+
+func TestOddsChangeAcceptable_ZeroTolerance(t *testing.T) {
+	assert.True(t, OddsChangeAcceptable(300, 300, 0))
+	assert.False(t, OddsChangeAcceptable(300, 301, 0))
+}
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+)
+
+// MinOdds is the shortest price (x100) a selection may be offered at.
+const MinOdds = 101
+
+// MarketMarginBps returns a market's overround in basis points: the sum of
+// the selections' implied probabilities less 100%. A fair book is 0.
+func MarketMarginBps(odds []int) int {
+	return int(math.Round((impliedBook(odds) - 1) * 10000))
+}
+
+// ApplyMarginDelta reprices a market so its overround changes by deltaBps
+// while keeping the selections' relative probabilities. Each implied
+// probability is scaled by the same factor and the odds (x100) rounded to the
+// nearest hundredth.
+func ApplyMarginDelta(odds []int, deltaBps int) ([]int, error) {
+	if len(odds) == 0 {
+		return nil, fmt.Errorf("market has no active selections")
+	}
+	for _, o := range odds {
+		if o < MinOdds {
+			return nil, fmt.Errorf("odds must be at least %d", MinOdds)
+		}
+	}
+	book := impliedBook(odds)
+	target := book + float64(deltaBps)/10000
+	if target <= 0 {
+		return nil, fmt.Errorf("margin delta of %d bps leaves no book", deltaBps)
+	}
+	factor := target / book
+
+	repriced := make([]int, len(odds))
+	for i, o := range odds {
+		repriced[i] = int(math.Round(float64(o) / factor))
+		if repriced[i] < MinOdds {
+			return nil, fmt.Errorf("margin delta of %d bps prices a selection below %d", deltaBps, MinOdds)
+		}
+	}
+	return repriced, nil
+}
+
+func impliedBook(odds []int) float64 {
+	var book float64
+	for _, o := range odds {
+		book += 100 / float64(o)
+	}
+	return book
+}
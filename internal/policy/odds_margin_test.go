@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketMarginBps(t *testing.T) {
+	assert.Equal(t, 0, MarketMarginBps([]int{200, 200}))
+	assert.Equal(t, 526, MarketMarginBps([]int{190, 190}))
+	assert.Equal(t, 0, MarketMarginBps([]int{300, 300, 300}))
+}
+
+func TestApplyMarginDelta(t *testing.T) {
+	tests := []struct {
+		name  string
+		odds  []int
+		delta int
+		want  []int
+	}{
+		{"widen an even book", []int{200, 200}, 500, []int{190, 190}},
+		{"tighten back", []int{190, 190}, -526, []int{200, 200}},
+		{"keeps relative prices", []int{150, 400}, 400, []int{144, 383}},
+		{"zero delta", []int{250, 160}, 0, []int{250, 160}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyMarginDelta(tt.odds, tt.delta)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyMarginDelta_Rejects(t *testing.T) {
+	_, err := ApplyMarginDelta(nil, 100)
+	assert.Error(t, err)
+
+	_, err = ApplyMarginDelta([]int{200, 200}, -10000)
+	assert.Error(t, err, "no book left")
+
+	_, err = ApplyMarginDelta([]int{105, 2000}, 2000)
+	assert.Error(t, err, "short price pushed below 1.01")
+}
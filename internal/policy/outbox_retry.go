@@ -0,0 +1,38 @@
+package policy
+
+import "time"
+
+// OutboxRetryPolicy bounds how long a failing outbox row is retried before it
+// is dead-lettered.
+type OutboxRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultOutboxRetryPolicy retries 8 times, 1s doubling to a 5 minute cap
+// (roughly 10 minutes end to end).
+func DefaultOutboxRetryPolicy() OutboxRetryPolicy {
+	return OutboxRetryPolicy{MaxAttempts: 8, BaseDelay: time.Second, MaxDelay: 5 * time.Minute}
+}
+
+// OutboxRetryDecision is what to do with a row after a failed publish.
+type OutboxRetryDecision struct {
+	DeadLetter bool
+	Delay      time.Duration // wait before the next attempt when not dead-lettered
+}
+
+// EvaluateOutboxRetry decides the next step after the attempts-th failure.
+func EvaluateOutboxRetry(p OutboxRetryPolicy, attempts int) OutboxRetryDecision {
+	if attempts >= p.MaxAttempts {
+		return OutboxRetryDecision{DeadLetter: true}
+	}
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return OutboxRetryDecision{Delay: delay}
+}
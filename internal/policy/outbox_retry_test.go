@@ -0,0 +1,29 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateOutboxRetry_Backoff(t *testing.T) {
+	p := DefaultOutboxRetryPolicy()
+
+	assert.Equal(t, time.Second, EvaluateOutboxRetry(p, 1).Delay)
+	assert.Equal(t, 2*time.Second, EvaluateOutboxRetry(p, 2).Delay)
+	assert.Equal(t, 8*time.Second, EvaluateOutboxRetry(p, 4).Delay)
+	assert.False(t, EvaluateOutboxRetry(p, 7).DeadLetter)
+}
+
+func TestEvaluateOutboxRetry_CapsDelay(t *testing.T) {
+	p := OutboxRetryPolicy{MaxAttempts: 50, BaseDelay: time.Second, MaxDelay: time.Minute}
+	assert.Equal(t, time.Minute, EvaluateOutboxRetry(p, 30).Delay)
+}
+
+func TestEvaluateOutboxRetry_DeadLetters(t *testing.T) {
+	p := DefaultOutboxRetryPolicy()
+	d := EvaluateOutboxRetry(p, p.MaxAttempts)
+	assert.True(t, d.DeadLetter)
+	assert.Zero(t, d.Delay)
+}
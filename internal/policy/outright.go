@@ -0,0 +1,58 @@
+package policy
+
+// EachWayTerms are a market's each-way terms: how many places pay and the
+// fraction of the win odds paid on the place line (4 means 1/4 the odds).
+type EachWayTerms struct {
+	Places   int `json:"places"`
+	Fraction int `json:"fraction"`
+}
+
+// Placing is where a selection finished and how many selections dead-heated
+// for that position. Position 0 means unplaced.
+type Placing struct {
+	Position int `json:"position"`
+	DeadHeat int `json:"dead_heat"`
+}
+
+// PlaceOdds returns the place-line odds (x100) for win odds (x100) under
+// terms, rounded down.
+func PlaceOdds(odds int, terms EachWayTerms) int {
+	return 100 + (odds-100)/terms.Fraction
+}
+
+// EachWayStake returns the total debited for an each-way bet: one stake on
+// the win line and one on the place line.
+func EachWayStake(stakePerLine int64) int64 {
+	return 2 * stakePerLine
+}
+
+// deadHeatReturn pays stake at odds on the part of a placing that falls inside
+// the paying positions. Under dead-heat rules, when k selections tie for
+// position p the stake is divided by k and multiplied by the number of paying
+// places the tie covers, so two joint winners of a win market each pay half.
+func deadHeatReturn(stake int64, odds int, p Placing, paying int) int64 {
+	if p.Position <= 0 || p.Position > paying {
+		return 0
+	}
+	tied := max(p.DeadHeat, 1)
+	shares := min(paying-p.Position+1, tied)
+	return stake * int64(odds) * int64(shares) / (100 * int64(tied))
+}
+
+// OutrightReturn returns the total paid on an outright bet given the
+// selection's placing. stake is per line; eachWay is nil for a win-only bet.
+// The win line pays on first place and the place line on any paying place at
+// PlaceOdds, each reduced by dead-heat rules.
+func OutrightReturn(stake int64, odds int, eachWay *EachWayTerms, p Placing) int64 {
+	ret := deadHeatReturn(stake, odds, p, 1)
+	if eachWay != nil {
+		ret += deadHeatReturn(stake, PlaceOdds(odds, *eachWay), p, eachWay.Places)
+	}
+	return ret
+}
+
+// OutrightMaxReturn returns what an outright bet pays if its selection wins
+// outright with no dead heat.
+func OutrightMaxReturn(stake int64, odds int, eachWay *EachWayTerms) int64 {
+	return OutrightReturn(stake, odds, eachWay, Placing{Position: 1, DeadHeat: 1})
+}
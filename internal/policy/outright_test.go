@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceOdds(t *testing.T) {
+	assert.Equal(t, 200, PlaceOdds(500, EachWayTerms{Places: 3, Fraction: 4}))
+	assert.Equal(t, 300, PlaceOdds(1100, EachWayTerms{Places: 4, Fraction: 5}))
+	assert.Equal(t, 150, PlaceOdds(300, EachWayTerms{Places: 2, Fraction: 4}))
+}
+
+func TestOutrightReturn(t *testing.T) {
+	terms := &EachWayTerms{Places: 3, Fraction: 4} // place odds 200 at 500
+
+	tests := []struct {
+		name    string
+		eachWay *EachWayTerms
+		placing Placing
+		want    int64
+	}{
+		{"win only, winner", nil, Placing{1, 1}, 5000},
+		{"win only, second", nil, Placing{2, 1}, 0},
+		{"win only, two-way dead heat for first", nil, Placing{1, 2}, 2500},
+		{"each-way, winner", terms, Placing{1, 1}, 5000 + 2000},
+		{"each-way, placed third", terms, Placing{3, 1}, 2000},
+		{"each-way, unplaced", terms, Placing{4, 1}, 0},
+		{"each-way, unranked", terms, Placing{0, 1}, 0},
+		{"each-way, dead heat for first", terms, Placing{1, 2}, 2500 + 2000},
+		{"each-way, dead heat for last place", terms, Placing{3, 2}, 1000},
+		{"each-way, three-way dead heat for second", terms, Placing{2, 3}, 1333},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OutrightReturn(1000, 500, tt.eachWay, tt.placing))
+		})
+	}
+}
+
+func TestOutrightMaxReturn(t *testing.T) {
+	assert.Equal(t, int64(2500), OutrightMaxReturn(1000, 250, nil))
+	assert.Equal(t, int64(2500+1370), OutrightMaxReturn(1000, 250, &EachWayTerms{Places: 2, Fraction: 4}))
+	assert.Equal(t, int64(2000), EachWayStake(1000))
+}
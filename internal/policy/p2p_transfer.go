@@ -0,0 +1,84 @@
+package policy
+
+// TransferRules configures player-to-player transfers. The zero value has
+// transfers disabled.
+type TransferRules struct {
+	Enabled     bool  `json:"enabled"`
+	MaxTransfer int64 `json:"max_transfer"` // per transfer, cents; 0 = no cap
+	DailyLimit  int64 `json:"daily_limit"`  // sent per UTC day, cents; 0 = no cap
+	FeeBps      int   `json:"fee_bps"`      // fee in basis points of the amount
+	FeeMin      int64 `json:"fee_min"`      // minimum fee when FeeBps applies, cents
+
+	// AML: a sender gifting this many distinct players in a day, or a
+	// recipient receiving from this many distinct players, raises an alert.
+	FanOutRecipients int `json:"fan_out_recipients"`
+	FanInSenders     int `json:"fan_in_senders"`
+}
+
+// DefaultTransferRules: off, €250 per transfer, €500 a day, no fee.
+func DefaultTransferRules() TransferRules {
+	return TransferRules{
+		MaxTransfer:      25_000,
+		DailyLimit:       50_000,
+		FanOutRecipients: 5,
+		FanInSenders:     5,
+	}
+}
+
+// TransferFee returns the fee charged to the sender on top of amount.
+func TransferFee(rules TransferRules, amount int64) int64 {
+	if rules.FeeBps <= 0 && rules.FeeMin <= 0 {
+		return 0
+	}
+	return max(amount*int64(rules.FeeBps)/10_000, rules.FeeMin)
+}
+
+// TransferCheck holds the facts gathered for a transfer request. Counts for
+// the day include the transfer being checked.
+type TransferCheck struct {
+	Amount                  int64 `json:"amount"`     // cents
+	SentToday               int64 `json:"sent_today"` // completed transfers, cents
+	RecipientsToday         int   `json:"recipients_today"`
+	SendersToRecipientToday int   `json:"senders_to_recipient_today"`
+	SenderKYCVerified       bool  `json:"sender_kyc_verified"`
+	SenderOpenAMLAlerts     int   `json:"sender_open_aml_alerts"`
+	RecipientOpenAMLAlerts  int   `json:"recipient_open_aml_alerts"`
+}
+
+// TransferDecision is the outcome of a transfer check. An allowed transfer
+// may still carry AML alerts to raise.
+type TransferDecision struct {
+	Allowed bool     `json:"allowed"`
+	Reason  string   `json:"reason,omitempty"`
+	Fee     int64    `json:"fee"`
+	Alerts  []string `json:"alerts,omitempty"`
+}
+
+// EvaluateTransfer decides whether a player-to-player transfer may proceed.
+// Open AML alerts on either side hold the transfer; unverified senders are
+// refused.
+func EvaluateTransfer(rules TransferRules, c TransferCheck) TransferDecision {
+	switch {
+	case !rules.Enabled:
+		return TransferDecision{Reason: "disabled"}
+	case c.Amount <= 0:
+		return TransferDecision{Reason: "invalid_amount"}
+	case rules.MaxTransfer > 0 && c.Amount > rules.MaxTransfer:
+		return TransferDecision{Reason: "exceeds_max_transfer"}
+	case rules.DailyLimit > 0 && c.SentToday+c.Amount > rules.DailyLimit:
+		return TransferDecision{Reason: "exceeds_daily_limit"}
+	case !c.SenderKYCVerified:
+		return TransferDecision{Reason: "kyc_required"}
+	case c.SenderOpenAMLAlerts > 0 || c.RecipientOpenAMLAlerts > 0:
+		return TransferDecision{Reason: "aml_hold"}
+	}
+
+	d := TransferDecision{Allowed: true, Fee: TransferFee(rules, c.Amount)}
+	if rules.FanOutRecipients > 0 && c.RecipientsToday >= rules.FanOutRecipients {
+		d.Alerts = append(d.Alerts, "p2p_fan_out")
+	}
+	if rules.FanInSenders > 0 && c.SendersToRecipientToday >= rules.FanInSenders {
+		d.Alerts = append(d.Alerts, "p2p_fan_in")
+	}
+	return d
+}
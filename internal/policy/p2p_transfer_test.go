@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateTransfer(t *testing.T) {
+	rules := DefaultTransferRules()
+	rules.Enabled = true
+	base := TransferCheck{Amount: 10000, SentToday: 0, RecipientsToday: 1, SendersToRecipientToday: 1, SenderKYCVerified: true}
+
+	tests := []struct {
+		name   string
+		rules  func(r *TransferRules)
+		modify func(c *TransferCheck)
+		reason string
+	}{
+		{"allowed", nil, nil, ""},
+		{"disabled by default", func(r *TransferRules) { *r = DefaultTransferRules() }, nil, "disabled"},
+		{"zero amount", nil, func(c *TransferCheck) { c.Amount = 0 }, "invalid_amount"},
+		{"over per-transfer cap", nil, func(c *TransferCheck) { c.Amount = 25001 }, "exceeds_max_transfer"},
+		{"at daily limit", nil, func(c *TransferCheck) { c.SentToday = 40000 }, ""},
+		{"over daily limit", nil, func(c *TransferCheck) { c.SentToday = 40001 }, "exceeds_daily_limit"},
+		{"no caps", func(r *TransferRules) { r.MaxTransfer, r.DailyLimit = 0, 0 }, func(c *TransferCheck) { c.Amount, c.SentToday = 1_000_000, 1_000_000 }, ""},
+		{"sender not verified", nil, func(c *TransferCheck) { c.SenderKYCVerified = false }, "kyc_required"},
+		{"sender aml alert", nil, func(c *TransferCheck) { c.SenderOpenAMLAlerts = 1 }, "aml_hold"},
+		{"recipient aml alert", nil, func(c *TransferCheck) { c.RecipientOpenAMLAlerts = 1 }, "aml_hold"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, c := rules, base
+			if tt.rules != nil {
+				tt.rules(&r)
+			}
+			if tt.modify != nil {
+				tt.modify(&c)
+			}
+			d := EvaluateTransfer(r, c)
+			assert.Equal(t, tt.reason == "", d.Allowed)
+			assert.Equal(t, tt.reason, d.Reason)
+		})
+	}
+}
+
+func TestEvaluateTransfer_AMLAlerts(t *testing.T) {
+	rules := DefaultTransferRules()
+	rules.Enabled = true
+	c := TransferCheck{Amount: 1000, SenderKYCVerified: true, RecipientsToday: 5, SendersToRecipientToday: 4}
+
+	d := EvaluateTransfer(rules, c)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, []string{"p2p_fan_out"}, d.Alerts)
+
+	c.RecipientsToday, c.SendersToRecipientToday = 1, 5
+	assert.Equal(t, []string{"p2p_fan_in"}, EvaluateTransfer(rules, c).Alerts)
+}
+
+func TestTransferFee(t *testing.T) {
+	assert.Equal(t, int64(0), TransferFee(TransferRules{}, 10000))
+	assert.Equal(t, int64(150), TransferFee(TransferRules{FeeBps: 150}, 10000))
+	assert.Equal(t, int64(50), TransferFee(TransferRules{FeeBps: 150, FeeMin: 50}, 1000))
+	assert.Equal(t, int64(25), TransferFee(TransferRules{FeeMin: 25}, 10000))
+}
@@ -0,0 +1,80 @@
+package policy
+
+import "time"
+
+// Placement verticals. VerticalAll matches any requested vertical.
+const (
+	VerticalAll         = "all"
+	VerticalCasino      = "casino"
+	VerticalSportsbook  = "sportsbook"
+	VerticalPredictions = "predictions"
+)
+
+// Player segments a placement can target. Every player is in SegmentAll.
+const (
+	SegmentAll          = "all"
+	SegmentNew          = "new"
+	SegmentDepositor    = "depositor"
+	SegmentNonDepositor = "non_depositor"
+	SegmentVIP          = "vip"
+	SegmentDormant      = "dormant"
+)
+
+const (
+	// NewPlayerWindow is how long after registration a player counts as new.
+	NewPlayerWindow = 7 * 24 * time.Hour
+	// DormantAfter is how long without a bet before an established player is dormant.
+	DormantAfter = 30 * 24 * time.Hour
+	// VIPLifetimeDeposits is the lifetime deposit total (cents) for the VIP segment.
+	VIPLifetimeDeposits = 1_000_000
+)
+
+// SegmentFacts holds what segmentation needs to know about a player.
+type SegmentFacts struct {
+	RegisteredAt     time.Time  `json:"registered_at"`
+	DepositCount     int        `json:"deposit_count"`
+	LifetimeDeposits int64      `json:"lifetime_deposits"` // cents
+	LastBetAt        *time.Time `json:"last_bet_at,omitempty"`
+}
+
+// PlayerSegments returns every segment the player belongs to.
+func PlayerSegments(f SegmentFacts, now time.Time) []string {
+	segments := []string{SegmentAll}
+	if now.Sub(f.RegisteredAt) < NewPlayerWindow {
+		segments = append(segments, SegmentNew)
+	}
+	if f.DepositCount > 0 {
+		segments = append(segments, SegmentDepositor)
+	} else {
+		segments = append(segments, SegmentNonDepositor)
+	}
+	if f.LifetimeDeposits >= VIPLifetimeDeposits {
+		segments = append(segments, SegmentVIP)
+	}
+	lastActive := f.RegisteredAt
+	if f.LastBetAt != nil {
+		lastActive = *f.LastBetAt
+	}
+	if now.Sub(lastActive) >= DormantAfter {
+		segments = append(segments, SegmentDormant)
+	}
+	return segments
+}
+
+// ValidSegment reports whether s is a known segment.
+func ValidSegment(s string) bool {
+	switch s {
+	case SegmentAll, SegmentNew, SegmentDepositor, SegmentNonDepositor, SegmentVIP, SegmentDormant:
+		return true
+	}
+	return false
+}
+
+// ValidVertical reports whether v is a known vertical.
+func ValidVertical(v string) bool {
+	switch v {
+	case VerticalAll, VerticalCasino, VerticalSportsbook, VerticalPredictions:
+		return true
+	}
+	return false
+}
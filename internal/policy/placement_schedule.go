@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// ScheduleFacts holds what the server looked up at serve time.
+type ScheduleFacts struct {
+	GameDay     bool // an event matching the GameDay rule starts today
+	Maintenance bool // a maintenance window covers the placement's vertical
+}
+
+// ValidateSchedule checks a placement schedule's rules are well-formed.
+func ValidateSchedule(s domain.PlacementSchedule) error {
+	if _, err := scheduleLocation(s); err != nil {
+		return fmt.Errorf("unknown timezone: %s", s.Timezone)
+	}
+	for _, d := range s.Weekdays {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("weekdays must be 0 (Sunday) to 6 (Saturday)")
+		}
+	}
+	if h := s.Hours; h != nil {
+		if h.From < 0 || h.From > 23 || h.To < 1 || h.To > 24 || h.From == h.To {
+			return fmt.Errorf("hours must run from 0-23 to 1-24 and not be empty")
+		}
+	}
+	if s.GameDay != nil && s.GameDay.Sport == "" {
+		return fmt.Errorf("game_day.sport is required")
+	}
+	return nil
+}
+
+// ScheduleDay returns the bounds of the schedule's local day containing now,
+// for looking up game days.
+func ScheduleDay(s domain.PlacementSchedule, now time.Time) (start, end time.Time) {
+	loc, err := scheduleLocation(s)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	start = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// ScheduleAllows reports whether a placement with schedule s may be served
+// at now.
+func ScheduleAllows(s domain.PlacementSchedule, now time.Time, facts ScheduleFacts) bool {
+	if s.HideDuringMaintenance && facts.Maintenance {
+		return false
+	}
+	if s.GameDay != nil && !facts.GameDay {
+		return false
+	}
+
+	loc, err := scheduleLocation(s)
+	if err != nil {
+		return false
+	}
+	local := now.In(loc)
+	if len(s.Weekdays) > 0 {
+		match := false
+		for _, d := range s.Weekdays {
+			if d == local.Weekday() {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	if h := s.Hours; h != nil {
+		hour := local.Hour()
+		if h.From < h.To {
+			return hour >= h.From && hour < h.To
+		}
+		return hour >= h.From || hour < h.To
+	}
+	return true
+}
+
+func scheduleLocation(s domain.PlacementSchedule) (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerSegments(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	recentBet := now.Add(-24 * time.Hour)
+	oldBet := now.Add(-45 * 24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		facts SegmentFacts
+		want  []string
+	}{
+		{"fresh signup", SegmentFacts{RegisteredAt: now.Add(-time.Hour)},
+			[]string{SegmentAll, SegmentNew, SegmentNonDepositor}},
+		{"active depositor", SegmentFacts{RegisteredAt: now.Add(-60 * 24 * time.Hour), DepositCount: 2, LifetimeDeposits: 5000, LastBetAt: &recentBet},
+			[]string{SegmentAll, SegmentDepositor}},
+		{"vip gone quiet", SegmentFacts{RegisteredAt: now.Add(-400 * 24 * time.Hour), DepositCount: 40, LifetimeDeposits: VIPLifetimeDeposits, LastBetAt: &oldBet},
+			[]string{SegmentAll, SegmentDepositor, SegmentVIP, SegmentDormant}},
+		{"never bet, old account", SegmentFacts{RegisteredAt: now.Add(-31 * 24 * time.Hour)},
+			[]string{SegmentAll, SegmentNonDepositor, SegmentDormant}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PlayerSegments(tt.facts, now))
+		})
+	}
+}
+
+func TestValidSegmentAndVertical(t *testing.T) {
+	assert.True(t, ValidSegment(SegmentVIP))
+	assert.False(t, ValidSegment("whales"))
+	assert.True(t, ValidVertical(VerticalSportsbook))
+	assert.False(t, ValidVertical("bingo"))
+}
+
+func TestScheduleAllows(t *testing.T) {
+	// Sunday 2026-09-13 18:00 UTC is 14:00 in New York.
+	now := time.Date(2026, 9, 13, 18, 0, 0, 0, time.UTC)
+	nfl := &domain.GameDayRule{Sport: "americanfootball"}
+
+	tests := []struct {
+		name     string
+		schedule domain.PlacementSchedule
+		facts    ScheduleFacts
+		want     bool
+	}{
+		{"no rules", domain.PlacementSchedule{}, ScheduleFacts{}, true},
+		{"game day with games", domain.PlacementSchedule{GameDay: nfl}, ScheduleFacts{GameDay: true}, true},
+		{"game day without games", domain.PlacementSchedule{GameDay: nfl}, ScheduleFacts{}, false},
+		{"hidden in maintenance", domain.PlacementSchedule{HideDuringMaintenance: true}, ScheduleFacts{Maintenance: true}, false},
+		{"maintenance ignored", domain.PlacementSchedule{}, ScheduleFacts{Maintenance: true}, true},
+		{"weekday match", domain.PlacementSchedule{Weekdays: []time.Weekday{time.Saturday, time.Sunday}}, ScheduleFacts{}, true},
+		{"weekday miss", domain.PlacementSchedule{Weekdays: []time.Weekday{time.Monday}}, ScheduleFacts{}, false},
+		{"local hours", domain.PlacementSchedule{Timezone: "America/New_York", Hours: &domain.HourWindow{From: 12, To: 16}}, ScheduleFacts{}, true},
+		{"utc hours miss", domain.PlacementSchedule{Hours: &domain.HourWindow{From: 12, To: 16}}, ScheduleFacts{}, false},
+		{"overnight window", domain.PlacementSchedule{Hours: &domain.HourWindow{From: 17, To: 2}}, ScheduleFacts{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ScheduleAllows(tt.schedule, now, tt.facts))
+		})
+	}
+}
+
+func TestValidateScheduleAndScheduleDay(t *testing.T) {
+	assert.NoError(t, ValidateSchedule(domain.PlacementSchedule{Timezone: "Europe/Berlin", Hours: &domain.HourWindow{From: 22, To: 6}}))
+	assert.Error(t, ValidateSchedule(domain.PlacementSchedule{Timezone: "Mars/Olympus"}))
+	assert.Error(t, ValidateSchedule(domain.PlacementSchedule{Hours: &domain.HourWindow{From: 9, To: 9}}))
+	assert.Error(t, ValidateSchedule(domain.PlacementSchedule{Weekdays: []time.Weekday{7}}))
+	assert.Error(t, ValidateSchedule(domain.PlacementSchedule{GameDay: &domain.GameDayRule{League: "NFL"}}))
+
+	// 02:00 UTC on the 14th is still the 13th in New York.
+	start, end := ScheduleDay(domain.PlacementSchedule{Timezone: "America/New_York"}, time.Date(2026, 9, 14, 2, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 9, 13, 4, 0, 0, 0, time.UTC), start.UTC())
+	assert.Equal(t, 24*time.Hour, end.Sub(start))
+}
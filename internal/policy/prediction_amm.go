@@ -0,0 +1,164 @@
+package policy
+
+import "math"
+
+// PredictionBook is a house prediction market priced by a logarithmic
+// market scoring rule (LMSR). A share pays one cent if its outcome wins, so
+// an outcome's shares are the house's payout if it wins. Prices follow
+// exp(q/b) normalized over the outcomes, where q is an outcome's seed plus
+// its issued shares and b the liquidity: a larger b moves odds less per
+// stake and bounds the house's subsidy at b·ln(n).
+type PredictionBook struct {
+	// Liquidity is the LMSR b parameter, in cents.
+	Liquidity float64 `json:"liquidity"`
+	// Seed holds virtual shares that set the opening prices; they are
+	// never paid out.
+	Seed []float64 `json:"seed"`
+	// Shares holds the shares issued on each outcome.
+	Shares []int64 `json:"shares"`
+	// Collected is the net amount staked into the market.
+	Collected int64 `json:"collected"`
+	// ExposureCap caps the house's worst-case loss in cents; zero is not
+	// enforced.
+	ExposureCap int64 `json:"exposure_cap"`
+}
+
+// SeedPredictionBook returns the seed that opens an LMSR market at the given
+// decimal odds. The odds are normalized, so an overround is ignored.
+func SeedPredictionBook(liquidity float64, odds []float64) []float64 {
+	var book float64
+	for _, o := range odds {
+		book += 1 / o
+	}
+	seed := make([]float64, len(odds))
+	for i, o := range odds {
+		seed[i] = liquidity * math.Log(1/o/book)
+	}
+	return seed
+}
+
+// Prices returns each outcome's current price, its implied probability.
+func (b PredictionBook) Prices() []float64 {
+	q := b.quantities()
+	top := math.Inf(-1)
+	for _, v := range q {
+		top = max(top, v)
+	}
+	prices := make([]float64, len(q))
+	var sum float64
+	for i, v := range q {
+		prices[i] = math.Exp((v - top) / b.Liquidity)
+		sum += prices[i]
+	}
+	for i := range prices {
+		prices[i] /= sum
+	}
+	return prices
+}
+
+// Odds returns each outcome's current decimal odds, rounded to hundredths,
+// for display. A stake locks its own odds from Quote.
+func (b PredictionBook) Odds() []float64 {
+	prices := b.Prices()
+	odds := make([]float64, len(prices))
+	for i, p := range prices {
+		odds[i] = math.Round(100/p) / 100
+	}
+	return odds
+}
+
+// Quote returns the odds and payout stake gets on outcome. The stake buys
+// the shares that raise the LMSR cost by exactly stake; the odds are rounded
+// down to thousandths, as stakes store them, and the payout is stake × odds
+// rounded down, so rounding always favors the house.
+func (b PredictionBook) Quote(outcome int, stake int64) (odds float64, payout int64) {
+	if stake <= 0 {
+		return 0, 0
+	}
+	p := b.Prices()[outcome]
+	x := float64(stake) / b.Liquidity
+	// shares = b·ln((e^x − 1 + p) / p), kept finite for large stakes.
+	shares := b.Liquidity * (x + math.Log1p((p-1)*math.Exp(-x)) - math.Log(p))
+	milli := int64(math.Floor(shares / float64(stake) * 1000))
+	return float64(milli) / 1000, stake * milli / 1000
+}
+
+// Buy returns the book after a stake with the given payout on outcome.
+func (b PredictionBook) Buy(outcome int, stake, payout int64) PredictionBook {
+	b.Shares = append([]int64(nil), b.Shares...)
+	b.Shares[outcome] += payout
+	b.Collected += stake
+	return b
+}
+
+// WorstCaseLoss returns what the house loses if the outcome with the most
+// shares wins, negative when every outcome leaves it ahead.
+func (b PredictionBook) WorstCaseLoss() int64 {
+	var most int64
+	for _, s := range b.Shares {
+		most = max(most, s)
+	}
+	return most - b.Collected
+}
+
+// MaxStake returns the largest stake, at most stake, on outcome that keeps
+// the worst-case loss within the exposure cap, possibly zero.
+func (b PredictionBook) MaxStake(outcome int, stake int64) int64 {
+	fits := func(s int64) bool {
+		_, payout := b.Quote(outcome, s)
+		return b.ExposureCap <= 0 || b.Buy(outcome, s, payout).WorstCaseLoss() <= b.ExposureCap
+	}
+	if fits(stake) {
+		return stake
+	}
+	// A stake that does not fit makes its outcome the house's worst case,
+	// where the loss grows with the stake, so bisection finds the largest fit.
+	lo, hi := int64(0), stake
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if fits(mid) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
+
+func (b PredictionBook) quantities() []float64 {
+	q := make([]float64, len(b.Shares))
+	for i, s := range b.Shares {
+		q[i] = float64(s)
+		if i < len(b.Seed) {
+			q[i] += b.Seed[i]
+		}
+	}
+	return q
+}
+
+// SellValue returns what selling shares of outcome back to the book pays:
+// the fall in the LMSR cost, rounded down.
+func (b PredictionBook) SellValue(outcome int, shares int64) int64 {
+	if shares <= 0 {
+		return 0
+	}
+	p := b.Prices()[outcome]
+	value := -b.Liquidity * math.Log1p(p*math.Expm1(-float64(shares)/b.Liquidity))
+	return int64(math.Floor(value))
+}
+
+// Sell returns the book after shares of outcome were sold back for value.
+func (b PredictionBook) Sell(outcome int, shares, value int64) PredictionBook {
+	return b.Buy(outcome, -value, -shares)
+}
+
+// PredictionSellValue returns what a fixed-odds prediction stake with the
+// given payout is worth at its outcome's current decimal odds, less
+// CashOutMarginBps.
+func PredictionSellValue(payout int64, currentOdds float64) int64 {
+	if payout <= 0 || currentOdds*100 < MinOdds {
+		return 0
+	}
+	fair := int64(math.Floor(float64(payout) / currentOdds))
+	return fair * (10000 - CashOutMarginBps) / 10000
+}
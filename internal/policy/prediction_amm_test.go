@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedPredictionBook_OpensAtOdds(t *testing.T) {
+	b := PredictionBook{Liquidity: 10_000, Shares: make([]int64, 2)}
+	b.Seed = SeedPredictionBook(b.Liquidity, []float64{2.5, 1.6})
+
+	prices := b.Prices()
+	assert.InDelta(t, 0.39, prices[0], 0.01)
+	assert.InDelta(t, 0.61, prices[1], 0.01)
+	assert.InDelta(t, 1.0, prices[0]+prices[1], 1e-9)
+}
+
+func TestPredictionBook_StakesMoveOdds(t *testing.T) {
+	b := PredictionBook{Liquidity: 10_000, Shares: make([]int64, 2)}
+	assert.Equal(t, []float64{2, 2}, b.Odds())
+
+	odds, payout := b.Quote(0, 1_000)
+	// Buying moves the price up during the stake, so the stake gets less
+	// than the opening odds.
+	assert.Less(t, odds, 2.0)
+	assert.Greater(t, odds, 1.9)
+	assert.Equal(t, int64(float64(1_000)*odds), payout)
+
+	b = b.Buy(0, 1_000, payout)
+	after := b.Odds()
+	assert.Less(t, after[0], 2.0)
+	assert.Greater(t, after[1], 2.0)
+
+	// The next stake on the same outcome gets a shorter price.
+	next, _ := b.Quote(0, 1_000)
+	assert.Less(t, next, odds)
+}
+
+func TestPredictionBook_LiquidityDampensMoves(t *testing.T) {
+	thin := PredictionBook{Liquidity: 1_000, Shares: make([]int64, 2)}
+	deep := PredictionBook{Liquidity: 100_000, Shares: make([]int64, 2)}
+	thinOdds, _ := thin.Quote(0, 1_000)
+	deepOdds, _ := deep.Quote(0, 1_000)
+	assert.Less(t, thinOdds, deepOdds)
+}
+
+func TestPredictionBook_LargeStakeStaysFinite(t *testing.T) {
+	b := PredictionBook{Liquidity: 100, Shares: make([]int64, 2)}
+	odds, payout := b.Quote(0, 10_000_000)
+	assert.GreaterOrEqual(t, odds, 1.0)
+	assert.GreaterOrEqual(t, payout, int64(10_000_000))
+}
+
+func TestPredictionBook_WorstCaseLoss(t *testing.T) {
+	b := PredictionBook{Shares: []int64{3_000, 1_000}, Collected: 2_000}
+	assert.Equal(t, int64(1_000), b.WorstCaseLoss())
+	b = PredictionBook{Shares: []int64{1_000, 1_000}, Collected: 2_000}
+	assert.Equal(t, int64(-1_000), b.WorstCaseLoss())
+}
+
+func TestPredictionBook_MaxStake(t *testing.T) {
+	b := PredictionBook{Liquidity: 10_000, Shares: make([]int64, 2)}
+	assert.Equal(t, int64(5_000), b.MaxStake(0, 5_000), "no cap")
+
+	b.ExposureCap = 1_000
+	capped := b.MaxStake(0, 5_000)
+	assert.Greater(t, capped, int64(0))
+	assert.Less(t, capped, int64(5_000))
+	_, payout := b.Quote(0, capped)
+	assert.LessOrEqual(t, b.Buy(0, capped, payout).WorstCaseLoss(), int64(1_000))
+	_, payout = b.Quote(0, capped+1)
+	assert.Greater(t, b.Buy(0, capped+1, payout).WorstCaseLoss(), int64(1_000))
+}
+
+func TestPredictionBook_SellUndoesBuy(t *testing.T) {
+	b := PredictionBook{Liquidity: 10_000, Shares: make([]int64, 2)}
+	_, payout := b.Quote(0, 1_000)
+	bought := b.Buy(0, 1_000, payout)
+
+	// Selling straight back returns the stake, less rounding.
+	value := bought.SellValue(0, payout)
+	assert.LessOrEqual(t, value, int64(1_000))
+	assert.GreaterOrEqual(t, value, int64(995))
+
+	sold := bought.Sell(0, payout, value)
+	assert.Equal(t, []int64{0, 0}, sold.Shares)
+	assert.Equal(t, 1_000-value, sold.Collected)
+	assert.InDeltaSlice(t, b.Prices(), sold.Prices(), 1e-9)
+}
+
+func TestPredictionBook_SellValueFollowsPrice(t *testing.T) {
+	b := PredictionBook{Liquidity: 10_000, Shares: make([]int64, 2)}
+	_, payout := b.Quote(0, 1_000)
+	b = b.Buy(0, 1_000, payout)
+	value := b.SellValue(0, payout)
+
+	// Others backing the same outcome raise the position's value.
+	_, more := b.Quote(0, 5_000)
+	assert.Greater(t, b.Buy(0, 5_000, more).SellValue(0, payout), value)
+	// Money on the other side lowers it.
+	_, against := b.Quote(1, 5_000)
+	assert.Less(t, b.Buy(1, 5_000, against).SellValue(0, payout), value)
+}
+
+func TestPredictionSellValue(t *testing.T) {
+	// A 2,500 payout at 2.00 is worth 1,250 fair, 1,187 after the margin.
+	assert.Equal(t, int64(1_187), PredictionSellValue(2_500, 2.0))
+	assert.Zero(t, PredictionSellValue(2_500, 1.0))
+	assert.Zero(t, PredictionSellValue(0, 2.0))
+}
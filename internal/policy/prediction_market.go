@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// MaxPredictionOutcomes caps the outcomes of a house prediction market.
+const MaxPredictionOutcomes = 20
+
+// maxOutcomeIDLen is the width of prediction_stakes.outcome_id.
+const maxOutcomeIDLen = 100
+
+// ValidatePredictionOutcomes checks a house market's outcomes: two to
+// MaxPredictionOutcomes of them, each with a unique ID, a label and odds of
+// at least MinOdds.
+func ValidatePredictionOutcomes(outcomes []domain.PredictionOutcome) error {
+	if len(outcomes) < 2 || len(outcomes) > MaxPredictionOutcomes {
+		return fmt.Errorf("a market needs between 2 and %d outcomes", MaxPredictionOutcomes)
+	}
+	seen := make(map[string]bool, len(outcomes))
+	for _, o := range outcomes {
+		if strings.TrimSpace(o.ID) == "" || len(o.ID) > maxOutcomeIDLen {
+			return fmt.Errorf("outcome ids must be 1 to %d characters", maxOutcomeIDLen)
+		}
+		if seen[o.ID] {
+			return fmt.Errorf("duplicate outcome id: %s", o.ID)
+		}
+		seen[o.ID] = true
+		if strings.TrimSpace(o.Label) == "" {
+			return fmt.Errorf("outcome %s needs a label", o.ID)
+		}
+		if o.Odds*100 < MinOdds {
+			return fmt.Errorf("outcome %s odds must be at least %.2f", o.ID, float64(MinOdds)/100)
+		}
+	}
+	return nil
+}
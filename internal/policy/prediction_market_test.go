@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePredictionOutcomes(t *testing.T) {
+	yesNo := []domain.PredictionOutcome{{ID: "yes", Label: "Yes", Odds: 2.5}, {ID: "no", Label: "No", Odds: 1.6}}
+	assert.NoError(t, ValidatePredictionOutcomes(yesNo))
+
+	tests := []struct {
+		name     string
+		outcomes []domain.PredictionOutcome
+	}{
+		{"one outcome", yesNo[:1]},
+		{"duplicate id", []domain.PredictionOutcome{yesNo[0], yesNo[0]}},
+		{"empty id", []domain.PredictionOutcome{yesNo[0], {ID: " ", Label: "No", Odds: 2}}},
+		{"long id", []domain.PredictionOutcome{yesNo[0], {ID: strings.Repeat("x", 101), Label: "No", Odds: 2}}},
+		{"no label", []domain.PredictionOutcome{yesNo[0], {ID: "no", Odds: 2}}},
+		{"odds too short", []domain.PredictionOutcome{yesNo[0], {ID: "no", Label: "No", Odds: 1.001}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, ValidatePredictionOutcomes(tt.outcomes))
+		})
+	}
+}
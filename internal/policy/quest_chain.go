@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ValidateQuestPrerequisites checks the prerequisites to set on questID
+// against the rest of the quest graph, which maps each quest to the quests
+// it requires. A quest cannot require itself, list a prerequisite twice, or
+// require a quest that already requires it, directly or down a chain: the
+// cycle would leave every quest on it locked forever.
+func ValidateQuestPrerequisites(questID uuid.UUID, prerequisites []uuid.UUID, graph map[uuid.UUID][]uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(prerequisites))
+	for _, p := range prerequisites {
+		if p == questID {
+			return fmt.Errorf("a quest cannot be its own prerequisite")
+		}
+		if seen[p] {
+			return fmt.Errorf("prerequisite %s is listed twice", p)
+		}
+		seen[p] = true
+	}
+
+	// Walk down from each new prerequisite; reaching questID closes a cycle.
+	visited := map[uuid.UUID]bool{}
+	stack := append([]uuid.UUID(nil), prerequisites...)
+	for len(stack) > 0 {
+		q := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if q == questID {
+			return fmt.Errorf("prerequisites would form a cycle through quest %s", questID)
+		}
+		if visited[q] {
+			continue
+		}
+		visited[q] = true
+		stack = append(stack, graph[q]...)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateQuestPrerequisites(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	// c requires b, b requires a
+	graph := map[uuid.UUID][]uuid.UUID{c: {b}, b: {a}}
+
+	assert.NoError(t, ValidateQuestPrerequisites(d, []uuid.UUID{c, a}, graph))
+	assert.NoError(t, ValidateQuestPrerequisites(c, []uuid.UUID{a, b}, graph), "replacing c's own edges")
+	assert.NoError(t, ValidateQuestPrerequisites(a, nil, graph))
+
+	assert.Error(t, ValidateQuestPrerequisites(d, []uuid.UUID{d}, graph))
+	assert.Error(t, ValidateQuestPrerequisites(d, []uuid.UUID{a, a}, graph))
+	assert.Error(t, ValidateQuestPrerequisites(a, []uuid.UUID{b}, graph), "direct cycle")
+	assert.Error(t, ValidateQuestPrerequisites(a, []uuid.UUID{c}, graph), "cycle down a chain")
+}
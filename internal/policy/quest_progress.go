@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Quest types the progress engine advances from outbox events. Count types
+// add one per qualifying event and volume types add its amount in minor
+// units; engagement quests add the value of a recorded signal. Quests of any
+// other type, such as "standard", only move when written directly.
+const (
+	QuestTypeBetCount      = "bet_count"
+	QuestTypeBetVolume     = "bet_volume"
+	QuestTypeDepositCount  = "deposit_count"
+	QuestTypeDepositVolume = "deposit_volume"
+	QuestTypeWinCount      = "win_count"
+	QuestTypeWinVolume     = "win_volume"
+	QuestTypeEngagement    = "engagement"
+)
+
+// Kinds of player activity that advance quests.
+const (
+	QuestActivityBet        = "bet"
+	QuestActivityDeposit    = "deposit"
+	QuestActivityWin        = "win"
+	QuestActivityEngagement = "engagement"
+)
+
+// EngagementSignals are the signal types POST /engagement/signal records.
+var EngagementSignals = []string{"video", "social", "prediction", "wager", "deposit"}
+
+// QuestCriteria narrows which events count towards an automated quest. Zero
+// values match everything.
+type QuestCriteria struct {
+	// MinAmount is the smallest bet, deposit or win that counts.
+	MinAmount int64 `json:"min_amount,omitempty"`
+	// Sources limits bets and wins to these manufacturers, e.g.
+	// "sportsbook" or a casino provider.
+	Sources []string `json:"sources,omitempty"`
+	// Signal is the engagement signal an engagement quest counts; required
+	// for that type.
+	Signal string `json:"signal,omitempty"`
+}
+
+// QuestActivity is one event's worth of player activity.
+type QuestActivity struct {
+	Kind   string
+	Amount int64
+	Source string
+	Signal string
+}
+
+// questTypeActivity maps each automated quest type to the activity it
+// counts and whether it counts the activity's amount.
+var questTypeActivity = map[string]struct {
+	kind   string
+	volume bool
+}{
+	QuestTypeBetCount:      {QuestActivityBet, false},
+	QuestTypeBetVolume:     {QuestActivityBet, true},
+	QuestTypeDepositCount:  {QuestActivityDeposit, false},
+	QuestTypeDepositVolume: {QuestActivityDeposit, true},
+	QuestTypeWinCount:      {QuestActivityWin, false},
+	QuestTypeWinVolume:     {QuestActivityWin, true},
+	QuestTypeEngagement:    {QuestActivityEngagement, true},
+}
+
+// IsAutomatedQuestType reports whether the progress engine advances quests
+// of type t.
+func IsAutomatedQuestType(t string) bool {
+	_, ok := questTypeActivity[t]
+	return ok
+}
+
+// QuestTypesFor returns the quest types an activity of kind can advance.
+func QuestTypesFor(kind string) []string {
+	var types []string
+	for t, a := range questTypeActivity {
+		if a.kind == kind {
+			types = append(types, t)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// ValidateQuestCriteria checks criteria against the quest type they belong
+// to. Criteria on a type the engine does not advance are rejected.
+func ValidateQuestCriteria(questType string, c QuestCriteria) error {
+	a, ok := questTypeActivity[questType]
+	if !ok {
+		if c.MinAmount != 0 || len(c.Sources) > 0 || c.Signal != "" {
+			return fmt.Errorf("quest type %q takes no criteria", questType)
+		}
+		return nil
+	}
+	if c.MinAmount < 0 {
+		return fmt.Errorf("min_amount must not be negative")
+	}
+	if a.kind == QuestActivityEngagement {
+		if !slices.Contains(EngagementSignals, c.Signal) {
+			return fmt.Errorf("engagement quests need a signal, one of %v", EngagementSignals)
+		}
+		if c.MinAmount != 0 || len(c.Sources) > 0 {
+			return fmt.Errorf("engagement quests take only a signal")
+		}
+		return nil
+	}
+	if c.Signal != "" {
+		return fmt.Errorf("only engagement quests take a signal")
+	}
+	return nil
+}
+
+// QuestIncrement returns how far activity a moves a quest of questType with
+// criteria c, zero when it does not count.
+func QuestIncrement(questType string, c QuestCriteria, a QuestActivity) int64 {
+	t, ok := questTypeActivity[questType]
+	if !ok || t.kind != a.Kind || a.Amount <= 0 {
+		return 0
+	}
+	if a.Amount < c.MinAmount {
+		return 0
+	}
+	if len(c.Sources) > 0 && !slices.Contains(c.Sources, a.Source) {
+		return 0
+	}
+	if a.Kind == QuestActivityEngagement && a.Signal != c.Signal {
+		return 0
+	}
+	if t.volume {
+		return a.Amount
+	}
+	return 1
+}
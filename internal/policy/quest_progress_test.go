@@ -0,0 +1,55 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuestIncrement(t *testing.T) {
+	bet := QuestActivity{Kind: QuestActivityBet, Amount: 500, Source: "sportsbook"}
+	tests := []struct {
+		name      string
+		questType string
+		criteria  QuestCriteria
+		activity  QuestActivity
+		want      int64
+	}{
+		{"bet counts once", QuestTypeBetCount, QuestCriteria{}, bet, 1},
+		{"bet volume counts its stake", QuestTypeBetVolume, QuestCriteria{}, bet, 500},
+		{"deposit quest ignores bets", QuestTypeDepositCount, QuestCriteria{}, bet, 0},
+		{"below min amount", QuestTypeBetCount, QuestCriteria{MinAmount: 1000}, bet, 0},
+		{"at min amount", QuestTypeBetCount, QuestCriteria{MinAmount: 500}, bet, 1},
+		{"matching source", QuestTypeBetCount, QuestCriteria{Sources: []string{"sportsbook"}}, bet, 1},
+		{"other source", QuestTypeBetCount, QuestCriteria{Sources: []string{"pragmatic"}}, bet, 0},
+		{"zero win", QuestTypeWinCount, QuestCriteria{}, QuestActivity{Kind: QuestActivityWin}, 0},
+		{"standard quest", "standard", QuestCriteria{}, bet, 0},
+		{"engagement signal", QuestTypeEngagement, QuestCriteria{Signal: "video"},
+			QuestActivity{Kind: QuestActivityEngagement, Amount: 12, Signal: "video"}, 12},
+		{"other engagement signal", QuestTypeEngagement, QuestCriteria{Signal: "video"},
+			QuestActivity{Kind: QuestActivityEngagement, Amount: 12, Signal: "social"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, QuestIncrement(tt.questType, tt.criteria, tt.activity))
+		})
+	}
+}
+
+func TestValidateQuestCriteria(t *testing.T) {
+	assert.NoError(t, ValidateQuestCriteria("standard", QuestCriteria{}))
+	assert.NoError(t, ValidateQuestCriteria(QuestTypeBetVolume, QuestCriteria{MinAmount: 100, Sources: []string{"sportsbook"}}))
+	assert.NoError(t, ValidateQuestCriteria(QuestTypeEngagement, QuestCriteria{Signal: "social"}))
+
+	assert.Error(t, ValidateQuestCriteria("standard", QuestCriteria{MinAmount: 1}))
+	assert.Error(t, ValidateQuestCriteria(QuestTypeBetCount, QuestCriteria{MinAmount: -1}))
+	assert.Error(t, ValidateQuestCriteria(QuestTypeBetCount, QuestCriteria{Signal: "video"}))
+	assert.Error(t, ValidateQuestCriteria(QuestTypeEngagement, QuestCriteria{}))
+	assert.Error(t, ValidateQuestCriteria(QuestTypeEngagement, QuestCriteria{Signal: "video", MinAmount: 5}))
+}
+
+func TestQuestTypesFor(t *testing.T) {
+	assert.Equal(t, []string{QuestTypeBetCount, QuestTypeBetVolume}, QuestTypesFor(QuestActivityBet))
+	assert.Equal(t, []string{QuestTypeEngagement}, QuestTypesFor(QuestActivityEngagement))
+	assert.Empty(t, QuestTypesFor("withdrawal"))
+}
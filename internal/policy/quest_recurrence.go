@@ -0,0 +1,183 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quest recurrences. A recurring quest's progress resets on schedule:
+// daily at 00:00 UTC, weekly at 00:00 UTC on Monday, or on a custom cron
+// schedule evaluated in UTC. Quests that do not recur never reset.
+const (
+	QuestRecurrenceNone   = "none"
+	QuestRecurrenceDaily  = "daily"
+	QuestRecurrenceWeekly = "weekly"
+	QuestRecurrenceCron   = "cron"
+)
+
+// ValidateQuestRecurrence checks a quest's recurrence and, for cron
+// recurrence, its schedule. An empty recurrence means none.
+func ValidateQuestRecurrence(recurrence, cron string) error {
+	switch recurrence {
+	case "", QuestRecurrenceNone, QuestRecurrenceDaily, QuestRecurrenceWeekly:
+		if cron != "" {
+			return fmt.Errorf("recurrence_cron is only used with cron recurrence")
+		}
+		return nil
+	case QuestRecurrenceCron:
+		if _, err := ParseCron(cron); err != nil {
+			return fmt.Errorf("recurrence_cron: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("recurrence must be one of none, daily, weekly, cron")
+}
+
+// NextQuestReset returns when a quest with recurrence next resets after
+// after; ok is false for quests that never reset.
+func NextQuestReset(recurrence, cron string, after time.Time) (next time.Time, ok bool, err error) {
+	after = after.UTC()
+	midnight := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, time.UTC)
+	switch recurrence {
+	case "", QuestRecurrenceNone:
+		return time.Time{}, false, nil
+	case QuestRecurrenceDaily:
+		return midnight.AddDate(0, 0, 1), true, nil
+	case QuestRecurrenceWeekly:
+		days := (int(time.Monday) - int(after.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return midnight.AddDate(0, 0, days), true, nil
+	case QuestRecurrenceCron:
+		s, err := ParseCron(cron)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		next, ok := s.Next(after)
+		if !ok {
+			return time.Time{}, false, fmt.Errorf("cron schedule %q never fires", cron)
+		}
+		return next, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("unknown recurrence %q", recurrence)
+}
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week (0 or 7 is Sunday). Each field takes *,
+// numbers, ranges (1-5), lists (1,15) and steps (*/15, 8-18/2). As in cron,
+// when both day fields are restricted a day matching either one fires.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCron parses a five-field cron expression.
+func ParseCron(expr string) (CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return CronSchedule{}, fmt.Errorf("cron expression needs 5 fields, got %d", len(fields))
+	}
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("%s: %w", cronFields[i].name, err)
+		}
+		bits[i] = b
+	}
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1 // 7 is Sunday too
+	}
+	return CronSchedule{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: dow,
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rng, step = part[:i], n
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time after t, to the minute, that s fires; ok is
+// false when it does not fire within five years (e.g. 30 February).
+func (s CronSchedule) Next(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	}
+	return dom || dow
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextQuestReset(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		recurrence string
+		cron       string
+		want       time.Time
+	}{
+		{"daily", QuestRecurrenceDaily, "", time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{"weekly", QuestRecurrenceWeekly, "", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"every six hours", QuestRecurrenceCron, "0 */6 * * *", time.Date(2026, 3, 11, 18, 0, 0, 0, time.UTC)},
+		{"friday evening", QuestRecurrenceCron, "0 18 * * 5", time.Date(2026, 3, 13, 18, 0, 0, 0, time.UTC)},
+		{"first of the month", QuestRecurrenceCron, "0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"sunday as 7", QuestRecurrenceCron, "30 9 * * 7", time.Date(2026, 3, 15, 9, 30, 0, 0, time.UTC)},
+		{"day of month or week", QuestRecurrenceCron, "0 0 20 * 1", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok, err := NextQuestReset(tt.recurrence, tt.cron, now)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+
+	// Monday midnight moves on to the following week
+	next, _, err := NextQuestReset(QuestRecurrenceWeekly, "", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC), next)
+
+	_, ok, err := NextQuestReset(QuestRecurrenceNone, "", now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = NextQuestReset(QuestRecurrenceCron, "0 0 30 2 *", now)
+	assert.Error(t, err, "30 February never comes")
+}
+
+func TestValidateQuestRecurrence(t *testing.T) {
+	assert.NoError(t, ValidateQuestRecurrence("", ""))
+	assert.NoError(t, ValidateQuestRecurrence(QuestRecurrenceDaily, ""))
+	assert.NoError(t, ValidateQuestRecurrence(QuestRecurrenceCron, "15 8-18/2 * * 1-5"))
+
+	assert.Error(t, ValidateQuestRecurrence("monthly", ""))
+	assert.Error(t, ValidateQuestRecurrence(QuestRecurrenceWeekly, "0 0 * * 1"))
+	assert.Error(t, ValidateQuestRecurrence(QuestRecurrenceCron, ""))
+	assert.Error(t, ValidateQuestRecurrence(QuestRecurrenceCron, "0 0 * *"))
+	assert.Error(t, ValidateQuestRecurrence(QuestRecurrenceCron, "60 * * * *"))
+	assert.Error(t, ValidateQuestRecurrence(QuestRecurrenceCron, "0 0 * * mon"))
+	assert.Error(t, ValidateQuestRecurrence(QuestRecurrenceCron, "*/0 * * * *"))
+}
@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// RecoveryAnswers holds the identity challenge answers a locked-out player submits.
+type RecoveryAnswers struct {
+	DateOfBirth       string `json:"date_of_birth"` // YYYY-MM-DD
+	PostCode          string `json:"post_code"`
+	MobileLast4       string `json:"mobile_last4"`
+	LastDepositAmount int64  `json:"last_deposit_amount"` // cents
+}
+
+// RecoveryChallengeResult holds the scored identity challenge.
+type RecoveryChallengeResult struct {
+	Score   int      `json:"score"`
+	Matched []string `json:"matched,omitempty"`
+	Failed  []string `json:"failed,omitempty"`
+	Passed  bool     `json:"passed"`
+}
+
+// RecoveryPassScore is the minimum challenge score support may approve without escalation.
+const RecoveryPassScore = 60
+
+// ScoreRecoveryChallenge compares submitted answers with the stored profile.
+// Each matched fact adds weight; blank answers count as failed.
+// lastDeposit is the player's most recent completed deposit (0 if none).
+func ScoreRecoveryChallenge(profile *domain.PlayerProfile, answers RecoveryAnswers, lastDeposit int64) RecoveryChallengeResult {
+	var result RecoveryChallengeResult
+
+	check := func(name string, weight int, ok bool) {
+		if ok {
+			result.Score += weight
+			result.Matched = append(result.Matched, name)
+		} else {
+			result.Failed = append(result.Failed, name)
+		}
+	}
+
+	dob := ""
+	if profile.DateOfBirth != nil {
+		dob = *profile.DateOfBirth
+	}
+	check("date_of_birth", 30, dob != "" && strings.HasPrefix(dob, strings.TrimSpace(answers.DateOfBirth)) && answers.DateOfBirth != "")
+
+	check("post_code", 25, profile.PostCode != "" &&
+		normalizeRecoveryValue(profile.PostCode) == normalizeRecoveryValue(answers.PostCode))
+
+	phone := normalizeRecoveryValue(profile.MobilePhone)
+	check("mobile_last4", 20, len(phone) >= 4 && len(answers.MobileLast4) == 4 &&
+		strings.HasSuffix(phone, answers.MobileLast4))
+
+	check("last_deposit_amount", 25, lastDeposit > 0 && answers.LastDepositAmount == lastDeposit)
+
+	result.Passed = result.Score >= RecoveryPassScore
+	return result
+}
+
+func normalizeRecoveryValue(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+}
@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func recoveryProfile() *domain.PlayerProfile {
+	dob := "1990-04-12"
+	return &domain.PlayerProfile{
+		DateOfBirth: &dob,
+		PostCode:    "SW1A 1AA",
+		MobilePhone: "+44 7700 900123",
+	}
+}
+
+func TestScoreRecoveryChallenge_AllMatch(t *testing.T) {
+	result := ScoreRecoveryChallenge(recoveryProfile(), RecoveryAnswers{
+		DateOfBirth:       "1990-04-12",
+		PostCode:          "sw1a1aa",
+		MobileLast4:       "0123",
+		LastDepositAmount: 5000,
+	}, 5000)
+
+	assert.Equal(t, 100, result.Score)
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Failed)
+}
+
+func TestScoreRecoveryChallenge_PartialMatchFails(t *testing.T) {
+	result := ScoreRecoveryChallenge(recoveryProfile(), RecoveryAnswers{
+		DateOfBirth: "1990-04-12",
+		MobileLast4: "9999",
+	}, 0)
+
+	assert.Equal(t, 30, result.Score)
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Failed, "mobile_last4")
+	assert.Contains(t, result.Failed, "last_deposit_amount")
+}
+
+func TestScoreRecoveryChallenge_BlankAnswersDoNotMatchBlankProfile(t *testing.T) {
+	result := ScoreRecoveryChallenge(&domain.PlayerProfile{}, RecoveryAnswers{}, 0)
+	assert.Equal(t, 0, result.Score)
+	assert.Len(t, result.Failed, 4)
+}
@@ -0,0 +1,36 @@
+package policy
+
+// RefundMaxWageredPct is the share of a deposit (percent) that may already
+// have been wagered before a refund is blocked — past this point the funds
+// have been played and refunding would let the player win-or-refund.
+const RefundMaxWageredPct = 20
+
+// RefundCheck holds the facts needed to decide whether a deposit may be refunded.
+type RefundCheck struct {
+	DepositAmount       int64 `json:"deposit_amount"`        // cents
+	AlreadyRefunded     int64 `json:"already_refunded"`      // cents, pending + succeeded
+	RefundAmount        int64 `json:"refund_amount"`         // cents
+	Balance             int64 `json:"balance"`               // current real balance, cents
+	WageredSinceDeposit int64 `json:"wagered_since_deposit"` // cents
+}
+
+// RefundDecision is the outcome of a refund check.
+type RefundDecision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// EvaluateDepositRefund decides whether a deposit refund may proceed.
+func EvaluateDepositRefund(c RefundCheck) RefundDecision {
+	switch {
+	case c.RefundAmount <= 0:
+		return RefundDecision{Reason: "invalid_amount"}
+	case c.AlreadyRefunded+c.RefundAmount > c.DepositAmount:
+		return RefundDecision{Reason: "exceeds_deposit"}
+	case c.WageredSinceDeposit*100 > c.DepositAmount*RefundMaxWageredPct:
+		return RefundDecision{Reason: "deposit_wagered"}
+	case c.Balance < c.RefundAmount:
+		return RefundDecision{Reason: "insufficient_balance"}
+	}
+	return RefundDecision{Allowed: true}
+}
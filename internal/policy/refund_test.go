@@ -0,0 +1,33 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateDepositRefund(t *testing.T) {
+	base := RefundCheck{DepositAmount: 10000, RefundAmount: 10000, Balance: 10000}
+
+	tests := []struct {
+		name   string
+		modify func(c *RefundCheck)
+		reason string
+	}{
+		{"full refund allowed", func(c *RefundCheck) {}, ""},
+		{"partial wagering under threshold", func(c *RefundCheck) { c.WageredSinceDeposit = 2000 }, ""},
+		{"wagered over threshold", func(c *RefundCheck) { c.WageredSinceDeposit = 2001 }, "deposit_wagered"},
+		{"exceeds remaining deposit", func(c *RefundCheck) { c.AlreadyRefunded = 5000 }, "exceeds_deposit"},
+		{"balance already spent", func(c *RefundCheck) { c.Balance = 9999 }, "insufficient_balance"},
+		{"zero amount", func(c *RefundCheck) { c.RefundAmount = 0 }, "invalid_amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := base
+			tt.modify(&c)
+			d := EvaluateDepositRefund(c)
+			assert.Equal(t, tt.reason == "", d.Allowed)
+			assert.Equal(t, tt.reason, d.Reason)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package policy
+
+import "time"
+
+// Retention actions: delete drops the rows, redact blanks the personal or
+// raw payload fields and keeps the rest of the row.
+const (
+	RetentionDelete = "delete"
+	RetentionRedact = "redact"
+)
+
+// Retention policy names.
+const (
+	RetentionProviderCallbacks   = "provider_callback_bodies"
+	RetentionPlacementEvents     = "placement_events"
+	RetentionExperimentExposures = "experiment_exposures"
+	RetentionAIConversations     = "ai_conversations"
+)
+
+// RetentionPeriods configures how many days each class of data is kept.
+type RetentionPeriods struct {
+	ProviderCallbackDays int `json:"provider_callback_days"` // raw callback bodies
+	AnalyticsEventDays   int `json:"analytics_event_days"`   // placement events, experiment exposures
+	AIConversationDays   int `json:"ai_conversation_days"`   // since the last message
+}
+
+// DefaultRetentionPeriods: raw provider callbacks 90 days, analytics events
+// a year, AI conversations six months after they go quiet.
+func DefaultRetentionPeriods() RetentionPeriods {
+	return RetentionPeriods{
+		ProviderCallbackDays: 90,
+		AnalyticsEventDays:   365,
+		AIConversationDays:   183,
+	}
+}
+
+// RetentionPolicy is one retention rule run by the retention worker.
+type RetentionPolicy struct {
+	Name   string `json:"name"`
+	Table  string `json:"table"`
+	Action string `json:"action"`
+	Days   int    `json:"days"`
+}
+
+// RetentionPolicies expands periods into the policies the worker applies.
+// A zero period falls back to its default.
+func RetentionPolicies(p RetentionPeriods) []RetentionPolicy {
+	d := DefaultRetentionPeriods()
+	if p.ProviderCallbackDays <= 0 {
+		p.ProviderCallbackDays = d.ProviderCallbackDays
+	}
+	if p.AnalyticsEventDays <= 0 {
+		p.AnalyticsEventDays = d.AnalyticsEventDays
+	}
+	if p.AIConversationDays <= 0 {
+		p.AIConversationDays = d.AIConversationDays
+	}
+	return []RetentionPolicy{
+		{RetentionProviderCallbacks, "provider_callbacks", RetentionRedact, p.ProviderCallbackDays},
+		{RetentionPlacementEvents, "placement_events", RetentionDelete, p.AnalyticsEventDays},
+		{RetentionExperimentExposures, "experiment_exposures", RetentionDelete, p.AnalyticsEventDays},
+		{RetentionAIConversations, "ai_conversations", RetentionDelete, p.AIConversationDays},
+	}
+}
+
+// RetentionCutoff returns the time before which the policy's data is due.
+func RetentionCutoff(p RetentionPolicy, now time.Time) time.Time {
+	return now.AddDate(0, 0, -p.Days)
+}
@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicies(t *testing.T) {
+	policies := RetentionPolicies(RetentionPeriods{AnalyticsEventDays: 30})
+	byName := map[string]RetentionPolicy{}
+	for _, p := range policies {
+		byName[p.Name] = p
+	}
+
+	assert.Len(t, policies, 4)
+	assert.Equal(t, 90, byName[RetentionProviderCallbacks].Days)
+	assert.Equal(t, RetentionRedact, byName[RetentionProviderCallbacks].Action)
+	assert.Equal(t, 30, byName[RetentionPlacementEvents].Days)
+	assert.Equal(t, 30, byName[RetentionExperimentExposures].Days)
+	assert.Equal(t, 183, byName[RetentionAIConversations].Days)
+	assert.Equal(t, RetentionDelete, byName[RetentionAIConversations].Action)
+}
+
+func TestRetentionCutoff(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	p := RetentionPolicy{Days: 90}
+	assert.Equal(t, time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), RetentionCutoff(p, now))
+}
@@ -1,5 +1,11 @@
 package policy
 
+import (
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
 // RgLimitPolicy defines responsible gaming limits for a player.
 type RgLimitPolicy struct {
 	SingleTransactionMax int64 `json:"single_transaction_max"` // cents
@@ -63,3 +69,42 @@ func EvaluateRgLimits(policy RgLimitPolicy, amount int64, txType string, dailyDe
 
 	return RgEvaluation{Allowed: true}
 }
+
+// RgLimitIncreaseCooldown is how long a player waits for a limit increase or
+// removal to take effect. Decreases apply immediately.
+const RgLimitIncreaseCooldown = 24 * time.Hour
+
+// LimitPeriodStart returns the start of the UTC calendar period containing
+// now: midnight for daily, Monday midnight for weekly and the first of the
+// month for monthly limits.
+func LimitPeriodStart(period domain.LimitPeriod, now time.Time) time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case domain.LimitWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case domain.LimitMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// LimitChangeAt returns when a change from current to requested takes effect.
+// A nil current means no limit is set and a nil requested removes the limit.
+// Setting a new or lower limit applies at now; raising or removing one waits
+// for RgLimitIncreaseCooldown.
+func LimitChangeAt(current, requested *int64, now time.Time) time.Time {
+	switch {
+	case current == nil:
+		return now
+	case requested != nil && *requested <= *current:
+		return now
+	}
+	return now.Add(RgLimitIncreaseCooldown)
+}
+
+// PlayerLimitAllows reports whether amount fits under limit given what was
+// already used in the period. A limit of 0 means none is set.
+func PlayerLimitAllows(limit, used, amount int64) bool {
+	return limit <= 0 || used+amount <= limit
+}
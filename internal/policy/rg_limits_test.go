@@ -2,7 +2,9 @@ package policy
 
 import (
 	"testing"
+	"time"
 
+	"github.com/attaboy/platform/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,3 +49,44 @@ func TestEvaluateRgLimits_DailyDepositDoesNotApplyToBets(t *testing.T) {
 	result := EvaluateRgLimits(policy, 50_000, "bet", 199_000, 0)
 	assert.True(t, result.Allowed)
 }
+
+func TestLimitPeriodStart(t *testing.T) {
+	now := time.Date(2026, 3, 19, 15, 30, 0, 0, time.UTC) // a Thursday
+	assert.Equal(t, time.Date(2026, 3, 19, 0, 0, 0, 0, time.UTC), LimitPeriodStart(domain.LimitDaily, now))
+	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), LimitPeriodStart(domain.LimitWeekly, now))
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), LimitPeriodStart(domain.LimitMonthly, now))
+
+	sunday := time.Date(2026, 3, 22, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), LimitPeriodStart(domain.LimitWeekly, sunday))
+	monday := time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, LimitPeriodStart(domain.LimitWeekly, monday))
+}
+
+func TestLimitChangeAt(t *testing.T) {
+	now := time.Date(2026, 3, 19, 15, 30, 0, 0, time.UTC)
+	later := now.Add(RgLimitIncreaseCooldown)
+	v := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name               string
+		current, requested *int64
+		want               time.Time
+	}{
+		{"new limit", nil, v(10_000), now},
+		{"decrease", v(10_000), v(5_000), now},
+		{"same value", v(10_000), v(10_000), now},
+		{"increase", v(10_000), v(20_000), later},
+		{"removal", v(10_000), nil, later},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LimitChangeAt(tt.current, tt.requested, now))
+		})
+	}
+}
+
+func TestPlayerLimitAllows(t *testing.T) {
+	assert.True(t, PlayerLimitAllows(0, 1_000_000, 1))
+	assert.True(t, PlayerLimitAllows(10_000, 6_000, 4_000))
+	assert.False(t, PlayerLimitAllows(10_000, 6_000, 4_001))
+}
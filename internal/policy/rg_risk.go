@@ -0,0 +1,156 @@
+package policy
+
+import (
+	"sort"
+	"time"
+)
+
+// SessionEvent is one casino stake or payout within a play session.
+type SessionEvent struct {
+	At     time.Time
+	Stake  int64 // cents wagered; zero for payouts
+	Payout int64 // cents won; zero for stakes
+}
+
+// PlaySession is a run of casino activity with no gap longer than
+// SessionGap between consecutive events.
+type PlaySession struct {
+	Start  time.Time
+	End    time.Time
+	Events []SessionEvent
+}
+
+// SessionGap ends a session: a player idle this long has taken a break.
+const SessionGap = 30 * time.Minute
+
+// Scoring rules. Points add up to a 0-100 score.
+const (
+	// A stake at least this multiple of the previous one, placed while the
+	// session is down, counts as an escalation.
+	ChaseEscalationFactor = 2
+	chasePointsEach       = 15
+	chasePointsMax        = 45
+
+	LongSessionDuration     = 3 * time.Hour
+	VeryLongSessionDuration = 6 * time.Hour
+	longSessionPoints       = 20
+	veryLongSessionPoints   = 35
+
+	// Night hours are 00:00-06:00 UTC. A session counts as a night session
+	// when at least half of its stakes fall inside them.
+	NightStartHour     = 0
+	NightEndHour       = 6
+	nightSessionPoints = 20
+	// Long sessions at night compound: late play and long play together.
+	longNightBonusPoints = 15
+
+	riskMediumScore = 30
+	riskHighScore   = 60
+)
+
+// SessionRisk is the scored outcome of one session.
+type SessionRisk struct {
+	Score       int       `json:"score"`
+	Band        RiskLevel `json:"band"`
+	Factors     []string  `json:"factors"`
+	Escalations int       `json:"escalations"`
+	Bets        int       `json:"bets"`
+	Staked      int64     `json:"staked"`
+	NetLoss     int64     `json:"net_loss"`
+	Minutes     int       `json:"minutes"`
+}
+
+// SplitSessions groups events into sessions separated by SessionGap. Events
+// need not be sorted.
+func SplitSessions(events []SessionEvent) []PlaySession {
+	if len(events) == 0 {
+		return nil
+	}
+	sorted := append([]SessionEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	var sessions []PlaySession
+	current := PlaySession{Start: sorted[0].At}
+	for i, ev := range sorted {
+		if i > 0 && ev.At.Sub(sorted[i-1].At) > SessionGap {
+			current.End = sorted[i-1].At
+			sessions = append(sessions, current)
+			current = PlaySession{Start: ev.At}
+		}
+		current.Events = append(current.Events, ev)
+	}
+	current.End = sorted[len(sorted)-1].At
+	return append(sessions, current)
+}
+
+// ScoreSession applies the rule-based chasing model to one session:
+// stake escalation after losses, long play, and play at night.
+func ScoreSession(s PlaySession) SessionRisk {
+	risk := SessionRisk{Factors: []string{}}
+
+	var lastStake, running int64
+	var nightStakes int
+	for _, ev := range s.Events {
+		if ev.Stake > 0 {
+			if lastStake > 0 && running < 0 && ev.Stake >= lastStake*ChaseEscalationFactor {
+				risk.Escalations++
+			}
+			lastStake = ev.Stake
+			risk.Bets++
+			risk.Staked += ev.Stake
+			if isNight(ev.At) {
+				nightStakes++
+			}
+		}
+		running += ev.Payout - ev.Stake
+	}
+	if running < 0 {
+		risk.NetLoss = -running
+	}
+
+	duration := s.End.Sub(s.Start)
+	risk.Minutes = int(duration / time.Minute)
+
+	if risk.Escalations > 0 {
+		risk.Score += min(risk.Escalations*chasePointsEach, chasePointsMax)
+		risk.Factors = append(risk.Factors, "stake_escalation_after_loss")
+	}
+	long := duration >= LongSessionDuration
+	switch {
+	case duration >= VeryLongSessionDuration:
+		risk.Score += veryLongSessionPoints
+		risk.Factors = append(risk.Factors, "very_long_session")
+	case long:
+		risk.Score += longSessionPoints
+		risk.Factors = append(risk.Factors, "long_session")
+	}
+	night := risk.Bets > 0 && nightStakes*2 >= risk.Bets
+	if night {
+		risk.Score += nightSessionPoints
+		risk.Factors = append(risk.Factors, "night_session")
+		if long {
+			risk.Score += longNightBonusPoints
+			risk.Factors = append(risk.Factors, "long_session_at_night")
+		}
+	}
+
+	risk.Score = min(risk.Score, 100)
+	risk.Band = RiskBand(risk.Score)
+	return risk
+}
+
+// RiskBand maps a session score to its risk level.
+func RiskBand(score int) RiskLevel {
+	switch {
+	case score >= riskHighScore:
+		return RiskHigh
+	case score >= riskMediumScore:
+		return RiskMedium
+	}
+	return RiskLow
+}
+
+func isNight(t time.Time) bool {
+	h := t.UTC().Hour()
+	return h >= NightStartHour && h < NightEndHour
+}
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSessions(t *testing.T) {
+	base := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+	events := []SessionEvent{
+		{At: base.Add(50 * time.Minute), Stake: 100},
+		{At: base, Stake: 100},
+		{At: base.Add(20 * time.Minute), Payout: 300},
+		{At: base.Add(2 * time.Hour), Stake: 100},
+	}
+
+	sessions := SplitSessions(events)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, base, sessions[0].Start)
+	assert.Equal(t, base.Add(50*time.Minute), sessions[0].End)
+	assert.Len(t, sessions[0].Events, 3)
+	assert.Len(t, sessions[1].Events, 1)
+	assert.Nil(t, SplitSessions(nil))
+}
+
+func TestScoreSession(t *testing.T) {
+	t.Run("steady daytime play is low risk", func(t *testing.T) {
+		start := time.Date(2026, 5, 1, 14, 0, 0, 0, time.UTC)
+		risk := ScoreSession(PlaySession{Start: start, End: start.Add(time.Hour), Events: []SessionEvent{
+			{At: start, Stake: 100},
+			{At: start.Add(time.Minute), Stake: 100},
+			{At: start.Add(time.Hour), Stake: 100, Payout: 150},
+		}})
+		assert.Equal(t, 0, risk.Score)
+		assert.Equal(t, RiskLow, risk.Band)
+		assert.Empty(t, risk.Factors)
+		assert.Equal(t, int64(150), risk.NetLoss)
+		assert.Equal(t, 60, risk.Minutes)
+	})
+
+	t.Run("doubling up after losses is chasing", func(t *testing.T) {
+		start := time.Date(2026, 5, 1, 14, 0, 0, 0, time.UTC)
+		risk := ScoreSession(PlaySession{Start: start, End: start.Add(10 * time.Minute), Events: []SessionEvent{
+			{At: start, Stake: 100},
+			{At: start.Add(time.Minute), Stake: 200},
+			{At: start.Add(2 * time.Minute), Stake: 400},
+			{At: start.Add(3 * time.Minute), Payout: 2000},
+			{At: start.Add(4 * time.Minute), Stake: 1000}, // up on the session, not chasing
+		}})
+		assert.Equal(t, 2, risk.Escalations)
+		assert.Equal(t, 30, risk.Score)
+		assert.Equal(t, RiskMedium, risk.Band)
+		assert.Equal(t, []string{"stake_escalation_after_loss"}, risk.Factors)
+	})
+
+	t.Run("long night session compounds", func(t *testing.T) {
+		start := time.Date(2026, 5, 2, 1, 0, 0, 0, time.UTC)
+		var events []SessionEvent
+		for i := 0; i <= 16; i++ {
+			events = append(events, SessionEvent{At: start.Add(time.Duration(i) * 15 * time.Minute), Stake: 100})
+		}
+		risk := ScoreSession(PlaySession{Start: start, End: start.Add(4 * time.Hour), Events: events})
+		assert.Equal(t, []string{"long_session", "night_session", "long_session_at_night"}, risk.Factors)
+		assert.Equal(t, 55, risk.Score)
+	})
+
+	t.Run("score is capped at 100", func(t *testing.T) {
+		start := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+		events := []SessionEvent{{At: start, Stake: 10}}
+		stake := int64(10)
+		for i := 1; i <= 5; i++ {
+			stake *= 2
+			events = append(events, SessionEvent{At: start.Add(time.Duration(i) * time.Minute), Stake: stake})
+		}
+		events = append(events, SessionEvent{At: start.Add(5 * time.Hour), Stake: 10})
+		risk := ScoreSession(PlaySession{Start: start, End: start.Add(7 * time.Hour), Events: events})
+		assert.Equal(t, 100, risk.Score)
+		assert.Equal(t, RiskHigh, risk.Band)
+	})
+}
@@ -0,0 +1,33 @@
+package policy
+
+import "time"
+
+// SagaRetryPolicy bounds how long a failing saga step, or the compensation
+// undoing it, is retried before the saga gives up on it.
+type SagaRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultSagaRetryPolicy retries 10 times, 30s doubling to a 30 minute cap
+// (roughly 3 hours end to end), long enough to ride out a PSP outage.
+func DefaultSagaRetryPolicy() SagaRetryPolicy {
+	return SagaRetryPolicy{MaxAttempts: 10, BaseDelay: 30 * time.Second, MaxDelay: 30 * time.Minute}
+}
+
+// SagaRetryDecision is what to do after a saga step fails.
+type SagaRetryDecision struct {
+	GiveUp bool
+	Delay  time.Duration // wait before the next attempt when not giving up
+}
+
+// EvaluateSagaRetry decides the next step after the attempts-th failure. A
+// permanent failure, such as a PSP decline, is given up on straight away.
+func EvaluateSagaRetry(p SagaRetryPolicy, attempts int, permanent bool) SagaRetryDecision {
+	if permanent {
+		return SagaRetryDecision{GiveUp: true}
+	}
+	d := EvaluateOutboxRetry(OutboxRetryPolicy(p), attempts)
+	return SagaRetryDecision{GiveUp: d.DeadLetter, Delay: d.Delay}
+}
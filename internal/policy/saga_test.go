@@ -0,0 +1,18 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateSagaRetry(t *testing.T) {
+	p := DefaultSagaRetryPolicy()
+
+	assert.Equal(t, SagaRetryDecision{Delay: 30 * time.Second}, EvaluateSagaRetry(p, 1, false))
+	assert.Equal(t, SagaRetryDecision{Delay: 2 * time.Minute}, EvaluateSagaRetry(p, 3, false))
+	assert.Equal(t, SagaRetryDecision{Delay: 30 * time.Minute}, EvaluateSagaRetry(p, 9, false))
+	assert.Equal(t, SagaRetryDecision{GiveUp: true}, EvaluateSagaRetry(p, p.MaxAttempts, false))
+	assert.Equal(t, SagaRetryDecision{GiveUp: true}, EvaluateSagaRetry(p, 1, true))
+}
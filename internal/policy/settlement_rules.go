@@ -0,0 +1,152 @@
+package policy
+
+import "strings"
+
+// Selection results a sportsbook selection settles to. Half results come
+// from Asian quarter lines, where the stake is split across the two
+// neighbouring lines; push means the line landed exactly and the stake is
+// returned.
+const (
+	ResultWon      = "won"
+	ResultPlaced   = "placed"
+	ResultLost     = "lost"
+	ResultVoid     = "void"
+	ResultPush     = "push"
+	ResultHalfWon  = "half_won"
+	ResultHalfLost = "half_lost"
+)
+
+// Line sides for handicap and totals selections.
+const (
+	SideHome  = "home"
+	SideAway  = "away"
+	SideOver  = "over"
+	SideUnder = "under"
+)
+
+// BetTerms are the terms a bet was struck at. Stake is the total debited;
+// an each-way bet splits it evenly across the win and place lines.
+type BetTerms struct {
+	Stake   int64
+	Odds    int // x100
+	EachWay *EachWayTerms
+}
+
+// SelectionOutcome is how the selection a bet is on settled. Placing is
+// used for won and placed results and may be zero for a plain win.
+type SelectionOutcome struct {
+	Result  string
+	Placing Placing
+}
+
+// Settlement is what a bet settles to. Refund means the whole stake is
+// returned by cancelling the bet; otherwise Return is credited when positive.
+type Settlement struct {
+	Status string
+	Return int64
+	Refund bool
+}
+
+// SettleBet applies the settlement rules to a bet: each-way and dead-heat
+// terms for won and placed selections, half stakes for quarter lines and a
+// full refund for void and push.
+func SettleBet(b BetTerms, o SelectionOutcome) Settlement {
+	switch o.Result {
+	case ResultVoid, ResultPush:
+		return Settlement{Status: o.Result, Return: b.Stake, Refund: true}
+	case ResultHalfWon:
+		return Settlement{Status: ResultHalfWon, Return: b.Stake * int64(b.Odds+100) / 200}
+	case ResultHalfLost:
+		return Settlement{Status: ResultHalfLost, Return: b.Stake / 2}
+	case ResultWon, ResultPlaced:
+		stake := b.Stake
+		if b.EachWay != nil {
+			stake /= 2
+		}
+		placing := o.Placing
+		if placing.Position == 0 && o.Result == ResultWon {
+			placing.Position = 1
+		}
+		if ret := OutrightReturn(stake, b.Odds, b.EachWay, placing); ret > 0 {
+			return Settlement{Status: ResultWon, Return: ret}
+		}
+	}
+	return Settlement{Status: ResultLost}
+}
+
+// LineResult settles a handicap or totals selection from the final score.
+// line is in hundredths: -25 is a -0.25 handicap, 250 a 2.5 total. Quarter
+// lines are settled as half the stake on each neighbouring line, so they can
+// half-win or half-lose; whole lines push when they land exactly.
+func LineResult(side string, line, scoreHome, scoreAway int) string {
+	if line%50 == 0 {
+		switch lineMargin(side, line, scoreHome, scoreAway) {
+		case 1:
+			return ResultWon
+		case 0:
+			return ResultPush
+		}
+		return ResultLost
+	}
+	switch lineMargin(side, line-25, scoreHome, scoreAway) + lineMargin(side, line+25, scoreHome, scoreAway) {
+	case 2:
+		return ResultWon
+	case 1:
+		return ResultHalfWon
+	case 0:
+		return ResultPush
+	case -1:
+		return ResultHalfLost
+	}
+	return ResultLost
+}
+
+// MoneylineResult settles a 1x2 (moneyline) selection from the final score.
+// The selection is matched by name to the home team, the away team or
+// "Draw"; ok is false when the name matches none of them.
+func MoneylineResult(selection, homeTeam, awayTeam string, scoreHome, scoreAway int) (result string, ok bool) {
+	var pick int
+	switch {
+	case strings.EqualFold(selection, homeTeam):
+		pick = 1
+	case strings.EqualFold(selection, awayTeam):
+		pick = -1
+	case strings.EqualFold(selection, "draw"):
+		pick = 0
+	default:
+		return "", false
+	}
+	outcome := 0
+	if scoreHome > scoreAway {
+		outcome = 1
+	} else if scoreHome < scoreAway {
+		outcome = -1
+	}
+	if pick == outcome {
+		return ResultWon, true
+	}
+	return ResultLost, true
+}
+
+// lineMargin reports whether a selection beats (1), lands on (0) or misses
+// (-1) a whole or half line.
+func lineMargin(side string, line, scoreHome, scoreAway int) int {
+	var m int
+	switch side {
+	case SideHome:
+		m = (scoreHome-scoreAway)*100 + line
+	case SideAway:
+		m = (scoreAway-scoreHome)*100 + line
+	case SideOver:
+		m = (scoreHome+scoreAway)*100 - line
+	case SideUnder:
+		m = line - (scoreHome+scoreAway)*100
+	}
+	switch {
+	case m > 0:
+		return 1
+	case m < 0:
+		return -1
+	}
+	return 0
+}
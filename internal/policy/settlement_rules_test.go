@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		side       string
+		line       int
+		home, away int
+		want       string
+	}{
+		{"home -0.5 wins by one", SideHome, -50, 1, 0, ResultWon},
+		{"home -0.5 draw", SideHome, -50, 1, 1, ResultLost},
+		{"home -1 wins by one pushes", SideHome, -100, 2, 1, ResultPush},
+		{"home -1 wins by two", SideHome, -100, 3, 1, ResultWon},
+		{"home -0.25 draw half-loses", SideHome, -25, 0, 0, ResultHalfLost},
+		{"home -0.25 wins", SideHome, -25, 1, 0, ResultWon},
+		{"away +0.25 draw half-wins", SideAway, 25, 0, 0, ResultHalfWon},
+		{"home -0.75 wins by one half-wins", SideHome, -75, 2, 1, ResultHalfWon},
+		{"home -0.75 wins by two", SideHome, -75, 2, 0, ResultWon},
+		{"away +0.75 loses by one half-loses", SideAway, 75, 2, 1, ResultHalfLost},
+		{"over 2.5 with three goals", SideOver, 250, 2, 1, ResultWon},
+		{"under 2.5 with three goals", SideUnder, 250, 2, 1, ResultLost},
+		{"over 3 with three goals pushes", SideOver, 300, 2, 1, ResultPush},
+		{"under 3 with three goals pushes", SideUnder, 300, 2, 1, ResultPush},
+		{"over 2.25 with two goals half-loses", SideOver, 225, 1, 1, ResultHalfLost},
+		{"under 2.25 with two goals half-wins", SideUnder, 225, 1, 1, ResultHalfWon},
+		{"over 2.75 with three goals half-wins", SideOver, 275, 2, 1, ResultHalfWon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LineResult(tt.side, tt.line, tt.home, tt.away))
+		})
+	}
+}
+
+func TestMoneylineResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		selection  string
+		home, away int
+		want       string
+		ok         bool
+	}{
+		{"home win", "Arsenal", 2, 1, ResultWon, true},
+		{"home loses", "Arsenal", 0, 1, ResultLost, true},
+		{"away win", "Chelsea", 0, 1, ResultWon, true},
+		{"draw", "Draw", 1, 1, ResultWon, true},
+		{"draw not landed", "Draw", 2, 1, ResultLost, true},
+		{"home on a draw", "Arsenal", 1, 1, ResultLost, true},
+		{"unknown selection", "Spurs", 1, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MoneylineResult(tt.selection, "Arsenal", "Chelsea", tt.home, tt.away)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSettleBet(t *testing.T) {
+	win := BetTerms{Stake: 1000, Odds: 190}
+	ew := BetTerms{Stake: 2000, Odds: 500, EachWay: &EachWayTerms{Places: 3, Fraction: 4}}
+
+	tests := []struct {
+		name    string
+		bet     BetTerms
+		outcome SelectionOutcome
+		want    Settlement
+	}{
+		{"won", win, SelectionOutcome{Result: ResultWon}, Settlement{Status: ResultWon, Return: 1900}},
+		{"lost", win, SelectionOutcome{Result: ResultLost}, Settlement{Status: ResultLost}},
+		{"void refunds", win, SelectionOutcome{Result: ResultVoid}, Settlement{Status: ResultVoid, Return: 1000, Refund: true}},
+		{"push refunds", win, SelectionOutcome{Result: ResultPush}, Settlement{Status: ResultPush, Return: 1000, Refund: true}},
+		{"half won", win, SelectionOutcome{Result: ResultHalfWon}, Settlement{Status: ResultHalfWon, Return: 950 + 500}},
+		{"half lost", win, SelectionOutcome{Result: ResultHalfLost}, Settlement{Status: ResultHalfLost, Return: 500}},
+		{"dead heat win", win, SelectionOutcome{Result: ResultWon, Placing: Placing{1, 2}}, Settlement{Status: ResultWon, Return: 950}},
+		{"placed without each-way loses", win, SelectionOutcome{Result: ResultPlaced, Placing: Placing{2, 1}}, Settlement{Status: ResultLost}},
+		{"each-way winner", ew, SelectionOutcome{Result: ResultWon}, Settlement{Status: ResultWon, Return: 5000 + 2000}},
+		{"each-way placed", ew, SelectionOutcome{Result: ResultPlaced, Placing: Placing{3, 1}}, Settlement{Status: ResultWon, Return: 2000}},
+		{"each-way unplaced", ew, SelectionOutcome{Result: ResultLost}, Settlement{Status: ResultLost}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SettleBet(tt.bet, tt.outcome))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package policy
+
+import "fmt"
+
+// Simulation windows: how many days of history a bonus or quest simulation
+// replays.
+const (
+	DefaultSimulationDays = 30
+	MaxSimulationDays     = 365
+)
+
+// SimulationDays resolves the lookback window of a simulation. Zero means
+// DefaultSimulationDays.
+func SimulationDays(days int) (int, error) {
+	if days == 0 {
+		return DefaultSimulationDays, nil
+	}
+	if days < 0 || days > MaxSimulationDays {
+		return 0, fmt.Errorf("days must be between 1 and %d", MaxSimulationDays)
+	}
+	return days, nil
+}
+
+// ProjectedBonusCost scales the most a bonus could cost, maxCost, by the
+// share of recent bonus money players went on to release: released out of
+// settled, the initial amounts of bonuses that completed wagering and of all
+// bonuses that completed, expired or were forfeited. With no settled bonuses
+// to go on the whole of maxCost is projected.
+func ProjectedBonusCost(maxCost, released, settled int64) int64 {
+	if settled <= 0 || released >= settled {
+		return maxCost
+	}
+	if released <= 0 {
+		return 0
+	}
+	return int64(float64(maxCost) * float64(released) / float64(settled))
+}
+
+// BudgetedRewards replays a quest's daily budget over the number of players
+// who would have completed it each day, in order. It returns how many
+// rewards would have been paid and what they cost; a dailyBudget of 0 is
+// unlimited.
+func BudgetedRewards(completedPerDay []int, reward, dailyBudget int64) (int, int64) {
+	var paid int
+	for _, n := range completedPerDay {
+		if dailyBudget > 0 && reward > 0 {
+			n = int(min(int64(n), dailyBudget/reward))
+		}
+		paid += n
+	}
+	return paid, int64(paid) * reward
+}
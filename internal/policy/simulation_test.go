@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationDays(t *testing.T) {
+	days, err := SimulationDays(0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSimulationDays, days)
+
+	days, err = SimulationDays(90)
+	require.NoError(t, err)
+	assert.Equal(t, 90, days)
+
+	_, err = SimulationDays(-1)
+	assert.EqualError(t, err, "days must be between 1 and 365")
+	_, err = SimulationDays(366)
+	assert.Error(t, err)
+}
+
+func TestProjectedBonusCost(t *testing.T) {
+	assert.Equal(t, int64(10_000), ProjectedBonusCost(10_000, 0, 0), "no history projects the maximum")
+	assert.Equal(t, int64(2_500), ProjectedBonusCost(10_000, 1_000, 4_000))
+	assert.Equal(t, int64(10_000), ProjectedBonusCost(10_000, 4_000, 4_000))
+	assert.Zero(t, ProjectedBonusCost(10_000, 0, 4_000), "nothing released")
+}
+
+func TestBudgetedRewards(t *testing.T) {
+	paid, cost := BudgetedRewards([]int{3, 10, 0}, 500, 2_000)
+	assert.Equal(t, 7, paid, "the second day is capped at four rewards")
+	assert.Equal(t, int64(3_500), cost)
+
+	paid, cost = BudgetedRewards([]int{3, 10}, 500, 0)
+	assert.Equal(t, 13, paid, "no budget")
+	assert.Equal(t, int64(6_500), cost)
+}
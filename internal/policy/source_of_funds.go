@@ -0,0 +1,55 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSOFThresholds are the cumulative-deposit levels (cents) that each
+// require a source-of-funds questionnaire: €5,000 and €25,000.
+var DefaultSOFThresholds = []int64{500_000, 2_500_000}
+
+// ParseSOFThresholds parses a comma-separated list of cents amounts into
+// ascending, de-duplicated thresholds. "off" disables the check.
+func ParseSOFThresholds(s string) ([]int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultSOFThresholds, nil
+	}
+	if strings.EqualFold(s, "off") {
+		return nil, nil
+	}
+
+	seen := make(map[int64]bool)
+	var out []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("invalid source-of-funds threshold %q", part)
+		}
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+// SOFThresholdReached returns the highest threshold cumulative deposits have
+// reached, or 0 when none has.
+func SOFThresholdReached(thresholds []int64, cumulativeDeposits int64) int64 {
+	var reached int64
+	for _, t := range thresholds {
+		if cumulativeDeposits >= t {
+			reached = t
+		}
+	}
+	return reached
+}
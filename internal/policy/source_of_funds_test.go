@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSOFThresholds(t *testing.T) {
+	t.Run("empty uses defaults", func(t *testing.T) {
+		got, err := ParseSOFThresholds("")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSOFThresholds, got)
+	})
+
+	t.Run("off disables", func(t *testing.T) {
+		got, err := ParseSOFThresholds("OFF")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("sorted and de-duplicated", func(t *testing.T) {
+		got, err := ParseSOFThresholds("2500000, 500000,500000")
+		require.NoError(t, err)
+		assert.Equal(t, []int64{500000, 2500000}, got)
+	})
+
+	t.Run("rejects non-positive and garbage", func(t *testing.T) {
+		_, err := ParseSOFThresholds("500000,-1")
+		assert.Error(t, err)
+		_, err = ParseSOFThresholds("5k")
+		assert.Error(t, err)
+	})
+}
+
+func TestSOFThresholdReached(t *testing.T) {
+	thresholds := []int64{500_000, 2_500_000}
+
+	tests := []struct {
+		name       string
+		cumulative int64
+		want       int64
+	}{
+		{"below first", 499_999, 0},
+		{"exactly first", 500_000, 500_000},
+		{"between", 1_000_000, 500_000},
+		{"above second", 3_000_000, 2_500_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SOFThresholdReached(thresholds, tt.cumulative))
+		})
+	}
+
+	assert.Equal(t, int64(0), SOFThresholdReached(nil, 9_999_999))
+}
@@ -0,0 +1,130 @@
+package policy
+
+import "strings"
+
+// Money-movement verticals gated by sweepstakes mode, alongside the game
+// verticals declared with placements.
+const (
+	VerticalDeposits    = "deposits"
+	VerticalWithdrawals = "withdrawals"
+	VerticalTransfers   = "transfers"
+	VerticalSweeps      = "sweeps" // coin purchases and SC redemptions
+)
+
+// SweepsRules configures sweepstakes mode. The zero value is a regular
+// real-money deployment.
+type SweepsRules struct {
+	Enabled bool `json:"enabled"`
+	// RedeemMin is the smallest SC redemption, in SC minor units.
+	RedeemMin int64 `json:"redeem_min"`
+	// Playthrough is how many times credited SC must be wagered before it
+	// becomes redeemable.
+	Playthrough int `json:"playthrough"`
+	// CashCurrency is the prize currency; 1 SC redeems for 1 unit of it.
+	CashCurrency      string   `json:"cash_currency"`
+	ExcludedCountries []string `json:"excluded_countries,omitempty"`
+	// DisabledVerticals lists game verticals closed in sweepstakes mode.
+	DisabledVerticals []string `json:"disabled_verticals,omitempty"`
+}
+
+// DefaultSweepsRules: off, 50 SC minimum redemption, 1x playthrough, USD
+// prizes, sportsbook and predictions closed.
+func DefaultSweepsRules() SweepsRules {
+	return SweepsRules{
+		RedeemMin:         5_000,
+		Playthrough:       1,
+		CashCurrency:      "USD",
+		DisabledVerticals: []string{VerticalSportsbook, VerticalPredictions},
+	}
+}
+
+// VerticalAllowed reports whether a vertical is open under the rules. Money
+// only enters and leaves a sweepstakes deployment through coin purchases and
+// redemptions, so deposits, withdrawals and transfers close when the mode is
+// on and the sweeps vertical only opens with it.
+func (r SweepsRules) VerticalAllowed(vertical string) bool {
+	switch vertical {
+	case VerticalSweeps:
+		return r.Enabled
+	case VerticalDeposits, VerticalWithdrawals, VerticalTransfers:
+		return !r.Enabled
+	}
+	if !r.Enabled {
+		return true
+	}
+	for _, v := range r.DisabledVerticals {
+		if strings.EqualFold(v, vertical) {
+			return false
+		}
+	}
+	return true
+}
+
+// RedeemableSC returns the part of an SC balance that has met the
+// playthrough: credited SC not yet wagered Playthrough times stays locked.
+func RedeemableSC(rules SweepsRules, balance, credited, wagered int64) int64 {
+	locked := max(credited*int64(max(rules.Playthrough, 0))-wagered, 0)
+	return max(balance-locked, 0)
+}
+
+// RedemptionCheck holds the facts gathered for an SC redemption. Credited
+// is all SC ever credited by purchases and bonuses; Wagered is all SC staked.
+type RedemptionCheck struct {
+	Amount      int64  `json:"amount"` // 0 checks eligibility only
+	Balance     int64  `json:"balance"`
+	Credited    int64  `json:"credited"`
+	Wagered     int64  `json:"wagered"`
+	KYCVerified bool   `json:"kyc_verified"`
+	Country     string `json:"country"`
+}
+
+// RedemptionDecision is the outcome of a redemption check.
+type RedemptionDecision struct {
+	Eligible   bool   `json:"eligible"`
+	Reason     string `json:"reason,omitempty"`
+	Redeemable int64  `json:"redeemable"`
+	Minimum    int64  `json:"minimum"`
+}
+
+// EvaluateRedemption decides whether a player may redeem SC. A zero Amount
+// reports general eligibility and the redeemable balance.
+func EvaluateRedemption(rules SweepsRules, c RedemptionCheck) RedemptionDecision {
+	d := RedemptionDecision{
+		Redeemable: RedeemableSC(rules, c.Balance, c.Credited, c.Wagered),
+		Minimum:    rules.RedeemMin,
+	}
+	deny := func(reason string) RedemptionDecision {
+		d.Reason = reason
+		return d
+	}
+
+	if !rules.Enabled {
+		return deny("disabled")
+	}
+	for _, country := range rules.ExcludedCountries {
+		if c.Country != "" && strings.EqualFold(country, c.Country) {
+			return deny("country_excluded")
+		}
+	}
+	if !c.KYCVerified {
+		return deny("kyc_required")
+	}
+	if c.Amount < 0 {
+		return deny("invalid_amount")
+	}
+	if c.Amount == 0 {
+		if d.Redeemable < rules.RedeemMin {
+			return deny("below_minimum")
+		}
+	} else {
+		if c.Amount < rules.RedeemMin {
+			return deny("below_minimum")
+		}
+		if c.Amount > d.Redeemable {
+			return deny("exceeds_redeemable")
+		}
+	}
+
+	d.Eligible = true
+	return d
+}
@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweepsVerticalAllowed(t *testing.T) {
+	off := DefaultSweepsRules()
+	on := DefaultSweepsRules()
+	on.Enabled = true
+
+	for _, v := range []string{VerticalDeposits, VerticalWithdrawals, VerticalTransfers, VerticalCasino, VerticalSportsbook} {
+		assert.True(t, off.VerticalAllowed(v), v)
+	}
+	assert.False(t, off.VerticalAllowed(VerticalSweeps))
+
+	assert.True(t, on.VerticalAllowed(VerticalSweeps))
+	assert.True(t, on.VerticalAllowed(VerticalCasino))
+	for _, v := range []string{VerticalDeposits, VerticalWithdrawals, VerticalTransfers, VerticalSportsbook, VerticalPredictions} {
+		assert.False(t, on.VerticalAllowed(v), v)
+	}
+}
+
+func TestRedeemableSC(t *testing.T) {
+	rules := DefaultSweepsRules()
+	assert.Equal(t, int64(0), RedeemableSC(rules, 10000, 10000, 0), "nothing played")
+	assert.Equal(t, int64(4000), RedeemableSC(rules, 10000, 10000, 4000), "partly played")
+	assert.Equal(t, int64(12000), RedeemableSC(rules, 12000, 10000, 15000), "fully played, with winnings")
+
+	rules.Playthrough = 3
+	assert.Equal(t, int64(0), RedeemableSC(rules, 10000, 10000, 15000))
+	assert.Equal(t, int64(5000), RedeemableSC(rules, 10000, 10000, 25000))
+}
+
+func TestEvaluateRedemption(t *testing.T) {
+	rules := DefaultSweepsRules()
+	rules.Enabled = true
+	rules.ExcludedCountries = []string{"WA"}
+	base := RedemptionCheck{Amount: 6000, Balance: 10000, Credited: 10000, Wagered: 10000, KYCVerified: true, Country: "US"}
+
+	tests := []struct {
+		name   string
+		modify func(c *RedemptionCheck)
+		reason string
+	}{
+		{"eligible", nil, ""},
+		{"eligibility only", func(c *RedemptionCheck) { c.Amount = 0 }, ""},
+		{"excluded country", func(c *RedemptionCheck) { c.Country = "wa" }, "country_excluded"},
+		{"not verified", func(c *RedemptionCheck) { c.KYCVerified = false }, "kyc_required"},
+		{"below minimum", func(c *RedemptionCheck) { c.Amount = 4999 }, "below_minimum"},
+		{"not played through", func(c *RedemptionCheck) { c.Wagered = 5000 }, "exceeds_redeemable"},
+		{"nothing redeemable", func(c *RedemptionCheck) { c.Amount, c.Wagered = 0, 0 }, "below_minimum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := base
+			if tt.modify != nil {
+				tt.modify(&c)
+			}
+			d := EvaluateRedemption(rules, c)
+			assert.Equal(t, tt.reason == "", d.Eligible)
+			assert.Equal(t, tt.reason, d.Reason)
+		})
+	}
+
+	d := EvaluateRedemption(DefaultSweepsRules(), base)
+	assert.False(t, d.Eligible)
+	assert.Equal(t, "disabled", d.Reason)
+}
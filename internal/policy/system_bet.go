@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// System bets: full-cover multiples over a fixed number of selections. Each
+// covers every combination of its selections from the smallest size up to
+// all of them, each combination being one line at the same stake.
+const (
+	SystemTrixie   = "trixie"   // 3 selections: 3 doubles and a treble
+	SystemPatent   = "patent"   // 3 selections: a trixie plus 3 singles
+	SystemYankee   = "yankee"   // 4 selections: 6 doubles, 4 trebles and a fourfold
+	SystemLucky15  = "lucky15"  // 4 selections: a yankee plus 4 singles
+	SystemCanadian = "canadian" // 5 selections: doubles up to a fivefold
+	SystemLucky31  = "lucky31"  // 5 selections: a canadian plus 5 singles
+	SystemHeinz    = "heinz"    // 6 selections: doubles up to a sixfold
+	SystemLucky63  = "lucky63"  // 6 selections: a heinz plus 6 singles
+)
+
+// SystemBet describes a system: how many selections it takes and the
+// smallest combination it covers.
+type SystemBet struct {
+	Selections int
+	MinSize    int
+}
+
+var systemBets = map[string]SystemBet{
+	SystemTrixie:   {Selections: 3, MinSize: 2},
+	SystemPatent:   {Selections: 3, MinSize: 1},
+	SystemYankee:   {Selections: 4, MinSize: 2},
+	SystemLucky15:  {Selections: 4, MinSize: 1},
+	SystemCanadian: {Selections: 5, MinSize: 2},
+	SystemLucky31:  {Selections: 5, MinSize: 1},
+	SystemHeinz:    {Selections: 6, MinSize: 2},
+	SystemLucky63:  {Selections: 6, MinSize: 1},
+}
+
+// LookupSystemBet returns the named system.
+func LookupSystemBet(name string) (SystemBet, bool) {
+	sb, ok := systemBets[name]
+	return sb, ok
+}
+
+// Lines returns how many lines the system covers.
+func (sb SystemBet) Lines() int {
+	lines := 0
+	for mask := 1; mask < 1<<sb.Selections; mask++ {
+		if bits.OnesCount(uint(mask)) >= sb.MinSize {
+			lines++
+		}
+	}
+	return lines
+}
+
+// SystemLeg is one selection of a system bet: its odds (x100) at placement
+// and, once settled, its outcome.
+type SystemLeg struct {
+	Odds    int
+	Outcome SelectionOutcome
+}
+
+// SettleSystemBet settles a system bet with stakePerLine on each line. A line
+// returns its stake times the product of its legs' returns per unit staked:
+// the odds for a winner, reduced by dead-heat rules, the stake for a void or
+// push, and half of each for a half-won or half-lost quarter line. When every
+// leg is void or pushes, the whole stake is refunded.
+func SettleSystemBet(sb SystemBet, stakePerLine int64, legs []SystemLeg) Settlement {
+	total := stakePerLine * int64(sb.Lines())
+	factors := make([]*big.Rat, len(legs))
+	refund := true
+	for i, leg := range legs {
+		factors[i] = legFactor(leg)
+		if leg.Outcome.Result != ResultVoid && leg.Outcome.Result != ResultPush {
+			refund = false
+		}
+	}
+	if refund {
+		return Settlement{Status: ResultVoid, Return: total, Refund: true}
+	}
+	if ret := systemReturn(sb, stakePerLine, factors); ret > 0 {
+		return Settlement{Status: ResultWon, Return: ret}
+	}
+	return Settlement{Status: ResultLost}
+}
+
+// SystemMaxReturn returns what a system bet pays if every leg wins outright.
+func SystemMaxReturn(sb SystemBet, stakePerLine int64, odds []int) int64 {
+	factors := make([]*big.Rat, len(odds))
+	for i, o := range odds {
+		factors[i] = big.NewRat(int64(o), 100)
+	}
+	return systemReturn(sb, stakePerLine, factors)
+}
+
+// systemReturn sums the returns of every line, rounding the total down.
+func systemReturn(sb SystemBet, stakePerLine int64, factors []*big.Rat) int64 {
+	sum := new(big.Rat)
+	for mask := 1; mask < 1<<len(factors); mask++ {
+		if bits.OnesCount(uint(mask)) < sb.MinSize {
+			continue
+		}
+		line := big.NewRat(stakePerLine, 1)
+		for i, f := range factors {
+			if mask&(1<<i) != 0 {
+				line.Mul(line, f)
+			}
+		}
+		sum.Add(sum, line)
+	}
+	return new(big.Int).Quo(sum.Num(), sum.Denom()).Int64()
+}
+
+// legFactor is what one unit staked on a leg returns.
+func legFactor(leg SystemLeg) *big.Rat {
+	odds := int64(leg.Odds)
+	switch leg.Outcome.Result {
+	case ResultVoid, ResultPush:
+		return big.NewRat(1, 1)
+	case ResultHalfWon:
+		return big.NewRat(odds+100, 200)
+	case ResultHalfLost:
+		return big.NewRat(1, 2)
+	case ResultWon:
+		tied := int64(max(leg.Outcome.Placing.DeadHeat, 1))
+		if p := leg.Outcome.Placing.Position; p > 1 {
+			return new(big.Rat)
+		}
+		return big.NewRat(odds, 100*tied)
+	}
+	return new(big.Rat)
+}
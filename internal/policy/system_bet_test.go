@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemBet_Lines(t *testing.T) {
+	tests := map[string]int{
+		SystemTrixie: 4, SystemPatent: 7, SystemYankee: 11, SystemLucky15: 15,
+		SystemCanadian: 26, SystemLucky31: 31, SystemHeinz: 57, SystemLucky63: 63,
+	}
+	for name, lines := range tests {
+		sb, ok := LookupSystemBet(name)
+		require.True(t, ok, name)
+		assert.Equal(t, lines, sb.Lines(), name)
+	}
+	_, ok := LookupSystemBet("goliath")
+	assert.False(t, ok)
+}
+
+func TestSettleSystemBet(t *testing.T) {
+	trixie, _ := LookupSystemBet(SystemTrixie)
+	patent, _ := LookupSystemBet(SystemPatent)
+	leg := func(odds int, result string) SystemLeg {
+		return SystemLeg{Odds: odds, Outcome: SelectionOutcome{Result: result}}
+	}
+
+	tests := []struct {
+		name string
+		sb   SystemBet
+		legs []SystemLeg
+		want Settlement
+	}{
+		// Doubles 2x3 + 2x4 + 3x4 = 26, treble 24: 50 per unit staked.
+		{"trixie all win", trixie, []SystemLeg{leg(200, ResultWon), leg(300, ResultWon), leg(400, ResultWon)},
+			Settlement{Status: ResultWon, Return: 50_000}},
+		// Only the 2x3 double comes in.
+		{"trixie one loser", trixie, []SystemLeg{leg(200, ResultWon), leg(300, ResultWon), leg(400, ResultLost)},
+			Settlement{Status: ResultWon, Return: 6_000}},
+		{"trixie two losers", trixie, []SystemLeg{leg(200, ResultWon), leg(300, ResultLost), leg(400, ResultLost)},
+			Settlement{Status: ResultLost}},
+		// The single on the winner still pays.
+		{"patent two losers", patent, []SystemLeg{leg(200, ResultWon), leg(300, ResultLost), leg(400, ResultLost)},
+			Settlement{Status: ResultWon, Return: 2_000}},
+		// A void leg counts as odds of 1: doubles 2 + 3 + 6, treble 6.
+		{"trixie void leg", trixie, []SystemLeg{leg(200, ResultWon), leg(300, ResultWon), leg(400, ResultVoid)},
+			Settlement{Status: ResultWon, Return: 17_000}},
+		{"trixie all void", trixie, []SystemLeg{leg(200, ResultVoid), leg(300, ResultPush), leg(400, ResultVoid)},
+			Settlement{Status: ResultVoid, Return: 4_000, Refund: true}},
+		// Half won at 2.00 returns 1.50, half lost 0.50 and a two-way dead heat
+		// at 4.00 returns 2.00.
+		{"trixie half results", trixie, []SystemLeg{leg(200, ResultHalfWon), leg(300, ResultHalfLost),
+			{Odds: 400, Outcome: SelectionOutcome{Result: ResultWon, Placing: Placing{Position: 1, DeadHeat: 2}}}},
+			Settlement{Status: ResultWon, Return: 750 + 3_000 + 1_000 + 1_500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SettleSystemBet(tt.sb, 1_000, tt.legs))
+		})
+	}
+}
+
+func TestSystemMaxReturn(t *testing.T) {
+	yankee, _ := LookupSystemBet(SystemYankee)
+	// Four evens legs: 6 doubles at 4, 4 trebles at 8 and a fourfold at 16.
+	assert.Equal(t, int64(6*400+4*800+1600), SystemMaxReturn(yankee, 100, []int{200, 200, 200, 200}))
+}
@@ -0,0 +1,52 @@
+package policy
+
+import "time"
+
+// Trading alert types raised for unusual sportsbook stake patterns.
+const (
+	AlertSelectionStakeSpike = "selection_stake_spike"
+	AlertPlayerHandleShare   = "player_handle_share"
+)
+
+// TradingAlertRules configures when stake patterns are flagged to traders.
+type TradingAlertRules struct {
+	// A selection taking more than SelectionStake within Window is flagged.
+	SelectionStake int64         `json:"selection_stake"` // cents
+	Window         time.Duration `json:"window"`
+
+	// A player holding more than PlayerShareBps of a market's open handle is
+	// flagged once the handle reaches MinMarketHandle.
+	PlayerShareBps  int   `json:"player_share_bps"`
+	MinMarketHandle int64 `json:"min_market_handle"` // cents
+}
+
+// DefaultTradingAlertRules: €5,000 on one selection within 15 minutes, or a
+// single player holding over half of a market's handle once it passes €1,000.
+func DefaultTradingAlertRules() TradingAlertRules {
+	return TradingAlertRules{
+		SelectionStake:  500_000,
+		Window:          15 * time.Minute,
+		PlayerShareBps:  5_000,
+		MinMarketHandle: 100_000,
+	}
+}
+
+// StakeFacts holds the open stake totals after a bet was placed.
+type StakeFacts struct {
+	SelectionWindowStake int64 `json:"selection_window_stake"` // on the selection within the window
+	MarketHandle         int64 `json:"market_handle"`          // open stakes on the market
+	PlayerMarketHandle   int64 `json:"player_market_handle"`   // the bettor's open stakes on the market
+}
+
+// EvaluateStakePattern returns the alert types the facts trigger.
+func EvaluateStakePattern(rules TradingAlertRules, f StakeFacts) []string {
+	var alerts []string
+	if rules.SelectionStake > 0 && f.SelectionWindowStake > rules.SelectionStake {
+		alerts = append(alerts, AlertSelectionStakeSpike)
+	}
+	if rules.PlayerShareBps > 0 && f.MarketHandle > 0 && f.MarketHandle >= rules.MinMarketHandle &&
+		f.PlayerMarketHandle*10_000 > f.MarketHandle*int64(rules.PlayerShareBps) {
+		alerts = append(alerts, AlertPlayerHandleShare)
+	}
+	return alerts
+}
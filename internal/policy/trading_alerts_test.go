@@ -0,0 +1,32 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateStakePattern(t *testing.T) {
+	rules := DefaultTradingAlertRules()
+
+	tests := []struct {
+		name  string
+		facts StakeFacts
+		want  []string
+	}{
+		{"quiet market", StakeFacts{SelectionWindowStake: 10_000, MarketHandle: 50_000, PlayerMarketHandle: 10_000}, nil},
+		{"at stake threshold", StakeFacts{SelectionWindowStake: 500_000, MarketHandle: 2_000_000, PlayerMarketHandle: 100_000}, nil},
+		{"stake spike", StakeFacts{SelectionWindowStake: 500_001, MarketHandle: 2_000_000, PlayerMarketHandle: 100_000}, []string{AlertSelectionStakeSpike}},
+		{"dominant player on small market", StakeFacts{SelectionWindowStake: 90_000, MarketHandle: 99_999, PlayerMarketHandle: 90_000}, nil},
+		{"dominant player", StakeFacts{SelectionWindowStake: 90_000, MarketHandle: 150_000, PlayerMarketHandle: 90_000}, []string{AlertPlayerHandleShare}},
+		{"exactly half", StakeFacts{SelectionWindowStake: 75_000, MarketHandle: 150_000, PlayerMarketHandle: 75_000}, nil},
+		{"both", StakeFacts{SelectionWindowStake: 600_000, MarketHandle: 700_000, PlayerMarketHandle: 600_000}, []string{AlertSelectionStakeSpike, AlertPlayerHandleShare}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EvaluateStakePattern(rules, tt.facts))
+		})
+	}
+
+	assert.Nil(t, EvaluateStakePattern(TradingAlertRules{}, StakeFacts{SelectionWindowStake: 1 << 40, MarketHandle: 1, PlayerMarketHandle: 1}))
+}
@@ -0,0 +1,89 @@
+package policy
+
+import "time"
+
+// WithdrawalRecommendation is the automated verdict attached to a withdrawal.
+type WithdrawalRecommendation string
+
+const (
+	WithdrawalApprove WithdrawalRecommendation = "approve"
+	WithdrawalReview  WithdrawalRecommendation = "review"
+	WithdrawalDeny    WithdrawalRecommendation = "deny"
+)
+
+// WithdrawalRiskInput holds the facts gathered when a withdrawal is requested.
+type WithdrawalRiskInput struct {
+	Amount                   int64         `json:"amount"`                     // cents
+	TotalDeposited           int64         `json:"total_deposited"`            // completed deposits, cents
+	TotalWagered             int64         `json:"total_wagered"`              // settled bet stakes, cents
+	BonusCredited            int64         `json:"bonus_credited"`             // lifetime bonus credits, cents
+	OutstandingBonusWagering int64         `json:"outstanding_bonus_wagering"` // remaining on active bonuses, cents
+	DepositMethodAge         time.Duration `json:"deposit_method_age"`         // since first use of the latest deposit method
+	KYCVerified              bool          `json:"kyc_verified"`
+	OpenAMLAlerts            int           `json:"open_aml_alerts"`
+}
+
+// WithdrawalRiskAssessment is the scored result of a withdrawal risk check.
+type WithdrawalRiskAssessment struct {
+	Score          int                      `json:"score"`
+	Recommendation WithdrawalRecommendation `json:"recommendation"`
+	Factors        []string                 `json:"factors,omitempty"`
+}
+
+// Thresholds for the withdrawal risk score (0-100).
+const (
+	WithdrawalReviewScore = 30
+	WithdrawalDenyScore   = 70
+
+	// newDepositMethodAge is how long a deposit method must have been in use
+	// before it stops counting as a risk factor.
+	newDepositMethodAge = 7 * 24 * time.Hour
+
+	// minBonusTurnover is the wagered-to-bonus ratio below which bonus abuse is suspected.
+	minBonusTurnover = 5
+)
+
+// EvaluateWithdrawalRisk scores a withdrawal request. Two or more open AML
+// alerts deny outright; otherwise the weighted score decides.
+func EvaluateWithdrawalRisk(in WithdrawalRiskInput) WithdrawalRiskAssessment {
+	var score int
+	var factors []string
+
+	add := func(weight int, factor string) {
+		score += weight
+		factors = append(factors, factor)
+	}
+
+	if in.OpenAMLAlerts > 0 {
+		add(40, "open_aml_alerts")
+	}
+	if !in.KYCVerified {
+		add(30, "kyc_not_verified")
+	}
+	if in.OutstandingBonusWagering > 0 {
+		add(30, "bonus_wagering_incomplete")
+	}
+	if in.TotalDeposited > 0 && in.TotalWagered < in.TotalDeposited {
+		add(20, "deposits_not_wagered")
+	}
+	if in.BonusCredited > 0 && in.TotalWagered < in.BonusCredited*minBonusTurnover {
+		add(15, "low_bonus_turnover")
+	}
+	if in.TotalDeposited > 0 && in.DepositMethodAge < newDepositMethodAge {
+		add(15, "new_deposit_method")
+	}
+
+	if score > 100 {
+		score = 100
+	}
+
+	rec := WithdrawalApprove
+	switch {
+	case in.OpenAMLAlerts >= 2 || score >= WithdrawalDenyScore:
+		rec = WithdrawalDeny
+	case score >= WithdrawalReviewScore:
+		rec = WithdrawalReview
+	}
+
+	return WithdrawalRiskAssessment{Score: score, Recommendation: rec, Factors: factors}
+}
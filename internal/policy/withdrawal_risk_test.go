@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cleanWithdrawal() WithdrawalRiskInput {
+	return WithdrawalRiskInput{
+		Amount:           5000,
+		TotalDeposited:   10000,
+		TotalWagered:     50000,
+		DepositMethodAge: 90 * 24 * time.Hour,
+		KYCVerified:      true,
+	}
+}
+
+func TestEvaluateWithdrawalRisk_Approve(t *testing.T) {
+	result := EvaluateWithdrawalRisk(cleanWithdrawal())
+	assert.Equal(t, WithdrawalApprove, result.Recommendation)
+	assert.Equal(t, 0, result.Score)
+	assert.Empty(t, result.Factors)
+}
+
+func TestEvaluateWithdrawalRisk_ReviewWithoutKYC(t *testing.T) {
+	in := cleanWithdrawal()
+	in.KYCVerified = false
+	result := EvaluateWithdrawalRisk(in)
+	assert.Equal(t, WithdrawalReview, result.Recommendation)
+	assert.Contains(t, result.Factors, "kyc_not_verified")
+}
+
+func TestEvaluateWithdrawalRisk_DenyBonusAbuse(t *testing.T) {
+	in := cleanWithdrawal()
+	in.BonusCredited = 20000
+	in.OutstandingBonusWagering = 100000
+	in.TotalWagered = 5000
+	in.DepositMethodAge = time.Hour
+	result := EvaluateWithdrawalRisk(in)
+	assert.Equal(t, WithdrawalDeny, result.Recommendation)
+	assert.Contains(t, result.Factors, "bonus_wagering_incomplete")
+	assert.Contains(t, result.Factors, "low_bonus_turnover")
+	assert.Contains(t, result.Factors, "new_deposit_method")
+}
+
+func TestEvaluateWithdrawalRisk_MultipleAMLAlertsDeny(t *testing.T) {
+	in := cleanWithdrawal()
+	in.OpenAMLAlerts = 2
+	result := EvaluateWithdrawalRisk(in)
+	assert.Equal(t, WithdrawalDeny, result.Recommendation)
+	assert.Less(t, result.Score, WithdrawalDenyScore)
+}
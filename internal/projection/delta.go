@@ -0,0 +1,39 @@
+package projection
+
+import "github.com/attaboy/platform/internal/domain"
+
+// Delta is what one ledger entry adds to a player's summary and to the
+// day's KPIs.
+type Delta struct {
+	DepositCount int
+	Deposits     int64
+	Withdrawals  int64
+	BetCount     int
+	Stakes       int64
+	Wins         int64
+	Bonuses      int64
+}
+
+// TransactionDelta returns the totals a ledger entry of type t and amount
+// moves. Withdrawals count once paid out; entry types the read models do not
+// track, cancellations included, return a zero Delta.
+func TransactionDelta(t domain.TransactionType, amount int64) Delta {
+	switch t {
+	case domain.TxDeposit:
+		return Delta{DepositCount: 1, Deposits: amount}
+	case domain.TxWithdrawalProcessed:
+		return Delta{Withdrawals: amount}
+	case domain.TxBet:
+		return Delta{BetCount: 1, Stakes: amount}
+	case domain.TxWin:
+		return Delta{Wins: amount}
+	case domain.TxBonusCredit:
+		return Delta{Bonuses: amount}
+	}
+	return Delta{}
+}
+
+// IsZero reports whether d moves no totals.
+func (d Delta) IsZero() bool {
+	return d == Delta{}
+}
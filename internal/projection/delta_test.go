@@ -0,0 +1,46 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionDelta(t *testing.T) {
+	tests := []struct {
+		txType domain.TransactionType
+		want   Delta
+	}{
+		{domain.TxDeposit, Delta{DepositCount: 1, Deposits: 500}},
+		{domain.TxWithdrawalProcessed, Delta{Withdrawals: 500}},
+		{domain.TxBet, Delta{BetCount: 1, Stakes: 500}},
+		{domain.TxWin, Delta{Wins: 500}},
+		{domain.TxBonusCredit, Delta{Bonuses: 500}},
+		// Reserved, not yet paid out
+		{domain.TxWithdrawal, Delta{}},
+		{domain.TxCancelBet, Delta{}},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.txType), func(t *testing.T) {
+			got := TransactionDelta(tc.txType, 500)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.want == Delta{}, got.IsZero())
+		})
+	}
+}
+
+func TestHandles(t *testing.T) {
+	assert.True(t, Handles(domain.EventPlayerCreated))
+	assert.True(t, Handles(domain.EventTransactionPosted))
+	assert.True(t, Handles(domain.EventBetSettled))
+	assert.True(t, Handles(domain.EventPredictionStakeSettled))
+	assert.False(t, Handles(domain.EventBonusExpiring))
+}
+
+func TestKPIDay(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	got := kpiDay(time.Date(2026, 5, 2, 1, 30, 0, 0, berlin))
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), got)
+}
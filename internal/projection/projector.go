@@ -0,0 +1,200 @@
+// Package projection maintains denormalized read models from outbox events:
+// player_summary, daily_kpis and bet_feed. The outbox consumer projects each
+// event before publishing it, since published events leave the outbox.
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Projector applies outbox events to the read-model tables. Each event is
+// applied at most once: its projected_events marker is written in the same
+// transaction as its updates.
+type Projector struct {
+	pool *pgxpool.Pool
+}
+
+// NewProjector creates a Projector.
+func NewProjector(pool *pgxpool.Pool) *Projector {
+	return &Projector{pool: pool}
+}
+
+// Handles reports whether events of type t feed a projection.
+func Handles(t domain.EventType) bool {
+	switch t {
+	case domain.EventPlayerCreated, domain.EventTransactionPosted,
+		domain.EventBetSettled, domain.EventPredictionStakeSettled:
+		return true
+	}
+	return false
+}
+
+// Project applies e to the read models. Events no projection uses, and
+// events already applied, are skipped.
+func (p *Projector) Project(ctx context.Context, e domain.OutboxDraft) error {
+	if !Handles(e.EventType) {
+		return nil
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO projected_events (event_id) VALUES ($1) ON CONFLICT DO NOTHING`, e.EventID)
+	if err != nil {
+		return fmt.Errorf("mark event projected: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	switch e.EventType {
+	case domain.EventPlayerCreated:
+		err = projectPlayerCreated(ctx, tx, e)
+	case domain.EventTransactionPosted:
+		err = projectTransaction(ctx, tx, e)
+	case domain.EventBetSettled, domain.EventPredictionStakeSettled:
+		err = projectSettledBet(ctx, tx, e)
+	}
+	if err != nil {
+		return fmt.Errorf("project %s event %s: %w", e.EventType, e.EventID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// projectPlayerCreated starts a player's summary and counts the
+// registration for the day.
+func projectPlayerCreated(ctx context.Context, tx pgx.Tx, e domain.OutboxDraft) error {
+	var payload struct {
+		PlayerID uuid.UUID `json:"player_id"`
+		Email    string    `json:"email"`
+		Currency string    `json:"currency"`
+	}
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO player_summary (player_id, email, currency, registered_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (player_id) DO UPDATE
+		SET email = EXCLUDED.email, currency = EXCLUDED.currency,
+		    registered_at = EXCLUDED.registered_at, updated_at = now()`,
+		payload.PlayerID, payload.Email, payload.Currency, e.OccurredAt); err != nil {
+		return fmt.Errorf("upsert player summary: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO daily_kpis (day, currency, registrations)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (day, currency) DO UPDATE
+		SET registrations = daily_kpis.registrations + 1, updated_at = now()`,
+		kpiDay(e.OccurredAt), payload.Currency); err != nil {
+		return fmt.Errorf("count registration: %w", err)
+	}
+	return nil
+}
+
+// projectTransaction adds a ledger entry to its player's totals and to the
+// day's KPIs in the wallet's currency. Every entry counts as activity.
+func projectTransaction(ctx context.Context, tx pgx.Tx, e domain.OutboxDraft) error {
+	var t domain.Transaction
+	if err := json.Unmarshal(e.Payload, &t); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	d := TransactionDelta(t.Type, t.Amount)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO player_summary (player_id, deposit_count, deposit_total, withdrawal_total,
+		                            bet_count, stake_total, win_total, bonus_total, last_activity_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (player_id) DO UPDATE
+		SET deposit_count    = player_summary.deposit_count + EXCLUDED.deposit_count,
+		    deposit_total    = player_summary.deposit_total + EXCLUDED.deposit_total,
+		    withdrawal_total = player_summary.withdrawal_total + EXCLUDED.withdrawal_total,
+		    bet_count        = player_summary.bet_count + EXCLUDED.bet_count,
+		    stake_total      = player_summary.stake_total + EXCLUDED.stake_total,
+		    win_total        = player_summary.win_total + EXCLUDED.win_total,
+		    bonus_total      = player_summary.bonus_total + EXCLUDED.bonus_total,
+		    last_activity_at = GREATEST(player_summary.last_activity_at, EXCLUDED.last_activity_at),
+		    updated_at       = now()`,
+		t.PlayerID, d.DepositCount, d.Deposits, d.Withdrawals,
+		d.BetCount, d.Stakes, d.Wins, d.Bonuses, t.CreatedAt); err != nil {
+		return fmt.Errorf("upsert player summary: %w", err)
+	}
+	if d.IsZero() {
+		return nil
+	}
+
+	// Base-currency entries leave their currency blank.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO daily_kpis (day, currency, deposit_count, deposit_total, withdrawal_total,
+		                        bet_count, stake_total, win_total, bonus_total)
+		VALUES ($1, COALESCE(NULLIF($2, ''), (SELECT currency FROM v2_players WHERE id = $3)),
+		        $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (day, currency) DO UPDATE
+		SET deposit_count    = daily_kpis.deposit_count + EXCLUDED.deposit_count,
+		    deposit_total    = daily_kpis.deposit_total + EXCLUDED.deposit_total,
+		    withdrawal_total = daily_kpis.withdrawal_total + EXCLUDED.withdrawal_total,
+		    bet_count        = daily_kpis.bet_count + EXCLUDED.bet_count,
+		    stake_total      = daily_kpis.stake_total + EXCLUDED.stake_total,
+		    win_total        = daily_kpis.win_total + EXCLUDED.win_total,
+		    bonus_total      = daily_kpis.bonus_total + EXCLUDED.bonus_total,
+		    updated_at       = now()`,
+		kpiDay(t.CreatedAt), t.Currency, t.PlayerID, d.DepositCount, d.Deposits, d.Withdrawals,
+		d.BetCount, d.Stakes, d.Wins, d.Bonuses); err != nil {
+		return fmt.Errorf("upsert daily kpis: %w", err)
+	}
+	return nil
+}
+
+// projectSettledBet records a settled sportsbook bet or prediction stake in
+// the bet feed. A resettled bet replaces its earlier entry.
+func projectSettledBet(ctx context.Context, tx pgx.Tx, e domain.OutboxDraft) error {
+	var payload struct {
+		PlayerID uuid.UUID `json:"player_id"`
+		BetID    uuid.UUID `json:"bet_id"`
+		StakeID  uuid.UUID `json:"stake_id"`
+		Stake    int64     `json:"stake"`
+		Status   string    `json:"status"`
+		Return   int64     `json:"return"`
+		Payout   int64     `json:"payout"`
+	}
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	kind, referenceID, returned := "sportsbook", payload.BetID, payload.Return
+	if e.EventType == domain.EventPredictionStakeSettled {
+		kind, referenceID, returned = "prediction", payload.StakeID, payload.Payout
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO bet_feed (kind, reference_id, player_id, stake, status, returned, settled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (kind, reference_id) DO UPDATE
+		SET status = EXCLUDED.status, returned = EXCLUDED.returned, settled_at = EXCLUDED.settled_at`,
+		kind, referenceID, payload.PlayerID, payload.Stake, payload.Status, returned, e.OccurredAt); err != nil {
+		return fmt.Errorf("insert bet feed entry: %w", err)
+	}
+	return nil
+}
+
+// kpiDay is the UTC day an event counts towards.
+func kpiDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
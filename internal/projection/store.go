@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -16,6 +17,7 @@ type Store interface {
 
 // InMemoryStore is a simple in-memory projection store for development/testing.
 type InMemoryStore struct {
+	mu   sync.Mutex
 	data map[string]entry
 }
 
@@ -30,6 +32,8 @@ func NewInMemoryStore() *InMemoryStore {
 }
 
 func (s *InMemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	e, ok := s.data[key]
 	if !ok {
 		return nil, fmt.Errorf("key not found: %s", key)
@@ -42,6 +46,8 @@ func (s *InMemoryStore) Get(_ context.Context, key string) ([]byte, error) {
 }
 
 func (s *InMemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	var exp time.Time
 	if ttl > 0 {
 		exp = time.Now().Add(ttl)
@@ -51,6 +57,8 @@ func (s *InMemoryStore) Set(_ context.Context, key string, value []byte, ttl tim
 }
 
 func (s *InMemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.data, key)
 	return nil
 }
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// WalletAdapter is implemented by every casino provider integration the wallet
+// server can mount. The server owns routing and ledger dispatch; the adapter
+// only translates between the provider's wire format and a WalletCallback.
+type WalletAdapter interface {
+	// Name is the manufacturer ID recorded on ledger transactions.
+	Name() string
+	// Routes lists the callback paths served under the adapter's prefix.
+	Routes() []WalletRoute
+	// ParseRequest reads and decodes a callback body.
+	ParseRequest(r *http.Request) (*WalletRequest, error)
+	// VerifySignature checks the callback signature against the raw body.
+	VerifySignature(body []byte, signature string) bool
+	// ToWalletCallback converts a parsed request into a unified callback. The
+	// action is the one bound to the route, or empty when the provider names
+	// the action in the body.
+	ToWalletCallback(req *WalletRequest, action WalletAction) (*WalletCallback, error)
+	// Respond writes the provider-specific response for a result.
+	Respond(w http.ResponseWriter, result WalletResult)
+}
+
+var (
+	_ WalletAdapter = (*BetSolutionsAdapter)(nil)
+	_ WalletAdapter = (*PragmaticAdapter)(nil)
+	_ WalletAdapter = (*EvolutionAdapter)(nil)
+	_ WalletAdapter = (*RelaxAdapter)(nil)
+	_ WalletAdapter = (*NetEntAdapter)(nil)
+)
+
+// WalletRoute binds a callback path to a wallet action. An empty Action means
+// the adapter resolves the action from the request body.
+type WalletRoute struct {
+	Path   string
+	Action WalletAction
+}
+
+// WalletRequest is a decoded provider callback together with its raw body and
+// the signature it carried.
+type WalletRequest struct {
+	Body      []byte
+	Signature string
+	Payload   any
+}
+
+// WalletStatus classifies the outcome of a callback for the adapter response.
+type WalletStatus int
+
+const (
+	WalletStatusOK WalletStatus = iota
+	WalletStatusBadRequest
+	WalletStatusUnauthorized
+	WalletStatusForbidden
+	WalletStatusInsufficientFunds
+	WalletStatusError
+)
+
+var walletStatusNames = map[WalletStatus]string{
+	WalletStatusOK:                "ok",
+	WalletStatusBadRequest:        "bad_request",
+	WalletStatusUnauthorized:      "unauthorized",
+	WalletStatusForbidden:         "forbidden",
+	WalletStatusInsufficientFunds: "insufficient_funds",
+	WalletStatusError:             "error",
+}
+
+// String returns the status name recorded in the callback journal.
+func (s WalletStatus) String() string {
+	if name, ok := walletStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("status_%d", int(s))
+}
+
+// WalletResult is what the wallet server hands back to an adapter to render.
+// Request is the parsed callback, nil when the body could not be read.
+type WalletResult struct {
+	Request      *WalletRequest
+	Status       WalletStatus
+	Message      string
+	Currency     string
+	Balance      int64
+	BonusBalance int64
+}
+
+// AdapterConfig configures one mounted adapter instance.
+type AdapterConfig struct {
+	Name   string // manufacturer ID, also the default route prefix
+	Kind   string // registered adapter kind
+	Prefix string
+	Secret string
+	// MoneyFormat, when set, is checked against the wire format of the kind.
+	MoneyFormat string
+}
+
+// MountedAdapter is an adapter bound to its route prefix.
+type MountedAdapter struct {
+	Prefix  string
+	Adapter WalletAdapter
+}
+
+// AdapterFactory builds an adapter from its configuration.
+type AdapterFactory func(cfg AdapterConfig, logger *slog.Logger) WalletAdapter
+
+// AdapterRegistry maps adapter kinds to factories.
+type AdapterRegistry struct {
+	factories map[string]AdapterFactory
+}
+
+// NewAdapterRegistry creates an empty registry.
+func NewAdapterRegistry() *AdapterRegistry {
+	return &AdapterRegistry{factories: make(map[string]AdapterFactory)}
+}
+
+// DefaultAdapterRegistry returns a registry with every built-in adapter kind.
+func DefaultAdapterRegistry() *AdapterRegistry {
+	reg := NewAdapterRegistry()
+	reg.Register("betsolutions", func(cfg AdapterConfig, logger *slog.Logger) WalletAdapter {
+		a := NewBetSolutionsAdapter(cfg.Secret, logger)
+		a.name = cfg.Name
+		return a
+	})
+	reg.Register("pragmatic", func(cfg AdapterConfig, logger *slog.Logger) WalletAdapter {
+		a := NewPragmaticAdapter(cfg.Secret, logger)
+		a.name = cfg.Name
+		return a
+	})
+	reg.Register("evolution", func(cfg AdapterConfig, logger *slog.Logger) WalletAdapter {
+		a := NewEvolutionAdapter(cfg.Secret, logger)
+		a.name = cfg.Name
+		return a
+	})
+	reg.Register("relax", func(cfg AdapterConfig, logger *slog.Logger) WalletAdapter {
+		a := NewRelaxAdapter(cfg.Secret, logger)
+		a.name = cfg.Name
+		return a
+	})
+	reg.Register("netent", func(cfg AdapterConfig, logger *slog.Logger) WalletAdapter {
+		a := NewNetEntAdapter(cfg.Secret, logger)
+		a.name = cfg.Name
+		return a
+	})
+	return reg
+}
+
+// Register adds or replaces the factory for a kind.
+func (r *AdapterRegistry) Register(kind string, factory AdapterFactory) {
+	r.factories[kind] = factory
+}
+
+// Kinds returns the registered adapter kinds in sorted order.
+func (r *AdapterRegistry) Kinds() []string {
+	kinds := make([]string, 0, len(r.factories))
+	for k := range r.factories {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Build instantiates every configured adapter. Unknown kinds and duplicate
+// names or prefixes are rejected so a typo cannot silently drop a provider.
+func (r *AdapterRegistry) Build(configs []AdapterConfig, logger *slog.Logger) ([]MountedAdapter, error) {
+	names := make(map[string]bool)
+	prefixes := make(map[string]bool)
+	mounted := make([]MountedAdapter, 0, len(configs))
+	for _, cfg := range configs {
+		factory, ok := r.factories[cfg.Kind]
+		if !ok {
+			return nil, fmt.Errorf("wallet provider %q: unknown adapter kind %q", cfg.Name, cfg.Kind)
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("wallet provider %q configured twice", cfg.Name)
+		}
+		if prefixes[cfg.Prefix] {
+			return nil, fmt.Errorf("wallet provider %q: prefix %s already mounted", cfg.Name, cfg.Prefix)
+		}
+		if want, ok := kindMoneyFormats[cfg.Kind]; ok && cfg.MoneyFormat != "" && cfg.MoneyFormat != want {
+			return nil, fmt.Errorf("wallet provider %q: %s adapters send %s amounts, not %s", cfg.Name, cfg.Kind, want, cfg.MoneyFormat)
+		}
+		names[cfg.Name] = true
+		prefixes[cfg.Prefix] = true
+		mounted = append(mounted, MountedAdapter{Prefix: cfg.Prefix, Adapter: factory(cfg, logger)})
+	}
+	return mounted, nil
+}
+
+// kindMoneyFormats records how each built-in adapter kind sends amounts.
+var kindMoneyFormats = map[string]string{
+	"betsolutions": domain.MoneyFormatCents,
+	"pragmatic":    domain.MoneyFormatDecimal,
+	"evolution":    domain.MoneyFormatDecimal,
+	"relax":        domain.MoneyFormatCents,
+	"netent":       domain.MoneyFormatDecimal,
+}
+
+// legacySecretEnv holds the secret variables used before adapters were
+// configured per provider; they still apply when the new variable is unset.
+var legacySecretEnv = map[string]string{
+	"betsolutions": "BETSOLUTIONS_HMAC_SECRET",
+	"pragmatic":    "PRAGMATIC_SECRET_KEY",
+}
+
+// ParseAdapterConfigs parses a comma-separated list of "name" or "name=kind"
+// entries and resolves each provider's prefix and secret through getenv.
+func ParseAdapterConfigs(list string, getenv func(string) string) ([]AdapterConfig, error) {
+	var configs []AdapterConfig
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, kind, _ := strings.Cut(entry, "=")
+		name, kind = strings.ToLower(strings.TrimSpace(name)), strings.ToLower(strings.TrimSpace(kind))
+		if name == "" {
+			return nil, fmt.Errorf("wallet provider entry %q has no name", entry)
+		}
+		if kind == "" {
+			kind = name
+		}
+
+		envPrefix := "WALLET_PROVIDER_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name)) + "_"
+		prefix := getenv(envPrefix + "PREFIX")
+		if prefix == "" {
+			prefix = "/" + name
+		}
+		if !strings.HasPrefix(prefix, "/") {
+			prefix = "/" + prefix
+		}
+		prefix = strings.TrimSuffix(prefix, "/")
+		if prefix == "" {
+			return nil, fmt.Errorf("wallet provider %q cannot be mounted at the root path", name)
+		}
+		secret := getenv(envPrefix + "SECRET")
+		if secret == "" && legacySecretEnv[name] != "" {
+			secret = getenv(legacySecretEnv[name])
+		}
+
+		configs = append(configs, AdapterConfig{
+			Name:   name,
+			Kind:   kind,
+			Prefix: prefix,
+			Secret: secret,
+		})
+	}
+	return configs, nil
+}
+
+// ManufacturerAdapterConfigs configures adapters from manufacturers rows,
+// resolving each secret through getenv from the variable its row names.
+// Disabled manufacturers are still mounted so they can be re-enabled without
+// a restart; the wallet server refuses their callbacks meanwhile.
+func ManufacturerAdapterConfigs(manufacturers []domain.Manufacturer, getenv func(string) string) ([]AdapterConfig, error) {
+	configs := make([]AdapterConfig, 0, len(manufacturers))
+	for _, m := range manufacturers {
+		prefix := "/" + strings.Trim(m.Prefix, "/")
+		if prefix == "/" {
+			return nil, fmt.Errorf("wallet provider %q cannot be mounted at the root path", m.ID)
+		}
+		var secret string
+		if m.SecretRef != nil && *m.SecretRef != "" {
+			secret = getenv(*m.SecretRef)
+			if secret == "" {
+				return nil, fmt.Errorf("wallet provider %q: secret variable %s is not set", m.ID, *m.SecretRef)
+			}
+		}
+		configs = append(configs, AdapterConfig{
+			Name:        m.ID,
+			Kind:        m.Kind,
+			Prefix:      prefix,
+			Secret:      secret,
+			MoneyFormat: m.MoneyFormat,
+		})
+	}
+	return configs, nil
+}
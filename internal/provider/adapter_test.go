@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envMap(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func TestParseAdapterConfigs(t *testing.T) {
+	t.Run("defaults prefix and falls back to legacy secrets", func(t *testing.T) {
+		configs, err := ParseAdapterConfigs("betsolutions, pragmatic", envMap(map[string]string{
+			"BETSOLUTIONS_HMAC_SECRET":         "bs-legacy",
+			"WALLET_PROVIDER_PRAGMATIC_SECRET": "pp-new",
+			"PRAGMATIC_SECRET_KEY":             "pp-legacy",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []AdapterConfig{
+			{Name: "betsolutions", Kind: "betsolutions", Prefix: "/betsolutions", Secret: "bs-legacy"},
+			{Name: "pragmatic", Kind: "pragmatic", Prefix: "/pragmatic", Secret: "pp-new"},
+		}, configs)
+	})
+
+	t.Run("named instance of another kind with custom prefix", func(t *testing.T) {
+		configs, err := ParseAdapterConfigs("pp-eu=pragmatic", envMap(map[string]string{
+			"WALLET_PROVIDER_PP_EU_PREFIX": "eu/pragmatic/",
+			"WALLET_PROVIDER_PP_EU_SECRET": "s",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []AdapterConfig{{Name: "pp-eu", Kind: "pragmatic", Prefix: "/eu/pragmatic", Secret: "s"}}, configs)
+	})
+
+	t.Run("root prefix is rejected", func(t *testing.T) {
+		_, err := ParseAdapterConfigs("x", envMap(map[string]string{"WALLET_PROVIDER_X_PREFIX": "/"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("empty name is rejected", func(t *testing.T) {
+		_, err := ParseAdapterConfigs("=pragmatic", envMap(nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestAdapterRegistry_Build(t *testing.T) {
+	reg := DefaultAdapterRegistry()
+	assert.Equal(t, []string{"betsolutions", "evolution", "netent", "pragmatic", "relax"}, reg.Kinds())
+
+	t.Run("instances take their configured name", func(t *testing.T) {
+		mounted, err := reg.Build([]AdapterConfig{
+			{Name: "betsolutions", Kind: "betsolutions", Prefix: "/betsolutions"},
+			{Name: "pp-eu", Kind: "pragmatic", Prefix: "/eu/pragmatic"},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, mounted, 2)
+		assert.Equal(t, "betsolutions", mounted[0].Adapter.Name())
+		assert.Len(t, mounted[0].Adapter.Routes(), 4)
+		assert.Equal(t, "pp-eu", mounted[1].Adapter.Name())
+		assert.Equal(t, "/eu/pragmatic", mounted[1].Prefix)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		_, err := reg.Build([]AdapterConfig{{Name: "acme", Kind: "acme", Prefix: "/acme"}}, nil)
+		assert.ErrorContains(t, err, "unknown adapter kind")
+	})
+
+	t.Run("duplicate prefix", func(t *testing.T) {
+		_, err := reg.Build([]AdapterConfig{
+			{Name: "a", Kind: "pragmatic", Prefix: "/pp"},
+			{Name: "b", Kind: "pragmatic", Prefix: "/pp"},
+		}, nil)
+		assert.ErrorContains(t, err, "already mounted")
+	})
+
+	t.Run("money format must match the kind", func(t *testing.T) {
+		_, err := reg.Build([]AdapterConfig{{Name: "bs", Kind: "betsolutions", Prefix: "/bs", MoneyFormat: "decimal"}}, nil)
+		assert.ErrorContains(t, err, "cents amounts")
+	})
+}
+
+func TestManufacturerAdapterConfigs(t *testing.T) {
+	ref := "BS_SECRET"
+	manufacturers := []domain.Manufacturer{
+		{ID: "betsolutions", Kind: "betsolutions", Prefix: "bs/", SecretRef: &ref, MoneyFormat: "cents"},
+		{ID: "pp-eu", Kind: "pragmatic", Prefix: "/eu/pragmatic", Enabled: false, MoneyFormat: "decimal"},
+	}
+
+	configs, err := ManufacturerAdapterConfigs(manufacturers, envMap(map[string]string{"BS_SECRET": "s3cret"}))
+	require.NoError(t, err)
+	assert.Equal(t, []AdapterConfig{
+		{Name: "betsolutions", Kind: "betsolutions", Prefix: "/bs", Secret: "s3cret", MoneyFormat: "cents"},
+		{Name: "pp-eu", Kind: "pragmatic", Prefix: "/eu/pragmatic", MoneyFormat: "decimal"},
+	}, configs)
+
+	_, err = ManufacturerAdapterConfigs(manufacturers, envMap(nil))
+	assert.ErrorContains(t, err, "BS_SECRET is not set")
+}
+
+func TestWalletAdapter_Respond(t *testing.T) {
+	t.Run("betsolutions reports status in body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewBetSolutionsAdapter("s", nil).Respond(w, WalletResult{Status: WalletStatusForbidden, Message: "account suspended"})
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"StatusCode":403,"Balance":0,"Error":"account suspended"}`, w.Body.String())
+	})
+
+	t.Run("pragmatic formats balances as decimals", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewPragmaticAdapter("s", nil).Respond(w, WalletResult{Status: WalletStatusOK, Currency: "EUR", Balance: 1050, BonusBalance: 5})
+		assert.JSONEq(t, `{"currency":"EUR","cash":"10.50","bonus":"0.05","error":0}`, w.Body.String())
+	})
+}
+
+func TestPragmaticAdapter_ParseRequest(t *testing.T) {
+	adapter := NewPragmaticAdapter("s", nil)
+	r := httptest.NewRequest("POST", "/pragmatic/", strings.NewReader(
+		`{"userId":"6f1c1f4e-8c1e-4c43-9d0a-1b2c3d4e5f60","action":"result","amount":"2.5","reference":"r1","hash":"abc"}`))
+	req, err := adapter.ParseRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", req.Signature)
+
+	cb, err := adapter.ToWalletCallback(req, "")
+	require.NoError(t, err)
+	assert.Equal(t, WalletActionWin, cb.Action)
+	assert.Equal(t, int64(250), cb.Amount)
+}
+
+func TestBetSolutionsAdapter_SessionToken(t *testing.T) {
+	adapter := NewBetSolutionsAdapter("s", nil)
+
+	cb, err := adapter.ToWalletCallback(&WalletRequest{Payload: &BetSolutionsRequest{Token: "tok", Amount: 100}}, WalletActionBet)
+	require.NoError(t, err)
+	assert.Equal(t, "tok", cb.SessionToken)
+	assert.Equal(t, uuid.Nil, cb.PlayerID)
+
+	_, err = adapter.ToWalletCallback(&WalletRequest{Payload: &BetSolutionsRequest{Token: "tok", PlayerID: "bad"}}, WalletActionBet)
+	assert.Error(t, err)
+	_, err = adapter.ToWalletCallback(&WalletRequest{Payload: &BetSolutionsRequest{}}, WalletActionBet)
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdyenProvider wraps Adyen Checkout API operations.
+type AdyenProvider struct {
+	apiKey          string
+	merchantAccount string
+	hmacKey         string // hex-encoded, as shown in the Adyen Customer Area
+	apiBaseURL      string
+	client          *http.Client
+}
+
+const adyenTestBaseURL = "https://checkout-test.adyen.com"
+
+// NewAdyenProvider creates an Adyen provider. An empty baseURL targets the
+// Adyen test environment; live accounts use their account-specific prefix URL.
+func NewAdyenProvider(apiKey, merchantAccount, hmacKey, baseURL string) *AdyenProvider {
+	if baseURL == "" {
+		baseURL = adyenTestBaseURL
+	}
+	return &AdyenProvider{
+		apiKey:          apiKey,
+		merchantAccount: merchantAccount,
+		hmacKey:         hmacKey,
+		apiBaseURL:      strings.TrimRight(baseURL, "/"),
+		client:          &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// AdyenSession represents an Adyen hosted checkout session response.
+type AdyenSession struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// AdyenAmount is an amount in minor units.
+type AdyenAmount struct {
+	Currency string `json:"currency"`
+	Value    int64  `json:"value"`
+}
+
+// AdyenNotificationItem is one NotificationRequestItem of a standard webhook.
+type AdyenNotificationItem struct {
+	AdditionalData      map[string]string `json:"additionalData"`
+	Amount              AdyenAmount       `json:"amount"`
+	EventCode           string            `json:"eventCode"`
+	EventDate           string            `json:"eventDate"`
+	MerchantAccountCode string            `json:"merchantAccountCode"`
+	MerchantReference   string            `json:"merchantReference"`
+	OriginalReference   string            `json:"originalReference"`
+	PspReference        string            `json:"pspReference"`
+	Reason              string            `json:"reason"`
+	Success             string            `json:"success"`
+}
+
+// Succeeded reports whether the notification describes a successful event.
+func (n AdyenNotificationItem) Succeeded() bool {
+	return n.Success == "true"
+}
+
+// CreateCheckoutSession creates an Adyen hosted checkout session for a
+// deposit. reference is our payment ID and comes back as merchantReference
+// on the AUTHORISATION webhook.
+func (a *AdyenProvider) CreateCheckoutSession(ctx context.Context, amountCents int64, currency, reference, playerID, returnURL string) (*AdyenSession, error) {
+	if a.apiKey == "" || a.merchantAccount == "" {
+		return nil, fmt.Errorf("adyen api key not configured")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"merchantAccount":  a.merchantAccount,
+		"amount":           AdyenAmount{Currency: strings.ToUpper(currency), Value: amountCents},
+		"reference":        reference,
+		"shopperReference": playerID,
+		"returnUrl":        returnURL,
+		"mode":             "hosted",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode adyen session: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.apiBaseURL+"/v71/sessions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", a.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", reference)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("adyen api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("adyen error (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var session AdyenSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("decode adyen response: %w", err)
+	}
+	return &session, nil
+}
+
+// VerifyNotification parses an Adyen standard webhook and verifies the HMAC
+// signature of every item. Any unsigned or mis-signed item rejects the whole
+// batch so Adyen retries it.
+func (a *AdyenProvider) VerifyNotification(payload []byte) ([]AdyenNotificationItem, error) {
+	if a.hmacKey == "" {
+		return nil, fmt.Errorf("adyen hmac key not configured")
+	}
+	key, err := hex.DecodeString(a.hmacKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid adyen hmac key: %w", err)
+	}
+
+	var notification struct {
+		NotificationItems []struct {
+			Item AdyenNotificationItem `json:"NotificationRequestItem"`
+		} `json:"notificationItems"`
+	}
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, fmt.Errorf("decode adyen notification: %w", err)
+	}
+	if len(notification.NotificationItems) == 0 {
+		return nil, fmt.Errorf("adyen notification has no items")
+	}
+
+	items := make([]AdyenNotificationItem, 0, len(notification.NotificationItems))
+	for _, wrapper := range notification.NotificationItems {
+		item := wrapper.Item
+		sig := item.AdditionalData["hmacSignature"]
+		if sig == "" {
+			return nil, fmt.Errorf("missing hmac signature for %s", item.PspReference)
+		}
+		expected := AdyenNotificationSignature(key, item)
+		if !hmac.Equal([]byte(expected), []byte(sig)) {
+			return nil, fmt.Errorf("invalid webhook signature for %s", item.PspReference)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// AdyenNotificationSignature computes the base64 HMAC-SHA256 signature Adyen
+// sends in additionalData.hmacSignature.
+func AdyenNotificationSignature(key []byte, item AdyenNotificationItem) string {
+	fields := []string{
+		item.PspReference,
+		item.OriginalReference,
+		item.MerchantAccountCode,
+		item.MerchantReference,
+		strconv.FormatInt(item.Amount.Value, 10),
+		item.Amount.Currency,
+		item.EventCode,
+		item.Success,
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join(fields, ":")))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
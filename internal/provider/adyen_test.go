@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdyenHMACKey = "44782def547aaa06c910c43932b1eb0c71fc68d9d0c057550c48ec2acf6ba056"
+
+func adyenNotification(t *testing.T, items ...AdyenNotificationItem) []byte {
+	t.Helper()
+	wrapped := make([]map[string]AdyenNotificationItem, len(items))
+	for i, item := range items {
+		wrapped[i] = map[string]AdyenNotificationItem{"NotificationRequestItem": item}
+	}
+	payload, err := json.Marshal(map[string]interface{}{"live": "false", "notificationItems": wrapped})
+	require.NoError(t, err)
+	return payload
+}
+
+func signedAdyenItem(item AdyenNotificationItem) AdyenNotificationItem {
+	key, _ := hex.DecodeString(testAdyenHMACKey)
+	item.AdditionalData = map[string]string{"hmacSignature": AdyenNotificationSignature(key, item)}
+	return item
+}
+
+func TestAdyenVerifyNotification_Valid(t *testing.T) {
+	p := NewAdyenProvider("", "", testAdyenHMACKey, "")
+	item := signedAdyenItem(AdyenNotificationItem{
+		Amount:              AdyenAmount{Currency: "EUR", Value: 5000},
+		EventCode:           "AUTHORISATION",
+		MerchantAccountCode: "AttaboyEU",
+		MerchantReference:   "pay_1",
+		PspReference:        "PSP123",
+		Success:             "true",
+	})
+
+	items, err := p.VerifyNotification(adyenNotification(t, item))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "PSP123", items[0].PspReference)
+	assert.True(t, items[0].Succeeded())
+}
+
+func TestAdyenVerifyNotification_TamperedAmount(t *testing.T) {
+	p := NewAdyenProvider("", "", testAdyenHMACKey, "")
+	item := signedAdyenItem(AdyenNotificationItem{
+		Amount:            AdyenAmount{Currency: "EUR", Value: 5000},
+		EventCode:         "AUTHORISATION",
+		MerchantReference: "pay_1",
+		PspReference:      "PSP123",
+		Success:           "true",
+	})
+	item.Amount.Value = 500000
+
+	_, err := p.VerifyNotification(adyenNotification(t, item))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid webhook signature")
+}
+
+func TestAdyenVerifyNotification_MissingSignature(t *testing.T) {
+	p := NewAdyenProvider("", "", testAdyenHMACKey, "")
+	_, err := p.VerifyNotification(adyenNotification(t, AdyenNotificationItem{PspReference: "PSP123"}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing hmac signature")
+}
+
+func TestAdyenCreateCheckoutSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v71/sessions", r.URL.Path)
+		assert.Equal(t, "test_key", r.Header.Get("X-API-Key"))
+
+		var body struct {
+			MerchantAccount string      `json:"merchantAccount"`
+			Amount          AdyenAmount `json:"amount"`
+			Reference       string      `json:"reference"`
+			Mode            string      `json:"mode"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "AttaboyEU", body.MerchantAccount)
+		assert.Equal(t, AdyenAmount{Currency: "EUR", Value: 2500}, body.Amount)
+		assert.Equal(t, "pay_1", body.Reference)
+		assert.Equal(t, "hosted", body.Mode)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"CS123","url":"https://checkoutshopper-test.adyen.com/pay/CS123"}`))
+	}))
+	defer srv.Close()
+
+	p := NewAdyenProvider("test_key", "AttaboyEU", "", srv.URL)
+	session, err := p.CreateCheckoutSession(context.Background(), 2500, "eur", "pay_1", "player_1", "https://example.com/return")
+	require.NoError(t, err)
+	assert.Equal(t, "CS123", session.ID)
+	assert.Contains(t, session.URL, "CS123")
+}
+
+func TestAdyenPayoutProvider_SubmitPayout(t *testing.T) {
+	refuse := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/payout", r.URL.Path)
+		assert.Equal(t, "test_key", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "pay_1", r.Header.Get("Idempotency-Key"))
+
+		var body struct {
+			MerchantAccount  string      `json:"merchantAccount"`
+			Amount           AdyenAmount `json:"amount"`
+			ShopperReference string      `json:"shopperReference"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "AttaboyEU", body.MerchantAccount)
+		assert.Equal(t, AdyenAmount{Currency: "EUR", Value: 5000}, body.Amount)
+		assert.Equal(t, "player_1", body.ShopperReference)
+
+		if refuse {
+			w.Write([]byte(`{"pspReference":"PSP2","resultCode":"Refused","refusalReason":"Blocked Card"}`))
+			return
+		}
+		w.Write([]byte(`{"pspReference":"PSP1","resultCode":"Authorised"}`))
+	}))
+	defer srv.Close()
+
+	p := NewAdyenPayoutProvider("test_key", "AttaboyEU", srv.URL)
+	require.True(t, p.Configured())
+	result, err := p.SubmitPayout(context.Background(), 5000, "eur", "pay_1", "player_1")
+	require.NoError(t, err)
+	assert.Equal(t, "PSP1", result.PSPReference)
+
+	refuse = true
+	_, err = p.SubmitPayout(context.Background(), 5000, "eur", "pay_1", "player_1")
+	assert.ErrorIs(t, err, ErrPayoutRefused)
+	assert.ErrorContains(t, err, "Blocked Card")
+
+	assert.False(t, NewAdyenPayoutProvider("", "", "").Configured())
+}
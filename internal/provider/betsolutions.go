@@ -16,13 +16,27 @@ import (
 
 // BetSolutionsAdapter handles BetSolutions wallet server callbacks.
 type BetSolutionsAdapter struct {
+	name       string
 	hmacSecret string
 	logger     *slog.Logger
 }
 
 // NewBetSolutionsAdapter creates a new BetSolutions adapter.
 func NewBetSolutionsAdapter(hmacSecret string, logger *slog.Logger) *BetSolutionsAdapter {
-	return &BetSolutionsAdapter{hmacSecret: hmacSecret, logger: logger}
+	return &BetSolutionsAdapter{name: "betsolutions", hmacSecret: hmacSecret, logger: logger}
+}
+
+// Name returns the manufacturer ID.
+func (a *BetSolutionsAdapter) Name() string { return a.name }
+
+// Routes serves one path per wallet action.
+func (a *BetSolutionsAdapter) Routes() []WalletRoute {
+	return []WalletRoute{
+		{Path: "/balance", Action: WalletActionBalance},
+		{Path: "/bet", Action: WalletActionBet},
+		{Path: "/win", Action: WalletActionWin},
+		{Path: "/rollback", Action: WalletActionRollback},
+	}
 }
 
 // BetSolutionsRequest is the common request shape from BetSolutions.
@@ -63,18 +77,18 @@ func (a *BetSolutionsAdapter) ComputeSignature(body []byte) string {
 }
 
 // ParseRequest extracts and validates a BetSolutions request from an HTTP request.
-func (a *BetSolutionsAdapter) ParseRequest(r *http.Request) (*BetSolutionsRequest, []byte, error) {
+func (a *BetSolutionsAdapter) ParseRequest(r *http.Request) (*WalletRequest, error) {
 	body, err := readBody(r, 1<<20) // 1MB
 	if err != nil {
-		return nil, nil, fmt.Errorf("read body: %w", err)
+		return nil, fmt.Errorf("read body: %w", err)
 	}
 
 	var req BetSolutionsRequest
 	if err := json.Unmarshal(body, &req); err != nil {
-		return nil, body, fmt.Errorf("unmarshal: %w", err)
+		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
 
-	return &req, body, nil
+	return &WalletRequest{Body: body, Signature: req.Hash, Payload: &req}, nil
 }
 
 // PlayerID parses the player UUID from a BetSolutions request.
@@ -100,6 +114,9 @@ const (
 	WalletActionBet      WalletAction = "bet"
 	WalletActionWin      WalletAction = "win"
 	WalletActionRollback WalletAction = "rollback"
+	// Two-phase settlement: reserve holds the stake, release settles it.
+	WalletActionReserve WalletAction = "reserve"
+	WalletActionRelease WalletAction = "release"
 )
 
 // WalletCallback is the unified interface for game provider wallet operations.
@@ -111,12 +128,24 @@ type WalletCallback struct {
 	TransactionID string
 	RoundID       string
 	GameID        string
+	WinType       domain.CasinoWinType // empty means a normal win
+	ReferenceID   string               // reservation settled by a release
+	// SessionToken is the game session token issued at launch. When set, the
+	// wallet server resolves the player and currency from it; PlayerID may
+	// then be uuid.Nil.
+	SessionToken string
 }
 
 // ToWalletCallback converts a BetSolutions request to a unified WalletCallback.
-func (a *BetSolutionsAdapter) ToWalletCallback(req *BetSolutionsRequest, action WalletAction) (*WalletCallback, error) {
+// The action always comes from the route. A request carrying a session token
+// may omit the player id.
+func (a *BetSolutionsAdapter) ToWalletCallback(wr *WalletRequest, action WalletAction) (*WalletCallback, error) {
+	req, ok := wr.Payload.(*BetSolutionsRequest)
+	if !ok {
+		return nil, domain.ErrValidation("invalid request")
+	}
 	playerID, err := a.PlayerID(req)
-	if err != nil {
+	if err != nil && (req.Token == "" || req.PlayerID != "") {
 		return nil, domain.ErrValidation("invalid player id")
 	}
 
@@ -128,9 +157,30 @@ func (a *BetSolutionsAdapter) ToWalletCallback(req *BetSolutionsRequest, action
 		TransactionID: req.TransactionID,
 		RoundID:       req.RoundID,
 		GameID:        req.GameID,
+		SessionToken:  req.Token,
 	}, nil
 }
 
+// Respond renders a wallet result; the status travels in StatusCode.
+func (a *BetSolutionsAdapter) Respond(w http.ResponseWriter, result WalletResult) {
+	resp := BetSolutionsResponse{StatusCode: http.StatusOK, Balance: result.Balance}
+	if result.Status != WalletStatusOK {
+		resp = BetSolutionsResponse{StatusCode: walletStatusHTTP[result.Status], Error: result.Message}
+	}
+	a.RespondJSON(w, resp)
+}
+
+// walletStatusHTTP maps wallet statuses to HTTP-style status codes for
+// providers that report them in the response body.
+var walletStatusHTTP = map[WalletStatus]int{
+	WalletStatusOK:                http.StatusOK,
+	WalletStatusBadRequest:        http.StatusBadRequest,
+	WalletStatusUnauthorized:      http.StatusUnauthorized,
+	WalletStatusForbidden:         http.StatusForbidden,
+	WalletStatusInsufficientFunds: http.StatusBadRequest,
+	WalletStatusError:             http.StatusInternalServerError,
+}
+
 // RespondJSON writes a BetSolutions JSON response.
 func (a *BetSolutionsAdapter) RespondJSON(w http.ResponseWriter, resp BetSolutionsResponse) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaVerifier validates a client-side CAPTCHA response token.
+type CaptchaVerifier interface {
+	// Name returns the provider name (hcaptcha, turnstile).
+	Name() string
+
+	// Verify checks the token with the provider. Returns an error if the
+	// token is invalid, expired, or the provider could not be reached.
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+const (
+	hcaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+// siteVerifyCaptcha implements the siteverify protocol shared by hCaptcha and
+// Cloudflare Turnstile: form POST of secret/response/remoteip, JSON reply.
+type siteVerifyCaptcha struct {
+	name      string
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+// NewHCaptchaVerifier creates an hCaptcha verifier.
+func NewHCaptchaVerifier(secret string) CaptchaVerifier {
+	return &siteVerifyCaptcha{
+		name:      "hcaptcha",
+		verifyURL: hcaptchaVerifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// NewTurnstileVerifier creates a Cloudflare Turnstile verifier.
+func NewTurnstileVerifier(secret string) CaptchaVerifier {
+	return &siteVerifyCaptcha{
+		name:      "turnstile",
+		verifyURL: turnstileVerifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// NewCaptchaVerifier returns the verifier for the named provider.
+func NewCaptchaVerifier(name, secret string) (CaptchaVerifier, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("captcha secret not configured for %s", name)
+	}
+	switch strings.ToLower(name) {
+	case "hcaptcha":
+		return NewHCaptchaVerifier(secret), nil
+	case "turnstile":
+		return NewTurnstileVerifier(secret), nil
+	default:
+		return nil, fmt.Errorf("unknown captcha provider: %s", name)
+	}
+}
+
+// ParseCaptchaBrands parses a per-brand captcha spec of the form
+// "brand=provider:secret;brand2=provider:secret".
+func ParseCaptchaBrands(spec string) (map[string]CaptchaVerifier, error) {
+	verifiers := make(map[string]CaptchaVerifier)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		brand, rest, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid captcha brand entry %q", entry)
+		}
+		name, secret, ok := strings.Cut(rest, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid captcha brand entry %q", entry)
+		}
+		v, err := NewCaptchaVerifier(strings.TrimSpace(name), strings.TrimSpace(secret))
+		if err != nil {
+			return nil, fmt.Errorf("brand %s: %w", brand, err)
+		}
+		verifiers[strings.ToLower(strings.TrimSpace(brand))] = v
+	}
+	return verifiers, nil
+}
+
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func (c *siteVerifyCaptcha) Name() string { return c.name }
+
+func (c *siteVerifyCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return fmt.Errorf("missing captcha token")
+	}
+
+	form := url.Values{}
+	form.Set("secret", c.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s siteverify: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s siteverify returned %d", c.name, resp.StatusCode)
+	}
+
+	var result siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode %s response: %w", c.name, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%s rejected token: %s", c.name, strings.Join(result.ErrorCodes, ","))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCaptcha(t *testing.T, body string) CaptchaVerifier {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "test-secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "1.2.3.4", r.PostForm.Get("remoteip"))
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	v := NewTurnstileVerifier("test-secret").(*siteVerifyCaptcha)
+	v.verifyURL = srv.URL
+	return v
+}
+
+func TestCaptchaVerify_Success(t *testing.T) {
+	v := newTestCaptcha(t, `{"success":true}`)
+	assert.NoError(t, v.Verify(context.Background(), "token", "1.2.3.4"))
+}
+
+func TestCaptchaVerify_Rejected(t *testing.T) {
+	v := newTestCaptcha(t, `{"success":false,"error-codes":["invalid-input-response"]}`)
+	err := v.Verify(context.Background(), "token", "1.2.3.4")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid-input-response")
+}
+
+func TestCaptchaVerify_MissingToken(t *testing.T) {
+	v := NewHCaptchaVerifier("test-secret")
+	assert.Error(t, v.Verify(context.Background(), "", "1.2.3.4"))
+}
+
+func TestNewCaptchaVerifier(t *testing.T) {
+	v, err := NewCaptchaVerifier("HCaptcha", "s")
+	require.NoError(t, err)
+	assert.Equal(t, "hcaptcha", v.Name())
+
+	_, err = NewCaptchaVerifier("recaptcha", "s")
+	assert.Error(t, err)
+
+	_, err = NewCaptchaVerifier("turnstile", "")
+	assert.Error(t, err)
+}
+
+func TestParseCaptchaBrands(t *testing.T) {
+	brands, err := ParseCaptchaBrands("attaboy=turnstile:abc; Luckyco=hcaptcha:def")
+	require.NoError(t, err)
+	require.Len(t, brands, 2)
+	assert.Equal(t, "turnstile", brands["attaboy"].Name())
+	assert.Equal(t, "hcaptcha", brands["luckyco"].Name())
+
+	_, err = ParseCaptchaBrands("attaboy-turnstile")
+	assert.Error(t, err)
+}
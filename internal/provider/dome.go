@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -13,7 +14,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/infra"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -193,6 +197,21 @@ type domeOutcome struct {
 
 // ── DomeConnector ──
 
+// Feed names of the connector's jobs, as rows of dome_feed_state.
+const (
+	DomeFeedMarketSync = "polymarket-sync"
+	DomeFeedPrices     = "price-updater"
+	DomeFeedSettlement = "settlement-checker"
+)
+
+var (
+	// ErrUnknownFeed is returned by Resync for a feed the connector has no
+	// job for.
+	ErrUnknownFeed = errors.New("no job for feed")
+	// ErrFeedBusy is returned by Resync while the feed's job is running.
+	ErrFeedBusy = errors.New("feed job already running")
+)
+
 // DomeConnector manages prediction market syncing from Dome (Polymarket, Kalshi).
 type DomeConnector struct {
 	pool    *pgxpool.Pool
@@ -200,6 +219,12 @@ type DomeConnector struct {
 	logger  *slog.Logger
 	client  *http.Client
 	limiter *rateLimiter
+
+	// ctx is the context the jobs were started with; manual resyncs run
+	// under it so they stop with the scheduled ones.
+	ctx     context.Context
+	mu      sync.Mutex
+	running map[string]bool
 }
 
 // NewDomeConnector creates a new Dome prediction feed connector.
@@ -218,71 +243,119 @@ func NewDomeConnector(pool *pgxpool.Pool, baseURL, apiKey string, logger *slog.L
 		logger:  logger,
 		client:  &http.Client{Timeout: 30 * time.Second},
 		limiter: newRateLimiter(),
+		ctx:     context.Background(),
+		running: map[string]bool{},
 	}
 }
 
-// StartMarketSync begins all three background jobs: market sync, price updater, settlement checker.
-func (c *DomeConnector) StartMarketSync(ctx context.Context) {
+// StartMarketSync begins all three background jobs: market sync, price
+// updater, settlement checker. Each backs off while it fails, re-validating
+// the pool in case the database failed over, and reports its runs to health.
+func (c *DomeConnector) StartMarketSync(ctx context.Context, health *infra.WorkerHealth) {
 	c.logger.Info("dome connector starting", "platforms", c.cfg.Platforms, "sync_interval", c.cfg.SyncInterval)
+	c.mu.Lock()
+	c.ctx = ctx
+	c.mu.Unlock()
 
-	// Run initial sync immediately
-	go func() {
-		if err := c.syncMarkets(ctx); err != nil {
-			c.logger.Error("dome initial market sync error", "error", err)
+	worker := func(feed string, interval time.Duration) infra.Worker {
+		return infra.Worker{
+			Name: "dome." + feed, Interval: interval, Timeout: 10 * time.Minute,
+			Backoff: infra.Backoff{Min: 5 * time.Second, Max: interval}, Pool: c.pool, Health: health, Logger: c.logger,
 		}
-	}()
+	}
 
-	// Market sync loop
+	// Market sync loop, starting with an initial sync
 	go func() {
-		ticker := time.NewTicker(c.cfg.SyncInterval)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				c.logger.Info("dome market sync stopped")
-				c.updateFeedState(context.Background(), "polymarket-sync", "idle", "")
-				return
-			case <-ticker.C:
-				if err := c.syncMarkets(ctx); err != nil {
-					c.logger.Error("dome market sync error", "error", err)
-				}
-			}
-		}
+		worker(DomeFeedMarketSync, c.cfg.SyncInterval).Run(ctx, func(ctx context.Context) error {
+			_, err := c.runJob(ctx, DomeFeedMarketSync)
+			return err
+		})
+		c.logger.Info("dome market sync stopped")
+		c.updateFeedState(context.Background(), "polymarket-sync", "idle", "")
 	}()
 
 	// Price updater loop
 	go func() {
-		ticker := time.NewTicker(c.cfg.PriceInterval)
-		defer ticker.Stop()
 		cycleCount := 0
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				cycleCount++
-				if err := c.updatePrices(ctx, cycleCount); err != nil {
-					c.logger.Error("dome price update error", "error", err)
-				}
-			}
-		}
+		worker(DomeFeedPrices, c.cfg.PriceInterval).Run(ctx, func(ctx context.Context) error {
+			cycleCount++
+			_, err := c.runGuarded(DomeFeedPrices, func() error { return c.updatePrices(ctx, cycleCount) })
+			return err
+		})
 	}()
 
 	// Settlement checker loop
 	go func() {
-		ticker := time.NewTicker(c.cfg.SettleInterval)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				if err := c.checkSettlements(ctx); err != nil {
-					c.logger.Error("dome settlement check error", "error", err)
-				}
-			}
+		worker(DomeFeedSettlement, c.cfg.SettleInterval).Run(ctx, func(ctx context.Context) error {
+			_, err := c.runJob(ctx, DomeFeedSettlement)
+			return err
+		})
+	}()
+}
+
+// ── Manual Resync ──
+
+// Resync starts feed's job now, out of band, and returns without waiting
+// for it. A price resync refreshes every open market, not just staked ones.
+func (c *DomeConnector) Resync(feed string) error {
+	if c.job(feed) == nil {
+		return ErrUnknownFeed
+	}
+	c.mu.Lock()
+	ctx := c.ctx
+	busy := c.running[feed]
+	c.mu.Unlock()
+	if busy {
+		return ErrFeedBusy
+	}
+
+	go func() {
+		ran, err := c.runJob(ctx, feed)
+		if err != nil {
+			c.logger.Error("dome manual resync error", "feed", feed, "error", err)
+		} else if ran {
+			c.logger.Info("dome manual resync complete", "feed", feed)
 		}
 	}()
+	return nil
+}
+
+// job returns the job behind feed, or nil. The price job run here is a
+// full cycle.
+func (c *DomeConnector) job(feed string) func(ctx context.Context) error {
+	switch feed {
+	case DomeFeedMarketSync:
+		return c.syncMarkets
+	case DomeFeedPrices:
+		return func(ctx context.Context) error { return c.updatePrices(ctx, 0) }
+	case DomeFeedSettlement:
+		return c.checkSettlements
+	}
+	return nil
+}
+
+// runJob runs feed's job unless it is already running.
+func (c *DomeConnector) runJob(ctx context.Context, feed string) (bool, error) {
+	job := c.job(feed)
+	return c.runGuarded(feed, func() error { return job(ctx) })
+}
+
+// runGuarded runs fn unless feed's job is already running, so a manual
+// resync never overlaps a scheduled run. It reports whether fn ran.
+func (c *DomeConnector) runGuarded(feed string, fn func() error) (bool, error) {
+	c.mu.Lock()
+	if c.running[feed] {
+		c.mu.Unlock()
+		return false, nil
+	}
+	c.running[feed] = true
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.running, feed)
+		c.mu.Unlock()
+	}()
+	return true, fn()
 }
 
 // ── Rate-limited HTTP helper ──
@@ -348,18 +421,18 @@ func (c *DomeConnector) syncMarkets(ctx context.Context) error {
 			return fmt.Errorf("decode polymarket markets: %w", err)
 		}
 
+		// Volume filter
+		var page []domePolymarketMarket
 		for _, m := range resp.Markets {
-			// Volume filter
-			if m.VolumeTotal < float64(c.cfg.MinVolume) {
-				continue
+			if m.VolumeTotal >= float64(c.cfg.MinVolume) {
+				page = append(page, m)
 			}
-
-			if err := c.upsertPolymarketMarket(ctx, m); err != nil {
-				c.logger.Error("upsert polymarket market", "slug", m.MarketSlug, "error", err)
-				continue
-			}
-			synced++
 		}
+		n, err := c.upsertPolymarketMarkets(ctx, page)
+		if err != nil {
+			c.logger.Error("upsert polymarket markets", "offset", offset, "error", err)
+		}
+		synced += n
 
 		hasMore = resp.Pagination.HasMore
 		offset += limit
@@ -370,7 +443,36 @@ func (c *DomeConnector) syncMarkets(ctx context.Context) error {
 	return nil
 }
 
-func (c *DomeConnector) upsertPolymarketMarket(ctx context.Context, m domePolymarketMarket) error {
+// upsertPolymarketMarkets writes a page of markets in one round trip and
+// returns how many were upserted. The batch runs as one implicit
+// transaction, so if it fails the markets are upserted one at a time and
+// only those that still fail are logged and skipped.
+func (c *DomeConnector) upsertPolymarketMarkets(ctx context.Context, markets []domePolymarketMarket) (int, error) {
+	if len(markets) == 0 {
+		return 0, nil
+	}
+	batch := &pgx.Batch{}
+	for _, m := range markets {
+		queuePolymarketUpsert(batch, m)
+	}
+	err := c.pool.SendBatch(ctx, batch).Close()
+	if err == nil {
+		return len(markets), nil
+	}
+	c.logger.Warn("upsert polymarket markets batch, retrying one by one", "error", err)
+	synced := len(markets)
+	err = execEach(ctx, c.pool, batch, func(i int, err error) {
+		c.logger.Error("upsert polymarket market", "slug", markets[i].MarketSlug, "error", err)
+		synced--
+	})
+	if err != nil {
+		return 0, err
+	}
+	return synced, nil
+}
+
+// queuePolymarketUpsert queues the upsert of one Polymarket market.
+func queuePolymarketUpsert(batch *pgx.Batch, m domePolymarketMarket) {
 	status := mapDomeStatus(m.Status)
 	category := mapTagsToCategory(m.Tags)
 
@@ -411,16 +513,16 @@ func (c *DomeConnector) upsertPolymarketMarket(ctx context.Context, m domePolyma
 	}
 
 	// Upsert: on conflict update (but preserve settled/voided status and existing outcome IDs)
-	_, err := c.pool.Exec(ctx, `
+	batch.Queue(`
 		INSERT INTO prediction_markets (
 			title, description, category, status, close_at, outcomes,
 			dome_platform, dome_market_slug, dome_condition_id, dome_event_slug,
-			dome_metadata, dome_auto_settle, tags,
+			dome_metadata, dome_auto_settle, tags, volume_minor,
 			created_by
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
 			'polymarket', $7, $8, $9,
-			$10, true, $11,
+			$10, true, $11, $12,
 			(SELECT id FROM admin_users LIMIT 1)
 		)
 		ON CONFLICT (dome_platform, dome_market_slug) WHERE dome_market_slug IS NOT NULL
@@ -436,11 +538,11 @@ func (c *DomeConnector) upsertPolymarketMarket(ctx context.Context, m domePolyma
 			dome_event_slug = EXCLUDED.dome_event_slug,
 			dome_metadata = EXCLUDED.dome_metadata,
 			tags = EXCLUDED.tags,
+			volume_minor = EXCLUDED.volume_minor,
 			updated_at = now()`,
 		m.Title, m.Description, category, status, closeAt, outcomesJSON,
 		m.MarketSlug, m.ConditionID, m.EventSlug,
-		metadataJSON, tagsJSON)
-	return err
+		metadataJSON, tagsJSON, int64(math.Round(m.VolumeTotal*100)))
 }
 
 // ── Price Updater ──
@@ -646,19 +748,17 @@ func (c *DomeConnector) checkSettlements(ctx context.Context) error {
 		digestInput := fmt.Sprintf("dome:%s:%s:%s:%d", market.Platform, market.Slug, winningSide.Label, time.Now().UnixMilli())
 		digest := fmt.Sprintf("%x", sha256.Sum256([]byte(digestInput)))
 
-		attestation, _ := json.Marshal(map[string]string{
-			"provider":      "dome",
-			"attestationId": fmt.Sprintf("dome-%s-%s", market.Platform, uuid.New().String()[:8]),
-			"digest":        digest,
-			"issuedAt":      time.Now().UTC().Format(time.RFC3339),
+		attestation, _ := json.Marshal(domain.Attestation{
+			Provider:      "dome",
+			AttestationID: fmt.Sprintf("dome-%s-%s", market.Platform, uuid.New().String()[:8]),
+			Digest:        digest,
+			IssuedAt:      time.Now().UTC(),
 		})
 
-		winUUID, _ := uuid.Parse(winningOutcomeID)
-
 		_, err := c.pool.Exec(ctx, `
 			UPDATE prediction_markets
 			SET status = 'settled', winning_outcome_id = $2, attestation = $3, updated_at = now()
-			WHERE id = $1`, market.ID, winUUID, attestation)
+			WHERE id = $1`, market.ID, winningOutcomeID, attestation)
 		if err != nil {
 			c.logger.Error("dome settle market", "market_id", market.ID, "error", err)
 			continue
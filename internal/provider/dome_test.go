@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomeResync_RejectsUnknownAndBusyFeeds(t *testing.T) {
+	c := NewDomeConnector(nil, "http://dome.invalid", "key", slog.Default())
+
+	assert.ErrorIs(t, c.Resync("kalshi-sync"), ErrUnknownFeed)
+
+	ran, err := c.runGuarded(DomeFeedSettlement, func() error {
+		assert.ErrorIs(t, c.Resync(DomeFeedSettlement), ErrFeedBusy)
+
+		nested, err := c.runGuarded(DomeFeedSettlement, func() error {
+			t.Fatal("overlapping run of the same feed")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, nested)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	// Released once the run finishes.
+	ran, err = c.runGuarded(DomeFeedSettlement, func() error { return nil })
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+)
+
+// EvolutionAdapter handles Evolution Gaming One Wallet callbacks for live
+// dealer tables.
+type EvolutionAdapter struct {
+	name      string
+	authToken string
+	logger    *slog.Logger
+}
+
+// NewEvolutionAdapter creates a new Evolution One Wallet adapter.
+func NewEvolutionAdapter(authToken string, logger *slog.Logger) *EvolutionAdapter {
+	return &EvolutionAdapter{name: "evolution", authToken: authToken, logger: logger}
+}
+
+// EvolutionRequest is the common request shape for One Wallet calls.
+type EvolutionRequest struct {
+	SID         string                `json:"sid"`
+	UserID      string                `json:"userId"`
+	UUID        string                `json:"uuid"`
+	Currency    string                `json:"currency"`
+	Game        *EvolutionGame        `json:"game,omitempty"`
+	Transaction *EvolutionTransaction `json:"transaction,omitempty"`
+}
+
+// EvolutionGame identifies the table round a transaction belongs to.
+type EvolutionGame struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// EvolutionTransaction carries a debit, credit or cancel. RefID links a
+// bet to its settlement and cancellation.
+type EvolutionTransaction struct {
+	ID     string      `json:"id"`
+	RefID  string      `json:"refId"`
+	Amount json.Number `json:"amount"` // decimal in currency units
+}
+
+// EvolutionResponse is the common response shape for One Wallet calls.
+type EvolutionResponse struct {
+	Status  string       `json:"status"`
+	Balance *json.Number `json:"balance,omitempty"`
+	Bonus   *json.Number `json:"bonus,omitempty"`
+	SID     string       `json:"sid,omitempty"`
+	UUID    string       `json:"uuid,omitempty"`
+}
+
+// One Wallet status codes.
+const (
+	EvolutionStatusOK                = "OK"
+	EvolutionStatusInvalidToken      = "INVALID_TOKEN_ID"
+	EvolutionStatusInvalidParameter  = "INVALID_PARAMETER"
+	EvolutionStatusAccountLocked     = "ACCOUNT_LOCKED"
+	EvolutionStatusInsufficientFunds = "INSUFFICIENT_FUNDS"
+	EvolutionStatusUnknownError      = "UNKNOWN_ERROR"
+)
+
+var evolutionStatus = map[WalletStatus]string{
+	WalletStatusOK:                EvolutionStatusOK,
+	WalletStatusBadRequest:        EvolutionStatusInvalidParameter,
+	WalletStatusUnauthorized:      EvolutionStatusInvalidToken,
+	WalletStatusForbidden:         EvolutionStatusAccountLocked,
+	WalletStatusInsufficientFunds: EvolutionStatusInsufficientFunds,
+	WalletStatusError:             EvolutionStatusUnknownError,
+}
+
+// Name returns the manufacturer ID.
+func (a *EvolutionAdapter) Name() string { return a.name }
+
+// Routes serves the One Wallet endpoints. check validates the session and,
+// like balance, only reads the wallet.
+func (a *EvolutionAdapter) Routes() []WalletRoute {
+	return []WalletRoute{
+		{Path: "/check", Action: WalletActionBalance},
+		{Path: "/balance", Action: WalletActionBalance},
+		{Path: "/debit", Action: WalletActionBet},
+		{Path: "/credit", Action: WalletActionWin},
+		{Path: "/cancel", Action: WalletActionRollback},
+	}
+}
+
+// ComputeSignature returns the auth token expected for a body: the hex
+// SHA-256 of the body followed by the shared secret.
+func (a *EvolutionAdapter) ComputeSignature(body []byte) string {
+	sum := sha256.Sum256(append(append([]byte{}, body...), a.authToken...))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifySignature validates the authToken query parameter against the body.
+func (a *EvolutionAdapter) VerifySignature(body []byte, token string) bool {
+	expected := a.ComputeSignature(body)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
+
+// ParseRequest reads a One Wallet call. The auth token travels in the
+// authToken query parameter rather than the body.
+func (a *EvolutionAdapter) ParseRequest(r *http.Request) (*WalletRequest, error) {
+	body, err := readBody(r, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var req EvolutionRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &WalletRequest{Body: body, Signature: r.URL.Query().Get("authToken"), Payload: &req}, nil
+}
+
+// ToWalletCallback converts a One Wallet call to a unified WalletCallback.
+// Debits and cancels are keyed by refId so a cancel finds the bet it voids;
+// credits are keyed by their own transaction id.
+func (a *EvolutionAdapter) ToWalletCallback(wr *WalletRequest, action WalletAction) (*WalletCallback, error) {
+	req, ok := wr.Payload.(*EvolutionRequest)
+	if !ok {
+		return nil, domain.ErrValidation("invalid request")
+	}
+	playerID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, domain.ErrValidation("invalid user id")
+	}
+
+	cb := &WalletCallback{Action: action, PlayerID: playerID, Currency: req.Currency}
+	if req.Game != nil {
+		cb.RoundID = req.Game.ID
+		cb.GameID = req.Game.Type
+	}
+	if action == WalletActionBalance {
+		return cb, nil
+	}
+
+	if req.Transaction == nil {
+		return nil, domain.ErrValidation("transaction is required")
+	}
+	amount, err := parseDecimalToCents(req.Transaction.Amount.String())
+	if err != nil || amount < 0 {
+		return nil, domain.ErrValidation("invalid amount")
+	}
+	cb.Amount = amount
+
+	cb.TransactionID = req.Transaction.ID
+	if action != WalletActionWin && req.Transaction.RefID != "" {
+		cb.TransactionID = req.Transaction.RefID
+	}
+	if cb.TransactionID == "" {
+		return nil, domain.ErrValidation("transaction id is required")
+	}
+	return cb, nil
+}
+
+// Respond renders a wallet result, echoing the call's uuid and session id.
+func (a *EvolutionAdapter) Respond(w http.ResponseWriter, result WalletResult) {
+	resp := EvolutionResponse{Status: evolutionStatus[result.Status]}
+	if result.Request != nil {
+		if req, ok := result.Request.Payload.(*EvolutionRequest); ok {
+			resp.UUID = req.UUID
+			resp.SID = req.SID
+		}
+	}
+	if result.Status == WalletStatusOK {
+		balance, bonus := json.Number(FormatCents(result.Balance)), json.Number(FormatCents(result.BonusBalance))
+		resp.Balance, resp.Bonus = &balance, &bonus
+	}
+	a.RespondJSON(w, resp)
+}
+
+// RespondJSON writes a One Wallet JSON response.
+func (a *EvolutionAdapter) RespondJSON(w http.ResponseWriter, resp EvolutionResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // errors travel in status
+	json.NewEncoder(w).Encode(resp)
+}
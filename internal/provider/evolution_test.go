@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const evolutionPlayer = "6f1c1f4e-8c1e-4c43-9d0a-1b2c3d4e5f60"
+
+func TestEvolutionAdapter_VerifySignature(t *testing.T) {
+	adapter := NewEvolutionAdapter("test-secret", nil)
+	body := []byte(`{"userId":"abc","uuid":"u1"}`)
+	token := adapter.ComputeSignature(body)
+
+	assert.Len(t, token, 64)
+	assert.True(t, adapter.VerifySignature(body, token))
+	assert.False(t, adapter.VerifySignature([]byte(`{"userId":"abd","uuid":"u1"}`), token))
+	assert.False(t, NewEvolutionAdapter("other", nil).VerifySignature(body, token))
+}
+
+func parseEvolution(t *testing.T, path, body string) *WalletRequest {
+	t.Helper()
+	r := httptest.NewRequest("POST", path+"?authToken=tok", strings.NewReader(body))
+	req, err := NewEvolutionAdapter("s", nil).ParseRequest(r)
+	require.NoError(t, err)
+	return req
+}
+
+func TestEvolutionAdapter_ToWalletCallback(t *testing.T) {
+	adapter := NewEvolutionAdapter("s", nil)
+
+	t.Run("debit is keyed by refId", func(t *testing.T) {
+		req := parseEvolution(t, "/evolution/debit", `{"userId":"`+evolutionPlayer+`","currency":"EUR",
+			"game":{"id":"g1","type":"blackjack"},"transaction":{"id":"d1","refId":"ref1","amount":12.5}}`)
+		assert.Equal(t, "tok", req.Signature)
+
+		cb, err := adapter.ToWalletCallback(req, WalletActionBet)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1250), cb.Amount)
+		assert.Equal(t, "ref1", cb.TransactionID)
+		assert.Equal(t, "g1", cb.RoundID)
+	})
+
+	t.Run("credit is keyed by its own id", func(t *testing.T) {
+		req := parseEvolution(t, "/evolution/credit", `{"userId":"`+evolutionPlayer+`",
+			"transaction":{"id":"c1","refId":"ref1","amount":30}}`)
+		cb, err := adapter.ToWalletCallback(req, WalletActionWin)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3000), cb.Amount)
+		assert.Equal(t, "c1", cb.TransactionID)
+	})
+
+	t.Run("check needs no transaction", func(t *testing.T) {
+		req := parseEvolution(t, "/evolution/check", `{"userId":"`+evolutionPlayer+`","sid":"s1"}`)
+		cb, err := adapter.ToWalletCallback(req, WalletActionBalance)
+		require.NoError(t, err)
+		assert.Equal(t, WalletActionBalance, cb.Action)
+	})
+
+	t.Run("debit without transaction is rejected", func(t *testing.T) {
+		req := parseEvolution(t, "/evolution/debit", `{"userId":"`+evolutionPlayer+`"}`)
+		_, err := adapter.ToWalletCallback(req, WalletActionBet)
+		assert.Error(t, err)
+	})
+}
+
+func TestEvolutionAdapter_Respond(t *testing.T) {
+	adapter := NewEvolutionAdapter("s", nil)
+	req := parseEvolution(t, "/evolution/check", `{"userId":"`+evolutionPlayer+`","sid":"s1","uuid":"u1"}`)
+
+	w := httptest.NewRecorder()
+	adapter.Respond(w, WalletResult{Request: req, Status: WalletStatusOK, Balance: 1050})
+	assert.JSONEq(t, `{"status":"OK","balance":10.50,"bonus":0.00,"sid":"s1","uuid":"u1"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	adapter.Respond(w, WalletResult{Request: req, Status: WalletStatusInsufficientFunds})
+	assert.JSONEq(t, `{"status":"INSUFFICIENT_FUNDS","sid":"s1","uuid":"u1"}`, w.Body.String())
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FreeRoundsProvider awards free rounds on a game server and plays them.
+// Free rounds are staked by the provider, so playing one never debits the
+// player; only the win comes back to the wallet.
+type FreeRoundsProvider interface {
+	// Name returns the provider name recorded with each grant.
+	Name() string
+
+	// GrantFreeRounds awards the rounds and returns the provider's grant id.
+	// Reference is our grant id; providers use it to deduplicate retries.
+	GrantFreeRounds(ctx context.Context, grant FreeRoundsGrant) (string, error)
+
+	// PlayFreeRound plays one round of a grant on gameID.
+	PlayFreeRound(ctx context.Context, providerGrantID, gameID string, bet int64) (*FreeRoundResult, error)
+}
+
+// FreeRoundsGrant describes free rounds to award.
+type FreeRoundsGrant struct {
+	Reference string    `json:"reference"`
+	PlayerID  uuid.UUID `json:"player_id"`
+	GameIDs   []string  `json:"game_ids"`
+	Rounds    int       `json:"rounds"`
+	Bet       int64     `json:"bet"` // stake per round, minor units
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FreeRoundResult is the outcome of one free round.
+type FreeRoundResult struct {
+	RoundID string
+	Win     int64 // minor units
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+)
+
+// NetEntAdapter handles NetEnt seamless wallet callbacks. Red Tiger games run
+// on the same wallet API and are mounted as a second instance of this adapter.
+// Stakes are reserved and later released rather than debited outright.
+type NetEntAdapter struct {
+	name   string
+	secret string // caller password and session token signing key
+	now    func() time.Time
+	logger *slog.Logger
+}
+
+// NewNetEntAdapter creates a new NetEnt seamless wallet adapter.
+func NewNetEntAdapter(secret string, logger *slog.Logger) *NetEntAdapter {
+	return &NetEntAdapter{name: "netent", secret: secret, now: time.Now, logger: logger}
+}
+
+// NetEntRequest is the common request shape for seamless wallet calls.
+type NetEntRequest struct {
+	CallerID       string      `json:"callerId"`
+	CallerPassword string      `json:"callerPassword"`
+	SessionID      string      `json:"sessionId"`
+	Currency       string      `json:"currency"`
+	GameID         string      `json:"gameId,omitempty"`
+	GameRoundRef   string      `json:"gameRoundRef,omitempty"`
+	TransactionRef string      `json:"transactionRef,omitempty"`
+	ReservationRef string      `json:"reservationRef,omitempty"` // release and rollback
+	Amount         json.Number `json:"amount,omitempty"`         // decimal in currency units
+}
+
+// NetEntResponse is the common response shape for seamless wallet calls.
+type NetEntResponse struct {
+	ErrorCode    string       `json:"errorCode,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	Balance      *json.Number `json:"balance,omitempty"`
+	BonusBalance *json.Number `json:"bonusBalance,omitempty"`
+	Currency     string       `json:"currency,omitempty"`
+	WalletRef    string       `json:"walletRef,omitempty"`
+}
+
+// Seamless wallet error codes.
+const (
+	NetEntErrInvalidSession    = "INVALID_SESSION"
+	NetEntErrInvalidRequest    = "INVALID_REQUEST"
+	NetEntErrPlayerBlocked     = "PLAYER_BLOCKED"
+	NetEntErrInsufficientFunds = "INSUFFICIENT_FUNDS"
+	NetEntErrInternal          = "INTERNAL_ERROR"
+)
+
+var netEntErrorCode = map[WalletStatus]string{
+	WalletStatusBadRequest:        NetEntErrInvalidRequest,
+	WalletStatusUnauthorized:      NetEntErrInvalidSession,
+	WalletStatusForbidden:         NetEntErrPlayerBlocked,
+	WalletStatusInsufficientFunds: NetEntErrInsufficientFunds,
+	WalletStatusError:             NetEntErrInternal,
+}
+
+// Name returns the manufacturer ID.
+func (a *NetEntAdapter) Name() string { return a.name }
+
+// Routes serves the seamless wallet endpoints.
+func (a *NetEntAdapter) Routes() []WalletRoute {
+	return []WalletRoute{
+		{Path: "/balance", Action: WalletActionBalance},
+		{Path: "/reserve", Action: WalletActionReserve},
+		{Path: "/release", Action: WalletActionRelease},
+		{Path: "/rollback", Action: WalletActionRollback},
+	}
+}
+
+// IssueSessionToken signs a game session for a player. The token is handed to
+// the game client at launch and presented by NetEnt on every wallet call.
+func (a *NetEntAdapter) IssueSessionToken(playerID uuid.UUID, ttl time.Duration) string {
+	payload := playerID.String() + "." + strconv.FormatInt(a.now().Add(ttl).Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + a.signSession(payload)
+}
+
+// ValidateSessionToken checks a session token's signature and returns the
+// player it was issued to. Expired tokens return the player and an error so
+// callers can still settle rounds that outlive the session.
+func (a *NetEntAdapter) ValidateSessionToken(token string) (uuid.UUID, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, domain.ErrUnauthorized("invalid session")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return uuid.Nil, domain.ErrUnauthorized("invalid session")
+	}
+	payload := string(raw)
+	if subtle.ConstantTimeCompare([]byte(a.signSession(payload)), []byte(sig)) != 1 {
+		return uuid.Nil, domain.ErrUnauthorized("invalid session")
+	}
+
+	player, expiry, ok := strings.Cut(payload, ".")
+	if !ok {
+		return uuid.Nil, domain.ErrUnauthorized("invalid session")
+	}
+	playerID, err := uuid.Parse(player)
+	if err != nil {
+		return uuid.Nil, domain.ErrUnauthorized("invalid session")
+	}
+	exp, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return uuid.Nil, domain.ErrUnauthorized("invalid session")
+	}
+	if a.now().Unix() > exp {
+		return playerID, domain.ErrUnauthorized("session expired")
+	}
+	return playerID, nil
+}
+
+func (a *NetEntAdapter) signSession(payload string) string {
+	mac := hmac.New(sha256.New, []byte(a.secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the caller password sent in the body. NetEnt does
+// not sign requests; the session token authenticates the player instead.
+func (a *NetEntAdapter) VerifySignature(_ []byte, password string) bool {
+	return a.secret != "" && subtle.ConstantTimeCompare([]byte(a.secret), []byte(password)) == 1
+}
+
+// ParseRequest reads a seamless wallet call.
+func (a *NetEntAdapter) ParseRequest(r *http.Request) (*WalletRequest, error) {
+	body, err := readBody(r, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var req NetEntRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &WalletRequest{Body: body, Signature: req.CallerPassword, Payload: &req}, nil
+}
+
+// ToWalletCallback converts a seamless wallet call to a unified WalletCallback.
+// The player comes from the session token, never from the body. Balance and
+// reserve need a live session; release and rollback accept an expired one so
+// rounds can settle after the player has left the game.
+func (a *NetEntAdapter) ToWalletCallback(wr *WalletRequest, action WalletAction) (*WalletCallback, error) {
+	req, ok := wr.Payload.(*NetEntRequest)
+	if !ok {
+		return nil, domain.ErrValidation("invalid request")
+	}
+	playerID, err := a.ValidateSessionToken(req.SessionID)
+	if err != nil && (playerID == uuid.Nil || action == WalletActionBalance || action == WalletActionReserve) {
+		return nil, err
+	}
+
+	cb := &WalletCallback{
+		Action:   action,
+		PlayerID: playerID,
+		Currency: req.Currency,
+		RoundID:  req.GameRoundRef,
+		GameID:   req.GameID,
+	}
+	if action == WalletActionBalance {
+		return cb, nil
+	}
+
+	if req.Amount != "" {
+		amount, err := parseDecimalToCents(req.Amount.String())
+		if err != nil || amount < 0 {
+			return nil, domain.ErrValidation("invalid amount")
+		}
+		cb.Amount = amount
+	}
+
+	switch action {
+	case WalletActionReserve:
+		cb.TransactionID = req.TransactionRef
+	case WalletActionRelease:
+		cb.TransactionID = req.TransactionRef
+		cb.ReferenceID = req.ReservationRef
+		if cb.ReferenceID == "" {
+			return nil, domain.ErrValidation("reservationRef is required")
+		}
+	case WalletActionRollback:
+		cb.TransactionID = req.ReservationRef
+	}
+	if cb.TransactionID == "" {
+		return nil, domain.ErrValidation("transaction reference is required")
+	}
+	return cb, nil
+}
+
+// Respond renders a wallet result. NetEnt reports errors in the body with
+// HTTP 200.
+func (a *NetEntAdapter) Respond(w http.ResponseWriter, result WalletResult) {
+	if result.Status != WalletStatusOK {
+		a.RespondJSON(w, NetEntResponse{ErrorCode: netEntErrorCode[result.Status], Message: result.Message})
+		return
+	}
+
+	balance, bonus := json.Number(FormatCents(result.Balance)), json.Number(FormatCents(result.BonusBalance))
+	resp := NetEntResponse{Balance: &balance, BonusBalance: &bonus, Currency: result.Currency}
+	if result.Request != nil {
+		if req, ok := result.Request.Payload.(*NetEntRequest); ok {
+			if resp.Currency == "" {
+				resp.Currency = req.Currency
+			}
+			if req.TransactionRef != "" {
+				resp.WalletRef = a.name + "_" + req.TransactionRef
+			}
+		}
+	}
+	a.RespondJSON(w, resp)
+}
+
+// RespondJSON writes a seamless wallet JSON response.
+func (a *NetEntAdapter) RespondJSON(w http.ResponseWriter, resp NetEntResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetEntAdapter_SessionToken(t *testing.T) {
+	adapter := NewNetEntAdapter("test-secret", nil)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	adapter.now = func() time.Time { return now }
+	playerID := uuid.New()
+
+	token := adapter.IssueSessionToken(playerID, time.Hour)
+	got, err := adapter.ValidateSessionToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, playerID, got)
+
+	_, err = NewNetEntAdapter("other", nil).ValidateSessionToken(token)
+	assert.Error(t, err)
+	_, err = adapter.ValidateSessionToken(token + "0")
+	assert.Error(t, err)
+
+	// Expired tokens still identify the player
+	now = now.Add(2 * time.Hour)
+	got, err = adapter.ValidateSessionToken(token)
+	assert.Error(t, err)
+	assert.Equal(t, playerID, got)
+}
+
+func TestNetEntAdapter_VerifySignature(t *testing.T) {
+	assert.True(t, NewNetEntAdapter("pw", nil).VerifySignature(nil, "pw"))
+	assert.False(t, NewNetEntAdapter("pw", nil).VerifySignature(nil, "px"))
+	assert.False(t, NewNetEntAdapter("", nil).VerifySignature(nil, ""))
+}
+
+func parseNetEnt(t *testing.T, adapter *NetEntAdapter, body string) *WalletRequest {
+	t.Helper()
+	r := httptest.NewRequest("POST", "/netent/reserve", strings.NewReader(body))
+	req, err := adapter.ParseRequest(r)
+	require.NoError(t, err)
+	return req
+}
+
+func TestNetEntAdapter_ToWalletCallback(t *testing.T) {
+	adapter := NewNetEntAdapter("s", nil)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	adapter.now = func() time.Time { return now }
+	playerID := uuid.New()
+	session := adapter.IssueSessionToken(playerID, time.Hour)
+
+	t.Run("reserve takes the player from the session", func(t *testing.T) {
+		req := parseNetEnt(t, adapter, `{"callerPassword":"s","sessionId":"`+session+`","currency":"EUR",
+			"gameRoundRef":"r1","transactionRef":"t1","amount":2.5}`)
+		assert.Equal(t, "s", req.Signature)
+
+		cb, err := adapter.ToWalletCallback(req, WalletActionReserve)
+		require.NoError(t, err)
+		assert.Equal(t, playerID, cb.PlayerID)
+		assert.Equal(t, int64(250), cb.Amount)
+		assert.Equal(t, "t1", cb.TransactionID)
+	})
+
+	t.Run("release references the reservation", func(t *testing.T) {
+		req := parseNetEnt(t, adapter, `{"sessionId":"`+session+`","transactionRef":"t2","reservationRef":"t1","amount":0}`)
+		cb, err := adapter.ToWalletCallback(req, WalletActionRelease)
+		require.NoError(t, err)
+		assert.Equal(t, "t2", cb.TransactionID)
+		assert.Equal(t, "t1", cb.ReferenceID)
+		assert.Equal(t, int64(0), cb.Amount)
+	})
+
+	t.Run("rollback is keyed by the reservation", func(t *testing.T) {
+		req := parseNetEnt(t, adapter, `{"sessionId":"`+session+`","transactionRef":"t3","reservationRef":"t1"}`)
+		cb, err := adapter.ToWalletCallback(req, WalletActionRollback)
+		require.NoError(t, err)
+		assert.Equal(t, "t1", cb.TransactionID)
+	})
+
+	t.Run("expired session can release but not reserve", func(t *testing.T) {
+		expired := adapter.IssueSessionToken(playerID, -time.Minute)
+		req := parseNetEnt(t, adapter, `{"sessionId":"`+expired+`","transactionRef":"t4","reservationRef":"t1","amount":1}`)
+		_, err := adapter.ToWalletCallback(req, WalletActionReserve)
+		assert.Error(t, err)
+		cb, err := adapter.ToWalletCallback(req, WalletActionRelease)
+		require.NoError(t, err)
+		assert.Equal(t, playerID, cb.PlayerID)
+	})
+
+	t.Run("forged session is rejected", func(t *testing.T) {
+		req := parseNetEnt(t, adapter, `{"sessionId":"`+NewNetEntAdapter("x", nil).IssueSessionToken(playerID, time.Hour)+`","transactionRef":"t5"}`)
+		_, err := adapter.ToWalletCallback(req, WalletActionRelease)
+		assert.Error(t, err)
+	})
+}
+
+func TestNetEntAdapter_Respond(t *testing.T) {
+	adapter := NewNetEntAdapter("s", nil)
+	req := parseNetEnt(t, adapter, `{"currency":"EUR","transactionRef":"t1"}`)
+
+	w := httptest.NewRecorder()
+	adapter.Respond(w, WalletResult{Request: req, Status: WalletStatusOK, Balance: 1050, BonusBalance: 200})
+	assert.JSONEq(t, `{"balance":10.50,"bonusBalance":2.00,"currency":"EUR","walletRef":"netent_t1"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	adapter.Respond(w, WalletResult{Request: req, Status: WalletStatusUnauthorized, Message: "invalid session"})
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"errorCode":"INVALID_SESSION","message":"invalid session"}`, w.Body.String())
+}
@@ -6,11 +6,16 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/attaboy/platform/internal/infra"
+	"github.com/attaboy/platform/internal/policy"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -53,6 +58,37 @@ type oddsOutcome struct {
 	Point *float64 `json:"point,omitempty"`
 }
 
+type oddsScoreEvent struct {
+	ID        string      `json:"id"`
+	SportKey  string      `json:"sport_key"`
+	Completed bool        `json:"completed"`
+	HomeTeam  string      `json:"home_team"`
+	AwayTeam  string      `json:"away_team"`
+	Scores    []oddsScore `json:"scores"`
+}
+
+type oddsScore struct {
+	Name  string `json:"name"`
+	Score string `json:"score"`
+}
+
+// FinalScore is a completed event's final score from The Odds API, with the
+// response entry it was read from kept as Raw for the settlement audit.
+type FinalScore struct {
+	ExternalID string          `json:"external_id"`
+	SportKey   string          `json:"sport_key"`
+	HomeTeam   string          `json:"home_team"`
+	AwayTeam   string          `json:"away_team"`
+	ScoreHome  int             `json:"score_home"`
+	ScoreAway  int             `json:"score_away"`
+	Raw        json.RawMessage `json:"raw"`
+}
+
+// EventKey is the odds88_event_id the event was synced under.
+func (f FinalScore) EventKey() int64 {
+	return hashOddsID(f.ExternalID)
+}
+
 // ── Sport key → icon mapping ──
 
 var sportGroupToIcon = map[string]string{
@@ -82,16 +118,20 @@ type OddsAPIConnector struct {
 	client  *http.Client
 	// Sports to sync (Odds API keys). If empty, syncs top sports.
 	sportKeys []string
+	// Freeze applied to an event's markets when it goes live or a price swings
+	suspension policy.InPlaySuspension
 }
 
-// NewOddsAPIConnector creates a new Odds API connector.
-func NewOddsAPIConnector(pool *pgxpool.Pool, apiKey string, logger *slog.Logger) *OddsAPIConnector {
+// NewOddsAPIConnector creates a new Odds API connector. A zero suspension
+// never freezes markets.
+func NewOddsAPIConnector(pool *pgxpool.Pool, apiKey string, suspension policy.InPlaySuspension, logger *slog.Logger) *OddsAPIConnector {
 	return &OddsAPIConnector{
-		pool:    pool,
-		baseURL: "https://api.the-odds-api.com",
-		apiKey:  apiKey,
-		logger:  logger,
-		client:  &http.Client{Timeout: 30 * time.Second},
+		pool:       pool,
+		baseURL:    "https://api.the-odds-api.com",
+		apiKey:     apiKey,
+		logger:     logger,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		suspension: suspension,
 		sportKeys: []string{
 			"americanfootball_nfl",
 			"basketball_nba",
@@ -105,33 +145,29 @@ func NewOddsAPIConnector(pool *pgxpool.Pool, apiKey string, logger *slog.Logger)
 	}
 }
 
-// StartSync begins periodic syncing of sports events and odds.
-func (c *OddsAPIConnector) StartSync(ctx context.Context) {
+// StartSync begins periodic syncing of sports events and odds. Each loop
+// backs off while it fails, re-validating the pool in case the database
+// failed over, and reports its runs to health.
+func (c *OddsAPIConnector) StartSync(ctx context.Context, health *infra.WorkerHealth) {
 	c.logger.Info("odds api connector starting", "sports", c.sportKeys)
 
-	// Initial sync
+	// Sync events + odds now and every 10 minutes (conserve free-tier quota)
 	go func() {
-		if err := c.syncAll(ctx); err != nil {
-			c.logger.Error("odds api initial sync error", "error", err)
-		}
+		infra.Worker{
+			Name: "oddsapi.sync", Interval: 10 * time.Minute, Timeout: 10 * time.Minute,
+			Backoff: infra.Backoff{Min: 30 * time.Second, Max: 10 * time.Minute}, Pool: c.pool, Health: health, Logger: c.logger,
+		}.Run(ctx, c.syncAll)
+		c.logger.Info("odds api sync stopped")
 	}()
 
-	// Sync events + odds every 10 minutes (conserve free-tier quota)
-	go func() {
-		ticker := time.NewTicker(10 * time.Minute)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				c.logger.Info("odds api sync stopped")
-				return
-			case <-ticker.C:
-				if err := c.syncAll(ctx); err != nil {
-					c.logger.Error("odds api sync error", "error", err)
-				}
-			}
-		}
-	}()
+	// Reopen frozen markets as their freeze runs out
+	if c.suspension.Enabled() {
+		interval := max(c.suspension.Freeze/4, time.Second)
+		go infra.Worker{
+			Name: "oddsapi.release-frozen", Interval: interval, Timeout: time.Minute,
+			Backoff: infra.Backoff{Min: time.Second, Max: max(interval, time.Minute)}, Pool: c.pool, Health: health, Logger: c.logger,
+		}.Run(ctx, c.releaseFrozenMarkets)
+	}
 }
 
 // ── HTTP helper ──
@@ -205,27 +241,37 @@ func (c *OddsAPIConnector) syncSports(ctx context.Context) error {
 		return fmt.Errorf("decode sports: %w", err)
 	}
 
+	// Upsert every active sport by key in one round trip
+	batch := &pgx.Batch{}
+	var keys []string
 	for _, s := range sports {
 		if !s.Active {
 			continue
 		}
+		keys = append(keys, s.Key)
 
 		icon := sportGroupToIcon[s.Group]
 		if icon == "" {
 			icon = strings.ToLower(strings.ReplaceAll(s.Group, " ", "-"))
 		}
 
-		// Upsert sport by key
-		_, err := c.pool.Exec(ctx, `
+		batch.Queue(`
 			INSERT INTO sports (id, key, name, icon, sort_order, active)
 			VALUES (gen_random_uuid(), $1, $2, $3, 0, true)
 			ON CONFLICT (key) DO UPDATE SET name = EXCLUDED.name, active = true`,
 			s.Key, s.Title, icon)
-		if err != nil {
-			c.logger.Warn("odds api upsert sport", "key", s.Key, "error", err)
-		}
 	}
-
+	if batch.Len() == 0 {
+		return nil
+	}
+	// The batch runs as one implicit transaction, so a bad sport fails them
+	// all; on error, upsert them one at a time and skip only the bad ones
+	if err := c.pool.SendBatch(ctx, batch).Close(); err != nil {
+		c.logger.Warn("odds api upsert sports batch, retrying one by one", "error", err)
+		return execEach(ctx, c.pool, batch, func(i int, err error) {
+			c.logger.Warn("odds api upsert sport", "key", keys[i], "error", err)
+		})
+	}
 	return nil
 }
 
@@ -264,45 +310,127 @@ func (c *OddsAPIConnector) syncSportEvents(ctx context.Context, sportKey string)
 		c.pool.QueryRow(ctx, `SELECT id FROM sports WHERE key = $1`, sportKey).Scan(&sportID)
 	}
 
-	synced := 0
+	return c.upsertEvents(ctx, sportID, events)
+}
+
+// oddsEventRow is an event of a sync with its parsed start and feed status.
+type oddsEventRow struct {
+	event  oddsEvent
+	key    int64
+	start  time.Time
+	status string
+}
+
+// oddsMarketRow is a market of a synced event, keyed by odds88_market_id.
+type oddsMarketRow struct {
+	eventID    uuid.UUID
+	key        string
+	name       string
+	marketType string
+	selections []oddsSelectionRow
+}
+
+// oddsSelectionRow is a selection of a synced market, keyed by
+// odds88_selection_id, with its odds as an integer (1.75 → 175).
+type oddsSelectionRow struct {
+	key       int64
+	name      string
+	odds      int
+	sortOrder int
+	line      *int
+	side      *string
+}
+
+// upsertEvents writes a sport's events with their markets and selections in
+// one transaction and returns how many events were synced. A failing
+// statement would abort the whole transaction, so each attempt runs in a
+// savepoint: if the sport's events fail together they are retried one by
+// one, and an event that still fails is logged and skipped.
+func (c *OddsAPIConnector) upsertEvents(ctx context.Context, sportID uuid.UUID, events []oddsEvent) (int, error) {
+	now := time.Now()
+	rows := make([]oddsEventRow, 0, len(events))
+	keys := make([]int64, 0, len(events))
 	for _, event := range events {
-		if err := c.upsertEvent(ctx, sportID, sportKey, event); err != nil {
-			c.logger.Warn("odds api upsert event", "event_id", event.ID, "error", err)
+		commenceTime, err := time.Parse(time.RFC3339, event.CommenceTime)
+		if err != nil {
+			c.logger.Warn("odds api parse commence_time", "event_id", event.ID, "error", err)
 			continue
 		}
-		synced++
+		status := "upcoming"
+		if now.After(commenceTime) {
+			status = "live"
+		}
+		row := oddsEventRow{event: event, key: hashOddsID(event.ID), start: commenceTime, status: status}
+		rows = append(rows, row)
+		keys = append(keys, row.key)
+	}
+	if len(rows) == 0 {
+		return 0, nil
 	}
 
-	return synced, nil
-}
-
-func (c *OddsAPIConnector) upsertEvent(ctx context.Context, sportID uuid.UUID, sportKey string, event oddsEvent) error {
-	// Determine league from sport key
-	league := event.SportTitle
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-	// Parse commence time
-	commenceTime, err := time.Parse(time.RFC3339, event.CommenceTime)
+	prevStatus := map[int64]string{}
+	prev, err := tx.Query(ctx, `
+		SELECT odds88_event_id, status FROM sports_events WHERE odds88_event_id = ANY($1)`, keys)
 	if err != nil {
-		return fmt.Errorf("parse commence_time: %w", err)
+		return 0, fmt.Errorf("load events: %w", err)
+	}
+	for prev.Next() {
+		var key int64
+		var status string
+		if err := prev.Scan(&key, &status); err != nil {
+			prev.Close()
+			return 0, fmt.Errorf("scan event: %w", err)
+		}
+		prevStatus[key] = status
+	}
+	prev.Close()
+	if err := prev.Err(); err != nil {
+		return 0, fmt.Errorf("load events: %w", err)
 	}
 
-	// Determine status
-	status := "upcoming"
-	if time.Now().After(commenceTime) {
-		status = "live"
+	synced := len(rows)
+	if err := inSavepoint(ctx, tx, func(sp pgx.Tx) error {
+		return c.writeEvents(ctx, sp, sportID, rows, prevStatus)
+	}); err != nil {
+		c.logger.Warn("odds api upsert events batch, retrying one by one", "sport_id", sportID, "error", err)
+		synced = 0
+		for _, r := range rows {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			if err := inSavepoint(ctx, tx, func(sp pgx.Tx) error {
+				return c.writeEvents(ctx, sp, sportID, []oddsEventRow{r}, prevStatus)
+			}); err != nil {
+				c.logger.Warn("odds api upsert event", "event_id", r.event.ID, "error", err)
+				continue
+			}
+			synced++
+		}
 	}
 
-	// Upsert event using odds88_event_id to store the Odds API event ID
-	// First try to find existing event by odds88_event_id
-	var eventID uuid.UUID
-	err = c.pool.QueryRow(ctx, `
-		SELECT id FROM sports_events WHERE odds88_event_id = $1`, hashOddsID(event.ID)).Scan(&eventID)
-	if err != nil {
-		// Create new event
-		eventID = uuid.New()
-		_, err = c.pool.Exec(ctx, `
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return synced, nil
+}
+
+// writeEvents upserts events as a single batch, then their markets and
+// selections: events first, since market keys derive from event IDs. Events
+// going in-play and selections whose price swings freeze their markets.
+func (c *OddsAPIConnector) writeEvents(ctx context.Context, tx pgx.Tx, sportID uuid.UUID, rows []oddsEventRow, prevStatus map[int64]string) error {
+	// Upsert events using odds88_event_id to store the Odds API event ID;
+	// the league and sport of a known event are left as first synced
+	batch := &pgx.Batch{}
+	for _, r := range rows {
+		batch.Queue(`
 			INSERT INTO sports_events (id, sport_id, league, home_team, away_team, start_time, status, odds88_event_id)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7)
 			ON CONFLICT (odds88_event_id) DO UPDATE SET
 				home_team = EXCLUDED.home_team,
 				away_team = EXCLUDED.away_team,
@@ -311,107 +439,381 @@ func (c *OddsAPIConnector) upsertEvent(ctx context.Context, sportID uuid.UUID, s
 					WHEN sports_events.status = 'settled' THEN sports_events.status
 					ELSE EXCLUDED.status
 				END,
-				updated_at = now()`,
-			eventID, sportID, league, event.HomeTeam, event.AwayTeam, commenceTime, status, hashOddsID(event.ID))
-		if err != nil {
-			return fmt.Errorf("upsert event: %w", err)
-		}
-		// Re-fetch the actual ID in case of conflict
-		c.pool.QueryRow(ctx, `SELECT id FROM sports_events WHERE odds88_event_id = $1`, hashOddsID(event.ID)).Scan(&eventID)
-	} else {
-		// Update existing
-		_, _ = c.pool.Exec(ctx, `
-			UPDATE sports_events SET
-				home_team = $2, away_team = $3, start_time = $4,
-				status = CASE WHEN status = 'settled' THEN status ELSE $5 END,
 				updated_at = now()
-			WHERE id = $1`, eventID, event.HomeTeam, event.AwayTeam, commenceTime, status)
+			RETURNING id`,
+			sportID, r.event.SportTitle, r.event.HomeTeam, r.event.AwayTeam, r.start, r.status, r.key)
+	}
+	eventIDs := make([]uuid.UUID, len(rows))
+	if err := sendBatchIDs(ctx, tx, batch, eventIDs); err != nil {
+		return fmt.Errorf("upsert events: %w", err)
 	}
 
-	// Process bookmakers — pick the first one with data (consensus odds)
-	if len(event.Bookmakers) == 0 {
-		return nil
+	var markets []oddsMarketRow
+	for i, r := range rows {
+		markets = append(markets, planOddsMarkets(eventIDs[i], r.event)...)
+	}
+	swung, err := c.upsertMarkets(ctx, tx, markets)
+	if err != nil {
+		return err
 	}
 
-	// Aggregate markets across bookmakers — use first bookmaker's odds
-	bk := event.Bookmakers[0]
-	for _, mkt := range bk.Markets {
-		if err := c.upsertMarketAndSelections(ctx, eventID, mkt); err != nil {
-			c.logger.Debug("odds api upsert market", "event_id", eventID, "market", mkt.Key, "error", err)
+	// Going in-play reprices everything: hold the event's markets
+	for i, r := range rows {
+		if r.status == "live" && prevStatus[r.key] == "upcoming" {
+			if err := c.freezeMarkets(ctx, tx, eventIDs[i], nil, "in_play"); err != nil {
+				return err
+			}
+		}
+	}
+	// A sharp move on a known selection freezes its market
+	for _, m := range swung {
+		if err := c.freezeMarkets(ctx, tx, m.eventID, &m.id, "odds_swing"); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
-func (c *OddsAPIConnector) upsertMarketAndSelections(ctx context.Context, eventID uuid.UUID, mkt oddsMarket) error {
-	// Map Odds API market key to our market type
-	marketName := mkt.Key
-	marketType := mkt.Key
-	switch mkt.Key {
-	case "h2h":
-		marketName = "Moneyline"
-		marketType = "1x2"
-	case "spreads":
-		marketName = "Spread"
-		marketType = "spread"
-	case "totals":
-		marketName = "Total"
-		marketType = "over_under"
-	}
-
-	// Generate a deterministic market ID from odds88_market_id
-	odds88MarketID := fmt.Sprintf("%s_%s", eventID.String()[:8], mkt.Key)
-
-	// Upsert market
-	var marketID uuid.UUID
-	err := c.pool.QueryRow(ctx, `
-		SELECT id FROM sports_markets WHERE odds88_market_id = $1`, odds88MarketID).Scan(&marketID)
-	if err != nil {
-		marketID = uuid.New()
-		_, err = c.pool.Exec(ctx, `
+// swungMarket is a market one of whose selections moved sharply this sync.
+type swungMarket struct {
+	eventID uuid.UUID
+	id      uuid.UUID
+}
+
+// upsertMarkets writes markets with their selections and returns the
+// markets with a selection whose price swung. If they fail together they are
+// retried one by one in savepoints, and a market that still fails is logged
+// and skipped rather than failing its events.
+func (c *OddsAPIConnector) upsertMarkets(ctx context.Context, tx pgx.Tx, markets []oddsMarketRow) ([]swungMarket, error) {
+	if len(markets) == 0 {
+		return nil, nil
+	}
+
+	var swung []swungMarket
+	err := inSavepoint(ctx, tx, func(sp pgx.Tx) (err error) {
+		swung, err = c.writeMarkets(ctx, sp, markets)
+		return err
+	})
+	if err == nil {
+		return swung, nil
+	}
+	c.logger.Warn("odds api upsert markets batch, retrying one by one", "error", err)
+	swung = nil
+	for _, m := range markets {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var s []swungMarket
+		if err := inSavepoint(ctx, tx, func(sp pgx.Tx) (err error) {
+			s, err = c.writeMarkets(ctx, sp, []oddsMarketRow{m})
+			return err
+		}); err != nil {
+			c.logger.Warn("odds api upsert market", "event_id", m.eventID, "market", m.key, "error", err)
+			continue
+		}
+		swung = append(swung, s...)
+	}
+	return swung, nil
+}
+
+// writeMarkets writes markets and then their selections, one batch each,
+// and returns the markets with a selection whose price swung.
+func (c *OddsAPIConnector) writeMarkets(ctx context.Context, tx pgx.Tx, markets []oddsMarketRow) ([]swungMarket, error) {
+
+	batch := &pgx.Batch{}
+	for _, m := range markets {
+		batch.Queue(`
 			INSERT INTO sports_markets (id, event_id, name, type, status, odds88_market_id)
-			VALUES ($1, $2, $3, $4, 'open', $5)
+			VALUES (gen_random_uuid(), $1, $2, $3, 'open', $4)
 			ON CONFLICT (odds88_market_id) DO UPDATE SET
-				name = EXCLUDED.name, updated_at = now()`,
-			marketID, eventID, marketName, marketType, odds88MarketID)
-		if err != nil {
-			return fmt.Errorf("upsert market: %w", err)
+				name = EXCLUDED.name, updated_at = now()
+			RETURNING id`,
+			m.eventID, m.name, m.marketType, m.key)
+	}
+	marketIDs := make([]uuid.UUID, len(markets))
+	if err := sendBatchIDs(ctx, tx, batch, marketIDs); err != nil {
+		return nil, fmt.Errorf("upsert markets: %w", err)
+	}
+
+	var selectionKeys []int64
+	for _, m := range markets {
+		for _, s := range m.selections {
+			selectionKeys = append(selectionKeys, s.key)
+		}
+	}
+	prevOdds := map[int64]int{}
+	prev, err := tx.Query(ctx, `
+		SELECT odds88_selection_id, odds_decimal FROM sports_selections WHERE odds88_selection_id = ANY($1)`, selectionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("load selections: %w", err)
+	}
+	for prev.Next() {
+		var key int64
+		var odds int
+		if err := prev.Scan(&key, &odds); err != nil {
+			prev.Close()
+			return nil, fmt.Errorf("scan selection: %w", err)
+		}
+		prevOdds[key] = odds
+	}
+	prev.Close()
+	if err := prev.Err(); err != nil {
+		return nil, fmt.Errorf("load selections: %w", err)
+	}
+
+	var swung []swungMarket
+	batch = &pgx.Batch{}
+	for i, m := range markets {
+		swing := false
+		for _, s := range m.selections {
+			swing = swing || c.suspension.IsSwing(prevOdds[s.key], s.odds)
+			batch.Queue(`
+				INSERT INTO sports_selections (id, market_id, name, odds_decimal, status, sort_order, odds88_selection_id, line, side)
+				VALUES (gen_random_uuid(), $1, $2, $3, 'active', $4, $5, $6, $7)
+				ON CONFLICT (odds88_selection_id) DO UPDATE SET
+					name = EXCLUDED.name,
+					odds_decimal = EXCLUDED.odds_decimal,
+					line = EXCLUDED.line,
+					side = EXCLUDED.side,
+					updated_at = now()`,
+				marketIDs[i], s.name, s.odds, s.sortOrder, s.key, s.line, s.side)
+		}
+		if swing {
+			swung = append(swung, swungMarket{eventID: m.eventID, id: marketIDs[i]})
+		}
+	}
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return nil, fmt.Errorf("upsert selections: %w", err)
+		}
+	}
+	return swung, nil
+}
+
+// sendBatchIDs sends a batch of statements that each return one id and
+// reads them into ids, in queue order.
+func sendBatchIDs(ctx context.Context, tx pgx.Tx, batch *pgx.Batch, ids []uuid.UUID) error {
+	results := tx.SendBatch(ctx, batch)
+	for i := range ids {
+		if err := results.QueryRow().Scan(&ids[i]); err != nil {
+			results.Close()
+			return err
 		}
-		c.pool.QueryRow(ctx, `SELECT id FROM sports_markets WHERE odds88_market_id = $1`, odds88MarketID).Scan(&marketID)
 	}
+	return results.Close()
+}
+
+// inSavepoint runs fn in a savepoint of tx, rolling back to it if fn fails
+// so the rest of the transaction can carry on.
+func inSavepoint(ctx context.Context, tx pgx.Tx, fn func(pgx.Tx) error) error {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("savepoint: %w", err)
+	}
+	if err := fn(sp); err != nil {
+		sp.Rollback(ctx)
+		return err
+	}
+	return sp.Commit(ctx)
+}
+
+// execEach runs each statement queued in batch on its own, for when the
+// batch failed as a whole, and passes the index and error of each statement
+// that fails to skip.
+func execEach(ctx context.Context, pool *pgxpool.Pool, batch *pgx.Batch, skip func(i int, err error)) error {
+	for i, q := range batch.QueuedQueries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := pool.Exec(ctx, q.SQL, q.Arguments...); err != nil {
+			skip(i, err)
+		}
+	}
+	return nil
+}
+
+// planOddsMarkets maps an event's markets from its first bookmaker (the
+// consensus odds) to the rows synced under eventID.
+func planOddsMarkets(eventID uuid.UUID, event oddsEvent) []oddsMarketRow {
+	if len(event.Bookmakers) == 0 {
+		return nil
+	}
+
+	var markets []oddsMarketRow
+	for _, mkt := range event.Bookmakers[0].Markets {
+		// Map Odds API market key to our market type
+		m := oddsMarketRow{
+			eventID:    eventID,
+			key:        fmt.Sprintf("%s_%s", eventID.String()[:8], mkt.Key),
+			name:       mkt.Key,
+			marketType: mkt.Key,
+		}
+		switch mkt.Key {
+		case "h2h":
+			m.name, m.marketType = "Moneyline", "1x2"
+		case "spreads":
+			m.name, m.marketType = "Spread", "spread"
+		case "totals":
+			m.name, m.marketType = "Total", "over_under"
+		}
 
-	// Upsert selections
-	for i, outcome := range mkt.Outcomes {
-		selName := outcome.Name
-		if outcome.Point != nil {
-			if mkt.Key == "spreads" {
-				selName = fmt.Sprintf("%s %+.1f", outcome.Name, *outcome.Point)
-			} else if mkt.Key == "totals" {
-				selName = fmt.Sprintf("%s %.1f", outcome.Name, *outcome.Point)
+		for i, outcome := range mkt.Outcomes {
+			selName := outcome.Name
+			if outcome.Point != nil {
+				if mkt.Key == "spreads" {
+					selName = fmt.Sprintf("%s %+.1f", outcome.Name, *outcome.Point)
+				} else if mkt.Key == "totals" {
+					selName = fmt.Sprintf("%s %.1f", outcome.Name, *outcome.Point)
+				}
 			}
+
+			// Record the line and side so settlement can resolve spreads and
+			// totals from the final score
+			line, side := selectionLine(mkt.Key, outcome, event.HomeTeam)
+			m.selections = append(m.selections, oddsSelectionRow{
+				key:       hashOddsID(fmt.Sprintf("%s_%s_%d", m.key, outcome.Name, i)),
+				name:      selName,
+				odds:      int(outcome.Price * 100),
+				sortOrder: i + 1,
+				line:      line,
+				side:      side,
+			})
 		}
+		markets = append(markets, m)
+	}
+	return markets
+}
 
-		// Convert decimal odds to integer (1.75 → 175)
-		oddsDecimal := int(outcome.Price * 100)
+// ── In-Play Suspension ──
 
-		// Deterministic selection ID
-		odds88SelectionID := int64(hashOddsID(fmt.Sprintf("%s_%s_%d", odds88MarketID, outcome.Name, i)))
+// freezeMarkets suspends an event's open markets, or just marketID when set,
+// until the freeze window has passed. A market already frozen by the feed has
+// its freeze extended; markets suspended by hand or settled are left alone.
+func (c *OddsAPIConnector) freezeMarkets(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, marketID *uuid.UUID, reason string) error {
+	if !c.suspension.Enabled() {
+		return nil
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE sports_markets SET
+			status = 'suspended',
+			suspended_until = GREATEST(suspended_until, now() + make_interval(secs => $3)),
+			suspension_reason = $4,
+			updated_at = now()
+		WHERE event_id = $1 AND ($2::uuid IS NULL OR id = $2)
+		  AND (status = 'open' OR (status = 'suspended' AND suspended_until IS NOT NULL))`,
+		eventID, marketID, c.suspension.Freeze.Seconds(), reason)
+	if err != nil {
+		return fmt.Errorf("freeze markets: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		c.logger.Info("odds api markets frozen", "event_id", eventID, "reason", reason,
+			"markets", tag.RowsAffected(), "freeze", c.suspension.Freeze)
+	}
+	return nil
+}
+
+// releaseFrozenMarkets reopens markets whose freeze has run out.
+func (c *OddsAPIConnector) releaseFrozenMarkets(ctx context.Context) error {
+	_, err := c.pool.Exec(ctx, `
+		UPDATE sports_markets SET status = 'open', suspended_until = NULL, suspension_reason = NULL, updated_at = now()
+		WHERE status = 'suspended' AND suspended_until <= now()`)
+	return err
+}
+
+// ── Scores ──
 
-		_, err := c.pool.Exec(ctx, `
-			INSERT INTO sports_selections (id, market_id, name, odds_decimal, status, sort_order, odds88_selection_id)
-			VALUES (gen_random_uuid(), $1, $2, $3, 'active', $4, $5)
-			ON CONFLICT (odds88_selection_id) DO UPDATE SET
-				name = EXCLUDED.name,
-				odds_decimal = EXCLUDED.odds_decimal,
-				updated_at = now()`,
-			marketID, selName, oddsDecimal, i+1, odds88SelectionID)
+// FetchFinalScores returns the final scores of events completed in the last
+// three days across the synced sports. A sport that fails to load is logged
+// and skipped; quota exhaustion stops the fetch with what was read so far.
+func (c *OddsAPIConnector) FetchFinalScores(ctx context.Context) ([]FinalScore, error) {
+	var scores []FinalScore
+	for _, sportKey := range c.sportKeys {
+		body, status, err := c.oddsGet(ctx, fmt.Sprintf("/v4/sports/%s/scores/?daysFrom=3&dateFormat=iso", sportKey))
+		if err != nil {
+			if status == 429 {
+				c.logger.Warn("odds api quota exceeded, stopping scores fetch")
+				break
+			}
+			c.logger.Error("odds api fetch scores failed", "sport", sportKey, "error", err)
+			continue
+		}
+		parsed, err := parseFinalScores(body)
 		if err != nil {
-			c.logger.Debug("odds api upsert selection", "name", selName, "error", err)
+			c.logger.Error("odds api decode scores failed", "sport", sportKey, "error", err)
+			continue
 		}
+		scores = append(scores, parsed...)
 	}
+	return scores, nil
+}
 
-	return nil
+// parseFinalScores reads a /scores response, keeping completed events whose
+// score lines name both teams.
+func parseFinalScores(body []byte) ([]FinalScore, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("decode scores: %w", err)
+	}
+
+	var scores []FinalScore
+	for _, raw := range entries {
+		var ev oddsScoreEvent
+		if err := json.Unmarshal(raw, &ev); err != nil || !ev.Completed {
+			continue
+		}
+		home, away := -1, -1
+		for _, sc := range ev.Scores {
+			n, err := strconv.Atoi(strings.TrimSpace(sc.Score))
+			if err != nil {
+				continue
+			}
+			switch sc.Name {
+			case ev.HomeTeam:
+				home = n
+			case ev.AwayTeam:
+				away = n
+			}
+		}
+		if home < 0 || away < 0 {
+			continue
+		}
+		scores = append(scores, FinalScore{
+			ExternalID: ev.ID,
+			SportKey:   ev.SportKey,
+			HomeTeam:   ev.HomeTeam,
+			AwayTeam:   ev.AwayTeam,
+			ScoreHome:  home,
+			ScoreAway:  away,
+			Raw:        raw,
+		})
+	}
+	return scores, nil
+}
+
+// selectionLine returns the line (x100) and side of a spreads or totals
+// outcome, or nils when the outcome has no line settlement can use.
+func selectionLine(marketKey string, outcome oddsOutcome, homeTeam string) (*int, *string) {
+	if outcome.Point == nil {
+		return nil, nil
+	}
+	var side string
+	switch {
+	case marketKey == "spreads" && outcome.Name == homeTeam:
+		side = "home"
+	case marketKey == "spreads":
+		side = "away"
+	case marketKey == "totals" && strings.EqualFold(outcome.Name, "over"):
+		side = "over"
+	case marketKey == "totals" && strings.EqualFold(outcome.Name, "under"):
+		side = "under"
+	default:
+		return nil, nil
+	}
+	line := int(math.Round(*outcome.Point * 100))
+	if line%25 != 0 {
+		return nil, nil
+	}
+	return &line, &side
 }
 
 // hashOddsID converts an Odds API string ID to a stable int64 for odds88_event_id column.
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectionLine(t *testing.T) {
+	point := func(v float64) *float64 { return &v }
+
+	line, side := selectionLine("spreads", oddsOutcome{Name: "Arsenal", Point: point(-0.25)}, "Arsenal")
+	assert.Equal(t, -25, *line)
+	assert.Equal(t, "home", *side)
+
+	line, side = selectionLine("spreads", oddsOutcome{Name: "Chelsea", Point: point(0.25)}, "Arsenal")
+	assert.Equal(t, 25, *line)
+	assert.Equal(t, "away", *side)
+
+	line, side = selectionLine("totals", oddsOutcome{Name: "Over", Point: point(2.5)}, "Arsenal")
+	assert.Equal(t, 250, *line)
+	assert.Equal(t, "over", *side)
+
+	line, side = selectionLine("totals", oddsOutcome{Name: "Under", Point: point(218.5)}, "Lakers")
+	assert.Equal(t, 21850, *line)
+	assert.Equal(t, "under", *side)
+
+	line, side = selectionLine("totals", oddsOutcome{Name: "Over", Point: point(2.1)}, "Arsenal")
+	assert.Nil(t, line, "lines off the quarter grid are not settled automatically")
+	assert.Nil(t, side)
+
+	line, side = selectionLine("h2h", oddsOutcome{Name: "Arsenal"}, "Arsenal")
+	assert.Nil(t, line)
+	assert.Nil(t, side)
+}
+
+func TestParseFinalScores(t *testing.T) {
+	body := []byte(`[
+		{"id": "ev1", "sport_key": "soccer_epl", "completed": true, "home_team": "Arsenal", "away_team": "Chelsea",
+		 "scores": [{"name": "Arsenal", "score": "2"}, {"name": "Chelsea", "score": "1"}]},
+		{"id": "ev2", "sport_key": "soccer_epl", "completed": false, "home_team": "Spurs", "away_team": "Fulham",
+		 "scores": [{"name": "Spurs", "score": "0"}, {"name": "Fulham", "score": "0"}]},
+		{"id": "ev3", "sport_key": "soccer_epl", "completed": true, "home_team": "Leeds", "away_team": "Wolves",
+		 "scores": null}
+	]`)
+
+	scores, err := parseFinalScores(body)
+	require.NoError(t, err)
+	require.Len(t, scores, 1, "in-play and unscored events are skipped")
+	assert.Equal(t, "ev1", scores[0].ExternalID)
+	assert.Equal(t, 2, scores[0].ScoreHome)
+	assert.Equal(t, 1, scores[0].ScoreAway)
+	assert.Equal(t, hashOddsID("ev1"), scores[0].EventKey())
+	assert.Contains(t, string(scores[0].Raw), `"Arsenal"`)
+
+	_, err = parseFinalScores([]byte(`{"message": "bad key"}`))
+	assert.Error(t, err)
+}
+
+func TestPlanOddsMarkets(t *testing.T) {
+	point := func(v float64) *float64 { return &v }
+	eventID := uuid.MustParse("0a1b2c3d-0000-0000-0000-000000000000")
+	event := oddsEvent{
+		ID: "ev1", HomeTeam: "Arsenal", AwayTeam: "Chelsea",
+		Bookmakers: []oddsBookmaker{
+			{Key: "first", Markets: []oddsMarket{
+				{Key: "h2h", Outcomes: []oddsOutcome{{Name: "Arsenal", Price: 1.75}, {Name: "Chelsea", Price: 4.2}}},
+				{Key: "totals", Outcomes: []oddsOutcome{{Name: "Over", Price: 1.9, Point: point(2.5)}}},
+			}},
+			{Key: "second", Markets: []oddsMarket{{Key: "spreads"}}},
+		},
+	}
+
+	markets := planOddsMarkets(eventID, event)
+	require.Len(t, markets, 2, "only the first bookmaker's markets are synced")
+
+	h2h := markets[0]
+	assert.Equal(t, eventID, h2h.eventID)
+	assert.Equal(t, "0a1b2c3d_h2h", h2h.key)
+	assert.Equal(t, "Moneyline", h2h.name)
+	assert.Equal(t, "1x2", h2h.marketType)
+	require.Len(t, h2h.selections, 2)
+	assert.Equal(t, 175, h2h.selections[0].odds)
+	assert.Equal(t, 2, h2h.selections[1].sortOrder)
+	assert.Equal(t, hashOddsID("0a1b2c3d_h2h_Chelsea_1"), h2h.selections[1].key)
+	assert.Nil(t, h2h.selections[0].line)
+
+	totals := markets[1]
+	assert.Equal(t, "over_under", totals.marketType)
+	require.Len(t, totals.selections, 1)
+	assert.Equal(t, "Over 2.5", totals.selections[0].name)
+	assert.Equal(t, 250, *totals.selections[0].line)
+	assert.Equal(t, "over", *totals.selections[0].side)
+
+	assert.Empty(t, planOddsMarkets(eventID, oddsEvent{ID: "ev2"}))
+}
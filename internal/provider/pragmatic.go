@@ -15,13 +15,22 @@ import (
 
 // PragmaticAdapter handles Pragmatic Play wallet server callbacks.
 type PragmaticAdapter struct {
+	name      string
 	secretKey string
 	logger    *slog.Logger
 }
 
 // NewPragmaticAdapter creates a new Pragmatic Play adapter.
 func NewPragmaticAdapter(secretKey string, logger *slog.Logger) *PragmaticAdapter {
-	return &PragmaticAdapter{secretKey: secretKey, logger: logger}
+	return &PragmaticAdapter{name: "pragmatic", secretKey: secretKey, logger: logger}
+}
+
+// Name returns the manufacturer ID.
+func (a *PragmaticAdapter) Name() string { return a.name }
+
+// Routes serves a single endpoint; the action is named in the body.
+func (a *PragmaticAdapter) Routes() []WalletRoute {
+	return []WalletRoute{{Path: "/"}}
 }
 
 // PragmaticRequest is the common request shape from Pragmatic Play.
@@ -65,18 +74,18 @@ func (a *PragmaticAdapter) ComputeSignature(body []byte) string {
 }
 
 // ParseRequest extracts a Pragmatic Play request from an HTTP request.
-func (a *PragmaticAdapter) ParseRequest(r *http.Request) (*PragmaticRequest, []byte, error) {
+func (a *PragmaticAdapter) ParseRequest(r *http.Request) (*WalletRequest, error) {
 	body, err := readBody(r, 1<<20)
 	if err != nil {
-		return nil, nil, fmt.Errorf("read body: %w", err)
+		return nil, fmt.Errorf("read body: %w", err)
 	}
 
 	var req PragmaticRequest
 	if err := json.Unmarshal(body, &req); err != nil {
-		return nil, body, fmt.Errorf("unmarshal: %w", err)
+		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
 
-	return &req, body, nil
+	return &WalletRequest{Body: body, Signature: req.ProvidedHash, Payload: &req}, nil
 }
 
 // PlayerID parses the player UUID from a Pragmatic request.
@@ -85,9 +94,15 @@ func (a *PragmaticAdapter) PlayerID(req *PragmaticRequest) (uuid.UUID, error) {
 }
 
 // ToWalletCallback converts a Pragmatic request to a unified WalletCallback.
-func (a *PragmaticAdapter) ToWalletCallback(req *PragmaticRequest) (*WalletCallback, error) {
+// The action is read from the body; the route action is ignored. A request
+// carrying a session token may omit the user id.
+func (a *PragmaticAdapter) ToWalletCallback(wr *WalletRequest, _ WalletAction) (*WalletCallback, error) {
+	req, ok := wr.Payload.(*PragmaticRequest)
+	if !ok {
+		return nil, domain.ErrValidation("invalid request")
+	}
 	playerID, err := a.PlayerID(req)
-	if err != nil {
+	if err != nil && (req.Token == "" || req.UserID != "") {
 		return nil, domain.ErrValidation("invalid user id")
 	}
 
@@ -119,9 +134,23 @@ func (a *PragmaticAdapter) ToWalletCallback(req *PragmaticRequest) (*WalletCallb
 		TransactionID: req.TransactionID,
 		RoundID:       req.RoundID,
 		GameID:        req.GameID,
+		SessionToken:  req.Token,
 	}, nil
 }
 
+// Respond renders a wallet result; any failure is error 1 with a description.
+func (a *PragmaticAdapter) Respond(w http.ResponseWriter, result WalletResult) {
+	if result.Status != WalletStatusOK {
+		a.RespondJSON(w, PragmaticResponse{Error: 1, Message: result.Message})
+		return
+	}
+	a.RespondJSON(w, PragmaticResponse{
+		Currency: result.Currency,
+		Cash:     FormatCents(result.Balance),
+		Bonus:    FormatCents(result.BonusBalance),
+	})
+}
+
 // RespondJSON writes a Pragmatic Play JSON response.
 func (a *PragmaticAdapter) RespondJSON(w http.ResponseWriter, resp PragmaticResponse) {
 	w.Header().Set("Content-Type", "application/json")
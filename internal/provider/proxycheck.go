@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// IPIntelligence looks up the reputation of an IP address: whether it is a
+// VPN, proxy or Tor exit and how risky it is.
+type IPIntelligence interface {
+	// Name returns the provider name recorded with each detection.
+	Name() string
+
+	// Lookup returns the reputation of ip.
+	Lookup(ctx context.Context, ip string) (*domain.IPReputation, error)
+}
+
+const proxyCheckBaseURL = "https://proxycheck.io/v2"
+
+// ProxyCheckConfig configures the proxycheck.io client. An empty base URL
+// uses the public API.
+type ProxyCheckConfig struct {
+	BaseURL string
+	APIKey  string
+}
+
+// ProxyCheckClient is an IPIntelligence backed by proxycheck.io.
+type ProxyCheckClient struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewProxyCheckClient creates a proxycheck.io client.
+func NewProxyCheckClient(cfg ProxyCheckConfig) *ProxyCheckClient {
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = proxyCheckBaseURL
+	}
+	return &ProxyCheckClient{
+		baseURL: baseURL,
+		apiKey:  cfg.APIKey,
+		client:  &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+// Configured reports whether an API key is set.
+func (c *ProxyCheckClient) Configured() bool {
+	return c.apiKey != ""
+}
+
+// Name returns "proxycheck".
+func (c *ProxyCheckClient) Name() string { return "proxycheck" }
+
+// proxyCheckResult is one address in a proxycheck.io response.
+type proxyCheckResult struct {
+	Proxy   string `json:"proxy"` // "yes" or "no"
+	Type    string `json:"type"`
+	Risk    int    `json:"risk"`
+	ISOCode string `json:"isocode"`
+}
+
+// Lookup queries proxycheck.io with VPN detection and risk scoring on.
+func (c *ProxyCheckClient) Lookup(ctx context.Context, ip string) (*domain.IPReputation, error) {
+	q := url.Values{"key": {c.apiKey}, "vpn": {"1"}, "risk": {"1"}, "asn": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.baseURL+"/"+url.PathEscape(ip)+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create proxycheck request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("proxycheck lookup: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("proxycheck lookup: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxycheck lookup: status %d", resp.StatusCode)
+	}
+
+	// The reply is keyed by address next to "status" and "message".
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("decode proxycheck response: %w", err)
+	}
+	var status, message string
+	_ = json.Unmarshal(raw["status"], &status)
+	_ = json.Unmarshal(raw["message"], &message)
+	if status != "ok" && status != "warning" {
+		return nil, fmt.Errorf("proxycheck lookup: %s: %s", status, message)
+	}
+	entry, ok := raw[ip]
+	if !ok {
+		return nil, fmt.Errorf("proxycheck lookup: no result for %s", ip)
+	}
+	var result proxyCheckResult
+	if err := json.Unmarshal(entry, &result); err != nil {
+		return nil, fmt.Errorf("decode proxycheck result: %w", err)
+	}
+	return &domain.IPReputation{
+		Proxy:     strings.EqualFold(result.Proxy, "yes"),
+		ProxyType: result.Type,
+		Risk:      result.Risk,
+		Country:   result.ISOCode,
+	}, nil
+}
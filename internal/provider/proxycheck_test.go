@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProxyCheck(t *testing.T, body string) *ProxyCheckClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/203.0.113.7", r.URL.Path)
+		assert.Equal(t, "test-key", r.URL.Query().Get("key"))
+		assert.Equal(t, "1", r.URL.Query().Get("vpn"))
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return NewProxyCheckClient(ProxyCheckConfig{BaseURL: srv.URL, APIKey: "test-key"})
+}
+
+func TestProxyCheckLookup_VPN(t *testing.T) {
+	c := newTestProxyCheck(t, `{"status":"ok","203.0.113.7":{"asn":"AS9009","isocode":"NL","proxy":"yes","type":"VPN","risk":66}}`)
+	rep, err := c.Lookup(context.Background(), "203.0.113.7")
+	require.NoError(t, err)
+	assert.Equal(t, domain.IPReputation{Proxy: true, ProxyType: "VPN", Risk: 66, Country: "NL"}, *rep)
+}
+
+func TestProxyCheckLookup_Clean(t *testing.T) {
+	c := newTestProxyCheck(t, `{"status":"warning","message":"nearing query limit","203.0.113.7":{"isocode":"GB","proxy":"no","type":"Residential","risk":0}}`)
+	rep, err := c.Lookup(context.Background(), "203.0.113.7")
+	require.NoError(t, err)
+	assert.False(t, rep.Proxy)
+	assert.Equal(t, "GB", rep.Country)
+}
+
+func TestProxyCheckLookup_Denied(t *testing.T) {
+	c := newTestProxyCheck(t, `{"status":"denied","message":"invalid API key"}`)
+	_, err := c.Lookup(context.Background(), "203.0.113.7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid API key")
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+)
+
+// RelaxAdapter handles Relax Gaming P2P wallet callbacks, including payouts
+// for promotional free rounds granted through the Relax freespins API.
+type RelaxAdapter struct {
+	name        string
+	credentials string // "user:password" for HTTP Basic auth
+	logger      *slog.Logger
+}
+
+// NewRelaxAdapter creates a new Relax Gaming adapter. credentials is the
+// "user:password" pair Relax presents in the Authorization header.
+func NewRelaxAdapter(credentials string, logger *slog.Logger) *RelaxAdapter {
+	return &RelaxAdapter{name: "relax", credentials: credentials, logger: logger}
+}
+
+// RelaxRequest is the common request shape for P2P wallet calls. Amounts are
+// integer minor units.
+type RelaxRequest struct {
+	CustomerID    string `json:"customerid"`
+	PlayerID      int64  `json:"playerid,omitempty"`
+	SessionID     int64  `json:"sessionid,omitempty"`
+	Currency      string `json:"currency"`
+	GameRef       string `json:"gameref,omitempty"`
+	GameSessionID int64  `json:"gamesessionid,omitempty"`
+	RoundID       int64  `json:"roundid,omitempty"`
+	TxID          int64  `json:"txid,omitempty"`
+	OriginalTxID  int64  `json:"originaltxid,omitempty"`
+	Amount        int64  `json:"amount"`
+	TxType        string `json:"txtype,omitempty"`
+	Ended         bool   `json:"ended,omitempty"`
+	PromoCode     string `json:"promocode,omitempty"`
+	FreespinsID   string `json:"freespinsid,omitempty"`
+}
+
+// RelaxResponse is the success response for P2P wallet calls.
+type RelaxResponse struct {
+	CustomerID string `json:"customerid,omitempty"`
+	Currency   string `json:"currency,omitempty"`
+	Balance    int64  `json:"balance"`
+	TxID       int64  `json:"txid,omitempty"`
+	RemoteTxID string `json:"remotetxid,omitempty"`
+}
+
+// RelaxErrorResponse is returned alongside a non-200 HTTP status.
+type RelaxErrorResponse struct {
+	ErrorCode    string `json:"errorcode"`
+	ErrorMessage string `json:"errormessage,omitempty"`
+}
+
+// Relax transaction types.
+const (
+	RelaxTxWithdraw        = "withdraw"
+	RelaxTxDeposit         = "deposit"
+	RelaxTxFreespinsPayout = "freespinspayout"
+)
+
+// P2P error codes.
+const (
+	RelaxErrInvalidToken      = "INVALID_TOKEN"
+	RelaxErrInvalidParameters = "INVALID_PARAMETERS"
+	RelaxErrBlocked           = "BLOCKED_FROM_PRODUCT"
+	RelaxErrInsufficientFunds = "INSUFFICIENT_FUNDS"
+	RelaxErrUnhandled         = "UNHANDLED"
+)
+
+var relaxErrorCode = map[WalletStatus]string{
+	WalletStatusBadRequest:        RelaxErrInvalidParameters,
+	WalletStatusUnauthorized:      RelaxErrInvalidToken,
+	WalletStatusForbidden:         RelaxErrBlocked,
+	WalletStatusInsufficientFunds: RelaxErrInsufficientFunds,
+	WalletStatusError:             RelaxErrUnhandled,
+}
+
+// Name returns the manufacturer ID.
+func (a *RelaxAdapter) Name() string { return a.name }
+
+// Routes serves the P2P endpoints. Free-round payouts arrive on deposit with
+// txtype freespinspayout.
+func (a *RelaxAdapter) Routes() []WalletRoute {
+	return []WalletRoute{
+		{Path: "/verifyToken", Action: WalletActionBalance},
+		{Path: "/getBalance", Action: WalletActionBalance},
+		{Path: "/withdraw", Action: WalletActionBet},
+		{Path: "/deposit", Action: WalletActionWin},
+		{Path: "/rollback", Action: WalletActionRollback},
+	}
+}
+
+// ComputeSignature returns the Authorization header Relax is expected to send.
+func (a *RelaxAdapter) ComputeSignature() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.credentials))
+}
+
+// VerifySignature validates the Basic auth header. Relax authenticates the
+// caller rather than signing the body, so body is unused.
+func (a *RelaxAdapter) VerifySignature(_ []byte, authorization string) bool {
+	return subtle.ConstantTimeCompare([]byte(a.ComputeSignature()), []byte(authorization)) == 1
+}
+
+// ParseRequest reads a P2P call. The credentials travel in the
+// Authorization header.
+func (a *RelaxAdapter) ParseRequest(r *http.Request) (*WalletRequest, error) {
+	body, err := readBody(r, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+
+	var req RelaxRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &WalletRequest{Body: body, Signature: r.Header.Get("Authorization"), Payload: &req}, nil
+}
+
+// ToWalletCallback converts a P2P call to a unified WalletCallback.
+// Rollbacks are keyed by originaltxid so they find the withdraw they void.
+// A deposit with txtype freespinspayout is a promotional free-round win and
+// is credited to bonus balance.
+func (a *RelaxAdapter) ToWalletCallback(wr *WalletRequest, action WalletAction) (*WalletCallback, error) {
+	req, ok := wr.Payload.(*RelaxRequest)
+	if !ok {
+		return nil, domain.ErrValidation("invalid request")
+	}
+	playerID, err := uuid.Parse(req.CustomerID)
+	if err != nil {
+		return nil, domain.ErrValidation("invalid customer id")
+	}
+
+	cb := &WalletCallback{Action: action, PlayerID: playerID, Currency: req.Currency, GameID: req.GameRef}
+	if req.RoundID != 0 {
+		cb.RoundID = strconv.FormatInt(req.RoundID, 10)
+	}
+	if action == WalletActionBalance {
+		return cb, nil
+	}
+
+	if req.Amount < 0 {
+		return nil, domain.ErrValidation("invalid amount")
+	}
+	cb.Amount = req.Amount
+
+	txID := req.TxID
+	if action == WalletActionRollback {
+		txID = req.OriginalTxID
+	}
+	if txID == 0 {
+		return nil, domain.ErrValidation("transaction id is required")
+	}
+	cb.TransactionID = strconv.FormatInt(txID, 10)
+
+	if action == WalletActionWin && req.TxType == RelaxTxFreespinsPayout {
+		if req.FreespinsID == "" {
+			return nil, domain.ErrValidation("freespinsid is required for a freespins payout")
+		}
+		cb.WinType = domain.CasinoWinPromoFreeRound
+		if cb.RoundID == "" {
+			cb.RoundID = "freespins_" + req.FreespinsID
+		}
+	}
+	return cb, nil
+}
+
+// Respond renders a wallet result. Balance is reported as the sum of real
+// and bonus funds, as Relax shows a single balance in the game client.
+// Failures use the HTTP status with an error code in the body.
+func (a *RelaxAdapter) Respond(w http.ResponseWriter, result WalletResult) {
+	var req *RelaxRequest
+	if result.Request != nil {
+		req, _ = result.Request.Payload.(*RelaxRequest)
+	}
+
+	if result.Status != WalletStatusOK {
+		a.RespondError(w, walletStatusHTTP[result.Status], RelaxErrorResponse{
+			ErrorCode:    relaxErrorCode[result.Status],
+			ErrorMessage: result.Message,
+		})
+		return
+	}
+
+	resp := RelaxResponse{Currency: result.Currency, Balance: result.Balance + result.BonusBalance}
+	if req != nil {
+		resp.CustomerID = req.CustomerID
+		resp.TxID = req.TxID
+		if req.TxID != 0 {
+			resp.RemoteTxID = fmt.Sprintf("%s_%d", a.name, req.TxID)
+		}
+		if resp.Currency == "" {
+			resp.Currency = req.Currency
+		}
+	}
+	a.RespondJSON(w, resp)
+}
+
+// RespondJSON writes a successful P2P response.
+func (a *RelaxAdapter) RespondJSON(w http.ResponseWriter, resp RelaxResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RespondError writes a P2P error response with the given HTTP status.
+func (a *RelaxAdapter) RespondError(w http.ResponseWriter, status int, resp RelaxErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
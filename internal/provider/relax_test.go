@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const relaxPlayer = "0b6a3c1e-2f4d-4e5a-8b7c-9d0e1f2a3b4c"
+
+func TestRelaxAdapter_VerifySignature(t *testing.T) {
+	adapter := NewRelaxAdapter("relax:secret", nil)
+	auth := adapter.ComputeSignature()
+
+	assert.Equal(t, "Basic cmVsYXg6c2VjcmV0", auth)
+	assert.True(t, adapter.VerifySignature(nil, auth))
+	assert.False(t, adapter.VerifySignature(nil, "Basic bad"))
+	assert.False(t, NewRelaxAdapter("relax:other", nil).VerifySignature(nil, auth))
+}
+
+func parseRelax(t *testing.T, path, body string) *WalletRequest {
+	t.Helper()
+	r := httptest.NewRequest("POST", path, strings.NewReader(body))
+	r.Header.Set("Authorization", "Basic tok")
+	req, err := NewRelaxAdapter("s", nil).ParseRequest(r)
+	require.NoError(t, err)
+	return req
+}
+
+func TestRelaxAdapter_ToWalletCallback(t *testing.T) {
+	adapter := NewRelaxAdapter("s", nil)
+
+	t.Run("withdraw is a bet keyed by txid", func(t *testing.T) {
+		req := parseRelax(t, "/relax/withdraw", `{"customerid":"`+relaxPlayer+`","currency":"EUR",
+			"gameref":"rlx.money.train","roundid":77,"txid":1001,"amount":250,"txtype":"withdraw"}`)
+		assert.Equal(t, "Basic tok", req.Signature)
+
+		cb, err := adapter.ToWalletCallback(req, WalletActionBet)
+		require.NoError(t, err)
+		assert.Equal(t, int64(250), cb.Amount)
+		assert.Equal(t, "1001", cb.TransactionID)
+		assert.Equal(t, "77", cb.RoundID)
+		assert.Empty(t, cb.WinType)
+	})
+
+	t.Run("rollback is keyed by originaltxid", func(t *testing.T) {
+		req := parseRelax(t, "/relax/rollback", `{"customerid":"`+relaxPlayer+`","txid":1002,"originaltxid":1001,"amount":250}`)
+		cb, err := adapter.ToWalletCallback(req, WalletActionRollback)
+		require.NoError(t, err)
+		assert.Equal(t, "1001", cb.TransactionID)
+	})
+
+	t.Run("freespins payout is a promotional win", func(t *testing.T) {
+		req := parseRelax(t, "/relax/deposit", `{"customerid":"`+relaxPlayer+`","txid":1003,"amount":900,
+			"txtype":"freespinspayout","freespinsid":"fs-42","promocode":"WELCOME"}`)
+		cb, err := adapter.ToWalletCallback(req, WalletActionWin)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CasinoWinPromoFreeRound, cb.WinType)
+		assert.Equal(t, "freespins_fs-42", cb.RoundID)
+	})
+
+	t.Run("freespins payout requires freespinsid", func(t *testing.T) {
+		req := parseRelax(t, "/relax/deposit", `{"customerid":"`+relaxPlayer+`","txid":1004,"amount":900,"txtype":"freespinspayout"}`)
+		_, err := adapter.ToWalletCallback(req, WalletActionWin)
+		assert.Error(t, err)
+	})
+
+	t.Run("withdraw without txid is rejected", func(t *testing.T) {
+		req := parseRelax(t, "/relax/withdraw", `{"customerid":"`+relaxPlayer+`","amount":100}`)
+		_, err := adapter.ToWalletCallback(req, WalletActionBet)
+		assert.Error(t, err)
+	})
+}
+
+func TestRelaxAdapter_Respond(t *testing.T) {
+	adapter := NewRelaxAdapter("s", nil)
+	req := parseRelax(t, "/relax/withdraw", `{"customerid":"`+relaxPlayer+`","currency":"EUR","txid":1001,"amount":100}`)
+
+	w := httptest.NewRecorder()
+	adapter.Respond(w, WalletResult{Request: req, Status: WalletStatusOK, Balance: 1000, BonusBalance: 500})
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"customerid":"`+relaxPlayer+`","currency":"EUR","balance":1500,"txid":1001,"remotetxid":"relax_1001"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	adapter.Respond(w, WalletResult{Request: req, Status: WalletStatusInsufficientFunds, Message: "insufficient balance"})
+	assert.Equal(t, 400, w.Code)
+	assert.JSONEq(t, `{"errorcode":"INSUFFICIENT_FUNDS","errormessage":"insufficient balance"}`, w.Body.String())
+}
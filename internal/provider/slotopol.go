@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"time"
@@ -38,11 +39,13 @@ type SlotopolGame struct {
 	Features    []string `json:"features,omitempty"`
 }
 
-// SlotopolSpinRequest is the request body for a spin.
+// SlotopolSpinRequest is the request body for a spin. A spin with
+// FreeRoundsID plays one round of that grant instead of a paid spin.
 type SlotopolSpinRequest struct {
-	GameID string `json:"game_id"`
-	Bet    int64  `json:"bet"` // in cents
-	Lines  int    `json:"lines"`
+	GameID       string `json:"game_id"`
+	Bet          int64  `json:"bet"` // in cents
+	Lines        int    `json:"lines"`
+	FreeRoundsID string `json:"free_rounds_id,omitempty"`
 }
 
 // SlotopolSpinResult is the response from a spin.
@@ -121,6 +124,52 @@ func (c *SlotopolClient) Spin(ctx context.Context, spin SlotopolSpinRequest) (*S
 	return &result, nil
 }
 
+// Name returns "slotopol".
+func (c *SlotopolClient) Name() string { return "slotopol" }
+
+// GrantFreeRounds awards free rounds on Slotopol.
+func (c *SlotopolClient) GrantFreeRounds(ctx context.Context, grant FreeRoundsGrant) (string, error) {
+	url := fmt.Sprintf("%s/freerounds", c.baseURL)
+
+	body, _ := json.Marshal(grant)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, jsonReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("free rounds request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("slotopol free rounds returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode free rounds grant: %w", err)
+	}
+	if result.ID == "" {
+		return "", fmt.Errorf("slotopol free rounds returned no id")
+	}
+
+	return result.ID, nil
+}
+
+// PlayFreeRound plays one round of a Slotopol free rounds grant.
+func (c *SlotopolClient) PlayFreeRound(ctx context.Context, providerGrantID, gameID string, bet int64) (*FreeRoundResult, error) {
+	result, err := c.Spin(ctx, SlotopolSpinRequest{GameID: gameID, Bet: bet, FreeRoundsID: providerGrantID})
+	if err != nil {
+		return nil, err
+	}
+	return &FreeRoundResult{RoundID: result.GameRoundID, Win: result.Win}, nil
+}
+
 // jsonReader creates a reader from a byte slice.
 func jsonReader(data []byte) *jsonReadCloser {
 	return &jsonReadCloser{data: data, pos: 0}
@@ -133,7 +182,7 @@ type jsonReadCloser struct {
 
 func (r *jsonReadCloser) Read(p []byte) (int, error) {
 	if r.pos >= len(r.data) {
-		return 0, fmt.Errorf("EOF")
+		return 0, io.EOF
 	}
 	n := copy(p, r.data[r.pos:])
 	r.pos += n
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotopolGrantFreeRounds(t *testing.T) {
+	playerID := uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/freerounds", r.URL.Path)
+		var grant FreeRoundsGrant
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&grant))
+		assert.Equal(t, "ref-1", grant.Reference)
+		assert.Equal(t, playerID, grant.PlayerID)
+		assert.Equal(t, []string{"book-of-ra"}, grant.GameIDs)
+		assert.Equal(t, 10, grant.Rounds)
+		assert.Equal(t, int64(20), grant.Bet)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"fr-42"}`))
+	}))
+	defer srv.Close()
+
+	c := NewSlotopolClient(srv.URL, slog.Default())
+	id, err := c.GrantFreeRounds(context.Background(), FreeRoundsGrant{
+		Reference: "ref-1", PlayerID: playerID, GameIDs: []string{"book-of-ra"}, Rounds: 10, Bet: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fr-42", id)
+}
+
+func TestSlotopolGrantFreeRounds_Rejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	c := NewSlotopolClient(srv.URL, slog.Default())
+	_, err := c.GrantFreeRounds(context.Background(), FreeRoundsGrant{Reference: "ref-1", Rounds: 1})
+	assert.EqualError(t, err, "slotopol free rounds returned 422")
+}
+
+func TestSlotopolPlayFreeRound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/spin", r.URL.Path)
+		var spin SlotopolSpinRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&spin))
+		assert.Equal(t, SlotopolSpinRequest{GameID: "book-of-ra", Bet: 20, FreeRoundsID: "fr-42"}, spin)
+		w.Write([]byte(`{"game_round_id":"r-1","win":150}`))
+	}))
+	defer srv.Close()
+
+	c := NewSlotopolClient(srv.URL, slog.Default())
+	result, err := c.PlayFreeRound(context.Background(), "fr-42", "book-of-ra", 20)
+	require.NoError(t, err)
+	assert.Equal(t, FreeRoundResult{RoundID: "r-1", Win: 150}, *result)
+}
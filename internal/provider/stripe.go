@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -8,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -17,13 +19,19 @@ import (
 type StripeProvider struct {
 	secretKey      string
 	webhookSecret  string
+	apiBaseURL     string
+	client         *http.Client
 }
 
+const stripeAPIBaseURL = "https://api.stripe.com"
+
 // NewStripeProvider creates a Stripe provider.
 func NewStripeProvider(secretKey, webhookSecret string) *StripeProvider {
 	return &StripeProvider{
 		secretKey:     secretKey,
 		webhookSecret: webhookSecret,
+		apiBaseURL:    stripeAPIBaseURL,
+		client:        &http.Client{Timeout: 30 * time.Second},
 	}
 }
 
@@ -52,7 +60,7 @@ type CheckoutSessionData struct {
 
 // CreateCheckoutSession creates a Stripe checkout session for a deposit.
 // In production, this would call the Stripe API. For now, returns a structured response.
-func (s *StripeProvider) CreateCheckoutSession(amountCents int64, currency, playerID, successURL, cancelURL string) (*CheckoutSession, error) {
+func (s *StripeProvider) CreateCheckoutSession(ctx context.Context, amountCents int64, currency, playerID, successURL, cancelURL string) (*CheckoutSession, error) {
 	if s.secretKey == "" {
 		return nil, fmt.Errorf("stripe secret key not configured")
 	}
@@ -63,14 +71,14 @@ func (s *StripeProvider) CreateCheckoutSession(amountCents int64, currency, play
 		strings.ToLower(currency), amountCents, playerID, successURL, cancelURL,
 	)
 
-	req, err := http.NewRequest("POST", "https://api.stripe.com/v1/checkout/sessions", strings.NewReader(form))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.stripe.com/v1/checkout/sessions", strings.NewReader(form))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+s.secretKey)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("stripe api call: %w", err)
 	}
@@ -160,3 +168,231 @@ func ParseCheckoutSessionData(data json.RawMessage) (*CheckoutSessionData, error
 	}
 	return &wrapper.Object, nil
 }
+
+// StripeBalanceTransaction is one entry from Stripe's balance history
+// (GET /v1/balance_transactions). Amounts are in minor units.
+type StripeBalanceTransaction struct {
+	ID                string `json:"id"`
+	Type              string `json:"type"` // charge, payment, refund, payout, ...
+	ReportingCategory string `json:"reporting_category"`
+	Amount            int64  `json:"amount"`
+	Fee               int64  `json:"fee"`
+	Net               int64  `json:"net"`
+	Currency          string `json:"currency"`
+	Status            string `json:"status"`
+	Created           int64  `json:"created"`
+	// SourceID is the charge/refund/payout ID; PaymentIntent is filled when
+	// the source is a charge or refund tied to a payment intent.
+	SourceID      string `json:"-"`
+	PaymentIntent string `json:"-"`
+}
+
+// UnmarshalJSON handles the expanded or unexpanded "source" field.
+func (t *StripeBalanceTransaction) UnmarshalJSON(data []byte) error {
+	type plain StripeBalanceTransaction
+	var raw struct {
+		plain
+		Source json.RawMessage `json:"source"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*t = StripeBalanceTransaction(raw.plain)
+
+	if len(raw.Source) == 0 || string(raw.Source) == "null" {
+		return nil
+	}
+	if raw.Source[0] == '"' {
+		return json.Unmarshal(raw.Source, &t.SourceID)
+	}
+	var src struct {
+		ID            string `json:"id"`
+		PaymentIntent string `json:"payment_intent"`
+	}
+	if err := json.Unmarshal(raw.Source, &src); err != nil {
+		return fmt.Errorf("decode balance transaction source: %w", err)
+	}
+	t.SourceID = src.ID
+	t.PaymentIntent = src.PaymentIntent
+	return nil
+}
+
+// ListBalanceTransactions pages through Stripe balance transactions created
+// in [from, to), expanding the source so charges carry their payment intent.
+func (s *StripeProvider) ListBalanceTransactions(ctx context.Context, from, to time.Time) ([]StripeBalanceTransaction, error) {
+	if s.secretKey == "" {
+		return nil, fmt.Errorf("stripe secret key not configured")
+	}
+
+	var all []StripeBalanceTransaction
+	startingAfter := ""
+	for {
+		q := url.Values{}
+		q.Set("limit", "100")
+		q.Set("created[gte]", strconv.FormatInt(from.Unix(), 10))
+		q.Set("created[lt]", strconv.FormatInt(to.Unix(), 10))
+		q.Add("expand[]", "data.source")
+		if startingAfter != "" {
+			q.Set("starting_after", startingAfter)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiBaseURL+"/v1/balance_transactions?"+q.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+s.secretKey)
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("stripe api call: %w", err)
+		}
+
+		var page struct {
+			Data    []StripeBalanceTransaction `json:"data"`
+			HasMore bool                       `json:"has_more"`
+		}
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("stripe error (status %d): %s", resp.StatusCode, string(body))
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode balance transactions: %w", err)
+		}
+
+		all = append(all, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			return all, nil
+		}
+		startingAfter = page.Data[len(page.Data)-1].ID
+	}
+}
+
+// StripeRefund is the subset of a Stripe refund object we track.
+type StripeRefund struct {
+	ID            string            `json:"id"`
+	Amount        int64             `json:"amount"`
+	Currency      string            `json:"currency"`
+	PaymentIntent string            `json:"payment_intent"`
+	Status        string            `json:"status"` // pending, requires_action, succeeded, failed, canceled
+	FailureReason string            `json:"failure_reason,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// CreateRefund refunds amountCents of a payment intent. idempotencyKey makes
+// retries safe; refundID, when set, is stored in the refund metadata for
+// webhook lookup.
+func (s *StripeProvider) CreateRefund(ctx context.Context, paymentIntentID string, amountCents int64, refundID, idempotencyKey string) (*StripeRefund, error) {
+	if s.secretKey == "" {
+		return nil, fmt.Errorf("stripe secret key not configured")
+	}
+
+	form := url.Values{}
+	form.Set("payment_intent", paymentIntentID)
+	form.Set("amount", strconv.FormatInt(amountCents, 10))
+	if refundID != "" {
+		form.Set("metadata[refund_id]", refundID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiBaseURL+"/v1/refunds", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("stripe api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("stripe error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var refund StripeRefund
+	if err := json.NewDecoder(resp.Body).Decode(&refund); err != nil {
+		return nil, fmt.Errorf("decode stripe refund: %w", err)
+	}
+	return &refund, nil
+}
+
+// ParseRefundData extracts the refund object from a refund.* webhook event.
+func ParseRefundData(data json.RawMessage) (*StripeRefund, error) {
+	var wrapper struct {
+		Object StripeRefund `json:"object"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("parse refund data: %w", err)
+	}
+	return &wrapper.Object, nil
+}
+
+// StripePaymentMethod is the subset of a Stripe payment method object we
+// keep: its type and display details. Card numbers and IBANs stay at Stripe.
+type StripePaymentMethod struct {
+	ID   string `json:"id"`
+	Type string `json:"type"` // card, sepa_debit, us_bank_account
+	Card *struct {
+		Brand   string `json:"brand"`
+		Last4   string `json:"last4"`
+		Country string `json:"country"`
+	} `json:"card,omitempty"`
+	SepaDebit *struct {
+		Last4   string `json:"last4"`
+		Country string `json:"country"`
+	} `json:"sepa_debit,omitempty"`
+	USBankAccount *struct {
+		BankName string `json:"bank_name"`
+		Last4    string `json:"last4"`
+	} `json:"us_bank_account,omitempty"`
+}
+
+// Display returns the brand (or bank), last four digits and country shown
+// to the player for a saved method.
+func (m *StripePaymentMethod) Display() (brand, last4, country string) {
+	switch {
+	case m.Card != nil:
+		return m.Card.Brand, m.Card.Last4, strings.ToUpper(m.Card.Country)
+	case m.SepaDebit != nil:
+		return "sepa", m.SepaDebit.Last4, strings.ToUpper(m.SepaDebit.Country)
+	case m.USBankAccount != nil:
+		return m.USBankAccount.BankName, m.USBankAccount.Last4, "US"
+	}
+	return "", "", ""
+}
+
+// GetPaymentMethod retrieves a tokenized payment method by its pm_ id.
+func (s *StripeProvider) GetPaymentMethod(ctx context.Context, id string) (*StripePaymentMethod, error) {
+	if s.secretKey == "" {
+		return nil, fmt.Errorf("stripe secret key not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.apiBaseURL+"/v1/payment_methods/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("stripe api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("stripe error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var method StripePaymentMethod
+	if err := json.NewDecoder(resp.Body).Decode(&method); err != nil {
+		return nil, fmt.Errorf("decode stripe payment method: %w", err)
+	}
+	return &method, nil
+}
@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -70,3 +73,77 @@ func TestVerifyWebhookSignature_MissingHeader(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid signature header format")
 }
+
+func TestListBalanceTransactions_Paginates(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		assert.Equal(t, "data.source", r.URL.Query().Get("expand[]"))
+		if r.URL.Query().Get("starting_after") == "" {
+			w.Write([]byte(`{"has_more":true,"data":[
+				{"id":"txn_1","type":"charge","amount":5000,"fee":175,"net":4825,"currency":"eur",
+				 "source":{"id":"ch_1","payment_intent":"pi_1"}}]}`))
+			return
+		}
+		assert.Equal(t, "txn_1", r.URL.Query().Get("starting_after"))
+		w.Write([]byte(`{"has_more":false,"data":[
+			{"id":"txn_2","type":"payout","amount":-4825,"currency":"eur","source":"po_1"}]}`))
+	}))
+	defer srv.Close()
+
+	p := NewStripeProvider("sk_test", "")
+	p.apiBaseURL = srv.URL
+
+	txs, err := p.ListBalanceTransactions(context.Background(), time.Now().Add(-24*time.Hour), time.Now())
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "pi_1", txs[0].PaymentIntent)
+	assert.Equal(t, "ch_1", txs[0].SourceID)
+	assert.Equal(t, int64(5000), txs[0].Amount)
+	assert.Equal(t, "po_1", txs[1].SourceID)
+	assert.Empty(t, txs[1].PaymentIntent)
+}
+
+func TestCreateRefund(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/v1/refunds", r.URL.Path)
+		assert.Equal(t, "refund-key", r.Header.Get("Idempotency-Key"))
+		assert.Equal(t, "pi_1", r.PostForm.Get("payment_intent"))
+		assert.Equal(t, "2500", r.PostForm.Get("amount"))
+		assert.Equal(t, "rf-local", r.PostForm.Get("metadata[refund_id]"))
+		w.Write([]byte(`{"id":"re_1","amount":2500,"currency":"eur","payment_intent":"pi_1","status":"pending"}`))
+	}))
+	defer srv.Close()
+
+	p := NewStripeProvider("sk_test", "")
+	p.apiBaseURL = srv.URL
+
+	refund, err := p.CreateRefund(context.Background(), "pi_1", 2500, "rf-local", "refund-key")
+	require.NoError(t, err)
+	assert.Equal(t, "re_1", refund.ID)
+	assert.Equal(t, "pending", refund.Status)
+}
+
+func TestGetPaymentMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/payment_methods/pm_1", r.URL.Path)
+		assert.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"id":"pm_1","type":"sepa_debit","sepa_debit":{"last4":"3000","country":"de"}}`))
+	}))
+	defer srv.Close()
+
+	p := NewStripeProvider("sk_test", "")
+	p.apiBaseURL = srv.URL
+
+	method, err := p.GetPaymentMethod(context.Background(), "pm_1")
+	require.NoError(t, err)
+	assert.Equal(t, "sepa_debit", method.Type)
+	brand, last4, country := method.Display()
+	assert.Equal(t, "sepa", brand)
+	assert.Equal(t, "3000", last4)
+	assert.Equal(t, "DE", country)
+}
@@ -0,0 +1,137 @@
+package reconciliation
+
+import (
+	"sort"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	// SnapshotMismatch: stored balances differ from the latest transaction snapshot.
+	SnapshotMismatch DiscrepancyKind = "snapshot_mismatch"
+	// MissingHistory: the wallet holds funds but has no transactions at all.
+	MissingHistory DiscrepancyKind = "missing_history"
+)
+
+// WalletState is one wallet's stored balances next to the balance snapshots
+// of its newest transactions. Latest holds several snapshots when the newest
+// rows share a timestamp (posted in one DB transaction) and is empty when the
+// wallet has no history.
+type WalletState struct {
+	PlayerID          uuid.UUID
+	Currency          string
+	Stored            domain.Balances
+	Latest            []domain.Balances
+	LastTransactionID *uuid.UUID
+}
+
+// BalanceDrift is a wallet whose stored balances cannot be explained by its
+// transaction history.
+type BalanceDrift struct {
+	Kind     DiscrepancyKind `json:"kind"`
+	PlayerID uuid.UUID       `json:"player_id"`
+	Currency string          `json:"currency"`
+	Stored   domain.Balances `json:"stored"`
+	Expected domain.Balances `json:"expected"`
+}
+
+// CheckWallet recomputes a wallet's balances from its transaction snapshots.
+// It returns nil when the stored balances match any of the newest snapshots,
+// or when an empty wallet has no history.
+func CheckWallet(w WalletState) *BalanceDrift {
+	if len(w.Latest) == 0 {
+		if w.Stored == (domain.Balances{}) {
+			return nil
+		}
+		return &BalanceDrift{Kind: MissingHistory, PlayerID: w.PlayerID, Currency: w.Currency, Stored: w.Stored}
+	}
+	for _, snap := range w.Latest {
+		if snap == w.Stored {
+			return nil
+		}
+	}
+	return &BalanceDrift{
+		Kind: SnapshotMismatch, PlayerID: w.PlayerID, Currency: w.Currency,
+		Stored: w.Stored, Expected: w.Latest[0],
+	}
+}
+
+// WalletHistory is a wallet's history up to an instant: the snapshot of its
+// newest transaction without ledger postings (history older than the ledger),
+// the net postings on its tiers since that snapshot, and the snapshots of its
+// newest transactions at or before the instant.
+type WalletHistory struct {
+	PlayerID uuid.UUID
+	Currency string
+	Base     domain.Balances
+	Deltas   domain.Balances
+	Latest   []domain.Balances
+}
+
+// Replay returns the wallet's balances as its base snapshot plus the ledger
+// deltas since, and checks them against the newest transaction snapshots.
+// The drift is nil when the two agree.
+func Replay(h WalletHistory) (domain.Balances, *BalanceDrift) {
+	bal := domain.Balances{
+		Balance:         h.Base.Balance + h.Deltas.Balance,
+		BonusBalance:    h.Base.BonusBalance + h.Deltas.BonusBalance,
+		ReservedBalance: h.Base.ReservedBalance + h.Deltas.ReservedBalance,
+	}
+	return bal, CheckWallet(WalletState{PlayerID: h.PlayerID, Currency: h.Currency, Stored: bal, Latest: h.Latest})
+}
+
+// WalletTotals is the wallets of one currency summed at an instant.
+type WalletTotals struct {
+	Currency string `json:"currency"`
+	Wallets  int    `json:"wallets"`
+	// Ledger sums each wallet's replayed balances.
+	Ledger domain.Balances `json:"ledger"`
+	// Snapshot sums each wallet's newest balance_after snapshot.
+	Snapshot domain.Balances `json:"snapshot"`
+	// Drifted counts the wallets whose two sources disagree.
+	Drifted int `json:"drifted_wallets"`
+}
+
+// SumWallets replays each wallet and sums the results per currency, in
+// currency order. A wallet that reconciles adds its replayed balances to
+// both sums; one that drifts adds its first newest snapshot to Snapshot.
+func SumWallets(histories []WalletHistory) []WalletTotals {
+	byCurrency := map[string]*WalletTotals{}
+	var currencies []string
+	for _, h := range histories {
+		t := byCurrency[h.Currency]
+		if t == nil {
+			t = &WalletTotals{Currency: h.Currency}
+			byCurrency[h.Currency] = t
+			currencies = append(currencies, h.Currency)
+		}
+		bal, drift := Replay(h)
+		snap := bal
+		if drift != nil {
+			t.Drifted++
+			snap = domain.Balances{}
+			if len(h.Latest) > 0 {
+				snap = h.Latest[0]
+			}
+		}
+		t.Wallets++
+		t.Ledger = addBalances(t.Ledger, bal)
+		t.Snapshot = addBalances(t.Snapshot, snap)
+	}
+
+	sort.Strings(currencies)
+	totals := make([]WalletTotals, len(currencies))
+	for i, c := range currencies {
+		totals[i] = *byCurrency[c]
+	}
+	return totals
+}
+
+func addBalances(a, b domain.Balances) domain.Balances {
+	return domain.Balances{
+		Balance:         a.Balance + b.Balance,
+		BonusBalance:    a.BonusBalance + b.BonusBalance,
+		ReservedBalance: a.ReservedBalance + b.ReservedBalance,
+	}
+}
@@ -0,0 +1,98 @@
+package reconciliation
+
+import (
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWallet_MatchesLatestSnapshot(t *testing.T) {
+	bal := domain.Balances{Balance: 5000, BonusBalance: 200}
+	assert.Nil(t, CheckWallet(WalletState{PlayerID: uuid.New(), Currency: "EUR", Stored: bal, Latest: []domain.Balances{bal}}))
+}
+
+func TestCheckWallet_EmptyWalletWithoutHistory(t *testing.T) {
+	assert.Nil(t, CheckWallet(WalletState{PlayerID: uuid.New(), Currency: "EUR"}))
+}
+
+func TestCheckWallet_SnapshotMismatch(t *testing.T) {
+	w := WalletState{
+		PlayerID: uuid.New(), Currency: "EUR",
+		Stored: domain.Balances{Balance: 9000},
+		Latest: []domain.Balances{{Balance: 5000}},
+	}
+	drift := CheckWallet(w)
+	require.NotNil(t, drift)
+	assert.Equal(t, SnapshotMismatch, drift.Kind)
+	assert.Equal(t, int64(9000), drift.Stored.Balance)
+	assert.Equal(t, int64(5000), drift.Expected.Balance)
+}
+
+func TestCheckWallet_FundsWithoutHistory(t *testing.T) {
+	drift := CheckWallet(WalletState{PlayerID: uuid.New(), Currency: "USD", Stored: domain.Balances{BonusBalance: 100}})
+	require.NotNil(t, drift)
+	assert.Equal(t, MissingHistory, drift.Kind)
+	assert.Equal(t, domain.Balances{}, drift.Expected)
+}
+
+func TestCheckWallet_TiedSnapshots(t *testing.T) {
+	// Two legs posted in one DB transaction share created_at; either may be last.
+	w := WalletState{
+		PlayerID: uuid.New(), Currency: "EUR",
+		Stored: domain.Balances{Balance: 700, BonusBalance: 300},
+		Latest: []domain.Balances{{Balance: 1000}, {Balance: 700, BonusBalance: 300}},
+	}
+	assert.Nil(t, CheckWallet(w))
+}
+
+func TestReplay_BasePlusDeltas(t *testing.T) {
+	h := WalletHistory{
+		PlayerID: uuid.New(), Currency: "EUR",
+		Base:   domain.Balances{Balance: 1000},
+		Deltas: domain.Balances{Balance: -400, ReservedBalance: 400},
+		Latest: []domain.Balances{{Balance: 600, ReservedBalance: 400}},
+	}
+	bal, drift := Replay(h)
+	assert.Nil(t, drift)
+	assert.Equal(t, domain.Balances{Balance: 600, ReservedBalance: 400}, bal)
+}
+
+func TestReplay_DeltasDisagreeWithSnapshot(t *testing.T) {
+	h := WalletHistory{
+		PlayerID: uuid.New(), Currency: "EUR",
+		Deltas: domain.Balances{Balance: 500},
+		Latest: []domain.Balances{{Balance: 700}},
+	}
+	bal, drift := Replay(h)
+	assert.Equal(t, int64(500), bal.Balance)
+	require.NotNil(t, drift)
+	assert.Equal(t, SnapshotMismatch, drift.Kind)
+	assert.Equal(t, int64(700), drift.Expected.Balance)
+}
+
+func TestSumWallets_PerCurrency(t *testing.T) {
+	histories := []WalletHistory{
+		{PlayerID: uuid.New(), Currency: "USD",
+			Deltas: domain.Balances{Balance: 300}, Latest: []domain.Balances{{Balance: 300}}},
+		{PlayerID: uuid.New(), Currency: "EUR",
+			Base: domain.Balances{Balance: 1000}, Deltas: domain.Balances{Balance: -200, BonusBalance: 50},
+			Latest: []domain.Balances{{Balance: 800, BonusBalance: 50}}},
+		{PlayerID: uuid.New(), Currency: "EUR",
+			Deltas: domain.Balances{Balance: 500}, Latest: []domain.Balances{{Balance: 700}}},
+	}
+
+	totals := SumWallets(histories)
+	require.Len(t, totals, 2)
+	eur, usd := totals[0], totals[1]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.Equal(t, 2, eur.Wallets)
+	assert.Equal(t, domain.Balances{Balance: 1300, BonusBalance: 50}, eur.Ledger)
+	assert.Equal(t, domain.Balances{Balance: 1500, BonusBalance: 50}, eur.Snapshot)
+	assert.Equal(t, 1, eur.Drifted)
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Equal(t, usd.Ledger, usd.Snapshot)
+	assert.Zero(t, usd.Drifted)
+}
@@ -0,0 +1,163 @@
+// Package reconciliation matches external provider records against the
+// payments table and ledger, producing discrepancies for admin review.
+package reconciliation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscrepancyKind classifies a reconciliation mismatch.
+type DiscrepancyKind string
+
+const (
+	// MissingLocal: the provider settled money we have no payment for.
+	MissingLocal DiscrepancyKind = "missing_local"
+	// MissingProvider: we credited a deposit the provider has no record of.
+	MissingProvider DiscrepancyKind = "missing_provider"
+	// AmountMismatch: provider and payment amounts differ.
+	AmountMismatch DiscrepancyKind = "amount_mismatch"
+	// CurrencyMismatch: provider and payment currencies differ.
+	CurrencyMismatch DiscrepancyKind = "currency_mismatch"
+	// StatusMismatch: provider settled the charge but the payment is not completed.
+	StatusMismatch DiscrepancyKind = "status_mismatch"
+	// LedgerMismatch: payment is completed but the ledger entry is missing or differs.
+	LedgerMismatch DiscrepancyKind = "ledger_mismatch"
+)
+
+// ProviderRecord is a settled money movement reported by the provider.
+type ProviderRecord struct {
+	ID         string    `json:"id"`          // provider balance transaction ID
+	PaymentRef string    `json:"payment_ref"` // ID stored in payments.provider_payment_id
+	Type       string    `json:"type"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	Created    time.Time `json:"created"`
+}
+
+// LocalPayment is a deposit from the payments table with its ledger amount.
+type LocalPayment struct {
+	ID                uuid.UUID  `json:"id"`
+	ProviderPaymentID string     `json:"provider_payment_id"`
+	Amount            int64      `json:"amount"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"`
+	TransactionID     *uuid.UUID `json:"transaction_id,omitempty"`
+	LedgerAmount      *int64     `json:"ledger_amount,omitempty"`
+}
+
+// Discrepancy is a single reconciliation finding.
+type Discrepancy struct {
+	Kind           DiscrepancyKind `json:"kind"`
+	ProviderRef    string          `json:"provider_ref,omitempty"`
+	PaymentID      *uuid.UUID      `json:"payment_id,omitempty"`
+	ExpectedAmount *int64          `json:"expected_amount,omitempty"` // provider side
+	ActualAmount   *int64          `json:"actual_amount,omitempty"`   // local side
+	Detail         string          `json:"detail,omitempty"`
+}
+
+// Result is the outcome of matching one period.
+type Result struct {
+	Matched       int           `json:"matched"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// MatchDeposits pairs provider charge records with local deposits by payment
+// reference. Every provider charge must have a completed payment with equal
+// amount, currency and ledger entry; every completed local deposit in the
+// period must appear at the provider.
+func MatchDeposits(records []ProviderRecord, payments []LocalPayment) Result {
+	res := Result{Discrepancies: []Discrepancy{}}
+
+	byRef := make(map[string]*LocalPayment, len(payments))
+	for i := range payments {
+		if payments[i].ProviderPaymentID != "" {
+			byRef[payments[i].ProviderPaymentID] = &payments[i]
+		}
+	}
+	seen := make(map[uuid.UUID]bool, len(payments))
+
+	for _, rec := range records {
+		amount := rec.Amount
+		p, ok := byRef[rec.PaymentRef]
+		if rec.PaymentRef == "" || !ok {
+			res.Discrepancies = append(res.Discrepancies, Discrepancy{
+				Kind: MissingLocal, ProviderRef: rec.ID, ExpectedAmount: &amount,
+				Detail: "no payment for " + nonEmpty(rec.PaymentRef, rec.ID),
+			})
+			continue
+		}
+		seen[p.ID] = true
+		id := p.ID
+		local := p.Amount
+
+		clean := true
+		if !strings.EqualFold(rec.Currency, p.Currency) {
+			clean = false
+			res.Discrepancies = append(res.Discrepancies, Discrepancy{
+				Kind: CurrencyMismatch, ProviderRef: rec.ID, PaymentID: &id,
+				Detail: strings.ToUpper(rec.Currency) + " vs " + strings.ToUpper(p.Currency),
+			})
+		}
+		if rec.Amount != p.Amount {
+			clean = false
+			res.Discrepancies = append(res.Discrepancies, Discrepancy{
+				Kind: AmountMismatch, ProviderRef: rec.ID, PaymentID: &id,
+				ExpectedAmount: &amount, ActualAmount: &local,
+			})
+		}
+		if p.Status != "completed" {
+			clean = false
+			res.Discrepancies = append(res.Discrepancies, Discrepancy{
+				Kind: StatusMismatch, ProviderRef: rec.ID, PaymentID: &id,
+				Detail: "payment status " + p.Status,
+			})
+		} else if d, bad := ledgerDiscrepancy(p); bad {
+			clean = false
+			d.ProviderRef = rec.ID
+			res.Discrepancies = append(res.Discrepancies, d)
+		}
+		if clean {
+			res.Matched++
+		}
+	}
+
+	for i := range payments {
+		p := &payments[i]
+		if seen[p.ID] || p.Status != "completed" {
+			continue
+		}
+		id := p.ID
+		local := p.Amount
+		res.Discrepancies = append(res.Discrepancies, Discrepancy{
+			Kind: MissingProvider, PaymentID: &id, ActualAmount: &local,
+			Detail: "no provider record for " + nonEmpty(p.ProviderPaymentID, p.ID.String()),
+		})
+	}
+
+	return res
+}
+
+func ledgerDiscrepancy(p *LocalPayment) (Discrepancy, bool) {
+	id := p.ID
+	expected := p.Amount
+	if p.TransactionID == nil || p.LedgerAmount == nil {
+		return Discrepancy{Kind: LedgerMismatch, PaymentID: &id, ExpectedAmount: &expected,
+			Detail: "completed payment has no ledger entry"}, true
+	}
+	if *p.LedgerAmount != p.Amount {
+		actual := *p.LedgerAmount
+		return Discrepancy{Kind: LedgerMismatch, PaymentID: &id, ExpectedAmount: &expected,
+			ActualAmount: &actual, Detail: "ledger amount differs from payment"}, true
+	}
+	return Discrepancy{}, false
+}
+
+func nonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
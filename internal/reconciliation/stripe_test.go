@@ -0,0 +1,63 @@
+package reconciliation
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completedDeposit(ref string, amount int64) LocalPayment {
+	txID := uuid.New()
+	return LocalPayment{
+		ID: uuid.New(), ProviderPaymentID: ref, Amount: amount, Currency: "EUR",
+		Status: "completed", TransactionID: &txID, LedgerAmount: &amount,
+	}
+}
+
+func TestMatchDeposits_AllMatch(t *testing.T) {
+	res := MatchDeposits(
+		[]ProviderRecord{{ID: "txn_1", PaymentRef: "pi_1", Amount: 5000, Currency: "eur"}},
+		[]LocalPayment{completedDeposit("pi_1", 5000)},
+	)
+	assert.Equal(t, 1, res.Matched)
+	assert.Empty(t, res.Discrepancies)
+}
+
+func TestMatchDeposits_FlagsMismatches(t *testing.T) {
+	short := completedDeposit("pi_amount", 4000)
+	pending := completedDeposit("pi_pending", 1000)
+	pending.Status = "pending"
+	orphan := completedDeposit("pi_orphan", 2500)
+	noLedger := completedDeposit("pi_noledger", 3000)
+	noLedger.TransactionID = nil
+	noLedger.LedgerAmount = nil
+
+	res := MatchDeposits([]ProviderRecord{
+		{ID: "txn_a", PaymentRef: "pi_amount", Amount: 5000, Currency: "eur"},
+		{ID: "txn_p", PaymentRef: "pi_pending", Amount: 1000, Currency: "eur"},
+		{ID: "txn_l", PaymentRef: "pi_noledger", Amount: 3000, Currency: "eur"},
+		{ID: "txn_x", PaymentRef: "pi_unknown", Amount: 700, Currency: "eur"},
+	}, []LocalPayment{short, pending, orphan, noLedger})
+
+	assert.Equal(t, 0, res.Matched)
+	kinds := map[DiscrepancyKind]int{}
+	for _, d := range res.Discrepancies {
+		kinds[d.Kind]++
+	}
+	assert.Equal(t, map[DiscrepancyKind]int{
+		AmountMismatch:  1,
+		StatusMismatch:  1,
+		LedgerMismatch:  1,
+		MissingLocal:    1,
+		MissingProvider: 1,
+	}, kinds)
+
+	for _, d := range res.Discrepancies {
+		if d.Kind == MissingProvider {
+			require.NotNil(t, d.PaymentID)
+			assert.Equal(t, orphan.ID, *d.PaymentID)
+		}
+	}
+}
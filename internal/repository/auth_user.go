@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -19,11 +21,11 @@ func NewPgAuthUserRepository() *PgAuthUserRepository {
 // FindByEmail returns an auth user by email, or nil if not found.
 func (r *PgAuthUserRepository) FindByEmail(ctx context.Context, db DBTX, email string) (*domain.AuthUser, error) {
 	row := db.QueryRow(ctx,
-		`SELECT id, email, password_hash, created_at, updated_at
+		`SELECT id, email, password_hash, password_reset_required, created_at, updated_at
 		 FROM auth_users WHERE email = $1`, email)
 
 	u := &domain.AuthUser{}
-	err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.CreatedAt, &u.UpdatedAt)
+	err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.PasswordResetRequired, &u.CreatedAt, &u.UpdatedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -41,10 +43,12 @@ func (r *PgAuthUserRepository) Create(ctx context.Context, db DBTX, user *domain
 	return err
 }
 
-// UpdatePasswordHash updates the password hash for the given email.
+// UpdatePasswordHash updates the password hash for the given email and clears
+// any forced-reset flag.
 func (r *PgAuthUserRepository) UpdatePasswordHash(ctx context.Context, db DBTX, email, hash string) error {
 	tag, err := db.Exec(ctx,
-		`UPDATE auth_users SET password_hash = $1, updated_at = now() WHERE email = $2`,
+		`UPDATE auth_users SET password_hash = $1, password_reset_required = false, updated_at = now()
+		 WHERE email = $2`,
 		hash, email)
 	if err != nil {
 		return err
@@ -54,3 +58,41 @@ func (r *PgAuthUserRepository) UpdatePasswordHash(ctx context.Context, db DBTX,
 	}
 	return nil
 }
+
+// SetPasswordResetRequired flags the account so login is refused until the
+// password has been reset.
+func (r *PgAuthUserRepository) SetPasswordResetRequired(ctx context.Context, db DBTX, email string) error {
+	tag, err := db.Exec(ctx,
+		`UPDATE auth_users SET password_reset_required = true, updated_at = now() WHERE email = $1`,
+		email)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("user", email)
+	}
+	return nil
+}
+
+// RevokeTokens stamps tokens_revoked_at so JWTs issued before now are rejected.
+func (r *PgAuthUserRepository) RevokeTokens(ctx context.Context, db DBTX, id uuid.UUID) (time.Time, error) {
+	var revokedAt time.Time
+	err := db.QueryRow(ctx,
+		`UPDATE auth_users SET tokens_revoked_at = now(), updated_at = now() WHERE id = $1 RETURNING tokens_revoked_at`,
+		id).Scan(&revokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, domain.ErrNotFound("user", id.String())
+	}
+	return revokedAt, err
+}
+
+// TokensRevokedAt returns the token revocation cutoff for a user, or nil if never revoked.
+func (r *PgAuthUserRepository) TokensRevokedAt(ctx context.Context, db DBTX, id uuid.UUID) (*time.Time, error) {
+	var revokedAt *time.Time
+	err := db.QueryRow(ctx,
+		`SELECT tokens_revoked_at FROM auth_users WHERE id = $1`, id).Scan(&revokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return revokedAt, err
+}
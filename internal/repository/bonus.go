@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type bonusRepo struct{}
+
+// NewBonusRepository returns a pgx-backed BonusRepository.
+func NewBonusRepository() BonusRepository {
+	return &bonusRepo{}
+}
+
+// playerBonusColumns selects a player_bonuses row (aliased pb, joined to
+// its bonus definition as b) for scanPlayerBonus. The legacy decimal and
+// nullable columns are normalised, and a bonus granted without an explicit
+// expiry expires days_until_expiry after it was granted.
+const playerBonusColumns = `pb.id, pb.player_id, pb.bonus_id, COALESCE(pb.status, 'active'),
+	COALESCE(pb.initial_amount, 0)::bigint, COALESCE(pb.wagering_requirement, 0)::bigint,
+	COALESCE(pb.wagered, 0)::bigint, ` + playerBonusExpiry + `, pb.payment_id, pb.created_at`
+
+const (
+	playerBonusFrom   = `player_bonuses pb LEFT JOIN bonuses b ON b.id = pb.bonus_id`
+	playerBonusExpiry = `COALESCE(pb.expires_at, pb.created_at + make_interval(days => b.days_until_expiry))`
+)
+
+func scanPlayerBonus(row pgx.Row) (*domain.PlayerBonus, error) {
+	var b domain.PlayerBonus
+	var bonusID *uuid.UUID
+	if err := row.Scan(&b.ID, &b.PlayerID, &bonusID, &b.Status, &b.InitialAmount,
+		&b.WageringRequirement, &b.Wagered, &b.ExpiresAt, &b.PaymentID, &b.CreatedAt); err != nil {
+		return nil, err
+	}
+	if bonusID != nil {
+		b.BonusID = *bonusID
+	}
+	return &b, nil
+}
+
+func (r *bonusRepo) FindGameWagering(ctx context.Context, db DBTX, manufacturerID, externalGameID string) (string, *int, error) {
+	var category string
+	var overrideBps *int
+	err := db.QueryRow(ctx, `
+		SELECT COALESCE(g.category, ''), g.wagering_contribution_bps
+		FROM games g
+		JOIN game_manufacturers m ON m.id = g.manufacturer_id
+		WHERE g.external_game_id = $2 AND (m.id = $1 OR lower(m.name) = lower($1))
+		LIMIT 1`, manufacturerID, externalGameID).Scan(&category, &overrideBps)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("find game wagering weight: %w", err)
+	}
+	return category, overrideBps, nil
+}
+
+func (r *bonusRepo) Insert(ctx context.Context, db DBTX, b *domain.PlayerBonus) error {
+	b.Status = domain.BonusStatusActive
+	b.Wagered = 0
+	err := db.QueryRow(ctx, `
+		INSERT INTO player_bonuses (player_id, bonus_id, status, initial_amount, wagering_requirement, wagered, expires_at, payment_id)
+		VALUES ($1, $2, $3, $4, $5, 0, $6, $7)
+		RETURNING id, created_at`,
+		b.PlayerID, b.BonusID, b.Status, b.InitialAmount, b.WageringRequirement, b.ExpiresAt, b.PaymentID,
+	).Scan(&b.ID, &b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert player bonus: %w", err)
+	}
+	return nil
+}
+
+func (r *bonusRepo) LockActive(ctx context.Context, db DBTX, playerID uuid.UUID) ([]domain.PlayerBonus, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+playerBonusColumns+` FROM `+playerBonusFrom+`
+		WHERE pb.player_id = $1 AND pb.status = 'active'
+		ORDER BY pb.created_at, pb.id
+		FOR UPDATE OF pb`, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("lock active bonuses: %w", err)
+	}
+	return collectPlayerBonuses(rows)
+}
+
+func (r *bonusRepo) LockByID(ctx context.Context, db DBTX, id uuid.UUID) (*domain.PlayerBonus, error) {
+	b, err := scanPlayerBonus(db.QueryRow(ctx, `
+		SELECT `+playerBonusColumns+` FROM `+playerBonusFrom+`
+		WHERE pb.id = $1
+		FOR UPDATE OF pb`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock player bonus: %w", err)
+	}
+	return b, nil
+}
+
+func (r *bonusRepo) ListExpired(ctx context.Context, db DBTX, now time.Time, limit int) ([]domain.PlayerBonus, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+playerBonusColumns+` FROM `+playerBonusFrom+`
+		WHERE pb.status = 'active' AND `+playerBonusExpiry+` <= $1
+		ORDER BY `+playerBonusExpiry+`
+		LIMIT $2`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list expired bonuses: %w", err)
+	}
+	return collectPlayerBonuses(rows)
+}
+
+func (r *bonusRepo) ListExpiring(ctx context.Context, db DBTX, now, until time.Time, limit int) ([]domain.PlayerBonus, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+playerBonusColumns+` FROM `+playerBonusFrom+`
+		WHERE pb.status = 'active' AND pb.expiry_reminded_at IS NULL
+		  AND `+playerBonusExpiry+` > $1 AND `+playerBonusExpiry+` <= $2
+		ORDER BY `+playerBonusExpiry+`
+		LIMIT $3`, now, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list expiring bonuses: %w", err)
+	}
+	return collectPlayerBonuses(rows)
+}
+
+func (r *bonusRepo) MarkReminded(ctx context.Context, db DBTX, id uuid.UUID) (bool, error) {
+	tag, err := db.Exec(ctx, `
+		UPDATE player_bonuses SET expiry_reminded_at = now()
+		WHERE id = $1 AND expiry_reminded_at IS NULL`, id)
+	if err != nil {
+		return false, fmt.Errorf("mark bonus reminded: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func collectPlayerBonuses(rows pgx.Rows) ([]domain.PlayerBonus, error) {
+	defer rows.Close()
+	var bonuses []domain.PlayerBonus
+	for rows.Next() {
+		b, err := scanPlayerBonus(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan player bonus: %w", err)
+		}
+		bonuses = append(bonuses, *b)
+	}
+	return bonuses, rows.Err()
+}
+
+func (r *bonusRepo) AddWagered(ctx context.Context, db DBTX, id uuid.UUID, amount int64, status domain.BonusStatus) error {
+	_, err := db.Exec(ctx, `
+		UPDATE player_bonuses SET wagered = COALESCE(wagered, 0) + $2, status = $3
+		WHERE id = $1`, id, amount, status)
+	if err != nil {
+		return fmt.Errorf("add bonus wagering: %w", err)
+	}
+	return nil
+}
+
+func (r *bonusRepo) SetStatus(ctx context.Context, db DBTX, id uuid.UUID, status domain.BonusStatus) error {
+	_, err := db.Exec(ctx, `UPDATE player_bonuses SET status = $2 WHERE id = $1`, id, status)
+	if err != nil {
+		return fmt.Errorf("set bonus status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type consentRepo struct{}
+
+// NewConsentRepository returns a pgx-backed ConsentRepository.
+func NewConsentRepository() ConsentRepository {
+	return &consentRepo{}
+}
+
+const consentColumns = `id, player_id, purpose, channel, granted, source, ip, created_at`
+
+func scanConsentRecord(row pgx.Row) (*domain.ConsentRecord, error) {
+	var r domain.ConsentRecord
+	if err := row.Scan(&r.ID, &r.PlayerID, &r.Purpose, &r.Channel, &r.Granted, &r.Source, &r.IP,
+		&r.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *consentRepo) Insert(ctx context.Context, db DBTX, c *domain.ConsentRecord) error {
+	err := db.QueryRow(ctx, `
+		INSERT INTO consents (player_id, purpose, channel, granted, source, ip)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		c.PlayerID, c.Purpose, c.Channel, c.Granted, c.Source, c.IP,
+	).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert consent: %w", err)
+	}
+	return nil
+}
+
+func (r *consentRepo) Current(ctx context.Context, db DBTX, playerID uuid.UUID) ([]domain.ConsentRecord, error) {
+	rows, err := db.Query(ctx, `
+		SELECT DISTINCT ON (purpose, channel) `+consentColumns+`
+		FROM consents
+		WHERE player_id = $1
+		ORDER BY purpose, channel, id DESC`, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("query current consents: %w", err)
+	}
+	return collectConsentRecords(rows)
+}
+
+func (r *consentRepo) History(ctx context.Context, db DBTX, playerID uuid.UUID, limit int) ([]domain.ConsentRecord, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+consentColumns+` FROM consents
+		WHERE player_id = $1
+		ORDER BY id DESC
+		LIMIT $2`, playerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query consent history: %w", err)
+	}
+	return collectConsentRecords(rows)
+}
+
+func (r *consentRepo) GrantedChannels(ctx context.Context, db DBTX, playerID uuid.UUID, purpose string) ([]string, error) {
+	rows, err := db.Query(ctx, `
+		SELECT channel FROM (
+			SELECT DISTINCT ON (channel) channel, granted
+			FROM consents
+			WHERE player_id = $1 AND purpose = $2
+			ORDER BY channel, id DESC
+		) latest
+		WHERE granted
+		ORDER BY channel`, playerID, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("query granted consent: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []string{}
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("scan granted consent: %w", err)
+		}
+		channels = append(channels, c)
+	}
+	return channels, rows.Err()
+}
+
+func collectConsentRecords(rows pgx.Rows) ([]domain.ConsentRecord, error) {
+	defer rows.Close()
+	records := []domain.ConsentRecord{}
+	for rows.Next() {
+		c, err := scanConsentRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan consent: %w", err)
+		}
+		records = append(records, *c)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type gameRoundRepo struct{}
+
+// NewGameRoundRepository returns a pgx-backed GameRoundRepository.
+func NewGameRoundRepository() GameRoundRepository {
+	return &gameRoundRepo{}
+}
+
+const gameRoundColumns = `id, player_id, manufacturer_id, round_id, game_id, currency, status,
+	stake_total, win_total, refund_total, opened_at, last_activity_at, closed_at`
+
+func scanGameRound(row pgx.Row) (*domain.GameRound, error) {
+	var g domain.GameRound
+	err := row.Scan(&g.ID, &g.PlayerID, &g.ManufacturerID, &g.RoundID, &g.GameID, &g.Currency, &g.Status,
+		&g.StakeTotal, &g.WinTotal, &g.RefundTotal, &g.OpenedAt, &g.LastActivityAt, &g.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *gameRoundRepo) RecordStake(ctx context.Context, db DBTX, playerID uuid.UUID, manufacturerID, roundID, gameID, currency string, amount int64) error {
+	_, err := db.Exec(ctx, `
+		INSERT INTO game_rounds (player_id, manufacturer_id, round_id, game_id, currency, stake_total)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)
+		ON CONFLICT (player_id, manufacturer_id, round_id) DO UPDATE
+		SET stake_total = game_rounds.stake_total + EXCLUDED.stake_total,
+		    game_id = COALESCE(game_rounds.game_id, EXCLUDED.game_id),
+		    status = 'open', closed_at = NULL, last_activity_at = now()`,
+		playerID, manufacturerID, roundID, gameID, currency, amount)
+	if err != nil {
+		return fmt.Errorf("record round stake: %w", err)
+	}
+	return nil
+}
+
+func (r *gameRoundRepo) RecordSettlement(ctx context.Context, db DBTX, playerID uuid.UUID, manufacturerID, roundID, currency string, win int64) error {
+	_, err := db.Exec(ctx, `
+		INSERT INTO game_rounds (player_id, manufacturer_id, round_id, currency, status, win_total, closed_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), 'closed', $5, now())
+		ON CONFLICT (player_id, manufacturer_id, round_id) DO UPDATE
+		SET win_total = game_rounds.win_total + EXCLUDED.win_total,
+		    status = CASE WHEN game_rounds.status = 'voided' THEN game_rounds.status ELSE 'closed' END,
+		    closed_at = COALESCE(game_rounds.closed_at, now()),
+		    last_activity_at = now()`,
+		playerID, manufacturerID, roundID, currency, win)
+	if err != nil {
+		return fmt.Errorf("record round settlement: %w", err)
+	}
+	return nil
+}
+
+func (r *gameRoundRepo) ListStale(ctx context.Context, db DBTX, idleSince time.Time, exempt []string, limit int) ([]domain.GameRound, error) {
+	if exempt == nil {
+		exempt = []string{}
+	}
+	rows, err := db.Query(ctx, `
+		SELECT `+gameRoundColumns+`
+		FROM game_rounds
+		WHERE status = 'open' AND last_activity_at <= $1 AND NOT (manufacturer_id = ANY($2))
+		ORDER BY last_activity_at ASC
+		LIMIT $3`, idleSince, exempt, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale rounds: %w", err)
+	}
+	defer rows.Close()
+
+	var rounds []domain.GameRound
+	for rows.Next() {
+		g, err := scanGameRound(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan game round: %w", err)
+		}
+		rounds = append(rounds, *g)
+	}
+	return rounds, rows.Err()
+}
+
+func (r *gameRoundRepo) LockOpen(ctx context.Context, db DBTX, id uuid.UUID, idleSince time.Time) (*domain.GameRound, error) {
+	g, err := scanGameRound(db.QueryRow(ctx, `
+		SELECT `+gameRoundColumns+`
+		FROM game_rounds
+		WHERE id = $1 AND status = 'open' AND last_activity_at <= $2
+		FOR UPDATE`, id, idleSince))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock game round: %w", err)
+	}
+	return g, nil
+}
+
+func (r *gameRoundRepo) MarkVoided(ctx context.Context, db DBTX, id uuid.UUID, refund int64) error {
+	_, err := db.Exec(ctx, `
+		UPDATE game_rounds
+		SET status = 'voided', refund_total = refund_total + $2, closed_at = now(), last_activity_at = now()
+		WHERE id = $1`, id, refund)
+	if err != nil {
+		return fmt.Errorf("void game round: %w", err)
+	}
+	return nil
+}
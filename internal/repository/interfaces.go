@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/google/uuid"
@@ -32,6 +33,22 @@ type PlayerRepository interface {
 	UpdateBalances(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, delta domain.BalanceUpdate) (*domain.Player, error)
 }
 
+// WalletRepository provides access to player_wallets (non-base currencies).
+type WalletRepository interface {
+	// ListByPlayer returns the player's secondary-currency wallets.
+	ListByPlayer(ctx context.Context, db DBTX, playerID uuid.UUID) ([]domain.Wallet, error)
+
+	// Create opens a zero-balance wallet. Returns false if it already exists.
+	Create(ctx context.Context, db DBTX, playerID uuid.UUID, currency string) (bool, error)
+
+	// LockForUpdate acquires a row-level lock on one wallet. Returns nil if absent.
+	LockForUpdate(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, currency string) (*domain.Wallet, error)
+
+	// UpdateBalances applies a balance delta with server-side arithmetic.
+	// Returns nil if the wallet does not exist.
+	UpdateBalances(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, currency string, delta domain.BalanceUpdate) (*domain.Wallet, error)
+}
+
 // TransactionRepository provides access to v2_transactions.
 type TransactionRepository interface {
 	// FindExisting checks the idempotency index for a duplicate transaction.
@@ -50,11 +67,116 @@ type TransactionRepository interface {
 	// ListByGameRound returns all transactions in a casino game round.
 	ListByGameRound(ctx context.Context, db DBTX, gameRoundID string) ([]domain.Transaction, error)
 
+	// ListByExternalID returns a provider's transactions for a player under one
+	// external transaction id, across all sub-transaction ids.
+	ListByExternalID(ctx context.Context, db DBTX, playerID uuid.UUID, manufacturerID, externalID string) ([]domain.Transaction, error)
+
+	// ListByReferences returns up to limit transactions whose id, target,
+	// external transaction id or game round id is one of refs, oldest first.
+	ListByReferences(ctx context.Context, db DBTX, refs []string, limit int) ([]domain.Transaction, error)
+
+	// FindByTarget returns the earliest transaction of the given type that
+	// targets another transaction, or nil if there is none.
+	FindByTarget(ctx context.Context, db DBTX, targetID uuid.UUID, txType domain.TransactionType) (*domain.Transaction, error)
+
 	// DailySumByType returns the total amount of transactions of the given type
 	// for a player since the start of the current calendar day (UTC).
 	DailySumByType(ctx context.Context, db DBTX, playerID uuid.UUID, txType string) (int64, error)
 }
 
+// GameRoundRepository provides access to game_rounds, the lifecycle of each
+// provider game round.
+type GameRoundRepository interface {
+	// RecordStake opens the round, or reopens a settled one, adding amount to
+	// its stake total. The round keeps the first non-empty gameID it sees.
+	RecordStake(ctx context.Context, db DBTX, playerID uuid.UUID, manufacturerID, roundID, gameID, currency string, amount int64) error
+
+	// RecordSettlement closes the round, adding win (possibly zero) to its win
+	// total. A voided round stays voided.
+	RecordSettlement(ctx context.Context, db DBTX, playerID uuid.UUID, manufacturerID, roundID, currency string, win int64) error
+
+	// ListStale returns open rounds idle since idleSince, oldest first,
+	// skipping the exempt manufacturers.
+	ListStale(ctx context.Context, db DBTX, idleSince time.Time, exempt []string, limit int) ([]domain.GameRound, error)
+
+	// LockOpen row-locks a round that is still open and idle since idleSince.
+	// Returns nil if it has since been settled or seen activity.
+	LockOpen(ctx context.Context, db DBTX, id uuid.UUID, idleSince time.Time) (*domain.GameRound, error)
+
+	// MarkVoided voids a round, recording the stakes refunded.
+	MarkVoided(ctx context.Context, db DBTX, id uuid.UUID, refund int64) error
+}
+
+// BonusRepository provides access to player_bonuses: the wagering state the
+// ledger updates on every bet, and the expiry of unfinished bonuses.
+type BonusRepository interface {
+	// FindGameWagering returns a catalogued game's category and per-game
+	// wagering weight override. The manufacturer matches the catalog's
+	// manufacturer by id or name. An uncatalogued game yields "" and nil.
+	FindGameWagering(ctx context.Context, db DBTX, manufacturerID, externalGameID string) (string, *int, error)
+
+	// Insert grants a player bonus, setting its ID and CreatedAt. The bonus
+	// starts active with nothing wagered.
+	Insert(ctx context.Context, db DBTX, b *domain.PlayerBonus) error
+
+	// LockActive row-locks the player's active bonuses, oldest first,
+	// including any past their expiry.
+	LockActive(ctx context.Context, db DBTX, playerID uuid.UUID) ([]domain.PlayerBonus, error)
+
+	// LockByID row-locks a player bonus. Returns nil if it does not exist.
+	LockByID(ctx context.Context, db DBTX, id uuid.UUID) (*domain.PlayerBonus, error)
+
+	// ListExpired returns active bonuses whose expiry has passed at now,
+	// longest expired first.
+	ListExpired(ctx context.Context, db DBTX, now time.Time, limit int) ([]domain.PlayerBonus, error)
+
+	// ListExpiring returns active bonuses expiring after now and by until
+	// whose player has not been reminded yet, soonest first.
+	ListExpiring(ctx context.Context, db DBTX, now, until time.Time, limit int) ([]domain.PlayerBonus, error)
+
+	// MarkReminded records that the player was reminded of the bonus's
+	// expiry. Returns false if they already were.
+	MarkReminded(ctx context.Context, db DBTX, id uuid.UUID) (bool, error)
+
+	// AddWagered adds amount to a bonus's wagered total and sets its status.
+	AddWagered(ctx context.Context, db DBTX, id uuid.UUID, amount int64, status domain.BonusStatus) error
+
+	// SetStatus moves a bonus to status.
+	SetStatus(ctx context.Context, db DBTX, id uuid.UUID, status domain.BonusStatus) error
+}
+
+// ConsentRepository provides access to consents: the append-only record of
+// players granting and withdrawing consent per purpose and channel.
+type ConsentRepository interface {
+	// Insert records a grant or withdrawal, setting its ID and CreatedAt.
+	Insert(ctx context.Context, db DBTX, r *domain.ConsentRecord) error
+
+	// Current returns the player's latest record per purpose and channel.
+	Current(ctx context.Context, db DBTX, playerID uuid.UUID) ([]domain.ConsentRecord, error)
+
+	// History returns the player's records, newest first.
+	History(ctx context.Context, db DBTX, playerID uuid.UUID, limit int) ([]domain.ConsentRecord, error)
+
+	// GrantedChannels returns the channels the player currently consents to
+	// for purpose; empty if none.
+	GrantedChannels(ctx context.Context, db DBTX, playerID uuid.UUID, purpose string) ([]string, error)
+}
+
+// LedgerEntryRepository provides access to ledger_entries (double-entry postings).
+type LedgerEntryRepository interface {
+	// Insert writes the postings for one transaction.
+	Insert(ctx context.Context, db DBTX, transactionID uuid.UUID, postings []domain.LedgerPosting) error
+
+	// Totals returns the sum of all debits and credits.
+	Totals(ctx context.Context, db DBTX) (debits, credits int64, err error)
+
+	// Unbalanced returns up to limit transactions whose debits and credits differ.
+	Unbalanced(ctx context.Context, db DBTX, limit int) ([]uuid.UUID, error)
+
+	// AccountBalances returns net positions for accounts starting with prefix.
+	AccountBalances(ctx context.Context, db DBTX, prefix string) ([]domain.AccountBalance, error)
+}
+
 // OutboxRepository provides access to the event_outbox table.
 type OutboxRepository interface {
 	// Insert writes an outbox event (within the same transaction as the ledger entry).
@@ -68,6 +190,22 @@ type OutboxRepository interface {
 
 	// MarkPublished deletes or marks events as published.
 	MarkPublished(ctx context.Context, db DBTX, ids []int64) error
+
+	// RecordFailure increments a row's attempt count and defers its next attempt.
+	RecordFailure(ctx context.Context, db DBTX, id int64, errMsg string, nextAttemptAt time.Time) error
+
+	// MoveToDLQ moves a row into event_outbox_dlq with the final error.
+	MoveToDLQ(ctx context.Context, db DBTX, id int64, errMsg string) error
+
+	// ListDLQ returns dead-lettered events, newest first.
+	ListDLQ(ctx context.Context, db DBTX, limit int) ([]domain.OutboxDeadLetter, error)
+
+	// Requeue moves a dead-lettered event back onto the outbox with a fresh
+	// retry budget. Returns false if the entry does not exist.
+	Requeue(ctx context.Context, db DBTX, dlqID int64) (bool, error)
+
+	// Stats returns the outbox backlog and dead-letter depth.
+	Stats(ctx context.Context, db DBTX) (OutboxStats, error)
 }
 
 // AuthUserRepository provides access to auth_users.
@@ -80,6 +218,15 @@ type AuthUserRepository interface {
 
 	// UpdatePasswordHash updates the password hash for the given email.
 	UpdatePasswordHash(ctx context.Context, db DBTX, email, hash string) error
+
+	// SetPasswordResetRequired forces a password reset before the next login.
+	SetPasswordResetRequired(ctx context.Context, db DBTX, email string) error
+
+	// RevokeTokens invalidates every JWT issued to the user before now.
+	RevokeTokens(ctx context.Context, db DBTX, id uuid.UUID) (time.Time, error)
+
+	// TokensRevokedAt returns the revocation cutoff, or nil if none is set.
+	TokensRevokedAt(ctx context.Context, db DBTX, id uuid.UUID) (*time.Time, error)
 }
 
 // ProfileRepository provides access to player_profiles.
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/infra"
+	"github.com/google/uuid"
+)
+
+type ledgerEntryRepo struct{}
+
+// NewLedgerEntryRepository returns a pgx-backed LedgerEntryRepository.
+func NewLedgerEntryRepository() LedgerEntryRepository {
+	return &ledgerEntryRepo{}
+}
+
+func (r *ledgerEntryRepo) Insert(ctx context.Context, db DBTX, transactionID uuid.UUID, postings []domain.LedgerPosting) error {
+	if len(postings) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(postings))
+	args := []interface{}{transactionID}
+	for _, p := range postings {
+		n := len(args)
+		values = append(values, fmt.Sprintf("($1, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4))
+		args = append(args, p.Account, string(p.Direction), infra.Int64ToNumeric(p.Amount), p.Currency)
+	}
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO ledger_entries (transaction_id, account, direction, amount, currency)
+		VALUES `+strings.Join(values, ", "), args...)
+	if err != nil {
+		return fmt.Errorf("insert ledger entries: %w", err)
+	}
+	return nil
+}
+
+func (r *ledgerEntryRepo) Totals(ctx context.Context, db DBTX) (debits, credits int64, err error) {
+	err = db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount) FILTER (WHERE direction = 'debit'), 0)::bigint,
+		       COALESCE(SUM(amount) FILTER (WHERE direction = 'credit'), 0)::bigint
+		FROM ledger_entries`).Scan(&debits, &credits)
+	if err != nil {
+		return 0, 0, fmt.Errorf("sum ledger entries: %w", err)
+	}
+	return debits, credits, nil
+}
+
+func (r *ledgerEntryRepo) Unbalanced(ctx context.Context, db DBTX, limit int) ([]uuid.UUID, error) {
+	rows, err := db.Query(ctx, `
+		SELECT transaction_id
+		FROM ledger_entries
+		GROUP BY transaction_id, currency
+		HAVING SUM(CASE WHEN direction = 'debit' THEN amount ELSE -amount END) <> 0
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query unbalanced entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan transaction id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *ledgerEntryRepo) AccountBalances(ctx context.Context, db DBTX, prefix string) ([]domain.AccountBalance, error) {
+	rows, err := db.Query(ctx, `
+		SELECT account, currency,
+		       COALESCE(SUM(amount) FILTER (WHERE direction = 'debit'), 0)::bigint,
+		       COALESCE(SUM(amount) FILTER (WHERE direction = 'credit'), 0)::bigint
+		FROM ledger_entries
+		WHERE left(account, length($1)) = $1
+		GROUP BY account, currency
+		ORDER BY account, currency`, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("query account balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := []domain.AccountBalance{}
+	for rows.Next() {
+		var b domain.AccountBalance
+		if err := rows.Scan(&b.Account, &b.Currency, &b.Debits, &b.Credits); err != nil {
+			return nil, fmt.Errorf("scan account balance: %w", err)
+		}
+		b.Net = b.Credits - b.Debits
+		balances = append(balances, b)
+	}
+	return balances, rows.Err()
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/jackc/pgx/v5"
@@ -62,17 +63,19 @@ func (r *outboxRepo) FetchUnpublished(ctx context.Context, db DBTX, limit int) (
 	return events, rows.Err()
 }
 
-// OutboxRow wraps an OutboxDraft with its database sequence ID.
+// OutboxRow wraps an OutboxDraft with its database sequence ID and retry state.
 type OutboxRow struct {
-	SeqID int64
+	SeqID    int64
+	Attempts int
 	domain.OutboxDraft
 }
 
 func (r *outboxRepo) FetchUnpublishedRows(ctx context.Context, db DBTX, limit int) ([]OutboxRow, error) {
 	rows, err := db.Query(ctx, `
-		SELECT "id", "eventId", "aggregateType", "aggregateId", "eventType",
-		       "partitionKey", "headers", "payload", "occurredAt"
+		SELECT "id", "attempts", "eventId", "aggregateType", "aggregateId", "eventType",
+		       COALESCE("partitionKey", ''), "headers", "payload", "occurredAt"
 		FROM event_outbox
+		WHERE "nextAttemptAt" IS NULL OR "nextAttemptAt" <= now()
 		ORDER BY "id" ASC
 		LIMIT $1`, limit)
 	if err != nil {
@@ -83,7 +86,7 @@ func (r *outboxRepo) FetchUnpublishedRows(ctx context.Context, db DBTX, limit in
 	var events []OutboxRow
 	for rows.Next() {
 		var row OutboxRow
-		err := rows.Scan(&row.SeqID, &row.EventID, &row.AggregateType, &row.AggregateID,
+		err := rows.Scan(&row.SeqID, &row.Attempts, &row.EventID, &row.AggregateType, &row.AggregateID,
 			&row.EventType, &row.PartitionKey, &row.Headers, &row.Payload, &row.OccurredAt)
 		if err != nil {
 			return nil, fmt.Errorf("scan outbox row: %w", err)
@@ -104,5 +107,107 @@ func (r *outboxRepo) MarkPublished(ctx context.Context, db DBTX, ids []int64) er
 	return nil
 }
 
+func (r *outboxRepo) RecordFailure(ctx context.Context, db DBTX, id int64, errMsg string, nextAttemptAt time.Time) error {
+	_, err := db.Exec(ctx, `
+		UPDATE event_outbox
+		SET "attempts" = "attempts" + 1, "lastError" = $2, "nextAttemptAt" = $3
+		WHERE "id" = $1`, id, errMsg, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("record outbox failure: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) MoveToDLQ(ctx context.Context, db DBTX, id int64, errMsg string) error {
+	_, err := db.Exec(ctx, `
+		WITH moved AS (
+			DELETE FROM event_outbox WHERE "id" = $1
+			RETURNING "eventId", "aggregateType", "aggregateId", "eventType", "partitionKey",
+			          "headers", "payload", "occurredAt", "attempts"
+		)
+		INSERT INTO event_outbox_dlq
+		  ("eventId", "aggregateType", "aggregateId", "eventType", "partitionKey",
+		   "headers", "payload", "occurredAt", "attempts", "lastError")
+		SELECT "eventId", "aggregateType", "aggregateId", "eventType", "partitionKey",
+		       "headers", "payload", "occurredAt", "attempts" + 1, $2
+		FROM moved
+		ON CONFLICT ("eventId") DO UPDATE
+		SET "attempts" = EXCLUDED."attempts", "lastError" = EXCLUDED."lastError", "failedAt" = now()`,
+		id, errMsg)
+	if err != nil {
+		return fmt.Errorf("move outbox row to dlq: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) ListDLQ(ctx context.Context, db DBTX, limit int) ([]domain.OutboxDeadLetter, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := db.Query(ctx, `
+		SELECT "id", "eventId", "aggregateType", "aggregateId", "eventType",
+		       COALESCE("partitionKey", ''), "headers", "payload", "occurredAt",
+		       "attempts", "lastError", "failedAt"
+		FROM event_outbox_dlq
+		ORDER BY "failedAt" DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list dlq: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.OutboxDeadLetter{}
+	for rows.Next() {
+		var e domain.OutboxDeadLetter
+		err := rows.Scan(&e.ID, &e.EventID, &e.AggregateType, &e.AggregateID, &e.EventType,
+			&e.PartitionKey, &e.Headers, &e.Payload, &e.OccurredAt,
+			&e.Attempts, &e.LastError, &e.FailedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan dlq row: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *outboxRepo) Requeue(ctx context.Context, db DBTX, dlqID int64) (bool, error) {
+	tag, err := db.Exec(ctx, `
+		WITH moved AS (
+			DELETE FROM event_outbox_dlq WHERE "id" = $1
+			RETURNING "eventId", "aggregateType", "aggregateId", "eventType", "partitionKey",
+			          "headers", "payload", "occurredAt"
+		)
+		INSERT INTO event_outbox
+		  ("eventId", "aggregateType", "aggregateId", "eventType", "partitionKey",
+		   "headers", "payload", "occurredAt")
+		SELECT "eventId", "aggregateType", "aggregateId", "eventType", "partitionKey",
+		       "headers", "payload", "occurredAt"
+		FROM moved`, dlqID)
+	if err != nil {
+		return false, fmt.Errorf("requeue dlq row: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// OutboxStats is a snapshot of the outbox backlog. OldestCreatedAt is nil
+// when nothing is waiting to be published.
+type OutboxStats struct {
+	Unpublished     int64
+	OldestCreatedAt *time.Time
+	DeadLettered    int64
+}
+
+func (r *outboxRepo) Stats(ctx context.Context, db DBTX) (OutboxStats, error) {
+	var s OutboxStats
+	err := db.QueryRow(ctx, `
+		SELECT (SELECT count(*) FROM event_outbox),
+		       (SELECT min("createdAt") FROM event_outbox),
+		       (SELECT count(*) FROM event_outbox_dlq)`).Scan(&s.Unpublished, &s.OldestCreatedAt, &s.DeadLettered)
+	if err != nil {
+		return s, fmt.Errorf("outbox stats: %w", err)
+	}
+	return s, nil
+}
+
 // scanOutboxRow is unused currently but reserved for single-row scans.
 var _ pgx.Row // keep import
@@ -20,6 +20,7 @@ type PaymentRepository interface {
 	UpdateStatus(ctx context.Context, db DBTX, id uuid.UUID, status domain.PaymentStatus, providerPaymentID *string, txID *uuid.UUID) error
 	ListByPlayer(ctx context.Context, db DBTX, playerID uuid.UUID, limit int) ([]domain.Payment, error)
 	FindByProviderSessionID(ctx context.Context, db DBTX, sessionID string) (*domain.Payment, error)
+	ListByReferences(ctx context.Context, db DBTX, refs []string, limit int) ([]domain.Payment, error)
 	InsertEvent(ctx context.Context, db DBTX, event *domain.PaymentEvent) error
 }
 
@@ -103,6 +104,36 @@ func (r *paymentRepo) ListByPlayer(ctx context.Context, db DBTX, playerID uuid.U
 	return payments, rows.Err()
 }
 
+// ListByReferences returns up to limit payments whose id, ledger
+// transaction, external transaction id or provider session or payment id is
+// one of refs, oldest first.
+func (r *paymentRepo) ListByReferences(ctx context.Context, db DBTX, refs []string, limit int) ([]domain.Payment, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, player_id, type, amount, currency, status,
+		       payment_method_id, external_transaction_id, transaction_id,
+		       provider, provider_session_id, provider_payment_id,
+		       approved_by, approved_at, metadata, created_at, updated_at
+		FROM payments
+		WHERE id = ANY($2) OR transaction_id = ANY($2) OR external_transaction_id = ANY($1)
+		   OR provider_session_id = ANY($1) OR provider_payment_id = ANY($1)
+		ORDER BY created_at ASC
+		LIMIT $3`, refs, uuidRefs(refs), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query payments by reference: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []domain.Payment{}
+	for rows.Next() {
+		p, err := scanPaymentRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, *p)
+	}
+	return payments, rows.Err()
+}
+
 func (r *paymentRepo) InsertEvent(ctx context.Context, db DBTX, event *domain.PaymentEvent) error {
 	raw := event.RawData
 	if raw == nil {
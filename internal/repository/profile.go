@@ -20,7 +20,7 @@ func NewPgProfileRepository() *PgProfileRepository {
 // FindByPlayerID returns a player profile, or nil if not found.
 func (r *PgProfileRepository) FindByPlayerID(ctx context.Context, db DBTX, playerID uuid.UUID) (*domain.PlayerProfile, error) {
 	row := db.QueryRow(ctx,
-		`SELECT player_id, email, first_name, last_name, date_of_birth,
+		`SELECT player_id, email, first_name, last_name, date_of_birth::text,
 		        country, currency, language, mobile_phone, address, post_code,
 		        verified, account_status, risk_profile, created_at
 		 FROM player_profiles WHERE player_id = $1`, playerID)
@@ -46,7 +46,7 @@ func (r *PgProfileRepository) Create(ctx context.Context, db DBTX, profile *doma
 		`INSERT INTO player_profiles (player_id, email, first_name, last_name, date_of_birth,
 		 country, currency, language, mobile_phone, address, post_code,
 		 verified, account_status, risk_profile)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		 VALUES ($1, $2, $3, $4, $5::text::date, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
 		profile.PlayerID, profile.Email, profile.FirstName, profile.LastName, profile.DateOfBirth,
 		profile.Country, profile.Currency, profile.Language, profile.MobilePhone, profile.Address, profile.PostCode,
 		profile.Verified, profile.AccountStatus, profile.RiskProfile,
@@ -58,7 +58,7 @@ func (r *PgProfileRepository) Create(ctx context.Context, db DBTX, profile *doma
 func (r *PgProfileRepository) Update(ctx context.Context, db DBTX, profile *domain.PlayerProfile) error {
 	_, err := db.Exec(ctx,
 		`UPDATE player_profiles SET
-		 first_name = $2, last_name = $3, date_of_birth = $4,
+		 first_name = $2, last_name = $3, date_of_birth = $4::text::date,
 		 country = $5, currency = $6, language = $7,
 		 mobile_phone = $8, address = $9, post_code = $10,
 		 verified = $11, account_status = $12, risk_profile = $13
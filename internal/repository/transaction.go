@@ -24,7 +24,7 @@ func (r *transactionRepo) FindExisting(ctx context.Context, db DBTX, key domain.
 	row := db.QueryRow(ctx, `
 		SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 		       external_transaction_id, manufacturer_id, sub_transaction_id,
-		       target_transaction_id, game_round_id, metadata, created_at
+		       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
 		FROM v2_transactions
 		WHERE player_id = $1 AND manufacturer_id = $2
 		  AND external_transaction_id = $3 AND sub_transaction_id = $4`,
@@ -42,11 +42,12 @@ func (r *transactionRepo) Insert(ctx context.Context, db DBTX, params domain.Pos
 		INSERT INTO v2_transactions
 		  (player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 		   external_transaction_id, manufacturer_id, sub_transaction_id,
-		   target_transaction_id, game_round_id, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		   target_transaction_id, game_round_id, metadata, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+		        COALESCE(NULLIF($13, ''), (SELECT currency FROM v2_players WHERE id = $1)))
 		RETURNING id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 		          external_transaction_id, manufacturer_id, sub_transaction_id,
-		          target_transaction_id, game_round_id, metadata, created_at`,
+		          target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')`,
 		params.PlayerID,
 		string(params.Type),
 		infra.Int64ToNumeric(params.Amount),
@@ -59,6 +60,7 @@ func (r *transactionRepo) Insert(ctx context.Context, db DBTX, params domain.Pos
 		params.TargetTransactionID,
 		params.GameRoundID,
 		meta,
+		params.Currency,
 	)
 	return scanTransaction(row)
 }
@@ -67,7 +69,7 @@ func (r *transactionRepo) FindByID(ctx context.Context, db DBTX, id uuid.UUID) (
 	row := db.QueryRow(ctx, `
 		SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 		       external_transaction_id, manufacturer_id, sub_transaction_id,
-		       target_transaction_id, game_round_id, metadata, created_at
+		       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
 		FROM v2_transactions WHERE id = $1`, id)
 	return scanTransaction(row)
 }
@@ -83,7 +85,7 @@ func (r *transactionRepo) ListByPlayer(ctx context.Context, db DBTX, playerID uu
 		rows, err = db.Query(ctx, `
 			SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 			       external_transaction_id, manufacturer_id, sub_transaction_id,
-			       target_transaction_id, game_round_id, metadata, created_at
+			       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
 			FROM v2_transactions
 			WHERE player_id = $1
 			  AND (created_at, id) <= ((SELECT created_at, id FROM v2_transactions WHERE id = $2))
@@ -93,7 +95,7 @@ func (r *transactionRepo) ListByPlayer(ctx context.Context, db DBTX, playerID uu
 		rows, err = db.Query(ctx, `
 			SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 			       external_transaction_id, manufacturer_id, sub_transaction_id,
-			       target_transaction_id, game_round_id, metadata, created_at
+			       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
 			FROM v2_transactions
 			WHERE player_id = $1
 			ORDER BY created_at DESC, id DESC
@@ -111,7 +113,7 @@ func (r *transactionRepo) ListByGameRound(ctx context.Context, db DBTX, gameRoun
 	rows, err := db.Query(ctx, `
 		SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
 		       external_transaction_id, manufacturer_id, sub_transaction_id,
-		       target_transaction_id, game_round_id, metadata, created_at
+		       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
 		FROM v2_transactions
 		WHERE game_round_id = $1
 		ORDER BY created_at ASC`, gameRoundID)
@@ -123,6 +125,64 @@ func (r *transactionRepo) ListByGameRound(ctx context.Context, db DBTX, gameRoun
 	return collectTransactions(rows)
 }
 
+func (r *transactionRepo) ListByReferences(ctx context.Context, db DBTX, refs []string, limit int) ([]domain.Transaction, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
+		       external_transaction_id, manufacturer_id, sub_transaction_id,
+		       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
+		FROM v2_transactions
+		WHERE id = ANY($2) OR target_transaction_id = ANY($2)
+		   OR external_transaction_id = ANY($1) OR game_round_id = ANY($1)
+		ORDER BY created_at ASC, id ASC
+		LIMIT $3`, refs, uuidRefs(refs), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions by reference: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTransactions(rows)
+}
+
+// uuidRefs returns the references that parse as UUIDs, for matching the
+// uuid columns of a reference search by index.
+func uuidRefs(refs []string) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, ref := range refs {
+		if id, err := uuid.Parse(ref); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *transactionRepo) ListByExternalID(ctx context.Context, db DBTX, playerID uuid.UUID, manufacturerID, externalID string) ([]domain.Transaction, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
+		       external_transaction_id, manufacturer_id, sub_transaction_id,
+		       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
+		FROM v2_transactions
+		WHERE player_id = $1 AND manufacturer_id = $2 AND external_transaction_id = $3
+		ORDER BY created_at ASC, sub_transaction_id ASC`, playerID, manufacturerID, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("query external transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTransactions(rows)
+}
+
+func (r *transactionRepo) FindByTarget(ctx context.Context, db DBTX, targetID uuid.UUID, txType domain.TransactionType) (*domain.Transaction, error) {
+	row := db.QueryRow(ctx, `
+		SELECT id, player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
+		       external_transaction_id, manufacturer_id, sub_transaction_id,
+		       target_transaction_id, game_round_id, metadata, created_at, COALESCE(currency, '')
+		FROM v2_transactions
+		WHERE target_transaction_id = $1 AND type = $2
+		ORDER BY created_at ASC
+		LIMIT 1`, targetID, string(txType))
+	return scanTransaction(row)
+}
+
 func (r *transactionRepo) DailySumByType(ctx context.Context, db DBTX, playerID uuid.UUID, txType string) (int64, error) {
 	now := time.Now().UTC()
 	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
@@ -145,7 +205,7 @@ func scanTransaction(row pgx.Row) (*domain.Transaction, error) {
 		&tx.ID, &tx.PlayerID, &tx.Type,
 		&amountNum, &balNum, &bonusNum, &reservedNum,
 		&tx.ExternalTransactionID, &tx.ManufacturerID, &tx.SubTransactionID,
-		&tx.TargetTransactionID, &tx.GameRoundID, &tx.Metadata, &tx.CreatedAt,
+		&tx.TargetTransactionID, &tx.GameRoundID, &tx.Metadata, &tx.CreatedAt, &tx.Currency,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -184,7 +244,7 @@ func collectTransactions(rows pgx.Rows) ([]domain.Transaction, error) {
 			&tx.ID, &tx.PlayerID, &tx.Type,
 			&amountNum, &balNum, &bonusNum, &reservedNum,
 			&tx.ExternalTransactionID, &tx.ManufacturerID, &tx.SubTransactionID,
-			&tx.TargetTransactionID, &tx.GameRoundID, &tx.Metadata, &tx.CreatedAt,
+			&tx.TargetTransactionID, &tx.GameRoundID, &tx.Metadata, &tx.CreatedAt, &tx.Currency,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan transaction row: %w", err)
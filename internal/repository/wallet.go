@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/infra"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type walletRepo struct{}
+
+// NewWalletRepository returns a pgx-backed WalletRepository.
+func NewWalletRepository() WalletRepository {
+	return &walletRepo{}
+}
+
+func (r *walletRepo) ListByPlayer(ctx context.Context, db DBTX, playerID uuid.UUID) ([]domain.Wallet, error) {
+	rows, err := db.Query(ctx, `
+		SELECT player_id, currency, balance, bonus_balance, reserved_balance, updated_at
+		FROM player_wallets WHERE player_id = $1
+		ORDER BY currency`, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("query wallets: %w", err)
+	}
+	defer rows.Close()
+
+	var wallets []domain.Wallet
+	for rows.Next() {
+		w, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, *w)
+	}
+	return wallets, rows.Err()
+}
+
+func (r *walletRepo) Create(ctx context.Context, db DBTX, playerID uuid.UUID, currency string) (bool, error) {
+	tag, err := db.Exec(ctx, `
+		INSERT INTO player_wallets (player_id, currency) VALUES ($1, $2)
+		ON CONFLICT (player_id, currency) DO NOTHING`, playerID, currency)
+	if err != nil {
+		return false, fmt.Errorf("insert wallet: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *walletRepo) LockForUpdate(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, currency string) (*domain.Wallet, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT player_id, currency, balance, bonus_balance, reserved_balance, updated_at
+		FROM player_wallets WHERE player_id = $1 AND currency = $2 FOR UPDATE`, playerID, currency)
+	return scanWallet(row)
+}
+
+// UpdateBalances mirrors playerRepo.UpdateBalances for a secondary wallet.
+func (r *walletRepo) UpdateBalances(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, currency string, delta domain.BalanceUpdate) (*domain.Wallet, error) {
+	setClauses := []string{"updated_at = now()"}
+	args := []interface{}{}
+	argIdx := 1
+
+	if delta.HasBalanceDelta() {
+		setClauses = append(setClauses, fmt.Sprintf("balance = balance + $%d", argIdx))
+		args = append(args, infra.Int64ToNumeric(delta.Balance))
+		argIdx++
+	}
+	if delta.HasBonusDelta() {
+		setClauses = append(setClauses, fmt.Sprintf("bonus_balance = bonus_balance + $%d", argIdx))
+		args = append(args, infra.Int64ToNumeric(delta.BonusBalance))
+		argIdx++
+	}
+	if delta.HasReservedDelta() {
+		setClauses = append(setClauses, fmt.Sprintf("reserved_balance = reserved_balance + $%d", argIdx))
+		args = append(args, infra.Int64ToNumeric(delta.ReservedBalance))
+		argIdx++
+	}
+
+	args = append(args, playerID, currency)
+	query := fmt.Sprintf(`
+		UPDATE player_wallets SET %s
+		WHERE player_id = $%d AND currency = $%d
+		RETURNING player_id, currency, balance, bonus_balance, reserved_balance, updated_at`,
+		strings.Join(setClauses, ", "), argIdx, argIdx+1)
+
+	return scanWallet(tx.QueryRow(ctx, query, args...))
+}
+
+func scanWallet(row pgx.Row) (*domain.Wallet, error) {
+	var w domain.Wallet
+	var balNum, bonusNum, reservedNum pgtype.Numeric
+	err := row.Scan(&w.PlayerID, &w.Currency, &balNum, &bonusNum, &reservedNum, &w.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("scan wallet: %w", err)
+	}
+
+	var convErr error
+	if w.Balance, convErr = infra.NumericToInt64(balNum); convErr != nil {
+		return nil, fmt.Errorf("convert balance: %w", convErr)
+	}
+	if w.BonusBalance, convErr = infra.NumericToInt64(bonusNum); convErr != nil {
+		return nil, fmt.Errorf("convert bonus_balance: %w", convErr)
+	}
+	if w.ReservedBalance, convErr = infra.NumericToInt64(reservedNum); convErr != nil {
+		return nil, fmt.Errorf("convert reserved_balance: %w", convErr)
+	}
+	return &w, nil
+}
@@ -0,0 +1,435 @@
+// Package saga runs multi-step flows that span the ledger and external
+// systems, such as paying out a withdrawal through a PSP. Each saga's
+// progress is stored after every step, so a failed or interrupted flow is
+// retried, or its completed steps compensated, by whichever runner picks it
+// up next.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Step is one action of a saga and the compensation that undoes it. Neither
+// runs in a transaction the orchestrator holds, and either may run again
+// after a crash, so both must be idempotent. A nil Compensate has nothing to
+// undo.
+type Step struct {
+	Name       string
+	Action     func(ctx context.Context, s *domain.Saga) error
+	Compensate func(ctx context.Context, s *domain.Saga) error
+	// Pivot marks a step that cannot be undone, such as money leaving
+	// through a PSP. Once it succeeds the saga can only go forward: a later
+	// step that gives up fails the saga for an operator instead of
+	// compensating. The pivot itself compensates only on a Permanent
+	// failure; when it gives up after retrying, it may have taken effect
+	// without saying so (a PSP timeout), so the saga fails for an operator
+	// too.
+	Pivot bool
+}
+
+// pastPivot reports whether any of the first completed steps is a pivot.
+func (d Definition) pastPivot(completed int) bool {
+	for _, step := range d.Steps[:completed] {
+		if step.Pivot {
+			return true
+		}
+	}
+	return false
+}
+
+// giveUp returns the status a saga moves to when the action of step index
+// is given up on: compensating, unless that could undo a pivot that took
+// effect, in which case failed.
+func (d Definition) giveUp(index int, permanent bool) domain.SagaStatus {
+	if d.pastPivot(index) || (d.Steps[index].Pivot && !permanent) {
+		return domain.SagaFailed
+	}
+	return domain.SagaCompensating
+}
+
+// Definition is a kind of saga: its steps in order and how failing steps are
+// retried.
+type Definition struct {
+	Kind  string
+	Steps []Step
+	Retry policy.SagaRetryPolicy
+}
+
+// permanentError marks a failure retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as a failure retrying cannot fix, such as a PSP
+// decline: the saga compensates straight away.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// Load decodes a saga's data into v.
+func Load(s *domain.Saga, v any) error {
+	return json.Unmarshal(s.Data, v)
+}
+
+// Store replaces a saga's data with v. The orchestrator saves it once the
+// step that stored it succeeds.
+func Store(s *domain.Saga, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.Data = data
+	return nil
+}
+
+// defaultLease is how long a runner holds a saga before another may take it
+// over.
+const defaultLease = 5 * time.Minute
+
+const sagaColumns = `id, kind, reference, status, step, attempts, data, last_error,
+	next_attempt_at, created_at, updated_at`
+
+// Orchestrator starts sagas and advances them step by step.
+type Orchestrator struct {
+	pool   *pgxpool.Pool
+	defs   map[string]Definition
+	lease  time.Duration
+	logger *slog.Logger
+}
+
+// NewOrchestrator creates an Orchestrator with no saga kinds registered.
+func NewOrchestrator(pool *pgxpool.Pool, logger *slog.Logger) *Orchestrator {
+	return &Orchestrator{pool: pool, defs: map[string]Definition{}, lease: defaultLease, logger: logger}
+}
+
+// Register adds a kind of saga. Register every kind before starting the
+// processor.
+func (o *Orchestrator) Register(def Definition) {
+	o.defs[def.Kind] = def
+}
+
+// Start records a new saga of kind for reference with its initial data, in
+// db so it commits with the caller's own changes. Run advances it. Starting
+// a saga that already exists for the reference returns the existing one.
+func (o *Orchestrator) Start(ctx context.Context, db repository.DBTX, kind, reference string, data any) (*domain.Saga, error) {
+	if _, ok := o.defs[kind]; !ok {
+		return nil, fmt.Errorf("unknown saga kind %q", kind)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode saga data: %w", err)
+	}
+	s, err := scanSaga(db.QueryRow(ctx, `
+		INSERT INTO sagas (kind, reference, data) VALUES ($1, $2, $3)
+		ON CONFLICT (kind, reference) DO UPDATE SET kind = EXCLUDED.kind
+		RETURNING `+sagaColumns, kind, reference, raw))
+	if err != nil {
+		return nil, fmt.Errorf("insert saga: %w", err)
+	}
+	return s, nil
+}
+
+// Get returns a saga, or nil when it does not exist.
+func (o *Orchestrator) Get(ctx context.Context, id uuid.UUID) (*domain.Saga, error) {
+	s, err := scanSaga(o.pool.QueryRow(ctx, `SELECT `+sagaColumns+` FROM sagas WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return s, err
+}
+
+// List returns up to 100 sagas, most recently updated first, optionally
+// filtered by status.
+func (o *Orchestrator) List(ctx context.Context, status string) ([]domain.Saga, error) {
+	rows, err := o.pool.Query(ctx, `
+		SELECT `+sagaColumns+` FROM sagas
+		WHERE $1 = '' OR status = $1
+		ORDER BY updated_at DESC LIMIT 100`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Saga{}
+	for rows.Next() {
+		s, err := scanSaga(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+// Steps returns a saga's step log, oldest first.
+func (o *Orchestrator) Steps(ctx context.Context, id uuid.UUID) ([]domain.SagaStepLog, error) {
+	rows, err := o.pool.Query(ctx, `
+		SELECT step, name, phase, succeeded, error, created_at
+		FROM saga_step_log WHERE saga_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.SagaStepLog{}
+	for rows.Next() {
+		var l domain.SagaStepLog
+		if err := rows.Scan(&l.Step, &l.Name, &l.Phase, &l.Succeeded, &l.Error, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+// Run advances a saga as far as it can go now: through its remaining steps
+// to completion, or, once a step fails permanently or exhausts its retries,
+// back through the compensations of the steps already completed. A step
+// that fails but may yet succeed leaves the saga waiting for its next
+// attempt. Run returns the saga as it left it; a saga another runner holds
+// is returned as is.
+func (o *Orchestrator) Run(ctx context.Context, id uuid.UUID) (*domain.Saga, error) {
+	s, err := o.claim(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return o.Get(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim saga: %w", err)
+	}
+	def, ok := o.defs[s.Kind]
+	if !ok {
+		o.release(ctx, s.ID)
+		return nil, fmt.Errorf("unknown saga kind %q", s.Kind)
+	}
+
+	for {
+		var step Step
+		index, phase := s.Step, "action"
+		switch {
+		case s.Status == domain.SagaRunning && s.Step >= len(def.Steps):
+			s.Status = domain.SagaCompleted
+			return s, o.save(ctx, s, true)
+		case s.Status == domain.SagaRunning:
+			step = def.Steps[s.Step]
+		case s.Status == domain.SagaCompensating && s.Step == 0:
+			s.Status = domain.SagaCompensated
+			return s, o.save(ctx, s, true)
+		case s.Status == domain.SagaCompensating:
+			index, phase = s.Step-1, "compensation"
+			step = def.Steps[index]
+		default:
+			return s, o.save(ctx, s, true)
+		}
+
+		stepErr := o.runStep(ctx, s, step, phase)
+		o.logStep(ctx, s.ID, index, step.Name, phase, stepErr)
+		if stepErr == nil {
+			if phase == "action" {
+				s.Step++
+			} else {
+				s.Step--
+			}
+			s.Attempts, s.LastError = 0, nil
+			if err := o.save(ctx, s, false); err != nil {
+				return s, err
+			}
+			continue
+		}
+
+		msg := stepErr.Error()
+		s.Attempts++
+		s.LastError = &msg
+		decision := policy.EvaluateSagaRetry(def.Retry, s.Attempts, IsPermanent(stepErr))
+		switch {
+		case !decision.GiveUp:
+			s.NextAttemptAt = time.Now().Add(decision.Delay)
+			o.logger.Warn("saga step failed, will retry", "saga_id", s.ID, "kind", s.Kind,
+				"step", step.Name, "phase", phase, "attempts", s.Attempts, "error", stepErr)
+			return s, o.save(ctx, s, true)
+		case phase == "action" && def.giveUp(s.Step, IsPermanent(stepErr)) == domain.SagaFailed:
+			o.logger.Error("saga step failed at or after its pivot", "saga_id", s.ID, "kind", s.Kind,
+				"step", step.Name, "error", stepErr)
+			s.Status = domain.SagaFailed
+			return s, o.save(ctx, s, true)
+		case phase == "action":
+			o.logger.Warn("saga step failed, compensating", "saga_id", s.ID, "kind", s.Kind,
+				"step", step.Name, "error", stepErr)
+			s.Status, s.Attempts = domain.SagaCompensating, 0
+			if err := o.save(ctx, s, false); err != nil {
+				return s, err
+			}
+		default:
+			o.logger.Error("saga compensation failed", "saga_id", s.ID, "kind", s.Kind,
+				"step", step.Name, "error", stepErr)
+			s.Status = domain.SagaFailed
+			return s, o.save(ctx, s, true)
+		}
+	}
+}
+
+// Retry runs a saga waiting for its next attempt now, such as once an
+// operator knows the PSP is back. ok is false when the saga is not waiting.
+func (o *Orchestrator) Retry(ctx context.Context, id uuid.UUID) (s *domain.Saga, ok bool, err error) {
+	tag, err := o.pool.Exec(ctx, `
+		UPDATE sagas SET next_attempt_at = now(), updated_at = now()
+		WHERE id = $1 AND status IN ('running', 'compensating')`, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("reschedule saga: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		s, err = o.Get(ctx, id)
+		return s, false, err
+	}
+	s, err = o.Run(ctx, id)
+	return s, true, err
+}
+
+// runStep runs a step's action or compensation, turning a panic into a
+// failure so one bad step cannot take the runner down.
+func (o *Orchestrator) runStep(ctx context.Context, s *domain.Saga, step Step, phase string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("step %s panicked: %v", step.Name, r)
+		}
+	}()
+	if phase == "compensation" {
+		if step.Compensate == nil {
+			return nil
+		}
+		return step.Compensate(ctx, s)
+	}
+	return step.Action(ctx, s)
+}
+
+// claim takes the lease on a saga that is due, unfinished and not held by
+// another runner.
+func (o *Orchestrator) claim(ctx context.Context, id uuid.UUID) (*domain.Saga, error) {
+	return scanSaga(o.pool.QueryRow(ctx, `
+		UPDATE sagas SET locked_until = now() + $2 * interval '1 second', updated_at = now()
+		WHERE id = $1 AND status IN ('running', 'compensating')
+		  AND (locked_until IS NULL OR locked_until < now())
+		RETURNING `+sagaColumns, id, o.lease.Seconds()))
+}
+
+// save stores a saga's progress, extending its lease or, when done is set,
+// letting it go.
+func (o *Orchestrator) save(ctx context.Context, s *domain.Saga, done bool) error {
+	_, err := o.pool.Exec(ctx, `
+		UPDATE sagas
+		SET status = $2, step = $3, attempts = $4, data = $5, last_error = $6, next_attempt_at = $7,
+		    locked_until = CASE WHEN $8 THEN NULL ELSE now() + $9 * interval '1 second' END, updated_at = now()
+		WHERE id = $1`,
+		s.ID, s.Status, s.Step, s.Attempts, s.Data, s.LastError, s.NextAttemptAt, done, o.lease.Seconds())
+	if err != nil {
+		return fmt.Errorf("save saga: %w", err)
+	}
+	return nil
+}
+
+// release lets go of a saga without changing it.
+func (o *Orchestrator) release(ctx context.Context, id uuid.UUID) {
+	if _, err := o.pool.Exec(ctx, `UPDATE sagas SET locked_until = NULL WHERE id = $1`, id); err != nil {
+		o.logger.Error("release saga", "saga_id", id, "error", err)
+	}
+}
+
+// logStep records an attempt at a step in the saga's step log.
+func (o *Orchestrator) logStep(ctx context.Context, id uuid.UUID, index int, name, phase string, stepErr error) {
+	var msg *string
+	if stepErr != nil {
+		m := stepErr.Error()
+		msg = &m
+	}
+	_, err := o.pool.Exec(ctx, `
+		INSERT INTO saga_step_log (saga_id, step, name, phase, succeeded, error)
+		VALUES ($1, $2, $3, $4, $5, $6)`, id, index, name, phase, stepErr == nil, msg)
+	if err != nil {
+		o.logger.Error("log saga step", "saga_id", id, "step", name, "error", err)
+	}
+}
+
+// RunDue advances every unfinished saga whose next attempt is due, returning
+// how many it ran. A saga that fails to run is logged and left for the next
+// pass.
+func (o *Orchestrator) RunDue(ctx context.Context) (int, error) {
+	rows, err := o.pool.Query(ctx, `
+		SELECT id FROM sagas
+		WHERE status IN ('running', 'compensating') AND next_attempt_at <= now()
+		  AND (locked_until IS NULL OR locked_until < now())
+		ORDER BY next_attempt_at
+		LIMIT 100`)
+	if err != nil {
+		return 0, fmt.Errorf("query due sagas: %w", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan due saga: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate due sagas: %w", err)
+	}
+
+	for _, id := range ids {
+		if _, err := o.Run(ctx, id); err != nil {
+			o.logger.Error("run saga", "saga_id", id, "error", err)
+		}
+	}
+	return len(ids), nil
+}
+
+// StartProcessor resumes due sagas every interval until ctx is done.
+func (o *Orchestrator) StartProcessor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				o.logger.Info("saga processor stopped")
+				return
+			case <-ticker.C:
+				n, err := o.RunDue(ctx)
+				if err != nil {
+					o.logger.Error("run due sagas", "error", err)
+				} else if n > 0 {
+					o.logger.Info("ran due sagas", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+func scanSaga(row pgx.Row) (*domain.Saga, error) {
+	var s domain.Saga
+	err := row.Scan(&s.ID, &s.Kind, &s.Reference, &s.Status, &s.Step, &s.Attempts, &s.Data,
+		&s.LastError, &s.NextAttemptAt, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
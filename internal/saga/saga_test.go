@@ -0,0 +1,53 @@
+package saga
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermanent(t *testing.T) {
+	declined := errors.New("payout refused")
+
+	err := fmt.Errorf("payout: %w", Permanent(declined))
+	assert.True(t, IsPermanent(err))
+	assert.ErrorIs(t, err, declined)
+	assert.False(t, IsPermanent(errors.New("timeout")))
+	assert.False(t, IsPermanent(nil))
+}
+
+func TestLoadStore(t *testing.T) {
+	type data struct {
+		PaymentID string `json:"payment_id"`
+		Reference string `json:"reference,omitempty"`
+	}
+	s := &domain.Saga{}
+	require.NoError(t, Store(s, data{PaymentID: "p1"}))
+	assert.JSONEq(t, `{"payment_id":"p1"}`, string(s.Data))
+
+	var d data
+	require.NoError(t, Load(s, &d))
+	d.Reference = "psp-1"
+	require.NoError(t, Store(s, d))
+
+	var got data
+	require.NoError(t, Load(s, &got))
+	assert.Equal(t, data{PaymentID: "p1", Reference: "psp-1"}, got)
+}
+
+func TestDefinitionGiveUp(t *testing.T) {
+	def := Definition{Steps: []Step{
+		{Name: "reserve"},
+		{Name: "payout", Pivot: true},
+		{Name: "complete"},
+	}}
+
+	assert.Equal(t, domain.SagaCompensating, def.giveUp(0, false), "before the pivot")
+	assert.Equal(t, domain.SagaCompensating, def.giveUp(1, true), "pivot refused outright")
+	assert.Equal(t, domain.SagaFailed, def.giveUp(1, false), "pivot may have taken effect")
+	assert.Equal(t, domain.SagaFailed, def.giveUp(2, true), "after the pivot")
+}
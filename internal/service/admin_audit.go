@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MaxAdminActivityRows caps each view of the admin activity report.
+const MaxAdminActivityRows = 1000
+
+const adminAuditColumns = `id, admin_id, admin_email, role, method, route, path, player_id,
+	status_code, COALESCE(ip, ''), created_at`
+
+func scanAdminAuditEntry(row pgx.Row) (*domain.AdminAuditEntry, error) {
+	var e domain.AdminAuditEntry
+	if err := row.Scan(&e.ID, &e.AdminID, &e.AdminEmail, &e.Role, &e.Method, &e.Route, &e.Path,
+		&e.PlayerID, &e.StatusCode, &e.IP, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// AdminAuditService records admin API requests and aggregates them into the
+// admin activity report.
+type AdminAuditService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewAdminAuditService creates an AdminAuditService.
+func NewAdminAuditService(pool *pgxpool.Pool, logger *slog.Logger) *AdminAuditService {
+	return &AdminAuditService{pool: pool, logger: logger}
+}
+
+// Record appends an entry to the audit log. A failed write is logged rather
+// than returned, so auditing never fails the request it describes.
+func (s *AdminAuditService) Record(ctx context.Context, e domain.AdminAuditEntry) {
+	var ip *string
+	if e.IP != "" {
+		ip = &e.IP
+	}
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO admin_audit_log (admin_id, admin_email, role, method, route, path, player_id, status_code, ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		e.AdminID, e.AdminEmail, e.Role, e.Method, e.Route, e.Path, e.PlayerID, e.StatusCode, ip); err != nil {
+		s.logger.Error("record admin audit entry", "admin_id", e.AdminID, "route", e.Route, "error", err)
+	}
+}
+
+// AdminAuditFilter narrows ListEntries. Zero fields match everything.
+type AdminAuditFilter struct {
+	AdminID  *uuid.UUID
+	PlayerID *uuid.UUID
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
+
+// ListEntries returns audit log entries, newest first, flagging those
+// outside hours.
+func (s *AdminAuditService) ListEntries(ctx context.Context, f AdminAuditFilter, hours policy.BusinessHours) ([]domain.AdminAuditEntry, error) {
+	if f.Limit <= 0 || f.Limit > MaxAdminActivityRows {
+		f.Limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+adminAuditColumns+` FROM admin_audit_log
+		WHERE ($1::uuid IS NULL OR admin_id = $1)
+		  AND ($2::uuid IS NULL OR player_id = $2)
+		  AND created_at >= $3 AND created_at < $4
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5`, f.AdminID, f.PlayerID, f.From, f.To, f.Limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list admin audit log", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.AdminAuditEntry{}
+	for rows.Next() {
+		e, err := scanAdminAuditEntry(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan admin audit entry", err)
+		}
+		e.OutOfHours = hours.IsOutOfHours(e.CreatedAt)
+		entries = append(entries, *e)
+	}
+	return entries, rows.Err()
+}
+
+// AdminActivityReport aggregates the audit log between From and To.
+type AdminActivityReport struct {
+	From       time.Time                   `json:"from"`
+	To         time.Time                   `json:"to"`
+	TimeZone   string                      `json:"time_zone"`
+	Heatmap    []domain.AdminDailyActivity `json:"heatmap"`
+	TopPlayers []domain.PlayerTouches      `json:"top_players"`
+	OutOfHours []domain.AdminOutOfHours    `json:"out_of_hours"`
+}
+
+// ActivityReport builds the admin activity report: actions per admin per
+// day, the players admins touched most, and each admin's activity outside
+// hours. Days and hours are taken in hours.Location.
+func (s *AdminAuditService) ActivityReport(ctx context.Context, from, to time.Time, hours policy.BusinessHours) (*AdminActivityReport, error) {
+	report := &AdminActivityReport{From: from, To: to, TimeZone: hours.Location.String()}
+	var err error
+	if report.Heatmap, err = s.Heatmap(ctx, from, to, hours); err != nil {
+		return nil, err
+	}
+	if report.TopPlayers, err = s.TopPlayers(ctx, from, to, 50); err != nil {
+		return nil, err
+	}
+	if report.OutOfHours, err = s.OutOfHours(ctx, from, to, hours); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Heatmap returns the actions of each admin on each day, by day then
+// busiest admin.
+func (s *AdminAuditService) Heatmap(ctx context.Context, from, to time.Time, hours policy.BusinessHours) ([]domain.AdminDailyActivity, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT admin_id, max(admin_email), to_char(created_at AT TIME ZONE $3, 'YYYY-MM-DD') AS day,
+		       count(*), count(*) FILTER (WHERE method <> 'GET'),
+		       count(*) FILTER (WHERE status_code IN (401, 403))
+		FROM admin_audit_log
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY admin_id, day
+		ORDER BY day, count(*) DESC, admin_id
+		LIMIT $4`, from, to, hours.Location.String(), MaxAdminActivityRows)
+	if err != nil {
+		return nil, domain.ErrInternal("admin activity heatmap", err)
+	}
+	defer rows.Close()
+
+	cells := []domain.AdminDailyActivity{}
+	for rows.Next() {
+		var c domain.AdminDailyActivity
+		if err := rows.Scan(&c.AdminID, &c.AdminEmail, &c.Day, &c.Actions, &c.Writes, &c.Denied); err != nil {
+			return nil, domain.ErrInternal("scan admin activity", err)
+		}
+		cells = append(cells, c)
+	}
+	return cells, rows.Err()
+}
+
+// TopPlayers returns the players admins made the most requests about.
+func (s *AdminAuditService) TopPlayers(ctx context.Context, from, to time.Time, limit int) ([]domain.PlayerTouches, error) {
+	if limit <= 0 || limit > MaxAdminActivityRows {
+		limit = MaxAdminActivityRows
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT player_id, count(*), count(*) FILTER (WHERE method <> 'GET'),
+		       count(DISTINCT admin_id), max(created_at)
+		FROM admin_audit_log
+		WHERE player_id IS NOT NULL AND created_at >= $1 AND created_at < $2
+		GROUP BY player_id
+		ORDER BY count(*) DESC, player_id
+		LIMIT $3`, from, to, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("most touched players", err)
+	}
+	defer rows.Close()
+
+	players := []domain.PlayerTouches{}
+	for rows.Next() {
+		var p domain.PlayerTouches
+		if err := rows.Scan(&p.PlayerID, &p.Actions, &p.Writes, &p.Admins, &p.LastAt); err != nil {
+			return nil, domain.ErrInternal("scan player touches", err)
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// OutOfHours sums each admin's requests at weekends or outside the
+// business hours, busiest admin first.
+func (s *AdminAuditService) OutOfHours(ctx context.Context, from, to time.Time, hours policy.BusinessHours) ([]domain.AdminOutOfHours, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT admin_id, max(admin_email), count(*), count(*) FILTER (WHERE method <> 'GET'),
+		       min(created_at), max(created_at)
+		FROM admin_audit_log
+		WHERE created_at >= $1 AND created_at < $2
+		  AND (EXTRACT(ISODOW FROM created_at AT TIME ZONE $3) >= 6
+		       OR EXTRACT(HOUR FROM created_at AT TIME ZONE $3) < $4
+		       OR EXTRACT(HOUR FROM created_at AT TIME ZONE $3) >= $5)
+		GROUP BY admin_id
+		ORDER BY count(*) DESC, admin_id
+		LIMIT $6`, from, to, hours.Location.String(), hours.StartHour, hours.EndHour, MaxAdminActivityRows)
+	if err != nil {
+		return nil, domain.ErrInternal("out of hours activity", err)
+	}
+	defer rows.Close()
+
+	admins := []domain.AdminOutOfHours{}
+	for rows.Next() {
+		var a domain.AdminOutOfHours
+		if err := rows.Scan(&a.AdminID, &a.AdminEmail, &a.Actions, &a.Writes, &a.FirstAt, &a.LastAt); err != nil {
+			return nil, domain.ErrInternal("scan out of hours activity", err)
+		}
+		admins = append(admins, a)
+	}
+	return admins, rows.Err()
+}
@@ -6,11 +6,13 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/attaboy/platform/internal/auth"
 	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/policy"
 	"github.com/attaboy/platform/internal/repository"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -24,7 +26,10 @@ type AuthService struct {
 	users    repository.AuthUserRepository
 	players  repository.PlayerRepository
 	profiles repository.ProfileRepository
+	outbox   repository.OutboxRepository
 	jwtMgr   *auth.JWTManager
+	captcha  *CaptchaGate
+	ipRisk   *IPRiskService
 }
 
 // NewAuthService creates a new AuthService.
@@ -33,22 +38,33 @@ func NewAuthService(
 	users repository.AuthUserRepository,
 	players repository.PlayerRepository,
 	profiles repository.ProfileRepository,
+	outbox repository.OutboxRepository,
 	jwtMgr *auth.JWTManager,
+	captcha *CaptchaGate,
+	ipRisk *IPRiskService,
 ) *AuthService {
 	return &AuthService{
 		pool:     pool,
 		users:    users,
 		players:  players,
 		profiles: profiles,
+		outbox:   outbox,
 		jwtMgr:   jwtMgr,
+		captcha:  captcha,
+		ipRisk:   ipRisk,
 	}
 }
 
 // RegisterInput holds the registration request fields.
 type RegisterInput struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Currency string `json:"currency"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	Currency     string `json:"currency"`
+	DateOfBirth  string `json:"date_of_birth,omitempty"` // YYYY-MM-DD
+	Country      string `json:"country,omitempty"`       // ISO 3166-1 alpha-2, selects the minimum age
+	CaptchaToken string `json:"captcha_token,omitempty"`
+	IP           string `json:"-"`
+	Brand        string `json:"-"`
 }
 
 // AuthResult is returned on successful registration or login.
@@ -57,6 +73,9 @@ type AuthResult struct {
 	PlayerID uuid.UUID     `json:"player_id"`
 	Email    string        `json:"email"`
 	Balance  domain.Balances `json:"balance"`
+	// PendingTerms lists published documents the player must (re-)accept
+	// before money-moving routes open up again.
+	PendingTerms []domain.TermsDocument `json:"pending_terms,omitempty"`
 }
 
 // Register creates a new player account within a single transaction.
@@ -73,6 +92,29 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthR
 	if err := domain.ValidateCurrency(input.Currency); err != nil {
 		return nil, domain.ErrValidation(err.Error())
 	}
+	input.Country = strings.ToUpper(strings.TrimSpace(input.Country))
+	if input.Country != "" && len(input.Country) != 2 {
+		return nil, domain.ErrValidation("country must be a 2-letter ISO code")
+	}
+	var dob *string
+	if input.DateOfBirth != "" {
+		if err := policy.CheckMinimumAge(input.DateOfBirth, input.Country, time.Now()); err != nil {
+			return nil, err
+		}
+		d := strings.TrimSpace(input.DateOfBirth)
+		dob = &d
+	}
+
+	if err := s.captcha.Enforce(ctx, CaptchaCheck{
+		Brand: input.Brand, IP: input.IP, Email: input.Email, Token: input.CaptchaToken,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.ipRisk.Screen(ctx, IPScreen{
+		Checkpoint: policy.IPCheckpointRegistration, IP: input.IP, Reference: input.Email,
+	}); err != nil {
+		return nil, err
+	}
 
 	// Check for existing user
 	existing, err := s.users.FindByEmail(ctx, s.pool, input.Email)
@@ -118,14 +160,19 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthR
 	profile := &domain.PlayerProfile{
 		PlayerID:      playerID,
 		Email:         input.Email,
+		DateOfBirth:   dob,
+		Country:       input.Country,
 		Currency:      input.Currency,
 		Language:      "en",
-		AccountStatus: "active",
+		AccountStatus: string(domain.AccountStatusActive),
 		RiskProfile:   "low",
 	}
 	if err := s.profiles.Create(ctx, tx, profile); err != nil {
 		return nil, domain.ErrInternal("create profile", err)
 	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewPlayerCreatedEvent(playerID, input.Email, input.Currency)); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return nil, domain.ErrInternal("commit tx", err)
@@ -147,9 +194,26 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthR
 
 // LoginInput holds the login request fields.
 type LoginInput struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	IP       string `json:"-"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	CaptchaToken string `json:"captcha_token,omitempty"`
+	IP           string `json:"-"`
+	Brand        string `json:"-"`
+	Country      string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// loginAttempt builds the login history row for this input.
+func (in LoginInput) loginAttempt(playerID *uuid.UUID, success bool) guard.LoginAttempt {
+	return guard.LoginAttempt{
+		Email:     in.Email,
+		Realm:     "player",
+		PlayerID:  playerID,
+		IP:        in.IP,
+		Country:   in.Country,
+		UserAgent: in.UserAgent,
+		Success:   success,
+	}
 }
 
 // Login authenticates a player and returns a JWT.
@@ -158,21 +222,40 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*AuthResult,
 		return nil, err
 	}
 
+	if err := s.captcha.Enforce(ctx, CaptchaCheck{
+		Brand: input.Brand, IP: input.IP, Email: input.Email, Token: input.CaptchaToken,
+	}); err != nil {
+		return nil, err
+	}
+
 	user, err := s.users.FindByEmail(ctx, s.pool, input.Email)
 	if err != nil {
 		return nil, domain.ErrInternal("find user", err)
 	}
 	if user == nil {
-		guard.RecordAttempt(ctx, s.pool, input.Email, "player", input.IP, false)
+		guard.RecordAttemptDetail(ctx, s.pool, input.loginAttempt(nil, false))
 		return nil, domain.ErrUnauthorized("invalid credentials")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
-		guard.RecordAttempt(ctx, s.pool, input.Email, "player", input.IP, false)
+		guard.RecordAttemptDetail(ctx, s.pool, input.loginAttempt(&user.ID, false))
 		return nil, domain.ErrUnauthorized("invalid credentials")
 	}
 
-	guard.RecordAttempt(ctx, s.pool, input.Email, "player", input.IP, true)
+	if user.PasswordResetRequired {
+		return nil, &domain.AppError{
+			Code:    "PASSWORD_RESET_REQUIRED",
+			Message: "a password reset is required before signing in",
+			Status:  403,
+		}
+	}
+
+	guard.RecordAttemptDetail(ctx, s.pool, input.loginAttempt(&user.ID, true))
+
+	// Self-excluded, closed and suspended accounts get no session to play from
+	if err := guard.CheckAccountActive(ctx, s.pool, user.ID); err != nil {
+		return nil, err
+	}
 
 	// Fetch player for balance
 	player, err := s.players.FindByID(ctx, s.pool, user.ID)
@@ -188,11 +271,17 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*AuthResult,
 		return nil, domain.ErrInternal("generate token", err)
 	}
 
+	pending, err := guard.PendingTerms(ctx, s.pool, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AuthResult{
-		Token:    token,
-		PlayerID: user.ID,
-		Email:    user.Email,
-		Balance:  player.Balances,
+		Token:        token,
+		PlayerID:     user.ID,
+		Email:        user.Email,
+		Balance:      player.Balances,
+		PendingTerms: pending,
 	}, nil
 }
 
@@ -212,10 +301,20 @@ func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) (*
 		return &PasswordResetResult{Token: ""}, nil
 	}
 
-	// Generate 32-byte random token
+	tokenHex, err := issuePasswordResetToken(ctx, s.pool, email, time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasswordResetResult{Token: tokenHex}, nil
+}
+
+// issuePasswordResetToken stores the SHA-256 hash of a fresh 32-byte token and
+// returns the hex token for delivery to the player.
+func issuePasswordResetToken(ctx context.Context, db repository.DBTX, email string, ttl time.Duration) (string, error) {
 	rawToken := make([]byte, 32)
 	if _, err := rand.Read(rawToken); err != nil {
-		return nil, domain.ErrInternal("generate token", err)
+		return "", domain.ErrInternal("generate token", err)
 	}
 	tokenHex := hex.EncodeToString(rawToken)
 
@@ -223,17 +322,14 @@ func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) (*
 	hash := sha256.Sum256([]byte(tokenHex))
 	tokenHash := hex.EncodeToString(hash[:])
 
-	expiresAt := time.Now().Add(1 * time.Hour)
-
-	_, err = s.pool.Exec(ctx, `
+	_, err := db.Exec(ctx, `
 		INSERT INTO password_reset_tokens (email, realm, token_hash, expires_at)
 		VALUES ($1, 'player', $2, $3)`,
-		email, tokenHash, expiresAt)
+		email, tokenHash, time.Now().Add(ttl))
 	if err != nil {
-		return nil, domain.ErrInternal("store reset token", err)
+		return "", domain.ErrInternal("store reset token", err)
 	}
-
-	return &PasswordResetResult{Token: tokenHex}, nil
+	return tokenHex, nil
 }
 
 // ConfirmPasswordReset validates the token and updates the password.
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BetReceiptService issues signed sportsbook bet receipts. A receipt records
+// the bet as placed together with the hash of the terms the player had
+// accepted at the time; the HMAC signature lets support confirm a receipt
+// produced in a dispute was issued by the platform and not altered.
+type BetReceiptService struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+	key    []byte
+	logger *slog.Logger
+}
+
+// NewBetReceiptService creates a BetReceiptService signing with signingKey.
+func NewBetReceiptService(pool *pgxpool.Pool, outbox repository.OutboxRepository, signingKey []byte, logger *slog.Logger) *BetReceiptService {
+	return &BetReceiptService{pool: pool, outbox: outbox, key: signingKey, logger: logger}
+}
+
+// Receipt builds and signs the receipt for one of the player's bets.
+func (s *BetReceiptService) Receipt(ctx context.Context, playerID, betID uuid.UUID) (*domain.BetReceipt, error) {
+	var r domain.BetReceipt
+	var sel domain.BetReceiptSelection
+	var home, away, league *string
+	err := s.pool.QueryRow(ctx, `
+		SELECT b.id, b.player_id, b.placed_at, b.stake_amount_minor, b.currency, b.each_way,
+		       b.each_way_places, b.each_way_fraction, b.potential_payout_minor, b.transaction_id,
+		       COALESCE((
+		           SELECT a.content_hash FROM terms_acceptances a
+		           JOIN terms_documents d ON d.id = a.document_id
+		           WHERE a.player_id = b.player_id AND d.kind = $3 AND a.accepted_at <= b.placed_at
+		           ORDER BY d.version DESC LIMIT 1), ''),
+		       b.event_id, e.home_team, e.away_team, e.start_time, m.league,
+		       b.market_id, m.name, b.selection_id, s.name, b.odds_at_placement
+		FROM sports_bets b
+		JOIN sports_markets m ON m.id = b.market_id
+		JOIN sports_selections s ON s.id = b.selection_id
+		LEFT JOIN sports_events e ON e.id = b.event_id
+		WHERE b.id = $1 AND b.player_id = $2`,
+		betID, playerID, domain.TermsGeneral,
+	).Scan(&r.BetID, &r.PlayerID, &r.PlacedAt, &r.Stake, &r.Currency, &r.EachWay,
+		&r.EachWayPlaces, &r.EachWayFraction, &r.PotentialPayout, &r.TransactionID,
+		&r.TermsHash,
+		&sel.EventID, &home, &away, &sel.StartsAt, &league,
+		&sel.MarketID, &sel.Market, &sel.SelectionID, &sel.Selection, &sel.Odds)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("bet", betID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("query bet receipt", err)
+	}
+
+	switch {
+	case home != nil && away != nil:
+		sel.Event = *home + " v " + *away
+	case league != nil:
+		sel.Event = *league
+	default:
+		sel.Event = sel.Market
+	}
+	r.Selections = []domain.BetReceiptSelection{sel}
+	r.IssuedAt = time.Now().UTC().Truncate(time.Second)
+	r.Signature = r.Sign(s.key)
+	return &r, nil
+}
+
+// Email queues the receipt for delivery to the player's registered address;
+// the notification consumer sends the email from the outbox event.
+func (s *BetReceiptService) Email(ctx context.Context, playerID, betID uuid.UUID) (*domain.BetReceipt, error) {
+	receipt, err := s.Receipt(ctx, playerID, betID)
+	if err != nil {
+		return nil, err
+	}
+
+	var email string
+	if err := s.pool.QueryRow(ctx,
+		`SELECT email FROM player_profiles WHERE player_id = $1`, playerID).Scan(&email); err != nil {
+		return nil, domain.ErrInternal("query player email", err)
+	}
+	if err := s.outbox.Insert(ctx, s.pool, domain.NewBetReceiptRequestedEvent(*receipt, email)); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+	s.logger.Info("bet receipt email requested", "player_id", playerID, "bet_id", betID)
+	return receipt, nil
+}
+
+// Verify reports whether a receipt presented in a dispute carries a valid
+// signature.
+func (s *BetReceiptService) Verify(r domain.BetReceipt) bool {
+	return r.Verify(s.key)
+}
@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultFreeSpinDays is how long free rounds last when their bonus has no
+// expiry of its own.
+const defaultFreeSpinDays = 7
+
+// bonusColumns selects a bonuses row for scanBonus, normalising the legacy
+// nullable columns.
+const bonusColumns = `id, COALESCE(name, ''), COALESCE(code, ''), type,
+	COALESCE(wagering_multiplier, 0)::float8, COALESCE(min_deposit, 0)::bigint,
+	COALESCE(max_bonus, 0)::bigint, COALESCE(days_until_expiry, 0),
+	COALESCE(free_spins, 0), COALESCE(free_spin_value, 0), COALESCE(free_spin_games, '{}'),
+	COALESCE(match_percent, 0), COALESCE(active, false)`
+
+func scanBonus(row pgx.Row) (*domain.Bonus, error) {
+	var b domain.Bonus
+	if err := row.Scan(&b.ID, &b.Name, &b.Code, &b.Type, &b.WageringMultiplier, &b.MinDeposit,
+		&b.MaxBonus, &b.DaysUntilExpiry, &b.FreeSpins, &b.FreeSpinValue, &b.FreeSpinGames,
+		&b.MatchPercent, &b.Active); err != nil {
+		return nil, err
+	}
+	if len(b.FreeSpinGames) == 0 {
+		b.FreeSpinGames = nil
+	}
+	return &b, nil
+}
+
+const freeSpinGrantColumns = `id, player_id, bonus_id, provider, provider_grant_id, game_ids, rounds,
+	rounds_used, spin_value, total_win, status, player_bonus_id, granted_by, expires_at, created_at, updated_at`
+
+func scanFreeSpinGrant(row pgx.Row) (*domain.FreeSpinGrant, error) {
+	var g domain.FreeSpinGrant
+	if err := row.Scan(&g.ID, &g.PlayerID, &g.BonusID, &g.Provider, &g.ProviderGrantID, &g.GameIDs,
+		&g.Rounds, &g.RoundsUsed, &g.SpinValue, &g.TotalWin, &g.Status, &g.PlayerBonusID, &g.GrantedBy,
+		&g.ExpiresAt, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// BonusService manages bonus definitions and grants them to players. A cash
+// bonus is credited to the bonus balance straight away; a free_spins bonus
+// awards free rounds at the game provider, and their wins are credited to the
+// bonus balance as they are played.
+type BonusService struct {
+	pool       *pgxpool.Pool
+	engine     *ledger.Engine
+	bonuses    repository.BonusRepository
+	outbox     repository.OutboxRepository
+	freeRounds provider.FreeRoundsProvider
+	logger     *slog.Logger
+}
+
+// NewBonusService creates a BonusService. A nil freeRounds disables free
+// spins bonuses.
+func NewBonusService(
+	pool *pgxpool.Pool,
+	engine *ledger.Engine,
+	bonuses repository.BonusRepository,
+	outbox repository.OutboxRepository,
+	freeRounds provider.FreeRoundsProvider,
+	logger *slog.Logger,
+) *BonusService {
+	return &BonusService{pool: pool, engine: engine, bonuses: bonuses, outbox: outbox, freeRounds: freeRounds, logger: logger}
+}
+
+// ─── Definitions ────────────────────────────────────────────────────────────
+
+// ListBonuses returns bonus definitions, active ones first.
+func (s *BonusService) ListBonuses(ctx context.Context) ([]domain.Bonus, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+bonusColumns+` FROM bonuses
+		ORDER BY active DESC, name ASC LIMIT 50`)
+	if err != nil {
+		return nil, domain.ErrInternal("list bonuses", err)
+	}
+	defer rows.Close()
+
+	bonuses := []domain.Bonus{}
+	for rows.Next() {
+		b, err := scanBonus(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan bonus", err)
+		}
+		bonuses = append(bonuses, *b)
+	}
+	return bonuses, rows.Err()
+}
+
+// CreateBonus adds an active bonus definition. An empty type is a cash bonus.
+func (s *BonusService) CreateBonus(ctx context.Context, input domain.Bonus) (*domain.Bonus, error) {
+	if err := policy.ValidateBonus(input); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	if input.Type == "" {
+		input.Type = domain.BonusTypeCash
+	}
+	if input.Type != domain.BonusTypeFreeSpins {
+		input.FreeSpins, input.FreeSpinValue, input.FreeSpinGames = 0, 0, nil
+	}
+	if input.Type != domain.BonusTypeDepositMatch {
+		input.MatchPercent = 0
+	}
+
+	b, err := scanBonus(s.pool.QueryRow(ctx, `
+		INSERT INTO bonuses (name, code, type, wagering_multiplier, min_deposit, max_bonus, days_until_expiry,
+			free_spins, free_spin_value, free_spin_games, match_percent, active)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, NULLIF($8, 0), NULLIF($9, 0), $10, NULLIF($11, 0), true)
+		RETURNING `+bonusColumns,
+		input.Name, input.Code, input.Type, input.WageringMultiplier, input.MinDeposit, input.MaxBonus,
+		input.DaysUntilExpiry, input.FreeSpins, input.FreeSpinValue, input.FreeSpinGames, input.MatchPercent))
+	if err != nil {
+		return nil, domain.ErrInternal("create bonus", err)
+	}
+	return b, nil
+}
+
+// GetBonus returns a bonus definition.
+func (s *BonusService) GetBonus(ctx context.Context, id uuid.UUID) (*domain.Bonus, error) {
+	b, err := scanBonus(s.pool.QueryRow(ctx, `SELECT `+bonusColumns+` FROM bonuses WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("bonus", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get bonus", err)
+	}
+	return b, nil
+}
+
+// ─── Grants ─────────────────────────────────────────────────────────────────
+
+// BonusGrant is the result of granting a bonus: the player bonus of a cash
+// bonus, or the free rounds of a free_spins bonus.
+type BonusGrant struct {
+	PlayerBonus *domain.PlayerBonus   `json:"player_bonus,omitempty"`
+	FreeSpins   *domain.FreeSpinGrant `json:"free_spins,omitempty"`
+}
+
+// Grant awards an active bonus to a player. grantedBy is the admin granting
+// it, if any.
+func (s *BonusService) Grant(ctx context.Context, bonusID, playerID uuid.UUID, grantedBy *uuid.UUID) (*BonusGrant, error) {
+	b, err := s.GetBonus(ctx, bonusID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(b); err != nil {
+		return nil, err
+	}
+	var exists bool
+	if err := s.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM v2_players WHERE id = $1)`, playerID).Scan(&exists); err != nil {
+		return nil, domain.ErrInternal("find player", err)
+	}
+	if !exists {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+
+	if b.Type == domain.BonusTypeFreeSpins {
+		g, err := s.grantFreeSpins(ctx, b, playerID, grantedBy)
+		if err != nil {
+			return nil, err
+		}
+		return &BonusGrant{FreeSpins: g}, nil
+	}
+	pb, err := s.grantCash(ctx, b, playerID)
+	if err != nil {
+		return nil, err
+	}
+	return &BonusGrant{PlayerBonus: pb}, nil
+}
+
+// checkGrantable returns why b cannot be granted, or nil if it can.
+func (s *BonusService) checkGrantable(b *domain.Bonus) error {
+	switch {
+	case !b.Active:
+		return domain.ErrValidation("bonus is not active")
+	case b.Type == domain.BonusTypeDepositMatch:
+		return domain.ErrValidation("deposit match bonuses are awarded on deposit")
+	case b.Type == domain.BonusTypeFreeSpins && s.freeRounds == nil:
+		return domain.ErrValidation("free spins are not available")
+	case b.Type != domain.BonusTypeFreeSpins && b.MaxBonus <= 0:
+		return domain.ErrValidation("bonus has no amount to grant")
+	}
+	return nil
+}
+
+// grantCash records a player bonus of max_bonus and credits it to the bonus
+// balance.
+func (s *BonusService) grantCash(ctx context.Context, b *domain.Bonus, playerID uuid.UUID) (*domain.PlayerBonus, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	pb, err := s.awardBonus(ctx, tx, b, playerID, b.MaxBonus, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	meta, _ := json.Marshal(map[string]interface{}{
+		"bonus_id":        b.ID.String(),
+		"player_bonus_id": pb.ID.String(),
+	})
+	if _, err := s.engine.ExecuteBonusCredit(ctx, tx, domain.BonusCreditParams{
+		PlayerID:              playerID,
+		Amount:                b.MaxBonus,
+		ExternalTransactionID: "bonus-grant-" + pb.ID.String(),
+		Metadata:              meta,
+	}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("bonus granted", "bonus_id", b.ID, "player_id", playerID,
+		"player_bonus_id", pb.ID, "amount", b.MaxBonus)
+	return pb, nil
+}
+
+// awardBonus records an active player bonus of amount, with the bonus's
+// wagering requirement and expiry.
+func (s *BonusService) awardBonus(ctx context.Context, db repository.DBTX, b *domain.Bonus, playerID uuid.UUID, amount int64, now time.Time) (*domain.PlayerBonus, error) {
+	pb := newPlayerBonus(b, playerID, amount, now)
+	if err := s.insertBonus(ctx, db, pb); err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+// insertBonus records a player bonus and publishes its award to the outbox
+// in the same transaction.
+func (s *BonusService) insertBonus(ctx context.Context, db repository.DBTX, pb *domain.PlayerBonus) error {
+	if err := s.bonuses.Insert(ctx, db, pb); err != nil {
+		return domain.ErrInternal("award bonus", err)
+	}
+	if err := s.outbox.Insert(ctx, db, domain.NewBonusAwardedEvent(*pb)); err != nil {
+		return domain.ErrInternal("insert outbox event", err)
+	}
+	return nil
+}
+
+// newPlayerBonus builds a player bonus of amount with the bonus's wagering
+// requirement and expiry, ready to insert.
+func newPlayerBonus(b *domain.Bonus, playerID uuid.UUID, amount int64, now time.Time) *domain.PlayerBonus {
+	pb := &domain.PlayerBonus{
+		PlayerID:            playerID,
+		BonusID:             b.ID,
+		InitialAmount:       amount,
+		WageringRequirement: policy.BonusWageringRequirement(amount, b.WageringMultiplier),
+	}
+	if b.DaysUntilExpiry > 0 {
+		expires := now.AddDate(0, 0, b.DaysUntilExpiry)
+		pb.ExpiresAt = &expires
+	}
+	return pb
+}
+
+// grantFreeSpins awards the bonus's free rounds at the provider and records
+// the grant.
+func (s *BonusService) grantFreeSpins(ctx context.Context, b *domain.Bonus, playerID uuid.UUID, grantedBy *uuid.UUID) (*domain.FreeSpinGrant, error) {
+	days := b.DaysUntilExpiry
+	if days <= 0 {
+		days = defaultFreeSpinDays
+	}
+	id := uuid.New()
+	expiresAt := time.Now().AddDate(0, 0, days)
+
+	providerGrantID, err := s.freeRounds.GrantFreeRounds(ctx, provider.FreeRoundsGrant{
+		Reference: id.String(),
+		PlayerID:  playerID,
+		GameIDs:   b.FreeSpinGames,
+		Rounds:    b.FreeSpins,
+		Bet:       b.FreeSpinValue,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, domain.ErrInternal("grant free rounds", err)
+	}
+
+	g, err := scanFreeSpinGrant(s.pool.QueryRow(ctx, `
+		INSERT INTO free_spin_grants (id, player_id, bonus_id, provider, provider_grant_id, game_ids, rounds,
+			spin_value, granted_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING `+freeSpinGrantColumns,
+		id, playerID, b.ID, s.freeRounds.Name(), providerGrantID, b.FreeSpinGames, b.FreeSpins,
+		b.FreeSpinValue, grantedBy, expiresAt))
+	if err != nil {
+		// The provider already holds the rounds; log its id so they can be
+		// revoked by hand.
+		s.logger.Error("record free spin grant", "bonus_id", b.ID, "player_id", playerID,
+			"provider", s.freeRounds.Name(), "provider_grant_id", providerGrantID, "error", err)
+		return nil, domain.ErrInternal("record free spin grant", err)
+	}
+	s.logger.Info("free spins granted", "bonus_id", b.ID, "player_id", playerID, "grant_id", g.ID,
+		"rounds", g.Rounds, "spin_value", g.SpinValue)
+	return g, nil
+}
+
+// ListFreeSpins returns a player's free spin grants, newest first.
+func (s *BonusService) ListFreeSpins(ctx context.Context, playerID uuid.UUID) ([]domain.FreeSpinGrant, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+freeSpinGrantColumns+` FROM free_spin_grants
+		WHERE player_id = $1
+		ORDER BY created_at DESC LIMIT 50`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list free spins", err)
+	}
+	defer rows.Close()
+
+	grants := []domain.FreeSpinGrant{}
+	for rows.Next() {
+		g, err := scanFreeSpinGrant(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan free spin grant", err)
+		}
+		grants = append(grants, *g)
+	}
+	return grants, rows.Err()
+}
+
+// FreeSpinResult is the outcome of playing one free round.
+type FreeSpinResult struct {
+	Grant   *domain.FreeSpinGrant `json:"grant"`
+	RoundID string                `json:"round_id"`
+	Win     int64                 `json:"win"`
+}
+
+// PlayFreeSpin plays one of the player's free rounds on gameID, which must be
+// one of the grant's games; empty picks the first. The win is credited to the
+// bonus balance. Once every round is settled, the total win becomes a player
+// bonus carrying the bonus's wagering requirement.
+func (s *BonusService) PlayFreeSpin(ctx context.Context, playerID, grantID uuid.UUID, gameID string) (*FreeSpinResult, error) {
+	if s.freeRounds == nil {
+		return nil, domain.ErrValidation("free spins are not available")
+	}
+	g, round, err := s.startFreeRound(ctx, playerID, grantID, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if gameID == "" {
+		gameID = g.GameIDs[0]
+	}
+
+	played, err := s.freeRounds.PlayFreeRound(ctx, g.ProviderGrantID, gameID, g.SpinValue)
+	if err != nil {
+		// Hand the round back so the player can retry it.
+		if _, rerr := s.pool.Exec(ctx, `
+			UPDATE free_spin_grants SET rounds_used = rounds_used - 1, updated_at = now()
+			WHERE id = $1 AND rounds_used > rounds_settled`, g.ID); rerr != nil {
+			s.logger.Error("return free round", "grant_id", g.ID, "error", rerr)
+		}
+		return nil, domain.ErrInternal("play free round", err)
+	}
+
+	g, err = s.settleFreeRound(ctx, g.ID, round, gameID, played)
+	if err != nil {
+		return nil, err
+	}
+	return &FreeSpinResult{Grant: g, RoundID: played.RoundID, Win: played.Win}, nil
+}
+
+// startFreeRound claims the grant's next round and returns its number.
+func (s *BonusService) startFreeRound(ctx context.Context, playerID, grantID uuid.UUID, gameID string) (*domain.FreeSpinGrant, int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, 0, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	g, err := scanFreeSpinGrant(tx.QueryRow(ctx, `
+		SELECT `+freeSpinGrantColumns+` FROM free_spin_grants
+		WHERE id = $1 AND player_id = $2
+		FOR UPDATE`, grantID, playerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, domain.ErrNotFound("free spins", grantID.String())
+	}
+	if err != nil {
+		return nil, 0, domain.ErrInternal("lock free spin grant", err)
+	}
+	if g.Status != domain.FreeSpinStatusActive {
+		return nil, 0, domain.ErrConflict(fmt.Sprintf("free spins are %s", g.Status))
+	}
+	if !g.ExpiresAt.After(time.Now()) {
+		if _, err := tx.Exec(ctx, `
+			UPDATE free_spin_grants SET status = $2, updated_at = now() WHERE id = $1`,
+			g.ID, domain.FreeSpinStatusExpired); err != nil {
+			return nil, 0, domain.ErrInternal("expire free spin grant", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, 0, domain.ErrInternal("commit tx", err)
+		}
+		return nil, 0, domain.ErrConflict("free spins are expired")
+	}
+	if gameID != "" && !slices.Contains(g.GameIDs, gameID) {
+		return nil, 0, domain.ErrValidation("game is not eligible for these free spins")
+	}
+	if g.RoundsLeft() <= 0 {
+		return nil, 0, domain.ErrConflict("no free spins left")
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE free_spin_grants SET rounds_used = rounds_used + 1, updated_at = now()
+		WHERE id = $1`, g.ID); err != nil {
+		return nil, 0, domain.ErrInternal("start free round", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, domain.ErrInternal("commit tx", err)
+	}
+	g.RoundsUsed++
+	return g, g.RoundsUsed, nil
+}
+
+// settleFreeRound credits a played round's win and completes the grant once
+// every round is settled. The win of a round still in play when its grant
+// was revoked is not credited.
+func (s *BonusService) settleFreeRound(ctx context.Context, grantID uuid.UUID, round int, gameID string, played *provider.FreeRoundResult) (*domain.FreeSpinGrant, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var rounds, settled int
+	var totalWin int64
+	var playerID, bonusID uuid.UUID
+	var status domain.FreeSpinStatus
+	err = tx.QueryRow(ctx, `
+		UPDATE free_spin_grants
+		SET rounds_settled = rounds_settled + 1,
+		    total_win = total_win + CASE WHEN status = 'revoked' THEN 0 ELSE $2 END,
+		    updated_at = now()
+		WHERE id = $1
+		RETURNING player_id, bonus_id, rounds, rounds_settled, total_win, status`, grantID, played.Win,
+	).Scan(&playerID, &bonusID, &rounds, &settled, &totalWin, &status)
+	if err != nil {
+		return nil, domain.ErrInternal("settle free round", err)
+	}
+	revoked := status == domain.FreeSpinStatusRevoked
+
+	if played.Win > 0 && !revoked {
+		meta, _ := json.Marshal(map[string]interface{}{
+			"free_spin_grant_id": grantID.String(),
+			"round_id":           played.RoundID,
+			"game_id":            gameID,
+		})
+		if _, err := s.engine.ExecuteBonusCredit(ctx, tx, domain.BonusCreditParams{
+			PlayerID:              playerID,
+			Amount:                played.Win,
+			ExternalTransactionID: fmt.Sprintf("freespin-%s-%d", grantID, round),
+			Metadata:              meta,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if settled == rounds && !revoked {
+		var playerBonusID *uuid.UUID
+		if totalWin > 0 {
+			b, err := s.GetBonus(ctx, bonusID)
+			if err != nil {
+				return nil, err
+			}
+			pb, err := s.awardBonus(ctx, tx, b, playerID, totalWin, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			playerBonusID = &pb.ID
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE free_spin_grants SET status = $2, player_bonus_id = $3, updated_at = now()
+			WHERE id = $1`, grantID, domain.FreeSpinStatusCompleted, playerBonusID); err != nil {
+			return nil, domain.ErrInternal("complete free spin grant", err)
+		}
+		s.logger.Info("free spins completed", "grant_id", grantID, "player_id", playerID, "total_win", totalWin)
+	}
+
+	g, err := scanFreeSpinGrant(tx.QueryRow(ctx, `
+		SELECT `+freeSpinGrantColumns+` FROM free_spin_grants WHERE id = $1`, grantID))
+	if err != nil {
+		return nil, domain.ErrInternal("get free spin grant", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return g, nil
+}
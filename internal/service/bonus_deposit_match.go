@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const bonusOptInColumns = `id, player_id, bonus_id, status, player_bonus_id, created_at, updated_at`
+
+func scanBonusOptIn(row pgx.Row) (*domain.BonusOptIn, error) {
+	var o domain.BonusOptIn
+	if err := row.Scan(&o.ID, &o.PlayerID, &o.BonusID, &o.Status, &o.PlayerBonusID,
+		&o.CreatedAt, &o.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// ListDepositMatchOffers returns the active deposit_match bonuses players
+// can opt in to.
+func (s *BonusService) ListDepositMatchOffers(ctx context.Context) ([]domain.Bonus, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+bonusColumns+` FROM bonuses
+		WHERE type = $1 AND active
+		ORDER BY name ASC LIMIT 50`, domain.BonusTypeDepositMatch)
+	if err != nil {
+		return nil, domain.ErrInternal("list deposit match offers", err)
+	}
+	defer rows.Close()
+
+	bonuses := []domain.Bonus{}
+	for rows.Next() {
+		b, err := scanBonus(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan bonus", err)
+		}
+		bonuses = append(bonuses, *b)
+	}
+	return bonuses, rows.Err()
+}
+
+// ListOptIns returns a player's deposit match opt-ins, newest first.
+func (s *BonusService) ListOptIns(ctx context.Context, playerID uuid.UUID) ([]domain.BonusOptIn, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+bonusOptInColumns+` FROM bonus_opt_ins
+		WHERE player_id = $1
+		ORDER BY created_at DESC LIMIT 50`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list bonus opt-ins", err)
+	}
+	defer rows.Close()
+
+	optIns := []domain.BonusOptIn{}
+	for rows.Next() {
+		o, err := scanBonusOptIn(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan bonus opt-in", err)
+		}
+		optIns = append(optIns, *o)
+	}
+	return optIns, rows.Err()
+}
+
+// OptIn opts a player in to an active deposit_match bonus; their next
+// qualifying deposit is matched. Opting in again while the first opt-in is
+// still pending returns it unchanged.
+func (s *BonusService) OptIn(ctx context.Context, playerID, bonusID uuid.UUID) (*domain.BonusOptIn, error) {
+	b, err := s.GetBonus(ctx, bonusID)
+	if err != nil {
+		return nil, err
+	}
+	if b.Type != domain.BonusTypeDepositMatch {
+		return nil, domain.ErrValidation("bonus is not a deposit match bonus")
+	}
+	if !b.Active {
+		return nil, domain.ErrValidation("bonus is not active")
+	}
+
+	o, err := scanBonusOptIn(s.pool.QueryRow(ctx, `
+		INSERT INTO bonus_opt_ins (player_id, bonus_id)
+		VALUES ($1, $2)
+		ON CONFLICT (player_id, bonus_id) WHERE status = 'pending' DO NOTHING
+		RETURNING `+bonusOptInColumns, playerID, bonusID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		o, err = scanBonusOptIn(s.pool.QueryRow(ctx, `
+			SELECT `+bonusOptInColumns+` FROM bonus_opt_ins
+			WHERE player_id = $1 AND bonus_id = $2 AND status = 'pending'`, playerID, bonusID))
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("opt in to bonus", err)
+	}
+	return o, nil
+}
+
+// CancelOptIn withdraws a player's pending opt-in to a bonus.
+func (s *BonusService) CancelOptIn(ctx context.Context, playerID, bonusID uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE bonus_opt_ins SET status = 'cancelled', updated_at = now()
+		WHERE player_id = $1 AND bonus_id = $2 AND status = 'pending'`, playerID, bonusID)
+	if err != nil {
+		return domain.ErrInternal("cancel bonus opt-in", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("bonus opt-in", bonusID.String())
+	}
+	return nil
+}
+
+// ApplyDepositMatch awards the deposit_match bonus a player has opted in to
+// for a completed deposit, inside the caller's transaction so the match
+// commits or rolls back with the deposit. The oldest pending opt-in whose
+// bonus is still active and whose min_deposit the deposit meets is
+// claimed; it returns nil when there is none.
+func (s *BonusService) ApplyDepositMatch(ctx context.Context, tx pgx.Tx, payment *domain.Payment) (*domain.PlayerBonus, error) {
+	var optInID, bonusID uuid.UUID
+	err := tx.QueryRow(ctx, `
+		SELECT o.id, o.bonus_id
+		FROM bonus_opt_ins o
+		JOIN bonuses b ON b.id = o.bonus_id
+		WHERE o.player_id = $1 AND o.status = 'pending'
+		  AND b.type = $2 AND b.active AND COALESCE(b.min_deposit, 0) <= $3
+		ORDER BY o.created_at, o.id
+		LIMIT 1
+		FOR UPDATE OF o`, payment.PlayerID, domain.BonusTypeDepositMatch, payment.Amount,
+	).Scan(&optInID, &bonusID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find bonus opt-in", err)
+	}
+	b, err := scanBonus(tx.QueryRow(ctx, `SELECT `+bonusColumns+` FROM bonuses WHERE id = $1`, bonusID))
+	if err != nil {
+		return nil, domain.ErrInternal("get bonus", err)
+	}
+
+	amount := policy.DepositMatchAmount(payment.Amount, b.MinDeposit, b.MaxBonus, b.MatchPercent)
+	if amount <= 0 {
+		return nil, nil
+	}
+	pb := newPlayerBonus(b, payment.PlayerID, amount, time.Now())
+	pb.PaymentID = &payment.ID
+	if err := s.insertBonus(ctx, tx, pb); err != nil {
+		return nil, err
+	}
+	meta, _ := json.Marshal(map[string]interface{}{
+		"bonus_id":        b.ID.String(),
+		"player_bonus_id": pb.ID.String(),
+		"payment_id":      payment.ID.String(),
+	})
+	if _, err := s.engine.ExecuteBonusCredit(ctx, tx, domain.BonusCreditParams{
+		PlayerID:              payment.PlayerID,
+		Amount:                amount,
+		ExternalTransactionID: "deposit-match-" + payment.ID.String(),
+		Metadata:              meta,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE bonus_opt_ins SET status = 'claimed', player_bonus_id = $2, updated_at = now()
+		WHERE id = $1`, optInID, pb.ID); err != nil {
+		return nil, domain.ErrInternal("claim bonus opt-in", err)
+	}
+
+	s.logger.Info("deposit matched", "bonus_id", b.ID, "player_id", payment.PlayerID,
+		"payment_id", payment.ID, "player_bonus_id", pb.ID, "amount", amount)
+	return pb, nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// bonusExpiryBatch bounds the bonuses expired or reminded about per pass.
+const bonusExpiryBatch = 100
+
+// BonusExpiryWorker expires player bonuses past their expiry, forfeiting the
+// bonus balance left on them and notifying CRM. Bets already expire a
+// player's bonuses lazily; the worker catches players who stop playing.
+// It also reminds players remindBefore ahead of a bonus expiring.
+type BonusExpiryWorker struct {
+	pool         *pgxpool.Pool
+	engine       *ledger.Engine
+	bonuses      repository.BonusRepository
+	outbox       repository.OutboxRepository
+	remindBefore time.Duration
+	logger       *slog.Logger
+
+	expired      *metrics.CounterVec
+	expiredValue *metrics.CounterVec
+	reminders    *metrics.CounterVec
+}
+
+// NewBonusExpiryWorker creates a BonusExpiryWorker. A non-positive
+// remindBefore disables expiry reminders.
+func NewBonusExpiryWorker(
+	pool *pgxpool.Pool,
+	engine *ledger.Engine,
+	bonuses repository.BonusRepository,
+	outbox repository.OutboxRepository,
+	remindBefore time.Duration,
+	logger *slog.Logger,
+) *BonusExpiryWorker {
+	return &BonusExpiryWorker{
+		pool: pool, engine: engine, bonuses: bonuses, outbox: outbox, remindBefore: remindBefore, logger: logger,
+		expired: metrics.NewCounterVec("bonus_expired_total",
+			"Player bonuses expired by the expiry worker.", nil),
+		expiredValue: metrics.NewCounterVec("bonus_expired_forfeited_minor_total",
+			"Bonus balance forfeited on expiry, in minor currency units.", nil),
+		reminders: metrics.NewCounterVec("bonus_expiry_reminders_total",
+			"Players reminded that a bonus is about to expire.", nil),
+	}
+}
+
+// Metrics returns the worker's counters for export.
+func (w *BonusExpiryWorker) Metrics() []io.WriterTo {
+	return []io.WriterTo{w.expired, w.expiredValue, w.reminders}
+}
+
+// ExpireDue expires every bonus past its expiry and returns how many it
+// expired. Each bonus is expired in its own transaction; a failure is logged
+// and the bonus retried on the next pass.
+func (w *BonusExpiryWorker) ExpireDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	expired := 0
+	for {
+		due, err := w.bonuses.ListExpired(ctx, w.pool, now, bonusExpiryBatch)
+		if err != nil {
+			return expired, domain.ErrInternal("list expired bonuses", err)
+		}
+
+		progressed := false
+		for _, b := range due {
+			forfeited, ok, err := w.expire(ctx, b, now)
+			if err != nil {
+				w.logger.Error("expire bonus", "player_bonus_id", b.ID, "player_id", b.PlayerID, "error", err)
+				continue
+			}
+			progressed = true
+			if ok {
+				expired++
+				w.expired.Add(1)
+				w.expiredValue.Add(float64(forfeited))
+				w.logger.Info("bonus expired", "player_bonus_id", b.ID, "player_id", b.PlayerID,
+					"wagered", b.Wagered, "wagering_requirement", b.WageringRequirement, "forfeited", forfeited)
+			}
+		}
+		// A short page is the last one; a page of failures would only repeat.
+		if len(due) < bonusExpiryBatch || !progressed {
+			return expired, nil
+		}
+	}
+}
+
+func (w *BonusExpiryWorker) expire(ctx context.Context, b domain.PlayerBonus, now time.Time) (int64, bool, error) {
+	tx, err := w.pool.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := w.engine.ExecuteExpireBonus(ctx, tx, domain.ExpireBonusParams{
+		PlayerID:      b.PlayerID,
+		PlayerBonusID: b.ID,
+		Now:           now,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	if result == nil {
+		return 0, false, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, err
+	}
+	var forfeited int64
+	if result.Transaction != nil {
+		forfeited = result.Transaction.Amount
+	}
+	return forfeited, true, nil
+}
+
+// SendReminders reminds players of every active bonus expiring within
+// remindBefore, once per bonus: an in-app notification, and a CRM event that
+// is only delivered with marketing consent. Returns how many it sent.
+func (w *BonusExpiryWorker) SendReminders(ctx context.Context) (int, error) {
+	if w.remindBefore <= 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	sent := 0
+	for {
+		due, err := w.bonuses.ListExpiring(ctx, w.pool, now, now.Add(w.remindBefore), bonusExpiryBatch)
+		if err != nil {
+			return sent, domain.ErrInternal("list expiring bonuses", err)
+		}
+
+		progressed := false
+		for _, b := range due {
+			ok, err := w.remind(ctx, b)
+			if err != nil {
+				w.logger.Error("remind bonus expiry", "player_bonus_id", b.ID, "player_id", b.PlayerID, "error", err)
+				continue
+			}
+			progressed = true
+			if ok {
+				sent++
+				w.reminders.Add(1)
+				w.logger.Info("bonus expiry reminder sent", "player_bonus_id", b.ID, "player_id", b.PlayerID,
+					"expires_at", b.ExpiresAt)
+			}
+		}
+		if len(due) < bonusExpiryBatch || !progressed {
+			return sent, nil
+		}
+	}
+}
+
+func (w *BonusExpiryWorker) remind(ctx context.Context, b domain.PlayerBonus) (bool, error) {
+	tx, err := w.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	ok, err := w.bonuses.MarkReminded(ctx, tx, b.ID)
+	if err != nil || !ok {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO player_notifications (player_id, type, title, message)
+		VALUES ($1, 'bonus_expiry', 'Your bonus is about to expire', $2)`,
+		b.PlayerID, "Complete its wagering before "+b.ExpiresAt.UTC().Format("2 Jan 2006 15:04 MST")+
+			" to keep your bonus."); err != nil {
+		return false, err
+	}
+	if err := w.outbox.Insert(ctx, tx, domain.NewBonusExpiringEvent(b)); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StartScheduler expires due bonuses and sends expiry reminders every
+// interval until ctx is done. A non-positive interval disables the worker.
+func (w *BonusExpiryWorker) StartScheduler(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		w.logger.Info("bonus expiry worker disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				w.logger.Info("bonus expiry worker stopped")
+				return
+			case <-ticker.C:
+				n, err := w.ExpireDue(ctx)
+				if err != nil {
+					w.logger.Error("expire bonuses", "error", err)
+				} else if n > 0 {
+					w.logger.Info("expired bonuses", "count", n)
+				}
+				n, err = w.SendReminders(ctx)
+				if err != nil {
+					w.logger.Error("send bonus expiry reminders", "error", err)
+				} else if n > 0 {
+					w.logger.Info("sent bonus expiry reminders", "count", n)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,565 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxBulkGrantPlayers caps the player list of a single bulk grant.
+const MaxBulkGrantPlayers = 20000
+
+// bonusGrantItemsLimit bounds the items returned by ListBulkGrantItems.
+const bonusGrantItemsLimit = 500
+
+// bonusGrantJobColumns selects a bonus_grant_jobs row j with its progress,
+// counted from the job's items i.
+const bonusGrantJobColumns = `j.id, j.bonus_id, j.segment, j.status, j.created_by, j.rolled_back_by,
+	j.created_at, j.finished_at, j.rollback_at, j.rolled_back_at,
+	COUNT(i.player_id),
+	COUNT(i.player_id) FILTER (WHERE i.status IN ('pending', 'granting')),
+	COUNT(i.player_id) FILTER (WHERE i.status IN ('granted', 'revoking', 'revoke_failed')),
+	COUNT(i.player_id) FILTER (WHERE i.status = 'skipped'),
+	COUNT(i.player_id) FILTER (WHERE i.status = 'failed'),
+	COUNT(i.player_id) FILTER (WHERE i.status = 'revoked'),
+	COUNT(i.player_id) FILTER (WHERE i.status = 'revoke_failed')`
+
+func scanBonusGrantJob(row pgx.Row) (*domain.BonusGrantJob, error) {
+	var j domain.BonusGrantJob
+	p := &j.Progress
+	if err := row.Scan(&j.ID, &j.BonusID, &j.Segment, &j.Status, &j.CreatedBy, &j.RolledBackBy,
+		&j.CreatedAt, &j.FinishedAt, &j.RollbackAt, &j.RolledBackAt,
+		&p.Total, &p.Pending, &p.Granted, &p.Skipped, &p.Failed, &p.Revoked, &p.RevokeFailed); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// BulkGrantInput picks the players of a bulk grant: everyone in Segment, or
+// the listed players.
+type BulkGrantInput struct {
+	Segment   string      `json:"segment"`
+	PlayerIDs []uuid.UUID `json:"player_ids"`
+}
+
+// StartBulkGrant grants a bonus to a segment or list of players in the
+// background and returns the job tracking it. Listed players who are unknown
+// or not active are skipped.
+func (s *BonusService) StartBulkGrant(ctx context.Context, bonusID uuid.UUID, input BulkGrantInput, adminID *uuid.UUID) (*domain.BonusGrantJob, error) {
+	switch {
+	case (input.Segment == "") == (len(input.PlayerIDs) == 0):
+		return nil, domain.ErrValidation("give either a segment or player_ids")
+	case input.Segment != "" && !policy.ValidSegment(input.Segment):
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown segment: %s", input.Segment))
+	case len(input.PlayerIDs) > MaxBulkGrantPlayers:
+		return nil, domain.ErrValidation(fmt.Sprintf("at most %d players per grant", MaxBulkGrantPlayers))
+	}
+	b, err := s.GetBonus(ctx, bonusID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(b); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var players []uuid.UUID
+	var statuses []string
+	var reasons []*string
+	if input.Segment != "" {
+		players, err = activePlayersInSegment(ctx, tx, input.Segment, time.Now())
+		if err != nil {
+			return nil, domain.ErrInternal("resolve segment", err)
+		}
+		if len(players) == 0 {
+			return nil, domain.ErrValidation(fmt.Sprintf("no players in segment %s", input.Segment))
+		}
+		for range players {
+			statuses = append(statuses, string(domain.BonusGrantItemPending))
+			reasons = append(reasons, nil)
+		}
+	} else {
+		players, statuses, reasons, err = s.eligiblePlayers(ctx, tx, input.PlayerIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var segment *string
+	if input.Segment != "" {
+		segment = &input.Segment
+	}
+	var jobID uuid.UUID
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO bonus_grant_jobs (bonus_id, segment, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id`, bonusID, segment, adminID).Scan(&jobID); err != nil {
+		return nil, domain.ErrInternal("create bonus grant job", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO bonus_grant_job_items (job_id, player_id, status, error)
+		SELECT $1, t.player_id, t.status, t.error
+		FROM unnest($2::uuid[], $3::text[], $4::text[]) AS t(player_id, status, error)`,
+		jobID, players, statuses, reasons); err != nil {
+		return nil, domain.ErrInternal("create bonus grant items", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Info("bulk bonus grant started", "job_id", jobID, "bonus_id", bonusID,
+		"segment", input.Segment, "players", len(players))
+	go s.runBulkGrant(context.Background(), jobID)
+	return s.GetBulkGrant(ctx, jobID)
+}
+
+// eligiblePlayers dedupes ids and marks those that are unknown or not active
+// to be skipped.
+func (s *BonusService) eligiblePlayers(ctx context.Context, tx pgx.Tx, ids []uuid.UUID) ([]uuid.UUID, []string, []*string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT p.id, COALESCE(pp.account_status, 'active')
+		FROM v2_players p
+		LEFT JOIN player_profiles pp ON pp.player_id = p.id
+		WHERE p.id = ANY($1)`, ids)
+	if err != nil {
+		return nil, nil, nil, domain.ErrInternal("find players", err)
+	}
+	defer rows.Close()
+	accountStatus := make(map[uuid.UUID]string, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		var status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, nil, nil, domain.ErrInternal("scan player", err)
+		}
+		accountStatus[id] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, domain.ErrInternal("find players", err)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	players := make([]uuid.UUID, 0, len(ids))
+	statuses := make([]string, 0, len(ids))
+	reasons := make([]*string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		status, reason := domain.BonusGrantItemPending, ""
+		switch accountStatus[id] {
+		case "":
+			status, reason = domain.BonusGrantItemSkipped, "player not found"
+		case string(domain.AccountStatusActive):
+		default:
+			status, reason = domain.BonusGrantItemSkipped, "account is "+accountStatus[id]
+		}
+		players = append(players, id)
+		statuses = append(statuses, string(status))
+		if reason != "" {
+			reasons = append(reasons, &reason)
+		} else {
+			reasons = append(reasons, nil)
+		}
+	}
+	return players, statuses, reasons, nil
+}
+
+// GetBulkGrant returns a bulk grant job with its progress.
+func (s *BonusService) GetBulkGrant(ctx context.Context, jobID uuid.UUID) (*domain.BonusGrantJob, error) {
+	j, err := scanBonusGrantJob(s.pool.QueryRow(ctx, `
+		SELECT `+bonusGrantJobColumns+`
+		FROM bonus_grant_jobs j
+		LEFT JOIN bonus_grant_job_items i ON i.job_id = j.id
+		WHERE j.id = $1
+		GROUP BY j.id`, jobID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("bonus grant", jobID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get bonus grant", err)
+	}
+	return j, nil
+}
+
+// ListBulkGrants returns bulk grant jobs with their progress, newest first.
+func (s *BonusService) ListBulkGrants(ctx context.Context) ([]domain.BonusGrantJob, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+bonusGrantJobColumns+`
+		FROM bonus_grant_jobs j
+		LEFT JOIN bonus_grant_job_items i ON i.job_id = j.id
+		GROUP BY j.id
+		ORDER BY j.created_at DESC LIMIT 50`)
+	if err != nil {
+		return nil, domain.ErrInternal("list bonus grants", err)
+	}
+	defer rows.Close()
+
+	jobs := []domain.BonusGrantJob{}
+	for rows.Next() {
+		j, err := scanBonusGrantJob(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan bonus grant", err)
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
+}
+
+// ListBulkGrantItems returns what a bulk grant did per player, optionally
+// only the items in status.
+func (s *BonusService) ListBulkGrantItems(ctx context.Context, jobID uuid.UUID, status string) ([]domain.BonusGrantItem, error) {
+	if _, err := s.GetBulkGrant(ctx, jobID); err != nil {
+		return nil, err
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT player_id, status, player_bonus_id, free_spin_grant_id, error, updated_at
+		FROM bonus_grant_job_items
+		WHERE job_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY updated_at DESC, player_id
+		LIMIT $3`, jobID, status, bonusGrantItemsLimit)
+	if err != nil {
+		return nil, domain.ErrInternal("list bonus grant items", err)
+	}
+	defer rows.Close()
+
+	items := []domain.BonusGrantItem{}
+	for rows.Next() {
+		var it domain.BonusGrantItem
+		if err := rows.Scan(&it.PlayerID, &it.Status, &it.PlayerBonusID, &it.FreeSpinGrantID,
+			&it.Error, &it.UpdatedAt); err != nil {
+			return nil, domain.ErrInternal("scan bonus grant item", err)
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// RollbackBulkGrant revokes, in the background, every bonus a completed bulk
+// grant gave out: what is left of cash bonuses is forfeited and free spins
+// are revoked along with their wins. Bonuses whose wagering is already
+// complete cannot be revoked and are reported as revoke_failed.
+func (s *BonusService) RollbackBulkGrant(ctx context.Context, jobID, adminID uuid.UUID) (*domain.BonusGrantJob, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE bonus_grant_jobs SET status = $2, rolled_back_by = $3, rollback_at = now()
+		WHERE id = $1 AND status = $4`,
+		jobID, domain.BonusGrantJobRollingBack, adminID, domain.BonusGrantJobCompleted)
+	if err != nil {
+		return nil, domain.ErrInternal("roll back bonus grant", err)
+	}
+	if tag.RowsAffected() == 0 {
+		j, err := s.GetBulkGrant(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrConflict(fmt.Sprintf("bonus grant is %s", j.Status))
+	}
+
+	s.logger.Info("bulk bonus grant rollback started", "job_id", jobID, "admin_id", adminID)
+	go s.runBulkRollback(context.Background(), jobID)
+	return s.GetBulkGrant(ctx, jobID)
+}
+
+// ResumeBulkGrants picks up the grants and rollbacks that were in progress
+// when the process last stopped. Items interrupted mid-way are marked failed
+// rather than retried, since their grant may or may not have happened.
+func (s *BonusService) ResumeBulkGrants(ctx context.Context) {
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE bonus_grant_job_items
+		SET status = CASE status WHEN 'granting' THEN 'failed' ELSE 'revoke_failed' END,
+		    error = 'interrupted', updated_at = now()
+		WHERE status IN ('granting', 'revoking')`); err != nil {
+		s.logger.Error("reset interrupted bonus grant items", "error", err)
+		return
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, status FROM bonus_grant_jobs WHERE status IN ('running', 'rolling_back')`)
+	if err != nil {
+		s.logger.Error("list unfinished bonus grants", "error", err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id uuid.UUID
+		var status domain.BonusGrantJobStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			s.logger.Error("scan unfinished bonus grant", "error", err)
+			return
+		}
+		s.logger.Info("resuming bulk bonus grant", "job_id", id, "status", status)
+		if status == domain.BonusGrantJobRunning {
+			go s.runBulkGrant(context.Background(), id)
+		} else {
+			go s.runBulkRollback(context.Background(), id)
+		}
+	}
+}
+
+// runBulkGrant grants the job's pending items one at a time until none are
+// left, then completes the job.
+func (s *BonusService) runBulkGrant(ctx context.Context, jobID uuid.UUID) {
+	var bonusID uuid.UUID
+	var createdBy *uuid.UUID
+	if err := s.pool.QueryRow(ctx, `
+		SELECT bonus_id, created_by FROM bonus_grant_jobs WHERE id = $1`, jobID).Scan(&bonusID, &createdBy); err != nil {
+		s.logger.Error("load bonus grant job", "job_id", jobID, "error", err)
+		return
+	}
+
+	for ctx.Err() == nil {
+		playerID, ok, err := s.claimGrantItem(ctx, jobID, domain.BonusGrantItemPending, domain.BonusGrantItemGranting)
+		if err != nil {
+			s.logger.Error("claim bonus grant item", "job_id", jobID, "error", err)
+			return
+		}
+		if !ok {
+			break
+		}
+
+		var pbID, fsID *uuid.UUID
+		status, reason := domain.BonusGrantItemGranted, ""
+		grant, err := s.Grant(ctx, bonusID, playerID, createdBy)
+		switch {
+		case err != nil:
+			status, reason = domain.BonusGrantItemFailed, err.Error()
+		case grant.PlayerBonus != nil:
+			pbID = &grant.PlayerBonus.ID
+		case grant.FreeSpins != nil:
+			fsID = &grant.FreeSpins.ID
+		}
+		if err := s.finishGrantItem(ctx, jobID, playerID, status, reason, pbID, fsID); err != nil {
+			s.logger.Error("record bonus grant item", "job_id", jobID, "player_id", playerID,
+				"status", status, "error", err)
+			return
+		}
+	}
+
+	s.finishJob(ctx, jobID, domain.BonusGrantJobRunning, domain.BonusGrantJobCompleted,
+		domain.BonusGrantItemPending, domain.BonusGrantItemGranting)
+}
+
+// runBulkRollback revokes the job's granted items one at a time until none
+// are left, then marks the job rolled back.
+func (s *BonusService) runBulkRollback(ctx context.Context, jobID uuid.UUID) {
+	for ctx.Err() == nil {
+		playerID, ok, err := s.claimGrantItem(ctx, jobID, domain.BonusGrantItemGranted, domain.BonusGrantItemRevoking)
+		if err != nil {
+			s.logger.Error("claim bonus grant item", "job_id", jobID, "error", err)
+			return
+		}
+		if !ok {
+			break
+		}
+
+		var pbID, fsID *uuid.UUID
+		if err := s.pool.QueryRow(ctx, `
+			SELECT player_bonus_id, free_spin_grant_id FROM bonus_grant_job_items
+			WHERE job_id = $1 AND player_id = $2`, jobID, playerID).Scan(&pbID, &fsID); err != nil {
+			s.logger.Error("load bonus grant item", "job_id", jobID, "player_id", playerID, "error", err)
+			return
+		}
+		status, reason := domain.BonusGrantItemRevoked, ""
+		if err := s.revokeGrant(ctx, jobID, playerID, pbID, fsID); err != nil {
+			status, reason = domain.BonusGrantItemRevokeFailed, err.Error()
+		}
+		if err := s.finishGrantItem(ctx, jobID, playerID, status, reason, pbID, fsID); err != nil {
+			s.logger.Error("record bonus grant item", "job_id", jobID, "player_id", playerID,
+				"status", status, "error", err)
+			return
+		}
+	}
+
+	s.finishJob(ctx, jobID, domain.BonusGrantJobRollingBack, domain.BonusGrantJobRolledBack,
+		domain.BonusGrantItemGranted, domain.BonusGrantItemRevoking)
+}
+
+// claimGrantItem moves one of the job's items from one status to another and
+// returns its player. ok is false when no item is left in from.
+func (s *BonusService) claimGrantItem(ctx context.Context, jobID uuid.UUID, from, to domain.BonusGrantItemStatus) (uuid.UUID, bool, error) {
+	var playerID uuid.UUID
+	err := s.pool.QueryRow(ctx, `
+		UPDATE bonus_grant_job_items SET status = $3, updated_at = now()
+		WHERE job_id = $1 AND player_id = (
+			SELECT player_id FROM bonus_grant_job_items
+			WHERE job_id = $1 AND status = $2
+			LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING player_id`, jobID, from, to).Scan(&playerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, false, nil
+	}
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	return playerID, true, nil
+}
+
+func (s *BonusService) finishGrantItem(ctx context.Context, jobID, playerID uuid.UUID, status domain.BonusGrantItemStatus, reason string, pbID, fsID *uuid.UUID) error {
+	var errText *string
+	if reason != "" {
+		errText = &reason
+	}
+	_, err := s.pool.Exec(ctx, `
+		UPDATE bonus_grant_job_items
+		SET status = $3, error = $4, player_bonus_id = $5, free_spin_grant_id = $6, updated_at = now()
+		WHERE job_id = $1 AND player_id = $2`, jobID, playerID, status, errText, pbID, fsID)
+	return err
+}
+
+// finishJob moves the job from one status to another once none of its items
+// are left in the working statuses. Another runner still holding an item
+// finishes the job instead.
+func (s *BonusService) finishJob(ctx context.Context, jobID uuid.UUID, from, to domain.BonusGrantJobStatus, working ...domain.BonusGrantItemStatus) {
+	statuses := make([]string, len(working))
+	for i, w := range working {
+		statuses[i] = string(w)
+	}
+	column := "finished_at"
+	if to == domain.BonusGrantJobRolledBack {
+		column = "rolled_back_at"
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE bonus_grant_jobs SET status = $3, `+column+` = now()
+		WHERE id = $1 AND status = $2
+		  AND NOT EXISTS (
+		      SELECT 1 FROM bonus_grant_job_items WHERE job_id = $1 AND status = ANY($4))`,
+		jobID, from, to, statuses)
+	if err != nil {
+		s.logger.Error("finish bonus grant job", "job_id", jobID, "status", to, "error", err)
+		return
+	}
+	if tag.RowsAffected() == 0 {
+		return
+	}
+	if j, err := s.GetBulkGrant(ctx, jobID); err == nil {
+		s.logger.Info("bulk bonus grant finished", "job_id", jobID, "status", to, "total", j.Progress.Total,
+			"granted", j.Progress.Granted, "skipped", j.Progress.Skipped, "failed", j.Progress.Failed,
+			"revoked", j.Progress.Revoked, "revoke_failed", j.Progress.RevokeFailed)
+	}
+}
+
+// revokeGrant takes back what a bulk grant gave one player: a cash player
+// bonus, or free spins.
+func (s *BonusService) revokeGrant(ctx context.Context, jobID, playerID uuid.UUID, pbID, fsID *uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	switch {
+	case pbID != nil:
+		err = s.revokePlayerBonus(ctx, tx, jobID, playerID, *pbID)
+	case fsID != nil:
+		err = s.revokeFreeSpins(ctx, tx, jobID, playerID, *fsID)
+	default:
+		return domain.ErrInternal("revoke bonus", errors.New("grant item records no bonus"))
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	return nil
+}
+
+// revokePlayerBonus forfeits an active player bonus and what is left of its
+// bonus balance, capped at the amount granted. A bonus that already expired
+// or was forfeited has nothing left to revoke.
+func (s *BonusService) revokePlayerBonus(ctx context.Context, tx pgx.Tx, jobID, playerID, pbID uuid.UUID) error {
+	// Lock the player before the bonus, in the order bets lock them.
+	if _, err := s.engine.LockPlayerForUpdate(ctx, tx, playerID); err != nil {
+		return err
+	}
+	b, err := s.bonuses.LockByID(ctx, tx, pbID)
+	if err != nil {
+		return domain.ErrInternal("lock player bonus", err)
+	}
+	if b == nil || b.PlayerID != playerID {
+		return domain.ErrNotFound("player bonus", pbID.String())
+	}
+	switch b.Status {
+	case domain.BonusStatusExpired, domain.BonusStatusForfeited:
+		return nil
+	case domain.BonusStatusCompleted:
+		return domain.ErrConflict("bonus wagering is already complete")
+	}
+	if err := s.bonuses.SetStatus(ctx, tx, b.ID, domain.BonusStatusForfeited); err != nil {
+		return domain.ErrInternal("forfeit player bonus", err)
+	}
+	return s.forfeitBonusBalance(ctx, tx, playerID, b.InitialAmount, "bonus-revoke-"+b.ID.String(), jobID)
+}
+
+// revokeFreeSpins revokes a free spin grant so no more rounds can be played,
+// and forfeits what its wins left on the bonus balance.
+func (s *BonusService) revokeFreeSpins(ctx context.Context, tx pgx.Tx, jobID, playerID, grantID uuid.UUID) error {
+	if _, err := s.engine.LockPlayerForUpdate(ctx, tx, playerID); err != nil {
+		return err
+	}
+	g, err := scanFreeSpinGrant(tx.QueryRow(ctx, `
+		SELECT `+freeSpinGrantColumns+` FROM free_spin_grants
+		WHERE id = $1 AND player_id = $2
+		FOR UPDATE`, grantID, playerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("free spins", grantID.String())
+	}
+	if err != nil {
+		return domain.ErrInternal("lock free spin grant", err)
+	}
+
+	switch {
+	case g.Status == domain.FreeSpinStatusRevoked:
+		return nil
+	case g.PlayerBonusID != nil:
+		// The wins already became a player bonus; revoking it forfeits them.
+		if err := s.revokePlayerBonus(ctx, tx, jobID, playerID, *g.PlayerBonusID); err != nil {
+			return err
+		}
+	case g.Status == domain.FreeSpinStatusActive && g.TotalWin > 0:
+		if err := s.forfeitBonusBalance(ctx, tx, playerID, g.TotalWin, "freespin-revoke-"+g.ID.String(), jobID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE free_spin_grants SET status = $2, updated_at = now() WHERE id = $1`,
+		g.ID, domain.FreeSpinStatusRevoked); err != nil {
+		return domain.ErrInternal("revoke free spins", err)
+	}
+	return nil
+}
+
+// forfeitBonusBalance removes up to amount from the player's bonus balance.
+func (s *BonusService) forfeitBonusBalance(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, amount int64, extID string, jobID uuid.UUID) error {
+	player, err := s.engine.LockPlayerForUpdate(ctx, tx, playerID)
+	if err != nil {
+		return err
+	}
+	amount = min(player.BonusBalance, amount)
+	if amount <= 0 {
+		return nil
+	}
+	meta, _ := json.Marshal(map[string]interface{}{
+		"bonus_grant_job_id": jobID.String(),
+		"reason":             "revoked",
+	})
+	_, err = s.engine.ExecuteForfeitBonus(ctx, tx, domain.ForfeitBonusParams{
+		PlayerID:              playerID,
+		Amount:                amount,
+		ExternalTransactionID: extID,
+		Metadata:              meta,
+	})
+	return err
+}
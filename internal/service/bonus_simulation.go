@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+)
+
+// BonusSimulationInput is a proposed bonus configuration to replay against
+// the last Days of history, optionally targeted at a player segment.
+type BonusSimulationInput struct {
+	Bonus   domain.Bonus `json:"bonus"`
+	Segment string       `json:"segment,omitempty"`
+	Days    int          `json:"days,omitempty"`
+}
+
+// BonusSimulation is what a proposed bonus would have cost over the window.
+// MaxCost assumes every qualifying player releases the whole bonus;
+// ProjectedCost scales it by ReleaseRate, the share of recent bonus money of
+// the same type players went on to release.
+type BonusSimulation struct {
+	Days             int       `json:"days"`
+	Since            time.Time `json:"since"`
+	QualifiedPlayers int       `json:"qualified_players"`
+	MaxCost          int64     `json:"max_cost"`
+	ReleaseRate      float64   `json:"release_rate"`
+	ProjectedCost    int64     `json:"projected_cost"`
+}
+
+// SimulateBonus estimates how many players a proposed bonus would have
+// reached and what it would have cost, without saving or granting anything.
+// A deposit_match bonus, or any bonus with a min_deposit, qualifies players
+// by their first completed deposit of at least min_deposit in the window;
+// other bonuses reach every active player in the segment.
+func (s *BonusService) SimulateBonus(ctx context.Context, input BonusSimulationInput) (*BonusSimulation, error) {
+	days, err := policy.SimulationDays(input.Days)
+	if err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	b := input.Bonus
+	if err := policy.ValidateBonus(b); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	if b.Type == "" {
+		b.Type = domain.BonusTypeCash
+	}
+	if input.Segment != "" && !policy.ValidSegment(input.Segment) {
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown segment: %s", input.Segment))
+	}
+
+	now := time.Now()
+	sim := &BonusSimulation{Days: days, Since: now.AddDate(0, 0, -days)}
+
+	var inSegment map[uuid.UUID]bool
+	if input.Segment != "" {
+		ids, err := activePlayersInSegment(ctx, s.pool, input.Segment, now)
+		if err != nil {
+			return nil, domain.ErrInternal("resolve segment", err)
+		}
+		inSegment = make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			inSegment[id] = true
+		}
+	}
+
+	perPlayer := b.MaxBonus
+	if b.Type == domain.BonusTypeFreeSpins {
+		perPlayer = int64(b.FreeSpins) * b.FreeSpinValue
+	}
+
+	if b.Type == domain.BonusTypeDepositMatch || b.MinDeposit > 0 {
+		deposits, err := s.firstDeposits(ctx, sim.Since, b.MinDeposit)
+		if err != nil {
+			return nil, err
+		}
+		for playerID, amount := range deposits {
+			if inSegment != nil && !inSegment[playerID] {
+				continue
+			}
+			sim.QualifiedPlayers++
+			if b.Type == domain.BonusTypeDepositMatch {
+				sim.MaxCost += policy.DepositMatchAmount(amount, b.MinDeposit, b.MaxBonus, b.MatchPercent)
+			} else {
+				sim.MaxCost += perPlayer
+			}
+		}
+	} else {
+		if inSegment == nil {
+			ids, err := activePlayersInSegment(ctx, s.pool, policy.SegmentAll, now)
+			if err != nil {
+				return nil, domain.ErrInternal("resolve segment", err)
+			}
+			sim.QualifiedPlayers = len(ids)
+		} else {
+			sim.QualifiedPlayers = len(inSegment)
+		}
+		sim.MaxCost = int64(sim.QualifiedPlayers) * perPlayer
+	}
+
+	var released, settled int64
+	if err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(pb.initial_amount) FILTER (WHERE pb.status = 'completed'), 0)::bigint,
+		       COALESCE(SUM(pb.initial_amount) FILTER (WHERE pb.status IN ('completed', 'expired', 'forfeited')), 0)::bigint
+		FROM player_bonuses pb
+		JOIN bonuses b ON b.id = pb.bonus_id
+		WHERE pb.created_at >= $1 AND b.type = $2`, sim.Since, b.Type).Scan(&released, &settled); err != nil {
+		return nil, domain.ErrInternal("load bonus release rate", err)
+	}
+	sim.ReleaseRate = 1
+	if settled > 0 {
+		sim.ReleaseRate = float64(released) / float64(settled)
+	}
+	sim.ProjectedCost = policy.ProjectedBonusCost(sim.MaxCost, released, settled)
+	return sim, nil
+}
+
+// firstDeposits returns the amount of each active player's first completed
+// deposit of at least minDeposit since since.
+func (s *BonusService) firstDeposits(ctx context.Context, since time.Time, minDeposit int64) (map[uuid.UUID]int64, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (pay.player_id) pay.player_id, pay.amount::bigint
+		FROM payments pay
+		LEFT JOIN player_profiles pp ON pp.player_id = pay.player_id
+		WHERE pay.type = $1 AND pay.status = $2 AND pay.created_at >= $3 AND pay.amount >= $4
+		  AND COALESCE(pp.account_status, 'active') = 'active'
+		ORDER BY pay.player_id, pay.created_at`,
+		domain.PaymentTypeDeposit, domain.PaymentStatusCompleted, since, minDeposit)
+	if err != nil {
+		return nil, domain.ErrInternal("load deposits", err)
+	}
+	defer rows.Close()
+
+	deposits := map[uuid.UUID]int64{}
+	for rows.Next() {
+		var playerID uuid.UUID
+		var amount int64
+		if err := rows.Scan(&playerID, &amount); err != nil {
+			return nil, domain.ErrInternal("scan deposit", err)
+		}
+		deposits[playerID] = amount
+	}
+	return deposits, rows.Err()
+}
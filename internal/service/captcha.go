@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CaptchaGate decides whether an auth request must solve a CAPTCHA and verifies
+// the token when it does. A challenge is only required when the per-IP soft rate
+// limiter trips or the session risk score is medium or higher.
+type CaptchaGate struct {
+	pool        *pgxpool.Pool
+	defaultVer  provider.CaptchaVerifier
+	brands      map[string]provider.CaptchaVerifier
+	softLimiter *guard.RateLimiter
+	logger      *slog.Logger
+}
+
+// NewCaptchaGate creates a CaptchaGate. defaultProvider/defaultSecret configure the
+// fallback verifier; brandSpec overrides it per brand (see provider.ParseCaptchaBrands).
+// With no verifier configured the gate never challenges.
+func NewCaptchaGate(pool *pgxpool.Pool, defaultProvider, defaultSecret, brandSpec string, logger *slog.Logger) *CaptchaGate {
+	g := &CaptchaGate{
+		pool:        pool,
+		brands:      map[string]provider.CaptchaVerifier{},
+		softLimiter: guard.NewRateLimiter(3, 15*time.Minute),
+		logger:      logger,
+	}
+
+	if defaultProvider != "" {
+		v, err := provider.NewCaptchaVerifier(defaultProvider, defaultSecret)
+		if err != nil {
+			logger.Warn("captcha default provider disabled", "error", err)
+		} else {
+			g.defaultVer = v
+		}
+	}
+
+	if brandSpec != "" {
+		brands, err := provider.ParseCaptchaBrands(brandSpec)
+		if err != nil {
+			logger.Warn("captcha brand overrides ignored", "error", err)
+		} else {
+			g.brands = brands
+		}
+	}
+
+	return g
+}
+
+// CaptchaCheck holds the inputs for a gate evaluation.
+type CaptchaCheck struct {
+	Brand string
+	IP    string
+	Email string
+	Token string
+}
+
+// Enforce returns a CAPTCHA_REQUIRED error when a challenge is needed but no token
+// was supplied, and CAPTCHA_INVALID when the provider rejects the token.
+func (g *CaptchaGate) Enforce(ctx context.Context, check CaptchaCheck) error {
+	if g == nil {
+		return nil
+	}
+	verifier := g.verifierFor(check.Brand)
+	if verifier == nil {
+		return nil
+	}
+
+	if !g.challengeRequired(ctx, check) {
+		return nil
+	}
+
+	if check.Token == "" {
+		return &domain.AppError{
+			Code:    "CAPTCHA_REQUIRED",
+			Message: "captcha verification required",
+			Status:  403,
+		}
+	}
+
+	if err := verifier.Verify(ctx, check.Token, check.IP); err != nil {
+		g.logger.Warn("captcha verification failed", "provider", verifier.Name(), "ip", check.IP, "error", err)
+		return &domain.AppError{
+			Code:    "CAPTCHA_INVALID",
+			Message: "captcha verification failed",
+			Status:  403,
+		}
+	}
+	return nil
+}
+
+func (g *CaptchaGate) verifierFor(brand string) provider.CaptchaVerifier {
+	if v, ok := g.brands[brand]; ok {
+		return v
+	}
+	return g.defaultVer
+}
+
+func (g *CaptchaGate) challengeRequired(ctx context.Context, check CaptchaCheck) bool {
+	if !g.softLimiter.Check(ctx, check.IP).Allowed {
+		return true
+	}
+
+	var failures int
+	err := g.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM login_attempts
+		WHERE (ip_address = $1 OR email = $2) AND success = false
+		  AND created_at > now() - interval '1 hour'`,
+		check.IP, check.Email).Scan(&failures)
+	if err != nil {
+		// Fail closed: challenge when we cannot assess risk.
+		return true
+	}
+
+	risk := policy.EvaluateSessionRisk(policy.SessionRiskSignals{AuthFailures: failures})
+	return risk.Level != policy.RiskLow
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/projection"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// gameSessionGap is the idle time between two rounds of a game after
+	// which the later round starts a new session.
+	gameSessionGap = 30 * time.Minute
+	// gameSessionLookback bounds how far back session history and recently
+	// played games reach.
+	gameSessionLookback = 90 * 24 * time.Hour
+	// recommendationWindow is the launch history recommendations draw on.
+	recommendationWindow = 30 * 24 * time.Hour
+	// lobbyListMax is how many recent or recommended games are computed and
+	// cached per player.
+	lobbyListMax = 50
+
+	recentGamesTTL      = 5 * time.Minute
+	recommendedGamesTTL = 15 * time.Minute
+)
+
+// CasinoService serves a player's casino activity and the lobby's recently
+// played and recommended games, which are cached per player.
+type CasinoService struct {
+	pool     *pgxpool.Pool
+	cache    projection.Store
+	sessions *GameSessionService
+	logger   *slog.Logger
+}
+
+// NewCasinoService creates a CasinoService.
+func NewCasinoService(pool *pgxpool.Pool, cache projection.Store, sessions *GameSessionService, logger *slog.Logger) *CasinoService {
+	return &CasinoService{pool: pool, cache: cache, sessions: sessions, logger: logger}
+}
+
+// ListSessions returns the player's most recent game sessions, newest first.
+// Rounds of one game are joined into a session while each starts within
+// gameSessionGap of the previous round's last activity; a session with an
+// open round has no end yet.
+func (s *CasinoService) ListSessions(ctx context.Context, playerID uuid.UUID, limit int) ([]domain.GameSession, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		WITH rounds AS (
+			SELECT r.*, COALESCE(r.game_id, '') AS game_key,
+			       CASE WHEN r.opened_at - LAG(r.last_activity_at) OVER w <= make_interval(secs => $2) THEN 0 ELSE 1 END AS starts
+			FROM game_rounds r
+			WHERE r.player_id = $1 AND r.opened_at >= now() - make_interval(secs => $3)
+			WINDOW w AS (PARTITION BY r.manufacturer_id, COALESCE(r.game_id, '') ORDER BY r.opened_at, r.id)
+		), numbered AS (
+			SELECT *, SUM(starts) OVER (PARTITION BY manufacturer_id, game_key ORDER BY opened_at, id) AS session_no
+			FROM rounds
+		), sessions AS (
+			SELECT manufacturer_id, MAX(game_id) AS game_id, MAX(currency) AS currency,
+			       MIN(opened_at) AS started_at,
+			       CASE WHEN BOOL_OR(status = 'open') THEN NULL
+			            ELSE MAX(COALESCE(closed_at, last_activity_at)) END AS ended_at,
+			       COUNT(*)::int AS rounds,
+			       SUM(stake_total)::bigint AS stake, SUM(win_total)::bigint AS win,
+			       SUM(refund_total)::bigint AS refund
+			FROM numbered
+			GROUP BY manufacturer_id, game_key, session_no
+			ORDER BY MIN(opened_at) DESC
+			LIMIT $4
+		)
+		SELECT s.manufacturer_id, gm.provider, s.game_id, gm.name, s.currency, s.started_at, s.ended_at,
+		       s.rounds, s.stake, s.win, s.refund
+		FROM sessions s
+		LEFT JOIN LATERAL (
+			SELECT m.name AS provider, g.name FROM game_manufacturers m
+			LEFT JOIN games g ON g.manufacturer_id = m.id AND g.external_game_id = s.game_id
+			WHERE m.id = s.manufacturer_id OR lower(m.name) = lower(s.manufacturer_id)
+			ORDER BY g.name IS NULL
+			LIMIT 1
+		) gm ON true
+		ORDER BY s.started_at DESC`,
+		playerID, gameSessionGap.Seconds(), gameSessionLookback.Seconds(), limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list game sessions", err)
+	}
+	defer rows.Close()
+
+	sessions := []domain.GameSession{}
+	for rows.Next() {
+		var gs domain.GameSession
+		if err := rows.Scan(&gs.ManufacturerID, &gs.Provider, &gs.GameID, &gs.GameName, &gs.Currency,
+			&gs.StartedAt, &gs.EndedAt, &gs.Rounds, &gs.TotalBet, &gs.TotalWin, &gs.TotalRefund); err != nil {
+			return nil, domain.ErrInternal("scan game session", err)
+		}
+		gs.Net = gs.TotalWin + gs.TotalRefund - gs.TotalBet
+		sessions = append(sessions, gs)
+	}
+	return sessions, rows.Err()
+}
+
+const lobbyGameColumns = `g.id, g.manufacturer_id, m.name, g.external_game_id, g.name, g.category, g.rtp::float8`
+
+func recentGamesKey(playerID uuid.UUID) string {
+	return fmt.Sprintf("casino:recent:%s", playerID)
+}
+
+func recommendedGamesKey(playerID uuid.UUID) string {
+	return fmt.Sprintf("casino:recommended:%s", playerID)
+}
+
+// RecordLaunch records the player opening an active catalogue game, issues
+// the game session token for the provider's wallet callbacks in the player's
+// base currency, and drops their cached lobby lists, which the launch changes.
+func (s *CasinoService) RecordLaunch(ctx context.Context, playerID, gameID uuid.UUID) (*domain.GameLaunch, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	launch := domain.GameLaunch{GameID: gameID}
+	var manufacturerID *string
+	var currency string
+	err = tx.QueryRow(ctx, `
+		WITH g AS (
+			SELECT id, manufacturer_id FROM games WHERE id = $2 AND active IS NOT FALSE
+		), l AS (
+			INSERT INTO game_launches (player_id, game_id)
+			SELECT $1, id FROM g
+			RETURNING launched_at
+		)
+		SELECT l.launched_at, g.manufacturer_id, p.currency
+		FROM l, g, v2_players p
+		WHERE p.id = $1`, playerID, gameID).Scan(&launch.LaunchedAt, &manufacturerID, &currency)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("game", gameID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("record game launch", err)
+	}
+
+	if manufacturerID != nil {
+		token, expiresAt, err := s.sessions.Issue(ctx, tx, playerID, *manufacturerID, &gameID, currency)
+		if err != nil {
+			return nil, err
+		}
+		launch.SessionToken, launch.SessionExpiresAt = token, &expiresAt
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	for _, key := range []string{recentGamesKey(playerID), recommendedGamesKey(playerID)} {
+		if err := s.cache.Delete(ctx, key); err != nil {
+			s.logger.Warn("drop cached lobby games", "key", key, "error", err)
+		}
+	}
+	return &launch, nil
+}
+
+// RecentGames returns the games the player launched most recently, newest
+// first, with how often each was launched.
+func (s *CasinoService) RecentGames(ctx context.Context, playerID uuid.UUID, limit int) ([]domain.RecentGame, error) {
+	var games []domain.RecentGame
+	if err := projection.GetJSON(ctx, s.cache, recentGamesKey(playerID), &games); err != nil {
+		games, err = s.queryRecentGames(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheList(ctx, recentGamesKey(playerID), games, recentGamesTTL)
+	}
+	return games[:min(len(games), lobbyLimit(limit))], nil
+}
+
+func (s *CasinoService) queryRecentGames(ctx context.Context, playerID uuid.UUID) ([]domain.RecentGame, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+lobbyGameColumns+`, MAX(l.launched_at), COUNT(*)::int
+		FROM game_launches l
+		JOIN games g ON g.id = l.game_id
+		LEFT JOIN game_manufacturers m ON m.id = g.manufacturer_id
+		WHERE l.player_id = $1 AND l.launched_at >= now() - make_interval(secs => $2)
+		  AND g.active IS NOT FALSE
+		GROUP BY g.id, m.name
+		ORDER BY MAX(l.launched_at) DESC
+		LIMIT $3`, playerID, gameSessionLookback.Seconds(), lobbyListMax)
+	if err != nil {
+		return nil, domain.ErrInternal("query recent games", err)
+	}
+	defer rows.Close()
+
+	games := []domain.RecentGame{}
+	for rows.Next() {
+		var g domain.RecentGame
+		if err := rows.Scan(&g.ID, &g.ManufacturerID, &g.Provider, &g.ExternalGameID, &g.Name, &g.Category,
+			&g.RTP, &g.LastLaunchedAt, &g.Launches); err != nil {
+			return nil, domain.ErrInternal("scan recent game", err)
+		}
+		games = append(games, g)
+	}
+	return games, rows.Err()
+}
+
+// RecommendedGames suggests active games the player has not launched in the
+// recommendation window: first those launched by players who play the same
+// games, then popular games in the categories the player plays, then games
+// popular across the casino. Within each reason, games with more players
+// come first.
+func (s *CasinoService) RecommendedGames(ctx context.Context, playerID uuid.UUID, limit int) ([]domain.RecommendedGame, error) {
+	var games []domain.RecommendedGame
+	if err := projection.GetJSON(ctx, s.cache, recommendedGamesKey(playerID), &games); err != nil {
+		games, err = s.queryRecommendedGames(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheList(ctx, recommendedGamesKey(playerID), games, recommendedGamesTTL)
+	}
+	return games[:min(len(games), lobbyLimit(limit))], nil
+}
+
+func (s *CasinoService) queryRecommendedGames(ctx context.Context, playerID uuid.UUID) ([]domain.RecommendedGame, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH recent AS (
+			SELECT player_id, game_id FROM game_launches
+			WHERE launched_at >= now() - make_interval(secs => $2)
+		), mine AS (
+			SELECT DISTINCT game_id FROM recent WHERE player_id = $1
+		), peers AS (
+			SELECT DISTINCT r.player_id FROM recent r JOIN mine USING (game_id) WHERE r.player_id <> $1
+		), my_categories AS (
+			SELECT DISTINCT g.category FROM mine JOIN games g ON g.id = mine.game_id WHERE g.category IS NOT NULL
+		), popularity AS (
+			SELECT game_id, COUNT(DISTINCT player_id) AS players FROM recent GROUP BY game_id
+		), peer_popularity AS (
+			SELECT r.game_id, COUNT(DISTINCT r.player_id) AS players
+			FROM recent r JOIN peers USING (player_id) GROUP BY r.game_id
+		), ranked AS (
+			SELECT g.id,
+			       CASE WHEN pp.players IS NOT NULL THEN 0
+			            WHEN g.category IN (SELECT category FROM my_categories) THEN 1
+			            ELSE 2 END AS tier,
+			       COALESCE(pp.players, p.players, 0)::int AS players
+			FROM games g
+			LEFT JOIN peer_popularity pp ON pp.game_id = g.id
+			LEFT JOIN popularity p ON p.game_id = g.id
+			WHERE g.active IS NOT FALSE
+			  AND g.id NOT IN (SELECT game_id FROM mine)
+			  AND (pp.players IS NOT NULL OR p.players IS NOT NULL
+			       OR g.category IN (SELECT category FROM my_categories))
+		)
+		SELECT `+lobbyGameColumns+`, r.tier, r.players
+		FROM ranked r
+		JOIN games g ON g.id = r.id
+		LEFT JOIN game_manufacturers m ON m.id = g.manufacturer_id
+		ORDER BY r.tier, r.players DESC, g.name
+		LIMIT $3`, playerID, recommendationWindow.Seconds(), lobbyListMax)
+	if err != nil {
+		return nil, domain.ErrInternal("query recommended games", err)
+	}
+	defer rows.Close()
+
+	reasons := []string{domain.RecommendSimilarPlayers, domain.RecommendSameCategory, domain.RecommendPopular}
+	games := []domain.RecommendedGame{}
+	for rows.Next() {
+		var g domain.RecommendedGame
+		var tier int
+		if err := rows.Scan(&g.ID, &g.ManufacturerID, &g.Provider, &g.ExternalGameID, &g.Name, &g.Category,
+			&g.RTP, &tier, &g.Players); err != nil {
+			return nil, domain.ErrInternal("scan recommended game", err)
+		}
+		g.Reason = reasons[tier]
+		games = append(games, g)
+	}
+	return games, rows.Err()
+}
+
+// cacheList stores a computed lobby list. A failed write is logged; the list
+// is recomputed on the next request.
+func (s *CasinoService) cacheList(ctx context.Context, key string, v any, ttl time.Duration) {
+	if err := projection.SetJSON(ctx, s.cache, key, v, ttl); err != nil {
+		s.logger.Warn("cache lobby games", "key", key, "error", err)
+	}
+}
+
+// lobbyLimit clamps a requested lobby list length.
+func lobbyLimit(limit int) int {
+	if limit <= 0 || limit > lobbyListMax {
+		return 12
+	}
+	return limit
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// casinoReportMaxDays bounds the period of one casino performance report.
+const casinoReportMaxDays = 366
+
+// CasinoReportService aggregates casino stakes and wins per game and day
+// from the ledger, checks each game's actual RTP against its theoretical
+// RTP and serves the casino performance report.
+type CasinoReportService struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+	rules  policy.RTPBandRules
+	logger *slog.Logger
+}
+
+// NewCasinoReportService creates a CasinoReportService. Zero rules use the
+// defaults.
+func NewCasinoReportService(pool *pgxpool.Pool, outbox repository.OutboxRepository, rules policy.RTPBandRules, logger *slog.Logger) *CasinoReportService {
+	if rules == (policy.RTPBandRules{}) {
+		rules = policy.DefaultRTPBandRules()
+	}
+	return &CasinoReportService{pool: pool, outbox: outbox, rules: rules, logger: logger}
+}
+
+// RTPAggregateSummary is the outcome of aggregating one day.
+type RTPAggregateSummary struct {
+	Day    time.Time `json:"day"`
+	Games  int       `json:"games"`
+	Alerts int       `json:"alerts"`
+}
+
+// StartScheduler re-aggregates yesterday and today on a fixed interval until
+// ctx is cancelled, so late wins on yesterday's rounds are picked up.
+func (s *CasinoReportService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("casino rtp scheduler stopped")
+				return
+			case <-ticker.C:
+				today := time.Now().UTC().Truncate(24 * time.Hour)
+				for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+					if _, err := s.Aggregate(ctx, day); err != nil {
+						s.logger.Error("casino rtp aggregation", "day", day.Format(time.DateOnly), "error", err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Aggregate recomputes every game's stakes, wins, rounds and players for the
+// UTC day containing day and raises an alert for each game whose actual RTP
+// is outside its band. Stakes and wins are attributed to the game recorded on
+// their round; voided rounds and rounds with no game are left out.
+func (s *CasinoReportService) Aggregate(ctx context.Context, day time.Time) (*RTPAggregateSummary, error) {
+	day = day.UTC().Truncate(24 * time.Hour)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		WITH activity AS (
+			SELECT r.manufacturer_id, r.game_id,
+			       COALESCE(SUM(t.amount) FILTER (WHERE t.type IN ('bet', 'bet_reserve')), 0)::bigint AS stake,
+			       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'win'), 0)::bigint AS win,
+			       COUNT(DISTINCT r.id)::int AS rounds,
+			       COUNT(DISTINCT r.player_id)::int AS players
+			FROM v2_transactions t
+			JOIN game_rounds r ON r.player_id = t.player_id AND r.manufacturer_id = t.manufacturer_id
+			                  AND r.round_id = t.game_round_id
+			WHERE t.created_at >= $1 AND t.created_at < $1 + interval '1 day'
+			  AND t.type IN ('bet', 'bet_reserve', 'win')
+			  AND NOT (t.type = 'bet' AND t.target_transaction_id IS NOT NULL)
+			  AND r.game_id IS NOT NULL AND r.status <> 'voided'
+			GROUP BY r.manufacturer_id, r.game_id
+		)
+		SELECT a.manufacturer_id, a.game_id, g.name, g.rtp::float8, a.stake, a.win, a.rounds, a.players
+		FROM activity a
+		LEFT JOIN LATERAL (
+			SELECT g.name, g.rtp FROM games g
+			JOIN game_manufacturers m ON m.id = g.manufacturer_id
+			WHERE g.external_game_id = a.game_id
+			  AND (m.id = a.manufacturer_id OR lower(m.name) = lower(a.manufacturer_id))
+			LIMIT 1
+		) g ON true`, day)
+	if err != nil {
+		return nil, domain.ErrInternal("aggregate game activity", err)
+	}
+	var games []domain.GameRTPDay
+	for rows.Next() {
+		g := domain.GameRTPDay{Day: day}
+		if err := rows.Scan(&g.ManufacturerID, &g.GameID, &g.GameName, &g.TheoreticalRTP,
+			&g.StakeTotal, &g.WinTotal, &g.Rounds, &g.Players); err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan game activity", err)
+		}
+		games = append(games, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read game activity", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM game_rtp_daily WHERE day = $1`, day); err != nil {
+		return nil, domain.ErrInternal("clear game rtp day", err)
+	}
+	var raised []domain.GameRTPAlert
+	for _, g := range games {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO game_rtp_daily (manufacturer_id, game_id, day, game_name, theoretical_rtp,
+				stake_total, win_total, rounds, players)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			g.ManufacturerID, g.GameID, day, g.GameName, g.TheoreticalRTP,
+			g.StakeTotal, g.WinTotal, g.Rounds, g.Players); err != nil {
+			return nil, domain.ErrInternal("save game rtp day", err)
+		}
+		if g.TheoreticalRTP == nil {
+			continue
+		}
+		check := policy.CheckRTP(s.rules, *g.TheoreticalRTP, g.StakeTotal, g.WinTotal, g.Rounds)
+		if !check.Deviates {
+			continue
+		}
+		alert, err := s.raiseAlert(ctx, tx, g, check)
+		if err != nil {
+			return nil, err
+		}
+		if alert != nil {
+			raised = append(raised, *alert)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	for _, a := range raised {
+		s.logger.Warn("game rtp outside band", "alert_id", a.ID, "manufacturer_id", a.ManufacturerID,
+			"game_id", a.GameID, "day", a.Day.Format(time.DateOnly), "actual_rtp", a.ActualRTP,
+			"lower_bound", a.LowerBound, "upper_bound", a.UpperBound)
+	}
+	return &RTPAggregateSummary{Day: day, Games: len(games), Alerts: len(raised)}, nil
+}
+
+const rtpAlertColumns = `id, manufacturer_id, game_id, day, theoretical_rtp::float8, actual_rtp::float8,
+	lower_bound::float8, upper_bound::float8, stake_total, rounds, status, acknowledged_by,
+	acknowledged_at, created_at`
+
+func scanRTPAlert(row pgx.Row) (*domain.GameRTPAlert, error) {
+	var a domain.GameRTPAlert
+	if err := row.Scan(&a.ID, &a.ManufacturerID, &a.GameID, &a.Day, &a.TheoreticalRTP, &a.ActualRTP,
+		&a.LowerBound, &a.UpperBound, &a.StakeTotal, &a.Rounds, &a.Status, &a.AcknowledgedBy,
+		&a.AcknowledgedAt, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// raiseAlert records a game's deviating day and publishes it to the outbox.
+// A day already alerted on has its figures refreshed while the alert is open
+// and returns nil.
+func (s *CasinoReportService) raiseAlert(ctx context.Context, tx pgx.Tx, g domain.GameRTPDay, check policy.RTPCheck) (*domain.GameRTPAlert, error) {
+	alert, err := scanRTPAlert(tx.QueryRow(ctx, `
+		INSERT INTO game_rtp_alerts (manufacturer_id, game_id, day, theoretical_rtp, actual_rtp,
+			lower_bound, upper_bound, stake_total, rounds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (manufacturer_id, game_id, day) DO NOTHING
+		RETURNING `+rtpAlertColumns,
+		g.ManufacturerID, g.GameID, g.Day, *g.TheoreticalRTP, check.Actual,
+		check.Lower, check.Upper, g.StakeTotal, g.Rounds))
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := tx.Exec(ctx, `
+			UPDATE game_rtp_alerts
+			SET actual_rtp = $4, lower_bound = $5, upper_bound = $6, stake_total = $7, rounds = $8
+			WHERE manufacturer_id = $1 AND game_id = $2 AND day = $3 AND status = 'open'`,
+			g.ManufacturerID, g.GameID, g.Day, check.Actual, check.Lower, check.Upper,
+			g.StakeTotal, g.Rounds); err != nil {
+			return nil, domain.ErrInternal("refresh rtp alert", err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("insert rtp alert", err)
+	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewGameRTPAlertRaisedEvent(*alert)); err != nil {
+		return nil, domain.ErrInternal("publish rtp alert", err)
+	}
+	return alert, nil
+}
+
+// Report returns per-game casino performance for the UTC days from..to
+// inclusive, largest stake first. A non-empty gameID limits it to that game.
+func (s *CasinoReportService) Report(ctx context.Context, from, to time.Time, gameID string) (*domain.CasinoPerformanceReport, error) {
+	from, to = from.UTC().Truncate(24*time.Hour), to.UTC().Truncate(24*time.Hour)
+	if to.Before(from) {
+		return nil, domain.ErrValidation("to must not be before from")
+	}
+	if to.Sub(from) >= casinoReportMaxDays*24*time.Hour {
+		return nil, domain.ErrValidation("report period is limited to 366 days")
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT manufacturer_id, game_id, day, game_name, theoretical_rtp::float8,
+		       stake_total, win_total, rounds, players, computed_at
+		FROM game_rtp_daily
+		WHERE day BETWEEN $1 AND $2 AND ($3 = '' OR game_id = $3)
+		ORDER BY manufacturer_id, game_id, day`, from, to, gameID)
+	if err != nil {
+		return nil, domain.ErrInternal("query casino performance", err)
+	}
+	defer rows.Close()
+
+	report := &domain.CasinoPerformanceReport{From: from, To: to, Games: []domain.GamePerformance{}}
+	byGame := map[string]int{}
+	for rows.Next() {
+		var d domain.GameRTPDay
+		if err := rows.Scan(&d.ManufacturerID, &d.GameID, &d.Day, &d.GameName, &d.TheoreticalRTP,
+			&d.StakeTotal, &d.WinTotal, &d.Rounds, &d.Players, &d.ComputedAt); err != nil {
+			return nil, domain.ErrInternal("scan casino performance", err)
+		}
+		s.applyCheck(&d)
+
+		key := d.ManufacturerID + ":" + d.GameID
+		i, ok := byGame[key]
+		if !ok {
+			i = len(report.Games)
+			byGame[key] = i
+			report.Games = append(report.Games, domain.GamePerformance{
+				ManufacturerID: d.ManufacturerID, GameID: d.GameID, Days: []domain.GameRTPDay{},
+			})
+		}
+		g := &report.Games[i]
+		g.GameName, g.TheoreticalRTP = d.GameName, d.TheoreticalRTP
+		g.StakeTotal += d.StakeTotal
+		g.WinTotal += d.WinTotal
+		g.Rounds += d.Rounds
+		if d.Deviates {
+			g.DeviatingDays++
+		}
+		g.Days = append(g.Days, d)
+		report.StakeTotal += d.StakeTotal
+		report.WinTotal += d.WinTotal
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read casino performance", err)
+	}
+
+	for i := range report.Games {
+		g := &report.Games[i]
+		g.GGR = g.StakeTotal - g.WinTotal
+		g.ActualRTP = policy.ActualRTP(g.StakeTotal, g.WinTotal)
+	}
+	sort.SliceStable(report.Games, func(i, j int) bool {
+		return report.Games[i].StakeTotal > report.Games[j].StakeTotal
+	})
+	report.GGR = report.StakeTotal - report.WinTotal
+	report.ActualRTP = policy.ActualRTP(report.StakeTotal, report.WinTotal)
+
+	if err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM game_rtp_alerts WHERE status = 'open'`).Scan(&report.OpenAlerts); err != nil {
+		return nil, domain.ErrInternal("count rtp alerts", err)
+	}
+	return report, nil
+}
+
+// applyCheck fills a day's derived figures and its band check.
+func (s *CasinoReportService) applyCheck(d *domain.GameRTPDay) {
+	d.GGR = d.StakeTotal - d.WinTotal
+	if d.Rounds > 0 {
+		d.AvgStake = d.StakeTotal / int64(d.Rounds)
+	}
+	var theoretical float64
+	if d.TheoreticalRTP != nil {
+		theoretical = *d.TheoreticalRTP
+	}
+	check := policy.CheckRTP(s.rules, theoretical, d.StakeTotal, d.WinTotal, d.Rounds)
+	d.ActualRTP = check.Actual
+	if check.Evaluated {
+		d.LowerBound, d.UpperBound = &check.Lower, &check.Upper
+		d.Deviates = check.Deviates
+	}
+}
+
+// ListAlerts returns RTP alerts, newest first. An empty status lists all.
+func (s *CasinoReportService) ListAlerts(ctx context.Context, status string, limit int) ([]domain.GameRTPAlert, error) {
+	if status != "" && status != "open" && status != "acknowledged" {
+		return nil, domain.ErrValidation("status must be open or acknowledged")
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+rtpAlertColumns+` FROM game_rtp_alerts
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC LIMIT $2`, status, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list rtp alerts", err)
+	}
+	defer rows.Close()
+
+	alerts := []domain.GameRTPAlert{}
+	for rows.Next() {
+		a, err := scanRTPAlert(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan rtp alert", err)
+		}
+		alerts = append(alerts, *a)
+	}
+	return alerts, rows.Err()
+}
+
+// AcknowledgeAlert closes an open RTP alert. The game's day is not alerted
+// on again.
+func (s *CasinoReportService) AcknowledgeAlert(ctx context.Context, id, adminID uuid.UUID) (*domain.GameRTPAlert, error) {
+	a, err := scanRTPAlert(s.pool.QueryRow(ctx, `
+		UPDATE game_rtp_alerts
+		SET status = 'acknowledged', acknowledged_by = $2, acknowledged_at = now()
+		WHERE id = $1 AND status = 'open'
+		RETURNING `+rtpAlertColumns, id, adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := s.pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM game_rtp_alerts WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, domain.ErrInternal("find rtp alert", err)
+		}
+		if exists {
+			return nil, domain.ErrConflict("rtp alert already acknowledged")
+		}
+		return nil, domain.ErrNotFound("rtp alert", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("acknowledge rtp alert", err)
+	}
+	return a, nil
+}
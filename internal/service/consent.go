@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// consentHistoryLimit bounds the records returned by ConsentHistory.
+const consentHistoryLimit = 200
+
+// ConsentService records players' marketing, analytics and profiling
+// consent. Consent is opt-in: a purpose and channel without a record is not
+// consented to.
+type ConsentService struct {
+	pool     *pgxpool.Pool
+	consents repository.ConsentRepository
+	outbox   repository.OutboxRepository
+	logger   *slog.Logger
+}
+
+// NewConsentService creates a ConsentService.
+func NewConsentService(pool *pgxpool.Pool, consents repository.ConsentRepository, outbox repository.OutboxRepository, logger *slog.Logger) *ConsentService {
+	return &ConsentService{pool: pool, consents: consents, outbox: outbox, logger: logger}
+}
+
+// ConsentChange grants or withdraws one purpose and channel. Channel may be
+// empty for purposes that are not per channel.
+type ConsentChange struct {
+	Purpose string `json:"purpose"`
+	Channel string `json:"channel"`
+	Granted bool   `json:"granted"`
+}
+
+// ListConsents returns the player's consent for every purpose and channel.
+func (s *ConsentService) ListConsents(ctx context.Context, playerID uuid.UUID) ([]domain.Consent, error) {
+	return s.listConsents(ctx, s.pool, playerID)
+}
+
+func (s *ConsentService) listConsents(ctx context.Context, db repository.DBTX, playerID uuid.UUID) ([]domain.Consent, error) {
+	records, err := s.consents.Current(ctx, db, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list consents", err)
+	}
+	latest := make(map[[2]string]domain.ConsentRecord, len(records))
+	for _, r := range records {
+		latest[[2]string{r.Purpose, r.Channel}] = r
+	}
+
+	consents := []domain.Consent{}
+	for _, purpose := range policy.ConsentPurposes {
+		for _, channel := range policy.ConsentChannels(purpose) {
+			c := domain.Consent{Purpose: purpose, Channel: channel}
+			if r, ok := latest[[2]string{purpose, channel}]; ok {
+				c.Granted = r.Granted
+				c.Source = r.Source
+				c.UpdatedAt = &r.CreatedAt
+			}
+			consents = append(consents, c)
+		}
+	}
+	return consents, nil
+}
+
+// UpdateConsents records the changes that differ from the player's current
+// consent and returns the result. source says where the change came from,
+// ip is the address it was made from, if known.
+func (s *ConsentService) UpdateConsents(ctx context.Context, playerID uuid.UUID, changes []ConsentChange, source, ip string) ([]domain.Consent, error) {
+	if len(changes) == 0 {
+		return nil, domain.ErrValidation("no consent changes")
+	}
+	for i, c := range changes {
+		channel, err := policy.ValidateConsent(c.Purpose, c.Channel)
+		if err != nil {
+			return nil, domain.ErrValidation(err.Error())
+		}
+		changes[i].Channel = channel
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialise a player's consent updates so concurrent changes cannot
+	// both see the same current state.
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM v2_players WHERE id = $1 FOR UPDATE`, playerID); err != nil {
+		return nil, domain.ErrInternal("lock player", err)
+	}
+	current, err := s.listConsents(ctx, tx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[[2]string]bool, len(current))
+	for _, c := range current {
+		granted[[2]string{c.Purpose, c.Channel}] = c.Granted
+	}
+
+	var ipAddr *string
+	if ip != "" {
+		ipAddr = &ip
+	}
+	var recorded []domain.ConsentRecord
+	for _, c := range changes {
+		key := [2]string{c.Purpose, c.Channel}
+		if granted[key] == c.Granted {
+			continue
+		}
+		granted[key] = c.Granted
+		r := domain.ConsentRecord{
+			PlayerID: playerID, Purpose: c.Purpose, Channel: c.Channel,
+			Granted: c.Granted, Source: source, IP: ipAddr,
+		}
+		if err := s.consents.Insert(ctx, tx, &r); err != nil {
+			return nil, domain.ErrInternal("record consent", err)
+		}
+		recorded = append(recorded, r)
+	}
+
+	if len(recorded) > 0 {
+		if err := s.outbox.Insert(ctx, tx, domain.NewConsentChangedEvent(playerID, recorded)); err != nil {
+			return nil, domain.ErrInternal("insert outbox event", err)
+		}
+	}
+	consents, err := s.listConsents(ctx, tx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	if len(recorded) > 0 {
+		s.logger.Info("consents updated", "player_id", playerID, "changes", len(recorded), "source", source)
+	}
+	return consents, nil
+}
+
+// ConsentHistory returns the player's consent records, newest first.
+func (s *ConsentService) ConsentHistory(ctx context.Context, playerID uuid.UUID) ([]domain.ConsentRecord, error) {
+	records, err := s.consents.History(ctx, s.pool, playerID, consentHistoryLimit)
+	if err != nil {
+		return nil, domain.ErrInternal("list consent history", err)
+	}
+	return records, nil
+}
+
+// consented reports whether the player consents to purpose on any channel.
+// A failed lookup counts as no consent.
+func consented(ctx context.Context, consents repository.ConsentRepository, db repository.DBTX, playerID uuid.UUID, purpose string, logger *slog.Logger) bool {
+	channels, err := consents.GrantedChannels(ctx, db, playerID, purpose)
+	if err != nil {
+		logger.Error("check consent", "player_id", playerID, "purpose", purpose, "error", err)
+		return false
+	}
+	return len(channels) > 0
+}
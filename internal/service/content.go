@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	contentSlugPattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	contentLocalePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+)
+
+// contentPageSelect reads a page with the translations of its latest version.
+const contentPageSelect = `
+	SELECT p.id, p.slug, p.default_locale, p.latest_version, p.published_version, p.published_at,
+	       v.translations, p.created_by, p.created_at, p.updated_at
+	FROM content_pages p
+	JOIN content_page_versions v ON v.page_id = p.id AND v.version = p.latest_version`
+
+func scanContentPage(row pgx.Row) (*domain.ContentPage, error) {
+	var p domain.ContentPage
+	if err := row.Scan(&p.ID, &p.Slug, &p.DefaultLocale, &p.LatestVersion, &p.PublishedVersion, &p.PublishedAt,
+		&p.Translations, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ContentService manages CMS pages: versioned, localized markdown served
+// publicly once published.
+type ContentService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewContentService creates a ContentService.
+func NewContentService(pool *pgxpool.Pool, logger *slog.Logger) *ContentService {
+	return &ContentService{pool: pool, logger: logger}
+}
+
+// Published returns the published version of the page at slug in the first
+// of locales it has, trying each locale's base language ("de" for "de-AT")
+// before falling back to the page's default locale.
+func (s *ContentService) Published(ctx context.Context, slug string, locales []string) (*domain.PublishedContent, error) {
+	var defaultLocale string
+	var version int
+	var translations map[string]domain.ContentTranslation
+	out := domain.PublishedContent{Slug: slug}
+	err := s.pool.QueryRow(ctx, `
+		SELECT p.default_locale, p.published_version, p.published_at, v.translations
+		FROM content_pages p
+		JOIN content_page_versions v ON v.page_id = p.id AND v.version = p.published_version
+		WHERE p.slug = $1`, slug).Scan(&defaultLocale, &version, &out.PublishedAt, &translations)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("content page", slug)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find content page", err)
+	}
+
+	out.Version = version
+	out.Locale = pickLocale(translations, locales, defaultLocale)
+	t := translations[out.Locale]
+	out.Title, out.Body = t.Title, t.Body
+	return &out, nil
+}
+
+// pickLocale returns the first requested locale, or its base language, that
+// has a translation; otherwise the default.
+func pickLocale(translations map[string]domain.ContentTranslation, locales []string, defaultLocale string) string {
+	for _, l := range locales {
+		if _, ok := translations[l]; ok {
+			return l
+		}
+		if base, _, found := strings.Cut(l, "-"); found {
+			if _, ok := translations[base]; ok {
+				return base
+			}
+		}
+	}
+	return defaultLocale
+}
+
+// ─── Admin ──────────────────────────────────────────────────────────────────
+
+// ContentPageInput is a new page, or a new version of an existing one.
+type ContentPageInput struct {
+	Slug          string                               `json:"slug"`
+	DefaultLocale string                               `json:"default_locale"`
+	Translations  map[string]domain.ContentTranslation `json:"translations"`
+}
+
+func (in *ContentPageInput) validate() error {
+	in.Slug = strings.TrimSpace(in.Slug)
+	if in.DefaultLocale == "" {
+		in.DefaultLocale = "en"
+	}
+	if !contentSlugPattern.MatchString(in.Slug) {
+		return domain.ErrValidation("slug must be lowercase letters, digits and hyphens")
+	}
+	if len(in.Translations) == 0 {
+		return domain.ErrValidation("at least one translation is required")
+	}
+	for locale, t := range in.Translations {
+		if !contentLocalePattern.MatchString(locale) {
+			return domain.ErrValidation("invalid locale: " + locale)
+		}
+		if strings.TrimSpace(t.Title) == "" || strings.TrimSpace(t.Body) == "" {
+			return domain.ErrValidation("title and body are required for locale " + locale)
+		}
+	}
+	if _, ok := in.Translations[in.DefaultLocale]; !ok {
+		return domain.ErrValidation("translations must include the default locale " + in.DefaultLocale)
+	}
+	return nil
+}
+
+// ListPages returns every page, drafts included, with its latest version.
+func (s *ContentService) ListPages(ctx context.Context) ([]domain.ContentPage, error) {
+	rows, err := s.pool.Query(ctx, contentPageSelect+` ORDER BY p.slug`)
+	if err != nil {
+		return nil, domain.ErrInternal("list content pages", err)
+	}
+	defer rows.Close()
+
+	pages := []domain.ContentPage{}
+	for rows.Next() {
+		p, err := scanContentPage(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan content page", err)
+		}
+		pages = append(pages, *p)
+	}
+	return pages, rows.Err()
+}
+
+// GetPage returns a page with its latest version.
+func (s *ContentService) GetPage(ctx context.Context, id uuid.UUID) (*domain.ContentPage, error) {
+	p, err := scanContentPage(s.pool.QueryRow(ctx, contentPageSelect+` WHERE p.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("content page", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find content page", err)
+	}
+	return p, nil
+}
+
+// CreatePage stores a new unpublished page as version 1.
+func (s *ContentService) CreatePage(ctx context.Context, input ContentPageInput, adminID uuid.UUID) (*domain.ContentPage, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO content_pages (slug, default_locale, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (slug) DO NOTHING
+		RETURNING id`, input.Slug, input.DefaultLocale, adminID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrConflict("content page slug already exists: " + input.Slug)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("insert content page", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO content_page_versions (page_id, version, translations, created_by)
+		VALUES ($1, 1, $2, $3)`, id, input.Translations, adminID); err != nil {
+		return nil, domain.ErrInternal("insert content page version", err)
+	}
+
+	page, err := scanContentPage(tx.QueryRow(ctx, contentPageSelect+` WHERE p.id = $1`, id))
+	if err != nil {
+		return nil, domain.ErrInternal("read content page", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("content page created", "slug", page.Slug, "id", page.ID)
+	return page, nil
+}
+
+// UpdatePage saves the input as the page's next version. The published
+// version keeps being served until the new one is published.
+func (s *ContentService) UpdatePage(ctx context.Context, id uuid.UUID, input ContentPageInput, adminID uuid.UUID) (*domain.ContentPage, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var taken bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM content_pages WHERE slug = $1 AND id <> $2)`,
+		input.Slug, id).Scan(&taken); err != nil {
+		return nil, domain.ErrInternal("check content page slug", err)
+	}
+	if taken {
+		return nil, domain.ErrConflict("content page slug already exists: " + input.Slug)
+	}
+
+	var version int
+	err = tx.QueryRow(ctx, `
+		UPDATE content_pages
+		SET slug = $2, default_locale = $3, latest_version = latest_version + 1, updated_at = now()
+		WHERE id = $1
+		RETURNING latest_version`, id, input.Slug, input.DefaultLocale).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("content page", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("update content page", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO content_page_versions (page_id, version, translations, created_by)
+		VALUES ($1, $2, $3, $4)`, id, version, input.Translations, adminID); err != nil {
+		return nil, domain.ErrInternal("insert content page version", err)
+	}
+
+	page, err := scanContentPage(tx.QueryRow(ctx, contentPageSelect+` WHERE p.id = $1`, id))
+	if err != nil {
+		return nil, domain.ErrInternal("read content page", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return page, nil
+}
+
+// ListVersions returns a page's version history, newest first.
+func (s *ContentService) ListVersions(ctx context.Context, id uuid.UUID) ([]domain.ContentPageVersion, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT page_id, version, translations, created_by, created_at
+		FROM content_page_versions WHERE page_id = $1
+		ORDER BY version DESC`, id)
+	if err != nil {
+		return nil, domain.ErrInternal("list content page versions", err)
+	}
+	defer rows.Close()
+
+	versions := []domain.ContentPageVersion{}
+	for rows.Next() {
+		var v domain.ContentPageVersion
+		if err := rows.Scan(&v.PageID, &v.Version, &v.Translations, &v.CreatedBy, &v.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan content page version", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("list content page versions", err)
+	}
+	if len(versions) == 0 {
+		return nil, domain.ErrNotFound("content page", id.String())
+	}
+	return versions, nil
+}
+
+// Publish serves the given version of a page, or its latest when version is
+// zero. Publishing an older version rolls the page back.
+func (s *ContentService) Publish(ctx context.Context, id uuid.UUID, version int) (*domain.ContentPage, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE content_pages p
+		SET published_version = v.version, published_at = now(), updated_at = now()
+		FROM content_page_versions v
+		WHERE p.id = $1 AND v.page_id = p.id
+		  AND v.version = CASE WHEN $2 = 0 THEN p.latest_version ELSE $2 END`, id, version)
+	if err != nil {
+		return nil, domain.ErrInternal("publish content page", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.ErrNotFound("content page version", id.String())
+	}
+	page, err := s.GetPage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("content page published", "slug", page.Slug, "version", *page.PublishedVersion)
+	return page, nil
+}
+
+// Unpublish takes a page offline without discarding its versions.
+func (s *ContentService) Unpublish(ctx context.Context, id uuid.UUID) (*domain.ContentPage, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE content_pages SET published_version = NULL, published_at = NULL, updated_at = now()
+		WHERE id = $1`, id)
+	if err != nil {
+		return nil, domain.ErrInternal("unpublish content page", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.ErrNotFound("content page", id.String())
+	}
+	return s.GetPage(ctx, id)
+}
+
+// DeletePage removes a page and its history.
+func (s *ContentService) DeletePage(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM content_pages WHERE id = $1`, id)
+	if err != nil {
+		return domain.ErrInternal("delete content page", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("content page", id.String())
+	}
+	s.logger.Info("content page deleted", "id", id)
+	return nil
+}
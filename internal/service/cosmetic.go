@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var cosmeticSlots = map[string]bool{"avatar": true, "frame": true, "hat": true, "background": true, "badge": true}
+
+var cosmeticRarities = map[string]bool{"common": true, "rare": true, "epic": true, "legendary": true}
+
+const cosmeticItemColumns = `code, name, slot, rarity, image_url, active, created_at, updated_at`
+
+func scanCosmeticItem(row pgx.Row) (*domain.CosmeticItem, error) {
+	var c domain.CosmeticItem
+	if err := row.Scan(&c.Code, &c.Name, &c.Slot, &c.Rarity, &c.ImageURL, &c.Active, &c.CreatedAt,
+		&c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CosmeticService manages the cosmetic catalog, player inventories and what
+// players have equipped.
+type CosmeticService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewCosmeticService creates a CosmeticService.
+func NewCosmeticService(pool *pgxpool.Pool, logger *slog.Logger) *CosmeticService {
+	return &CosmeticService{pool: pool, logger: logger}
+}
+
+// ─── Catalog ────────────────────────────────────────────────────────────────
+
+// CosmeticItemInput holds a new or updated catalog item.
+type CosmeticItemInput struct {
+	Name     string `json:"name"`
+	Slot     string `json:"slot"`
+	Rarity   string `json:"rarity"`
+	ImageURL string `json:"image_url"`
+	Active   *bool  `json:"active,omitempty"`
+}
+
+// ListItems returns the cosmetic catalog.
+func (s *CosmeticService) ListItems(ctx context.Context) ([]domain.CosmeticItem, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+cosmeticItemColumns+` FROM cosmetic_items ORDER BY slot, code`)
+	if err != nil {
+		return nil, domain.ErrInternal("list cosmetic items", err)
+	}
+	defer rows.Close()
+
+	items := []domain.CosmeticItem{}
+	for rows.Next() {
+		c, err := scanCosmeticItem(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan cosmetic item", err)
+		}
+		items = append(items, *c)
+	}
+	return items, rows.Err()
+}
+
+// PutItem creates or updates a catalog item. Owned copies carry their slot,
+// so an existing item's slot cannot change.
+func (s *CosmeticService) PutItem(ctx context.Context, code string, input CosmeticItemInput) (*domain.CosmeticItem, error) {
+	code = strings.TrimSpace(code)
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Rarity == "" {
+		input.Rarity = "common"
+	}
+	if code == "" || input.Name == "" {
+		return nil, domain.ErrValidation("code and name are required")
+	}
+	if !cosmeticSlots[input.Slot] {
+		return nil, domain.ErrValidation("slot must be avatar, frame, hat, background or badge")
+	}
+	if !cosmeticRarities[input.Rarity] {
+		return nil, domain.ErrValidation("rarity must be common, rare, epic or legendary")
+	}
+	active := input.Active == nil || *input.Active
+
+	c, err := scanCosmeticItem(s.pool.QueryRow(ctx, `
+		INSERT INTO cosmetic_items (code, name, slot, rarity, image_url, active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (code) DO UPDATE
+		SET name = EXCLUDED.name, rarity = EXCLUDED.rarity, image_url = EXCLUDED.image_url,
+		    active = EXCLUDED.active, updated_at = now()
+		WHERE cosmetic_items.slot = EXCLUDED.slot
+		RETURNING `+cosmeticItemColumns,
+		code, input.Name, input.Slot, input.Rarity, input.ImageURL, active))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrConflict("cosmetic item slot cannot change: " + code)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("save cosmetic item", err)
+	}
+	return c, nil
+}
+
+// ─── Inventory ──────────────────────────────────────────────────────────────
+
+// Inventory returns the items a player owns, equipped ones first.
+func (s *CosmeticService) Inventory(ctx context.Context, playerID uuid.UUID) ([]domain.PlayerCosmetic, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT pc.item_code, ci.name, pc.slot, ci.rarity, ci.image_url, pc.source, pc.source_ref,
+		       pc.equipped, pc.acquired_at
+		FROM player_cosmetics pc
+		JOIN cosmetic_items ci ON ci.code = pc.item_code
+		WHERE pc.player_id = $1
+		ORDER BY pc.equipped DESC, pc.acquired_at DESC`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list inventory", err)
+	}
+	defer rows.Close()
+
+	items := []domain.PlayerCosmetic{}
+	for rows.Next() {
+		var c domain.PlayerCosmetic
+		if err := rows.Scan(&c.ItemCode, &c.Name, &c.Slot, &c.Rarity, &c.ImageURL, &c.Source, &c.SourceRef,
+			&c.Equipped, &c.AcquiredAt); err != nil {
+			return nil, domain.ErrInternal("scan inventory item", err)
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// Grant adds an item to a player's inventory. Granting an item the player
+// already owns is a no-op and reports false.
+func (s *CosmeticService) Grant(ctx context.Context, playerID uuid.UUID, code string, source domain.CosmeticSource, sourceRef string) (bool, error) {
+	switch source {
+	case domain.CosmeticSourceAchievement, domain.CosmeticSourceTournament, domain.CosmeticSourceAdmin:
+	default:
+		return false, domain.ErrValidation("source must be achievement, tournament or admin")
+	}
+	granted, err := grantCosmetic(ctx, s.pool, playerID, code, source, sourceRef)
+	if err != nil {
+		return false, err
+	}
+	if granted {
+		s.logger.Info("cosmetic granted", "player_id", playerID, "item_code", code, "source", source)
+	}
+	return granted, nil
+}
+
+// grantCosmetic inserts an inventory row in the caller's transaction or pool.
+// Store purchases grant through it when their payment completes.
+func grantCosmetic(ctx context.Context, db repository.DBTX, playerID uuid.UUID, code string, source domain.CosmeticSource, sourceRef string) (bool, error) {
+	var slot string
+	err := db.QueryRow(ctx, `SELECT slot FROM cosmetic_items WHERE code = $1`, code).Scan(&slot)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, domain.ErrNotFound("cosmetic item", code)
+	}
+	if err != nil {
+		return false, domain.ErrInternal("find cosmetic item", err)
+	}
+
+	tag, err := db.Exec(ctx, `
+		INSERT INTO player_cosmetics (player_id, item_code, slot, source, source_ref)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (player_id, item_code) DO NOTHING`,
+		playerID, code, slot, source, sourceRef)
+	if err != nil {
+		return false, domain.ErrInternal("grant cosmetic", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Equip equips an owned item, replacing whatever was equipped in its slot.
+func (s *CosmeticService) Equip(ctx context.Context, playerID uuid.UUID, code string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var slot string
+	err = tx.QueryRow(ctx, `
+		SELECT slot FROM player_cosmetics WHERE player_id = $1 AND item_code = $2 FOR UPDATE`,
+		playerID, code).Scan(&slot)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("inventory item", code)
+	}
+	if err != nil {
+		return domain.ErrInternal("find inventory item", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE player_cosmetics SET equipped = false
+		WHERE player_id = $1 AND slot = $2 AND equipped AND item_code <> $3`,
+		playerID, slot, code); err != nil {
+		return domain.ErrInternal("unequip slot", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE player_cosmetics SET equipped = true WHERE player_id = $1 AND item_code = $2`,
+		playerID, code); err != nil {
+		return domain.ErrInternal("equip item", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	return nil
+}
+
+// Unequip takes an owned item off.
+func (s *CosmeticService) Unequip(ctx context.Context, playerID uuid.UUID, code string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE player_cosmetics SET equipped = false WHERE player_id = $1 AND item_code = $2`,
+		playerID, code)
+	if err != nil {
+		return domain.ErrInternal("unequip item", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("inventory item", code)
+	}
+	return nil
+}
+
+// Equipped returns the equipped items of each player, for decorating feeds.
+// Players with nothing equipped are absent from the map.
+func (s *CosmeticService) Equipped(ctx context.Context, playerIDs []uuid.UUID) (map[uuid.UUID][]domain.EquippedCosmetic, error) {
+	out := make(map[uuid.UUID][]domain.EquippedCosmetic)
+	if len(playerIDs) == 0 {
+		return out, nil
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT pc.player_id, pc.slot, pc.item_code, ci.name, ci.rarity, ci.image_url
+		FROM player_cosmetics pc
+		JOIN cosmetic_items ci ON ci.code = pc.item_code
+		WHERE pc.player_id = ANY($1) AND pc.equipped
+		ORDER BY pc.slot`, playerIDs)
+	if err != nil {
+		return nil, domain.ErrInternal("list equipped cosmetics", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerID uuid.UUID
+		var c domain.EquippedCosmetic
+		if err := rows.Scan(&playerID, &c.Slot, &c.ItemCode, &c.Name, &c.Rarity, &c.ImageURL); err != nil {
+			return nil, domain.ErrInternal("scan equipped cosmetic", err)
+		}
+		out[playerID] = append(out[playerID], c)
+	}
+	return out, rows.Err()
+}
+
+// PublicProfile returns what other players see of a player: a display name
+// built from the first name and last initial, and the equipped cosmetics.
+func (s *CosmeticService) PublicProfile(ctx context.Context, playerID uuid.UUID) (*domain.PublicProfile, error) {
+	var firstName, lastName *string
+	var memberSince time.Time
+	err := s.pool.QueryRow(ctx, `
+		SELECT first_name, last_name, created_at FROM player_profiles WHERE player_id = $1`,
+		playerID).Scan(&firstName, &lastName, &memberSince)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find player profile", err)
+	}
+
+	equipped, err := s.Equipped(ctx, []uuid.UUID{playerID})
+	if err != nil {
+		return nil, err
+	}
+	profile := &domain.PublicProfile{
+		PlayerID:    playerID,
+		DisplayName: displayName(playerID, firstName, lastName),
+		MemberSince: memberSince,
+		Equipped:    equipped[playerID],
+	}
+	if profile.Equipped == nil {
+		profile.Equipped = []domain.EquippedCosmetic{}
+	}
+	return profile, nil
+}
+
+// displayName never exposes a full surname. Players without a first name are
+// shown by the start of their id.
+func displayName(playerID uuid.UUID, firstName, lastName *string) string {
+	if firstName == nil || strings.TrimSpace(*firstName) == "" {
+		return fmt.Sprintf("Player %s", playerID.String()[:8])
+	}
+	name := strings.TrimSpace(*firstName)
+	if lastName != nil {
+		if last := strings.TrimSpace(*lastName); last != "" {
+			name += " " + string([]rune(last)[:1]) + "."
+		}
+	}
+	return name
+}
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const experimentColumns = `id, key, flag_key, description, status, traffic_pct, variants,
+	started_at, stopped_at, created_at`
+
+// ExperimentService owns feature flags and the A/B experiments layered on them.
+// A running experiment attached to a flag replaces the flag's value with the
+// variant the player hashes into.
+type ExperimentService struct {
+	pool     *pgxpool.Pool
+	outbox   repository.OutboxRepository
+	consents repository.ConsentRepository
+	logger   *slog.Logger
+}
+
+// NewExperimentService creates an ExperimentService.
+func NewExperimentService(pool *pgxpool.Pool, outbox repository.OutboxRepository, consents repository.ConsentRepository, logger *slog.Logger) *ExperimentService {
+	return &ExperimentService{pool: pool, outbox: outbox, consents: consents, logger: logger}
+}
+
+// EvaluateFlags returns every flag's value for the player. It does not log
+// exposures; clients report those via ExposeFlag when a variant is shown.
+func (s *ExperimentService) EvaluateFlags(ctx context.Context, playerID uuid.UUID) (map[string]domain.FlagEvaluation, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+experimentColumns+` FROM experiments WHERE status = 'running' AND flag_key IS NOT NULL`)
+	if err != nil {
+		return nil, domain.ErrInternal("list running experiments", err)
+	}
+	running := make(map[string]*domain.Experiment)
+	for rows.Next() {
+		exp, err := scanExperiment(rows)
+		if err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan experiment", err)
+		}
+		running[*exp.FlagKey] = exp
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("list running experiments", err)
+	}
+
+	flags, err := s.ListFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	evals := make(map[string]domain.FlagEvaluation, len(flags))
+	for _, f := range flags {
+		eval := domain.FlagEvaluation{Enabled: f.Enabled, Value: f.Value}
+		if exp, ok := running[f.Key]; ok && f.Enabled {
+			if v := assign(exp, playerID); v != nil {
+				eval.Value, eval.Experiment, eval.Variant = v.Value, exp.Key, v.Name
+			}
+		}
+		evals[f.Key] = eval
+	}
+	return evals, nil
+}
+
+// ExposeFlag logs that the player saw the experiment-driven value of a flag and
+// returns that value. Flags without a running experiment are returned unlogged.
+func (s *ExperimentService) ExposeFlag(ctx context.Context, playerID uuid.UUID, flagKey string) (*domain.FlagEvaluation, error) {
+	var eval domain.FlagEvaluation
+	err := s.pool.QueryRow(ctx,
+		`SELECT enabled, value FROM feature_flags WHERE key = $1`, flagKey).Scan(&eval.Enabled, &eval.Value)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("feature flag", flagKey)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find flag", err)
+	}
+	if !eval.Enabled {
+		return &eval, nil
+	}
+
+	exp, err := scanExperiment(s.pool.QueryRow(ctx, `
+		SELECT `+experimentColumns+` FROM experiments
+		WHERE flag_key = $1 AND status = 'running'`, flagKey))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &eval, nil
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find experiment", err)
+	}
+
+	v, err := s.expose(ctx, playerID, exp)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		eval.Value, eval.Experiment, eval.Variant = v.Value, exp.Key, v.Name
+	}
+	return &eval, nil
+}
+
+// AssignExperiment returns the player's variant for a running experiment and
+// logs the exposure. Server-side callers use it to pick, e.g., a bonus amount.
+// Returns nil when the experiment is not running or the player is not in it.
+func (s *ExperimentService) AssignExperiment(ctx context.Context, playerID uuid.UUID, experimentKey string) (*domain.ExperimentVariant, error) {
+	exp, err := scanExperiment(s.pool.QueryRow(ctx, `
+		SELECT `+experimentColumns+` FROM experiments WHERE key = $1`, experimentKey))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("experiment", experimentKey)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find experiment", err)
+	}
+	if exp.Status != domain.ExperimentRunning {
+		return nil, nil
+	}
+	return s.expose(ctx, playerID, exp)
+}
+
+// expose records the first exposure (with an outbox event) and returns the
+// variant. Exposures of players without analytics consent are not recorded.
+func (s *ExperimentService) expose(ctx context.Context, playerID uuid.UUID, exp *domain.Experiment) (*domain.ExperimentVariant, error) {
+	v := assign(exp, playerID)
+	if v == nil {
+		return nil, nil
+	}
+	if !consented(ctx, s.consents, s.pool, playerID, domain.ConsentAnalytics, s.logger) {
+		return v, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO experiment_exposures (experiment_id, player_id, variant)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (experiment_id, player_id) DO NOTHING`, exp.ID, playerID, v.Name)
+	if err != nil {
+		return nil, domain.ErrInternal("record exposure", err)
+	}
+	if tag.RowsAffected() == 1 {
+		if err := s.outbox.Insert(ctx, tx, domain.NewExperimentExposedEvent(playerID, exp.Key, v.Name)); err != nil {
+			return nil, domain.ErrInternal("insert outbox event", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return v, nil
+}
+
+func assign(exp *domain.Experiment, playerID uuid.UUID) *domain.ExperimentVariant {
+	weighted := make([]policy.WeightedVariant, len(exp.Variants))
+	for i, v := range exp.Variants {
+		weighted[i] = policy.WeightedVariant{Name: v.Name, Weight: v.Weight}
+	}
+	name, ok := policy.AssignVariant(exp.Key, playerID, exp.TrafficPct, weighted)
+	if !ok {
+		return nil
+	}
+	for i := range exp.Variants {
+		if exp.Variants[i].Name == name {
+			return &exp.Variants[i]
+		}
+	}
+	return nil
+}
+
+// --- Admin ---
+
+// ListFlags returns all feature flags.
+func (s *ExperimentService) ListFlags(ctx context.Context) ([]domain.FeatureFlag, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT key, description, enabled, value, updated_at FROM feature_flags ORDER BY key`)
+	if err != nil {
+		return nil, domain.ErrInternal("list flags", err)
+	}
+	defer rows.Close()
+
+	flags := []domain.FeatureFlag{}
+	for rows.Next() {
+		var f domain.FeatureFlag
+		if err := rows.Scan(&f.Key, &f.Description, &f.Enabled, &f.Value, &f.UpdatedAt); err != nil {
+			return nil, domain.ErrInternal("scan flag", err)
+		}
+		flags = append(flags, f)
+	}
+	return flags, rows.Err()
+}
+
+// FlagInput is the admin-editable part of a feature flag.
+type FlagInput struct {
+	Description *string         `json:"description,omitempty"`
+	Enabled     bool            `json:"enabled"`
+	Value       json.RawMessage `json:"value,omitempty"`
+}
+
+// SetFlag creates or replaces a feature flag.
+func (s *ExperimentService) SetFlag(ctx context.Context, key string, input FlagInput, adminID uuid.UUID) (*domain.FeatureFlag, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, domain.ErrValidation("flag key is required")
+	}
+	if len(input.Value) == 0 {
+		input.Value = json.RawMessage(`true`)
+	}
+	if !json.Valid(input.Value) {
+		return nil, domain.ErrValidation("value must be valid JSON")
+	}
+
+	f := domain.FeatureFlag{Key: key}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO feature_flags (key, description, enabled, value, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key) DO UPDATE
+		  SET description = EXCLUDED.description, enabled = EXCLUDED.enabled,
+		      value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = now()
+		RETURNING description, enabled, value, updated_at`,
+		key, input.Description, input.Enabled, input.Value, adminID,
+	).Scan(&f.Description, &f.Enabled, &f.Value, &f.UpdatedAt)
+	if err != nil {
+		return nil, domain.ErrInternal("set flag", err)
+	}
+	s.logger.Info("feature flag updated", "key", key, "enabled", f.Enabled, "admin_id", adminID)
+	return &f, nil
+}
+
+// ListExperiments returns all experiments, newest first.
+func (s *ExperimentService) ListExperiments(ctx context.Context) ([]domain.Experiment, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+experimentColumns+` FROM experiments ORDER BY created_at DESC LIMIT 100`)
+	if err != nil {
+		return nil, domain.ErrInternal("list experiments", err)
+	}
+	defer rows.Close()
+
+	experiments := []domain.Experiment{}
+	for rows.Next() {
+		exp, err := scanExperiment(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan experiment", err)
+		}
+		experiments = append(experiments, *exp)
+	}
+	return experiments, rows.Err()
+}
+
+// ExperimentInput holds a new experiment definition.
+type ExperimentInput struct {
+	Key         string                     `json:"key"`
+	FlagKey     *string                    `json:"flag_key,omitempty"`
+	Description *string                    `json:"description,omitempty"`
+	TrafficPct  *int                       `json:"traffic_pct,omitempty"`
+	Variants    []domain.ExperimentVariant `json:"variants"`
+}
+
+// CreateExperiment stores a draft experiment.
+func (s *ExperimentService) CreateExperiment(ctx context.Context, input ExperimentInput, adminID uuid.UUID) (*domain.Experiment, error) {
+	input.Key = strings.TrimSpace(input.Key)
+	if input.Key == "" {
+		return nil, domain.ErrValidation("experiment key is required")
+	}
+	traffic := 100
+	if input.TrafficPct != nil {
+		traffic = *input.TrafficPct
+	}
+	if traffic < 0 || traffic > 100 {
+		return nil, domain.ErrValidation("traffic_pct must be between 0 and 100")
+	}
+	if len(input.Variants) < 2 {
+		return nil, domain.ErrValidation("an experiment needs at least two variants")
+	}
+	seen := make(map[string]bool)
+	for i, v := range input.Variants {
+		if v.Name == "" || v.Weight <= 0 {
+			return nil, domain.ErrValidation("every variant needs a name and a positive weight")
+		}
+		if seen[v.Name] {
+			return nil, domain.ErrValidation(fmt.Sprintf("duplicate variant: %s", v.Name))
+		}
+		seen[v.Name] = true
+		if len(v.Value) == 0 {
+			input.Variants[i].Value = json.RawMessage(`null`)
+		} else if !json.Valid(v.Value) {
+			return nil, domain.ErrValidation(fmt.Sprintf("variant %s value must be valid JSON", v.Name))
+		}
+	}
+
+	var exists bool
+	if err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM experiments WHERE key = $1)`, input.Key).Scan(&exists); err != nil {
+		return nil, domain.ErrInternal("check experiment key", err)
+	}
+	if exists {
+		return nil, domain.ErrConflict(fmt.Sprintf("experiment %s already exists", input.Key))
+	}
+	if input.FlagKey != nil {
+		if err := s.pool.QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM feature_flags WHERE key = $1)`, *input.FlagKey).Scan(&exists); err != nil {
+			return nil, domain.ErrInternal("check flag", err)
+		}
+		if !exists {
+			return nil, domain.ErrValidation(fmt.Sprintf("unknown feature flag: %s", *input.FlagKey))
+		}
+	}
+
+	variants, _ := json.Marshal(input.Variants)
+	exp, err := scanExperiment(s.pool.QueryRow(ctx, `
+		INSERT INTO experiments (key, flag_key, description, traffic_pct, variants, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+experimentColumns,
+		input.Key, input.FlagKey, input.Description, traffic, variants, adminID))
+	if err != nil {
+		return nil, domain.ErrInternal("create experiment", err)
+	}
+	s.logger.Info("experiment created", "experiment", exp.Key, "admin_id", adminID)
+	return exp, nil
+}
+
+// SetExperimentStatus moves an experiment draft → running → stopped. Only one
+// running experiment may drive a flag at a time.
+func (s *ExperimentService) SetExperimentStatus(ctx context.Context, id uuid.UUID, status domain.ExperimentStatus) (*domain.Experiment, error) {
+	exp, err := scanExperiment(s.pool.QueryRow(ctx,
+		`SELECT `+experimentColumns+` FROM experiments WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("experiment", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find experiment", err)
+	}
+
+	switch {
+	case status == domain.ExperimentRunning && exp.Status == domain.ExperimentDraft:
+		if exp.FlagKey != nil {
+			var busy bool
+			if err := s.pool.QueryRow(ctx, `
+				SELECT EXISTS(SELECT 1 FROM experiments WHERE flag_key = $1 AND status = 'running')`,
+				*exp.FlagKey).Scan(&busy); err != nil {
+				return nil, domain.ErrInternal("check running experiments", err)
+			}
+			if busy {
+				return nil, domain.ErrConflict(fmt.Sprintf("flag %s already has a running experiment", *exp.FlagKey))
+			}
+		}
+	case status == domain.ExperimentStopped && exp.Status == domain.ExperimentRunning:
+	default:
+		return nil, domain.ErrConflict(fmt.Sprintf("cannot move experiment from %s to %s", exp.Status, status))
+	}
+
+	exp, err = scanExperiment(s.pool.QueryRow(ctx, `
+		UPDATE experiments
+		SET status = $2::varchar,
+		    started_at = CASE WHEN $2::varchar = 'running' THEN now() ELSE started_at END,
+		    stopped_at = CASE WHEN $2::varchar = 'stopped' THEN now() ELSE stopped_at END,
+		    updated_at = now()
+		WHERE id = $1
+		RETURNING `+experimentColumns, id, string(status)))
+	if err != nil {
+		return nil, domain.ErrInternal("update experiment", err)
+	}
+	s.logger.Info("experiment status changed", "experiment", exp.Key, "status", exp.Status)
+	return exp, nil
+}
+
+// ExperimentResults aggregates post-exposure outcomes per variant.
+func (s *ExperimentService) ExperimentResults(ctx context.Context, id uuid.UUID) ([]domain.VariantResult, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT x.variant,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE o.deposit_total > 0),
+		       COALESCE(SUM(o.deposit_total), 0)::bigint,
+		       COALESCE(SUM(o.bet_total), 0)::bigint
+		FROM experiment_exposures x
+		LEFT JOIN LATERAL (
+		  SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'deposit'), 0) AS deposit_total,
+		         COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'bet'), 0) AS bet_total
+		  FROM v2_transactions t
+		  WHERE t.player_id = x.player_id AND t.created_at >= x.exposed_at
+		    AND t.type IN ('deposit', 'bet')
+		) o ON true
+		WHERE x.experiment_id = $1
+		GROUP BY x.variant
+		ORDER BY x.variant`, id)
+	if err != nil {
+		return nil, domain.ErrInternal("experiment results", err)
+	}
+	defer rows.Close()
+
+	results := []domain.VariantResult{}
+	for rows.Next() {
+		var r domain.VariantResult
+		if err := rows.Scan(&r.Variant, &r.Exposed, &r.DepositingPlayers, &r.DepositTotal, &r.BetTotal); err != nil {
+			return nil, domain.ErrInternal("scan variant result", err)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+func scanExperiment(row pgx.Row) (*domain.Experiment, error) {
+	var exp domain.Experiment
+	var variants []byte
+	err := row.Scan(&exp.ID, &exp.Key, &exp.FlagKey, &exp.Description, &exp.Status, &exp.TrafficPct,
+		&variants, &exp.StartedAt, &exp.StoppedAt, &exp.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(variants, &exp.Variants); err != nil {
+		return nil, fmt.Errorf("decode variants: %w", err)
+	}
+	return &exp, nil
+}
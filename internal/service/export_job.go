@@ -0,0 +1,426 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultMaxExportsPerAdmin caps each admin's queued and running export jobs
+// when no limit is configured.
+const DefaultMaxExportsPerAdmin = 2
+
+// exportDownloadTTL is how long a presigned export download link works.
+const exportDownloadTTL = 15 * time.Minute
+
+// exportProgressEvery is how many rows are written between progress updates.
+const exportProgressEvery = 500
+
+// exportLease is how long a job stays claimed by the replica running it
+// without a renewal. The runner renews it every quarter lease; a job whose
+// lease lapsed was interrupted.
+const exportLease = 2 * time.Minute
+
+const exportJobColumns = `id, admin_id, kind, format, from_at, to_at, status, total_rows, rows_written,
+	error, object_key, created_at, started_at, finished_at`
+
+func scanExportJob(row pgx.Row) (*domain.ExportJob, error) {
+	var j domain.ExportJob
+	if err := row.Scan(&j.ID, &j.AdminID, &j.Kind, &j.Format, &j.From, &j.To, &j.Status, &j.TotalRows,
+		&j.RowsWritten, &j.Error, &j.ObjectKey, &j.CreatedAt, &j.StartedAt, &j.FinishedAt); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// exportSource is the query behind one export kind. rows selects the rows
+// created in [$1, $2) in file order; record turns the scanned columns into
+// a CSV record and a JSON object.
+type exportSource struct {
+	count  string
+	rows   string
+	header []string
+	record func(pgx.Rows) ([]string, map[string]any, error)
+}
+
+var exportSources = map[string]exportSource{
+	domain.ExportKindTransactions: {
+		count: `SELECT count(*) FROM v2_transactions WHERE created_at >= $1 AND created_at < $2`,
+		rows: `
+			SELECT id, player_id, type, amount::bigint, balance_after::bigint, bonus_balance_after::bigint,
+			       COALESCE(external_transaction_id, ''), COALESCE(manufacturer_id, ''), created_at
+			FROM v2_transactions
+			WHERE created_at >= $1 AND created_at < $2
+			ORDER BY created_at, id`,
+		header: []string{"id", "player_id", "type", "amount", "balance_after", "bonus_balance_after",
+			"external_transaction_id", "manufacturer_id", "created_at"},
+		record: func(rows pgx.Rows) ([]string, map[string]any, error) {
+			var id, playerID uuid.UUID
+			var txType, extID, mfgID string
+			var amount, balance, bonus int64
+			var createdAt time.Time
+			if err := rows.Scan(&id, &playerID, &txType, &amount, &balance, &bonus, &extID, &mfgID, &createdAt); err != nil {
+				return nil, nil, err
+			}
+			return []string{id.String(), playerID.String(), txType, strconv.FormatInt(amount, 10),
+					strconv.FormatInt(balance, 10), strconv.FormatInt(bonus, 10), extID, mfgID,
+					createdAt.UTC().Format(time.RFC3339)},
+				map[string]any{"id": id, "player_id": playerID, "type": txType, "amount": amount,
+					"balance_after": balance, "bonus_balance_after": bonus, "external_transaction_id": extID,
+					"manufacturer_id": mfgID, "created_at": createdAt},
+				nil
+		},
+	},
+	domain.ExportKindPlayers: {
+		count: `SELECT count(*) FROM v2_players WHERE created_at >= $1 AND created_at < $2`,
+		rows: `
+			SELECT p.id, COALESCE(pp.email::text, ''), COALESCE(pp.country, ''), p.currency,
+			       p.balance::bigint, p.bonus_balance::bigint, COALESCE(pp.account_status, 'active'), p.created_at
+			FROM v2_players p
+			LEFT JOIN player_profiles pp ON pp.player_id = p.id
+			WHERE p.created_at >= $1 AND p.created_at < $2
+			ORDER BY p.created_at, p.id`,
+		header: []string{"id", "email", "country", "currency", "balance", "bonus_balance", "account_status", "created_at"},
+		record: func(rows pgx.Rows) ([]string, map[string]any, error) {
+			var id uuid.UUID
+			var email, country, currency, status string
+			var balance, bonus int64
+			var createdAt time.Time
+			if err := rows.Scan(&id, &email, &country, &currency, &balance, &bonus, &status, &createdAt); err != nil {
+				return nil, nil, err
+			}
+			return []string{id.String(), email, country, currency, strconv.FormatInt(balance, 10),
+					strconv.FormatInt(bonus, 10), status, createdAt.UTC().Format(time.RFC3339)},
+				map[string]any{"id": id, "email": email, "country": country, "currency": currency,
+					"balance": balance, "bonus_balance": bonus, "account_status": status, "created_at": createdAt},
+				nil
+		},
+	},
+}
+
+// ExportJobService runs admin exports in the background and stores the
+// files in object storage.
+type ExportJobService struct {
+	pool    *pgxpool.Pool
+	storage *provider.S3Storage
+	maxJobs int
+	logger  *slog.Logger
+}
+
+// NewExportJobService creates an ExportJobService. maxJobs caps each admin's
+// queued and running jobs; zero or less uses DefaultMaxExportsPerAdmin.
+func NewExportJobService(pool *pgxpool.Pool, storage *provider.S3Storage, maxJobs int, logger *slog.Logger) *ExportJobService {
+	if maxJobs <= 0 {
+		maxJobs = DefaultMaxExportsPerAdmin
+	}
+	return &ExportJobService{pool: pool, storage: storage, maxJobs: maxJobs, logger: logger}
+}
+
+// ExportJobInput is what to export: rows of Kind created between From and
+// To, as Format (csv or json).
+type ExportJobInput struct {
+	Kind   string    `json:"kind"`
+	Format string    `json:"format"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// Create queues an export job for an admin and starts it in the background.
+// An admin already at the concurrency limit is refused.
+func (s *ExportJobService) Create(ctx context.Context, adminID uuid.UUID, input ExportJobInput) (*domain.ExportJob, error) {
+	if _, ok := exportSources[input.Kind]; !ok {
+		return nil, domain.ErrValidation("kind must be transactions or players")
+	}
+	if input.Format == "" {
+		input.Format = "csv"
+	}
+	if input.Format != "csv" && input.Format != "json" {
+		return nil, domain.ErrValidation("format must be csv or json")
+	}
+	if input.From.IsZero() || input.To.IsZero() || !input.From.Before(input.To) {
+		return nil, domain.ErrValidation("from and to are required and from must be before to")
+	}
+	if s.storage == nil || !s.storage.Configured() {
+		return nil, domain.ErrInternal("export storage is not configured", nil)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialise an admin's job creation so two requests cannot both pass the
+	// limit check.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "export_jobs:"+adminID.String()); err != nil {
+		return nil, domain.ErrInternal("lock export jobs", err)
+	}
+	var active int
+	if err := tx.QueryRow(ctx, `
+		SELECT count(*) FROM admin_export_jobs
+		WHERE admin_id = $1 AND status IN ('queued', 'running')`, adminID).Scan(&active); err != nil {
+		return nil, domain.ErrInternal("count export jobs", err)
+	}
+	if active >= s.maxJobs {
+		return nil, &domain.AppError{
+			Code:    "EXPORT_LIMIT_REACHED",
+			Message: fmt.Sprintf("at most %d exports may run at once", s.maxJobs),
+			Status:  429,
+		}
+	}
+
+	job, err := scanExportJob(tx.QueryRow(ctx, `
+		INSERT INTO admin_export_jobs (admin_id, kind, format, from_at, to_at, locked_until)
+		VALUES ($1, $2, $3, $4, $5, now() + $6 * interval '1 second')
+		RETURNING `+exportJobColumns, adminID, input.Kind, input.Format, input.From, input.To, exportLease.Seconds()))
+	if err != nil {
+		return nil, domain.ErrInternal("create export job", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Info("export job queued", "job_id", job.ID, "admin_id", adminID, "kind", input.Kind)
+	go s.run(context.Background(), job)
+	return job, nil
+}
+
+// Get returns one of an admin's export jobs, with a download link when it
+// has completed.
+func (s *ExportJobService) Get(ctx context.Context, adminID, jobID uuid.UUID) (*domain.ExportJob, error) {
+	job, err := scanExportJob(s.pool.QueryRow(ctx, `
+		SELECT `+exportJobColumns+` FROM admin_export_jobs
+		WHERE id = $1 AND admin_id = $2`, jobID, adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("export job", jobID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get export job", err)
+	}
+	if job.Status == domain.ExportJobCompleted && job.ObjectKey != nil {
+		if job.DownloadURL, err = s.storage.PresignGetURL(*job.ObjectKey, exportDownloadTTL); err != nil {
+			return nil, domain.ErrInternal("presign export download", err)
+		}
+	}
+	return job, nil
+}
+
+// List returns an admin's export jobs, newest first.
+func (s *ExportJobService) List(ctx context.Context, adminID uuid.UUID) ([]domain.ExportJob, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+exportJobColumns+` FROM admin_export_jobs
+		WHERE admin_id = $1
+		ORDER BY created_at DESC LIMIT 50`, adminID)
+	if err != nil {
+		return nil, domain.ErrInternal("list export jobs", err)
+	}
+	defer rows.Close()
+
+	jobs := []domain.ExportJob{}
+	for rows.Next() {
+		j, err := scanExportJob(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan export job", err)
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
+}
+
+// FailInterrupted marks queued and running jobs whose lease has lapsed as
+// failed, so they stop counting against their admin's limit. Jobs another
+// replica is still running keep their lease and are left alone.
+func (s *ExportJobService) FailInterrupted(ctx context.Context) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE admin_export_jobs
+		SET status = 'failed', error = 'interrupted', finished_at = now(), locked_until = NULL
+		WHERE status IN ('queued', 'running') AND (locked_until IS NULL OR locked_until < now())`)
+	if err != nil {
+		return 0, fmt.Errorf("fail interrupted export jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// StartInterruptedSweep fails interrupted jobs before returning, then again
+// every interval in the background until ctx is done.
+func (s *ExportJobService) StartInterruptedSweep(ctx context.Context, interval time.Duration) {
+	sweep := func() {
+		n, err := s.FailInterrupted(ctx)
+		if err != nil {
+			s.logger.Error("sweep export jobs", "error", err)
+		} else if n > 0 {
+			s.logger.Warn("failed interrupted export jobs", "count", n)
+		}
+	}
+	sweep()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("export job sweep stopped")
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	}()
+}
+
+// renewLease extends the job's lease every quarter lease until ctx is done.
+func (s *ExportJobService) renewLease(ctx context.Context, id uuid.UUID) {
+	ticker := time.NewTicker(exportLease / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.pool.Exec(ctx, `
+				UPDATE admin_export_jobs SET locked_until = now() + $2 * interval '1 second'
+				WHERE id = $1 AND status IN ('queued', 'running')`, id, exportLease.Seconds()); err != nil {
+				s.logger.Warn("renew export job lease", "job_id", id, "error", err)
+			}
+		}
+	}
+}
+
+// run writes a job's file and uploads it, recording progress as it goes.
+func (s *ExportJobService) run(ctx context.Context, job *domain.ExportJob) {
+	leaseCtx, stopLease := context.WithCancel(ctx)
+	go s.renewLease(leaseCtx, job.ID)
+	key, err := s.export(ctx, job)
+	stopLease()
+	if err != nil {
+		s.logger.Error("export job failed", "job_id", job.ID, "error", err)
+		if _, uerr := s.pool.Exec(ctx, `
+			UPDATE admin_export_jobs SET status = 'failed', error = $2, finished_at = now(), locked_until = NULL
+			WHERE id = $1`, job.ID, err.Error()); uerr != nil {
+			s.logger.Error("mark export job failed", "job_id", job.ID, "error", uerr)
+		}
+		return
+	}
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE admin_export_jobs SET status = 'completed', object_key = $2, finished_at = now(), locked_until = NULL
+		WHERE id = $1`, job.ID, key); err != nil {
+		s.logger.Error("mark export job completed", "job_id", job.ID, "error", err)
+		return
+	}
+	s.logger.Info("export job completed", "job_id", job.ID, "key", key)
+}
+
+// export writes the job's file to a temporary file, so memory stays flat
+// however many rows it has, and uploads it, returning its key.
+func (s *ExportJobService) export(ctx context.Context, job *domain.ExportJob) (string, error) {
+	src := exportSources[job.Kind]
+	var total int
+	if err := s.pool.QueryRow(ctx, src.count, job.From, job.To).Scan(&total); err != nil {
+		return "", fmt.Errorf("count rows: %w", err)
+	}
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE admin_export_jobs SET status = 'running', total_rows = $2, started_at = now()
+		WHERE id = $1`, job.ID, total); err != nil {
+		return "", fmt.Errorf("start job: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "export-*."+job.Format)
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	written, err := s.writeRows(ctx, job, src, f)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("rewind temp file: %w", err)
+	}
+
+	contentType := "application/json"
+	if job.Format == "csv" {
+		contentType = "text/csv"
+	}
+	key := fmt.Sprintf("exports/%s/%s.%s", job.AdminID, job.ID, job.Format)
+	if err := s.storage.PutObjectFrom(ctx, key, contentType, f); err != nil {
+		return "", err
+	}
+	if _, err := s.pool.Exec(ctx, `UPDATE admin_export_jobs SET rows_written = $2 WHERE id = $1`,
+		job.ID, written); err != nil {
+		return "", fmt.Errorf("record rows written: %w", err)
+	}
+	return key, nil
+}
+
+// writeRows streams the job's rows to w in its format and returns how many
+// it wrote.
+func (s *ExportJobService) writeRows(ctx context.Context, job *domain.ExportJob, src exportSource, w io.Writer) (int, error) {
+	rows, err := s.pool.Query(ctx, src.rows, job.From, job.To)
+	if err != nil {
+		return 0, fmt.Errorf("query rows: %w", err)
+	}
+	defer rows.Close()
+
+	buf := bufio.NewWriter(w)
+	var cw *csv.Writer
+	if job.Format == "csv" {
+		cw = csv.NewWriter(buf)
+		cw.Write(src.header)
+	} else {
+		buf.WriteByte('[')
+	}
+	written := 0
+	for rows.Next() {
+		record, object, err := src.record(rows)
+		if err != nil {
+			return 0, fmt.Errorf("scan row: %w", err)
+		}
+		if cw != nil {
+			cw.Write(record)
+		} else {
+			if written > 0 {
+				buf.WriteByte(',')
+			}
+			b, _ := json.Marshal(object)
+			buf.Write(b)
+		}
+		written++
+		if written%exportProgressEvery == 0 {
+			if _, err := s.pool.Exec(ctx, `UPDATE admin_export_jobs SET rows_written = $2 WHERE id = $1`,
+				job.ID, written); err != nil {
+				s.logger.Warn("update export progress", "job_id", job.ID, "error", err)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("read rows: %w", err)
+	}
+	if cw != nil {
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return 0, fmt.Errorf("write csv: %w", err)
+		}
+	} else {
+		buf.WriteByte(']')
+	}
+	if err := buf.Flush(); err != nil {
+		return 0, fmt.Errorf("write temp file: %w", err)
+	}
+	return written, nil
+}
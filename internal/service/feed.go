@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FeedResyncer starts a feed's sync job out of band. The Dome connector is
+// the one implementation.
+type FeedResyncer interface {
+	Resync(feed string) error
+}
+
+// FeedState is a row of dome_feed_state: how a prediction feed's last sync
+// went.
+type FeedState struct {
+	Name          string          `json:"name"`
+	Status        string          `json:"status"`
+	LastSyncAt    *time.Time      `json:"last_sync_at,omitempty"`
+	LastSyncCount int             `json:"last_sync_count"`
+	ErrorMessage  *string         `json:"error_message,omitempty"`
+	Metadata      json.RawMessage `json:"metadata"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// FeedService reports prediction feed health and lets admins trigger a
+// resync without waiting for the next tick.
+type FeedService struct {
+	pool     *pgxpool.Pool
+	resyncer FeedResyncer
+	logger   *slog.Logger
+}
+
+// NewFeedService creates a FeedService. resyncer is nil when the Dome
+// connector is not configured.
+func NewFeedService(pool *pgxpool.Pool, resyncer FeedResyncer, logger *slog.Logger) *FeedService {
+	return &FeedService{pool: pool, resyncer: resyncer, logger: logger}
+}
+
+const feedStateColumns = `feed_name, status, last_sync_at, last_sync_count, error_message, COALESCE(metadata, '{}'::jsonb), COALESCE(updated_at, created_at, now())`
+
+func scanFeedState(row pgx.Row) (*FeedState, error) {
+	var f FeedState
+	if err := row.Scan(&f.Name, &f.Status, &f.LastSyncAt, &f.LastSyncCount, &f.ErrorMessage, &f.Metadata, &f.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// List returns every feed's state, by name.
+func (s *FeedService) List(ctx context.Context) ([]FeedState, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+feedStateColumns+` FROM dome_feed_state ORDER BY feed_name`)
+	if err != nil {
+		return nil, domain.ErrInternal("list feeds", err)
+	}
+	defer rows.Close()
+
+	feeds := []FeedState{}
+	for rows.Next() {
+		f, err := scanFeedState(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan feed", err)
+		}
+		feeds = append(feeds, *f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("list feeds", err)
+	}
+	return feeds, nil
+}
+
+// Resync starts the named feed's job now and returns the feed's state as it
+// was before the run; the job records its outcome in dome_feed_state.
+func (s *FeedService) Resync(ctx context.Context, name string) (*FeedState, error) {
+	feed, err := scanFeedState(s.pool.QueryRow(ctx, `SELECT `+feedStateColumns+` FROM dome_feed_state WHERE feed_name = $1`, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("feed", name)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get feed", err)
+	}
+
+	if s.resyncer == nil {
+		return nil, feedUnavailable("prediction feeds are not configured")
+	}
+	switch err := s.resyncer.Resync(name); {
+	case errors.Is(err, provider.ErrUnknownFeed):
+		return nil, feedUnavailable("feed " + name + " cannot be resynced")
+	case errors.Is(err, provider.ErrFeedBusy):
+		return nil, domain.ErrConflict("feed " + name + " is already syncing")
+	case err != nil:
+		return nil, domain.ErrInternal("resync feed", err)
+	}
+
+	s.logger.Info("feed resync triggered", "feed", name)
+	return feed, nil
+}
+
+func feedUnavailable(msg string) *domain.AppError {
+	return &domain.AppError{Code: "FEED_UNAVAILABLE", Message: msg, Status: 503}
+}
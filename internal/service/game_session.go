@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultGameSessionTTL is how long a game session token stays live for new
+// stakes after launch.
+const DefaultGameSessionTTL = 4 * time.Hour
+
+// GameSessionService issues the session tokens game clients receive at launch
+// and resolves them on provider wallet callbacks. Only token hashes are stored.
+type GameSessionService struct {
+	pool *pgxpool.Pool
+	ttl  time.Duration
+}
+
+// NewGameSessionService creates a GameSessionService; a non-positive ttl uses
+// DefaultGameSessionTTL.
+func NewGameSessionService(pool *pgxpool.Pool, ttl time.Duration) *GameSessionService {
+	if ttl <= 0 {
+		ttl = DefaultGameSessionTTL
+	}
+	return &GameSessionService{pool: pool, ttl: ttl}
+}
+
+// Issue stores a session for the player on a manufacturer's game and returns
+// the token with its expiry.
+func (s *GameSessionService) Issue(ctx context.Context, db repository.DBTX, playerID uuid.UUID, manufacturerID string, gameID *uuid.UUID, currency string) (string, time.Time, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, domain.ErrInternal("generate session token", err)
+	}
+	token := hex.EncodeToString(raw)
+	expiresAt := time.Now().Add(s.ttl)
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO game_session_tokens (token_hash, player_id, manufacturer_id, game_id, currency, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		hashSessionToken(token), playerID, manufacturerID, gameID, currency, expiresAt)
+	if err != nil {
+		return "", time.Time{}, domain.ErrInternal("store session token", err)
+	}
+	return token, expiresAt, nil
+}
+
+// Resolve returns the session a token was issued for. An expired session is
+// returned with an unauthorized error, so callers can still settle rounds
+// that outlive it.
+func (s *GameSessionService) Resolve(ctx context.Context, token string) (*domain.GameSessionToken, error) {
+	var gs domain.GameSessionToken
+	err := s.pool.QueryRow(ctx, `
+		SELECT t.player_id, t.manufacturer_id, m.name, t.game_id, t.currency, t.expires_at
+		FROM game_session_tokens t
+		LEFT JOIN game_manufacturers m ON m.id = t.manufacturer_id
+		WHERE t.token_hash = $1`, hashSessionToken(token),
+	).Scan(&gs.PlayerID, &gs.ManufacturerID, &gs.Provider, &gs.GameID, &gs.Currency, &gs.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrUnauthorized("invalid session token")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("resolve session token", err)
+	}
+	if time.Now().After(gs.ExpiresAt) {
+		return &gs, domain.ErrUnauthorized("session expired")
+	}
+	return &gs, nil
+}
+
+func hashSessionToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
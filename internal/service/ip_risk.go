@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ipReputationTTL is how long a looked-up reputation is reused, so a player
+// betting repeatedly costs one provider query an hour.
+const ipReputationTTL = time.Hour
+
+const ipRiskCheckColumns = `id, player_id, reference, checkpoint, ip, provider, proxy, proxy_type, risk,
+	country, verdict, reason, reviewed_by, reviewed_at, review_note, created_at`
+
+func scanIPRiskCheck(row pgx.Row) (*domain.IPRiskCheck, error) {
+	var c domain.IPRiskCheck
+	var proxyType, country *string
+	if err := row.Scan(&c.ID, &c.PlayerID, &c.Reference, &c.Checkpoint, &c.IP, &c.Provider, &c.Proxy,
+		&proxyType, &c.Risk, &country, &c.Verdict, &c.Reason, &c.ReviewedBy, &c.ReviewedAt,
+		&c.ReviewNote, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	if proxyType != nil {
+		c.ProxyType = *proxyType
+	}
+	if country != nil {
+		c.Country = *country
+	}
+	return &c, nil
+}
+
+type cachedReputation struct {
+	rep     domain.IPReputation
+	expires time.Time
+}
+
+// IPRiskService screens the IP behind registrations, deposits and bets for
+// VPNs, proxies and risky networks. Flagged requests go through and are
+// recorded for review; blocked ones are recorded and refused. Screening
+// fails open: if the provider cannot be reached the request is allowed.
+type IPRiskService struct {
+	pool   *pgxpool.Pool
+	intel  provider.IPIntelligence
+	rules  policy.IPRiskRules
+	logger *slog.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedReputation
+}
+
+// NewIPRiskService creates an IPRiskService. A nil intel disables screening.
+func NewIPRiskService(pool *pgxpool.Pool, intel provider.IPIntelligence, rules policy.IPRiskRules, logger *slog.Logger) *IPRiskService {
+	return &IPRiskService{
+		pool:   pool,
+		intel:  intel,
+		rules:  rules,
+		logger: logger,
+		cache:  make(map[string]cachedReputation),
+	}
+}
+
+// IPScreen identifies the request being screened. PlayerID is nil at
+// registration, where Reference carries the email instead.
+type IPScreen struct {
+	Checkpoint string
+	IP         string
+	PlayerID   *uuid.UUID
+	Reference  string
+}
+
+// Screen evaluates the request's IP and returns IP_BLOCKED if policy blocks
+// it. Flagged and blocked requests are recorded.
+func (s *IPRiskService) Screen(ctx context.Context, req IPScreen) error {
+	if s == nil || s.intel == nil || net.ParseIP(req.IP) == nil {
+		return nil
+	}
+	rep, err := s.reputation(ctx, req.IP)
+	if err != nil {
+		s.logger.Warn("ip screening unavailable", "checkpoint", req.Checkpoint, "ip", req.IP, "error", err)
+		return nil
+	}
+
+	verdict, reason := policy.EvaluateIPRisk(s.rules, req.Checkpoint, *rep)
+	if verdict == policy.IPVerdictAllow {
+		return nil
+	}
+	if err := s.record(ctx, req, *rep, verdict, reason); err != nil {
+		s.logger.Error("record ip risk check", "checkpoint", req.Checkpoint, "ip", req.IP, "error", err)
+	}
+	s.logger.Warn("ip screening", "checkpoint", req.Checkpoint, "ip", req.IP, "player_id", req.PlayerID,
+		"verdict", verdict, "reason", reason)
+	if verdict == policy.IPVerdictBlock {
+		return domain.ErrIPBlocked(req.Checkpoint)
+	}
+	return nil
+}
+
+func (s *IPRiskService) reputation(ctx context.Context, ip string) (*domain.IPReputation, error) {
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.cache[ip]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return &cached.rep, nil
+	}
+
+	rep, err := s.intel.Lookup(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	for k, v := range s.cache {
+		if now.After(v.expires) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[ip] = cachedReputation{rep: *rep, expires: now.Add(ipReputationTTL)}
+	s.mu.Unlock()
+	return rep, nil
+}
+
+func (s *IPRiskService) record(ctx context.Context, req IPScreen, rep domain.IPReputation, verdict, reason string) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO ip_risk_checks (player_id, reference, checkpoint, ip, provider, proxy, proxy_type,
+			risk, country, verdict, reason)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, NULLIF($7, ''), $8, NULLIF($9, ''), $10, $11)`,
+		req.PlayerID, req.Reference, req.Checkpoint, req.IP, s.intel.Name(), rep.Proxy, rep.ProxyType,
+		rep.Risk, rep.Country, verdict, reason)
+	return err
+}
+
+// IPRiskCheckFilter narrows ListChecks. Zero values match everything.
+type IPRiskCheckFilter struct {
+	PlayerID   *uuid.UUID
+	Verdict    string
+	Unreviewed bool
+	Limit      int
+}
+
+// ListChecks returns recorded detections, newest first.
+func (s *IPRiskService) ListChecks(ctx context.Context, f IPRiskCheckFilter) ([]domain.IPRiskCheck, error) {
+	if f.Verdict != "" && f.Verdict != policy.IPVerdictReview && f.Verdict != policy.IPVerdictBlock {
+		return nil, domain.ErrValidation("verdict must be review or block")
+	}
+	if f.Limit <= 0 || f.Limit > 500 {
+		f.Limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+ipRiskCheckColumns+` FROM ip_risk_checks
+		WHERE ($1::uuid IS NULL OR player_id = $1)
+		  AND ($2 = '' OR verdict = $2)
+		  AND ($3 = false OR reviewed_at IS NULL)
+		ORDER BY created_at DESC LIMIT $4`, f.PlayerID, f.Verdict, f.Unreviewed, f.Limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list ip risk checks", err)
+	}
+	defer rows.Close()
+
+	checks := []domain.IPRiskCheck{}
+	for rows.Next() {
+		c, err := scanIPRiskCheck(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan ip risk check", err)
+		}
+		checks = append(checks, *c)
+	}
+	return checks, rows.Err()
+}
+
+// ReviewCheck records a compliance review of a detection.
+func (s *IPRiskService) ReviewCheck(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.IPRiskCheck, error) {
+	c, err := scanIPRiskCheck(s.pool.QueryRow(ctx, `
+		UPDATE ip_risk_checks
+		SET reviewed_by = $2, reviewed_at = now(), review_note = NULLIF($3, '')
+		WHERE id = $1 AND reviewed_at IS NULL
+		RETURNING `+ipRiskCheckColumns, id, adminID, note))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := s.pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM ip_risk_checks WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, domain.ErrInternal("find ip risk check", err)
+		}
+		if exists {
+			return nil, domain.ErrConflict("ip risk check already reviewed")
+		}
+		return nil, domain.ErrNotFound("ip risk check", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("review ip risk check", err)
+	}
+	s.logger.Info("ip risk check reviewed", "check_id", id, "admin_id", adminID)
+	return c, nil
+}
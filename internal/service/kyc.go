@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MaxKYCDocumentBytes caps a single KYC upload.
+const MaxKYCDocumentBytes = 10 << 20
+
+// kycDocumentTypes are the content types accepted as KYC documents.
+var kycDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// KYCService runs identity verification: players upload documents to object
+// storage, compliance approves or rejects them, and a player becomes verified
+// once the approved documents cover identity and address.
+type KYCService struct {
+	pool      *pgxpool.Pool
+	storage   *provider.S3Storage
+	threshold int64
+	logger    *slog.Logger
+}
+
+// NewKYCService creates a KYCService. threshold is the withdrawal amount in
+// cents above which verification is required, reported in the player's
+// status; 0 means it is never required.
+func NewKYCService(pool *pgxpool.Pool, storage *provider.S3Storage, threshold int64, logger *slog.Logger) *KYCService {
+	return &KYCService{pool: pool, storage: storage, threshold: threshold, logger: logger}
+}
+
+const kycColumns = `id, player_id, document_type, file_name, content_type, size_bytes, status,
+	review_note, reviewed_by, reviewed_at, uploaded_at`
+
+func scanKYCDocument(row pgx.Row) (*domain.KYCDocument, error) {
+	var d domain.KYCDocument
+	if err := row.Scan(&d.ID, &d.PlayerID, &d.DocumentType, &d.FileName, &d.ContentType, &d.SizeBytes,
+		&d.Status, &d.ReviewNote, &d.ReviewedBy, &d.ReviewedAt, &d.UploadedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Status returns the player's verification state and documents.
+func (s *KYCService) Status(ctx context.Context, playerID uuid.UUID) (*domain.KYCStatus, error) {
+	var verified bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(verified, false) FROM player_profiles WHERE player_id = $1`, playerID).Scan(&verified)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find player profile", err)
+	}
+
+	docs, err := s.ListForPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	var approved []domain.KYCDocumentType
+	for _, d := range docs {
+		if d.Status == domain.KYCDocumentApproved {
+			approved = append(approved, d.DocumentType)
+		}
+	}
+	missing := []string{}
+	if !verified {
+		missing = policy.MissingKYC(approved)
+	}
+	return &domain.KYCStatus{
+		Verified:            verified,
+		Missing:             missing,
+		WithdrawalThreshold: s.threshold,
+		Documents:           docs,
+	}, nil
+}
+
+// ListForPlayer returns the player's documents, newest first.
+func (s *KYCService) ListForPlayer(ctx context.Context, playerID uuid.UUID) ([]domain.KYCDocument, error) {
+	return s.list(ctx, `WHERE player_id = $1 ORDER BY uploaded_at DESC`, playerID)
+}
+
+// KYCUpload is a document file submitted by a player.
+type KYCUpload struct {
+	DocumentType domain.KYCDocumentType
+	FileName     string
+	ContentType  string
+	Content      []byte
+}
+
+// Upload stores a document in object storage and queues it for review.
+func (s *KYCService) Upload(ctx context.Context, playerID uuid.UUID, upload KYCUpload) (*domain.KYCDocument, error) {
+	if !upload.DocumentType.Valid() {
+		return nil, domain.ErrValidation("document_type must be passport, national_id, driving_licence, proof_of_address or selfie")
+	}
+	upload.FileName = strings.TrimSpace(upload.FileName)
+	if upload.FileName == "" || len(upload.Content) == 0 {
+		return nil, domain.ErrValidation("a non-empty file is required")
+	}
+	if len(upload.Content) > MaxKYCDocumentBytes {
+		return nil, domain.ErrValidation("file exceeds 10MB")
+	}
+	if !kycDocumentTypes[upload.ContentType] {
+		return nil, domain.ErrValidation("file must be a PDF, JPEG or PNG")
+	}
+	if s.storage == nil || !s.storage.Configured() {
+		return nil, domain.ErrInternal("kyc document storage is not configured", nil)
+	}
+
+	id := uuid.New()
+	key := "kyc/" + playerID.String() + "/" + id.String()
+	if err := s.storage.PutObject(ctx, key, upload.ContentType, upload.Content); err != nil {
+		return nil, domain.ErrInternal("store kyc document", err)
+	}
+
+	d, err := scanKYCDocument(s.pool.QueryRow(ctx, `
+		INSERT INTO kyc_documents (id, player_id, document_type, file_name, content_type, size_bytes, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+kycColumns,
+		id, playerID, upload.DocumentType, upload.FileName, upload.ContentType, len(upload.Content), key))
+	if err != nil {
+		s.logger.Warn("kyc document stored but not recorded", "storage_key", key, "error", err)
+		return nil, domain.ErrInternal("insert kyc document", err)
+	}
+	s.logger.Info("kyc document uploaded", "player_id", playerID, "document_id", id, "type", upload.DocumentType)
+	return d, nil
+}
+
+// --- Admin ---
+
+// ReviewQueue returns documents for review, oldest upload first. status
+// defaults to pending.
+func (s *KYCService) ReviewQueue(ctx context.Context, status string, limit int) ([]domain.KYCDocument, error) {
+	if status == "" {
+		status = string(domain.KYCDocumentPending)
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.list(ctx, `WHERE status = $1 ORDER BY uploaded_at ASC LIMIT $2`, status, limit)
+}
+
+// Approve accepts a pending document. When the player's approved documents
+// now cover identity and address, the player is marked verified.
+func (s *KYCService) Approve(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.KYCDocument, error) {
+	return s.review(ctx, id, adminID, domain.KYCDocumentApproved, note)
+}
+
+// Reject refuses a pending document; the player must upload a new one. A
+// note is required.
+func (s *KYCService) Reject(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.KYCDocument, error) {
+	if strings.TrimSpace(note) == "" {
+		return nil, domain.ErrValidation("a note is required when rejecting")
+	}
+	return s.review(ctx, id, adminID, domain.KYCDocumentRejected, note)
+}
+
+func (s *KYCService) review(ctx context.Context, id, adminID uuid.UUID, status domain.KYCDocumentStatus, note string) (*domain.KYCDocument, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	d, err := scanKYCDocument(tx.QueryRow(ctx, `
+		UPDATE kyc_documents
+		SET status = $2, review_note = NULLIF($3, ''), reviewed_by = $4, reviewed_at = now()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+kycColumns,
+		id, status, strings.TrimSpace(note), adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var current domain.KYCDocumentStatus
+		err := tx.QueryRow(ctx, `SELECT status FROM kyc_documents WHERE id = $1`, id).Scan(&current)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("kyc document", id.String())
+		}
+		if err != nil {
+			return nil, domain.ErrInternal("find kyc document", err)
+		}
+		return nil, domain.ErrConflict("document is " + string(current) + ", not pending")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("review kyc document", err)
+	}
+
+	verified := false
+	if status == domain.KYCDocumentApproved {
+		rows, err := tx.Query(ctx, `
+			SELECT DISTINCT document_type FROM kyc_documents
+			WHERE player_id = $1 AND status = 'approved'`, d.PlayerID)
+		if err != nil {
+			return nil, domain.ErrInternal("query approved kyc documents", err)
+		}
+		var approved []domain.KYCDocumentType
+		for rows.Next() {
+			var t domain.KYCDocumentType
+			if err := rows.Scan(&t); err != nil {
+				rows.Close()
+				return nil, domain.ErrInternal("scan approved kyc documents", err)
+			}
+			approved = append(approved, t)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, domain.ErrInternal("read approved kyc documents", err)
+		}
+		if len(policy.MissingKYC(approved)) == 0 {
+			if _, err := tx.Exec(ctx, `
+				UPDATE player_profiles SET verified = true WHERE player_id = $1`, d.PlayerID); err != nil {
+				return nil, domain.ErrInternal("mark player verified", err)
+			}
+			verified = true
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("kyc document reviewed", "document_id", id, "status", status, "admin_id", adminID)
+	if verified {
+		s.logger.Info("player kyc verified", "player_id", d.PlayerID)
+	}
+	return d, nil
+}
+
+// Document returns a document's metadata and file content.
+func (s *KYCService) Document(ctx context.Context, id uuid.UUID) (*domain.KYCDocument, []byte, error) {
+	var d domain.KYCDocument
+	var key string
+	err := s.pool.QueryRow(ctx, `
+		SELECT `+kycColumns+`, storage_key FROM kyc_documents WHERE id = $1`, id).
+		Scan(&d.ID, &d.PlayerID, &d.DocumentType, &d.FileName, &d.ContentType, &d.SizeBytes,
+			&d.Status, &d.ReviewNote, &d.ReviewedBy, &d.ReviewedAt, &d.UploadedAt, &key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, domain.ErrNotFound("kyc document", id.String())
+	}
+	if err != nil {
+		return nil, nil, domain.ErrInternal("find kyc document", err)
+	}
+	if s.storage == nil || !s.storage.Configured() {
+		return nil, nil, domain.ErrInternal("kyc document storage is not configured", nil)
+	}
+	content, _, err := s.storage.GetObject(ctx, key)
+	if err != nil {
+		return nil, nil, domain.ErrInternal("fetch kyc document", err)
+	}
+	return &d, content, nil
+}
+
+func (s *KYCService) list(ctx context.Context, where string, args ...any) ([]domain.KYCDocument, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+kycColumns+` FROM kyc_documents `+where, args...)
+	if err != nil {
+		return nil, domain.ErrInternal("query kyc documents", err)
+	}
+	defer rows.Close()
+
+	docs := []domain.KYCDocument{}
+	for rows.Next() {
+		d, err := scanKYCDocument(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan kyc document", err)
+		}
+		docs = append(docs, *d)
+	}
+	return docs, rows.Err()
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/reconciliation"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LedgerReconciliationService recomputes every wallet's balances from its
+// v2_transactions snapshots and records drift against the stored balances.
+type LedgerReconciliationService struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+	logger *slog.Logger
+}
+
+// NewLedgerReconciliationService creates a LedgerReconciliationService.
+func NewLedgerReconciliationService(pool *pgxpool.Pool, outbox repository.OutboxRepository, logger *slog.Logger) *LedgerReconciliationService {
+	return &LedgerReconciliationService{pool: pool, outbox: outbox, logger: logger}
+}
+
+// LedgerReconcileSummary is the outcome of one reconciliation pass.
+type LedgerReconcileSummary struct {
+	WalletsChecked int `json:"wallets_checked"`
+	Drifted        int `json:"drifted"`
+	Opened         int `json:"opened"`
+	Resolved       int `json:"resolved"`
+}
+
+// Run checks every base and secondary-currency wallet. A new drift opens a
+// ledger_discrepancies row and emits an alert event; a wallet that already has
+// an open discrepancy is not re-alerted. Open discrepancies whose wallet now
+// reconciles are marked resolved.
+func (s *LedgerReconciliationService) Run(ctx context.Context) (*LedgerReconcileSummary, error) {
+	states, err := s.loadWallets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &LedgerReconcileSummary{WalletsChecked: len(states)}
+	var driftPlayers []uuid.UUID
+	var driftCurrencies []string
+	for _, st := range states {
+		drift := reconciliation.CheckWallet(st)
+		if drift == nil {
+			continue
+		}
+		summary.Drifted++
+		driftPlayers = append(driftPlayers, drift.PlayerID)
+		driftCurrencies = append(driftCurrencies, drift.Currency)
+
+		opened, err := s.open(ctx, drift, st.LastTransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if opened {
+			summary.Opened++
+			s.logger.Warn("ledger balance drift detected",
+				"player_id", drift.PlayerID, "currency", drift.Currency, "kind", drift.Kind,
+				"stored", drift.Stored.Balance, "expected", drift.Expected.Balance)
+		}
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE ledger_discrepancies ld SET resolved_at = now()
+		WHERE ld.resolved_at IS NULL
+		  AND NOT EXISTS (
+		    SELECT 1 FROM unnest($1::uuid[], $2::varchar[]) AS d(player_id, currency)
+		    WHERE d.player_id = ld.player_id AND d.currency = ld.currency)`,
+		driftPlayers, driftCurrencies)
+	if err != nil {
+		return nil, domain.ErrInternal("resolve ledger discrepancies", err)
+	}
+	summary.Resolved = int(tag.RowsAffected())
+	return summary, nil
+}
+
+// loadWallets reads each wallet with the snapshots of its newest transactions
+// in one statement, so balances and history come from the same snapshot.
+func (s *LedgerReconciliationService) loadWallets(ctx context.Context) ([]reconciliation.WalletState, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH wallets AS (
+			SELECT id AS player_id, currency, balance, bonus_balance, reserved_balance FROM v2_players
+			UNION ALL
+			SELECT player_id, currency, balance, bonus_balance, reserved_balance FROM player_wallets
+		)
+		SELECT w.player_id, w.currency,
+		       w.balance::bigint, w.bonus_balance::bigint, w.reserved_balance::bigint,
+		       l.id, l.balance_after::bigint, l.bonus_balance_after::bigint, l.reserved_balance_after::bigint
+		FROM wallets w
+		LEFT JOIN LATERAL (
+			SELECT t.id, t.balance_after, t.bonus_balance_after, t.reserved_balance_after
+			FROM v2_transactions t
+			WHERE t.player_id = w.player_id AND t.currency = w.currency
+			  AND t.created_at = (
+			    SELECT max(created_at) FROM v2_transactions
+			    WHERE player_id = w.player_id AND currency = w.currency)
+		) l ON true
+		ORDER BY w.player_id, w.currency`)
+	if err != nil {
+		return nil, domain.ErrInternal("load wallet snapshots", err)
+	}
+	defer rows.Close()
+
+	var states []reconciliation.WalletState
+	for rows.Next() {
+		var (
+			st                   reconciliation.WalletState
+			txID                 *uuid.UUID
+			bal, bonus, reserved *int64
+		)
+		if err := rows.Scan(&st.PlayerID, &st.Currency,
+			&st.Stored.Balance, &st.Stored.BonusBalance, &st.Stored.ReservedBalance,
+			&txID, &bal, &bonus, &reserved); err != nil {
+			return nil, domain.ErrInternal("scan wallet snapshot", err)
+		}
+
+		if n := len(states); n > 0 && states[n-1].PlayerID == st.PlayerID && states[n-1].Currency == st.Currency {
+			st = states[n-1]
+			states = states[:n-1]
+		}
+		if txID != nil {
+			st.Latest = append(st.Latest, domain.Balances{Balance: *bal, BonusBalance: *bonus, ReservedBalance: *reserved})
+			if st.LastTransactionID == nil {
+				st.LastTransactionID = txID
+			}
+		}
+		states = append(states, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read wallet snapshots", err)
+	}
+	return states, nil
+}
+
+// open inserts a discrepancy and its alert event unless the wallet already
+// has an open one. Reports whether a new row was written.
+func (s *LedgerReconciliationService) open(ctx context.Context, drift *reconciliation.BalanceDrift, lastTxID *uuid.UUID) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	d := domain.LedgerDiscrepancy{
+		PlayerID: drift.PlayerID, Currency: drift.Currency, Kind: string(drift.Kind),
+		Stored: drift.Stored, Expected: drift.Expected, LastTransactionID: lastTxID,
+	}
+	rows, err := tx.Query(ctx, `
+		INSERT INTO ledger_discrepancies
+			(player_id, currency, kind, stored_balance, stored_bonus_balance, stored_reserved_balance,
+			 expected_balance, expected_bonus_balance, expected_reserved_balance, last_transaction_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (player_id, currency) WHERE resolved_at IS NULL DO NOTHING
+		RETURNING id, detected_at`,
+		d.PlayerID, d.Currency, d.Kind,
+		d.Stored.Balance, d.Stored.BonusBalance, d.Stored.ReservedBalance,
+		d.Expected.Balance, d.Expected.BonusBalance, d.Expected.ReservedBalance, d.LastTransactionID)
+	if err != nil {
+		return false, domain.ErrInternal("insert ledger discrepancy", err)
+	}
+	inserted := rows.Next()
+	if inserted {
+		if err := rows.Scan(&d.ID, &d.DetectedAt); err != nil {
+			rows.Close()
+			return false, domain.ErrInternal("scan ledger discrepancy", err)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, domain.ErrInternal("insert ledger discrepancy", err)
+	}
+	if !inserted {
+		return false, nil
+	}
+
+	if err := s.outbox.Insert(ctx, tx, domain.NewLedgerDriftDetectedEvent(d)); err != nil {
+		return false, domain.ErrInternal("insert drift event", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, domain.ErrInternal("commit tx", err)
+	}
+	return true, nil
+}
+
+// ListDiscrepancies returns the newest discrepancies, open ones only unless all is set.
+func (s *LedgerReconciliationService) ListDiscrepancies(ctx context.Context, all bool, limit int) ([]domain.LedgerDiscrepancy, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, player_id, currency, kind,
+		       stored_balance::bigint, stored_bonus_balance::bigint, stored_reserved_balance::bigint,
+		       expected_balance::bigint, expected_bonus_balance::bigint, expected_reserved_balance::bigint,
+		       last_transaction_id, detected_at, resolved_at
+		FROM ledger_discrepancies
+		WHERE $1 OR resolved_at IS NULL
+		ORDER BY detected_at DESC
+		LIMIT $2`, all, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list ledger discrepancies", err)
+	}
+	defer rows.Close()
+
+	out := []domain.LedgerDiscrepancy{}
+	for rows.Next() {
+		var d domain.LedgerDiscrepancy
+		if err := rows.Scan(&d.ID, &d.PlayerID, &d.Currency, &d.Kind,
+			&d.Stored.Balance, &d.Stored.BonusBalance, &d.Stored.ReservedBalance,
+			&d.Expected.Balance, &d.Expected.BonusBalance, &d.Expected.ReservedBalance,
+			&d.LastTransactionID, &d.DetectedAt, &d.ResolvedAt); err != nil {
+			return nil, domain.ErrInternal("scan ledger discrepancy", err)
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// BalanceAsOf is a wallet's balances reconstructed at a past instant.
+type BalanceAsOf struct {
+	PlayerID uuid.UUID       `json:"player_id"`
+	Currency string          `json:"currency"`
+	At       time.Time       `json:"at"`
+	Balances domain.Balances `json:"balances"`
+	// LastTransactionID is the newest transaction at or before At, nil when
+	// the wallet had no history yet.
+	LastTransactionID *uuid.UUID `json:"last_transaction_id,omitempty"`
+	LastTransactionAt *time.Time `json:"last_transaction_at,omitempty"`
+	// Replayed counts the transactions whose ledger postings were added to
+	// the base snapshot.
+	Replayed int `json:"replayed_transactions"`
+	// Drift is set when the replayed balances disagree with the
+	// balance_after snapshots at At.
+	Drift *reconciliation.BalanceDrift `json:"drift,omitempty"`
+}
+
+// BalanceAt reconstructs a player's balances in currency (the base currency
+// when empty) as they stood at at. It starts from the balance_after snapshot
+// of the newest transaction that predates the ledger, adds the ledger
+// postings on the player's tiers up to at, and cross-checks the result
+// against the newest balance_after snapshots, so a dispute answer does not
+// rest on a single source.
+func (s *LedgerReconciliationService) BalanceAt(ctx context.Context, playerID uuid.UUID, currency string, at time.Time) (*BalanceAsOf, error) {
+	// Repeatable read: when at is recent, every query sees the same history.
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var base string
+	err = tx.QueryRow(ctx, `SELECT currency FROM v2_players WHERE id = $1`, playerID).Scan(&base)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find player", err)
+	}
+	if currency == "" {
+		currency = base
+	}
+
+	h := reconciliation.WalletHistory{PlayerID: playerID, Currency: currency}
+	var baseAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT t.created_at, t.balance_after::bigint, t.bonus_balance_after::bigint, t.reserved_balance_after::bigint
+		FROM v2_transactions t
+		WHERE t.player_id = $1 AND t.currency = $2 AND t.created_at <= $3
+		  AND NOT EXISTS (SELECT 1 FROM ledger_entries le WHERE le.transaction_id = t.id)
+		ORDER BY t.created_at DESC
+		LIMIT 1`, playerID, currency, at).Scan(&baseAt, &h.Base.Balance, &h.Base.BonusBalance, &h.Base.ReservedBalance)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInternal("find base snapshot", err)
+	}
+
+	result := &BalanceAsOf{PlayerID: playerID, Currency: currency, At: at}
+	err = tx.QueryRow(ctx, `
+		SELECT count(DISTINCT t.id),
+		       COALESCE(SUM(CASE le.direction WHEN 'credit' THEN le.amount ELSE -le.amount END)
+		                FILTER (WHERE le.account = $5), 0)::bigint,
+		       COALESCE(SUM(CASE le.direction WHEN 'credit' THEN le.amount ELSE -le.amount END)
+		                FILTER (WHERE le.account = $6), 0)::bigint,
+		       COALESCE(SUM(CASE le.direction WHEN 'credit' THEN le.amount ELSE -le.amount END)
+		                FILTER (WHERE le.account = $7), 0)::bigint
+		FROM v2_transactions t
+		JOIN ledger_entries le ON le.transaction_id = t.id
+		WHERE t.player_id = $1 AND t.currency = $2 AND t.created_at <= $3
+		  AND ($4::timestamptz IS NULL OR t.created_at > $4)`,
+		playerID, currency, at, baseAt,
+		domain.PlayerAccount(playerID, domain.TierCash),
+		domain.PlayerAccount(playerID, domain.TierBonus),
+		domain.PlayerAccount(playerID, domain.TierReserved),
+	).Scan(&result.Replayed, &h.Deltas.Balance, &h.Deltas.BonusBalance, &h.Deltas.ReservedBalance)
+	if err != nil {
+		return nil, domain.ErrInternal("sum ledger deltas", err)
+	}
+
+	// Rows posted in one DB transaction share created_at, so every snapshot
+	// at the newest timestamp is a candidate, as in Run.
+	rows, err := tx.Query(ctx, `
+		SELECT id, created_at, balance_after::bigint, bonus_balance_after::bigint, reserved_balance_after::bigint
+		FROM v2_transactions
+		WHERE player_id = $1 AND currency = $2
+		  AND created_at = (
+		    SELECT max(created_at) FROM v2_transactions
+		    WHERE player_id = $1 AND currency = $2 AND created_at <= $3)
+		ORDER BY id`, playerID, currency, at)
+	if err != nil {
+		return nil, domain.ErrInternal("query balance snapshots", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			when time.Time
+			snap domain.Balances
+		)
+		if err := rows.Scan(&id, &when, &snap.Balance, &snap.BonusBalance, &snap.ReservedBalance); err != nil {
+			return nil, domain.ErrInternal("scan balance snapshot", err)
+		}
+		if result.LastTransactionID == nil {
+			result.LastTransactionID, result.LastTransactionAt = &id, &when
+		}
+		h.Latest = append(h.Latest, snap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read balance snapshots", err)
+	}
+
+	result.Balances, result.Drift = reconciliation.Replay(h)
+	return result, nil
+}
+
+// StartScheduler runs a reconciliation pass immediately and then every
+// interval until ctx is done.
+func (s *LedgerReconciliationService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		s.runLogged(ctx)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("ledger reconciliation stopped")
+				return
+			case <-ticker.C:
+				s.runLogged(ctx)
+			}
+		}
+	}()
+}
+
+// runLogged runs one pass and logs its summary or error.
+func (s *LedgerReconciliationService) runLogged(ctx context.Context) {
+	summary, err := s.Run(ctx)
+	if err != nil {
+		s.logger.Error("ledger reconciliation run", "error", err)
+		return
+	}
+	s.logger.Info("ledger reconciliation completed",
+		"wallets", summary.WalletsChecked, "drifted", summary.Drifted,
+		"opened", summary.Opened, "resolved", summary.Resolved)
+}
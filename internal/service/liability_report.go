@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/reconciliation"
+	"github.com/jackc/pgx/v5"
+)
+
+// CurrencyLiability is what the operator owed players in one currency at an
+// instant. Wallet balances are the ledger's; Reconciliation sets them next
+// to the balance_after snapshots.
+type CurrencyLiability struct {
+	Currency        string `json:"currency"`
+	Balance         int64  `json:"balance"`
+	ReservedBalance int64  `json:"reserved_balance"`
+	BonusBalance    int64  `json:"bonus_balance"`
+	// BonusValue is the bonus balance at the rate recent bonus money was
+	// released as cash.
+	BonusValue           int64 `json:"bonus_conversion_value"`
+	OpenSportsBets       int   `json:"open_sports_bets"`
+	SportsbookExposure   int64 `json:"sportsbook_exposure"`
+	OpenPredictionStakes int   `json:"open_prediction_stakes"`
+	PredictionExposure   int64 `json:"prediction_exposure"`
+	// TotalLiability is cash, reserved, bonus value and the payouts open
+	// bets and stakes could win.
+	TotalLiability int64                       `json:"total_liability"`
+	Reconciliation reconciliation.WalletTotals `json:"reconciliation"`
+}
+
+// LiabilityReport is the operator's player liabilities per currency at At.
+type LiabilityReport struct {
+	At time.Time `json:"at"`
+	// BonusReleaseRate is the share of bonus money awarded in the
+	// DefaultSimulationDays before At that players went on to release.
+	BonusReleaseRate float64             `json:"bonus_release_rate"`
+	Currencies       []CurrencyLiability `json:"currencies"`
+}
+
+// LiabilityReport sums player liabilities per currency as they stood at at,
+// for treasury: each wallet is replayed from the ledger as in BalanceAt and
+// checked against its balance_after snapshots, bonus balances are valued at
+// the recent release rate, and open bets and prediction stakes count at the
+// payout they could still win. Every figure comes from one snapshot.
+func (s *LedgerReconciliationService) LiabilityReport(ctx context.Context, at time.Time) (*LiabilityReport, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	histories, err := walletHistoriesAt(ctx, tx, at)
+	if err != nil {
+		return nil, err
+	}
+	lines := map[string]*CurrencyLiability{}
+	line := func(currency string) *CurrencyLiability {
+		if lines[currency] == nil {
+			lines[currency] = &CurrencyLiability{
+				Currency:       currency,
+				Reconciliation: reconciliation.WalletTotals{Currency: currency},
+			}
+		}
+		return lines[currency]
+	}
+	for _, t := range reconciliation.SumWallets(histories) {
+		l := line(t.Currency)
+		l.Balance, l.BonusBalance, l.ReservedBalance = t.Ledger.Balance, t.Ledger.BonusBalance, t.Ledger.ReservedBalance
+		l.Reconciliation = t
+	}
+
+	report := &LiabilityReport{At: at, BonusReleaseRate: 1}
+	var released, settled int64
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(initial_amount) FILTER (WHERE status = 'completed'), 0)::bigint,
+		       COALESCE(SUM(initial_amount) FILTER (WHERE status IN ('completed', 'expired', 'forfeited')), 0)::bigint
+		FROM player_bonuses
+		WHERE created_at > $1 AND created_at <= $2`,
+		at.AddDate(0, 0, -policy.DefaultSimulationDays), at).Scan(&released, &settled); err != nil {
+		return nil, domain.ErrInternal("load bonus release rate", err)
+	}
+	if settled > 0 {
+		report.BonusReleaseRate = float64(released) / float64(settled)
+	}
+
+	// A bet or stake was open at at if it was placed by then and had not
+	// settled yet.
+	exposures := []struct {
+		query string
+		apply func(l *CurrencyLiability, count int, payout int64)
+	}{
+		{`
+			SELECT currency, count(*), COALESCE(SUM(potential_payout_minor), 0)::bigint
+			FROM sports_bets
+			WHERE placed_at <= $1 AND (settled_at IS NULL AND status = 'open' OR settled_at > $1)
+			GROUP BY currency`,
+			func(l *CurrencyLiability, count int, payout int64) {
+				l.OpenSportsBets, l.SportsbookExposure = count, payout
+			}},
+		{`
+			SELECT p.currency, count(*),
+			       COALESCE(SUM(ps.shares), 0)::bigint
+			FROM prediction_stakes ps
+			JOIN v2_players p ON p.id = ps.player_id
+			WHERE ps.placed_at <= $1 AND (ps.status = 'active' OR ps.settled_at > $1)
+			GROUP BY p.currency`,
+			func(l *CurrencyLiability, count int, payout int64) {
+				l.OpenPredictionStakes, l.PredictionExposure = count, payout
+			}},
+	}
+	for _, e := range exposures {
+		rows, err := tx.Query(ctx, e.query, at)
+		if err != nil {
+			return nil, domain.ErrInternal("query open exposure", err)
+		}
+		for rows.Next() {
+			var currency string
+			var count int
+			var payout int64
+			if err := rows.Scan(&currency, &count, &payout); err != nil {
+				rows.Close()
+				return nil, domain.ErrInternal("scan open exposure", err)
+			}
+			e.apply(line(currency), count, payout)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, domain.ErrInternal("read open exposure", err)
+		}
+	}
+
+	for _, l := range lines {
+		l.BonusValue = policy.ProjectedBonusCost(l.BonusBalance, released, settled)
+		l.TotalLiability = l.Balance + l.ReservedBalance + l.BonusValue + l.SportsbookExposure + l.PredictionExposure
+		report.Currencies = append(report.Currencies, *l)
+	}
+	sort.Slice(report.Currencies, func(i, j int) bool {
+		return report.Currencies[i].Currency < report.Currencies[j].Currency
+	})
+	if report.Currencies == nil {
+		report.Currencies = []CurrencyLiability{}
+	}
+	return report, nil
+}
+
+// walletHistoriesAt reads every wallet's history up to at in one statement:
+// its pre-ledger base snapshot, the net ledger postings on its tiers since,
+// and the snapshots of its newest transactions.
+func walletHistoriesAt(ctx context.Context, tx pgx.Tx, at time.Time) ([]reconciliation.WalletHistory, error) {
+	rows, err := tx.Query(ctx, `
+		WITH latest_at AS (
+			SELECT player_id, currency, max(created_at) AS created_at
+			FROM v2_transactions
+			WHERE created_at <= $1
+			GROUP BY player_id, currency
+		), base AS (
+			SELECT DISTINCT ON (t.player_id, t.currency) t.player_id, t.currency, t.created_at,
+			       t.balance_after, t.bonus_balance_after, t.reserved_balance_after
+			FROM v2_transactions t
+			WHERE t.created_at <= $1
+			  AND NOT EXISTS (SELECT 1 FROM ledger_entries le WHERE le.transaction_id = t.id)
+			ORDER BY t.player_id, t.currency, t.created_at DESC
+		), deltas AS (
+			SELECT t.player_id, t.currency,
+			       SUM(CASE le.direction WHEN 'credit' THEN le.amount ELSE -le.amount END)
+			           FILTER (WHERE le.account = 'player:' || t.player_id::text || ':' || $2::text) AS balance,
+			       SUM(CASE le.direction WHEN 'credit' THEN le.amount ELSE -le.amount END)
+			           FILTER (WHERE le.account = 'player:' || t.player_id::text || ':' || $3::text) AS bonus,
+			       SUM(CASE le.direction WHEN 'credit' THEN le.amount ELSE -le.amount END)
+			           FILTER (WHERE le.account = 'player:' || t.player_id::text || ':' || $4::text) AS reserved
+			FROM v2_transactions t
+			JOIN ledger_entries le ON le.transaction_id = t.id
+			LEFT JOIN base b ON b.player_id = t.player_id AND b.currency = t.currency
+			WHERE t.created_at <= $1 AND (b.created_at IS NULL OR t.created_at > b.created_at)
+			GROUP BY t.player_id, t.currency
+		)
+		SELECT l.player_id, l.currency,
+		       COALESCE(b.balance_after, 0)::bigint, COALESCE(b.bonus_balance_after, 0)::bigint,
+		       COALESCE(b.reserved_balance_after, 0)::bigint,
+		       COALESCE(d.balance, 0)::bigint, COALESCE(d.bonus, 0)::bigint, COALESCE(d.reserved, 0)::bigint,
+		       t.balance_after::bigint, t.bonus_balance_after::bigint, t.reserved_balance_after::bigint
+		FROM latest_at l
+		JOIN v2_transactions t
+		  ON t.player_id = l.player_id AND t.currency = l.currency AND t.created_at = l.created_at
+		LEFT JOIN base b ON b.player_id = l.player_id AND b.currency = l.currency
+		LEFT JOIN deltas d ON d.player_id = l.player_id AND d.currency = l.currency
+		ORDER BY l.player_id, l.currency, t.id`,
+		at, domain.TierCash, domain.TierBonus, domain.TierReserved)
+	if err != nil {
+		return nil, domain.ErrInternal("load wallet histories", err)
+	}
+	defer rows.Close()
+
+	var histories []reconciliation.WalletHistory
+	for rows.Next() {
+		var (
+			h    reconciliation.WalletHistory
+			snap domain.Balances
+		)
+		if err := rows.Scan(&h.PlayerID, &h.Currency,
+			&h.Base.Balance, &h.Base.BonusBalance, &h.Base.ReservedBalance,
+			&h.Deltas.Balance, &h.Deltas.BonusBalance, &h.Deltas.ReservedBalance,
+			&snap.Balance, &snap.BonusBalance, &snap.ReservedBalance); err != nil {
+			return nil, domain.ErrInternal("scan wallet history", err)
+		}
+		// Rows posted in one DB transaction share created_at, so a wallet
+		// can have several newest snapshots.
+		if n := len(histories); n > 0 && histories[n-1].PlayerID == h.PlayerID && histories[n-1].Currency == h.Currency {
+			histories[n-1].Latest = append(histories[n-1].Latest, snap)
+			continue
+		}
+		h.Latest = []domain.Balances{snap}
+		histories = append(histories, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read wallet histories", err)
+	}
+	return histories, nil
+}
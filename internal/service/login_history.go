@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoginHistoryService exposes a player's login history and handles "not me"
+// reports, which lock the account behind a password reset and revoke every
+// outstanding session.
+type LoginHistoryService struct {
+	pool   *pgxpool.Pool
+	users  repository.AuthUserRepository
+	outbox repository.OutboxRepository
+	logger *slog.Logger
+}
+
+// NewLoginHistoryService creates a LoginHistoryService.
+func NewLoginHistoryService(
+	pool *pgxpool.Pool,
+	users repository.AuthUserRepository,
+	outbox repository.OutboxRepository,
+	logger *slog.Logger,
+) *LoginHistoryService {
+	return &LoginHistoryService{pool: pool, users: users, outbox: outbox, logger: logger}
+}
+
+// List returns the player's most recent login attempts, newest first.
+func (s *LoginHistoryService) List(ctx context.Context, playerID uuid.UUID, limit int) ([]domain.LoginRecord, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, success, ip_address, country, device, user_agent, reported_at, created_at
+		FROM login_attempts
+		WHERE player_id = $1 AND realm = 'player'
+		ORDER BY created_at DESC
+		LIMIT $2`, playerID, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list logins", err)
+	}
+	defer rows.Close()
+
+	records := []domain.LoginRecord{}
+	for rows.Next() {
+		var rec domain.LoginRecord
+		if err := rows.Scan(&rec.ID, &rec.Success, &rec.IPAddress, &rec.Country, &rec.Device,
+			&rec.UserAgent, &rec.ReportedAt, &rec.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan login", err)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// ReportNotMe flags a login the player does not recognise. The account is
+// forced through a password reset, all sessions are deleted, every JWT issued
+// so far is revoked, and a pam.session.revoked event is written to the outbox.
+func (s *LoginHistoryService) ReportNotMe(ctx context.Context, playerID uuid.UUID, loginID int64) (*domain.LoginReport, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var email string
+	err = tx.QueryRow(ctx, `
+		UPDATE login_attempts la SET reported_at = COALESCE(la.reported_at, now())
+		FROM auth_users u
+		WHERE la.id = $1 AND la.player_id = $2 AND u.id = la.player_id
+		RETURNING u.email`, loginID, playerID).Scan(&email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("login", "")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("flag login", err)
+	}
+
+	if err := s.users.SetPasswordResetRequired(ctx, tx, email); err != nil {
+		return nil, domain.ErrInternal("force password reset", err)
+	}
+
+	revokedAt, err := s.users.RevokeTokens(ctx, tx, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("revoke tokens", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM sessions WHERE player_id = $1`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("delete sessions", err)
+	}
+
+	if err := s.outbox.Insert(ctx, tx, domain.NewSessionRevokedEvent(playerID, "login_reported", tag.RowsAffected())); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Warn("login reported as not me", "player_id", playerID, "login_id", loginID)
+
+	return &domain.LoginReport{
+		LoginID:         loginID,
+		PlayerID:        playerID,
+		SessionsRevoked: tag.RowsAffected(),
+		PasswordReset:   true,
+		TokensRevokedAt: revokedAt,
+	}, nil
+}
+
+// RevokedSince implements auth.RevocationChecker for player tokens.
+func (s *LoginHistoryService) RevokedSince(ctx context.Context, subject string) (time.Time, error) {
+	id, err := uuid.Parse(subject)
+	if err != nil {
+		return time.Time{}, err
+	}
+	revokedAt, err := s.users.TokensRevokedAt(ctx, s.pool, id)
+	if err != nil || revokedAt == nil {
+		return time.Time{}, err
+	}
+	return *revokedAt, nil
+}
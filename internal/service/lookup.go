@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// lookupLimit caps the records of each kind a lookup returns.
+	lookupLimit = 100
+	// lookupPasses is how many searches a lookup makes, each also matching
+	// the IDs the one before found.
+	lookupPasses = 3
+)
+
+// SupportLookupService traces an external or provider ID across the wallet,
+// payments, sportsbook and provider callback journal for support.
+type SupportLookupService struct {
+	pool         *pgxpool.Pool
+	transactions repository.TransactionRepository
+	payments     repository.PaymentRepository
+}
+
+// NewSupportLookupService creates a SupportLookupService.
+func NewSupportLookupService(pool *pgxpool.Pool, transactions repository.TransactionRepository, payments repository.PaymentRepository) *SupportLookupService {
+	return &SupportLookupService{pool: pool, transactions: transactions, payments: payments}
+}
+
+// LookupSportsBet is a sports_bets row found by a lookup.
+type LookupSportsBet struct {
+	ID              uuid.UUID  `json:"id"`
+	PlayerID        uuid.UUID  `json:"player_id"`
+	EventID         *uuid.UUID `json:"event_id,omitempty"`
+	MarketID        uuid.UUID  `json:"market_id"`
+	SelectionID     uuid.UUID  `json:"selection_id"`
+	Stake           int64      `json:"stake"`
+	Odds            int        `json:"odds"`
+	PotentialPayout int64      `json:"potential_payout"`
+	Status          string     `json:"status"`
+	Payout          int64      `json:"payout"`
+	GameRoundID     string     `json:"game_round_id"`
+	TransactionID   *uuid.UUID `json:"transaction_id,omitempty"`
+	PlacedAt        time.Time  `json:"placed_at"`
+	SettledAt       *time.Time `json:"settled_at,omitempty"`
+}
+
+// ExternalIDLookup is every record linked to an external ID.
+type ExternalIDLookup struct {
+	ExternalID        string                    `json:"external_id"`
+	Transactions      []domain.Transaction      `json:"transactions"`
+	Payments          []domain.Payment          `json:"payments"`
+	SportsBets        []LookupSportsBet         `json:"sports_bets"`
+	ProviderCallbacks []domain.ProviderCallback `json:"provider_callbacks"`
+}
+
+// Lookup returns the records that carry externalID (a transaction, payment,
+// bet or callback ID, a provider's transaction, payment, session or round
+// ID) and the records linked to those: a payment's ledger transaction, a
+// bet's round, a callback's ledger entries and the cancellations of any of
+// them, up to lookupPasses searches deep.
+func (s *SupportLookupService) Lookup(ctx context.Context, externalID string) (*ExternalIDLookup, error) {
+	if externalID == "" {
+		return nil, domain.ErrValidation("external_id is required")
+	}
+
+	refs := []string{externalID}
+	var result *ExternalIDLookup
+	for range lookupPasses {
+		var err error
+		result, err = s.find(ctx, externalID, refs)
+		if err != nil {
+			return nil, err
+		}
+		next := result.references(slices.Clone(refs))
+		if len(next) == len(refs) {
+			break
+		}
+		refs = next
+	}
+	return result, nil
+}
+
+// find returns the records matching any of refs.
+func (s *SupportLookupService) find(ctx context.Context, externalID string, refs []string) (*ExternalIDLookup, error) {
+	result := &ExternalIDLookup{ExternalID: externalID}
+	var err error
+	if result.Transactions, err = s.transactions.ListByReferences(ctx, s.pool, refs, lookupLimit); err != nil {
+		return nil, domain.ErrInternal("look up transactions", err)
+	}
+	if result.Transactions == nil {
+		result.Transactions = []domain.Transaction{}
+	}
+	if result.Payments, err = s.payments.ListByReferences(ctx, s.pool, refs, lookupLimit); err != nil {
+		return nil, domain.ErrInternal("look up payments", err)
+	}
+	if result.SportsBets, err = s.findSportsBets(ctx, refs); err != nil {
+		return nil, err
+	}
+	if result.ProviderCallbacks, err = s.findProviderCallbacks(ctx, refs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *SupportLookupService) findSportsBets(ctx context.Context, refs []string) ([]LookupSportsBet, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, player_id, event_id, market_id, selection_id, stake_amount_minor, odds_at_placement,
+		       potential_payout_minor, status, COALESCE(payout_amount_minor, 0), game_round_id, transaction_id,
+		       placed_at, settled_at
+		FROM sports_bets
+		WHERE id = ANY($2) OR transaction_id = ANY($2) OR game_round_id = ANY($1)
+		ORDER BY placed_at ASC
+		LIMIT $3`, refs, lookupIDs(refs), lookupLimit)
+	if err != nil {
+		return nil, domain.ErrInternal("look up sports bets", err)
+	}
+	defer rows.Close()
+
+	bets := []LookupSportsBet{}
+	for rows.Next() {
+		var b LookupSportsBet
+		if err := rows.Scan(&b.ID, &b.PlayerID, &b.EventID, &b.MarketID, &b.SelectionID, &b.Stake, &b.Odds,
+			&b.PotentialPayout, &b.Status, &b.Payout, &b.GameRoundID, &b.TransactionID,
+			&b.PlacedAt, &b.SettledAt); err != nil {
+			return nil, domain.ErrInternal("scan sports bet", err)
+		}
+		bets = append(bets, b)
+	}
+	return bets, rows.Err()
+}
+
+func (s *SupportLookupService) findProviderCallbacks(ctx context.Context, refs []string) ([]domain.ProviderCallback, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+providerCallbackColumns+` FROM provider_callbacks
+		WHERE id = ANY($2) OR transaction_id = ANY($1) OR reference_id = ANY($1) OR round_id = ANY($1)
+		ORDER BY received_at ASC
+		LIMIT $3`, refs, lookupIDs(refs), lookupLimit)
+	if err != nil {
+		return nil, domain.ErrInternal("look up provider callbacks", err)
+	}
+	defer rows.Close()
+
+	callbacks := []domain.ProviderCallback{}
+	for rows.Next() {
+		c, err := scanProviderCallback(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan provider callback", err)
+		}
+		callbacks = append(callbacks, *c)
+	}
+	return callbacks, rows.Err()
+}
+
+// lookupIDs returns the references that parse as UUIDs, to match uuid
+// columns by index.
+func lookupIDs(refs []string) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, ref := range refs {
+		if id, err := uuid.Parse(ref); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// references adds the IDs the found records carry to refs, so the next pass
+// finds the records linked to them.
+func (l *ExternalIDLookup) references(refs []string) []string {
+	add := func(ref *string) {
+		if ref != nil && *ref != "" && !slices.Contains(refs, *ref) {
+			refs = append(refs, *ref)
+		}
+	}
+	addID := func(id *uuid.UUID) {
+		if id != nil {
+			ref := id.String()
+			add(&ref)
+		}
+	}
+
+	for _, t := range l.Transactions {
+		addID(&t.ID)
+		addID(t.TargetTransactionID)
+		add(t.ExternalTransactionID)
+		add(t.GameRoundID)
+	}
+	for _, p := range l.Payments {
+		addID(&p.ID)
+		addID(p.TransactionID)
+		add(p.ExternalTransactionID)
+		add(p.ProviderSessionID)
+		add(p.ProviderPaymentID)
+	}
+	for _, b := range l.SportsBets {
+		addID(&b.ID)
+		addID(b.TransactionID)
+		add(&b.GameRoundID)
+	}
+	for _, c := range l.ProviderCallbacks {
+		add(c.TransactionID)
+		add(c.ReferenceID)
+		add(c.RoundID)
+	}
+	return refs
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// walletActions are the callback actions a manufacturer can be limited to.
+var walletActions = []provider.WalletAction{
+	provider.WalletActionBalance,
+	provider.WalletActionBet,
+	provider.WalletActionWin,
+	provider.WalletActionRollback,
+	provider.WalletActionReserve,
+	provider.WalletActionRelease,
+}
+
+const manufacturerColumns = `id, kind, display_name, prefix, secret_ref, enabled, money_format,
+	allowed_actions, disabled_reason, updated_by, created_at, updated_at`
+
+// ManufacturerService manages the wallet provider registry. The wallet server
+// mounts the registered providers at startup and picks up enablement and
+// allowed-action changes as they are written.
+type ManufacturerService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewManufacturerService creates a ManufacturerService.
+func NewManufacturerService(pool *pgxpool.Pool, logger *slog.Logger) *ManufacturerService {
+	return &ManufacturerService{pool: pool, logger: logger}
+}
+
+// ManufacturerInput registers or reconfigures a wallet provider. Kind, prefix
+// and secret changes take effect when the wallet server restarts.
+type ManufacturerInput struct {
+	Kind           string   `json:"kind"`
+	DisplayName    string   `json:"display_name"`
+	Prefix         string   `json:"prefix"`
+	SecretRef      *string  `json:"secret_ref"`
+	MoneyFormat    string   `json:"money_format"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+// List returns every registered manufacturer by id.
+func (s *ManufacturerService) List(ctx context.Context) ([]domain.Manufacturer, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+manufacturerColumns+` FROM manufacturers ORDER BY id`)
+	if err != nil {
+		return nil, domain.ErrInternal("list manufacturers", err)
+	}
+	defer rows.Close()
+
+	manufacturers := []domain.Manufacturer{}
+	for rows.Next() {
+		m, err := scanManufacturer(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan manufacturer", err)
+		}
+		manufacturers = append(manufacturers, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("iterate manufacturers", err)
+	}
+	return manufacturers, nil
+}
+
+// Get returns one manufacturer.
+func (s *ManufacturerService) Get(ctx context.Context, id string) (*domain.Manufacturer, error) {
+	m, err := scanManufacturer(s.pool.QueryRow(ctx,
+		`SELECT `+manufacturerColumns+` FROM manufacturers WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("manufacturer", id)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get manufacturer", err)
+	}
+	return m, nil
+}
+
+// Put registers a manufacturer or replaces its configuration, keeping its
+// enablement. The prefix defaults to /<id>.
+func (s *ManufacturerService) Put(ctx context.Context, id string, input ManufacturerInput, adminID uuid.UUID) (*domain.Manufacturer, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return nil, domain.ErrValidation("manufacturer id is required")
+	}
+	input.Kind = strings.ToLower(strings.TrimSpace(input.Kind))
+	if !slices.Contains(provider.DefaultAdapterRegistry().Kinds(), input.Kind) {
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown adapter kind %q", input.Kind))
+	}
+	if strings.TrimSpace(input.DisplayName) == "" {
+		input.DisplayName = id
+	}
+	input.Prefix = "/" + strings.Trim(input.Prefix, "/ ")
+	if input.Prefix == "/" {
+		input.Prefix = "/" + id
+	}
+	if input.MoneyFormat == "" {
+		input.MoneyFormat = domain.MoneyFormatDecimal
+	}
+	if input.MoneyFormat != domain.MoneyFormatCents && input.MoneyFormat != domain.MoneyFormatDecimal {
+		return nil, domain.ErrValidation("money_format must be cents or decimal")
+	}
+	if input.AllowedActions == nil {
+		input.AllowedActions = []string{}
+	}
+	for _, action := range input.AllowedActions {
+		if !slices.Contains(walletActions, provider.WalletAction(action)) {
+			return nil, domain.ErrValidation(fmt.Sprintf("unknown wallet action %q", action))
+		}
+	}
+
+	var taken string
+	err := s.pool.QueryRow(ctx, `SELECT id FROM manufacturers WHERE prefix = $1 AND id <> $2`, input.Prefix, id).Scan(&taken)
+	if err == nil {
+		return nil, domain.ErrConflict("prefix " + input.Prefix + " is already used by " + taken)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInternal("check prefix", err)
+	}
+
+	m, err := scanManufacturer(s.pool.QueryRow(ctx, `
+		INSERT INTO manufacturers (id, kind, display_name, prefix, secret_ref, money_format, allowed_actions, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE
+		  SET kind = EXCLUDED.kind, display_name = EXCLUDED.display_name, prefix = EXCLUDED.prefix,
+		      secret_ref = EXCLUDED.secret_ref, money_format = EXCLUDED.money_format,
+		      allowed_actions = EXCLUDED.allowed_actions, updated_by = EXCLUDED.updated_by, updated_at = now()
+		RETURNING `+manufacturerColumns,
+		id, input.Kind, input.DisplayName, input.Prefix, input.SecretRef, input.MoneyFormat, input.AllowedActions, adminID))
+	if err != nil {
+		return nil, domain.ErrInternal("put manufacturer", err)
+	}
+	s.logger.Info("manufacturer configured", "manufacturer", id, "kind", m.Kind, "admin_id", adminID)
+	return m, nil
+}
+
+// SetEnabled enables or disables a manufacturer. A disabled manufacturer's
+// callbacks are refused by the wallet server as soon as it reloads, which the
+// change itself triggers.
+func (s *ManufacturerService) SetEnabled(ctx context.Context, id string, enabled bool, reason string, adminID uuid.UUID) (*domain.Manufacturer, error) {
+	if !enabled && strings.TrimSpace(reason) == "" {
+		return nil, domain.ErrValidation("a reason is required to disable a manufacturer")
+	}
+	var disabledReason *string
+	if !enabled {
+		disabledReason = &reason
+	}
+
+	m, err := scanManufacturer(s.pool.QueryRow(ctx, `
+		UPDATE manufacturers
+		SET enabled = $2, disabled_reason = $3, updated_by = $4, updated_at = now()
+		WHERE id = $1
+		RETURNING `+manufacturerColumns,
+		id, enabled, disabledReason, adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("manufacturer", id)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("set manufacturer enabled", err)
+	}
+	s.logger.Warn("manufacturer enablement changed", "manufacturer", id, "enabled", enabled, "reason", reason, "admin_id", adminID)
+	return m, nil
+}
+
+func scanManufacturer(row pgx.Row) (*domain.Manufacturer, error) {
+	var m domain.Manufacturer
+	err := row.Scan(&m.ID, &m.Kind, &m.DisplayName, &m.Prefix, &m.SecretRef, &m.Enabled, &m.MoneyFormat,
+		&m.AllowedActions, &m.DisabledReason, &m.UpdatedBy, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const maxTransferMessageLength = 200
+
+const p2pTransferColumns = `id, sender_id, recipient_id, amount, fee, currency, message, status, reject_reason,
+	aml_flags, debit_transaction_id, credit_transaction_id, fee_transaction_id, created_at`
+
+func scanP2PTransfer(row pgx.Row) (*domain.P2PTransfer, error) {
+	var t domain.P2PTransfer
+	if err := row.Scan(&t.ID, &t.SenderID, &t.RecipientID, &t.Amount, &t.Fee, &t.Currency, &t.Message, &t.Status,
+		&t.RejectReason, &t.AMLFlags, &t.DebitTransactionID, &t.CreditTransactionID, &t.FeeTransactionID,
+		&t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// P2PTransferService handles player-to-player transfers, for social casino
+// deployments. Transfers are off unless enabled in the rules.
+type P2PTransferService struct {
+	pool   *pgxpool.Pool
+	engine *ledger.Engine
+	outbox repository.OutboxRepository
+	rules  policy.TransferRules
+	logger *slog.Logger
+}
+
+// NewP2PTransferService creates a P2PTransferService.
+func NewP2PTransferService(pool *pgxpool.Pool, engine *ledger.Engine, outbox repository.OutboxRepository, rules policy.TransferRules, logger *slog.Logger) *P2PTransferService {
+	return &P2PTransferService{pool: pool, engine: engine, outbox: outbox, rules: rules, logger: logger}
+}
+
+// Rules returns the transfer rules in force.
+func (s *P2PTransferService) Rules() policy.TransferRules {
+	return s.rules
+}
+
+// SendTransferInput holds a transfer request. The recipient is named by id
+// or by their registered email.
+type SendTransferInput struct {
+	RecipientID    *uuid.UUID `json:"recipient_id,omitempty"`
+	RecipientEmail string     `json:"recipient_email,omitempty"`
+	Amount         int64      `json:"amount"`
+	Message        string     `json:"message,omitempty"`
+}
+
+// Send transfers real balance from the sender to another player in the
+// sender's base currency. The request is checked against the daily cap and
+// AML rules first; refused requests are recorded as rejected transfers and
+// returned as ErrTransferRejected. Allowed transfers that match an AML
+// pattern raise an aml_alerts row, which holds further transfers for either
+// player until compliance resolves it.
+func (s *P2PTransferService) Send(ctx context.Context, senderID uuid.UUID, input SendTransferInput) (*domain.P2PTransfer, error) {
+	if !s.rules.Enabled {
+		return nil, domain.ErrForbidden("player transfers are not enabled")
+	}
+	if input.Amount <= 0 {
+		return nil, domain.ErrValidation("amount must be positive")
+	}
+	message := strings.TrimSpace(input.Message)
+	if utf8.RuneCountInString(message) > maxTransferMessageLength {
+		return nil, domain.ErrValidation("message must be at most 200 characters")
+	}
+
+	recipientID, err := s.resolveRecipient(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if recipientID == senderID {
+		return nil, domain.ErrValidation("cannot transfer to yourself")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock both players before reading the day's totals so concurrent
+	// transfers cannot slip past the cap; player-id order matches the ledger.
+	var currency string
+	rows, err := tx.Query(ctx,
+		`SELECT id, currency FROM v2_players WHERE id = ANY($1) ORDER BY id FOR UPDATE`,
+		[]uuid.UUID{senderID, recipientID})
+	if err != nil {
+		return nil, domain.ErrInternal("lock players", err)
+	}
+	for rows.Next() {
+		var id uuid.UUID
+		var c string
+		if err := rows.Scan(&id, &c); err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan player", err)
+		}
+		if id == senderID {
+			currency = c
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("lock players", err)
+	}
+	if currency == "" {
+		return nil, domain.ErrNotFound("player", senderID.String())
+	}
+
+	check, err := s.transferCheck(ctx, tx, senderID, recipientID, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+	decision := policy.EvaluateTransfer(s.rules, check)
+
+	t := &domain.P2PTransfer{
+		ID:          uuid.New(),
+		SenderID:    senderID,
+		RecipientID: recipientID,
+		Amount:      input.Amount,
+		Fee:         decision.Fee,
+		Currency:    currency,
+		Status:      domain.P2PTransferCompleted,
+		CreatedAt:   time.Now(),
+	}
+	if message != "" {
+		t.Message = &message
+	}
+	t.AMLFlags, _ = json.Marshal(append([]string{}, decision.Alerts...))
+
+	if !decision.Allowed {
+		t.Status = domain.P2PTransferRejected
+		t.Fee = 0
+		t.RejectReason = &decision.Reason
+		if err := s.insert(ctx, tx, t); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, domain.ErrInternal("commit tx", err)
+		}
+		s.logger.Warn("p2p transfer rejected", "sender_id", senderID, "recipient_id", recipientID,
+			"amount", input.Amount, "reason", decision.Reason)
+		return nil, domain.ErrTransferRejected(decision.Reason)
+	}
+
+	result, err := s.engine.ExecuteTransfer(ctx, tx, domain.TransferParams{
+		SenderID:    senderID,
+		RecipientID: recipientID,
+		Amount:      input.Amount,
+		Fee:         decision.Fee,
+		Currency:    currency,
+		TransferID:  t.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.DebitTransactionID = &result.Debit.ID
+	t.CreditTransactionID = &result.Credit.ID
+	if result.Fee != nil {
+		t.FeeTransactionID = &result.Fee.ID
+	}
+	if err := s.insert(ctx, tx, t); err != nil {
+		return nil, err
+	}
+
+	for _, alert := range decision.Alerts {
+		playerID := senderID
+		if alert == "p2p_fan_in" {
+			playerID = recipientID
+		}
+		details, _ := json.Marshal(map[string]any{"transfer_id": t.ID, "check": check})
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO aml_alerts (player_id, alert_type, severity, details)
+			VALUES ($1, $2, 'medium', $3)`, playerID, alert, details); err != nil {
+			return nil, domain.ErrInternal("insert aml alert", err)
+		}
+	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewP2PTransferReceivedEvent(*t)); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("p2p transfer completed", "transfer_id", t.ID, "sender_id", senderID,
+		"recipient_id", recipientID, "amount", t.Amount, "fee", t.Fee, "alerts", decision.Alerts)
+	return t, nil
+}
+
+// resolveRecipient finds an active recipient by id or email. Players who
+// are suspended, self-excluded or closed cannot receive transfers.
+func (s *P2PTransferService) resolveRecipient(ctx context.Context, input SendTransferInput) (uuid.UUID, error) {
+	var where, label string
+	var arg any
+	switch email := strings.TrimSpace(input.RecipientEmail); {
+	case input.RecipientID != nil:
+		where, arg, label = `p.id = $1`, *input.RecipientID, input.RecipientID.String()
+	case email != "":
+		where, arg, label = `pp.email = $1`, email, email
+	default:
+		return uuid.Nil, domain.ErrValidation("recipient_id or recipient_email is required")
+	}
+
+	var id uuid.UUID
+	var status string
+	err := s.pool.QueryRow(ctx, `
+		SELECT p.id, COALESCE(pp.account_status, 'active')
+		FROM v2_players p LEFT JOIN player_profiles pp ON pp.player_id = p.id
+		WHERE `+where, arg).Scan(&id, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, domain.ErrNotFound("recipient", label)
+	}
+	if err != nil {
+		return uuid.Nil, domain.ErrInternal("find recipient", err)
+	}
+	if domain.AccountStatus(status) != domain.AccountStatusActive {
+		return uuid.Nil, domain.ErrValidation("recipient cannot receive transfers")
+	}
+	return id, nil
+}
+
+// transferCheck gathers the day's transfer totals, the sender's KYC state
+// and open AML alerts on both sides. Counts include this transfer.
+func (s *P2PTransferService) transferCheck(ctx context.Context, db repository.DBTX, senderID, recipientID uuid.UUID, amount int64) (policy.TransferCheck, error) {
+	c := policy.TransferCheck{Amount: amount}
+	now := time.Now().UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	err := db.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE sender_id = $1), 0)::bigint,
+			COUNT(DISTINCT recipient_id) FILTER (WHERE sender_id = $1 AND recipient_id <> $2),
+			COUNT(DISTINCT sender_id) FILTER (WHERE recipient_id = $2 AND sender_id <> $1)
+		FROM p2p_transfers
+		WHERE status = 'completed' AND created_at >= $3 AND (sender_id = $1 OR recipient_id = $2)`,
+		senderID, recipientID, startOfDay,
+	).Scan(&c.SentToday, &c.RecipientsToday, &c.SendersToRecipientToday)
+	if err != nil {
+		return c, domain.ErrInternal("query transfer totals", err)
+	}
+	c.RecipientsToday++
+	c.SendersToRecipientToday++
+
+	err = db.QueryRow(ctx, `
+		SELECT COALESCE((SELECT verified FROM player_profiles WHERE player_id = $1), false),
+		       (SELECT COUNT(*) FROM aml_alerts WHERE player_id = $1 AND status = 'open'),
+		       (SELECT COUNT(*) FROM aml_alerts WHERE player_id = $2 AND status = 'open')`,
+		senderID, recipientID,
+	).Scan(&c.SenderKYCVerified, &c.SenderOpenAMLAlerts, &c.RecipientOpenAMLAlerts)
+	if err != nil {
+		return c, domain.ErrInternal("query transfer aml state", err)
+	}
+	return c, nil
+}
+
+func (s *P2PTransferService) insert(ctx context.Context, tx pgx.Tx, t *domain.P2PTransfer) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO p2p_transfers (id, sender_id, recipient_id, amount, fee, currency, message, status,
+			reject_reason, aml_flags, debit_transaction_id, credit_transaction_id, fee_transaction_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		t.ID, t.SenderID, t.RecipientID, t.Amount, t.Fee, t.Currency, t.Message, t.Status,
+		t.RejectReason, t.AMLFlags, t.DebitTransactionID, t.CreditTransactionID, t.FeeTransactionID, t.CreatedAt)
+	if err != nil {
+		return domain.ErrInternal("insert p2p transfer", err)
+	}
+	return nil
+}
+
+// ListForPlayer returns a player's completed transfers, sent and received,
+// newest first.
+func (s *P2PTransferService) ListForPlayer(ctx context.Context, playerID uuid.UUID) ([]domain.P2PTransfer, error) {
+	return s.query(ctx, `
+		SELECT `+p2pTransferColumns+` FROM p2p_transfers
+		WHERE (sender_id = $1 OR recipient_id = $1) AND status = 'completed'
+		ORDER BY created_at DESC LIMIT 100`, playerID)
+}
+
+// List returns transfers for compliance review, newest first, optionally
+// filtered by a player on either side and by status.
+func (s *P2PTransferService) List(ctx context.Context, playerID *uuid.UUID, status string, limit int) ([]domain.P2PTransfer, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.query(ctx, `
+		SELECT `+p2pTransferColumns+` FROM p2p_transfers
+		WHERE ($1::uuid IS NULL OR sender_id = $1 OR recipient_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC LIMIT $3`, playerID, status, limit)
+}
+
+func (s *P2PTransferService) query(ctx context.Context, sql string, args ...any) ([]domain.P2PTransfer, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, domain.ErrInternal("query p2p transfers", err)
+	}
+	defer rows.Close()
+
+	out := []domain.P2PTransfer{}
+	for rows.Next() {
+		t, err := scanP2PTransfer(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan p2p transfer", err)
+		}
+		out = append(out, *t)
+	}
+	return out, rows.Err()
+}
@@ -3,15 +3,20 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
 	"github.com/attaboy/platform/internal/ledger"
 	"github.com/attaboy/platform/internal/policy"
 	"github.com/attaboy/platform/internal/provider"
 	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/saga"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,93 +24,183 @@ import (
 type PaymentService struct {
 	pool     *pgxpool.Pool
 	stripe   *provider.StripeProvider
+	adyen    *provider.AdyenProvider
+	payouts  *provider.AdyenPayoutProvider // unconfigured: withdrawals are paid by hand
 	payments repository.PaymentRepository
 	players  repository.PlayerRepository
 	txRepo   repository.TransactionRepository
+	outbox   repository.OutboxRepository
 	engine   *ledger.Engine
-	logger   *slog.Logger
+	sagas    *saga.Orchestrator
+	// bonuses matches deposits the player has opted in to a deposit_match
+	// bonus for; nil disables deposit matching.
+	bonuses *BonusService
+	// closedLoop requires withdrawals to go to a verified saved method
+	// already used for a completed deposit.
+	closedLoop bool
+	// kycThreshold is the withdrawal amount in cents above which the
+	// player must be KYC verified; 0 disables the check.
+	kycThreshold int64
+	logger       *slog.Logger
 }
 
 // NewPaymentService creates a PaymentService.
 func NewPaymentService(
 	pool *pgxpool.Pool,
 	stripe *provider.StripeProvider,
+	adyen *provider.AdyenProvider,
+	payouts *provider.AdyenPayoutProvider,
 	payments repository.PaymentRepository,
 	players repository.PlayerRepository,
 	txRepo repository.TransactionRepository,
+	outbox repository.OutboxRepository,
 	engine *ledger.Engine,
+	sagas *saga.Orchestrator,
+	bonuses *BonusService,
+	closedLoop bool,
+	kycThreshold int64,
 	logger *slog.Logger,
 ) *PaymentService {
-	return &PaymentService{
-		pool:     pool,
-		stripe:   stripe,
-		payments: payments,
-		players:  players,
-		txRepo:   txRepo,
-		engine:   engine,
-		logger:   logger,
+	s := &PaymentService{
+		pool:         pool,
+		stripe:       stripe,
+		adyen:        adyen,
+		payouts:      payouts,
+		payments:     payments,
+		players:      players,
+		txRepo:       txRepo,
+		outbox:       outbox,
+		engine:       engine,
+		sagas:        sagas,
+		bonuses:      bonuses,
+		closedLoop:   closedLoop,
+		kycThreshold: kycThreshold,
+		logger:       logger,
 	}
+	sagas.Register(s.withdrawalPayoutSaga())
+	return s
 }
 
-// DepositSession holds the Stripe checkout session details.
+// Deposit methods accepted by InitiateDeposit.
+const (
+	PaymentMethodStripe = "stripe"
+	PaymentMethodAdyen  = "adyen"
+)
+
+// DepositSession holds the PSP checkout session details.
 type DepositSession struct {
 	SessionID  string `json:"session_id"`
 	SessionURL string `json:"session_url"`
 	PaymentID  string `json:"payment_id"`
+	Method     string `json:"method"`
 }
 
-// InitiateDeposit creates a Stripe checkout session and records a pending payment.
-func (s *PaymentService) InitiateDeposit(ctx context.Context, playerID uuid.UUID, amount int64, currency, successURL, cancelURL string) (*DepositSession, error) {
+// InitiateDeposit creates a hosted checkout session with the PSP chosen by
+// method (Stripe when empty) and records a pending payment. Adyen has a
+// single return URL, so cancelURL is only used by Stripe. paymentMethodID,
+// when set, names the saved method paid with; completed deposits make it an
+// eligible withdrawal destination.
+func (s *PaymentService) InitiateDeposit(ctx context.Context, playerID uuid.UUID, amount int64, currency, method, successURL, cancelURL string, paymentMethodID *uuid.UUID) (*DepositSession, error) {
 	if currency == "" {
 		currency = "EUR"
 	}
+	method, err := checkoutMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDepositMethod(ctx, playerID, paymentMethodID); err != nil {
+		return nil, err
+	}
 
-	// Responsible gaming: check daily deposit limit before hitting Stripe.
+	// Responsible gaming: check daily deposit limit before hitting the PSP.
 	dailyDeposits, err := s.txRepo.DailySumByType(ctx, s.pool, playerID, string(domain.TxDeposit))
 	if err != nil {
 		return nil, domain.ErrInternal("rg daily deposit query", err)
 	}
 	rgResult := policy.EvaluateRgLimits(policy.DefaultRgLimits(), amount, "wallet_deposit", dailyDeposits, 0)
 	if !rgResult.Allowed {
-		return nil, &domain.AppError{
-			Code:    "RG_LIMIT_BREACHED",
-			Message: fmt.Sprintf("deposit exceeds %s limit", rgResult.BreachedLimit),
-			Status:  422,
-		}
+		return nil, domain.ErrRGLimitBreached("deposit", rgResult.BreachedLimit, rgResult.LimitValue)
+	}
+	if err := guard.CheckPlayerLimits(ctx, s.pool, playerID, domain.LimitDeposit, amount); err != nil {
+		return nil, err
 	}
 
-	// Create Stripe checkout session
-	session, err := s.stripe.CreateCheckoutSession(amount, currency, playerID.String(), successURL, cancelURL)
+	// Create the PSP checkout session
+	paymentID := uuid.New()
+	sessionID, sessionURL, err := s.createCheckout(ctx, method, paymentID, playerID, amount, currency, successURL, cancelURL)
 	if err != nil {
-		return nil, domain.ErrInternal("create checkout session", err)
+		return nil, err
 	}
 
 	// Record pending payment
-	providerName := "stripe"
+	providerName := method
 	payment := &domain.Payment{
-		ID:                uuid.New(),
+		ID:                paymentID,
 		PlayerID:          playerID,
 		Type:              domain.PaymentTypeDeposit,
 		Amount:            amount,
 		Currency:          currency,
 		Status:            domain.PaymentStatusPending,
+		PaymentMethodID:   paymentMethodID,
 		Provider:          &providerName,
-		ProviderSessionID: &session.ID,
+		ProviderSessionID: &sessionID,
 	}
-	if err := s.payments.Create(ctx, s.pool, payment); err != nil {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := s.payments.Create(ctx, tx, payment); err != nil {
 		return nil, domain.ErrInternal("record payment", err)
 	}
+	if err := s.publishPaymentStatus(ctx, tx, payment, domain.PaymentStatusPending); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
 
 	// Record payment event
 	s.recordEvent(ctx, payment.ID, domain.PaymentStatusPending, "checkout session created", nil)
 
 	return &DepositSession{
-		SessionID:  session.ID,
-		SessionURL: session.URL,
+		SessionID:  sessionID,
+		SessionURL: sessionURL,
 		PaymentID:  payment.ID.String(),
+		Method:     method,
 	}, nil
 }
 
+// checkoutMethod normalises a deposit method; empty means Stripe.
+func checkoutMethod(method string) (string, error) {
+	switch method {
+	case "":
+		return PaymentMethodStripe, nil
+	case PaymentMethodStripe, PaymentMethodAdyen:
+		return method, nil
+	}
+	return "", domain.ErrValidation("unsupported payment method: " + method)
+}
+
+// createCheckout opens a hosted checkout session with the method's PSP for a
+// payment about to be recorded as paymentID.
+func (s *PaymentService) createCheckout(ctx context.Context, method string, paymentID, playerID uuid.UUID, amount int64, currency, successURL, cancelURL string) (sessionID, sessionURL string, err error) {
+	switch method {
+	case PaymentMethodAdyen:
+		session, err := s.adyen.CreateCheckoutSession(ctx, amount, currency, paymentID.String(), playerID.String(), successURL)
+		if err != nil {
+			return "", "", domain.ErrInternal("create checkout session", err)
+		}
+		return session.ID, session.URL, nil
+	default:
+		session, err := s.stripe.CreateCheckoutSession(ctx, amount, currency, playerID.String(), successURL, cancelURL)
+		if err != nil {
+			return "", "", domain.ErrInternal("create checkout session", err)
+		}
+		return session.ID, session.URL, nil
+	}
+}
+
 // HandleStripeWebhook processes a verified Stripe webhook event.
 func (s *PaymentService) HandleStripeWebhook(ctx context.Context, payload []byte, sigHeader string) error {
 	event, err := s.stripe.VerifyWebhookSignature(payload, sigHeader)
@@ -116,6 +211,8 @@ func (s *PaymentService) HandleStripeWebhook(ctx context.Context, payload []byte
 	switch event.Type {
 	case "checkout.session.completed":
 		return s.handleCheckoutCompleted(ctx, event)
+	case "refund.created", "refund.updated", "refund.failed":
+		return s.handleRefundUpdated(ctx, event)
 	default:
 		s.logger.Info("unhandled stripe event type", "type", event.Type)
 		return nil
@@ -138,55 +235,183 @@ func (s *PaymentService) handleCheckoutCompleted(ctx context.Context, event *pro
 		return nil // Don't error — Stripe may retry
 	}
 
-	// Idempotency: already completed
-	if payment.Status == domain.PaymentStatusCompleted {
+	return s.completeDeposit(ctx, payment, sessionData.PaymentIntent, event.ID)
+}
+
+// HandleAdyenWebhook processes an Adyen standard webhook. Successful
+// AUTHORISATION items credit the deposit named by merchantReference; refused
+// ones fail it. Other event codes are acknowledged and ignored.
+func (s *PaymentService) HandleAdyenWebhook(ctx context.Context, payload []byte) error {
+	items, err := s.adyen.VerifyNotification(payload)
+	if err != nil {
+		return domain.ErrUnauthorized(fmt.Sprintf("webhook verification failed: %v", err))
+	}
+
+	for _, item := range items {
+		if item.EventCode != "AUTHORISATION" {
+			s.logger.Info("unhandled adyen event code", "event_code", item.EventCode)
+			continue
+		}
+		if err := s.handleAdyenAuthorisation(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PaymentService) handleAdyenAuthorisation(ctx context.Context, item provider.AdyenNotificationItem) error {
+	paymentID, err := uuid.Parse(item.MerchantReference)
+	if err != nil {
+		s.logger.Warn("adyen notification with unknown reference", "reference", item.MerchantReference)
+		return nil
+	}
+	payment, err := s.payments.FindByID(ctx, s.pool, paymentID)
+	if err != nil {
+		return domain.ErrInternal("find payment", err)
+	}
+	if payment == nil || payment.Provider == nil || *payment.Provider != PaymentMethodAdyen {
+		s.logger.Warn("payment not found for adyen reference", "reference", item.MerchantReference)
+		return nil // Acknowledge — Adyen retries unaccepted notifications
+	}
+	if item.Amount.Value != payment.Amount || !strings.EqualFold(item.Amount.Currency, payment.Currency) {
+		s.logger.Error("adyen amount mismatch", "payment_id", payment.ID,
+			"expected", payment.Amount, "got", item.Amount.Value, "currency", item.Amount.Currency)
+		return nil
+	}
+
+	if !item.Succeeded() {
+		if payment.Status != domain.PaymentStatusPending {
+			return nil
+		}
+		tx, err := s.pool.Begin(ctx)
+		if err != nil {
+			return domain.ErrInternal("begin tx", err)
+		}
+		defer tx.Rollback(ctx)
+		if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusFailed, &item.PspReference, nil); err != nil {
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return domain.ErrInternal("commit tx", err)
+		}
+		s.recordEvent(ctx, payment.ID, domain.PaymentStatusFailed, "adyen authorisation refused: "+item.Reason, nil)
+		return nil
+	}
+
+	return s.completeDeposit(ctx, payment, item.PspReference, item.PspReference)
+}
+
+// completeDeposit credits a captured deposit, or holds it for review when
+// the player is flagged. Replayed webhooks for a settled payment are no-ops.
+func (s *PaymentService) completeDeposit(ctx context.Context, payment *domain.Payment, providerPaymentID, eventID string) error {
+	// Idempotency: already completed or held for review
+	if payment.Status == domain.PaymentStatusCompleted || payment.Status == domain.PaymentStatusOnHold {
 		return nil
 	}
 
-	// Credit the player's wallet via the ledger engine
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return domain.ErrInternal("begin tx", err)
 	}
 	defer tx.Rollback(ctx)
 
-	extTxID := fmt.Sprintf("stripe_%s", event.ID)
+	// Fraud-flagged players: hold the funds for admin review instead of crediting.
+	held, err := s.holdDepositIfFlagged(ctx, tx, payment, providerPaymentID, eventID)
+	if err != nil {
+		return err
+	}
+	if held {
+		if err := tx.Commit(ctx); err != nil {
+			return domain.ErrInternal("commit tx", err)
+		}
+		s.recordEvent(ctx, payment.ID, domain.PaymentStatusOnHold, "deposit held for review", nil)
+		s.logger.Info("deposit held for review", "payment_id", payment.ID, "player_id", payment.PlayerID)
+		return nil
+	}
+
+	// Credit the player's wallet via the ledger engine
+	if _, err := s.creditDeposit(ctx, tx, payment, providerPaymentID, eventID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+
+	s.recordEvent(ctx, payment.ID, domain.PaymentStatusCompleted, "deposit credited via "+paymentProvider(payment), nil)
+	s.logger.Info("deposit completed", "payment_id", payment.ID, "amount", payment.Amount, "player_id", payment.PlayerID)
+	return nil
+}
+
+// creditDeposit posts the ledger deposit for a captured PSP payment, marks
+// the payment completed and applies any deposit match the player opted in
+// to. Coin package purchases credit the coin
+// wallets instead, and store orders grant the item bought.
+func (s *PaymentService) creditDeposit(ctx context.Context, tx pgx.Tx, payment *domain.Payment, providerPaymentID, eventID string) (*domain.CommandResult, error) {
+	switch payment.Type {
+	case domain.PaymentTypePurchase:
+		return s.creditPurchase(ctx, tx, payment, providerPaymentID, eventID)
+	case domain.PaymentTypeStore:
+		return s.creditStoreOrder(ctx, tx, payment, providerPaymentID, eventID)
+	}
+	providerName := paymentProvider(payment)
+	extTxID := fmt.Sprintf("%s_%s", providerName, eventID)
+	meta, _ := json.Marshal(map[string]string{"provider": providerName, "event_id": eventID})
 	result, err := s.engine.ExecuteDeposit(ctx, tx, domain.DepositParams{
 		PlayerID:              payment.PlayerID,
 		Amount:                payment.Amount,
 		ExternalTransactionID: extTxID,
-		ManufacturerID:        "stripe",
+		ManufacturerID:        providerName,
 		SubTransactionID:      "1",
-		Metadata:              json.RawMessage(`{"provider":"stripe","event_id":"` + event.ID + `"}`),
+		Metadata:              meta,
 	})
 	if err != nil {
-		return domain.ErrInternal("execute deposit", err)
+		return nil, domain.ErrInternal("execute deposit", err)
 	}
 
 	// Update payment status
-	ppID := sessionData.PaymentIntent
-	if err := s.payments.UpdateStatus(ctx, tx, payment.ID, domain.PaymentStatusCompleted, &ppID, &result.Transaction.ID); err != nil {
-		return domain.ErrInternal("update payment status", err)
+	if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusCompleted, &providerPaymentID, &result.Transaction.ID); err != nil {
+		return nil, err
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return domain.ErrInternal("commit tx", err)
+	// Match the deposit in the same transaction, so a failed match fails
+	// the credit and the PSP retries both.
+	if s.bonuses != nil {
+		if _, err := s.bonuses.ApplyDepositMatch(ctx, tx, payment); err != nil {
+			return nil, err
+		}
 	}
+	return result, nil
+}
 
-	s.recordEvent(ctx, payment.ID, domain.PaymentStatusCompleted, "deposit credited via stripe", nil)
-	s.logger.Info("deposit completed", "payment_id", payment.ID, "amount", payment.Amount, "player_id", payment.PlayerID)
-	return nil
+// paymentProvider returns the PSP a payment went through. Payments recorded
+// before provider tracking are Stripe.
+func paymentProvider(payment *domain.Payment) string {
+	if payment.Provider == nil {
+		return PaymentMethodStripe
+	}
+	return *payment.Provider
 }
 
-// RequestWithdrawal initiates a withdrawal (reserve balance, create pending withdrawal).
-func (s *PaymentService) RequestWithdrawal(ctx context.Context, playerID uuid.UUID, amount int64) error {
-	// Execute withdraw command (reserves balance)
+// RequestWithdrawal initiates a withdrawal (reserve balance, create pending
+// withdrawal) to the saved method paymentMethodID, which the closed-loop
+// policy may require.
+func (s *PaymentService) RequestWithdrawal(ctx context.Context, playerID uuid.UUID, amount int64, paymentMethodID *uuid.UUID) error {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return domain.ErrInternal("begin tx", err)
 	}
 	defer tx.Rollback(ctx)
 
+	if err := s.checkWithdrawalKYC(ctx, tx, playerID, amount); err != nil {
+		return err
+	}
+	if err := s.checkWithdrawalDestination(ctx, tx, playerID, paymentMethodID); err != nil {
+		return err
+	}
+
+	// Execute withdraw command (reserves balance)
+
 	extTxID := fmt.Sprintf("wd_%s", uuid.New().String()[:8])
 	_, err = s.engine.ExecuteWithdraw(ctx, tx, domain.WithdrawParams{
 		PlayerID:              playerID,
@@ -205,11 +430,19 @@ func (s *PaymentService) RequestWithdrawal(ctx context.Context, playerID uuid.UU
 		Amount:                amount,
 		Currency:              "EUR",
 		Status:                domain.PaymentStatusPending,
+		PaymentMethodID:       paymentMethodID,
 		ExternalTransactionID: &extTxID,
 	}
 	if err := s.payments.Create(ctx, tx, payment); err != nil {
 		return domain.ErrInternal("record withdrawal", err)
 	}
+	if err := s.publishPaymentStatus(ctx, tx, payment, domain.PaymentStatusPending); err != nil {
+		return err
+	}
+
+	if err := s.assessWithdrawal(ctx, tx, payment.ID, playerID, amount); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return domain.ErrInternal("commit tx", err)
@@ -219,6 +452,24 @@ func (s *PaymentService) RequestWithdrawal(ctx context.Context, playerID uuid.UU
 	return nil
 }
 
+// checkWithdrawalKYC blocks withdrawals above the KYC threshold until the
+// player is verified.
+func (s *PaymentService) checkWithdrawalKYC(ctx context.Context, db repository.DBTX, playerID uuid.UUID, amount int64) error {
+	if !policy.WithdrawalNeedsKYC(s.kycThreshold, amount, false) {
+		return nil
+	}
+	var verified bool
+	err := db.QueryRow(ctx, `
+		SELECT COALESCE(verified, false) FROM player_profiles WHERE player_id = $1`, playerID).Scan(&verified)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrInternal("check kyc status", err)
+	}
+	if policy.WithdrawalNeedsKYC(s.kycThreshold, amount, verified) {
+		return domain.ErrKYCRequired(s.kycThreshold)
+	}
+	return nil
+}
+
 // ListPayments returns a player's payment history.
 func (s *PaymentService) ListPayments(ctx context.Context, playerID uuid.UUID) ([]domain.Payment, error) {
 	payments, err := s.payments.ListByPlayer(ctx, s.pool, playerID, 50)
@@ -228,6 +479,23 @@ func (s *PaymentService) ListPayments(ctx context.Context, playerID uuid.UUID) (
 	return payments, nil
 }
 
+// updatePaymentStatus moves a payment to status and publishes the change to
+// the outbox in the same transaction.
+func (s *PaymentService) updatePaymentStatus(ctx context.Context, db repository.DBTX, payment *domain.Payment, status domain.PaymentStatus, providerPaymentID *string, transactionID *uuid.UUID) error {
+	if err := s.payments.UpdateStatus(ctx, db, payment.ID, status, providerPaymentID, transactionID); err != nil {
+		return domain.ErrInternal("update payment status", err)
+	}
+	return s.publishPaymentStatus(ctx, db, payment, status)
+}
+
+// publishPaymentStatus writes a payment status event to the outbox.
+func (s *PaymentService) publishPaymentStatus(ctx context.Context, db repository.DBTX, payment *domain.Payment, status domain.PaymentStatus) error {
+	if err := s.outbox.Insert(ctx, db, domain.NewPaymentStatusChangedEvent(*payment, status)); err != nil {
+		return domain.ErrInternal("insert outbox event", err)
+	}
+	return nil
+}
+
 func (s *PaymentService) recordEvent(ctx context.Context, paymentID uuid.UUID, status domain.PaymentStatus, message string, rawData json.RawMessage) {
 	event := &domain.PaymentEvent{
 		PaymentID: paymentID,
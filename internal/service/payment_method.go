@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const paymentMethodColumns = `id, player_id, name, type, provider, provider_ref, brand, last4, country,
+	active, verified, verified_by, verified_at, created_at, updated_at`
+
+func scanPaymentMethod(row pgx.Row) (*domain.PaymentMethod, error) {
+	var m domain.PaymentMethod
+	if err := row.Scan(&m.ID, &m.PlayerID, &m.Label, &m.Type, &m.Provider, &m.ProviderRef, &m.Brand, &m.Last4,
+		&m.Country, &m.Active, &m.Verified, &m.VerifiedBy, &m.VerifiedAt, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// AddPaymentMethod saves a card or bank account the client tokenized with
+// Stripe. Only the display details are copied from Stripe; new methods start
+// unverified.
+func (s *PaymentService) AddPaymentMethod(ctx context.Context, playerID uuid.UUID, providerRef, label string) (*domain.PaymentMethod, error) {
+	providerRef = strings.TrimSpace(providerRef)
+	if !strings.HasPrefix(providerRef, "pm_") {
+		return nil, domain.ErrValidation("provider_ref must be a Stripe payment method id")
+	}
+
+	pm, err := s.stripe.GetPaymentMethod(ctx, providerRef)
+	if err != nil {
+		return nil, domain.ErrInternal("retrieve payment method", err)
+	}
+	brand, last4, country := pm.Display()
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = strings.TrimSpace(brand + " " + last4)
+	}
+
+	m, err := scanPaymentMethod(s.pool.QueryRow(ctx, `
+		INSERT INTO payment_methods (player_id, name, type, provider, provider_ref, brand, last4, country)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''))
+		ON CONFLICT (player_id, provider, provider_ref) WHERE player_id IS NOT NULL DO NOTHING
+		RETURNING `+paymentMethodColumns,
+		playerID, label, pm.Type, PaymentMethodStripe, providerRef, brand, last4, country))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrConflict("payment method already saved")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("save payment method", err)
+	}
+	s.logger.Info("payment method saved", "player_id", playerID, "payment_method_id", m.ID, "type", m.Type)
+	return m, nil
+}
+
+// ListPaymentMethods returns a player's active saved methods.
+func (s *PaymentService) ListPaymentMethods(ctx context.Context, playerID uuid.UUID) ([]domain.PaymentMethod, error) {
+	return s.listPaymentMethods(ctx, playerID, true)
+}
+
+// ListPlayerPaymentMethods returns all of a player's saved methods,
+// including removed ones, for admin review.
+func (s *PaymentService) ListPlayerPaymentMethods(ctx context.Context, playerID uuid.UUID) ([]domain.PaymentMethod, error) {
+	return s.listPaymentMethods(ctx, playerID, false)
+}
+
+func (s *PaymentService) listPaymentMethods(ctx context.Context, playerID uuid.UUID, activeOnly bool) ([]domain.PaymentMethod, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+paymentMethodColumns+` FROM payment_methods
+		WHERE player_id = $1 AND ($2 = false OR active)
+		ORDER BY created_at DESC`, playerID, activeOnly)
+	if err != nil {
+		return nil, domain.ErrInternal("list payment methods", err)
+	}
+	defer rows.Close()
+
+	methods := []domain.PaymentMethod{}
+	for rows.Next() {
+		m, err := scanPaymentMethod(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan payment method", err)
+		}
+		methods = append(methods, *m)
+	}
+	return methods, rows.Err()
+}
+
+// RenamePaymentMethod changes the label of one of the player's methods.
+func (s *PaymentService) RenamePaymentMethod(ctx context.Context, playerID, id uuid.UUID, label string) (*domain.PaymentMethod, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil, domain.ErrValidation("label is required")
+	}
+	m, err := scanPaymentMethod(s.pool.QueryRow(ctx, `
+		UPDATE payment_methods SET name = $3, updated_at = now()
+		WHERE id = $1 AND player_id = $2 AND active
+		RETURNING `+paymentMethodColumns, id, playerID, label))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("payment method", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("rename payment method", err)
+	}
+	return m, nil
+}
+
+// RemovePaymentMethod deactivates one of the player's methods. The row is
+// kept because payments reference it.
+func (s *PaymentService) RemovePaymentMethod(ctx context.Context, playerID, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE payment_methods SET active = false, updated_at = now()
+		WHERE id = $1 AND player_id = $2 AND active`, id, playerID)
+	if err != nil {
+		return domain.ErrInternal("remove payment method", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("payment method", id.String())
+	}
+	return nil
+}
+
+// VerifyPaymentMethod marks a saved method as verified by an admin, for
+// example after checking a bank statement. Verifying twice is a no-op.
+func (s *PaymentService) VerifyPaymentMethod(ctx context.Context, id, adminID uuid.UUID) (*domain.PaymentMethod, error) {
+	m, err := scanPaymentMethod(s.pool.QueryRow(ctx, `
+		UPDATE payment_methods
+		SET verified = true,
+		    verified_by = CASE WHEN verified THEN verified_by ELSE $2 END,
+		    verified_at = COALESCE(verified_at, now()),
+		    updated_at = now()
+		WHERE id = $1 AND player_id IS NOT NULL
+		RETURNING `+paymentMethodColumns, id, adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("payment method", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("verify payment method", err)
+	}
+	s.logger.Info("payment method verified", "payment_method_id", id, "admin_id", adminID)
+	return m, nil
+}
+
+// checkDepositMethod ensures a deposit's saved method belongs to the player
+// and is still active.
+func (s *PaymentService) checkDepositMethod(ctx context.Context, playerID uuid.UUID, id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	var active bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT active FROM payment_methods WHERE id = $1 AND player_id = $2`, *id, playerID).Scan(&active)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("payment method", id.String())
+	}
+	if err != nil {
+		return domain.ErrInternal("find payment method", err)
+	}
+	if !active {
+		return domain.ErrValidation("payment method has been removed")
+	}
+	return nil
+}
+
+// checkWithdrawalDestination applies the closed-loop policy to a withdrawal
+// targeting the saved method id. The method row is locked so it cannot be
+// removed while the withdrawal is recorded.
+func (s *PaymentService) checkWithdrawalDestination(ctx context.Context, db repository.DBTX, playerID uuid.UUID, id *uuid.UUID) error {
+	var dest *policy.WithdrawalDestination
+	if id != nil {
+		dest = &policy.WithdrawalDestination{}
+		err := db.QueryRow(ctx, `
+			SELECT pm.active, pm.verified,
+			       (SELECT count(*) FROM payments p
+			        WHERE p.payment_method_id = pm.id AND p.player_id = pm.player_id
+			          AND p.type = 'deposit' AND p.status = 'completed')
+			FROM payment_methods pm
+			WHERE pm.id = $1 AND pm.player_id = $2
+			FOR UPDATE OF pm`, *id, playerID).Scan(&dest.Active, &dest.Verified, &dest.CompletedDeposits)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound("payment method", id.String())
+		}
+		if err != nil {
+			return domain.ErrInternal("find payment method", err)
+		}
+	}
+	if reason := policy.CheckWithdrawalDestination(s.closedLoop, dest); reason != "" {
+		return domain.ErrWithdrawalDestinationRejected(reason)
+	}
+	return nil
+}
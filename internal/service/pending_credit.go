@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const pendingCreditColumns = `id, payment_id, player_id, amount::bigint, currency, provider,
+	provider_payment_id, provider_event_id, status, note, reviewed_by, reviewed_at,
+	transaction_id, provider_refund_id, created_at`
+
+// SetDepositReview turns manual review before credit on or off for a player.
+func (s *PaymentService) SetDepositReview(ctx context.Context, playerID uuid.UUID, required bool) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE player_profiles SET deposit_review_required = $2 WHERE player_id = $1`, playerID, required)
+	if err != nil {
+		return domain.ErrInternal("set deposit review", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("player", playerID.String())
+	}
+	return nil
+}
+
+// holdDepositIfFlagged queues a captured deposit in pending_credits when the
+// player requires deposit review. It reports whether the deposit was held.
+func (s *PaymentService) holdDepositIfFlagged(ctx context.Context, tx pgx.Tx, payment *domain.Payment, providerPaymentID, eventID string) (bool, error) {
+	var required bool
+	err := tx.QueryRow(ctx,
+		`SELECT deposit_review_required FROM player_profiles WHERE player_id = $1`, payment.PlayerID,
+	).Scan(&required)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, domain.ErrInternal("check deposit review", err)
+	}
+	if !required {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO pending_credits (payment_id, player_id, amount, currency, provider,
+			provider_payment_id, provider_event_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (payment_id) DO NOTHING`,
+		payment.ID, payment.PlayerID, payment.Amount, payment.Currency, paymentProvider(payment),
+		providerPaymentID, eventID)
+	if err != nil {
+		return false, domain.ErrInternal("queue pending credit", err)
+	}
+
+	if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusOnHold, &providerPaymentID, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListPendingCredits returns held deposits, oldest first. status defaults to pending.
+func (s *PaymentService) ListPendingCredits(ctx context.Context, status string) ([]domain.PendingCredit, error) {
+	if status == "" {
+		status = string(domain.PendingCreditPending)
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+pendingCreditColumns+` FROM pending_credits
+		WHERE status = $1 ORDER BY created_at LIMIT 200`, status)
+	if err != nil {
+		return nil, domain.ErrInternal("list pending credits", err)
+	}
+	defer rows.Close()
+
+	credits := []domain.PendingCredit{}
+	for rows.Next() {
+		pc, err := scanPendingCredit(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan pending credit", err)
+		}
+		credits = append(credits, *pc)
+	}
+	return credits, rows.Err()
+}
+
+// ApprovePendingCredit credits a held deposit to the player's wallet.
+func (s *PaymentService) ApprovePendingCredit(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.PendingCredit, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	pc, err := s.lockPendingCredit(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.payments.FindByID(ctx, tx, pc.PaymentID)
+	if err != nil {
+		return nil, domain.ErrInternal("find payment", err)
+	}
+	if payment == nil {
+		return nil, domain.ErrNotFound("payment", pc.PaymentID.String())
+	}
+
+	providerPaymentID := ""
+	if pc.ProviderPaymentID != nil {
+		providerPaymentID = *pc.ProviderPaymentID
+	}
+	result, err := s.creditDeposit(ctx, tx, payment, providerPaymentID, pc.ProviderEventID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE payments SET approved_by = $2, approved_at = now() WHERE id = $1`, payment.ID, adminID); err != nil {
+		return nil, domain.ErrInternal("record approval", err)
+	}
+
+	// Store orders for non-coin items grant without a ledger entry
+	var transactionID *uuid.UUID
+	if result != nil {
+		transactionID = &result.Transaction.ID
+	}
+	if err := s.reviewPendingCredit(ctx, tx, pc, domain.PendingCreditApproved, adminID, note, transactionID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.recordEvent(ctx, payment.ID, domain.PaymentStatusCompleted, "held deposit approved and credited", nil)
+	return pc, nil
+}
+
+// RejectPendingCredit rejects a held deposit and returns the funds through
+// the PSP. The ledger is never touched.
+func (s *PaymentService) RejectPendingCredit(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.PendingCredit, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	pc, err := s.lockPendingCredit(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	payment, err := s.payments.FindByID(ctx, tx, pc.PaymentID)
+	if err != nil {
+		return nil, domain.ErrInternal("find payment", err)
+	}
+	if payment == nil {
+		return nil, domain.ErrNotFound("payment", pc.PaymentID.String())
+	}
+	if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusRejected, pc.ProviderPaymentID, nil); err != nil {
+		return nil, err
+	}
+	if err := s.reviewPendingCredit(ctx, tx, pc, domain.PendingCreditRejected, adminID, note, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.recordEvent(ctx, pc.PaymentID, domain.PaymentStatusRejected, "held deposit rejected", nil)
+
+	if pc.ProviderPaymentID != nil && *pc.ProviderPaymentID != "" {
+		refund, err := s.stripe.CreateRefund(ctx, *pc.ProviderPaymentID, pc.Amount, "", "pending_credit_"+pc.ID.String())
+		if err != nil {
+			// The rejection stands; finance retries the refund from the PSP dashboard.
+			s.logger.Error("refund rejected deposit", "error", err, "pending_credit_id", pc.ID)
+			return pc, nil
+		}
+		if _, err := s.pool.Exec(ctx,
+			`UPDATE pending_credits SET provider_refund_id = $2 WHERE id = $1`, pc.ID, refund.ID); err != nil {
+			s.logger.Error("store pending credit refund id", "error", err, "pending_credit_id", pc.ID)
+		}
+		pc.ProviderRefundID = &refund.ID
+	}
+	return pc, nil
+}
+
+func (s *PaymentService) reviewPendingCredit(ctx context.Context, tx pgx.Tx, pc *domain.PendingCredit, status domain.PendingCreditStatus, adminID uuid.UUID, note string, txID *uuid.UUID) error {
+	now := time.Now()
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE pending_credits
+		SET status = $2, reviewed_by = $3, reviewed_at = $4, note = $5, transaction_id = $6
+		WHERE id = $1`, pc.ID, status, adminID, now, notePtr, txID); err != nil {
+		return domain.ErrInternal("review pending credit", err)
+	}
+	pc.Status = status
+	pc.ReviewedBy = &adminID
+	pc.ReviewedAt = &now
+	pc.Note = notePtr
+	pc.TransactionID = txID
+	return nil
+}
+
+func (s *PaymentService) lockPendingCredit(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*domain.PendingCredit, error) {
+	pc, err := scanPendingCredit(tx.QueryRow(ctx,
+		`SELECT `+pendingCreditColumns+` FROM pending_credits WHERE id = $1 FOR UPDATE`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("pending credit", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get pending credit", err)
+	}
+	if pc.Status != domain.PendingCreditPending {
+		return nil, domain.ErrConflict("pending credit already " + string(pc.Status))
+	}
+	return pc, nil
+}
+
+func scanPendingCredit(row pgx.Row) (*domain.PendingCredit, error) {
+	var pc domain.PendingCredit
+	err := row.Scan(&pc.ID, &pc.PaymentID, &pc.PlayerID, &pc.Amount, &pc.Currency, &pc.Provider,
+		&pc.ProviderPaymentID, &pc.ProviderEventID, &pc.Status, &pc.Note, &pc.ReviewedBy,
+		&pc.ReviewedAt, &pc.TransactionID, &pc.ProviderRefundID, &pc.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &pc, nil
+}
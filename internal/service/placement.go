@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxPlayerPlacements caps the feed returned to a single client request.
+const maxPlayerPlacements = 20
+
+const placementColumns = `id, name, kind, title, body, image_url, deep_link, vertical, segment,
+	priority, starts_at, ends_at, schedule, active, created_at`
+
+// PlacementService schedules promotional placements and serves them per player.
+// Segment targeting needs the player's profiling consent, and their events
+// are only attributed to them with analytics consent.
+type PlacementService struct {
+	pool     *pgxpool.Pool
+	consents repository.ConsentRepository
+	logger   *slog.Logger
+}
+
+// NewPlacementService creates a PlacementService.
+func NewPlacementService(pool *pgxpool.Pool, consents repository.ConsentRepository, logger *slog.Logger) *PlacementService {
+	return &PlacementService{pool: pool, consents: consents, logger: logger}
+}
+
+// PlacementInput is the admin-editable part of a placement.
+type PlacementInput struct {
+	Name     string                   `json:"name"`
+	Kind     domain.PlacementKind     `json:"kind"`
+	Title    string                   `json:"title"`
+	Body     *string                  `json:"body,omitempty"`
+	ImageURL *string                  `json:"image_url,omitempty"`
+	DeepLink string                   `json:"deep_link"`
+	Vertical string                   `json:"vertical"`
+	Segment  string                   `json:"segment"`
+	Priority int                      `json:"priority"`
+	StartsAt *time.Time               `json:"starts_at,omitempty"`
+	EndsAt   *time.Time               `json:"ends_at,omitempty"`
+	Schedule domain.PlacementSchedule `json:"schedule"`
+}
+
+func (in *PlacementInput) normalize() error {
+	in.Name = strings.TrimSpace(in.Name)
+	in.Title = strings.TrimSpace(in.Title)
+	in.DeepLink = strings.TrimSpace(in.DeepLink)
+	if in.Vertical == "" {
+		in.Vertical = policy.VerticalAll
+	}
+	if in.Segment == "" {
+		in.Segment = policy.SegmentAll
+	}
+	if in.StartsAt == nil {
+		now := time.Now()
+		in.StartsAt = &now
+	}
+
+	switch {
+	case in.Name == "" || in.Title == "":
+		return domain.ErrValidation("name and title are required")
+	case in.Kind != domain.PlacementBanner && in.Kind != domain.PlacementCard:
+		return domain.ErrValidation("kind must be banner or card")
+	case in.DeepLink == "":
+		return domain.ErrValidation("deep_link is required")
+	case !policy.ValidVertical(in.Vertical):
+		return domain.ErrValidation(fmt.Sprintf("unknown vertical: %s", in.Vertical))
+	case !policy.ValidSegment(in.Segment):
+		return domain.ErrValidation(fmt.Sprintf("unknown segment: %s", in.Segment))
+	case in.EndsAt != nil && !in.EndsAt.After(*in.StartsAt):
+		return domain.ErrValidation("ends_at must be after starts_at")
+	}
+	if err := policy.ValidateSchedule(in.Schedule); err != nil {
+		return domain.ErrValidation(err.Error())
+	}
+	return nil
+}
+
+// ListForPlayer returns the live placements targeting the player's segments,
+// highest priority first, dropping those their schedule rules out right now.
+// Without profiling consent only placements for every player are served. An
+// empty vertical returns every vertical.
+func (s *PlacementService) ListForPlayer(ctx context.Context, playerID uuid.UUID, vertical string) ([]domain.Placement, error) {
+	if vertical != "" && !policy.ValidVertical(vertical) {
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown vertical: %s", vertical))
+	}
+
+	segments := []string{policy.SegmentAll}
+	if consented(ctx, s.consents, s.pool, playerID, domain.ConsentProfiling, s.logger) {
+		facts, err := s.segmentFacts(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+		segments = policy.PlayerSegments(*facts, time.Now())
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+placementColumns+`
+		FROM placements
+		WHERE active AND starts_at <= now() AND (ends_at IS NULL OR ends_at > now())
+		  AND segment = ANY($1)
+		  AND ($2 = '' OR vertical = 'all' OR vertical = $2)
+		ORDER BY priority DESC, starts_at DESC`, segments, vertical)
+	if err != nil {
+		return nil, domain.ErrInternal("list placements", err)
+	}
+	live, err := collectPlacements(rows)
+	if err != nil {
+		return nil, err
+	}
+	return s.scheduled(ctx, live, time.Now())
+}
+
+// scheduled keeps the placements whose schedule allows serving them at now,
+// up to maxPlayerPlacements. Maintenance windows and game days are looked up
+// only when some schedule asks for them.
+func (s *PlacementService) scheduled(ctx context.Context, placements []domain.Placement, now time.Time) ([]domain.Placement, error) {
+	var maintenance map[string]bool
+	gameDays := make(map[string]bool)
+	out := []domain.Placement{}
+	for _, p := range placements {
+		if len(out) == maxPlayerPlacements {
+			break
+		}
+		var facts policy.ScheduleFacts
+		if p.Schedule.HideDuringMaintenance {
+			if maintenance == nil {
+				var err error
+				if maintenance, err = s.maintenanceVerticals(ctx, now); err != nil {
+					return nil, err
+				}
+			}
+			facts.Maintenance = maintenance[policy.VerticalAll] || maintenance[p.Vertical]
+		}
+		if rule := p.Schedule.GameDay; rule != nil {
+			start, end := policy.ScheduleDay(p.Schedule, now)
+			key := rule.Sport + "|" + rule.League + "|" + start.UTC().Format(time.RFC3339)
+			gameDay, ok := gameDays[key]
+			if !ok {
+				if err := s.pool.QueryRow(ctx, `
+					SELECT EXISTS (
+						SELECT 1 FROM sports_events e JOIN sports sp ON sp.id = e.sport_id
+						WHERE sp.key = $1 AND ($2 = '' OR e.league = $2)
+						  AND e.start_time >= $3 AND e.start_time < $4 AND e.status <> 'cancelled')`,
+					rule.Sport, rule.League, start, end).Scan(&gameDay); err != nil {
+					return nil, domain.ErrInternal("check game day", err)
+				}
+				gameDays[key] = gameDay
+			}
+			facts.GameDay = gameDay
+		}
+		if policy.ScheduleAllows(p.Schedule, now, facts) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// maintenanceVerticals returns the verticals under maintenance at now.
+func (s *PlacementService) maintenanceVerticals(ctx context.Context, now time.Time) (map[string]bool, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT vertical FROM maintenance_windows WHERE starts_at <= $1 AND ends_at > $1`, now)
+	if err != nil {
+		return nil, domain.ErrInternal("list maintenance windows", err)
+	}
+	defer rows.Close()
+
+	verticals := make(map[string]bool)
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, domain.ErrInternal("scan maintenance window", err)
+		}
+		verticals[v] = true
+	}
+	return verticals, rows.Err()
+}
+
+// segmentFactsQuery selects each player's id and policy.SegmentFacts. Callers
+// append their WHERE clause and group by p.id.
+const segmentFactsQuery = `
+	SELECT p.id, p.created_at,
+	       COUNT(t.id) FILTER (WHERE t.type = 'deposit'),
+	       COALESCE(SUM(t.amount) FILTER (WHERE t.type = 'deposit'), 0)::bigint,
+	       MAX(t.created_at) FILTER (WHERE t.type = 'bet')
+	FROM v2_players p
+	LEFT JOIN player_profiles pp ON pp.player_id = p.id
+	LEFT JOIN v2_transactions t ON t.player_id = p.id AND t.type IN ('deposit', 'bet')`
+
+func (s *PlacementService) segmentFacts(ctx context.Context, playerID uuid.UUID) (*policy.SegmentFacts, error) {
+	var id uuid.UUID
+	var f policy.SegmentFacts
+	err := s.pool.QueryRow(ctx, segmentFactsQuery+`
+		WHERE p.id = $1
+		GROUP BY p.id`, playerID).Scan(&id, &f.RegisteredAt, &f.DepositCount, &f.LifetimeDeposits, &f.LastBetAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("load segment facts", err)
+	}
+	return &f, nil
+}
+
+// activePlayersInSegment returns the active players in segment. Players are
+// only segmented with their profiling consent, so for any segment but
+// policy.SegmentAll only consenting players are returned.
+func activePlayersInSegment(ctx context.Context, db repository.DBTX, segment string, now time.Time) ([]uuid.UUID, error) {
+	where := `
+		WHERE COALESCE(pp.account_status, 'active') = 'active'`
+	if segment != policy.SegmentAll {
+		where += `
+		  AND EXISTS (
+		      SELECT 1 FROM (
+		          SELECT DISTINCT ON (c.channel) c.granted FROM consents c
+		          WHERE c.player_id = p.id AND c.purpose = '` + domain.ConsentProfiling + `'
+		          ORDER BY c.channel, c.id DESC
+		      ) latest WHERE latest.granted)`
+	}
+	rows, err := db.Query(ctx, segmentFactsQuery+where+`
+		GROUP BY p.id`)
+	if err != nil {
+		return nil, fmt.Errorf("query segment facts: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		var f policy.SegmentFacts
+		if err := rows.Scan(&id, &f.RegisteredAt, &f.DepositCount, &f.LifetimeDeposits, &f.LastBetAt); err != nil {
+			return nil, fmt.Errorf("scan segment facts: %w", err)
+		}
+		if slices.Contains(policy.PlayerSegments(f, now), segment) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, rows.Err()
+}
+
+// Track records an impression or click against a placement. Without
+// analytics consent the event is recorded anonymously.
+func (s *PlacementService) Track(ctx context.Context, playerID, placementID uuid.UUID, eventType string) error {
+	var player *uuid.UUID
+	if consented(ctx, s.consents, s.pool, playerID, domain.ConsentAnalytics, s.logger) {
+		player = &playerID
+	}
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO placement_events (placement_id, player_id, type)
+		SELECT id, $2, $3 FROM placements WHERE id = $1`,
+		placementID, player, eventType)
+	if err != nil {
+		return domain.ErrInternal("record placement event", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("placement", placementID.String())
+	}
+	return nil
+}
+
+// List returns every placement with its engagement totals, newest first.
+func (s *PlacementService) List(ctx context.Context) ([]domain.PlacementStats, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT p.id, p.name, p.kind, p.title, p.body, p.image_url, p.deep_link, p.vertical, p.segment,
+		       p.priority, p.starts_at, p.ends_at, p.schedule, p.active, p.created_at,
+		       COUNT(e.id) FILTER (WHERE e.type = 'impression'),
+		       COUNT(e.id) FILTER (WHERE e.type = 'click'),
+		       COUNT(DISTINCT e.player_id) FILTER (WHERE e.type = 'impression')
+		FROM placements p
+		LEFT JOIN placement_events e ON e.placement_id = p.id
+		GROUP BY p.id
+		ORDER BY p.created_at DESC
+		LIMIT 100`)
+	if err != nil {
+		return nil, domain.ErrInternal("list placements", err)
+	}
+	defer rows.Close()
+
+	placements := []domain.PlacementStats{}
+	for rows.Next() {
+		var ps domain.PlacementStats
+		p := &ps.Placement
+		if err := rows.Scan(&p.ID, &p.Name, &p.Kind, &p.Title, &p.Body, &p.ImageURL, &p.DeepLink,
+			&p.Vertical, &p.Segment, &p.Priority, &p.StartsAt, &p.EndsAt, &p.Schedule, &p.Active, &p.CreatedAt,
+			&ps.Impressions, &ps.Clicks, &ps.UniqueViewers); err != nil {
+			return nil, domain.ErrInternal("scan placement", err)
+		}
+		placements = append(placements, ps)
+	}
+	return placements, rows.Err()
+}
+
+// Create schedules a new placement.
+func (s *PlacementService) Create(ctx context.Context, input PlacementInput, adminID uuid.UUID) (*domain.Placement, error) {
+	if err := input.normalize(); err != nil {
+		return nil, err
+	}
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO placements (name, kind, title, body, image_url, deep_link, vertical, segment,
+		                        priority, starts_at, ends_at, schedule, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING `+placementColumns,
+		input.Name, input.Kind, input.Title, input.Body, input.ImageURL, input.DeepLink,
+		input.Vertical, input.Segment, input.Priority, input.StartsAt, input.EndsAt, input.Schedule, adminID)
+	p, err := scanPlacement(row)
+	if err != nil {
+		return nil, domain.ErrInternal("create placement", err)
+	}
+	s.logger.Info("placement created", "placement_id", p.ID, "segment", p.Segment, "vertical", p.Vertical, "admin_id", adminID)
+	return p, nil
+}
+
+// Update replaces a placement's content, targeting and schedule.
+func (s *PlacementService) Update(ctx context.Context, id uuid.UUID, input PlacementInput) (*domain.Placement, error) {
+	if err := input.normalize(); err != nil {
+		return nil, err
+	}
+	row := s.pool.QueryRow(ctx, `
+		UPDATE placements
+		SET name = $2, kind = $3, title = $4, body = $5, image_url = $6, deep_link = $7,
+		    vertical = $8, segment = $9, priority = $10, starts_at = $11, ends_at = $12, schedule = $13,
+		    updated_at = now()
+		WHERE id = $1
+		RETURNING `+placementColumns,
+		id, input.Name, input.Kind, input.Title, input.Body, input.ImageURL, input.DeepLink,
+		input.Vertical, input.Segment, input.Priority, input.StartsAt, input.EndsAt, input.Schedule)
+	p, err := scanPlacement(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("placement", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("update placement", err)
+	}
+	return p, nil
+}
+
+// SetActive pauses or resumes a placement without touching its schedule.
+func (s *PlacementService) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE placements SET active = $2, updated_at = now() WHERE id = $1`, id, active)
+	if err != nil {
+		return domain.ErrInternal("update placement status", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("placement", id.String())
+	}
+	return nil
+}
+
+func scanPlacement(row pgx.Row) (*domain.Placement, error) {
+	var p domain.Placement
+	err := row.Scan(&p.ID, &p.Name, &p.Kind, &p.Title, &p.Body, &p.ImageURL, &p.DeepLink,
+		&p.Vertical, &p.Segment, &p.Priority, &p.StartsAt, &p.EndsAt, &p.Schedule, &p.Active, &p.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func collectPlacements(rows pgx.Rows) ([]domain.Placement, error) {
+	defer rows.Close()
+	placements := []domain.Placement{}
+	for rows.Next() {
+		p, err := scanPlacement(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan placement", err)
+		}
+		placements = append(placements, *p)
+	}
+	return placements, rows.Err()
+}
+
+// ─── Maintenance windows ────────────────────────────────────────────────────
+
+// MaintenanceWindowInput schedules a maintenance window.
+type MaintenanceWindowInput struct {
+	Vertical string    `json:"vertical"`
+	Reason   string    `json:"reason"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+const maintenanceWindowColumns = `id, vertical, reason, starts_at, ends_at, created_by, created_at`
+
+func scanMaintenanceWindow(row pgx.Row) (*domain.MaintenanceWindow, error) {
+	var m domain.MaintenanceWindow
+	if err := row.Scan(&m.ID, &m.Vertical, &m.Reason, &m.StartsAt, &m.EndsAt, &m.CreatedBy, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListMaintenanceWindows returns current and upcoming windows, soonest first.
+func (s *PlacementService) ListMaintenanceWindows(ctx context.Context) ([]domain.MaintenanceWindow, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+maintenanceWindowColumns+` FROM maintenance_windows
+		WHERE ends_at > now()
+		ORDER BY starts_at`)
+	if err != nil {
+		return nil, domain.ErrInternal("list maintenance windows", err)
+	}
+	defer rows.Close()
+
+	windows := []domain.MaintenanceWindow{}
+	for rows.Next() {
+		m, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan maintenance window", err)
+		}
+		windows = append(windows, *m)
+	}
+	return windows, rows.Err()
+}
+
+// CreateMaintenanceWindow schedules a window during which placements that
+// hide during maintenance are not served.
+func (s *PlacementService) CreateMaintenanceWindow(ctx context.Context, input MaintenanceWindowInput, adminID uuid.UUID) (*domain.MaintenanceWindow, error) {
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Vertical == "" {
+		input.Vertical = policy.VerticalAll
+	}
+	switch {
+	case !policy.ValidVertical(input.Vertical):
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown vertical: %s", input.Vertical))
+	case input.Reason == "":
+		return nil, domain.ErrValidation("reason is required")
+	case input.StartsAt.IsZero() || !input.EndsAt.After(input.StartsAt):
+		return nil, domain.ErrValidation("ends_at must be after starts_at")
+	}
+
+	m, err := scanMaintenanceWindow(s.pool.QueryRow(ctx, `
+		INSERT INTO maintenance_windows (vertical, reason, starts_at, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+maintenanceWindowColumns,
+		input.Vertical, input.Reason, input.StartsAt, input.EndsAt, adminID))
+	if err != nil {
+		return nil, domain.ErrInternal("create maintenance window", err)
+	}
+	s.logger.Info("maintenance window scheduled", "id", m.ID, "vertical", m.Vertical, "starts_at", m.StartsAt, "admin_id", adminID)
+	return m, nil
+}
+
+// DeleteMaintenanceWindow cancels a window.
+func (s *PlacementService) DeleteMaintenanceWindow(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM maintenance_windows WHERE id = $1`, id)
+	if err != nil {
+		return domain.ErrInternal("delete maintenance window", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("maintenance window", id.String())
+	}
+	return nil
+}
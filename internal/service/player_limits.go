@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PlayerLimitService manages players' self-set deposit and loss limits.
+// Limits are enforced by guard.CheckPlayerLimits in the deposit and bet
+// paths.
+type PlayerLimitService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewPlayerLimitService creates a PlayerLimitService.
+func NewPlayerLimitService(pool *pgxpool.Pool, logger *slog.Logger) *PlayerLimitService {
+	return &PlayerLimitService{pool: pool, logger: logger}
+}
+
+const playerLimitColumns = `id, player_id, type, period, limit_value::bigint, pending_value::bigint,
+	pending_effective_at, created_at, updated_at`
+
+func scanPlayerLimit(row pgx.Row) (*domain.PlayerLimit, error) {
+	var l domain.PlayerLimit
+	if err := row.Scan(&l.ID, &l.PlayerID, &l.Type, &l.Period, &l.Value, &l.PendingValue,
+		&l.PendingEffectiveAt, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// List returns the player's limits with usage in the current period.
+// Pending changes whose cooldown has passed are applied first.
+func (s *PlayerLimitService) List(ctx context.Context, playerID uuid.UUID) ([]domain.PlayerLimit, error) {
+	if err := s.applyDue(ctx, s.pool, playerID); err != nil {
+		return nil, err
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+playerLimitColumns+` FROM player_limits
+		WHERE player_id = $1 AND active AND type IN ('deposit', 'loss')
+		ORDER BY type, CASE period WHEN 'daily' THEN 1 WHEN 'weekly' THEN 2 ELSE 3 END`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list player limits", err)
+	}
+	defer rows.Close()
+
+	limits := []domain.PlayerLimit{}
+	for rows.Next() {
+		l, err := scanPlayerLimit(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan player limit", err)
+		}
+		limits = append(limits, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read player limits", err)
+	}
+
+	now := time.Now()
+	for i := range limits {
+		used, err := guard.PlayerLimitUsage(ctx, s.pool, playerID, limits[i].Type, policy.LimitPeriodStart(limits[i].Period, now))
+		if err != nil {
+			return nil, err
+		}
+		limits[i].Used = used
+	}
+	return limits, nil
+}
+
+// PlayerLimitInput sets one of the player's limits.
+type PlayerLimitInput struct {
+	Type   domain.PlayerLimitType `json:"type"`
+	Period domain.LimitPeriod     `json:"period"`
+	Value  int64                  `json:"value"` // cents
+}
+
+// Set creates or changes a limit. New limits and decreases apply at once and
+// cancel any pending increase; increases wait for the cooldown.
+func (s *PlayerLimitService) Set(ctx context.Context, playerID uuid.UUID, input PlayerLimitInput) (*domain.PlayerLimit, error) {
+	if !input.Type.Valid() {
+		return nil, domain.ErrValidation("type must be deposit or loss")
+	}
+	if !input.Period.Valid() {
+		return nil, domain.ErrValidation("period must be daily, weekly or monthly")
+	}
+	if input.Value <= 0 {
+		return nil, domain.ErrValidation("value must be positive")
+	}
+	return s.change(ctx, playerID, input.Type, input.Period, &input.Value)
+}
+
+// Remove lifts a limit once the cooldown has passed.
+func (s *PlayerLimitService) Remove(ctx context.Context, playerID uuid.UUID, limitType domain.PlayerLimitType, period domain.LimitPeriod) (*domain.PlayerLimit, error) {
+	if !limitType.Valid() || !period.Valid() {
+		return nil, domain.ErrNotFound("limit", string(period)+" "+string(limitType))
+	}
+	return s.change(ctx, playerID, limitType, period, nil)
+}
+
+func (s *PlayerLimitService) change(ctx context.Context, playerID uuid.UUID, limitType domain.PlayerLimitType, period domain.LimitPeriod, requested *int64) (*domain.PlayerLimit, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.applyDue(ctx, tx, playerID); err != nil {
+		return nil, err
+	}
+
+	existing, err := scanPlayerLimit(tx.QueryRow(ctx, `
+		SELECT `+playerLimitColumns+` FROM player_limits
+		WHERE player_id = $1 AND type = $2 AND period = $3 AND active
+		FOR UPDATE`, playerID, limitType, period))
+	if errors.Is(err, pgx.ErrNoRows) {
+		existing = nil
+	} else if err != nil {
+		return nil, domain.ErrInternal("find player limit", err)
+	}
+
+	var current *int64
+	if existing != nil {
+		current = &existing.Value
+	}
+	now := time.Now()
+	effectiveAt := policy.LimitChangeAt(current, requested, now)
+
+	var l *domain.PlayerLimit
+	switch {
+	case existing == nil && requested == nil:
+		return nil, domain.ErrNotFound("limit", string(period)+" "+string(limitType))
+	case existing == nil:
+		l, err = scanPlayerLimit(tx.QueryRow(ctx, `
+			INSERT INTO player_limits (player_id, type, period, limit_value)
+			VALUES ($1, $2, $3, $4)
+			RETURNING `+playerLimitColumns, playerID, limitType, period, *requested))
+	case !effectiveAt.After(now):
+		l, err = scanPlayerLimit(tx.QueryRow(ctx, `
+			UPDATE player_limits
+			SET limit_value = $2, pending_value = NULL, pending_effective_at = NULL, updated_at = now()
+			WHERE id = $1
+			RETURNING `+playerLimitColumns, existing.ID, *requested))
+	default:
+		var pending int64
+		if requested != nil {
+			pending = *requested
+		}
+		l, err = scanPlayerLimit(tx.QueryRow(ctx, `
+			UPDATE player_limits
+			SET pending_value = $2, pending_effective_at = $3, updated_at = now()
+			WHERE id = $1
+			RETURNING `+playerLimitColumns, existing.ID, pending, effectiveAt))
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("save player limit", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("player limit changed", "player_id", playerID, "type", limitType, "period", period,
+		"value", l.Value, "pending_value", l.PendingValue, "effective_at", effectiveAt)
+	return l, nil
+}
+
+// applyDue applies the player's pending changes whose cooldown has passed.
+// A pending value of 0 removes the limit.
+func (s *PlayerLimitService) applyDue(ctx context.Context, db repository.DBTX, playerID uuid.UUID) error {
+	if _, err := db.Exec(ctx, `
+		UPDATE player_limits
+		SET limit_value = pending_value,
+		    active = pending_value > 0,
+		    pending_value = NULL, pending_effective_at = NULL, updated_at = now()
+		WHERE player_id = $1 AND active AND pending_effective_at <= now()`, playerID); err != nil {
+		return domain.ErrInternal("apply pending player limits", err)
+	}
+	return nil
+}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PlayerStatusService owns every change to player_profiles.account_status.
+// Each transition is validated against the domain state machine, appended to
+// player_status_history and published as pam.player.status.changed. Changes
+// with a future effective date are stored as scheduled and applied by the
+// scheduler once due.
+type PlayerStatusService struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+	logger *slog.Logger
+}
+
+// NewPlayerStatusService creates a PlayerStatusService.
+func NewPlayerStatusService(pool *pgxpool.Pool, outbox repository.OutboxRepository, logger *slog.Logger) *PlayerStatusService {
+	return &PlayerStatusService{pool: pool, outbox: outbox, logger: logger}
+}
+
+// StatusTransitionInput requests an account status change.
+type StatusTransitionInput struct {
+	PlayerID    uuid.UUID  `json:"-"`
+	ToStatus    string     `json:"account_status"`
+	Reason      string     `json:"reason"`
+	EffectiveAt *time.Time `json:"effective_at,omitempty"` // nil or past = immediately
+	ActorType   string     `json:"-"`                      // admin, player, system
+	ActorID     *uuid.UUID `json:"-"`
+}
+
+// Transition validates and records a status change. Immediate changes update
+// account_status in the same transaction; future-dated ones are scheduled.
+func (s *PlayerStatusService) Transition(ctx context.Context, input StatusTransitionInput) (*domain.PlayerStatusChange, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := s.TransitionTx(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return change, nil
+}
+
+// TransitionTx is Transition inside a caller-owned transaction, so RG and
+// admin flows can change status atomically with their own writes.
+func (s *PlayerStatusService) TransitionTx(ctx context.Context, tx pgx.Tx, input StatusTransitionInput) (*domain.PlayerStatusChange, error) {
+	to, err := domain.ParseAccountStatus(input.ToStatus)
+	if err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	if input.Reason == "" {
+		return nil, domain.ErrValidation("reason is required")
+	}
+	if input.ActorType == "" {
+		input.ActorType = "system"
+	}
+
+	from, err := s.lockStatus(ctx, tx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if !from.CanTransitionTo(to) {
+		return nil, domain.ErrInvalidStatusTransition(from, to)
+	}
+
+	now := time.Now()
+	change := &domain.PlayerStatusChange{
+		PlayerID:    input.PlayerID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Reason:      input.Reason,
+		ActorType:   input.ActorType,
+		ActorID:     input.ActorID,
+		State:       domain.StatusChangeApplied,
+		EffectiveAt: now,
+	}
+	if input.EffectiveAt != nil && input.EffectiveAt.After(now) {
+		change.State = domain.StatusChangeScheduled
+		change.EffectiveAt = *input.EffectiveAt
+	}
+
+	if change.State == domain.StatusChangeApplied {
+		// An immediate change supersedes anything queued from the old state.
+		if _, err := tx.Exec(ctx, `
+			UPDATE player_status_history SET state = 'cancelled'
+			WHERE player_id = $1 AND state = 'scheduled'`, input.PlayerID); err != nil {
+			return nil, domain.ErrInternal("cancel scheduled status changes", err)
+		}
+	}
+
+	if err := s.insertChange(ctx, tx, change); err != nil {
+		return nil, err
+	}
+	if change.State == domain.StatusChangeApplied {
+		if err := s.apply(ctx, tx, change); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
+
+// SuspendUntil suspends the player now and schedules automatic reinstatement
+// at until. The window is stored on player_profiles.suspended_until so wallet
+// and betting paths can report the reinstate time.
+func (s *PlayerStatusService) SuspendUntil(ctx context.Context, playerID uuid.UUID, until time.Time, reason, actorType string, actorID *uuid.UUID) (*domain.PlayerStatusChange, error) {
+	if !until.After(time.Now()) {
+		return nil, domain.ErrValidation("until must be in the future")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	suspension, err := s.TransitionTx(ctx, tx, StatusTransitionInput{
+		PlayerID:  playerID,
+		ToStatus:  string(domain.AccountStatusSuspended),
+		Reason:    reason,
+		ActorType: actorType,
+		ActorID:   actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.TransitionTx(ctx, tx, StatusTransitionInput{
+		PlayerID:    playerID,
+		ToStatus:    string(domain.AccountStatusActive),
+		Reason:      "suspension window ended",
+		EffectiveAt: &until,
+		ActorType:   "system",
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE player_profiles SET suspended_until = $2 WHERE player_id = $1`, playerID, until); err != nil {
+		return nil, domain.ErrInternal("set suspended_until", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return suspension, nil
+}
+
+// MaxSelfExclusionDays caps a time-limited self-exclusion; longer ones must
+// be permanent.
+const MaxSelfExclusionDays = 5 * 365
+
+// SelfExclusionInput is a player's request to exclude themselves, either for
+// a number of days or permanently.
+type SelfExclusionInput struct {
+	Days      int    `json:"duration_days"`
+	Permanent bool   `json:"permanent"`
+	Reason    string `json:"reason"`
+}
+
+// SelfExclude moves the player to self_excluded immediately. A time-limited
+// exclusion schedules reinstatement at its end and records the end on
+// player_profiles.self_excluded_until; a permanent one can only be lifted
+// by an admin. An existing exclusion cannot be shortened or replaced.
+func (s *PlayerStatusService) SelfExclude(ctx context.Context, playerID uuid.UUID, input SelfExclusionInput) (*domain.SelfExclusion, error) {
+	var until *time.Time
+	switch {
+	case input.Permanent && input.Days != 0:
+		return nil, domain.ErrValidation("give either duration_days or permanent, not both")
+	case input.Permanent:
+	case input.Days < 1 || input.Days > MaxSelfExclusionDays:
+		return nil, domain.ErrValidation(fmt.Sprintf("duration_days must be between 1 and %d", MaxSelfExclusionDays))
+	default:
+		end := time.Now().AddDate(0, 0, input.Days)
+		until = &end
+	}
+	reason := "player self-exclusion"
+	if r := strings.TrimSpace(input.Reason); r != "" {
+		reason += ": " + r
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := s.TransitionTx(ctx, tx, StatusTransitionInput{
+		PlayerID:  playerID,
+		ToStatus:  string(domain.AccountStatusSelfExcluded),
+		Reason:    reason,
+		ActorType: "player",
+		ActorID:   &playerID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if until != nil {
+		if _, err := s.TransitionTx(ctx, tx, StatusTransitionInput{
+			PlayerID:    playerID,
+			ToStatus:    string(domain.AccountStatusActive),
+			Reason:      "self-exclusion period ended",
+			EffectiveAt: until,
+			ActorType:   "system",
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE player_profiles SET self_excluded_until = $2 WHERE player_id = $1`, playerID, until); err != nil {
+		return nil, domain.ErrInternal("set self_excluded_until", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("player self-excluded", "player_id", playerID, "permanent", until == nil)
+	return &domain.SelfExclusion{
+		Excluded:      true,
+		Permanent:     until == nil,
+		Until:         until,
+		AccountStatus: domain.AccountStatusSelfExcluded,
+	}, nil
+}
+
+// SelfExclusion returns the player's current self-exclusion state.
+func (s *PlayerStatusService) SelfExclusion(ctx context.Context, playerID uuid.UUID) (*domain.SelfExclusion, error) {
+	var status string
+	var until *time.Time
+	err := s.pool.QueryRow(ctx, `
+		SELECT account_status, self_excluded_until FROM player_profiles WHERE player_id = $1`,
+		playerID).Scan(&status, &until)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get self-exclusion", err)
+	}
+	excluded := domain.AccountStatus(status) == domain.AccountStatusSelfExcluded
+	return &domain.SelfExclusion{
+		Excluded:      excluded,
+		Permanent:     excluded && until == nil,
+		Until:         until,
+		AccountStatus: domain.AccountStatus(status),
+	}, nil
+}
+
+// History returns a player's status transitions, newest first.
+func (s *PlayerStatusService) History(ctx context.Context, playerID uuid.UUID) ([]domain.PlayerStatusChange, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, player_id, from_status, to_status, reason, actor_type, actor_id,
+		       state, effective_at, applied_at, created_at
+		FROM player_status_history
+		WHERE player_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 200`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list status history", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.PlayerStatusChange{}
+	for rows.Next() {
+		var c domain.PlayerStatusChange
+		if err := rows.Scan(&c.ID, &c.PlayerID, &c.FromStatus, &c.ToStatus, &c.Reason, &c.ActorType,
+			&c.ActorID, &c.State, &c.EffectiveAt, &c.AppliedAt, &c.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan status change", err)
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// ApplyDue applies scheduled transitions whose effective date has passed.
+// A scheduled change that is no longer valid from the player's current status
+// is cancelled rather than forced. Returns the number of changes applied.
+func (s *PlayerStatusService) ApplyDue(ctx context.Context) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, player_id, from_status, to_status, reason, actor_type, actor_id, effective_at, created_at
+		FROM player_status_history
+		WHERE state = 'scheduled' AND effective_at <= now()
+		ORDER BY effective_at
+		LIMIT 100
+		FOR UPDATE SKIP LOCKED`)
+	if err != nil {
+		return 0, domain.ErrInternal("fetch due status changes", err)
+	}
+	var due []*domain.PlayerStatusChange
+	for rows.Next() {
+		c := &domain.PlayerStatusChange{State: domain.StatusChangeScheduled}
+		if err := rows.Scan(&c.ID, &c.PlayerID, &c.FromStatus, &c.ToStatus, &c.Reason, &c.ActorType,
+			&c.ActorID, &c.EffectiveAt, &c.CreatedAt); err != nil {
+			rows.Close()
+			return 0, domain.ErrInternal("scan status change", err)
+		}
+		due = append(due, c)
+	}
+	rows.Close()
+
+	applied := 0
+	for _, c := range due {
+		current, err := s.lockStatus(ctx, tx, c.PlayerID)
+		if err != nil {
+			return applied, err
+		}
+		if !current.CanTransitionTo(c.ToStatus) {
+			if _, err := tx.Exec(ctx,
+				`UPDATE player_status_history SET state = 'cancelled' WHERE id = $1`, c.ID); err != nil {
+				return applied, domain.ErrInternal("cancel status change", err)
+			}
+			s.logger.Warn("scheduled status change cancelled",
+				"player_id", c.PlayerID, "from", current, "to", c.ToStatus)
+			continue
+		}
+		c.FromStatus = current
+		c.State = domain.StatusChangeApplied
+		if err := s.apply(ctx, tx, c); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, domain.ErrInternal("commit tx", err)
+	}
+	return applied, nil
+}
+
+// StartScheduler applies due status changes every interval until ctx is done.
+func (s *PlayerStatusService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("player status scheduler stopped")
+				return
+			case <-ticker.C:
+				n, err := s.ApplyDue(ctx)
+				if err != nil {
+					s.logger.Error("apply scheduled status changes", "error", err)
+				} else if n > 0 {
+					s.logger.Info("applied scheduled status changes", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+// lockStatus reads and row-locks the player's current status.
+func (s *PlayerStatusService) lockStatus(ctx context.Context, tx pgx.Tx, playerID uuid.UUID) (domain.AccountStatus, error) {
+	var current string
+	err := tx.QueryRow(ctx,
+		`SELECT account_status FROM player_profiles WHERE player_id = $1 FOR UPDATE`, playerID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return "", domain.ErrInternal("lock player status", err)
+	}
+	return domain.AccountStatus(current), nil
+}
+
+func (s *PlayerStatusService) insertChange(ctx context.Context, tx pgx.Tx, c *domain.PlayerStatusChange) error {
+	err := tx.QueryRow(ctx, `
+		INSERT INTO player_status_history
+			(player_id, from_status, to_status, reason, actor_type, actor_id, state, effective_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`,
+		c.PlayerID, c.FromStatus, c.ToStatus, c.Reason, c.ActorType, c.ActorID, c.State, c.EffectiveAt,
+	).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		return domain.ErrInternal("insert status change", err)
+	}
+	return nil
+}
+
+// apply projects a change onto account_status and emits its outbox event.
+func (s *PlayerStatusService) apply(ctx context.Context, tx pgx.Tx, c *domain.PlayerStatusChange) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE player_profiles
+		SET account_status = $2,
+		    suspended_until = CASE WHEN $2 = 'suspended' THEN suspended_until END,
+		    self_excluded_until = CASE WHEN $2 = 'self_excluded' THEN self_excluded_until END
+		WHERE player_id = $1`,
+		c.PlayerID, c.ToStatus); err != nil {
+		return domain.ErrInternal("update account status", err)
+	}
+
+	now := time.Now()
+	c.AppliedAt = &now
+	if _, err := tx.Exec(ctx, `
+		UPDATE player_status_history SET state = 'applied', from_status = $2, applied_at = $3
+		WHERE id = $1`, c.ID, c.FromStatus, now); err != nil {
+		return domain.ErrInternal("mark status change applied", err)
+	}
+
+	if err := s.outbox.Insert(ctx, tx, domain.NewPlayerStatusChangedEvent(c)); err != nil {
+		return domain.ErrInternal("insert outbox event", err)
+	}
+
+	// Self-exclusion starting or ending is also published for RG consumers,
+	// whichever flow (player, admin, RG case, scheduler) caused it.
+	entering := c.ToStatus == domain.AccountStatusSelfExcluded
+	if entering != (c.FromStatus == domain.AccountStatusSelfExcluded) {
+		if err := s.outbox.Insert(ctx, tx, domain.NewSelfExclusionEvent(c.PlayerID, entering, c.Reason)); err != nil {
+			return domain.ErrInternal("insert outbox event", err)
+		}
+	}
+	return nil
+}
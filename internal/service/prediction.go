@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/settlement"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PredictionService places stakes on prediction markets. Each stake is
+// debited through the ledger as its own game round under the "predictions"
+// manufacturer; PredictionSettlementService pays it out unless the player
+// sells it back first.
+type PredictionService struct {
+	pool    *pgxpool.Pool
+	txRepo  repository.TransactionRepository
+	settler *settlement.PredictionSettlement
+	outbox  repository.OutboxRepository
+	logger  *slog.Logger
+}
+
+// NewPredictionService creates a PredictionService.
+func NewPredictionService(pool *pgxpool.Pool, txRepo repository.TransactionRepository, engine *ledger.Engine, outbox repository.OutboxRepository, logger *slog.Logger) *PredictionService {
+	return &PredictionService{
+		pool:    pool,
+		txRepo:  txRepo,
+		settler: settlement.NewPredictionSettlement(engine),
+		outbox:  outbox,
+		logger:  logger,
+	}
+}
+
+// PlaceStakeResult is a placed prediction stake and the player's balances
+// after its debit.
+type PlaceStakeResult struct {
+	ID    uuid.UUID `json:"id"`
+	Odds  float64   `json:"odds"`
+	Stake int64     `json:"stake"`
+	domain.Balances
+}
+
+// PlaceStake stakes amount on an outcome of an open market, locking the
+// outcome's current odds, or for a market-maker market the odds its book
+// gives the stake. The stake and its debit commit together, so a
+// player without the funds, or over a bet or loss limit, places nothing.
+func (s *PredictionService) PlaceStake(ctx context.Context, playerID, marketID uuid.UUID, outcomeID string, amount int64) (*PlaceStakeResult, error) {
+	if amount <= 0 {
+		return nil, domain.ErrValidation("amount must be positive")
+	}
+	if err := checkBetLimit(ctx, s.pool, s.txRepo, playerID, amount); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the market so it cannot close or settle under the stake; a
+	// market-maker market also moves its book with the stake.
+	var status, pricing string
+	var odds *float64
+	err = tx.QueryRow(ctx, `
+		SELECT pm.status, pm.pricing,
+		       (SELECT (o->>'odds')::float8 FROM jsonb_array_elements(pm.outcomes) o
+		        WHERE o->>'id' = $2 LIMIT 1)
+		FROM prediction_markets pm
+		WHERE pm.id = $1
+		FOR UPDATE`, marketID, outcomeID).Scan(&status, &pricing, &odds)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && status != "open") {
+		return nil, domain.ErrValidation("market is not open for stakes")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("query prediction market", err)
+	}
+	if odds == nil || *odds <= 1 {
+		return nil, domain.ErrValidation("unknown outcome")
+	}
+	// A market maker issues the shares its book priced; a fixed-odds stake
+	// gets stake × odds as stored, rounded down
+	var shares *int64
+	if pricing == PricingLMSR {
+		locked, issued, err := priceAMMStake(ctx, tx, marketID, outcomeID, amount)
+		if err != nil {
+			return nil, err
+		}
+		odds, shares = &locked, &issued
+	}
+
+	stakeID := uuid.New()
+	debit, err := s.settler.PlaceStake(ctx, tx, playerID, stakeID, marketID, outcomeID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO prediction_stakes (id, player_id, market_id, outcome_id, stake_amount_minor,
+		                               odds_at_placement, transaction_id, shares)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, floor($5 * $6::numeric(10,3))::bigint))`,
+		stakeID, playerID, marketID, outcomeID, amount, *odds, debit.Transaction.ID, shares); err != nil {
+		return nil, domain.ErrInternal("insert prediction stake", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE prediction_markets SET volume_minor = volume_minor + $2 WHERE id = $1`, marketID, amount); err != nil {
+		return nil, domain.ErrInternal("update market volume", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Info("prediction stake placed", "stake_id", stakeID, "market_id", marketID, "amount", amount)
+	return &PlaceStakeResult{
+		ID:       stakeID,
+		Odds:     *odds,
+		Stake:    amount,
+		Balances: debit.Player.Balances,
+	}, nil
+}
@@ -0,0 +1,436 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PricingFixed marks a prediction market whose odds are the ones in its
+// outcomes until an admin edits them.
+const PricingFixed = "fixed"
+
+// PredictionAdminService lets admins run house prediction markets: create
+// them, edit their outcomes, close, void and settle them. Voiding and
+// settling pay out the market's stakes straight away through
+// PredictionSettlementService.
+type PredictionAdminService struct {
+	pool       *pgxpool.Pool
+	settlement *PredictionSettlementService
+	logger     *slog.Logger
+}
+
+// NewPredictionAdminService creates a PredictionAdminService.
+func NewPredictionAdminService(pool *pgxpool.Pool, settlement *PredictionSettlementService, logger *slog.Logger) *PredictionAdminService {
+	return &PredictionAdminService{pool: pool, settlement: settlement, logger: logger}
+}
+
+// PredictionMarketInput is a new house market.
+type PredictionMarketInput struct {
+	Title       string                     `json:"title"`
+	Description *string                    `json:"description,omitempty"`
+	Category    string                     `json:"category"`
+	Tags        []string                   `json:"tags"`
+	CloseAt     *time.Time                 `json:"close_at,omitempty"`
+	Outcomes    []domain.PredictionOutcome `json:"outcomes"`
+	// Pricing is fixed (the default) or lmsr; an lmsr market needs
+	// Liquidity and may cap the house's worst case with ExposureCap.
+	Pricing     string `json:"pricing"`
+	Liquidity   int64  `json:"liquidity,omitempty"`
+	ExposureCap int64  `json:"exposure_cap,omitempty"`
+}
+
+// PredictionOutcomeExposure is one outcome of a market with the active
+// stakes on it and what they would pay if it won.
+type PredictionOutcomeExposure struct {
+	domain.PredictionOutcome
+	ActiveStakes int   `json:"active_stakes"`
+	Staked       int64 `json:"staked"`
+	Payout       int64 `json:"payout"`
+}
+
+// AdminPredictionMarket is a market with its stake and liability summary.
+// WorstCaseLiability is what the house loses on the active stakes if the
+// outcome with the largest payout wins, negative when every outcome leaves
+// it ahead.
+type AdminPredictionMarket struct {
+	ID                 uuid.UUID                   `json:"id"`
+	Title              string                      `json:"title"`
+	Description        *string                     `json:"description,omitempty"`
+	Category           string                      `json:"category"`
+	Tags               json.RawMessage             `json:"tags"`
+	Status             string                      `json:"status"`
+	Pricing            string                      `json:"pricing"`
+	Source             *string                     `json:"source,omitempty"`
+	CloseAt            *time.Time                  `json:"close_at,omitempty"`
+	WinningOutcomeID   *string                     `json:"winning_outcome_id,omitempty"`
+	Volume             int64                       `json:"volume"`
+	CreatedAt          time.Time                   `json:"created_at"`
+	Stakes             int                         `json:"stakes"`
+	ActiveStakes       int                         `json:"active_stakes"`
+	ActiveStaked       int64                       `json:"active_staked"`
+	Outcomes           []PredictionOutcomeExposure `json:"outcomes"`
+	WorstCaseLiability int64                       `json:"worst_case_liability"`
+}
+
+// PredictionMarketSettlement is a voided or settled market and the stakes
+// its settlement closed.
+type PredictionMarketSettlement struct {
+	Market     *AdminPredictionMarket  `json:"market"`
+	Settlement *PredictionSettleResult `json:"settlement"`
+}
+
+// Create adds an open house market.
+func (s *PredictionAdminService) Create(ctx context.Context, adminID uuid.UUID, input PredictionMarketInput) (*AdminPredictionMarket, error) {
+	input.Title = strings.TrimSpace(input.Title)
+	if input.Title == "" {
+		return nil, domain.ErrValidation("title is required")
+	}
+	if input.Category == "" {
+		input.Category = "general"
+	}
+	if input.Tags == nil {
+		input.Tags = []string{}
+	}
+	if input.CloseAt != nil && !input.CloseAt.After(time.Now()) {
+		return nil, domain.ErrValidation("close_at must be in the future")
+	}
+	if err := policy.ValidatePredictionOutcomes(input.Outcomes); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+
+	var liquidity, exposureCap *int64
+	switch input.Pricing {
+	case "", PricingFixed:
+		input.Pricing = PricingFixed
+	case PricingLMSR:
+		if input.Liquidity <= 0 {
+			return nil, domain.ErrValidation("liquidity must be positive for lmsr pricing")
+		}
+		if input.ExposureCap < 0 {
+			return nil, domain.ErrValidation("exposure_cap must not be negative")
+		}
+		liquidity = &input.Liquidity
+		if input.ExposureCap > 0 {
+			exposureCap = &input.ExposureCap
+		}
+	default:
+		return nil, domain.ErrValidation("pricing must be fixed or lmsr")
+	}
+
+	outcomes, _ := json.Marshal(input.Outcomes)
+	tags, _ := json.Marshal(input.Tags)
+	var id uuid.UUID
+	if err := s.pool.QueryRow(ctx, `
+		INSERT INTO prediction_markets (title, description, category, tags, close_at, outcomes,
+		                                pricing, amm_liquidity, amm_exposure_cap, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`,
+		input.Title, input.Description, input.Category, tags, input.CloseAt, outcomes,
+		input.Pricing, liquidity, exposureCap, adminID).Scan(&id); err != nil {
+		return nil, domain.ErrInternal("insert prediction market", err)
+	}
+
+	s.logger.Info("prediction market created", "market_id", id, "admin_id", adminID, "pricing", input.Pricing)
+	return s.Get(ctx, id)
+}
+
+// UpdateOutcomes replaces an unsettled house market's outcomes. An outcome
+// with active stakes cannot be removed. Once a market-maker market has
+// taken a stake its book sets the odds, so only labels can change; before
+// that, new odds reseed the book.
+func (s *PredictionAdminService) UpdateOutcomes(ctx context.Context, marketID uuid.UUID, outcomes []domain.PredictionOutcome) (*AdminPredictionMarket, error) {
+	if err := policy.ValidatePredictionOutcomes(outcomes); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status, pricing string
+	var source *string
+	var rawOutcomes []byte
+	var staked bool
+	err = tx.QueryRow(ctx, `
+		SELECT status, pricing, dome_platform, outcomes,
+		       EXISTS (SELECT 1 FROM prediction_stakes WHERE market_id = $1)
+		FROM prediction_markets WHERE id = $1
+		FOR UPDATE`, marketID).Scan(&status, &pricing, &source, &rawOutcomes, &staked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("prediction market", marketID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("load prediction market", err)
+	}
+	if source != nil {
+		return nil, domain.ErrConflict("outcomes of a Dome market come from its feed")
+	}
+	if status != "open" && status != "closed" {
+		return nil, domain.ErrConflict(fmt.Sprintf("market is already %s", status))
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT outcome_id FROM prediction_stakes WHERE market_id = $1 AND status = 'active'`, marketID)
+	if err != nil {
+		return nil, domain.ErrInternal("query staked outcomes", err)
+	}
+	stakedOutcomes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, domain.ErrInternal("scan staked outcomes", err)
+	}
+	for _, id := range stakedOutcomes {
+		if !slices.ContainsFunc(outcomes, func(o domain.PredictionOutcome) bool { return o.ID == id }) {
+			return nil, domain.ErrConflict(fmt.Sprintf("outcome %s has active stakes", id))
+		}
+	}
+
+	resetBook := false
+	if pricing == PricingLMSR {
+		if staked {
+			var current []domain.PredictionOutcome
+			if err := json.Unmarshal(rawOutcomes, &current); err != nil {
+				return nil, domain.ErrInternal("decode market outcomes", err)
+			}
+			if len(current) != len(outcomes) {
+				return nil, domain.ErrConflict("a market-maker market's outcomes are fixed once it has stakes")
+			}
+			for i := range outcomes {
+				if outcomes[i].ID != current[i].ID {
+					return nil, domain.ErrConflict("a market-maker market's outcomes are fixed once it has stakes")
+				}
+				outcomes[i].Odds = current[i].Odds
+			}
+		} else {
+			resetBook = true
+		}
+	}
+
+	raw, _ := json.Marshal(outcomes)
+	if _, err := tx.Exec(ctx, `
+		UPDATE prediction_markets
+		SET outcomes = $2, amm_seed = CASE WHEN $3 THEN '{}'::jsonb ELSE amm_seed END, updated_at = now()
+		WHERE id = $1`, marketID, raw, resetBook); err != nil {
+		return nil, domain.ErrInternal("update market outcomes", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return s.Get(ctx, marketID)
+}
+
+// Close stops an open market taking stakes; it can still be settled or
+// voided.
+func (s *PredictionAdminService) Close(ctx context.Context, marketID uuid.UUID) (*AdminPredictionMarket, error) {
+	if err := s.transition(ctx, marketID, []string{"open"}, "closed", nil, nil); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, marketID)
+}
+
+// Void cancels an open or closed market and refunds its active stakes.
+func (s *PredictionAdminService) Void(ctx context.Context, marketID uuid.UUID) (*PredictionMarketSettlement, error) {
+	if err := s.transition(ctx, marketID, []string{"open", "closed"}, "voided", nil, nil); err != nil {
+		return nil, err
+	}
+	return s.payOut(ctx, marketID)
+}
+
+// Settle resolves an open or closed market to winningOutcome on the
+// strength of an oracle attestation, then pays its active stakes.
+func (s *PredictionAdminService) Settle(ctx context.Context, marketID uuid.UUID, winningOutcome string, attestation domain.Attestation) (*PredictionMarketSettlement, error) {
+	if err := domain.ValidateAttestation(attestation); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	if winningOutcome == "" {
+		return nil, domain.ErrValidation("winning_outcome_id is required")
+	}
+	if err := s.transition(ctx, marketID, []string{"open", "closed"}, "settled", &winningOutcome, &attestation); err != nil {
+		return nil, err
+	}
+	return s.payOut(ctx, marketID)
+}
+
+// transition moves a market from one of from to status under a row lock. A
+// settlement also records the winning outcome, which must be one of the
+// market's, and the attestation.
+func (s *PredictionAdminService) transition(ctx context.Context, marketID uuid.UUID, from []string, status string, winning *string, attestation *domain.Attestation) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var current string
+	var rawOutcomes []byte
+	err = tx.QueryRow(ctx, `SELECT status, outcomes FROM prediction_markets WHERE id = $1 FOR UPDATE`, marketID).
+		Scan(&current, &rawOutcomes)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("prediction market", marketID.String())
+	}
+	if err != nil {
+		return domain.ErrInternal("load prediction market", err)
+	}
+	if !slices.Contains(from, current) {
+		return domain.ErrConflict(fmt.Sprintf("market is %s", current))
+	}
+	if winning != nil {
+		var outcomes []domain.PredictionOutcome
+		if err := json.Unmarshal(rawOutcomes, &outcomes); err != nil {
+			return domain.ErrInternal("decode market outcomes", err)
+		}
+		if !slices.ContainsFunc(outcomes, func(o domain.PredictionOutcome) bool { return o.ID == *winning }) {
+			return domain.ErrValidation("winning_outcome_id is not an outcome of the market")
+		}
+	}
+
+	var rawAttestation []byte
+	if attestation != nil {
+		rawAttestation, _ = json.Marshal(attestation)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE prediction_markets
+		SET status = $2, winning_outcome_id = COALESCE($3, winning_outcome_id),
+		    attestation = COALESCE($4, attestation), updated_at = now()
+		WHERE id = $1`, marketID, status, winning, rawAttestation); err != nil {
+		return domain.ErrInternal("update market status", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("prediction market status changed", "market_id", marketID, "from", current, "to", status)
+	return nil
+}
+
+// payOut settles a voided or settled market's stakes now rather than on the
+// settlement processor's next pass.
+func (s *PredictionAdminService) payOut(ctx context.Context, marketID uuid.UUID) (*PredictionMarketSettlement, error) {
+	result, err := s.settlement.SettleMarket(ctx, marketID)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.Get(ctx, marketID)
+	if err != nil {
+		return nil, err
+	}
+	return &PredictionMarketSettlement{Market: market, Settlement: result}, nil
+}
+
+const adminPredictionMarketColumns = `pm.id, pm.title, pm.description, pm.category, COALESCE(pm.tags, '[]'::jsonb),
+	pm.status, pm.pricing, pm.dome_platform, pm.close_at, pm.winning_outcome_id, pm.volume_minor, pm.created_at,
+	pm.outcomes, (SELECT count(*) FROM prediction_stakes ps WHERE ps.market_id = pm.id)`
+
+// Get returns a market with its stake and liability summary.
+func (s *PredictionAdminService) Get(ctx context.Context, marketID uuid.UUID) (*AdminPredictionMarket, error) {
+	markets, err := s.query(ctx, `
+		SELECT `+adminPredictionMarketColumns+` FROM prediction_markets pm WHERE pm.id = $1`, marketID)
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, domain.ErrNotFound("prediction market", marketID.String())
+	}
+	return &markets[0], nil
+}
+
+// List returns the newest markets, in status when given, and only house
+// markets when houseOnly is set, with their summaries.
+func (s *PredictionAdminService) List(ctx context.Context, status string, houseOnly bool, limit int) ([]AdminPredictionMarket, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.query(ctx, `
+		SELECT `+adminPredictionMarketColumns+`
+		FROM prediction_markets pm
+		WHERE ($1 = '' OR pm.status = $1) AND (NOT $2 OR pm.dome_platform IS NULL)
+		ORDER BY pm.created_at DESC, pm.id
+		LIMIT $3`, status, houseOnly, limit)
+}
+
+// query reads markets and adds the active stakes on each outcome.
+func (s *PredictionAdminService) query(ctx context.Context, query string, args ...any) ([]AdminPredictionMarket, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, domain.ErrInternal("query prediction markets", err)
+	}
+	defer rows.Close()
+
+	markets := []AdminPredictionMarket{}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var m AdminPredictionMarket
+		var rawOutcomes []byte
+		if err := rows.Scan(&m.ID, &m.Title, &m.Description, &m.Category, &m.Tags, &m.Status, &m.Pricing,
+			&m.Source, &m.CloseAt, &m.WinningOutcomeID, &m.Volume, &m.CreatedAt, &rawOutcomes, &m.Stakes); err != nil {
+			return nil, domain.ErrInternal("scan prediction market", err)
+		}
+		var outcomes []domain.PredictionOutcome
+		if err := json.Unmarshal(rawOutcomes, &outcomes); err != nil {
+			return nil, domain.ErrInternal("decode market outcomes", err)
+		}
+		m.Outcomes = make([]PredictionOutcomeExposure, len(outcomes))
+		for i, o := range outcomes {
+			m.Outcomes[i].PredictionOutcome = o
+		}
+		markets = append(markets, m)
+		ids = append(ids, m.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read prediction markets", err)
+	}
+	if len(markets) == 0 {
+		return markets, nil
+	}
+
+	exposure, err := s.pool.Query(ctx, `
+		SELECT market_id, outcome_id, count(*), SUM(stake_amount_minor)::bigint,
+		       SUM(shares)::bigint
+		FROM prediction_stakes
+		WHERE market_id = ANY($1) AND status = 'active'
+		GROUP BY market_id, outcome_id`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("query market exposure", err)
+	}
+	defer exposure.Close()
+	for exposure.Next() {
+		var marketID uuid.UUID
+		var outcomeID string
+		var count int
+		var staked, payout int64
+		if err := exposure.Scan(&marketID, &outcomeID, &count, &staked, &payout); err != nil {
+			return nil, domain.ErrInternal("scan market exposure", err)
+		}
+		m := &markets[slices.Index(ids, marketID)]
+		m.ActiveStakes += count
+		m.ActiveStaked += staked
+		if i := slices.IndexFunc(m.Outcomes, func(o PredictionOutcomeExposure) bool { return o.ID == outcomeID }); i >= 0 {
+			m.Outcomes[i].ActiveStakes, m.Outcomes[i].Staked, m.Outcomes[i].Payout = count, staked, payout
+		}
+	}
+	if err := exposure.Err(); err != nil {
+		return nil, domain.ErrInternal("read market exposure", err)
+	}
+
+	for i := range markets {
+		m := &markets[i]
+		var most int64
+		for _, o := range m.Outcomes {
+			most = max(most, o.Payout)
+		}
+		m.WorstCaseLiability = most - m.ActiveStaked
+	}
+	return markets, nil
+}
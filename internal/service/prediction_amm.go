@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PricingLMSR marks a house prediction market whose odds are set by its
+// market maker rather than fixed in its outcomes.
+const PricingLMSR = "lmsr"
+
+// ammMarket is a house market's outcomes and LMSR book, in outcome order.
+type ammMarket struct {
+	id       uuid.UUID
+	outcomes []map[string]any
+	ids      []string
+	book     policy.PredictionBook
+}
+
+// loadAMMMarket reads a market-maker market's book. The caller holds the
+// market row locked. A market that has not taken a stake yet is seeded from
+// the odds in its outcomes.
+func loadAMMMarket(ctx context.Context, tx pgx.Tx, marketID uuid.UUID) (*ammMarket, error) {
+	var rawOutcomes, rawSeed, rawShares []byte
+	var liquidity, exposureCap, collected int64
+	err := tx.QueryRow(ctx, `
+		SELECT outcomes, amm_liquidity::bigint, COALESCE(amm_exposure_cap, 0)::bigint,
+		       amm_seed, amm_shares, amm_collected::bigint
+		FROM prediction_markets WHERE id = $1`, marketID).
+		Scan(&rawOutcomes, &liquidity, &exposureCap, &rawSeed, &rawShares, &collected)
+	if err != nil {
+		return nil, domain.ErrInternal("load market book", err)
+	}
+
+	m := &ammMarket{id: marketID}
+	var seed map[string]float64
+	var shares map[string]int64
+	if err := json.Unmarshal(rawOutcomes, &m.outcomes); err != nil {
+		return nil, domain.ErrInternal("decode market outcomes", err)
+	}
+	if err := json.Unmarshal(rawSeed, &seed); err != nil {
+		return nil, domain.ErrInternal("decode market seed", err)
+	}
+	if err := json.Unmarshal(rawShares, &shares); err != nil {
+		return nil, domain.ErrInternal("decode market shares", err)
+	}
+
+	m.book = policy.PredictionBook{
+		Liquidity:   float64(liquidity),
+		Seed:        make([]float64, len(m.outcomes)),
+		Shares:      make([]int64, len(m.outcomes)),
+		Collected:   collected,
+		ExposureCap: exposureCap,
+	}
+	opening := make([]float64, len(m.outcomes))
+	for i, o := range m.outcomes {
+		id, _ := o["id"].(string)
+		m.ids = append(m.ids, id)
+		m.book.Seed[i] = seed[id]
+		m.book.Shares[i] = shares[id]
+		opening[i], _ = o["odds"].(float64)
+	}
+	if len(seed) == 0 && !slices.ContainsFunc(opening, func(o float64) bool { return o <= 1 }) {
+		m.book.Seed = policy.SeedPredictionBook(m.book.Liquidity, opening)
+	}
+	return m, nil
+}
+
+// save writes the book back with the outcomes repriced at its current odds.
+func (m *ammMarket) save(ctx context.Context, tx pgx.Tx) error {
+	seed := make(map[string]float64, len(m.ids))
+	shares := make(map[string]int64, len(m.ids))
+	for i, odds := range m.book.Odds() {
+		m.outcomes[i]["odds"] = odds
+		seed[m.ids[i]] = m.book.Seed[i]
+		shares[m.ids[i]] = m.book.Shares[i]
+	}
+	outcomes, _ := json.Marshal(m.outcomes)
+	rawSeed, _ := json.Marshal(seed)
+	rawShares, _ := json.Marshal(shares)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE prediction_markets
+		SET outcomes = $2, amm_seed = $3, amm_shares = $4, amm_collected = $5, updated_at = now()
+		WHERE id = $1`, m.id, outcomes, rawSeed, rawShares, m.book.Collected); err != nil {
+		return domain.ErrInternal("update market book", err)
+	}
+	return nil
+}
+
+// priceAMMStake prices a stake against a house market's book and records it
+// there, returning the odds the stake locks and the shares it was issued. A stake that would take the
+// house's worst case past the market's exposure cap is rejected with the
+// largest stake that fits.
+func priceAMMStake(ctx context.Context, tx pgx.Tx, marketID uuid.UUID, outcomeID string, amount int64) (float64, int64, error) {
+	m, err := loadAMMMarket(ctx, tx, marketID)
+	if err != nil {
+		return 0, 0, err
+	}
+	i := slices.Index(m.ids, outcomeID)
+	if i < 0 {
+		return 0, 0, domain.ErrValidation("unknown outcome")
+	}
+
+	if maxStake := m.book.MaxStake(i, amount); maxStake < amount {
+		return 0, 0, domain.ErrExposureLimitExceeded(maxStake)
+	}
+	odds, payout := m.book.Quote(i, amount)
+	if odds*100 < policy.MinOdds {
+		return 0, 0, domain.ErrValidation("stake is too large for the market's liquidity")
+	}
+	m.book = m.book.Buy(i, amount, payout)
+	if err := m.save(ctx, tx); err != nil {
+		return 0, 0, err
+	}
+	return odds, payout, nil
+}
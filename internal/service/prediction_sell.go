@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PositionSale is a prediction stake sold back before its market settled,
+// with the player's balances after the proceeds were credited.
+type PositionSale struct {
+	ID          uuid.UUID `json:"id"`
+	MarketID    uuid.UUID `json:"market_id"`
+	OutcomeID   string    `json:"outcome_id"`
+	Stake       int64     `json:"stake"`
+	Proceeds    int64     `json:"proceeds"`
+	RealizedPnL int64     `json:"realized_pnl"`
+	domain.Balances
+}
+
+// SellPosition sells a player's active stake back at its market's current
+// odds and marks it sold with its realized P&L. A market-maker market buys
+// the shares issued to the stake back through its book, which moves its
+// odds; a fixed-odds market pays the stake's payout at the outcome's current
+// odds, less the cash-out margin. A positive minValue refuses the sale if
+// the proceeds have fallen below it.
+func (s *PredictionService) SellPosition(ctx context.Context, playerID, stakeID uuid.UUID, minValue int64) (*PositionSale, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the stake against settlement and the market against closing or
+	// other trades moving its book.
+	sale := &PositionSale{ID: stakeID}
+	var owner uuid.UUID
+	var status, marketStatus, pricing string
+	var shares int64
+	var odds *float64
+	err = tx.QueryRow(ctx, `
+		SELECT ps.player_id, ps.market_id, ps.outcome_id, ps.stake_amount_minor, ps.status,
+		       ps.shares,
+		       pm.status, pm.pricing,
+		       (SELECT (o->>'odds')::float8 FROM jsonb_array_elements(pm.outcomes) o
+		        WHERE o->>'id' = ps.outcome_id LIMIT 1)
+		FROM prediction_stakes ps
+		JOIN prediction_markets pm ON pm.id = ps.market_id
+		WHERE ps.id = $1
+		FOR UPDATE OF ps, pm`, stakeID).
+		Scan(&owner, &sale.MarketID, &sale.OutcomeID, &sale.Stake, &status, &shares, &marketStatus, &pricing, &odds)
+	if errors.Is(err, pgx.ErrNoRows) || err == nil && owner != playerID {
+		return nil, domain.ErrNotFound("prediction position", stakeID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("load prediction position", err)
+	}
+	if status != "active" {
+		return nil, domain.ErrConflict(fmt.Sprintf("position is already %s", status))
+	}
+	if marketStatus != "open" {
+		return nil, sellUnavailable("market is not open for trading")
+	}
+
+	var market *ammMarket
+	if pricing == PricingLMSR {
+		if market, err = loadAMMMarket(ctx, tx, sale.MarketID); err != nil {
+			return nil, err
+		}
+		i := slices.Index(market.ids, sale.OutcomeID)
+		if i < 0 {
+			return nil, sellUnavailable("outcome is no longer offered")
+		}
+		sale.Proceeds = market.book.SellValue(i, shares)
+		market.book = market.book.Sell(i, shares, sale.Proceeds)
+	} else if odds != nil {
+		sale.Proceeds = policy.PredictionSellValue(shares, *odds)
+	}
+	if sale.Proceeds <= 0 {
+		return nil, sellUnavailable("position has no sale value")
+	}
+	if minValue > 0 && sale.Proceeds < minValue {
+		return nil, &domain.AppError{
+			Code:    "SELL_VALUE_CHANGED",
+			Message: "sale value has changed",
+			Details: map[string]interface{}{"value": sale.Proceeds, "min_value": minValue},
+			Status:  409,
+		}
+	}
+	if market != nil {
+		if err := market.save(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	credit, err := s.settler.SellStake(ctx, tx, playerID, stakeID, sale.MarketID, sale.Proceeds)
+	if err != nil {
+		return nil, fmt.Errorf("sell prediction stake %s: %w", stakeID, err)
+	}
+	sale.RealizedPnL = sale.Proceeds - sale.Stake
+	sale.Balances = credit.Player.Balances
+	if _, err := tx.Exec(ctx, `
+		UPDATE prediction_stakes
+		SET status = 'sold', payout_amount_minor = $2, realized_pnl_minor = $3, settled_at = now()
+		WHERE id = $1`, stakeID, sale.Proceeds, sale.RealizedPnL); err != nil {
+		return nil, domain.ErrInternal("update sold prediction stake", err)
+	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewPredictionStakeSettledEvent(playerID, stakeID, sale.MarketID, sale.Stake, "sold", sale.Proceeds)); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Info("prediction position sold", "stake_id", stakeID, "player_id", playerID, "proceeds", sale.Proceeds)
+	return sale, nil
+}
+
+func sellUnavailable(reason string) error {
+	return &domain.AppError{
+		Code:    "SELL_UNAVAILABLE",
+		Message: fmt.Sprintf("position cannot be sold: %s", reason),
+		Status:  422,
+	}
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/settlement"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PredictionSettlementService pays out prediction stakes once their market
+// is settled, by Dome or an admin, or voided.
+type PredictionSettlementService struct {
+	pool    *pgxpool.Pool
+	engine  *ledger.Engine
+	settler *settlement.PredictionSettlement
+	outbox  repository.OutboxRepository
+	logger  *slog.Logger
+}
+
+// NewPredictionSettlementService creates a PredictionSettlementService.
+func NewPredictionSettlementService(pool *pgxpool.Pool, engine *ledger.Engine, outbox repository.OutboxRepository, logger *slog.Logger) *PredictionSettlementService {
+	return &PredictionSettlementService{
+		pool:    pool,
+		engine:  engine,
+		settler: settlement.NewPredictionSettlement(engine),
+		outbox:  outbox,
+		logger:  logger,
+	}
+}
+
+// PredictionSettleResult summarizes the stakes a market settlement closed.
+type PredictionSettleResult struct {
+	Settled int   `json:"settled"`
+	Won     int   `json:"won"`
+	Lost    int   `json:"lost"`
+	Voided  int   `json:"voided"`
+	Paid    int64 `json:"paid"`
+}
+
+// predictionStake is an active stake with the payout its locked odds give.
+type predictionStake struct {
+	ID            uuid.UUID
+	PlayerID      uuid.UUID
+	OutcomeID     string
+	Stake         int64
+	TransactionID *uuid.UUID
+	Payout        int64
+}
+
+// SettleMarket settles the active stakes of a settled or voided market, each
+// in its own transaction:
+//   - Winning outcome → CreditWin of stake × the odds locked at placement
+//   - Other outcomes → a settlement-loss entry (stake already deducted)
+//   - Voided market → CancelTransaction to refund the stake
+//
+// A settled market needs its winning outcome and a valid attestation. Stakes
+// are settled once; running it again settles only stakes still active.
+func (s *PredictionSettlementService) SettleMarket(ctx context.Context, marketID uuid.UUID) (*PredictionSettleResult, error) {
+	var status string
+	var winning *string
+	var rawAttestation []byte
+	err := s.pool.QueryRow(ctx,
+		`SELECT status, winning_outcome_id, attestation FROM prediction_markets WHERE id = $1`,
+		marketID).Scan(&status, &winning, &rawAttestation)
+	if err != nil {
+		return nil, domain.ErrNotFound("prediction market", marketID.String())
+	}
+
+	var attestation domain.Attestation
+	switch status {
+	case "voided":
+	case "settled":
+		if winning == nil || *winning == "" {
+			return nil, domain.ErrValidation("settled market has no winning outcome")
+		}
+		if err := json.Unmarshal(rawAttestation, &attestation); err != nil {
+			return nil, domain.ErrValidation("settled market has no readable attestation")
+		}
+		if err := domain.ValidateAttestation(attestation); err != nil {
+			return nil, domain.ErrValidation(err.Error())
+		}
+	default:
+		return nil, domain.ErrValidation("market must be settled or voided to pay out stakes")
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, player_id, outcome_id, stake_amount_minor, transaction_id,
+		       shares
+		FROM prediction_stakes
+		WHERE market_id = $1 AND status = 'active'
+		ORDER BY placed_at`, marketID)
+	if err != nil {
+		return nil, domain.ErrInternal("query prediction stakes", err)
+	}
+	var stakes []predictionStake
+	for rows.Next() {
+		var st predictionStake
+		if err := rows.Scan(&st.ID, &st.PlayerID, &st.OutcomeID, &st.Stake, &st.TransactionID, &st.Payout); err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan prediction stake", err)
+		}
+		stakes = append(stakes, st)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("iterate prediction stakes", err)
+	}
+
+	result := &PredictionSettleResult{}
+	for _, st := range stakes {
+		outcome := "void"
+		if status == "settled" {
+			outcome = "lost"
+			if st.OutcomeID == *winning {
+				outcome = "won"
+			}
+		}
+		settled, err := s.settleStake(ctx, marketID, st, outcome, attestation)
+		if err != nil {
+			return result, err
+		}
+		if !settled {
+			continue
+		}
+		switch outcome {
+		case "won":
+			result.Won++
+			result.Paid += st.Payout
+		case "lost":
+			result.Lost++
+		case "void":
+			result.Voided++
+		}
+		result.Settled++
+	}
+	return result, nil
+}
+
+// settleStake marks a stake with its outcome and posts the matching ledger
+// entry in one transaction. It reports false when the stake was settled
+// concurrently.
+func (s *PredictionSettlementService) settleStake(ctx context.Context, marketID uuid.UUID, st predictionStake, outcome string, attestation domain.Attestation) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, domain.ErrInternal("begin settle tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var payout int64
+	if outcome == "won" {
+		payout = st.Payout
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE prediction_stakes
+		SET status = $2, payout_amount_minor = $3, settled_at = now(),
+		    realized_pnl_minor = CASE WHEN $2 = 'void' THEN 0 ELSE $3 - stake_amount_minor END
+		WHERE id = $1 AND status = 'active'`, st.ID, outcome, payout)
+	if err != nil {
+		return false, domain.ErrInternal("update prediction stake", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	switch {
+	case outcome == "won" && payout > 0:
+		_, err = s.settler.SettleOutcomeWin(ctx, tx, st.PlayerID, st.ID, marketID, payout, attestation)
+	case outcome == "won":
+		s.logger.Warn("winning prediction stake has no locked odds", "stake_id", st.ID)
+	case outcome == "lost":
+		_, err = s.settler.SettleOutcomeLoss(ctx, tx, st.PlayerID, st.ID, marketID, attestation)
+	case st.TransactionID != nil:
+		_, err = s.settler.VoidStake(ctx, tx, st.PlayerID, st.ID, *st.TransactionID, st.Stake)
+	}
+	if err == nil && outcome != "won" && st.TransactionID != nil {
+		// Wins close the stake's round as they post; losses and refunds do not.
+		_, err = s.engine.ExecuteCloseRound(ctx, tx, domain.CloseRoundParams{
+			PlayerID:       st.PlayerID,
+			ManufacturerID: settlement.PredictionManufacturerID,
+			GameRoundID:    settlement.StakeRound(st.ID),
+		})
+	}
+	if err != nil {
+		return false, fmt.Errorf("settle prediction stake %s: %w", st.ID, err)
+	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewPredictionStakeSettledEvent(st.PlayerID, st.ID, marketID, st.Stake, outcome, payout)); err != nil {
+		return false, domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, domain.ErrInternal("commit settle tx", err)
+	}
+	return true, nil
+}
+
+// SettlePending settles every settled or voided market that still has
+// active stakes, returning how many stakes were settled. A market that
+// fails is logged and left for the next run.
+func (s *PredictionSettlementService) SettlePending(ctx context.Context) (int, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT m.id
+		FROM prediction_markets m
+		JOIN prediction_stakes ps ON ps.market_id = m.id AND ps.status = 'active'
+		WHERE m.status IN ('settled', 'voided')`)
+	if err != nil {
+		return 0, domain.ErrInternal("query settled prediction markets", err)
+	}
+	var markets []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, domain.ErrInternal("scan settled prediction market", err)
+		}
+		markets = append(markets, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, domain.ErrInternal("iterate settled prediction markets", err)
+	}
+
+	settled := 0
+	for _, id := range markets {
+		result, err := s.SettleMarket(ctx, id)
+		if result != nil {
+			settled += result.Settled
+		}
+		if err != nil {
+			s.logger.Error("settle prediction market", "market_id", id, "error", err)
+		}
+	}
+	return settled, nil
+}
+
+// StartSettlementProcessor pays out settled and voided markets every
+// interval until ctx is done.
+func (s *PredictionSettlementService) StartSettlementProcessor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("prediction settlement processor stopped")
+				return
+			case <-ticker.C:
+				n, err := s.SettlePending(ctx)
+				if err != nil {
+					s.logger.Error("settle prediction stakes", "error", err)
+				} else if n > 0 {
+					s.logger.Info("settled prediction stakes", "count", n)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// providerMismatchMaxWindow bounds a mismatch report, which scans both the
+// journal and the ledger.
+const providerMismatchMaxWindow = 31 * 24 * time.Hour
+
+// ProviderCallbackService reads the provider callback journal written by the
+// wallet server, for vendor disputes and ledger reconciliation.
+type ProviderCallbackService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewProviderCallbackService creates a ProviderCallbackService.
+func NewProviderCallbackService(pool *pgxpool.Pool, logger *slog.Logger) *ProviderCallbackService {
+	return &ProviderCallbackService{pool: pool, logger: logger}
+}
+
+// ProviderCallbackFilter narrows a journal query. Zero values match all.
+type ProviderCallbackFilter struct {
+	Provider       string
+	Action         string
+	Status         string
+	PlayerID       *uuid.UUID
+	TransactionID  string // matches the transaction or reference id
+	SignatureValid *bool
+	From           *time.Time
+	To             *time.Time
+	Limit          int
+}
+
+const providerCallbackColumns = `id, provider, path, action, player_id, transaction_id, reference_id, round_id,
+	amount::bigint, currency, body, signature_valid, status, message, balance::bigint, bonus_balance::bigint,
+	duration_ms, received_at`
+
+func scanProviderCallback(row pgx.Row) (*domain.ProviderCallback, error) {
+	var c domain.ProviderCallback
+	if err := row.Scan(&c.ID, &c.Provider, &c.Path, &c.Action, &c.PlayerID, &c.TransactionID, &c.ReferenceID,
+		&c.RoundID, &c.Amount, &c.Currency, &c.Body, &c.SignatureValid, &c.Status, &c.Message, &c.Balance,
+		&c.BonusBalance, &c.DurationMs, &c.ReceivedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// List returns journaled callbacks, newest first.
+func (s *ProviderCallbackService) List(ctx context.Context, f ProviderCallbackFilter) ([]domain.ProviderCallback, error) {
+	if f.Limit <= 0 || f.Limit > 500 {
+		f.Limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+providerCallbackColumns+` FROM provider_callbacks
+		WHERE ($1 = '' OR provider = $1) AND ($2 = '' OR action = $2) AND ($3 = '' OR status = $3)
+		  AND ($4::uuid IS NULL OR player_id = $4)
+		  AND ($5 = '' OR transaction_id = $5 OR reference_id = $5)
+		  AND ($6::boolean IS NULL OR signature_valid = $6)
+		  AND ($7::timestamptz IS NULL OR received_at >= $7)
+		  AND ($8::timestamptz IS NULL OR received_at < $8)
+		ORDER BY received_at DESC LIMIT $9`,
+		f.Provider, f.Action, f.Status, f.PlayerID, f.TransactionID, f.SignatureValid, f.From, f.To, f.Limit)
+	if err != nil {
+		return nil, domain.ErrInternal("query provider callbacks", err)
+	}
+	defer rows.Close()
+
+	out := []domain.ProviderCallback{}
+	for rows.Next() {
+		c, err := scanProviderCallback(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan provider callback", err)
+		}
+		out = append(out, *c)
+	}
+	return out, rows.Err()
+}
+
+// callbackLedgerKey is the external transaction id the ledger records for an
+// accepted callback: releases settle the reservation they reference and
+// rollbacks post a prefixed cancellation.
+const callbackLedgerKey = `CASE c.action
+		WHEN 'release' THEN c.reference_id
+		WHEN 'rollback' THEN 'rollback_' || c.transaction_id
+		ELSE c.transaction_id END`
+
+// roundRollbackMatch pairs a rollback sent with only a round id with the
+// cancellations it posted, which target transactions of that round.
+const roundRollbackMatch = `c.action = 'rollback' AND c.transaction_id IS NULL AND t.target_transaction_id IN (
+			SELECT r.id FROM v2_transactions r
+			WHERE r.player_id = c.player_id AND r.manufacturer_id = c.provider AND r.game_round_id = c.round_id)`
+
+// Mismatches compares the journal with the ledger over [from, to). It reports
+// accepted bet, win, reserve, release and rollback callbacks with no ledger
+// transaction, and ledger transactions from journaled providers that no
+// accepted callback explains. Zero wins (losses, which post nothing) and the
+// stuck-round sweeper's refunds are not mismatches. provider may be empty for
+// all providers.
+func (s *ProviderCallbackService) Mismatches(ctx context.Context, providerName string, from, to time.Time) ([]domain.ProviderCallbackMismatch, error) {
+	if !to.After(from) {
+		return nil, domain.ErrValidation("to must be after from")
+	}
+	if to.Sub(from) > providerMismatchMaxWindow {
+		return nil, domain.ErrValidation("window must not exceed 31 days")
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT 'missing_transaction', c.provider, c.player_id, COALESCE(c.transaction_id, ''), c.action,
+		       COALESCE(c.amount, 0)::bigint, c.id, NULL::uuid, c.received_at
+		FROM provider_callbacks c
+		WHERE c.received_at >= $2 AND c.received_at < $3 AND ($1 = '' OR c.provider = $1)
+		  AND c.status = 'ok' AND c.action IN ('bet', 'win', 'reserve', 'release', 'rollback')
+		  AND NOT (c.action = 'win' AND COALESCE(c.amount, 0) = 0)
+		  AND NOT EXISTS (
+			SELECT 1 FROM v2_transactions t
+			WHERE t.manufacturer_id = c.provider AND t.player_id = c.player_id
+			  AND (t.external_transaction_id = `+callbackLedgerKey+`
+			       OR (`+roundRollbackMatch+`)))
+		UNION ALL
+		SELECT 'unjournaled_transaction', t.manufacturer_id, t.player_id, COALESCE(t.external_transaction_id, ''),
+		       t.type, t.amount::bigint, NULL::uuid, t.id, t.created_at
+		FROM v2_transactions t
+		WHERE t.created_at >= $2 AND t.created_at < $3 AND ($1 = '' OR t.manufacturer_id = $1)
+		  AND t.manufacturer_id IN (SELECT DISTINCT provider FROM provider_callbacks)
+		  AND COALESCE(t.external_transaction_id, '') NOT LIKE 'void\_%'
+		  AND NOT EXISTS (
+			SELECT 1 FROM provider_callbacks c
+			WHERE c.provider = t.manufacturer_id AND c.player_id = t.player_id AND c.status = 'ok'
+			  AND (t.external_transaction_id IN (c.transaction_id, c.reference_id, 'rollback_' || c.transaction_id)
+			       OR (`+roundRollbackMatch+`)))
+		ORDER BY 9`, providerName, from, to)
+	if err != nil {
+		return nil, domain.ErrInternal("query provider mismatches", err)
+	}
+	defer rows.Close()
+
+	out := []domain.ProviderCallbackMismatch{}
+	for rows.Next() {
+		var m domain.ProviderCallbackMismatch
+		if err := rows.Scan(&m.Kind, &m.Provider, &m.PlayerID, &m.TransactionID, &m.Action,
+			&m.Amount, &m.CallbackID, &m.LedgerID, &m.At); err != nil {
+			return nil, domain.ErrInternal("scan provider mismatch", err)
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// QuestProgressEngine advances automated quests from outbox events: bets,
+// deposits and wins posted to the ledger, and recorded engagement signals.
+// Each event is matched against the active quests of the types it can move
+// and their criteria, skipping quests the player has not unlocked yet, adds
+// to the player's progress, and completes a quest once its target is
+// reached. Like the read-model projections, the outbox consumer applies each
+// event before publishing it, and an event is applied at most once: its
+// quest_progress_events marker is written in the same transaction as the
+// progress.
+type QuestProgressEngine struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+	logger *slog.Logger
+}
+
+// NewQuestProgressEngine creates a QuestProgressEngine.
+func NewQuestProgressEngine(pool *pgxpool.Pool, outbox repository.OutboxRepository, logger *slog.Logger) *QuestProgressEngine {
+	return &QuestProgressEngine{pool: pool, outbox: outbox, logger: logger}
+}
+
+// Apply advances the quests e counts towards. Events that carry no quest
+// activity, and events already applied, are skipped.
+func (q *QuestProgressEngine) Apply(ctx context.Context, e domain.OutboxDraft) error {
+	playerID, activity, ok, err := questActivity(e)
+	if err != nil {
+		return fmt.Errorf("quest activity of %s event %s: %w", e.EventType, e.EventID, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO quest_progress_events (event_id) VALUES ($1) ON CONFLICT DO NOTHING`, e.EventID)
+	if err != nil {
+		return fmt.Errorf("mark event applied: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	type candidate struct {
+		id       uuid.UUID
+		target   int
+		increase int64
+	}
+	// Quests still locked for the player behind unclaimed prerequisites do
+	// not advance until they unlock.
+	rows, err := tx.Query(ctx, `
+		SELECT q.id, q.type, q.criteria, q.target_progress FROM quests q
+		WHERE q.active AND q.type = ANY($1)
+		  AND NOT EXISTS (
+		    SELECT 1 FROM quest_prerequisites qp
+		    WHERE qp.quest_id = q.id
+		      AND NOT EXISTS (SELECT 1 FROM player_quest_progress c
+		                      WHERE c.player_id = $2 AND c.quest_id = qp.prerequisite_id AND c.status = 'claimed')
+		      AND NOT EXISTS (SELECT 1 FROM player_quest_history c
+		                      WHERE c.player_id = $2 AND c.quest_id = qp.prerequisite_id AND c.status = 'claimed'))`,
+		policy.QuestTypesFor(activity.Kind), playerID)
+	if err != nil {
+		return fmt.Errorf("list quests: %w", err)
+	}
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		var questType string
+		var criteria policy.QuestCriteria
+		if err := rows.Scan(&c.id, &questType, &criteria, &c.target); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan quest: %w", err)
+		}
+		if c.increase = policy.QuestIncrement(questType, criteria, activity); c.increase > 0 {
+			candidates = append(candidates, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("list quests: %w", err)
+	}
+
+	for _, c := range candidates {
+		completed, err := advanceQuest(ctx, tx, playerID, c.id, c.increase, c.target)
+		if err != nil {
+			return fmt.Errorf("advance quest %s: %w", c.id, err)
+		}
+		if !completed {
+			continue
+		}
+		if err := q.outbox.Insert(ctx, tx, domain.NewQuestCompletedEvent(playerID, c.id)); err != nil {
+			return fmt.Errorf("insert outbox event: %w", err)
+		}
+		q.logger.Info("quest completed", "player_id", playerID, "quest_id", c.id, "event_id", e.EventID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// advanceQuest adds increase to the player's progress on an active quest,
+// capped at target, and reports whether this completed it. Progress on a
+// quest already completed or claimed stays as it is.
+func advanceQuest(ctx context.Context, tx pgx.Tx, playerID, questID uuid.UUID, increase int64, target int) (bool, error) {
+	var status string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO player_quest_progress (player_id, quest_id, progress, status, completed_at)
+		VALUES ($1, $2, LEAST($3::bigint, $4), CASE WHEN $3::bigint >= $4 THEN 'completed' ELSE 'active' END,
+		        CASE WHEN $3::bigint >= $4 THEN now() END)
+		ON CONFLICT (player_id, quest_id) DO UPDATE
+		SET progress     = LEAST(player_quest_progress.progress + $3::bigint, $4),
+		    status       = CASE WHEN player_quest_progress.progress + $3::bigint >= $4 THEN 'completed' ELSE 'active' END,
+		    completed_at = CASE WHEN player_quest_progress.progress + $3::bigint >= $4 THEN now() END,
+		    updated_at   = now()
+		WHERE player_quest_progress.status = 'active'
+		RETURNING status`, playerID, questID, increase, target).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return status == "completed", nil
+}
+
+// questActivity reads the player activity out of e; ok is false for events
+// that carry none.
+func questActivity(e domain.OutboxDraft) (uuid.UUID, policy.QuestActivity, bool, error) {
+	switch e.EventType {
+	case domain.EventTransactionPosted:
+		var t domain.Transaction
+		if err := json.Unmarshal(e.Payload, &t); err != nil {
+			return uuid.Nil, policy.QuestActivity{}, false, fmt.Errorf("decode payload: %w", err)
+		}
+		var kind string
+		switch t.Type {
+		case domain.TxBet:
+			kind = policy.QuestActivityBet
+		case domain.TxDeposit:
+			kind = policy.QuestActivityDeposit
+		case domain.TxWin:
+			kind = policy.QuestActivityWin
+		default:
+			return uuid.Nil, policy.QuestActivity{}, false, nil
+		}
+		activity := policy.QuestActivity{Kind: kind, Amount: t.Amount}
+		if t.ManufacturerID != nil {
+			activity.Source = *t.ManufacturerID
+		}
+		return t.PlayerID, activity, true, nil
+
+	case domain.EventEngagementSignal:
+		var payload struct {
+			PlayerID uuid.UUID `json:"player_id"`
+			Signal   string    `json:"signal"`
+			Value    int64     `json:"value"`
+		}
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return uuid.Nil, policy.QuestActivity{}, false, fmt.Errorf("decode payload: %w", err)
+		}
+		return payload.PlayerID, policy.QuestActivity{
+			Kind: policy.QuestActivityEngagement, Amount: payload.Value, Signal: payload.Signal,
+		}, true, nil
+	}
+	return uuid.Nil, policy.QuestActivity{}, false, nil
+}
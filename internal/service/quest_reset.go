@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// QuestResetService resets recurring quests as their period ends. Each
+// player's progress for the period is archived to player_quest_history,
+// claimed or expired, and cleared, so the quest starts over from
+// not_started while the claim history stays.
+type QuestResetService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewQuestResetService creates a QuestResetService.
+func NewQuestResetService(pool *pgxpool.Pool, logger *slog.Logger) *QuestResetService {
+	return &QuestResetService{pool: pool, logger: logger}
+}
+
+// StartResetScheduler resets due quests every interval until ctx is done.
+func (s *QuestResetService) StartResetScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("quest reset scheduler stopped")
+				return
+			case <-ticker.C:
+				n, err := s.ResetDue(ctx)
+				if err != nil {
+					s.logger.Error("reset quests", "error", err)
+				} else if n > 0 {
+					s.logger.Info("reset recurring quests", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+// ResetDue resets every quest whose period has ended and returns how many
+// it reset. Each quest resets in its own transaction.
+func (s *QuestResetService) ResetDue(ctx context.Context) (int, error) {
+	rows, err := s.pool.Query(ctx, `SELECT id FROM quests WHERE next_reset_at <= now()`)
+	if err != nil {
+		return 0, fmt.Errorf("list due quests: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, fmt.Errorf("list due quests: %w", err)
+	}
+
+	reset := 0
+	for _, id := range ids {
+		ok, err := s.reset(ctx, id)
+		if err != nil {
+			return reset, fmt.Errorf("reset quest %s: %w", id, err)
+		}
+		if ok {
+			reset++
+		}
+	}
+	return reset, nil
+}
+
+// reset ends quest id's current period, unless another scheduler already
+// has.
+func (s *QuestResetService) reset(ctx context.Context, id uuid.UUID) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var recurrence, cron string
+	var periodEnd time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT recurrence, COALESCE(recurrence_cron, ''), next_reset_at FROM quests
+		WHERE id = $1 AND next_reset_at <= now()
+		FOR UPDATE SKIP LOCKED`, id).Scan(&recurrence, &cron, &periodEnd)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("lock quest: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO player_quest_history (player_id, quest_id, period_end, progress, status, completed_at, claimed_at)
+		SELECT player_id, quest_id, $2, progress,
+		       CASE WHEN status = 'claimed' THEN 'claimed' ELSE 'expired' END, completed_at, claimed_at
+		FROM player_quest_progress WHERE quest_id = $1`, id, periodEnd)
+	if err != nil {
+		return false, fmt.Errorf("archive progress: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM player_quest_progress WHERE quest_id = $1`, id); err != nil {
+		return false, fmt.Errorf("clear progress: %w", err)
+	}
+
+	// A period missed while the scheduler was down is not replayed: the
+	// next one is counted from now.
+	var next *time.Time
+	if at, ok, err := policy.NextQuestReset(recurrence, cron, time.Now()); err != nil {
+		s.logger.Error("quest recurrence invalid, no longer resetting", "quest_id", id, "error", err)
+	} else if ok {
+		next = &at
+	}
+	if _, err := tx.Exec(ctx, `UPDATE quests SET next_reset_at = $2, updated_at = now() WHERE id = $1`, id, next); err != nil {
+		return false, fmt.Errorf("schedule next reset: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit tx: %w", err)
+	}
+	s.logger.Info("quest reset", "quest_id", id, "period_end", periodEnd, "archived", tag.RowsAffected(), "next_reset_at", next)
+	return true, nil
+}
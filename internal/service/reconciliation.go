@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/internal/reconciliation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReconciliationService matches provider settlement reports against the
+// payments table and ledger and stores each run's discrepancies.
+type ReconciliationService struct {
+	pool   *pgxpool.Pool
+	stripe *provider.StripeProvider
+	logger *slog.Logger
+}
+
+// NewReconciliationService creates a ReconciliationService.
+func NewReconciliationService(pool *pgxpool.Pool, stripe *provider.StripeProvider, logger *slog.Logger) *ReconciliationService {
+	return &ReconciliationService{pool: pool, stripe: stripe, logger: logger}
+}
+
+// ReconciliationReport is a run with its discrepancies.
+type ReconciliationReport struct {
+	Run           domain.ReconciliationRun           `json:"run"`
+	Discrepancies []domain.ReconciliationDiscrepancy `json:"discrepancies"`
+}
+
+// RunStripe reconciles Stripe balance transactions created in [from, to).
+// The run row is always written, and marked failed if Stripe or the DB errors.
+func (s *ReconciliationService) RunStripe(ctx context.Context, from, to time.Time) (*domain.ReconciliationRun, error) {
+	if !to.After(from) {
+		return nil, domain.ErrValidation("period end must be after start")
+	}
+
+	run := domain.ReconciliationRun{Provider: "stripe", PeriodStart: from, PeriodEnd: to, Status: "running"}
+	if err := s.pool.QueryRow(ctx, `
+		INSERT INTO reconciliation_runs (provider, period_start, period_end)
+		VALUES ($1, $2, $3) RETURNING id, started_at`,
+		run.Provider, from, to).Scan(&run.ID, &run.StartedAt); err != nil {
+		return nil, domain.ErrInternal("create reconciliation run", err)
+	}
+
+	result, records, err := s.matchStripe(ctx, from, to)
+	if err != nil {
+		msg := err.Error()
+		run.Status = "failed"
+		run.Error = &msg
+		_, _ = s.pool.Exec(ctx, `
+			UPDATE reconciliation_runs SET status = 'failed', error = $2, finished_at = now()
+			WHERE id = $1`, run.ID, msg)
+		s.logger.Error("stripe reconciliation failed", "run_id", run.ID, "error", err)
+		return &run, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, d := range result.Discrepancies {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO reconciliation_discrepancies
+				(run_id, kind, provider_ref, payment_id, expected_amount, actual_amount, detail)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)`,
+			run.ID, d.Kind, d.ProviderRef, d.PaymentID, d.ExpectedAmount, d.ActualAmount, d.Detail); err != nil {
+			return nil, domain.ErrInternal("insert discrepancy", err)
+		}
+	}
+
+	now := time.Now()
+	run.Status = "completed"
+	run.ProviderRecords = records
+	run.MatchedCount = result.Matched
+	run.DiscrepancyCount = len(result.Discrepancies)
+	run.FinishedAt = &now
+	if _, err := tx.Exec(ctx, `
+		UPDATE reconciliation_runs
+		SET status = 'completed', provider_records = $2, matched_count = $3, discrepancy_count = $4, finished_at = $5
+		WHERE id = $1`, run.ID, records, run.MatchedCount, run.DiscrepancyCount, now); err != nil {
+		return nil, domain.ErrInternal("finish reconciliation run", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	if run.DiscrepancyCount > 0 {
+		s.logger.Warn("stripe reconciliation found discrepancies",
+			"run_id", run.ID, "count", run.DiscrepancyCount, "matched", run.MatchedCount)
+	}
+	return &run, nil
+}
+
+// matchStripe pulls Stripe charges and the corresponding local deposits.
+// Returns the match result and the number of Stripe charge records examined.
+func (s *ReconciliationService) matchStripe(ctx context.Context, from, to time.Time) (reconciliation.Result, int, error) {
+	txs, err := s.stripe.ListBalanceTransactions(ctx, from, to)
+	if err != nil {
+		return reconciliation.Result{}, 0, err
+	}
+
+	var records []reconciliation.ProviderRecord
+	var refs []string
+	for _, t := range txs {
+		// Payouts, fees and refunds are not deposits; only charges are matched here.
+		if t.Type != "charge" && t.Type != "payment" {
+			continue
+		}
+		records = append(records, reconciliation.ProviderRecord{
+			ID:         t.ID,
+			PaymentRef: t.PaymentIntent,
+			Type:       t.Type,
+			Amount:     t.Amount,
+			Currency:   t.Currency,
+			Created:    time.Unix(t.Created, 0),
+		})
+		if t.PaymentIntent != "" {
+			refs = append(refs, t.PaymentIntent)
+		}
+	}
+
+	// Local side: deposits completed in the window plus any deposit Stripe
+	// referenced (the checkout may have been opened before the window).
+	rows, err := s.pool.Query(ctx, `
+		SELECT p.id, COALESCE(p.provider_payment_id, ''), p.amount::bigint, p.currency, p.status,
+		       p.transaction_id, t.amount::bigint
+		FROM payments p
+		LEFT JOIN v2_transactions t ON t.id = p.transaction_id
+		WHERE p.type = 'deposit' AND p.provider = 'stripe'
+		  AND ((p.status = 'completed' AND p.updated_at >= $1 AND p.updated_at < $2)
+		       OR p.provider_payment_id = ANY($3))`, from, to, refs)
+	if err != nil {
+		return reconciliation.Result{}, 0, err
+	}
+	defer rows.Close()
+
+	var payments []reconciliation.LocalPayment
+	for rows.Next() {
+		var p reconciliation.LocalPayment
+		if err := rows.Scan(&p.ID, &p.ProviderPaymentID, &p.Amount, &p.Currency, &p.Status,
+			&p.TransactionID, &p.LedgerAmount); err != nil {
+			return reconciliation.Result{}, 0, err
+		}
+		payments = append(payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		return reconciliation.Result{}, 0, err
+	}
+
+	return reconciliation.MatchDeposits(records, payments), len(records), nil
+}
+
+// ListRuns returns the most recent reconciliation runs.
+func (s *ReconciliationService) ListRuns(ctx context.Context) ([]domain.ReconciliationRun, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, provider, period_start, period_end, status, provider_records, matched_count,
+		       discrepancy_count, error, started_at, finished_at
+		FROM reconciliation_runs
+		ORDER BY started_at DESC LIMIT 50`)
+	if err != nil {
+		return nil, domain.ErrInternal("list reconciliation runs", err)
+	}
+	defer rows.Close()
+
+	runs := []domain.ReconciliationRun{}
+	for rows.Next() {
+		var r domain.ReconciliationRun
+		if err := rows.Scan(&r.ID, &r.Provider, &r.PeriodStart, &r.PeriodEnd, &r.Status, &r.ProviderRecords,
+			&r.MatchedCount, &r.DiscrepancyCount, &r.Error, &r.StartedAt, &r.FinishedAt); err != nil {
+			return nil, domain.ErrInternal("scan reconciliation run", err)
+		}
+		runs = append(runs, r)
+	}
+	return runs, nil
+}
+
+// GetReport returns a run and its discrepancies.
+func (s *ReconciliationService) GetReport(ctx context.Context, id uuid.UUID) (*ReconciliationReport, error) {
+	var rep ReconciliationReport
+	r := &rep.Run
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, provider, period_start, period_end, status, provider_records, matched_count,
+		       discrepancy_count, error, started_at, finished_at
+		FROM reconciliation_runs WHERE id = $1`, id).Scan(
+		&r.ID, &r.Provider, &r.PeriodStart, &r.PeriodEnd, &r.Status, &r.ProviderRecords,
+		&r.MatchedCount, &r.DiscrepancyCount, &r.Error, &r.StartedAt, &r.FinishedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("reconciliation run", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get reconciliation run", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, run_id, kind, provider_ref, payment_id, expected_amount, actual_amount, detail, created_at
+		FROM reconciliation_discrepancies WHERE run_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, domain.ErrInternal("list discrepancies", err)
+	}
+	defer rows.Close()
+
+	rep.Discrepancies = []domain.ReconciliationDiscrepancy{}
+	for rows.Next() {
+		var d domain.ReconciliationDiscrepancy
+		if err := rows.Scan(&d.ID, &d.RunID, &d.Kind, &d.ProviderRef, &d.PaymentID,
+			&d.ExpectedAmount, &d.ActualAmount, &d.Detail, &d.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan discrepancy", err)
+		}
+		rep.Discrepancies = append(rep.Discrepancies, d)
+	}
+	return &rep, nil
+}
+
+// StartDailyStripe reconciles the previous UTC day once every 24 hours.
+func (s *ReconciliationService) StartDailyStripe(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("stripe reconciliation stopped")
+				return
+			case <-ticker.C:
+				end := time.Now().UTC().Truncate(24 * time.Hour)
+				if _, err := s.RunStripe(ctx, end.Add(-24*time.Hour), end); err != nil {
+					s.logger.Error("stripe reconciliation run", "error", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RecoveryService runs the support-assisted account recovery workflow for players
+// locked out of their primary email: identity challenge → admin review →
+// forced password reset, with every step written to account_recovery_audit.
+// The reset token only ever goes to a backup email the player registered and
+// verified while signed in.
+type RecoveryService struct {
+	pool     *pgxpool.Pool
+	users    repository.AuthUserRepository
+	profiles repository.ProfileRepository
+	outbox   repository.OutboxRepository
+	logger   *slog.Logger
+}
+
+// NewRecoveryService creates a RecoveryService.
+func NewRecoveryService(
+	pool *pgxpool.Pool,
+	users repository.AuthUserRepository,
+	profiles repository.ProfileRepository,
+	outbox repository.OutboxRepository,
+	logger *slog.Logger,
+) *RecoveryService {
+	return &RecoveryService{pool: pool, users: users, profiles: profiles, outbox: outbox, logger: logger}
+}
+
+// backupEmailVerifyTTL is how long a backup email verification token is valid.
+const backupEmailVerifyTTL = 24 * time.Hour
+
+// GetBackupEmail returns the player's backup email, or nil if they have not
+// registered one.
+func (s *RecoveryService) GetBackupEmail(ctx context.Context, playerID uuid.UUID) (*domain.BackupEmail, error) {
+	var b domain.BackupEmail
+	err := s.pool.QueryRow(ctx, `
+		SELECT email, verified_at FROM player_backup_emails WHERE player_id = $1`, playerID,
+	).Scan(&b.Email, &b.VerifiedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find backup email", err)
+	}
+	b.Verified = b.VerifiedAt != nil
+	return &b, nil
+}
+
+// SetBackupEmail registers email as the player's backup email, unverified,
+// and sends it a verification token. Setting the address again resends the
+// token; a new address replaces the old one and its verification.
+func (s *RecoveryService) SetBackupEmail(ctx context.Context, playerID uuid.UUID, email string) (*domain.BackupEmail, error) {
+	email = strings.TrimSpace(email)
+	if err := domain.ValidateEmail(email); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+
+	var accountEmail string
+	err := s.pool.QueryRow(ctx, `SELECT email FROM auth_users WHERE id = $1`, playerID).Scan(&accountEmail)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find user", err)
+	}
+	if strings.EqualFold(accountEmail, email) {
+		return nil, domain.ErrValidation("backup email must differ from the account email")
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(backupEmailVerifyTTL)
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var b domain.BackupEmail
+	err = tx.QueryRow(ctx, `
+		INSERT INTO player_backup_emails (player_id, email, verification_token_hash, verification_expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (player_id) DO UPDATE SET
+			email = EXCLUDED.email,
+			verified_at = CASE WHEN player_backup_emails.email = EXCLUDED.email
+			                   THEN player_backup_emails.verified_at END,
+			verification_token_hash = EXCLUDED.verification_token_hash,
+			verification_expires_at = EXCLUDED.verification_expires_at,
+			updated_at = now()
+		RETURNING email, verified_at`,
+		playerID, email, tokenHash, expiresAt,
+	).Scan(&b.Email, &b.VerifiedAt)
+	if err != nil {
+		return nil, domain.ErrInternal("store backup email", err)
+	}
+	b.Verified = b.VerifiedAt != nil
+
+	if err := s.outbox.Insert(ctx, tx, domain.NewBackupEmailVerifyEvent(playerID, b.Email, token, expiresAt)); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return &b, nil
+}
+
+// VerifyBackupEmail confirms the player's backup email with the token sent
+// to it.
+func (s *RecoveryService) VerifyBackupEmail(ctx context.Context, playerID uuid.UUID, token string) (*domain.BackupEmail, error) {
+	hash := sha256.Sum256([]byte(token))
+
+	var b domain.BackupEmail
+	err := s.pool.QueryRow(ctx, `
+		UPDATE player_backup_emails
+		SET verified_at = now(), verification_token_hash = NULL, verification_expires_at = NULL, updated_at = now()
+		WHERE player_id = $1 AND verification_token_hash = $2 AND verification_expires_at > now()
+		RETURNING email, verified_at`,
+		playerID, hex.EncodeToString(hash[:]),
+	).Scan(&b.Email, &b.VerifiedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrValidation("invalid or expired verification token")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("verify backup email", err)
+	}
+	b.Verified = true
+	return &b, nil
+}
+
+// newVerificationToken returns a fresh 32-byte hex token and the hex SHA-256
+// hash it is stored as.
+func newVerificationToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", domain.ErrInternal("generate token", err)
+	}
+	token = hex.EncodeToString(raw)
+	sum := sha256.Sum256([]byte(token))
+	return token, hex.EncodeToString(sum[:]), nil
+}
+
+// RecoverySubmitInput holds a player's recovery request.
+type RecoverySubmitInput struct {
+	Email   string                 `json:"email"`
+	Answers policy.RecoveryAnswers `json:"answers"`
+	IP      string                 `json:"-"`
+}
+
+// Submit records a recovery request and scores the identity challenge. The
+// response never reveals whether the email exists, and accounts without a
+// verified backup email cannot be recovered this way.
+func (s *RecoveryService) Submit(ctx context.Context, input RecoverySubmitInput) error {
+	if err := domain.ValidateEmail(input.Email); err != nil {
+		return domain.ErrValidation(err.Error())
+	}
+
+	user, err := s.users.FindByEmail(ctx, s.pool, input.Email)
+	if err != nil {
+		return domain.ErrInternal("find user", err)
+	}
+	if user == nil {
+		s.logger.Info("recovery requested for unknown email", "ip", input.IP)
+		return nil
+	}
+
+	backup, err := s.GetBackupEmail(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if backup == nil || !backup.Verified {
+		s.logger.Info("recovery requested without a verified backup email", "player_id", user.ID, "ip", input.IP)
+		return nil
+	}
+
+	var pending int
+	if err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM account_recovery_requests
+		WHERE player_id = $1 AND status = 'pending'`, user.ID).Scan(&pending); err != nil {
+		return domain.ErrInternal("count pending recoveries", err)
+	}
+	if pending > 0 {
+		return nil
+	}
+
+	profile, err := s.profiles.FindByPlayerID(ctx, s.pool, user.ID)
+	if err != nil {
+		return domain.ErrInternal("find profile", err)
+	}
+	if profile == nil {
+		profile = &domain.PlayerProfile{PlayerID: user.ID}
+	}
+
+	var lastDeposit int64
+	_ = s.pool.QueryRow(ctx, `
+		SELECT amount::bigint FROM payments
+		WHERE player_id = $1 AND type = 'deposit' AND status = 'completed'
+		ORDER BY created_at DESC LIMIT 1`, user.ID).Scan(&lastDeposit)
+
+	challenge := policy.ScoreRecoveryChallenge(profile, input.Answers, lastDeposit)
+	challengeJSON, _ := json.Marshal(challenge)
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var requestID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO account_recovery_requests
+			(player_id, email, backup_email, challenge_score, challenge_result, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		user.ID, user.Email, backup.Email, challenge.Score, challengeJSON, input.IP,
+	).Scan(&requestID)
+	if err != nil {
+		return domain.ErrInternal("insert recovery request", err)
+	}
+
+	if err := s.audit(ctx, tx, requestID, "player", &user.ID, "submitted", map[string]interface{}{
+		"ip": input.IP, "challenge_score": challenge.Score, "matched": challenge.Matched,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	return nil
+}
+
+// List returns recovery requests filtered by status (all when empty).
+func (s *RecoveryService) List(ctx context.Context, status string) ([]domain.RecoveryRequest, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, player_id, email, backup_email, status, challenge_score, challenge_result,
+		       ip_address, reviewed_by, reviewed_at, decision_note, created_at
+		FROM account_recovery_requests
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC LIMIT 100`, status)
+	if err != nil {
+		return nil, domain.ErrInternal("list recovery requests", err)
+	}
+	defer rows.Close()
+
+	var requests []domain.RecoveryRequest
+	for rows.Next() {
+		req, err := scanRecoveryRequest(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan recovery request", err)
+		}
+		requests = append(requests, *req)
+	}
+	return requests, rows.Err()
+}
+
+// RecoveryDetail is a recovery request with its audit trail.
+type RecoveryDetail struct {
+	Request domain.RecoveryRequest      `json:"request"`
+	Audit   []domain.RecoveryAuditEntry `json:"audit"`
+}
+
+// Get returns a recovery request and its audit trail.
+func (s *RecoveryService) Get(ctx context.Context, id uuid.UUID) (*RecoveryDetail, error) {
+	req, err := s.find(ctx, s.pool, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, actor_type, actor_id, action, detail, created_at
+		FROM account_recovery_audit WHERE request_id = $1
+		ORDER BY created_at ASC, id ASC`, id)
+	if err != nil {
+		return nil, domain.ErrInternal("list recovery audit", err)
+	}
+	defer rows.Close()
+
+	detail := &RecoveryDetail{Request: *req}
+	for rows.Next() {
+		var e domain.RecoveryAuditEntry
+		if err := rows.Scan(&e.ID, &e.ActorType, &e.ActorID, &e.Action, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan recovery audit", err)
+		}
+		detail.Audit = append(detail.Audit, e)
+	}
+	return detail, rows.Err()
+}
+
+// RecoveryApproval is returned to support after approving a request. The reset
+// token itself is emailed to the player's verified backup email, never to
+// support.
+type RecoveryApproval struct {
+	RequestID       uuid.UUID `json:"request_id"`
+	BackupEmail     string    `json:"backup_email"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	SessionsRevoked int64     `json:"sessions_revoked"`
+	TokensRevokedAt time.Time `json:"tokens_revoked_at"`
+}
+
+// recoveryTokenTTL is longer than the self-service reset window because the
+// player may be slow to reach their backup inbox.
+const recoveryTokenTTL = 24 * time.Hour
+
+// Approve forces a password reset on the account, signs out every session
+// and revokes every JWT issued so far, as ReportNotMe does, and emails a
+// reset token to the backup email the request was made with. Requests below
+// policy.RecoveryPassScore need override=true and a note.
+func (s *RecoveryService) Approve(ctx context.Context, id, adminID uuid.UUID, note string, override bool) (*RecoveryApproval, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	req, err := s.find(ctx, tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != domain.RecoveryStatusPending {
+		return nil, domain.ErrConflict("recovery request already " + string(req.Status))
+	}
+	if req.ChallengeScore < policy.RecoveryPassScore && (!override || note == "") {
+		return nil, domain.ErrValidation("identity challenge below pass score; override with a decision note")
+	}
+
+	// The backup email must still be the player's verified one: it may have
+	// changed since the request was made.
+	var verified bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM player_backup_emails
+		               WHERE player_id = $1 AND email = $2 AND verified_at IS NOT NULL)`,
+		req.PlayerID, req.BackupEmail).Scan(&verified); err != nil {
+		return nil, domain.ErrInternal("check backup email", err)
+	}
+	if !verified {
+		return nil, domain.ErrConflict("backup email is no longer verified for this account")
+	}
+
+	if err := s.users.SetPasswordResetRequired(ctx, tx, req.Email); err != nil {
+		return nil, domain.ErrInternal("force password reset", err)
+	}
+
+	revokedAt, err := s.users.RevokeTokens(ctx, tx, req.PlayerID)
+	if err != nil {
+		return nil, domain.ErrInternal("revoke tokens", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM sessions WHERE player_id = $1`, req.PlayerID)
+	if err != nil {
+		return nil, domain.ErrInternal("delete sessions", err)
+	}
+
+	if err := s.outbox.Insert(ctx, tx, domain.NewSessionRevokedEvent(req.PlayerID, "account_recovery", tag.RowsAffected())); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+
+	// Invalidate any outstanding self-service tokens before issuing ours.
+	if _, err := tx.Exec(ctx, `
+		UPDATE password_reset_tokens SET used_at = now()
+		WHERE email = $1 AND realm = 'player' AND used_at IS NULL`, req.Email); err != nil {
+		return nil, domain.ErrInternal("invalidate reset tokens", err)
+	}
+
+	token, err := issuePasswordResetToken(ctx, tx, req.Email, recoveryTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(recoveryTokenTTL)
+
+	if err := s.outbox.Insert(ctx, tx, domain.NewRecoveryResetIssuedEvent(req.PlayerID, id, req.BackupEmail, token, expiresAt)); err != nil {
+		return nil, domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := s.review(ctx, tx, id, adminID, domain.RecoveryStatusApproved, note); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, tx, id, "admin", &adminID, "approved", map[string]interface{}{
+		"note": note, "override": override, "challenge_score": req.ChallengeScore,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, tx, id, "system", nil, "password_reset_forced", map[string]interface{}{
+		"backup_email": req.BackupEmail, "sessions_revoked": tag.RowsAffected(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Info("account recovery approved", "request_id", id, "player_id", req.PlayerID, "admin_id", adminID)
+	return &RecoveryApproval{
+		RequestID:       id,
+		BackupEmail:     req.BackupEmail,
+		ExpiresAt:       expiresAt,
+		SessionsRevoked: tag.RowsAffected(),
+		TokensRevokedAt: revokedAt,
+	}, nil
+}
+
+// Reject closes a pending recovery request without changing the account.
+func (s *RecoveryService) Reject(ctx context.Context, id, adminID uuid.UUID, note string) error {
+	if note == "" {
+		return domain.ErrValidation("decision note is required")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	req, err := s.find(ctx, tx, id, true)
+	if err != nil {
+		return err
+	}
+	if req.Status != domain.RecoveryStatusPending {
+		return domain.ErrConflict("recovery request already " + string(req.Status))
+	}
+
+	if err := s.review(ctx, tx, id, adminID, domain.RecoveryStatusRejected, note); err != nil {
+		return err
+	}
+	if err := s.audit(ctx, tx, id, "admin", &adminID, "rejected", map[string]interface{}{"note": note}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	return nil
+}
+
+func (s *RecoveryService) find(ctx context.Context, db repository.DBTX, id uuid.UUID, forUpdate bool) (*domain.RecoveryRequest, error) {
+	query := `
+		SELECT id, player_id, email, backup_email, status, challenge_score, challenge_result,
+		       ip_address, reviewed_by, reviewed_at, decision_note, created_at
+		FROM account_recovery_requests WHERE id = $1`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	req, err := scanRecoveryRequest(db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, domain.ErrNotFound("recovery request", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find recovery request", err)
+	}
+	return req, nil
+}
+
+func (s *RecoveryService) review(ctx context.Context, tx pgx.Tx, id, adminID uuid.UUID, status domain.RecoveryStatus, note string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE account_recovery_requests
+		SET status = $2, reviewed_by = $3, reviewed_at = now(), decision_note = $4, updated_at = now()
+		WHERE id = $1`, id, string(status), adminID, note)
+	if err != nil {
+		return domain.ErrInternal("update recovery request", err)
+	}
+	return nil
+}
+
+func (s *RecoveryService) audit(ctx context.Context, db repository.DBTX, requestID uuid.UUID, actorType string, actorID *uuid.UUID, action string, detail map[string]interface{}) error {
+	detailJSON, _ := json.Marshal(detail)
+	_, err := db.Exec(ctx, `
+		INSERT INTO account_recovery_audit (request_id, actor_type, actor_id, action, detail)
+		VALUES ($1, $2, $3, $4, $5)`,
+		requestID, actorType, actorID, action, detailJSON)
+	if err != nil {
+		return domain.ErrInternal("write recovery audit", err)
+	}
+	return nil
+}
+
+func scanRecoveryRequest(row pgx.Row) (*domain.RecoveryRequest, error) {
+	var r domain.RecoveryRequest
+	err := row.Scan(&r.ID, &r.PlayerID, &r.Email, &r.BackupEmail, &r.Status, &r.ChallengeScore,
+		&r.ChallengeResult, &r.IPAddress, &r.ReviewedBy, &r.ReviewedAt, &r.DecisionNote, &r.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// RefundInput holds an admin refund request.
+type RefundInput struct {
+	Amount int64  `json:"amount"` // cents; 0 refunds the remaining deposit
+	Reason string `json:"reason"`
+}
+
+const refundColumns = `id, payment_id, player_id, amount::bigint, currency, status, reason, provider,
+	provider_refund_id, failure_reason, requested_by, transaction_id, reversal_transaction_id,
+	created_at, updated_at`
+
+// RefundDeposit refunds a completed Stripe deposit. The player's balance is
+// debited first; if the PSP rejects the refund the debit is reversed.
+func (s *PaymentService) RefundDeposit(ctx context.Context, paymentID uuid.UUID, input RefundInput, adminID uuid.UUID) (*domain.PaymentRefund, error) {
+	payment, err := s.payments.FindByID(ctx, s.pool, paymentID)
+	if err != nil {
+		return nil, domain.ErrInternal("find payment", err)
+	}
+	if payment == nil {
+		return nil, domain.ErrNotFound("payment", paymentID.String())
+	}
+	if payment.Type != domain.PaymentTypeDeposit || payment.Status != domain.PaymentStatusCompleted {
+		return nil, domain.ErrValidation("only completed deposits can be refunded")
+	}
+	if payment.Provider == nil || *payment.Provider != "stripe" || payment.ProviderPaymentID == nil || payment.TransactionID == nil {
+		return nil, domain.ErrValidation("payment has no refundable stripe charge")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	player, err := s.engine.LockPlayerForUpdate(ctx, tx, payment.PlayerID)
+	if err != nil {
+		return nil, domain.ErrInternal("lock player", err)
+	}
+
+	check := policy.RefundCheck{
+		DepositAmount: payment.Amount,
+		RefundAmount:  input.Amount,
+		Balance:       player.Balance,
+	}
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount), 0)::bigint FROM payment_refunds
+		WHERE payment_id = $1 AND status IN ('pending', 'succeeded')`, paymentID,
+	).Scan(&check.AlreadyRefunded); err != nil {
+		return nil, domain.ErrInternal("sum refunds", err)
+	}
+	if err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount), 0)::bigint FROM v2_transactions
+		WHERE player_id = $1 AND type = 'bet' AND created_at >= $2`, payment.PlayerID, payment.CreatedAt,
+	).Scan(&check.WageredSinceDeposit); err != nil {
+		return nil, domain.ErrInternal("sum wagers", err)
+	}
+	if check.RefundAmount == 0 {
+		check.RefundAmount = payment.Amount - check.AlreadyRefunded
+	}
+
+	decision := policy.EvaluateDepositRefund(check)
+	if !decision.Allowed {
+		return nil, &domain.AppError{
+			Code:    "REFUND_BLOCKED",
+			Message: fmt.Sprintf("refund blocked: %s", decision.Reason),
+			Details: map[string]interface{}{"reason": decision.Reason, "check": check},
+			Status:  422,
+		}
+	}
+
+	refund := &domain.PaymentRefund{
+		ID:          uuid.New(),
+		PaymentID:   payment.ID,
+		PlayerID:    payment.PlayerID,
+		Amount:      check.RefundAmount,
+		Currency:    payment.Currency,
+		Status:      domain.RefundStatusPending,
+		Reason:      input.Reason,
+		Provider:    "stripe",
+		RequestedBy: &adminID,
+	}
+
+	result, err := s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+		PlayerID:              payment.PlayerID,
+		Amount:                refund.Amount,
+		ExternalTransactionID: "refund_" + refund.ID.String(),
+		ManufacturerID:        "stripe",
+		SubTransactionID:      "1",
+		TargetTransactionID:   *payment.TransactionID,
+		Metadata:              json.RawMessage(`{"provider":"stripe","reason":"refund"}`),
+	})
+	if err != nil {
+		return nil, err
+	}
+	refund.TransactionID = &result.Transaction.ID
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO payment_refunds (id, payment_id, player_id, amount, currency, status, reason,
+			provider, requested_by, transaction_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at`,
+		refund.ID, refund.PaymentID, refund.PlayerID, refund.Amount, refund.Currency,
+		refund.Status, refund.Reason, refund.Provider, refund.RequestedBy, refund.TransactionID,
+	).Scan(&refund.CreatedAt, &refund.UpdatedAt)
+	if err != nil {
+		return nil, domain.ErrInternal("record refund", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	psp, err := s.stripe.CreateRefund(ctx, *payment.ProviderPaymentID, refund.Amount, refund.ID.String(), "refund_"+refund.ID.String())
+	if err != nil {
+		s.logger.Error("stripe refund failed", "error", err, "refund_id", refund.ID)
+		if rerr := s.failRefund(ctx, refund.ID, err.Error()); rerr != nil {
+			return nil, rerr
+		}
+		s.recordEvent(ctx, payment.ID, payment.Status, "refund rejected by stripe", nil)
+		return nil, &domain.AppError{
+			Code:    "REFUND_FAILED",
+			Message: "payment provider rejected the refund",
+			Status:  502,
+			Cause:   err,
+		}
+	}
+
+	if err := s.applyProviderRefund(ctx, refund.ID, psp); err != nil {
+		return nil, err
+	}
+	s.recordEvent(ctx, payment.ID, payment.Status, fmt.Sprintf("refund of %d requested", refund.Amount), nil)
+
+	return s.getRefund(ctx, s.pool, refund.ID)
+}
+
+// ListRefunds returns the refunds raised against a payment.
+func (s *PaymentService) ListRefunds(ctx context.Context, paymentID uuid.UUID) ([]domain.PaymentRefund, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+refundColumns+` FROM payment_refunds
+		WHERE payment_id = $1 ORDER BY created_at DESC`, paymentID)
+	if err != nil {
+		return nil, domain.ErrInternal("list refunds", err)
+	}
+	defer rows.Close()
+
+	refunds := []domain.PaymentRefund{}
+	for rows.Next() {
+		rf, err := scanRefund(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan refund", err)
+		}
+		refunds = append(refunds, *rf)
+	}
+	return refunds, rows.Err()
+}
+
+// handleRefundUpdated tracks refund.* webhook events. Failed or cancelled
+// refunds credit the player back.
+func (s *PaymentService) handleRefundUpdated(ctx context.Context, event *provider.StripeWebhookEvent) error {
+	data, err := provider.ParseRefundData(event.Data)
+	if err != nil {
+		return domain.ErrInternal("parse refund", err)
+	}
+
+	var refundID uuid.UUID
+	err = s.pool.QueryRow(ctx, `SELECT id FROM payment_refunds WHERE provider_refund_id = $1`, data.ID).Scan(&refundID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The webhook may beat our own write of provider_refund_id.
+		refundID, err = uuid.Parse(data.Metadata["refund_id"])
+		if err != nil {
+			s.logger.Warn("refund not found for stripe refund", "stripe_refund_id", data.ID)
+			return nil
+		}
+	} else if err != nil {
+		return domain.ErrInternal("find refund", err)
+	}
+
+	return s.applyProviderRefund(ctx, refundID, data)
+}
+
+// applyProviderRefund records the PSP's view of a refund and settles the
+// local state when it reaches a terminal status.
+func (s *PaymentService) applyProviderRefund(ctx context.Context, refundID uuid.UUID, psp *provider.StripeRefund) error {
+	switch psp.Status {
+	case "failed", "canceled":
+		reason := psp.FailureReason
+		if reason == "" {
+			reason = psp.Status
+		}
+		if _, err := s.pool.Exec(ctx, `
+			UPDATE payment_refunds SET provider_refund_id = $2, updated_at = now()
+			WHERE id = $1 AND provider_refund_id IS NULL`, refundID, psp.ID); err != nil {
+			return domain.ErrInternal("update refund", err)
+		}
+		return s.failRefund(ctx, refundID, reason)
+	case "succeeded":
+		tag, err := s.pool.Exec(ctx, `
+			UPDATE payment_refunds SET status = 'succeeded', provider_refund_id = $2, updated_at = now()
+			WHERE id = $1 AND status = 'pending'`, refundID, psp.ID)
+		if err != nil {
+			return domain.ErrInternal("update refund", err)
+		}
+		if tag.RowsAffected() > 0 {
+			s.recordRefundEvent(ctx, refundID, "refund succeeded")
+		}
+	default:
+		if _, err := s.pool.Exec(ctx, `
+			UPDATE payment_refunds SET provider_refund_id = $2, updated_at = now()
+			WHERE id = $1 AND status = 'pending'`, refundID, psp.ID); err != nil {
+			return domain.ErrInternal("update refund", err)
+		}
+	}
+	return nil
+}
+
+// failRefund marks a pending refund failed and credits the debited amount
+// back to the player. Repeated calls are no-ops.
+func (s *PaymentService) failRefund(ctx context.Context, refundID uuid.UUID, reason string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	refund, err := s.lockRefund(ctx, tx, refundID)
+	if err != nil {
+		return err
+	}
+	if refund.Status != domain.RefundStatusPending {
+		return nil
+	}
+
+	meta, _ := json.Marshal(map[string]string{"provider": "stripe", "reason": "refund_reversal", "refund_id": refund.ID.String()})
+	result, err := s.engine.ExecuteDeposit(ctx, tx, domain.DepositParams{
+		PlayerID:              refund.PlayerID,
+		Amount:                refund.Amount,
+		ExternalTransactionID: "refund_reversal_" + refund.ID.String(),
+		ManufacturerID:        "stripe",
+		SubTransactionID:      "1",
+		Metadata:              meta,
+	})
+	if err != nil {
+		return domain.ErrInternal("reverse refund debit", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE payment_refunds
+		SET status = 'failed', failure_reason = $2, reversal_transaction_id = $3, updated_at = $4
+		WHERE id = $1`, refund.ID, reason, result.Transaction.ID, time.Now()); err != nil {
+		return domain.ErrInternal("update refund", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+
+	s.recordRefundEvent(ctx, refund.ID, "refund failed: "+reason)
+	s.logger.Warn("refund failed, balance restored", "refund_id", refund.ID, "player_id", refund.PlayerID, "reason", reason)
+	return nil
+}
+
+func (s *PaymentService) recordRefundEvent(ctx context.Context, refundID uuid.UUID, message string) {
+	refund, err := s.getRefund(ctx, s.pool, refundID)
+	if err != nil {
+		s.logger.Error("load refund for event", "error", err, "refund_id", refundID)
+		return
+	}
+	s.recordEvent(ctx, refund.PaymentID, domain.PaymentStatusCompleted, message, nil)
+}
+
+func (s *PaymentService) getRefund(ctx context.Context, db repository.DBTX, id uuid.UUID) (*domain.PaymentRefund, error) {
+	return s.queryRefund(ctx, db, `SELECT `+refundColumns+` FROM payment_refunds WHERE id = $1`, id)
+}
+
+func (s *PaymentService) lockRefund(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*domain.PaymentRefund, error) {
+	return s.queryRefund(ctx, tx, `SELECT `+refundColumns+` FROM payment_refunds WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (s *PaymentService) queryRefund(ctx context.Context, db repository.DBTX, query string, id uuid.UUID) (*domain.PaymentRefund, error) {
+	rf, err := scanRefund(db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("refund", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get refund", err)
+	}
+	return rf, nil
+}
+
+func scanRefund(row pgx.Row) (*domain.PaymentRefund, error) {
+	var rf domain.PaymentRefund
+	err := row.Scan(&rf.ID, &rf.PaymentID, &rf.PlayerID, &rf.Amount, &rf.Currency, &rf.Status,
+		&rf.Reason, &rf.Provider, &rf.ProviderRefundID, &rf.FailureReason, &rf.RequestedBy,
+		&rf.TransactionID, &rf.ReversalTransactionID, &rf.CreatedAt, &rf.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rf, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// retentionBatchSize bounds the rows each delete or redact statement
+// touches, so a large backlog does not hold long locks.
+const retentionBatchSize = 5000
+
+// retentionTargets maps each policy to the rows it covers: a WHERE clause on
+// its table with the cutoff as $1, and the SET clause for redact policies.
+// The cutoff is cast to timestamptz because some of the older tables use
+// timestamp columns.
+var retentionTargets = map[string]struct {
+	where string
+	set   string
+}{
+	policy.RetentionProviderCallbacks: {
+		where: `received_at < $1::timestamptz AND body <> ''`,
+		set:   `body = ''`,
+	},
+	policy.RetentionPlacementEvents: {
+		where: `created_at < $1::timestamptz`,
+	},
+	policy.RetentionExperimentExposures: {
+		where: `exposed_at < $1::timestamptz`,
+	},
+	// A conversation counts as closed once nothing has been said in it
+	// since the cutoff.
+	policy.RetentionAIConversations: {
+		where: `COALESCE(updated_at, created_at) < $1::timestamptz
+		    AND NOT EXISTS (SELECT 1 FROM ai_messages m
+		                    WHERE m.conversation_id = ai_conversations.id AND m.created_at >= $1::timestamptz)`,
+	},
+}
+
+// RetentionService deletes or redacts data past its retention period and
+// records every run in retention_runs.
+type RetentionService struct {
+	pool     *pgxpool.Pool
+	policies []policy.RetentionPolicy
+	logger   *slog.Logger
+}
+
+// NewRetentionService creates a RetentionService applying periods.
+func NewRetentionService(pool *pgxpool.Pool, periods policy.RetentionPeriods, logger *slog.Logger) *RetentionService {
+	return &RetentionService{pool: pool, policies: policy.RetentionPolicies(periods), logger: logger}
+}
+
+// Policies returns the retention policies in force.
+func (s *RetentionService) Policies() []policy.RetentionPolicy {
+	return s.policies
+}
+
+// Run applies every policy. A dry run only counts the rows each policy would
+// touch. The run and its per-policy counts are recorded either way; a policy
+// that fails marks the run failed and stops it.
+func (s *RetentionService) Run(ctx context.Context, dryRun bool) (*domain.RetentionRun, error) {
+	run := &domain.RetentionRun{DryRun: dryRun, Status: "running", Items: []domain.RetentionRunItem{}}
+	if err := s.pool.QueryRow(ctx, `
+		INSERT INTO retention_runs (dry_run) VALUES ($1) RETURNING id, started_at`,
+		dryRun).Scan(&run.ID, &run.StartedAt); err != nil {
+		return nil, domain.ErrInternal("start retention run", err)
+	}
+
+	now := time.Now()
+	var runErr error
+	for _, p := range s.policies {
+		item := domain.RetentionRunItem{
+			Policy: p.Name, Table: p.Table, Action: p.Action, Cutoff: policy.RetentionCutoff(p, now),
+		}
+		item.Affected, runErr = s.apply(ctx, p, item.Cutoff, dryRun)
+		if runErr != nil {
+			runErr = fmt.Errorf("%s: %w", p.Name, runErr)
+			break
+		}
+		if _, err := s.pool.Exec(ctx, `
+			INSERT INTO retention_run_items (run_id, policy, table_name, action, cutoff, affected)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			run.ID, item.Policy, item.Table, item.Action, item.Cutoff, item.Affected); err != nil {
+			runErr = fmt.Errorf("record %s: %w", p.Name, err)
+			break
+		}
+		run.Items = append(run.Items, item)
+	}
+
+	run.Status = "completed"
+	if runErr != nil {
+		run.Status = "failed"
+		msg := runErr.Error()
+		run.Error = &msg
+	}
+	if err := s.pool.QueryRow(ctx, `
+		UPDATE retention_runs SET status = $2, error = $3, finished_at = now()
+		WHERE id = $1 RETURNING finished_at`,
+		run.ID, run.Status, run.Error).Scan(&run.FinishedAt); err != nil {
+		return nil, domain.ErrInternal("finish retention run", err)
+	}
+	if runErr != nil {
+		return run, domain.ErrInternal("retention run", runErr)
+	}
+	return run, nil
+}
+
+// apply counts (dry run) or deletes/redacts the policy's rows older than
+// cutoff, in batches.
+func (s *RetentionService) apply(ctx context.Context, p policy.RetentionPolicy, cutoff time.Time, dryRun bool) (int64, error) {
+	target, ok := retentionTargets[p.Name]
+	if !ok {
+		return 0, fmt.Errorf("no retention target for policy")
+	}
+	if dryRun {
+		var n int64
+		err := s.pool.QueryRow(ctx,
+			`SELECT count(*) FROM `+p.Table+` WHERE `+target.where, cutoff).Scan(&n)
+		return n, err
+	}
+
+	batch := `DELETE FROM ` + p.Table
+	if p.Action == policy.RetentionRedact {
+		batch = `UPDATE ` + p.Table + ` SET ` + target.set
+	}
+	batch += ` WHERE ctid IN (SELECT ctid FROM ` + p.Table + ` WHERE ` + target.where + ` LIMIT $2)`
+
+	var total int64
+	for {
+		tag, err := s.pool.Exec(ctx, batch, cutoff, retentionBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += tag.RowsAffected()
+		if tag.RowsAffected() < retentionBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// ListRuns returns recent retention runs with their per-policy counts,
+// newest first.
+func (s *RetentionService) ListRuns(ctx context.Context, limit int) ([]domain.RetentionRun, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, dry_run, status, error, started_at, finished_at
+		FROM retention_runs ORDER BY started_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list retention runs", err)
+	}
+	runs := []domain.RetentionRun{}
+	index := map[uuid.UUID]int{}
+	for rows.Next() {
+		r := domain.RetentionRun{Items: []domain.RetentionRunItem{}}
+		if err := rows.Scan(&r.ID, &r.DryRun, &r.Status, &r.Error, &r.StartedAt, &r.FinishedAt); err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan retention run", err)
+		}
+		index[r.ID] = len(runs)
+		runs = append(runs, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("list retention runs", err)
+	}
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	ids := make([]uuid.UUID, len(runs))
+	for i, r := range runs {
+		ids[i] = r.ID
+	}
+	rows, err = s.pool.Query(ctx, `
+		SELECT run_id, policy, table_name, action, cutoff, affected
+		FROM retention_run_items WHERE run_id = ANY($1) ORDER BY policy`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("list retention run items", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var runID uuid.UUID
+		var item domain.RetentionRunItem
+		if err := rows.Scan(&runID, &item.Policy, &item.Table, &item.Action, &item.Cutoff, &item.Affected); err != nil {
+			return nil, domain.ErrInternal("scan retention run item", err)
+		}
+		r := &runs[index[runID]]
+		r.Items = append(r.Items, item)
+	}
+	return runs, rows.Err()
+}
+
+// StartScheduler runs the retention policies every interval until ctx is
+// done. With dryRun set the worker only reports what it would remove.
+func (s *RetentionService) StartScheduler(ctx context.Context, interval time.Duration, dryRun bool) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("retention worker stopped")
+				return
+			case <-ticker.C:
+				run, err := s.Run(ctx, dryRun)
+				if err != nil {
+					s.logger.Error("retention run", "error", err)
+					continue
+				}
+				for _, item := range run.Items {
+					s.logger.Info("retention policy applied", "run_id", run.ID, "dry_run", dryRun,
+						"policy", item.Policy, "action", item.Action, "affected", item.Affected)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rgWorklistWindow is how far back risk scores rank players on the worklist.
+const rgWorklistWindow = 30 * 24 * time.Hour
+
+// rgCaseContextWindow is how much activity before a case opened is included
+// in its exported history, so the export shows why the case was raised.
+const rgCaseContextWindow = 30 * 24 * time.Hour
+
+// RGCaseService runs the responsible-gambling case workflow: compliance
+// agents open a case on a player, take ownership, log every contact and close
+// it with an outcome. Closing as self-excluded or account closed changes the
+// player's status in the same transaction.
+type RGCaseService struct {
+	pool            *pgxpool.Pool
+	txRepo          repository.TransactionRepository
+	statusSvc       *PlayerStatusService
+	interventionSvc *NetLossInterventionService
+	riskSvc         *RGRiskService
+	logger          *slog.Logger
+}
+
+// NewRGCaseService creates an RGCaseService.
+func NewRGCaseService(
+	pool *pgxpool.Pool,
+	txRepo repository.TransactionRepository,
+	statusSvc *PlayerStatusService,
+	interventionSvc *NetLossInterventionService,
+	riskSvc *RGRiskService,
+	logger *slog.Logger,
+) *RGCaseService {
+	return &RGCaseService{
+		pool:            pool,
+		txRepo:          txRepo,
+		statusSvc:       statusSvc,
+		interventionSvc: interventionSvc,
+		riskSvc:         riskSvc,
+		logger:          logger,
+	}
+}
+
+// OpenRGCaseInput opens a case on a player.
+type OpenRGCaseInput struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Priority string    `json:"priority"` // low, medium (default), high
+	Source   string    `json:"source"`   // risk_score, intervention, manual (default)
+	Reason   string    `json:"reason"`
+}
+
+// ListPlayers returns players with scored sessions in the last 30 days,
+// riskiest first, with their pending interventions and open case. band
+// filters on each player's riskiest session.
+func (s *RGCaseService) ListPlayers(ctx context.Context, band string, limit int) ([]domain.RGCasePlayer, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		WITH risk AS (
+			SELECT player_id, MAX(score) AS max_score,
+			       (ARRAY_AGG(band ORDER BY score DESC))[1] AS band,
+			       COUNT(*) AS sessions, MAX(session_end) AS last_session_at
+			FROM rg_risk_scores
+			WHERE session_start >= $1
+			GROUP BY player_id
+		)
+		SELECT r.player_id, COALESCE(pp.email, ''), COALESCE(pp.account_status, 'active'),
+		       r.max_score, r.band, r.sessions, r.last_session_at,
+		       (SELECT COUNT(*) FROM rg_interventions i
+		        WHERE i.player_id = r.player_id AND i.acknowledged_at IS NULL),
+		       c.id, c.status
+		FROM risk r
+		LEFT JOIN player_profiles pp ON pp.player_id = r.player_id
+		LEFT JOIN rg_cases c ON c.player_id = r.player_id AND c.status <> 'closed'
+		WHERE ($2 = '' OR r.band = $2)
+		ORDER BY r.max_score DESC, r.last_session_at DESC
+		LIMIT $3`, time.Now().Add(-rgWorklistWindow), band, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("query rg worklist", err)
+	}
+	defer rows.Close()
+
+	out := []domain.RGCasePlayer{}
+	for rows.Next() {
+		var p domain.RGCasePlayer
+		if err := rows.Scan(&p.PlayerID, &p.Email, &p.AccountStatus, &p.MaxScore, &p.Band, &p.Sessions,
+			&p.LastSessionAt, &p.PendingInterventions, &p.OpenCaseID, &p.OpenCaseStatus); err != nil {
+			return nil, domain.ErrInternal("scan rg worklist", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// Open opens a case. A player has at most one case that is not closed.
+func (s *RGCaseService) Open(ctx context.Context, input OpenRGCaseInput, adminID uuid.UUID) (*domain.RGCase, error) {
+	if input.PlayerID == uuid.Nil {
+		return nil, domain.ErrValidation("player_id is required")
+	}
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		return nil, domain.ErrValidation("reason is required")
+	}
+	if input.Priority == "" {
+		input.Priority = string(policy.RiskMedium)
+	}
+	switch policy.RiskLevel(input.Priority) {
+	case policy.RiskLow, policy.RiskMedium, policy.RiskHigh:
+	default:
+		return nil, domain.ErrValidation("priority must be low, medium or high")
+	}
+	if input.Source == "" {
+		input.Source = domain.RGCaseSourceManual
+	}
+	switch input.Source {
+	case domain.RGCaseSourceRiskScore, domain.RGCaseSourceIntervention, domain.RGCaseSourceManual:
+	default:
+		return nil, domain.ErrValidation("source must be risk_score, intervention or manual")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "rg_case:"+input.PlayerID.String()); err != nil {
+		return nil, domain.ErrInternal("lock player cases", err)
+	}
+	var playerExists, caseOpen bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM v2_players WHERE id = $1),
+		       EXISTS (SELECT 1 FROM rg_cases WHERE player_id = $1 AND status <> 'closed')`,
+		input.PlayerID).Scan(&playerExists, &caseOpen); err != nil {
+		return nil, domain.ErrInternal("check player cases", err)
+	}
+	if !playerExists {
+		return nil, domain.ErrNotFound("player", input.PlayerID.String())
+	}
+	if caseOpen {
+		return nil, domain.ErrConflict("player already has an open RG case")
+	}
+
+	c, err := scanRGCase(tx.QueryRow(ctx, `
+		INSERT INTO rg_cases (player_id, priority, source, reason, opened_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+rgCaseColumns,
+		input.PlayerID, input.Priority, input.Source, input.Reason, adminID))
+	if err != nil {
+		return nil, domain.ErrInternal("insert rg case", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("rg case opened", "case_id", c.ID, "player_id", c.PlayerID, "source", c.Source, "admin_id", adminID)
+	return c, nil
+}
+
+// Assign hands a case to an agent and moves it to in_progress.
+func (s *RGCaseService) Assign(ctx context.Context, id, assignee, adminID uuid.UUID) (*domain.RGCase, error) {
+	if assignee == uuid.Nil {
+		return nil, domain.ErrValidation("assignee is required")
+	}
+	return s.update(ctx, id, func(tx pgx.Tx, c *domain.RGCase) (*domain.RGCase, error) {
+		updated, err := scanRGCase(tx.QueryRow(ctx, `
+			UPDATE rg_cases SET assigned_to = $2, status = 'in_progress', updated_at = now()
+			WHERE id = $1
+			RETURNING `+rgCaseColumns, id, assignee))
+		if err != nil {
+			return nil, domain.ErrInternal("assign rg case", err)
+		}
+		summary := "assigned to " + assignee.String()
+		if c.AssignedTo != nil {
+			summary = fmt.Sprintf("reassigned from %s to %s", c.AssignedTo, assignee)
+		}
+		return updated, s.insertInteraction(ctx, tx, id, adminID, domain.RGInteractionAssignment, summary, nil)
+	})
+}
+
+// AddInteraction records a contact with the player or an internal note.
+func (s *RGCaseService) AddInteraction(ctx context.Context, id, adminID uuid.UUID, kind, summary string) (*domain.RGCaseInteraction, error) {
+	switch kind {
+	case domain.RGInteractionNote, domain.RGInteractionCall, domain.RGInteractionEmail, domain.RGInteractionChat:
+	default:
+		return nil, domain.ErrValidation("kind must be note, call, email or chat")
+	}
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return nil, domain.ErrValidation("summary is required")
+	}
+
+	var interaction domain.RGCaseInteraction
+	_, err := s.update(ctx, id, func(tx pgx.Tx, c *domain.RGCase) (*domain.RGCase, error) {
+		if _, err := tx.Exec(ctx, `UPDATE rg_cases SET updated_at = now() WHERE id = $1`, id); err != nil {
+			return nil, domain.ErrInternal("touch rg case", err)
+		}
+		return c, s.insertInteraction(ctx, tx, id, adminID, kind, summary, &interaction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &interaction, nil
+}
+
+// Close resolves a case with an outcome. Self-excluded and account-closed
+// outcomes move the player to that status unless they are already there.
+func (s *RGCaseService) Close(ctx context.Context, id, adminID uuid.UUID, outcome domain.RGCaseOutcome, note string) (*domain.RGCase, error) {
+	if !outcome.Valid() {
+		return nil, domain.ErrValidation("outcome must be no_action, monitoring, limits_set, self_excluded or account_closed")
+	}
+	note = strings.TrimSpace(note)
+
+	return s.update(ctx, id, func(tx pgx.Tx, c *domain.RGCase) (*domain.RGCase, error) {
+		var target domain.AccountStatus
+		switch outcome {
+		case domain.RGOutcomeSelfExcluded:
+			target = domain.AccountStatusSelfExcluded
+		case domain.RGOutcomeAccountClosed:
+			target = domain.AccountStatusClosed
+		}
+		if target != "" {
+			if err := s.applyOutcomeStatus(ctx, tx, c, target, adminID); err != nil {
+				return nil, err
+			}
+		}
+
+		var outcomeNote *string
+		if note != "" {
+			outcomeNote = &note
+		}
+		closed, err := scanRGCase(tx.QueryRow(ctx, `
+			UPDATE rg_cases SET status = 'closed', outcome = $2, outcome_note = $3,
+				closed_at = now(), updated_at = now()
+			WHERE id = $1
+			RETURNING `+rgCaseColumns, id, outcome, outcomeNote))
+		if err != nil {
+			return nil, domain.ErrInternal("close rg case", err)
+		}
+		summary := "closed: " + string(outcome)
+		if note != "" {
+			summary += ": " + note
+		}
+		return closed, s.insertInteraction(ctx, tx, id, adminID, domain.RGInteractionStatusChange, summary, nil)
+	})
+}
+
+func (s *RGCaseService) applyOutcomeStatus(ctx context.Context, tx pgx.Tx, c *domain.RGCase, target domain.AccountStatus, adminID uuid.UUID) error {
+	var current string
+	err := tx.QueryRow(ctx, `SELECT account_status FROM player_profiles WHERE player_id = $1`, c.PlayerID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("player", c.PlayerID.String())
+	}
+	if err != nil {
+		return domain.ErrInternal("read account status", err)
+	}
+	if domain.AccountStatus(current) == target {
+		return nil
+	}
+	_, err = s.statusSvc.TransitionTx(ctx, tx, StatusTransitionInput{
+		PlayerID:  c.PlayerID,
+		ToStatus:  string(target),
+		Reason:    "rg case " + c.ID.String() + " closed",
+		ActorType: "admin",
+		ActorID:   &adminID,
+	})
+	return err
+}
+
+// update locks an open case and runs fn in the same transaction. Closed
+// cases are read-only.
+func (s *RGCaseService) update(ctx context.Context, id uuid.UUID, fn func(tx pgx.Tx, c *domain.RGCase) (*domain.RGCase, error)) (*domain.RGCase, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	c, err := scanRGCase(tx.QueryRow(ctx, `SELECT `+rgCaseColumns+` FROM rg_cases WHERE id = $1 FOR UPDATE`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("rg case", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock rg case", err)
+	}
+	if c.Status == domain.RGCaseClosed {
+		return nil, domain.ErrConflict("rg case is closed")
+	}
+
+	updated, err := fn(tx, c)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return updated, nil
+}
+
+func (s *RGCaseService) insertInteraction(ctx context.Context, tx pgx.Tx, caseID, adminID uuid.UUID, kind, summary string, into *domain.RGCaseInteraction) error {
+	var i domain.RGCaseInteraction
+	err := tx.QueryRow(ctx, `
+		INSERT INTO rg_case_interactions (case_id, admin_id, kind, summary)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, case_id, admin_id, kind, summary, created_at`,
+		caseID, adminID, kind, summary,
+	).Scan(&i.ID, &i.CaseID, &i.AdminID, &i.Kind, &i.Summary, &i.CreatedAt)
+	if err != nil {
+		return domain.ErrInternal("insert rg case interaction", err)
+	}
+	if into != nil {
+		*into = i
+	}
+	return nil
+}
+
+const rgCaseColumns = `id, player_id, status, priority, source, reason, assigned_to, opened_by,
+	outcome, outcome_note, opened_at, updated_at, closed_at`
+
+func scanRGCase(row pgx.Row) (*domain.RGCase, error) {
+	var c domain.RGCase
+	if err := row.Scan(&c.ID, &c.PlayerID, &c.Status, &c.Priority, &c.Source, &c.Reason, &c.AssignedTo,
+		&c.OpenedBy, &c.Outcome, &c.OutcomeNote, &c.OpenedAt, &c.UpdatedAt, &c.ClosedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// List returns cases, most recently opened first, optionally filtered by
+// player, status and assignee.
+func (s *RGCaseService) List(ctx context.Context, playerID *uuid.UUID, status string, assignedTo *uuid.UUID, limit int) ([]domain.RGCase, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+rgCaseColumns+` FROM rg_cases
+		WHERE ($1::uuid IS NULL OR player_id = $1) AND ($2 = '' OR status = $2)
+		  AND ($3::uuid IS NULL OR assigned_to = $3)
+		ORDER BY opened_at DESC LIMIT $4`, playerID, status, assignedTo, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("query rg cases", err)
+	}
+	defer rows.Close()
+
+	out := []domain.RGCase{}
+	for rows.Next() {
+		c, err := scanRGCase(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan rg case", err)
+		}
+		out = append(out, *c)
+	}
+	return out, rows.Err()
+}
+
+// Get returns a case with its interactions and the player's RG context.
+func (s *RGCaseService) Get(ctx context.Context, id uuid.UUID) (*domain.RGCaseDetail, error) {
+	c, err := scanRGCase(s.pool.QueryRow(ctx, `SELECT `+rgCaseColumns+` FROM rg_cases WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("rg case", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("get rg case", err)
+	}
+
+	d := &domain.RGCaseDetail{RGCase: *c}
+	if d.Interactions, err = s.interactions(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE((SELECT account_status FROM player_profiles WHERE player_id = $1), 'active')`,
+		c.PlayerID).Scan(&d.AccountStatus); err != nil {
+		return nil, domain.ErrInternal("read account status", err)
+	}
+	if d.StatusHistory, err = s.statusSvc.History(ctx, c.PlayerID); err != nil {
+		return nil, err
+	}
+	if d.Interventions, err = s.interventionSvc.ListForPlayer(ctx, c.PlayerID, false); err != nil {
+		return nil, err
+	}
+	if d.RiskScores, err = s.riskSvc.List(ctx, &c.PlayerID, "", 20); err != nil {
+		return nil, err
+	}
+
+	limits := policy.DefaultRgLimits()
+	deposits, err := s.txRepo.DailySumByType(ctx, s.pool, c.PlayerID, string(domain.TxDeposit))
+	if err != nil {
+		return nil, domain.ErrInternal("rg daily deposit query", err)
+	}
+	d.Limits = domain.RGLimitUsage{
+		SingleTransactionMax: limits.SingleTransactionMax,
+		DailyDepositMax:      limits.DailyDepositMax,
+		DailyLossMax:         limits.DailyLossMax,
+		DepositsToday:        deposits,
+	}
+	return d, nil
+}
+
+func (s *RGCaseService) interactions(ctx context.Context, caseID uuid.UUID) ([]domain.RGCaseInteraction, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, case_id, admin_id, kind, summary, created_at
+		FROM rg_case_interactions WHERE case_id = $1
+		ORDER BY created_at, id`, caseID)
+	if err != nil {
+		return nil, domain.ErrInternal("query rg case interactions", err)
+	}
+	defer rows.Close()
+
+	out := []domain.RGCaseInteraction{}
+	for rows.Next() {
+		var i domain.RGCaseInteraction
+		if err := rows.Scan(&i.ID, &i.CaseID, &i.AdminID, &i.Kind, &i.Summary, &i.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan rg case interaction", err)
+		}
+		out = append(out, i)
+	}
+	return out, rows.Err()
+}
+
+// History returns the case as one timeline, oldest first: the case opening
+// and interactions, plus the player's interventions, status changes and
+// medium or high risk sessions from 30 days before it opened until it closed.
+func (s *RGCaseService) History(ctx context.Context, id uuid.UUID) ([]domain.RGCaseHistoryEntry, error) {
+	d, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	from := d.OpenedAt.Add(-rgCaseContextWindow)
+	to := time.Now()
+	if d.ClosedAt != nil {
+		to = *d.ClosedAt
+	}
+	inWindow := func(t time.Time) bool { return !t.Before(from) && !t.After(to) }
+
+	entries := []domain.RGCaseHistoryEntry{{
+		At: d.OpenedAt, Source: "case", Kind: "opened", ActorID: d.OpenedBy,
+		Summary: fmt.Sprintf("%s priority, source %s: %s", d.Priority, d.Source, d.Reason),
+	}}
+	for _, i := range d.Interactions {
+		entries = append(entries, domain.RGCaseHistoryEntry{
+			At: i.CreatedAt, Source: "interaction", Kind: i.Kind, ActorID: i.AdminID, Summary: i.Summary,
+		})
+	}
+	for _, i := range d.Interventions {
+		if inWindow(i.TriggeredAt) {
+			entries = append(entries, domain.RGCaseHistoryEntry{
+				At: i.TriggeredAt, Source: "intervention", Kind: i.Kind,
+				Summary: fmt.Sprintf("net loss %d over %d days, threshold %d (%s)", i.NetLoss, i.WindowDays, i.Threshold, i.Jurisdiction),
+			})
+		}
+		if i.AcknowledgedAt != nil && i.Response != nil && inWindow(*i.AcknowledgedAt) {
+			entries = append(entries, domain.RGCaseHistoryEntry{
+				At: *i.AcknowledgedAt, Source: "intervention", Kind: i.Kind + "_" + *i.Response,
+				Summary: "player " + *i.Response + " " + i.Kind,
+			})
+		}
+	}
+	for _, c := range d.StatusHistory {
+		if inWindow(c.CreatedAt) {
+			entries = append(entries, domain.RGCaseHistoryEntry{
+				At: c.CreatedAt, Source: "status", Kind: string(c.ToStatus), ActorID: c.ActorID,
+				Summary: fmt.Sprintf("%s -> %s (%s, %s): %s", c.FromStatus, c.ToStatus, c.ActorType, c.State, c.Reason),
+			})
+		}
+	}
+	for _, r := range d.RiskScores {
+		if r.Band != string(policy.RiskLow) && inWindow(r.SessionStart) {
+			entries = append(entries, domain.RGCaseHistoryEntry{
+				At: r.SessionStart, Source: "risk_score", Kind: r.Band,
+				Summary: fmt.Sprintf("score %d over %d minutes, %d bets, net loss %d: %s", r.Score, r.Minutes, r.Bets, r.NetLoss, r.Factors),
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].At.Before(entries[j].At) })
+	return entries, nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NetLossInterventionService watches rolling net losses and triggers the
+// jurisdiction's responsible-gambling interventions as thresholds are crossed.
+type NetLossInterventionService struct {
+	pool   *pgxpool.Pool
+	outbox repository.OutboxRepository
+	rules  map[string]policy.NetLossRule
+	logger *slog.Logger
+}
+
+// NewNetLossInterventionService creates a NetLossInterventionService. rules
+// holds per-jurisdiction overrides in the policy.ParseNetLossRules format; an
+// invalid value is logged and the defaults apply.
+func NewNetLossInterventionService(pool *pgxpool.Pool, outbox repository.OutboxRepository, rules string, logger *slog.Logger) *NetLossInterventionService {
+	parsed, err := policy.ParseNetLossRules(rules)
+	if err != nil {
+		logger.Warn("net-loss rules ignored", "error", err)
+		parsed = policy.DefaultNetLossRules
+	}
+	return &NetLossInterventionService{pool: pool, outbox: outbox, rules: parsed, logger: logger}
+}
+
+// NetLossRunSummary is the outcome of one evaluation pass.
+type NetLossRunSummary struct {
+	PlayersChecked int `json:"players_checked"`
+	Triggered      int `json:"triggered"`
+}
+
+// netLossSQL sums stakes minus winnings, net of cancellations, per player
+// since $1.
+const netLossSQL = `
+	SELECT t.player_id, COALESCE(p.country, ''),
+	       SUM(CASE WHEN t.type IN ('bet', 'cancel_win') THEN t.amount ELSE -t.amount END)::bigint
+	FROM v2_transactions t
+	LEFT JOIN player_profiles p ON p.player_id = t.player_id
+	WHERE t.created_at >= $1 AND t.type IN ('bet', 'win', 'cash_out', 'cancel_bet', 'cancel_win')
+	GROUP BY t.player_id, p.country`
+
+// Run evaluates every player with betting activity inside their
+// jurisdiction's window and records any intervention that is due. An
+// intervention is not repeated while an earlier one of the same kind is
+// still inside the window.
+func (s *NetLossInterventionService) Run(ctx context.Context) (*NetLossRunSummary, error) {
+	now := time.Now()
+	windows := make(map[time.Duration]bool)
+	for _, rule := range s.rules {
+		windows[rule.Window] = true
+	}
+
+	summary := &NetLossRunSummary{}
+	for window := range windows {
+		rows, err := s.pool.Query(ctx, netLossSQL, now.Add(-window))
+		if err != nil {
+			return nil, domain.ErrInternal("query net losses", err)
+		}
+		type candidate struct {
+			playerID     uuid.UUID
+			jurisdiction string
+			rule         policy.NetLossRule
+			netLoss      int64
+		}
+		var candidates []candidate
+		for rows.Next() {
+			var c candidate
+			var country string
+			if err := rows.Scan(&c.playerID, &country, &c.netLoss); err != nil {
+				rows.Close()
+				return nil, domain.ErrInternal("scan net loss", err)
+			}
+			c.jurisdiction, c.rule = policy.NetLossRuleFor(s.rules, country)
+			if c.rule.Window == window {
+				candidates = append(candidates, c)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, domain.ErrInternal("read net losses", err)
+		}
+
+		for _, c := range candidates {
+			summary.PlayersChecked++
+			n, err := s.intervene(ctx, c.playerID, c.jurisdiction, c.rule, c.netLoss, now)
+			if err != nil {
+				return nil, err
+			}
+			summary.Triggered += n
+		}
+	}
+	return summary, nil
+}
+
+// intervene records the player's due interventions and their events in one
+// transaction, serialised per player so concurrent runs cannot double up.
+func (s *NetLossInterventionService) intervene(ctx context.Context, playerID uuid.UUID, jurisdiction string, rule policy.NetLossRule, netLoss int64, now time.Time) (int, error) {
+	if len(policy.DueInterventions(rule, netLoss, nil)) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "rg_intervention:"+playerID.String()); err != nil {
+		return 0, domain.ErrInternal("lock player interventions", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT kind FROM rg_interventions WHERE player_id = $1 AND triggered_at >= $2`,
+		playerID, now.Add(-rule.Window))
+	if err != nil {
+		return 0, domain.ErrInternal("query recent interventions", err)
+	}
+	triggered := make(map[policy.InterventionKind]bool)
+	for rows.Next() {
+		var kind string
+		if err := rows.Scan(&kind); err != nil {
+			rows.Close()
+			return 0, domain.ErrInternal("scan intervention kind", err)
+		}
+		triggered[policy.InterventionKind(kind)] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, domain.ErrInternal("read recent interventions", err)
+	}
+
+	due := policy.DueInterventions(rule, netLoss, triggered)
+	windowDays := int(rule.Window / (24 * time.Hour))
+	for _, t := range due {
+		i := domain.RGIntervention{
+			PlayerID: playerID, Kind: string(t.Kind), Jurisdiction: jurisdiction,
+			NetLoss: netLoss, Threshold: t.Amount, WindowDays: windowDays,
+		}
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO rg_interventions (player_id, kind, jurisdiction, net_loss, threshold, window_days)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, triggered_at`,
+			i.PlayerID, i.Kind, i.Jurisdiction, i.NetLoss, i.Threshold, i.WindowDays).Scan(&i.ID, &i.TriggeredAt); err != nil {
+			return 0, domain.ErrInternal("insert intervention", err)
+		}
+		if err := s.outbox.Insert(ctx, tx, domain.NewRGInterventionTriggeredEvent(i)); err != nil {
+			return 0, domain.ErrInternal("insert intervention event", err)
+		}
+		s.logger.Info("rg intervention triggered",
+			"player_id", playerID, "kind", i.Kind, "jurisdiction", jurisdiction,
+			"net_loss", netLoss, "threshold", t.Amount)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, domain.ErrInternal("commit tx", err)
+	}
+	return len(due), nil
+}
+
+const interventionColumns = `id, player_id, kind, jurisdiction, net_loss::bigint, threshold::bigint,
+	window_days, triggered_at, acknowledged_at, response`
+
+func scanIntervention(row pgx.Row) (*domain.RGIntervention, error) {
+	var i domain.RGIntervention
+	if err := row.Scan(&i.ID, &i.PlayerID, &i.Kind, &i.Jurisdiction, &i.NetLoss, &i.Threshold,
+		&i.WindowDays, &i.TriggeredAt, &i.AcknowledgedAt, &i.Response); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// ListForPlayer returns the player's interventions, newest first; pending
+// limits it to those not yet acknowledged.
+func (s *NetLossInterventionService) ListForPlayer(ctx context.Context, playerID uuid.UUID, pending bool) ([]domain.RGIntervention, error) {
+	return s.query(ctx, `
+		SELECT `+interventionColumns+` FROM rg_interventions
+		WHERE player_id = $1 AND (NOT $2 OR acknowledged_at IS NULL)
+		ORDER BY triggered_at DESC LIMIT 100`, playerID, pending)
+}
+
+// Acknowledge records the player's response to an intervention. The first
+// response stands; later ones are rejected.
+func (s *NetLossInterventionService) Acknowledge(ctx context.Context, playerID, id uuid.UUID, response string) (*domain.RGIntervention, error) {
+	if response != domain.InterventionAccepted && response != domain.InterventionDismissed {
+		return nil, domain.ErrValidation("response must be accepted or dismissed")
+	}
+
+	i, err := scanIntervention(s.pool.QueryRow(ctx, `
+		UPDATE rg_interventions SET acknowledged_at = now(), response = $3
+		WHERE id = $1 AND player_id = $2 AND acknowledged_at IS NULL
+		RETURNING `+interventionColumns, id, playerID, response))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := s.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM rg_interventions WHERE id = $1 AND player_id = $2)`,
+			id, playerID).Scan(&exists); err != nil {
+			return nil, domain.ErrInternal("find intervention", err)
+		}
+		if !exists {
+			return nil, domain.ErrNotFound("intervention", id.String())
+		}
+		return nil, domain.ErrConflict("intervention already acknowledged")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("acknowledge intervention", err)
+	}
+	return i, nil
+}
+
+// List returns the intervention audit log, newest first, optionally filtered
+// by player and kind.
+func (s *NetLossInterventionService) List(ctx context.Context, playerID *uuid.UUID, kind string, limit int) ([]domain.RGIntervention, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.query(ctx, `
+		SELECT `+interventionColumns+` FROM rg_interventions
+		WHERE ($1::uuid IS NULL OR player_id = $1) AND ($2 = '' OR kind = $2)
+		ORDER BY triggered_at DESC LIMIT $3`, playerID, kind, limit)
+}
+
+func (s *NetLossInterventionService) query(ctx context.Context, sql string, args ...any) ([]domain.RGIntervention, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, domain.ErrInternal("query interventions", err)
+	}
+	defer rows.Close()
+
+	out := []domain.RGIntervention{}
+	for rows.Next() {
+		i, err := scanIntervention(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan intervention", err)
+		}
+		out = append(out, *i)
+	}
+	return out, rows.Err()
+}
+
+// StartScheduler evaluates net losses every interval until ctx is done.
+func (s *NetLossInterventionService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("net-loss intervention scheduler stopped")
+				return
+			case <-ticker.C:
+				summary, err := s.Run(ctx)
+				if err != nil {
+					s.logger.Error("net-loss intervention run", "error", err)
+				} else if summary.Triggered > 0 {
+					s.logger.Info("net-loss interventions triggered",
+						"players", summary.PlayersChecked, "triggered", summary.Triggered)
+				}
+			}
+		}
+	}()
+}
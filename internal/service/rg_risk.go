@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rgRiskLookback bounds how much recent casino activity each run re-scores.
+const rgRiskLookback = 24 * time.Hour
+
+// rgDashboardWindow is the period the RG dashboard summarises.
+const rgDashboardWindow = 7 * 24 * time.Hour
+
+// RGRiskService scores casino play sessions for chasing behaviour and keeps
+// rg_risk_scores current for the compliance dashboard.
+type RGRiskService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewRGRiskService creates an RGRiskService.
+func NewRGRiskService(pool *pgxpool.Pool, logger *slog.Logger) *RGRiskService {
+	return &RGRiskService{pool: pool, logger: logger}
+}
+
+// RGRiskRunSummary is the outcome of one scoring pass.
+type RGRiskRunSummary struct {
+	SessionsScored int `json:"sessions_scored"`
+	HighRisk       int `json:"high_risk"`
+}
+
+// Run scores every session with stakes in the lookback window. Sessions that
+// begin within one SessionGap of the window start may have started earlier
+// and are left to the run that saw them whole.
+func (s *RGRiskService) Run(ctx context.Context) (*RGRiskRunSummary, error) {
+	since := time.Now().Add(-rgRiskLookback)
+	rows, err := s.pool.Query(ctx, `
+		SELECT player_id, created_at, type, amount::bigint
+		FROM v2_transactions
+		WHERE created_at >= $1 AND type IN ('bet', 'win', 'cash_out')
+		ORDER BY player_id, created_at`, since)
+	if err != nil {
+		return nil, domain.ErrInternal("query session activity", err)
+	}
+	activity := make(map[uuid.UUID][]policy.SessionEvent)
+	var order []uuid.UUID
+	for rows.Next() {
+		var playerID uuid.UUID
+		var ev policy.SessionEvent
+		var txType string
+		var amount int64
+		if err := rows.Scan(&playerID, &ev.At, &txType, &amount); err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan session activity", err)
+		}
+		if txType == string(domain.TxBet) {
+			ev.Stake = amount
+		} else {
+			ev.Payout = amount
+		}
+		if _, ok := activity[playerID]; !ok {
+			order = append(order, playerID)
+		}
+		activity[playerID] = append(activity[playerID], ev)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read session activity", err)
+	}
+
+	summary := &RGRiskRunSummary{}
+	cutoff := since.Add(policy.SessionGap)
+	for _, playerID := range order {
+		for _, session := range policy.SplitSessions(activity[playerID]) {
+			if session.Start.Before(cutoff) {
+				continue
+			}
+			risk := policy.ScoreSession(session)
+			if risk.Bets == 0 {
+				continue
+			}
+			if err := s.save(ctx, playerID, session, risk); err != nil {
+				return nil, err
+			}
+			summary.SessionsScored++
+			if risk.Band == policy.RiskHigh {
+				summary.HighRisk++
+				s.logger.Info("high-risk play session",
+					"player_id", playerID, "score", risk.Score, "factors", risk.Factors,
+					"session_start", session.Start)
+			}
+		}
+	}
+	return summary, nil
+}
+
+func (s *RGRiskService) save(ctx context.Context, playerID uuid.UUID, session policy.PlaySession, risk policy.SessionRisk) error {
+	factors, _ := json.Marshal(risk.Factors)
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO rg_risk_scores (player_id, session_start, session_end, score, band, factors,
+			escalations, bets, staked, net_loss, minutes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (player_id, session_start) DO UPDATE SET
+			session_end = EXCLUDED.session_end, score = EXCLUDED.score, band = EXCLUDED.band,
+			factors = EXCLUDED.factors, escalations = EXCLUDED.escalations, bets = EXCLUDED.bets,
+			staked = EXCLUDED.staked, net_loss = EXCLUDED.net_loss, minutes = EXCLUDED.minutes,
+			scored_at = now()`,
+		playerID, session.Start, session.End, risk.Score, risk.Band, factors,
+		risk.Escalations, risk.Bets, risk.Staked, risk.NetLoss, risk.Minutes)
+	if err != nil {
+		return domain.ErrInternal("save risk score", err)
+	}
+	return nil
+}
+
+const riskScoreColumns = `id, player_id, session_start, session_end, score, band, factors,
+	escalations, bets, staked::bigint, net_loss::bigint, minutes, scored_at`
+
+func scanRiskScore(row pgx.Row) (*domain.RGRiskScore, error) {
+	var r domain.RGRiskScore
+	if err := row.Scan(&r.ID, &r.PlayerID, &r.SessionStart, &r.SessionEnd, &r.Score, &r.Band, &r.Factors,
+		&r.Escalations, &r.Bets, &r.Staked, &r.NetLoss, &r.Minutes, &r.ScoredAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// List returns scored sessions, newest first, optionally filtered by player
+// and band.
+func (s *RGRiskService) List(ctx context.Context, playerID *uuid.UUID, band string, limit int) ([]domain.RGRiskScore, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+riskScoreColumns+` FROM rg_risk_scores
+		WHERE ($1::uuid IS NULL OR player_id = $1) AND ($2 = '' OR band = $2)
+		ORDER BY session_start DESC LIMIT $3`, playerID, band, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("query risk scores", err)
+	}
+	defer rows.Close()
+
+	out := []domain.RGRiskScore{}
+	for rows.Next() {
+		r, err := scanRiskScore(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan risk score", err)
+		}
+		out = append(out, *r)
+	}
+	return out, rows.Err()
+}
+
+// Dashboard summarises the last seven days: sessions per risk band, the
+// players with high-risk sessions, and intervention activity.
+func (s *RGRiskService) Dashboard(ctx context.Context) (*domain.RGDashboard, error) {
+	since := time.Now().Add(-rgDashboardWindow)
+	d := &domain.RGDashboard{
+		Since:               since,
+		SessionsByBand:      map[string]int{string(policy.RiskLow): 0, string(policy.RiskMedium): 0, string(policy.RiskHigh): 0},
+		HighRiskPlayers:     []domain.RGRiskPlayer{},
+		InterventionsByKind: map[string]int{},
+	}
+
+	if err := s.countBy(ctx, `
+		SELECT band, COUNT(*) FROM rg_risk_scores WHERE session_start >= $1 GROUP BY band`,
+		since, d.SessionsByBand); err != nil {
+		return nil, err
+	}
+	if err := s.countBy(ctx, `
+		SELECT kind, COUNT(*) FROM rg_interventions WHERE triggered_at >= $1 GROUP BY kind`,
+		since, d.InterventionsByKind); err != nil {
+		return nil, err
+	}
+	if err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM rg_interventions WHERE acknowledged_at IS NULL`,
+	).Scan(&d.PendingInterventions); err != nil {
+		return nil, domain.ErrInternal("count pending interventions", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (r.player_id) r.player_id, COALESCE(pp.email, ''), r.score,
+		       COUNT(*) OVER (PARTITION BY r.player_id),
+		       MAX(r.session_end) OVER (PARTITION BY r.player_id), r.factors
+		FROM rg_risk_scores r
+		LEFT JOIN player_profiles pp ON pp.player_id = r.player_id
+		WHERE r.session_start >= $1 AND r.band = 'high'
+		ORDER BY r.player_id, r.score DESC, r.session_start DESC`, since)
+	if err != nil {
+		return nil, domain.ErrInternal("query high-risk players", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p domain.RGRiskPlayer
+		if err := rows.Scan(&p.PlayerID, &p.Email, &p.MaxScore, &p.HighSessions, &p.LastSessionAt, &p.Factors); err != nil {
+			return nil, domain.ErrInternal("scan high-risk player", err)
+		}
+		d.HighRiskPlayers = append(d.HighRiskPlayers, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read high-risk players", err)
+	}
+	// Riskiest first, then most recently active
+	sort.Slice(d.HighRiskPlayers, func(i, j int) bool {
+		a, b := d.HighRiskPlayers[i], d.HighRiskPlayers[j]
+		if a.MaxScore != b.MaxScore {
+			return a.MaxScore > b.MaxScore
+		}
+		return a.LastSessionAt.After(b.LastSessionAt)
+	})
+	return d, nil
+}
+
+func (s *RGRiskService) countBy(ctx context.Context, sql string, since time.Time, into map[string]int) error {
+	rows, err := s.pool.Query(ctx, sql, since)
+	if err != nil {
+		return domain.ErrInternal("query dashboard counts", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var n int
+		if err := rows.Scan(&key, &n); err != nil {
+			return domain.ErrInternal("scan dashboard count", err)
+		}
+		into[key] = n
+	}
+	return rows.Err()
+}
+
+// StartScheduler scores recent sessions every interval until ctx is done.
+func (s *RGRiskService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("rg risk scoring scheduler stopped")
+				return
+			case <-ticker.C:
+				summary, err := s.Run(ctx)
+				if err != nil {
+					s.logger.Error("rg risk scoring run", "error", err)
+				} else if summary.HighRisk > 0 {
+					s.logger.Info("rg risk scoring flagged sessions",
+						"sessions", summary.SessionsScored, "high_risk", summary.HighRisk)
+				}
+			}
+		}
+	}()
+}
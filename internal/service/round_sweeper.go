@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// roundSweepBatch bounds the rounds voided per sweep.
+const roundSweepBatch = 100
+
+// RoundSweeper voids provider game rounds left open beyond a TTL, refunding
+// their unsettled stakes. Without it a round the provider abandons — a lost
+// result callback, a game server crash — leaves the stake deducted forever.
+// Manufacturers whose rounds legitimately stay open for long periods, such as
+// the sportsbook's outright bets and prediction stakes, are exempt.
+type RoundSweeper struct {
+	pool   *pgxpool.Pool
+	engine *ledger.Engine
+	rounds repository.GameRoundRepository
+	ttl    time.Duration
+	exempt []string
+	logger *slog.Logger
+}
+
+// NewRoundSweeper creates a RoundSweeper. exempt is a comma-separated list of
+// manufacturer ids never swept.
+func NewRoundSweeper(
+	pool *pgxpool.Pool,
+	engine *ledger.Engine,
+	rounds repository.GameRoundRepository,
+	ttl time.Duration,
+	exempt string,
+	logger *slog.Logger,
+) *RoundSweeper {
+	var ids []string
+	for _, id := range strings.Split(exempt, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return &RoundSweeper{pool: pool, engine: engine, rounds: rounds, ttl: ttl, exempt: ids, logger: logger}
+}
+
+// Sweep voids one batch of stale rounds and returns how many it voided.
+// Each round is voided in its own transaction; a failure is logged and the
+// round retried on the next sweep.
+func (s *RoundSweeper) Sweep(ctx context.Context) (int, error) {
+	idleSince := time.Now().Add(-s.ttl)
+	stale, err := s.rounds.ListStale(ctx, s.pool, idleSince, s.exempt, roundSweepBatch)
+	if err != nil {
+		return 0, domain.ErrInternal("list stale rounds", err)
+	}
+
+	voided := 0
+	for _, round := range stale {
+		ok, err := s.voidRound(ctx, round, idleSince)
+		if err != nil {
+			s.logger.Error("void stuck round", "round_id", round.RoundID,
+				"player_id", round.PlayerID, "manufacturer", round.ManufacturerID, "error", err)
+			continue
+		}
+		if ok {
+			voided++
+		}
+	}
+	return voided, nil
+}
+
+func (s *RoundSweeper) voidRound(ctx context.Context, round domain.GameRound, idleSince time.Time) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := s.engine.ExecuteVoidRound(ctx, tx, domain.VoidRoundParams{
+		RoundID:   round.ID,
+		PlayerID:  round.PlayerID,
+		IdleSince: idleSince,
+	})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	s.logger.Warn("voided stuck game round", "round_id", round.RoundID,
+		"player_id", round.PlayerID, "manufacturer", round.ManufacturerID,
+		"stake_total", round.StakeTotal, "opened_at", round.OpenedAt)
+	return true, nil
+}
+
+// StartScheduler sweeps every interval until ctx is done. A non-positive TTL
+// or interval disables the sweeper.
+func (s *RoundSweeper) StartScheduler(ctx context.Context, interval time.Duration) {
+	if s.ttl <= 0 || interval <= 0 {
+		s.logger.Info("round sweeper disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("round sweeper stopped")
+				return
+			case <-ticker.C:
+				n, err := s.Sweep(ctx)
+				if err != nil {
+					s.logger.Error("sweep stuck rounds", "error", err)
+				} else if n > 0 {
+					s.logger.Info("swept stuck rounds", "count", n)
+				}
+			}
+		}
+	}()
+}
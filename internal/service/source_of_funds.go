@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MaxSOFDocumentBytes caps a single source-of-funds attachment.
+const MaxSOFDocumentBytes = 5 << 20
+
+// sofDocumentTypes are the content types accepted as attachments.
+var sofDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// SourceOfFundsService runs the source-of-funds questionnaire workflow:
+// questionnaires opened at deposit thresholds, player submissions with
+// supporting documents, and compliance review.
+type SourceOfFundsService struct {
+	pool       *pgxpool.Pool
+	thresholds []int64
+	logger     *slog.Logger
+}
+
+// NewSourceOfFundsService creates a SourceOfFundsService. thresholds is a
+// comma-separated list of cumulative-deposit amounts in cents; empty uses
+// policy.DefaultSOFThresholds and "off" disables the check. An invalid list
+// is logged and the defaults apply.
+func NewSourceOfFundsService(pool *pgxpool.Pool, thresholds string, logger *slog.Logger) *SourceOfFundsService {
+	parsed, err := policy.ParseSOFThresholds(thresholds)
+	if err != nil {
+		logger.Warn("source-of-funds thresholds ignored", "error", err)
+		parsed = policy.DefaultSOFThresholds
+	}
+	return &SourceOfFundsService{pool: pool, thresholds: parsed, logger: logger}
+}
+
+// Thresholds returns the configured cumulative-deposit thresholds, ascending.
+func (s *SourceOfFundsService) Thresholds() []int64 {
+	return s.thresholds
+}
+
+const sofColumns = `id, player_id, threshold::bigint, status, occupation, employer, annual_income_band,
+	source_of_funds, details, review_note, reviewed_by, reviewed_at, submitted_at, created_at, updated_at`
+
+func scanSOF(row pgx.Row) (*domain.SOFQuestionnaire, error) {
+	var q domain.SOFQuestionnaire
+	if err := row.Scan(&q.ID, &q.PlayerID, &q.Threshold, &q.Status, &q.Occupation, &q.Employer,
+		&q.AnnualIncomeBand, &q.SourceOfFunds, &q.Details, &q.ReviewNote, &q.ReviewedBy,
+		&q.ReviewedAt, &q.SubmittedAt, &q.CreatedAt, &q.UpdatedAt); err != nil {
+		return nil, err
+	}
+	q.Documents = []domain.SOFDocument{}
+	return &q, nil
+}
+
+// ListForPlayer returns the player's questionnaires, newest threshold first,
+// with their document metadata.
+func (s *SourceOfFundsService) ListForPlayer(ctx context.Context, playerID uuid.UUID) ([]domain.SOFQuestionnaire, error) {
+	return s.list(ctx, `WHERE player_id = $1 ORDER BY threshold DESC`, playerID)
+}
+
+// SOFSubmission is the player's answer to a questionnaire.
+type SOFSubmission struct {
+	Occupation       string               `json:"occupation"`
+	Employer         string               `json:"employer"`
+	AnnualIncomeBand string               `json:"annual_income_band"`
+	SourceOfFunds    domain.SourceOfFunds `json:"source_of_funds"`
+	Details          string               `json:"details"`
+}
+
+// Submit records the player's answers. Pending and rejected questionnaires
+// can be submitted; submitting unblocks deposits while review is pending.
+func (s *SourceOfFundsService) Submit(ctx context.Context, playerID, id uuid.UUID, input SOFSubmission) (*domain.SOFQuestionnaire, error) {
+	input.Occupation = strings.TrimSpace(input.Occupation)
+	input.AnnualIncomeBand = strings.TrimSpace(input.AnnualIncomeBand)
+	if input.Occupation == "" || input.AnnualIncomeBand == "" {
+		return nil, domain.ErrValidation("occupation and annual_income_band are required")
+	}
+	if !input.SourceOfFunds.Valid() {
+		return nil, domain.ErrValidation("invalid source_of_funds")
+	}
+	if input.SourceOfFunds == domain.SOFOtherSource && strings.TrimSpace(input.Details) == "" {
+		return nil, domain.ErrValidation("details are required when source_of_funds is other")
+	}
+
+	q, err := scanSOF(s.pool.QueryRow(ctx, `
+		UPDATE sof_questionnaires
+		SET occupation = $3, employer = NULLIF($4, ''), annual_income_band = $5,
+		    source_of_funds = $6, details = NULLIF($7, ''), status = 'submitted',
+		    submitted_at = now(), updated_at = now()
+		WHERE id = $1 AND player_id = $2 AND status IN ('pending', 'rejected')
+		RETURNING `+sofColumns,
+		id, playerID, input.Occupation, strings.TrimSpace(input.Employer), input.AnnualIncomeBand,
+		input.SourceOfFunds, strings.TrimSpace(input.Details)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.notOpen(ctx, playerID, id)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("submit source-of-funds questionnaire", err)
+	}
+	if err := s.loadDocuments(ctx, q); err != nil {
+		return nil, err
+	}
+	s.logger.Info("source-of-funds submitted", "player_id", playerID, "questionnaire_id", id, "threshold", q.Threshold)
+	return q, nil
+}
+
+// SOFUpload is an attachment supplied by the player.
+type SOFUpload struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// AttachDocument stores a supporting document on one of the player's
+// questionnaires. Approved questionnaires are closed to new documents.
+func (s *SourceOfFundsService) AttachDocument(ctx context.Context, playerID, id uuid.UUID, upload SOFUpload) (*domain.SOFDocument, error) {
+	upload.FileName = strings.TrimSpace(upload.FileName)
+	if upload.FileName == "" || len(upload.Content) == 0 {
+		return nil, domain.ErrValidation("a non-empty file is required")
+	}
+	if len(upload.Content) > MaxSOFDocumentBytes {
+		return nil, domain.ErrValidation("file exceeds 5MB")
+	}
+	if !sofDocumentTypes[upload.ContentType] {
+		return nil, domain.ErrValidation("file must be a PDF, JPEG or PNG")
+	}
+
+	var d domain.SOFDocument
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO sof_documents (questionnaire_id, file_name, content_type, size_bytes, content)
+		SELECT q.id, $3, $4, $5, $6 FROM sof_questionnaires q
+		WHERE q.id = $1 AND q.player_id = $2 AND q.status <> 'approved'
+		RETURNING id, questionnaire_id, file_name, content_type, size_bytes, uploaded_at`,
+		id, playerID, upload.FileName, upload.ContentType, len(upload.Content), upload.Content).
+		Scan(&d.ID, &d.QuestionnaireID, &d.FileName, &d.ContentType, &d.SizeBytes, &d.UploadedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.notOpen(ctx, playerID, id)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("insert source-of-funds document", err)
+	}
+	return &d, nil
+}
+
+// notOpen explains why a player-side update matched no questionnaire.
+func (s *SourceOfFundsService) notOpen(ctx context.Context, playerID, id uuid.UUID) error {
+	var status domain.SOFStatus
+	err := s.pool.QueryRow(ctx, `
+		SELECT status FROM sof_questionnaires WHERE id = $1 AND player_id = $2`, id, playerID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("source-of-funds questionnaire", id.String())
+	}
+	if err != nil {
+		return domain.ErrInternal("find source-of-funds questionnaire", err)
+	}
+	return domain.ErrConflict("questionnaire is already " + string(status))
+}
+
+// --- Admin ---
+
+// List returns questionnaires for review, oldest submission first, optionally
+// filtered by status.
+func (s *SourceOfFundsService) List(ctx context.Context, status string, limit int) ([]domain.SOFQuestionnaire, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.list(ctx, `WHERE $1 = '' OR status = $1
+		ORDER BY submitted_at ASC NULLS LAST, created_at ASC LIMIT $2`, status, limit)
+}
+
+// Get returns one questionnaire with its document metadata.
+func (s *SourceOfFundsService) Get(ctx context.Context, id uuid.UUID) (*domain.SOFQuestionnaire, error) {
+	q, err := scanSOF(s.pool.QueryRow(ctx, `SELECT `+sofColumns+` FROM sof_questionnaires WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("source-of-funds questionnaire", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find source-of-funds questionnaire", err)
+	}
+	if err := s.loadDocuments(ctx, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Approve accepts a submitted questionnaire.
+func (s *SourceOfFundsService) Approve(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.SOFQuestionnaire, error) {
+	return s.review(ctx, id, adminID, domain.SOFApproved, note)
+}
+
+// Reject returns a submitted questionnaire to the player. Deposits are
+// blocked again until it is resubmitted.
+func (s *SourceOfFundsService) Reject(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.SOFQuestionnaire, error) {
+	if strings.TrimSpace(note) == "" {
+		return nil, domain.ErrValidation("a note is required when rejecting")
+	}
+	return s.review(ctx, id, adminID, domain.SOFRejected, note)
+}
+
+func (s *SourceOfFundsService) review(ctx context.Context, id, adminID uuid.UUID, status domain.SOFStatus, note string) (*domain.SOFQuestionnaire, error) {
+	q, err := scanSOF(s.pool.QueryRow(ctx, `
+		UPDATE sof_questionnaires
+		SET status = $2, review_note = NULLIF($3, ''), reviewed_by = $4, reviewed_at = now(), updated_at = now()
+		WHERE id = $1 AND status = 'submitted'
+		RETURNING `+sofColumns,
+		id, status, strings.TrimSpace(note), adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		existing, getErr := s.Get(ctx, id)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return nil, domain.ErrConflict("questionnaire is " + string(existing.Status) + ", not submitted")
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("review source-of-funds questionnaire", err)
+	}
+	if err := s.loadDocuments(ctx, q); err != nil {
+		return nil, err
+	}
+	s.logger.Info("source-of-funds reviewed", "questionnaire_id", id, "status", status, "admin_id", adminID)
+	return q, nil
+}
+
+// Document returns an attachment's metadata and content.
+func (s *SourceOfFundsService) Document(ctx context.Context, id uuid.UUID) (*domain.SOFDocument, []byte, error) {
+	var d domain.SOFDocument
+	var content []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, questionnaire_id, file_name, content_type, size_bytes, uploaded_at, content
+		FROM sof_documents WHERE id = $1`, id).
+		Scan(&d.ID, &d.QuestionnaireID, &d.FileName, &d.ContentType, &d.SizeBytes, &d.UploadedAt, &content)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, domain.ErrNotFound("source-of-funds document", id.String())
+	}
+	if err != nil {
+		return nil, nil, domain.ErrInternal("find source-of-funds document", err)
+	}
+	return &d, content, nil
+}
+
+// list runs a questionnaire query and attaches document metadata.
+func (s *SourceOfFundsService) list(ctx context.Context, where string, args ...any) ([]domain.SOFQuestionnaire, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+sofColumns+` FROM sof_questionnaires `+where, args...)
+	if err != nil {
+		return nil, domain.ErrInternal("query source-of-funds questionnaires", err)
+	}
+	defer rows.Close()
+
+	out := []domain.SOFQuestionnaire{}
+	index := make(map[uuid.UUID]int)
+	var ids []uuid.UUID
+	for rows.Next() {
+		q, err := scanSOF(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan source-of-funds questionnaire", err)
+		}
+		index[q.ID] = len(out)
+		ids = append(ids, q.ID)
+		out = append(out, *q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read source-of-funds questionnaires", err)
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	docs, err := s.documents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range docs {
+		i := index[d.QuestionnaireID]
+		out[i].Documents = append(out[i].Documents, d)
+	}
+	return out, nil
+}
+
+func (s *SourceOfFundsService) loadDocuments(ctx context.Context, q *domain.SOFQuestionnaire) error {
+	docs, err := s.documents(ctx, []uuid.UUID{q.ID})
+	if err != nil {
+		return err
+	}
+	q.Documents = append(q.Documents, docs...)
+	return nil
+}
+
+func (s *SourceOfFundsService) documents(ctx context.Context, questionnaireIDs []uuid.UUID) ([]domain.SOFDocument, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, questionnaire_id, file_name, content_type, size_bytes, uploaded_at
+		FROM sof_documents WHERE questionnaire_id = ANY($1)
+		ORDER BY uploaded_at`, questionnaireIDs)
+	if err != nil {
+		return nil, domain.ErrInternal("query source-of-funds documents", err)
+	}
+	defer rows.Close()
+
+	var docs []domain.SOFDocument
+	for rows.Next() {
+		var d domain.SOFDocument
+		if err := rows.Scan(&d.ID, &d.QuestionnaireID, &d.FileName, &d.ContentType, &d.SizeBytes, &d.UploadedAt); err != nil {
+			return nil, domain.ErrInternal("scan source-of-funds document", err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
@@ -5,34 +5,73 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
 	"github.com/attaboy/platform/internal/ledger"
 	"github.com/attaboy/platform/internal/policy"
 	"github.com/attaboy/platform/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // SportsbookService handles sportsbook bet operations.
 type SportsbookService struct {
-	pool   *pgxpool.Pool
-	engine *ledger.Engine
-	txRepo repository.TransactionRepository
-	logger *slog.Logger
+	pool     *pgxpool.Pool
+	engine   *ledger.Engine
+	txRepo   repository.TransactionRepository
+	outbox   repository.OutboxRepository
+	alerts   policy.TradingAlertRules
+	exposure policy.ExposureLimits
+	builder  policy.BetBuilderRules
+	logger   *slog.Logger
 }
 
 // NewSportsbookService creates a SportsbookService.
-func NewSportsbookService(pool *pgxpool.Pool, txRepo repository.TransactionRepository, engine *ledger.Engine, logger *slog.Logger) *SportsbookService {
-	return &SportsbookService{pool: pool, engine: engine, txRepo: txRepo, logger: logger}
+func NewSportsbookService(pool *pgxpool.Pool, txRepo repository.TransactionRepository, engine *ledger.Engine, outbox repository.OutboxRepository, exposure policy.ExposureLimits, logger *slog.Logger) *SportsbookService {
+	return &SportsbookService{
+		pool:     pool,
+		engine:   engine,
+		txRepo:   txRepo,
+		outbox:   outbox,
+		alerts:   policy.DefaultTradingAlertRules(),
+		exposure: exposure,
+		builder:  policy.DefaultBetBuilderRules(),
+		logger:   logger,
+	}
 }
 
-// PlaceBetInput holds the bet placement request.
+// PlaceBetInput holds the bet placement request. EventID is ignored in favour
+// of the market's event and may be omitted for outrights. Stake is per line:
+// an each-way bet debits twice the stake and a system bet the stake times its
+// lines. BetClass defaults to single, or each_way when EachWay is set; a
+// system bet names its System and lists its Legs instead of a selection, and
+// a bet builder lists its Legs from one event. ExpectedOdds are the odds on
+// the bettor's slip, for a bet builder its combined odds; unless AcceptOddsChanges is
+// set, the bet is refused when the current odds have moved beyond
+// policy.OddsChangeToleranceBps from them. A stake whose payout would breach
+// an exposure limit is refused, or with AcceptStakeCap cut to the largest
+// stake that fits.
 type PlaceBetInput struct {
-	EventID     uuid.UUID `json:"event_id"`
-	MarketID    uuid.UUID `json:"market_id"`
-	SelectionID uuid.UUID `json:"selection_id"`
-	Stake       int64     `json:"stake"`
+	EventID           uuid.UUID     `json:"event_id"`
+	MarketID          uuid.UUID     `json:"market_id"`
+	SelectionID       uuid.UUID     `json:"selection_id"`
+	Stake             int64         `json:"stake"`
+	BetClass          string        `json:"bet_class,omitempty"`
+	EachWay           bool          `json:"each_way,omitempty"`
+	System            string        `json:"system,omitempty"`
+	Legs              []BetLegInput `json:"legs,omitempty"`
+	ExpectedOdds      int           `json:"expected_odds,omitempty"`
+	AcceptOddsChanges bool          `json:"accept_odds_changes,omitempty"`
+	AcceptStakeCap    bool          `json:"accept_stake_cap,omitempty"`
+}
+
+// BetLegInput is one selection of a system bet or bet builder, with the odds on the slip.
+type BetLegInput struct {
+	SelectionID  uuid.UUID `json:"selection_id"`
+	ExpectedOdds int       `json:"expected_odds,omitempty"`
 }
 
 // PlaceBetResult holds the result of a bet placement.
@@ -42,39 +81,105 @@ type PlaceBetResult struct {
 	Stake       int64     `json:"stake"`
 	Odds        int       `json:"odds"`
 	PotentialPayout int64 `json:"potential_payout"`
+	BetClass    string    `json:"bet_class"`
+	EachWay     bool      `json:"each_way,omitempty"`
+	System      string    `json:"system,omitempty"`
+	Lines       int       `json:"lines"`
+	TotalStake  int64     `json:"total_stake"`
+	StakeCapped bool      `json:"stake_capped,omitempty"`
 }
 
-// PlaceBet places a single bet, deducting from the player's wallet.
+// PlaceBet places a bet, deducting from the player's wallet. Each-way bets
+// lock the market's each-way terms at placement; system bets are placed by
+// placeSystemBet and bet builders by placeBetBuilder.
 func (s *SportsbookService) PlaceBet(ctx context.Context, playerID uuid.UUID, input PlaceBetInput) (*PlaceBetResult, error) {
 	if input.Stake <= 0 {
 		return nil, domain.ErrValidation("stake must be positive")
 	}
-
-	// Responsible gaming: check daily bet (loss) limit.
-	dailyBets, err := s.txRepo.DailySumByType(ctx, s.pool, playerID, string(domain.TxBet))
-	if err != nil {
-		return nil, domain.ErrInternal("rg daily bet query", err)
+	if input.ExpectedOdds != 0 && input.ExpectedOdds < policy.MinOdds {
+		return nil, domain.ErrValidation(fmt.Sprintf("expected_odds must be at least %d", policy.MinOdds))
 	}
-	rgResult := policy.EvaluateRgLimits(policy.DefaultRgLimits(), input.Stake, "bet", 0, dailyBets)
-	if !rgResult.Allowed {
-		return nil, &domain.AppError{
-			Code:    "RG_LIMIT_BREACHED",
-			Message: fmt.Sprintf("bet exceeds %s limit", rgResult.BreachedLimit),
-			Status:  422,
+	switch input.BetClass {
+	case "":
+		input.BetClass = domain.BetClassSingle
+		if input.EachWay {
+			input.BetClass = domain.BetClassEachWay
 		}
+	case domain.BetClassSingle:
+		if input.EachWay {
+			return nil, domain.ErrValidation("a single bet cannot be each-way")
+		}
+	case domain.BetClassEachWay:
+		input.EachWay = true
+	case domain.BetClassSystem:
+		return s.placeSystemBet(ctx, playerID, input)
+	case domain.BetClassBetBuilder:
+		return s.placeBetBuilder(ctx, playerID, input)
+	default:
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown bet_class %q", input.BetClass))
+	}
+	if len(input.Legs) > 0 || input.System != "" {
+		return nil, domain.ErrValidation("legs are only valid for system bets and bet builders, system for system bets only")
+	}
+	lines := 1
+	totalStake := input.Stake
+	if input.EachWay {
+		lines = 2
+		totalStake = policy.EachWayStake(input.Stake)
+	}
+
+	// Responsible gaming: check daily bet (loss) limit.
+	if err := s.checkBetLimit(ctx, playerID, totalStake); err != nil {
+		return nil, err
 	}
 
-	// Fetch selection for odds
+	// Fetch selection for odds, and its market for event and each-way terms
 	var odds int
-	err = s.pool.QueryRow(ctx,
-		`SELECT odds_decimal FROM sports_selections WHERE id = $1 AND status = 'active'`,
-		input.SelectionID).Scan(&odds)
+	var eventID *uuid.UUID
+	var marketStatus string
+	var suspendedUntil *time.Time
+	var ewPlaces, ewFraction *int
+	err := s.pool.QueryRow(ctx, `
+		SELECT sel.odds_decimal, m.event_id,
+		       CASE WHEN m.status = 'suspended' AND m.suspended_until <= now() THEN 'open' ELSE m.status END,
+		       m.suspended_until, m.each_way_places, m.each_way_fraction
+		FROM sports_selections sel JOIN sports_markets m ON m.id = sel.market_id
+		WHERE sel.id = $1 AND sel.status = 'active'`,
+		input.SelectionID).Scan(&odds, &eventID, &marketStatus, &suspendedUntil, &ewPlaces, &ewFraction)
 	if err != nil {
 		return nil, domain.ErrNotFound("selection", input.SelectionID.String())
 	}
+	if marketStatus == "suspended" {
+		return nil, domain.ErrMarketSuspended(suspendedUntil)
+	}
+	if marketStatus != "open" {
+		return nil, domain.ErrValidation("market is not open for betting")
+	}
+	if input.ExpectedOdds != 0 && !input.AcceptOddsChanges &&
+		!policy.OddsChangeAcceptable(input.ExpectedOdds, odds, policy.OddsChangeToleranceBps) {
+		return nil, domain.ErrOddsChanged(input.ExpectedOdds, odds)
+	}
 
-	// Calculate potential payout: stake * (odds / 100)
-	potentialPayout := input.Stake * int64(odds) / 100
+	// Calculate potential payout: stake * (odds / 100), plus the place line
+	// at the fractional place odds for each-way bets
+	var terms *policy.EachWayTerms
+	if input.EachWay {
+		if ewPlaces == nil || ewFraction == nil {
+			return nil, domain.ErrValidation("market does not offer each-way betting")
+		}
+		terms = &policy.EachWayTerms{Places: *ewPlaces, Fraction: *ewFraction}
+	} else {
+		ewPlaces, ewFraction = nil, nil
+	}
+	payoutFor := func(stake int64) int64 {
+		if terms != nil {
+			return policy.OutrightMaxReturn(stake, odds, terms)
+		}
+		return stake * int64(odds) / 100
+	}
+	metadata, _ := json.Marshal(map[string]any{
+		"event_id": eventID, "market_id": input.MarketID, "selection_id": input.SelectionID, "each_way": input.EachWay,
+	})
 
 	betID := uuid.New()
 	gameRoundID := fmt.Sprintf("sb_%s", betID.String()[:8])
@@ -85,16 +190,36 @@ func (s *SportsbookService) PlaceBet(ctx context.Context, playerID uuid.UUID, in
 	}
 	defer tx.Rollback(ctx)
 
+	// Exposure: the book stays locked until commit so concurrent bets see
+	// each other's liability.
+	facts, limits, err := s.bookExposure(ctx, tx, playerID, eventID, input.MarketID, input.SelectionID)
+	if err != nil {
+		return nil, err
+	}
+	stake, capped := input.Stake, false
+	if maxPayout := limits.MaxPayout(facts); payoutFor(stake) > maxPayout {
+		stake = policy.CapStake(stake, maxPayout, payoutFor)
+		if !input.AcceptStakeCap || stake <= 0 {
+			return nil, domain.ErrExposureLimitExceeded(stake)
+		}
+		capped = true
+		totalStake = stake
+		if input.EachWay {
+			totalStake = policy.EachWayStake(stake)
+		}
+	}
+	potentialPayout := payoutFor(stake)
+
 	// Deduct from wallet via ledger
 	extTxID := fmt.Sprintf("bet_%s", betID.String()[:8])
 	result, err := s.engine.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
 		PlayerID:              playerID,
-		Amount:                input.Stake,
+		Amount:                totalStake,
 		ExternalTransactionID: extTxID,
 		ManufacturerID:        "sportsbook",
 		SubTransactionID:      "1",
 		GameRoundID:           gameRoundID,
-		Metadata:              json.RawMessage(fmt.Sprintf(`{"event_id":"%s","market_id":"%s","selection_id":"%s"}`, input.EventID, input.MarketID, input.SelectionID)),
+		Metadata:              metadata,
 	})
 	if err != nil {
 		return nil, err
@@ -104,11 +229,13 @@ func (s *SportsbookService) PlaceBet(ctx context.Context, playerID uuid.UUID, in
 	_, err = tx.Exec(ctx, `
 		INSERT INTO sports_bets (id, player_id, event_id, market_id, selection_id,
 			stake_amount_minor, currency, odds_at_placement, potential_payout_minor,
-			status, game_round_id, transaction_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
-		betID, playerID, input.EventID, input.MarketID, input.SelectionID,
-		input.Stake, "EUR", odds, potentialPayout,
-		"open", gameRoundID, result.Transaction.ID,
+			status, game_round_id, transaction_id, each_way, each_way_places, each_way_fraction,
+			bet_class, stake_per_line_minor, num_lines)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+		betID, playerID, eventID, input.MarketID, input.SelectionID,
+		totalStake, result.Transaction.Currency, odds, potentialPayout,
+		"open", gameRoundID, result.Transaction.ID, input.EachWay, ewPlaces, ewFraction,
+		input.BetClass, stake, lines,
 	)
 	if err != nil {
 		return nil, domain.ErrInternal("insert bet", err)
@@ -117,42 +244,143 @@ func (s *SportsbookService) PlaceBet(ctx context.Context, playerID uuid.UUID, in
 	if err := tx.Commit(ctx); err != nil {
 		return nil, domain.ErrInternal("commit tx", err)
 	}
+	s.checkTradingAlerts(ctx, playerID, input.MarketID, input.SelectionID)
 
 	return &PlaceBetResult{
 		BetID:           betID,
 		GameRoundID:     gameRoundID,
-		Stake:           input.Stake,
+		Stake:           stake,
 		Odds:            odds,
 		PotentialPayout: potentialPayout,
+		BetClass:        input.BetClass,
+		EachWay:         input.EachWay,
+		Lines:           lines,
+		TotalStake:      totalStake,
+		StakeCapped:     capped,
 	}, nil
 }
 
-// ListPlayerBets returns a player's bet history.
-func (s *SportsbookService) ListPlayerBets(ctx context.Context, playerID uuid.UUID) ([]domain.SportsBetRecord, error) {
+// checkBetLimit rejects a stake that would breach the default daily bet
+// (loss) limit or one of the player's own loss limits.
+func (s *SportsbookService) checkBetLimit(ctx context.Context, playerID uuid.UUID, amount int64) error {
+	return checkBetLimit(ctx, s.pool, s.txRepo, playerID, amount)
+}
+
+// checkBetLimit rejects a stake of any vertical that would breach the
+// default daily bet (loss) limit or one of the player's own loss limits.
+func checkBetLimit(ctx context.Context, pool *pgxpool.Pool, txRepo repository.TransactionRepository, playerID uuid.UUID, amount int64) error {
+	dailyBets, err := txRepo.DailySumByType(ctx, pool, playerID, string(domain.TxBet))
+	if err != nil {
+		return domain.ErrInternal("rg daily bet query", err)
+	}
+	rgResult := policy.EvaluateRgLimits(policy.DefaultRgLimits(), amount, "bet", 0, dailyBets)
+	if !rgResult.Allowed {
+		return domain.ErrRGLimitBreached("bet", rgResult.BreachedLimit, rgResult.LimitValue)
+	}
+	return guard.CheckPlayerLimits(ctx, pool, playerID, domain.LimitLoss, amount)
+}
+
+// BetHistoryFilter narrows and pages a player's bet history. Statuses match
+// bet statuses exactly, except "settled", which matches every bet that is no
+// longer open. From is inclusive and To exclusive. Cursor is the bet a page
+// starts at, as returned in the previous page's NextCursor.
+type BetHistoryFilter struct {
+	Statuses []string
+	From     *time.Time
+	To       *time.Time
+	SportID  *uuid.UUID
+	Cursor   *uuid.UUID
+	Limit    int
+}
+
+// BetHistoryPage is one page of a player's bet history, newest first.
+type BetHistoryPage struct {
+	Bets       []domain.SportsBetRecord `json:"bets"`
+	NextCursor *string                  `json:"next_cursor,omitempty"`
+}
+
+// betHistoryStatuses are the statuses a bet history can be filtered by.
+var betHistoryStatuses = map[string]bool{
+	"open": true, "settled": true, "won": true, "lost": true, "void": true,
+	"push": true, "half_won": true, "half_lost": true, string(domain.BetStatusCashedOut): true,
+}
+
+// ListPlayerBets returns a page of a player's bet history, newest first. The
+// sport filter covers fixture bets through their event and outright bets
+// through their market.
+func (s *SportsbookService) ListPlayerBets(ctx context.Context, playerID uuid.UUID, filter BetHistoryFilter) (*BetHistoryPage, error) {
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	var statuses []string
+	settled := false
+	for _, st := range filter.Statuses {
+		if !betHistoryStatuses[st] {
+			return nil, domain.ErrValidation(fmt.Sprintf("unknown bet status %q", st))
+		}
+		if st == "settled" {
+			settled = true
+		} else {
+			statuses = append(statuses, st)
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrValidation("from must be before to")
+	}
+
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, player_id, event_id, market_id, selection_id,
-		       stake_amount_minor, currency, odds_at_placement, potential_payout_minor,
-		       status, payout_amount_minor, game_round_id, transaction_id, placed_at, settled_at
-		FROM sports_bets WHERE player_id = $1
-		ORDER BY placed_at DESC LIMIT 50`, playerID)
+		SELECT b.id, b.player_id, b.event_id, b.market_id, b.selection_id, b.bet_class, b.system_type, b.each_way,
+		       b.stake_amount_minor, b.stake_per_line_minor, b.num_lines, b.currency, b.odds_at_placement, b.potential_payout_minor,
+		       b.status, b.payout_amount_minor, b.game_round_id, b.transaction_id, b.placed_at, b.settled_at
+		FROM sports_bets b
+		WHERE b.player_id = $1
+		  AND (NOT $2::bool AND $3::text[] IS NULL
+		       OR $2 AND b.status <> 'open'
+		       OR b.status = ANY($3))
+		  AND ($4::timestamptz IS NULL OR b.placed_at >= $4)
+		  AND ($5::timestamptz IS NULL OR b.placed_at < $5)
+		  AND ($6::uuid IS NULL OR $6 IN (
+		        (SELECT e.sport_id FROM sports_events e WHERE e.id = b.event_id),
+		        (SELECT m.sport_id FROM sports_markets m WHERE m.id = b.market_id))
+		       OR EXISTS (SELECT 1 FROM sports_bet_legs l JOIN sports_events e ON e.id = l.event_id
+		                  WHERE l.bet_id = b.id AND e.sport_id = $6))
+		  AND ($7::uuid IS NULL
+		       OR (b.placed_at, b.id) <= (SELECT c.placed_at, c.id FROM sports_bets c WHERE c.id = $7 AND c.player_id = $1))
+		ORDER BY b.placed_at DESC, b.id DESC
+		LIMIT $8`,
+		playerID, settled, statuses, filter.From, filter.To, filter.SportID, filter.Cursor, filter.Limit+1)
 	if err != nil {
 		return nil, domain.ErrInternal("query bets", err)
 	}
 	defer rows.Close()
 
-	var bets []domain.SportsBetRecord
+	bets := []domain.SportsBetRecord{}
 	for rows.Next() {
 		var b domain.SportsBetRecord
 		if err := rows.Scan(
-			&b.ID, &b.PlayerID, &b.EventID, &b.MarketID, &b.SelectionID,
-			&b.StakeAmountMinor, &b.Currency, &b.OddsAtPlacement, &b.PotentialPayoutMinor,
+			&b.ID, &b.PlayerID, &b.EventID, &b.MarketID, &b.SelectionID, &b.BetClass, &b.SystemType, &b.EachWay,
+			&b.StakeAmountMinor, &b.StakePerLineMinor, &b.NumLines, &b.Currency, &b.OddsAtPlacement, &b.PotentialPayoutMinor,
 			&b.Status, &b.PayoutAmountMinor, &b.GameRoundID, &b.TransactionID, &b.PlacedAt, &b.SettledAt,
 		); err != nil {
 			return nil, domain.ErrInternal("scan bet", err)
 		}
 		bets = append(bets, b)
 	}
-	return bets, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read bets", err)
+	}
+	rows.Close()
+	if err := s.loadBetLegs(ctx, bets); err != nil {
+		return nil, err
+	}
+
+	page := &BetHistoryPage{Bets: bets}
+	if len(bets) > filter.Limit {
+		page.Bets = bets[:filter.Limit]
+		next := bets[filter.Limit].ID.String()
+		page.NextCursor = &next
+	}
+	return page, nil
 }
 
 // ListSports returns all active sports.
@@ -201,7 +429,7 @@ func (s *SportsbookService) ListEvents(ctx context.Context, db repository.DBTX,
 // ListMarkets returns markets for an event.
 func (s *SportsbookService) ListMarkets(ctx context.Context, db repository.DBTX, eventID uuid.UUID) ([]domain.SportsMarket, error) {
 	rows, err := db.Query(ctx,
-		`SELECT id, event_id, name, type, status, specifiers, sort_order, created_at
+		`SELECT `+sportsMarketColumns+`
 		 FROM sports_markets WHERE event_id = $1 AND status = 'open'
 		 ORDER BY sort_order ASC`, eventID)
 	if err != nil {
@@ -211,33 +439,47 @@ func (s *SportsbookService) ListMarkets(ctx context.Context, db repository.DBTX,
 
 	var markets []domain.SportsMarket
 	for rows.Next() {
-		var m domain.SportsMarket
-		if err := rows.Scan(&m.ID, &m.EventID, &m.Name, &m.Type, &m.Status, &m.Specifiers, &m.SortOrder, &m.CreatedAt); err != nil {
+		m, err := scanSportsMarket(rows)
+		if err != nil {
 			return nil, domain.ErrInternal("scan market", err)
 		}
-		markets = append(markets, m)
+		markets = append(markets, *m)
 	}
 	return markets, rows.Err()
 }
 
 // SettleEventResult holds the summary of an event settlement.
 type SettleEventResult struct {
-	Settled int `json:"settled"`
-	Won     int `json:"won"`
-	Lost    int `json:"lost"`
-	Voided  int `json:"voided"`
+	Settled     int `json:"settled"`
+	Won         int `json:"won"`
+	Lost        int `json:"lost"`
+	Voided      int `json:"voided"`
+	Pushed      int `json:"pushed"`
+	HalfWon     int `json:"half_won"`
+	HalfLost    int `json:"half_lost"`
+	Pools       int `json:"pools"`
+	Systems     int `json:"systems"`
+	BetBuilders int `json:"bet_builders"`
 }
 
 // SettleEvent settles all open bets for a given event based on selection results.
-// The event must have status "settled". For each open bet:
-//   - Won selection → CreditWin with payout amount
+// The event must have status "settled". Handicap and totals selections with
+// no result recorded are resolved from the final score by policy.LineResult.
+// For each open bet, per policy.SettleBet:
+//   - Won selection → CreditWin with the payout under each-way and dead-heat terms
+//   - Half won/lost → CreditWin with the half payout or the half stake returned
 //   - Lost selection → update bet status only (stake already deducted)
-//   - Void selection → CancelTransaction to restore stake
+//   - Void or push → CancelTransaction to restore stake
+//
+// Placed bet pools on the event are then settled as one bet each, and system
+// bets with a leg on the event and the event's bet builders once all their
+// legs have results.
 func (s *SportsbookService) SettleEvent(ctx context.Context, eventID uuid.UUID) (*SettleEventResult, error) {
 	// Verify event status
 	var eventStatus string
+	var scoreHome, scoreAway *int
 	err := s.pool.QueryRow(ctx,
-		`SELECT status FROM sports_events WHERE id = $1`, eventID).Scan(&eventStatus)
+		`SELECT status, score_home, score_away FROM sports_events WHERE id = $1`, eventID).Scan(&eventStatus, &scoreHome, &scoreAway)
 	if err != nil {
 		return nil, domain.ErrNotFound("event", eventID.String())
 	}
@@ -245,33 +487,71 @@ func (s *SportsbookService) SettleEvent(ctx context.Context, eventID uuid.UUID)
 		return nil, domain.ErrValidation("event must have status 'settled' to settle bets")
 	}
 
-	// Query open bets with their selection results
+	bets, err := s.queryOpenBets(ctx, `b.event_id = $1`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for i, bet := range bets {
+		if bet.Result == nil && bet.Line != nil && bet.Side != nil && scoreHome != nil && scoreAway != nil {
+			r := policy.LineResult(*bet.Side, *bet.Line, *scoreHome, *scoreAway)
+			bets[i].Result = &r
+		}
+	}
+	result, err := s.settleOpenBets(ctx, bets)
+	if err != nil {
+		return nil, err
+	}
+	if result.Pools, err = s.settlePools(ctx, `p.event_id = $1`, eventID, scoreHome, scoreAway); err != nil {
+		return nil, err
+	}
+	if err := s.settleSystemBets(ctx, eventID, result); err != nil {
+		return nil, err
+	}
+	if err := s.settleBetBuilders(ctx, eventID, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// openSportsBet is an open bet joined to its selection's outcome.
+type openSportsBet struct {
+	ID            uuid.UUID
+	PlayerID      uuid.UUID
+	TransactionID *uuid.UUID
+	Stake         int64
+	Odds          int
+	EachWay       bool
+	Places        *int
+	Fraction      *int
+	GameRoundID   string
+	Result        *string
+	Position      *int
+	DeadHeat      int
+	Line          *int
+	Side          *string
+}
+
+// queryOpenBets returns open bets matching where, which is written against
+// sports_bets b and takes one argument.
+func (s *SportsbookService) queryOpenBets(ctx context.Context, where string, arg any) ([]openSportsBet, error) {
 	rows, err := s.pool.Query(ctx, `
-		SELECT b.id, b.player_id, b.transaction_id, b.stake_amount_minor,
-		       b.potential_payout_minor, b.game_round_id, sel.result
+		SELECT b.id, b.player_id, b.transaction_id, b.stake_amount_minor, b.odds_at_placement,
+		       b.each_way, b.each_way_places, b.each_way_fraction, b.game_round_id,
+		       sel.result, sel.finish_position, sel.dead_heat_count, sel.line, sel.side
 		FROM sports_bets b
 		JOIN sports_selections sel ON sel.id = b.selection_id
-		WHERE b.event_id = $1 AND b.status = 'open'`, eventID)
+		WHERE `+where+` AND b.status = 'open'`, arg)
 	if err != nil {
 		return nil, domain.ErrInternal("query open bets", err)
 	}
 	defer rows.Close()
 
-	type openBet struct {
-		ID            uuid.UUID
-		PlayerID      uuid.UUID
-		TransactionID *uuid.UUID
-		Stake         int64
-		Payout        int64
-		GameRoundID   string
-		Result        *string
-	}
-
-	var bets []openBet
+	var bets []openSportsBet
 	for rows.Next() {
-		var b openBet
-		if err := rows.Scan(&b.ID, &b.PlayerID, &b.TransactionID, &b.Stake,
-			&b.Payout, &b.GameRoundID, &b.Result); err != nil {
+		var b openSportsBet
+		if err := rows.Scan(&b.ID, &b.PlayerID, &b.TransactionID, &b.Stake, &b.Odds,
+			&b.EachWay, &b.Places, &b.Fraction, &b.GameRoundID,
+			&b.Result, &b.Position, &b.DeadHeat, &b.Line, &b.Side); err != nil {
 			return nil, domain.ErrInternal("scan bet", err)
 		}
 		bets = append(bets, b)
@@ -279,101 +559,165 @@ func (s *SportsbookService) SettleEvent(ctx context.Context, eventID uuid.UUID)
 	if err := rows.Err(); err != nil {
 		return nil, domain.ErrInternal("iterate bets", err)
 	}
+	return bets, nil
+}
 
+// settleOpenBets settles each bet in its own transaction by the settlement
+// rules. Bets whose selection has no result yet are skipped.
+func (s *SportsbookService) settleOpenBets(ctx context.Context, bets []openSportsBet) (*SettleEventResult, error) {
 	result := &SettleEventResult{}
 
 	for _, bet := range bets {
 		if bet.Result == nil || *bet.Result == "" {
-			s.logger.Warn("skipping bet with no selection result",
-				"bet_id", bet.ID, "event_id", eventID)
+			s.logger.Warn("skipping bet with no selection result", "bet_id", bet.ID)
 			continue
 		}
 
-		tx, err := s.pool.Begin(ctx)
-		if err != nil {
-			return nil, domain.ErrInternal("begin settle tx", err)
+		terms := policy.BetTerms{Stake: bet.Stake, Odds: bet.Odds}
+		if bet.EachWay && bet.Places != nil && bet.Fraction != nil {
+			terms.EachWay = &policy.EachWayTerms{Places: *bet.Places, Fraction: *bet.Fraction}
 		}
+		outcome := policy.SelectionOutcome{Result: *bet.Result, Placing: policy.Placing{DeadHeat: bet.DeadHeat}}
+		if bet.Position != nil {
+			outcome.Placing.Position = *bet.Position
+		}
+		st := policy.SettleBet(terms, outcome)
 
 		switch *bet.Result {
-		case "won":
-			_, err = s.engine.ExecuteCreditWin(ctx, tx, domain.CreditWinParams{
-				PlayerID:              bet.PlayerID,
-				Amount:                bet.Payout,
-				ExternalTransactionID: fmt.Sprintf("settle_win_%s", bet.ID.String()[:8]),
-				ManufacturerID:        "sportsbook",
-				SubTransactionID:      "1",
-				GameRoundID:           bet.GameRoundID,
-				WinType:               domain.CasinoWinNormal,
-			})
-			if err != nil {
-				tx.Rollback(ctx)
-				return nil, fmt.Errorf("settle win bet %s: %w", bet.ID, err)
-			}
-			_, err = tx.Exec(ctx,
-				`UPDATE sports_bets SET status = 'won', payout_amount_minor = $2, settled_at = now() WHERE id = $1`,
-				bet.ID, bet.Payout)
-			if err != nil {
-				tx.Rollback(ctx)
-				return nil, domain.ErrInternal("update won bet", err)
-			}
-			result.Won++
-
-		case "lost":
-			_, err = tx.Exec(ctx,
-				`UPDATE sports_bets SET status = 'lost', settled_at = now() WHERE id = $1`,
-				bet.ID)
-			if err != nil {
-				tx.Rollback(ctx)
-				return nil, domain.ErrInternal("update lost bet", err)
-			}
-			result.Lost++
-
-		case "void":
-			if bet.TransactionID == nil {
-				tx.Rollback(ctx)
-				s.logger.Warn("void bet has no transaction_id", "bet_id", bet.ID)
-				continue
-			}
-			_, err = s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
-				PlayerID:              bet.PlayerID,
-				Amount:                bet.Stake,
-				ExternalTransactionID: fmt.Sprintf("settle_void_%s", bet.ID.String()[:8]),
-				ManufacturerID:        "sportsbook",
-				SubTransactionID:      "1",
-				TargetTransactionID:   *bet.TransactionID,
-			})
-			if err != nil {
-				tx.Rollback(ctx)
-				return nil, fmt.Errorf("settle void bet %s: %w", bet.ID, err)
-			}
-			_, err = tx.Exec(ctx,
-				`UPDATE sports_bets SET status = 'void', settled_at = now() WHERE id = $1`,
-				bet.ID)
-			if err != nil {
-				tx.Rollback(ctx)
-				return nil, domain.ErrInternal("update void bet", err)
-			}
-			result.Voided++
-
+		case policy.ResultWon, policy.ResultPlaced, policy.ResultLost, policy.ResultVoid,
+			policy.ResultPush, policy.ResultHalfWon, policy.ResultHalfLost:
 		default:
-			tx.Rollback(ctx)
 			s.logger.Warn("unknown selection result", "result", *bet.Result, "bet_id", bet.ID)
 			continue
 		}
+		if st.Refund && bet.TransactionID == nil {
+			s.logger.Warn("void bet has no transaction_id", "bet_id", bet.ID)
+			continue
+		}
 
-		if err := tx.Commit(ctx); err != nil {
-			return nil, domain.ErrInternal("commit settle tx", err)
+		if err := s.applySettlement(ctx, bet, st); err != nil {
+			return nil, err
 		}
-		result.Settled++
+		result.add(st.Status)
 	}
 
 	return result, nil
 }
 
+// applySettlement posts a bet's settlement in its own transaction: a refund
+// cancels the stake, a return is credited and anything else marks the bet
+// lost. Bet.Stake is the total debited.
+func (s *SportsbookService) applySettlement(ctx context.Context, bet openSportsBet, st policy.Settlement) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin settle tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	switch {
+	case st.Refund:
+		err = s.settleVoidBet(ctx, tx, bet.ID, bet.PlayerID, bet.Stake, *bet.TransactionID, st.Status)
+	case st.Return > 0:
+		err = s.settleWonBet(ctx, tx, bet.ID, bet.PlayerID, bet.GameRoundID, st.Return, st.Status)
+	default:
+		if _, execErr := tx.Exec(ctx,
+			`UPDATE sports_bets SET status = 'lost', settled_at = now() WHERE id = $1`,
+			bet.ID); execErr != nil {
+			err = domain.ErrInternal("update lost bet", execErr)
+		}
+	}
+	if err == nil && (st.Refund || st.Return == 0) {
+		// Wins close the bet's round as they post; losses and refunds do not.
+		_, err = s.engine.ExecuteCloseRound(ctx, tx, domain.CloseRoundParams{
+			PlayerID:       bet.PlayerID,
+			ManufacturerID: "sportsbook",
+			GameRoundID:    bet.GameRoundID,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewBetSettledEvent(bet.PlayerID, bet.ID, bet.Stake, st.Status, st.Return)); err != nil {
+		return domain.ErrInternal("insert outbox event", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit settle tx", err)
+	}
+	return nil
+}
+
+// add counts a settled bet under its status.
+func (r *SettleEventResult) add(status string) {
+	switch status {
+	case policy.ResultWon:
+		r.Won++
+	case policy.ResultLost:
+		r.Lost++
+	case policy.ResultVoid:
+		r.Voided++
+	case policy.ResultPush:
+		r.Pushed++
+	case policy.ResultHalfWon:
+		r.HalfWon++
+	case policy.ResultHalfLost:
+		r.HalfLost++
+	}
+	r.Settled++
+}
+
+// settleWonBet credits payout for a bet that returns money, marking it with
+// status (won, half_won or half_lost).
+func (s *SportsbookService) settleWonBet(ctx context.Context, tx pgx.Tx, betID, playerID uuid.UUID, gameRoundID string, payout int64, status string) error {
+	_, err := s.engine.ExecuteCreditWin(ctx, tx, domain.CreditWinParams{
+		PlayerID:              playerID,
+		Amount:                payout,
+		ExternalTransactionID: fmt.Sprintf("settle_win_%s", betID.String()[:8]),
+		ManufacturerID:        "sportsbook",
+		SubTransactionID:      "1",
+		GameRoundID:           gameRoundID,
+		WinType:               domain.CasinoWinNormal,
+	})
+	if err != nil {
+		return fmt.Errorf("settle win bet %s: %w", betID, err)
+	}
+	_, err = tx.Exec(ctx,
+		`UPDATE sports_bets SET status = $3, payout_amount_minor = $2, settled_at = now() WHERE id = $1`,
+		betID, payout, status)
+	if err != nil {
+		return domain.ErrInternal("update won bet", err)
+	}
+	return nil
+}
+
+// settleVoidBet cancels the stake transaction of a void or pushed bet and
+// marks it with status.
+func (s *SportsbookService) settleVoidBet(ctx context.Context, tx pgx.Tx, betID, playerID uuid.UUID, stake int64, transactionID uuid.UUID, status string) error {
+	_, err := s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+		PlayerID:              playerID,
+		Amount:                stake,
+		ExternalTransactionID: fmt.Sprintf("settle_void_%s", betID.String()[:8]),
+		ManufacturerID:        "sportsbook",
+		SubTransactionID:      "1",
+		TargetTransactionID:   transactionID,
+	})
+	if err != nil {
+		return fmt.Errorf("settle void bet %s: %w", betID, err)
+	}
+	_, err = tx.Exec(ctx,
+		`UPDATE sports_bets SET status = $2, settled_at = now() WHERE id = $1`,
+		betID, status)
+	if err != nil {
+		return domain.ErrInternal("update void bet", err)
+	}
+	return nil
+}
+
 // ListSelections returns selections for a market.
 func (s *SportsbookService) ListSelections(ctx context.Context, db repository.DBTX, marketID uuid.UUID) ([]domain.SportsSelection, error) {
 	rows, err := db.Query(ctx,
-		`SELECT id, market_id, name, odds_decimal, odds_fractional, odds_american, status, result, sort_order, created_at
+		`SELECT id, market_id, name, odds_decimal, odds_fractional, odds_american, status, result,
+		        finish_position, dead_heat_count, line, side, sort_order, created_at
 		 FROM sports_selections WHERE market_id = $1 AND status = 'active'
 		 ORDER BY sort_order ASC`, marketID)
 	if err != nil {
@@ -384,7 +728,7 @@ func (s *SportsbookService) ListSelections(ctx context.Context, db repository.DB
 	var selections []domain.SportsSelection
 	for rows.Next() {
 		var s domain.SportsSelection
-		if err := rows.Scan(&s.ID, &s.MarketID, &s.Name, &s.OddsDecimal, &s.OddsFractional, &s.OddsAmerican, &s.Status, &s.Result, &s.SortOrder, &s.CreatedAt); err != nil {
+		if err := rows.Scan(&s.ID, &s.MarketID, &s.Name, &s.OddsDecimal, &s.OddsFractional, &s.OddsAmerican, &s.Status, &s.Result, &s.FinishPosition, &s.DeadHeatCount, &s.Line, &s.Side, &s.SortOrder, &s.CreatedAt); err != nil {
 			return nil, domain.ErrInternal("scan selection", err)
 		}
 		selections = append(selections, s)
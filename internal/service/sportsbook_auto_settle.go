@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// OddsAPISettlementSource is the settlement audit source for scores from
+// The Odds API.
+const OddsAPISettlementSource = "the_odds_api"
+
+// ScoreFeed supplies final scores of completed events.
+type ScoreFeed interface {
+	FetchFinalScores(ctx context.Context) ([]provider.FinalScore, error)
+}
+
+// StartAutoSettlement polls feed every interval and settles the events it
+// reports as completed, until ctx is cancelled.
+func (s *SportsbookService) StartAutoSettlement(ctx context.Context, feed ScoreFeed, source string, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("auto settlement stopped")
+				return
+			case <-ticker.C:
+				n, err := s.AutoSettle(ctx, feed, source)
+				if err != nil {
+					s.logger.Error("auto settlement", "error", err)
+				} else if n > 0 {
+					s.logger.Info("auto settled events", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+// AutoSettle settles every event feed reports a final score for and returns
+// how many were settled. An event that fails is logged and retried on the
+// next run.
+func (s *SportsbookService) AutoSettle(ctx context.Context, feed ScoreFeed, source string) (int, error) {
+	scores, err := feed.FetchFinalScores(ctx)
+	if err != nil {
+		return 0, err
+	}
+	settled := 0
+	for _, score := range scores {
+		result, err := s.SettleFromScore(ctx, source, score)
+		if err != nil {
+			s.logger.Error("auto settle event", "external_event_id", score.ExternalID, "error", err)
+			continue
+		}
+		if result != nil {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// SettleFromScore settles the event a feed score belongs to: it records the
+// final score, resolves the event's 1x2 selections from it and calls
+// SettleEvent, which resolves handicap and totals lines. Every attempt is
+// written to the settlement audit with the feed data. It returns nil without
+// settling when the event is unknown, was already settled automatically, or
+// was settled by hand.
+func (s *SportsbookService) SettleFromScore(ctx context.Context, source string, score provider.FinalScore) (*SettleEventResult, error) {
+	var eventID uuid.UUID
+	var status, homeTeam, awayTeam string
+	var audited, succeeded bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT e.id, e.status, e.home_team, e.away_team,
+		       EXISTS (SELECT 1 FROM sports_settlement_audit a WHERE a.event_id = e.id),
+		       EXISTS (SELECT 1 FROM sports_settlement_audit a WHERE a.event_id = e.id AND a.error IS NULL)
+		FROM sports_events e WHERE e.odds88_event_id = $1`, score.EventKey()).
+		Scan(&eventID, &status, &homeTeam, &awayTeam, &audited, &succeeded)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find event", err)
+	}
+	if succeeded || status == "settled" && !audited {
+		return nil, nil
+	}
+
+	var result *SettleEventResult
+	err = s.applyFinalScore(ctx, eventID, homeTeam, awayTeam, score)
+	if err == nil {
+		result, err = s.SettleEvent(ctx, eventID)
+	}
+	s.recordSettlementAudit(ctx, eventID, source, score, result, err)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("event auto settled", "event_id", eventID, "source", source,
+		"score_home", score.ScoreHome, "score_away", score.ScoreAway, "settled", result.Settled)
+	return result, nil
+}
+
+// applyFinalScore marks the event settled with its final score and sets the
+// result of each unresulted 1x2 selection.
+func (s *SportsbookService) applyFinalScore(ctx context.Context, eventID uuid.UUID, homeTeam, awayTeam string, score provider.FinalScore) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE sports_events SET status = 'settled', score_home = $2, score_away = $3, updated_at = now()
+		WHERE id = $1`, eventID, score.ScoreHome, score.ScoreAway); err != nil {
+		return domain.ErrInternal("update event score", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT sel.id, sel.name
+		FROM sports_selections sel JOIN sports_markets m ON m.id = sel.market_id
+		WHERE m.event_id = $1 AND m.type = '1x2' AND sel.result IS NULL`, eventID)
+	if err != nil {
+		return domain.ErrInternal("query 1x2 selections", err)
+	}
+	results := map[uuid.UUID]string{}
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return domain.ErrInternal("scan selection", err)
+		}
+		if r, ok := policy.MoneylineResult(name, homeTeam, awayTeam, score.ScoreHome, score.ScoreAway); ok {
+			results[id] = r
+		} else {
+			s.logger.Warn("unmatched 1x2 selection", "event_id", eventID, "selection", name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ErrInternal("read selections", err)
+	}
+	for id, r := range results {
+		if _, err := tx.Exec(ctx, `
+			UPDATE sports_selections SET result = $2, updated_at = now() WHERE id = $1`, id, r); err != nil {
+			return domain.ErrInternal("update selection result", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	return nil
+}
+
+// recordSettlementAudit writes one settlement attempt to the audit. A failed
+// write is logged; it does not undo the settlement.
+func (s *SportsbookService) recordSettlementAudit(ctx context.Context, eventID uuid.UUID, source string, score provider.FinalScore, result *SettleEventResult, settleErr error) {
+	var resultJSON []byte
+	var errText *string
+	if settleErr != nil {
+		msg := settleErr.Error()
+		errText = &msg
+	} else if result != nil {
+		resultJSON, _ = json.Marshal(result)
+	}
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO sports_settlement_audit
+			(event_id, source, external_event_id, score_home, score_away, source_data, result, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		eventID, source, score.ExternalID, score.ScoreHome, score.ScoreAway, score.Raw, resultJSON, errText); err != nil {
+		s.logger.Error("record settlement audit", "event_id", eventID, "error", err)
+	}
+}
+
+// ListSettlementAudit returns an event's automatic settlement attempts,
+// newest first.
+func (s *SportsbookService) ListSettlementAudit(ctx context.Context, eventID uuid.UUID) ([]domain.SettlementAudit, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, event_id, source, external_event_id, score_home, score_away,
+		       source_data, result, error, created_at
+		FROM sports_settlement_audit WHERE event_id = $1
+		ORDER BY created_at DESC, id DESC`, eventID)
+	if err != nil {
+		return nil, domain.ErrInternal("list settlement audit", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.SettlementAudit{}
+	for rows.Next() {
+		var a domain.SettlementAudit
+		if err := rows.Scan(&a.ID, &a.EventID, &a.Source, &a.ExternalEventID, &a.ScoreHome, &a.ScoreAway,
+			&a.SourceData, &a.Result, &a.Error, &a.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan settlement audit", err)
+		}
+		entries = append(entries, a)
+	}
+	return entries, rows.Err()
+}
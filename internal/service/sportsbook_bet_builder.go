@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+)
+
+// placeBetBuilder places a bet builder: its legs are selections in
+// different markets of one event, priced together at the product of their
+// odds discounted for correlation by s.builder. The top-level ExpectedOdds,
+// if given, are checked against that combined price. The bet counts toward
+// the event's book and the player's event exposure like a single.
+func (s *SportsbookService) placeBetBuilder(ctx context.Context, playerID uuid.UUID, input PlaceBetInput) (*PlaceBetResult, error) {
+	if input.EachWay {
+		return nil, domain.ErrValidation("bet builders cannot be each-way")
+	}
+	if input.System != "" {
+		return nil, domain.ErrValidation("system is only valid for system bets")
+	}
+
+	legs := make([]betLeg, len(input.Legs))
+	priced := make([]policy.BuilderLeg, len(input.Legs))
+	types := make([]string, len(input.Legs))
+	markets := map[uuid.UUID]bool{}
+	var eventID uuid.UUID
+	for i, in := range input.Legs {
+		if in.ExpectedOdds != 0 && in.ExpectedOdds < policy.MinOdds {
+			return nil, domain.ErrValidation(fmt.Sprintf("expected_odds must be at least %d", policy.MinOdds))
+		}
+		leg, err := s.priceBetLeg(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			eventID = *leg.EventID
+		}
+		if *leg.EventID != eventID || (input.EventID != uuid.Nil && *leg.EventID != input.EventID) {
+			return nil, domain.ErrValidation("bet builder selections must be on the same event")
+		}
+		if markets[leg.MarketID] {
+			return nil, domain.ErrValidation("bet builder selections must be on different markets")
+		}
+		markets[leg.MarketID] = true
+		legs[i] = *leg
+		priced[i] = policy.BuilderLeg{MarketType: leg.MarketType, Odds: leg.Odds}
+		types[i] = leg.MarketType
+	}
+	if err := s.builder.Combinable(types); err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+
+	odds := s.builder.Price(priced)
+	if odds < policy.MinOdds {
+		return nil, domain.ErrValidation("combined odds are below the minimum")
+	}
+	if input.ExpectedOdds != 0 && !input.AcceptOddsChanges &&
+		!policy.OddsChangeAcceptable(input.ExpectedOdds, odds, policy.OddsChangeToleranceBps) {
+		return nil, domain.ErrOddsChanged(input.ExpectedOdds, odds)
+	}
+
+	if err := s.checkBetLimit(ctx, playerID, input.Stake); err != nil {
+		return nil, err
+	}
+
+	payoutFor := func(stake int64) int64 { return stake * int64(odds) / 100 }
+	selectionIDs := make([]uuid.UUID, len(legs))
+	for i, leg := range legs {
+		selectionIDs[i] = leg.SelectionID
+	}
+	metadata, _ := json.Marshal(map[string]any{"event_id": eventID, "selection_ids": selectionIDs})
+
+	betID := uuid.New()
+	gameRoundID := fmt.Sprintf("sb_%s", betID.String()[:8])
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	facts, limits, err := s.bookExposure(ctx, tx, playerID, &eventID, uuid.Nil, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	stake, capped := input.Stake, false
+	if maxPayout := limits.MaxPayout(facts); payoutFor(stake) > maxPayout {
+		stake = policy.CapStake(stake, maxPayout, payoutFor)
+		if !input.AcceptStakeCap || stake <= 0 {
+			return nil, domain.ErrExposureLimitExceeded(stake)
+		}
+		capped = true
+	}
+	potentialPayout := payoutFor(stake)
+
+	result, err := s.engine.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
+		PlayerID:              playerID,
+		Amount:                stake,
+		ExternalTransactionID: fmt.Sprintf("bet_%s", betID.String()[:8]),
+		ManufacturerID:        "sportsbook",
+		SubTransactionID:      "1",
+		GameRoundID:           gameRoundID,
+		Metadata:              metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO sports_bets (id, player_id, event_id, stake_amount_minor, currency, odds_at_placement,
+			potential_payout_minor, status, game_round_id, transaction_id,
+			bet_class, stake_per_line_minor, num_lines)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'open', $8, $9, $10, $11, 1)`,
+		betID, playerID, eventID, stake, result.Transaction.Currency, odds, potentialPayout, gameRoundID,
+		result.Transaction.ID, domain.BetClassBetBuilder, stake)
+	if err != nil {
+		return nil, domain.ErrInternal("insert bet", err)
+	}
+	for i, leg := range legs {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO sports_bet_legs (bet_id, leg_no, event_id, market_id, selection_id, odds_at_placement)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			betID, i+1, leg.EventID, leg.MarketID, leg.SelectionID, leg.Odds); err != nil {
+			return nil, domain.ErrInternal("insert bet leg", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	return &PlaceBetResult{
+		BetID:           betID,
+		GameRoundID:     gameRoundID,
+		Stake:           stake,
+		Odds:            odds,
+		PotentialPayout: potentialPayout,
+		BetClass:        domain.BetClassBetBuilder,
+		Lines:           1,
+		TotalStake:      stake,
+		StakeCapped:     capped,
+	}, nil
+}
+
+// settleBetBuilders settles the event's open bet builders once all their
+// legs have results, per policy.SettleBetBuilder.
+func (s *SportsbookService) settleBetBuilders(ctx context.Context, eventID uuid.UUID, result *SettleEventResult) error {
+	rows, err := s.pool.Query(ctx, `
+		SELECT b.id, b.player_id, b.transaction_id, b.stake_amount_minor, b.odds_at_placement, b.game_round_id
+		FROM sports_bets b
+		WHERE b.bet_class = 'bet_builder' AND b.status = 'open' AND b.event_id = $1`, eventID)
+	if err != nil {
+		return domain.ErrInternal("query bet builders", err)
+	}
+	var bets []openSportsBet
+	for rows.Next() {
+		var b openSportsBet
+		if err := rows.Scan(&b.ID, &b.PlayerID, &b.TransactionID, &b.Stake, &b.Odds, &b.GameRoundID); err != nil {
+			rows.Close()
+			return domain.ErrInternal("scan bet builder", err)
+		}
+		bets = append(bets, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ErrInternal("iterate bet builders", err)
+	}
+
+	for _, bet := range bets {
+		legs, complete, err := s.betLegOutcomes(ctx, bet.ID)
+		if err != nil {
+			return err
+		}
+		if !complete {
+			continue
+		}
+		st := policy.SettleBetBuilder(s.builder, bet.Stake, bet.Odds, legs)
+		if st.Refund && bet.TransactionID == nil {
+			s.logger.Warn("void bet has no transaction_id", "bet_id", bet.ID)
+			continue
+		}
+		if err := s.applySettlement(ctx, bet, st); err != nil {
+			return err
+		}
+		result.add(st.Status)
+		result.BetBuilders++
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CashOutQuote is what an open bet can be cashed out for right now. When
+// Available is false, Reason says why and Value is zero.
+type CashOutQuote struct {
+	BetID           uuid.UUID `json:"bet_id"`
+	Stake           int64     `json:"stake"`
+	OddsAtPlacement int       `json:"odds_at_placement"`
+	CurrentOdds     int       `json:"current_odds"`
+	Value           int64     `json:"value"`
+	Available       bool      `json:"available"`
+	Reason          string    `json:"reason,omitempty"`
+}
+
+// cashOutBet is an open bet joined to the state its cash-out depends on.
+type cashOutBet struct {
+	PlayerID    uuid.UUID
+	Status      domain.BetStatus
+	GameRoundID string
+	Currency    string // wallet the stake was taken from
+	Quote       CashOutQuote
+}
+
+// loadCashOutBet reads a player's bet and prices its cash-out. System bets
+// and bet builders have no selection of their own, so the selection and
+// market are left-joined and such bets are quoted as unavailable. The
+// currency is the stake's ledger entry's, so the cash-out is paid to the
+// wallet the stake came from. With lock set the bet row is locked for the
+// caller's transaction.
+func loadCashOutBet(ctx context.Context, db repository.DBTX, playerID, betID uuid.UUID, lock bool) (*cashOutBet, error) {
+	query := `
+		SELECT b.player_id, b.status, b.game_round_id, COALESCE(t.currency, b.currency),
+		       b.stake_amount_minor, b.odds_at_placement,
+		       b.bet_class, b.each_way, COALESCE(sel.odds_decimal, 0), COALESCE(sel.status, ''),
+		       COALESCE(sel.result IS NOT NULL AND sel.result <> '', false),
+		       COALESCE(m.status, ''), COALESCE(e.cash_out_enabled, true)
+		FROM sports_bets b
+		LEFT JOIN v2_transactions t ON t.id = b.transaction_id
+		LEFT JOIN sports_selections sel ON sel.id = b.selection_id
+		LEFT JOIN sports_markets m ON m.id = b.market_id
+		LEFT JOIN sports_events e ON e.id = b.event_id
+		WHERE b.id = $1`
+	if lock {
+		query += ` FOR UPDATE OF b`
+	}
+
+	bet := &cashOutBet{Quote: CashOutQuote{BetID: betID}}
+	var state policy.CashOutState
+	err := db.QueryRow(ctx, query, betID).Scan(&bet.PlayerID, &bet.Status, &bet.GameRoundID, &bet.Currency,
+		&bet.Quote.Stake, &bet.Quote.OddsAtPlacement, &state.BetClass, &state.EachWay, &bet.Quote.CurrentOdds,
+		&state.SelectionStatus, &state.HasResult, &state.MarketStatus, &state.EventEnabled)
+	if errors.Is(err, pgx.ErrNoRows) || err == nil && bet.PlayerID != playerID {
+		return nil, domain.ErrNotFound("bet", betID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("load bet", err)
+	}
+
+	if bet.Status != domain.BetStatusOpen {
+		bet.Quote.Reason = "bet is already settled"
+	} else if err := policy.CashOutAvailable(state); err != nil {
+		bet.Quote.Reason = err.Error()
+	} else {
+		bet.Quote.Value = policy.CashOutValue(bet.Quote.Stake, bet.Quote.OddsAtPlacement, bet.Quote.CurrentOdds)
+		bet.Quote.Available = bet.Quote.Value > 0
+		if !bet.Quote.Available {
+			bet.Quote.Reason = "bet has no cash-out value"
+		}
+	}
+	return bet, nil
+}
+
+// CashOutQuote prices a player's open bet without settling it.
+func (s *SportsbookService) CashOutQuote(ctx context.Context, playerID, betID uuid.UUID) (*CashOutQuote, error) {
+	bet, err := loadCashOutBet(ctx, s.pool, playerID, betID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &bet.Quote, nil
+}
+
+// CashOut settles a player's open bet early at its current cash-out value
+// and marks it cashed_out. A positive minValue guards against the price
+// moving between quote and request: the cash-out is refused if the value has
+// fallen below it.
+func (s *SportsbookService) CashOut(ctx context.Context, playerID, betID uuid.UUID, minValue int64) (*CashOutQuote, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	bet, err := loadCashOutBet(ctx, tx, playerID, betID, true)
+	if err != nil {
+		return nil, err
+	}
+	q := bet.Quote
+	if bet.Status != domain.BetStatusOpen {
+		return nil, domain.ErrConflict("bet is already settled")
+	}
+	if !q.Available {
+		return nil, &domain.AppError{
+			Code:    "CASH_OUT_UNAVAILABLE",
+			Message: fmt.Sprintf("cash-out unavailable: %s", q.Reason),
+			Status:  422,
+		}
+	}
+	if minValue > 0 && q.Value < minValue {
+		return nil, &domain.AppError{
+			Code:    "CASH_OUT_VALUE_CHANGED",
+			Message: "cash-out value has changed",
+			Details: map[string]interface{}{"value": q.Value, "min_value": minValue},
+			Status:  409,
+		}
+	}
+
+	meta, _ := json.Marshal(map[string]any{
+		"bet_id": betID, "odds_at_placement": q.OddsAtPlacement, "current_odds": q.CurrentOdds,
+	})
+	if _, err := s.engine.ExecuteCashOut(ctx, tx, domain.CashOutParams{
+		PlayerID:              playerID,
+		Amount:                q.Value,
+		ExternalTransactionID: fmt.Sprintf("cashout_%s", betID.String()[:8]),
+		ManufacturerID:        "sportsbook",
+		GameRoundID:           bet.GameRoundID,
+		Metadata:              meta,
+		Currency:              bet.Currency,
+	}); err != nil {
+		return nil, fmt.Errorf("cash out bet %s: %w", betID, err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE sports_bets SET status = $2, payout_amount_minor = $3, settled_at = now()
+		WHERE id = $1`, betID, domain.BetStatusCashedOut, q.Value); err != nil {
+		return nil, domain.ErrInternal("update cashed out bet", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.logger.Info("bet cashed out", "bet_id", betID, "player_id", playerID, "value", q.Value)
+	return &q, nil
+}
+
+// SetEventCashOut switches cash-out on or off for every bet on an event.
+func (s *SportsbookService) SetEventCashOut(ctx context.Context, eventID uuid.UUID, enabled bool) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE sports_events SET cash_out_enabled = $2, updated_at = now() WHERE id = $1`, eventID, enabled)
+	if err != nil {
+		return domain.ErrInternal("update event cash-out", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("event", eventID.String())
+	}
+	return nil
+}
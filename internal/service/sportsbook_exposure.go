@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// bookExposure locks the book a bet is placed into, its event or for a bet
+// without one its market, for the caller's transaction and reads the
+// liability the bet adds to, with the limits that apply.
+func (s *SportsbookService) bookExposure(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, eventID *uuid.UUID, marketID, selectionID uuid.UUID) (policy.ExposureFacts, policy.ExposureLimits, error) {
+	var facts policy.ExposureFacts
+	var override *int64
+	limits := s.exposure
+
+	book := marketID.String()
+	if eventID != nil {
+		book = eventID.String()
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "sportsbook_exposure:"+book); err != nil {
+		return facts, limits, domain.ErrInternal("lock book", err)
+	}
+
+	err := tx.QueryRow(ctx, `
+		WITH book AS (
+			SELECT player_id, market_id, selection_id, potential_payout_minor::bigint AS payout
+			FROM sports_bets
+			WHERE status = 'open' AND (($1::uuid IS NULL AND market_id = $2) OR event_id = $1)
+		), sel AS (
+			SELECT market_id, selection_id, SUM(payout) AS liability FROM book GROUP BY market_id, selection_id
+		), mkt AS (
+			SELECT market_id, MAX(liability) AS worst FROM sel GROUP BY market_id
+		)
+		SELECT COALESCE((SELECT liability FROM sel WHERE selection_id = $3), 0)::bigint,
+		       COALESCE((SELECT worst FROM mkt WHERE market_id = $2), 0)::bigint,
+		       COALESCE((SELECT SUM(worst) FROM mkt), 0)::bigint,
+		       COALESCE((SELECT SUM(payout) FROM book WHERE player_id = $4), 0)::bigint,
+		       (SELECT max_liability FROM sports_events WHERE id = $1)`,
+		eventID, marketID, selectionID, playerID,
+	).Scan(&facts.Selection, &facts.Market, &facts.Event, &facts.PlayerEvent, &override)
+	if err != nil {
+		return facts, limits, domain.ErrInternal("read book exposure", err)
+	}
+	if override != nil {
+		limits.Event = *override
+	}
+	return facts, limits, nil
+}
+
+// EventExposure returns the open-bet liability on an event by market and
+// selection, with the ten players holding the most of it.
+func (s *SportsbookService) EventExposure(ctx context.Context, eventID uuid.UUID) (*domain.EventExposure, error) {
+	exposures, err := s.listExposure(ctx, &eventID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(exposures) == 0 {
+		return nil, domain.ErrNotFound("event", eventID.String())
+	}
+	e := &exposures[0]
+	e.Markets = []domain.MarketExposure{}
+	e.Players = []domain.PlayerExposure{}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT m.id, m.name, sel.id, sel.name, sel.odds_decimal,
+		       COUNT(b.id)::int, SUM(b.stake_amount_minor)::bigint, SUM(b.potential_payout_minor)::bigint,
+		       (SELECT COUNT(*) FROM trading_alerts a
+		        WHERE a.status = 'open' AND a.market_id = m.id
+		          AND (a.selection_id = sel.id OR a.selection_id IS NULL))::int
+		FROM sports_bets b
+		JOIN sports_selections sel ON sel.id = b.selection_id
+		JOIN sports_markets m ON m.id = b.market_id
+		WHERE b.status = 'open' AND b.event_id = $1
+		GROUP BY m.id, sel.id
+		ORDER BY m.sort_order, m.name, SUM(b.potential_payout_minor) DESC`, eventID)
+	if err != nil {
+		return nil, domain.ErrInternal("query market exposure", err)
+	}
+	for rows.Next() {
+		var l domain.SelectionLiability
+		if err := rows.Scan(&l.MarketID, &l.MarketName, &l.SelectionID, &l.SelectionName, &l.OddsDecimal,
+			&l.OpenBets, &l.Stake, &l.Liability, &l.OpenAlerts); err != nil {
+			rows.Close()
+			return nil, domain.ErrInternal("scan market exposure", err)
+		}
+		n := len(e.Markets)
+		if n == 0 || e.Markets[n-1].MarketID != l.MarketID {
+			e.Markets = append(e.Markets, domain.MarketExposure{MarketID: l.MarketID, MarketName: l.MarketName})
+			n++
+		}
+		m := &e.Markets[n-1]
+		m.OpenBets += l.OpenBets
+		m.Stake += l.Stake
+		m.WorstCase = max(m.WorstCase, l.Liability)
+		m.Selections = append(m.Selections, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read market exposure", err)
+	}
+
+	rows, err = s.pool.Query(ctx, `
+		SELECT player_id, COUNT(*)::int, SUM(stake_amount_minor)::bigint, SUM(potential_payout_minor)::bigint
+		FROM sports_bets WHERE status = 'open' AND event_id = $1
+		GROUP BY player_id
+		ORDER BY SUM(potential_payout_minor) DESC
+		LIMIT 10`, eventID)
+	if err != nil {
+		return nil, domain.ErrInternal("query player exposure", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p domain.PlayerExposure
+		if err := rows.Scan(&p.PlayerID, &p.OpenBets, &p.Stake, &p.Liability); err != nil {
+			return nil, domain.ErrInternal("scan player exposure", err)
+		}
+		e.Players = append(e.Players, p)
+	}
+	return e, rows.Err()
+}
+
+// ListExposure returns events with open bets, largest worst case first.
+func (s *SportsbookService) ListExposure(ctx context.Context, limit int) ([]domain.EventExposure, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.listExposure(ctx, nil, limit)
+}
+
+// listExposure reads event exposure totals; a nil eventID covers every event
+// with open bets, otherwise the one event is returned even without bets.
+func (s *SportsbookService) listExposure(ctx context.Context, eventID *uuid.UUID, limit int) ([]domain.EventExposure, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH sel AS (
+			SELECT event_id, market_id, COUNT(*) AS bets, SUM(stake_amount_minor) AS stake,
+			       SUM(potential_payout_minor) AS liability
+			FROM sports_bets
+			WHERE status = 'open' AND ($1::uuid IS NULL OR event_id = $1)
+			GROUP BY event_id, market_id, selection_id
+		), mkt AS (
+			SELECT event_id, SUM(bets) AS bets, SUM(stake) AS stake, MAX(liability) AS worst
+			FROM sel GROUP BY event_id, market_id
+		), evt AS (
+			SELECT event_id, SUM(bets) AS bets, SUM(stake) AS stake, SUM(worst) AS worst
+			FROM mkt GROUP BY event_id
+		)
+		SELECT e.id, e.home_team, e.away_team, e.status, e.start_time,
+		       COALESCE(evt.bets, 0)::int, COALESCE(evt.stake, 0)::bigint, COALESCE(evt.worst, 0)::bigint,
+		       e.max_liability
+		FROM sports_events e
+		LEFT JOIN evt ON evt.event_id = e.id
+		WHERE ($1::uuid IS NULL AND evt.event_id IS NOT NULL) OR e.id = $1
+		ORDER BY COALESCE(evt.worst, 0) DESC, e.start_time
+		LIMIT $2`, eventID, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list exposure", err)
+	}
+	defer rows.Close()
+
+	exposures := []domain.EventExposure{}
+	for rows.Next() {
+		var e domain.EventExposure
+		var override *int64
+		if err := rows.Scan(&e.EventID, &e.HomeTeam, &e.AwayTeam, &e.Status, &e.StartTime,
+			&e.OpenBets, &e.Stake, &e.WorstCase, &override); err != nil {
+			return nil, domain.ErrInternal("scan exposure", err)
+		}
+		e.LiabilityLimit = s.exposure.Event
+		if override != nil {
+			e.LiabilityLimit, e.LimitOverride = *override, true
+		}
+		exposures = append(exposures, e)
+	}
+	return exposures, rows.Err()
+}
+
+// SetEventLiabilityLimit overrides an event's worst-case liability limit in
+// cents; nil restores the default and zero lifts the limit for the event.
+func (s *SportsbookService) SetEventLiabilityLimit(ctx context.Context, eventID uuid.UUID, limit *int64) error {
+	if limit != nil && *limit < 0 {
+		return domain.ErrValidation("max_liability must not be negative")
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE sports_events SET max_liability = $2, updated_at = now() WHERE id = $1`, eventID, limit)
+	if err != nil {
+		return domain.ErrInternal("update event liability limit", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound("event", eventID.String())
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxBulkOddsSelections bounds one bulk odds edit.
+const maxBulkOddsSelections = 500
+
+// OddsOverride sets one selection's price (x100).
+type OddsOverride struct {
+	SelectionID uuid.UUID `json:"selection_id"`
+	OddsDecimal int       `json:"odds_decimal"`
+}
+
+// BulkOddsInput is a trader's odds edit: either explicit prices for a list of
+// selections, or a margin delta applied to every active selection of one
+// market.
+type BulkOddsInput struct {
+	Selections     []OddsOverride `json:"selections,omitempty"`
+	MarketID       *uuid.UUID     `json:"market_id,omitempty"`
+	MarginDeltaBps *int           `json:"margin_delta_bps,omitempty"`
+	Reason         string         `json:"reason"`
+}
+
+// BulkOddsResult reports the selections repriced by an edit and each touched
+// market's resulting overround.
+type BulkOddsResult struct {
+	EditID     uuid.UUID           `json:"edit_id"`
+	Changes    []domain.OddsChange `json:"changes"`
+	MarginsBps map[uuid.UUID]int   `json:"margins_bps"`
+}
+
+func (in BulkOddsInput) validate() error {
+	byMargin := in.MarketID != nil || in.MarginDeltaBps != nil
+	switch {
+	case byMargin && len(in.Selections) > 0:
+		return domain.ErrValidation("give either selections or market_id with margin_delta_bps, not both")
+	case byMargin && (in.MarketID == nil || in.MarginDeltaBps == nil):
+		return domain.ErrValidation("market_id and margin_delta_bps are both required for a margin edit")
+	case byMargin:
+		return nil
+	case len(in.Selections) == 0:
+		return domain.ErrValidation("selections or a margin edit is required")
+	case len(in.Selections) > maxBulkOddsSelections:
+		return domain.ErrValidation("too many selections in one edit")
+	}
+	seen := make(map[uuid.UUID]bool, len(in.Selections))
+	for _, o := range in.Selections {
+		if seen[o.SelectionID] {
+			return domain.ErrValidation("selection " + o.SelectionID.String() + " is listed twice")
+		}
+		seen[o.SelectionID] = true
+		if o.OddsDecimal < policy.MinOdds {
+			return domain.ErrValidation("odds_decimal must be at least 101")
+		}
+	}
+	return nil
+}
+
+// BulkUpdateOdds applies a trader's odds edit atomically. The markets
+// involved are suspended for the duration of the edit so no bet is struck
+// against a half-updated book, then reopened; markets that were already
+// suspended stay suspended. Every repriced selection is recorded in
+// sports_odds_changes.
+func (s *SportsbookService) BulkUpdateOdds(ctx context.Context, adminID uuid.UUID, input BulkOddsInput) (*BulkOddsResult, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	marketIDs, err := s.oddsEditMarkets(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	suspended, err := s.suspendMarkets(ctx, marketIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer s.reopenMarkets(context.WithoutCancel(ctx), suspended)
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	prices, err := s.lockEditPrices(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkOddsResult{EditID: uuid.New(), Changes: []domain.OddsChange{}, MarginsBps: map[uuid.UUID]int{}}
+	for _, p := range prices {
+		if _, err := tx.Exec(ctx, `
+			UPDATE sports_selections
+			SET odds_decimal = $2, odds_fractional = NULL, odds_american = NULL, updated_at = now()
+			WHERE id = $1`, p.selectionID, p.newOdds); err != nil {
+			return nil, domain.ErrInternal("update selection odds", err)
+		}
+		var c domain.OddsChange
+		err := tx.QueryRow(ctx, `
+			INSERT INTO sports_odds_changes (edit_id, selection_id, market_id, old_odds, new_odds, margin_delta_bps, reason, admin_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING `+oddsChangeColumns,
+			result.EditID, p.selectionID, p.marketID, p.oldOdds, p.newOdds, input.MarginDeltaBps, input.Reason, adminID).
+			Scan(&c.ID, &c.EditID, &c.SelectionID, &c.MarketID, &c.OldOdds, &c.NewOdds, &c.MarginDeltaBps,
+				&c.Reason, &c.AdminID, &c.CreatedAt)
+		if err != nil {
+			return nil, domain.ErrInternal("record odds change", err)
+		}
+		result.Changes = append(result.Changes, c)
+	}
+
+	for _, id := range marketIDs {
+		odds, err := s.activeOdds(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		result.MarginsBps[id] = policy.MarketMarginBps(odds)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("bulk odds edit applied", "edit_id", result.EditID, "admin_id", adminID,
+		"selections", len(result.Changes), "markets", len(marketIDs))
+	return result, nil
+}
+
+// oddsEditMarkets returns the markets an edit touches, refusing settled ones.
+func (s *SportsbookService) oddsEditMarkets(ctx context.Context, input BulkOddsInput) ([]uuid.UUID, error) {
+	if input.MarketID != nil {
+		var status string
+		err := s.pool.QueryRow(ctx, `SELECT status FROM sports_markets WHERE id = $1`, *input.MarketID).Scan(&status)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound("market", input.MarketID.String())
+		}
+		if err != nil {
+			return nil, domain.ErrInternal("find market", err)
+		}
+		if status == "settled" {
+			return nil, domain.ErrConflict("market " + input.MarketID.String() + " is settled")
+		}
+		return []uuid.UUID{*input.MarketID}, nil
+	}
+
+	ids := make([]uuid.UUID, len(input.Selections))
+	for i, o := range input.Selections {
+		ids[i] = o.SelectionID
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT sel.id, m.id, m.status
+		FROM sports_selections sel JOIN sports_markets m ON m.id = sel.market_id
+		WHERE sel.id = ANY($1)`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("query selections", err)
+	}
+	defer rows.Close()
+
+	found := make(map[uuid.UUID]bool, len(ids))
+	seenMarket := map[uuid.UUID]bool{}
+	var markets []uuid.UUID
+	for rows.Next() {
+		var selID, marketID uuid.UUID
+		var status string
+		if err := rows.Scan(&selID, &marketID, &status); err != nil {
+			return nil, domain.ErrInternal("scan selection", err)
+		}
+		if status == "settled" {
+			return nil, domain.ErrConflict("market " + marketID.String() + " is settled")
+		}
+		found[selID] = true
+		if !seenMarket[marketID] {
+			seenMarket[marketID] = true
+			markets = append(markets, marketID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("read selections", err)
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return nil, domain.ErrNotFound("selection", id.String())
+		}
+	}
+	return markets, nil
+}
+
+// suspendMarkets suspends the open markets among ids and returns them, so
+// only those are reopened afterwards. It commits on its own so bet placement
+// sees the suspension while the edit runs.
+func (s *SportsbookService) suspendMarkets(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE sports_markets SET status = 'suspended', updated_at = now()
+		WHERE id = ANY($1) AND status = 'open'
+		RETURNING id`, ids)
+	if err != nil {
+		return nil, domain.ErrInternal("suspend markets", err)
+	}
+	defer rows.Close()
+
+	suspended := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, domain.ErrInternal("scan market", err)
+		}
+		suspended = append(suspended, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("suspend markets", err)
+	}
+	return suspended, nil
+}
+
+// reopenMarkets reopens markets suspended by suspendMarkets. It runs whether
+// or not the edit succeeded; a failure is logged and leaves the markets
+// suspended, which is the safe side.
+func (s *SportsbookService) reopenMarkets(ctx context.Context, ids []uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE sports_markets SET status = 'open', updated_at = now()
+		WHERE id = ANY($1) AND status = 'suspended'`, ids); err != nil {
+		s.logger.Error("reopen markets after odds edit failed", "markets", ids, "error", err)
+	}
+}
+
+type selectionPrice struct {
+	selectionID uuid.UUID
+	marketID    uuid.UUID
+	oldOdds     int
+	newOdds     int
+}
+
+// lockEditPrices locks the selections an edit touches and works out their
+// new prices.
+func (s *SportsbookService) lockEditPrices(ctx context.Context, tx pgx.Tx, input BulkOddsInput) ([]selectionPrice, error) {
+	var rows pgx.Rows
+	var err error
+	if input.MarketID != nil {
+		rows, err = tx.Query(ctx, `
+			SELECT id, market_id, odds_decimal FROM sports_selections
+			WHERE market_id = $1 AND status = 'active'
+			ORDER BY sort_order, id
+			FOR UPDATE`, *input.MarketID)
+	} else {
+		ids := make([]uuid.UUID, len(input.Selections))
+		for i, o := range input.Selections {
+			ids[i] = o.SelectionID
+		}
+		rows, err = tx.Query(ctx, `
+			SELECT id, market_id, odds_decimal FROM sports_selections
+			WHERE id = ANY($1)
+			ORDER BY id
+			FOR UPDATE`, ids)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock selections", err)
+	}
+	defer rows.Close()
+
+	var prices []selectionPrice
+	for rows.Next() {
+		var p selectionPrice
+		if err := rows.Scan(&p.selectionID, &p.marketID, &p.oldOdds); err != nil {
+			return nil, domain.ErrInternal("scan selection", err)
+		}
+		prices = append(prices, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("lock selections", err)
+	}
+
+	if input.MarketID == nil {
+		newOdds := make(map[uuid.UUID]int, len(input.Selections))
+		for _, o := range input.Selections {
+			newOdds[o.SelectionID] = o.OddsDecimal
+		}
+		for i := range prices {
+			prices[i].newOdds = newOdds[prices[i].selectionID]
+		}
+		return prices, nil
+	}
+
+	old := make([]int, len(prices))
+	for i, p := range prices {
+		old[i] = p.oldOdds
+	}
+	repriced, err := policy.ApplyMarginDelta(old, *input.MarginDeltaBps)
+	if err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	for i := range prices {
+		prices[i].newOdds = repriced[i]
+	}
+	return prices, nil
+}
+
+func (s *SportsbookService) activeOdds(ctx context.Context, tx pgx.Tx, marketID uuid.UUID) ([]int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT odds_decimal FROM sports_selections WHERE market_id = $1 AND status = 'active'`, marketID)
+	if err != nil {
+		return nil, domain.ErrInternal("query market odds", err)
+	}
+	defer rows.Close()
+
+	var odds []int
+	for rows.Next() {
+		var o int
+		if err := rows.Scan(&o); err != nil {
+			return nil, domain.ErrInternal("scan market odds", err)
+		}
+		odds = append(odds, o)
+	}
+	return odds, rows.Err()
+}
+
+const oddsChangeColumns = `id, edit_id, selection_id, market_id, old_odds, new_odds, margin_delta_bps,
+	reason, admin_id, created_at`
+
+// ListOddsChanges returns a market's odds audit trail, newest first.
+func (s *SportsbookService) ListOddsChanges(ctx context.Context, marketID uuid.UUID, limit int) ([]domain.OddsChange, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+oddsChangeColumns+` FROM sports_odds_changes
+		WHERE market_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT $2`, marketID, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list odds changes", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.OddsChange{}
+	for rows.Next() {
+		var c domain.OddsChange
+		if err := rows.Scan(&c.ID, &c.EditID, &c.SelectionID, &c.MarketID, &c.OldOdds, &c.NewOdds,
+			&c.MarginDeltaBps, &c.Reason, &c.AdminID, &c.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan odds change", err)
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxEachWayPlaces bounds the paying places an outright may offer.
+const maxEachWayPlaces = 10
+
+const sportsMarketColumns = `id, event_id, sport_id, league, name, type, status, specifiers, sort_order,
+	settles_at, each_way_places, each_way_fraction, created_at`
+
+func scanSportsMarket(row pgx.Row) (*domain.SportsMarket, error) {
+	var m domain.SportsMarket
+	if err := row.Scan(&m.ID, &m.EventID, &m.SportID, &m.League, &m.Name, &m.Type, &m.Status, &m.Specifiers,
+		&m.SortOrder, &m.SettlesAt, &m.EachWayPlaces, &m.EachWayFraction, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// OutrightSelectionInput is one selection of a new outright market.
+type OutrightSelectionInput struct {
+	Name        string `json:"name"`
+	OddsDecimal int    `json:"odds_decimal"`
+}
+
+// CreateOutrightInput holds a new outright market. EachWay is nil for a
+// win-only market.
+type CreateOutrightInput struct {
+	SportID    uuid.UUID                `json:"sport_id"`
+	League     string                   `json:"league"`
+	Name       string                   `json:"name"`
+	SettlesAt  *time.Time               `json:"settles_at,omitempty"`
+	EachWay    *policy.EachWayTerms     `json:"each_way,omitempty"`
+	Selections []OutrightSelectionInput `json:"selections"`
+}
+
+// CreateOutright creates an outright market and its selections. Outrights are
+// not tied to an event; they belong to a sport and league and are settled
+// explicitly with SettleOutright once the season is decided.
+func (s *SportsbookService) CreateOutright(ctx context.Context, input CreateOutrightInput) (*domain.OutrightMarket, error) {
+	if input.Name == "" {
+		return nil, domain.ErrValidation("name is required")
+	}
+	if len(input.Selections) < 2 {
+		return nil, domain.ErrValidation("an outright needs at least two selections")
+	}
+	for _, sel := range input.Selections {
+		if sel.Name == "" {
+			return nil, domain.ErrValidation("selection name is required")
+		}
+		if sel.OddsDecimal <= 100 {
+			return nil, domain.ErrValidation("selection odds must be greater than 1.00")
+		}
+	}
+	if input.SettlesAt != nil && !input.SettlesAt.After(time.Now()) {
+		return nil, domain.ErrValidation("settles_at must be in the future")
+	}
+	var ewPlaces, ewFraction *int
+	if ew := input.EachWay; ew != nil {
+		if ew.Places < 1 || ew.Places > maxEachWayPlaces || ew.Places >= len(input.Selections) {
+			return nil, domain.ErrValidation("each-way places must be between 1 and 10 and fewer than the selections")
+		}
+		if ew.Fraction < 1 {
+			return nil, domain.ErrValidation("each-way fraction must be positive")
+		}
+		ewPlaces, ewFraction = &ew.Places, &ew.Fraction
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	m, err := scanSportsMarket(tx.QueryRow(ctx, `
+		INSERT INTO sports_markets (sport_id, league, name, type, status, settles_at, each_way_places, each_way_fraction)
+		SELECT id, NULLIF($2, ''), $3, $4, 'open', $5, $6, $7 FROM sports WHERE id = $1
+		RETURNING `+sportsMarketColumns,
+		input.SportID, input.League, input.Name, domain.MarketTypeOutright, input.SettlesAt, ewPlaces, ewFraction))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("sport", input.SportID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("insert outright market", err)
+	}
+
+	out := &domain.OutrightMarket{SportsMarket: *m, Selections: []domain.SportsSelection{}}
+	for i, in := range input.Selections {
+		var sel domain.SportsSelection
+		err := tx.QueryRow(ctx, `
+			INSERT INTO sports_selections (market_id, name, odds_decimal, status, sort_order)
+			VALUES ($1, $2, $3, 'active', $4)
+			RETURNING id, market_id, name, odds_decimal, odds_fractional, odds_american, status, result,
+			          finish_position, dead_heat_count, line, side, sort_order, created_at`,
+			m.ID, in.Name, in.OddsDecimal, i+1,
+		).Scan(&sel.ID, &sel.MarketID, &sel.Name, &sel.OddsDecimal, &sel.OddsFractional, &sel.OddsAmerican,
+			&sel.Status, &sel.Result, &sel.FinishPosition, &sel.DeadHeatCount, &sel.Line, &sel.Side, &sel.SortOrder, &sel.CreatedAt)
+		if err != nil {
+			return nil, domain.ErrInternal("insert outright selection", err)
+		}
+		out.Selections = append(out.Selections, sel)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return out, nil
+}
+
+// ListOutrights returns a sport's open outright markets, soonest to settle
+// first.
+func (s *SportsbookService) ListOutrights(ctx context.Context, db repository.DBTX, sportID uuid.UUID) ([]domain.SportsMarket, error) {
+	rows, err := db.Query(ctx,
+		`SELECT `+sportsMarketColumns+`
+		 FROM sports_markets WHERE sport_id = $1 AND event_id IS NULL AND status = 'open'
+		 ORDER BY settles_at ASC NULLS LAST, sort_order ASC, created_at ASC`, sportID)
+	if err != nil {
+		return nil, domain.ErrInternal("query outrights", err)
+	}
+	defer rows.Close()
+
+	markets := []domain.SportsMarket{}
+	for rows.Next() {
+		m, err := scanSportsMarket(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan outright", err)
+		}
+		markets = append(markets, *m)
+	}
+	return markets, rows.Err()
+}
+
+// OutrightPlacing is a selection's finishing position in an outright result.
+// Selections sharing a position dead-heated for it.
+type OutrightPlacing struct {
+	SelectionID uuid.UUID `json:"selection_id"`
+	Position    int       `json:"position"`
+}
+
+// SettleOutrightInput holds an outright's result. Selections not listed are
+// unplaced; Void selections (non-runners) have their stakes refunded.
+type SettleOutrightInput struct {
+	Placings []OutrightPlacing `json:"placings"`
+	Void     []uuid.UUID       `json:"void,omitempty"`
+}
+
+// SettleOutright records an outright market's result and settles its open
+// bets by policy.SettleBet. Win-only bets pay on first place; each-way bets
+// also pay the place line on the places locked at placement. Ties are paid
+// under dead-heat rules, the dead-heat count being the number of selections
+// placed at the same position.
+func (s *SportsbookService) SettleOutright(ctx context.Context, marketID uuid.UUID, input SettleOutrightInput) (*SettleEventResult, error) {
+	if err := s.recordOutrightResult(ctx, marketID, input); err != nil {
+		return nil, err
+	}
+
+	bets, err := s.queryOpenBets(ctx, `b.market_id = $1`, marketID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.settleOpenBets(ctx, bets)
+	if err != nil {
+		return nil, err
+	}
+	if result.Pools, err = s.settlePools(ctx, `p.market_id = $1`, marketID, nil, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// recordOutrightResult validates an outright result, writes each selection's
+// placing and marks the market settled.
+func (s *SportsbookService) recordOutrightResult(ctx context.Context, marketID uuid.UUID, input SettleOutrightInput) error {
+	tied := map[int]int{}
+	for _, p := range input.Placings {
+		if p.Position < 1 {
+			return domain.ErrValidation("placing positions must be positive")
+		}
+		tied[p.Position]++
+	}
+	if tied[1] == 0 {
+		return domain.ErrValidation("result must include a winner")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var eventID *uuid.UUID
+	var status string
+	err = tx.QueryRow(ctx,
+		`SELECT event_id, status FROM sports_markets WHERE id = $1 FOR UPDATE`, marketID).Scan(&eventID, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound("market", marketID.String())
+	}
+	if err != nil {
+		return domain.ErrInternal("lock market", err)
+	}
+	if eventID != nil {
+		return domain.ErrValidation("market belongs to an event; settle the event instead")
+	}
+	if status == "settled" {
+		return domain.ErrConflict("outright is already settled")
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE sports_selections SET result = 'lost', finish_position = NULL, dead_heat_count = 1, updated_at = now()
+		WHERE market_id = $1`, marketID); err != nil {
+		return domain.ErrInternal("reset selections", err)
+	}
+
+	seen := map[uuid.UUID]bool{}
+	mark := func(id uuid.UUID, result string, position *int, deadHeat int) error {
+		if seen[id] {
+			return domain.ErrValidation("selection " + id.String() + " appears more than once")
+		}
+		seen[id] = true
+		tag, err := tx.Exec(ctx, `
+			UPDATE sports_selections SET result = $3, finish_position = $4, dead_heat_count = $5, updated_at = now()
+			WHERE id = $1 AND market_id = $2`, id, marketID, result, position, deadHeat)
+		if err != nil {
+			return domain.ErrInternal("update selection result", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrValidation("selection " + id.String() + " is not in this market")
+		}
+		return nil
+	}
+	for _, p := range input.Placings {
+		result := policy.ResultPlaced
+		if p.Position == 1 {
+			result = policy.ResultWon
+		}
+		if err := mark(p.SelectionID, result, &p.Position, tied[p.Position]); err != nil {
+			return err
+		}
+	}
+	for _, id := range input.Void {
+		if err := mark(id, policy.ResultVoid, nil, 1); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE sports_markets SET status = 'settled', updated_at = now() WHERE id = $1`, marketID); err != nil {
+		return domain.ErrInternal("settle market", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrInternal("commit tx", err)
+	}
+	return nil
+}
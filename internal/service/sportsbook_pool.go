@@ -0,0 +1,924 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// maxBetPoolMembers bounds the invited and joined members of a pool.
+	maxBetPoolMembers = 50
+	// defaultPoolMinContribution is the smallest contribution (cents) when
+	// the owner does not set one.
+	defaultPoolMinContribution = 100
+	maxPoolNameLength          = 100
+	maxPoolMessageLength       = 1000
+	poolMessagePageSize        = 100
+)
+
+const betPoolColumns = `id, owner_id, name, event_id, market_id, selection_id, min_contribution,
+	contributions_close_at, status, total_stake, odds_at_placement, result, payout, game_round_id,
+	created_at, placed_at, settled_at`
+
+func scanBetPool(row pgx.Row) (*domain.BetPool, error) {
+	var p domain.BetPool
+	if err := row.Scan(&p.ID, &p.OwnerID, &p.Name, &p.EventID, &p.MarketID, &p.SelectionID, &p.MinContribution,
+		&p.ContributionsCloseAt, &p.Status, &p.TotalStake, &p.OddsAtPlacement, &p.Result, &p.Payout, &p.GameRoundID,
+		&p.CreatedAt, &p.PlacedAt, &p.SettledAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateBetPoolInput holds a new bet pool. MinContribution defaults to 1.00;
+// Invite lists players to invite straight away.
+type CreateBetPoolInput struct {
+	Name                 string      `json:"name"`
+	SelectionID          uuid.UUID   `json:"selection_id"`
+	MinContribution      int64       `json:"min_contribution"`
+	ContributionsCloseAt time.Time   `json:"contributions_close_at"`
+	Invite               []uuid.UUID `json:"invite,omitempty"`
+}
+
+// CreatePool opens a bet pool on a selection with the caller as owner and
+// first member. Contributions are taken until ContributionsCloseAt, which for
+// an event market may be no later than the event's start; the pool is then
+// placed at the selection's odds at that time.
+func (s *SportsbookService) CreatePool(ctx context.Context, ownerID uuid.UUID, input CreateBetPoolInput) (*domain.BetPoolDetail, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxPoolNameLength {
+		return nil, domain.ErrValidation("name is required and must be at most 100 characters")
+	}
+	minContribution := input.MinContribution
+	if minContribution == 0 {
+		minContribution = defaultPoolMinContribution
+	}
+	if minContribution < 0 {
+		return nil, domain.ErrValidation("min_contribution must be positive")
+	}
+	if !input.ContributionsCloseAt.After(time.Now()) {
+		return nil, domain.ErrValidation("contributions_close_at must be in the future")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var marketID uuid.UUID
+	var eventID *uuid.UUID
+	var marketStatus string
+	var startTime *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT m.id, m.event_id, m.status, e.start_time
+		FROM sports_selections sel
+		JOIN sports_markets m ON m.id = sel.market_id
+		LEFT JOIN sports_events e ON e.id = m.event_id
+		WHERE sel.id = $1 AND sel.status = 'active'`,
+		input.SelectionID).Scan(&marketID, &eventID, &marketStatus, &startTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("selection", input.SelectionID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("query selection", err)
+	}
+	if marketStatus != "open" {
+		return nil, domain.ErrValidation("market is not open for betting")
+	}
+	if startTime != nil && input.ContributionsCloseAt.After(*startTime) {
+		return nil, domain.ErrValidation("contributions must close before the event starts")
+	}
+
+	poolID := uuid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO bet_pools (id, owner_id, name, event_id, market_id, selection_id, min_contribution,
+			contributions_close_at, game_round_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		poolID, ownerID, name, eventID, marketID, input.SelectionID, minContribution,
+		input.ContributionsCloseAt, fmt.Sprintf("pool_%s", poolID.String()[:8]))
+	if err != nil {
+		return nil, domain.ErrInternal("insert bet pool", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO bet_pool_members (pool_id, player_id, status, joined_at)
+		VALUES ($1, $2, 'joined', now())`, poolID, ownerID); err != nil {
+		return nil, domain.ErrInternal("insert pool owner", err)
+	}
+	if err := s.invitePoolMembers(ctx, tx, poolID, ownerID, input.Invite); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return s.GetPool(ctx, poolID, ownerID)
+}
+
+// InvitePool invites players to an open pool. Only the owner may invite;
+// players who declined earlier are invited again.
+func (s *SportsbookService) InvitePool(ctx context.Context, poolID, ownerID uuid.UUID, playerIDs []uuid.UUID) (*domain.BetPoolDetail, error) {
+	if len(playerIDs) == 0 {
+		return nil, domain.ErrValidation("player_ids is required")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := s.lockPool(ctx, tx, poolID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if p.OwnerID != ownerID {
+		return nil, domain.ErrForbidden("only the pool owner can invite players")
+	}
+	if err := acceptingContributions(p); err != nil {
+		return nil, err
+	}
+	if err := s.invitePoolMembers(ctx, tx, poolID, ownerID, playerIDs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return s.GetPool(ctx, poolID, ownerID)
+}
+
+// invitePoolMembers adds invitations from inviterID, skipping players who
+// are already invited or joined.
+func (s *SportsbookService) invitePoolMembers(ctx context.Context, tx pgx.Tx, poolID, inviterID uuid.UUID, playerIDs []uuid.UUID) error {
+	if len(playerIDs) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(playerIDs))
+	seen := map[uuid.UUID]bool{inviterID: true}
+	for _, id := range playerIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var found int
+	if err := tx.QueryRow(ctx,
+		`SELECT count(*) FROM v2_players WHERE id = ANY($1)`, ids).Scan(&found); err != nil {
+		return domain.ErrInternal("query invited players", err)
+	}
+	if found != len(ids) {
+		return domain.ErrValidation("one or more invited players do not exist")
+	}
+
+	for _, id := range ids {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO bet_pool_members (pool_id, player_id, invited_by)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (pool_id, player_id) DO UPDATE
+			SET status = 'invited', invited_by = EXCLUDED.invited_by, invited_at = now()
+			WHERE bet_pool_members.status = 'declined'`, poolID, id, inviterID); err != nil {
+			return domain.ErrInternal("insert pool invitation", err)
+		}
+	}
+
+	var members int
+	if err := tx.QueryRow(ctx,
+		`SELECT count(*) FROM bet_pool_members WHERE pool_id = $1 AND status <> 'declined'`,
+		poolID).Scan(&members); err != nil {
+		return domain.ErrInternal("count pool members", err)
+	}
+	if members > maxBetPoolMembers {
+		return domain.ErrValidation(fmt.Sprintf("a pool can have at most %d members", maxBetPoolMembers))
+	}
+	return nil
+}
+
+// JoinPool accepts an invitation to an open pool. Joining twice is a no-op.
+func (s *SportsbookService) JoinPool(ctx context.Context, poolID, playerID uuid.UUID) (*domain.BetPoolDetail, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := s.lockPool(ctx, tx, poolID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := acceptingContributions(p); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE bet_pool_members SET status = 'joined', joined_at = now()
+		WHERE pool_id = $1 AND player_id = $2 AND status <> 'joined'`, poolID, playerID); err != nil {
+		return nil, domain.ErrInternal("join pool", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return s.GetPool(ctx, poolID, playerID)
+}
+
+// DeclinePool turns down a pending invitation.
+func (s *SportsbookService) DeclinePool(ctx context.Context, poolID, playerID uuid.UUID) error {
+	status, err := poolMemberStatus(ctx, s.pool, poolID, playerID)
+	if err != nil {
+		return err
+	}
+	if status != domain.BetPoolMemberInvited {
+		return domain.ErrValidation("only a pending invitation can be declined")
+	}
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE bet_pool_members SET status = 'declined'
+		WHERE pool_id = $1 AND player_id = $2 AND status = 'invited'`, poolID, playerID); err != nil {
+		return domain.ErrInternal("decline pool", err)
+	}
+	return nil
+}
+
+// ContributePool debits a joined member's stake into an open pool. Each
+// contribution is a sportsbook bet in the pool's game round, so it counts
+// towards the player's daily bet limit like any other stake.
+func (s *SportsbookService) ContributePool(ctx context.Context, poolID, playerID uuid.UUID, amount int64) (*domain.BetPoolContribution, error) {
+	if amount <= 0 {
+		return nil, domain.ErrValidation("amount must be positive")
+	}
+	if err := s.checkBetLimit(ctx, playerID, amount); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := s.lockPool(ctx, tx, poolID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := acceptingContributions(p); err != nil {
+		return nil, err
+	}
+	if amount < p.MinContribution {
+		return nil, domain.ErrValidation(fmt.Sprintf("contribution must be at least %d", p.MinContribution))
+	}
+	if err := requireJoinedMember(ctx, tx, poolID, playerID); err != nil {
+		return nil, err
+	}
+
+	contributionID := uuid.New()
+	metadata, _ := json.Marshal(map[string]any{
+		"pool_id": poolID, "event_id": p.EventID, "market_id": p.MarketID, "selection_id": p.SelectionID,
+	})
+	result, err := s.engine.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
+		PlayerID:              playerID,
+		Amount:                amount,
+		ExternalTransactionID: fmt.Sprintf("pool_%s", contributionID.String()[:8]),
+		ManufacturerID:        "sportsbook",
+		SubTransactionID:      "1",
+		GameRoundID:           p.GameRoundID,
+		Metadata:              metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var c domain.BetPoolContribution
+	err = tx.QueryRow(ctx, `
+		INSERT INTO bet_pool_contributions (id, pool_id, player_id, amount, transaction_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, pool_id, player_id, amount, transaction_id, created_at`,
+		contributionID, poolID, playerID, amount, result.Transaction.ID,
+	).Scan(&c.ID, &c.PoolID, &c.PlayerID, &c.Amount, &c.TransactionID, &c.CreatedAt)
+	if err != nil {
+		return nil, domain.ErrInternal("insert pool contribution", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE bet_pools SET total_stake = total_stake + $2 WHERE id = $1`, poolID, amount); err != nil {
+		return nil, domain.ErrInternal("update pool stake", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return &c, nil
+}
+
+// PlacePool closes an open pool's contribution window early and places it
+// at the selection's current odds. Only the owner may place.
+func (s *SportsbookService) PlacePool(ctx context.Context, poolID, ownerID uuid.UUID) (*domain.BetPoolDetail, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := s.lockPool(ctx, tx, poolID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if p.OwnerID != ownerID {
+		return nil, domain.ErrForbidden("only the pool owner can place the pool")
+	}
+	if p.Status != domain.BetPoolOpen {
+		return nil, domain.ErrValidation("pool is not open")
+	}
+	odds, reason, err := s.poolOdds(ctx, tx, p)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, domain.ErrValidation(reason)
+	}
+	if err := s.placePool(ctx, tx, poolID, odds); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return s.GetPool(ctx, poolID, ownerID)
+}
+
+// CancelPool cancels an open pool and refunds every contribution. Only the
+// owner may cancel.
+func (s *SportsbookService) CancelPool(ctx context.Context, poolID, ownerID uuid.UUID) (*domain.BetPoolDetail, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := s.lockPool(ctx, tx, poolID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if p.OwnerID != ownerID {
+		return nil, domain.ErrForbidden("only the pool owner can cancel the pool")
+	}
+	if p.Status != domain.BetPoolOpen {
+		return nil, domain.ErrValidation("only an open pool can be cancelled")
+	}
+	if err := s.cancelPool(ctx, tx, p); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return s.GetPool(ctx, poolID, ownerID)
+}
+
+// lockPool locks a pool row for update. Pools the player is not a member of
+// are reported as not found.
+func (s *SportsbookService) lockPool(ctx context.Context, tx pgx.Tx, poolID, playerID uuid.UUID) (*domain.BetPool, error) {
+	p, err := scanBetPool(tx.QueryRow(ctx, `
+		SELECT `+betPoolColumns+` FROM bet_pools
+		WHERE id = $1 AND EXISTS (SELECT 1 FROM bet_pool_members m WHERE m.pool_id = $1 AND m.player_id = $2)
+		FOR UPDATE`, poolID, playerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("bet pool", poolID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock bet pool", err)
+	}
+	return p, nil
+}
+
+// acceptingContributions reports whether a pool's contribution window is
+// still open.
+func acceptingContributions(p *domain.BetPool) error {
+	if p.Status != domain.BetPoolOpen || !time.Now().Before(p.ContributionsCloseAt) {
+		return domain.ErrValidation("pool is closed to contributions")
+	}
+	return nil
+}
+
+// poolMemberStatus returns a player's standing in a pool.
+func poolMemberStatus(ctx context.Context, db repository.DBTX, poolID, playerID uuid.UUID) (domain.BetPoolMemberStatus, error) {
+	var status domain.BetPoolMemberStatus
+	err := db.QueryRow(ctx,
+		`SELECT status FROM bet_pool_members WHERE pool_id = $1 AND player_id = $2`,
+		poolID, playerID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrNotFound("bet pool", poolID.String())
+	}
+	if err != nil {
+		return "", domain.ErrInternal("query pool member", err)
+	}
+	return status, nil
+}
+
+// requireJoinedMember rejects players who have not joined the pool.
+func requireJoinedMember(ctx context.Context, db repository.DBTX, poolID, playerID uuid.UUID) error {
+	status, err := poolMemberStatus(ctx, db, poolID, playerID)
+	if err != nil {
+		return err
+	}
+	if status != domain.BetPoolMemberJoined {
+		return domain.ErrForbidden("join the pool first")
+	}
+	return nil
+}
+
+// poolOdds returns the odds an open pool would be placed at, or the reason
+// it cannot be placed.
+func (s *SportsbookService) poolOdds(ctx context.Context, tx pgx.Tx, p *domain.BetPool) (int, string, error) {
+	if p.TotalStake == 0 {
+		return 0, "pool has no contributions", nil
+	}
+	var odds int
+	var marketStatus string
+	err := tx.QueryRow(ctx, `
+		SELECT sel.odds_decimal, m.status
+		FROM sports_selections sel JOIN sports_markets m ON m.id = sel.market_id
+		WHERE sel.id = $1 AND sel.status = 'active'`, p.SelectionID).Scan(&odds, &marketStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "selection is no longer available", nil
+	}
+	if err != nil {
+		return 0, "", domain.ErrInternal("query pool selection", err)
+	}
+	if marketStatus != "open" {
+		return 0, "market is not open for betting", nil
+	}
+	return odds, "", nil
+}
+
+// placePool locks the pool's odds and marks it placed.
+func (s *SportsbookService) placePool(ctx context.Context, tx pgx.Tx, poolID uuid.UUID, odds int) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE bet_pools SET status = 'placed', odds_at_placement = $2, placed_at = now()
+		WHERE id = $1`, poolID, odds); err != nil {
+		return domain.ErrInternal("place bet pool", err)
+	}
+	return nil
+}
+
+// cancelPool refunds an open pool's contributions and marks it cancelled.
+func (s *SportsbookService) cancelPool(ctx context.Context, tx pgx.Tx, p *domain.BetPool) error {
+	if err := s.refundPool(ctx, tx, p); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE bet_pools SET status = 'cancelled' WHERE id = $1`, p.ID); err != nil {
+		return domain.ErrInternal("cancel bet pool", err)
+	}
+	return nil
+}
+
+// refundPool cancels each contribution's stake transaction and closes the
+// contributors' rounds.
+func (s *SportsbookService) refundPool(ctx context.Context, tx pgx.Tx, p *domain.BetPool) error {
+	contributions, err := poolContributions(ctx, tx, p.ID)
+	if err != nil {
+		return err
+	}
+	for _, c := range contributions {
+		_, err := s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+			PlayerID:              c.PlayerID,
+			Amount:                c.Amount,
+			ExternalTransactionID: fmt.Sprintf("pool_refund_%s", c.ID.String()[:8]),
+			ManufacturerID:        "sportsbook",
+			SubTransactionID:      "1",
+			TargetTransactionID:   c.TransactionID,
+		})
+		if err != nil {
+			return fmt.Errorf("refund pool contribution %s: %w", c.ID, err)
+		}
+	}
+	for _, playerID := range contributorIDs(contributions) {
+		if _, err := s.engine.ExecuteCloseRound(ctx, tx, domain.CloseRoundParams{
+			PlayerID:       playerID,
+			ManufacturerID: "sportsbook",
+			GameRoundID:    p.GameRoundID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// poolContributions returns a pool's contributions ordered by player, so
+// wallets are always locked in the same order.
+func poolContributions(ctx context.Context, tx pgx.Tx, poolID uuid.UUID) ([]domain.BetPoolContribution, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, pool_id, player_id, amount, transaction_id, created_at
+		FROM bet_pool_contributions WHERE pool_id = $1
+		ORDER BY player_id, created_at`, poolID)
+	if err != nil {
+		return nil, domain.ErrInternal("query pool contributions", err)
+	}
+	defer rows.Close()
+
+	var contributions []domain.BetPoolContribution
+	for rows.Next() {
+		var c domain.BetPoolContribution
+		if err := rows.Scan(&c.ID, &c.PoolID, &c.PlayerID, &c.Amount, &c.TransactionID, &c.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan pool contribution", err)
+		}
+		contributions = append(contributions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("iterate pool contributions", err)
+	}
+	return contributions, nil
+}
+
+// contributorIDs returns the distinct players of contributions, which are
+// ordered by player.
+func contributorIDs(contributions []domain.BetPoolContribution) []uuid.UUID {
+	var ids []uuid.UUID
+	for i, c := range contributions {
+		if i == 0 || c.PlayerID != contributions[i-1].PlayerID {
+			ids = append(ids, c.PlayerID)
+		}
+	}
+	return ids
+}
+
+// ClosePools places open pools whose contribution window has closed, and
+// cancels and refunds those that cannot be placed: no contributions, or a
+// market that is no longer open. It returns the number of pools closed.
+func (s *SportsbookService) ClosePools(ctx context.Context) (int, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT p.id FROM bet_pools p JOIN sports_markets m ON m.id = p.market_id
+		WHERE p.status = 'open' AND (p.contributions_close_at <= now() OR m.status <> 'open')
+		ORDER BY p.contributions_close_at LIMIT 100`)
+	if err != nil {
+		return 0, domain.ErrInternal("query due pools", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, domain.ErrInternal("scan due pool", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, domain.ErrInternal("iterate due pools", err)
+	}
+
+	closed := 0
+	for _, id := range ids {
+		ok, err := s.closePool(ctx, id)
+		if err != nil {
+			return closed, err
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// closePool places or cancels one due pool in its own transaction.
+func (s *SportsbookService) closePool(ctx context.Context, poolID uuid.UUID) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := scanBetPool(tx.QueryRow(ctx,
+		`SELECT `+betPoolColumns+` FROM bet_pools WHERE id = $1 AND status = 'open' FOR UPDATE`, poolID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, domain.ErrInternal("lock bet pool", err)
+	}
+
+	odds, reason, err := s.poolOdds(ctx, tx, p)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case reason != "":
+		s.logger.Info("cancelling bet pool", "pool_id", p.ID, "reason", reason)
+		err = s.cancelPool(ctx, tx, p)
+	case time.Now().Before(p.ContributionsCloseAt):
+		// Still taking contributions and the market has reopened.
+		return false, nil
+	default:
+		err = s.placePool(ctx, tx, p.ID, odds)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, domain.ErrInternal("commit tx", err)
+	}
+	return true, nil
+}
+
+// StartPoolScheduler runs ClosePools on a fixed interval until ctx is
+// cancelled.
+func (s *SportsbookService) StartPoolScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("bet pool scheduler stopped")
+				return
+			case <-ticker.C:
+				n, err := s.ClosePools(ctx)
+				if err != nil {
+					s.logger.Error("close bet pools", "error", err)
+				} else if n > 0 {
+					s.logger.Info("closed bet pools", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+// placedPool is a placed pool joined to its selection's outcome.
+type placedPool struct {
+	ID       uuid.UUID
+	Result   *string
+	Position *int
+	DeadHeat int
+	Line     *int
+	Side     *string
+}
+
+// settlePools settles placed pools matching where, which is written against
+// bet_pools p and takes one argument. Line selections with no result are
+// resolved from the final score when one is given. It returns the number of
+// pools settled.
+func (s *SportsbookService) settlePools(ctx context.Context, where string, arg any, scoreHome, scoreAway *int) (int, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT p.id, sel.result, sel.finish_position, sel.dead_heat_count, sel.line, sel.side
+		FROM bet_pools p
+		JOIN sports_selections sel ON sel.id = p.selection_id
+		WHERE `+where+` AND p.status = 'placed'`, arg)
+	if err != nil {
+		return 0, domain.ErrInternal("query placed pools", err)
+	}
+	var pools []placedPool
+	for rows.Next() {
+		var p placedPool
+		if err := rows.Scan(&p.ID, &p.Result, &p.Position, &p.DeadHeat, &p.Line, &p.Side); err != nil {
+			rows.Close()
+			return 0, domain.ErrInternal("scan placed pool", err)
+		}
+		pools = append(pools, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, domain.ErrInternal("iterate placed pools", err)
+	}
+
+	settled := 0
+	for _, p := range pools {
+		if p.Result == nil && p.Line != nil && p.Side != nil && scoreHome != nil && scoreAway != nil {
+			r := policy.LineResult(*p.Side, *p.Line, *scoreHome, *scoreAway)
+			p.Result = &r
+		}
+		if p.Result == nil || *p.Result == "" {
+			s.logger.Warn("skipping bet pool with no selection result", "pool_id", p.ID)
+			continue
+		}
+		outcome := policy.SelectionOutcome{Result: *p.Result, Placing: policy.Placing{DeadHeat: p.DeadHeat}}
+		if p.Position != nil {
+			outcome.Placing.Position = *p.Position
+		}
+		ok, err := s.settlePool(ctx, p.ID, outcome)
+		if err != nil {
+			return settled, err
+		}
+		if ok {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// settlePool settles one placed pool as a single bet of its total stake at
+// the locked odds. The return is split between contributors pro-rata to
+// their stakes, each share credited as a win in the contributor's round;
+// void and pushed pools refund every contribution.
+func (s *SportsbookService) settlePool(ctx context.Context, poolID uuid.UUID, outcome policy.SelectionOutcome) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, domain.ErrInternal("begin settle tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := scanBetPool(tx.QueryRow(ctx,
+		`SELECT `+betPoolColumns+` FROM bet_pools WHERE id = $1 AND status = 'placed' FOR UPDATE`, poolID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, domain.ErrInternal("lock bet pool", err)
+	}
+	if p.OddsAtPlacement == nil {
+		return false, domain.ErrInternal("settle bet pool", fmt.Errorf("pool %s has no locked odds", p.ID))
+	}
+
+	contributions, err := poolContributions(ctx, tx, p.ID)
+	if err != nil {
+		return false, err
+	}
+	players := contributorIDs(contributions)
+	stakes := make([]int64, len(players))
+	for i, j := 0, 0; i < len(contributions); i++ {
+		if contributions[i].PlayerID != players[j] {
+			j++
+		}
+		stakes[j] += contributions[i].Amount
+	}
+
+	st := policy.SettleBet(policy.BetTerms{Stake: p.TotalStake, Odds: *p.OddsAtPlacement}, outcome)
+	payout := st.Return
+	shares := stakes
+	if st.Refund {
+		payout = p.TotalStake
+		if err := s.refundPool(ctx, tx, p); err != nil {
+			return false, err
+		}
+	} else {
+		shares = policy.SplitProRata(st.Return, stakes)
+	}
+
+	for i, playerID := range players {
+		var transactionID *uuid.UUID
+		switch {
+		case st.Refund:
+		case shares[i] > 0:
+			res, err := s.engine.ExecuteCreditWin(ctx, tx, domain.CreditWinParams{
+				PlayerID:              playerID,
+				Amount:                shares[i],
+				ExternalTransactionID: fmt.Sprintf("pool_win_%s", p.ID.String()[:8]),
+				ManufacturerID:        "sportsbook",
+				SubTransactionID:      "1",
+				GameRoundID:           p.GameRoundID,
+				WinType:               domain.CasinoWinNormal,
+			})
+			if err != nil {
+				return false, fmt.Errorf("settle pool %s win for %s: %w", p.ID, playerID, err)
+			}
+			transactionID = &res.Transaction.ID
+		default:
+			if _, err := s.engine.ExecuteCloseRound(ctx, tx, domain.CloseRoundParams{
+				PlayerID:       playerID,
+				ManufacturerID: "sportsbook",
+				GameRoundID:    p.GameRoundID,
+			}); err != nil {
+				return false, err
+			}
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO bet_pool_payouts (pool_id, player_id, stake, payout, transaction_id)
+			VALUES ($1, $2, $3, $4, $5)`, p.ID, playerID, stakes[i], shares[i], transactionID); err != nil {
+			return false, domain.ErrInternal("insert pool payout", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE bet_pools SET status = 'settled', result = $2, payout = $3, settled_at = now()
+		WHERE id = $1`, p.ID, st.Status, payout); err != nil {
+		return false, domain.ErrInternal("settle bet pool", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, domain.ErrInternal("commit settle tx", err)
+	}
+	return true, nil
+}
+
+// GetPool returns a pool and its members. Pools the player is not a member
+// of are reported as not found.
+func (s *SportsbookService) GetPool(ctx context.Context, poolID, playerID uuid.UUID) (*domain.BetPoolDetail, error) {
+	p, err := scanBetPool(s.pool.QueryRow(ctx, `
+		SELECT `+betPoolColumns+` FROM bet_pools
+		WHERE id = $1 AND EXISTS (SELECT 1 FROM bet_pool_members m WHERE m.pool_id = $1 AND m.player_id = $2)`,
+		poolID, playerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("bet pool", poolID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("query bet pool", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT m.player_id, m.status, m.invited_by, m.invited_at, m.joined_at,
+		       COALESCE((SELECT sum(c.amount) FROM bet_pool_contributions c
+		                 WHERE c.pool_id = m.pool_id AND c.player_id = m.player_id), 0),
+		       po.payout
+		FROM bet_pool_members m
+		LEFT JOIN bet_pool_payouts po ON po.pool_id = m.pool_id AND po.player_id = m.player_id
+		WHERE m.pool_id = $1
+		ORDER BY m.invited_at ASC, m.player_id ASC`, poolID)
+	if err != nil {
+		return nil, domain.ErrInternal("query pool members", err)
+	}
+	defer rows.Close()
+
+	detail := &domain.BetPoolDetail{BetPool: *p, Members: []domain.BetPoolMember{}}
+	for rows.Next() {
+		var m domain.BetPoolMember
+		if err := rows.Scan(&m.PlayerID, &m.Status, &m.InvitedBy, &m.InvitedAt, &m.JoinedAt,
+			&m.Contributed, &m.Payout); err != nil {
+			return nil, domain.ErrInternal("scan pool member", err)
+		}
+		detail.Members = append(detail.Members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrInternal("iterate pool members", err)
+	}
+	return detail, nil
+}
+
+// ListPlayerPools returns the pools a player has joined or been invited to,
+// newest first.
+func (s *SportsbookService) ListPlayerPools(ctx context.Context, playerID uuid.UUID) ([]domain.BetPool, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+betPoolColumns+` FROM bet_pools
+		WHERE id IN (SELECT pool_id FROM bet_pool_members WHERE player_id = $1 AND status <> 'declined')
+		ORDER BY created_at DESC LIMIT 50`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("query player pools", err)
+	}
+	defer rows.Close()
+
+	pools := []domain.BetPool{}
+	for rows.Next() {
+		p, err := scanBetPool(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan bet pool", err)
+		}
+		pools = append(pools, *p)
+	}
+	return pools, rows.Err()
+}
+
+// ListPoolMessages returns the latest chat messages of a pool, oldest first.
+// Only joined members can read the chat.
+func (s *SportsbookService) ListPoolMessages(ctx context.Context, poolID, playerID uuid.UUID) ([]domain.BetPoolMessage, error) {
+	if err := requireJoinedMember(ctx, s.pool, poolID, playerID); err != nil {
+		return nil, err
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, pool_id, player_id, body, created_at FROM (
+			SELECT id, pool_id, player_id, body, created_at FROM bet_pool_messages
+			WHERE pool_id = $1 ORDER BY created_at DESC LIMIT $2
+		) latest ORDER BY created_at ASC`, poolID, poolMessagePageSize)
+	if err != nil {
+		return nil, domain.ErrInternal("query pool messages", err)
+	}
+	defer rows.Close()
+
+	messages := []domain.BetPoolMessage{}
+	for rows.Next() {
+		var m domain.BetPoolMessage
+		if err := rows.Scan(&m.ID, &m.PoolID, &m.PlayerID, &m.Body, &m.CreatedAt); err != nil {
+			return nil, domain.ErrInternal("scan pool message", err)
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+// PostPoolMessage posts a chat message to a pool as a joined member.
+func (s *SportsbookService) PostPoolMessage(ctx context.Context, poolID, playerID uuid.UUID, body string) (*domain.BetPoolMessage, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxPoolMessageLength {
+		return nil, domain.ErrValidation("message must be between 1 and 1000 characters")
+	}
+	if err := requireJoinedMember(ctx, s.pool, poolID, playerID); err != nil {
+		return nil, err
+	}
+
+	var m domain.BetPoolMessage
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO bet_pool_messages (pool_id, player_id, body) VALUES ($1, $2, $3)
+		RETURNING id, pool_id, player_id, body, created_at`, poolID, playerID, body,
+	).Scan(&m.ID, &m.PoolID, &m.PlayerID, &m.Body, &m.CreatedAt)
+	if err != nil {
+		return nil, domain.ErrInternal("insert pool message", err)
+	}
+	return &m, nil
+}
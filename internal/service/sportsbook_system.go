@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+)
+
+// betLeg is a system bet or bet builder leg as priced at placement.
+type betLeg struct {
+	SelectionID uuid.UUID
+	MarketID    uuid.UUID
+	MarketType  string
+	EventID     *uuid.UUID
+	Odds        int
+}
+
+// placeSystemBet places a system bet: one line at the stake on every
+// combination the system covers of its legs, which must be on open event
+// markets of different events. The whole bet is debited at once. Its
+// potential payout, every leg winning, may not exceed the per-player event
+// exposure limit; the bet is not counted in per-selection book exposure.
+func (s *SportsbookService) placeSystemBet(ctx context.Context, playerID uuid.UUID, input PlaceBetInput) (*PlaceBetResult, error) {
+	sb, ok := policy.LookupSystemBet(input.System)
+	if !ok {
+		return nil, domain.ErrValidation(fmt.Sprintf("unknown system %q", input.System))
+	}
+	if input.EachWay {
+		return nil, domain.ErrValidation("system bets cannot be each-way")
+	}
+	if len(input.Legs) != sb.Selections {
+		return nil, domain.ErrValidation(fmt.Sprintf("a %s takes %d selections", input.System, sb.Selections))
+	}
+	lines := sb.Lines()
+	totalStake := input.Stake * int64(lines)
+
+	if err := s.checkBetLimit(ctx, playerID, totalStake); err != nil {
+		return nil, err
+	}
+
+	legs := make([]betLeg, len(input.Legs))
+	events := map[uuid.UUID]bool{}
+	odds := make([]int, len(input.Legs))
+	for i, in := range input.Legs {
+		if in.ExpectedOdds != 0 && in.ExpectedOdds < policy.MinOdds {
+			return nil, domain.ErrValidation(fmt.Sprintf("expected_odds must be at least %d", policy.MinOdds))
+		}
+		leg, err := s.priceBetLeg(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if events[*leg.EventID] {
+			return nil, domain.ErrValidation("system bet selections must be on different events")
+		}
+		events[*leg.EventID] = true
+		legs[i], odds[i] = *leg, leg.Odds
+	}
+
+	potentialPayout := policy.SystemMaxReturn(sb, input.Stake, odds)
+	if s.exposure.PlayerEvent > 0 && potentialPayout > s.exposure.PlayerEvent {
+		return nil, domain.ErrExposureLimitExceeded(policy.CapStake(input.Stake, s.exposure.PlayerEvent, func(stake int64) int64 {
+			return policy.SystemMaxReturn(sb, stake, odds)
+		}))
+	}
+	// The bet's odds are its potential payout over its total stake.
+	effectiveOdds := int(potentialPayout * 100 / totalStake)
+
+	selectionIDs := make([]uuid.UUID, len(legs))
+	for i, leg := range legs {
+		selectionIDs[i] = leg.SelectionID
+	}
+	metadata, _ := json.Marshal(map[string]any{"system": input.System, "selection_ids": selectionIDs})
+
+	betID := uuid.New()
+	gameRoundID := fmt.Sprintf("sb_%s", betID.String()[:8])
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := s.engine.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
+		PlayerID:              playerID,
+		Amount:                totalStake,
+		ExternalTransactionID: fmt.Sprintf("bet_%s", betID.String()[:8]),
+		ManufacturerID:        "sportsbook",
+		SubTransactionID:      "1",
+		GameRoundID:           gameRoundID,
+		Metadata:              metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO sports_bets (id, player_id, stake_amount_minor, currency, odds_at_placement,
+			potential_payout_minor, status, game_round_id, transaction_id,
+			bet_class, system_type, stake_per_line_minor, num_lines)
+		VALUES ($1, $2, $3, $4, $5, $6, 'open', $7, $8, $9, $10, $11, $12)`,
+		betID, playerID, totalStake, result.Transaction.Currency, effectiveOdds, potentialPayout, gameRoundID,
+		result.Transaction.ID, domain.BetClassSystem, input.System, input.Stake, lines)
+	if err != nil {
+		return nil, domain.ErrInternal("insert bet", err)
+	}
+	for i, leg := range legs {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO sports_bet_legs (bet_id, leg_no, event_id, market_id, selection_id, odds_at_placement)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			betID, i+1, leg.EventID, leg.MarketID, leg.SelectionID, leg.Odds); err != nil {
+			return nil, domain.ErrInternal("insert bet leg", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	return &PlaceBetResult{
+		BetID:           betID,
+		GameRoundID:     gameRoundID,
+		Stake:           input.Stake,
+		Odds:            effectiveOdds,
+		PotentialPayout: potentialPayout,
+		BetClass:        domain.BetClassSystem,
+		System:          input.System,
+		Lines:           lines,
+		TotalStake:      totalStake,
+	}, nil
+}
+
+// priceBetLeg reads a leg's current odds, checking its market is an open
+// event market and the odds are still acceptable against the slip.
+func (s *SportsbookService) priceBetLeg(ctx context.Context, in BetLegInput) (*betLeg, error) {
+	leg := betLeg{SelectionID: in.SelectionID}
+	var marketStatus string
+	var suspendedUntil *time.Time
+	err := s.pool.QueryRow(ctx, `
+		SELECT sel.odds_decimal, m.id, m.type, m.event_id,
+		       CASE WHEN m.status = 'suspended' AND m.suspended_until <= now() THEN 'open' ELSE m.status END,
+		       m.suspended_until
+		FROM sports_selections sel JOIN sports_markets m ON m.id = sel.market_id
+		WHERE sel.id = $1 AND sel.status = 'active'`,
+		in.SelectionID).Scan(&leg.Odds, &leg.MarketID, &leg.MarketType, &leg.EventID, &marketStatus, &suspendedUntil)
+	if err != nil {
+		return nil, domain.ErrNotFound("selection", in.SelectionID.String())
+	}
+	if marketStatus == "suspended" {
+		return nil, domain.ErrMarketSuspended(suspendedUntil)
+	}
+	if marketStatus != "open" {
+		return nil, domain.ErrValidation("market is not open for betting")
+	}
+	if leg.EventID == nil {
+		return nil, domain.ErrValidation("outright selections cannot be combined")
+	}
+	if in.ExpectedOdds != 0 && !policy.OddsChangeAcceptable(in.ExpectedOdds, leg.Odds, policy.OddsChangeToleranceBps) {
+		return nil, domain.ErrOddsChanged(in.ExpectedOdds, leg.Odds)
+	}
+	return &leg, nil
+}
+
+// settleSystemBets settles open system bets with a leg on the event whose
+// legs all have results. Handicap and totals legs without a result are
+// resolved from their own event's final score.
+func (s *SportsbookService) settleSystemBets(ctx context.Context, eventID uuid.UUID, result *SettleEventResult) error {
+	rows, err := s.pool.Query(ctx, `
+		SELECT b.id, b.player_id, b.transaction_id, b.stake_amount_minor, b.stake_per_line_minor,
+		       b.system_type, b.game_round_id
+		FROM sports_bets b
+		WHERE b.bet_class = 'system' AND b.status = 'open'
+		  AND EXISTS (SELECT 1 FROM sports_bet_legs l WHERE l.bet_id = b.id AND l.event_id = $1)`, eventID)
+	if err != nil {
+		return domain.ErrInternal("query system bets", err)
+	}
+	type systemBet struct {
+		openSportsBet
+		StakePerLine int64
+		System       string
+	}
+	var bets []systemBet
+	for rows.Next() {
+		var b systemBet
+		if err := rows.Scan(&b.ID, &b.PlayerID, &b.TransactionID, &b.Stake, &b.StakePerLine,
+			&b.System, &b.GameRoundID); err != nil {
+			rows.Close()
+			return domain.ErrInternal("scan system bet", err)
+		}
+		bets = append(bets, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ErrInternal("iterate system bets", err)
+	}
+
+	for _, bet := range bets {
+		sb, ok := policy.LookupSystemBet(bet.System)
+		if !ok {
+			s.logger.Warn("unknown system bet type", "system", bet.System, "bet_id", bet.ID)
+			continue
+		}
+		outcomes, complete, err := s.betLegOutcomes(ctx, bet.ID)
+		if err != nil {
+			return err
+		}
+		if !complete {
+			continue
+		}
+		legs := make([]policy.SystemLeg, len(outcomes))
+		for i, o := range outcomes {
+			legs[i] = policy.SystemLeg{Odds: o.Odds, Outcome: o.Outcome}
+		}
+		st := policy.SettleSystemBet(sb, bet.StakePerLine, legs)
+		if st.Refund && bet.TransactionID == nil {
+			s.logger.Warn("void bet has no transaction_id", "bet_id", bet.ID)
+			continue
+		}
+		if err := s.applySettlement(ctx, bet.openSportsBet, st); err != nil {
+			return err
+		}
+		result.add(st.Status)
+		result.Systems++
+	}
+	return nil
+}
+
+// betLegOutcomes reads a bet's legs with their market types and outcomes;
+// complete is false while any leg has no result.
+func (s *SportsbookService) betLegOutcomes(ctx context.Context, betID uuid.UUID) ([]policy.BuilderLeg, bool, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT m.type, l.odds_at_placement, sel.result, sel.finish_position, sel.dead_heat_count,
+		       sel.line, sel.side, e.status, e.score_home, e.score_away
+		FROM sports_bet_legs l
+		JOIN sports_selections sel ON sel.id = l.selection_id
+		JOIN sports_markets m ON m.id = l.market_id
+		JOIN sports_events e ON e.id = l.event_id
+		WHERE l.bet_id = $1
+		ORDER BY l.leg_no`, betID)
+	if err != nil {
+		return nil, false, domain.ErrInternal("query system bet legs", err)
+	}
+	defer rows.Close()
+
+	var legs []policy.BuilderLeg
+	complete := true
+	for rows.Next() {
+		var leg policy.BuilderLeg
+		var result, side *string
+		var position, line, scoreHome, scoreAway *int
+		var eventStatus string
+		if err := rows.Scan(&leg.MarketType, &leg.Odds, &result, &position, &leg.Outcome.Placing.DeadHeat,
+			&line, &side, &eventStatus, &scoreHome, &scoreAway); err != nil {
+			return nil, false, domain.ErrInternal("scan system bet leg", err)
+		}
+		if (result == nil || *result == "") && line != nil && side != nil &&
+			eventStatus == "settled" && scoreHome != nil && scoreAway != nil {
+			r := policy.LineResult(*side, *line, *scoreHome, *scoreAway)
+			result = &r
+		}
+		if result == nil || *result == "" {
+			complete = false
+			continue
+		}
+		leg.Outcome.Result = *result
+		if position != nil {
+			leg.Outcome.Placing.Position = *position
+		}
+		legs = append(legs, leg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, domain.ErrInternal("iterate system bet legs", err)
+	}
+	return legs, complete, nil
+}
+
+// loadBetLegs attaches their legs to the system bets and bet builders among
+// bets.
+func (s *SportsbookService) loadBetLegs(ctx context.Context, bets []domain.SportsBetRecord) error {
+	index := map[uuid.UUID]int{}
+	var ids []uuid.UUID
+	for i, b := range bets {
+		if b.BetClass == domain.BetClassSystem || b.BetClass == domain.BetClassBetBuilder {
+			index[b.ID] = i
+			ids = append(ids, b.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT bet_id, leg_no, event_id, market_id, selection_id, odds_at_placement
+		FROM sports_bet_legs WHERE bet_id = ANY($1)
+		ORDER BY bet_id, leg_no`, ids)
+	if err != nil {
+		return domain.ErrInternal("query bet legs", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var betID uuid.UUID
+		var leg domain.SportsBetLeg
+		if err := rows.Scan(&betID, &leg.LegNo, &leg.EventID, &leg.MarketID, &leg.SelectionID, &leg.OddsAtPlacement); err != nil {
+			return domain.ErrInternal("scan bet leg", err)
+		}
+		b := &bets[index[betID]]
+		b.Legs = append(b.Legs, leg)
+	}
+	return rows.Err()
+}
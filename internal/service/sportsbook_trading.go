@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const tradingAlertColumns = `id, alert_type, market_id, selection_id, player_id, details, status,
+	acknowledged_by, acknowledged_at, created_at`
+
+func scanTradingAlert(row pgx.Row) (*domain.TradingAlert, error) {
+	var a domain.TradingAlert
+	if err := row.Scan(&a.ID, &a.AlertType, &a.MarketID, &a.SelectionID, &a.PlayerID, &a.Details, &a.Status,
+		&a.AcknowledgedBy, &a.AcknowledgedAt, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// checkTradingAlerts evaluates the market's stake pattern after a bet and
+// raises any alerts it triggers. The bet has already been accepted, so
+// failures are logged rather than returned.
+func (s *SportsbookService) checkTradingAlerts(ctx context.Context, playerID, marketID, selectionID uuid.UUID) {
+	var facts policy.StakeFacts
+	err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(stake_amount_minor) FILTER (
+		           WHERE selection_id = $2 AND placed_at >= now() - make_interval(secs => $4)), 0)::bigint,
+		       COALESCE(SUM(stake_amount_minor) FILTER (WHERE status = 'open'), 0)::bigint,
+		       COALESCE(SUM(stake_amount_minor) FILTER (WHERE status = 'open' AND player_id = $3), 0)::bigint
+		FROM sports_bets WHERE market_id = $1`,
+		marketID, selectionID, playerID, s.alerts.Window.Seconds(),
+	).Scan(&facts.SelectionWindowStake, &facts.MarketHandle, &facts.PlayerMarketHandle)
+	if err != nil {
+		s.logger.Error("trading alert facts", "market_id", marketID, "error", err)
+		return
+	}
+
+	for _, alertType := range policy.EvaluateStakePattern(s.alerts, facts) {
+		alert := domain.TradingAlert{AlertType: alertType, MarketID: marketID}
+		var key string
+		switch alertType {
+		case policy.AlertSelectionStakeSpike:
+			alert.SelectionID = &selectionID
+			key = alertType + ":" + selectionID.String()
+			alert.Details, _ = json.Marshal(map[string]any{
+				"window_stake": facts.SelectionWindowStake, "threshold": s.alerts.SelectionStake,
+				"window_seconds": int(s.alerts.Window.Seconds()),
+			})
+		case policy.AlertPlayerHandleShare:
+			alert.PlayerID = &playerID
+			key = alertType + ":" + marketID.String() + ":" + playerID.String()
+			alert.Details, _ = json.Marshal(map[string]any{
+				"player_handle": facts.PlayerMarketHandle, "market_handle": facts.MarketHandle,
+				"share_bps": facts.PlayerMarketHandle * 10_000 / facts.MarketHandle,
+			})
+		}
+		if err := s.raiseTradingAlert(ctx, key, &alert); err != nil {
+			s.logger.Error("raise trading alert", "market_id", marketID, "alert_type", alertType, "error", err)
+		}
+	}
+}
+
+// raiseTradingAlert records the alert and publishes it to the outbox, unless
+// an alert with the same key is still open.
+func (s *SportsbookService) raiseTradingAlert(ctx context.Context, key string, alert *domain.TradingAlert) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	inserted, err := scanTradingAlert(tx.QueryRow(ctx, `
+		INSERT INTO trading_alerts (alert_type, dedupe_key, market_id, selection_id, player_id, details)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (dedupe_key) WHERE status = 'open' DO NOTHING
+		RETURNING `+tradingAlertColumns,
+		alert.AlertType, key, alert.MarketID, alert.SelectionID, alert.PlayerID, alert.Details))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.outbox.Insert(ctx, tx, domain.NewTradingAlertRaisedEvent(*inserted)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	s.logger.Warn("sportsbook trading alert", "alert_id", inserted.ID, "alert_type", inserted.AlertType,
+		"market_id", inserted.MarketID, "selection_id", inserted.SelectionID, "player_id", inserted.PlayerID)
+	return nil
+}
+
+// ListTradingAlerts returns alerts, newest first. An empty status lists all.
+func (s *SportsbookService) ListTradingAlerts(ctx context.Context, status string, limit int) ([]domain.TradingAlert, error) {
+	if status != "" && status != "open" && status != "acknowledged" {
+		return nil, domain.ErrValidation("status must be open or acknowledged")
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+tradingAlertColumns+` FROM trading_alerts
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC LIMIT $2`, status, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list trading alerts", err)
+	}
+	defer rows.Close()
+
+	alerts := []domain.TradingAlert{}
+	for rows.Next() {
+		a, err := scanTradingAlert(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan trading alert", err)
+		}
+		alerts = append(alerts, *a)
+	}
+	return alerts, rows.Err()
+}
+
+// AcknowledgeTradingAlert closes an open alert. A later matching pattern
+// raises a new one.
+func (s *SportsbookService) AcknowledgeTradingAlert(ctx context.Context, id, adminID uuid.UUID) (*domain.TradingAlert, error) {
+	a, err := scanTradingAlert(s.pool.QueryRow(ctx, `
+		UPDATE trading_alerts
+		SET status = 'acknowledged', acknowledged_by = $2, acknowledged_at = now()
+		WHERE id = $1 AND status = 'open'
+		RETURNING `+tradingAlertColumns, id, adminID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := s.pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM trading_alerts WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, domain.ErrInternal("find trading alert", err)
+		}
+		if exists {
+			return nil, domain.ErrConflict("trading alert already acknowledged")
+		}
+		return nil, domain.ErrNotFound("trading alert", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("acknowledge trading alert", err)
+	}
+	return a, nil
+}
+
+// Liabilities returns open-bet exposure per selection on unsettled markets,
+// largest liability first, with the number of open alerts touching each.
+// A nil marketID covers every market.
+func (s *SportsbookService) Liabilities(ctx context.Context, marketID *uuid.UUID, limit int) ([]domain.SelectionLiability, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT sel.id, sel.name, m.id, m.name, sel.odds_decimal,
+		       COUNT(b.id)::int, SUM(b.stake_amount_minor)::bigint, SUM(b.potential_payout_minor)::bigint,
+		       (SELECT COUNT(*) FROM trading_alerts a
+		        WHERE a.status = 'open' AND a.market_id = m.id
+		          AND (a.selection_id = sel.id OR a.selection_id IS NULL))::int
+		FROM sports_bets b
+		JOIN sports_selections sel ON sel.id = b.selection_id
+		JOIN sports_markets m ON m.id = b.market_id
+		WHERE b.status = 'open' AND ($1::uuid IS NULL OR b.market_id = $1)
+		GROUP BY sel.id, m.id
+		ORDER BY SUM(b.potential_payout_minor) DESC
+		LIMIT $2`, marketID, limit)
+	if err != nil {
+		return nil, domain.ErrInternal("list liabilities", err)
+	}
+	defer rows.Close()
+
+	liabilities := []domain.SelectionLiability{}
+	for rows.Next() {
+		var l domain.SelectionLiability
+		if err := rows.Scan(&l.SelectionID, &l.SelectionName, &l.MarketID, &l.MarketName, &l.OddsDecimal,
+			&l.OpenBets, &l.Stake, &l.Liability, &l.OpenAlerts); err != nil {
+			return nil, domain.ErrInternal("scan liability", err)
+		}
+		liabilities = append(liabilities, l)
+	}
+	return liabilities, rows.Err()
+}
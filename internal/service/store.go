@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const storeItemColumns = `id, sku, name, description, kind, price, price_currency, coin_currency, coin_amount,
+	item_code, boost_multiplier_bps, boost_minutes, active, sort_order, created_at, updated_at`
+
+func scanStoreItem(row pgx.Row) (*domain.StoreItem, error) {
+	var i domain.StoreItem
+	if err := row.Scan(&i.ID, &i.SKU, &i.Name, &i.Description, &i.Kind, &i.Price, &i.PriceCurrency,
+		&i.CoinCurrency, &i.CoinAmount, &i.ItemCode, &i.BoostMultiplierBps, &i.BoostMinutes, &i.Active,
+		&i.SortOrder, &i.CreatedAt, &i.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+const storeOrderColumns = `id, player_id, item_id, payment_id, sku, item_name, kind, price, price_currency,
+	status, created_at, completed_at`
+
+func scanStoreOrder(row pgx.Row) (*domain.StoreOrder, error) {
+	var o domain.StoreOrder
+	if err := row.Scan(&o.ID, &o.PlayerID, &o.ItemID, &o.PaymentID, &o.SKU, &o.ItemName, &o.Kind, &o.Price,
+		&o.PriceCurrency, &o.Status, &o.CreatedAt, &o.CompletedAt); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+const entitlementColumns = `id, player_id, item_id, order_id, kind, item_code, coin_currency, coin_amount,
+	transaction_id, boost_multiplier_bps, expires_at, created_at`
+
+func scanEntitlement(row pgx.Row) (*domain.Entitlement, error) {
+	var e domain.Entitlement
+	if err := row.Scan(&e.ID, &e.PlayerID, &e.ItemID, &e.OrderID, &e.Kind, &e.ItemCode, &e.CoinCurrency,
+		&e.CoinAmount, &e.TransactionID, &e.BoostMultiplierBps, &e.ExpiresAt, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// StoreService sells virtual goods: coin bundles, avatar items and quest
+// boosts. Checkout goes through the PSPs; the webhook grants the item.
+type StoreService struct {
+	pool     *pgxpool.Pool
+	payments *PaymentService
+	wallets  repository.WalletRepository
+	logger   *slog.Logger
+}
+
+// NewStoreService creates a StoreService.
+func NewStoreService(pool *pgxpool.Pool, payments *PaymentService, wallets repository.WalletRepository, logger *slog.Logger) *StoreService {
+	return &StoreService{pool: pool, payments: payments, wallets: wallets, logger: logger}
+}
+
+// ─── Catalog ────────────────────────────────────────────────────────────────
+
+// StoreItemInput holds a new or replacement store item. Only the grant fields
+// of the item's kind are kept.
+type StoreItemInput struct {
+	SKU                string               `json:"sku"`
+	Name               string               `json:"name"`
+	Description        string               `json:"description"`
+	Kind               domain.StoreItemKind `json:"kind"`
+	Price              int64                `json:"price"`
+	PriceCurrency      string               `json:"price_currency"`
+	CoinCurrency       string               `json:"coin_currency"`
+	CoinAmount         int64                `json:"coin_amount"`
+	ItemCode           string               `json:"item_code"`
+	BoostMultiplierBps int                  `json:"boost_multiplier_bps"`
+	BoostMinutes       int                  `json:"boost_minutes"`
+	Active             *bool                `json:"active,omitempty"`
+	SortOrder          int                  `json:"sort_order"`
+}
+
+func (in *StoreItemInput) validate() error {
+	in.SKU = strings.TrimSpace(in.SKU)
+	in.Name = strings.TrimSpace(in.Name)
+	in.PriceCurrency = strings.ToUpper(strings.TrimSpace(in.PriceCurrency))
+	in.CoinCurrency = strings.ToUpper(strings.TrimSpace(in.CoinCurrency))
+	in.ItemCode = strings.TrimSpace(in.ItemCode)
+	if in.SKU == "" || in.Name == "" {
+		return domain.ErrValidation("sku and name are required")
+	}
+	if in.Price <= 0 {
+		return domain.ErrValidation("price must be positive")
+	}
+	if err := domain.ValidateCurrency(in.PriceCurrency); err != nil {
+		return domain.ErrValidation(err.Error())
+	}
+
+	switch in.Kind {
+	case domain.StoreItemCoinBundle:
+		// Cash balances are only funded by deposits
+		if in.CoinCurrency != domain.CurrencyGoldCoins && in.CoinCurrency != domain.CurrencySweepsCoins {
+			return domain.ErrValidation("coin_currency must be GC or SC")
+		}
+		if in.CoinAmount <= 0 {
+			return domain.ErrValidation("coin_amount must be positive")
+		}
+		in.ItemCode, in.BoostMultiplierBps, in.BoostMinutes = "", 0, 0
+	case domain.StoreItemAvatar:
+		if in.ItemCode == "" {
+			return domain.ErrValidation("item_code is required")
+		}
+		in.CoinCurrency, in.CoinAmount, in.BoostMultiplierBps, in.BoostMinutes = "", 0, 0, 0
+	case domain.StoreItemQuestBoost:
+		if in.BoostMultiplierBps <= 10_000 {
+			return domain.ErrValidation("boost_multiplier_bps must be above 10000")
+		}
+		if in.BoostMinutes <= 0 {
+			return domain.ErrValidation("boost_minutes must be positive")
+		}
+		in.CoinCurrency, in.CoinAmount, in.ItemCode = "", 0, ""
+	default:
+		return domain.ErrValidation("kind must be coin_bundle, avatar_item or quest_boost")
+	}
+	return nil
+}
+
+// validateItem checks an item input, including that an avatar item sells a
+// cosmetic from the catalog.
+func (s *StoreService) validateItem(ctx context.Context, input *StoreItemInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+	if input.Kind != domain.StoreItemAvatar {
+		return nil
+	}
+	var exists bool
+	if err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM cosmetic_items WHERE code = $1)`,
+		input.ItemCode).Scan(&exists); err != nil {
+		return domain.ErrInternal("check cosmetic item", err)
+	}
+	if !exists {
+		return domain.ErrValidation("item_code is not a cosmetic item: " + input.ItemCode)
+	}
+	return nil
+}
+
+// ListItems returns the store catalog in display order. Players only see
+// active items.
+func (s *StoreService) ListItems(ctx context.Context, activeOnly bool) ([]domain.StoreItem, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+storeItemColumns+` FROM store_items
+		WHERE active OR NOT $1
+		ORDER BY sort_order, price`, activeOnly)
+	if err != nil {
+		return nil, domain.ErrInternal("list store items", err)
+	}
+	defer rows.Close()
+
+	items := []domain.StoreItem{}
+	for rows.Next() {
+		i, err := scanStoreItem(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan store item", err)
+		}
+		items = append(items, *i)
+	}
+	return items, rows.Err()
+}
+
+// CreateItem adds a store item. SKUs are unique.
+func (s *StoreService) CreateItem(ctx context.Context, input StoreItemInput) (*domain.StoreItem, error) {
+	if err := s.validateItem(ctx, &input); err != nil {
+		return nil, err
+	}
+	active := input.Active == nil || *input.Active
+	i, err := scanStoreItem(s.pool.QueryRow(ctx, `
+		INSERT INTO store_items (sku, name, description, kind, price, price_currency, coin_currency, coin_amount,
+		                         item_code, boost_multiplier_bps, boost_minutes, active, sort_order)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, NULLIF($9, ''), $10, $11, $12, $13)
+		ON CONFLICT (sku) DO NOTHING
+		RETURNING `+storeItemColumns,
+		input.SKU, input.Name, input.Description, input.Kind, input.Price, input.PriceCurrency, input.CoinCurrency,
+		input.CoinAmount, input.ItemCode, input.BoostMultiplierBps, input.BoostMinutes, active, input.SortOrder))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrConflict("sku already exists: " + input.SKU)
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("insert store item", err)
+	}
+	return i, nil
+}
+
+// UpdateItem replaces a store item. Orders already started keep the price
+// they were sold at; the grant is read when the payment completes.
+func (s *StoreService) UpdateItem(ctx context.Context, id uuid.UUID, input StoreItemInput) (*domain.StoreItem, error) {
+	if err := s.validateItem(ctx, &input); err != nil {
+		return nil, err
+	}
+	active := input.Active == nil || *input.Active
+	i, err := scanStoreItem(s.pool.QueryRow(ctx, `
+		UPDATE store_items
+		SET sku = $2, name = $3, description = $4, kind = $5, price = $6, price_currency = $7,
+		    coin_currency = NULLIF($8, ''), coin_amount = $9, item_code = NULLIF($10, ''),
+		    boost_multiplier_bps = $11, boost_minutes = $12, active = $13, sort_order = $14, updated_at = now()
+		WHERE id = $1
+		RETURNING `+storeItemColumns,
+		id, input.SKU, input.Name, input.Description, input.Kind, input.Price, input.PriceCurrency,
+		input.CoinCurrency, input.CoinAmount, input.ItemCode, input.BoostMultiplierBps, input.BoostMinutes,
+		active, input.SortOrder))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("store item", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("update store item", err)
+	}
+	return i, nil
+}
+
+// ─── Orders ─────────────────────────────────────────────────────────────────
+
+// StoreCheckoutInput holds a player's store checkout request.
+type StoreCheckoutInput struct {
+	ItemID     uuid.UUID `json:"item_id"`
+	Method     string    `json:"method"` // stripe (default) or adyen
+	SuccessURL string    `json:"success_url"`
+	CancelURL  string    `json:"cancel_url"`
+}
+
+// Checkout opens a PSP checkout for a store item. The item is granted when
+// the PSP webhook confirms the payment.
+func (s *StoreService) Checkout(ctx context.Context, playerID uuid.UUID, input StoreCheckoutInput) (*DepositSession, error) {
+	item, err := scanStoreItem(s.pool.QueryRow(ctx,
+		`SELECT `+storeItemColumns+` FROM store_items WHERE id = $1 AND active`, input.ItemID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("store item", input.ItemID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find store item", err)
+	}
+
+	switch item.Kind {
+	case domain.StoreItemAvatar:
+		var owned bool
+		if err := s.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM player_entitlements
+			               WHERE player_id = $1 AND kind = 'avatar_item' AND item_code = $2)`,
+			playerID, item.ItemCode).Scan(&owned); err != nil {
+			return nil, domain.ErrInternal("check entitlement", err)
+		}
+		if owned {
+			return nil, domain.ErrConflict("avatar item already owned")
+		}
+	case domain.StoreItemCoinBundle:
+		// Open the coin wallet up front so the webhook only has to post
+		if _, err := s.wallets.Create(ctx, s.pool, playerID, *item.CoinCurrency); err != nil {
+			return nil, domain.ErrInternal("open coin wallet", err)
+		}
+	}
+
+	return s.payments.InitiateStoreOrder(ctx, playerID, *item, input.Method, input.SuccessURL, input.CancelURL)
+}
+
+// ListOrders returns a player's store orders, newest first.
+func (s *StoreService) ListOrders(ctx context.Context, playerID uuid.UUID) ([]domain.StoreOrder, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+storeOrderColumns+` FROM store_orders
+		WHERE player_id = $1 ORDER BY created_at DESC LIMIT 100`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list store orders", err)
+	}
+	defer rows.Close()
+
+	orders := []domain.StoreOrder{}
+	for rows.Next() {
+		o, err := scanStoreOrder(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan store order", err)
+		}
+		orders = append(orders, *o)
+	}
+	return orders, rows.Err()
+}
+
+// ListEntitlements returns what a player has been granted, newest first.
+// With activeOnly, expired quest boosts are left out.
+func (s *StoreService) ListEntitlements(ctx context.Context, playerID uuid.UUID, activeOnly bool) ([]domain.Entitlement, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+entitlementColumns+` FROM player_entitlements
+		WHERE player_id = $1 AND (NOT $2 OR expires_at IS NULL OR expires_at > now())
+		ORDER BY created_at DESC LIMIT 200`, playerID, activeOnly)
+	if err != nil {
+		return nil, domain.ErrInternal("list entitlements", err)
+	}
+	defer rows.Close()
+
+	entitlements := []domain.Entitlement{}
+	for rows.Next() {
+		e, err := scanEntitlement(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan entitlement", err)
+		}
+		entitlements = append(entitlements, *e)
+	}
+	return entitlements, rows.Err()
+}
+
+// InitiateStoreOrder creates a checkout session for a store item and records
+// the pending payment and order.
+func (s *PaymentService) InitiateStoreOrder(ctx context.Context, playerID uuid.UUID, item domain.StoreItem, method, successURL, cancelURL string) (*DepositSession, error) {
+	method, err := checkoutMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentID := uuid.New()
+	sessionID, sessionURL, err := s.createCheckout(ctx, method, paymentID, playerID, item.Price, item.PriceCurrency, successURL, cancelURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	providerName := method
+	payment := &domain.Payment{
+		ID:                paymentID,
+		PlayerID:          playerID,
+		Type:              domain.PaymentTypeStore,
+		Amount:            item.Price,
+		Currency:          item.PriceCurrency,
+		Status:            domain.PaymentStatusPending,
+		Provider:          &providerName,
+		ProviderSessionID: &sessionID,
+	}
+	if err := s.payments.Create(ctx, tx, payment); err != nil {
+		return nil, domain.ErrInternal("record payment", err)
+	}
+	if err := s.publishPaymentStatus(ctx, tx, payment, domain.PaymentStatusPending); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO store_orders (player_id, item_id, payment_id, sku, item_name, kind, price, price_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		playerID, item.ID, paymentID, item.SKU, item.Name, item.Kind, item.Price, item.PriceCurrency); err != nil {
+		return nil, domain.ErrInternal("record store order", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.recordEvent(ctx, payment.ID, domain.PaymentStatusPending, "store checkout created", nil)
+	return &DepositSession{
+		SessionID:  sessionID,
+		SessionURL: sessionURL,
+		PaymentID:  payment.ID.String(),
+		Method:     method,
+	}, nil
+}
+
+// creditStoreOrder grants a paid store order and marks the payment completed.
+// Coin bundles post a ledger deposit to the coin wallet and return it; avatar
+// items land in the cosmetic inventory. Only coin bundles return a result.
+func (s *PaymentService) creditStoreOrder(ctx context.Context, tx pgx.Tx, payment *domain.Payment, providerPaymentID, eventID string) (*domain.CommandResult, error) {
+	order, err := scanStoreOrder(tx.QueryRow(ctx,
+		`SELECT `+storeOrderColumns+` FROM store_orders WHERE payment_id = $1 FOR UPDATE`, payment.ID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("store order", payment.ID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock store order", err)
+	}
+	item, err := scanStoreItem(tx.QueryRow(ctx,
+		`SELECT `+storeItemColumns+` FROM store_items WHERE id = $1`, order.ItemID))
+	if err != nil {
+		return nil, domain.ErrInternal("find store item", err)
+	}
+
+	var result *domain.CommandResult
+	var transactionID *uuid.UUID
+	var expiresAt *time.Time
+	switch item.Kind {
+	case domain.StoreItemCoinBundle:
+		providerName := paymentProvider(payment)
+		meta, _ := json.Marshal(map[string]string{
+			"provider": providerName, "event_id": eventID, "store_order_id": order.ID.String(),
+		})
+		result, err = s.engine.ExecuteDeposit(ctx, tx, domain.DepositParams{
+			PlayerID:              payment.PlayerID,
+			Amount:                item.CoinAmount,
+			ExternalTransactionID: fmt.Sprintf("%s_%s", providerName, eventID),
+			ManufacturerID:        providerName,
+			SubTransactionID:      "store",
+			Metadata:              meta,
+			Currency:              *item.CoinCurrency,
+		})
+		if err != nil {
+			return nil, domain.ErrInternal("credit coin bundle", err)
+		}
+		transactionID = &result.Transaction.ID
+	case domain.StoreItemAvatar:
+		if _, err := grantCosmetic(ctx, tx, payment.PlayerID, *item.ItemCode, domain.CosmeticSourceStore, order.ID.String()); err != nil {
+			return nil, err
+		}
+	case domain.StoreItemQuestBoost:
+		t := time.Now().Add(time.Duration(item.BoostMinutes) * time.Minute)
+		expiresAt = &t
+	}
+
+	// A duplicate avatar from two concurrent checkouts is paid but not granted twice
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO player_entitlements (player_id, item_id, order_id, kind, item_code, coin_currency, coin_amount,
+		                                 transaction_id, boost_multiplier_bps, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT DO NOTHING`,
+		payment.PlayerID, item.ID, order.ID, item.Kind, item.ItemCode, item.CoinCurrency, item.CoinAmount,
+		transactionID, item.BoostMultiplierBps, expiresAt); err != nil {
+		return nil, domain.ErrInternal("grant entitlement", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE store_orders SET status = 'completed', completed_at = now() WHERE id = $1`, order.ID); err != nil {
+		return nil, domain.ErrInternal("complete store order", err)
+	}
+	if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusCompleted, &providerPaymentID, transactionID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,553 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sweepsManufacturer tags the ledger entries of coin purchases and
+// redemptions.
+const sweepsManufacturer = "sweeps"
+
+const coinPackageColumns = `id, name, price, price_currency, gold_coins, sweeps_coins, active, sort_order,
+	created_at, updated_at`
+
+func scanCoinPackage(row pgx.Row) (*domain.CoinPackage, error) {
+	var p domain.CoinPackage
+	if err := row.Scan(&p.ID, &p.Name, &p.Price, &p.PriceCurrency, &p.GoldCoins, &p.SweepsCoins, &p.Active,
+		&p.SortOrder, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+const coinPurchaseColumns = `id, player_id, package_id, payment_id, price, price_currency, gold_coins, sweeps_coins,
+	status, created_at, completed_at`
+
+func scanCoinPurchase(row pgx.Row) (*domain.CoinPurchase, error) {
+	var p domain.CoinPurchase
+	if err := row.Scan(&p.ID, &p.PlayerID, &p.PackageID, &p.PaymentID, &p.Price, &p.PriceCurrency, &p.GoldCoins,
+		&p.SweepsCoins, &p.Status, &p.CreatedAt, &p.CompletedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+const redemptionColumns = `id, player_id, amount, cash_amount, cash_currency, status, reserve_transaction_id,
+	settle_transaction_id, reviewed_by, review_note, created_at, reviewed_at`
+
+func scanRedemption(row pgx.Row) (*domain.SweepsRedemption, error) {
+	var r domain.SweepsRedemption
+	if err := row.Scan(&r.ID, &r.PlayerID, &r.Amount, &r.CashAmount, &r.CashCurrency, &r.Status,
+		&r.ReserveTransactionID, &r.SettleTransactionID, &r.ReviewedBy, &r.ReviewNote, &r.CreatedAt,
+		&r.ReviewedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// SweepstakesService runs sweepstakes mode: coin package sales, which credit
+// the GC and SC wallets, and SC redemptions for cash prizes.
+type SweepstakesService struct {
+	pool     *pgxpool.Pool
+	payments *PaymentService
+	wallets  repository.WalletRepository
+	engine   *ledger.Engine
+	rules    policy.SweepsRules
+	logger   *slog.Logger
+}
+
+// NewSweepstakesService creates a SweepstakesService.
+func NewSweepstakesService(pool *pgxpool.Pool, payments *PaymentService, wallets repository.WalletRepository, engine *ledger.Engine, rules policy.SweepsRules, logger *slog.Logger) *SweepstakesService {
+	if rules.CashCurrency == "" {
+		rules.CashCurrency = policy.DefaultSweepsRules().CashCurrency
+	}
+	return &SweepstakesService{pool: pool, payments: payments, wallets: wallets, engine: engine, rules: rules, logger: logger}
+}
+
+// Rules returns the sweepstakes rules in force.
+func (s *SweepstakesService) Rules() policy.SweepsRules {
+	return s.rules
+}
+
+// ─── Packages ───────────────────────────────────────────────────────────────
+
+// CoinPackageInput holds a new or replacement coin package.
+type CoinPackageInput struct {
+	Name          string `json:"name"`
+	Price         int64  `json:"price"`
+	PriceCurrency string `json:"price_currency"`
+	GoldCoins     int64  `json:"gold_coins"`
+	SweepsCoins   int64  `json:"sweeps_coins"`
+	Active        *bool  `json:"active,omitempty"`
+	SortOrder     int    `json:"sort_order"`
+}
+
+func (in *CoinPackageInput) validate() error {
+	in.Name = strings.TrimSpace(in.Name)
+	in.PriceCurrency = strings.ToUpper(strings.TrimSpace(in.PriceCurrency))
+	if in.Name == "" {
+		return domain.ErrValidation("name is required")
+	}
+	if in.Price <= 0 {
+		return domain.ErrValidation("price must be positive")
+	}
+	if err := domain.ValidateCurrency(in.PriceCurrency); err != nil {
+		return domain.ErrValidation(err.Error())
+	}
+	if in.GoldCoins < 0 || in.SweepsCoins < 0 || in.GoldCoins+in.SweepsCoins == 0 {
+		return domain.ErrValidation("a package must grant gold or sweeps coins")
+	}
+	return nil
+}
+
+// ListPackages returns the coin packages in display order. Players only see
+// active packages.
+func (s *SweepstakesService) ListPackages(ctx context.Context, activeOnly bool) ([]domain.CoinPackage, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+coinPackageColumns+` FROM coin_packages
+		WHERE active OR NOT $1
+		ORDER BY sort_order, price`, activeOnly)
+	if err != nil {
+		return nil, domain.ErrInternal("list coin packages", err)
+	}
+	defer rows.Close()
+
+	packages := []domain.CoinPackage{}
+	for rows.Next() {
+		p, err := scanCoinPackage(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan coin package", err)
+		}
+		packages = append(packages, *p)
+	}
+	return packages, rows.Err()
+}
+
+// CreatePackage adds a coin package.
+func (s *SweepstakesService) CreatePackage(ctx context.Context, input CoinPackageInput) (*domain.CoinPackage, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	active := input.Active == nil || *input.Active
+	p, err := scanCoinPackage(s.pool.QueryRow(ctx, `
+		INSERT INTO coin_packages (name, price, price_currency, gold_coins, sweeps_coins, active, sort_order)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+coinPackageColumns,
+		input.Name, input.Price, input.PriceCurrency, input.GoldCoins, input.SweepsCoins, active, input.SortOrder))
+	if err != nil {
+		return nil, domain.ErrInternal("insert coin package", err)
+	}
+	return p, nil
+}
+
+// UpdatePackage replaces a coin package. Purchases already started keep the
+// coins they were sold with.
+func (s *SweepstakesService) UpdatePackage(ctx context.Context, id uuid.UUID, input CoinPackageInput) (*domain.CoinPackage, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	active := input.Active == nil || *input.Active
+	p, err := scanCoinPackage(s.pool.QueryRow(ctx, `
+		UPDATE coin_packages
+		SET name = $2, price = $3, price_currency = $4, gold_coins = $5, sweeps_coins = $6, active = $7,
+		    sort_order = $8, updated_at = now()
+		WHERE id = $1
+		RETURNING `+coinPackageColumns,
+		id, input.Name, input.Price, input.PriceCurrency, input.GoldCoins, input.SweepsCoins, active, input.SortOrder))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("coin package", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("update coin package", err)
+	}
+	return p, nil
+}
+
+// ─── Purchases ──────────────────────────────────────────────────────────────
+
+// PurchaseInput holds a player's coin package checkout request.
+type PurchaseInput struct {
+	PackageID  uuid.UUID `json:"package_id"`
+	Method     string    `json:"method"` // stripe (default) or adyen
+	SuccessURL string    `json:"success_url"`
+	CancelURL  string    `json:"cancel_url"`
+}
+
+// Purchase opens a PSP checkout for a coin package. The coins are credited
+// when the PSP webhook confirms the payment.
+func (s *SweepstakesService) Purchase(ctx context.Context, playerID uuid.UUID, input PurchaseInput) (*DepositSession, error) {
+	if !s.rules.Enabled {
+		return nil, domain.ErrForbidden("sweepstakes mode is not enabled")
+	}
+	pkg, err := scanCoinPackage(s.pool.QueryRow(ctx,
+		`SELECT `+coinPackageColumns+` FROM coin_packages WHERE id = $1 AND active`, input.PackageID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("coin package", input.PackageID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find coin package", err)
+	}
+
+	// Coins land in the player's GC and SC wallets; open them up front so
+	// the webhook only has to post.
+	for _, currency := range []string{domain.CurrencyGoldCoins, domain.CurrencySweepsCoins} {
+		if _, err := s.wallets.Create(ctx, s.pool, playerID, currency); err != nil {
+			return nil, domain.ErrInternal("open coin wallet", err)
+		}
+	}
+
+	return s.payments.InitiatePurchase(ctx, playerID, *pkg, input.Method, input.SuccessURL, input.CancelURL)
+}
+
+// ListPurchases returns a player's coin purchases, newest first.
+func (s *SweepstakesService) ListPurchases(ctx context.Context, playerID uuid.UUID) ([]domain.CoinPurchase, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+coinPurchaseColumns+` FROM coin_purchases
+		WHERE player_id = $1 ORDER BY created_at DESC LIMIT 100`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list coin purchases", err)
+	}
+	defer rows.Close()
+
+	purchases := []domain.CoinPurchase{}
+	for rows.Next() {
+		p, err := scanCoinPurchase(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan coin purchase", err)
+		}
+		purchases = append(purchases, *p)
+	}
+	return purchases, rows.Err()
+}
+
+// InitiatePurchase creates a checkout session for a coin package and records
+// the pending payment with the coins it will credit.
+func (s *PaymentService) InitiatePurchase(ctx context.Context, playerID uuid.UUID, pkg domain.CoinPackage, method, successURL, cancelURL string) (*DepositSession, error) {
+	method, err := checkoutMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentID := uuid.New()
+	sessionID, sessionURL, err := s.createCheckout(ctx, method, paymentID, playerID, pkg.Price, pkg.PriceCurrency, successURL, cancelURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	providerName := method
+	payment := &domain.Payment{
+		ID:                paymentID,
+		PlayerID:          playerID,
+		Type:              domain.PaymentTypePurchase,
+		Amount:            pkg.Price,
+		Currency:          pkg.PriceCurrency,
+		Status:            domain.PaymentStatusPending,
+		Provider:          &providerName,
+		ProviderSessionID: &sessionID,
+	}
+	if err := s.payments.Create(ctx, tx, payment); err != nil {
+		return nil, domain.ErrInternal("record payment", err)
+	}
+	if err := s.publishPaymentStatus(ctx, tx, payment, domain.PaymentStatusPending); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO coin_purchases (player_id, package_id, payment_id, price, price_currency, gold_coins, sweeps_coins)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		playerID, pkg.ID, paymentID, pkg.Price, pkg.PriceCurrency, pkg.GoldCoins, pkg.SweepsCoins); err != nil {
+		return nil, domain.ErrInternal("record coin purchase", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+
+	s.recordEvent(ctx, payment.ID, domain.PaymentStatusPending, "coin package checkout created", nil)
+	return &DepositSession{
+		SessionID:  sessionID,
+		SessionURL: sessionURL,
+		PaymentID:  payment.ID.String(),
+		Method:     method,
+	}, nil
+}
+
+// creditPurchase posts a captured coin purchase to the GC and SC wallets and
+// marks the payment completed. The returned result is the first leg posted.
+func (s *PaymentService) creditPurchase(ctx context.Context, tx pgx.Tx, payment *domain.Payment, providerPaymentID, eventID string) (*domain.CommandResult, error) {
+	purchase, err := scanCoinPurchase(tx.QueryRow(ctx,
+		`SELECT `+coinPurchaseColumns+` FROM coin_purchases WHERE payment_id = $1 FOR UPDATE`, payment.ID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("coin purchase", payment.ID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock coin purchase", err)
+	}
+
+	providerName := paymentProvider(payment)
+	meta, _ := json.Marshal(map[string]string{
+		"provider": providerName, "event_id": eventID, "coin_purchase_id": purchase.ID.String(),
+	})
+	var first *domain.CommandResult
+	for _, leg := range []struct {
+		currency string
+		amount   int64
+	}{
+		{domain.CurrencyGoldCoins, purchase.GoldCoins},
+		{domain.CurrencySweepsCoins, purchase.SweepsCoins},
+	} {
+		if leg.amount == 0 {
+			continue
+		}
+		result, err := s.engine.ExecuteDeposit(ctx, tx, domain.DepositParams{
+			PlayerID:              payment.PlayerID,
+			Amount:                leg.amount,
+			ExternalTransactionID: fmt.Sprintf("%s_%s", providerName, eventID),
+			ManufacturerID:        providerName,
+			SubTransactionID:      strings.ToLower(leg.currency),
+			Metadata:              meta,
+			Currency:              leg.currency,
+		})
+		if err != nil {
+			return nil, domain.ErrInternal("credit "+leg.currency, err)
+		}
+		if first == nil {
+			first = result
+		}
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE coin_purchases SET status = 'completed', completed_at = now() WHERE id = $1`, purchase.ID); err != nil {
+		return nil, domain.ErrInternal("complete coin purchase", err)
+	}
+	if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusCompleted, &providerPaymentID, &first.Transaction.ID); err != nil {
+		return nil, err
+	}
+	return first, nil
+}
+
+// ─── Redemptions ────────────────────────────────────────────────────────────
+
+// RedemptionEligibility reports whether the player can redeem SC now and how
+// much of their balance is redeemable.
+func (s *SweepstakesService) RedemptionEligibility(ctx context.Context, playerID uuid.UUID) (*policy.RedemptionDecision, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	check, err := s.redemptionCheck(ctx, tx, playerID, 0)
+	if err != nil {
+		return nil, err
+	}
+	d := policy.EvaluateRedemption(s.rules, check)
+	return &d, nil
+}
+
+// Redeem reserves SC for a cash redemption pending admin review. The SC
+// wallet stays locked while eligibility is checked so concurrent requests
+// cannot both spend the same redeemable balance.
+func (s *SweepstakesService) Redeem(ctx context.Context, playerID uuid.UUID, amount int64) (*domain.SweepsRedemption, error) {
+	if !s.rules.Enabled {
+		return nil, domain.ErrForbidden("sweepstakes mode is not enabled")
+	}
+	if amount <= 0 {
+		return nil, domain.ErrValidation("amount must be positive")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := s.engine.LockWalletForUpdate(ctx, tx, playerID, domain.CurrencySweepsCoins); err != nil {
+		return nil, err
+	}
+	check, err := s.redemptionCheck(ctx, tx, playerID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if d := policy.EvaluateRedemption(s.rules, check); !d.Eligible {
+		return nil, domain.ErrRedemptionIneligible(d.Reason)
+	}
+
+	id := uuid.New()
+	result, err := s.engine.ExecuteWithdraw(ctx, tx, domain.WithdrawParams{
+		PlayerID:              playerID,
+		Amount:                amount,
+		ExternalTransactionID: "redeem_" + id.String(),
+		Metadata:              json.RawMessage(`{"redemption_id":"` + id.String() + `"}`),
+		Currency:              domain.CurrencySweepsCoins,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := scanRedemption(tx.QueryRow(ctx, `
+		INSERT INTO sweeps_redemptions (id, player_id, amount, cash_amount, cash_currency, reserve_transaction_id)
+		VALUES ($1, $2, $3, $3, $4, $5)
+		RETURNING `+redemptionColumns,
+		id, playerID, amount, s.rules.CashCurrency, result.Transaction.ID))
+	if err != nil {
+		return nil, domain.ErrInternal("insert redemption", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("sweeps redemption requested", "redemption_id", r.ID, "player_id", playerID, "amount", amount)
+	return r, nil
+}
+
+// redemptionCheck gathers the SC balance, lifetime SC credited and wagered,
+// and the player's KYC state and country.
+func (s *SweepstakesService) redemptionCheck(ctx context.Context, db repository.DBTX, playerID uuid.UUID, amount int64) (policy.RedemptionCheck, error) {
+	c := policy.RedemptionCheck{Amount: amount}
+
+	var country *string
+	err := db.QueryRow(ctx,
+		`SELECT verified, country FROM player_profiles WHERE player_id = $1`, playerID).Scan(&c.KYCVerified, &country)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return c, domain.ErrInternal("read player profile", err)
+	}
+	if country != nil {
+		c.Country = *country
+	}
+
+	err = db.QueryRow(ctx,
+		`SELECT COALESCE(balance, 0)::bigint FROM player_wallets WHERE player_id = $1 AND currency = $2`,
+		playerID, domain.CurrencySweepsCoins).Scan(&c.Balance)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return c, domain.ErrInternal("read sc balance", err)
+	}
+
+	err = db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount) FILTER (WHERE type IN ($3, $4)), 0)::bigint
+		     - COALESCE(SUM(amount) FILTER (WHERE type = $5), 0)::bigint,
+		       COALESCE(SUM(amount) FILTER (WHERE type = $6), 0)::bigint
+		     - COALESCE(SUM(amount) FILTER (WHERE type = $7), 0)::bigint
+		FROM v2_transactions WHERE player_id = $1 AND currency = $2`,
+		playerID, domain.CurrencySweepsCoins,
+		domain.TxDeposit, domain.TxBonusCredit, domain.TxCancelDeposit, domain.TxBet, domain.TxCancelBet,
+	).Scan(&c.Credited, &c.Wagered)
+	if err != nil {
+		return c, domain.ErrInternal("sum sc activity", err)
+	}
+	return c, nil
+}
+
+// ListRedemptions returns redemptions newest first, for one player when
+// playerID is set and optionally filtered by status.
+func (s *SweepstakesService) ListRedemptions(ctx context.Context, playerID *uuid.UUID, status string) ([]domain.SweepsRedemption, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+redemptionColumns+` FROM sweeps_redemptions
+		WHERE ($1::uuid IS NULL OR player_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC LIMIT 200`, playerID, status)
+	if err != nil {
+		return nil, domain.ErrInternal("list redemptions", err)
+	}
+	defer rows.Close()
+
+	out := []domain.SweepsRedemption{}
+	for rows.Next() {
+		r, err := scanRedemption(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan redemption", err)
+		}
+		out = append(out, *r)
+	}
+	return out, rows.Err()
+}
+
+// ApproveRedemption marks a pending redemption paid and burns the reserved SC.
+func (s *SweepstakesService) ApproveRedemption(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.SweepsRedemption, error) {
+	return s.reviewRedemption(ctx, id, adminID, true, note)
+}
+
+// RejectRedemption returns a pending redemption's reserved SC to the player.
+func (s *SweepstakesService) RejectRedemption(ctx context.Context, id, adminID uuid.UUID, note string) (*domain.SweepsRedemption, error) {
+	return s.reviewRedemption(ctx, id, adminID, false, note)
+}
+
+func (s *SweepstakesService) reviewRedemption(ctx context.Context, id, adminID uuid.UUID, approve bool, note string) (*domain.SweepsRedemption, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	r, err := scanRedemption(tx.QueryRow(ctx,
+		`SELECT `+redemptionColumns+` FROM sweeps_redemptions WHERE id = $1 FOR UPDATE`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("redemption", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock redemption", err)
+	}
+	if r.Status != domain.RedemptionPending {
+		return nil, domain.ErrConflict(fmt.Sprintf("redemption is already %s", r.Status))
+	}
+
+	meta := json.RawMessage(`{"redemption_id":"` + r.ID.String() + `"}`)
+	var result *domain.CommandResult
+	status := domain.RedemptionRejected
+	if approve {
+		status = domain.RedemptionPaid
+		result, err = s.engine.ExecuteCompleteWithdrawal(ctx, tx, domain.CompleteWithdrawalParams{
+			PlayerID:              r.PlayerID,
+			Amount:                r.Amount,
+			ExternalTransactionID: "redeem_paid_" + r.ID.String(),
+			Metadata:              meta,
+			Currency:              domain.CurrencySweepsCoins,
+		})
+	} else {
+		result, err = s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+			PlayerID:              r.PlayerID,
+			Amount:                r.Amount,
+			ExternalTransactionID: "redeem_rejected_" + r.ID.String(),
+			ManufacturerID:        sweepsManufacturer,
+			TargetTransactionID:   r.ReserveTransactionID,
+			Metadata:              meta,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewNote *string
+	if note = strings.TrimSpace(note); note != "" {
+		reviewNote = &note
+	}
+	r, err = scanRedemption(tx.QueryRow(ctx, `
+		UPDATE sweeps_redemptions
+		SET status = $2, settle_transaction_id = $3, reviewed_by = $4, review_note = $5, reviewed_at = now()
+		WHERE id = $1
+		RETURNING `+redemptionColumns,
+		r.ID, status, result.Transaction.ID, adminID, reviewNote))
+	if err != nil {
+		return nil, domain.ErrInternal("update redemption", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("sweeps redemption reviewed", "redemption_id", r.ID, "status", r.Status, "admin_id", adminID)
+	return r, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TermsService manages versioned T&C documents and player acceptances.
+type TermsService struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewTermsService creates a new TermsService.
+func NewTermsService(pool *pgxpool.Pool, logger *slog.Logger) *TermsService {
+	return &TermsService{pool: pool, logger: logger}
+}
+
+const termsColumns = `id, kind, version, title, body, content_hash, created_at, published_at`
+
+func scanTerms(row pgx.Row) (*domain.TermsDocument, error) {
+	var d domain.TermsDocument
+	if err := row.Scan(&d.ID, &d.Kind, &d.Version, &d.Title, &d.Body, &d.ContentHash, &d.CreatedAt, &d.PublishedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Current returns the latest published version of each document kind.
+func (s *TermsService) Current(ctx context.Context) ([]domain.TermsDocument, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (kind) `+termsColumns+`
+		FROM terms_documents
+		WHERE published_at IS NOT NULL
+		ORDER BY kind, version DESC`)
+	if err != nil {
+		return nil, domain.ErrInternal("query current terms", err)
+	}
+	defer rows.Close()
+
+	docs := []domain.TermsDocument{}
+	for rows.Next() {
+		d, err := scanTerms(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan terms", err)
+		}
+		docs = append(docs, *d)
+	}
+	return docs, rows.Err()
+}
+
+// Pending returns the current documents the player still has to accept.
+func (s *TermsService) Pending(ctx context.Context, playerID uuid.UUID) ([]domain.TermsDocument, error) {
+	return guard.PendingTerms(ctx, s.pool, playerID)
+}
+
+// Accept records the player's acceptance of a document. Only the current
+// published version of a kind can be accepted; accepting it again is a no-op
+// that returns the original record.
+func (s *TermsService) Accept(ctx context.Context, playerID, documentID uuid.UUID, ip string) (*domain.TermsAcceptance, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	doc, err := scanTerms(tx.QueryRow(ctx, `SELECT `+termsColumns+` FROM terms_documents WHERE id = $1`, documentID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("terms document", documentID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find terms document", err)
+	}
+	if doc.PublishedAt == nil {
+		return nil, domain.ErrNotFound("terms document", documentID.String())
+	}
+
+	var latest int
+	if err := tx.QueryRow(ctx, `
+		SELECT MAX(version) FROM terms_documents WHERE kind = $1 AND published_at IS NOT NULL`,
+		doc.Kind).Scan(&latest); err != nil {
+		return nil, domain.ErrInternal("find latest terms version", err)
+	}
+	if doc.Version != latest {
+		return nil, domain.ErrConflict("a newer version of these terms has been published")
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO terms_acceptances (player_id, document_id, content_hash, ip)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (player_id, document_id) DO NOTHING`,
+		playerID, doc.ID, doc.ContentHash, ip); err != nil {
+		return nil, domain.ErrInternal("insert terms acceptance", err)
+	}
+
+	a := domain.TermsAcceptance{PlayerID: playerID, DocumentID: doc.ID, Kind: doc.Kind, Version: doc.Version}
+	if err := tx.QueryRow(ctx, `
+		SELECT content_hash, ip, accepted_at FROM terms_acceptances WHERE player_id = $1 AND document_id = $2`,
+		playerID, doc.ID).Scan(&a.ContentHash, &a.IP, &a.AcceptedAt); err != nil {
+		return nil, domain.ErrInternal("read terms acceptance", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	return &a, nil
+}
+
+// ListAcceptances returns every acceptance the player has recorded, newest first.
+func (s *TermsService) ListAcceptances(ctx context.Context, playerID uuid.UUID) ([]domain.TermsAcceptance, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT a.player_id, a.document_id, d.kind, d.version, a.content_hash, a.ip, a.accepted_at
+		FROM terms_acceptances a
+		JOIN terms_documents d ON d.id = a.document_id
+		WHERE a.player_id = $1
+		ORDER BY a.accepted_at DESC, d.kind`, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("query terms acceptances", err)
+	}
+	defer rows.Close()
+
+	out := []domain.TermsAcceptance{}
+	for rows.Next() {
+		var a domain.TermsAcceptance
+		if err := rows.Scan(&a.PlayerID, &a.DocumentID, &a.Kind, &a.Version, &a.ContentHash, &a.IP, &a.AcceptedAt); err != nil {
+			return nil, domain.ErrInternal("scan terms acceptance", err)
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// --- Admin ---
+
+// ListDocuments returns every version, drafts included, optionally filtered by kind.
+func (s *TermsService) ListDocuments(ctx context.Context, kind string) ([]domain.TermsDocument, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+termsColumns+`
+		FROM terms_documents
+		WHERE $1 = '' OR kind = $1
+		ORDER BY kind, version DESC`, kind)
+	if err != nil {
+		return nil, domain.ErrInternal("query terms documents", err)
+	}
+	defer rows.Close()
+
+	docs := []domain.TermsDocument{}
+	for rows.Next() {
+		d, err := scanTerms(rows)
+		if err != nil {
+			return nil, domain.ErrInternal("scan terms", err)
+		}
+		docs = append(docs, *d)
+	}
+	return docs, rows.Err()
+}
+
+// TermsInput is the admin request to draft a new document version.
+type TermsInput struct {
+	Kind  domain.TermsKind `json:"kind"`
+	Title string           `json:"title"`
+	Body  string           `json:"body"`
+}
+
+// CreateDraft stores the next version of a document kind as an unpublished draft.
+func (s *TermsService) CreateDraft(ctx context.Context, input TermsInput, adminID uuid.UUID) (*domain.TermsDocument, error) {
+	input.Title = strings.TrimSpace(input.Title)
+	if !input.Kind.Valid() {
+		return nil, domain.ErrValidation("kind must be terms or bonus_terms")
+	}
+	if input.Title == "" || strings.TrimSpace(input.Body) == "" {
+		return nil, domain.ErrValidation("title and body are required")
+	}
+
+	doc, err := scanTerms(s.pool.QueryRow(ctx, `
+		INSERT INTO terms_documents (kind, version, title, body, content_hash, created_by)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5
+		FROM terms_documents WHERE kind = $1
+		RETURNING `+termsColumns,
+		input.Kind, input.Title, input.Body, domain.TermsContentHash(input.Title, input.Body), adminID))
+	if err != nil {
+		return nil, domain.ErrInternal("insert terms document", err)
+	}
+	return doc, nil
+}
+
+// Publish makes a draft the current version of its kind. Every player then has
+// to accept it. Only a version newer than the current one can be published.
+func (s *TermsService) Publish(ctx context.Context, id uuid.UUID) (*domain.TermsDocument, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	doc, err := scanTerms(tx.QueryRow(ctx, `SELECT `+termsColumns+` FROM terms_documents WHERE id = $1 FOR UPDATE`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("terms document", id.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("find terms document", err)
+	}
+	if doc.PublishedAt != nil {
+		return nil, domain.ErrConflict("terms document is already published")
+	}
+
+	var newer bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM terms_documents
+		               WHERE kind = $1 AND version > $2 AND published_at IS NOT NULL)`,
+		doc.Kind, doc.Version).Scan(&newer); err != nil {
+		return nil, domain.ErrInternal("check newer terms", err)
+	}
+	if newer {
+		return nil, domain.ErrConflict("a newer version is already published")
+	}
+
+	if err := tx.QueryRow(ctx, `
+		UPDATE terms_documents SET published_at = now() WHERE id = $1 RETURNING published_at`,
+		id).Scan(&doc.PublishedAt); err != nil {
+		return nil, domain.ErrInternal("publish terms document", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.logger.Info("terms published", "kind", doc.Kind, "version", doc.Version, "id", doc.ID)
+	return doc, nil
+}
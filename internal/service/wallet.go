@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultWalletCurrencies is used when WALLET_CURRENCIES is unset.
+const DefaultWalletCurrencies = "EUR,USD,GBP"
+
+// WalletService manages a player's per-currency wallets and conversions
+// between them.
+type WalletService struct {
+	pool       *pgxpool.Pool
+	players    repository.PlayerRepository
+	wallets    repository.WalletRepository
+	engine     *ledger.Engine
+	currencies map[string]bool
+	logger     *slog.Logger
+}
+
+// NewWalletService creates a WalletService. currencies is a comma-separated
+// list of the ISO codes players may open wallets in.
+func NewWalletService(pool *pgxpool.Pool, players repository.PlayerRepository, wallets repository.WalletRepository, engine *ledger.Engine, currencies string, logger *slog.Logger) *WalletService {
+	if strings.TrimSpace(currencies) == "" {
+		currencies = DefaultWalletCurrencies
+	}
+	supported := make(map[string]bool)
+	for _, c := range strings.Split(currencies, ",") {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			supported[c] = true
+		}
+	}
+	return &WalletService{pool: pool, players: players, wallets: wallets, engine: engine, currencies: supported, logger: logger}
+}
+
+// ListWallets returns the base wallet followed by any secondary wallets.
+func (s *WalletService) ListWallets(ctx context.Context, playerID uuid.UUID) ([]domain.Wallet, error) {
+	player, err := s.players.FindByID(ctx, s.pool, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("find player", err)
+	}
+	if player == nil {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+
+	secondary, err := s.wallets.ListByPlayer(ctx, s.pool, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("list wallets", err)
+	}
+
+	wallets := []domain.Wallet{{
+		PlayerID:  player.ID,
+		Currency:  player.Currency,
+		Balances:  player.Balances,
+		Base:      true,
+		UpdatedAt: player.UpdatedAt,
+	}}
+	return append(wallets, secondary...), nil
+}
+
+// OpenWallet creates an empty wallet in currency. Opening a wallet the player
+// already holds is a conflict.
+func (s *WalletService) OpenWallet(ctx context.Context, playerID uuid.UUID, currency string) (*domain.Wallet, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !s.currencies[currency] {
+		return nil, domain.ErrValidation(fmt.Sprintf("unsupported currency: %s", currency))
+	}
+
+	player, err := s.players.FindByID(ctx, s.pool, playerID)
+	if err != nil {
+		return nil, domain.ErrInternal("find player", err)
+	}
+	if player == nil {
+		return nil, domain.ErrNotFound("player", playerID.String())
+	}
+	if strings.EqualFold(player.Currency, currency) {
+		return nil, domain.ErrConflict(fmt.Sprintf("%s is the account's base currency", currency))
+	}
+
+	created, err := s.wallets.Create(ctx, s.pool, playerID, currency)
+	if err != nil {
+		return nil, domain.ErrInternal("create wallet", err)
+	}
+	if !created {
+		return nil, domain.ErrConflict(fmt.Sprintf("%s wallet already exists", currency))
+	}
+	return &domain.Wallet{PlayerID: playerID, Currency: currency}, nil
+}
+
+// ConvertInput holds a player's conversion request.
+type ConvertInput struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	Amount         int64  `json:"amount"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// Convert moves real balance between two of the player's wallets at the
+// current fx_rates rate.
+func (s *WalletService) Convert(ctx context.Context, playerID uuid.UUID, input ConvertInput) (*domain.ConversionResult, error) {
+	from := strings.ToUpper(strings.TrimSpace(input.From))
+	to := strings.ToUpper(strings.TrimSpace(input.To))
+	if from == "" || to == "" {
+		return nil, domain.ErrValidation("from and to are required")
+	}
+
+	rate, err := s.findRate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := policy.ConvertAmount(input.Amount, rate)
+	if err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+	if converted <= 0 {
+		return nil, domain.ErrValidation("amount is too small to convert")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := s.engine.ExecuteCurrencyConversion(ctx, tx, domain.ConversionParams{
+		PlayerID:              playerID,
+		FromCurrency:          from,
+		ToCurrency:            to,
+		Amount:                input.Amount,
+		ConvertedAmount:       converted,
+		Rate:                  rate,
+		ExternalTransactionID: input.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	if !result.Idempotent {
+		s.logger.Info("currency converted", "player_id", playerID, "from", from, "to", to,
+			"amount", input.Amount, "converted", converted, "rate", rate)
+	}
+	return result, nil
+}
+
+func (s *WalletService) findRate(ctx context.Context, from, to string) (string, error) {
+	var rate string
+	err := s.pool.QueryRow(ctx,
+		`SELECT rate::text FROM fx_rates WHERE base = $1 AND quote = $2`, from, to).Scan(&rate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrValidation(fmt.Sprintf("no exchange rate for %s/%s", from, to))
+	}
+	if err != nil {
+		return "", domain.ErrInternal("find fx rate", err)
+	}
+	return rate, nil
+}
+
+// ListRates returns all configured conversion rates.
+func (s *WalletService) ListRates(ctx context.Context) ([]domain.FxRate, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT base, quote, rate::text, updated_by, updated_at
+		FROM fx_rates ORDER BY base, quote`)
+	if err != nil {
+		return nil, domain.ErrInternal("list fx rates", err)
+	}
+	defer rows.Close()
+
+	rates := []domain.FxRate{}
+	for rows.Next() {
+		var r domain.FxRate
+		if err := rows.Scan(&r.Base, &r.Quote, &r.Rate, &r.UpdatedBy, &r.UpdatedAt); err != nil {
+			return nil, domain.ErrInternal("scan fx rate", err)
+		}
+		rates = append(rates, r)
+	}
+	return rates, rows.Err()
+}
+
+// SetRate creates or replaces the base/quote rate.
+func (s *WalletService) SetRate(ctx context.Context, base, quote, rate string, adminID uuid.UUID) (*domain.FxRate, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if !s.currencies[base] || !s.currencies[quote] {
+		return nil, domain.ErrValidation("unsupported currency pair")
+	}
+	if base == quote {
+		return nil, domain.ErrValidation("base and quote must differ")
+	}
+	if _, err := policy.ConvertAmount(1, rate); err != nil {
+		return nil, domain.ErrValidation("rate must be a positive decimal")
+	}
+
+	r := domain.FxRate{Base: base, Quote: quote}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO fx_rates (base, quote, rate, updated_by)
+		VALUES ($1, $2, $3::numeric, $4)
+		ON CONFLICT (base, quote) DO UPDATE
+		  SET rate = EXCLUDED.rate, updated_by = EXCLUDED.updated_by, updated_at = now()
+		RETURNING rate::text, updated_by, updated_at`,
+		base, quote, rate, adminID).Scan(&r.Rate, &r.UpdatedBy, &r.UpdatedAt)
+	if err != nil {
+		return nil, domain.ErrInternal("set fx rate", err)
+	}
+	s.logger.Info("fx rate updated", "base", base, "quote", quote, "rate", r.Rate, "admin_id", adminID)
+	return &r, nil
+}
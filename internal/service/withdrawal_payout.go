@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/internal/saga"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// WithdrawalPayoutSaga is the saga kind that pays out an approved
+// withdrawal: it holds the reserved funds, sends them through the PSP and
+// burns the reservation. A payout the PSP refuses releases the reservation
+// back to the player's balance. One that keeps failing otherwise, such as
+// timing out, may have been paid, so the saga fails with the funds still
+// reserved for an operator to reconcile with the PSP.
+const WithdrawalPayoutSaga = "withdrawal_payout"
+
+// withdrawalPayout is the data of a withdrawal payout saga.
+type withdrawalPayout struct {
+	PaymentID            uuid.UUID  `json:"payment_id"`
+	ApprovedBy           uuid.UUID  `json:"approved_by"`
+	ReserveTransactionID *uuid.UUID `json:"reserve_transaction_id,omitempty"`
+	ProviderReference    string     `json:"provider_reference,omitempty"`
+}
+
+// withdrawalPayoutSaga defines the withdrawal payout steps.
+func (s *PaymentService) withdrawalPayoutSaga() saga.Definition {
+	return saga.Definition{
+		Kind:  WithdrawalPayoutSaga,
+		Retry: policy.DefaultSagaRetryPolicy(),
+		Steps: []saga.Step{
+			{Name: "reserve", Action: s.reserveWithdrawal, Compensate: s.releaseWithdrawal},
+			{Name: "payout", Action: s.payOutWithdrawal, Pivot: true},
+			{Name: "complete", Action: s.completeWithdrawal},
+		},
+	}
+}
+
+// ApproveWithdrawal approves a pending withdrawal and runs its payout saga.
+// The saga usually finishes before this returns; when the PSP is
+// unavailable it is left to retry in the background, and the returned saga
+// says where it stands.
+func (s *PaymentService) ApproveWithdrawal(ctx context.Context, paymentID, adminID uuid.UUID) (*domain.Saga, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, domain.ErrInternal("begin tx", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status domain.PaymentStatus
+	err = tx.QueryRow(ctx,
+		`SELECT status FROM payments WHERE id = $1 AND type = 'withdrawal' FOR UPDATE`, paymentID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound("withdrawal", paymentID.String())
+	}
+	if err != nil {
+		return nil, domain.ErrInternal("lock withdrawal", err)
+	}
+	if status != domain.PaymentStatusPending {
+		return nil, domain.ErrConflict(fmt.Sprintf("withdrawal is already %s", status))
+	}
+	payment, err := s.payments.FindByID(ctx, tx, paymentID)
+	if err != nil {
+		return nil, domain.ErrInternal("find payment", err)
+	}
+	if payment.ExternalTransactionID == nil {
+		return nil, domain.ErrValidation("withdrawal has no reservation to pay out")
+	}
+
+	if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusApproved, nil, nil); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE payments SET approved_by = $2, approved_at = now() WHERE id = $1`, paymentID, adminID); err != nil {
+		return nil, domain.ErrInternal("record approval", err)
+	}
+	sg, err := s.sagas.Start(ctx, tx, WithdrawalPayoutSaga, paymentID.String(),
+		withdrawalPayout{PaymentID: paymentID, ApprovedBy: adminID})
+	if err != nil {
+		return nil, domain.ErrInternal("start payout saga", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, domain.ErrInternal("commit tx", err)
+	}
+	s.recordEvent(ctx, paymentID, domain.PaymentStatusApproved, "withdrawal approved", nil)
+
+	sg, err = s.sagas.Run(ctx, sg.ID)
+	if err != nil {
+		return nil, domain.ErrInternal("run payout saga", err)
+	}
+	return sg, nil
+}
+
+// loadWithdrawal reads a payout saga's data and its withdrawal.
+func (s *PaymentService) loadWithdrawal(ctx context.Context, sg *domain.Saga) (*withdrawalPayout, *domain.Payment, error) {
+	var data withdrawalPayout
+	if err := saga.Load(sg, &data); err != nil {
+		return nil, nil, saga.Permanent(fmt.Errorf("decode saga data: %w", err))
+	}
+	payment, err := s.payments.FindByID(ctx, s.pool, data.PaymentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find payment: %w", err)
+	}
+	if payment == nil {
+		return nil, nil, saga.Permanent(fmt.Errorf("payment %s not found", data.PaymentID))
+	}
+	return &data, payment, nil
+}
+
+// reserveWithdrawal holds the withdrawal's funds. The reservation was made
+// when the withdrawal was requested, under the same external ID, so this
+// finds it rather than reserving twice.
+func (s *PaymentService) reserveWithdrawal(ctx context.Context, sg *domain.Saga) error {
+	data, payment, err := s.loadWithdrawal(ctx, sg)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := s.engine.ExecuteWithdraw(ctx, tx, domain.WithdrawParams{
+		PlayerID:              payment.PlayerID,
+		Amount:                payment.Amount,
+		ExternalTransactionID: *payment.ExternalTransactionID,
+	})
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) && appErr.Status < 500 {
+		return saga.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	data.ReserveTransactionID = &result.Transaction.ID
+	return saga.Store(sg, data)
+}
+
+// releaseWithdrawal returns the reserved funds to the player's balance and
+// marks the withdrawal failed.
+func (s *PaymentService) releaseWithdrawal(ctx context.Context, sg *domain.Saga) error {
+	data, payment, err := s.loadWithdrawal(ctx, sg)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if data.ReserveTransactionID != nil {
+		if _, err := s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+			PlayerID:              payment.PlayerID,
+			Amount:                payment.Amount,
+			ExternalTransactionID: "wd_release_" + payment.ID.String(),
+			TargetTransactionID:   *data.ReserveTransactionID,
+		}); err != nil {
+			return err
+		}
+	}
+	if payment.Status != domain.PaymentStatusFailed {
+		if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusFailed, nil, nil); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	reason := "payout failed"
+	if sg.LastError != nil {
+		reason = "payout failed: " + *sg.LastError
+	}
+	s.recordEvent(ctx, payment.ID, domain.PaymentStatusFailed, reason+"; funds released", nil)
+	return nil
+}
+
+// payOutWithdrawal sends the withdrawal through the PSP, keyed by the
+// payment ID so a retry after a timeout confirms the payout instead of
+// sending it again. Without a payout PSP configured, withdrawals are paid
+// by hand and the admin's approval confirms the payout.
+func (s *PaymentService) payOutWithdrawal(ctx context.Context, sg *domain.Saga) error {
+	data, payment, err := s.loadWithdrawal(ctx, sg)
+	if err != nil {
+		return err
+	}
+
+	if s.payouts == nil || !s.payouts.Configured() {
+		return nil
+	}
+
+	result, err := s.payouts.SubmitPayout(ctx, payment.Amount, payment.Currency, payment.ID.String(), payment.PlayerID.String())
+	if errors.Is(err, provider.ErrPayoutRefused) {
+		return saga.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+	data.ProviderReference = result.PSPReference
+	return saga.Store(sg, data)
+}
+
+// completeWithdrawal burns the reserved funds and marks the withdrawal
+// completed with the PSP's reference, if it was paid through one.
+func (s *PaymentService) completeWithdrawal(ctx context.Context, sg *domain.Saga) error {
+	data, payment, err := s.loadWithdrawal(ctx, sg)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := s.engine.ExecuteCompleteWithdrawal(ctx, tx, domain.CompleteWithdrawalParams{
+		PlayerID:              payment.PlayerID,
+		Amount:                payment.Amount,
+		ExternalTransactionID: "wd_paid_" + payment.ID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	var reference *string
+	if data.ProviderReference != "" {
+		reference = &data.ProviderReference
+	}
+	if payment.Status != domain.PaymentStatusCompleted {
+		if err := s.updatePaymentStatus(ctx, tx, payment, domain.PaymentStatusCompleted,
+			reference, &result.Transaction.ID); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	s.recordEvent(ctx, payment.ID, domain.PaymentStatusCompleted, "withdrawal paid out", nil)
+	return nil
+}
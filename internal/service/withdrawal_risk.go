@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+// assessWithdrawal runs the automated risk check for a withdrawal and stores
+// the recommendation against the payment. If the facts cannot be gathered the
+// withdrawal is still queued, flagged for manual review.
+func (s *PaymentService) assessWithdrawal(ctx context.Context, db repository.DBTX, paymentID, playerID uuid.UUID, amount int64) error {
+	input, err := s.withdrawalRiskInput(ctx, db, playerID, amount)
+	var assessment policy.WithdrawalRiskAssessment
+	if err != nil {
+		s.logger.Error("gather withdrawal risk inputs", "error", err, "payment_id", paymentID)
+		assessment = policy.WithdrawalRiskAssessment{
+			Score:          policy.WithdrawalReviewScore,
+			Recommendation: policy.WithdrawalReview,
+			Factors:        []string{"risk_check_failed"},
+		}
+	} else {
+		assessment = policy.EvaluateWithdrawalRisk(input)
+	}
+
+	factors, _ := json.Marshal(assessment.Factors)
+	inputs, _ := json.Marshal(input)
+	_, err = db.Exec(ctx, `
+		INSERT INTO withdrawal_risk_assessments (payment_id, player_id, score, recommendation, factors, inputs)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		paymentID, playerID, assessment.Score, assessment.Recommendation, factors, inputs)
+	if err != nil {
+		return domain.ErrInternal("record withdrawal risk", err)
+	}
+	return nil
+}
+
+// withdrawalRiskInput collects wagering, bonus, deposit-method, KYC and AML
+// facts for a player.
+func (s *PaymentService) withdrawalRiskInput(ctx context.Context, db repository.DBTX, playerID uuid.UUID, amount int64) (policy.WithdrawalRiskInput, error) {
+	in := policy.WithdrawalRiskInput{Amount: amount}
+
+	err := db.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE type = 'wallet_deposit'), 0)::bigint,
+			COALESCE(SUM(amount) FILTER (WHERE type = 'bet'), 0)::bigint,
+			COALESCE(SUM(amount) FILTER (WHERE type = 'bonus_credit'), 0)::bigint
+		FROM v2_transactions WHERE player_id = $1`, playerID,
+	).Scan(&in.TotalDeposited, &in.TotalWagered, &in.BonusCredited)
+	if err != nil {
+		return in, err
+	}
+
+	err = db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(GREATEST(wagering_requirement - wagered, 0)), 0)::bigint
+		FROM player_bonuses WHERE player_id = $1 AND status = 'active'`, playerID,
+	).Scan(&in.OutstandingBonusWagering)
+	if err != nil {
+		return in, err
+	}
+
+	// Age of the most recently used deposit method: time since the player
+	// first completed a deposit through that provider.
+	var firstUse *time.Time
+	err = db.QueryRow(ctx, `
+		WITH latest AS (
+			SELECT COALESCE(provider, '') AS provider FROM payments
+			WHERE player_id = $1 AND type = 'deposit' AND status = 'completed'
+			ORDER BY created_at DESC LIMIT 1
+		)
+		SELECT MIN(p.created_at) FROM payments p, latest
+		WHERE p.player_id = $1 AND p.type = 'deposit' AND p.status = 'completed'
+		  AND COALESCE(p.provider, '') = latest.provider`, playerID,
+	).Scan(&firstUse)
+	if err != nil {
+		return in, err
+	}
+	if firstUse != nil {
+		in.DepositMethodAge = time.Since(*firstUse)
+	}
+
+	err = db.QueryRow(ctx, `
+		SELECT COALESCE((SELECT verified FROM player_profiles WHERE player_id = $1), false),
+		       (SELECT COUNT(*) FROM aml_alerts WHERE player_id = $1 AND status = 'open')`, playerID,
+	).Scan(&in.KYCVerified, &in.OpenAMLAlerts)
+	return in, err
+}
+
+// ListWithdrawalQueue returns withdrawals in the given status (default
+// pending) with their risk recommendation, riskiest first.
+func (s *PaymentService) ListWithdrawalQueue(ctx context.Context, status string) ([]domain.WithdrawalQueueItem, error) {
+	if status == "" {
+		status = string(domain.PaymentStatusPending)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT p.id, p.player_id, COALESCE(pp.email, ''), p.amount, p.currency, p.status,
+		       wra.score, wra.recommendation, wra.factors, p.created_at
+		FROM payments p
+		LEFT JOIN player_profiles pp ON pp.player_id = p.player_id
+		LEFT JOIN withdrawal_risk_assessments wra ON wra.payment_id = p.id
+		WHERE p.type = 'withdrawal' AND p.status = $1
+		ORDER BY wra.score DESC NULLS FIRST, p.created_at
+		LIMIT 100`, status)
+	if err != nil {
+		return nil, domain.ErrInternal("list withdrawal queue", err)
+	}
+	defer rows.Close()
+
+	items := []domain.WithdrawalQueueItem{}
+	for rows.Next() {
+		var item domain.WithdrawalQueueItem
+		if err := rows.Scan(&item.PaymentID, &item.PlayerID, &item.Email, &item.Amount, &item.Currency,
+			&item.Status, &item.RiskScore, &item.Recommendation, &item.RiskFactors, &item.RequestedAt); err != nil {
+			return nil, domain.ErrInternal("scan withdrawal", err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
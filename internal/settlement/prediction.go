@@ -26,50 +26,65 @@ func (s *PredictionSettlement) ValidateAttestation(attestation domain.Attestatio
 	return domain.ValidateAttestation(attestation)
 }
 
+// PredictionManufacturerID is the manufacturer prediction stakes and their
+// settlements are posted under.
+const PredictionManufacturerID = "predictions"
+
+// StakeRound is the game round of a prediction stake: each stake is its own
+// round, so a win's real/bonus split follows that stake alone.
+func StakeRound(stakeID uuid.UUID) string {
+	return "pred-" + stakeID.String()
+}
+
 // PlaceStake deducts a stake from the player's balance.
-func (s *PredictionSettlement) PlaceStake(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, marketID uuid.UUID, outcome string, amount int64) (*domain.CommandResult, error) {
+func (s *PredictionSettlement) PlaceStake(ctx context.Context, tx pgx.Tx, playerID, stakeID, marketID uuid.UUID, outcome string, amount int64) (*domain.CommandResult, error) {
 	meta, _ := json.Marshal(map[string]interface{}{
 		"marketId": marketID.String(),
+		"stakeId":  stakeID.String(),
 		"outcome":  outcome,
 		"type":     "prediction_stake",
 	})
 	return s.engine.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
 		PlayerID:              playerID,
 		Amount:                amount,
-		ExternalTransactionID: fmt.Sprintf("pred-stake-%s-%s", marketID, outcome),
-		GameRoundID:           marketID.String(),
+		ExternalTransactionID: fmt.Sprintf("pred-stake-%s", stakeID),
+		ManufacturerID:        PredictionManufacturerID,
+		GameRoundID:           StakeRound(stakeID),
 		Metadata:              meta,
 	})
 }
 
-// SettleOutcomeWin credits a winning prediction. Requires valid attestation.
-func (s *PredictionSettlement) SettleOutcomeWin(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, marketID uuid.UUID, winAmount int64, attestation domain.Attestation) (*domain.CommandResult, error) {
+// SettleOutcomeWin credits a winning stake. Requires valid attestation.
+func (s *PredictionSettlement) SettleOutcomeWin(ctx context.Context, tx pgx.Tx, playerID, stakeID, marketID uuid.UUID, winAmount int64, attestation domain.Attestation) (*domain.CommandResult, error) {
 	if err := s.ValidateAttestation(attestation); err != nil {
 		return nil, fmt.Errorf("prediction settlement: %w", err)
 	}
 
 	meta, _ := json.Marshal(map[string]interface{}{
 		"marketId":    marketID.String(),
+		"stakeId":     stakeID.String(),
 		"settlement":  "prediction_win",
 		"attestation": attestation,
 	})
 	return s.engine.ExecuteCreditWin(ctx, tx, domain.CreditWinParams{
 		PlayerID:              playerID,
 		Amount:                winAmount,
-		ExternalTransactionID: fmt.Sprintf("pred-win-%s", marketID),
-		GameRoundID:           marketID.String(),
+		ExternalTransactionID: fmt.Sprintf("pred-win-%s", stakeID),
+		ManufacturerID:        PredictionManufacturerID,
+		GameRoundID:           StakeRound(stakeID),
 		Metadata:              meta,
 	})
 }
 
-// SettleOutcomeLoss records a losing prediction (no balance change).
-func (s *PredictionSettlement) SettleOutcomeLoss(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, marketID uuid.UUID, attestation domain.Attestation) (*domain.CommandResult, error) {
+// SettleOutcomeLoss records a losing stake (no balance change).
+func (s *PredictionSettlement) SettleOutcomeLoss(ctx context.Context, tx pgx.Tx, playerID, stakeID, marketID uuid.UUID, attestation domain.Attestation) (*domain.CommandResult, error) {
 	if err := s.ValidateAttestation(attestation); err != nil {
 		return nil, fmt.Errorf("prediction loss settlement: %w", err)
 	}
 
 	meta, _ := json.Marshal(map[string]interface{}{
 		"marketId":    marketID.String(),
+		"stakeId":     stakeID.String(),
 		"settlement":  "prediction_loss",
 		"attestation": attestation,
 	})
@@ -78,8 +93,9 @@ func (s *PredictionSettlement) SettleOutcomeLoss(ctx context.Context, tx pgx.Tx,
 		Type:                  domain.TxSettlementLoss,
 		Amount:                0,
 		BalanceUpdate:         domain.BalanceUpdate{},
-		ExternalTransactionID: strPtr(fmt.Sprintf("pred-loss-%s", marketID)),
-		GameRoundID:           strPtr(marketID.String()),
+		ExternalTransactionID: strPtr(fmt.Sprintf("pred-loss-%s", stakeID)),
+		ManufacturerID:        strPtr(PredictionManufacturerID),
+		GameRoundID:           strPtr(StakeRound(stakeID)),
 		Metadata:              meta,
 	})
 	if err != nil {
@@ -92,12 +108,31 @@ func (s *PredictionSettlement) SettleOutcomeLoss(ctx context.Context, tx pgx.Tx,
 	}, nil
 }
 
-// VoidMarket cancels all stakes in a voided market (returns stakes to players).
-func (s *PredictionSettlement) VoidMarket(ctx context.Context, tx pgx.Tx, playerID uuid.UUID, stakeTxID uuid.UUID, stakeAmount int64) (*domain.CommandResult, error) {
+// SellStake credits the proceeds of a stake sold back before its market
+// settles. Like a cash-out it closes the stake's round.
+func (s *PredictionSettlement) SellStake(ctx context.Context, tx pgx.Tx, playerID, stakeID, marketID uuid.UUID, proceeds int64) (*domain.CommandResult, error) {
+	meta, _ := json.Marshal(map[string]interface{}{
+		"marketId":   marketID.String(),
+		"stakeId":    stakeID.String(),
+		"settlement": "prediction_sell",
+	})
+	return s.engine.ExecuteCashOut(ctx, tx, domain.CashOutParams{
+		PlayerID:              playerID,
+		Amount:                proceeds,
+		ExternalTransactionID: fmt.Sprintf("pred-sell-%s", stakeID),
+		ManufacturerID:        PredictionManufacturerID,
+		GameRoundID:           StakeRound(stakeID),
+		Metadata:              meta,
+	})
+}
+
+// VoidStake cancels a stake in a voided market, returning it to the player.
+func (s *PredictionSettlement) VoidStake(ctx context.Context, tx pgx.Tx, playerID, stakeID, stakeTxID uuid.UUID, stakeAmount int64) (*domain.CommandResult, error) {
 	return s.engine.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
 		PlayerID:              playerID,
 		Amount:                stakeAmount,
-		ExternalTransactionID: fmt.Sprintf("pred-void-%s", stakeTxID),
+		ExternalTransactionID: fmt.Sprintf("pred-void-%s", stakeID),
+		ManufacturerID:        PredictionManufacturerID,
 		TargetTransactionID:   stakeTxID,
 	})
 }
@@ -2,12 +2,16 @@ package walletserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
 	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/metrics"
 	"github.com/attaboy/platform/internal/provider"
 	"github.com/attaboy/platform/internal/repository"
 	"github.com/go-chi/chi/v5"
@@ -15,13 +19,19 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// NewRouter builds the wallet server chi.Router with all provider endpoints.
+// NewRouter builds the wallet server chi.Router, mounting each provider
+// adapter's callback routes under its prefix. Callbacks carrying a game
+// session token are resolved through sessions; providers gates each provider's
+// callbacks by its registry entry.
 func NewRouter(
 	pool *pgxpool.Pool,
 	eng *ledger.Engine,
 	txRepo repository.TransactionRepository,
-	bsAdapter *provider.BetSolutionsAdapter,
-	ppAdapter *provider.PragmaticAdapter,
+	sessions SessionResolver,
+	providers *ProviderRegistry,
+	adapters []provider.MountedAdapter,
+	latency *metrics.CallbackLatency,
+	breaker *guard.CircuitBreaker,
 	logger *slog.Logger,
 ) chi.Router {
 	r := chi.NewRouter()
@@ -40,143 +50,208 @@ func NewRouter(
 		w.Write([]byte(`{"status":"ok"}`))
 	})
 
-	// BetSolutions endpoints
-	r.Route("/betsolutions", func(r chi.Router) {
-		r.Post("/balance", WalletHandler(bsAdapter, provider.WalletActionBalance, pool, eng, txRepo, logger))
-		r.Post("/bet", WalletHandler(bsAdapter, provider.WalletActionBet, pool, eng, txRepo, logger))
-		r.Post("/win", WalletHandler(bsAdapter, provider.WalletActionWin, pool, eng, txRepo, logger))
-		r.Post("/rollback", WalletHandler(bsAdapter, provider.WalletActionRollback, pool, eng, txRepo, logger))
-	})
+	// Callback-to-commit latency histograms and SLO burn rate
+	r.Handle("/metrics", latency.Handler())
 
-	// Pragmatic Play endpoints
-	r.Route("/pragmatic", func(r chi.Router) {
-		r.Post("/", PragmaticHandler(ppAdapter, pool, eng, txRepo, logger))
-	})
+	// Circuit breaker state and recent error rate per provider
+	r.Get("/providers/health", HealthHandler(adapters, breaker, latency))
+
+	// Provider endpoints
+	for _, m := range adapters {
+		r.Route(m.Prefix, func(r chi.Router) {
+			for _, route := range m.Adapter.Routes() {
+				r.Post(route.Path, WalletHandler(m.Adapter, route.Action, pool, eng, txRepo, sessions, providers, latency, breaker, logger))
+			}
+		})
+		logger.Info("wallet provider mounted", "provider", m.Adapter.Name(), "prefix", m.Prefix)
+	}
 
 	return r
 }
 
-// WalletHandler creates an HTTP handler for BetSolutions wallet callbacks.
+// WalletHandler creates an HTTP handler for one provider callback route. The
+// action is fixed by the route, or empty when the adapter reads it from the body.
+// Every callback and its outcome is recorded in provider_callbacks. While the
+// provider's circuit is open, callbacks fail fast without touching the ledger.
+// A callback with a session token is rejected unless the token resolves to a
+// session of this provider matching the callback. A provider disabled in the
+// registry is refused outright, and actions outside its allowed list are
+// forbidden.
 func WalletHandler(
-	adapter *provider.BetSolutionsAdapter,
+	adapter provider.WalletAdapter,
 	action provider.WalletAction,
 	pool *pgxpool.Pool,
 	eng *ledger.Engine,
 	txRepo repository.TransactionRepository,
+	sessions SessionResolver,
+	providers *ProviderRegistry,
+	latency *metrics.CallbackLatency,
+	breaker *guard.CircuitBreaker,
 	logger *slog.Logger,
 ) http.HandlerFunc {
+	name := adapter.Name()
+	journal := &callbackJournal{pool: pool, logger: logger}
 	return func(w http.ResponseWriter, r *http.Request) {
-		req, body, err := adapter.ParseRequest(r)
+		received := time.Now()
+		entry := journal.begin(r, name)
+		respond := func(result provider.WalletResult) {
+			entry.result = result
+			adapter.Respond(w, result)
+			// Journal the callback even when it ran out its time budget.
+			journal.record(context.WithoutCancel(r.Context()), entry)
+		}
+
+		req, err := adapter.ParseRequest(r)
 		if err != nil {
-			adapter.RespondJSON(w, provider.BetSolutionsResponse{
-				StatusCode: 400,
-				Error:      "invalid request",
+			respond(provider.WalletResult{
+				Status:  provider.WalletStatusBadRequest,
+				Message: "invalid request",
 			})
 			return
 		}
 
-		if !adapter.VerifySignature(body, req.Hash) {
-			logger.Warn("betsolutions signature mismatch")
-			adapter.RespondJSON(w, provider.BetSolutionsResponse{
-				StatusCode: 401,
-				Error:      "invalid signature",
+		if enabled, reason := providers.Enabled(name); !enabled {
+			logger.Warn("wallet callback from disabled provider", "provider", name, "reason", reason)
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusError,
+				Message: "provider disabled",
+			})
+			return
+		}
+
+		valid := adapter.VerifySignature(req.Body, req.Signature)
+		entry.signatureValid = &valid
+		if !valid {
+			logger.Warn("wallet callback signature mismatch", "provider", name)
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusUnauthorized,
+				Message: "invalid signature",
 			})
 			return
 		}
 
 		cb, err := adapter.ToWalletCallback(req, action)
 		if err != nil {
-			adapter.RespondJSON(w, provider.BetSolutionsResponse{
-				StatusCode: 400,
-				Error:      err.Error(),
+			status := provider.WalletStatusBadRequest
+			if appErr, ok := err.(*domain.AppError); ok && appErr.Status == http.StatusUnauthorized {
+				status = provider.WalletStatusUnauthorized
+			}
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  status,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		if err := applySession(r.Context(), sessions, cb, name); err != nil {
+			status := provider.WalletStatusError
+			if appErr, ok := err.(*domain.AppError); ok && appErr.Status == http.StatusUnauthorized {
+				status = provider.WalletStatusUnauthorized
+			}
+			logger.Warn("wallet callback session rejected", "provider", name, "error", err)
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  status,
+				Message: err.Error(),
 			})
 			return
 		}
 
-		logger.Info("betsolutions callback",
+		entry.cb = cb
+
+		if !providers.Allows(name, cb.Action) {
+			logger.Warn("wallet callback action not allowed", "provider", name, "action", cb.Action)
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusForbidden,
+				Message: "action not allowed for provider",
+			})
+			return
+		}
+
+		logger.Info("wallet callback",
+			"provider", name,
 			"action", cb.Action,
 			"player_id", cb.PlayerID,
 			"amount", cb.Amount,
 			"tx_id", cb.TransactionID)
 
-		balance, _, err := DispatchWalletAction(r.Context(), pool, eng, txRepo, cb, "betsolutions", logger)
-		if err != nil {
-			logger.Error("betsolutions wallet action failed", "error", err, "action", cb.Action, "player_id", cb.PlayerID)
-			adapter.RespondJSON(w, provider.BetSolutionsResponse{
-				StatusCode: 500,
-				Error:      "internal error",
+		if check := breaker.Check(r.Context(), name); !check.Allowed {
+			logger.Warn("wallet provider circuit open", "provider", name, "reason", check.Reason)
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusError,
+				Message: "provider temporarily unavailable",
 			})
 			return
 		}
 
-		adapter.RespondJSON(w, provider.BetSolutionsResponse{
-			StatusCode: 200,
-			Balance:    balance,
-		})
-	}
-}
-
-// PragmaticHandler creates an HTTP handler for Pragmatic Play wallet callbacks.
-func PragmaticHandler(
-	adapter *provider.PragmaticAdapter,
-	pool *pgxpool.Pool,
-	eng *ledger.Engine,
-	txRepo repository.TransactionRepository,
-	logger *slog.Logger,
-) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		req, body, err := adapter.ParseRequest(r)
-		if err != nil {
-			adapter.RespondJSON(w, provider.PragmaticResponse{
-				Error:   1,
-				Message: "invalid request",
+		balance, bonusBalance, err := DispatchWalletAction(r.Context(), pool, eng, txRepo, cb, name, logger)
+		// Declines such as insufficient funds or a blocked player are answers,
+		// not failures; only the rest count against the provider's circuit.
+		failure := dispatchFailure(err)
+		latency.Observe(name, string(cb.Action), time.Since(received), failure)
+		if failure != nil {
+			breaker.RecordFailure(name)
+		} else {
+			breaker.RecordSuccess(name)
+		}
+		if appErr, ok := err.(*domain.AppError); ok && appErr.Status == http.StatusForbidden {
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusForbidden,
+				Message: appErr.Message,
 			})
 			return
 		}
-
-		if !adapter.VerifySignature(body, req.ProvidedHash) {
-			logger.Warn("pragmatic signature mismatch")
-			adapter.RespondJSON(w, provider.PragmaticResponse{
-				Error:   1,
-				Message: "invalid signature",
+		if appErr, ok := err.(*domain.AppError); ok && appErr.Status == http.StatusConflict {
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusBadRequest,
+				Message: appErr.Message,
 			})
 			return
 		}
-
-		cb, err := adapter.ToWalletCallback(req)
-		if err != nil {
-			adapter.RespondJSON(w, provider.PragmaticResponse{
-				Error:   1,
-				Message: err.Error(),
+		if appErr, ok := err.(*domain.AppError); ok && appErr.Code == "INSUFFICIENT_BALANCE" {
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusInsufficientFunds,
+				Message: appErr.Message,
 			})
 			return
 		}
-
-		logger.Info("pragmatic callback",
-			"action", cb.Action,
-			"player_id", cb.PlayerID,
-			"amount", cb.Amount,
-			"tx_id", cb.TransactionID)
-
-		balance, bonusBalance, err := DispatchWalletAction(r.Context(), pool, eng, txRepo, cb, "pragmatic", logger)
 		if err != nil {
-			logger.Error("pragmatic wallet action failed", "error", err, "action", cb.Action, "player_id", cb.PlayerID)
-			adapter.RespondJSON(w, provider.PragmaticResponse{
-				Error:   1,
+			logger.Error("wallet action failed", "error", err, "provider", name, "action", cb.Action, "player_id", cb.PlayerID)
+			respond(provider.WalletResult{
+				Request: req,
+				Status:  provider.WalletStatusError,
 				Message: "internal error",
 			})
 			return
 		}
 
-		adapter.RespondJSON(w, provider.PragmaticResponse{
-			Currency: cb.Currency,
-			Cash:     provider.FormatCents(balance),
-			Bonus:    provider.FormatCents(bonusBalance),
-			Error:    0,
+		respond(provider.WalletResult{
+			Request:      req,
+			Status:       provider.WalletStatusOK,
+			Currency:     cb.Currency,
+			Balance:      balance,
+			BonusBalance: bonusBalance,
 		})
 	}
 }
 
+// dispatchFailure returns err unless it is a client-side AppError.
+func dispatchFailure(err error) error {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) && appErr.Status < http.StatusInternalServerError {
+		return nil
+	}
+	return err
+}
+
 // DispatchWalletAction executes the appropriate ledger command for a wallet callback.
 func DispatchWalletAction(
 	ctx context.Context,
@@ -200,6 +275,10 @@ func DispatchWalletAction(
 		balance, bonusBalance, err = handleBet(ctx, tx, eng, cb, manufacturerID)
 	case provider.WalletActionWin:
 		balance, bonusBalance, err = handleWin(ctx, tx, eng, cb, manufacturerID)
+	case provider.WalletActionReserve:
+		balance, bonusBalance, err = handleReserve(ctx, tx, eng, cb, manufacturerID)
+	case provider.WalletActionRelease:
+		balance, bonusBalance, err = handleRelease(ctx, tx, eng, cb, manufacturerID)
 	case provider.WalletActionRollback:
 		balance, bonusBalance, err = handleRollback(ctx, tx, eng, txRepo, cb, manufacturerID, logger)
 	default:
@@ -217,8 +296,10 @@ func DispatchWalletAction(
 	return balance, bonusBalance, nil
 }
 
+// handleBalance reports the wallet matching the callback currency; an empty or
+// base currency reads the player's primary balances.
 func handleBalance(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.WalletCallback) (int64, int64, error) {
-	player, err := eng.LockPlayerForUpdate(ctx, tx, cb.PlayerID)
+	player, err := eng.LockWalletForUpdate(ctx, tx, cb.PlayerID, cb.Currency)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -226,6 +307,15 @@ func handleBalance(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provi
 }
 
 func handleBet(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.WalletCallback, manufacturerID string) (int64, int64, error) {
+	// Wins and rollbacks still settle for suspended players or players at
+	// their loss limit; new stakes do not.
+	if err := guard.CheckAccountActive(ctx, tx, cb.PlayerID); err != nil {
+		return 0, 0, err
+	}
+	if err := guard.CheckPlayerLimits(ctx, tx, cb.PlayerID, domain.LimitLoss, cb.Amount); err != nil {
+		return 0, 0, err
+	}
+
 	result, err := eng.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
 		PlayerID:              cb.PlayerID,
 		Amount:                cb.Amount,
@@ -233,6 +323,8 @@ func handleBet(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.
 		ManufacturerID:        manufacturerID,
 		SubTransactionID:      "1",
 		GameRoundID:           cb.RoundID,
+		GameID:                cb.GameID,
+		Currency:              cb.Currency,
 	})
 	if err != nil {
 		return 0, 0, err
@@ -240,7 +332,26 @@ func handleBet(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.
 	return result.Player.Balance, result.Player.BonusBalance, nil
 }
 
+// handleWin credits a win. A zero amount is the provider reporting a loss,
+// which closes the round without posting.
 func handleWin(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.WalletCallback, manufacturerID string) (int64, int64, error) {
+	if cb.Amount == 0 && cb.RoundID != "" {
+		result, err := eng.ExecuteCloseRound(ctx, tx, domain.CloseRoundParams{
+			PlayerID:       cb.PlayerID,
+			ManufacturerID: manufacturerID,
+			GameRoundID:    cb.RoundID,
+			Currency:       cb.Currency,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		return result.Player.Balance, result.Player.BonusBalance, nil
+	}
+
+	winType := cb.WinType
+	if winType == "" {
+		winType = domain.CasinoWinNormal
+	}
 	result, err := eng.ExecuteCreditWin(ctx, tx, domain.CreditWinParams{
 		PlayerID:              cb.PlayerID,
 		Amount:                cb.Amount,
@@ -248,7 +359,49 @@ func handleWin(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.
 		ManufacturerID:        manufacturerID,
 		SubTransactionID:      "1",
 		GameRoundID:           cb.RoundID,
-		WinType:               domain.CasinoWinNormal,
+		WinType:               winType,
+		Currency:              cb.Currency,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return result.Player.Balance, result.Player.BonusBalance, nil
+}
+
+// handleReserve holds the stake in reserved_balance until the provider
+// releases or rolls it back.
+func handleReserve(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.WalletCallback, manufacturerID string) (int64, int64, error) {
+	if err := guard.CheckAccountActive(ctx, tx, cb.PlayerID); err != nil {
+		return 0, 0, err
+	}
+	if err := guard.CheckPlayerLimits(ctx, tx, cb.PlayerID, domain.LimitLoss, cb.Amount); err != nil {
+		return 0, 0, err
+	}
+
+	result, err := eng.ExecuteReserve(ctx, tx, domain.ReserveParams{
+		PlayerID:              cb.PlayerID,
+		Amount:                cb.Amount,
+		ExternalTransactionID: cb.TransactionID,
+		ManufacturerID:        manufacturerID,
+		GameRoundID:           cb.RoundID,
+		Currency:              cb.Currency,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return result.Player.Balance, result.Player.BonusBalance, nil
+}
+
+// handleRelease settles a reservation; cb.Amount is the win, possibly zero.
+func handleRelease(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.WalletCallback, manufacturerID string) (int64, int64, error) {
+	result, err := eng.ExecuteRelease(ctx, tx, domain.ReleaseParams{
+		PlayerID:              cb.PlayerID,
+		ReservationID:         cb.ReferenceID,
+		WinAmount:             cb.Amount,
+		ExternalTransactionID: cb.TransactionID,
+		ManufacturerID:        manufacturerID,
+		GameRoundID:           cb.RoundID,
+		Currency:              cb.Currency,
 	})
 	if err != nil {
 		return 0, 0, err
@@ -256,6 +409,13 @@ func handleWin(ctx context.Context, tx pgx.Tx, eng *ledger.Engine, cb *provider.
 	return result.Player.Balance, result.Player.BonusBalance, nil
 }
 
+// handleRollback cancels what a provider rollback refers to: every
+// sub-transaction under the callback's transaction id, or when the provider
+// sends only a round id, every transaction of that round. Bets, wins and
+// reservations are reversed by the cancellation type CancellationTypeMap
+// gives them, newest first so a win comes off before its stake is returned.
+// Transactions already cancelled, or of a type that cannot be cancelled,
+// are skipped. A released reservation is reversed through its stake leg.
 func handleRollback(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -265,39 +425,116 @@ func handleRollback(
 	manufacturerID string,
 	logger *slog.Logger,
 ) (int64, int64, error) {
-	original, err := txRepo.FindExisting(ctx, tx, domain.IdempotencyKey{
-		PlayerID:              cb.PlayerID,
-		ManufacturerID:        manufacturerID,
-		ExternalTransactionID: cb.TransactionID,
-		SubTransactionID:      "1",
-	})
+	originals, err := rollbackTargets(ctx, tx, txRepo, cb, manufacturerID)
 	if err != nil {
-		return 0, 0, fmt.Errorf("find original transaction: %w", err)
+		return 0, 0, err
 	}
 
-	if original == nil {
+	if len(originals) == 0 {
 		logger.Warn("rollback: original transaction not found, returning current balance",
 			"player_id", cb.PlayerID,
 			"external_tx_id", cb.TransactionID,
+			"round_id", cb.RoundID,
 			"manufacturer", manufacturerID)
-		player, lockErr := eng.LockPlayerForUpdate(ctx, tx, cb.PlayerID)
+		player, lockErr := eng.LockWalletForUpdate(ctx, tx, cb.PlayerID, cb.Currency)
 		if lockErr != nil {
 			return 0, 0, lockErr
 		}
 		return player.Balance, player.BonusBalance, nil
 	}
 
-	rollbackExtID := fmt.Sprintf("rollback_%s", cb.TransactionID)
-	result, err := eng.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
-		PlayerID:              cb.PlayerID,
-		Amount:                original.Amount,
-		ExternalTransactionID: rollbackExtID,
-		ManufacturerID:        manufacturerID,
-		SubTransactionID:      "1",
-		TargetTransactionID:   original.ID,
-	})
-	if err != nil {
-		return 0, 0, err
+	var result *domain.CommandResult
+	for i := len(originals) - 1; i >= 0; i-- {
+		original := originals[i]
+		if original.Type == domain.TxReserve {
+			// A released reservation's stake already left reserved_balance as a
+			// bet leg; reverse that leg instead of the reservation.
+			released, err := txRepo.FindByTarget(ctx, tx, original.ID, domain.TxBet)
+			if err != nil {
+				return 0, 0, fmt.Errorf("find released stake: %w", err)
+			}
+			if released != nil {
+				original = *released
+			}
+		}
+		cancelType, ok := domain.CancellationTypeMap[original.Type]
+		if !ok {
+			logger.Warn("rollback: transaction type cannot be cancelled",
+				"transaction_id", original.ID, "type", original.Type, "manufacturer", manufacturerID)
+			continue
+		}
+		cancelled, err := txRepo.FindByTarget(ctx, tx, original.ID, cancelType)
+		if err != nil {
+			return 0, 0, fmt.Errorf("find cancellation: %w", err)
+		}
+		if cancelled != nil {
+			continue
+		}
+
+		externalID := ""
+		if original.ExternalTransactionID != nil {
+			externalID = *original.ExternalTransactionID
+		}
+		subID := "1"
+		if original.SubTransactionID != nil {
+			subID = *original.SubTransactionID
+		}
+		result, err = eng.ExecuteCancelTransaction(ctx, tx, domain.CancelTransactionParams{
+			PlayerID:              cb.PlayerID,
+			Amount:                original.Amount,
+			ExternalTransactionID: fmt.Sprintf("rollback_%s", externalID),
+			ManufacturerID:        manufacturerID,
+			SubTransactionID:      subID,
+			TargetTransactionID:   original.ID,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if result == nil {
+		player, err := eng.LockWalletForUpdate(ctx, tx, cb.PlayerID, cb.Currency)
+		if err != nil {
+			return 0, 0, err
+		}
+		return player.Balance, player.BonusBalance, nil
 	}
 	return result.Player.Balance, result.Player.BonusBalance, nil
 }
+
+// rollbackTargets returns the transactions a rollback refers to, oldest first.
+// Earlier rollbacks are excluded so a round rollback does not try to cancel
+// its own cancellations.
+func rollbackTargets(
+	ctx context.Context,
+	tx pgx.Tx,
+	txRepo repository.TransactionRepository,
+	cb *provider.WalletCallback,
+	manufacturerID string,
+) ([]domain.Transaction, error) {
+	var txs []domain.Transaction
+	var err error
+	switch {
+	case cb.TransactionID != "":
+		txs, err = txRepo.ListByExternalID(ctx, tx, cb.PlayerID, manufacturerID, cb.TransactionID)
+	case cb.RoundID != "":
+		txs, err = txRepo.ListByGameRound(ctx, tx, cb.RoundID)
+	default:
+		return nil, domain.ErrValidation("rollback needs a transaction or round id")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find original transactions: %w", err)
+	}
+
+	out := txs[:0]
+	for _, t := range txs {
+		if t.PlayerID != cb.PlayerID || t.ManufacturerID == nil || *t.ManufacturerID != manufacturerID {
+			continue
+		}
+		if t.TargetTransactionID != nil {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,63 @@
+package walletserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/provider"
+)
+
+// ProviderHealth is one provider's entry in /providers/health.
+type ProviderHealth struct {
+	Name          string     `json:"name"`
+	Prefix        string     `json:"prefix"`
+	Circuit       string     `json:"circuit"` // closed, open, half_open
+	Failures      int        `json:"consecutive_failures"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	ErrorRate5m   float64    `json:"error_rate_5m"`
+	ErrorRate1h   float64    `json:"error_rate_1h"`
+	SLOAlerting   bool       `json:"slo_alerting"`
+}
+
+// HealthReport is the /providers/health response. Status is degraded when
+// any provider's circuit is not closed.
+type HealthReport struct {
+	Status    string           `json:"status"` // ok, degraded
+	Providers []ProviderHealth `json:"providers"`
+}
+
+// HealthHandler reports circuit breaker state and recent callback error rates
+// for every mounted provider. It always answers 200 so one provider tripping
+// does not take the wallet server out of a load balancer.
+func HealthHandler(adapters []provider.MountedAdapter, breaker *guard.CircuitBreaker, latency *metrics.CallbackLatency) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := HealthReport{Status: "ok", Providers: make([]ProviderHealth, 0, len(adapters))}
+		for _, m := range adapters {
+			name := m.Adapter.Name()
+			status := breaker.Status(name)
+			h := ProviderHealth{
+				Name:        name,
+				Prefix:      m.Prefix,
+				Circuit:     status.State.String(),
+				Failures:    status.Failures,
+				ErrorRate5m: latency.ErrorRate(name, 5*time.Minute),
+				ErrorRate1h: latency.ErrorRate(name, time.Hour),
+				SLOAlerting: latency.Alerting(name),
+			}
+			if !status.LastFailure.IsZero() {
+				h.LastFailureAt = &status.LastFailure
+			}
+			if status.State != guard.CircuitClosed {
+				report.Status = "degraded"
+			}
+			report.Providers = append(report.Providers, h)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(report)
+	}
+}
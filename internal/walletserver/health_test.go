@@ -0,0 +1,62 @@
+package walletserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_ReportsTrippedProvider(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	adapters := []provider.MountedAdapter{
+		{Prefix: "/relax", Adapter: provider.NewRelaxAdapter("u:p", logger)},
+		{Prefix: "/netent", Adapter: provider.NewNetEntAdapter("secret", logger)},
+	}
+	breaker := guard.NewCircuitBreaker(2, time.Minute)
+	latency := metrics.NewCallbackLatency(metrics.DefaultSLOConfig(), logger)
+
+	breaker.Check(context.Background(), "netent")
+	for i := 0; i < 2; i++ {
+		breaker.RecordFailure("netent")
+		latency.Observe("netent", "reserve", time.Millisecond, errors.New("commit failed"))
+	}
+	latency.Observe("netent", "reserve", time.Millisecond, nil)
+	latency.Observe("netent", "reserve", time.Millisecond, nil)
+
+	rec := httptest.NewRecorder()
+	HealthHandler(adapters, breaker, latency)(rec, httptest.NewRequest(http.MethodGet, "/providers/health", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var report HealthReport
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	assert.Equal(t, "degraded", report.Status)
+	require.Len(t, report.Providers, 2)
+	assert.Equal(t, "closed", report.Providers[0].Circuit)
+	assert.Zero(t, report.Providers[0].ErrorRate5m)
+	assert.Equal(t, "open", report.Providers[1].Circuit)
+	assert.Equal(t, 2, report.Providers[1].Failures)
+	assert.NotNil(t, report.Providers[1].LastFailureAt)
+	assert.InDelta(t, 0.5, report.Providers[1].ErrorRate5m, 0.0001)
+}
+
+func TestDispatchFailure_IgnoresDeclines(t *testing.T) {
+	assert.NoError(t, dispatchFailure(nil))
+	assert.NoError(t, dispatchFailure(domain.ErrInsufficientBalance()))
+	assert.NoError(t, dispatchFailure(fmt.Errorf("credit win: %w", domain.ErrNotFound("player", "x"))))
+	assert.Error(t, dispatchFailure(errors.New("commit transaction: connection reset")))
+	assert.Error(t, dispatchFailure(domain.ErrInternal("lock wallet", errors.New("timeout"))))
+}
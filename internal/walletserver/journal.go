@@ -0,0 +1,109 @@
+package walletserver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// journalBodyLimit caps how much of a callback body is kept in the journal.
+const journalBodyLimit = 64 << 10
+
+// secretFieldPattern matches JSON string fields that carry credentials, such
+// as NetEnt's callerPassword, so they are never written to the journal.
+var secretFieldPattern = regexp.MustCompile(`("[A-Za-z_]*(?i:password|secret)[A-Za-z_]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// callbackEntry is one provider_callbacks row, filled in as the callback is
+// handled.
+type callbackEntry struct {
+	provider       string
+	path           string
+	body           *bytes.Buffer
+	signatureValid *bool
+	cb             *provider.WalletCallback
+	result         provider.WalletResult
+	received       time.Time
+}
+
+// callbackJournal records every wallet callback and its outcome. Writes are
+// best effort: a journal failure is logged and never fails the callback.
+type callbackJournal struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// begin starts an entry and tees the request body so it is captured even when
+// the adapter cannot parse it.
+func (j *callbackJournal) begin(r *http.Request, providerName string) *callbackEntry {
+	e := &callbackEntry{provider: providerName, path: r.URL.Path, body: &bytes.Buffer{}, received: time.Now()}
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(r.Body, &limitedBuffer{buf: e.body, limit: journalBodyLimit}), r.Body}
+	return e
+}
+
+func (j *callbackJournal) record(ctx context.Context, e *callbackEntry) {
+	var action, transactionID, referenceID, roundID, currency *string
+	var playerID any
+	var amount *int64
+	if e.cb != nil {
+		a := string(e.cb.Action)
+		action = &a
+		playerID = e.cb.PlayerID
+		amount = &e.cb.Amount
+		transactionID = nonEmpty(e.cb.TransactionID)
+		referenceID = nonEmpty(e.cb.ReferenceID)
+		roundID = nonEmpty(e.cb.RoundID)
+		currency = nonEmpty(e.cb.Currency)
+	}
+	var balance, bonusBalance *int64
+	if e.result.Status == provider.WalletStatusOK {
+		balance, bonusBalance = &e.result.Balance, &e.result.BonusBalance
+	}
+
+	body := secretFieldPattern.ReplaceAllString(e.body.String(), `$1"[redacted]"`)
+	body = strings.ReplaceAll(strings.ToValidUTF8(body, "�"), "\x00", "")
+
+	// The provider may already have hung up; the journal row is still wanted.
+	_, err := j.pool.Exec(context.WithoutCancel(ctx), `
+		INSERT INTO provider_callbacks (provider, path, action, player_id, transaction_id, reference_id,
+			round_id, amount, currency, body, signature_valid, status, message, balance, bonus_balance,
+			duration_ms, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
+		e.provider, e.path, action, playerID, transactionID, referenceID,
+		roundID, amount, currency, body, e.signatureValid, e.result.Status.String(), nonEmpty(e.result.Message),
+		balance, bonusBalance, time.Since(e.received).Milliseconds(), e.received)
+	if err != nil {
+		j.logger.Error("record provider callback", "error", err, "provider", e.provider, "path", e.path)
+	}
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest without failing the writer.
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
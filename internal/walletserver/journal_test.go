@@ -0,0 +1,26 @@
+package walletserver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretFieldPattern_RedactsCredentials(t *testing.T) {
+	body := `{"callerId":"attaboy","callerPassword":"s3cr\"et","sessionId":"abc","apiSecret":"x"}`
+	got := secretFieldPattern.ReplaceAllString(body, `$1"[redacted]"`)
+	assert.Equal(t, `{"callerId":"attaboy","callerPassword":"[redacted]","sessionId":"abc","apiSecret":"[redacted]"}`, got)
+}
+
+func TestLimitedBuffer_KeepsPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	lb := &limitedBuffer{buf: &buf, limit: 5}
+	n, err := lb.Write([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	n, _ = lb.Write([]byte("defgh"))
+	assert.Equal(t, 5, n)
+	lb.Write([]byte("ij"))
+	assert.Equal(t, "abcde", buf.String())
+}
@@ -0,0 +1,156 @@
+package walletserver
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// manufacturersChannel is the NOTIFY channel raised by the manufacturers
+// change trigger.
+const manufacturersChannel = "manufacturers_changed"
+
+// listenerRetryDelay is how long to wait before re-establishing a dropped
+// LISTEN connection. The poll ticker keeps the registry fresh meanwhile.
+const listenerRetryDelay = 5 * time.Second
+
+// ManufacturerSource lists the registered wallet providers.
+type ManufacturerSource interface {
+	List(ctx context.Context) ([]domain.Manufacturer, error)
+}
+
+// ProviderRegistry holds the enablement and allowed actions of the registered
+// wallet providers, swapped whole on every reload so callbacks never see a
+// half-applied change. Providers not in the registry, such as those mounted
+// from the environment, are always allowed. A nil registry allows everything.
+type ProviderRegistry struct {
+	source ManufacturerSource
+	state  atomic.Pointer[map[string]domain.Manufacturer]
+	logger *slog.Logger
+}
+
+// NewProviderRegistry creates an empty ProviderRegistry; call Reload to fill it.
+func NewProviderRegistry(source ManufacturerSource, logger *slog.Logger) *ProviderRegistry {
+	r := &ProviderRegistry{source: source, logger: logger}
+	r.state.Store(&map[string]domain.Manufacturer{})
+	return r
+}
+
+// Reload replaces the registry with the manufacturers currently stored.
+func (r *ProviderRegistry) Reload(ctx context.Context) error {
+	manufacturers, err := r.source.List(ctx)
+	if err != nil {
+		return err
+	}
+	r.Set(manufacturers)
+	return nil
+}
+
+// Set replaces the registry with manufacturers.
+func (r *ProviderRegistry) Set(manufacturers []domain.Manufacturer) {
+	next := make(map[string]domain.Manufacturer, len(manufacturers))
+	for _, m := range manufacturers {
+		next[m.ID] = m
+	}
+	r.state.Store(&next)
+}
+
+// Enabled reports whether a provider's callbacks are accepted, returning the
+// reason it was disabled otherwise.
+func (r *ProviderRegistry) Enabled(name string) (bool, string) {
+	if r == nil {
+		return true, ""
+	}
+	m, ok := (*r.state.Load())[name]
+	if !ok || m.Enabled {
+		return true, ""
+	}
+	if m.DisabledReason != nil {
+		return false, *m.DisabledReason
+	}
+	return false, ""
+}
+
+// Allows reports whether a provider may send a callback action.
+func (r *ProviderRegistry) Allows(name string, action provider.WalletAction) bool {
+	if r == nil {
+		return true
+	}
+	m, ok := (*r.state.Load())[name]
+	return !ok || m.Allows(string(action))
+}
+
+// Watch keeps the registry current until ctx is done: it reloads on every
+// change notification and, as a fallback, every interval.
+func (r *ProviderRegistry) Watch(ctx context.Context, pool *pgxpool.Pool, interval time.Duration) {
+	wake := make(chan struct{}, 1)
+	go r.listen(ctx, pool, wake)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wake:
+		}
+		if err := r.Reload(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("reload wallet providers failed", "error", err)
+		}
+	}
+}
+
+// listen holds a dedicated connection on LISTEN manufacturers_changed and
+// signals wake for every notification, reconnecting when it drops.
+func (r *ProviderRegistry) listen(ctx context.Context, pool *pgxpool.Pool, wake chan<- struct{}) {
+	for {
+		err := r.listenOnce(ctx, pool, wake)
+		if ctx.Err() != nil {
+			return
+		}
+		r.logger.Warn("wallet provider listener disconnected, falling back to polling", "error", err, "retry_in", listenerRetryDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenerRetryDelay):
+		}
+	}
+}
+
+func (r *ProviderRegistry) listenOnce(ctx context.Context, pool *pgxpool.Pool, wake chan<- struct{}) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A connection left in LISTEN state must not return to the pool.
+	listenConn := conn.Hijack()
+	defer listenConn.Close(context.Background())
+
+	if _, err := listenConn.Exec(ctx, "LISTEN "+manufacturersChannel); err != nil {
+		return err
+	}
+
+	// Catch up on anything changed before LISTEN took effect.
+	wakeReload(wake)
+
+	for {
+		if _, err := listenConn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		wakeReload(wake)
+	}
+}
+
+func wakeReload(wake chan<- struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,50 @@
+package walletserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManufacturers []domain.Manufacturer
+
+func (f *fakeManufacturers) List(context.Context) ([]domain.Manufacturer, error) {
+	return *f, nil
+}
+
+func TestProviderRegistry(t *testing.T) {
+	reason := "double-crediting wins"
+	source := &fakeManufacturers{
+		{ID: "betsolutions", Enabled: true, AllowedActions: []string{"balance", "win", "rollback"}},
+		{ID: "pragmatic", Enabled: false, DisabledReason: &reason},
+	}
+	registry := NewProviderRegistry(source, nil)
+	require.NoError(t, registry.Reload(context.Background()))
+
+	enabled, _ := registry.Enabled("betsolutions")
+	assert.True(t, enabled)
+	assert.True(t, registry.Allows("betsolutions", provider.WalletActionWin))
+	assert.False(t, registry.Allows("betsolutions", provider.WalletActionBet))
+
+	enabled, why := registry.Enabled("pragmatic")
+	assert.False(t, enabled)
+	assert.Equal(t, reason, why)
+	assert.True(t, registry.Allows("pragmatic", provider.WalletActionBet), "no action list allows all")
+
+	enabled, _ = registry.Enabled("evolution")
+	assert.True(t, enabled, "unregistered providers are allowed")
+
+	(*source)[1].Enabled = true
+	require.NoError(t, registry.Reload(context.Background()))
+	enabled, _ = registry.Enabled("pragmatic")
+	assert.True(t, enabled)
+
+	var none *ProviderRegistry
+	enabled, _ = none.Enabled("pragmatic")
+	assert.True(t, enabled)
+	assert.True(t, none.Allows("pragmatic", provider.WalletActionBet))
+}
@@ -0,0 +1,54 @@
+package walletserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+)
+
+// SessionResolver looks up the game session a launch token was issued for.
+// An expired session is returned together with an error.
+type SessionResolver interface {
+	Resolve(ctx context.Context, token string) (*domain.GameSessionToken, error)
+}
+
+// applySession authenticates a callback carrying a session token and fills in
+// the player and currency from the session. The token must belong to the
+// calling provider and agree with any player and currency in the callback.
+// Balance checks and new stakes need a live session; wins, releases and
+// rollbacks accept an expired one so rounds settle after the player has left.
+func applySession(ctx context.Context, sessions SessionResolver, cb *provider.WalletCallback, providerName string) error {
+	if cb.SessionToken == "" {
+		return nil
+	}
+	session, err := sessions.Resolve(ctx, cb.SessionToken)
+	if session == nil {
+		return err
+	}
+	if err != nil && needsLiveSession(cb.Action) {
+		return err
+	}
+	if !session.IssuedTo(providerName) {
+		return domain.ErrUnauthorized("session token was issued for another provider")
+	}
+	if cb.PlayerID != uuid.Nil && cb.PlayerID != session.PlayerID {
+		return domain.ErrUnauthorized("session token was issued to another player")
+	}
+	if cb.Currency != "" && !strings.EqualFold(cb.Currency, session.Currency) {
+		return domain.ErrUnauthorized("currency does not match the game session")
+	}
+	cb.PlayerID = session.PlayerID
+	cb.Currency = session.Currency
+	return nil
+}
+
+func needsLiveSession(action provider.WalletAction) bool {
+	switch action {
+	case provider.WalletActionBalance, provider.WalletActionBet, provider.WalletActionReserve:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package walletserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessions resolves one token, optionally as expired.
+type fakeSessions struct {
+	token   string
+	session domain.GameSessionToken
+	expired bool
+}
+
+func (f *fakeSessions) Resolve(_ context.Context, token string) (*domain.GameSessionToken, error) {
+	if token != f.token {
+		return nil, domain.ErrUnauthorized("invalid session token")
+	}
+	s := f.session
+	if f.expired {
+		return &s, domain.ErrUnauthorized("session expired")
+	}
+	return &s, nil
+}
+
+func TestApplySession(t *testing.T) {
+	playerID := uuid.New()
+	name := "betsolutions"
+	sessions := &fakeSessions{token: "tok", session: domain.GameSessionToken{
+		PlayerID: playerID, ManufacturerID: "BS", Provider: &name, Currency: "EUR",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}}
+	ctx := context.Background()
+
+	t.Run("fills player and currency", func(t *testing.T) {
+		cb := &provider.WalletCallback{Action: provider.WalletActionBet, SessionToken: "tok"}
+		require.NoError(t, applySession(ctx, sessions, cb, "betsolutions"))
+		assert.Equal(t, playerID, cb.PlayerID)
+		assert.Equal(t, "EUR", cb.Currency)
+	})
+
+	t.Run("matches manufacturer id", func(t *testing.T) {
+		cb := &provider.WalletCallback{Action: provider.WalletActionBalance, SessionToken: "tok", Currency: "eur"}
+		require.NoError(t, applySession(ctx, sessions, cb, "BS"))
+		assert.Equal(t, "EUR", cb.Currency)
+	})
+
+	t.Run("no token leaves callback alone", func(t *testing.T) {
+		other := uuid.New()
+		cb := &provider.WalletCallback{Action: provider.WalletActionBet, PlayerID: other}
+		require.NoError(t, applySession(ctx, sessions, cb, "betsolutions"))
+		assert.Equal(t, other, cb.PlayerID)
+	})
+
+	rejected := []struct {
+		name     string
+		cb       provider.WalletCallback
+		provider string
+	}{
+		{"unknown token", provider.WalletCallback{Action: provider.WalletActionWin, SessionToken: "nope"}, "betsolutions"},
+		{"other provider", provider.WalletCallback{Action: provider.WalletActionBet, SessionToken: "tok"}, "pragmatic"},
+		{"other player", provider.WalletCallback{Action: provider.WalletActionBet, SessionToken: "tok", PlayerID: uuid.New()}, "betsolutions"},
+		{"other currency", provider.WalletCallback{Action: provider.WalletActionBet, SessionToken: "tok", Currency: "USD"}, "betsolutions"},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			cb := tc.cb
+			err := applySession(ctx, sessions, &cb, tc.provider)
+			var appErr *domain.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, 401, appErr.Status)
+		})
+	}
+}
+
+func TestApplySession_Expired(t *testing.T) {
+	playerID := uuid.New()
+	sessions := &fakeSessions{token: "tok", expired: true, session: domain.GameSessionToken{
+		PlayerID: playerID, ManufacturerID: "BS", Currency: "EUR",
+	}}
+
+	for _, action := range []provider.WalletAction{provider.WalletActionBalance, provider.WalletActionBet, provider.WalletActionReserve} {
+		cb := &provider.WalletCallback{Action: action, SessionToken: "tok"}
+		assert.Error(t, applySession(context.Background(), sessions, cb, "BS"), action)
+	}
+	for _, action := range []provider.WalletAction{provider.WalletActionWin, provider.WalletActionRelease, provider.WalletActionRollback} {
+		cb := &provider.WalletCallback{Action: action, SessionToken: "tok"}
+		require.NoError(t, applySession(context.Background(), sessions, cb, "BS"), action)
+		assert.Equal(t, playerID, cb.PlayerID)
+	}
+}
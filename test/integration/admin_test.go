@@ -3,11 +3,17 @@
 package integration
 
 import (
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
 	"github.com/attaboy/platform/test/integration/testutil"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -129,7 +135,7 @@ func TestAdminPlayers_UpdateStatus(t *testing.T) {
 	adminToken := env.AdminToken("superadmin")
 
 	resp := env.AuthPATCH("/admin/players/"+playerID.String()+"/status",
-		map[string]string{"account_status": "suspended"}, adminToken)
+		map[string]string{"account_status": "suspended", "reason": "chargeback investigation"}, adminToken)
 	defer resp.Body.Close()
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
@@ -139,6 +145,64 @@ func TestAdminPlayers_UpdateStatus(t *testing.T) {
 	env.Pool.QueryRow(t.Context(),
 		"SELECT account_status FROM player_profiles WHERE player_id = $1", playerID).Scan(&status)
 	assert.Equal(t, "suspended", status)
+
+	var historyCount int
+	env.Pool.QueryRow(t.Context(),
+		"SELECT COUNT(*) FROM player_status_history WHERE player_id = $1 AND to_status = 'suspended' AND state = 'applied'",
+		playerID).Scan(&historyCount)
+	assert.Equal(t, 1, historyCount)
+}
+
+func TestAdminPlayers_SuspendUntilBlocksWallet(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("suspendwin@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("superadmin")
+
+	until := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	resp := env.AuthPOST("/admin/players/"+playerID.String()+"/suspend",
+		map[string]interface{}{"until": until, "reason": "cooling period"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthPOST("/payments/deposit", map[string]interface{}{
+		"amount": 1000, "currency": "EUR",
+		"success_url": "http://example.com/ok", "cancel_url": "http://example.com/no",
+	}, token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	var body struct {
+		Code    string `json:"code"`
+		Details struct {
+			ReinstateAt time.Time `json:"reinstate_at"`
+		} `json:"details"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ACCOUNT_SUSPENDED", body.Code)
+	assert.True(t, until.Equal(body.Details.ReinstateAt))
+
+	var scheduled int
+	env.Pool.QueryRow(t.Context(),
+		"SELECT COUNT(*) FROM player_status_history WHERE player_id = $1 AND to_status = 'active' AND state = 'scheduled'",
+		playerID).Scan(&scheduled)
+	assert.Equal(t, 1, scheduled)
+}
+
+func TestAdminPlayers_UpdateStatusInvalidTransition(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("statusclosed@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("superadmin")
+
+	resp := env.AuthPATCH("/admin/players/"+playerID.String()+"/status",
+		map[string]string{"account_status": "closed", "reason": "player request"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthPATCH("/admin/players/"+playerID.String()+"/status",
+		map[string]string{"account_status": "active", "reason": "reopen"}, adminToken)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
 }
 
 // ─── Bonus CRUD Tests (6) ─────────────────────────────────────────────────
@@ -374,7 +438,7 @@ func TestAdminSportsbook_RequiresSport(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
 
-// ─── Reports Tests (4) ────────────────────────────────────────────────────
+// ─── Reports Tests (5) ────────────────────────────────────────────────────
 
 func TestAdminReports_DashboardEmpty(t *testing.T) {
 	env := testutil.NewTestEnv(t)
@@ -396,6 +460,7 @@ func TestAdminReports_DashboardWithData(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	env.RegisterPlayer("report1@test.com", "securepass123", "EUR")
 	env.RegisterPlayer("report2@test.com", "securepass123", "EUR")
+	env.RunProjections()
 	adminToken := env.AdminToken("superadmin")
 
 	resp := env.AuthGET("/admin/reports/dashboard", adminToken)
@@ -408,6 +473,57 @@ func TestAdminReports_DashboardWithData(t *testing.T) {
 	assert.Equal(t, 2, stats.TotalPlayers)
 }
 
+func TestAdminReports_ProjectionsFromOutbox(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("projected@test.com", "securepass123", "EUR")
+
+	ctx := context.Background()
+	tx, err := env.Pool.Begin(ctx)
+	require.NoError(t, err)
+	_, err = env.LedgerEngine().ExecuteDeposit(ctx, tx, domain.DepositParams{
+		PlayerID: playerID, Amount: 5000, ExternalTransactionID: "projected-dep-1",
+		ManufacturerID: "stripe", SubTransactionID: "1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	// A redelivered event is applied once.
+	env.RunProjections()
+	env.RunProjections()
+	adminToken := env.AdminToken("superadmin")
+
+	var summary struct {
+		Email          string     `json:"email"`
+		DepositCount   int        `json:"deposit_count"`
+		DepositTotal   int64      `json:"deposit_total"`
+		LastActivityAt *time.Time `json:"last_activity_at"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/admin/players/"+playerID.String()+"/summary", adminToken), &summary)
+	assert.Equal(t, "projected@test.com", summary.Email)
+	assert.Equal(t, 1, summary.DepositCount)
+	assert.Equal(t, int64(5000), summary.DepositTotal)
+	assert.NotNil(t, summary.LastActivityAt)
+
+	var kpis []struct {
+		Currency      string `json:"currency"`
+		Registrations int    `json:"registrations"`
+		DepositTotal  int64  `json:"deposit_total"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/admin/reports/kpis?days=1", adminToken), &kpis)
+	require.Len(t, kpis, 1)
+	assert.Equal(t, "EUR", kpis[0].Currency)
+	assert.Equal(t, 1, kpis[0].Registrations)
+	assert.Equal(t, int64(5000), kpis[0].DepositTotal)
+
+	var stats struct {
+		ActivePlayers int   `json:"active_players"`
+		TotalDeposits int64 `json:"total_deposits"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/admin/reports/dashboard", adminToken), &stats)
+	assert.Equal(t, 1, stats.ActivePlayers)
+	assert.Equal(t, int64(5000), stats.TotalDeposits)
+}
+
 func TestAdminReports_TransactionReportEmpty(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	adminToken := env.AdminToken("superadmin")
@@ -418,6 +534,31 @@ func TestAdminReports_TransactionReportEmpty(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestAdminReports_ExportTransactionsStreams(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("txexport@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 5000)
+	adminToken := env.AdminToken("viewer")
+
+	resp := env.AuthGET("/admin/reports/transactions/export", adminToken)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var rows []struct {
+		PlayerID uuid.UUID `json:"player_id"`
+		Amount   int64     `json:"amount"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, playerID, rows[0].PlayerID)
+	assert.Equal(t, int64(5000), rows[0].Amount)
+
+	csvResp := env.AuthGET("/admin/reports/transactions/export?format=csv", adminToken)
+	defer csvResp.Body.Close()
+	require.Equal(t, http.StatusOK, csvResp.StatusCode)
+	assert.Equal(t, "text/csv", csvResp.Header.Get("Content-Type"))
+}
+
 func TestAdminReports_TransactionReportWithData(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	_, playerID := env.RegisterPlayer("txreport@test.com", "securepass123", "EUR")
@@ -785,21 +926,21 @@ func TestAdminPlayers_InvalidUUIDRejects(t *testing.T) {
 		"expected error status, got %d", resp.StatusCode)
 }
 
-func TestAdminPlayers_StatusUpdateVerified(t *testing.T) {
+func TestAdminPlayers_StatusUpdateUnknownStatus(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	_, playerID := env.RegisterPlayer("statusverify@test.com", "securepass123", "EUR")
 	adminToken := env.AdminToken("superadmin")
 
 	resp := env.AuthPATCH("/admin/players/"+playerID.String()+"/status",
-		map[string]string{"account_status": "verified"}, adminToken)
+		map[string]string{"account_status": "verified", "reason": "kyc passed"}, adminToken)
 	defer resp.Body.Close()
 
-	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
 	var status string
 	env.Pool.QueryRow(t.Context(),
 		"SELECT account_status FROM player_profiles WHERE player_id = $1", playerID).Scan(&status)
-	assert.Equal(t, "verified", status)
+	assert.Equal(t, "active", status)
 }
 
 // ─── Admin Reports Extended Tests (2) ─────────────────────────────────────
@@ -822,6 +963,33 @@ func TestAdminReports_DashboardPendingWithdrawals(t *testing.T) {
 	assert.GreaterOrEqual(t, stats.PendingWithdrawals, 1)
 }
 
+func TestAdminWithdrawals_QueueCarriesRiskRecommendation(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("wdrisk@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	resp := env.AuthPOST("/payments/withdraw", map[string]int64{"amount": 3000}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/withdrawals", env.AdminToken("viewer"))
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var items []struct {
+		PlayerID       uuid.UUID `json:"player_id"`
+		Recommendation *string   `json:"recommendation"`
+		RiskFactors    []string  `json:"risk_factors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+	require.Len(t, items, 1)
+	assert.Equal(t, playerID, items[0].PlayerID)
+	require.NotNil(t, items[0].Recommendation)
+	// Unverified player whose deposit has not been wagered
+	assert.NotEqual(t, "approve", *items[0].Recommendation)
+	assert.Contains(t, items[0].RiskFactors, "kyc_not_verified")
+}
+
 func TestAdminReports_DashboardOpenBets(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	token, playerID := env.RegisterPlayer("dashbets@test.com", "securepass123", "EUR")
@@ -847,8 +1015,210 @@ func TestAdminReports_DashboardOpenBets(t *testing.T) {
 	assert.GreaterOrEqual(t, stats.OpenBets, 0) // May be 0 if bet didn't succeed, or >=1 if it did
 }
 
+// ─── Admin DOB Verification Tests (2) ─────────────────────────────────────
+
+func TestAdminDOB_ReportUntilVerified(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+
+	resp := env.POST("/auth/register", map[string]string{
+		"email": "dob@test.com", "password": "securepass123", "currency": "EUR",
+		"date_of_birth": "1990-04-02", "country": "DE",
+	}, "")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var reg struct {
+		Token    string    `json:"token"`
+		PlayerID uuid.UUID `json:"player_id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&reg))
+	resp.Body.Close()
+
+	// Profile with a stored DOB still loads
+	resp = env.AuthGET("/players/me", reg.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	type reportRow struct {
+		PlayerID    uuid.UUID `json:"player_id"`
+		DateOfBirth *string   `json:"date_of_birth"`
+	}
+	report := func() []reportRow {
+		resp := env.AuthGET("/admin/reports/unverified-dob", adminToken)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var rows []reportRow
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&rows))
+		return rows
+	}
+
+	rows := report()
+	require.Len(t, rows, 1)
+	assert.Equal(t, reg.PlayerID, rows[0].PlayerID)
+	require.NotNil(t, rows[0].DateOfBirth)
+	assert.Equal(t, "1990-04-02", *rows[0].DateOfBirth)
+
+	resp = env.AuthPUT("/admin/players/"+reg.PlayerID.String()+"/dob-verification",
+		map[string]string{"date_of_birth": "1990-04-02"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Empty(t, report())
+}
+
+func TestAdminDOB_VerificationRejectsUnderage(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("dobminor@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPUT("/admin/players/"+playerID.String()+"/dob-verification",
+		map[string]string{"date_of_birth": time.Now().AddDate(-16, 0, 0).Format("2006-01-02")}, env.AdminToken("admin"))
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "UNDERAGE")
+}
+
 // ─── Admin Quest Extended Tests (5) ───────────────────────────────────────
 
+func TestAdminPayments_RefundRejectsWithdrawal(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("refundwd@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	resp := env.AuthPOST("/payments/withdraw", map[string]int64{"amount": 3000}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var paymentID uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT id FROM payments WHERE player_id = $1`, playerID).Scan(&paymentID))
+
+	body := map[string]interface{}{"amount": 3000, "reason": "chargeback risk"}
+	resp = env.AuthPOST("/admin/payments/"+paymentID.String()+"/refund", body, env.AdminToken("admin"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Viewers cannot issue refunds
+	resp = env.AuthPOST("/admin/payments/"+paymentID.String()+"/refund", body, env.AdminToken("viewer"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestAdminPayments_DepositReviewFlag(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("depreview@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPUT("/admin/players/"+playerID.String()+"/deposit-review",
+		map[string]bool{"required": true}, env.AdminToken("admin"))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var required bool
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT deposit_review_required FROM player_profiles WHERE player_id = $1`, playerID).Scan(&required))
+	assert.True(t, required)
+
+	resp = env.AuthPUT("/admin/players/"+uuid.New().String()+"/deposit-review",
+		map[string]bool{"required": true}, env.AdminToken("admin"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/pending-credits", env.AdminToken("viewer"))
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var credits []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&credits))
+	assert.Empty(t, credits)
+}
+
+func TestAdminOutbox_RequeueDLQ(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	var dlqID int64
+	require.NoError(t, env.Pool.QueryRow(ctx, `
+		INSERT INTO event_outbox_dlq ("eventId", "aggregateType", "aggregateId", "eventType",
+			"payload", "occurredAt", "attempts", "lastError")
+		VALUES ($1, 'wallet', 'p1', 'wallet.transaction.posted', '{}'::jsonb, now(), 8, 'broker down')
+		RETURNING "id"`, eventID).Scan(&dlqID))
+
+	resp := env.AuthGET("/admin/outbox/dlq", env.AdminToken("viewer"))
+	var entries []struct {
+		ID        int64  `json:"id"`
+		LastError string `json:"lastError"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&entries))
+	resp.Body.Close()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "broker down", entries[0].LastError)
+
+	path := fmt.Sprintf("/admin/outbox/dlq/%d/requeue", dlqID)
+	resp = env.AuthPOST(path, nil, env.AdminToken("admin"))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var attempts int
+	require.NoError(t, env.Pool.QueryRow(ctx,
+		`SELECT "attempts" FROM event_outbox WHERE "eventId" = $1`, eventID).Scan(&attempts))
+	assert.Zero(t, attempts)
+
+	resp = env.AuthPOST(path, nil, env.AdminToken("admin"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestOutbox_InsertNotifiesListeners(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := env.Pool.Acquire(ctx)
+	require.NoError(t, err)
+	listenConn := conn.Hijack()
+	defer listenConn.Close(context.Background())
+
+	_, err = listenConn.Exec(ctx, "LISTEN event_outbox")
+	require.NoError(t, err)
+
+	_, playerID := env.RegisterPlayer("outboxnotify@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
+
+	n, err := listenConn.WaitForNotification(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "event_outbox", n.Channel)
+}
+
+func TestOutbox_StatsReportBacklogAndDLQ(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+	repo := repository.NewOutboxRepository()
+
+	_, err := env.Pool.Exec(ctx, `TRUNCATE event_outbox, event_outbox_dlq`)
+	require.NoError(t, err)
+	stats, err := repo.Stats(ctx, env.Pool)
+	require.NoError(t, err)
+	assert.Zero(t, stats.Unpublished)
+	assert.Nil(t, stats.OldestCreatedAt)
+
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO event_outbox ("aggregateType", "aggregateId", "eventType", "payload", "createdAt")
+		VALUES ('wallet', 'p1', 'wallet.transaction.posted', '{}'::jsonb, now() - interval '10 minutes'),
+		       ('wallet', 'p2', 'wallet.transaction.posted', '{}'::jsonb, now())`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO event_outbox_dlq ("eventId", "aggregateType", "aggregateId", "eventType",
+			"payload", "occurredAt", "attempts", "lastError")
+		VALUES ($1, 'wallet', 'p3', 'wallet.transaction.posted', '{}'::jsonb, now(), 8, 'broker down')`, uuid.New())
+	require.NoError(t, err)
+
+	stats, err = repo.Stats(ctx, env.Pool)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Unpublished)
+	assert.Equal(t, int64(1), stats.DeadLettered)
+	require.NotNil(t, stats.OldestCreatedAt)
+	assert.InDelta(t, 10*time.Minute, time.Since(*stats.OldestCreatedAt), float64(time.Minute))
+}
+
 func TestAdminQuests_ListReturnsCreated(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	adminToken := env.AdminToken("superadmin")
@@ -955,3 +1325,1501 @@ func TestAdminQuests_RequiresAdminToken(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
+
+// ─── RG Net-Loss Intervention Tests (1) ───────────────────────────────────
+
+func TestRGInterventions_NetLossLadder(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("netloss@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	_, err := env.Pool.Exec(t.Context(), `UPDATE player_profiles SET country = 'GB' WHERE player_id = $1`, playerID)
+	require.NoError(t, err)
+
+	stake := func(txType string, amount int64) {
+		_, err := env.Pool.Exec(t.Context(), `
+			INSERT INTO v2_transactions (player_id, type, amount, balance_after, bonus_balance_after,
+				reserved_balance_after, external_transaction_id, manufacturer_id, sub_transaction_id, metadata)
+			VALUES ($1, $2, $3, 0, 0, 0, $4, 'test', '1', '{}')`,
+			playerID, txType, amount, uuid.New().String())
+		require.NoError(t, err)
+	}
+	run := func() int {
+		resp := env.AuthPOST("/admin/rg/interventions/run", nil, adminToken)
+		var summary struct {
+			Triggered int `json:"triggered"`
+		}
+		testutil.DecodeJSON(t, resp, &summary)
+		return summary.Triggered
+	}
+
+	// GB nudges at €250 net loss
+	stake("bet", 40000)
+	stake("win", 15000)
+	assert.Equal(t, 1, run())
+	assert.Equal(t, 0, run(), "same kind is not repeated inside the window")
+
+	stake("bet", 80000)
+	assert.Equal(t, 1, run()) // €1,050 net loss crosses the €1,000 break threshold
+
+	resp := env.AuthGET("/players/me/interventions?pending=true", token)
+	var pending []struct {
+		ID           string `json:"id"`
+		Kind         string `json:"kind"`
+		Jurisdiction string `json:"jurisdiction"`
+		NetLoss      int64  `json:"net_loss"`
+	}
+	testutil.DecodeJSON(t, resp, &pending)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "break_suggestion", pending[0].Kind)
+	assert.Equal(t, "GB", pending[0].Jurisdiction)
+	assert.Equal(t, int64(105000), pending[0].NetLoss)
+
+	resp = env.AuthPOST("/players/me/interventions/"+pending[0].ID+"/acknowledge",
+		map[string]string{"response": "accepted"}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = env.AuthPOST("/players/me/interventions/"+pending[0].ID+"/acknowledge",
+		map[string]string{"response": "dismissed"}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/rg/interventions?player_id="+playerID.String(), adminToken)
+	var audit []struct {
+		Kind     string  `json:"kind"`
+		Response *string `json:"response"`
+	}
+	testutil.DecodeJSON(t, resp, &audit)
+	require.Len(t, audit, 2)
+	require.NotNil(t, audit[0].Response)
+	assert.Equal(t, "accepted", *audit[0].Response)
+
+	var events int
+	env.Pool.QueryRow(t.Context(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.rg.intervention.triggered' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&events)
+	assert.Equal(t, 2, events)
+}
+
+func TestRGRiskScores_ChasingSessionOnDashboard(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("chaser@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	start := time.Now().Add(-5 * time.Hour)
+	play := func(txType string, amount int64, at time.Time) {
+		_, err := env.Pool.Exec(t.Context(), `
+			INSERT INTO v2_transactions (player_id, type, amount, balance_after, bonus_balance_after,
+				reserved_balance_after, external_transaction_id, manufacturer_id, sub_transaction_id, metadata, created_at)
+			VALUES ($1, $2, $3, 0, 0, 0, $4, 'test', '1', '{}', $5)`,
+			playerID, txType, amount, uuid.New().String(), at)
+		require.NoError(t, err)
+	}
+
+	// Doubling up after every loss, kept going for four hours
+	stake := int64(500)
+	for i := 0; i < 4; i++ {
+		play("bet", stake, start.Add(time.Duration(i)*time.Minute))
+		stake *= 2
+	}
+	for i := 1; i <= 16; i++ {
+		play("bet", 500, start.Add(time.Duration(i)*15*time.Minute))
+	}
+
+	resp := env.AuthPOST("/admin/rg/risk-scores/run", nil, adminToken)
+	var summary struct {
+		SessionsScored int `json:"sessions_scored"`
+		HighRisk       int `json:"high_risk"`
+	}
+	testutil.DecodeJSON(t, resp, &summary)
+	assert.Equal(t, 1, summary.SessionsScored)
+	assert.Equal(t, 1, summary.HighRisk)
+
+	// Re-scoring updates the same session
+	resp = env.AuthPOST("/admin/rg/risk-scores/run", nil, adminToken)
+	resp.Body.Close()
+
+	resp = env.AuthGET("/admin/rg/risk-scores?player_id="+playerID.String(), adminToken)
+	var scores []struct {
+		Score       int      `json:"score"`
+		Band        string   `json:"band"`
+		Factors     []string `json:"factors"`
+		Escalations int      `json:"escalations"`
+		Bets        int      `json:"bets"`
+	}
+	testutil.DecodeJSON(t, resp, &scores)
+	require.Len(t, scores, 1)
+	assert.Equal(t, "high", scores[0].Band)
+	assert.Equal(t, 3, scores[0].Escalations)
+	assert.Equal(t, 20, scores[0].Bets)
+	assert.Contains(t, scores[0].Factors, "stake_escalation_after_loss")
+	assert.Contains(t, scores[0].Factors, "long_session")
+
+	resp = env.AuthGET("/admin/rg/dashboard", adminToken)
+	var dashboard struct {
+		SessionsByBand  map[string]int `json:"sessions_by_band"`
+		HighRiskPlayers []struct {
+			PlayerID string `json:"player_id"`
+			Email    string `json:"email"`
+			MaxScore int    `json:"max_score"`
+		} `json:"high_risk_players"`
+	}
+	testutil.DecodeJSON(t, resp, &dashboard)
+	assert.Equal(t, 1, dashboard.SessionsByBand["high"])
+	require.Len(t, dashboard.HighRiskPlayers, 1)
+	assert.Equal(t, playerID.String(), dashboard.HighRiskPlayers[0].PlayerID)
+	assert.Equal(t, "chaser@test.com", dashboard.HighRiskPlayers[0].Email)
+}
+
+func TestRGCases_WorkflowClosesWithSelfExclusion(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("rgcase@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	_, err := env.Pool.Exec(t.Context(), `
+		INSERT INTO rg_risk_scores (player_id, session_start, session_end, score, band, factors, bets, minutes)
+		VALUES ($1, now() - interval '5 hours', now() - interval '1 hour', 80, 'high',
+			'["stake_escalation_after_loss", "long_session"]', 20, 240)`, playerID)
+	require.NoError(t, err)
+
+	resp := env.AuthGET("/admin/rg/players?band=high", adminToken)
+	var players []struct {
+		PlayerID      string  `json:"player_id"`
+		MaxScore      int     `json:"max_score"`
+		AccountStatus string  `json:"account_status"`
+		OpenCaseID    *string `json:"open_case_id"`
+	}
+	testutil.DecodeJSON(t, resp, &players)
+	require.Len(t, players, 1)
+	assert.Equal(t, playerID.String(), players[0].PlayerID)
+	assert.Equal(t, 80, players[0].MaxScore)
+	assert.Equal(t, "active", players[0].AccountStatus)
+	assert.Nil(t, players[0].OpenCaseID)
+
+	resp = env.AuthPOST("/admin/rg/cases", map[string]interface{}{
+		"player_id": playerID, "priority": "high", "source": "risk_score", "reason": "chasing losses over four hours",
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var opened struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	testutil.DecodeJSON(t, resp, &opened)
+	assert.Equal(t, "open", opened.Status)
+
+	// One open case per player
+	resp = env.AuthPOST("/admin/rg/cases", map[string]interface{}{
+		"player_id": playerID, "reason": "duplicate",
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = env.AuthPOST("/admin/rg/cases/"+opened.ID+"/assign", map[string]interface{}{}, adminToken)
+	var assigned struct {
+		Status     string  `json:"status"`
+		AssignedTo *string `json:"assigned_to"`
+	}
+	testutil.DecodeJSON(t, resp, &assigned)
+	assert.Equal(t, "in_progress", assigned.Status)
+	assert.NotNil(t, assigned.AssignedTo)
+
+	resp = env.AuthPOST("/admin/rg/cases/"+opened.ID+"/interactions", map[string]interface{}{
+		"kind": "call", "summary": "player asked to be excluded",
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = env.AuthPOST("/admin/rg/cases/"+opened.ID+"/close", map[string]interface{}{
+		"outcome": "self_excluded", "note": "six month exclusion",
+	}, adminToken)
+	var closed struct {
+		Status  string `json:"status"`
+		Outcome string `json:"outcome"`
+	}
+	testutil.DecodeJSON(t, resp, &closed)
+	assert.Equal(t, "closed", closed.Status)
+	assert.Equal(t, "self_excluded", closed.Outcome)
+
+	var status string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT account_status FROM player_profiles WHERE player_id = $1`, playerID).Scan(&status))
+	assert.Equal(t, "self_excluded", status)
+
+	// Closed cases are read-only
+	resp = env.AuthPOST("/admin/rg/cases/"+opened.ID+"/interactions", map[string]interface{}{
+		"kind": "note", "summary": "late note",
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/rg/cases/"+opened.ID, adminToken)
+	var detail struct {
+		AccountStatus string `json:"account_status"`
+		Interactions  []struct {
+			Kind string `json:"kind"`
+		} `json:"interactions"`
+		StatusHistory []struct {
+			ToStatus string `json:"to_status"`
+		} `json:"status_history"`
+		RiskScores []json.RawMessage `json:"risk_scores"`
+	}
+	testutil.DecodeJSON(t, resp, &detail)
+	assert.Equal(t, "self_excluded", detail.AccountStatus)
+	require.Len(t, detail.Interactions, 3)
+	assert.Equal(t, "assignment", detail.Interactions[0].Kind)
+	assert.Equal(t, "call", detail.Interactions[1].Kind)
+	assert.Equal(t, "status_change", detail.Interactions[2].Kind)
+	require.NotEmpty(t, detail.StatusHistory)
+	assert.Equal(t, "self_excluded", detail.StatusHistory[0].ToStatus)
+	assert.Len(t, detail.RiskScores, 1)
+
+	resp = env.AuthGET("/admin/rg/cases/"+opened.ID+"/export", adminToken)
+	var history []struct {
+		Source string `json:"source"`
+		Kind   string `json:"kind"`
+	}
+	testutil.DecodeJSON(t, resp, &history)
+	require.Len(t, history, 6)
+	assert.Equal(t, "risk_score", history[0].Source)
+	assert.Equal(t, "opened", history[1].Kind)
+
+	resp = env.AuthGET("/admin/rg/cases/"+opened.ID+"/export?format=csv", adminToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+}
+
+func TestProviderCallbacks_JournalAndMismatches(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("journal@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	journal := func(action, txID, status string, valid bool) {
+		_, err := env.Pool.Exec(t.Context(), `
+			INSERT INTO provider_callbacks (provider, path, action, player_id, transaction_id, amount,
+				currency, body, signature_valid, status)
+			VALUES ('relax', '/relax/withdraw', $1, $2, $3, 500, 'EUR', '{}', $4, $5)`,
+			action, playerID, txID, valid, status)
+		require.NoError(t, err)
+	}
+	ledger := func(txType, extID string) {
+		_, err := env.Pool.Exec(t.Context(), `
+			INSERT INTO v2_transactions (player_id, type, amount, balance_after, bonus_balance_after,
+				reserved_balance_after, external_transaction_id, manufacturer_id, sub_transaction_id, metadata)
+			VALUES ($1, $2, 500, 0, 0, 0, $3, 'relax', '1', '{}')`, playerID, txType, extID)
+		require.NoError(t, err)
+	}
+
+	journal("bet", "tx-matched", "ok", true)
+	ledger("bet", "tx-matched")
+	journal("bet", "tx-lost", "ok", true) // accepted but never posted
+	journal("bet", "tx-refused", "unauthorized", false)
+	ledger("win", "tx-orphan") // posted without a journaled callback
+
+	resp := env.AuthGET("/admin/providers/callbacks?provider=relax&signature_valid=false", adminToken)
+	var callbacks []struct {
+		TransactionID string `json:"transaction_id"`
+		Status        string `json:"status"`
+	}
+	testutil.DecodeJSON(t, resp, &callbacks)
+	require.Len(t, callbacks, 1)
+	assert.Equal(t, "tx-refused", callbacks[0].TransactionID)
+	assert.Equal(t, "unauthorized", callbacks[0].Status)
+
+	resp = env.AuthGET("/admin/providers/callbacks?transaction_id=tx-matched", adminToken)
+	testutil.DecodeJSON(t, resp, &callbacks)
+	require.Len(t, callbacks, 1)
+
+	resp = env.AuthGET("/admin/providers/callbacks/mismatches?provider=relax", adminToken)
+	var mismatches []struct {
+		Kind          string `json:"kind"`
+		TransactionID string `json:"transaction_id"`
+		Action        string `json:"action"`
+	}
+	testutil.DecodeJSON(t, resp, &mismatches)
+	require.Len(t, mismatches, 2)
+	byKind := map[string]string{}
+	for _, m := range mismatches {
+		byKind[m.Kind] = m.TransactionID
+	}
+	assert.Equal(t, "tx-lost", byKind["missing_transaction"])
+	assert.Equal(t, "tx-orphan", byKind["unjournaled_transaction"])
+
+	resp = env.AuthGET("/admin/providers/callbacks/mismatches?from=2026-01-01T00:00:00Z&to=2026-06-01T00:00:00Z", adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── Data Retention Tests (1) ─────────────────────────────────────────────
+
+func TestRetention_DryRunThenApply(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("retention@test.com", "securepass123", "EUR")
+	ctx := context.Background()
+
+	_, err := env.Pool.Exec(ctx, `
+		INSERT INTO provider_callbacks (provider, path, body, status, received_at)
+		VALUES ('pragmatic', '/bet', '{"old":true}', 'ok', now() - interval '100 days'),
+		       ('pragmatic', '/bet', '{"new":true}', 'ok', now() - interval '10 days')`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO ai_conversations (player_id, created_at, updated_at)
+		VALUES ($1, now() - interval '200 days', now() - interval '200 days')`, playerID)
+	require.NoError(t, err)
+	var newConv uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(ctx, `
+		INSERT INTO ai_conversations (player_id) VALUES ($1) RETURNING id`, playerID).Scan(&newConv))
+
+	type run struct {
+		DryRun bool   `json:"dry_run"`
+		Status string `json:"status"`
+		Items  []struct {
+			Policy   string `json:"policy"`
+			Affected int64  `json:"affected"`
+		} `json:"items"`
+	}
+	affected := func(r run) map[string]int64 {
+		m := map[string]int64{}
+		for _, item := range r.Items {
+			m[item.Policy] = item.Affected
+		}
+		return m
+	}
+
+	// Running deletions is superadmin only; runs are dry by default
+	resp := env.AuthPOST("/admin/retention/runs", nil, env.AdminToken("admin"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	superToken := env.AdminToken("superadmin")
+	resp = env.AuthPOST("/admin/retention/runs", nil, superToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var dry run
+	testutil.DecodeJSON(t, resp, &dry)
+	assert.True(t, dry.DryRun)
+	assert.Equal(t, "completed", dry.Status)
+	assert.Equal(t, int64(1), affected(dry)["provider_callback_bodies"])
+	assert.Equal(t, int64(1), affected(dry)["ai_conversations"])
+
+	var conversations int
+	env.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM ai_conversations`).Scan(&conversations)
+	assert.Equal(t, 2, conversations)
+
+	resp = env.AuthPOST("/admin/retention/runs", map[string]bool{"dry_run": false}, superToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var applied run
+	testutil.DecodeJSON(t, resp, &applied)
+	assert.False(t, applied.DryRun)
+	assert.Equal(t, int64(1), affected(applied)["provider_callback_bodies"])
+
+	var bodies []string
+	rows, err := env.Pool.Query(ctx, `SELECT body FROM provider_callbacks ORDER BY received_at`)
+	require.NoError(t, err)
+	for rows.Next() {
+		var b string
+		require.NoError(t, rows.Scan(&b))
+		bodies = append(bodies, b)
+	}
+	rows.Close()
+	assert.Equal(t, []string{"", `{"new":true}`}, bodies)
+
+	var remaining uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(ctx, `SELECT id FROM ai_conversations`).Scan(&remaining))
+	assert.Equal(t, newConv, remaining)
+
+	// Both runs are kept as the audit trail
+	resp = env.AuthGET("/admin/retention/runs", env.AdminToken("viewer"))
+	var runs []run
+	testutil.DecodeJSON(t, resp, &runs)
+	require.Len(t, runs, 2)
+	assert.False(t, runs[0].DryRun)
+	assert.Len(t, runs[0].Items, 4)
+}
+
+// ─── Bonus Grant Tests (4) ────────────────────────────────────────────────
+
+func TestBonusGrant_CashBonusCreditsBonusBalance(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	_, playerID := env.RegisterPlayer("grantcash@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/admin/bonuses", map[string]interface{}{
+		"name": "Reload", "code": "RELOAD10", "wagering_multiplier": 5, "max_bonus": 1000,
+		"days_until_expiry": 14,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bonus struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+	testutil.DecodeJSON(t, resp, &bonus)
+	assert.Equal(t, "cash", bonus.Type)
+
+	resp = env.AuthPOST("/admin/players/"+playerID.String()+"/bonuses",
+		map[string]string{"bonus_id": bonus.ID}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var grant struct {
+		PlayerBonus struct {
+			InitialAmount       int64 `json:"initial_amount"`
+			WageringRequirement int64 `json:"wagering_requirement"`
+		} `json:"player_bonus"`
+	}
+	testutil.DecodeJSON(t, resp, &grant)
+	assert.Equal(t, int64(1000), grant.PlayerBonus.InitialAmount)
+	assert.Equal(t, int64(5000), grant.PlayerBonus.WageringRequirement)
+	testutil.AssertBalance(t, env, playerID, 0, 1000, 0)
+}
+
+func TestBonusGrant_FreeSpinWinsCreditedAndWagered(t *testing.T) {
+	env := testutil.NewSlotopolTestEnv(t, 0, 150, 50)
+	adminToken := env.AdminToken("admin")
+	token, playerID := env.RegisterPlayer("grantspins@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/admin/bonuses", map[string]interface{}{
+		"name": "Welcome Spins", "type": "free_spins", "wagering_multiplier": 10,
+		"free_spins": 3, "free_spin_value": 20, "free_spin_games": []string{"book-of-ra"},
+		"days_until_expiry": 7,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bonus struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &bonus)
+
+	resp = env.AuthPOST("/admin/players/"+playerID.String()+"/bonuses",
+		map[string]string{"bonus_id": bonus.ID}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var grant struct {
+		FreeSpins struct {
+			ID              string `json:"id"`
+			Provider        string `json:"provider"`
+			ProviderGrantID string `json:"provider_grant_id"`
+			Rounds          int    `json:"rounds"`
+		} `json:"free_spins"`
+	}
+	testutil.DecodeJSON(t, resp, &grant)
+	assert.Equal(t, "slotopol", grant.FreeSpins.Provider)
+	assert.Equal(t, "fr-1", grant.FreeSpins.ProviderGrantID)
+	assert.Equal(t, 3, grant.FreeSpins.Rounds)
+
+	// Wrong game
+	resp = env.AuthPOST("/bonuses/free-spins/"+grant.FreeSpins.ID+"/spin",
+		map[string]string{"game_id": "starburst"}, token)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	type spinResult struct {
+		Win   int64 `json:"win"`
+		Grant struct {
+			RoundsUsed    int     `json:"rounds_used"`
+			TotalWin      int64   `json:"total_win"`
+			Status        string  `json:"status"`
+			PlayerBonusID *string `json:"player_bonus_id"`
+		} `json:"grant"`
+	}
+	var last spinResult
+	for i := 0; i < 3; i++ {
+		resp = env.AuthPOST("/bonuses/free-spins/"+grant.FreeSpins.ID+"/spin", nil, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		testutil.DecodeJSON(t, resp, &last)
+	}
+	assert.Equal(t, int64(50), last.Win)
+	assert.Equal(t, 3, last.Grant.RoundsUsed)
+	assert.Equal(t, int64(200), last.Grant.TotalWin)
+	assert.Equal(t, "completed", last.Grant.Status)
+	require.NotNil(t, last.Grant.PlayerBonusID)
+	testutil.AssertBalance(t, env, playerID, 0, 200, 0)
+
+	// The total win carries the bonus's wagering requirement
+	var initial, requirement int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT initial_amount, wagering_requirement FROM player_bonuses WHERE id = $1`,
+		*last.Grant.PlayerBonusID).Scan(&initial, &requirement))
+	assert.Equal(t, int64(200), initial)
+	assert.Equal(t, int64(2000), requirement)
+
+	resp = env.AuthPOST("/bonuses/free-spins/"+grant.FreeSpins.ID+"/spin", nil, token)
+	testutil.AssertErrorCode(t, resp, "CONFLICT")
+}
+
+type bonusGrantJob struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Progress struct {
+		Total        int `json:"total"`
+		Pending      int `json:"pending"`
+		Granted      int `json:"granted"`
+		Skipped      int `json:"skipped"`
+		Failed       int `json:"failed"`
+		Revoked      int `json:"revoked"`
+		RevokeFailed int `json:"revoke_failed"`
+	} `json:"progress"`
+}
+
+// waitForBonusGrant polls a bulk grant until it reaches status.
+func waitForBonusGrant(t *testing.T, env *testutil.TestEnv, id, status, adminToken string) bonusGrantJob {
+	t.Helper()
+	var job bonusGrantJob
+	require.Eventually(t, func() bool {
+		testutil.DecodeJSON(t, env.AuthGET("/admin/bonus-grants/"+id, adminToken), &job)
+		return job.Status == status
+	}, 10*time.Second, 50*time.Millisecond)
+	return job
+}
+
+func TestBonusGrant_BulkUploadGrantsThenRollsBack(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	_, firstID := env.RegisterPlayer("bulk1@test.com", "securepass123", "EUR")
+	_, playerID := env.RegisterPlayer("bulk2@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/admin/bonuses", map[string]interface{}{
+		"name": "Apology", "wagering_multiplier": 5, "max_bonus": 500,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bonus struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &bonus)
+
+	// A header, a duplicate and an unknown player.
+	unknown := uuid.New()
+	csv := fmt.Sprintf("player_id\n%s\n%s\n%s\n%s\n", firstID, playerID, firstID, unknown)
+	resp = env.AuthPOSTRaw("/admin/bonuses/"+bonus.ID+"/grant", "text/csv", csv, adminToken)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var job bonusGrantJob
+	testutil.DecodeJSON(t, resp, &job)
+	assert.Equal(t, 3, job.Progress.Total)
+
+	job = waitForBonusGrant(t, env, job.ID, "completed", adminToken)
+	assert.Equal(t, 2, job.Progress.Granted)
+	assert.Equal(t, 1, job.Progress.Skipped)
+	assert.Zero(t, job.Progress.Pending)
+	testutil.AssertBalance(t, env, firstID, 0, 500, 0)
+	testutil.AssertBalance(t, env, playerID, 0, 500, 0)
+
+	var skipped []struct {
+		PlayerID string `json:"player_id"`
+		Error    string `json:"error"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/admin/bonus-grants/"+job.ID+"/items?status=skipped", adminToken), &skipped)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, unknown.String(), skipped[0].PlayerID)
+	assert.Equal(t, "player not found", skipped[0].Error)
+
+	resp = env.AuthPOST("/admin/bonus-grants/"+job.ID+"/rollback", nil, adminToken)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+
+	job = waitForBonusGrant(t, env, job.ID, "rolled_back", adminToken)
+	assert.Equal(t, 2, job.Progress.Revoked)
+	testutil.AssertBalance(t, env, firstID, 0, 0, 0)
+	testutil.AssertBalance(t, env, playerID, 0, 0, 0)
+
+	var statuses []string
+	rows, err := env.Pool.Query(context.Background(),
+		`SELECT status FROM player_bonuses WHERE player_id = ANY($1)`, []uuid.UUID{firstID, playerID})
+	require.NoError(t, err)
+	for rows.Next() {
+		var status string
+		require.NoError(t, rows.Scan(&status))
+		statuses = append(statuses, status)
+	}
+	rows.Close()
+	assert.Equal(t, []string{"forfeited", "forfeited"}, statuses)
+
+	// A rolled back grant cannot be rolled back again.
+	resp = env.AuthPOST("/admin/bonus-grants/"+job.ID+"/rollback", nil, adminToken)
+	testutil.AssertErrorCode(t, resp, "CONFLICT")
+}
+
+func TestBonusGrant_BulkGrantValidation(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	_, playerID := env.RegisterPlayer("bulkval@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/admin/bonuses", map[string]interface{}{
+		"name": "Reload", "wagering_multiplier": 5, "max_bonus": 500,
+	}, adminToken)
+	var bonus struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &bonus)
+	path := "/admin/bonuses/" + bonus.ID + "/grant"
+
+	for _, body := range []map[string]interface{}{
+		{},
+		{"segment": "vip", "player_ids": []string{playerID.String()}},
+		{"segment": "whales"},
+	} {
+		resp = env.AuthPOST(path, body, adminToken)
+		testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	}
+
+	// No one has consented to profiling, so the new-player segment is empty.
+	resp = env.AuthPOST(path, map[string]string{"segment": "new"}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	resp = env.AuthPOST(path, map[string]string{"segment": "all"}, adminToken)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var job bonusGrantJob
+	testutil.DecodeJSON(t, resp, &job)
+	job = waitForBonusGrant(t, env, job.ID, "completed", adminToken)
+	assert.Equal(t, 1, job.Progress.Granted)
+	testutil.AssertBalance(t, env, playerID, 0, 500, 0)
+
+	resp = env.AuthPOSTRaw(path, "text/csv", "player_id\nnot-a-uuid\n", adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Simulation Tests (2) ─────────────────────────────────────────────────
+
+func TestSimulation_DepositMatchBonusCost(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	_, bigID := env.RegisterPlayer("simbig@test.com", "securepass123", "EUR")
+	_, smallID := env.RegisterPlayer("simsmall@test.com", "securepass123", "EUR")
+	env.RegisterPlayer("simnone@test.com", "securepass123", "EUR")
+
+	for _, dep := range []struct {
+		player uuid.UUID
+		amount int64
+	}{{bigID, 10_000}, {bigID, 50_000}, {smallID, 500}} {
+		_, err := env.Pool.Exec(context.Background(), `
+			INSERT INTO payments (player_id, type, amount, currency, status)
+			VALUES ($1, 'deposit', $2, 'EUR', 'completed')`, dep.player, dep.amount)
+		require.NoError(t, err)
+	}
+
+	resp := env.AuthPOST("/admin/bonuses/simulate", map[string]interface{}{
+		"bonus": map[string]interface{}{
+			"name": "Match", "type": "deposit_match", "match_percent": 100,
+			"max_bonus": 8000, "min_deposit": 1000,
+		},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sim struct {
+		Days             int     `json:"days"`
+		QualifiedPlayers int     `json:"qualified_players"`
+		MaxCost          int64   `json:"max_cost"`
+		ReleaseRate      float64 `json:"release_rate"`
+		ProjectedCost    int64   `json:"projected_cost"`
+	}
+	testutil.DecodeJSON(t, resp, &sim)
+	assert.Equal(t, 30, sim.Days)
+	// Only the first qualifying deposit is matched, capped at max_bonus
+	assert.Equal(t, 1, sim.QualifiedPlayers)
+	assert.Equal(t, int64(8000), sim.MaxCost)
+	assert.Equal(t, 1.0, sim.ReleaseRate)
+	assert.Equal(t, int64(8000), sim.ProjectedCost)
+
+	// Nothing is saved
+	resp = env.AuthGET("/admin/bonuses", adminToken)
+	var bonuses []map[string]interface{}
+	testutil.DecodeJSON(t, resp, &bonuses)
+	assert.Empty(t, bonuses)
+
+	resp = env.AuthPOST("/admin/bonuses/simulate", map[string]interface{}{
+		"bonus": map[string]interface{}{"name": "Match", "type": "deposit_match"},
+	}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+func TestSimulation_QuestAppliesDailyBudget(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	today := time.Now().UTC().Format("2006-01-02")
+	for i := 0; i < 3; i++ {
+		_, playerID := env.RegisterPlayer(fmt.Sprintf("simquest%d@test.com", i), "securepass123", "EUR")
+		// Each player reaches 10 wagers today, over two days
+		_, err := env.Pool.Exec(context.Background(), `
+			INSERT INTO player_engagement (player_id, date, wager_count, score)
+			VALUES ($1, $2, 6, 40), ($1, $3, 4, 80)`, playerID, yesterday, today)
+		require.NoError(t, err)
+	}
+
+	resp := env.AuthPOST("/admin/quests/simulate", map[string]interface{}{
+		"metric": "wager_count", "target_progress": 10, "min_score": 50,
+		"reward_amount": 500, "daily_budget_minor": 1000, "days": 7,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sim struct {
+		QualifiedPlayers  int   `json:"qualified_players"`
+		RewardedPlayers   int   `json:"rewarded_players"`
+		BudgetLimitedDays int   `json:"budget_limited_days"`
+		ProjectedCost     int64 `json:"projected_cost"`
+	}
+	testutil.DecodeJSON(t, resp, &sim)
+	assert.Equal(t, 3, sim.QualifiedPlayers)
+	assert.Equal(t, 2, sim.RewardedPlayers)
+	assert.Equal(t, 1, sim.BudgetLimitedDays)
+	assert.Equal(t, int64(1000), sim.ProjectedCost)
+
+	resp = env.AuthPOST("/admin/quests/simulate", map[string]interface{}{
+		"metric": "logins", "target_progress": 1,
+	}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Admin Activity Tests (2) ─────────────────────────────────────────────
+
+func TestAdminActivity_ReportAggregatesAuditLog(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("audited@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	viewerToken := env.AdminToken("viewer")
+	superToken := env.AdminToken("superadmin")
+
+	for i := 0; i < 3; i++ {
+		resp := env.AuthGET("/admin/players/"+playerID.String(), adminToken)
+		resp.Body.Close()
+	}
+	resp := env.AuthGET("/admin/reports/admin-activity", viewerToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/reports/admin-activity", superToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report struct {
+		Heatmap []struct {
+			Actions int `json:"actions"`
+			Denied  int `json:"denied"`
+		} `json:"heatmap"`
+		TopPlayers []struct {
+			PlayerID uuid.UUID `json:"player_id"`
+			Actions  int       `json:"actions"`
+			Admins   int       `json:"admins"`
+		} `json:"top_players"`
+	}
+	testutil.DecodeJSON(t, resp, &report)
+	require.Len(t, report.Heatmap, 2, "one cell each for the admin and the viewer")
+	assert.Equal(t, 3, report.Heatmap[0].Actions)
+	assert.Equal(t, 1, report.Heatmap[1].Denied)
+	require.Len(t, report.TopPlayers, 1)
+	assert.Equal(t, playerID, report.TopPlayers[0].PlayerID)
+	assert.Equal(t, 3, report.TopPlayers[0].Actions)
+	assert.Equal(t, 1, report.TopPlayers[0].Admins)
+
+	resp = env.AuthGET("/admin/audit-log?player_id="+playerID.String(), superToken)
+	var entries []struct {
+		Route      string `json:"route"`
+		StatusCode int    `json:"status_code"`
+	}
+	testutil.DecodeJSON(t, resp, &entries)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "/admin/players/{id}", entries[0].Route)
+	assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+}
+
+func TestAdminActivity_OutOfHoursExport(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	superToken := env.AdminToken("superadmin")
+	adminID := uuid.New()
+
+	// Saturday night and a Wednesday afternoon
+	_, err := env.Pool.Exec(context.Background(), `
+		INSERT INTO admin_audit_log (admin_id, admin_email, role, method, route, path, status_code, created_at)
+		VALUES ($1, 'night@test.com', 'admin', 'POST', '/admin/bonuses', '/admin/bonuses', 201, '2025-10-18T23:30:00Z'),
+		       ($1, 'night@test.com', 'admin', 'GET', '/admin/bonuses', '/admin/bonuses', 200, '2025-10-15T14:00:00Z')`,
+		adminID)
+	require.NoError(t, err)
+
+	window := "from=2025-10-13T00:00:00Z&to=2025-10-20T00:00:00Z"
+	resp := env.AuthGET("/admin/reports/admin-activity/export?view=out_of_hours&"+window, superToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var rows []struct {
+		AdminID uuid.UUID `json:"admin_id"`
+		Actions int       `json:"actions"`
+		Writes  int       `json:"writes"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rows))
+	resp.Body.Close()
+	require.Len(t, rows, 1)
+	assert.Equal(t, adminID, rows[0].AdminID)
+	assert.Equal(t, 1, rows[0].Actions)
+	assert.Equal(t, 1, rows[0].Writes)
+
+	// 14:00 UTC is after hours in Tokyo
+	resp = env.AuthGET("/admin/reports/admin-activity/export?view=out_of_hours&tz=Asia/Tokyo&"+window, superToken)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rows))
+	resp.Body.Close()
+	require.Len(t, rows, 1)
+	assert.Equal(t, 2, rows[0].Actions)
+
+	csvResp := env.AuthGET("/admin/reports/admin-activity/export?view=heatmap&format=csv&"+window, superToken)
+	defer csvResp.Body.Close()
+	require.Equal(t, http.StatusOK, csvResp.StatusCode)
+	assert.Equal(t, "text/csv", csvResp.Header.Get("Content-Type"))
+
+	resp = env.AuthGET("/admin/reports/admin-activity/export?view=logins", superToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Export Job Tests (2) ─────────────────────────────────────────────────
+
+func TestExportJobs_CompletesWithDownloadAndLimitsAdmins(t *testing.T) {
+	env := testutil.NewExportTestEnv(t)
+	_, playerID := env.RegisterPlayer("exportjob@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 5000)
+	adminToken := env.AdminToken("admin")
+
+	// Starting an export is a write; viewers can only follow their jobs.
+	from := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	to := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	resp := env.AuthPOST("/admin/exports", map[string]string{
+		"kind": "players", "format": "csv", "from": from, "to": to,
+	}, env.AdminToken("viewer"))
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = env.AuthPOST("/admin/exports", map[string]string{
+		"kind": "players", "format": "csv", "from": from, "to": to,
+	}, adminToken)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var job struct {
+		ID          string `json:"id"`
+		AdminID     string `json:"admin_id"`
+		Status      string `json:"status"`
+		TotalRows   int    `json:"total_rows"`
+		RowsWritten int    `json:"rows_written"`
+		DownloadURL string `json:"download_url"`
+	}
+	testutil.DecodeJSON(t, resp, &job)
+
+	require.Eventually(t, func() bool {
+		resp := env.AuthGET("/admin/exports/"+job.ID, adminToken)
+		testutil.DecodeJSON(t, resp, &job)
+		return job.Status == "completed" || job.Status == "failed"
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, "completed", job.Status)
+	assert.Equal(t, 1, job.TotalRows)
+	assert.Equal(t, 1, job.RowsWritten)
+	require.NotEmpty(t, job.DownloadURL)
+
+	download, err := http.Get(job.DownloadURL)
+	require.NoError(t, err)
+	defer download.Body.Close()
+	require.Equal(t, http.StatusOK, download.StatusCode)
+	assert.Equal(t, "text/csv", download.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(download.Body)
+	assert.Contains(t, string(body), playerID.String())
+	assert.Contains(t, string(body), "exportjob@test.com")
+
+	// Another admin cannot see the job.
+	resp = env.AuthGET("/admin/exports/"+job.ID, env.AdminToken("viewer"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// With a job still running the admin is at the limit of one.
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO admin_export_jobs (admin_id, kind, format, from_at, to_at, status)
+		VALUES ($1, 'transactions', 'csv', now() - interval '1 day', now(), 'running')`, job.AdminID)
+	require.NoError(t, err)
+	resp = env.AuthPOST("/admin/exports", map[string]string{
+		"kind": "transactions", "from": from, "to": to,
+	}, adminToken)
+	testutil.AssertErrorCode(t, resp, "EXPORT_LIMIT_REACHED")
+}
+
+func TestExportJobs_SweepFailsOnlyLapsedLeases(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminID := uuid.New()
+
+	insert := func(lockedUntil string) uuid.UUID {
+		t.Helper()
+		var id uuid.UUID
+		require.NoError(t, env.Pool.QueryRow(t.Context(), `
+			INSERT INTO admin_export_jobs (admin_id, kind, format, from_at, to_at, status, locked_until)
+			VALUES ($1, 'players', 'csv', now() - interval '1 day', now(), 'running', now() + $2::interval)
+			RETURNING id`, adminID, lockedUntil).Scan(&id))
+		return id
+	}
+	// One job is still being run by another replica; the other's replica
+	// stopped renewing its lease.
+	held := insert("1 minute")
+	lapsed := insert("-1 minute")
+
+	assert.Equal(t, int64(1), env.FailInterruptedExports())
+
+	status := func(id uuid.UUID) string {
+		var s string
+		require.NoError(t, env.Pool.QueryRow(t.Context(),
+			`SELECT status FROM admin_export_jobs WHERE id = $1`, id).Scan(&s))
+		return s
+	}
+	assert.Equal(t, "running", status(held))
+	assert.Equal(t, "failed", status(lapsed))
+}
+
+// ─── Manufacturer Registry Tests (1) ──────────────────────────────────────
+
+func TestManufacturers_RegisterDisableAndEnable(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+
+	type manufacturer struct {
+		ID             string   `json:"id"`
+		Prefix         string   `json:"prefix"`
+		Enabled        bool     `json:"enabled"`
+		MoneyFormat    string   `json:"money_format"`
+		AllowedActions []string `json:"allowed_actions"`
+		DisabledReason *string  `json:"disabled_reason"`
+	}
+
+	resp := env.AuthPUT("/admin/manufacturers/pp-eu", map[string]interface{}{
+		"kind": "pragmatic", "display_name": "Pragmatic EU", "prefix": "eu/pragmatic",
+		"secret_ref": "PP_EU_SECRET", "allowed_actions": []string{"balance", "bet", "win"},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var m manufacturer
+	testutil.DecodeJSON(t, resp, &m)
+	assert.Equal(t, "/eu/pragmatic", m.Prefix)
+	assert.Equal(t, "decimal", m.MoneyFormat)
+	assert.True(t, m.Enabled)
+	assert.Equal(t, []string{"balance", "bet", "win"}, m.AllowedActions)
+
+	// Unknown kinds and actions, and prefixes already taken, are refused.
+	resp = env.AuthPUT("/admin/manufacturers/acme", map[string]interface{}{"kind": "acme"}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	resp = env.AuthPUT("/admin/manufacturers/pp-us", map[string]interface{}{
+		"kind": "pragmatic", "allowed_actions": []string{"jackpot"},
+	}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	resp = env.AuthPUT("/admin/manufacturers/pp-us", map[string]interface{}{
+		"kind": "pragmatic", "prefix": "/eu/pragmatic",
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Disabling needs a reason.
+	resp = env.AuthPOST("/admin/manufacturers/pp-eu/disable", map[string]string{}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	resp = env.AuthPOST("/admin/manufacturers/pp-eu/disable", map[string]string{"reason": "duplicate wins"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &m)
+	assert.False(t, m.Enabled)
+	require.NotNil(t, m.DisabledReason)
+	assert.Equal(t, "duplicate wins", *m.DisabledReason)
+
+	resp = env.AuthPOST("/admin/manufacturers/pp-eu/enable", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &m)
+	assert.True(t, m.Enabled)
+	assert.Nil(t, m.DisabledReason)
+
+	var list []manufacturer
+	resp = env.AuthGET("/admin/manufacturers", env.AdminToken("viewer"))
+	testutil.DecodeJSON(t, resp, &list)
+	require.Len(t, list, 1)
+	assert.Equal(t, "pp-eu", list[0].ID)
+
+	// Viewers cannot change the registry.
+	resp = env.AuthPOST("/admin/manufacturers/pp-eu/disable", map[string]string{"reason": "x"}, env.AdminToken("viewer"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+// ─── Withdrawal Payout Tests (4) ──────────────────────────────────────────
+
+type payoutSaga struct {
+	ID        uuid.UUID `json:"id"`
+	Status    string    `json:"status"`
+	Step      int       `json:"step"`
+	Attempts  int       `json:"attempts"`
+	LastError *string   `json:"last_error"`
+}
+
+// requestWithdrawal withdraws amount for the player and returns the pending
+// withdrawal's payment ID.
+func requestWithdrawal(t *testing.T, env *testutil.TestEnv, token string, playerID uuid.UUID, amount int64) uuid.UUID {
+	t.Helper()
+	resp := env.AuthPOST("/payments/withdraw", map[string]int64{"amount": amount}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var id uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT id FROM payments WHERE player_id = $1 AND type = 'withdrawal'
+		ORDER BY created_at DESC LIMIT 1`, playerID).Scan(&id))
+	return id
+}
+
+func paymentStatus(t *testing.T, env *testutil.TestEnv, id uuid.UUID) string {
+	t.Helper()
+	var status string
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `SELECT status FROM payments WHERE id = $1`, id).Scan(&status))
+	return status
+}
+
+func TestWithdrawalPayout_ApprovalPaysOut(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("payout@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	paymentID := requestWithdrawal(t, env, token, playerID, 4000)
+	adminToken := env.AdminToken("superadmin")
+
+	// No payout PSP is configured: the approval confirms a manual payout.
+	resp := env.AuthPOST("/admin/withdrawals/"+paymentID.String()+"/approve", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sg payoutSaga
+	testutil.DecodeJSON(t, resp, &sg)
+	assert.Equal(t, "completed", sg.Status)
+	assert.Equal(t, 3, sg.Step)
+
+	testutil.AssertBalance(t, env, playerID, 6000, 0, 0)
+	assert.Equal(t, "completed", paymentStatus(t, env, paymentID))
+
+	resp = env.AuthPOST("/admin/withdrawals/"+paymentID.String()+"/approve", nil, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestWithdrawalPayout_RefusedPayoutReleasesFunds(t *testing.T) {
+	env := testutil.NewPayoutTestEnv(t, "refused")
+	token, playerID := env.RegisterPlayer("payoutrefused@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	paymentID := requestWithdrawal(t, env, token, playerID, 4000)
+	adminToken := env.AdminToken("superadmin")
+
+	resp := env.AuthPOST("/admin/withdrawals/"+paymentID.String()+"/approve", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sg payoutSaga
+	testutil.DecodeJSON(t, resp, &sg)
+	assert.Equal(t, "compensated", sg.Status)
+	require.NotNil(t, sg.LastError)
+	assert.Contains(t, *sg.LastError, "Blocked Card")
+
+	// The reservation went back to the balance.
+	testutil.AssertBalance(t, env, playerID, 10000, 0, 0)
+	assert.Equal(t, "failed", paymentStatus(t, env, paymentID))
+
+	var detail struct {
+		Steps []struct {
+			Name      string `json:"name"`
+			Phase     string `json:"phase"`
+			Succeeded bool   `json:"succeeded"`
+		} `json:"steps"`
+	}
+	resp = env.AuthGET("/admin/sagas/"+sg.ID.String(), env.AdminToken("viewer"))
+	testutil.DecodeJSON(t, resp, &detail)
+	require.Len(t, detail.Steps, 3)
+	assert.Equal(t, "reserve", detail.Steps[0].Name)
+	assert.Equal(t, "payout", detail.Steps[1].Name)
+	assert.False(t, detail.Steps[1].Succeeded)
+	assert.Equal(t, "reserve", detail.Steps[2].Name)
+	assert.Equal(t, "compensation", detail.Steps[2].Phase)
+}
+
+func TestWithdrawalPayout_PSPOutageIsRetried(t *testing.T) {
+	env := testutil.NewPayoutTestEnv(t, "unavailable")
+	token, playerID := env.RegisterPlayer("payoutretry@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	paymentID := requestWithdrawal(t, env, token, playerID, 4000)
+	adminToken := env.AdminToken("superadmin")
+
+	// The PSP is down: the funds stay reserved while the payout waits.
+	resp := env.AuthPOST("/admin/withdrawals/"+paymentID.String()+"/approve", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sg payoutSaga
+	testutil.DecodeJSON(t, resp, &sg)
+	assert.Equal(t, "running", sg.Status)
+	assert.Equal(t, 1, sg.Step)
+	assert.Equal(t, 1, sg.Attempts)
+	testutil.AssertBalance(t, env, playerID, 6000, 0, 4000)
+	assert.Equal(t, "approved", paymentStatus(t, env, paymentID))
+
+	var waiting []payoutSaga
+	resp = env.AuthGET("/admin/sagas?status=running", env.AdminToken("viewer"))
+	testutil.DecodeJSON(t, resp, &waiting)
+	require.Len(t, waiting, 1)
+	assert.Equal(t, sg.ID, waiting[0].ID)
+
+	resp = env.AuthPOST("/admin/sagas/"+sg.ID.String()+"/retry", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &sg)
+	assert.Equal(t, "completed", sg.Status)
+	testutil.AssertBalance(t, env, playerID, 6000, 0, 0)
+	assert.Equal(t, "completed", paymentStatus(t, env, paymentID))
+
+	resp = env.AuthPOST("/admin/sagas/"+sg.ID.String()+"/retry", nil, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestWithdrawalPayout_TimedOutPayoutKeepsFundsReserved(t *testing.T) {
+	env := testutil.NewPayoutTestEnv(t, "unavailable", "unavailable")
+	token, playerID := env.RegisterPlayer("payouttimeout@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	paymentID := requestWithdrawal(t, env, token, playerID, 4000)
+	adminToken := env.AdminToken("superadmin")
+
+	resp := env.AuthPOST("/admin/withdrawals/"+paymentID.String()+"/approve", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sg payoutSaga
+	testutil.DecodeJSON(t, resp, &sg)
+	require.Equal(t, "running", sg.Status)
+
+	// Skip ahead to the last attempt: the PSP may have paid a payout it
+	// never answered, so giving up must not hand the funds back.
+	_, err := env.Pool.Exec(t.Context(), `UPDATE sagas SET attempts = 9 WHERE id = $1`, sg.ID)
+	require.NoError(t, err)
+	resp = env.AuthPOST("/admin/sagas/"+sg.ID.String()+"/retry", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &sg)
+	assert.Equal(t, "failed", sg.Status)
+	assert.Equal(t, 1, sg.Step)
+	require.NotNil(t, sg.LastError)
+
+	testutil.AssertBalance(t, env, playerID, 6000, 0, 4000)
+	assert.Equal(t, "approved", paymentStatus(t, env, paymentID))
+}
+
+// ─── Support Lookup Tests (2) ─────────────────────────────────────────────
+
+type supportLookup struct {
+	Transactions []struct {
+		ID                    uuid.UUID `json:"id"`
+		Type                  string    `json:"type"`
+		ExternalTransactionID *string   `json:"external_transaction_id"`
+	} `json:"transactions"`
+	Payments []struct {
+		ID     uuid.UUID `json:"id"`
+		Status string    `json:"status"`
+	} `json:"payments"`
+	SportsBets        []json.RawMessage `json:"sports_bets"`
+	ProviderCallbacks []json.RawMessage `json:"provider_callbacks"`
+}
+
+func TestSupportLookup_TracesWithdrawal(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("lookup@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	paymentID := requestWithdrawal(t, env, token, playerID, 4000)
+	resp := env.AuthPOST("/admin/withdrawals/"+paymentID.String()+"/approve", nil, env.AdminToken("superadmin"))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var externalID string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT external_transaction_id FROM payments WHERE id = $1`, paymentID).Scan(&externalID))
+
+	viewer := env.AdminToken("viewer")
+	for _, ref := range []string{externalID, paymentID.String()} {
+		resp = env.AuthGET("/admin/lookup?external_id="+ref, viewer)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var found supportLookup
+		testutil.DecodeJSON(t, resp, &found)
+
+		require.Len(t, found.Payments, 1, ref)
+		assert.Equal(t, paymentID, found.Payments[0].ID)
+		assert.Equal(t, "completed", found.Payments[0].Status)
+
+		// The reservation and the payout that burned it, not the deposit.
+		types := []string{}
+		for _, tx := range found.Transactions {
+			types = append(types, tx.Type)
+		}
+		assert.ElementsMatch(t, []string{"wallet_withdrawal", "wallet_withdrawal_processed"}, types, ref)
+		assert.Empty(t, found.SportsBets)
+		assert.Empty(t, found.ProviderCallbacks)
+	}
+}
+
+func TestSupportLookup_UnknownAndMissingID(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	viewer := env.AdminToken("viewer")
+
+	resp := env.AuthGET("/admin/lookup?external_id=no-such-ticket", viewer)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var found supportLookup
+	testutil.DecodeJSON(t, resp, &found)
+	assert.Empty(t, found.Transactions)
+	assert.Empty(t, found.Payments)
+
+	resp = env.AuthGET("/admin/lookup", viewer)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Liability Report Tests (2) ───────────────────────────────────────────
+
+type liabilityReport struct {
+	Currencies []struct {
+		Currency             string `json:"currency"`
+		Balance              int64  `json:"balance"`
+		ReservedBalance      int64  `json:"reserved_balance"`
+		BonusBalance         int64  `json:"bonus_balance"`
+		OpenPredictionStakes int    `json:"open_prediction_stakes"`
+		PredictionExposure   int64  `json:"prediction_exposure"`
+		TotalLiability       int64  `json:"total_liability"`
+		Reconciliation       struct {
+			Wallets  int              `json:"wallets"`
+			Drifted  int              `json:"drifted_wallets"`
+			Ledger   map[string]int64 `json:"ledger"`
+			Snapshot map[string]int64 `json:"snapshot"`
+		} `json:"reconciliation"`
+	} `json:"currencies"`
+}
+
+func TestLiabilityReport_SumsWalletsAndOpenStakes(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("liability@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	requestWithdrawal(t, env, token, playerID, 4000)
+	otherToken, otherID := env.RegisterPlayer("liability2@test.com", "securepass123", "EUR")
+	env.DirectDeposit(otherID, 5000)
+	marketID := env.SeedPredictionMarket("Liability market")
+	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": "yes", "amount": 1000,
+	}, otherToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/reports/liabilities", env.AdminToken("viewer"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report liabilityReport
+	testutil.DecodeJSON(t, resp, &report)
+
+	require.Len(t, report.Currencies, 1)
+	eur := report.Currencies[0]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.Equal(t, int64(10000), eur.Balance)
+	assert.Equal(t, int64(4000), eur.ReservedBalance)
+	assert.Equal(t, 1, eur.OpenPredictionStakes)
+	assert.Equal(t, int64(2500), eur.PredictionExposure)
+	assert.Equal(t, int64(16500), eur.TotalLiability)
+	assert.Equal(t, 2, eur.Reconciliation.Wallets)
+	assert.Zero(t, eur.Reconciliation.Drifted)
+	assert.Equal(t, eur.Reconciliation.Ledger, eur.Reconciliation.Snapshot)
+}
+
+func TestLiabilityReport_AsOfAndCSV(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("liabilitycsv@test.com", "securepass123", "EUR")
+	before := time.Now().Add(-time.Second).UTC().Format(time.RFC3339)
+	env.DirectDeposit(playerID, 10000)
+	viewer := env.AdminToken("viewer")
+
+	// Before the deposit there was nothing to owe.
+	resp := env.AuthGET("/admin/reports/liabilities?at="+before, viewer)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report liabilityReport
+	testutil.DecodeJSON(t, resp, &report)
+	assert.Empty(t, report.Currencies)
+
+	resp = env.AuthGET("/admin/reports/liabilities?format=csv", viewer)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "currency", records[0][1])
+	assert.Equal(t, "EUR", records[1][1])
+	assert.Equal(t, "10000", records[1][10])
+
+	resp = env.AuthGET("/admin/reports/liabilities?format=xlsx", viewer)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Admin Prediction Market Tests (3) ────────────────────────────────────
+
+type adminPredictionMarket struct {
+	ID                 uuid.UUID `json:"id"`
+	Status             string    `json:"status"`
+	WinningOutcomeID   *string   `json:"winning_outcome_id"`
+	ActiveStakes       int       `json:"active_stakes"`
+	ActiveStaked       int64     `json:"active_staked"`
+	WorstCaseLiability int64     `json:"worst_case_liability"`
+	Outcomes           []struct {
+		ID     string  `json:"id"`
+		Label  string  `json:"label"`
+		Odds   float64 `json:"odds"`
+		Staked int64   `json:"staked"`
+		Payout int64   `json:"payout"`
+	} `json:"outcomes"`
+}
+
+func TestAdminPredictions_CreateEditAndSummarise(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	admin := env.RegisterAdmin("predadmin@test.com", "securepass123", "admin")
+
+	resp := env.AuthPOST("/admin/predictions", map[string]interface{}{
+		"title": "One outcome", "outcomes": []map[string]interface{}{{"id": "yes", "label": "Yes", "odds": 2}},
+	}, admin)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	resp = env.AuthPOST("/admin/predictions", map[string]interface{}{
+		"title": "Admin market", "category": "politics",
+		"outcomes": []map[string]interface{}{
+			{"id": "yes", "label": "Yes", "odds": 2.5},
+			{"id": "no", "label": "No", "odds": 1.6},
+		},
+	}, admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var market adminPredictionMarket
+	testutil.DecodeJSON(t, resp, &market)
+	assert.Equal(t, "open", market.Status)
+	require.Len(t, market.Outcomes, 2)
+
+	token, playerID := env.RegisterPlayer("predadminplayer@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	stakePrediction(t, env, token, market.ID, "yes", 1000)
+	stakePrediction(t, env, token, market.ID, "no", 2000)
+
+	resp = env.AuthGET("/admin/predictions/"+market.ID.String(), env.AdminToken("viewer"))
+	testutil.DecodeJSON(t, resp, &market)
+	assert.Equal(t, 2, market.ActiveStakes)
+	assert.Equal(t, int64(3000), market.ActiveStaked)
+	assert.Equal(t, int64(2500), market.Outcomes[0].Payout)
+	assert.Equal(t, int64(3200), market.Outcomes[1].Payout)
+	assert.Equal(t, int64(200), market.WorstCaseLiability)
+
+	// A staked outcome can't be dropped, but it can be relabelled and repriced.
+	resp = env.AuthPUT("/admin/predictions/"+market.ID.String()+"/outcomes", map[string]interface{}{
+		"outcomes": []map[string]interface{}{
+			{"id": "yes", "label": "Yes", "odds": 2.5},
+			{"id": "maybe", "label": "Maybe", "odds": 3},
+		},
+	}, admin)
+	testutil.AssertErrorCode(t, resp, "CONFLICT")
+	resp = env.AuthPUT("/admin/predictions/"+market.ID.String()+"/outcomes", map[string]interface{}{
+		"outcomes": []map[string]interface{}{
+			{"id": "yes", "label": "Yes, before close", "odds": 2.2},
+			{"id": "no", "label": "No", "odds": 1.7},
+			{"id": "maybe", "label": "Maybe", "odds": 3},
+		},
+	}, admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &market)
+	require.Len(t, market.Outcomes, 3)
+	assert.Equal(t, "Yes, before close", market.Outcomes[0].Label)
+	assert.Equal(t, int64(2500), market.Outcomes[0].Payout, "stakes keep their locked odds")
+
+	resp = env.AuthGET("/admin/predictions?status=open", env.AdminToken("viewer"))
+	var markets []adminPredictionMarket
+	testutil.DecodeJSON(t, resp, &markets)
+	require.Len(t, markets, 1)
+	assert.Equal(t, market.ID, markets[0].ID)
+
+	resp = env.AuthPOST("/admin/predictions", map[string]interface{}{"title": "Viewer market"}, env.AdminToken("viewer"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestAdminPredictions_SettleWithAttestation(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predadminsettle@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
+	marketID := env.SeedPredictionMarket("Admin settled market")
+	stakePrediction(t, env, token, marketID, "yes", 1000)
+	path := "/admin/predictions/" + marketID.String()
+
+	resp := env.AuthPOST(path+"/close", nil, env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	var attestation map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(predictionAttestation), &attestation))
+	resp = env.AuthPOST(path+"/settle", map[string]interface{}{
+		"winning_outcome_id": "yes", "attestation": attestation,
+	}, env.AdminToken("admin"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "settlement is superadmin only")
+
+	superadmin := env.AdminToken("superadmin")
+	resp = env.AuthPOST(path+"/settle", map[string]interface{}{
+		"winning_outcome_id": "yes", "attestation": map[string]interface{}{"provider": "test-oracle"},
+	}, superadmin)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	resp = env.AuthPOST(path+"/settle", map[string]interface{}{
+		"winning_outcome_id": "maybe", "attestation": attestation,
+	}, superadmin)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	resp = env.AuthPOST(path+"/settle", map[string]interface{}{
+		"winning_outcome_id": "yes", "attestation": attestation,
+	}, superadmin)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Market     adminPredictionMarket `json:"market"`
+		Settlement struct {
+			Won  int   `json:"won"`
+			Paid int64 `json:"paid"`
+		} `json:"settlement"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, "settled", result.Market.Status)
+	require.NotNil(t, result.Market.WinningOutcomeID)
+	assert.Equal(t, "yes", *result.Market.WinningOutcomeID)
+	assert.Zero(t, result.Market.ActiveStakes)
+	assert.Equal(t, 1, result.Settlement.Won)
+	assert.Equal(t, int64(2500), result.Settlement.Paid)
+	testutil.AssertBalance(t, env, playerID, 2500, 0, 0)
+
+	resp = env.AuthPOST(path+"/void", nil, superadmin)
+	testutil.AssertErrorCode(t, resp, "CONFLICT")
+}
+
+func TestAdminPredictions_VoidRefundsStakes(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predadminvoid@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
+	marketID := env.SeedPredictionMarket("Admin voided market")
+	stakePrediction(t, env, token, marketID, "no", 700)
+	testutil.AssertBalance(t, env, playerID, 300, 0, 0)
+
+	resp := env.AuthPOST("/admin/predictions/"+marketID.String()+"/void", nil, env.AdminToken("superadmin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Market adminPredictionMarket `json:"market"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, "voided", result.Market.Status)
+	testutil.AssertBalance(t, env, playerID, 1000, 0, 0)
+
+	resp = env.AuthGET("/admin/predictions/"+uuid.NewString(), env.AdminToken("viewer"))
+	testutil.AssertErrorCode(t, resp, "NOT_FOUND")
+}
+
+// ─── Feed State Tests (1) ─────────────────────────────────────────────────
+
+func TestAdminFeeds_ListAndResync(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.Pool.Exec(ctx, `
+		INSERT INTO dome_feed_state (feed_name, status, last_sync_at, last_sync_count, error_message)
+		VALUES ('polymarket-sync', 'error', now(), 0, 'dome api returned 502'),
+		       ('price-updater', 'ok', now(), 42, NULL)`)
+	require.NoError(t, err)
+
+	resp := env.AuthGET("/admin/feeds", env.AdminToken("viewer"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var feeds []struct {
+		Name          string     `json:"name"`
+		Status        string     `json:"status"`
+		LastSyncAt    *time.Time `json:"last_sync_at"`
+		LastSyncCount int        `json:"last_sync_count"`
+		ErrorMessage  *string    `json:"error_message"`
+	}
+	testutil.DecodeJSON(t, resp, &feeds)
+	require.Len(t, feeds, 2)
+	assert.Equal(t, "polymarket-sync", feeds[0].Name)
+	assert.Equal(t, "error", feeds[0].Status)
+	require.NotNil(t, feeds[0].ErrorMessage)
+	assert.Equal(t, "dome api returned 502", *feeds[0].ErrorMessage)
+	assert.NotNil(t, feeds[0].LastSyncAt)
+	assert.Equal(t, 42, feeds[1].LastSyncCount)
+	assert.Nil(t, feeds[1].ErrorMessage)
+
+	resp = env.AuthPOST("/admin/feeds/price-updater/resync", nil, env.AdminToken("viewer"))
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/admin/feeds/no-such-feed/resync", nil, env.AdminToken("admin"))
+	testutil.AssertErrorCode(t, resp, "NOT_FOUND")
+
+	// The test environment runs without Dome credentials.
+	resp = env.AuthPOST("/admin/feeds/price-updater/resync", nil, env.AdminToken("admin"))
+	testutil.AssertErrorCode(t, resp, "FEED_UNAVAILABLE")
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/attaboy/platform/internal/auth"
 	"github.com/attaboy/platform/test/integration/testutil"
@@ -16,7 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// ─── Registration Tests (10) ────────────────────────────────────────────────
+// ─── Registration Tests (11) ────────────────────────────────────────────────
 
 func TestRegister_Success(t *testing.T) {
 	env := testutil.NewTestEnv(t)
@@ -57,6 +58,17 @@ func TestRegister_CreatesThreeRows(t *testing.T) {
 	assert.Equal(t, 1, profileCount)
 }
 
+func TestRegister_EmitsPlayerCreatedEvent(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("createdevent@test.com", "securepass123", "EUR")
+
+	var events int
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.player.created' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&events))
+	assert.Equal(t, 1, events)
+}
+
 func TestRegister_DefaultCurrency(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	_, playerID := env.RegisterPlayer("defcur@test.com", "securepass123", "")
@@ -244,6 +256,173 @@ func TestLogin_TokenRejectedOnAdminRoute(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
 
+// ─── Age Verification Tests (3) ────────────────────────────────────────────
+
+func TestRegister_UnderageBlocked(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	dob := time.Now().AddDate(-17, 0, 0).Format("2006-01-02")
+
+	resp := env.POST("/auth/register", map[string]string{
+		"email": "minor@test.com", "password": "securepass123", "currency": "EUR",
+		"date_of_birth": dob, "country": "DE",
+	}, "")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "UNDERAGE")
+}
+
+func TestRegister_JurisdictionMinimumAge(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	dob := time.Now().AddDate(-19, 0, 0).Format("2006-01-02")
+
+	resp := env.POST("/auth/register", map[string]string{
+		"email": "nineteen-gr@test.com", "password": "securepass123", "currency": "EUR",
+		"date_of_birth": dob, "country": "GR",
+	}, "")
+	testutil.AssertErrorCode(t, resp, "UNDERAGE")
+	resp.Body.Close()
+
+	resp = env.POST("/auth/register", map[string]string{
+		"email": "nineteen-de@test.com", "password": "securepass123", "currency": "EUR",
+		"date_of_birth": dob, "country": "DE",
+	}, "")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestRegister_InvalidDateOfBirth(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+
+	resp := env.POST("/auth/register", map[string]string{
+		"email": "baddob@test.com", "password": "securepass123", "currency": "EUR",
+		"date_of_birth": "31/12/1990",
+	}, "")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Terms Acceptance Tests (2) ────────────────────────────────────────────
+
+// publishTerms drafts and publishes the next version of a document kind.
+func publishTerms(t *testing.T, env *testutil.TestEnv, kind, title string) (id, hash string) {
+	t.Helper()
+	admin := env.AdminToken("admin")
+	resp := env.AuthPOST("/admin/terms", map[string]string{"kind": kind, "title": title, "body": "Full text of " + title}, admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var doc struct {
+		ID          string `json:"id"`
+		ContentHash string `json:"content_hash"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/admin/terms/"+doc.ID+"/publish", nil, admin)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return doc.ID, doc.ContentHash
+}
+
+func TestTerms_LoginRequiresAcceptanceOfNewVersion(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("terms@test.com", "securepass123", "EUR")
+	docID, hash := publishTerms(t, env, "terms", "General Terms v1")
+
+	resp := env.POST("/auth/login", map[string]string{
+		"email": "terms@test.com", "password": "securepass123",
+	}, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var login struct {
+		Token        string `json:"token"`
+		PendingTerms []struct {
+			ID      string `json:"id"`
+			Version int    `json:"version"`
+		} `json:"pending_terms"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&login))
+	resp.Body.Close()
+	require.Len(t, login.PendingTerms, 1)
+	assert.Equal(t, docID, login.PendingTerms[0].ID)
+	assert.Equal(t, 1, login.PendingTerms[0].Version)
+
+	// Money-moving routes stay closed until accepted
+	resp = env.AuthPOST("/wallet/convert", map[string]interface{}{"from": "EUR", "to": "USD", "amount": 100}, login.Token)
+	testutil.AssertErrorCode(t, resp, "TERMS_ACCEPTANCE_REQUIRED")
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/terms/"+docID+"/accept", nil, login.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/wallet/convert", map[string]interface{}{"from": "EUR", "to": "USD", "amount": 100}, login.Token)
+	assert.NotEqual(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	// Evidence: timestamped acceptance with the document hash
+	resp = env.AuthGET("/admin/players/"+playerID.String()+"/terms-acceptances", env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var acceptances []struct {
+		DocumentID  string `json:"document_id"`
+		ContentHash string `json:"content_hash"`
+		AcceptedAt  string `json:"accepted_at"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&acceptances))
+	resp.Body.Close()
+	require.Len(t, acceptances, 1)
+	assert.Equal(t, docID, acceptances[0].DocumentID)
+	assert.Equal(t, hash, acceptances[0].ContentHash)
+	assert.NotEmpty(t, acceptances[0].AcceptedAt)
+
+	// A new version re-opens the requirement; the old one can no longer be accepted
+	newID, _ := publishTerms(t, env, "terms", "General Terms v2")
+	resp = env.AuthGET("/terms/pending", login.Token)
+	var pending []struct {
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pending))
+	resp.Body.Close()
+	require.Len(t, pending, 1)
+	assert.Equal(t, newID, pending[0].ID)
+	assert.Equal(t, 2, pending[0].Version)
+
+	resp = env.AuthPOST("/terms/"+docID+"/accept", nil, login.Token)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestTerms_BonusTermsTrackedSeparately(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("bonusterms@test.com", "securepass123", "EUR")
+	termsID, _ := publishTerms(t, env, "terms", "General Terms")
+	bonusID, _ := publishTerms(t, env, "bonus_terms", "Bonus Terms")
+
+	resp := env.AuthPOST("/terms/"+termsID+"/accept", nil, token)
+	resp.Body.Close()
+
+	resp = env.AuthGET("/terms/pending", token)
+	var pending []struct {
+		ID   string `json:"id"`
+		Kind string `json:"kind"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pending))
+	resp.Body.Close()
+	require.Len(t, pending, 1)
+	assert.Equal(t, bonusID, pending[0].ID)
+	assert.Equal(t, "bonus_terms", pending[0].Kind)
+
+	resp = env.GET("/terms")
+	var current []struct {
+		Kind string `json:"kind"`
+		Body string `json:"body"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&current))
+	resp.Body.Close()
+	assert.Len(t, current, 2)
+}
+
 // ─── JWT Middleware Tests (7) ───────────────────────────────────────────────
 
 func TestPlayerRoute_NoToken(t *testing.T) {
@@ -0,0 +1,191 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─── Game Session Tests (1) ───────────────────────────────────────────────
+
+func TestCasinoSessions_GroupsRoundsByGameAndGap(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("casinosessions@test.com", "securepass123", "EUR")
+	_, otherID := env.RegisterPlayer("casinosessions-other@test.com", "securepass123", "EUR")
+
+	_, err := env.Pool.Exec(t.Context(), `INSERT INTO game_manufacturers (id, name) VALUES ('BS', 'betsolutions')`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO games (manufacturer_id, external_game_id, name, category)
+		VALUES ('BS', 'slot-1', 'Lucky Slot', 'slots')`)
+	require.NoError(t, err)
+
+	// Two slot rounds ten minutes apart make one session; a third two hours
+	// earlier is its own session. The open round on the other game is a
+	// session still in progress.
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO game_rounds (player_id, manufacturer_id, round_id, game_id, currency, status,
+			stake_total, win_total, opened_at, last_activity_at, closed_at)
+		VALUES
+			($1, 'betsolutions', 'r1', 'slot-1', 'EUR', 'closed', 100, 0,   now() - interval '150 minutes', now() - interval '149 minutes', now() - interval '149 minutes'),
+			($1, 'betsolutions', 'r2', 'slot-1', 'EUR', 'closed', 100, 250, now() - interval '20 minutes',  now() - interval '19 minutes',  now() - interval '19 minutes'),
+			($1, 'betsolutions', 'r3', 'slot-1', 'EUR', 'closed', 200, 0,   now() - interval '9 minutes',   now() - interval '8 minutes',   now() - interval '8 minutes'),
+			($1, 'betsolutions', 'r4', 'game-x', 'EUR', 'open',   50,  0,   now() - interval '5 minutes',   now() - interval '5 minutes',   NULL),
+			($2, 'betsolutions', 'r5', 'slot-1', 'EUR', 'closed', 500, 0,   now() - interval '1 minute',    now() - interval '1 minute',    now())`,
+		playerID, otherID)
+	require.NoError(t, err)
+
+	resp := env.AuthGET("/casino/sessions", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Sessions []struct {
+			Provider *string `json:"provider"`
+			GameID   *string `json:"game_id"`
+			GameName *string `json:"game_name"`
+			EndedAt  *string `json:"ended_at"`
+			Rounds   int     `json:"rounds"`
+			TotalBet int64   `json:"total_bet"`
+			TotalWin int64   `json:"total_win"`
+			Net      int64   `json:"net"`
+		} `json:"sessions"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	require.Len(t, result.Sessions, 3)
+
+	live := result.Sessions[0]
+	require.NotNil(t, live.GameID)
+	assert.Equal(t, "game-x", *live.GameID)
+	assert.Nil(t, live.GameName)
+	assert.Nil(t, live.EndedAt)
+	assert.Equal(t, int64(-50), live.Net)
+
+	recent := result.Sessions[1]
+	require.NotNil(t, recent.GameName)
+	assert.Equal(t, "Lucky Slot", *recent.GameName)
+	require.NotNil(t, recent.Provider)
+	assert.Equal(t, "betsolutions", *recent.Provider)
+	assert.NotNil(t, recent.EndedAt)
+	assert.Equal(t, 2, recent.Rounds)
+	assert.Equal(t, int64(300), recent.TotalBet)
+	assert.Equal(t, int64(250), recent.TotalWin)
+	assert.Equal(t, int64(-50), recent.Net)
+
+	assert.Equal(t, 1, result.Sessions[2].Rounds)
+	assert.Equal(t, int64(-100), result.Sessions[2].Net)
+}
+
+// ─── Lobby Tests (2) ──────────────────────────────────────────────────────
+
+func TestCasinoLobby_RecentAndRecommendedGames(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	tokenA, _ := env.RegisterPlayer("lobby-a@test.com", "securepass123", "EUR")
+	tokenB, _ := env.RegisterPlayer("lobby-b@test.com", "securepass123", "EUR")
+	tokenC, _ := env.RegisterPlayer("lobby-c@test.com", "securepass123", "EUR")
+
+	_, err := env.Pool.Exec(t.Context(), `INSERT INTO game_manufacturers (id, name) VALUES ('BS', 'betsolutions')`)
+	require.NoError(t, err)
+	seedGame := func(name, category string) uuid.UUID {
+		t.Helper()
+		var id uuid.UUID
+		require.NoError(t, env.Pool.QueryRow(t.Context(), `
+			INSERT INTO games (manufacturer_id, external_game_id, name, category)
+			VALUES ('BS', $1, $1, $2) RETURNING id`, name, category).Scan(&id))
+		return id
+	}
+	slot := seedGame("Lucky Slot", "slots")
+	roulette := seedGame("Roulette", "table")
+	otherSlot := seedGame("Gold Slot", "slots")
+	liveGame := seedGame("Live Blackjack", "live")
+
+	launch := func(token string, gameID uuid.UUID) {
+		t.Helper()
+		resp := env.AuthPOST("/casino/games/"+gameID.String()+"/launch", nil, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var launched struct {
+			SessionToken     string     `json:"session_token"`
+			SessionExpiresAt *time.Time `json:"session_expires_at"`
+		}
+		testutil.DecodeJSON(t, resp, &launched)
+		assert.NotEmpty(t, launched.SessionToken)
+		assert.NotNil(t, launched.SessionExpiresAt)
+	}
+	launch(tokenA, slot)
+	launch(tokenA, slot)
+	launch(tokenB, slot)
+	launch(tokenB, roulette)
+	launch(tokenC, liveGame)
+
+	resp := env.AuthPOST("/casino/games/"+uuid.New().String()+"/launch", nil, tokenA)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	type game struct {
+		ID       uuid.UUID `json:"id"`
+		Provider *string   `json:"provider"`
+		Reason   string    `json:"reason"`
+		Players  int       `json:"players"`
+		Launches int       `json:"launches"`
+	}
+	var recommended struct {
+		Games []game `json:"games"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/casino/games/recommended", tokenA), &recommended)
+	require.Len(t, recommended.Games, 3)
+	assert.Equal(t, roulette, recommended.Games[0].ID)
+	assert.Equal(t, "similar_players", recommended.Games[0].Reason)
+	assert.Equal(t, otherSlot, recommended.Games[1].ID)
+	assert.Equal(t, "same_category", recommended.Games[1].Reason)
+	assert.Equal(t, liveGame, recommended.Games[2].ID)
+	assert.Equal(t, "popular", recommended.Games[2].Reason)
+	assert.Equal(t, 1, recommended.Games[2].Players)
+
+	// A launch refreshes the cached lists
+	launch(tokenA, otherSlot)
+	var recent struct {
+		Games []game `json:"games"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/casino/games/recent", tokenA), &recent)
+	require.Len(t, recent.Games, 2)
+	assert.Equal(t, otherSlot, recent.Games[0].ID)
+	assert.Equal(t, slot, recent.Games[1].ID)
+	assert.Equal(t, 2, recent.Games[1].Launches)
+	require.NotNil(t, recent.Games[1].Provider)
+	assert.Equal(t, "betsolutions", *recent.Games[1].Provider)
+
+	testutil.DecodeJSON(t, env.AuthGET("/casino/games/recommended", tokenA), &recommended)
+	require.Len(t, recommended.Games, 2)
+	assert.Equal(t, roulette, recommended.Games[0].ID)
+}
+
+func TestCasinoLobby_SelfExcludedPlayerCannotLaunch(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("lobby-excluded@test.com", "securepass123", "EUR")
+
+	_, err := env.Pool.Exec(t.Context(), `INSERT INTO game_manufacturers (id, name) VALUES ('BS', 'betsolutions')`)
+	require.NoError(t, err)
+	var gameID uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		INSERT INTO games (manufacturer_id, external_game_id, name, category)
+		VALUES ('BS', 'slot-1', 'Lucky Slot', 'slots') RETURNING id`).Scan(&gameID))
+
+	resp := env.AuthPOST("/players/me/self-exclusion", map[string]interface{}{"duration_days": 180}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Launching would issue a wallet session token, so it is play
+	resp = env.AuthPOST("/casino/games/"+gameID.String()+"/launch", nil, token)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "ACCOUNT_INACTIVE")
+
+	var launches int
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT COUNT(*) FROM game_launches WHERE game_id = $1`, gameID).Scan(&launches))
+	assert.Zero(t, launches)
+}
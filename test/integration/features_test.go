@@ -3,10 +3,19 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/attaboy/platform/test/integration/testutil"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -207,6 +216,328 @@ func TestQuests_MultipleQuestsProgress(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, resp2.StatusCode)
 }
 
+// ─── Quest Progress Automation Tests (2) ──────────────────────────────────
+
+func TestQuestProgress_DepositsCompleteQuest(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("questauto@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	resp := env.AuthPOST("/admin/quests", map[string]interface{}{
+		"name": "Two deposits", "type": "deposit_count", "target_progress": 2,
+		"reward_amount": 500, "reward_currency": "EUR",
+		"criteria": map[string]interface{}{"min_amount": 1000},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &created)
+
+	resp = env.AuthPOST("/admin/quests", map[string]interface{}{
+		"name": "Bad criteria", "type": "deposit_count", "target_progress": 2,
+		"reward_amount": 500, "criteria": map[string]interface{}{"signal": "video"},
+	}, adminToken)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	deposit := func(ref string, amount int64) {
+		ctx := context.Background()
+		tx, err := env.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+		_, err = env.LedgerEngine().ExecuteDeposit(ctx, tx, domain.DepositParams{
+			PlayerID: playerID, Amount: amount, ExternalTransactionID: ref,
+			ManufacturerID: "stripe", SubTransactionID: "1",
+		})
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit(ctx))
+	}
+
+	type questState struct {
+		Name     string `json:"name"`
+		Progress int    `json:"progress"`
+		Status   string `json:"status"`
+	}
+	quest := func() questState {
+		resp := env.AuthGET("/quests", token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var quests []questState
+		testutil.DecodeJSON(t, resp, &quests)
+		require.Len(t, quests, 1)
+		return quests[0]
+	}
+
+	// Below the quest's minimum: does not count.
+	deposit("quest-dep-small", 500)
+	deposit("quest-dep-1", 2000)
+	// A redelivered event is applied once.
+	env.RunQuestProgress()
+	env.RunQuestProgress()
+	assert.Equal(t, questState{Name: "Two deposits", Progress: 1, Status: "active"}, quest())
+
+	deposit("quest-dep-2", 2000)
+	deposit("quest-dep-3", 2000)
+	env.RunQuestProgress()
+	assert.Equal(t, questState{Name: "Two deposits", Progress: 2, Status: "completed"}, quest())
+
+	var completions int
+	require.NoError(t, env.Pool.QueryRow(context.Background(), `
+		SELECT count(*) FROM event_outbox WHERE "eventType" = 'pam.quest.completed' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&completions))
+	assert.Equal(t, 1, completions)
+
+	resp = env.AuthPOST("/quests/"+created.ID+"/claim", nil, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestQuestProgress_EngagementSignals(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("questengage@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/admin/quests", map[string]interface{}{
+		"name": "Watch ten minutes", "type": "engagement", "target_progress": 10,
+		"reward_amount": 100, "reward_currency": "EUR",
+		"criteria": map[string]interface{}{"signal": "video"},
+	}, env.AdminToken("admin"))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	for _, signal := range []map[string]interface{}{
+		{"type": "video", "value": 6},
+		{"type": "social", "value": 20},
+		{"type": "video", "value": 6},
+	} {
+		resp := env.AuthPOST("/engagement/signal", signal, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+	env.RunQuestProgress()
+
+	resp = env.AuthGET("/quests", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quests []struct {
+		Progress int    `json:"progress"`
+		Status   string `json:"status"`
+	}
+	testutil.DecodeJSON(t, resp, &quests)
+	require.Len(t, quests, 1)
+	assert.Equal(t, 10, quests[0].Progress, "capped at the target")
+	assert.Equal(t, "completed", quests[0].Status)
+}
+
+// ─── Recurring Quest Tests (2) ────────────────────────────────────────────
+
+func TestRecurringQuest_ResetKeepsClaimHistory(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("questdaily@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/admin/quests", map[string]interface{}{
+		"name": "Daily video", "type": "engagement", "target_progress": 5,
+		"reward_amount": 100, "reward_currency": "EUR", "recurrence": "daily",
+		"criteria": map[string]interface{}{"signal": "video"},
+	}, env.AdminToken("admin"))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &created)
+
+	type questState struct {
+		Progress        int        `json:"progress"`
+		Status          string     `json:"status"`
+		Recurrence      string     `json:"recurrence"`
+		ResetsAt        *time.Time `json:"resets_at"`
+		ResetsInSeconds *int64     `json:"resets_in_seconds"`
+	}
+	quest := func() questState {
+		resp := env.AuthGET("/quests", token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var quests []questState
+		testutil.DecodeJSON(t, resp, &quests)
+		require.Len(t, quests, 1)
+		return quests[0]
+	}
+
+	resp = env.AuthPOST("/engagement/signal", map[string]interface{}{"type": "video", "value": 5}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	env.RunQuestProgress()
+
+	q := quest()
+	assert.Equal(t, "completed", q.Status)
+	assert.Equal(t, "daily", q.Recurrence)
+	require.NotNil(t, q.ResetsAt)
+	require.NotNil(t, q.ResetsInSeconds)
+	assert.LessOrEqual(t, *q.ResetsInSeconds, int64(24*60*60))
+	assert.Zero(t, q.ResetsAt.Hour(), "daily quests reset at midnight UTC")
+
+	resp = env.AuthPOST("/quests/"+created.ID+"/claim", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Not due yet: nothing resets.
+	assert.Equal(t, 0, env.ResetDueQuests())
+
+	_, err := env.Pool.Exec(context.Background(),
+		`UPDATE quests SET next_reset_at = now() - interval '1 minute' WHERE id = $1`, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, env.ResetDueQuests())
+
+	q = quest()
+	assert.Equal(t, 0, q.Progress)
+	assert.Equal(t, "not_started", q.Status)
+	require.NotNil(t, q.ResetsAt)
+	assert.True(t, q.ResetsAt.After(time.Now()))
+
+	resp = env.AuthGET("/quests/history", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var history []struct {
+		QuestID   string     `json:"quest_id"`
+		Progress  int        `json:"progress"`
+		Status    string     `json:"status"`
+		ClaimedAt *time.Time `json:"claimed_at"`
+	}
+	testutil.DecodeJSON(t, resp, &history)
+	require.Len(t, history, 1)
+	assert.Equal(t, created.ID, history[0].QuestID)
+	assert.Equal(t, 5, history[0].Progress)
+	assert.Equal(t, "claimed", history[0].Status)
+	assert.NotNil(t, history[0].ClaimedAt)
+}
+
+func TestRecurringQuest_RejectsBadSchedule(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+
+	for _, body := range []map[string]interface{}{
+		{"name": "Monthly", "reward_amount": 100, "recurrence": "monthly"},
+		{"name": "Bad cron", "reward_amount": 100, "recurrence": "cron", "recurrence_cron": "0 0 * *"},
+		{"name": "Stray cron", "reward_amount": 100, "recurrence": "weekly", "recurrence_cron": "0 0 * * 1"},
+	} {
+		resp := env.AuthPOST("/admin/quests", body, adminToken)
+		testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	}
+
+	resp := env.AuthPOST("/admin/quests", map[string]interface{}{
+		"name": "Friday night", "reward_amount": 100, "recurrence": "cron", "recurrence_cron": "0 18 * * 5",
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = env.AuthGET("/admin/quests", adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quests []struct {
+		Recurrence  string     `json:"recurrence"`
+		NextResetAt *time.Time `json:"next_reset_at"`
+	}
+	testutil.DecodeJSON(t, resp, &quests)
+	require.Len(t, quests, 1)
+	assert.Equal(t, "cron", quests[0].Recurrence)
+	require.NotNil(t, quests[0].NextResetAt)
+	assert.Equal(t, time.Friday, quests[0].NextResetAt.UTC().Weekday())
+}
+
+// ─── Quest Chain Tests (2) ────────────────────────────────────────────────
+
+func TestQuestChain_LockedUntilPrerequisiteClaimed(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("questchain@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	first := env.SeedQuest("Chapter one", 1, 100)
+
+	resp := env.AuthPOST("/admin/quests", map[string]interface{}{
+		"name": "Chapter two", "target_progress": 1, "reward_amount": 200, "reward_currency": "EUR",
+		"prerequisite_ids": []string{first.String()},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &created)
+
+	type questState struct {
+		ID       string   `json:"id"`
+		Status   string   `json:"status"`
+		LockedBy []string `json:"locked_by"`
+	}
+	second := func() questState {
+		resp := env.AuthGET("/quests", token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var quests []questState
+		testutil.DecodeJSON(t, resp, &quests)
+		for _, q := range quests {
+			if q.ID == created.ID {
+				return q
+			}
+		}
+		t.Fatalf("quest %s not listed", created.ID)
+		return questState{}
+	}
+	assert.Equal(t, questState{ID: created.ID, Status: "locked", LockedBy: []string{first.String()}}, second())
+
+	complete := func(questID string) {
+		_, err := env.Pool.Exec(context.Background(), `
+			INSERT INTO player_quest_progress (player_id, quest_id, progress, status)
+			VALUES ($1, $2, 1, 'completed')`, playerID, questID)
+		require.NoError(t, err)
+	}
+	complete(created.ID)
+	resp = env.AuthPOST("/quests/"+created.ID+"/claim", nil, token)
+	testutil.AssertErrorCode(t, resp, "QUEST_LOCKED")
+
+	complete(first.String())
+	resp = env.AuthPOST("/quests/"+first.String()+"/claim", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	assert.Equal(t, questState{ID: created.ID, Status: "completed"}, second())
+	resp = env.AuthPOST("/quests/"+created.ID+"/claim", nil, token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestQuestChain_RejectsCycles(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	a := env.SeedQuest("Quest A", 1, 100)
+	b := env.SeedQuest("Quest B", 1, 100)
+	c := env.SeedQuest("Quest C", 1, 100)
+
+	put := func(quest uuid.UUID, prerequisites ...uuid.UUID) *http.Response {
+		return env.AuthPUT("/admin/quests/"+quest.String()+"/prerequisites",
+			map[string]interface{}{"prerequisite_ids": prerequisites}, adminToken)
+	}
+
+	// c requires b, b requires a
+	resp := put(b, a)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = put(c, b)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	testutil.AssertErrorCode(t, put(a, c), "VALIDATION_ERROR")
+	testutil.AssertErrorCode(t, put(a, a), "VALIDATION_ERROR")
+	testutil.AssertErrorCode(t, put(a, uuid.New()), "VALIDATION_ERROR")
+	testutil.AssertErrorCode(t, put(uuid.New(), a), "NOT_FOUND")
+
+	resp = env.AuthGET("/admin/quests", adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quests []struct {
+		ID              string   `json:"id"`
+		PrerequisiteIDs []string `json:"prerequisite_ids"`
+	}
+	testutil.DecodeJSON(t, resp, &quests)
+	graph := map[string][]string{}
+	for _, q := range quests {
+		graph[q.ID] = q.PrerequisiteIDs
+	}
+	assert.Empty(t, graph[a.String()], "rejected edits leave the graph unchanged")
+	assert.Equal(t, []string{a.String()}, graph[b.String()])
+	assert.Equal(t, []string{b.String()}, graph[c.String()])
+}
+
 // ─── Engagement Tests (4) ─────────────────────────────────────────────────
 
 func TestEngagement_EmptyDefaults(t *testing.T) {
@@ -381,7 +712,7 @@ func TestEngagement_ZeroValueSignal(t *testing.T) {
 		"expected 200 or 400, got %d", resp.StatusCode)
 }
 
-// ─── Prediction Tests (6) ─────────────────────────────────────────────────
+// ─── Prediction Tests (7) ─────────────────────────────────────────────────
 
 func TestPredictions_ListMarkets(t *testing.T) {
 	env := testutil.NewTestEnv(t)
@@ -419,7 +750,8 @@ func TestPredictions_GetMarket(t *testing.T) {
 
 func TestPredictions_PlaceStake(t *testing.T) {
 	env := testutil.NewTestEnv(t)
-	token, _ := env.RegisterPlayer("predstake@test.com", "securepass123", "EUR")
+	token, playerID := env.RegisterPlayer("predstake@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 2000)
 	marketID := env.SeedPredictionMarket("Stake market")
 
 	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
@@ -430,10 +762,34 @@ func TestPredictions_PlaceStake(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
 	var result struct {
-		ID string `json:"id"`
+		ID      string  `json:"id"`
+		Odds    float64 `json:"odds"`
+		Balance int64   `json:"balance"`
 	}
 	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
 	assert.NotEmpty(t, result.ID)
+	assert.Equal(t, 2.5, result.Odds)
+	assert.Equal(t, int64(1500), result.Balance)
+	testutil.AssertBalance(t, env, playerID, 1500, 0, 0)
+}
+
+func TestPredictions_StakeInsufficientBalance(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predbroke@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 300)
+	marketID := env.SeedPredictionMarket("Broke market")
+
+	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": "yes", "amount": 500,
+	}, token)
+	testutil.AssertErrorCode(t, resp, "INSUFFICIENT_BALANCE")
+
+	// Nothing was staked or debited.
+	var count int
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		"SELECT COUNT(*) FROM prediction_stakes WHERE player_id = $1", playerID).Scan(&count))
+	assert.Zero(t, count)
+	testutil.AssertBalance(t, env, playerID, 300, 0, 0)
 }
 
 func TestPredictions_ClosedMarketRejects(t *testing.T) {
@@ -464,7 +820,8 @@ func TestPredictions_EmptyPositions(t *testing.T) {
 
 func TestPredictions_AfterStakeShowsPosition(t *testing.T) {
 	env := testutil.NewTestEnv(t)
-	token, _ := env.RegisterPlayer("predpos@test.com", "securepass123", "EUR")
+	token, playerID := env.RegisterPlayer("predpos@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
 	marketID := env.SeedPredictionMarket("Position market")
 
 	env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
@@ -511,6 +868,7 @@ func TestPredictions_StakeZeroAmount(t *testing.T) {
 func TestPredictions_MultipleStakesSameMarket(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	token, playerID := env.RegisterPlayer("predmulti@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
 	marketID := env.SeedPredictionMarket("Multi Stake Market")
 
 	r1 := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
@@ -534,6 +892,7 @@ func TestPredictions_MultipleStakesSameMarket(t *testing.T) {
 func TestPredictions_StakeRecordsOutcome(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	token, playerID := env.RegisterPlayer("predoutcome@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
 	marketID := env.SeedPredictionMarket("Outcome Market")
 
 	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
@@ -553,7 +912,8 @@ func TestPredictions_StakeRecordsOutcome(t *testing.T) {
 
 func TestPredictions_PositionIsolation(t *testing.T) {
 	env := testutil.NewTestEnv(t)
-	token1, _ := env.RegisterPlayer("prediso1@test.com", "securepass123", "EUR")
+	token1, player1 := env.RegisterPlayer("prediso1@test.com", "securepass123", "EUR")
+	env.DirectDeposit(player1, 1000)
 	token2, _ := env.RegisterPlayer("prediso2@test.com", "securepass123", "EUR")
 	marketID := env.SeedPredictionMarket("Isolation Market")
 
@@ -623,6 +983,429 @@ func TestPredictions_ListMarketsDefault(t *testing.T) {
 	assert.GreaterOrEqual(t, len(markets), 3)
 }
 
+// ─── Prediction Market Search Tests (2) ───────────────────────────────────
+
+// seedListedMarket seeds an open market with a category, tags, close time
+// and traded volume.
+func seedListedMarket(t *testing.T, env *testutil.TestEnv, title, category, tags string, closeIn time.Duration, volume int64) uuid.UUID {
+	t.Helper()
+	marketID := env.SeedPredictionMarket(title)
+	_, err := env.Pool.Exec(t.Context(), `
+		UPDATE prediction_markets
+		SET category = $2, tags = $3::jsonb, close_at = now() + $4 * interval '1 second', volume_minor = $5
+		WHERE id = $1`, marketID, category, tags, int64(closeIn.Seconds()), volume)
+	require.NoError(t, err)
+	return marketID
+}
+
+func listMarketTitles(t *testing.T, env *testutil.TestEnv, token, query string) []string {
+	t.Helper()
+	resp := env.AuthGET("/predictions/markets?"+query, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode, query)
+	var markets []struct {
+		Title string `json:"title"`
+	}
+	testutil.DecodeJSON(t, resp, &markets)
+	titles := []string{}
+	for _, m := range markets {
+		titles = append(titles, m.Title)
+	}
+	return titles
+}
+
+func TestPredictionMarkets_FilterSearchSort(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("predsearch@test.com", "securepass123", "EUR")
+	seedListedMarket(t, env, "Senate majority after the midterms", "politics", `["elections"]`, 72*time.Hour, 5000)
+	seedListedMarket(t, env, "Governor race in Ohio", "politics", `["elections", "us"]`, 2*time.Hour, 90000)
+	seedListedMarket(t, env, "Bitcoin above 100k", "crypto", `["bitcoin"]`, 24*time.Hour, 20000)
+
+	assert.ElementsMatch(t, []string{"Senate majority after the midterms", "Governor race in Ohio"},
+		listMarketTitles(t, env, token, "category=politics"))
+	assert.Equal(t, []string{"Governor race in Ohio"}, listMarketTitles(t, env, token, "tag=us"))
+	assert.Equal(t, []string{"Senate majority after the midterms"}, listMarketTitles(t, env, token, "q=midterms"))
+	assert.Equal(t, []string{"Governor race in Ohio", "Bitcoin above 100k"},
+		listMarketTitles(t, env, token, "close_before="+url.QueryEscape(time.Now().Add(48*time.Hour).Format(time.RFC3339))+"&sort=closing"))
+
+	assert.Equal(t, []string{"Governor race in Ohio", "Bitcoin above 100k", "Senate majority after the midterms"},
+		listMarketTitles(t, env, token, "sort=volume"))
+	assert.Equal(t, []string{"Bitcoin above 100k"}, listMarketTitles(t, env, token, "sort=volume&limit=1&offset=1"))
+}
+
+func TestPredictionMarkets_CategoriesAndBadParams(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predcategories@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	seedListedMarket(t, env, "Election A", "politics", `[]`, time.Hour, 0)
+	seedListedMarket(t, env, "Election B", "politics", `[]`, time.Hour, 0)
+	cryptoID := seedListedMarket(t, env, "Coin C", "crypto", `[]`, time.Hour, 0)
+
+	resp := env.AuthGET("/predictions/categories", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var categories []struct {
+		Category string `json:"category"`
+		Markets  int    `json:"markets"`
+	}
+	testutil.DecodeJSON(t, resp, &categories)
+	require.Len(t, categories, 2)
+	assert.Equal(t, "politics", categories[0].Category)
+	assert.Equal(t, 2, categories[0].Markets)
+
+	// Stakes add to a house market's volume.
+	stakePrediction(t, env, token, cryptoID, "yes", 1500)
+	resp = env.AuthGET("/predictions/markets/"+cryptoID.String(), token)
+	var market struct {
+		Volume int64 `json:"volume"`
+	}
+	testutil.DecodeJSON(t, resp, &market)
+	assert.Equal(t, int64(1500), market.Volume)
+
+	resp = env.AuthGET("/predictions/markets?sort=popular", token)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Prediction Settlement Tests (2) ──────────────────────────────────────
+
+// predictionAttestation is a valid oracle attestation for a settled market.
+const predictionAttestation = `{"provider": "test-oracle", "attestation_id": "att-1",
+	"digest": "0123456789abcdef0123456789abcdef", "issued_at": "2026-01-01T00:00:00Z"}`
+
+func TestPredictionSettlement_PaysWinnersAtLockedOdds(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	winnerToken, winnerID := env.RegisterPlayer("predwinner@test.com", "securepass123", "EUR")
+	loserToken, loserID := env.RegisterPlayer("predloser@test.com", "securepass123", "EUR")
+	env.DirectDeposit(winnerID, 1000)
+	env.DirectDeposit(loserID, 1000)
+	marketID := env.SeedPredictionMarket("Settled market")
+
+	stake := func(token, outcome string) *http.Response {
+		return env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+			"outcome_id": outcome, "amount": 1000,
+		}, token)
+	}
+	resp := stake(winnerToken, "maybe")
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+	resp = stake(winnerToken, "yes")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// The odds move after the stake: it still pays at 2.50.
+	_, err := env.Pool.Exec(t.Context(), `
+		UPDATE prediction_markets SET outcomes = jsonb_set(outcomes, '{0,odds}', '1.2') WHERE id = $1`, marketID)
+	require.NoError(t, err)
+	resp = stake(loserToken, "no")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	svc := service.NewPredictionSettlementService(env.Pool, env.LedgerEngine(), repository.NewOutboxRepository(), slog.Default())
+	_, err = svc.SettleMarket(t.Context(), marketID)
+	require.Error(t, err, "an open market does not pay out")
+
+	_, err = env.Pool.Exec(t.Context(), `
+		UPDATE prediction_markets SET status = 'settled', winning_outcome_id = 'yes', attestation = $2
+		WHERE id = $1`, marketID, predictionAttestation)
+	require.NoError(t, err)
+
+	result, err := svc.SettleMarket(t.Context(), marketID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Settled)
+	assert.Equal(t, 1, result.Won)
+	assert.Equal(t, 1, result.Lost)
+	assert.Equal(t, int64(2500), result.Paid)
+	testutil.AssertBalance(t, env, winnerID, 2500, 0, 0)
+	testutil.AssertBalance(t, env, loserID, 0, 0, 0)
+
+	var status string
+	var payout int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT status, payout_amount_minor FROM prediction_stakes WHERE player_id = $1`, winnerID).Scan(&status, &payout))
+	assert.Equal(t, "won", status)
+	assert.Equal(t, int64(2500), payout)
+
+	// Settling again pays nothing twice.
+	n, err := svc.SettlePending(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	testutil.AssertBalance(t, env, winnerID, 2500, 0, 0)
+}
+
+func TestPredictionSettlement_VoidedMarketClosesStakes(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predvoid@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
+	marketID := env.SeedPredictionMarket("Voided market")
+
+	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": "no", "amount": 700,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+	testutil.AssertBalance(t, env, playerID, 300, 0, 0)
+
+	_, err := env.Pool.Exec(t.Context(), `UPDATE prediction_markets SET status = 'voided' WHERE id = $1`, marketID)
+	require.NoError(t, err)
+
+	svc := service.NewPredictionSettlementService(env.Pool, env.LedgerEngine(), repository.NewOutboxRepository(), slog.Default())
+	n, err := svc.SettlePending(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	resp = env.AuthGET("/predictions/positions", token)
+	var positions []struct {
+		Status string  `json:"status"`
+		Odds   float64 `json:"odds"`
+	}
+	testutil.DecodeJSON(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "void", positions[0].Status)
+	assert.Equal(t, 1.6, positions[0].Odds)
+	testutil.AssertBalance(t, env, playerID, 1000, 0, 0)
+}
+
+// ─── Prediction Market Maker Tests (2) ────────────────────────────────────
+
+// seedAMMMarket seeds a house market priced by its market maker.
+func seedAMMMarket(t *testing.T, env *testutil.TestEnv, title string, liquidity, exposureCap int64) uuid.UUID {
+	t.Helper()
+	marketID := env.SeedPredictionMarket(title)
+	_, err := env.Pool.Exec(t.Context(), `
+		UPDATE prediction_markets SET pricing = 'lmsr', amm_liquidity = $2, amm_exposure_cap = NULLIF($3, 0)
+		WHERE id = $1`, marketID, liquidity, exposureCap)
+	require.NoError(t, err)
+	return marketID
+}
+
+func TestPredictionAMM_OddsMoveWithStakes(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predamm@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	marketID := seedAMMMarket(t, env, "AMM market", 5000, 0)
+
+	type stakeResult struct {
+		Odds float64 `json:"odds"`
+	}
+	stake := func() stakeResult {
+		resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+			"outcome_id": "yes", "amount": 1000,
+		}, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var r stakeResult
+		testutil.DecodeJSON(t, resp, &r)
+		return r
+	}
+	outcomeOdds := func() map[string]float64 {
+		resp := env.AuthGET("/predictions/markets/"+marketID.String(), token)
+		var m struct {
+			Pricing  string `json:"pricing"`
+			Outcomes []struct {
+				ID   string  `json:"id"`
+				Odds float64 `json:"odds"`
+			} `json:"outcomes"`
+		}
+		testutil.DecodeJSON(t, resp, &m)
+		assert.Equal(t, "lmsr", m.Pricing)
+		odds := map[string]float64{}
+		for _, o := range m.Outcomes {
+			odds[o.ID] = o.Odds
+		}
+		return odds
+	}
+
+	// The first stake prices off the seeded 2.50 / 1.60 book.
+	first := stake()
+	assert.Less(t, first.Odds, 2.5)
+	assert.Greater(t, first.Odds, 2.0)
+
+	odds := outcomeOdds()
+	assert.Less(t, odds["yes"], first.Odds)
+	assert.Greater(t, odds["no"], 1.6)
+
+	second := stake()
+	assert.Less(t, second.Odds, first.Odds)
+
+	// Each stake locks its own odds for settlement.
+	var locked []float64
+	rows, err := env.Pool.Query(t.Context(), `
+		SELECT odds_at_placement::float8 FROM prediction_stakes WHERE market_id = $1 ORDER BY placed_at`, marketID)
+	require.NoError(t, err)
+	for rows.Next() {
+		var o float64
+		require.NoError(t, rows.Scan(&o))
+		locked = append(locked, o)
+	}
+	rows.Close()
+	assert.Equal(t, []float64{first.Odds, second.Odds}, locked)
+	testutil.AssertBalance(t, env, playerID, 8000, 0, 0)
+}
+
+func TestPredictionAMM_ExposureCap(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predammcap@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 100000)
+	marketID := seedAMMMarket(t, env, "Capped AMM market", 100000, 2000)
+
+	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": "yes", "amount": 5000,
+	}, token)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var body struct {
+		Code    string `json:"code"`
+		Details struct {
+			MaxStake int64 `json:"max_stake"`
+		} `json:"details"`
+	}
+	testutil.DecodeJSON(t, resp, &body)
+	assert.Equal(t, "EXPOSURE_LIMIT_EXCEEDED", body.Code)
+	assert.Greater(t, body.Details.MaxStake, int64(0))
+	assert.Less(t, body.Details.MaxStake, int64(5000))
+	testutil.AssertBalance(t, env, playerID, 100000, 0, 0)
+
+	resp = env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": "yes", "amount": body.Details.MaxStake,
+	}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+// ─── Prediction Position Sale Tests (3) ─────────────────────────────────────
+
+// stakePrediction stakes amount on outcome and returns the stake's ID.
+func stakePrediction(t *testing.T, env *testutil.TestEnv, token string, marketID uuid.UUID, outcome string, amount int64) uuid.UUID {
+	t.Helper()
+	resp := env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": outcome, "amount": amount,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var r struct {
+		ID uuid.UUID `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &r)
+	return r.ID
+}
+
+type positionSale struct {
+	Proceeds    int64 `json:"proceeds"`
+	RealizedPnL int64 `json:"realized_pnl"`
+}
+
+func TestPredictionSell_FixedOdds(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predsell@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	marketID := env.SeedPredictionMarket("Sell market")
+	stakeID := stakePrediction(t, env, token, marketID, "yes", 1000)
+
+	// A 2,500 payout at unchanged 2.50 odds is worth the stake, less the margin.
+	resp := env.AuthPOST("/predictions/positions/"+stakeID.String()+"/sell", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sale positionSale
+	testutil.DecodeJSON(t, resp, &sale)
+	assert.Equal(t, int64(950), sale.Proceeds)
+	assert.Equal(t, int64(-50), sale.RealizedPnL)
+	testutil.AssertBalance(t, env, playerID, 9950, 0, 0)
+
+	resp = env.AuthGET("/predictions/positions", token)
+	var positions []struct {
+		Status      string `json:"status"`
+		Payout      int64  `json:"payout_amount"`
+		RealizedPnL *int64 `json:"realized_pnl"`
+	}
+	testutil.DecodeJSON(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "sold", positions[0].Status)
+	assert.Equal(t, int64(950), positions[0].Payout)
+	require.NotNil(t, positions[0].RealizedPnL)
+	assert.Equal(t, int64(-50), *positions[0].RealizedPnL)
+
+	// A sold position is closed: it cannot be sold again or settled.
+	resp = env.AuthPOST("/predictions/positions/"+stakeID.String()+"/sell", nil, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	_, err := env.Pool.Exec(t.Context(), `
+		UPDATE prediction_markets SET status = 'voided' WHERE id = $1`, marketID)
+	require.NoError(t, err)
+	svc := service.NewPredictionSettlementService(env.Pool, env.LedgerEngine(), repository.NewOutboxRepository(), slog.Default())
+	result, err := svc.SettleMarket(t.Context(), marketID)
+	require.NoError(t, err)
+	assert.Zero(t, result.Settled)
+	testutil.AssertBalance(t, env, playerID, 9950, 0, 0)
+}
+
+func TestPredictionSell_MarketMaker(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predsellamm@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	marketID := seedAMMMarket(t, env, "Sell AMM market", 5000, 0)
+	stakeID := stakePrediction(t, env, token, marketID, "yes", 1000)
+
+	// The stake records the shares the book issued it.
+	var issued, booked int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT ps.shares, (pm.amm_shares->>'yes')::bigint
+		FROM prediction_stakes ps JOIN prediction_markets pm ON pm.id = ps.market_id
+		WHERE ps.id = $1`, stakeID).Scan(&issued, &booked))
+	assert.Positive(t, issued)
+	assert.Equal(t, booked, issued)
+
+	// Selling straight back returns the stake, less rounding, and buys the
+	// shares back out of the book.
+	resp := env.AuthPOST("/predictions/positions/"+stakeID.String()+"/sell", map[string]interface{}{
+		"min_value": 990,
+	}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var sale positionSale
+	testutil.DecodeJSON(t, resp, &sale)
+	assert.LessOrEqual(t, sale.Proceeds, int64(1000))
+	assert.GreaterOrEqual(t, sale.Proceeds, int64(990))
+	assert.Equal(t, sale.Proceeds-1000, sale.RealizedPnL)
+	testutil.AssertBalance(t, env, playerID, 9000+sale.Proceeds, 0, 0)
+
+	var shares, collected int64
+	err := env.Pool.QueryRow(t.Context(), `
+		SELECT (amm_shares->>'yes')::bigint, amm_collected::bigint FROM prediction_markets WHERE id = $1`,
+		marketID).Scan(&shares, &collected)
+	require.NoError(t, err)
+	assert.Zero(t, shares)
+	assert.Equal(t, 1000-sale.Proceeds, collected)
+}
+
+func TestPredictionSell_Refused(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("predsellno@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	otherToken, _ := env.RegisterPlayer("predsellother@test.com", "securepass123", "EUR")
+	marketID := env.SeedPredictionMarket("Refused sell market")
+	stakeID := stakePrediction(t, env, token, marketID, "yes", 1000)
+	sellPath := "/predictions/positions/" + stakeID.String() + "/sell"
+
+	// Another player's position is not found.
+	resp := env.AuthPOST(sellPath, nil, otherToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Proceeds below min_value are refused with the current value.
+	resp = env.AuthPOST(sellPath, map[string]interface{}{"min_value": 1000}, token)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	var body struct {
+		Code    string `json:"code"`
+		Details struct {
+			Value int64 `json:"value"`
+		} `json:"details"`
+	}
+	testutil.DecodeJSON(t, resp, &body)
+	assert.Equal(t, "SELL_VALUE_CHANGED", body.Code)
+	assert.Equal(t, int64(950), body.Details.Value)
+
+	// A closed market takes no trades.
+	_, err := env.Pool.Exec(t.Context(), `UPDATE prediction_markets SET status = 'closed' WHERE id = $1`, marketID)
+	require.NoError(t, err)
+	resp = env.AuthPOST(sellPath, nil, token)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "SELL_UNAVAILABLE")
+	testutil.AssertBalance(t, env, playerID, 9000, 0, 0)
+}
+
 // ─── AI Tests (5) ─────────────────────────────────────────────────────────
 
 func TestAI_CreateConversation(t *testing.T) {
@@ -1413,3 +2196,789 @@ func TestEngagement_GetScoreRequiresAuth(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
+
+// ─── GraphQL Tests (3) ──────────────────────────────────────────────────────
+
+func TestGraphQL_LobbyQuery(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("graphql@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 5000)
+	marketID := env.SeedPredictionMarket("GraphQL market")
+	env.SeedQuest("GraphQL quest", 3, 100)
+
+	env.AuthPOST("/predictions/markets/"+marketID.String()+"/stake", map[string]interface{}{
+		"outcome_id": "yes", "amount": 300,
+	}, token)
+	env.AuthPOST("/social/posts", map[string]interface{}{"content": "hello lobby"}, token)
+
+	resp := env.AuthPOST("/graphql", map[string]interface{}{
+		"query": `query Lobby($n: Int) {
+			me {
+				id
+				wallet { balance currency }
+				transactions(limit: $n) { type amount }
+				quests { name progress }
+				predictionPositions { stakeAmount market { title } }
+			}
+			feed(limit: 5) { content author { id } }
+		}`,
+		"variables": map[string]interface{}{"n": 5},
+	}, token)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data struct {
+			Me struct {
+				ID     string `json:"id"`
+				Wallet struct {
+					Balance int64 `json:"balance"`
+				} `json:"wallet"`
+				Transactions        []struct{ Amount int64 } `json:"transactions"`
+				Quests              []struct{ Name string }  `json:"quests"`
+				PredictionPositions []struct {
+					StakeAmount int `json:"stakeAmount"`
+					Market      struct {
+						Title string `json:"title"`
+					} `json:"market"`
+				} `json:"predictionPositions"`
+			} `json:"me"`
+			Feed []struct {
+				Content string `json:"content"`
+				Author  struct {
+					ID string `json:"id"`
+				} `json:"author"`
+			} `json:"feed"`
+		} `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Empty(t, body.Errors)
+
+	me := body.Data.Me
+	assert.Equal(t, playerID.String(), me.ID)
+	assert.NotEmpty(t, me.Transactions)
+	assert.NotEmpty(t, me.Quests)
+	require.Len(t, me.PredictionPositions, 1)
+	assert.Equal(t, "GraphQL market", me.PredictionPositions[0].Market.Title)
+	require.NotEmpty(t, body.Data.Feed)
+	assert.Equal(t, playerID.String(), body.Data.Feed[0].Author.ID)
+}
+
+func TestGraphQL_UnknownFieldRejected(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("graphqlbad@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/graphql", map[string]interface{}{"query": `{ me { password } }`}, token)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data   interface{}              `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Nil(t, body.Data)
+	require.Len(t, body.Errors, 1)
+	assert.Contains(t, body.Errors[0]["message"], "password")
+}
+
+func TestGraphQL_CostLimit(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("graphqlcost@test.com", "securepass123", "EUR")
+
+	// Eleven aliased pages of 50 posts exceed the 500 cost budget.
+	var fields []string
+	for i := 0; i < 11; i++ {
+		fields = append(fields, fmt.Sprintf("f%d: feed(limit: 50) { id }", i))
+	}
+	resp := env.AuthPOST("/graphql", map[string]interface{}{
+		"query": "{ " + strings.Join(fields, " ") + " }",
+	}, token)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.NotEmpty(t, body.Errors)
+	assert.Contains(t, body.Errors[0]["message"], "maximum cost")
+}
+
+// ─── Home Tests (1) ─────────────────────────────────────────────────────────
+
+func TestHome_AggregatesSections(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("home@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 2500)
+	env.SeedQuest("Home quest", 5, 100)
+	env.SeedPredictionMarket("Home market")
+	env.SeedSportsbook(250)
+
+	_, err := env.Pool.Exec(context.Background(), `
+		INSERT INTO player_notifications (player_id, type, title) VALUES ($1, 'promo', 'Hi'), ($1, 'promo', 'Again')`,
+		playerID)
+	require.NoError(t, err)
+
+	resp := env.AuthGET("/home", token)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var home struct {
+		Balance struct {
+			Balance int64 `json:"balance"`
+		} `json:"balance"`
+		ActiveBonuses []interface{} `json:"active_bonuses"`
+		TopQuests     []struct {
+			Name string `json:"name"`
+		} `json:"top_quests"`
+		FeaturedEvents  []interface{} `json:"featured_events"`
+		TrendingMarkets []struct {
+			Title string `json:"title"`
+		} `json:"trending_markets"`
+		RecentWins          []interface{} `json:"recent_wins"`
+		UnreadNotifications int           `json:"unread_notifications"`
+		Unavailable         []string      `json:"unavailable"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&home))
+	assert.Empty(t, home.Unavailable)
+	assert.Equal(t, int64(2500), home.Balance.Balance)
+	assert.NotNil(t, home.ActiveBonuses)
+	require.NotEmpty(t, home.TopQuests)
+	assert.Equal(t, "Home quest", home.TopQuests[0].Name)
+	require.NotEmpty(t, home.TrendingMarkets)
+	assert.Equal(t, "Home market", home.TrendingMarkets[0].Title)
+	assert.NotNil(t, home.RecentWins)
+	assert.Equal(t, 2, home.UnreadNotifications)
+}
+
+// ─── Placement Tests (3) ────────────────────────────────────────────────────
+
+func TestPlacements_TargetedFeedAndTracking(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("placements@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	env.GrantConsent(token, "analytics", "profiling")
+
+	create := func(name, vertical, segment string, priority int) string {
+		resp := env.AuthPOST("/admin/placements", map[string]interface{}{
+			"name": name, "kind": "banner", "title": name, "deep_link": "attaboy://" + name,
+			"vertical": vertical, "segment": segment, "priority": priority,
+		}, adminToken)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var p struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		return p.ID
+	}
+	welcomeID := create("welcome", "all", "new", 10)
+	create("casino-only", "casino", "all", 5)
+	create("vip-lounge", "all", "vip", 20)
+
+	resp := env.AuthGET("/placements?vertical=sportsbook", token)
+	var feed []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&feed))
+	resp.Body.Close()
+	require.Len(t, feed, 1)
+	assert.Equal(t, "welcome", feed[0].Name)
+
+	resp = env.AuthGET("/placements?vertical=casino", token)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&feed))
+	resp.Body.Close()
+	require.Len(t, feed, 2)
+	assert.Equal(t, "welcome", feed[0].Name)
+
+	for _, path := range []string{"impression", "impression", "click"} {
+		resp = env.AuthPOST("/placements/"+welcomeID+"/"+path, nil, token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+	resp = env.AuthPOST("/placements/"+testutil.FakeUUID()+"/click", nil, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/placements", adminToken)
+	defer resp.Body.Close()
+	var stats []struct {
+		ID            string `json:"id"`
+		Impressions   int64  `json:"impressions"`
+		Clicks        int64  `json:"clicks"`
+		UniqueViewers int64  `json:"unique_viewers"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	for _, s := range stats {
+		if s.ID == welcomeID {
+			assert.Equal(t, int64(2), s.Impressions)
+			assert.Equal(t, int64(1), s.Clicks)
+			assert.Equal(t, int64(1), s.UniqueViewers)
+		}
+	}
+}
+
+func TestPlacements_ValidityWindowAndPause(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("placewin@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	future := time.Now().Add(24 * time.Hour)
+	resp := env.AuthPOST("/admin/placements", map[string]interface{}{
+		"name": "later", "kind": "card", "title": "Later", "deep_link": "attaboy://later", "starts_at": future,
+	}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = env.AuthPOST("/admin/placements", map[string]interface{}{
+		"name": "now", "kind": "card", "title": "Now", "deep_link": "attaboy://now",
+	}, adminToken)
+	var live struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&live))
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/admin/placements", map[string]interface{}{
+		"name": "bad", "kind": "popup", "title": "Bad", "deep_link": "attaboy://bad",
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var feed []struct {
+		Name string `json:"name"`
+	}
+	resp = env.AuthGET("/placements", token)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&feed))
+	resp.Body.Close()
+	require.Len(t, feed, 1)
+	assert.Equal(t, "now", feed[0].Name)
+
+	resp = env.AuthPATCH("/admin/placements/"+live.ID+"/status", map[string]bool{"active": false}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/placements", token)
+	defer resp.Body.Close()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&feed))
+	assert.Empty(t, feed)
+}
+
+func TestPlacements_GameDayAndMaintenanceSchedule(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("placesched@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	sportID, eventID, _, _ := env.SeedSportsbook(200)
+	var sportKey string
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT key FROM sports WHERE id = $1`, sportID).Scan(&sportKey))
+
+	for _, p := range []map[string]interface{}{
+		{"name": "game-day", "vertical": "sportsbook",
+			"schedule": map[string]interface{}{"game_day": map[string]string{"sport": sportKey, "league": "Premier League"}}},
+		{"name": "casino-promo", "vertical": "casino",
+			"schedule": map[string]interface{}{"hide_during_maintenance": true}},
+	} {
+		p["kind"], p["title"], p["deep_link"] = "banner", p["name"], "attaboy://promo"
+		resp := env.AuthPOST("/admin/placements", p, adminToken)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	feedNames := func() []string {
+		resp := env.AuthGET("/placements", token)
+		defer resp.Body.Close()
+		var feed []struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&feed))
+		names := []string{}
+		for _, p := range feed {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	// The seeded event starts tomorrow, so today is not a game day.
+	assert.Equal(t, []string{"casino-promo"}, feedNames())
+
+	_, err := env.Pool.Exec(context.Background(),
+		`UPDATE sports_events SET start_time = now() WHERE id = $1`, eventID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"game-day", "casino-promo"}, feedNames())
+
+	resp := env.AuthPOST("/admin/maintenance-windows", map[string]interface{}{
+		"vertical": "casino", "reason": "provider upgrade",
+		"starts_at": time.Now().Add(-time.Minute), "ends_at": time.Now().Add(time.Hour),
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var window struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&window))
+	resp.Body.Close()
+	assert.Equal(t, []string{"game-day"}, feedNames())
+
+	resp = env.AuthDELETE("/admin/maintenance-windows/"+window.ID, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Len(t, feedNames(), 2)
+}
+
+// ─── Experiment Tests (2) ───────────────────────────────────────────────────
+
+func TestExperiments_FlagVariantAndExposure(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("experiment@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	env.GrantConsent(token, "analytics")
+
+	resp := env.AuthPUT("/admin/flags/welcome_bonus", map[string]interface{}{
+		"enabled": true, "value": map[string]int{"amount": 1000},
+	}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthPOST("/admin/experiments", map[string]interface{}{
+		"key": "welcome_bonus_size", "flag_key": "welcome_bonus",
+		"variants": []map[string]interface{}{
+			{"name": "control", "weight": 1, "value": map[string]int{"amount": 1000}},
+			{"name": "big", "weight": 1, "value": map[string]int{"amount": 2500}},
+		},
+	}, adminToken)
+	var exp struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&exp))
+	resp.Body.Close()
+	require.Equal(t, "draft", exp.Status)
+
+	// Draft experiments do not affect flag values
+	var flags map[string]struct {
+		Enabled bool   `json:"enabled"`
+		Variant string `json:"variant"`
+	}
+	resp = env.AuthGET("/features", token)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&flags))
+	resp.Body.Close()
+	assert.True(t, flags["welcome_bonus"].Enabled)
+	assert.Empty(t, flags["welcome_bonus"].Variant)
+
+	resp = env.AuthPATCH("/admin/experiments/"+exp.ID+"/status", map[string]string{"status": "running"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/features", token)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&flags))
+	resp.Body.Close()
+	variant := flags["welcome_bonus"].Variant
+	assert.Contains(t, []string{"control", "big"}, variant)
+
+	// Exposure is logged once and emits one outbox event
+	for i := 0; i < 2; i++ {
+		resp = env.AuthPOST("/features/welcome_bonus/exposure", nil, token)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	var exposures, events int
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM experiment_exposures WHERE player_id = $1 AND variant = $2`, playerID, variant).Scan(&exposures))
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.experiment.exposed' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&events))
+	assert.Equal(t, 1, exposures)
+	assert.Equal(t, 1, events)
+
+	env.DirectDeposit(playerID, 4000)
+
+	resp = env.AuthGET("/admin/experiments/"+exp.ID+"/results", adminToken)
+	defer resp.Body.Close()
+	var results []struct {
+		Variant           string `json:"variant"`
+		Exposed           int64  `json:"exposed"`
+		DepositingPlayers int64  `json:"depositing_players"`
+		DepositTotal      int64  `json:"deposit_total"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+	require.Len(t, results, 1)
+	assert.Equal(t, variant, results[0].Variant)
+	assert.Equal(t, int64(1), results[0].Exposed)
+	assert.Equal(t, int64(1), results[0].DepositingPlayers)
+	assert.Equal(t, int64(4000), results[0].DepositTotal)
+}
+
+func TestExperiments_OneRunningExperimentPerFlag(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+
+	resp := env.AuthPUT("/admin/flags/lobby_layout", map[string]interface{}{"enabled": true}, adminToken)
+	resp.Body.Close()
+
+	start := func(key string) int {
+		resp := env.AuthPOST("/admin/experiments", map[string]interface{}{
+			"key": key, "flag_key": "lobby_layout",
+			"variants": []map[string]interface{}{{"name": "a", "weight": 1}, {"name": "b", "weight": 1}},
+		}, adminToken)
+		var exp struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&exp))
+		resp.Body.Close()
+
+		resp = env.AuthPATCH("/admin/experiments/"+exp.ID+"/status", map[string]string{"status": "running"}, adminToken)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, start("layout_v1"))
+	assert.Equal(t, http.StatusConflict, start("layout_v2"))
+
+	resp = env.AuthPOST("/admin/experiments", map[string]interface{}{
+		"key": "single", "variants": []map[string]interface{}{{"name": "a", "weight": 1}},
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── Consent Tests (2) ──────────────────────────────────────────────────────
+
+func TestConsents_UpdateListAndHistory(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("consent@test.com", "securepass123", "EUR")
+
+	type consent struct {
+		Purpose string `json:"purpose"`
+		Channel string `json:"channel"`
+		Granted bool   `json:"granted"`
+		Source  string `json:"source"`
+	}
+	granted := func(consents []consent) []string {
+		out := []string{}
+		for _, c := range consents {
+			if c.Granted {
+				out = append(out, c.Purpose+"/"+c.Channel)
+			}
+		}
+		return out
+	}
+
+	// Nothing is consented to until the player opts in.
+	var consents []consent
+	testutil.DecodeJSON(t, env.AuthGET("/players/me/consents", token), &consents)
+	require.NotEmpty(t, consents)
+	assert.Empty(t, granted(consents))
+
+	update := func(changes ...map[string]interface{}) *http.Response {
+		return env.AuthPUT("/players/me/consents", map[string]interface{}{"consents": changes}, token)
+	}
+	resp := update(
+		map[string]interface{}{"purpose": "marketing", "channel": "email", "granted": true},
+		map[string]interface{}{"purpose": "analytics", "granted": true},
+	)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &consents)
+	assert.ElementsMatch(t, []string{"marketing/email", "analytics/all"}, granted(consents))
+
+	// Repeating a granted consent records nothing; withdrawing does.
+	resp = update(
+		map[string]interface{}{"purpose": "marketing", "channel": "email", "granted": true},
+		map[string]interface{}{"purpose": "analytics", "granted": false},
+	)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &consents)
+	assert.Equal(t, []string{"marketing/email"}, granted(consents))
+
+	resp = update(map[string]interface{}{"purpose": "marketing", "channel": "fax", "granted": true})
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	var history []struct {
+		Purpose string  `json:"purpose"`
+		Granted bool    `json:"granted"`
+		Source  string  `json:"source"`
+		IP      *string `json:"ip"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/players/me/consents/history", token), &history)
+	require.Len(t, history, 3)
+	assert.Equal(t, "analytics", history[0].Purpose)
+	assert.False(t, history[0].Granted)
+	assert.Equal(t, "player", history[0].Source)
+	assert.NotNil(t, history[0].IP)
+
+	var events int
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.player.consent.changed' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&events))
+	assert.Equal(t, 2, events)
+
+	var view struct {
+		Consents []consent        `json:"consents"`
+		History  []map[string]interface{} `json:"history"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/admin/players/"+playerID.String()+"/consents", env.AdminToken("admin")), &view)
+	assert.Equal(t, []string{"marketing/email"}, granted(view.Consents))
+	assert.Len(t, view.History, 3)
+}
+
+func TestConsents_AnalyticsAndProfilingNeedConsent(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("noconsent@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	resp := env.AuthPOST("/admin/placements", map[string]interface{}{
+		"name": "welcome", "kind": "banner", "title": "Welcome", "deep_link": "attaboy://welcome", "segment": "new",
+	}, adminToken)
+	var placement struct {
+		ID string `json:"id"`
+	}
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &placement)
+
+	resp = env.AuthPUT("/admin/flags/welcome_bonus", map[string]interface{}{"enabled": true}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = env.AuthPOST("/admin/experiments", map[string]interface{}{
+		"key": "welcome_bonus_size", "flag_key": "welcome_bonus",
+		"variants": []map[string]interface{}{{"name": "control", "weight": 1}, {"name": "big", "weight": 1}},
+	}, adminToken)
+	var exp struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &exp)
+	resp = env.AuthPATCH("/admin/experiments/"+exp.ID+"/status", map[string]string{"status": "running"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Without profiling consent the segment-targeted placement is withheld.
+	var feed []struct {
+		Name string `json:"name"`
+	}
+	testutil.DecodeJSON(t, env.AuthGET("/placements", token), &feed)
+	assert.Empty(t, feed)
+
+	// Without analytics consent events are anonymous and exposures unrecorded.
+	resp = env.AuthPOST("/placements/"+placement.ID+"/click", nil, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = env.AuthPOST("/features/welcome_bonus/exposure", nil, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var tracked, exposures int
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM placement_events WHERE player_id = $1`, playerID).Scan(&tracked))
+	require.NoError(t, env.Pool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM experiment_exposures WHERE player_id = $1`, playerID).Scan(&exposures))
+	assert.Zero(t, tracked)
+	assert.Zero(t, exposures)
+
+	env.GrantConsent(token, "profiling")
+	testutil.DecodeJSON(t, env.AuthGET("/placements", token), &feed)
+	require.Len(t, feed, 1)
+	assert.Equal(t, "welcome", feed[0].Name)
+}
+
+// ─── Cosmetic Tests (3) ─────────────────────────────────────────────────────
+
+// seedCosmetics adds a catalog item per code, all in the given slot.
+func seedCosmetics(t *testing.T, env *testutil.TestEnv, slot string, codes ...string) {
+	t.Helper()
+	adminToken := env.AdminToken("admin")
+	for _, code := range codes {
+		resp := env.AuthPUT("/admin/cosmetics/"+code, map[string]string{"name": code, "slot": slot}, adminToken)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func grantCosmetic(t *testing.T, env *testutil.TestEnv, playerID uuid.UUID, code, source string) {
+	t.Helper()
+	resp := env.AuthPOST("/admin/players/"+playerID.String()+"/cosmetics",
+		map[string]string{"item_code": code, "source": source}, env.AdminToken("admin"))
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestCosmetics_EquipReplacesSlot(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("cosmeticequip@test.com", "securepass123", "EUR")
+	seedCosmetics(t, env, "hat", "hat_red", "hat_blue")
+	grantCosmetic(t, env, playerID, "hat_red", "achievement")
+	grantCosmetic(t, env, playerID, "hat_blue", "tournament")
+
+	for _, code := range []string{"hat_red", "hat_blue"} {
+		resp := env.AuthPOST("/players/me/cosmetics/"+code+"/equip", nil, token)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp := env.AuthGET("/players/me/cosmetics", token)
+	var items []struct {
+		ItemCode string `json:"item_code"`
+		Source   string `json:"source"`
+		Equipped bool   `json:"equipped"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+	resp.Body.Close()
+	require.Len(t, items, 2)
+	assert.Equal(t, "hat_blue", items[0].ItemCode)
+	assert.True(t, items[0].Equipped)
+	assert.Equal(t, "tournament", items[0].Source)
+	assert.False(t, items[1].Equipped)
+}
+
+func TestCosmetics_CannotEquipUnowned(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("cosmeticunowned@test.com", "securepass123", "EUR")
+	seedCosmetics(t, env, "frame", "frame_gold")
+
+	resp := env.AuthPOST("/players/me/cosmetics/frame_gold/equip", nil, token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCosmetics_ShownOnProfileAndFeed(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("cosmeticfeed@test.com", "securepass123", "EUR")
+	seedCosmetics(t, env, "badge", "badge_champion")
+	grantCosmetic(t, env, playerID, "badge_champion", "tournament")
+	resp := env.AuthPOST("/players/me/cosmetics/badge_champion/equip", nil, token)
+	resp.Body.Close()
+
+	type equipped struct {
+		ItemCode string `json:"item_code"`
+	}
+	resp = env.AuthGET("/social/players/"+playerID.String(), token)
+	var profile struct {
+		Equipped []equipped `json:"equipped"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&profile))
+	resp.Body.Close()
+	require.Len(t, profile.Equipped, 1)
+	assert.Equal(t, "badge_champion", profile.Equipped[0].ItemCode)
+
+	resp = env.AuthPOST("/social/posts", map[string]string{"content": "won the weekly"}, token)
+	resp.Body.Close()
+	resp = env.AuthGET("/social/posts", token)
+	var posts []struct {
+		Equipped []equipped `json:"equipped"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&posts))
+	resp.Body.Close()
+	require.Len(t, posts, 1)
+	require.Len(t, posts[0].Equipped, 1)
+	assert.Equal(t, "badge_champion", posts[0].Equipped[0].ItemCode)
+}
+
+// ─── Content Page Tests (2) ─────────────────────────────────────────────────
+
+// createContentPage drafts a page through the admin API and returns its id.
+func createContentPage(t *testing.T, env *testutil.TestEnv, slug string, translations map[string]interface{}) string {
+	t.Helper()
+	resp := env.AuthPOST("/admin/content/pages", map[string]interface{}{
+		"slug": slug, "default_locale": "en", "translations": translations,
+	}, env.AdminToken("admin"))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var page struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &page)
+	return page.ID
+}
+
+func TestContent_PublishAndLocalize(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	pageID := createContentPage(t, env, "promo-rules", map[string]interface{}{
+		"en": map[string]string{"title": "Promo rules", "body": "# Rules\nBe nice."},
+		"de": map[string]string{"title": "Aktionsregeln", "body": "# Regeln\nSei nett."},
+	})
+
+	// Drafts are not served
+	resp := env.GET("/content/promo-rules")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/admin/content/pages/"+pageID+"/publish", nil, env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	type content struct {
+		Locale  string `json:"locale"`
+		Title   string `json:"title"`
+		Version int    `json:"version"`
+	}
+	var page content
+	resp = env.GETWithHeaders("/content/promo-rules", map[string]string{"Accept-Language": "de-AT,de;q=0.9,en;q=0.5"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &page)
+	assert.Equal(t, "de", page.Locale)
+	assert.Equal(t, "Aktionsregeln", page.Title)
+
+	// Unknown locales fall back to the default
+	resp = env.GET("/content/promo-rules?locale=fr")
+	testutil.DecodeJSON(t, resp, &page)
+	assert.Equal(t, "en", page.Locale)
+	assert.Equal(t, 1, page.Version)
+
+	resp = env.AuthPOST("/admin/content/pages/"+pageID+"/unpublish", nil, env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = env.GET("/content/promo-rules")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestContent_VersionHistoryAndRollback(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token := env.AdminToken("admin")
+	pageID := createContentPage(t, env, "help-deposits", map[string]interface{}{
+		"en": map[string]string{"title": "Deposits", "body": "First draft."},
+	})
+	resp := env.AuthPOST("/admin/content/pages/"+pageID+"/publish", nil, token)
+	resp.Body.Close()
+
+	resp = env.AuthPUT("/admin/content/pages/"+pageID, map[string]interface{}{
+		"slug": "help-deposits",
+		"translations": map[string]interface{}{
+			"en": map[string]string{"title": "Deposits", "body": "Second draft."},
+		},
+	}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// The published version is served until the new one is published
+	var page struct {
+		Body    string `json:"body"`
+		Version int    `json:"version"`
+	}
+	resp = env.GET("/content/help-deposits")
+	testutil.DecodeJSON(t, resp, &page)
+	assert.Equal(t, "First draft.", page.Body)
+
+	resp = env.AuthPOST("/admin/content/pages/"+pageID+"/publish", nil, token)
+	resp.Body.Close()
+	resp = env.GET("/content/help-deposits")
+	testutil.DecodeJSON(t, resp, &page)
+	assert.Equal(t, "Second draft.", page.Body)
+	assert.Equal(t, 2, page.Version)
+
+	resp = env.AuthGET("/admin/content/pages/"+pageID+"/versions", env.AdminToken("viewer"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var versions []struct {
+		Version int `json:"version"`
+	}
+	testutil.DecodeJSON(t, resp, &versions)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Version)
+
+	// Roll back to version 1
+	resp = env.AuthPOST("/admin/content/pages/"+pageID+"/publish", map[string]int{"version": 1}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	resp = env.GET("/content/help-deposits")
+	testutil.DecodeJSON(t, resp, &page)
+	assert.Equal(t, "First draft.", page.Body)
+}
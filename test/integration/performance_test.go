@@ -0,0 +1,258 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/guard"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─── Query Plan Baseline Tests (1) ──────────────────────────────────────────
+
+// recordingDB runs statements on the pool and remembers their SQL, so plan
+// baselines check the statements the repositories actually send.
+type recordingDB struct {
+	repository.DBTX
+	statements []string
+}
+
+func (r *recordingDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	r.statements = append(r.statements, sql)
+	return r.DBTX.Exec(ctx, sql, args...)
+}
+
+func (r *recordingDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	r.statements = append(r.statements, sql)
+	return r.DBTX.Query(ctx, sql, args...)
+}
+
+func (r *recordingDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	r.statements = append(r.statements, sql)
+	return r.DBTX.QueryRow(ctx, sql, args...)
+}
+
+// planNode is the part of an EXPLAIN (FORMAT JSON) plan node the baselines
+// look at.
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+func (n planNode) walk(visit func(planNode)) {
+	visit(n)
+	for _, child := range n.Plans {
+		child.walk(visit)
+	}
+}
+
+// genericPlan explains sql as a prepared statement's generic plan, the one
+// the statement cache settles on, without binding any parameters.
+func genericPlan(t *testing.T, pool *pgxpool.Pool, sql string) planNode {
+	t.Helper()
+	conn, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+	defer conn.Release()
+
+	results, err := conn.Conn().PgConn().Exec(t.Context(), "EXPLAIN (GENERIC_PLAN, FORMAT JSON) "+sql).ReadAll()
+	require.NoError(t, err, sql)
+	require.Len(t, results, 1)
+	require.NotEmpty(t, results[0].Rows)
+	var plans []struct {
+		Plan planNode `json:"Plan"`
+	}
+	require.NoError(t, json.Unmarshal(results[0].Rows[0][0], &plans), sql)
+	require.Len(t, plans, 1)
+	return plans[0].Plan
+}
+
+func TestQueryPlans_HotPathsUseIndexes(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	ctx := t.Context()
+
+	// Enough history that the planner prefers the indexes, as in production.
+	_, err := env.Pool.Exec(ctx, `
+		INSERT INTO v2_players (id, currency, balance, bonus_balance, reserved_balance)
+		SELECT gen_random_uuid(), 'EUR', 0, 0, 0 FROM generate_series(1, 200)`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO v2_transactions (player_id, type, amount, balance_after, bonus_balance_after, reserved_balance_after,
+		                             external_transaction_id, manufacturer_id, sub_transaction_id, created_at)
+		SELECT p.id, (ARRAY['bet', 'win', 'wallet_deposit'])[1 + i % 3], 100, 0, 0, 0,
+		       'perf-' || i, 'perf', '1', now() - i * interval '1 minute'
+		FROM v2_players p, generate_series(1, 100) i`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO idempotency_keys (player_id, key, scope, request_hash, status_code, created_at)
+		SELECT p.id, 'perf-' || i, 'POST /wallet/deposit', 'hash', 201, now() - i * interval '1 hour'
+		FROM v2_players p, generate_series(1, 20) i`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `ANALYZE v2_players, v2_transactions, idempotency_keys`)
+	require.NoError(t, err)
+
+	var playerID uuid.UUID
+	var txID string
+	require.NoError(t, env.Pool.QueryRow(ctx, `SELECT player_id, id::text FROM v2_transactions LIMIT 1`).Scan(&playerID, &txID))
+	txRepo := repository.NewTransactionRepository()
+
+	baselines := []struct {
+		name    string
+		run     func(db repository.DBTX) error
+		indexes []string
+	}{
+		{"idempotency lookup", func(db repository.DBTX) error {
+			_, err := txRepo.FindExisting(ctx, db, domain.IdempotencyKey{
+				PlayerID: playerID, ManufacturerID: "perf", ExternalTransactionID: "perf-1", SubTransactionID: "1",
+			})
+			return err
+		}, []string{"v2_transactions_idempotency_idx"}},
+		{"transaction history", func(db repository.DBTX) error {
+			_, err := txRepo.ListByPlayer(ctx, db, playerID, nil, 20)
+			return err
+		}, []string{"idx_v2_transactions_player_history"}},
+		{"transaction history page", func(db repository.DBTX) error {
+			_, err := txRepo.ListByPlayer(ctx, db, playerID, &txID, 20)
+			return err
+		}, []string{"idx_v2_transactions_player_history", "v2_transactions_pkey"}},
+		{"daily limit sum", func(db repository.DBTX) error {
+			_, err := txRepo.DailySumByType(ctx, db, playerID, "wallet_deposit")
+			return err
+		}, []string{"idx_v2_transactions_player_type_created"}},
+		{"idempotency key claim", func(db repository.DBTX) error {
+			_, err := guard.ClaimIdempotencyKey(ctx, db, playerID, "perf-new", "POST /wallet/deposit", "hash")
+			return err
+		}, []string{"idx_idempotency_keys_player_created"}},
+	}
+
+	for _, b := range baselines {
+		t.Run(b.name, func(t *testing.T) {
+			db := &recordingDB{DBTX: env.Pool}
+			require.NoError(t, b.run(db))
+			require.NotEmpty(t, db.statements)
+
+			var used []string
+			for _, sql := range db.statements {
+				genericPlan(t, env.Pool, sql).walk(func(n planNode) {
+					if n.IndexName != "" {
+						used = append(used, n.IndexName)
+					}
+					assert.NotEqual(t, "Sort", n.NodeType, sql)
+					if n.NodeType == "Seq Scan" {
+						assert.Fail(t, "sequential scan on "+n.RelationName, sql)
+					}
+				})
+			}
+			for _, index := range b.indexes {
+				assert.Contains(t, used, index)
+			}
+		})
+	}
+}
+
+// ─── Ledger Benchmarks ──────────────────────────────────────────────────────
+
+// placeBetP99Budget is the p99 latency ExecutePlaceBet must stay under in
+// BenchmarkExecutePlaceBet_Contention; PLACE_BET_P99_BUDGET_MS overrides it
+// for slower CI hosts.
+func placeBetP99Budget() time.Duration {
+	if ms, err := strconv.Atoi(os.Getenv("PLACE_BET_P99_BUDGET_MS")); err == nil && ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return 250 * time.Millisecond
+}
+
+// BenchmarkExecutePlaceBet_Contention places bets from parallel goroutines
+// on a handful of players, so most bets wait on another's wallet lock, and
+// fails if the p99 latency of a bet's transaction exceeds the budget.
+func BenchmarkExecutePlaceBet_Contention(b *testing.B) {
+	env := testutil.NewWalletTestEnv(b)
+	ctx := context.Background()
+
+	const hotPlayers = 4
+	players := make([]uuid.UUID, hotPlayers)
+	for i := range players {
+		players[i] = env.CreatePlayer("EUR")
+		env.DirectDeposit(players[i], int64(b.N+1)*100)
+	}
+
+	var (
+		mu        sync.Mutex
+		latencies = make([]time.Duration, 0, b.N)
+		next      int
+	)
+	b.SetParallelism(4)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mu.Lock()
+			n := next
+			next++
+			mu.Unlock()
+
+			start := time.Now()
+			err := placeBenchBet(ctx, env, players[n%hotPlayers], n)
+			elapsed := time.Since(start)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			mu.Lock()
+			latencies = append(latencies, elapsed)
+			mu.Unlock()
+		}
+	})
+	b.StopTimer()
+
+	if len(latencies) == 0 {
+		return
+	}
+	slices.Sort(latencies)
+	p99 := latencies[(len(latencies)*99-1)/100]
+	b.ReportMetric(float64(latencies[len(latencies)/2].Microseconds())/1000, "p50-ms")
+	b.ReportMetric(float64(p99.Microseconds())/1000, "p99-ms")
+	if budget := placeBetP99Budget(); p99 > budget {
+		b.Fatalf("ExecutePlaceBet p99 %s exceeds budget %s", p99, budget)
+	}
+}
+
+// placeBenchBet places bet n in its own transaction, as a provider callback
+// does.
+func placeBenchBet(ctx context.Context, env *testutil.WalletTestEnv, playerID uuid.UUID, n int) error {
+	tx, err := env.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := env.Engine.ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
+		PlayerID:              playerID,
+		Amount:                100,
+		ExternalTransactionID: fmt.Sprintf("bench-bet-%d", n),
+		ManufacturerID:        "bench",
+		SubTransactionID:      "1",
+		GameRoundID:           fmt.Sprintf("bench-round-%d", n),
+		GameID:                "bench-game",
+	}); err != nil {
+		return fmt.Errorf("place bet %d: %w", n, err)
+	}
+	return tx.Commit(ctx)
+}
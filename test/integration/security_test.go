@@ -9,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -326,3 +328,153 @@ func TestPasswordReset_InvalidTokenFails(t *testing.T) {
 	defer resp.Body.Close()
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
+
+// ─── IP Screening Tests (2) ────────────────────────────────────────────────
+
+func TestIPScreening_VPNFlagsRegistrationAndBlocksDeposit(t *testing.T) {
+	env := testutil.NewIPRiskTestEnv(t, domain.IPReputation{Proxy: true, ProxyType: "VPN", Risk: 40})
+	token, playerID := env.RegisterPlayer("ipvpn@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/payments/deposit", map[string]interface{}{
+		"amount": 5000, "currency": "EUR",
+		"success_url": "http://example.com/ok", "cancel_url": "http://example.com/no",
+	}, token)
+	testutil.AssertErrorCode(t, resp, "IP_BLOCKED")
+
+	adminToken := env.AdminToken("admin")
+	resp = env.AuthGET("/admin/ip-checks?unreviewed=true", adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var checks []domain.IPRiskCheck
+	testutil.DecodeJSON(t, resp, &checks)
+	require.Len(t, checks, 2)
+
+	byCheckpoint := map[string]domain.IPRiskCheck{}
+	for _, c := range checks {
+		byCheckpoint[c.Checkpoint] = c
+	}
+	reg := byCheckpoint["registration"]
+	assert.Equal(t, "review", reg.Verdict)
+	assert.Equal(t, "proxy:vpn", reg.Reason)
+	require.NotNil(t, reg.Reference)
+	assert.Equal(t, "ipvpn@test.com", *reg.Reference)
+	dep := byCheckpoint["deposit"]
+	assert.Equal(t, "block", dep.Verdict)
+	require.NotNil(t, dep.PlayerID)
+	assert.Equal(t, playerID, *dep.PlayerID)
+
+	resp = env.AuthPOST("/admin/ip-checks/"+reg.ID.String()+"/review",
+		map[string]string{"note": "known travel VPN"}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var reviewed domain.IPRiskCheck
+	testutil.DecodeJSON(t, resp, &reviewed)
+	assert.NotNil(t, reviewed.ReviewedAt)
+
+	resp = env.AuthGET("/admin/ip-checks?unreviewed=true", adminToken)
+	checks = nil
+	testutil.DecodeJSON(t, resp, &checks)
+	require.Len(t, checks, 1)
+	assert.Equal(t, "deposit", checks[0].Checkpoint)
+}
+
+func TestIPScreening_CleanAddressNotRecorded(t *testing.T) {
+	env := testutil.NewIPRiskTestEnv(t, domain.IPReputation{Risk: 5})
+	token, _ := env.RegisterPlayer("ipclean@test.com", "securepass123", "EUR")
+
+	// The deposit passes screening and reaches amount validation
+	resp := env.AuthPOST("/payments/deposit", map[string]interface{}{
+		"amount": 0, "currency": "EUR",
+		"success_url": "http://example.com/ok", "cancel_url": "http://example.com/no",
+	}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/ip-checks", env.AdminToken("admin"))
+	var checks []domain.IPRiskCheck
+	testutil.DecodeJSON(t, resp, &checks)
+	assert.Empty(t, checks)
+}
+
+// ─── Account Recovery Tests (2) ────────────────────────────────────────────
+
+// outboxToken returns the token in the player's latest outbox event of type
+// eventType, as the notification consumer would email it.
+func outboxToken(t *testing.T, env *testutil.TestEnv, playerID uuid.UUID, eventType domain.EventType) string {
+	t.Helper()
+	var token string
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT "payload"->>'token' FROM event_outbox
+		WHERE "aggregateId" = $1 AND "eventType" = $2
+		ORDER BY "occurredAt" DESC LIMIT 1`, playerID.String(), string(eventType)).Scan(&token))
+	return token
+}
+
+func TestAccountRecovery_ApprovalEmailsTokenAndRevokesSessions(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("recover@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPUT("/players/me/backup-email", map[string]string{"email": "backup@test.com"}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var backup domain.BackupEmail
+	testutil.DecodeJSON(t, resp, &backup)
+	assert.False(t, backup.Verified)
+
+	resp = env.AuthPOST("/players/me/backup-email/verify", map[string]string{
+		"token": outboxToken(t, env, playerID, domain.EventBackupEmailVerify),
+	}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &backup)
+	assert.True(t, backup.Verified)
+
+	resp = env.POST("/auth/recovery", map[string]string{"email": "recover@test.com"}, "")
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	adminToken := env.AdminToken("admin")
+	resp = env.AuthGET("/admin/recovery-requests?status=pending", adminToken)
+	var requests []domain.RecoveryRequest
+	testutil.DecodeJSON(t, resp, &requests)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "backup@test.com", requests[0].BackupEmail)
+
+	resp = env.AuthPOST("/admin/recovery-requests/"+requests[0].ID.String()+"/approve",
+		map[string]interface{}{"note": "confirmed on a call", "override": true}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var approval map[string]interface{}
+	testutil.DecodeJSON(t, resp, &approval)
+	assert.NotContains(t, approval, "reset_token")
+	assert.Equal(t, "backup@test.com", approval["backup_email"])
+
+	// Whoever holds the account now is signed out.
+	resp = env.AuthGET("/players/me", token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = env.POST("/auth/password-reset/confirm", map[string]string{
+		"token":        outboxToken(t, env, playerID, domain.EventRecoveryResetIssued),
+		"new_password": "recovered456",
+	}, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAccountRecovery_UnverifiedBackupEmailNotRecoverable(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("unverified@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPUT("/players/me/backup-email", map[string]string{"email": "backup2@test.com"}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthPOST("/players/me/backup-email/verify", map[string]string{"token": "not-the-token"}, token)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	// The request is accepted, as for any address, but never reaches support.
+	resp = env.POST("/auth/recovery", map[string]string{"email": "unverified@test.com"}, "")
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/recovery-requests", env.AdminToken("viewer"))
+	var requests []domain.RecoveryRequest
+	testutil.DecodeJSON(t, resp, &requests)
+	assert.Empty(t, requests)
+}
@@ -4,9 +4,14 @@ package integration
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
 	"github.com/attaboy/platform/test/integration/testutil"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -287,7 +292,7 @@ func TestBet_SplitAllBonus(t *testing.T) {
 	testutil.AssertBalance(t, env, playerID, 0, 3000, 0)
 }
 
-// ─── My Bets Tests (3) ─────────────────────────────────────────────────────
+// ─── My Bets Tests (5) ─────────────────────────────────────────────────────
 
 func TestMyBets_Empty(t *testing.T) {
 	env := testutil.NewTestEnv(t)
@@ -312,13 +317,17 @@ func TestMyBets_ReturnsPlaced(t *testing.T) {
 	resp := env.AuthGET("/sportsbook/bets/me", token)
 	defer resp.Body.Close()
 
-	var bets []struct {
-		Status string `json:"status"`
-		Stake  int    `json:"stake_amount_minor"`
+	var result struct {
+		Bets []struct {
+			Status string `json:"status"`
+			Stake  int    `json:"stake_amount_minor"`
+		} `json:"bets"`
+		NextCursor *string `json:"next_cursor"`
 	}
-	require.NoError(t, json.NewDecoder(resp.Body).Decode(&bets))
-	assert.Len(t, bets, 1)
-	assert.Equal(t, "open", bets[0].Status)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Len(t, result.Bets, 1)
+	assert.Equal(t, "open", result.Bets[0].Status)
+	assert.Nil(t, result.NextCursor)
 }
 
 func TestMyBets_PlayerIsolation(t *testing.T) {
@@ -335,9 +344,97 @@ func TestMyBets_PlayerIsolation(t *testing.T) {
 	resp := env.AuthGET("/sportsbook/bets/me", token2)
 	defer resp.Body.Close()
 
-	var bets []json.RawMessage
-	require.NoError(t, json.NewDecoder(resp.Body).Decode(&bets))
-	assert.Empty(t, bets)
+	var result struct {
+		Bets []json.RawMessage `json:"bets"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Empty(t, result.Bets)
+}
+
+type betHistoryPage struct {
+	Bets []struct {
+		ID     uuid.UUID `json:"id"`
+		Status string    `json:"status"`
+	} `json:"bets"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+func TestMyBets_CursorPagination(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("mybetspages@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	for i := 0; i < 5; i++ {
+		resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 100,
+		}, token)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	seen := map[uuid.UUID]bool{}
+	path := "/sportsbook/bets/me?limit=2"
+	for pages := 1; ; pages++ {
+		var page betHistoryPage
+		testutil.DecodeJSON(t, env.AuthGET(path, token), &page)
+		for _, b := range page.Bets {
+			assert.False(t, seen[b.ID], "bet %s listed twice", b.ID)
+			seen[b.ID] = true
+		}
+		if page.NextCursor == nil {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		require.Len(t, page.Bets, 2)
+		path = "/sportsbook/bets/me?limit=2&cursor=" + *page.NextCursor
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestMyBets_Filters(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("mybetsfilter@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	sportA, eventA, marketA, selectionA := env.SeedSportsbook(250)
+	_, eventB, marketB, selectionB := env.SeedSportsbook(300)
+
+	place := func(eventID, marketID, selectionID uuid.UUID) uuid.UUID {
+		resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 100,
+		}, token)
+		var bet struct {
+			BetID uuid.UUID `json:"bet_id"`
+		}
+		testutil.DecodeJSON(t, resp, &bet)
+		return bet.BetID
+	}
+	won := place(eventA, marketA, selectionA)
+	old := place(eventA, marketA, selectionA)
+	other := place(eventB, marketB, selectionB)
+	_, err := env.Pool.Exec(t.Context(), `UPDATE sports_bets SET status = 'won' WHERE id = $1`, won)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `UPDATE sports_bets SET placed_at = now() - interval '3 days' WHERE id = $1`, old)
+	require.NoError(t, err)
+
+	ids := func(query string) []uuid.UUID {
+		t.Helper()
+		var page betHistoryPage
+		testutil.DecodeJSON(t, env.AuthGET("/sportsbook/bets/me"+query, token), &page)
+		out := []uuid.UUID{}
+		for _, b := range page.Bets {
+			out = append(out, b.ID)
+		}
+		return out
+	}
+	assert.Equal(t, []uuid.UUID{won}, ids("?status=settled"))
+	assert.ElementsMatch(t, []uuid.UUID{old, other}, ids("?status=open"))
+	assert.ElementsMatch(t, []uuid.UUID{won, old}, ids("?sport_id="+sportA.String()))
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	assert.ElementsMatch(t, []uuid.UUID{won, other}, ids("?from="+yesterday))
+	assert.Equal(t, []uuid.UUID{old}, ids("?to="+yesterday))
+
+	testutil.AssertErrorCode(t, env.AuthGET("/sportsbook/bets/me?status=pending", token), "VALIDATION_ERROR")
 }
 
 // ─── Settlement Tests (14) ────────────────────────────────────────────────
@@ -783,3 +880,1057 @@ func TestSettleEndpoint_ReturnsSummary(t *testing.T) {
 	assert.Equal(t, 1, result.Lost)
 	assert.Equal(t, 0, result.Voided)
 }
+
+func TestOutright_EachWayDeadHeatSettlement(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("superadmin")
+	token, playerID := env.RegisterPlayer("outright@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	sportID, _, _, _ := env.SeedSportsbook(250)
+
+	resp := env.POST("/admin/sportsbook/outrights", map[string]interface{}{
+		"sport_id": sportID, "league": "Premier League", "name": "League Winner",
+		"each_way": map[string]int{"places": 2, "fraction": 4},
+		"selections": []map[string]interface{}{
+			{"name": "Team A", "odds_decimal": 300},
+			{"name": "Team B", "odds_decimal": 500},
+			{"name": "Team C", "odds_decimal": 900},
+			{"name": "Team D", "odds_decimal": 1500},
+		},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var market struct {
+		ID         uuid.UUID  `json:"id"`
+		EventID    *uuid.UUID `json:"event_id"`
+		Selections []struct {
+			ID uuid.UUID `json:"id"`
+		} `json:"selections"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&market))
+	resp.Body.Close()
+	require.Nil(t, market.EventID)
+	require.Len(t, market.Selections, 4)
+	teamA, teamB, teamC, teamD := market.Selections[0].ID, market.Selections[1].ID, market.Selections[2].ID, market.Selections[3].ID
+
+	resp = env.AuthGET("/sportsbook/sports/"+sportID.String()+"/outrights", token)
+	var outrights []struct {
+		ID uuid.UUID `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&outrights))
+	resp.Body.Close()
+	require.Len(t, outrights, 1)
+	assert.Equal(t, market.ID, outrights[0].ID)
+
+	// Each-way 1000 a line on Team B at 5.00: place odds 1/4 → 2.00.
+	resp = env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"market_id": market.ID, "selection_id": teamB, "stake": 1000, "each_way": true,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bet struct {
+		TotalStake      int64 `json:"total_stake"`
+		PotentialPayout int64 `json:"potential_payout"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&bet))
+	resp.Body.Close()
+	assert.Equal(t, int64(2000), bet.TotalStake)
+	assert.Equal(t, int64(5000+2000), bet.PotentialPayout)
+
+	// Win-only 1000 on Team D, which is declared a non-runner.
+	resp = env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"market_id": market.ID, "selection_id": teamD, "stake": 1000,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+	testutil.AssertBalance(t, env, playerID, 7000, 0, 0)
+
+	// Team B and Team C dead-heat for second with two places paying: the
+	// place line pays half the stake at place odds.
+	resp = env.POST("/admin/sportsbook/outrights/"+market.ID.String()+"/settle", map[string]interface{}{
+		"placings": []map[string]interface{}{
+			{"selection_id": teamA, "position": 1},
+			{"selection_id": teamB, "position": 2},
+			{"selection_id": teamC, "position": 2},
+		},
+		"void": []uuid.UUID{teamD},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Settled int `json:"settled"`
+		Won     int `json:"won"`
+		Voided  int `json:"voided"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, 2, result.Settled)
+	assert.Equal(t, 1, result.Won)
+	assert.Equal(t, 1, result.Voided)
+	testutil.AssertBalance(t, env, playerID, 7000+1000+1000, 0, 0)
+
+	resp = env.POST("/admin/sportsbook/outrights/"+market.ID.String()+"/settle", map[string]interface{}{
+		"placings": []map[string]interface{}{{"selection_id": teamA, "position": 1}},
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestSettleEvent_AsianHandicapAndTotalsFromScore(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("superadmin")
+	token, playerID := env.RegisterPlayer("ahsettle@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, eventID, _, _ := env.SeedSportsbook(250)
+
+	marketID, homeQuarter, awayQuarter, overTwo := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	_, err := env.Pool.Exec(t.Context(), `
+		INSERT INTO sports_markets (id, event_id, name, type, status) VALUES ($1, $2, 'Asian Lines', 'spread', 'open')`,
+		marketID, eventID)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO sports_selections (id, market_id, name, odds_decimal, status, line, side)
+		VALUES ($1, $4, 'Team A -0.25', 200, 'active', -25, 'home'),
+		       ($2, $4, 'Team B +0.25', 190, 'active', 25, 'away'),
+		       ($3, $4, 'Over 2', 180, 'active', 200, 'over')`,
+		homeQuarter, awayQuarter, overTwo, marketID)
+	require.NoError(t, err)
+
+	for _, sel := range []uuid.UUID{homeQuarter, awayQuarter, overTwo} {
+		resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"market_id": marketID, "selection_id": sel, "stake": 1000,
+		}, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		resp.Body.Close()
+	}
+	testutil.AssertBalance(t, env, playerID, 7000, 0, 0)
+
+	// 1-1: home -0.25 half-loses, away +0.25 half-wins, over 2 pushes.
+	_, err = env.Pool.Exec(t.Context(),
+		`UPDATE sports_events SET status = 'settled', score_home = 1, score_away = 1 WHERE id = $1`, eventID)
+	require.NoError(t, err)
+
+	resp := env.POST("/admin/sportsbook/events/"+eventID.String()+"/settle", nil, adminToken)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Settled  int `json:"settled"`
+		Pushed   int `json:"pushed"`
+		HalfWon  int `json:"half_won"`
+		HalfLost int `json:"half_lost"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, 3, result.Settled)
+	assert.Equal(t, 1, result.Pushed)
+	assert.Equal(t, 1, result.HalfWon)
+	assert.Equal(t, 1, result.HalfLost)
+
+	// 500 back on the half loss, 950 + 500 on the half win, 1000 on the push.
+	testutil.AssertBalance(t, env, playerID, 7000+500+1450+1000, 0, 0)
+
+	var status string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status FROM sports_bets WHERE selection_id = $1`, overTwo).Scan(&status))
+	assert.Equal(t, "push", status)
+}
+
+func TestBetReceipt_SignedDownloadAndEmail(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("betreceipt@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	adminToken := env.AdminToken("superadmin")
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 1000,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bet struct {
+		BetID uuid.UUID `json:"bet_id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&bet))
+	resp.Body.Close()
+
+	// JSON receipt carries the bet as placed and verifies.
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID.String()+"/receipt?format=json", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var receipt map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&receipt))
+	resp.Body.Close()
+	assert.Equal(t, float64(1000), receipt["stake"])
+	assert.Equal(t, float64(2500), receipt["potential_payout"])
+	assert.Len(t, receipt["signature"], 64)
+
+	resp = env.POST("/admin/sportsbook/receipts/verify", receipt, adminToken)
+	var verified struct {
+		Valid bool `json:"valid"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&verified))
+	resp.Body.Close()
+	assert.True(t, verified.Valid)
+
+	// A tampered stake no longer verifies.
+	receipt["stake"] = 100000
+	resp = env.POST("/admin/sportsbook/receipts/verify", receipt, adminToken)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&verified))
+	resp.Body.Close()
+	assert.False(t, verified.Valid)
+
+	// Default format is a PDF attachment.
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID.String()+"/receipt", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), "%PDF"))
+
+	// Another player cannot fetch it.
+	otherToken, _ := env.RegisterPlayer("betreceipt2@test.com", "securepass123", "EUR")
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID.String()+"/receipt", otherToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Email requests are queued for the notification consumer.
+	before := testutil.CountOutboxEvents(t, env, playerID)
+	resp = env.AuthPOST("/sportsbook/bets/"+bet.BetID.String()+"/receipt/email", nil, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, before+1, testutil.CountOutboxEvents(t, env, playerID))
+}
+
+// ─── Bet Pool Tests (2) ───────────────────────────────────────────────────
+
+func TestBetPool_ProRataSettlement(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ownerToken, ownerID := env.RegisterPlayer("poolowner@test.com", "securepass123", "EUR")
+	friendToken, friendID := env.RegisterPlayer("poolfriend@test.com", "securepass123", "EUR")
+	outsiderToken, _ := env.RegisterPlayer("pooloutsider@test.com", "securepass123", "EUR")
+	env.DirectDeposit(ownerID, 10000)
+	env.DirectDeposit(friendID, 10000)
+	adminToken := env.AdminToken("superadmin")
+	_, eventID, _, selectionID := env.SeedSportsbook(250)
+
+	resp := env.AuthPOST("/sportsbook/pools", map[string]interface{}{
+		"name": "Friday five-a-side", "selection_id": selectionID,
+		"contributions_close_at": time.Now().Add(time.Hour), "invite": []uuid.UUID{friendID},
+	}, ownerToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var pool struct {
+		ID uuid.UUID `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pool))
+	resp.Body.Close()
+	path := "/sportsbook/pools/" + pool.ID.String()
+
+	// Invited players must join before contributing or chatting.
+	resp = env.AuthPOST(path+"/contributions", map[string]interface{}{"amount": 2000}, friendToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = env.AuthPOST(path+"/join", nil, friendToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	for _, c := range []struct {
+		token  string
+		amount int64
+	}{{ownerToken, 1000}, {friendToken, 2000}} {
+		resp = env.AuthPOST(path+"/contributions", map[string]interface{}{"amount": c.amount}, c.token)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	testutil.AssertBalance(t, env, ownerID, 9000, 0, 0)
+	testutil.AssertBalance(t, env, friendID, 8000, 0, 0)
+
+	resp = env.AuthPOST(path+"/messages", map[string]interface{}{"body": "Come on you Team A"}, friendToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp = env.AuthGET(path+"/messages", ownerToken)
+	var messages []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&messages))
+	resp.Body.Close()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Come on you Team A", messages[0]["body"])
+
+	// Outsiders cannot see the pool.
+	resp = env.AuthGET(path, outsiderToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Only the owner places early; odds lock at 2.50.
+	resp = env.AuthPOST(path+"/place", nil, friendToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = env.AuthPOST(path+"/place", nil, ownerToken)
+	var placed struct {
+		Status     string `json:"status"`
+		TotalStake int64  `json:"total_stake"`
+		Odds       int    `json:"odds_at_placement"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&placed))
+	resp.Body.Close()
+	assert.Equal(t, "placed", placed.Status)
+	assert.Equal(t, int64(3000), placed.TotalStake)
+	assert.Equal(t, 250, placed.Odds)
+
+	_, err := env.Pool.Exec(t.Context(), `UPDATE sports_selections SET result = 'won' WHERE id = $1`, selectionID)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `UPDATE sports_events SET status = 'settled' WHERE id = $1`, eventID)
+	require.NoError(t, err)
+
+	resp = env.POST("/admin/sportsbook/events/"+eventID.String()+"/settle", nil, adminToken)
+	var result struct {
+		Pools int `json:"pools"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, 1, result.Pools)
+
+	// 3000 at 2.50 returns 7500, split 1:2 between owner and friend.
+	testutil.AssertBalance(t, env, ownerID, 9000+2500, 0, 0)
+	testutil.AssertBalance(t, env, friendID, 8000+5000, 0, 0)
+
+	var payouts int
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT count(*) FROM bet_pool_payouts WHERE pool_id = $1 AND transaction_id IS NOT NULL`, pool.ID).Scan(&payouts))
+	assert.Equal(t, 2, payouts)
+}
+
+func TestBetPool_CancelRefundsContributions(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("poolcancel@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, _, _, selectionID := env.SeedSportsbook(250)
+
+	resp := env.AuthPOST("/sportsbook/pools", map[string]interface{}{
+		"name": "Solo pool", "selection_id": selectionID, "contributions_close_at": time.Now().Add(time.Hour),
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var pool struct {
+		ID uuid.UUID `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pool))
+	resp.Body.Close()
+	path := "/sportsbook/pools/" + pool.ID.String()
+
+	// Contributions below the pool minimum are rejected.
+	resp = env.AuthPOST(path+"/contributions", map[string]interface{}{"amount": 50}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = env.AuthPOST(path+"/contributions", map[string]interface{}{"amount": 1500}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 8500, 0, 0)
+
+	resp = env.AuthPOST(path+"/cancel", nil, token)
+	var cancelled struct {
+		Status string `json:"status"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&cancelled))
+	resp.Body.Close()
+	assert.Equal(t, "cancelled", cancelled.Status)
+	testutil.AssertBalance(t, env, playerID, 10000, 0, 0)
+
+	var roundStatus string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status FROM game_rounds WHERE player_id = $1 AND round_id LIKE 'pool_%'`, playerID).Scan(&roundStatus))
+	assert.Equal(t, "closed", roundStatus)
+}
+
+// ─── Bulk Odds Tests (2) ──────────────────────────────────────────────────
+
+// seedSelection adds another active selection to a seeded market.
+func seedSelection(t *testing.T, env *testutil.TestEnv, marketID uuid.UUID, name string, odds int) uuid.UUID {
+	t.Helper()
+	var id uuid.UUID
+	err := env.Pool.QueryRow(t.Context(), `
+		INSERT INTO sports_selections (market_id, name, odds_decimal, status, sort_order)
+		VALUES ($1, $2, $3, 'active', 2) RETURNING id`, marketID, name, odds).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
+
+func TestBulkOdds_OverrideIsAuditedAndReopensMarket(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	_, _, marketID, homeID := env.SeedSportsbook(200)
+	awayID := seedSelection(t, env, marketID, "Away Win", 200)
+
+	resp := env.AuthPOST("/admin/sportsbook/selections/bulk-odds", map[string]interface{}{
+		"selections": []map[string]interface{}{
+			{"selection_id": homeID, "odds_decimal": 180},
+			{"selection_id": awayID, "odds_decimal": 210},
+		},
+		"reason": "team news",
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		EditID     uuid.UUID         `json:"edit_id"`
+		Changes    []json.RawMessage `json:"changes"`
+		MarginsBps map[string]int    `json:"margins_bps"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Len(t, result.Changes, 2)
+	assert.Equal(t, 317, result.MarginsBps[marketID.String()])
+
+	var odds int
+	var fractional *string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT odds_decimal, odds_fractional FROM sports_selections WHERE id = $1`, homeID).Scan(&odds, &fractional))
+	assert.Equal(t, 180, odds)
+	assert.Nil(t, fractional)
+
+	var status string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status FROM sports_markets WHERE id = $1`, marketID).Scan(&status))
+	assert.Equal(t, "open", status)
+
+	resp = env.AuthGET("/admin/sportsbook/markets/"+marketID.String()+"/odds-changes", env.AdminToken("viewer"))
+	var changes []struct {
+		EditID  uuid.UUID `json:"edit_id"`
+		OldOdds int       `json:"old_odds"`
+		NewOdds int       `json:"new_odds"`
+		Reason  string    `json:"reason"`
+	}
+	testutil.DecodeJSON(t, resp, &changes)
+	require.Len(t, changes, 2)
+	assert.Equal(t, result.EditID, changes[0].EditID)
+	assert.Equal(t, "team news", changes[0].Reason)
+
+	// An unknown selection fails the whole edit and leaves prices untouched
+	resp = env.AuthPOST("/admin/sportsbook/selections/bulk-odds", map[string]interface{}{
+		"selections": []map[string]interface{}{
+			{"selection_id": homeID, "odds_decimal": 300},
+			{"selection_id": uuid.New(), "odds_decimal": 300},
+		},
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT odds_decimal FROM sports_selections WHERE id = $1`, homeID).Scan(&odds))
+	assert.Equal(t, 180, odds)
+}
+
+func TestBulkOdds_MarginDeltaRepricesMarket(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	adminToken := env.AdminToken("admin")
+	_, _, marketID, homeID := env.SeedSportsbook(200)
+	awayID := seedSelection(t, env, marketID, "Away Win", 200)
+
+	// A suspended market stays suspended after the edit
+	_, err := env.Pool.Exec(t.Context(), `UPDATE sports_markets SET status = 'suspended' WHERE id = $1`, marketID)
+	require.NoError(t, err)
+
+	resp := env.AuthPOST("/admin/sportsbook/selections/bulk-odds", map[string]interface{}{
+		"market_id": marketID, "margin_delta_bps": 500,
+	}, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		MarginsBps map[string]int `json:"margins_bps"`
+	}
+	testutil.DecodeJSON(t, resp, &result)
+	assert.Equal(t, 526, result.MarginsBps[marketID.String()])
+
+	for _, id := range []uuid.UUID{homeID, awayID} {
+		var odds int
+		require.NoError(t, env.Pool.QueryRow(t.Context(),
+			`SELECT odds_decimal FROM sports_selections WHERE id = $1`, id).Scan(&odds))
+		assert.Equal(t, 190, odds)
+	}
+	var status string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status FROM sports_markets WHERE id = $1`, marketID).Scan(&status))
+	assert.Equal(t, "suspended", status)
+
+	// Mixing both edit styles is rejected
+	resp = env.AuthPOST("/admin/sportsbook/selections/bulk-odds", map[string]interface{}{
+		"market_id": marketID, "margin_delta_bps": 100,
+		"selections": []map[string]interface{}{{"selection_id": homeID, "odds_decimal": 150}},
+	}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── Trading Alert Tests (1) ──────────────────────────────────────────────
+
+func TestTradingAlerts_DominantPlayerFlaggedOnce(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("tradingalert@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 200_000)
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+	adminToken := env.AdminToken("admin")
+
+	for i := 0; i < 2; i++ {
+		resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 60_000,
+		}, token)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	// Only the second bet crosses the minimum handle; one open alert per player and market
+	resp := env.AuthGET("/admin/sportsbook/trading-alerts?status=open", env.AdminToken("viewer"))
+	var alerts []struct {
+		ID        string `json:"id"`
+		AlertType string `json:"alert_type"`
+		PlayerID  string `json:"player_id"`
+	}
+	testutil.DecodeJSON(t, resp, &alerts)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "player_handle_share", alerts[0].AlertType)
+	assert.Equal(t, playerID.String(), alerts[0].PlayerID)
+
+	var events int
+	env.Pool.QueryRow(t.Context(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.sportsbook.trading_alert.raised' AND "aggregateId" = $1`,
+		marketID.String()).Scan(&events)
+	assert.Equal(t, 1, events)
+
+	resp = env.AuthGET("/admin/sportsbook/liabilities?market_id="+marketID.String(), adminToken)
+	var liabilities []struct {
+		SelectionID string `json:"selection_id"`
+		OpenBets    int    `json:"open_bets"`
+		Stake       int64  `json:"stake"`
+		Liability   int64  `json:"liability"`
+		OpenAlerts  int    `json:"open_alerts"`
+	}
+	testutil.DecodeJSON(t, resp, &liabilities)
+	require.Len(t, liabilities, 1)
+	assert.Equal(t, selectionID.String(), liabilities[0].SelectionID)
+	assert.Equal(t, 2, liabilities[0].OpenBets)
+	assert.Equal(t, int64(120_000), liabilities[0].Stake)
+	assert.Equal(t, int64(300_000), liabilities[0].Liability)
+	assert.Equal(t, 1, liabilities[0].OpenAlerts)
+
+	resp = env.AuthPOST("/admin/sportsbook/trading-alerts/"+alerts[0].ID+"/acknowledge", nil, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = env.AuthPOST("/admin/sportsbook/trading-alerts/"+alerts[0].ID+"/acknowledge", nil, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+// ─── Cash-Out Tests (4) ───────────────────────────────────────────────────
+
+func TestCashOut_SettlesAtCurrentOdds(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("cashout@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	adminToken := env.AdminToken("superadmin")
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 1000,
+	}, token)
+	var bet struct {
+		BetID string `json:"bet_id"`
+	}
+	testutil.DecodeJSON(t, resp, &bet)
+
+	// The selection shortens to 1.25: fair value 1000 * 250/125 = 2000, less 5%.
+	_, err := env.Pool.Exec(t.Context(), `UPDATE sports_selections SET odds_decimal = 125 WHERE id = $1`, selectionID)
+	require.NoError(t, err)
+
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID+"/cashout", token)
+	var quote struct {
+		Value     int64 `json:"value"`
+		Available bool  `json:"available"`
+	}
+	testutil.DecodeJSON(t, resp, &quote)
+	assert.True(t, quote.Available)
+	assert.Equal(t, int64(1900), quote.Value)
+
+	resp = env.AuthPOST("/sportsbook/bets/"+bet.BetID+"/cashout", map[string]int64{"min_value": 2000}, token)
+	testutil.AssertErrorCode(t, resp, "CASH_OUT_VALUE_CHANGED")
+
+	resp = env.AuthPOST("/sportsbook/bets/"+bet.BetID+"/cashout", map[string]int64{"min_value": 1900}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 10900, 0, 0)
+
+	var status string
+	var payout int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status, payout_amount_minor FROM sports_bets WHERE id = $1`, bet.BetID).Scan(&status, &payout))
+	assert.Equal(t, "cashed_out", status)
+	assert.Equal(t, int64(1900), payout)
+
+	var txType string
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT type FROM v2_transactions WHERE player_id = $1 ORDER BY created_at DESC LIMIT 1`, playerID).Scan(&txType))
+	assert.Equal(t, "cash_out", txType)
+
+	// A second cash-out is refused, and settling the event leaves the bet alone.
+	resp = env.AuthPOST("/sportsbook/bets/"+bet.BetID+"/cashout", nil, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	_, err = env.Pool.Exec(t.Context(), `UPDATE sports_events SET status = 'settled' WHERE id = $1`, eventID)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `UPDATE sports_selections SET result = 'won' WHERE id = $1`, selectionID)
+	require.NoError(t, err)
+	resp = env.POST("/admin/sportsbook/events/"+eventID.String()+"/settle", nil, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 10900, 0, 0)
+}
+
+func TestCashOut_EventKillSwitch(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("cashoutkill@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	adminToken := env.AdminToken("superadmin")
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 1000,
+	}, token)
+	var bet struct {
+		BetID string `json:"bet_id"`
+	}
+	testutil.DecodeJSON(t, resp, &bet)
+
+	resp = env.AuthPATCH("/admin/sportsbook/events/"+eventID.String()+"/cash-out", map[string]bool{"enabled": false}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID+"/cashout", token)
+	var quote struct {
+		Available bool   `json:"available"`
+		Reason    string `json:"reason"`
+	}
+	testutil.DecodeJSON(t, resp, &quote)
+	assert.False(t, quote.Available)
+	assert.Equal(t, "cash-out is disabled for this event", quote.Reason)
+
+	resp = env.AuthPOST("/sportsbook/bets/"+bet.BetID+"/cashout", nil, token)
+	testutil.AssertErrorCode(t, resp, "CASH_OUT_UNAVAILABLE")
+	testutil.AssertBalance(t, env, playerID, 9000, 0, 0)
+
+	// Another player cannot see the bet at all.
+	otherToken, _ := env.RegisterPlayer("cashoutother@test.com", "securepass123", "EUR")
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID+"/cashout", otherToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCashOut_SystemBetUnavailable(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("cashoutsystem@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	var legs []map[string]interface{}
+	for _, odds := range []int{200, 300, 400} {
+		_, _, _, selectionID := env.SeedSportsbook(odds)
+		legs = append(legs, map[string]interface{}{"selection_id": selectionID, "expected_odds": odds})
+	}
+	resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"bet_class": "system", "system": "trixie", "stake": 100, "legs": legs,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bet struct {
+		BetID string `json:"bet_id"`
+	}
+	testutil.DecodeJSON(t, resp, &bet)
+
+	// The bet has no selection of its own, but is still quoted.
+	resp = env.AuthGET("/sportsbook/bets/"+bet.BetID+"/cashout", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var quote struct {
+		Available bool   `json:"available"`
+		Reason    string `json:"reason"`
+	}
+	testutil.DecodeJSON(t, resp, &quote)
+	assert.False(t, quote.Available)
+	assert.Equal(t, "system and bet-builder bets cannot be cashed out", quote.Reason)
+
+	resp = env.AuthPOST("/sportsbook/bets/"+bet.BetID+"/cashout", nil, token)
+	testutil.AssertErrorCode(t, resp, "CASH_OUT_UNAVAILABLE")
+	testutil.AssertBalance(t, env, playerID, 9600, 0, 0)
+}
+
+func TestCashOut_PaysTheStakeWallet(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("cashoutusd@test.com", "securepass123", "EUR")
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+	ctx := t.Context()
+
+	// Stake 1000 from the player's USD wallet rather than the EUR base
+	_, err := env.Pool.Exec(ctx,
+		`INSERT INTO player_wallets (player_id, currency, balance) VALUES ($1, 'USD', 5000)`, playerID)
+	require.NoError(t, err)
+	betID := uuid.New()
+	tx, err := env.Pool.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+	placed, err := env.LedgerEngine().ExecutePlaceBet(ctx, tx, domain.PlaceBetParams{
+		PlayerID: playerID, Amount: 1000, ExternalTransactionID: "bet_usd", ManufacturerID: "sportsbook",
+		SubTransactionID: "1", GameRoundID: "sb_usd", Currency: "USD",
+	})
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO sports_bets (id, player_id, event_id, market_id, selection_id, stake_amount_minor, currency,
+			odds_at_placement, potential_payout_minor, status, game_round_id, transaction_id, stake_per_line_minor)
+		VALUES ($1, $2, $3, $4, $5, 1000, 'USD', 250, 2500, 'open', 'sb_usd', $6, 1000)`,
+		betID, playerID, eventID, marketID, selectionID, placed.Transaction.ID)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	_, err = env.Pool.Exec(ctx, `UPDATE sports_selections SET odds_decimal = 125 WHERE id = $1`, selectionID)
+	require.NoError(t, err)
+	resp := env.AuthPOST("/sportsbook/bets/"+betID.String()+"/cashout", nil, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The 1900 cash-out lands in USD; the EUR base is untouched
+	var usd int64
+	require.NoError(t, env.Pool.QueryRow(ctx,
+		`SELECT balance::bigint FROM player_wallets WHERE player_id = $1 AND currency = 'USD'`, playerID).Scan(&usd))
+	assert.Equal(t, int64(5900), usd)
+	testutil.AssertBalance(t, env, playerID, 0, 0, 0)
+	var currency string
+	require.NoError(t, env.Pool.QueryRow(ctx,
+		`SELECT currency FROM v2_transactions WHERE player_id = $1 AND type = 'cash_out'`, playerID).Scan(&currency))
+	assert.Equal(t, "USD", currency)
+}
+
+// ─── Odds Change Tests (1) ────────────────────────────────────────────────
+
+func TestBet_OddsChangeNeedsConfirmation(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("oddschange@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	place := func(expected int, accept bool) *http.Response {
+		return env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 1000,
+			"expected_odds": expected, "accept_odds_changes": accept,
+		}, token)
+	}
+
+	// A move within the 2% tolerance is placed at the current odds.
+	resp := place(254, false)
+	var bet struct {
+		Odds int `json:"odds"`
+	}
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &bet)
+	assert.Equal(t, 250, bet.Odds)
+
+	// The selection shortens to 2.20: the slip's 2.50 must be re-confirmed.
+	_, err := env.Pool.Exec(t.Context(), `UPDATE sports_selections SET odds_decimal = 220 WHERE id = $1`, selectionID)
+	require.NoError(t, err)
+	resp = place(250, false)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	var errResp struct {
+		Code    string `json:"code"`
+		Details struct {
+			ExpectedOdds int `json:"expected_odds"`
+			CurrentOdds  int `json:"current_odds"`
+		} `json:"details"`
+	}
+	testutil.DecodeJSON(t, resp, &errResp)
+	assert.Equal(t, "ODDS_CHANGED", errResp.Code)
+	assert.Equal(t, 250, errResp.Details.ExpectedOdds)
+	assert.Equal(t, 220, errResp.Details.CurrentOdds)
+	testutil.AssertBalance(t, env, playerID, 9000, 0, 0)
+
+	// Accepting odds changes places it at the new price.
+	resp = place(250, true)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &bet)
+	assert.Equal(t, 220, bet.Odds)
+	testutil.AssertBalance(t, env, playerID, 8000, 0, 0)
+}
+
+// ─── Exposure Limit Tests (1) ─────────────────────────────────────────────
+
+func TestExposure_PlayerAndEventLimits(t *testing.T) {
+	env := testutil.NewExposureTestEnv(t, policy.ExposureLimits{Selection: 10_000, PlayerEvent: 6_000})
+	tokenA, playerA := env.RegisterPlayer("exposure-a@test.com", "securepass123", "EUR")
+	tokenB, playerB := env.RegisterPlayer("exposure-b@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerA, 10000)
+	env.DirectDeposit(playerB, 10000)
+	adminToken := env.AdminToken("superadmin")
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	place := func(token string, stake int64, acceptCap bool) *http.Response {
+		return env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": stake,
+			"accept_stake_cap": acceptCap,
+		}, token)
+	}
+	type refusal struct {
+		Code    string `json:"code"`
+		Details struct {
+			MaxStake int64 `json:"max_stake"`
+		} `json:"details"`
+	}
+	assertRefused := func(resp *http.Response, maxStake int64) {
+		t.Helper()
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		var r refusal
+		testutil.DecodeJSON(t, resp, &r)
+		assert.Equal(t, "EXPOSURE_LIMIT_EXCEEDED", r.Code)
+		assert.Equal(t, maxStake, r.Details.MaxStake)
+	}
+
+	// Player A may win at most 60.00 on the event: 20.00 at 2.50 pays 50.00,
+	// leaving room for a 4.00 stake.
+	resp := place(tokenA, 2000, false)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assertRefused(place(tokenA, 1000, false), 400)
+
+	resp = place(tokenA, 1000, true)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bet struct {
+		Stake       int64 `json:"stake"`
+		StakeCapped bool  `json:"stake_capped"`
+	}
+	testutil.DecodeJSON(t, resp, &bet)
+	assert.Equal(t, int64(400), bet.Stake)
+	assert.True(t, bet.StakeCapped)
+	testutil.AssertBalance(t, env, playerA, 7600, 0, 0)
+
+	// The selection has 60.00 of its 100.00 liability taken.
+	assertRefused(place(tokenB, 3000, false), 1600)
+
+	resp = env.AuthGET("/admin/sportsbook/events/"+eventID.String()+"/exposure", adminToken)
+	var exposure struct {
+		OpenBets  int   `json:"open_bets"`
+		Stake     int64 `json:"stake"`
+		WorstCase int64 `json:"worst_case"`
+		Players   []struct {
+			PlayerID  string `json:"player_id"`
+			Liability int64  `json:"liability"`
+		} `json:"players"`
+	}
+	testutil.DecodeJSON(t, resp, &exposure)
+	assert.Equal(t, 2, exposure.OpenBets)
+	assert.Equal(t, int64(2400), exposure.Stake)
+	assert.Equal(t, int64(6000), exposure.WorstCase)
+	require.Len(t, exposure.Players, 1)
+	assert.Equal(t, playerA.String(), exposure.Players[0].PlayerID)
+
+	// An event limit below the current worst case stops further liability.
+	resp = env.AuthPATCH("/admin/sportsbook/events/"+eventID.String()+"/exposure-limit",
+		map[string]int64{"max_liability": 5000}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assertRefused(place(tokenB, 100, false), 0)
+
+	resp = env.AuthGET("/admin/sportsbook/exposure", adminToken)
+	var list []struct {
+		EventID        string `json:"event_id"`
+		LiabilityLimit int64  `json:"liability_limit"`
+		LimitOverride  bool   `json:"limit_override"`
+	}
+	testutil.DecodeJSON(t, resp, &list)
+	require.Len(t, list, 1)
+	assert.Equal(t, eventID.String(), list[0].EventID)
+	assert.Equal(t, int64(5000), list[0].LiabilityLimit)
+	assert.True(t, list[0].LimitOverride)
+}
+
+// ─── Market Suspension Tests (1) ──────────────────────────────────────────
+
+func TestBet_SuspendedMarketRejected(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("frozen@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	place := func() *http.Response {
+		return env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 1000,
+		}, token)
+	}
+
+	// Frozen by the feed when the event went live
+	_, err := env.Pool.Exec(t.Context(), `
+		UPDATE sports_markets SET status = 'suspended', suspended_until = now() + interval '1 minute',
+			suspension_reason = 'in_play'
+		WHERE id = $1`, marketID)
+	require.NoError(t, err)
+
+	resp := place()
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	var body struct {
+		Code    string `json:"code"`
+		Details struct {
+			SuspendedUntil string `json:"suspended_until"`
+		} `json:"details"`
+	}
+	testutil.DecodeJSON(t, resp, &body)
+	assert.Equal(t, "MARKET_SUSPENDED", body.Code)
+	assert.NotEmpty(t, body.Details.SuspendedUntil)
+
+	// Suspended by hand: no end to the suspension
+	_, err = env.Pool.Exec(t.Context(), `
+		UPDATE sports_markets SET suspended_until = NULL, suspension_reason = NULL WHERE id = $1`, marketID)
+	require.NoError(t, err)
+	testutil.AssertErrorCode(t, place(), "MARKET_SUSPENDED")
+
+	// A freeze that has run out no longer blocks betting
+	_, err = env.Pool.Exec(t.Context(), `
+		UPDATE sports_markets SET suspended_until = now() - interval '1 second' WHERE id = $1`, marketID)
+	require.NoError(t, err)
+	resp = place()
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+// ─── System Bet Tests (1) ─────────────────────────────────────────────────
+
+func TestSystemBet_TrixieSettlesWhenAllLegsHaveResults(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("trixie@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	adminToken := env.AdminToken("superadmin")
+
+	var events []uuid.UUID
+	var legs []map[string]interface{}
+	for _, odds := range []int{200, 300, 400} {
+		_, eventID, _, selectionID := env.SeedSportsbook(odds)
+		events = append(events, eventID)
+		legs = append(legs, map[string]interface{}{"selection_id": selectionID, "expected_odds": odds})
+	}
+
+	// Two legs on one event are refused
+	resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"bet_class": "system", "system": "trixie", "stake": 100,
+		"legs": []interface{}{legs[0], legs[0], legs[1]},
+	}, token)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	// Four lines of 1.00: doubles at 6, 8 and 12 and a treble at 24
+	resp = env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"bet_class": "system", "system": "trixie", "stake": 100, "legs": legs,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var placed struct {
+		BetID           uuid.UUID `json:"bet_id"`
+		BetClass        string    `json:"bet_class"`
+		Lines           int       `json:"lines"`
+		TotalStake      int64     `json:"total_stake"`
+		PotentialPayout int64     `json:"potential_payout"`
+	}
+	testutil.DecodeJSON(t, resp, &placed)
+	assert.Equal(t, "system", placed.BetClass)
+	assert.Equal(t, 4, placed.Lines)
+	assert.Equal(t, int64(400), placed.TotalStake)
+	assert.Equal(t, int64(5000), placed.PotentialPayout)
+	testutil.AssertBalance(t, env, playerID, 9600, 0, 0)
+
+	settle := func(i int, result string) (settled, systems int) {
+		t.Helper()
+		_, err := env.Pool.Exec(t.Context(), `UPDATE sports_events SET status = 'settled' WHERE id = $1`, events[i])
+		require.NoError(t, err)
+		_, err = env.Pool.Exec(t.Context(), `UPDATE sports_selections SET result = $2 WHERE id = $1`,
+			legs[i]["selection_id"], result)
+		require.NoError(t, err)
+		resp := env.POST("/admin/sportsbook/events/"+events[i].String()+"/settle", nil, adminToken)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var body struct {
+			Settled int `json:"settled"`
+			Systems int `json:"systems"`
+		}
+		testutil.DecodeJSON(t, resp, &body)
+		return body.Settled, body.Systems
+	}
+
+	// The bet waits for its last leg
+	_, systems := settle(0, "won")
+	assert.Zero(t, systems)
+	_, systems = settle(1, "won")
+	assert.Zero(t, systems)
+
+	// The third leg is void, counting as evens: 6 + 2 + 3 + 6 = 17.00
+	settled, systems := settle(2, "void")
+	assert.Equal(t, 1, settled)
+	assert.Equal(t, 1, systems)
+	testutil.AssertBalance(t, env, playerID, 9600+1700, 0, 0)
+
+	resp = env.AuthGET("/sportsbook/bets/me", token)
+	var history struct {
+		Bets []struct {
+			BetClass   string `json:"bet_class"`
+			SystemType string `json:"system_type"`
+			Status     string `json:"status"`
+			Payout     int64  `json:"payout_amount_minor"`
+			Legs       []struct {
+				SelectionID uuid.UUID `json:"selection_id"`
+			} `json:"legs"`
+		} `json:"bets"`
+	}
+	testutil.DecodeJSON(t, resp, &history)
+	require.Len(t, history.Bets, 1)
+	assert.Equal(t, "trixie", history.Bets[0].SystemType)
+	assert.Equal(t, "won", history.Bets[0].Status)
+	assert.Equal(t, int64(1700), history.Bets[0].Payout)
+	assert.Len(t, history.Bets[0].Legs, 3)
+}
+
+// ─── Bet Builder Tests (1) ────────────────────────────────────────────────
+
+func TestBetBuilder_ResultAndTotalSettleFromScore(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("builder@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	adminToken := env.AdminToken("superadmin")
+	_, eventID, _, homeWin := env.SeedSportsbook(200)
+
+	totalsID, overTwoHalf, otherOver := uuid.New(), uuid.New(), uuid.New()
+	_, err := env.Pool.Exec(t.Context(), `
+		INSERT INTO sports_markets (id, event_id, name, type, status) VALUES ($1, $2, 'Total Goals', 'over_under', 'open')`,
+		totalsID, eventID)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO sports_selections (id, market_id, name, odds_decimal, status, line, side)
+		VALUES ($1, $3, 'Over 2.5', 190, 'active', 250, 'over'),
+		       ($2, $3, 'Over 3.5', 300, 'active', 350, 'over')`,
+		overTwoHalf, otherOver, totalsID)
+	require.NoError(t, err)
+
+	// Two selections from one market cannot be combined
+	resp := env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"bet_class": "bet_builder", "stake": 1000,
+		"legs": []interface{}{map[string]interface{}{"selection_id": overTwoHalf}, map[string]interface{}{"selection_id": otherOver}},
+	}, token)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+
+	// Team A wins and over 2.5 goals: 2.00 x 1.90 at 90% = 3.42
+	resp = env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"bet_class": "bet_builder", "stake": 1000, "expected_odds": 342,
+		"legs": []interface{}{map[string]interface{}{"selection_id": homeWin}, map[string]interface{}{"selection_id": overTwoHalf}},
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var placed struct {
+		BetClass        string `json:"bet_class"`
+		Odds            int    `json:"odds"`
+		PotentialPayout int64  `json:"potential_payout"`
+	}
+	testutil.DecodeJSON(t, resp, &placed)
+	assert.Equal(t, "bet_builder", placed.BetClass)
+	assert.Equal(t, 342, placed.Odds)
+	assert.Equal(t, int64(3420), placed.PotentialPayout)
+	testutil.AssertBalance(t, env, playerID, 9000, 0, 0)
+
+	// 2-1: the result is recorded, the total resolved from the score
+	_, err = env.Pool.Exec(t.Context(),
+		`UPDATE sports_events SET status = 'settled', score_home = 2, score_away = 1 WHERE id = $1`, eventID)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `UPDATE sports_selections SET result = 'won' WHERE id = $1`, homeWin)
+	require.NoError(t, err)
+	resp = env.POST("/admin/sportsbook/events/"+eventID.String()+"/settle", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Won         int `json:"won"`
+		BetBuilders int `json:"bet_builders"`
+	}
+	testutil.DecodeJSON(t, resp, &body)
+	assert.Equal(t, 1, body.Won)
+	assert.Equal(t, 1, body.BetBuilders)
+	testutil.AssertBalance(t, env, playerID, 9000+3420, 0, 0)
+}
@@ -0,0 +1,139 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─── Store Tests (3) ──────────────────────────────────────────────────────
+
+// createStoreItem adds an item to the catalog through the admin API.
+func createStoreItem(t *testing.T, env *testutil.TestEnv, item map[string]interface{}) domain.StoreItem {
+	t.Helper()
+	item["price"] = 500
+	item["price_currency"] = "USD"
+	resp := env.AuthPOST("/admin/store/items", item, env.AdminToken("admin"))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created domain.StoreItem
+	testutil.DecodeJSON(t, resp, &created)
+	return created
+}
+
+// seedStoreOrder records a pending Adyen store order, as Checkout would.
+func seedStoreOrder(t *testing.T, env *testutil.TestEnv, playerID uuid.UUID, item domain.StoreItem) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	if item.CoinCurrency != nil {
+		_, err := env.Pool.Exec(ctx, `INSERT INTO player_wallets (player_id, currency) VALUES ($1, $2)`,
+			playerID, *item.CoinCurrency)
+		require.NoError(t, err)
+	}
+
+	paymentID := uuid.New()
+	_, err := env.Pool.Exec(ctx, `
+		INSERT INTO payments (id, player_id, type, amount, currency, status, provider, provider_session_id)
+		VALUES ($1, $2, 'store', $3, 'USD', 'pending', 'adyen', 'CS_STORE')`,
+		paymentID, playerID, item.Price)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO store_orders (player_id, item_id, payment_id, sku, item_name, kind, price, price_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'USD')`,
+		playerID, item.ID, paymentID, item.SKU, item.Name, item.Kind, item.Price)
+	require.NoError(t, err)
+	return paymentID
+}
+
+func TestStore_CoinBundleCreditsWallet(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("storecoins@test.com", "securepass123", "USD")
+	item := createStoreItem(t, env, map[string]interface{}{
+		"sku": "gc-50k", "name": "50k Gold Coins", "kind": "coin_bundle",
+		"coin_currency": "GC", "coin_amount": 50_000,
+	})
+	paymentID := seedStoreOrder(t, env, playerID, item)
+
+	completeAdyenPayment(t, env, paymentID, item.Price)
+	completeAdyenPayment(t, env, paymentID, item.Price)
+
+	gc, _ := coinBalance(t, env, playerID, domain.CurrencyGoldCoins)
+	assert.Equal(t, int64(50_000), gc)
+	testutil.AssertBalance(t, env, playerID, 0, 0, 0)
+
+	resp := env.AuthGET("/store/orders", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var orders []domain.StoreOrder
+	testutil.DecodeJSON(t, resp, &orders)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "completed", orders[0].Status)
+
+	resp = env.AuthGET("/store/entitlements", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var entitlements []domain.Entitlement
+	testutil.DecodeJSON(t, resp, &entitlements)
+	require.Len(t, entitlements, 1)
+	assert.NotNil(t, entitlements[0].TransactionID)
+}
+
+func TestStore_AvatarOwnedOnce(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("storeavatar@test.com", "securepass123", "USD")
+	seedCosmetics(t, env, "hat", "hat_gold")
+	item := createStoreItem(t, env, map[string]interface{}{
+		"sku": "hat-gold", "name": "Gold Hat", "kind": "avatar_item", "item_code": "hat_gold",
+	})
+	completeAdyenPayment(t, env, seedStoreOrder(t, env, playerID, item), item.Price)
+
+	resp := env.AuthGET("/store/entitlements", token)
+	var entitlements []domain.Entitlement
+	testutil.DecodeJSON(t, resp, &entitlements)
+	require.Len(t, entitlements, 1)
+	require.NotNil(t, entitlements[0].ItemCode)
+	assert.Equal(t, "hat_gold", *entitlements[0].ItemCode)
+
+	// The avatar item lands in the cosmetic inventory
+	resp = env.AuthGET("/players/me/cosmetics", token)
+	var inventory []domain.PlayerCosmetic
+	testutil.DecodeJSON(t, resp, &inventory)
+	require.Len(t, inventory, 1)
+	assert.Equal(t, domain.CosmeticSourceStore, inventory[0].Source)
+
+	// Owned avatar items cannot be bought again
+	resp = env.AuthPOST("/store/orders", map[string]string{"item_id": item.ID.String()}, token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestStore_QuestBoostMultipliesReward(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("storeboost@test.com", "securepass123", "USD")
+	item := createStoreItem(t, env, map[string]interface{}{
+		"sku": "boost-2x", "name": "Double Rewards", "kind": "quest_boost",
+		"boost_multiplier_bps": 20_000, "boost_minutes": 60,
+	})
+	completeAdyenPayment(t, env, seedStoreOrder(t, env, playerID, item), item.Price)
+
+	questID := env.SeedQuest("Boosted Quest", 1, 500)
+	_, err := env.Pool.Exec(t.Context(), `
+		INSERT INTO player_quest_progress (player_id, quest_id, progress, status)
+		VALUES ($1, $2, 1, 'completed')`, playerID, questID)
+	require.NoError(t, err)
+
+	resp := env.AuthPOST("/quests/"+questID.String()+"/claim", nil, token)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var claim struct {
+		RewardAmount int `json:"reward_amount"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&claim))
+	assert.Equal(t, 1000, claim.RewardAmount)
+}
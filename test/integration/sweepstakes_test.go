@@ -0,0 +1,185 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─── Sweepstakes Tests (4) ────────────────────────────────────────────────
+
+// seedCoinPurchase creates a package through the admin API and records a
+// pending Adyen purchase of it, as Purchase would after opening the GC and SC
+// wallets.
+func seedCoinPurchase(t *testing.T, env *testutil.TestEnv, playerID uuid.UUID, goldCoins, sweepsCoins int64) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	resp := env.AuthPOST("/admin/sweeps/packages", map[string]interface{}{
+		"name": "Starter", "price": 1000, "price_currency": "USD",
+		"gold_coins": goldCoins, "sweeps_coins": sweepsCoins,
+	}, env.AdminToken("admin"))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var pkg domain.CoinPackage
+	testutil.DecodeJSON(t, resp, &pkg)
+
+	for _, currency := range []string{domain.CurrencyGoldCoins, domain.CurrencySweepsCoins} {
+		_, err := env.Pool.Exec(ctx, `INSERT INTO player_wallets (player_id, currency) VALUES ($1, $2)`, playerID, currency)
+		require.NoError(t, err)
+	}
+
+	paymentID := uuid.New()
+	_, err := env.Pool.Exec(ctx, `
+		INSERT INTO payments (id, player_id, type, amount, currency, status, provider, provider_session_id)
+		VALUES ($1, $2, 'purchase', 1000, 'USD', 'pending', 'adyen', 'CS_SWEEPS')`,
+		paymentID, playerID)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(ctx, `
+		INSERT INTO coin_purchases (player_id, package_id, payment_id, price, price_currency, gold_coins, sweeps_coins)
+		VALUES ($1, $2, $3, 1000, 'USD', $4, $5)`,
+		playerID, pkg.ID, paymentID, goldCoins, sweepsCoins)
+	require.NoError(t, err)
+	return paymentID
+}
+
+// completeAdyenPayment fires the Adyen AUTHORISATION webhook for a pending
+// payment of amount USD minor units.
+func completeAdyenPayment(t *testing.T, env *testutil.TestEnv, paymentID uuid.UUID, amount int64) {
+	t.Helper()
+	payload := testutil.AdyenWebhookPayload(provider.AdyenNotificationItem{
+		Amount:            provider.AdyenAmount{Currency: "USD", Value: amount},
+		EventCode:         "AUTHORISATION",
+		MerchantReference: paymentID.String(),
+		PspReference:      "PSP_" + paymentID.String()[:8],
+		Success:           "true",
+	})
+	resp := env.RawPOST("/webhooks/adyen", payload, map[string]string{"Content-Type": "application/json"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func coinBalance(t *testing.T, env *testutil.TestEnv, playerID uuid.UUID, currency string) (balance, reserved int64) {
+	t.Helper()
+	err := env.Pool.QueryRow(context.Background(),
+		`SELECT balance, reserved_balance FROM player_wallets WHERE player_id = $1 AND currency = $2`,
+		playerID, currency).Scan(&balance, &reserved)
+	require.NoError(t, err)
+	return balance, reserved
+}
+
+func TestSweepstakes_PurchaseCreditsCoins(t *testing.T) {
+	env := testutil.NewSweepsTestEnv(t)
+	token, playerID := env.RegisterPlayer("sweepsbuy@test.com", "securepass123", "USD")
+	paymentID := seedCoinPurchase(t, env, playerID, 100_000, 1_000)
+
+	completeAdyenPayment(t, env, paymentID, 1000)
+	// A redelivered notification credits nothing more
+	completeAdyenPayment(t, env, paymentID, 1000)
+
+	gc, _ := coinBalance(t, env, playerID, domain.CurrencyGoldCoins)
+	sc, _ := coinBalance(t, env, playerID, domain.CurrencySweepsCoins)
+	assert.Equal(t, int64(100_000), gc)
+	assert.Equal(t, int64(1_000), sc)
+	testutil.AssertBalance(t, env, playerID, 0, 0, 0)
+
+	resp := env.AuthGET("/sweeps/purchases", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var purchases []domain.CoinPurchase
+	testutil.DecodeJSON(t, resp, &purchases)
+	require.Len(t, purchases, 1)
+	assert.Equal(t, "completed", purchases[0].Status)
+}
+
+func TestSweepstakes_RedemptionRequiresKYC(t *testing.T) {
+	env := testutil.NewSweepsTestEnv(t)
+	token, playerID := env.RegisterPlayer("sweepskyc@test.com", "securepass123", "USD")
+	completeAdyenPayment(t, env, seedCoinPurchase(t, env, playerID, 0, 10_000), 1000)
+
+	resp := env.AuthGET("/sweeps/redemptions/eligibility", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var decision policy.RedemptionDecision
+	testutil.DecodeJSON(t, resp, &decision)
+	assert.False(t, decision.Eligible)
+	assert.Equal(t, "kyc_required", decision.Reason)
+
+	resp = env.AuthPOST("/sweeps/redemptions", map[string]int64{"amount": 5_000}, token)
+	defer resp.Body.Close()
+	testutil.AssertErrorCode(t, resp, "REDEMPTION_INELIGIBLE")
+}
+
+func TestSweepstakes_RedemptionReviewFlow(t *testing.T) {
+	env := testutil.NewSweepsTestEnv(t)
+	token, playerID := env.RegisterPlayer("sweepsredeem@test.com", "securepass123", "USD")
+	completeAdyenPayment(t, env, seedCoinPurchase(t, env, playerID, 0, 20_000), 1000)
+	_, err := env.Pool.Exec(context.Background(), `UPDATE player_profiles SET verified = true WHERE player_id = $1`, playerID)
+	require.NoError(t, err)
+	adminToken := env.AdminToken("admin")
+
+	redeem := func() domain.SweepsRedemption {
+		resp := env.AuthPOST("/sweeps/redemptions", map[string]int64{"amount": 5_000}, token)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var redemption domain.SweepsRedemption
+		testutil.DecodeJSON(t, resp, &redemption)
+		return redemption
+	}
+
+	// Rejecting returns the reserved SC
+	rejected := redeem()
+	assert.Equal(t, int64(5_000), rejected.CashAmount)
+	sc, reserved := coinBalance(t, env, playerID, domain.CurrencySweepsCoins)
+	assert.Equal(t, int64(15_000), sc)
+	assert.Equal(t, int64(5_000), reserved)
+
+	resp := env.AuthPOST("/admin/sweeps/redemptions/"+rejected.ID.String()+"/reject", map[string]string{"note": "duplicate"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	sc, reserved = coinBalance(t, env, playerID, domain.CurrencySweepsCoins)
+	assert.Equal(t, int64(20_000), sc)
+	assert.Equal(t, int64(0), reserved)
+
+	// Approving pays the prize out of the reserve
+	paid := redeem()
+	resp = env.AuthPOST("/admin/sweeps/redemptions/"+paid.ID.String()+"/approve", nil, adminToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var approved domain.SweepsRedemption
+	testutil.DecodeJSON(t, resp, &approved)
+	assert.Equal(t, domain.RedemptionPaid, approved.Status)
+	sc, reserved = coinBalance(t, env, playerID, domain.CurrencySweepsCoins)
+	assert.Equal(t, int64(15_000), sc)
+	assert.Equal(t, int64(0), reserved)
+
+	// A reviewed redemption cannot be reviewed again
+	resp = env.AuthPOST("/admin/sweeps/redemptions/"+paid.ID.String()+"/reject", nil, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestSweepstakes_GatesRealMoneyVerticals(t *testing.T) {
+	env := testutil.NewSweepsTestEnv(t)
+	token, _ := env.RegisterPlayer("sweepsgate@test.com", "securepass123", "USD")
+
+	resp := env.AuthPOST("/payments/deposit", map[string]interface{}{"amount": 1000}, token)
+	testutil.AssertErrorCode(t, resp, "VERTICAL_UNAVAILABLE")
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/payments/withdraw", map[string]interface{}{"amount": 1000}, token)
+	testutil.AssertErrorCode(t, resp, "VERTICAL_UNAVAILABLE")
+	resp.Body.Close()
+
+	// Outside sweepstakes mode the sweeps routes are closed instead
+	regular := testutil.NewTestEnv(t)
+	token, _ = regular.RegisterPlayer("regular@test.com", "securepass123", "USD")
+	resp = regular.AuthGET("/sweeps/packages", token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// newAdyenPayouts starts a fake Adyen Payout API that answers payouts with
+// results in order, then authorises every payout after them.
+func newAdyenPayouts(t *testing.T, results []string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		result := "authorised"
+		if len(results) > 0 {
+			result, results = results[0], results[1:]
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch result {
+		case "unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":503,"errorCode":"000","message":"Service unavailable"}`))
+		case "refused":
+			w.Write([]byte(`{"pspReference":"PSP-REFUSED","resultCode":"Refused","refusalReason":"Blocked Card"}`))
+		default:
+			w.Write([]byte(`{"pspReference":"PSP-PAID","resultCode":"Authorised"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
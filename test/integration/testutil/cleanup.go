@@ -48,7 +48,9 @@ func (env *TestEnv) CleanAll() {
 
 		// Gamification
 		"reward_grants",
+		"player_quest_history",
 		"player_quest_progress",
+		"quest_prerequisites",
 		"quests",
 		"player_engagement",
 
@@ -57,7 +59,16 @@ func (env *TestEnv) CleanAll() {
 		"odds88_player_map",
 		"odds88_feed_state",
 		"sports_parlay_legs",
+		"bet_pool_messages",
+		"bet_pool_payouts",
+		"bet_pool_contributions",
+		"bet_pool_members",
+		"bet_pools",
 		"sports_parlay_bets",
+		"trading_alerts",
+		"sports_odds_changes",
+		"sports_settlement_audit",
+		"sports_bet_legs",
 		"sports_bets",
 		"sports_selections",
 		"sports_markets",
@@ -65,25 +76,87 @@ func (env *TestEnv) CleanAll() {
 		"sports",
 
 		// Admin
+		"player_status_history",
 		"player_notes",
 		"admin_users",
 
 		// Payments
+		"pending_credits",
+		"payment_refunds",
+		"reconciliation_discrepancies",
+		"reconciliation_runs",
+		"retention_run_items",
+		"retention_runs",
+		"withdrawal_risk_assessments",
+		"aml_alerts",
 		"payment_events",
+		"player_cosmetics",
+		"player_entitlements",
+		"store_orders",
+		"store_items",
+		"cosmetic_items",
+		"sweeps_redemptions",
+		"coin_purchases",
+		"coin_packages",
+		"saga_step_log",
+		"sagas",
 		"payments",
 		"payment_methods",
 
 		// Player bonuses
+		"bonus_grant_job_items",
+		"bonus_grant_jobs",
+		"bonus_opt_ins",
+		"free_spin_grants",
 		"player_bonuses",
 		"bonuses",
 
 		// Core
+		"provider_callbacks",
+		"rg_case_interactions",
+		"rg_cases",
+		"rg_risk_scores",
+		"ip_risk_checks",
+		"consents",
+		"rg_interventions",
+		"kyc_documents",
+		"sof_documents",
+		"sof_questionnaires",
+		"terms_acceptances",
+		"terms_documents",
+		"content_page_versions",
+		"content_pages",
+		"experiment_exposures",
+		"experiments",
+		"feature_flags",
+		"maintenance_windows",
+		"placement_events",
+		"placements",
+		"player_notifications",
 		"player_limits",
 		"sessions",
+		"game_launches",
+		"game_session_tokens",
+		"manufacturers",
 		"games",
 		"game_manufacturers",
+		"event_outbox_dlq",
 		"event_outbox",
+		"projected_events",
+		"quest_progress_events",
+		"bet_feed",
+		"daily_kpis",
+		"player_summary",
+		"p2p_transfers",
+		"ledger_discrepancies",
+		"game_rtp_alerts",
+		"game_rtp_daily",
+		"game_rounds",
+		"idempotency_keys",
+		"ledger_entries",
 		"v2_transactions",
+		"player_wallets",
+		"fx_rates",
 		"player_profiles",
 		"auth_users",
 		"v2_players",
@@ -94,6 +167,9 @@ func (env *TestEnv) CleanAll() {
 		// Security
 		"login_attempts",
 		"password_reset_tokens",
+		"player_backup_emails",
+		"admin_audit_log",
+		"admin_export_jobs",
 	}
 
 	for _, table := range tables {
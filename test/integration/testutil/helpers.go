@@ -10,11 +10,20 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/attaboy/platform/internal/auth"
+	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/projection"
+	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -148,6 +157,30 @@ func (env *TestEnv) AuthPATCH(path string, body interface{}, token string) *http
 	return resp
 }
 
+// AuthPUT performs an authenticated PUT request.
+func (env *TestEnv) AuthPUT(path string, body interface{}, token string) *http.Response {
+	env.t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			env.t.Fatalf("PUT %s: encode: %v", path, err)
+		}
+	}
+	req, err := http.NewRequest("PUT", env.Server.URL+path, &buf)
+	if err != nil {
+		env.t.Fatalf("PUT %s: new request: %v", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		env.t.Fatalf("PUT %s: %v", path, err)
+	}
+	return resp
+}
+
 // AuthDELETE performs an authenticated DELETE request.
 func (env *TestEnv) AuthDELETE(path, token string) *http.Response {
 	env.t.Helper()
@@ -163,6 +196,22 @@ func (env *TestEnv) AuthDELETE(path, token string) *http.Response {
 	return resp
 }
 
+// AuthPOSTRaw performs an authenticated POST of a non-JSON body.
+func (env *TestEnv) AuthPOSTRaw(path, contentType, body, token string) *http.Response {
+	env.t.Helper()
+	req, err := http.NewRequest("POST", env.Server.URL+path, strings.NewReader(body))
+	if err != nil {
+		env.t.Fatalf("POST %s: new request: %v", path, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		env.t.Fatalf("POST %s: %v", path, err)
+	}
+	return resp
+}
+
 // OPTIONS performs an OPTIONS request.
 func (env *TestEnv) OPTIONS(path string) *http.Response {
 	env.t.Helper()
@@ -296,6 +345,23 @@ func (env *TestEnv) DirectBonusCredit(playerID uuid.UUID, amountCents int64) {
 	}
 }
 
+// GrantConsent records the player's consent to each purpose on every
+// channel it covers.
+func (env *TestEnv) GrantConsent(token string, purposes ...string) {
+	env.t.Helper()
+	changes := []map[string]interface{}{}
+	for _, purpose := range purposes {
+		for _, channel := range policy.ConsentChannels(purpose) {
+			changes = append(changes, map[string]interface{}{"purpose": purpose, "channel": channel, "granted": true})
+		}
+	}
+	resp := env.AuthPUT("/players/me/consents", map[string]interface{}{"consents": changes}, token)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		env.t.Fatalf("GrantConsent: status %d", resp.StatusCode)
+	}
+}
+
 // AdminToken generates a JWT for an admin user with the given role.
 func (env *TestEnv) AdminToken(role string) string {
 	env.t.Helper()
@@ -369,7 +435,8 @@ func (env *TestEnv) SeedQuest(name string, targetProgress, rewardAmount int) uui
 	return questID
 }
 
-// SeedPredictionMarket inserts a prediction market and returns its ID.
+// SeedPredictionMarket inserts an open prediction market with outcomes "yes"
+// at 2.50 and "no" at 1.60 and returns its ID.
 func (env *TestEnv) SeedPredictionMarket(title string) uuid.UUID {
 	env.t.Helper()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -388,8 +455,10 @@ func (env *TestEnv) SeedPredictionMarket(title string) uuid.UUID {
 
 	var marketID uuid.UUID
 	err := env.Pool.QueryRow(ctx, `
-		INSERT INTO prediction_markets (title, description, category, status, created_by)
-		VALUES ($1, 'Test prediction', 'general', 'open', $2) RETURNING id`,
+		INSERT INTO prediction_markets (title, description, category, status, outcomes, created_by)
+		VALUES ($1, 'Test prediction', 'general', 'open',
+		        '[{"id": "yes", "label": "Yes", "odds": 2.5}, {"id": "no", "label": "No", "odds": 1.6}]', $2)
+		RETURNING id`,
 		title, adminID).Scan(&marketID)
 	if err != nil {
 		env.t.Fatalf("SeedPredictionMarket: %v", err)
@@ -397,6 +466,75 @@ func (env *TestEnv) SeedPredictionMarket(title string) uuid.UUID {
 	return marketID
 }
 
+// LedgerEngine returns a ledger engine over the test DB, for driving services
+// the router runs in the background.
+func (env *TestEnv) LedgerEngine() *ledger.Engine {
+	return ledger.NewEngine(repository.NewPlayerRepository(), repository.NewWalletRepository(),
+		repository.NewTransactionRepository(), repository.NewLedgerEntryRepository(), repository.NewOutboxRepository(),
+		repository.NewGameRoundRepository(), repository.NewBonusRepository())
+}
+
+// RunProjections applies every event waiting in the outbox to the read
+// models, as the outbox consumer would before publishing it. The events stay
+// in the outbox.
+func (env *TestEnv) RunProjections() {
+	env.t.Helper()
+	ctx := context.Background()
+	rows, err := repository.NewOutboxRepository().FetchUnpublishedRows(ctx, env.Pool, 10_000)
+	if err != nil {
+		env.t.Fatalf("fetch outbox: %v", err)
+	}
+	projector := projection.NewProjector(env.Pool)
+	for _, row := range rows {
+		if err := projector.Project(ctx, row.OutboxDraft); err != nil {
+			env.t.Fatalf("project event %s: %v", row.EventID, err)
+		}
+	}
+}
+
+// RunQuestProgress applies every event waiting in the outbox to quest
+// progress, as the outbox consumer would before publishing it. The events
+// stay in the outbox.
+func (env *TestEnv) RunQuestProgress() {
+	env.t.Helper()
+	ctx := context.Background()
+	outbox := repository.NewOutboxRepository()
+	rows, err := outbox.FetchUnpublishedRows(ctx, env.Pool, 10_000)
+	if err != nil {
+		env.t.Fatalf("fetch outbox: %v", err)
+	}
+	engine := service.NewQuestProgressEngine(env.Pool, outbox, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	for _, row := range rows {
+		if err := engine.Apply(ctx, row.OutboxDraft); err != nil {
+			env.t.Fatalf("apply event %s to quests: %v", row.EventID, err)
+		}
+	}
+}
+
+// ResetDueQuests resets every recurring quest whose period has ended, as
+// the reset scheduler would on its next tick.
+func (env *TestEnv) ResetDueQuests() int {
+	env.t.Helper()
+	svc := service.NewQuestResetService(env.Pool, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	n, err := svc.ResetDue(context.Background())
+	if err != nil {
+		env.t.Fatalf("reset quests: %v", err)
+	}
+	return n
+}
+
+// FailInterruptedExports runs the export job sweep once and returns how many
+// jobs it failed.
+func (env *TestEnv) FailInterruptedExports() int64 {
+	env.t.Helper()
+	svc := service.NewExportJobService(env.Pool, nil, 0, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	n, err := svc.FailInterrupted(context.Background())
+	if err != nil {
+		env.t.Fatalf("fail interrupted exports: %v", err)
+	}
+	return n
+}
+
 // RegisterAffiliate creates a new affiliate and returns the auth token and affiliate ID.
 func (env *TestEnv) RegisterAffiliate(email, password, firstName, lastName string) (token string, affiliateID uuid.UUID) {
 	env.t.Helper()
@@ -553,3 +691,17 @@ func StripeWebhookSignature(payload []byte) string {
 	sig := hex.EncodeToString(mac.Sum(nil))
 	return fmt.Sprintf("t=%s,v1=%s", ts, sig)
 }
+
+// AdyenWebhookPayload builds a standard Adyen notification for one item,
+// signed with TestAdyenHMACKey.
+func AdyenWebhookPayload(item provider.AdyenNotificationItem) []byte {
+	key, _ := hex.DecodeString(TestAdyenHMACKey)
+	item.AdditionalData = map[string]string{"hmacSignature": provider.AdyenNotificationSignature(key, item)}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"live": "false",
+		"notificationItems": []map[string]provider.AdyenNotificationItem{
+			{"NotificationRequestItem": item},
+		},
+	})
+	return payload
+}
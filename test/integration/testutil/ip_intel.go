@@ -0,0 +1,35 @@
+//go:build integration
+
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attaboy/platform/internal/domain"
+)
+
+// newIPIntel starts a fake proxycheck.io server that reports rep for every
+// address it is asked about.
+func newIPIntel(t *testing.T, rep domain.IPReputation) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxy := "no"
+		if rep.Proxy {
+			proxy = "yes"
+		}
+		ip := strings.TrimPrefix(r.URL.Path, "/")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "ok",
+			ip: map[string]interface{}{
+				"proxy": proxy, "type": rep.ProxyType, "risk": rep.Risk, "isocode": rep.Country,
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -14,12 +14,16 @@ import (
 
 	"github.com/attaboy/platform/internal/app"
 	"github.com/attaboy/platform/internal/auth"
+	"github.com/attaboy/platform/internal/domain"
+	"github.com/attaboy/platform/internal/policy"
+	"github.com/attaboy/platform/internal/provider"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
 	TestJWTSecret          = "integration-test-secret"
 	TestStripeWebhookSecret = "whsec_test_integration_secret"
+	TestAdyenHMACKey = "44782def547aaa06c910c43932b1eb0c71fc68d9d0c057550c48ec2acf6ba056"
 	TestDBHost             = "localhost"
 	TestDBPort             = 5435
 	TestDBUser             = "attaboy"
@@ -132,7 +136,7 @@ func findProjectRoot() string {
 	return "."
 }
 
-func getSharedPool(t *testing.T) *pgxpool.Pool {
+func getSharedPool(t testing.TB) *pgxpool.Pool {
 	t.Helper()
 	poolOnce.Do(func() {
 		if err := ensureTestDB(); err != nil {
@@ -174,22 +178,133 @@ func getSharedPool(t *testing.T) *pgxpool.Pool {
 // NewTestEnv creates a test environment with an httptest.Server backed by the real router and test DB.
 func NewTestEnv(t *testing.T) *TestEnv {
 	t.Helper()
+	return newTestEnv(t, nil)
+}
+
+// NewSweepsTestEnv creates a test environment in sweepstakes mode. Playthrough
+// is off so purchased SC is redeemable straight away.
+func NewSweepsTestEnv(t *testing.T) *TestEnv {
+	t.Helper()
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.Sweepstakes = policy.DefaultSweepsRules()
+		deps.Sweepstakes.Enabled = true
+		deps.Sweepstakes.Playthrough = 0
+	})
+}
+
+// NewClosedLoopTestEnv creates a test environment where withdrawals must go
+// to a verified saved payment method already used for a deposit.
+func NewClosedLoopTestEnv(t *testing.T) *TestEnv {
+	t.Helper()
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.ClosedLoopPayouts = true
+	})
+}
+
+// TestKYCThreshold is the withdrawal amount above which NewKYCTestEnv
+// requires a verified player.
+const TestKYCThreshold = 100000
+
+// NewKYCTestEnv creates a test environment with KYC documents stored in an
+// in-memory object store and withdrawals above TestKYCThreshold gated on
+// verification.
+func NewKYCTestEnv(t *testing.T) *TestEnv {
+	t.Helper()
+	store := newObjectStore(t)
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.KYCStorage = provider.S3Config{
+			Endpoint: store.URL, Bucket: "kyc", AccessKey: "test", SecretKey: "test",
+		}
+		deps.KYCThreshold = TestKYCThreshold
+	})
+}
+
+// NewExportTestEnv creates a test environment whose export jobs are written
+// to an in-memory object store, one job per admin at a time.
+func NewExportTestEnv(t *testing.T) *TestEnv {
+	t.Helper()
+	store := newObjectStore(t)
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.ExportStorage = provider.S3Config{
+			Endpoint: store.URL, Bucket: "exports", AccessKey: "test", SecretKey: "test",
+		}
+		deps.MaxExportsPerAdmin = 1
+	})
+}
+
+// NewExposureTestEnv creates a test environment whose sportsbook enforces
+// the given liability limits.
+func NewExposureTestEnv(t *testing.T, limits policy.ExposureLimits) *TestEnv {
+	t.Helper()
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.SportsbookExposure = limits
+	})
+}
+
+// NewIPRiskTestEnv creates a test environment whose VPN/proxy detection
+// provider reports rep for every address, screened with the default rules.
+func NewIPRiskTestEnv(t *testing.T, rep domain.IPReputation) *TestEnv {
+	t.Helper()
+	intel := newIPIntel(t, rep)
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.IPIntel = provider.ProxyCheckConfig{BaseURL: intel.URL, APIKey: "test"}
+		deps.IPRisk = policy.DefaultIPRiskRules()
+	})
+}
+
+// NewPayoutTestEnv creates a test environment whose withdrawals are paid out
+// through a fake Adyen Payout API. It answers payouts with results in order,
+// each "authorised", "refused" or "unavailable", then authorises the rest.
+func NewPayoutTestEnv(t *testing.T, results ...string) *TestEnv {
+	t.Helper()
+	psp := newAdyenPayouts(t, results)
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.AdyenAPIKey = "test"
+		deps.AdyenMerchant = "AttaboyTest"
+		deps.AdyenPayoutURL = psp.URL
+	})
+}
+
+// NewSlotopolTestEnv creates a test environment backed by a fake Slotopol
+// server whose free rounds pay wins in order.
+func NewSlotopolTestEnv(t *testing.T, wins ...int64) *TestEnv {
+	t.Helper()
+	slotopol := newSlotopol(t, wins)
+	return newTestEnv(t, func(deps *app.RouterDeps) {
+		deps.SlotopolBaseURL = slotopol.URL
+	})
+}
+
+func newTestEnv(t *testing.T, configure func(*app.RouterDeps)) *TestEnv {
+	t.Helper()
 
 	pool := getSharedPool(t)
 
 	jwtMgr := auth.NewJWTManager(TestJWTSecret, 24*time.Hour, 8*time.Hour, 12*time.Hour)
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 
-	router := app.NewRouter(app.RouterDeps{
+	// P2P transfers are off by default; enable them with a 1% fee for tests.
+	p2pRules := policy.DefaultTransferRules()
+	p2pRules.Enabled = true
+	p2pRules.FeeBps = 100
+
+	deps := app.RouterDeps{
 		Pool:                pool,
 		JWTMgr:              jwtMgr,
 		Logger:              logger,
 		StripeSecretKey:     "",
 		StripeWebhookSecret: TestStripeWebhookSecret,
+		AdyenHMACKey:        TestAdyenHMACKey,
 		RandomOrgAPIKey:     "",
 		SlotopolBaseURL:     "http://localhost:4002",
 		CORSAllowedOrigins:  "*",
-	})
+		GraphQLEnabled:      true,
+		P2PTransfers:        p2pRules,
+	}
+	if configure != nil {
+		configure(&deps)
+	}
+	router := app.NewRouter(deps)
 
 	server := httptest.NewServer(router)
 
@@ -0,0 +1,52 @@
+//go:build integration
+
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// newSlotopol starts a fake Slotopol server that grants free rounds and pays
+// wins in order on each free round played, then nothing.
+func newSlotopol(t *testing.T, wins []int64) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	grants, played := 0, 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /freerounds", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		grants++
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("fr-%d", grants)})
+	})
+	mux.HandleFunc("POST /spin", func(w http.ResponseWriter, r *http.Request) {
+		var spin struct {
+			FreeRoundsID string `json:"free_rounds_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&spin); err != nil || spin.FreeRoundsID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		var win int64
+		if played < len(wins) {
+			win = wins[played]
+		}
+		played++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"game_round_id": fmt.Sprintf("round-%d", played), "win": win,
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -17,9 +18,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/attaboy/platform/internal/guard"
 	"github.com/attaboy/platform/internal/ledger"
+	"github.com/attaboy/platform/internal/metrics"
+	"github.com/attaboy/platform/internal/policy"
 	"github.com/attaboy/platform/internal/provider"
 	"github.com/attaboy/platform/internal/repository"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/attaboy/platform/internal/walletserver"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -28,19 +33,44 @@ import (
 const (
 	TestBSSecret = "test-bs-secret"
 	TestPPSecret = "test-pp-secret"
+	TestEVSecret = "test-ev-secret"
+	TestRLSecret = "test-rl-user:test-rl-pass"
+	TestNESecret = "test-ne-secret"
 )
 
+// TestBonusReminder is how long before expiry the wallet test env's bonus
+// expiry worker reminds players.
+const TestBonusReminder = 72 * time.Hour
+
+// TestRTPBands are the wallet test env's RTP band rules: a band of 3
+// standard errors of unit volatility, checked from the second round.
+var TestRTPBands = policy.RTPBandRules{Z: 3, Volatility: 1, MinRounds: 2}
+
 // WalletTestEnv holds resources for wallet server integration tests.
 type WalletTestEnv struct {
 	Server   *httptest.Server
 	Pool     *pgxpool.Pool
 	BSSecret string
 	PPSecret string
-	t        *testing.T
+	EVSecret string
+	RLSecret string
+	NESecret string
+	// Sweeper voids rounds idle for more than an hour.
+	Sweeper *service.RoundSweeper
+	// BonusExpiry reminds players TestBonusReminder before a bonus expires.
+	BonusExpiry *service.BonusExpiryWorker
+	// CasinoReport checks RTP with TestRTPBands.
+	CasinoReport *service.CasinoReportService
+	// Providers gates callbacks by the manufacturers table; tests call Reload
+	// after changing it.
+	Providers *walletserver.ProviderRegistry
+	// Engine is the ledger engine behind the wallet server.
+	Engine *ledger.Engine
+	t      testing.TB
 }
 
 // NewWalletTestEnv creates a test environment for the wallet server.
-func NewWalletTestEnv(t *testing.T) *WalletTestEnv {
+func NewWalletTestEnv(t testing.TB) *WalletTestEnv {
 	t.Helper()
 
 	pool := getSharedPool(t)
@@ -48,22 +78,46 @@ func NewWalletTestEnv(t *testing.T) *WalletTestEnv {
 
 	playerRepo := repository.NewPlayerRepository()
 	txRepo := repository.NewTransactionRepository()
+	ledgerEntryRepo := repository.NewLedgerEntryRepository()
 	outboxRepo := repository.NewOutboxRepository()
-	eng := ledger.NewEngine(playerRepo, txRepo, outboxRepo)
-
-	bsAdapter := provider.NewBetSolutionsAdapter(TestBSSecret, logger)
-	ppAdapter := provider.NewPragmaticAdapter(TestPPSecret, logger)
+	walletRepo := repository.NewWalletRepository()
+	gameRoundRepo := repository.NewGameRoundRepository()
+	bonusRepo := repository.NewBonusRepository()
+	eng := ledger.NewEngine(playerRepo, walletRepo, txRepo, ledgerEntryRepo, outboxRepo, gameRoundRepo, bonusRepo)
+
+	adapters, err := provider.DefaultAdapterRegistry().Build([]provider.AdapterConfig{
+		{Name: "betsolutions", Kind: "betsolutions", Prefix: "/betsolutions", Secret: TestBSSecret},
+		{Name: "pragmatic", Kind: "pragmatic", Prefix: "/pragmatic", Secret: TestPPSecret},
+		{Name: "evolution", Kind: "evolution", Prefix: "/evolution", Secret: TestEVSecret},
+		{Name: "relax", Kind: "relax", Prefix: "/relax", Secret: TestRLSecret},
+		{Name: "netent", Kind: "netent", Prefix: "/netent", Secret: TestNESecret},
+	}, logger)
+	if err != nil {
+		t.Fatalf("NewWalletTestEnv: build adapters: %v", err)
+	}
 
-	router := walletserver.NewRouter(pool, eng, txRepo, bsAdapter, ppAdapter, logger)
+	latency := metrics.NewCallbackLatency(metrics.DefaultSLOConfig(), logger)
+	breaker := guard.NewCircuitBreaker(10, 30*time.Second)
+	providers := walletserver.NewProviderRegistry(service.NewManufacturerService(pool, logger), logger)
+	router := walletserver.NewRouter(pool, eng, txRepo, service.NewGameSessionService(pool, 0), providers, adapters, latency, breaker, logger)
 	server := httptest.NewServer(router)
+	sweeper := service.NewRoundSweeper(pool, eng, gameRoundRepo, time.Hour, "sportsbook", logger)
 
 	env := &WalletTestEnv{
-		Server:   server,
-		Pool:     pool,
-		BSSecret: TestBSSecret,
-		PPSecret: TestPPSecret,
-		t:        t,
+		Server:    server,
+		Pool:      pool,
+		BSSecret:  TestBSSecret,
+		PPSecret:  TestPPSecret,
+		EVSecret:  TestEVSecret,
+		RLSecret:  TestRLSecret,
+		NESecret:  TestNESecret,
+		Sweeper:   sweeper,
+		Providers: providers,
+		Engine:    eng,
+		t:         t,
 	}
+	env.BonusExpiry = service.NewBonusExpiryWorker(pool, eng, bonusRepo, outboxRepo, TestBonusReminder, logger)
+	env.CasinoReport = service.NewCasinoReportService(pool, outboxRepo, TestRTPBands, logger)
 
 	t.Cleanup(func() {
 		server.Close()
@@ -80,8 +134,21 @@ func (env *WalletTestEnv) CleanAll() {
 	defer cancel()
 
 	tables := []string{
+		"provider_callbacks",
 		"event_outbox",
+		"ledger_discrepancies",
+		"game_rtp_alerts",
+		"game_rtp_daily",
+		"game_rounds",
+		"player_bonuses",
+		"bonuses",
+		"game_session_tokens",
+		"manufacturers",
+		"games",
+		"game_manufacturers",
+		"ledger_entries",
 		"v2_transactions",
+		"player_wallets",
 		"player_profiles",
 		"auth_users",
 		"v2_players",
@@ -269,6 +336,85 @@ func (env *WalletTestEnv) PPPostBadSig(req provider.PragmaticRequest) *http.Resp
 	return resp
 }
 
+// EVPost sends an Evolution One Wallet call with a valid authToken.
+func (env *WalletTestEnv) EVPost(path string, req provider.EvolutionRequest) *http.Response {
+	env.t.Helper()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		env.t.Fatalf("EVPost: marshal: %v", err)
+	}
+	sum := sha256.Sum256(append(append([]byte{}, body...), env.EVSecret...))
+
+	resp, err := http.Post(env.Server.URL+path+"?authToken="+hex.EncodeToString(sum[:]), "application/json", bytes.NewReader(body))
+	if err != nil {
+		env.t.Fatalf("EVPost: %v", err)
+	}
+	return resp
+}
+
+// RLPost sends a Relax Gaming P2P call with valid Basic auth credentials.
+func (env *WalletTestEnv) RLPost(path string, req provider.RelaxRequest) *http.Response {
+	env.t.Helper()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		env.t.Fatalf("RLPost: marshal: %v", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", env.Server.URL+path, bytes.NewReader(body))
+	if err != nil {
+		env.t.Fatalf("RLPost: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(env.RLSecret)))
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		env.t.Fatalf("RLPost: %v", err)
+	}
+	return resp
+}
+
+// NESession issues a NetEnt session token for a player.
+func (env *WalletTestEnv) NESession(playerID uuid.UUID, ttl time.Duration) string {
+	return provider.NewNetEntAdapter(TestNESecret, nil).IssueSessionToken(playerID, ttl)
+}
+
+// NEPost sends a NetEnt seamless wallet call with the caller password set.
+func (env *WalletTestEnv) NEPost(path string, req provider.NetEntRequest) *http.Response {
+	env.t.Helper()
+
+	req.CallerID = "netent"
+	req.CallerPassword = env.NESecret
+	body, err := json.Marshal(req)
+	if err != nil {
+		env.t.Fatalf("NEPost: marshal: %v", err)
+	}
+
+	resp, err := http.Post(env.Server.URL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		env.t.Fatalf("NEPost: %v", err)
+	}
+	return resp
+}
+
+// GetReservedBalance reads a player's reserved balance.
+func (env *WalletTestEnv) GetReservedBalance(playerID uuid.UUID) int64 {
+	env.t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var reserved int64
+	err := env.Pool.QueryRow(ctx,
+		"SELECT reserved_balance::bigint FROM v2_players WHERE id = $1",
+		playerID).Scan(&reserved)
+	if err != nil {
+		env.t.Fatalf("GetReservedBalance: %v", err)
+	}
+	return reserved
+}
+
 // computeHMAC computes HMAC-SHA256 for BetSolutions (strips "Hash" field).
 func computeHMAC(body []byte, secret string) string {
 	// Strip the Hash field (same logic as the adapter)
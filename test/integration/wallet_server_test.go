@@ -4,10 +4,15 @@ package integration
 
 import (
 	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/attaboy/platform/internal/provider"
+	"github.com/attaboy/platform/internal/service"
 	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,7 +25,6 @@ func TestBS_Balance(t *testing.T) {
 	env.DirectDeposit(playerID, 5000)
 
 	resp := env.BSPost("/betsolutions/balance", provider.BetSolutionsRequest{
-		Token:    "test-token",
 		PlayerID: playerID.String(),
 		Currency: "EUR",
 	})
@@ -38,7 +42,6 @@ func TestBS_Bet(t *testing.T) {
 	env.DirectDeposit(playerID, 10000)
 
 	resp := env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-1",
 		RoundID:       "round-1",
@@ -65,7 +68,6 @@ func TestBS_Win(t *testing.T) {
 
 	// Place bet first
 	env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-1",
 		RoundID:       "round-1",
@@ -76,7 +78,6 @@ func TestBS_Win(t *testing.T) {
 
 	// Credit win
 	resp := env.BSPost("/betsolutions/win", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-1",
 		RoundID:       "round-1",
@@ -102,7 +103,6 @@ func TestBS_Rollback_Found(t *testing.T) {
 
 	// Place bet
 	env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-1",
 		RoundID:       "round-1",
@@ -113,7 +113,6 @@ func TestBS_Rollback_Found(t *testing.T) {
 
 	// Rollback the bet
 	resp := env.BSPost("/betsolutions/rollback", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-1",
 		RoundID:       "round-1",
@@ -138,7 +137,6 @@ func TestBS_Rollback_NotFound(t *testing.T) {
 
 	// Rollback a transaction that never existed
 	resp := env.BSPost("/betsolutions/rollback", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		TransactionID: "tx-never-placed",
 		Currency:      "EUR",
@@ -151,12 +149,92 @@ func TestBS_Rollback_NotFound(t *testing.T) {
 	assert.Equal(t, int64(10000), result.Balance) // unchanged
 }
 
+func TestBS_Rollback_Win(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	for _, step := range []struct {
+		path, txID string
+		amount     int64
+	}{
+		{"/betsolutions/bet", "tx-bet-w", 3000},
+		{"/betsolutions/win", "tx-win-w", 8000},
+	} {
+		resp := env.BSPost(step.path, provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: "game-1", RoundID: "round-w",
+			TransactionID: step.txID, Amount: step.amount, Currency: "EUR",
+		})
+		resp.Body.Close()
+	}
+
+	// Rolling back the win takes it off and leaves the stake lost.
+	resp := env.BSPost("/betsolutions/rollback", provider.BetSolutionsRequest{
+		PlayerID: playerID.String(), GameID: "game-1", RoundID: "round-w",
+		TransactionID: "tx-win-w", Currency: "EUR",
+	})
+	defer resp.Body.Close()
+
+	var result provider.BetSolutionsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, int64(7000), result.Balance)
+
+	var cancelType string
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT type FROM v2_transactions WHERE player_id = $1 AND external_transaction_id = 'rollback_tx-win-w'`,
+		playerID).Scan(&cancelType))
+	assert.Equal(t, "cancel_win", cancelType)
+}
+
+func TestBS_Rollback_WholeRound(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	for _, step := range []struct {
+		path, txID string
+		amount     int64
+	}{
+		{"/betsolutions/bet", "tx-bet-r1", 2000},
+		{"/betsolutions/bet", "tx-bet-r2", 1000},
+		{"/betsolutions/win", "tx-win-r1", 5000},
+	} {
+		resp := env.BSPost(step.path, provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: "game-1", RoundID: "round-r",
+			TransactionID: step.txID, Amount: step.amount, Currency: "EUR",
+		})
+		resp.Body.Close()
+	}
+	bal, _ := env.GetBalance(playerID)
+	require.Equal(t, int64(12000), bal)
+
+	// A rollback carrying only the round cancels every transaction in it;
+	// replaying it changes nothing.
+	for range 2 {
+		resp := env.BSPost("/betsolutions/rollback", provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: "game-1", RoundID: "round-r",
+			Currency: "EUR",
+		})
+		var result provider.BetSolutionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		resp.Body.Close()
+		assert.Equal(t, 200, result.StatusCode)
+		assert.Equal(t, int64(10000), result.Balance)
+	}
+
+	var cancels int
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT COUNT(*) FROM v2_transactions WHERE player_id = $1 AND type IN ('cancel_bet', 'cancel_win')`,
+		playerID).Scan(&cancels))
+	assert.Equal(t, 3, cancels)
+}
+
 func TestBS_InvalidSignature(t *testing.T) {
 	env := testutil.NewWalletTestEnv(t)
 	playerID := env.CreatePlayer("EUR")
 
 	resp := env.BSPostBadSig("/betsolutions/balance", provider.BetSolutionsRequest{
-		Token:    "test-token",
 		PlayerID: playerID.String(),
 		Currency: "EUR",
 	})
@@ -174,7 +252,6 @@ func TestBS_IdempotentBet(t *testing.T) {
 	env.DirectDeposit(playerID, 10000)
 
 	req := provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-1",
 		RoundID:       "round-1",
@@ -214,7 +291,6 @@ func TestPP_Balance(t *testing.T) {
 		UserID:   playerID.String(),
 		Action:   "balance",
 		Currency: "EUR",
-		Token:    "test-token",
 	})
 	defer resp.Body.Close()
 
@@ -238,7 +314,6 @@ func TestPP_Bet(t *testing.T) {
 		TransactionID: "pp-bet-1",
 		RoundID:       "pp-round-1",
 		GameID:        "pp-game-1",
-		Token:         "test-token",
 	})
 	defer resp.Body.Close()
 
@@ -262,7 +337,6 @@ func TestPP_Result(t *testing.T) {
 		TransactionID: "pp-bet-1",
 		RoundID:       "pp-round-1",
 		GameID:        "pp-game-1",
-		Token:         "test-token",
 	})
 
 	// Win
@@ -274,7 +348,6 @@ func TestPP_Result(t *testing.T) {
 		TransactionID: "pp-win-1",
 		RoundID:       "pp-round-1",
 		GameID:        "pp-game-1",
-		Token:         "test-token",
 	})
 	defer resp.Body.Close()
 
@@ -298,7 +371,6 @@ func TestPP_Refund(t *testing.T) {
 		TransactionID: "pp-bet-1",
 		RoundID:       "pp-round-1",
 		GameID:        "pp-game-1",
-		Token:         "test-token",
 	})
 
 	// Refund
@@ -309,7 +381,6 @@ func TestPP_Refund(t *testing.T) {
 		TransactionID: "pp-bet-1", // same as original
 		RoundID:       "pp-round-1",
 		GameID:        "pp-game-1",
-		Token:         "test-token",
 	})
 	defer resp.Body.Close()
 
@@ -327,7 +398,6 @@ func TestPP_InvalidSignature(t *testing.T) {
 		UserID:   playerID.String(),
 		Action:   "balance",
 		Currency: "EUR",
-		Token:    "test-token",
 	})
 	defer resp.Body.Close()
 
@@ -350,7 +420,6 @@ func TestPP_DecimalAmounts(t *testing.T) {
 		TransactionID: "pp-dec-1",
 		RoundID:       "pp-round-dec",
 		GameID:        "pp-game-1",
-		Token:         "test-token",
 	})
 	defer resp.Body.Close()
 
@@ -370,7 +439,6 @@ func TestWallet_BetWinSequence(t *testing.T) {
 
 	// Check balance via BS
 	resp := env.BSPost("/betsolutions/balance", provider.BetSolutionsRequest{
-		Token:    "test-token",
 		PlayerID: playerID.String(),
 		Currency: "EUR",
 	})
@@ -381,7 +449,6 @@ func TestWallet_BetWinSequence(t *testing.T) {
 
 	// Bet 2000 via BS
 	resp = env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-seq",
 		RoundID:       "round-seq",
@@ -396,7 +463,6 @@ func TestWallet_BetWinSequence(t *testing.T) {
 
 	// Win 5000 via BS
 	resp = env.BSPost("/betsolutions/win", provider.BetSolutionsRequest{
-		Token:         "test-token",
 		PlayerID:      playerID.String(),
 		GameID:        "game-seq",
 		RoundID:       "round-seq",
@@ -413,3 +479,897 @@ func TestWallet_BetWinSequence(t *testing.T) {
 	bal, _ := env.GetBalance(playerID)
 	assert.Equal(t, int64(13000), bal)
 }
+
+func TestBS_BetRoutesToCurrencyWallet(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, err := env.Pool.Exec(t.Context(),
+		`INSERT INTO player_wallets (player_id, currency, balance) VALUES ($1, 'USD', 5000)`, playerID)
+	require.NoError(t, err)
+
+	resp := env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
+		PlayerID:      playerID.String(),
+		GameID:        "game-usd",
+		RoundID:       "round-usd",
+		TransactionID: "tx-usd-bet",
+		Amount:        2000,
+		Currency:      "USD",
+	})
+	defer resp.Body.Close()
+
+	var result provider.BetSolutionsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, int64(3000), result.Balance)
+
+	// The EUR base wallet is untouched
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(10000), bal)
+}
+
+// --- Evolution Tests ---
+
+func evolutionDebit(playerID, refID, amount string) provider.EvolutionRequest {
+	return provider.EvolutionRequest{
+		UserID:      playerID,
+		UUID:        "uuid-" + refID,
+		Currency:    "EUR",
+		Game:        &provider.EvolutionGame{ID: "ev-round-1", Type: "blackjack"},
+		Transaction: &provider.EvolutionTransaction{ID: "d-" + refID, RefID: refID, Amount: json.Number(amount)},
+	}
+}
+
+func TestEV_CheckAndBalance(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+
+	resp := env.EVPost("/evolution/check", provider.EvolutionRequest{
+		SID: "sid-1", UserID: playerID.String(), UUID: "u-check",
+	})
+	defer resp.Body.Close()
+
+	var result provider.EvolutionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, provider.EvolutionStatusOK, result.Status)
+	assert.Equal(t, "sid-1", result.SID)
+	assert.Equal(t, "u-check", result.UUID)
+	require.NotNil(t, result.Balance)
+	assert.Equal(t, "50.00", result.Balance.String())
+}
+
+func TestEV_DebitCreditCancel(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	resp := env.EVPost("/evolution/debit", evolutionDebit(playerID.String(), "ref-1", "25.50"))
+	var debit provider.EvolutionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&debit))
+	resp.Body.Close()
+	assert.Equal(t, provider.EvolutionStatusOK, debit.Status)
+	assert.Equal(t, "74.50", debit.Balance.String())
+
+	// A second bet on the same table is voided by its refId
+	resp = env.EVPost("/evolution/debit", evolutionDebit(playerID.String(), "ref-2", "10"))
+	resp.Body.Close()
+	resp = env.EVPost("/evolution/cancel", provider.EvolutionRequest{
+		UserID: playerID.String(), Currency: "EUR",
+		Transaction: &provider.EvolutionTransaction{ID: "x-ref-2", RefID: "ref-2", Amount: "10"},
+	})
+	var cancel provider.EvolutionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&cancel))
+	resp.Body.Close()
+	assert.Equal(t, provider.EvolutionStatusOK, cancel.Status)
+	assert.Equal(t, "74.50", cancel.Balance.String())
+
+	resp = env.EVPost("/evolution/credit", provider.EvolutionRequest{
+		UserID: playerID.String(), Currency: "EUR",
+		Game:        &provider.EvolutionGame{ID: "ev-round-1"},
+		Transaction: &provider.EvolutionTransaction{ID: "c-1", RefID: "ref-1", Amount: "51"},
+	})
+	var credit provider.EvolutionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&credit))
+	resp.Body.Close()
+	assert.Equal(t, "125.50", credit.Balance.String())
+
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(12550), bal)
+}
+
+func TestEV_InsufficientFundsAndBadToken(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 1000)
+
+	resp := env.EVPost("/evolution/debit", evolutionDebit(playerID.String(), "ref-big", "50"))
+	var result provider.EvolutionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, provider.EvolutionStatusInsufficientFunds, result.Status)
+	assert.Nil(t, result.Balance)
+
+	env.EVSecret = "wrong-secret"
+	resp = env.EVPost("/evolution/balance", provider.EvolutionRequest{UserID: playerID.String()})
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, provider.EvolutionStatusInvalidToken, result.Status)
+
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(1000), bal)
+}
+
+// --- Relax Gaming Tests ---
+
+func relaxWithdraw(playerID string, txID, amount int64) provider.RelaxRequest {
+	return provider.RelaxRequest{
+		CustomerID: playerID,
+		Currency:   "EUR",
+		GameRef:    "rlx.money.train",
+		RoundID:    9001,
+		TxID:       txID,
+		Amount:     amount,
+		TxType:     provider.RelaxTxWithdraw,
+	}
+}
+
+func TestRL_WithdrawDepositRollback(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	resp := env.RLPost("/relax/getBalance", provider.RelaxRequest{CustomerID: playerID.String(), Currency: "EUR"})
+	var result provider.RelaxResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(10000), result.Balance)
+
+	resp = env.RLPost("/relax/withdraw", relaxWithdraw(playerID.String(), 5001, 2500))
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, int64(7500), result.Balance)
+	assert.Equal(t, int64(5001), result.TxID)
+
+	resp = env.RLPost("/relax/deposit", provider.RelaxRequest{
+		CustomerID: playerID.String(), Currency: "EUR", RoundID: 9001,
+		TxID: 5002, Amount: 4000, TxType: provider.RelaxTxDeposit, Ended: true,
+	})
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, int64(11500), result.Balance)
+
+	// A second withdraw is voided by its originaltxid
+	resp = env.RLPost("/relax/withdraw", relaxWithdraw(playerID.String(), 5003, 1000))
+	resp.Body.Close()
+	resp = env.RLPost("/relax/rollback", provider.RelaxRequest{
+		CustomerID: playerID.String(), Currency: "EUR", TxID: 5004, OriginalTxID: 5003, Amount: 1000,
+	})
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, int64(11500), result.Balance)
+
+	bal, bonus := env.GetBalance(playerID)
+	assert.Equal(t, int64(11500), bal)
+	assert.Equal(t, int64(0), bonus)
+}
+
+func TestRL_FreespinsPayoutCreditsBonus(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 2000)
+
+	payout := provider.RelaxRequest{
+		CustomerID: playerID.String(), Currency: "EUR", GameRef: "rlx.money.train",
+		TxID: 6001, Amount: 1800, TxType: provider.RelaxTxFreespinsPayout,
+		FreespinsID: "fs-welcome-1", PromoCode: "WELCOME",
+	}
+	resp := env.RLPost("/relax/deposit", payout)
+	var result provider.RelaxResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(3800), result.Balance)
+
+	// Replays are idempotent
+	resp = env.RLPost("/relax/deposit", payout)
+	resp.Body.Close()
+
+	bal, bonus := env.GetBalance(playerID)
+	assert.Equal(t, int64(2000), bal)
+	assert.Equal(t, int64(1800), bonus)
+}
+
+func TestRL_InsufficientFundsAndBadAuth(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 1000)
+
+	resp := env.RLPost("/relax/withdraw", relaxWithdraw(playerID.String(), 7001, 5000))
+	var errResp provider.RelaxErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, provider.RelaxErrInsufficientFunds, errResp.ErrorCode)
+
+	env.RLSecret = "wrong:creds"
+	resp = env.RLPost("/relax/getBalance", provider.RelaxRequest{CustomerID: playerID.String()})
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, provider.RelaxErrInvalidToken, errResp.ErrorCode)
+
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(1000), bal)
+}
+
+// --- NetEnt Tests ---
+
+func netEntPost(t *testing.T, env *testutil.WalletTestEnv, path string, req provider.NetEntRequest) provider.NetEntResponse {
+	t.Helper()
+	resp := env.NEPost(path, req)
+	defer resp.Body.Close()
+	var result provider.NetEntResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return result
+}
+
+func TestNE_ReserveRelease(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+	session := env.NESession(playerID, time.Hour)
+
+	result := netEntPost(t, env, "/netent/reserve", provider.NetEntRequest{
+		SessionID: session, Currency: "EUR", GameRoundRef: "ne-round-1", TransactionRef: "res-1", Amount: "20",
+	})
+	assert.Empty(t, result.ErrorCode)
+	assert.Equal(t, "80.00", result.Balance.String())
+	assert.Equal(t, int64(2000), env.GetReservedBalance(playerID))
+
+	release := provider.NetEntRequest{
+		SessionID: session, Currency: "EUR", GameRoundRef: "ne-round-1",
+		TransactionRef: "rel-1", ReservationRef: "res-1", Amount: "55",
+	}
+	result = netEntPost(t, env, "/netent/release", release)
+	assert.Empty(t, result.ErrorCode)
+	assert.Equal(t, "135.00", result.Balance.String())
+
+	// Replays settle nothing twice
+	result = netEntPost(t, env, "/netent/release", release)
+	assert.Empty(t, result.ErrorCode)
+
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(13500), bal)
+	assert.Equal(t, int64(0), env.GetReservedBalance(playerID))
+
+	// A released reservation cannot be rolled back
+	result = netEntPost(t, env, "/netent/rollback", provider.NetEntRequest{
+		SessionID: session, TransactionRef: "rb-1", ReservationRef: "res-1",
+	})
+	assert.Equal(t, provider.NetEntErrInvalidRequest, result.ErrorCode)
+}
+
+func TestNE_RollbackReservation(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+	session := env.NESession(playerID, time.Hour)
+
+	netEntPost(t, env, "/netent/reserve", provider.NetEntRequest{
+		SessionID: session, Currency: "EUR", TransactionRef: "res-2", Amount: "30",
+	})
+	result := netEntPost(t, env, "/netent/rollback", provider.NetEntRequest{
+		SessionID: session, Currency: "EUR", TransactionRef: "rb-2", ReservationRef: "res-2",
+	})
+	assert.Empty(t, result.ErrorCode)
+	assert.Equal(t, "50.00", result.Balance.String())
+	assert.Equal(t, int64(0), env.GetReservedBalance(playerID))
+
+	// Releasing a rolled-back reservation is refused
+	result = netEntPost(t, env, "/netent/release", provider.NetEntRequest{
+		SessionID: session, Currency: "EUR", TransactionRef: "rel-2", ReservationRef: "res-2", Amount: "10",
+	})
+	assert.Equal(t, provider.NetEntErrInvalidRequest, result.ErrorCode)
+
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(5000), bal)
+}
+
+func TestNE_SessionValidation(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+
+	result := netEntPost(t, env, "/netent/balance", provider.NetEntRequest{SessionID: "forged.token"})
+	assert.Equal(t, provider.NetEntErrInvalidSession, result.ErrorCode)
+
+	// An expired session cannot stake but can still settle
+	live := env.NESession(playerID, time.Hour)
+	netEntPost(t, env, "/netent/reserve", provider.NetEntRequest{
+		SessionID: live, Currency: "EUR", TransactionRef: "res-3", Amount: "10",
+	})
+	expired := env.NESession(playerID, -time.Minute)
+	result = netEntPost(t, env, "/netent/reserve", provider.NetEntRequest{
+		SessionID: expired, Currency: "EUR", TransactionRef: "res-4", Amount: "10",
+	})
+	assert.Equal(t, provider.NetEntErrInvalidSession, result.ErrorCode)
+	result = netEntPost(t, env, "/netent/release", provider.NetEntRequest{
+		SessionID: expired, Currency: "EUR", TransactionRef: "rel-3", ReservationRef: "res-3",
+	})
+	assert.Empty(t, result.ErrorCode)
+	assert.Equal(t, "40.00", result.Balance.String())
+}
+
+// --- Callback Journal Tests ---
+
+func TestJournal_RecordsCallbacksWithOutcome(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+	session := env.NESession(playerID, time.Hour)
+
+	netEntPost(t, env, "/netent/reserve", provider.NetEntRequest{
+		SessionID: session, Currency: "EUR", TransactionRef: "jr-1", Amount: "20",
+	})
+	resp := env.BSPostBadSig("/betsolutions/balance", provider.BetSolutionsRequest{
+		PlayerID: playerID.String(), Currency: "EUR",
+	})
+	resp.Body.Close()
+
+	type row struct {
+		Provider       string
+		Action         *string
+		PlayerID       *uuid.UUID
+		TransactionID  *string
+		Amount         *int64
+		Body           string
+		SignatureValid *bool
+		Status         string
+		Balance        *int64
+	}
+	rows, err := env.Pool.Query(t.Context(), `
+		SELECT provider, action, player_id, transaction_id, amount::bigint, body, signature_valid,
+		       status, balance::bigint
+		FROM provider_callbacks ORDER BY received_at`)
+	require.NoError(t, err)
+	var journal []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(&r.Provider, &r.Action, &r.PlayerID, &r.TransactionID, &r.Amount,
+			&r.Body, &r.SignatureValid, &r.Status, &r.Balance))
+		journal = append(journal, r)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, journal, 2)
+
+	reserve := journal[0]
+	assert.Equal(t, "netent", reserve.Provider)
+	assert.Equal(t, "reserve", *reserve.Action)
+	assert.Equal(t, playerID, *reserve.PlayerID)
+	assert.Equal(t, "jr-1", *reserve.TransactionID)
+	assert.Equal(t, int64(2000), *reserve.Amount)
+	assert.True(t, *reserve.SignatureValid)
+	assert.Equal(t, "ok", reserve.Status)
+	assert.Equal(t, int64(3000), *reserve.Balance)
+	assert.Contains(t, reserve.Body, `"callerPassword":"[redacted]"`)
+	assert.NotContains(t, reserve.Body, env.NESecret)
+
+	rejected := journal[1]
+	assert.Equal(t, "betsolutions", rejected.Provider)
+	assert.Nil(t, rejected.Action)
+	assert.False(t, *rejected.SignatureValid)
+	assert.Equal(t, "unauthorized", rejected.Status)
+	assert.Nil(t, rejected.Balance)
+	assert.Contains(t, rejected.Body, playerID.String())
+}
+
+func TestProvidersHealth_ListsMountedProviders(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+
+	netEntPost(t, env, "/netent/balance", provider.NetEntRequest{SessionID: env.NESession(playerID, time.Hour)})
+
+	resp, err := http.Get(env.Server.URL + "/providers/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report struct {
+		Status    string `json:"status"`
+		Providers []struct {
+			Name        string  `json:"name"`
+			Circuit     string  `json:"circuit"`
+			ErrorRate5m float64 `json:"error_rate_5m"`
+		} `json:"providers"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(t, "ok", report.Status)
+	require.Len(t, report.Providers, 5)
+	for _, p := range report.Providers {
+		assert.Equal(t, "closed", p.Circuit, p.Name)
+		assert.Zero(t, p.ErrorRate5m, p.Name)
+	}
+}
+
+// ─── Round Lifecycle Tests ──────────────────────────────────────────────────
+
+func TestRound_OpensOnBetAndClosesOnResult(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	roundStatus := func(roundID string) (status string, stake, win int64) {
+		t.Helper()
+		require.NoError(t, env.Pool.QueryRow(t.Context(), `
+			SELECT status, stake_total, win_total FROM game_rounds WHERE player_id = $1 AND round_id = $2`,
+			playerID, roundID).Scan(&status, &stake, &win))
+		return status, stake, win
+	}
+	post := func(path, roundID, txID string, amount int64) provider.BetSolutionsResponse {
+		t.Helper()
+		resp := env.BSPost(path, provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: "game-1", RoundID: roundID,
+			TransactionID: txID, Amount: amount, Currency: "EUR",
+		})
+		defer resp.Body.Close()
+		var result provider.BetSolutionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	post("/betsolutions/bet", "round-w", "tx-lc-bet1", 1000)
+	status, stake, _ := roundStatus("round-w")
+	assert.Equal(t, "open", status)
+	assert.Equal(t, int64(1000), stake)
+
+	post("/betsolutions/win", "round-w", "tx-lc-win1", 2500)
+	status, _, win := roundStatus("round-w")
+	assert.Equal(t, "closed", status)
+	assert.Equal(t, int64(2500), win)
+
+	// A zero win is a loss: the round closes and no transaction posts.
+	post("/betsolutions/bet", "round-l", "tx-lc-bet2", 500)
+	result := post("/betsolutions/win", "round-l", "tx-lc-win2", 0)
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, int64(10000-1000+2500-500), result.Balance)
+	status, _, _ = roundStatus("round-l")
+	assert.Equal(t, "closed", status)
+}
+
+func TestRound_SweeperVoidsStuckRoundAndRefunds(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	for _, step := range []struct {
+		path, roundID, txID string
+		amount              int64
+	}{
+		{"/betsolutions/bet", "round-stuck", "tx-sw-bet1", 1000},
+		{"/betsolutions/win", "round-stuck", "tx-sw-win1", 1500},
+		{"/betsolutions/bet", "round-stuck", "tx-sw-bet2", 2000}, // reopens; never settled
+		{"/betsolutions/bet", "round-live", "tx-sw-bet3", 700},
+	} {
+		resp := env.BSPost(step.path, provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: "game-1", RoundID: step.roundID,
+			TransactionID: step.txID, Amount: step.amount, Currency: "EUR",
+		})
+		resp.Body.Close()
+	}
+	bal, _ := env.GetBalance(playerID)
+	require.Equal(t, int64(10000-1000+1500-2000-700), bal)
+
+	// Only the stuck round has been idle past the TTL.
+	_, err := env.Pool.Exec(t.Context(),
+		`UPDATE game_rounds SET last_activity_at = now() - interval '2 hours' WHERE round_id = 'round-stuck'`)
+	require.NoError(t, err)
+
+	n, err := env.Sweeper.Sweep(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = env.Sweeper.Sweep(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// The stake after the win is refunded once; the settled stake is not.
+	bal, _ = env.GetBalance(playerID)
+	assert.Equal(t, int64(10000-1000+1500-700), bal)
+
+	var status string
+	var refund int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status, refund_total FROM game_rounds WHERE round_id = 'round-stuck'`).Scan(&status, &refund))
+	assert.Equal(t, "voided", status)
+	assert.Equal(t, int64(2000), refund)
+
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status FROM game_rounds WHERE round_id = 'round-live'`).Scan(&status))
+	assert.Equal(t, "open", status)
+
+	var cancels int
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT COUNT(*) FROM v2_transactions
+		WHERE player_id = $1 AND type = 'cancel_bet' AND external_transaction_id = 'void_tx-sw-bet2'`,
+		playerID).Scan(&cancels))
+	assert.Equal(t, 1, cancels)
+}
+
+// ─── Bonus Wagering Tests ───────────────────────────────────────────────────
+
+// seedPlayerBonus grants bonus money under a wagering requirement.
+func seedPlayerBonus(t *testing.T, env *testutil.WalletTestEnv, playerID uuid.UUID, amount, requirement int64, expiresAt time.Time) uuid.UUID {
+	t.Helper()
+	_, err := env.Pool.Exec(t.Context(),
+		`UPDATE v2_players SET bonus_balance = bonus_balance + $2 WHERE id = $1`, playerID, amount)
+	require.NoError(t, err)
+	var id uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		INSERT INTO player_bonuses (player_id, initial_amount, wagering_requirement, expires_at)
+		VALUES ($1, $2, $3, $4) RETURNING id`, playerID, amount, requirement, expiresAt).Scan(&id))
+	return id
+}
+
+func TestBonusWagering_WeightedBetsCompleteAndConvert(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+	bonusID := seedPlayerBonus(t, env, playerID, 1000, 2000, time.Now().Add(24*time.Hour))
+
+	_, err := env.Pool.Exec(t.Context(), `INSERT INTO game_manufacturers (id, name) VALUES ('BS', 'betsolutions')`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO games (manufacturer_id, external_game_id, name, category)
+		VALUES ('BS', 'blackjack-1', 'Blackjack', 'table')`)
+	require.NoError(t, err)
+
+	bet := func(gameID, txID string, amount int64) {
+		t.Helper()
+		resp := env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: gameID, RoundID: "round-" + txID,
+			TransactionID: txID, Amount: amount, Currency: "EUR",
+		})
+		defer resp.Body.Close()
+		var result provider.BetSolutionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		require.Equal(t, 200, result.StatusCode)
+	}
+	progress := func() (status string, wagered int64) {
+		t.Helper()
+		require.NoError(t, env.Pool.QueryRow(t.Context(),
+			`SELECT status, wagered::bigint FROM player_bonuses WHERE id = $1`, bonusID).Scan(&status, &wagered))
+		return status, wagered
+	}
+
+	bet("game-1", "tx-wg-1", 1500)      // uncatalogued: counts in full
+	bet("blackjack-1", "tx-wg-2", 1000) // table game: counts 10%
+	bet("blackjack-1", "tx-wg-2", 1000) // replay: not counted again
+	status, wagered := progress()
+	assert.Equal(t, "active", status)
+	assert.Equal(t, int64(1600), wagered)
+
+	bet("game-1", "tx-wg-3", 500)
+	status, wagered = progress()
+	assert.Equal(t, "completed", status)
+	assert.Equal(t, int64(2100), wagered)
+
+	// The bonus money is now real money.
+	bal, bonus := env.GetBalance(playerID)
+	assert.Equal(t, int64(5000-1500-1000-500+1000), bal)
+	assert.Zero(t, bonus)
+}
+
+func TestBonusWagering_ExpiredBonusForfeitedOnBet(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 5000)
+	bonusID := seedPlayerBonus(t, env, playerID, 1000, 2000, time.Now().Add(-time.Minute))
+
+	resp := env.BSPost("/betsolutions/bet", provider.BetSolutionsRequest{
+		PlayerID: playerID.String(), GameID: "game-1", RoundID: "round-exp",
+		TransactionID: "tx-exp-1", Amount: 500, Currency: "EUR",
+	})
+	resp.Body.Close()
+
+	var status string
+	var wagered int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT status, wagered::bigint FROM player_bonuses WHERE id = $1`, bonusID).Scan(&status, &wagered))
+	assert.Equal(t, "expired", status)
+	assert.Zero(t, wagered)
+
+	bal, bonus := env.GetBalance(playerID)
+	assert.Equal(t, int64(4500), bal)
+	assert.Zero(t, bonus)
+}
+
+func TestBonusExpiry_WorkerForfeitsAndNotifiesCRM(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+
+	// A 30-day bonus granted 31 days ago, with no explicit expiry of its own.
+	var bonusDefID uuid.UUID
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		INSERT INTO bonuses (name, code, wagering_multiplier, days_until_expiry)
+		VALUES ('Welcome', 'WELCOME30', 20, 30) RETURNING id`).Scan(&bonusDefID))
+	expiredID := seedPlayerBonus(t, env, playerID, 800, 16_000, time.Now().Add(time.Hour))
+	_, err := env.Pool.Exec(t.Context(), `
+		UPDATE player_bonuses SET bonus_id = $2, expires_at = NULL, created_at = now() - interval '31 days'
+		WHERE id = $1`, expiredID, bonusDefID)
+	require.NoError(t, err)
+	liveID := seedPlayerBonus(t, env, playerID, 200, 4_000, time.Now().Add(time.Hour))
+
+	n, err := env.BonusExpiry.ExpireDue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = env.BonusExpiry.ExpireDue(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	statusOf := func(id uuid.UUID) string {
+		var status string
+		require.NoError(t, env.Pool.QueryRow(t.Context(),
+			`SELECT status FROM player_bonuses WHERE id = $1`, id).Scan(&status))
+		return status
+	}
+	assert.Equal(t, "expired", statusOf(expiredID))
+	assert.Equal(t, "active", statusOf(liveID))
+
+	// Only the expired bonus's money is forfeited.
+	_, bonus := env.GetBalance(playerID)
+	assert.Equal(t, int64(200), bonus)
+
+	var forfeits, events int
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT count(*) FROM v2_transactions WHERE player_id = $1 AND type = 'bonus_forfeit' AND amount = 800`,
+		playerID).Scan(&forfeits))
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT count(*) FROM event_outbox WHERE "eventType" = 'pam.bonus.expired' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&events))
+	assert.Equal(t, 1, forfeits)
+	assert.Equal(t, 1, events)
+}
+
+func TestBonusExpiry_RemindsOnceBeforeExpiry(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+
+	soonID := seedPlayerBonus(t, env, playerID, 500, 5_000, time.Now().Add(24*time.Hour))
+	seedPlayerBonus(t, env, playerID, 300, 3_000, time.Now().Add(testutil.TestBonusReminder+24*time.Hour))
+
+	n, err := env.BonusExpiry.SendReminders(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = env.BonusExpiry.SendReminders(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	var notifications, events int
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT count(*) FROM player_notifications WHERE player_id = $1 AND type = 'bonus_expiry'`,
+		playerID).Scan(&notifications))
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT count(*) FROM event_outbox
+		WHERE "eventType" = 'pam.bonus.expiring' AND "aggregateId" = $1 AND payload->>'player_bonus_id' = $2`,
+		playerID.String(), soonID.String()).Scan(&events))
+	assert.Equal(t, 1, notifications)
+	assert.Equal(t, 1, events)
+
+	// Expiring the reminded bonus counts its forfeited value.
+	_, err = env.Pool.Exec(t.Context(),
+		`UPDATE player_bonuses SET expires_at = now() - interval '1 minute' WHERE id = $1`, soonID)
+	require.NoError(t, err)
+	n, err = env.BonusExpiry.ExpireDue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	var out strings.Builder
+	for _, m := range env.BonusExpiry.Metrics() {
+		_, err := m.WriteTo(&out)
+		require.NoError(t, err)
+	}
+	assert.Contains(t, out.String(), "bonus_expired_total 1\n")
+	assert.Contains(t, out.String(), "bonus_expired_forfeited_minor_total 500\n")
+	assert.Contains(t, out.String(), "bonus_expiry_reminders_total 1\n")
+}
+
+// ─── Casino RTP Tests ───────────────────────────────────────────────────────
+
+func TestCasinoRTP_AggregatesPerGameAndAlertsOutsideBand(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10_000)
+
+	_, err := env.Pool.Exec(t.Context(), `INSERT INTO game_manufacturers (id, name) VALUES ('BS', 'betsolutions')`)
+	require.NoError(t, err)
+	_, err = env.Pool.Exec(t.Context(), `
+		INSERT INTO games (manufacturer_id, external_game_id, name, category, rtp)
+		VALUES ('BS', 'slot-1', 'Lucky Slot', 'slots', 96.00)`)
+	require.NoError(t, err)
+
+	post := func(path, gameID, roundID, txID string, amount int64) {
+		t.Helper()
+		resp := env.BSPost(path, provider.BetSolutionsRequest{
+			PlayerID: playerID.String(), GameID: gameID, RoundID: roundID,
+			TransactionID: txID, Amount: amount, Currency: "EUR",
+		})
+		defer resp.Body.Close()
+		var result provider.BetSolutionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		require.Equal(t, 200, result.StatusCode)
+	}
+	// Four rounds of 100 on the slot, one paying 1,000: 250% RTP, above the
+	// 96% ± 150 band for four rounds. A round on an uncatalogued game is
+	// reported without a band.
+	for _, round := range []string{"rtp-round-1", "rtp-round-2", "rtp-round-3", "rtp-round-4"} {
+		post("/betsolutions/bet", "slot-1", round, round+"-bet", 100)
+	}
+	post("/betsolutions/win", "slot-1", "rtp-round-1", "rtp-round-1-win", 1000)
+	post("/betsolutions/bet", "game-x", "rtp-round-x", "rtp-round-x-bet", 50)
+
+	summary, err := env.CasinoReport.Aggregate(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Games)
+	assert.Equal(t, 1, summary.Alerts)
+
+	// Re-running the day refreshes the figures without alerting again.
+	summary, err = env.CasinoReport.Aggregate(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, summary.Alerts)
+
+	report, err := env.CasinoReport.Report(t.Context(), time.Now(), time.Now(), "")
+	require.NoError(t, err)
+	require.Len(t, report.Games, 2)
+	assert.Equal(t, int64(450), report.StakeTotal)
+	assert.Equal(t, int64(1000), report.WinTotal)
+	assert.Equal(t, 1, report.OpenAlerts)
+
+	slot := report.Games[0]
+	assert.Equal(t, "slot-1", slot.GameID)
+	require.NotNil(t, slot.GameName)
+	assert.Equal(t, "Lucky Slot", *slot.GameName)
+	assert.Equal(t, 250.0, slot.ActualRTP)
+	assert.Equal(t, int64(-600), slot.GGR)
+	assert.Equal(t, 1, slot.DeviatingDays)
+	require.Len(t, slot.Days, 1)
+	day := slot.Days[0]
+	assert.Equal(t, 4, day.Rounds)
+	assert.Equal(t, 1, day.Players)
+	assert.Equal(t, int64(100), day.AvgStake)
+	require.NotNil(t, day.UpperBound)
+	assert.Equal(t, 246.0, *day.UpperBound)
+	assert.True(t, day.Deviates)
+
+	other := report.Games[1]
+	assert.Equal(t, "game-x", other.GameID)
+	assert.Nil(t, other.TheoreticalRTP)
+	assert.Nil(t, other.Days[0].UpperBound)
+	assert.False(t, other.Days[0].Deviates)
+
+	alerts, err := env.CasinoReport.ListAlerts(t.Context(), "open", 0)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "slot-1", alerts[0].GameID)
+	assert.Equal(t, 250.0, alerts[0].ActualRTP)
+
+	var events int
+	require.NoError(t, env.Pool.QueryRow(t.Context(), `
+		SELECT count(*) FROM event_outbox WHERE "eventType" = 'pam.casino.rtp_alert.raised'`).Scan(&events))
+	assert.Equal(t, 1, events)
+
+	acked, err := env.CasinoReport.AcknowledgeAlert(t.Context(), alerts[0].ID, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, "acknowledged", acked.Status)
+	_, err = env.CasinoReport.AcknowledgeAlert(t.Context(), alerts[0].ID, uuid.New())
+	assert.Error(t, err)
+}
+
+// ─── Game Session Token Tests ───────────────────────────────────────────────
+
+func TestSessionToken_ResolvesPlayerAndRejectsMismatches(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10_000)
+
+	_, err := env.Pool.Exec(t.Context(), `INSERT INTO game_manufacturers (id, name) VALUES ('BS', 'betsolutions')`)
+	require.NoError(t, err)
+	sessions := service.NewGameSessionService(env.Pool, time.Hour)
+	token, _, err := sessions.Issue(t.Context(), env.Pool, playerID, "BS", nil, "EUR")
+	require.NoError(t, err)
+
+	bsPost := func(path string, req provider.BetSolutionsRequest) provider.BetSolutionsResponse {
+		t.Helper()
+		resp := env.BSPost(path, req)
+		defer resp.Body.Close()
+		var result provider.BetSolutionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	// The token alone identifies the player and wallet.
+	result := bsPost("/betsolutions/bet", provider.BetSolutionsRequest{
+		Token: token, GameID: "game-1", RoundID: "round-s", TransactionID: "tx-s-bet", Amount: 1000,
+	})
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, int64(9000), result.Balance)
+
+	for _, req := range []provider.BetSolutionsRequest{
+		{Token: "unknown-token"},
+		{Token: token, PlayerID: uuid.New().String()},
+		{Token: token, Currency: "USD"},
+	} {
+		assert.Equal(t, 401, bsPost("/betsolutions/balance", req).StatusCode)
+	}
+
+	// Another provider cannot use the token.
+	resp := env.PPPost(provider.PragmaticRequest{Token: token, Action: "balance"})
+	defer resp.Body.Close()
+	var pp provider.PragmaticResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pp))
+	assert.NotEqual(t, 0, pp.Error)
+
+	// Once expired, the session takes no new stakes but still settles.
+	_, err = env.Pool.Exec(t.Context(), `UPDATE game_session_tokens SET expires_at = now() - interval '1 minute'`)
+	require.NoError(t, err)
+	result = bsPost("/betsolutions/bet", provider.BetSolutionsRequest{
+		Token: token, GameID: "game-1", RoundID: "round-s2", TransactionID: "tx-s2-bet", Amount: 1000,
+	})
+	assert.Equal(t, 401, result.StatusCode)
+	result = bsPost("/betsolutions/win", provider.BetSolutionsRequest{
+		Token: token, GameID: "game-1", RoundID: "round-s", TransactionID: "tx-s-win", Amount: 2500,
+	})
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, int64(11_500), result.Balance)
+}
+
+// ─── Provider Registry Tests ────────────────────────────────────────────────
+
+func TestProviderRegistry_DisabledAndRestrictedProviders(t *testing.T) {
+	env := testutil.NewWalletTestEnv(t)
+	playerID := env.CreatePlayer("EUR")
+	env.DirectDeposit(playerID, 10_000)
+
+	bsPost := func(path string, req provider.BetSolutionsRequest) provider.BetSolutionsResponse {
+		t.Helper()
+		resp := env.BSPost(path, req)
+		defer resp.Body.Close()
+		var result provider.BetSolutionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+	bet := provider.BetSolutionsRequest{
+		PlayerID: playerID.String(), GameID: "game-1", RoundID: "round-r", TransactionID: "tx-r-bet", Amount: 1000, Currency: "EUR",
+	}
+
+	_, err := env.Pool.Exec(t.Context(), `
+		INSERT INTO manufacturers (id, kind, display_name, prefix, money_format, enabled, disabled_reason)
+		VALUES ('betsolutions', 'betsolutions', 'BetSolutions', '/betsolutions', 'cents', false, 'double-crediting wins')`)
+	require.NoError(t, err)
+	require.NoError(t, env.Providers.Reload(t.Context()))
+
+	// A disabled provider is refused before the ledger is touched.
+	assert.Equal(t, 500, bsPost("/betsolutions/bet", bet).StatusCode)
+	bal, _ := env.GetBalance(playerID)
+	assert.Equal(t, int64(10_000), bal)
+
+	// Providers outside the registry are unaffected.
+	resp := env.PPPost(provider.PragmaticRequest{UserID: playerID.String(), Action: "balance"})
+	defer resp.Body.Close()
+	var pp provider.PragmaticResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pp))
+	assert.Equal(t, 0, pp.Error)
+
+	// Re-enabled but limited to settling: new stakes are forbidden.
+	_, err = env.Pool.Exec(t.Context(), `
+		UPDATE manufacturers SET enabled = true, disabled_reason = NULL,
+		       allowed_actions = '{balance,win,rollback}' WHERE id = 'betsolutions'`)
+	require.NoError(t, err)
+	require.NoError(t, env.Providers.Reload(t.Context()))
+
+	assert.Equal(t, 403, bsPost("/betsolutions/bet", bet).StatusCode)
+	result := bsPost("/betsolutions/balance", provider.BetSolutionsRequest{PlayerID: playerID.String(), Currency: "EUR"})
+	assert.Equal(t, 200, result.StatusCode)
+	assert.Equal(t, int64(10_000), result.Balance)
+}
@@ -3,11 +3,17 @@
 package integration
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/attaboy/platform/internal/domain"
 	"github.com/attaboy/platform/test/integration/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -562,3 +568,897 @@ func TestPaymentHistory_WithdrawValidation(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
+
+// ─── Multi-Currency Wallet Tests (3) ───────────────────────────────────────
+
+func TestWallets_OpenAndList(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("wallets@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/wallet/wallets", map[string]string{"currency": "usd"}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Duplicate and base-currency wallets are rejected
+	resp = env.AuthPOST("/wallet/wallets", map[string]string{"currency": "USD"}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = env.AuthPOST("/wallet/wallets", map[string]string{"currency": "EUR"}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = env.AuthPOST("/wallet/wallets", map[string]string{"currency": "JPY"}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = env.AuthGET("/wallet/wallets", token)
+	defer resp.Body.Close()
+	var wallets []struct {
+		Currency string `json:"currency"`
+		Balance  int64  `json:"balance"`
+		Base     bool   `json:"base"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&wallets))
+	require.Len(t, wallets, 2)
+	assert.Equal(t, "EUR", wallets[0].Currency)
+	assert.True(t, wallets[0].Base)
+	assert.Equal(t, "USD", wallets[1].Currency)
+	assert.False(t, wallets[1].Base)
+}
+
+func TestWallets_ConvertUsesAdminRate(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("convert@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	resp := env.AuthPOST("/wallet/wallets", map[string]string{"currency": "USD"}, token)
+	resp.Body.Close()
+
+	convert := map[string]interface{}{"from": "EUR", "to": "USD", "amount": 4000, "idempotency_key": "fx-1"}
+
+	// No rate configured yet
+	resp = env.AuthPOST("/wallet/convert", convert, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = env.AuthPUT("/admin/fx-rates/EUR/USD", map[string]string{"rate": "1.0850"}, env.AdminToken("admin"))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthPOST("/wallet/convert", convert, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var result struct {
+		Debit struct {
+			Amount   int64  `json:"amount"`
+			Currency string `json:"currency"`
+		} `json:"debit"`
+		Credit struct {
+			Amount   int64  `json:"amount"`
+			Currency string `json:"currency"`
+		} `json:"credit"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	resp.Body.Close()
+	assert.Equal(t, int64(4000), result.Debit.Amount)
+	assert.Equal(t, "EUR", result.Debit.Currency)
+	assert.Equal(t, int64(4340), result.Credit.Amount)
+	assert.Equal(t, "USD", result.Credit.Currency)
+
+	// Replaying the same key does not move funds again
+	resp = env.AuthPOST("/wallet/convert", convert, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	testutil.AssertBalance(t, env, playerID, 6000, 0, 0)
+	var usd int64
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT balance::bigint FROM player_wallets WHERE player_id = $1 AND currency = 'USD'`, playerID).Scan(&usd))
+	assert.Equal(t, int64(4340), usd)
+}
+
+func TestWallets_ConvertInsufficientBalance(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("convertlow@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 1000)
+
+	resp := env.AuthPOST("/wallet/wallets", map[string]string{"currency": "GBP"}, token)
+	resp.Body.Close()
+	resp = env.AuthPUT("/admin/fx-rates/EUR/GBP", map[string]string{"rate": "0.86"}, env.AdminToken("admin"))
+	resp.Body.Close()
+
+	resp = env.AuthPOST("/wallet/convert", map[string]interface{}{"from": "EUR", "to": "GBP", "amount": 5000}, token)
+	defer resp.Body.Close()
+	testutil.AssertErrorCode(t, resp, "INSUFFICIENT_BALANCE")
+	testutil.AssertBalance(t, env, playerID, 1000, 0, 0)
+}
+
+// ─── Double-Entry Ledger Tests (1) ─────────────────────────────────────────
+
+func TestLedger_PostingsBalanceAcrossAccounts(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("ledger@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	resp := env.AuthPOST("/wallet/wallets", map[string]string{"currency": "USD"}, token)
+	resp.Body.Close()
+	resp = env.AuthPUT("/admin/fx-rates/EUR/USD", map[string]string{"rate": "1.10"}, env.AdminToken("admin"))
+	resp.Body.Close()
+	resp = env.AuthPOST("/wallet/convert", map[string]interface{}{"from": "EUR", "to": "USD", "amount": 2000}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/ledger/verify", env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var report struct {
+		Balanced     bool     `json:"balanced"`
+		TotalDebits  int64    `json:"total_debits"`
+		TotalCredits int64    `json:"total_credits"`
+		Unbalanced   []string `json:"unbalanced_transactions"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	resp.Body.Close()
+	assert.True(t, report.Balanced)
+	assert.Equal(t, int64(10000+2000+2200), report.TotalCredits)
+	assert.Equal(t, report.TotalCredits, report.TotalDebits)
+	assert.Empty(t, report.Unbalanced)
+
+	resp = env.AuthGET("/admin/ledger/accounts?prefix=house:fx", env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var accounts []struct {
+		Account  string `json:"account"`
+		Currency string `json:"currency"`
+		Net      int64  `json:"net"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&accounts))
+	resp.Body.Close()
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "EUR", accounts[0].Currency)
+	assert.Equal(t, int64(2000), accounts[0].Net)
+	assert.Equal(t, "USD", accounts[1].Currency)
+	assert.Equal(t, int64(-2200), accounts[1].Net)
+}
+
+// ─── Ledger Reconciliation Tests (1) ───────────────────────────────────────
+
+func TestLedgerReconciliation_FlagsAndResolvesDrift(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("drift@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 5000)
+	admin := env.AdminToken("admin")
+
+	type summary struct {
+		Drifted  int `json:"drifted"`
+		Opened   int `json:"opened"`
+		Resolved int `json:"resolved"`
+	}
+	reconcile := func() summary {
+		resp := env.AuthPOST("/admin/ledger/reconcile", nil, admin)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var s summary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&s))
+		return s
+	}
+
+	assert.Equal(t, summary{}, reconcile())
+
+	// Balance changed outside the ledger
+	_, err := env.Pool.Exec(t.Context(), `UPDATE v2_players SET balance = balance + 500 WHERE id = $1`, playerID)
+	require.NoError(t, err)
+
+	assert.Equal(t, summary{Drifted: 1, Opened: 1}, reconcile())
+	// Still drifting, but not re-alerted
+	assert.Equal(t, summary{Drifted: 1}, reconcile())
+
+	var alerts int
+	require.NoError(t, env.Pool.QueryRow(t.Context(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.wallet.ledger.drift_detected' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&alerts))
+	assert.Equal(t, 1, alerts)
+
+	resp := env.AuthGET("/admin/ledger/discrepancies", admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var open []struct {
+		PlayerID string `json:"player_id"`
+		Kind     string `json:"kind"`
+		Stored   struct {
+			Balance int64 `json:"balance"`
+		} `json:"stored"`
+		Expected struct {
+			Balance int64 `json:"balance"`
+		} `json:"expected"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&open))
+	resp.Body.Close()
+	require.Len(t, open, 1)
+	assert.Equal(t, playerID.String(), open[0].PlayerID)
+	assert.Equal(t, "snapshot_mismatch", open[0].Kind)
+	assert.Equal(t, int64(5500), open[0].Stored.Balance)
+	assert.Equal(t, int64(5000), open[0].Expected.Balance)
+
+	_, err = env.Pool.Exec(t.Context(), `UPDATE v2_players SET balance = balance - 500 WHERE id = $1`, playerID)
+	require.NoError(t, err)
+	assert.Equal(t, summary{Resolved: 1}, reconcile())
+
+	resp = env.AuthGET("/admin/ledger/discrepancies", admin)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&open))
+	resp.Body.Close()
+	assert.Empty(t, open)
+}
+
+// ─── Balance As-Of Tests (1) ───────────────────────────────────────────────
+
+func TestBalanceAt_ReconstructsPastBalances(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("asof@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 5000)
+	// The first deposit happened two hours ago.
+	_, err := env.Pool.Exec(t.Context(),
+		`UPDATE v2_transactions SET created_at = now() - interval '2 hours' WHERE player_id = $1`, playerID)
+	require.NoError(t, err)
+	env.DirectDeposit(playerID, 2000)
+	admin := env.AdminToken("viewer")
+
+	type asOf struct {
+		Balances struct {
+			Balance int64 `json:"balance"`
+		} `json:"balances"`
+		Currency string          `json:"currency"`
+		Replayed int             `json:"replayed_transactions"`
+		Drift    json.RawMessage `json:"drift"`
+	}
+	balanceAt := func(ago time.Duration) asOf {
+		at := time.Now().Add(-ago).UTC().Format(time.RFC3339)
+		resp := env.AuthGET("/admin/players/"+playerID.String()+"/balance-at?at="+at, admin)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var b asOf
+		testutil.DecodeJSON(t, resp, &b)
+		return b
+	}
+
+	b := balanceAt(3 * time.Hour)
+	assert.Equal(t, int64(0), b.Balances.Balance)
+	assert.Nil(t, b.Drift)
+
+	b = balanceAt(time.Hour)
+	assert.Equal(t, int64(5000), b.Balances.Balance)
+	assert.Equal(t, "EUR", b.Currency)
+	assert.Equal(t, 1, b.Replayed)
+	assert.Nil(t, b.Drift)
+
+	b = balanceAt(0)
+	assert.Equal(t, int64(7000), b.Balances.Balance)
+	assert.Equal(t, 2, b.Replayed)
+
+	// History older than the ledger is picked up from its balance_after snapshot.
+	_, err = env.Pool.Exec(t.Context(), `
+		DELETE FROM ledger_entries WHERE transaction_id IN (
+			SELECT id FROM v2_transactions WHERE player_id = $1 AND created_at < now() - interval '1 hour')`, playerID)
+	require.NoError(t, err)
+	b = balanceAt(0)
+	assert.Equal(t, int64(7000), b.Balances.Balance)
+	assert.Equal(t, 1, b.Replayed)
+	assert.Nil(t, b.Drift)
+
+	resp := env.AuthGET("/admin/players/"+playerID.String()+"/balance-at?at=yesterday", admin)
+	testutil.AssertErrorCode(t, resp, "VALIDATION_ERROR")
+}
+
+// ─── Source of Funds Tests (2) ─────────────────────────────────────────────
+
+func sofDepositAttempt(env *testutil.TestEnv, token string) *http.Response {
+	return env.AuthPOST("/payments/deposit", map[string]interface{}{
+		"amount": 1000, "currency": "EUR",
+		"success_url": "http://example.com/ok", "cancel_url": "http://example.com/no",
+	}, token)
+}
+
+func sofQuestionnaireID(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	var body struct {
+		Code    string `json:"code"`
+		Details struct {
+			QuestionnaireID string `json:"questionnaire_id"`
+			Threshold       int64  `json:"threshold"`
+		} `json:"details"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "SOURCE_OF_FUNDS_REQUIRED", body.Code)
+	assert.Equal(t, int64(500000), body.Details.Threshold)
+	return body.Details.QuestionnaireID
+}
+
+var sofAnswers = map[string]interface{}{
+	"occupation": "Engineer", "employer": "Acme", "annual_income_band": "50k-100k", "source_of_funds": "salary",
+}
+
+func TestSourceOfFunds_BlocksDepositsUntilSubmitted(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("sof@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 499999)
+
+	resp := sofDepositAttempt(env, token)
+	resp.Body.Close()
+	assert.NotEqual(t, http.StatusForbidden, resp.StatusCode)
+
+	env.DirectDeposit(playerID, 1)
+	id := sofQuestionnaireID(t, sofDepositAttempt(env, token))
+
+	// Attach a document as multipart/form-data
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("file", "payslip.pdf")
+	require.NoError(t, err)
+	io.WriteString(part, "%PDF-1.4\n% test payslip\n")
+	require.NoError(t, mw.Close())
+	resp = env.RawPOST("/players/me/source-of-funds/"+id+"/documents", buf.Bytes(), map[string]string{
+		"Content-Type": mw.FormDataContentType(), "Authorization": "Bearer " + token,
+	})
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = env.AuthPUT("/players/me/source-of-funds/"+id, sofAnswers, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/players/me/source-of-funds", token)
+	var list []struct {
+		Status    string `json:"status"`
+		Documents []struct {
+			ID string `json:"id"`
+		} `json:"documents"`
+	}
+	testutil.DecodeJSON(t, resp, &list)
+	require.Len(t, list, 1)
+	assert.Equal(t, "submitted", list[0].Status)
+	require.Len(t, list[0].Documents, 1)
+
+	// Only write-tier admins can download the evidence
+	docPath := "/admin/source-of-funds/documents/" + list[0].Documents[0].ID
+	resp = env.AuthGET(docPath, env.AdminToken("viewer"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = env.AuthGET(docPath, env.AdminToken("admin"))
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "%PDF-1.4\n% test payslip\n", string(body))
+
+	resp = sofDepositAttempt(env, token)
+	resp.Body.Close()
+	assert.NotEqual(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestSourceOfFunds_AdminRejectAndApprove(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("sofreview@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	env.DirectDeposit(playerID, 600000)
+
+	id := sofQuestionnaireID(t, sofDepositAttempt(env, token))
+	resp := env.AuthPUT("/players/me/source-of-funds/"+id, sofAnswers, token)
+	resp.Body.Close()
+
+	// Rejecting needs a note and re-blocks deposits
+	resp = env.AuthPOST("/admin/source-of-funds/"+id+"/reject", map[string]string{}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = env.AuthPOST("/admin/source-of-funds/"+id+"/reject", map[string]string{"note": "payslip missing"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, id, sofQuestionnaireID(t, sofDepositAttempt(env, token)))
+
+	resp = env.AuthPUT("/players/me/source-of-funds/"+id, sofAnswers, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/source-of-funds?status=submitted", adminToken)
+	var queue []struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &queue)
+	require.Len(t, queue, 1)
+	assert.Equal(t, id, queue[0].ID)
+
+	resp = env.AuthPOST("/admin/source-of-funds/"+id+"/approve", map[string]string{}, adminToken)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// An approved questionnaire cannot be resubmitted
+	resp2 := env.AuthPUT("/players/me/source-of-funds/"+id, sofAnswers, token)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp2.StatusCode)
+}
+
+func TestWithdrawal_IdempotencyKeyReplaysResponse(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("wdidem@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+
+	post := func(key string, amount int64) *http.Response {
+		body, _ := json.Marshal(map[string]int64{"amount": amount})
+		return env.RawPOST("/payments/withdraw", body, map[string]string{
+			"Content-Type":    "application/json",
+			"Authorization":   "Bearer " + token,
+			"Idempotency-Key": key,
+		})
+	}
+
+	first := post("wd-key-1", 3000)
+	firstBody, _ := io.ReadAll(first.Body)
+	first.Body.Close()
+	require.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Empty(t, first.Header.Get("Idempotent-Replayed"))
+
+	// A double submit returns the original response and moves no money.
+	retry := post("wd-key-1", 3000)
+	retryBody, _ := io.ReadAll(retry.Body)
+	retry.Body.Close()
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("Idempotent-Replayed"))
+	assert.Equal(t, string(firstBody), string(retryBody))
+	testutil.AssertBalance(t, env, playerID, 7000, 0, 3000)
+
+	// The same key for a different request is refused.
+	reused := post("wd-key-1", 1000)
+	defer reused.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.StatusCode)
+	testutil.AssertErrorCode(t, reused, "IDEMPOTENCY_KEY_REUSED")
+
+	// A fresh key is a new withdrawal.
+	fresh := post("wd-key-2", 1000)
+	fresh.Body.Close()
+	assert.Equal(t, http.StatusOK, fresh.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 6000, 0, 4000)
+}
+
+// ─── P2P Transfer Tests (2) ───────────────────────────────────────────────
+
+func TestP2PTransfer_MovesBalanceAndChargesFee(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	senderToken, senderID := env.RegisterPlayer("p2psender@test.com", "securepass123", "EUR")
+	recipientToken, recipientID := env.RegisterPlayer("p2precipient@test.com", "securepass123", "EUR")
+	env.DirectDeposit(senderID, 20000)
+	_, err := env.Pool.Exec(context.Background(),
+		`UPDATE player_profiles SET verified = true WHERE player_id = $1`, senderID)
+	require.NoError(t, err)
+
+	resp := env.AuthPOST("/wallet/transfers", map[string]interface{}{
+		"recipient_email": "p2precipient@test.com",
+		"amount":          10000,
+		"message":         "for the pool",
+	}, senderToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var transfer struct {
+		Status      string `json:"status"`
+		RecipientID string `json:"recipient_id"`
+		Fee         int64  `json:"fee"`
+	}
+	testutil.DecodeJSON(t, resp, &transfer)
+	assert.Equal(t, "completed", transfer.Status)
+	assert.Equal(t, recipientID.String(), transfer.RecipientID)
+	assert.Equal(t, int64(100), transfer.Fee)
+
+	testutil.AssertBalance(t, env, senderID, 9900, 0, 0)
+	testutil.AssertBalance(t, env, recipientID, 10000, 0, 0)
+
+	// Both sides see the transfer in their history
+	resp = env.AuthGET("/wallet/transfers", recipientToken)
+	var history []struct {
+		SenderID string `json:"sender_id"`
+	}
+	testutil.DecodeJSON(t, resp, &history)
+	require.Len(t, history, 1)
+	assert.Equal(t, senderID.String(), history[0].SenderID)
+
+	resp = env.AuthGET("/admin/p2p-transfers?player_id="+senderID.String(), env.AdminToken("superadmin"))
+	var all []struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &all)
+	assert.Len(t, all, 1)
+}
+
+func TestP2PTransfer_RejectedWithoutKYC(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	senderToken, senderID := env.RegisterPlayer("p2punverified@test.com", "securepass123", "EUR")
+	_, recipientID := env.RegisterPlayer("p2ppayee@test.com", "securepass123", "EUR")
+	env.DirectDeposit(senderID, 20000)
+
+	resp := env.AuthPOST("/wallet/transfers", map[string]interface{}{
+		"recipient_id": recipientID,
+		"amount":       5000,
+	}, senderToken)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "TRANSFER_REJECTED")
+
+	testutil.AssertBalance(t, env, senderID, 20000, 0, 0)
+	testutil.AssertBalance(t, env, recipientID, 0, 0, 0)
+
+	var reason string
+	err := env.Pool.QueryRow(context.Background(),
+		`SELECT reject_reason FROM p2p_transfers WHERE sender_id = $1 AND status = 'rejected'`, senderID).Scan(&reason)
+	require.NoError(t, err)
+	assert.Equal(t, "kyc_required", reason)
+}
+
+// ─── Saved Payment Method Tests (3) ───────────────────────────────────────
+
+// seedPaymentMethod saves a tokenized card for the player, as
+// AddPaymentMethod would after the Stripe lookup.
+func seedPaymentMethod(t *testing.T, env *testutil.TestEnv, playerID fmt.Stringer, ref string) string {
+	t.Helper()
+	var id string
+	err := env.Pool.QueryRow(context.Background(), `
+		INSERT INTO payment_methods (player_id, name, type, provider, provider_ref, brand, last4, country)
+		VALUES ($1, 'Visa 4242', 'card', 'stripe', $2, 'visa', '4242', 'DE')
+		RETURNING id::text`, playerID.String(), ref).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
+
+func TestPaymentMethods_ClosedLoopWithdrawal(t *testing.T) {
+	env := testutil.NewClosedLoopTestEnv(t)
+	token, playerID := env.RegisterPlayer("closedloop@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	methodID := seedPaymentMethod(t, env, playerID, "pm_closedloop")
+
+	withdraw := func(body map[string]interface{}) *http.Response {
+		body["amount"] = 2000
+		return env.AuthPOST("/payments/withdraw", body, token)
+	}
+
+	resp := withdraw(map[string]interface{}{})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "WITHDRAWAL_DESTINATION_REJECTED")
+
+	resp = withdraw(map[string]interface{}{"payment_method_id": methodID})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "WITHDRAWAL_DESTINATION_REJECTED")
+
+	resp = env.AuthPOST("/admin/payment-methods/"+methodID+"/verify", nil, env.AdminToken("admin"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	// Verified but never deposited with
+	resp = withdraw(map[string]interface{}{"payment_method_id": methodID})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "WITHDRAWAL_DESTINATION_REJECTED")
+
+	_, err := env.Pool.Exec(context.Background(), `
+		INSERT INTO payments (player_id, type, amount, currency, status, payment_method_id, provider)
+		VALUES ($1, 'deposit', 10000, 'EUR', 'completed', $2, 'stripe')`, playerID, methodID)
+	require.NoError(t, err)
+
+	resp = withdraw(map[string]interface{}{"payment_method_id": methodID})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 8000, 0, 2000)
+
+	var stored string
+	err = env.Pool.QueryRow(context.Background(), `
+		SELECT payment_method_id::text FROM payments WHERE player_id = $1 AND type = 'withdrawal'`,
+		playerID).Scan(&stored)
+	require.NoError(t, err)
+	assert.Equal(t, methodID, stored)
+}
+
+func TestPaymentMethods_RenameAndRemove(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("pmremove@test.com", "securepass123", "EUR")
+	methodID := seedPaymentMethod(t, env, playerID, "pm_remove")
+
+	resp := env.AuthPATCH("/payments/methods/"+methodID, map[string]string{"label": "Main card"}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var renamed struct {
+		Label string `json:"label"`
+	}
+	testutil.DecodeJSON(t, resp, &renamed)
+	assert.Equal(t, "Main card", renamed.Label)
+
+	resp = env.AuthDELETE("/payments/methods/"+methodID, token)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = env.AuthGET("/payments/methods", token)
+	var methods []struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &methods)
+	assert.Empty(t, methods)
+
+	// Removed methods stay visible to admins and cannot receive payouts
+	resp = env.AuthGET("/admin/players/"+playerID.String()+"/payment-methods", env.AdminToken("viewer"))
+	testutil.DecodeJSON(t, resp, &methods)
+	assert.Len(t, methods, 1)
+
+	env.DirectDeposit(playerID, 5000)
+	resp = env.AuthPOST("/payments/withdraw", map[string]interface{}{"amount": 1000, "payment_method_id": methodID}, token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "WITHDRAWAL_DESTINATION_REJECTED")
+}
+
+func TestPaymentMethods_OtherPlayersMethodNotFound(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, ownerID := env.RegisterPlayer("pmowner@test.com", "securepass123", "EUR")
+	token, playerID := env.RegisterPlayer("pmother@test.com", "securepass123", "EUR")
+	methodID := seedPaymentMethod(t, env, ownerID, "pm_owner")
+	env.DirectDeposit(playerID, 5000)
+
+	resp := env.AuthPOST("/payments/withdraw", map[string]interface{}{"amount": 1000, "payment_method_id": methodID}, token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 5000, 0, 0)
+}
+
+// ─── KYC Tests (2) ────────────────────────────────────────────────────────
+
+// uploadKYCDocument posts a PNG of the given document type as
+// multipart/form-data and returns the created document id.
+func uploadKYCDocument(t *testing.T, env *testutil.TestEnv, token, docType string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	require.NoError(t, mw.WriteField("document_type", docType))
+	part, err := mw.CreateFormFile("file", docType+".png")
+	require.NoError(t, err)
+	part.Write([]byte("\x89PNG\r\n\x1a\n" + docType))
+	require.NoError(t, mw.Close())
+
+	resp := env.RawPOST("/players/me/kyc/documents", buf.Bytes(), map[string]string{
+		"Content-Type": mw.FormDataContentType(), "Authorization": "Bearer " + token,
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var doc struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	testutil.DecodeJSON(t, resp, &doc)
+	assert.Equal(t, "pending", doc.Status)
+	return doc.ID
+}
+
+func TestKYC_WithdrawalAboveThresholdNeedsVerification(t *testing.T) {
+	env := testutil.NewKYCTestEnv(t)
+	token, playerID := env.RegisterPlayer("kycwd@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	env.DirectDeposit(playerID, 500000)
+
+	// At the threshold no verification is needed
+	resp := env.AuthPOST("/payments/withdraw", map[string]int64{"amount": testutil.TestKYCThreshold}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = env.AuthPOST("/payments/withdraw", map[string]int64{"amount": testutil.TestKYCThreshold + 1}, token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "KYC_REQUIRED")
+
+	passport := uploadKYCDocument(t, env, token, "passport")
+	address := uploadKYCDocument(t, env, token, "proof_of_address")
+	for _, id := range []string{passport, address} {
+		r := env.AuthPOST("/admin/kyc/documents/"+id+"/approve", map[string]string{}, adminToken)
+		r.Body.Close()
+		require.Equal(t, http.StatusOK, r.StatusCode)
+	}
+
+	r := env.AuthGET("/players/me/kyc", token)
+	var status struct {
+		Verified bool     `json:"verified"`
+		Missing  []string `json:"missing"`
+	}
+	testutil.DecodeJSON(t, r, &status)
+	assert.True(t, status.Verified)
+	assert.Empty(t, status.Missing)
+
+	r = env.AuthPOST("/payments/withdraw", map[string]int64{"amount": testutil.TestKYCThreshold + 1}, token)
+	r.Body.Close()
+	assert.Equal(t, http.StatusOK, r.StatusCode)
+}
+
+func TestKYC_AdminReviewQueue(t *testing.T) {
+	env := testutil.NewKYCTestEnv(t)
+	token, _ := env.RegisterPlayer("kycqueue@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+	id := uploadKYCDocument(t, env, token, "national_id")
+
+	resp := env.AuthGET("/admin/kyc", env.AdminToken("viewer"))
+	var queue []struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &queue)
+	require.Len(t, queue, 1)
+	assert.Equal(t, id, queue[0].ID)
+
+	// Reviewers can download the stored file; read-only admins cannot
+	resp = env.AuthGET("/admin/kyc/documents/"+id, env.AdminToken("viewer"))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp = env.AuthGET("/admin/kyc/documents/"+id, adminToken)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "\x89PNG\r\n\x1a\nnational_id", string(body))
+
+	// Rejecting needs a note; a reviewed document leaves the queue
+	resp = env.AuthPOST("/admin/kyc/documents/"+id+"/reject", map[string]string{}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = env.AuthPOST("/admin/kyc/documents/"+id+"/reject", map[string]string{"note": "blurry"}, adminToken)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = env.AuthPOST("/admin/kyc/documents/"+id+"/approve", map[string]string{}, adminToken)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = env.AuthGET("/admin/kyc", adminToken)
+	testutil.DecodeJSON(t, resp, &queue)
+	assert.Empty(t, queue)
+
+	resp = env.AuthGET("/players/me/kyc", token)
+	var status struct {
+		Verified bool     `json:"verified"`
+		Missing  []string `json:"missing"`
+	}
+	testutil.DecodeJSON(t, resp, &status)
+	assert.False(t, status.Verified)
+	assert.Equal(t, []string{"identity", "proof_of_address"}, status.Missing)
+}
+
+// ─── Player Limit Tests (3) ───────────────────────────────────────────────
+
+func setPlayerLimit(t *testing.T, env *testutil.TestEnv, token, limitType, period string, value int64) domain.PlayerLimit {
+	t.Helper()
+	resp := env.AuthPUT("/players/me/limits", map[string]interface{}{
+		"type": limitType, "period": period, "value": value,
+	}, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var limit domain.PlayerLimit
+	testutil.DecodeJSON(t, resp, &limit)
+	return limit
+}
+
+func TestPlayerLimits_DepositLimitBlocksDeposit(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("limitdeposit@test.com", "securepass123", "EUR")
+	setPlayerLimit(t, env, token, "deposit", "daily", 500)
+
+	resp := sofDepositAttempt(env, token)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "RG_LIMIT_BREACHED")
+}
+
+func TestPlayerLimits_IncreaseWaitsForCooldown(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("limitcooldown@test.com", "securepass123", "EUR")
+	setPlayerLimit(t, env, token, "deposit", "weekly", 5000)
+
+	// Decreases apply at once
+	limit := setPlayerLimit(t, env, token, "deposit", "weekly", 2000)
+	assert.Equal(t, int64(2000), limit.Value)
+	assert.Nil(t, limit.PendingValue)
+
+	// Increases and removals only take effect after the cooldown
+	limit = setPlayerLimit(t, env, token, "deposit", "weekly", 8000)
+	assert.Equal(t, int64(2000), limit.Value)
+	require.NotNil(t, limit.PendingValue)
+	assert.Equal(t, int64(8000), *limit.PendingValue)
+	require.NotNil(t, limit.PendingEffectiveAt)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), *limit.PendingEffectiveAt, time.Minute)
+
+	resp := env.AuthDELETE("/players/me/limits/deposit/weekly", token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.DecodeJSON(t, resp, &limit)
+	assert.Equal(t, int64(2000), limit.Value)
+	require.NotNil(t, limit.PendingValue)
+	assert.Equal(t, int64(0), *limit.PendingValue)
+
+	// Once the cooldown has passed the pending value is applied
+	_, err := env.Pool.Exec(context.Background(),
+		`UPDATE player_limits SET pending_effective_at = now() - interval '1 minute' WHERE pending_value IS NOT NULL`)
+	require.NoError(t, err)
+	resp = env.AuthGET("/players/me/limits", token)
+	var limits []domain.PlayerLimit
+	testutil.DecodeJSON(t, resp, &limits)
+	assert.Empty(t, limits)
+}
+
+func TestPlayerLimits_LossLimitBlocksBet(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("limitloss@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+	setPlayerLimit(t, env, token, "loss", "daily", 1500)
+
+	bet := func(stake int) *http.Response {
+		return env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+			"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": stake,
+		}, token)
+	}
+	resp := bet(1000)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = bet(1000)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "RG_LIMIT_BREACHED")
+	testutil.AssertBalance(t, env, playerID, 9000, 0, 0)
+}
+
+// ─── Self-Exclusion Tests (2) ─────────────────────────────────────────────
+
+func TestSelfExclusion_BlocksDepositsAndBets(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("selfexclude@test.com", "securepass123", "EUR")
+	env.DirectDeposit(playerID, 10000)
+	_, eventID, marketID, selectionID := env.SeedSportsbook(250)
+
+	resp := env.AuthPOST("/players/me/self-exclusion", map[string]interface{}{"duration_days": 180}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var exclusion domain.SelfExclusion
+	testutil.DecodeJSON(t, resp, &exclusion)
+	assert.True(t, exclusion.Excluded)
+	assert.False(t, exclusion.Permanent)
+	require.NotNil(t, exclusion.Until)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 180), *exclusion.Until, time.Minute)
+
+	resp = sofDepositAttempt(env, token)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "ACCOUNT_INACTIVE")
+
+	resp = env.AuthPOST("/sportsbook/bets", map[string]interface{}{
+		"event_id": eventID, "market_id": marketID, "selection_id": selectionID, "stake": 1000,
+	}, token)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "ACCOUNT_INACTIVE")
+	testutil.AssertBalance(t, env, playerID, 10000, 0, 0)
+
+	// Signing in again does not hand out a fresh token
+	resp = env.POST("/auth/login", map[string]string{
+		"email": "selfexclude@test.com", "password": "securepass123",
+	}, "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	testutil.AssertErrorCode(t, resp, "ACCOUNT_INACTIVE")
+
+	// The exclusion is published and its end is scheduled
+	var events, scheduled int
+	env.Pool.QueryRow(t.Context(),
+		`SELECT COUNT(*) FROM event_outbox WHERE "eventType" = 'pam.selfexclusion.enabled' AND "aggregateId" = $1`,
+		playerID.String()).Scan(&events)
+	assert.Equal(t, 1, events)
+	env.Pool.QueryRow(t.Context(),
+		"SELECT COUNT(*) FROM player_status_history WHERE player_id = $1 AND to_status = 'active' AND state = 'scheduled'",
+		playerID).Scan(&scheduled)
+	assert.Equal(t, 1, scheduled)
+}
+
+func TestSelfExclusion_PermanentCannotBeReplaced(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, _ := env.RegisterPlayer("selfexcludeperm@test.com", "securepass123", "EUR")
+
+	resp := env.AuthPOST("/players/me/self-exclusion",
+		map[string]interface{}{"duration_days": 30, "permanent": true}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = env.AuthPOST("/players/me/self-exclusion", map[string]interface{}{"permanent": true}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = env.AuthPOST("/players/me/self-exclusion", map[string]interface{}{"duration_days": 1}, token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = env.AuthGET("/players/me/self-exclusion", token)
+	var exclusion domain.SelfExclusion
+	testutil.DecodeJSON(t, resp, &exclusion)
+	assert.True(t, exclusion.Excluded)
+	assert.True(t, exclusion.Permanent)
+	assert.Nil(t, exclusion.Until)
+}
@@ -3,11 +3,16 @@
 package integration
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"testing"
 
+	"github.com/attaboy/platform/internal/provider"
 	"github.com/attaboy/platform/test/integration/testutil"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ─── Stripe Webhook Tests (5) ─────────────────────────────────────────────
@@ -84,3 +89,147 @@ func TestStripeWebhook_ContentTypeRawBody(t *testing.T) {
 	assert.True(t, resp.StatusCode >= 400, "expected signature error, got %d", resp.StatusCode)
 	assert.NotEqual(t, http.StatusUnsupportedMediaType, resp.StatusCode)
 }
+
+// ─── Adyen Webhook Tests (3) ──────────────────────────────────────────────
+
+// seedAdyenPayment records a pending Adyen deposit as InitiateDeposit would.
+func seedAdyenPayment(t *testing.T, env *testutil.TestEnv, playerID uuid.UUID, amount int64) uuid.UUID {
+	t.Helper()
+	paymentID := uuid.New()
+	_, err := env.Pool.Exec(context.Background(), `
+		INSERT INTO payments (id, player_id, type, amount, currency, status, provider, provider_session_id)
+		VALUES ($1, $2, 'deposit', $3, 'EUR', 'pending', 'adyen', 'CS_TEST')`,
+		paymentID, playerID, amount)
+	require.NoError(t, err)
+	return paymentID
+}
+
+func TestAdyenWebhook_AuthorisationCreditsDeposit(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("adyen@test.com", "securepass123", "EUR")
+	paymentID := seedAdyenPayment(t, env, playerID, 5000)
+
+	payload := testutil.AdyenWebhookPayload(provider.AdyenNotificationItem{
+		Amount:            provider.AdyenAmount{Currency: "EUR", Value: 5000},
+		EventCode:         "AUTHORISATION",
+		MerchantReference: paymentID.String(),
+		PspReference:      "PSP_TEST_1",
+		Success:           "true",
+	})
+	post := func() *http.Response {
+		return env.RawPOST("/webhooks/adyen", payload, map[string]string{"Content-Type": "application/json"})
+	}
+
+	resp := post()
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "[accepted]", string(body))
+	testutil.AssertBalance(t, env, playerID, 5000, 0, 0)
+
+	// Adyen redelivers notifications; a replay credits nothing more
+	resp = post()
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 5000, 0, 0)
+}
+
+func TestAdyenWebhook_RefusedMarksPaymentFailed(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	_, playerID := env.RegisterPlayer("adyenrefused@test.com", "securepass123", "EUR")
+	paymentID := seedAdyenPayment(t, env, playerID, 5000)
+
+	payload := testutil.AdyenWebhookPayload(provider.AdyenNotificationItem{
+		Amount:            provider.AdyenAmount{Currency: "EUR", Value: 5000},
+		EventCode:         "AUTHORISATION",
+		MerchantReference: paymentID.String(),
+		PspReference:      "PSP_TEST_2",
+		Reason:            "Refused",
+		Success:           "false",
+	})
+	resp := env.RawPOST("/webhooks/adyen", payload, map[string]string{"Content-Type": "application/json"})
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status string
+	err := env.Pool.QueryRow(context.Background(), `SELECT status FROM payments WHERE id = $1`, paymentID).Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", status)
+	testutil.AssertBalance(t, env, playerID, 0, 0, 0)
+}
+
+func TestAdyenWebhook_InvalidSignature(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+
+	payload := []byte(`{"live":"false","notificationItems":[{"NotificationRequestItem":{
+		"additionalData":{"hmacSignature":"bm90LWEtc2lnbmF0dXJl"},
+		"amount":{"currency":"EUR","value":5000},"eventCode":"AUTHORISATION",
+		"merchantReference":"x","pspReference":"PSP_BAD","success":"true"}}]}`)
+	resp := env.RawPOST("/webhooks/adyen", payload, map[string]string{"Content-Type": "application/json"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+// ─── Deposit Match Tests (1) ──────────────────────────────────────────────
+
+func TestStripeWebhook_DepositMatchesOptedInBonus(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	token, playerID := env.RegisterPlayer("depositmatch@test.com", "securepass123", "EUR")
+	adminToken := env.AdminToken("admin")
+
+	resp := env.AuthPOST("/admin/bonuses", map[string]interface{}{
+		"name": "Half Match", "type": "deposit_match", "match_percent": 50,
+		"max_bonus": 2000, "min_deposit": 1000, "wagering_multiplier": 10,
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bonus struct {
+		ID string `json:"id"`
+	}
+	testutil.DecodeJSON(t, resp, &bonus)
+
+	resp = env.AuthPOST("/bonuses/"+bonus.ID+"/opt-in", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	paymentID := uuid.New()
+	_, err := env.Pool.Exec(context.Background(), `
+		INSERT INTO payments (id, player_id, type, amount, currency, status, provider, provider_session_id)
+		VALUES ($1, $2, 'deposit', 5000, 'EUR', 'pending', 'stripe', 'cs_match')`,
+		paymentID, playerID)
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"evt_match","type":"checkout.session.completed",` +
+		`"data":{"object":{"id":"cs_match","payment_intent":"pi_match"}}}`)
+	post := func() *http.Response {
+		return env.RawPOST("/webhooks/stripe", payload, map[string]string{
+			"Content-Type":     "application/json",
+			"Stripe-Signature": testutil.StripeWebhookSignature(payload),
+		})
+	}
+	resp = post()
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// 50% of 5000 is 2500, capped at the bonus's 2000
+	testutil.AssertBalance(t, env, playerID, 5000, 2000, 0)
+	var linked int
+	err = env.Pool.QueryRow(context.Background(),
+		`SELECT count(*) FROM player_bonuses WHERE payment_id = $1 AND initial_amount = 2000`, paymentID).Scan(&linked)
+	require.NoError(t, err)
+	assert.Equal(t, 1, linked)
+
+	resp = env.AuthGET("/bonuses/opt-ins", token)
+	var optIns []struct {
+		Status string `json:"status"`
+	}
+	testutil.DecodeJSON(t, resp, &optIns)
+	require.Len(t, optIns, 1)
+	assert.Equal(t, "claimed", optIns[0].Status)
+
+	// A replayed webhook matches nothing more
+	resp = post()
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	testutil.AssertBalance(t, env, playerID, 5000, 2000, 0)
+}
@@ -45,7 +45,7 @@ export default function SportsbookPage() {
   useEffect(() => {
     Promise.all([
       api<Sport[]>('/sportsbook/sports', { token }),
-      api<Bet[]>('/sportsbook/bets/me', { token }).catch(() => []),
+      api<{ bets: Bet[] }>('/sportsbook/bets/me', { token }).then((r) => r.bets).catch(() => []),
     ])
       .then(([s, b]) => { const sp = s || []; setSports(sp); setBets(b || []); if (sp.length > 0) setActiveSport(sp[0].id); })
       .finally(() => setLoading(false));